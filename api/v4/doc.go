@@ -0,0 +1,27 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package v4 is the go stubs of the service-center v4 API generated from
+// server/core/proto/services.proto by scripts/proto/gen.sh, including the
+// messages and the gRPC clients/servers. External projects import this
+// package instead of the server packages to avoid the server dependencies.
+//
+// The json names of the messages are the same as the REST API. The stubs
+// are committed in this directory, run scripts/proto/gen.sh after changing
+// services.proto, VERSION is the service-center version they are generated
+// from.
+package v4
@@ -73,3 +73,36 @@ func BenchmarkSync(b *testing.B) {
 	})
 	b.ReportAllocs()
 }
+
+type namedHandler struct {
+	name string
+}
+
+func (h *namedHandler) Handle(i *chain.Invocation) {
+	i.Next()
+}
+
+func TestUseHandlers(t *testing.T) {
+	a, b := &namedHandler{"a"}, &namedHandler{"b"}
+	chain.RegisterNamedHandler("_test_handlers_", "a", a)
+	chain.RegisterNamedHandler("_test_handlers_", "b", b)
+	hs := chain.Handlers("_test_handlers_")
+	if len(hs) != 2 || hs[0] != chain.Handler(a) || hs[1] != chain.Handler(b) {
+		t.Fatalf("RegisterNamedHandler failed, %v", hs)
+	}
+
+	missing := chain.UseHandlers("_test_handlers_", []string{"b", "x", "a"})
+	if len(missing) != 1 || missing[0] != "x" {
+		t.Fatalf("UseHandlers missing failed, %v", missing)
+	}
+	hs = chain.Handlers("_test_handlers_")
+	if len(hs) != 2 || hs[0] != chain.Handler(b) || hs[1] != chain.Handler(a) {
+		t.Fatalf("UseHandlers order failed, %v", hs)
+	}
+
+	chain.UseHandlers("_test_handlers_", []string{"a"})
+	hs = chain.Handlers("_test_handlers_")
+	if len(hs) != 1 || hs[0] != chain.Handler(a) {
+		t.Fatalf("UseHandlers disable failed, %v", hs)
+	}
+}
@@ -20,17 +20,24 @@ import (
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/validate"
 	"reflect"
+	"sync"
 )
 
 const CAP_SIZE = 10
 
-var handlersMap map[string][]Handler = make(map[string][]Handler, CAP_SIZE)
+var (
+	handlersMap      map[string][]Handler          = make(map[string][]Handler, CAP_SIZE)
+	namedHandlersMap map[string]map[string]Handler = make(map[string]map[string]Handler, CAP_SIZE)
+	lock             sync.RWMutex
+)
 
 type Handler interface {
 	Handle(i *Invocation)
 }
 
 func RegisterHandler(catalog string, h Handler) {
+	lock.Lock()
+	defer lock.Unlock()
 	handlers, ok := handlersMap[catalog]
 	if !ok {
 		handlers = make([]Handler, 0, CAP_SIZE)
@@ -42,6 +49,42 @@ func RegisterHandler(catalog string, h Handler) {
 	util.Logger().Infof("register handler[%s] %s/%s", catalog, t.Type.PkgPath(), t.Type.Name())
 }
 
+// RegisterNamedHandler registers the handler and makes it addressable by name,
+// so that the chain can be reordered or trimmed later by UseHandlers
+func RegisterNamedHandler(catalog string, name string, h Handler) {
+	RegisterHandler(catalog, h)
+
+	lock.Lock()
+	named, ok := namedHandlersMap[catalog]
+	if !ok {
+		named = make(map[string]Handler, CAP_SIZE)
+		namedHandlersMap[catalog] = named
+	}
+	named[name] = h
+	lock.Unlock()
+}
+
+// UseHandlers replaces the handlers of catalog with the named ones in the
+// given order, the names not registered are skipped and returned
+func UseHandlers(catalog string, names []string) (missing []string) {
+	lock.Lock()
+	defer lock.Unlock()
+	handlers := make([]Handler, 0, len(names))
+	for _, name := range names {
+		h, ok := namedHandlersMap[catalog][name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		handlers = append(handlers, h)
+	}
+	handlersMap[catalog] = handlers
+	util.Logger().Infof("use handlers[%s] %v", catalog, names)
+	return
+}
+
 func Handlers(catalog string) []Handler {
+	lock.RLock()
+	defer lock.RUnlock()
 	return handlersMap[catalog]
 }
@@ -17,6 +17,7 @@
 package etcdsync
 
 import (
+	"errors"
 	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
@@ -35,12 +36,16 @@ const (
 	ROOT_PATH  = "/cse/etcdsync"
 )
 
+// ErrLockTimeout is returned when the lock is not acquired within the timeout
+var ErrLockTimeout = errors.New("wait for the lock timeout")
+
 // A Mutex is a mutual exclusion lock which is distributed across a cluster.
 type LockerFactory struct {
-	key    string
-	ctx    context.Context
-	ttl    int64
-	mutex  *sync.Mutex
+	key string
+	ctx context.Context
+	ttl int64
+	// local mutex with a capacity of 1, which supports waiting with timeout
+	mutex  chan struct{}
 	logger io.Writer
 }
 
@@ -84,7 +89,7 @@ func New(key string, ttl int64) *LockerFactory {
 		key:   key,
 		ctx:   context.Background(),
 		ttl:   ttl,
-		mutex: new(sync.Mutex),
+		mutex: make(chan struct{}, 1),
 	}
 }
 
@@ -92,18 +97,37 @@ func New(key string, ttl int64) *LockerFactory {
 // If the lock is already in use, the calling goroutine
 // blocks until the mutex is available.
 func (m *LockerFactory) Lock() (l *Locker, err error) {
+	return m.LockWithTimeout(0)
+}
+
+// LockWithTimeout locks m like Lock, but returns ErrLockTimeout if the
+// mutex is not available within the timeout, timeout <= 0 means no limit.
+func (m *LockerFactory) LockWithTimeout(timeout time.Duration) (l *Locker, err error) {
+	ctx := m.ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 	if !IsDebug {
-		m.mutex.Lock()
+		select {
+		case m.mutex <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ErrLockTimeout
+		}
 	}
 	l = &Locker{
 		builder: m,
 		id:      fmt.Sprintf("%v-%v-%v", hostname, pid, time.Now().Format("20060102-15:04:05.999999999")),
 	}
 	for try := 1; try <= defaultTry; try++ {
-		err = l.Lock()
+		err = l.lock(ctx)
 		if err == nil {
 			return l, nil
 		}
+		if err == ErrLockTimeout {
+			break
+		}
 
 		if try <= defaultTry {
 			util.Logger().Warnf(err, "Try to lock key %s again, id=%s", m.key, l.id)
@@ -112,7 +136,7 @@ func (m *LockerFactory) Lock() (l *Locker, err error) {
 		}
 	}
 	if !IsDebug {
-		m.mutex.Unlock()
+		<-m.mutex
 	}
 	return l, err
 }
@@ -121,7 +145,16 @@ func (m *Locker) ID() string {
 	return m.id
 }
 
+func (m *Locker) Key() string {
+	return m.builder.key
+}
+
 func (m *Locker) Lock() error {
+	return m.lock(m.builder.ctx)
+}
+
+// lock stops waiting for the other holders and returns ErrLockTimeout when ctx is done
+func (m *Locker) lock(lockCtx context.Context) error {
 	opts := []registry.PluginOpOption{
 		registry.WithStrKey(m.builder.key),
 		registry.WithStrValue(m.id)}
@@ -164,9 +197,12 @@ func (m *Locker) Lock() error {
 		select {
 		case <-ctx.Done():
 			continue // 可以重新尝试获取锁
-		case <-m.builder.ctx.Done():
+		case <-lockCtx.Done():
 			cancel()
-			return m.builder.ctx.Err() // 机制错误，不应该超时的
+			if m.builder.ctx.Err() != nil {
+				return m.builder.ctx.Err() // 机制错误，不应该超时的
+			}
+			return ErrLockTimeout
 		}
 	}
 }
@@ -186,7 +222,7 @@ func (m *Locker) Unlock() (err error) {
 		_, err = backend.Registry().Do(m.builder.ctx, opts...)
 		if err == nil {
 			if !IsDebug {
-				m.builder.mutex.Unlock()
+				<-m.builder.mutex
 			}
 			util.Logger().Infof("Delete lock OK, key=%s, id=%s", m.builder.key, m.id)
 			return nil
@@ -195,18 +231,24 @@ func (m *Locker) Unlock() (err error) {
 		e, ok := err.(client.Error)
 		if ok && e.Code == client.ErrorCodeKeyNotFound {
 			if !IsDebug {
-				m.builder.mutex.Unlock()
+				<-m.builder.mutex
 			}
 			return nil
 		}
 	}
 	if !IsDebug {
-		m.builder.mutex.Unlock()
+		<-m.builder.mutex
 	}
 	return err
 }
 
 func Lock(key string) (*Locker, error) {
+	return LockWithTimeout(key, 0)
+}
+
+// LockWithTimeout locks the key like Lock, but returns ErrLockTimeout
+// if it is not acquired within the timeout, timeout <= 0 means no limit.
+func LockWithTimeout(key string, timeout time.Duration) (*Locker, error) {
 	globalMux.Lock()
 	lc, ok := globalMap[key]
 	if !ok {
@@ -214,5 +256,5 @@ func Lock(key string) (*Locker, error) {
 		globalMap[key] = lc
 	}
 	globalMux.Unlock()
-	return lc.Lock()
+	return lc.LockWithTimeout(timeout)
 }
@@ -21,6 +21,8 @@ import (
 
 	"fmt"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"time"
 )
 
 var _ = Describe("Mutex", func() {
@@ -45,5 +47,20 @@ var _ = Describe("Mutex", func() {
 			l.Unlock()
 
 		})
+
+		It("TestLockWithTimeout", func() {
+			m1 := New("key2", 10)
+			l1, err := m1.Lock()
+			Expect(err).To(BeNil())
+
+			m2 := New("key2", 10)
+			_, err = m2.LockWithTimeout(time.Second)
+			Expect(err).To(Equal(ErrLockTimeout))
+
+			l1.Unlock()
+			l2, err := m2.LockWithTimeout(time.Second)
+			Expect(err).To(BeNil())
+			l2.Unlock()
+		})
 	})
 })
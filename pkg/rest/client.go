@@ -19,6 +19,7 @@ package rest
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/tlsutil"
@@ -136,6 +137,20 @@ func GetClient(scheme string) (*HttpClient, error) {
 	return client, nil
 }
 
+// GetTLSClient 使用指定TLS配置的HTTP客户端，用于需要自定义对端校验的场景
+func GetTLSClient(gzip bool, tlsConfig *tls.Config) *HttpClient {
+	transport := NewTransport()
+	transport.TLSClientConfig = tlsConfig
+	transport.TLSHandshakeTimeout = DEFAULT_TLS_HANDSHAKE_TIMEOUT
+	return &HttpClient{
+		gzip: gzip,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   DEFAULT_REQUEST_TIMEOUT,
+		},
+	}
+}
+
 /**
   获取TLS认证HTTP客户端
   gzip  控制是否支持压缩
@@ -25,6 +25,7 @@ const (
 	HTTP_METHOD_PUT    = http.MethodPut
 	HTTP_METHOD_POST   = http.MethodPost
 	HTTP_METHOD_DELETE = http.MethodDelete
+	HTTP_METHOD_PATCH  = http.MethodPatch
 
 	CTX_RESPONSE      = "_server_response"
 	CTX_REQUEST       = "_server_request"
@@ -34,7 +35,7 @@ const (
 
 func isValidMethod(method string) bool {
 	switch method {
-	case http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete:
+	case http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodPatch:
 		return true
 	default:
 		return false
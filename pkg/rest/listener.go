@@ -49,9 +49,9 @@ func (rl *restListener) Accept() (c net.Conn, err error) {
 		return
 	}
 
-	if rl.server.KeepaliveTimeout > 0 {
+	if d := rl.server.KeepaliveTimeout(); d > 0 {
 		tc.SetKeepAlive(true)
-		tc.SetKeepAlivePeriod(rl.server.KeepaliveTimeout)
+		tc.SetKeepAlivePeriod(d)
 	}
 
 	c = restConn{
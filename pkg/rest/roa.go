@@ -85,3 +85,8 @@ func RegisterServent(servant interface{}) {
 func GetRouter() http.Handler {
 	return serverHandler
 }
+
+//GetRoutes return the registered routes of REST service
+func GetRoutes() []URLPattern {
+	return serverHandler.Routes()
+}
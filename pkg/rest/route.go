@@ -23,6 +23,7 @@ import (
 	errorsEx "github.com/apache/incubator-servicecomb-service-center/pkg/errors"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"net/http"
+	"sort"
 	"strings"
 )
 
@@ -73,6 +74,23 @@ func (this *ROAServerHandler) addRoute(route *Route) (err error) {
 	return nil
 }
 
+// Routes 返回已注册的路由，按path和method排序
+func (this *ROAServerHandler) Routes() []URLPattern {
+	var patterns []URLPattern
+	for method, handlers := range this.handlers {
+		for _, ph := range handlers {
+			patterns = append(patterns, URLPattern{Method: method, Path: ph.Path})
+		}
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if patterns[i].Path != patterns[j].Path {
+			return patterns[i].Path < patterns[j].Path
+		}
+		return patterns[i].Method < patterns[j].Method
+	})
+	return patterns
+}
+
 func (this *ROAServerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	for _, ph := range this.handlers[r.Method] {
 		if params, ok := ph.try(r.URL.Path); ok {
@@ -20,6 +20,7 @@ import (
 	"crypto/tls"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/grace"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"golang.org/x/net/http2"
 	"net"
 	"net/http"
 	"os"
@@ -64,7 +65,7 @@ func NewServer(srvCfg *ServerConfig) *Server {
 	if srvCfg == nil {
 		srvCfg = DefaultServerConfig()
 	}
-	return &Server{
+	srv := &Server{
 		Server: &http.Server{
 			Addr:              srvCfg.Addr,
 			Handler:           srvCfg.Handler,
@@ -75,19 +76,22 @@ func NewServer(srvCfg *ServerConfig) *Server {
 			WriteTimeout:      srvCfg.WriteTimeout,
 			MaxHeaderBytes:    srvCfg.MaxHeaderBytes,
 		},
-		KeepaliveTimeout: srvCfg.KeepAliveTimeout,
-		GraceTimeout:     srvCfg.GraceTimeout,
-		state:            serverStateInit,
-		Network:          "tcp",
+		GraceTimeout: srvCfg.GraceTimeout,
+		state:        serverStateInit,
+		Network:      "tcp",
 	}
+	srv.SetKeepaliveTimeout(srvCfg.KeepAliveTimeout)
+	return srv
 }
 
 type Server struct {
 	*http.Server
 
-	Network          string
-	KeepaliveTimeout time.Duration
-	GraceTimeout     time.Duration
+	Network      string
+	GraceTimeout time.Duration
+
+	keepaliveTimeout int64
+	h2               atomic.Value
 
 	registerListener net.Listener
 	restListener     net.Listener
@@ -98,6 +102,37 @@ type Server struct {
 	state uint8
 }
 
+func (srv *Server) KeepaliveTimeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&srv.keepaliveTimeout))
+}
+
+// SetKeepaliveTimeout 设置TCP保活周期，只对新accept的连接生效
+func (srv *Server) SetKeepaliveTimeout(d time.Duration) {
+	atomic.StoreInt64(&srv.keepaliveTimeout, int64(d))
+}
+
+// EnableHTTP2 在TLS监听上协商h2，需在Listen前调用
+func (srv *Server) EnableHTTP2(h2 *http2.Server) {
+	if srv.TLSConfig == nil {
+		return
+	}
+	srv.SetHTTP2(h2)
+	// TLS配置可能被多个server共用
+	srv.TLSConfig = srv.TLSConfig.Clone()
+	srv.TLSConfig.NextProtos = []string{http2.NextProtoTLS, "http/1.1"}
+	if srv.TLSNextProto == nil {
+		srv.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
+	}
+	srv.TLSNextProto[http2.NextProtoTLS] = func(hs *http.Server, c *tls.Conn, h http.Handler) {
+		srv.h2.Load().(*http2.Server).ServeConn(c, &http2.ServeConnOpts{BaseConfig: hs, Handler: h})
+	}
+}
+
+// SetHTTP2 替换h2的参数，只对新建立的连接生效
+func (srv *Server) SetHTTP2(h2 *http2.Server) {
+	srv.h2.Store(h2)
+}
+
 func (srv *Server) Serve() (err error) {
 	defer func() {
 		srv.state = serverStateClosed
@@ -0,0 +1,250 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sctest 在当前进程中启动使用内存存储的service center，提供完整的REST和gRPC接口，
+// 用于其他项目的集成测试。一个进程只能启动一次，多次调用Start返回同一个实例，
+// 用例之间需要使用不同的domain/project隔离数据。
+//
+// 只有租约过期使用可以推进的假时钟，其他定时任务(如服务清理、报表)仍使用真实时间。
+package sctest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/bootstrap"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/memory"
+	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_DOMAIN  = "default"
+	DEFAULT_PROJECT = "default"
+	// 等待服务启动和缓存同步的最长时间
+	DEFAULT_WAIT_TIMEOUT = 10 * time.Second
+)
+
+var (
+	startOnce sync.Once
+	instance  *Server
+	startErr  error
+)
+
+// Options 启动参数，Config中的配置项在启动前写入beego配置，可覆盖app.conf中的同名配置
+type Options struct {
+	Config map[string]string
+	// 假时钟的初始时间，为空时使用当前时间
+	StartTime time.Time
+}
+
+// FakeClock 只在调用Advance时前进的时钟
+type FakeClock struct {
+	lock sync.RWMutex
+	now  time.Time
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.now
+}
+
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	c.now = c.now.Add(d)
+	c.lock.Unlock()
+}
+
+// Server 进程内运行的service center
+type Server struct {
+	// REST接口地址，如http://127.0.0.1:30100
+	RESTEndpoint string
+	// gRPC接口地址，如127.0.0.1:30101
+	RPCEndpoint string
+	Clock       *FakeClock
+}
+
+// Start 启动service center并等待REST接口可用，同一进程中只启动一次，之后的调用忽略opts
+func Start(opts Options) (*Server, error) {
+	startOnce.Do(func() {
+		instance, startErr = start(opts)
+	})
+	return instance, startErr
+}
+
+func freePort() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+	return strconv.Itoa(l.Addr().(*net.TCPAddr).Port), nil
+}
+
+func start(opts Options) (*Server, error) {
+	restPort, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	rpcPort, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	config := map[string]string{
+		"registry_plugin": "memory",
+		"httpaddr":        "127.0.0.1",
+		"httpport":        restPort,
+		"rpcaddr":         "127.0.0.1",
+		"rpcport":         rpcPort,
+	}
+	for k, v := range opts.Config {
+		config[k] = v
+	}
+	for k, v := range config {
+		if err := beego.AppConfig.Set(k, v); err != nil {
+			return nil, err
+		}
+	}
+	// ServerInfo在初始化时已读取配置，测试中不使用TLS
+	core.ServerInfo.Config.SslEnabled = false
+
+	now := opts.StartTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	s := &Server{
+		RESTEndpoint: fmt.Sprintf("http://%s:%s", config["httpaddr"], config["httpport"]),
+		RPCEndpoint:  fmt.Sprintf("%s:%s", config["rpcaddr"], config["rpcport"]),
+		Clock:        &FakeClock{now: now},
+	}
+	memory.SetClock(s.Clock)
+
+	go server.Run()
+
+	if err := s.waitForReady(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Server) waitForReady() error {
+	client := &http.Client{Timeout: time.Second}
+	deadline := time.Now().Add(DEFAULT_WAIT_TIMEOUT)
+	for time.Now().Before(deadline) {
+		resp, err := client.Get(s.RESTEndpoint + "/version")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return errors.New("wait for service center ready timed out")
+}
+
+// Context 返回指定租户的请求上下文，用于直接调用服务接口
+func Context(domain, project string) context.Context {
+	ctx := util.SetContext(context.Background(), "domain", domain)
+	ctx = util.SetContext(ctx, "project", project)
+	return ctx
+}
+
+// Advance 推进时钟并立即删除过期的租约和附加的实例，返回前等待缓存同步，
+// 之后的发现请求和watch不再返回这些实例
+func (s *Server) Advance(d time.Duration) error {
+	s.Clock.Advance(d)
+	r, ok := backend.Registry().(*memory.MemoryRegistry)
+	if !ok {
+		return errors.New("registry plugin is not memory")
+	}
+	var rev int64
+	prefix := core.GetInstanceRootKey("")
+	for _, kv := range r.ExpireLeases() {
+		if strings.HasPrefix(util.BytesToStringWithNoCopy(kv.Key), prefix) && kv.ModRevision > rev {
+			rev = kv.ModRevision
+		}
+	}
+	return s.waitForCache(rev)
+}
+
+// waitForCache 等待实例缓存同步到rev
+func (s *Server) waitForCache(rev int64) error {
+	deadline := time.Now().Add(DEFAULT_WAIT_TIMEOUT)
+	for store.Store().Instance().Cache().Version() < rev {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("wait for cache to catch up revision %d timed out", rev)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return nil
+}
+
+// PreloadService 创建服务及其实例，返回serviceId和按顺序的instanceId
+func (s *Server) PreloadService(ctx context.Context, service *pb.MicroService,
+	instances ...*pb.MicroServiceInstance) (string, []string, error) {
+	resp, err := core.ServiceAPI.Create(ctx, &pb.CreateServiceRequest{Service: service})
+	if err != nil {
+		return "", nil, err
+	}
+	if resp.Response.Code != pb.Response_SUCCESS {
+		return "", nil, errors.New(resp.Response.Message)
+	}
+	ids := make([]string, 0, len(instances))
+	for _, inst := range instances {
+		inst.ServiceId = resp.ServiceId
+		id, err := s.PreloadInstance(ctx, inst)
+		if err != nil {
+			return resp.ServiceId, ids, err
+		}
+		ids = append(ids, id)
+	}
+	return resp.ServiceId, ids, nil
+}
+
+// PreloadInstance 注册实例并等待缓存同步，实例的租约按健康检查配置计算，使用假时钟过期
+func (s *Server) PreloadInstance(ctx context.Context, instance *pb.MicroServiceInstance) (string, error) {
+	resp, err := core.InstanceAPI.Register(ctx, &pb.RegisterInstanceRequest{Instance: instance})
+	if err != nil {
+		return "", err
+	}
+	if resp.Response.Code != pb.Response_SUCCESS {
+		return "", errors.New(resp.Response.Message)
+	}
+	key := core.GenerateInstanceKey(util.ParseDomainProject(ctx), instance.ServiceId, resp.InstanceId)
+	getResp, err := backend.Registry().Do(ctx, registry.GET, registry.WithStrKey(key))
+	if err != nil {
+		return resp.InstanceId, err
+	}
+	if len(getResp.Kvs) > 0 {
+		err = s.waitForCache(getResp.Kvs[0].ModRevision)
+	}
+	return resp.InstanceId, err
+}
@@ -129,6 +129,36 @@ func GetX509CACertPool(caCertFile string) (caCertPool *x509.CertPool, err error)
 	return pool, nil
 }
 
+// ParseCertificates 解析PEM文件中的所有证书
+func ParseCertificates(certFile string) ([]*x509.Certificate, error) {
+	content, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePEMCertificates(content)
+}
+
+// ParsePEMCertificates 解析PEM内容中的所有证书，忽略其他类型的块
+func ParsePEMCertificates(content []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, content = pem.Decode(content)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
 func LoadTLSCertificate(certFile, keyFile, plainPassphase string) (tlsCert []tls.Certificate, err error) {
 	certContent, err := ioutil.ReadFile(certFile)
 	if err != nil {
@@ -79,6 +79,50 @@ func GoCloseAndWait() {
 	defaultGo.Close(true)
 }
 
+// ParallelDo 使用不超过workers个goroutine并发执行f(0)...f(n-1)，
+// 返回第一个错误，出错后不再执行未开始的任务
+func ParallelDo(n, workers int, f func(i int) error) error {
+	if n <= 0 {
+		return nil
+	}
+	if workers <= 0 || workers > n {
+		workers = n
+	}
+
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+		err  error
+	)
+	idxCh, stopCh := make(chan int), make(chan struct{})
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idxCh {
+				if e := f(i); e != nil {
+					once.Do(func() {
+						err = e
+						close(stopCh)
+					})
+				}
+			}
+		}()
+	}
+
+loop:
+	for i := 0; i < n; i++ {
+		select {
+		case <-stopCh:
+			break loop
+		case idxCh <- i:
+		}
+	}
+	close(idxCh)
+	wg.Wait()
+	return err
+}
+
 func NewGo(stopCh chan struct{}) *GoRoutine {
 	gr := &GoRoutine{}
 	gr.Init(stopCh)
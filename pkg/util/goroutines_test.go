@@ -140,3 +140,52 @@ func TestNewGo(t *testing.T) {
 	g := NewGo(make(chan struct{}))
 	defer g.Close(true)
 }
+
+func TestParallelDo(t *testing.T) {
+	var (
+		lock          sync.Mutex
+		running, peak int
+		results       = make([]int, 100)
+	)
+	err := ParallelDo(len(results), 5, func(i int) error {
+		lock.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		lock.Unlock()
+		<-time.After(time.Millisecond)
+		results[i] = i * 2
+		lock.Lock()
+		running--
+		lock.Unlock()
+		return nil
+	})
+	if err != nil || peak > 5 {
+		fmt.Println("ParallelDo failed, peak workers:", peak, err)
+		t.FailNow()
+	}
+	for i, r := range results {
+		if r != i*2 {
+			fmt.Println("ParallelDo result mismatch at", i)
+			t.FailNow()
+		}
+	}
+
+	var count int
+	err = ParallelDo(100, 1, func(i int) error {
+		count++
+		if i == 3 {
+			return fmt.Errorf("error at %d", i)
+		}
+		return nil
+	})
+	if err == nil || count > 5 {
+		fmt.Println("ParallelDo should stop after error, executed:", count)
+		t.FailNow()
+	}
+
+	if ParallelDo(0, 5, nil) != nil {
+		t.FailNow()
+	}
+}
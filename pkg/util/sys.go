@@ -23,20 +23,25 @@ import (
 
 const INT_SIZE int = int(unsafe.Sizeof(0))
 
+// GetLocalIP 优先返回非回环的IPv4地址，只有IPv6时返回全局单播的IPv6地址
 func GetLocalIP() string {
 	addrs, err := net.InterfaceAddrs()
 	if err != nil {
 		return ""
 	}
+	var ipv6 string
 	for _, address := range addrs {
 		// check the address type and if it is not a loopback the display it
 		if ipnet, ok := address.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
 			if ipnet.IP.To4() != nil {
 				return ipnet.IP.String()
 			}
+			if len(ipv6) == 0 && ipnet.IP.IsGlobalUnicast() {
+				ipv6 = ipnet.IP.String()
+			}
 		}
 	}
-	return ""
+	return ipv6
 }
 
 func IsBigEndian() bool {
@@ -165,6 +165,20 @@ func ParseProject(ctx context.Context) string {
 	return v
 }
 
+// SetTargetDomainProject 设置被访问的服务所在的domain/project，用于跨租户发现共享服务
+func SetTargetDomainProject(ctx context.Context, domainProject string) context.Context {
+	return SetContext(ctx, "target-domain-project", domainProject)
+}
+
+// ParseTargetDomainProject 未设置时与请求方的domain/project相同
+func ParseTargetDomainProject(ctx context.Context) string {
+	v, ok := FromContext(ctx, "target-domain-project").(string)
+	if !ok || len(v) == 0 {
+		return ParseDomainProject(ctx)
+	}
+	return v
+}
+
 func GetIPFromContext(ctx context.Context) string {
 	v, ok := FromContext(ctx, "x-remote-ip").(string)
 	if !ok {
@@ -173,6 +187,30 @@ func GetIPFromContext(ctx context.Context) string {
 	return v
 }
 
+func GetSchemeFromContext(ctx context.Context) string {
+	v, ok := FromContext(ctx, "x-remote-scheme").(string)
+	if !ok {
+		return ""
+	}
+	return v
+}
+
+func GetUserAgentFromContext(ctx context.Context) string {
+	v, ok := FromContext(ctx, "x-user-agent").(string)
+	if !ok {
+		return ""
+	}
+	return v
+}
+
+func GetTLSIdentityFromContext(ctx context.Context) string {
+	v, ok := FromContext(ctx, "x-tls-identity").(string)
+	if !ok {
+		return ""
+	}
+	return v
+}
+
 func DeepCopy(dst, src interface{}) error {
 	var buf bytes.Buffer
 	if err := gob.NewEncoder(&buf).Encode(src); err != nil {
@@ -281,6 +319,7 @@ func GetCaller(skip int) (string, string, int, bool) {
 	return file, method, line, ok
 }
 
+// ParseEndpoint 返回地址的host:port，IPv6地址带中括号，如rest://[::1]:30100返回[::1]:30100
 func ParseEndpoint(ep string) (string, error) {
 	u, err := url.Parse(ep)
 	if err != nil {
@@ -288,16 +327,89 @@ func ParseEndpoint(ep string) (string, error) {
 	}
 	port := u.Port()
 	if len(port) > 0 {
-		return u.Hostname() + ":" + port, nil
+		return net.JoinHostPort(u.Hostname(), port), nil
 	}
 	return u.Hostname(), nil
 }
 
+// NormalizeIP 解析可能带中括号、端口或zone的IP，IPv4映射的IPv6地址转为IPv4，不是IP时返回空
+func NormalizeIP(s string) string {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	s = strings.Trim(s, "[]")
+	if i := strings.Index(s, "%"); i >= 0 {
+		s = s[:i]
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies 设置可信代理的地址列表(IP或CIDR)，为空时不信任任何来源的转发头
+func SetTrustedProxies(proxies []string) error {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if len(p) == 0 {
+			continue
+		}
+		if strings.Index(p, "/") < 0 {
+			if ip := net.ParseIP(p); ip != nil && ip.To4() != nil {
+				p += "/32"
+			} else {
+				p += "/128"
+			}
+		}
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return err
+		}
+		nets = append(nets, n)
+	}
+	trustedProxies = nets
+	return nil
+}
+
+func isTrustedProxy(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, n := range trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func remoteHost(r *http.Request) string {
+	if ip := NormalizeIP(r.RemoteAddr); len(ip) > 0 {
+		return ip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 func GetRealIP(r *http.Request) string {
+	host := remoteHost(r)
+	if !isTrustedProxy(host) {
+		return host
+	}
 	for _, h := range [2]string{"X-Forwarded-For", "X-Real-Ip"} {
 		addresses := strings.Split(r.Header.Get(h), ",")
 		for _, ip := range addresses {
-			ip = strings.TrimSpace(ip)
+			// 代理可能带上端口，如[2001:db8::1]:8080
+			ip = NormalizeIP(ip)
 			realIP := net.ParseIP(ip)
 			if !realIP.IsGlobalUnicast() {
 				continue
@@ -305,11 +417,21 @@ func GetRealIP(r *http.Request) string {
 			return ip
 		}
 	}
-	addrs := strings.Split(r.RemoteAddr, ":")
-	if len(addrs) > 0 {
-		return addrs[0]
+	return host
+}
+
+func GetRealScheme(r *http.Request) string {
+	if isTrustedProxy(remoteHost(r)) {
+		proto := strings.Split(r.Header.Get("X-Forwarded-Proto"), ",")[0]
+		proto = strings.ToLower(strings.TrimSpace(proto))
+		if proto == "http" || proto == "https" {
+			return proto
+		}
+	}
+	if r.TLS != nil {
+		return "https"
 	}
-	return ""
+	return "http"
 }
 
 func BytesToInt32(bs []byte) (in int32) {
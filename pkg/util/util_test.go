@@ -17,6 +17,8 @@
 package util
 
 import (
+	"golang.org/x/net/context"
+	"net/http"
 	"testing"
 )
 
@@ -51,3 +53,105 @@ func TestBytesToInt32(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestGetRealIP(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "[::1]:30100"
+	if GetRealIP(r) != "::1" || GetRealScheme(r) != "http" {
+		t.Fatalf("TestGetRealIP failed")
+	}
+
+	// 未配置可信代理时不信任转发头
+	r.Header.Set("X-Forwarded-For", "8.8.8.8, 10.0.0.1")
+	r.Header.Set("X-Forwarded-Proto", "https")
+	if GetRealIP(r) != "::1" || GetRealScheme(r) != "http" {
+		t.Fatalf("TestGetRealIP failed")
+	}
+
+	if err := SetTrustedProxies([]string{"127.0.0.1", "10.0.0.0/8"}); err != nil {
+		t.Fatalf("TestGetRealIP failed, %s", err.Error())
+	}
+	defer SetTrustedProxies(nil)
+	if GetRealIP(r) != "::1" || GetRealScheme(r) != "http" {
+		t.Fatalf("TestGetRealIP failed")
+	}
+	r.RemoteAddr = "10.1.1.1:30100"
+	if GetRealIP(r) != "8.8.8.8" || GetRealScheme(r) != "https" {
+		t.Fatalf("TestGetRealIP failed")
+	}
+
+	r.Header.Set("X-Forwarded-For", "[2001:db8::1]:8080")
+	if GetRealIP(r) != "2001:db8::1" {
+		t.Fatalf("TestGetRealIP ipv6 failed")
+	}
+	r.RemoteAddr = "[::ffff:10.1.1.1]:30100"
+	if GetRealIP(r) != "2001:db8::1" {
+		t.Fatalf("TestGetRealIP ipv4-mapped proxy failed")
+	}
+
+	if err := SetTrustedProxies([]string{"x.x.x.x"}); err == nil {
+		t.Fatalf("TestGetRealIP failed")
+	}
+}
+
+func TestGetRealIPSpoofing(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "203.0.113.5:40000"
+	r.Header.Set("X-Forwarded-For", "10.0.0.2")
+	r.Header.Set("X-Real-Ip", "10.0.0.3")
+	r.Header.Set("X-Forwarded-Proto", "https")
+
+	for _, proxies := range [][]string{nil, {""}, {"10.0.0.0/8"}} {
+		if err := SetTrustedProxies(proxies); err != nil {
+			t.Fatalf("TestGetRealIPSpoofing %v failed, %s", proxies, err.Error())
+		}
+		if ip := GetRealIP(r); ip != "203.0.113.5" {
+			t.Fatalf("TestGetRealIPSpoofing %v, spoofed ip %s accepted", proxies, ip)
+		}
+		if scheme := GetRealScheme(r); scheme != "http" {
+			t.Fatalf("TestGetRealIPSpoofing %v, spoofed scheme %s accepted", proxies, scheme)
+		}
+	}
+	SetTrustedProxies(nil)
+}
+
+func TestParseEndpoint(t *testing.T) {
+	for ep, expect := range map[string]string{
+		"rest://127.0.0.1:30100":                 "127.0.0.1:30100",
+		"rest://[::1]:30100?sslEnabled=true":     "[::1]:30100",
+		"grpc://[2001:db8::1]:30100":             "[2001:db8::1]:30100",
+		"rest://[fe80::1%25eth0]:30100":          "[fe80::1%eth0]:30100",
+		"rest://service-center.local:30100/path": "service-center.local:30100",
+	} {
+		addr, err := ParseEndpoint(ep)
+		if err != nil || addr != expect {
+			t.Fatalf("TestParseEndpoint %s failed, %s", ep, addr)
+		}
+	}
+
+	for s, expect := range map[string]string{
+		"10.0.0.1":             "10.0.0.1",
+		"10.0.0.1:8080":        "10.0.0.1",
+		"[2001:db8::1]:8080":   "2001:db8::1",
+		"2001:DB8::1":          "2001:db8::1",
+		"fe80::1%eth0":         "fe80::1",
+		"::ffff:10.0.0.1":      "10.0.0.1",
+		"service-center.local": "",
+	} {
+		if ip := NormalizeIP(s); ip != expect {
+			t.Fatalf("TestNormalizeIP %s failed, %s", s, ip)
+		}
+	}
+}
+
+func TestParseTargetDomainProject(t *testing.T) {
+	ctx := SetContext(context.Background(), "domain", "a")
+	ctx = SetContext(ctx, "project", "b")
+	if ParseTargetDomainProject(ctx) != "a/b" {
+		t.Fatalf("TestParseTargetDomainProject failed")
+	}
+	ctx = SetTargetDomainProject(CloneContext(ctx), "default/default")
+	if ParseTargetDomainProject(ctx) != "default/default" || ParseDomainProject(ctx) != "a/b" {
+		t.Fatalf("TestParseTargetDomainProject failed")
+	}
+}
@@ -26,6 +26,16 @@ import (
 	"unicode/utf8"
 )
 
+const (
+	CONSTRAINT_MIN     = "min"
+	CONSTRAINT_MAX     = "max"
+	CONSTRAINT_LENGTH  = "length"
+	CONSTRAINT_PATTERN = "pattern"
+
+	// 错误详情中回显的字段值最大长度
+	MAX_VIOLATION_VALUE_LENGTH = 64
+)
+
 type ValidateRule struct {
 	Min    int
 	Max    int
@@ -48,12 +58,27 @@ func (v *ValidateRule) String() string {
 		idx++
 	}
 	if v.Regexp != nil {
-		arr[idx] = fmt.Sprintf("Length: %s", v.Regexp)
+		arr[idx] = fmt.Sprintf("Regexp: %s", v.Regexp)
 		idx++
 	}
 	return "rule: {" + util.StringJoin(arr[:idx], ",") + "}"
 }
 
+// Violation 返回s违反的约束，匹配时返回空
+func (v *ValidateRule) Violation(s interface{}) string {
+	switch {
+	case v.Min != 0 && !(&ValidateRule{Min: v.Min}).Match(s):
+		return CONSTRAINT_MIN
+	case v.Max != 0 && !(&ValidateRule{Max: v.Max}).Match(s):
+		return CONSTRAINT_MAX
+	case v.Length != 0 && !(&ValidateRule{Length: v.Length}).Match(s):
+		return CONSTRAINT_LENGTH
+	case v.Regexp != nil && !(&ValidateRule{Regexp: v.Regexp}).Match(s):
+		return CONSTRAINT_PATTERN
+	}
+	return ""
+}
+
 func (v *ValidateRule) Match(s interface{}) bool {
 	var invalid bool = false
 	sv := reflect.ValueOf(s)
@@ -151,6 +176,49 @@ func (v *ValidateRule) Match(s interface{}) bool {
 	return !invalid
 }
 
+// ValidateError 单个字段的校验失败信息，Field为完整的字段路径，如Schemas[1].SchemaId
+type ValidateError struct {
+	Field      string
+	Rule       *ValidateRule
+	Constraint string
+	Value      string
+}
+
+func (e *ValidateError) Error() string {
+	return fmt.Sprintf("%s validate failed, %s", e.Field, e.Rule)
+}
+
+// ValidateErrors 一次校验中的所有字段校验失败信息
+type ValidateErrors []*ValidateError
+
+func (es ValidateErrors) Error() string {
+	arr := make([]string, 0, len(es))
+	for _, e := range es {
+		arr = append(arr, e.Error())
+	}
+	return util.StringJoin(arr, "; ")
+}
+
+func newValidateError(field string, rule *ValidateRule, value interface{}) *ValidateError {
+	str := []rune(fmt.Sprint(value))
+	if len(str) > MAX_VIOLATION_VALUE_LENGTH {
+		str = append(str[:MAX_VIOLATION_VALUE_LENGTH], []rune("...")...)
+	}
+	return &ValidateError{
+		Field:      field,
+		Rule:       rule,
+		Constraint: rule.Violation(value),
+		Value:      string(str),
+	}
+}
+
+func fieldPath(parent, name string) string {
+	if len(parent) == 0 {
+		return name
+	}
+	return parent + "." + name
+}
+
 type Validator struct {
 	rules map[string](*ValidateRule)
 	subs  map[string](*Validator)
@@ -190,14 +258,26 @@ func (v *Validator) GetRules() map[string](*ValidateRule) {
 	return v.rules
 }
 
+// Validate 校验s的所有字段，存在不合法字段时返回ValidateErrors
 func (v *Validator) Validate(s interface{}) error {
+	var errs ValidateErrors
+	if err := v.validate(s, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (v *Validator) validate(s interface{}, path string, errs *ValidateErrors) error {
 	sv := reflect.ValueOf(s)
 	if sv.Kind() == reflect.Ptr && !sv.IsNil() {
-		return v.Validate(sv.Elem().Interface())
+		return v.validate(sv.Elem().Interface(), path, errs)
 	}
 	if sv.Kind() == reflect.Slice && !sv.IsNil() {
 		for i, l := 0, sv.Len(); i < l; i++ {
-			err := v.Validate(sv.Index(i).Interface())
+			err := v.validate(sv.Index(i).Interface(), path+"["+strconv.Itoa(i)+"]", errs)
 			if err != nil {
 				return err
 			}
@@ -213,11 +293,8 @@ func (v *Validator) Validate(s interface{}) error {
 		field := sv.Field(i)
 		fieldName := st.Fields[i].Name
 		validator, ok := v.subs[fieldName]
-		if ok {
-			if (field.Kind() != reflect.Ptr && field.Kind() != reflect.Slice) || field.IsNil() {
-				continue
-			}
-			err := validator.Validate(field.Interface())
+		if ok && (field.Kind() == reflect.Ptr || field.Kind() == reflect.Slice) && !field.IsNil() {
+			err := validator.validate(field.Interface(), fieldPath(path, fieldName), errs)
 			if err != nil {
 				return err
 			}
@@ -229,7 +306,7 @@ func (v *Validator) Validate(s interface{}) error {
 				fi = field.Elem().Interface()
 				fsv := reflect.ValueOf(fi)
 				if fsv.Kind() == reflect.Struct {
-					err := v.Validate(fi)
+					err := v.validate(fi, fieldPath(path, fieldName), errs)
 					if err != nil {
 						return err
 					}
@@ -238,7 +315,7 @@ func (v *Validator) Validate(s interface{}) error {
 			}
 			// TODO null pointer如何校验
 			if field.Kind() != reflect.Ptr && !validate.Match(fi) {
-				return fmt.Errorf("%s validate failed, %s", fieldName, validate)
+				*errs = append(*errs, newValidateError(fieldPath(path, fieldName), validate, fi))
 			}
 		}
 	}
@@ -0,0 +1,282 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package admin
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/version"
+	"github.com/astaxie/beego"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"golang.org/x/net/context"
+	"net/url"
+	"runtime/pprof"
+	"strings"
+	"time"
+)
+
+const REDACTED = "REDACTED"
+
+// 配置项名称中包含这些单词时视为敏感配置
+var sensitiveWords = util.ListToMap([]string{"password", "passwd", "pwd", "pass", "secret", "token", "key", "ak", "sk"})
+
+type ProjectSummary struct {
+	Name         string         `json:"name"`
+	Apps         int            `json:"apps"`
+	Services     int            `json:"services"`
+	Instances    int            `json:"instances"`
+	Environments map[string]int `json:"environments"`
+	Frameworks   map[string]int `json:"frameworks"`
+}
+
+// RegistrySummary 匿名化的注册信息统计，名称均以随机盐做过摘要
+type RegistrySummary struct {
+	Revision int64             `json:"revision"`
+	Services int               `json:"services"`
+	Projects []*ProjectSummary `json:"projects"`
+}
+
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+// SupportBundle 收集配置(已脱敏)、监控指标、缓存统计、goroutine/heap profile
+// 及匿名化的注册信息统计，打包成tar.gz
+func SupportBundle(ctx context.Context, now time.Time) ([]byte, error) {
+	files := make([]*bundleFile, 0, 7)
+	add := func(name string, data []byte) {
+		files = append(files, &bundleFile{name, data})
+	}
+	addJson := func(name string, v interface{}) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		add(name, data)
+		return nil
+	}
+
+	if err := addJson("version.json", version.Ver()); err != nil {
+		return nil, err
+	}
+	if err := addJson("config.json", map[string]interface{}{
+		"server":  apt.ServerInfo.Config,
+		"appConf": RedactedAppConfig(),
+	}); err != nil {
+		return nil, err
+	}
+	if err := addJson("cache.json", store.Store().Stats()); err != nil {
+		return nil, err
+	}
+
+	metrics, err := metricsSnapshot()
+	if err != nil {
+		util.Logger().Errorf(err, "gather metrics failed")
+	} else {
+		add("metrics.txt", metrics)
+	}
+
+	summary, err := AnonymizedRegistrySummary(ctx)
+	if err != nil {
+		util.Logger().Errorf(err, "summarize registry failed")
+	} else if err := addJson("registry.json", summary); err != nil {
+		return nil, err
+	}
+
+	for _, p := range []struct {
+		name, file string
+		debug      int
+	}{
+		{"goroutine", "goroutine.txt", 2},
+		{"heap", "heap.pprof", 0},
+	} {
+		buf := bytes.NewBuffer(nil)
+		if err := pprof.Lookup(p.name).WriteTo(buf, p.debug); err != nil {
+			util.Logger().Errorf(err, "write %s profile failed", p.name)
+			continue
+		}
+		add(p.file, buf.Bytes())
+	}
+
+	return archive(files, now)
+}
+
+func archive(files []*bundleFile, now time.Time) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for _, f := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    "support-bundle/" + f.name,
+			Mode:    0600,
+			Size:    int64(len(f.data)),
+			ModTime: now,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RedactedAppConfig 返回app.conf的配置项，敏感配置及地址中的用户信息会被隐藏
+func RedactedAppConfig() map[string]string {
+	section, err := beego.AppConfig.GetSection("default")
+	if err != nil {
+		return map[string]string{}
+	}
+	conf := make(map[string]string, len(section))
+	for k, v := range section {
+		conf[k] = RedactConfigValue(k, v)
+	}
+	return conf
+}
+
+func RedactConfigValue(key, value string) string {
+	if len(value) == 0 {
+		return value
+	}
+	for _, word := range strings.Split(strings.ToLower(key), "_") {
+		if _, ok := sensitiveWords[word]; ok {
+			return REDACTED
+		}
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		u.User = url.User(REDACTED)
+		return u.String()
+	}
+	return value
+}
+
+func metricsSnapshot() ([]byte, error) {
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(nil)
+	enc := expfmt.NewEncoder(buf, expfmt.FmtText)
+	for _, mf := range mfs {
+		if err := enc.Encode(mf); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+type anonymizer struct {
+	salt []byte
+}
+
+func newAnonymizer() *anonymizer {
+	salt := make([]byte, 16)
+	rand.Read(salt)
+	return &anonymizer{salt: salt}
+}
+
+func (a *anonymizer) Name(s string) string {
+	h := sha256.New()
+	h.Write(a.salt)
+	h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// AnonymizedRegistrySummary 统计各project的应用、微服务、实例个数
+func AnonymizedRegistrySummary(ctx context.Context) (*RegistrySummary, error) {
+	resp, err := backend.Registry().Do(ctx, registry.GET,
+		registry.WithStrKey(apt.GetServiceRootKey("")),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	a := newAnonymizer()
+	summary := &RegistrySummary{Revision: resp.Revision}
+	projects := make(map[string]*ProjectSummary)
+	apps := make(map[string]map[string]struct{})
+	getProject := func(domainProject string) *ProjectSummary {
+		p, ok := projects[domainProject]
+		if !ok {
+			p = &ProjectSummary{
+				Name:         a.Name(domainProject),
+				Environments: make(map[string]int),
+				Frameworks:   make(map[string]int),
+			}
+			projects[domainProject] = p
+			apps[domainProject] = make(map[string]struct{})
+			summary.Projects = append(summary.Projects, p)
+		}
+		return p
+	}
+
+	for _, kv := range resp.Kvs {
+		_, domainProject, data := pb.GetInfoFromSvcKV(kv)
+		var ms pb.MicroService
+		if err := json.Unmarshal(data, &ms); err != nil {
+			continue
+		}
+		p := getProject(domainProject)
+		p.Services++
+		summary.Services++
+		apps[domainProject][ms.AppId] = struct{}{}
+		p.Apps = len(apps[domainProject])
+		env := ms.Environment
+		if len(env) == 0 {
+			env = pb.ENV_DEV
+		}
+		p.Environments[env]++
+		framework := pb.FRAMEWORK_UNKNOWN
+		if ms.Framework != nil && len(ms.Framework.Name) > 0 {
+			framework = ms.Framework.Name
+		}
+		p.Frameworks[framework]++
+	}
+
+	resp, err = backend.Registry().Do(ctx, registry.GET,
+		registry.WithStrKey(apt.GetInstanceRootKey("")),
+		registry.WithPrefix(),
+		registry.WithKeyOnly())
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range resp.Kvs {
+		_, _, domainProject, _ := pb.GetInfoFromInstKV(kv)
+		getProject(domainProject).Instances++
+	}
+	return summary, nil
+}
@@ -0,0 +1,1404 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/alarm"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/auditlog"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/metric"
+	"github.com/apache/incubator-servicecomb-service-center/server/migration"
+	"github.com/apache/incubator-servicecomb-service-center/server/mux"
+	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	"github.com/apache/incubator-servicecomb-service-center/server/scheduler"
+	"github.com/apache/incubator-servicecomb-service-center/server/service/uplink"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	sctls "github.com/apache/incubator-servicecomb-service-center/server/tls"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 未指定start时默认查询的依赖趋势范围
+const DEFAULT_DEPENDENCY_TREND_RANGE = 30 * 24 * time.Hour
+
+// AdminServiceControllerV4 运维管理接口，只允许默认domain访问
+type AdminServiceControllerV4 struct {
+	//
+}
+
+// URLPatterns 路由
+func (this *AdminServiceControllerV4) URLPatterns() []rest.Route {
+	return []rest.Route{
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/support-bundle", this.GetSupportBundle},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/runtime", this.GetRuntime},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/goroutines", this.GetGoroutines},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/debug/pprof", this.ListProfiles},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/debug/pprof/:name", this.GetProfile},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/alarms", this.GetAlarms},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/alarms/history", this.GetAlarmHistory},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/alarms/acknowledge", this.AcknowledgeAlarm},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/tls", this.GetTLSStatus},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/tls/reload", this.ReloadTLS},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/messages", this.GetBundleStatus},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/messages/reload", this.ReloadBundles},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/peers", this.GetPeers},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/admin/peers/:name", this.PutPeer},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/admin/peers/:name", this.DeletePeer},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/peers/:name/rotation", this.StagePeerCA},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/peers/:name/rotation/complete", this.CompletePeerRotation},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/admin/peers/:name/rotation", this.AbortPeerRotation},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/dependencies/migrate", this.MigrateDependencies},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/dependencies/gc", this.CollectOrphanDependencies},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/dependencies/trends", this.GetDependencyTrends},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/services/rename", this.RenameService},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/auditlog/verify", this.VerifyAuditLog},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/connections", this.GetConnTuning},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/admin/connections", this.PutConnTuning},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/jobs", this.GetJobs},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/locks", this.GetLocks},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/dump", this.Dump},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/shared-services", this.GetSharedServices},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/admin/shared-services", this.AddSharedService},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/admin/shared-services/:appId/:serviceName", this.DeleteSharedService},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/migrations", this.GetMigrations},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/lease-policies", this.GetLeasePolicies},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/admin/lease-policies", this.PutLeasePolicy},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/admin/lease-policies/:domain/:targetProject", this.DeleteLeasePolicy},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/discovery-scopes", this.GetDiscoveryScopes},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/admin/discovery-scopes", this.PutDiscoveryScope},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/admin/discovery-scopes/:domain/:targetProject", this.DeleteDiscoveryScope},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/discovery-policies/:domain/:targetProject/:serviceId", this.GetDiscoveryPolicy},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/admin/discovery-policies/:domain/:targetProject/:serviceId", this.PutDiscoveryPolicy},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/admin/discovery-policies/:domain/:targetProject/:serviceId", this.DeleteDiscoveryPolicy},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/features", this.GetFeatureFlags},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/admin/features/:name", this.PutFeatureFlag},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/admin/features/:name", this.DeleteFeatureFlag},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/migrations", this.RunMigrations},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/purges", this.GetPurgeTasks},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/purges", this.PurgeTenant},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/purges/:id", this.GetPurgeTask},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/storage-migration", this.GetStorageMigration},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/storage-migration/check", this.CheckStorageMigration},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/storage-migration/sync", this.SyncStorageMigration},
+		{rest.HTTP_METHOD_POST, "/v4/:project/admin/storage-migration/cutover", this.CutoverStorageMigration},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/metrics/families", this.GetMetricFamilies},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/admin/metrics/families/:name", this.PutMetricFamily},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/storage/layout", this.GetStorageLayout},
+		{rest.HTTP_METHOD_GET, "/v4/:project/admin/storage/usage", this.GetStorageUsage},
+	}
+}
+
+func checkAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if util.ParseDomain(r.Context()) == core.REGISTRY_DOMAIN {
+		return true
+	}
+	util.Logger().Errorf(nil, "access admin api %s refused, domain %s, operator %s",
+		r.URL.Path, util.ParseDomain(r.Context()), util.GetIPFromContext(r.Context()))
+	controller.WriteError(w, scerr.ErrForbidden, "Administrator permission required.")
+	return false
+}
+
+// GetSupportBundle 导出问题定位用的压缩包
+func (this *AdminServiceControllerV4) GetSupportBundle(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+
+	now := time.Now()
+	data, err := SupportBundle(r.Context(), now)
+	if err != nil {
+		util.Logger().Errorf(err, "create support bundle failed")
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+
+	util.Logger().Infof("export support bundle successfully, operator %s.", util.GetIPFromContext(r.Context()))
+	w.Header().Add("X-Response-Status", fmt.Sprint(http.StatusOK))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=support-bundle-%s.tar.gz", now.Format("20060102150405")))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetRuntime 查询goroutine、堆内存和GC停顿等运行时指标
+func (this *AdminServiceControllerV4) GetRuntime(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	controller.WriteJsonObject(w, Runtime())
+}
+
+// GetGoroutines 导出所有goroutine的调用栈
+func (this *AdminServiceControllerV4) GetGoroutines(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+
+	util.Logger().Infof("dump goroutines, operator %s.", util.GetIPFromContext(r.Context()))
+	w.Header().Add("X-Response-Status", fmt.Sprint(http.StatusOK))
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(GoroutineDump())
+}
+
+// ListProfiles 查询可用的profile
+func (this *AdminServiceControllerV4) ListProfiles(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	controller.WriteJsonObject(w, map[string][]string{"profiles": Profiles()})
+}
+
+// GetProfile 导出profile，兼容go tool pprof
+func (this *AdminServiceControllerV4) GetProfile(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+
+	query := r.URL.Query()
+	name := query.Get(":name")
+	debug, _ := strconv.Atoi(query.Get("debug"))
+	seconds, _ := strconv.Atoi(query.Get("seconds"))
+
+	exist := false
+	for _, p := range Profiles() {
+		if p == name {
+			exist = true
+			break
+		}
+	}
+	if !exist {
+		controller.WriteError(w, scerr.ErrInvalidParams, fmt.Sprintf("Unknown profile '%s'.", name))
+		return
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := WriteProfile(buf, name, debug, time.Duration(seconds)*time.Second); err != nil {
+		util.Logger().Errorf(err, "export profile %s failed", name)
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+
+	util.Logger().Infof("export profile %s successfully, operator %s.", name, util.GetIPFromContext(r.Context()))
+	w.Header().Add("X-Response-Status", fmt.Sprint(http.StatusOK))
+	if debug > 0 && name != PROFILE_CPU && name != PROFILE_TRACE {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", name))
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+type AcknowledgeAlarmRequest struct {
+	Id  string `json:"id"`
+	Ttl int64  `json:"ttl,omitempty"`
+}
+
+// GetAlarms 查询所有生效的告警，包括系统告警
+func (this *AdminServiceControllerV4) GetAlarms(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	controller.WriteJsonObject(w, map[string][]*alarm.Alarm{
+		"alarms": alarm.GetCenter().Active(""),
+	})
+}
+
+// GetAlarmHistory 查询最近的告警事件
+func (this *AdminServiceControllerV4) GetAlarmHistory(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	controller.WriteJsonObject(w, map[string][]*alarm.AlarmEvent{
+		"history": alarm.GetCenter().History(limit),
+	})
+}
+
+// AcknowledgeAlarm 确认告警，ttl(秒)内不再通知
+func (this *AdminServiceControllerV4) AcknowledgeAlarm(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &AcknowledgeAlarmRequest{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	if len(request.Id) == 0 || request.Ttl < 0 {
+		controller.WriteError(w, scerr.ErrInvalidParams, "Invalid alarm id or ttl.")
+		return
+	}
+
+	if !alarm.GetCenter().Acknowledge(request.Id, time.Duration(request.Ttl)*time.Second,
+		util.GetIPFromContext(r.Context())) {
+		controller.WriteError(w, scerr.ErrAlarmNotExists, "Alarm does not exist.")
+		return
+	}
+	controller.WriteJsonObject(w, nil)
+}
+
+// GetTLSStatus 查询已加载证书的有效期和最近一次重新加载的结果
+func (this *AdminServiceControllerV4) GetTLSStatus(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	controller.WriteJsonObject(w, sctls.Status())
+}
+
+// ReloadTLS 重新加载证书和CA，已建立的连接不受影响
+func (this *AdminServiceControllerV4) ReloadTLS(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	if !core.ServerInfo.Config.SslEnabled {
+		controller.WriteError(w, scerr.ErrInvalidParams, "SSL is disabled.")
+		return
+	}
+	if err := sctls.Reload(); err != nil {
+		util.Logger().Errorf(err, "reload tls certificates failed, operator %s.", util.GetIPFromContext(r.Context()))
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	util.Logger().Infof("reload tls certificates successfully, operator %s.", util.GetIPFromContext(r.Context()))
+	controller.WriteJsonObject(w, sctls.Status())
+}
+
+// GetBundleStatus 查询已加载的消息包版本和最近一次重新加载的结果
+func (this *AdminServiceControllerV4) GetBundleStatus(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	controller.WriteJsonObject(w, scerr.BundleLoaderStatus())
+}
+
+// ReloadBundles 重新加载message_bundle_dir中的消息包，失败时继续使用原有的消息包
+func (this *AdminServiceControllerV4) ReloadBundles(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	if err := scerr.ReloadBundles(); err != nil {
+		util.Logger().Errorf(err, "reload message bundles failed, operator %s.", util.GetIPFromContext(r.Context()))
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	util.Logger().Infof("reload message bundles successfully, operator %s.", util.GetIPFromContext(r.Context()))
+	controller.WriteJsonObject(w, scerr.BundleLoaderStatus())
+}
+
+// PeerView 登记的对端集群及本节点与其握手、认证的状态
+type PeerView struct {
+	*uplink.Peer
+	Status *sctls.PeerStatus `json:"status,omitempty"`
+}
+
+// GetPeers 查询登记的对端集群
+func (this *AdminServiceControllerV4) GetPeers(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	peers, err := uplink.GetPeers(r.Context())
+	if err != nil {
+		util.Logger().Errorf(err, "get peers failed")
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	status := make(map[string]*sctls.PeerStatus)
+	for _, st := range sctls.PeerStatuses() {
+		status[st.Name] = st
+	}
+	views := make([]*PeerView, 0, len(peers))
+	for _, p := range peers {
+		views = append(views, &PeerView{Peer: p, Status: status[p.Name]})
+	}
+	controller.WriteJsonObject(w, map[string][]*PeerView{
+		"peers": views,
+	})
+}
+
+// PutPeer 登记或覆盖对端集群及其CA，其他节点最多延迟同步周期生效
+func (this *AdminServiceControllerV4) PutPeer(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &uplink.Peer{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	request.Name = r.URL.Query().Get(":name")
+	if err := request.Check(); err != nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	request.Operator = util.GetIPFromContext(r.Context())
+	if err := uplink.PutPeer(r.Context(), request); err != nil {
+		util.Logger().Errorf(err, "put peer %s failed, operator %s.", request.Name, request.Operator)
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	util.Logger().Infof("put peer %s successfully, addr %s, operator %s.",
+		request.Name, request.Addr, request.Operator)
+	controller.WriteJsonObject(w, request)
+}
+
+// DeletePeer 删除对端集群，不再信任其CA
+func (this *AdminServiceControllerV4) DeletePeer(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	name := r.URL.Query().Get(":name")
+	ok, err := uplink.DeletePeer(r.Context(), name)
+	if err != nil {
+		util.Logger().Errorf(err, "delete peer %s failed, operator %s.",
+			name, util.GetIPFromContext(r.Context()))
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	if !ok {
+		controller.WriteError(w, scerr.ErrInvalidParams, "Peer does not exist.")
+		return
+	}
+	util.Logger().Infof("delete peer %s successfully, operator %s.",
+		name, util.GetIPFromContext(r.Context()))
+	controller.WriteJsonObject(w, nil)
+}
+
+// StagePeerCA 开始轮换对端的CA，完成前新旧CA同时被信任
+func (this *AdminServiceControllerV4) StagePeerCA(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &struct {
+		CA string `json:"ca"`
+	}{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	name := r.URL.Query().Get(":name")
+	if err := (&uplink.Peer{Name: name, CA: request.CA}).Check(); err != nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	this.rotatePeer(w, r, "stage", func(operator string) (*uplink.Peer, error) {
+		return uplink.StagePeerCA(r.Context(), name, request.CA, operator)
+	})
+}
+
+// CompletePeerRotation 完成轮换，两端已替换为新CA签发的证书后调用
+func (this *AdminServiceControllerV4) CompletePeerRotation(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	name := r.URL.Query().Get(":name")
+	this.rotatePeer(w, r, "complete", func(operator string) (*uplink.Peer, error) {
+		return uplink.CompletePeerRotation(r.Context(), name, operator)
+	})
+}
+
+// AbortPeerRotation 放弃轮换，继续只信任原来的CA
+func (this *AdminServiceControllerV4) AbortPeerRotation(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	name := r.URL.Query().Get(":name")
+	this.rotatePeer(w, r, "abort", func(operator string) (*uplink.Peer, error) {
+		return uplink.AbortPeerRotation(r.Context(), name, operator)
+	})
+}
+
+func (this *AdminServiceControllerV4) rotatePeer(w http.ResponseWriter, r *http.Request, action string,
+	f func(operator string) (*uplink.Peer, error)) {
+	name := r.URL.Query().Get(":name")
+	operator := util.GetIPFromContext(r.Context())
+	p, err := f(operator)
+	switch {
+	case err == uplink.ErrNoPendingRotation:
+		controller.WriteError(w, scerr.ErrInvalidParams, "No CA rotation in progress.")
+		return
+	case err != nil:
+		util.Logger().Errorf(err, "%s CA rotation of peer %s failed, operator %s.", action, name, operator)
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	case p == nil:
+		controller.WriteError(w, scerr.ErrInvalidParams, "Peer does not exist.")
+		return
+	}
+	util.Logger().Infof("%s CA rotation of peer %s successfully, operator %s.", action, name, operator)
+	controller.WriteJsonObject(w, p)
+}
+
+// MigrateDependencies 按appId映射迁移依赖规则，dryRun=true时只返回变更不写入
+func (this *AdminServiceControllerV4) MigrateDependencies(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &serviceUtil.DependencyMigration{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	if err := request.Check(); err != nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+	result, err := serviceUtil.MigrateDependencies(r.Context(), request, dryRun)
+	if err != nil {
+		util.Logger().Errorf(err, "migrate dependency rules failed, operator %s.", util.GetIPFromContext(r.Context()))
+		controller.WriteError(w, mux.ErrorCode(err), err.Error())
+		return
+	}
+	if !dryRun {
+		util.Logger().Infof("migrate dependency rules of %d consumer(s), %d failed, operator %s.",
+			result.Consumers, len(result.Failed), util.GetIPFromContext(r.Context()))
+	}
+	controller.WriteJsonObject(w, result)
+}
+
+// CollectOrphanDependencies 立即回收consumer已删除的依赖数据，dryRun=true时只返回找到的key
+func (this *AdminServiceControllerV4) CollectOrphanDependencies(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+	result, err := serviceUtil.CollectOrphanDependencies(r.Context(), dryRun)
+	if err != nil {
+		util.Logger().Errorf(err, "collect orphan dependencies failed, operator %s.", util.GetIPFromContext(r.Context()))
+		controller.WriteError(w, mux.ErrorCode(err), err.Error())
+		return
+	}
+	if !dryRun {
+		util.Logger().Infof("collect orphan dependencies, %d rule(s), %d relation(s), %d usage(s), %d failed, operator %s.",
+			result.Rules, result.Relations, result.Usages, len(result.Failed), util.GetIPFromContext(r.Context()))
+	}
+	controller.WriteJsonObject(w, result)
+}
+
+// RenameService 修改服务的appId或serviceName，dryRun=true时只返回变更不写入
+func (this *AdminServiceControllerV4) RenameService(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &serviceUtil.ServiceRename{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	ctx := r.Context()
+	domainProject := request.DomainProject
+	if len(domainProject) == 0 {
+		domainProject = util.ParseDomainProject(ctx)
+	}
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+	result, scErr := serviceUtil.RenameService(ctx, domainProject, request, dryRun)
+	if scErr != nil {
+		util.Logger().Errorf(scErr, "rename service %s failed, operator %s.",
+			request.ServiceId, util.GetIPFromContext(ctx))
+		controller.WriteError(w, scErr.Code, scErr.Detail)
+		return
+	}
+	if !dryRun {
+		util.Logger().Infof("rename %d version(s) of service %s/%s to appId '%s' serviceName '%s', %d change(s), operator %s.",
+			len(result.ServiceIds), domainProject, request.ServiceId, request.AppId, request.ServiceName,
+			len(result.Changes), util.GetIPFromContext(ctx))
+	}
+	controller.WriteJsonObject(w, result)
+}
+
+// VerifyAuditLog 校验审计日志的hash链，domainProject为空时校验所有租户
+func (this *AdminServiceControllerV4) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	verifier, ok := plugin.Plugins().Instance(plugin.AUDIT_LOG).(auditlog.Verifier)
+	if !ok {
+		controller.WriteError(w, scerr.ErrInvalidParams, "The audit log plugin does not support verification.")
+		return
+	}
+	results, err := verifier.Verify(r.URL.Query().Get("domainProject"))
+	if err != nil {
+		util.Logger().Errorf(err, "verify audit log failed")
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	valid := true
+	for _, result := range results {
+		if !result.Valid {
+			valid = false
+			util.Logger().Warnf(nil, "audit log of %s is broken at record %d, %s",
+				result.DomainProject, result.BrokenAt, result.Reason)
+		}
+	}
+	util.Logger().Infof("verify audit log of %d tenant(s), valid %v, operator %s.",
+		len(results), valid, util.GetIPFromContext(r.Context()))
+	controller.WriteJsonObject(w, map[string]interface{}{
+		"valid":   valid,
+		"results": results,
+	})
+}
+
+// GetConnTuning 查询REST和gRPC前端当前的连接保活和并发流参数
+func (this *AdminServiceControllerV4) GetConnTuning(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	controller.WriteJsonObject(w, core.GetConnTuning())
+}
+
+// PutConnTuning 运行时调整连接参数，未指定的字段保持不变，只对新建立的连接生效
+func (this *AdminServiceControllerV4) PutConnTuning(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	prev := core.GetConnTuning()
+	request := prev
+	err = json.Unmarshal(message, &request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	if err := request.Check(); err != nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	if request.HTTP2Enabled != prev.HTTP2Enabled {
+		controller.WriteError(w, scerr.ErrInvalidParams, "http2Enabled can not be changed at runtime.")
+		return
+	}
+
+	operator := util.GetIPFromContext(r.Context())
+	if err := core.SetConnTuning(request); err != nil {
+		util.Logger().Errorf(err, "tune connections failed, operator %s.", operator)
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	util.Logger().Infof("tune connections successfully, %+v, operator %s.", request, operator)
+	controller.WriteJsonObject(w, request)
+}
+
+// GetJobs 查询本节点后台任务的执行次数、最近一次执行结果和下次执行时间，
+// 以及集群中单例任务当前的leader
+func (this *AdminServiceControllerV4) GetJobs(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	leaders, err := mux.Leaders(r.Context())
+	if err != nil {
+		util.Logger().Errorf(err, "get leaders failed")
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	controller.WriteJsonObject(w, map[string]interface{}{
+		"jobs":    scheduler.GetScheduler().Status(),
+		"leaders": leaders,
+	})
+}
+
+// GetLocks 查询集群中当前被持有的分布式锁及持有者
+func (this *AdminServiceControllerV4) GetLocks(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	locks, err := mux.Locks(r.Context())
+	if err != nil {
+		util.Logger().Errorf(err, "get locks failed")
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	controller.WriteJsonObject(w, map[string][]*mux.LockStatus{
+		"locks": locks,
+	})
+}
+
+// GetSharedServices 查询所有租户可发现的共享服务
+func (this *AdminServiceControllerV4) GetSharedServices(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	services, err := serviceUtil.GetSharedServices(r.Context())
+	if err != nil {
+		util.Logger().Errorf(err, "get shared services failed")
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	controller.WriteJsonObject(w, map[string][]*serviceUtil.SharedService{
+		"services": services,
+	})
+}
+
+// AddSharedService 把租户下指定appId/serviceName的服务标记为共享，所有版本均可被其它租户发现
+func (this *AdminServiceControllerV4) AddSharedService(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &serviceUtil.SharedService{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	if err := request.Check(); err != nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	request.Operator = util.GetIPFromContext(r.Context())
+	if err := serviceUtil.AddSharedService(r.Context(), request); err != nil {
+		util.Logger().Errorf(err, "share service %s/%s of %s failed, operator %s.",
+			request.AppId, request.ServiceName, request.DomainProject(), request.Operator)
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	util.Logger().Infof("share service %s/%s of %s successfully, operator %s.",
+		request.AppId, request.ServiceName, request.DomainProject(), request.Operator)
+	controller.WriteJsonObject(w, request)
+}
+
+// DeleteSharedService 取消共享，已发现的实例缓存在消费者侧不受影响
+func (this *AdminServiceControllerV4) DeleteSharedService(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	appId, serviceName := query.Get(":appId"), query.Get(":serviceName")
+	ok, err := serviceUtil.DeleteSharedService(r.Context(), appId, serviceName)
+	if err != nil {
+		util.Logger().Errorf(err, "unshare service %s/%s failed, operator %s.",
+			appId, serviceName, util.GetIPFromContext(r.Context()))
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	if !ok {
+		controller.WriteError(w, scerr.ErrServiceNotExists, "Shared service does not exist.")
+		return
+	}
+	util.Logger().Infof("unshare service %s/%s successfully, operator %s.",
+		appId, serviceName, util.GetIPFromContext(r.Context()))
+	controller.WriteJsonObject(w, nil)
+}
+
+// GetMigrations 查询当前数据版本、待执行的迁移和执行记录
+func (this *AdminServiceControllerV4) GetMigrations(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	status, err := migration.GetStatus(r.Context())
+	if err != nil {
+		util.Logger().Errorf(err, "get migration status failed")
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	controller.WriteJsonObject(w, status)
+}
+
+// RunMigrations 执行未完成的迁移，dryRun=true时只返回将要变更的key数
+func (this *AdminServiceControllerV4) RunMigrations(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+	operator := util.GetIPFromContext(r.Context())
+
+	lock, err := mux.Lock(mux.GLOBAL_LOCK)
+	if err != nil {
+		util.Logger().Errorf(err, "run migrations failed, operator %s.", operator)
+		controller.WriteError(w, mux.ErrorCode(err), err.Error())
+		return
+	}
+	result, err := migration.Run(r.Context(), dryRun)
+	lock.Unlock()
+	if err != nil {
+		util.Logger().Errorf(err, "run migrations failed, dryRun %v, operator %s.", dryRun, operator)
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	util.Logger().Infof("run migrations from version %d to %d successfully, dryRun %v, operator %s.",
+		result.From, result.To, dryRun, operator)
+	controller.WriteJsonObject(w, result)
+}
+
+// GetLeasePolicies 查询所有租户的租约策略
+func (this *AdminServiceControllerV4) GetLeasePolicies(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	policies, err := serviceUtil.GetLeasePolicies(r.Context())
+	if err != nil {
+		util.Logger().Errorf(err, "get lease policies failed")
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	controller.WriteJsonObject(w, map[string][]*serviceUtil.LeasePolicy{
+		"policies": policies,
+	})
+}
+
+// PutLeasePolicy 配置租户的默认租约和TTL范围，已注册的实例在重新注册后生效
+func (this *AdminServiceControllerV4) PutLeasePolicy(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &serviceUtil.LeasePolicy{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	if err := request.Check(); err != nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	request.Operator = util.GetIPFromContext(r.Context())
+	if err := serviceUtil.PutLeasePolicy(r.Context(), request); err != nil {
+		util.Logger().Errorf(err, "put lease policy of %s failed, operator %s.",
+			request.DomainProject(), request.Operator)
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	util.Logger().Infof("put lease policy of %s successfully, default %ds*(%d+1), ttl [%d, %d], operator %s.",
+		request.DomainProject(), request.DefaultInterval, request.DefaultTimes,
+		request.MinTTL, request.MaxTTL, request.Operator)
+	controller.WriteJsonObject(w, request)
+}
+
+// DeleteLeasePolicy 删除租约策略，之后注册的实例恢复使用客户端指定的值
+func (this *AdminServiceControllerV4) DeleteLeasePolicy(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	domainProject := util.StringJoin([]string{query.Get(":domain"), query.Get(":targetProject")}, "/")
+	ok, err := serviceUtil.DeleteLeasePolicy(r.Context(), domainProject)
+	if err != nil {
+		util.Logger().Errorf(err, "delete lease policy of %s failed, operator %s.",
+			domainProject, util.GetIPFromContext(r.Context()))
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	if !ok {
+		controller.WriteError(w, scerr.ErrInvalidParams, "Lease policy does not exist.")
+		return
+	}
+	util.Logger().Infof("delete lease policy of %s successfully, operator %s.",
+		domainProject, util.GetIPFromContext(r.Context()))
+	controller.WriteJsonObject(w, nil)
+}
+
+// GetDiscoveryScopes 查询所有租户的跨环境发现范围
+func (this *AdminServiceControllerV4) GetDiscoveryScopes(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	scopes, err := serviceUtil.GetDiscoveryScopes(r.Context())
+	if err != nil {
+		util.Logger().Errorf(err, "get discovery scopes failed")
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	controller.WriteJsonObject(w, map[string][]*serviceUtil.DiscoveryScope{
+		"scopes": scopes,
+	})
+}
+
+// PutDiscoveryScope 配置租户内哪些环境之间可以互相发现
+func (this *AdminServiceControllerV4) PutDiscoveryScope(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &serviceUtil.DiscoveryScope{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	if err := request.Check(); err != nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	request.Operator = util.GetIPFromContext(r.Context())
+	if err := serviceUtil.PutDiscoveryScope(r.Context(), request); err != nil {
+		util.Logger().Errorf(err, "put discovery scope of %s failed, operator %s.",
+			request.DomainProject(), request.Operator)
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	util.Logger().Infof("put discovery scope of %s successfully, allowCrossEnvironment %v, visibility %v, operator %s.",
+		request.DomainProject(), request.AllowCrossEnvironment, request.Visibility, request.Operator)
+	controller.WriteJsonObject(w, request)
+}
+
+// DeleteDiscoveryScope 删除发现范围，之后只能发现同一环境的服务
+func (this *AdminServiceControllerV4) DeleteDiscoveryScope(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	domainProject := util.StringJoin([]string{query.Get(":domain"), query.Get(":targetProject")}, "/")
+	ok, err := serviceUtil.DeleteDiscoveryScope(r.Context(), domainProject)
+	if err != nil {
+		util.Logger().Errorf(err, "delete discovery scope of %s failed, operator %s.",
+			domainProject, util.GetIPFromContext(r.Context()))
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	if !ok {
+		controller.WriteError(w, scerr.ErrInvalidParams, "Discovery scope does not exist.")
+		return
+	}
+	util.Logger().Infof("delete discovery scope of %s successfully, operator %s.",
+		domainProject, util.GetIPFromContext(r.Context()))
+	controller.WriteJsonObject(w, nil)
+}
+
+// GetDiscoveryPolicy 查询指定租户内consumer的服务发现策略
+func (this *AdminServiceControllerV4) GetDiscoveryPolicy(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	resp, _ := core.ServiceAPI.GetDiscoveryPolicy(r.Context(), &pb.GetDiscoveryPolicyRequest{
+		ServiceId: query.Get(":serviceId"),
+		Domain:    query.Get(":domain"),
+		Project:   query.Get(":targetProject"),
+	})
+	controller.WriteResponse(w, resp.Response, resp.Policy)
+}
+
+// PutDiscoveryPolicy 配置指定租户内consumer的服务发现策略，在FindInstances时生效
+func (this *AdminServiceControllerV4) PutDiscoveryPolicy(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	policy := &pb.DiscoveryPolicy{}
+	err = json.Unmarshal(message, policy)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	query := r.URL.Query()
+	resp, _ := core.ServiceAPI.UpdateDiscoveryPolicy(r.Context(), &pb.UpdateDiscoveryPolicyRequest{
+		ServiceId: query.Get(":serviceId"),
+		Policy:    policy,
+		Domain:    query.Get(":domain"),
+		Project:   query.Get(":targetProject"),
+	})
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+// DeleteDiscoveryPolicy 删除指定租户内consumer的服务发现策略
+func (this *AdminServiceControllerV4) DeleteDiscoveryPolicy(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	resp, _ := core.ServiceAPI.DeleteDiscoveryPolicy(r.Context(), &pb.DeleteDiscoveryPolicyRequest{
+		ServiceId: query.Get(":serviceId"),
+		Domain:    query.Get(":domain"),
+		Project:   query.Get(":targetProject"),
+	})
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+// GetFeatureFlags 查询所有特性及其在各租户的状态
+func (this *AdminServiceControllerV4) GetFeatureFlags(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	flags, err := serviceUtil.GetFeatureFlags(r.Context())
+	if err != nil {
+		util.Logger().Errorf(err, "get feature flags failed")
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	controller.WriteJsonObject(w, map[string][]*serviceUtil.FeatureFlag{
+		"features": flags,
+	})
+}
+
+// PutFeatureFlag 设置特性的全局状态和按租户覆盖的状态，各节点最多延迟缓存时间生效
+func (this *AdminServiceControllerV4) PutFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &serviceUtil.FeatureFlag{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	request.Name = r.URL.Query().Get(":name")
+	if err := request.Check(); err != nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	request.Operator = util.GetIPFromContext(r.Context())
+	if err := serviceUtil.PutFeatureFlag(r.Context(), request); err != nil {
+		util.Logger().Errorf(err, "put feature flag %s failed, operator %s.", request.Name, request.Operator)
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	util.Logger().Infof("put feature flag %s successfully, enabled %v, tenants %v, operator %s.",
+		request.Name, request.Enabled, request.Tenants, request.Operator)
+	controller.WriteJsonObject(w, request)
+}
+
+// DeleteFeatureFlag 删除特性的配置，所有租户恢复默认状态
+func (this *AdminServiceControllerV4) DeleteFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	name := r.URL.Query().Get(":name")
+	ok, err := serviceUtil.DeleteFeatureFlag(r.Context(), name)
+	if err != nil {
+		util.Logger().Errorf(err, "delete feature flag %s failed, operator %s.",
+			name, util.GetIPFromContext(r.Context()))
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	if !ok {
+		controller.WriteError(w, scerr.ErrInvalidParams, "Feature flag is not configured.")
+		return
+	}
+	util.Logger().Infof("delete feature flag %s successfully, operator %s.",
+		name, util.GetIPFromContext(r.Context()))
+	controller.WriteJsonObject(w, nil)
+}
+
+// GetDependencyTrends 查询[start, end)内各租户依赖图的统计快照，默认查询最近30天
+func (this *AdminServiceControllerV4) GetDependencyTrends(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	end := time.Now()
+	if v := query.Get("end"); len(v) > 0 {
+		ts, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			controller.WriteError(w, scerr.ErrInvalidParams, "parameter end must be a unix timestamp")
+			return
+		}
+		end = time.Unix(ts, 0)
+	}
+	start := end.Add(-DEFAULT_DEPENDENCY_TREND_RANGE)
+	if v := query.Get("start"); len(v) > 0 {
+		ts, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			controller.WriteError(w, scerr.ErrInvalidParams, "parameter start must be a unix timestamp")
+			return
+		}
+		start = time.Unix(ts, 0)
+	}
+	if !start.Before(end) {
+		controller.WriteError(w, scerr.ErrInvalidParams, "parameter start must be less than end")
+		return
+	}
+
+	trends, err := serviceUtil.QueryDependencyGraphStats(r.Context(), start, end,
+		query.Get("domain"), query.Get("project"))
+	if err != nil {
+		util.Logger().Errorf(err, "query dependency trends failed")
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	controller.WriteJsonObject(w, map[string][]*serviceUtil.DependencyGraphStats{
+		"trends": trends,
+	})
+}
+
+// Dump 按查询条件导出注册数据，format=ndjson或Accept为application/x-ndjson时逐行流式输出
+func (this *AdminServiceControllerV4) Dump(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	q, err := ParseDumpQuery(query.Get("q"))
+	if err != nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	operator := util.GetIPFromContext(r.Context())
+
+	if query.Get("format") != "ndjson" && r.Header.Get("Accept") != "application/x-ndjson" {
+		entries := []*DumpEntry{}
+		rev, err := Dump(r.Context(), q, func(e *DumpEntry) error {
+			entries = append(entries, e)
+			return nil
+		})
+		if err != nil {
+			util.Logger().Errorf(err, "dump registry data failed, query '%s', operator %s.", query.Get("q"), operator)
+			controller.WriteError(w, scerr.ErrInternal, err.Error())
+			return
+		}
+		util.Logger().Infof("dump %d key(s) at revision %d, query '%s', operator %s.",
+			len(entries), rev, query.Get("q"), operator)
+		controller.WriteJsonObject(w, map[string]interface{}{
+			"revision": rev,
+			"entries":  entries,
+		})
+		return
+	}
+
+	// 流式输出时响应码已发出，中途失败时以error行结束
+	w.Header().Add("X-Response-Status", fmt.Sprint(http.StatusOK))
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	count := 0
+	rev, err := Dump(r.Context(), q, func(e *DumpEntry) error {
+		if err := encoder.Encode(e); err != nil {
+			return err
+		}
+		if count++; count%100 == 0 && flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		util.Logger().Errorf(err, "dump registry data failed after %d key(s), query '%s', operator %s.",
+			count, query.Get("q"), operator)
+		encoder.Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	util.Logger().Infof("dump %d key(s) at revision %d, query '%s', operator %s.",
+		count, rev, query.Get("q"), operator)
+}
+
+type PurgeTenantRequest struct {
+	Domain  string `json:"domain"`
+	Project string `json:"project"`
+}
+
+// PurgeTenant 后台清除已下线租户的所有数据，返回任务，通过GetPurgeTask查询进度和校验报告
+func (this *AdminServiceControllerV4) PurgeTenant(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &PurgeTenantRequest{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	operator := util.GetIPFromContext(r.Context())
+	task, err := PurgeTenant(request.Domain, request.Project, operator)
+	if err != nil {
+		util.Logger().Errorf(err, "purge tenant %s/%s failed, operator %s.", request.Domain, request.Project, operator)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	util.Logger().Warnf(nil, "start purge task %s of tenant %s, operator %s.", task.Id, task.DomainProject(), operator)
+	controller.WriteJsonObject(w, task)
+}
+
+// GetPurgeTasks 查询本节点最近的清除任务
+func (this *AdminServiceControllerV4) GetPurgeTasks(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	controller.WriteJsonObject(w, map[string][]*PurgeTask{
+		"tasks": GetPurgeTasks(),
+	})
+}
+
+// GetPurgeTask 查询清除任务的进度和校验报告
+func (this *AdminServiceControllerV4) GetPurgeTask(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	task := GetPurgeTask(r.URL.Query().Get(":id"))
+	if task == nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, "Purge task does not exist.")
+		return
+	}
+	controller.WriteJsonObject(w, task)
+}
+
+// migrator 只有registry_plugin为dualwrite时支持存储迁移
+func migrator(w http.ResponseWriter) (registry.Migrator, bool) {
+	m, ok := backend.Registry().(registry.Migrator)
+	if !ok {
+		controller.WriteError(w, scerr.ErrInvalidParams, "Storage migration is not enabled.")
+	}
+	return m, ok
+}
+
+// GetStorageMigration 查询存储迁移的状态
+func (this *AdminServiceControllerV4) GetStorageMigration(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	m, ok := migrator(w)
+	if !ok {
+		return
+	}
+	controller.WriteJsonObject(w, m.MigrationStatus())
+}
+
+// CheckStorageMigration 立即比较两个后端的数据
+func (this *AdminServiceControllerV4) CheckStorageMigration(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	m, ok := migrator(w)
+	if !ok {
+		return
+	}
+	check, err := m.CheckConsistency(r.Context())
+	if err != nil {
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	controller.WriteJsonObject(w, check)
+}
+
+// SyncStorageMigration 按主后端修正另一个后端中不一致的数据，返回修正后的检查结果
+func (this *AdminServiceControllerV4) SyncStorageMigration(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	m, ok := migrator(w)
+	if !ok {
+		return
+	}
+	operator := util.GetIPFromContext(r.Context())
+	fixed, err := m.Sync(r.Context())
+	if err != nil {
+		util.Logger().Errorf(err, "sync storage migration failed, %d key(s) fixed, operator %s.", fixed, operator)
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	util.Logger().Warnf(nil, "sync storage migration successfully, %d key(s) fixed, operator %s.", fixed, operator)
+	controller.WriteJsonObject(w, m.MigrationStatus())
+}
+
+// CutoverStorageMigration 切换到目标后端，force=true时跳过复制和一致性检查
+func (this *AdminServiceControllerV4) CutoverStorageMigration(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	m, ok := migrator(w)
+	if !ok {
+		return
+	}
+	operator := util.GetIPFromContext(r.Context())
+	force := r.URL.Query().Get("force") == "true"
+	if err := m.Cutover(r.Context(), force); err != nil {
+		util.Logger().Errorf(err, "cut over storage migration failed, force %v, operator %s.", force, operator)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	util.Logger().Warnf(nil, "cut over storage migration successfully, force %v, operator %s.", force, operator)
+	controller.WriteJsonObject(w, m.MigrationStatus())
+}
+
+// GetMetricFamilies 查询带租户label的指标族的租户数、时间序列数和聚合状态
+func (this *AdminServiceControllerV4) GetMetricFamilies(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	status, err := metric.GetCardinality()
+	if err != nil {
+		util.Logger().Errorf(err, "get metric families failed")
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	controller.WriteJsonObject(w, status)
+}
+
+// PutMetricFamily 开关指标族的租户聚合，只对本节点生效，返回设置后的状态
+func (this *AdminServiceControllerV4) PutMetricFamily(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &struct {
+		Enabled *bool `json:"enabled"`
+	}{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	name := r.URL.Query().Get(":name")
+	if request.Enabled == nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, "Enabled is required.")
+		return
+	}
+
+	metric.SetFamilyLimited(name, *request.Enabled)
+	util.Logger().Infof("set tenant aggregation of metric family %s to %v, operator %s.",
+		name, *request.Enabled, util.GetIPFromContext(r.Context()))
+	this.GetMetricFamilies(w, r)
+}
+
+// GetStorageLayout 查询service center管理的key前缀
+func (this *AdminServiceControllerV4) GetStorageLayout(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	controller.WriteJsonObject(w, map[string][]*KeyPrefix{"prefixes": KeyLayout()})
+}
+
+// GetStorageUsage 统计各前缀的key数、大小和修改版本范围，tenant为domain/project，
+// names为逗号分隔的前缀名
+func (this *AdminServiceControllerV4) GetStorageUsage(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	tenant := query.Get("tenant")
+	if err := CheckStorageTenant(tenant); err != nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	var names []string
+	if v := query.Get("names"); len(v) > 0 {
+		names = strings.Split(v, ",")
+	}
+
+	usage, err := GetStorageUsage(r.Context(), tenant, names)
+	if err != nil {
+		util.Logger().Errorf(err, "get storage usage failed, operator %s.", util.GetIPFromContext(r.Context()))
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	util.Logger().Infof("get storage usage of %d prefix(es), %d key(s), operator %s.",
+		len(usage.Prefixes), usage.Count, util.GetIPFromContext(r.Context()))
+	controller.WriteJsonObject(w, usage)
+}
@@ -0,0 +1,175 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/astaxie/beego"
+	"io"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"time"
+)
+
+const (
+	PROFILE_CPU   = "profile"
+	PROFILE_TRACE = "trace"
+
+	MAX_PROFILE_DURATION = 60 * time.Second
+	RECENT_GC_PAUSES     = 10
+)
+
+var startTime = time.Now()
+
+func init() {
+	// 开启锁竞争和阻塞分析，默认关闭
+	if fraction := beego.AppConfig.DefaultInt("pprof_mutex_fraction", 0); fraction > 0 {
+		runtime.SetMutexProfileFraction(fraction)
+	}
+	if rate := beego.AppConfig.DefaultInt("pprof_block_rate", 0); rate > 0 {
+		runtime.SetBlockProfileRate(rate)
+	}
+}
+
+type GCStats struct {
+	NumGC         uint32   `json:"numGC"`
+	PauseTotalNs  uint64   `json:"pauseTotalNs"`
+	LastGC        int64    `json:"lastGC"`
+	RecentPauseNs []uint64 `json:"recentPauseNs"`
+}
+
+type HeapStats struct {
+	Alloc        uint64 `json:"alloc"`
+	Sys          uint64 `json:"sys"`
+	Idle         uint64 `json:"idle"`
+	InUse        uint64 `json:"inUse"`
+	Objects      uint64 `json:"objects"`
+	TotalAlloc   uint64 `json:"totalAlloc"`
+	NextGC       uint64 `json:"nextGC"`
+	StackInUse   uint64 `json:"stackInUse"`
+	Mallocs      uint64 `json:"mallocs"`
+	Frees        uint64 `json:"frees"`
+	GCCPUPercent string `json:"gcCPUPercent"`
+}
+
+type RuntimeStats struct {
+	GoVersion  string    `json:"goVersion"`
+	NumCPU     int       `json:"numCPU"`
+	GoMaxProcs int       `json:"goMaxProcs"`
+	Goroutines int       `json:"goroutines"`
+	Uptime     string    `json:"uptime"`
+	Heap       HeapStats `json:"heap"`
+	GC         GCStats   `json:"gc"`
+}
+
+// Runtime 返回当前进程的goroutine、堆内存和GC停顿统计
+func Runtime() *RuntimeStats {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	n := int(ms.NumGC)
+	if n > RECENT_GC_PAUSES {
+		n = RECENT_GC_PAUSES
+	}
+	pauses := make([]uint64, 0, n)
+	for i := 0; i < n; i++ {
+		// PauseNs是环形缓冲区，最近一次GC位于(NumGC+255)%256
+		pauses = append(pauses, ms.PauseNs[(int(ms.NumGC)-1-i+len(ms.PauseNs))%len(ms.PauseNs)])
+	}
+
+	return &RuntimeStats{
+		GoVersion:  runtime.Version(),
+		NumCPU:     runtime.NumCPU(),
+		GoMaxProcs: runtime.GOMAXPROCS(0),
+		Goroutines: runtime.NumGoroutine(),
+		Uptime:     time.Since(startTime).String(),
+		Heap: HeapStats{
+			Alloc:        ms.HeapAlloc,
+			Sys:          ms.HeapSys,
+			Idle:         ms.HeapIdle,
+			InUse:        ms.HeapInuse,
+			Objects:      ms.HeapObjects,
+			TotalAlloc:   ms.TotalAlloc,
+			NextGC:       ms.NextGC,
+			StackInUse:   ms.StackInuse,
+			Mallocs:      ms.Mallocs,
+			Frees:        ms.Frees,
+			GCCPUPercent: fmt.Sprintf("%.4f", ms.GCCPUFraction*100),
+		},
+		GC: GCStats{
+			NumGC:         ms.NumGC,
+			PauseTotalNs:  ms.PauseTotalNs,
+			LastGC:        int64(ms.LastGC),
+			RecentPauseNs: pauses,
+		},
+	}
+}
+
+// Profiles 返回所有可用的profile名称
+func Profiles() []string {
+	profiles := pprof.Profiles()
+	names := make([]string, 0, len(profiles)+2)
+	for _, p := range profiles {
+		names = append(names, p.Name())
+	}
+	return append(names, PROFILE_CPU, PROFILE_TRACE)
+}
+
+// GoroutineDump 导出所有goroutine的调用栈
+func GoroutineDump() []byte {
+	buf := bytes.NewBuffer(nil)
+	pprof.Lookup("goroutine").WriteTo(buf, 2)
+	return buf.Bytes()
+}
+
+// WriteProfile 输出profile，cpu和trace会采样d时长
+func WriteProfile(w io.Writer, name string, debug int, d time.Duration) error {
+	if d <= 0 {
+		d = 30 * time.Second
+	}
+	if d > MAX_PROFILE_DURATION {
+		d = MAX_PROFILE_DURATION
+	}
+
+	switch name {
+	case PROFILE_CPU:
+		if err := pprof.StartCPUProfile(w); err != nil {
+			return err
+		}
+		util.Logger().Infof("start cpu profiling for %s", d)
+		time.Sleep(d)
+		pprof.StopCPUProfile()
+		return nil
+	case PROFILE_TRACE:
+		if err := trace.Start(w); err != nil {
+			return err
+		}
+		util.Logger().Infof("start tracing for %s", d)
+		time.Sleep(d)
+		trace.Stop()
+		return nil
+	}
+
+	p := pprof.Lookup(name)
+	if p == nil {
+		return fmt.Errorf("unknown profile '%s'", name)
+	}
+	return p.WriteTo(w, debug)
+}
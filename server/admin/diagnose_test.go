@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package admin
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRuntime(t *testing.T) {
+	stats := Runtime()
+	if stats.Goroutines <= 0 || stats.NumCPU <= 0 || stats.Heap.Sys == 0 {
+		t.Fatalf("TestRuntime failed, %v", stats)
+	}
+	if len(stats.GC.RecentPauseNs) > RECENT_GC_PAUSES {
+		t.Fatalf("TestRuntime failed, too many gc pauses %d", len(stats.GC.RecentPauseNs))
+	}
+}
+
+func TestWriteProfile(t *testing.T) {
+	if !strings.Contains(string(GoroutineDump()), "TestWriteProfile") {
+		t.Fatalf("TestWriteProfile failed, goroutine dump does not contain current stack")
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := WriteProfile(buf, "heap", 0, 0); err != nil || buf.Len() == 0 {
+		t.Fatalf("TestWriteProfile failed, %v", err)
+	}
+
+	if err := WriteProfile(buf, "unknown", 0, 0); err == nil {
+		t.Fatalf("TestWriteProfile failed")
+	}
+}
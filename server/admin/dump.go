@@ -0,0 +1,252 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DumpQuery 导出注册数据的过滤条件，由空格分隔的field:value组成，多个值以逗号分隔表示或，
+// 不同field之间为且，如: type:instance,lease tenant:default/default since:1024 limit:100；
+// type为缓存类型名(不区分大小写)，tenant为domain或domain/project，prefix为key前缀，
+// 未指定type时直接按前缀导出，可用于导出非缓存类型的数据，since只导出修改版本大于该值的key
+type DumpQuery struct {
+	Types    []store.StoreType
+	Tenants  []string
+	Prefixes []string
+	Since    int64
+	Limit    int
+}
+
+// DumpEntry 导出的key，值为json时原样输出，否则输出为字符串
+type DumpEntry struct {
+	Type           string          `json:"type,omitempty"`
+	Key            string          `json:"key"`
+	Value          json.RawMessage `json:"value,omitempty"`
+	CreateRevision int64           `json:"createRevision"`
+	ModRevision    int64           `json:"modRevision"`
+	Version        int64           `json:"version"`
+	Lease          int64           `json:"lease,omitempty"`
+}
+
+type dumpScan struct {
+	t      store.StoreType
+	typed  bool
+	root   string
+	prefix string
+}
+
+func parseStoreType(s string) (store.StoreType, bool) {
+	for t, name := range store.TypeNames {
+		if strings.EqualFold(name, s) {
+			return store.StoreType(t), true
+		}
+	}
+	return 0, false
+}
+
+func ParseDumpQuery(q string) (*DumpQuery, error) {
+	query := &DumpQuery{}
+	for _, term := range strings.Fields(q) {
+		kv := strings.SplitN(term, ":", 2)
+		if len(kv) != 2 || len(kv[1]) == 0 {
+			return nil, fmt.Errorf("invalid term '%s', expect field:value", term)
+		}
+		values := strings.Split(kv[1], ",")
+		switch kv[0] {
+		case "type":
+			for _, v := range values {
+				t, ok := parseStoreType(v)
+				if !ok {
+					return nil, fmt.Errorf("unknown type '%s'", v)
+				}
+				query.Types = append(query.Types, t)
+			}
+		case "tenant":
+			for _, v := range values {
+				v = strings.Trim(v, "/")
+				if len(v) == 0 || strings.Count(v, "/") > 1 {
+					return nil, fmt.Errorf("invalid tenant '%s', expect domain or domain/project", v)
+				}
+				query.Tenants = append(query.Tenants, v)
+			}
+		case "prefix":
+			query.Prefixes = append(query.Prefixes, values...)
+		case "since":
+			since, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil || since < 0 {
+				return nil, fmt.Errorf("invalid since '%s'", kv[1])
+			}
+			query.Since = since
+		case "limit":
+			limit, err := strconv.Atoi(kv[1])
+			if err != nil || limit <= 0 {
+				return nil, fmt.Errorf("invalid limit '%s'", kv[1])
+			}
+			query.Limit = limit
+		default:
+			return nil, fmt.Errorf("unknown field '%s'", kv[0])
+		}
+	}
+	return query, nil
+}
+
+// typeOf 按最长的根路径推断key的资源类型
+func typeOf(key string) (t store.StoreType, root string, ok bool) {
+	for st, r := range store.TypeRoots {
+		if strings.HasPrefix(key, r) && len(r) > len(root) {
+			t, root, ok = st, r, true
+		}
+	}
+	return
+}
+
+func (q *DumpQuery) scans() []*dumpScan {
+	var scans []*dumpScan
+	if len(q.Types) == 0 && len(q.Prefixes) > 0 {
+		for _, p := range q.Prefixes {
+			t, root, ok := typeOf(p)
+			scans = append(scans, &dumpScan{t: t, typed: ok, root: root, prefix: p})
+		}
+		return scans
+	}
+
+	types := q.Types
+	if len(types) == 0 {
+		for t := range store.TypeRoots {
+			types = append(types, t)
+		}
+		sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	}
+	tenants := q.Tenants
+	if len(tenants) == 0 {
+		tenants = []string{""}
+	}
+	for _, t := range types {
+		root := store.TypeRoots[t]
+		for _, tenant := range tenants {
+			base := root + tenant
+			if len(q.Prefixes) == 0 {
+				scans = append(scans, &dumpScan{t: t, typed: true, root: root, prefix: base})
+				continue
+			}
+			// 前缀比类型和租户更精确时缩小扫描范围
+			for _, p := range q.Prefixes {
+				switch {
+				case strings.HasPrefix(p, base):
+					scans = append(scans, &dumpScan{t: t, typed: true, root: root, prefix: p})
+				case strings.HasPrefix(base, p):
+					scans = append(scans, &dumpScan{t: t, typed: true, root: root, prefix: base})
+				}
+			}
+		}
+	}
+	return scans
+}
+
+// matchTenant tenant为domain时需匹配完整的domain，避免default匹配到default2
+func (q *DumpQuery) matchTenant(s *dumpScan, key string) bool {
+	if len(q.Tenants) == 0 || !s.typed {
+		return true
+	}
+	rest := key[len(s.root):]
+	for _, tenant := range q.Tenants {
+		if rest == tenant || strings.HasPrefix(rest, tenant+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Dump 按条件导出注册数据，所有扫描读取同一版本，返回该版本；fn返回错误时停止导出
+func Dump(ctx context.Context, q *DumpQuery, fn func(e *DumpEntry) error) (int64, error) {
+	var (
+		rev   int64
+		count int
+		seen  = make(map[string]struct{})
+	)
+	for _, s := range q.scans() {
+		if _, ok := seen[s.prefix]; ok {
+			continue
+		}
+		seen[s.prefix] = struct{}{}
+
+		opts := []registry.PluginOpOption{
+			registry.WithStrKey(s.prefix),
+			registry.WithPrefix(),
+		}
+		if rev > 0 {
+			opts = append(opts, registry.WithRev(rev))
+		}
+		resp, err := backend.Registry().Do(ctx, append([]registry.PluginOpOption{registry.GET}, opts...)...)
+		if err != nil {
+			return rev, err
+		}
+		if rev == 0 {
+			rev = resp.Revision
+		}
+		for _, kv := range resp.Kvs {
+			if kv.ModRevision <= q.Since {
+				continue
+			}
+			key := util.BytesToStringWithNoCopy(kv.Key)
+			if !q.matchTenant(s, key) {
+				continue
+			}
+			e := &DumpEntry{
+				Key:            key,
+				Value:          dumpValue(kv.Value),
+				CreateRevision: kv.CreateRevision,
+				ModRevision:    kv.ModRevision,
+				Version:        kv.Version,
+				Lease:          kv.Lease,
+			}
+			if s.typed {
+				e.Type = s.t.String()
+			}
+			if err := fn(e); err != nil {
+				return rev, err
+			}
+			count++
+			if q.Limit > 0 && count >= q.Limit {
+				return rev, nil
+			}
+		}
+	}
+	return rev, nil
+}
+
+func dumpValue(v []byte) json.RawMessage {
+	if len(v) == 0 {
+		return nil
+	}
+	var raw json.RawMessage
+	if err := json.Unmarshal(v, &raw); err == nil {
+		return raw
+	}
+	data, _ := json.Marshal(util.BytesToStringWithNoCopy(v))
+	return data
+}
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package admin
+
+import (
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	"testing"
+)
+
+func TestParseDumpQuery(t *testing.T) {
+	q, err := ParseDumpQuery("type:Instance,lease tenant:default/default since:1024 limit:10")
+	if err != nil {
+		t.Fatalf("TestParseDumpQuery failed, %s", err.Error())
+	}
+	if len(q.Types) != 2 || q.Types[0] != store.INSTANCE || q.Types[1] != store.LEASE ||
+		len(q.Tenants) != 1 || q.Since != 1024 || q.Limit != 10 {
+		t.Fatalf("TestParseDumpQuery failed, %v", q)
+	}
+	if len(q.scans()) != 2 || q.scans()[0].prefix != apt.GetInstanceRootKey("default/default") {
+		t.Fatalf("TestParseDumpQuery failed, unexpected scans")
+	}
+
+	for _, s := range []string{"type:unknown", "tenant:a/b/c", "since:-1", "limit:0", "foo:bar", "type"} {
+		if _, err := ParseDumpQuery(s); err == nil {
+			t.Fatalf("TestParseDumpQuery %s should fail", s)
+		}
+	}
+}
+
+func TestDumpQueryScans(t *testing.T) {
+	q, _ := ParseDumpQuery("tenant:default")
+	scans := q.scans()
+	if len(scans) != len(store.TypeRoots) {
+		t.Fatalf("TestDumpQueryScans failed, %d scans", len(scans))
+	}
+	key := apt.GenerateServiceKey("default/default", "1")
+	for _, s := range scans {
+		if s.t != store.SERVICE {
+			continue
+		}
+		if !q.matchTenant(s, key) || q.matchTenant(s, apt.GenerateServiceKey("default2/default", "1")) {
+			t.Fatalf("TestDumpQueryScans failed, tenant mismatched")
+		}
+	}
+
+	q, _ = ParseDumpQuery("prefix:" + apt.GetMigrationRootKey())
+	scans = q.scans()
+	if len(scans) != 1 || scans[0].typed {
+		t.Fatalf("TestDumpQueryScans failed, untyped prefix")
+	}
+
+	q, _ = ParseDumpQuery("type:service prefix:" + key)
+	scans = q.scans()
+	if len(scans) != 1 || scans[0].prefix != key || scans[0].t != store.SERVICE {
+		t.Fatalf("TestDumpQueryScans failed, narrowed prefix")
+	}
+}
@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package admin_test
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/admin"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/admission/buildin"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/quota/buildin"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/etcd"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/uuid/dynamic"
+	"github.com/apache/incubator-servicecomb-service-center/server/service"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func tenantContext(domain, project string) context.Context {
+	ctx := context.TODO()
+	ctx = util.SetContext(ctx, "domain", domain)
+	ctx = util.SetContext(ctx, "project", project)
+	ctx = util.SetContext(ctx, "noCache", "1")
+	return ctx
+}
+
+func TestDiscoveryPolicyAdmin(t *testing.T) {
+	core.ServiceAPI, core.InstanceAPI = service.AssembleResources()
+
+	tenant := tenantContext("policy_tenant", "policy_project")
+	respCreate, err := core.ServiceAPI.Create(tenant, &pb.CreateServiceRequest{
+		Service: &pb.MicroService{
+			AppId:       "policy_admin_group",
+			ServiceName: "policy_admin_consumer",
+			Version:     "1.0.0",
+			Level:       "FRONT",
+			Status:      pb.MS_UP,
+		},
+	})
+	if err != nil || respCreate.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("create consumer failed, %v", respCreate.Response)
+	}
+	serviceId := respCreate.ServiceId
+	defer core.ServiceAPI.Delete(tenant, &pb.DeleteServiceRequest{ServiceId: serviceId, Force: true})
+
+	c := &admin.AdminServiceControllerV4{}
+	do := func(ctx context.Context, method, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/v4/default/admin/discovery-policies/policy_tenant/policy_project/"+serviceId+
+			"?:domain=policy_tenant&:targetProject=policy_project&:serviceId="+serviceId, strings.NewReader(body))
+		r = r.WithContext(ctx)
+		w := httptest.NewRecorder()
+		switch method {
+		case http.MethodGet:
+			c.GetDiscoveryPolicy(w, r)
+		case http.MethodPut:
+			c.PutDiscoveryPolicy(w, r)
+		case http.MethodDelete:
+			c.DeleteDiscoveryPolicy(w, r)
+		}
+		return w
+	}
+
+	// 租户自己不能修改发现策略
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		if w := do(tenant, method, `{"maxInstances":1}`); w.Code != http.StatusForbidden {
+			t.Fatalf("TestDiscoveryPolicyAdmin failed, %s by tenant returns %d", method, w.Code)
+		}
+	}
+
+	// 管理员为其他租户配置
+	adminCtx := tenantContext(core.REGISTRY_DOMAIN, core.REGISTRY_PROJECT)
+	if w := do(adminCtx, http.MethodPut, `{"maxInstances":1}`); w.Code != http.StatusOK {
+		t.Fatalf("TestDiscoveryPolicyAdmin failed, put returns %d, %s", w.Code, w.Body.String())
+	}
+	policy, err := serviceUtil.GetDiscoveryPolicy(adminCtx, "policy_tenant/policy_project", serviceId)
+	if err != nil || policy == nil || policy.MaxInstances != 1 {
+		t.Fatalf("TestDiscoveryPolicyAdmin failed, policy of the tenant %v, %v", policy, err)
+	}
+	policy, err = serviceUtil.GetDiscoveryPolicy(adminCtx, "default/default", serviceId)
+	if err != nil || policy != nil {
+		t.Fatalf("TestDiscoveryPolicyAdmin failed, policy written to the administrator tenant")
+	}
+	if w := do(adminCtx, http.MethodGet, ""); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"maxInstances":1`) {
+		t.Fatalf("TestDiscoveryPolicyAdmin failed, get returns %d, %s", w.Code, w.Body.String())
+	}
+	if w := do(adminCtx, http.MethodDelete, ""); w.Code != http.StatusOK {
+		t.Fatalf("TestDiscoveryPolicyAdmin failed, delete returns %d, %s", w.Code, w.Body.String())
+	}
+	if w := do(adminCtx, http.MethodGet, ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("TestDiscoveryPolicyAdmin failed, get deleted policy returns %d", w.Code)
+	}
+}
@@ -0,0 +1,367 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/auditlog"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	PURGE_RUNNING   = "running"
+	PURGE_SUCCEEDED = "succeeded"
+	PURGE_FAILED    = "failed"
+
+	// 删除后校验仍有残留时(如实例重新注册)重新删除的最多轮数
+	MAX_PURGE_ROUNDS = 3
+	// 保留最近的清除任务
+	MAX_PURGE_TASKS = 20
+)
+
+var purgeTasks = &purgeTaskList{}
+
+// PurgeTarget 清除的key或key前缀
+type PurgeTarget struct {
+	Name   string `json:"name"`
+	Key    string `json:"key"`
+	Prefix bool   `json:"prefix"`
+	// 第一轮删除前的key数
+	Scanned   int64 `json:"scanned"`
+	Deleted   int64 `json:"deleted"`
+	Remaining int64 `json:"remaining"`
+}
+
+// PurgeReport 校验报告，Verified为true表示所有前缀在最后一轮校验时已没有key
+type PurgeReport struct {
+	Targets  []*PurgeTarget `json:"targets"`
+	Rounds   int            `json:"rounds"`
+	Verified bool           `json:"verified"`
+	// 执行节点上清除的审计记录数，审计插件不支持清除时为空
+	AuditRecords *int64 `json:"auditRecords,omitempty"`
+}
+
+// PurgeTask 异步执行的租户数据清除，任务状态只保存在执行的节点上
+type PurgeTask struct {
+	Id        string       `json:"id"`
+	Domain    string       `json:"domain"`
+	Project   string       `json:"project"`
+	Status    string       `json:"status"`
+	Done      int          `json:"done"`
+	Total     int          `json:"total"`
+	Error     string       `json:"error,omitempty"`
+	Operator  string       `json:"operator,omitempty"`
+	StartTime string       `json:"startTime"`
+	EndTime   string       `json:"endTime,omitempty"`
+	Report    *PurgeReport `json:"report,omitempty"`
+}
+
+func (t *PurgeTask) DomainProject() string {
+	return util.StringJoin([]string{t.Domain, t.Project}, "/")
+}
+
+type purgeTaskList struct {
+	lock  sync.RWMutex
+	tasks []*PurgeTask
+}
+
+// Add 同一租户同时只能有一个清除任务
+func (l *purgeTaskList) Add(task *PurgeTask) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, t := range l.tasks {
+		if t.Status == PURGE_RUNNING && t.DomainProject() == task.DomainProject() {
+			return fmt.Errorf("purge task %s of %s is running", t.Id, t.DomainProject())
+		}
+	}
+	l.tasks = append(l.tasks, task)
+	if len(l.tasks) > MAX_PURGE_TASKS {
+		for i, t := range l.tasks {
+			if t.Status != PURGE_RUNNING {
+				l.tasks = append(l.tasks[:i], l.tasks[i+1:]...)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Update 在锁内修改任务，读取方得到的是副本
+func (l *purgeTaskList) Update(task *PurgeTask, f func(t *PurgeTask)) {
+	l.lock.Lock()
+	f(task)
+	l.lock.Unlock()
+}
+
+func (l *purgeTaskList) copy(t *PurgeTask) *PurgeTask {
+	c := *t
+	if t.Report != nil {
+		r := *t.Report
+		r.Targets = make([]*PurgeTarget, 0, len(t.Report.Targets))
+		for _, target := range t.Report.Targets {
+			tc := *target
+			r.Targets = append(r.Targets, &tc)
+		}
+		c.Report = &r
+	}
+	return &c
+}
+
+func (l *purgeTaskList) Get(id string) *PurgeTask {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	for _, t := range l.tasks {
+		if t.Id == id {
+			return l.copy(t)
+		}
+	}
+	return nil
+}
+
+// List 返回最近的任务，新的在前，不包含报告
+func (l *purgeTaskList) List() []*PurgeTask {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	tasks := make([]*PurgeTask, 0, len(l.tasks))
+	for i := len(l.tasks) - 1; i >= 0; i-- {
+		c := *l.tasks[i]
+		c.Report = nil
+		tasks = append(tasks, &c)
+	}
+	return tasks
+}
+
+func tenantPrefix(root string) string {
+	return root + "/"
+}
+
+// PurgeTargets 租户数据所在的key前缀，以及不在租户前缀下但属于租户的key；
+// 前缀以/结尾，避免default/default匹配到default/default2。domain的key不清除，
+// 可能还有其他project；审计日志不在注册中心中，由purgeAuditLog单独清除
+func PurgeTargets(ctx context.Context, domain, project string) ([]*PurgeTarget, error) {
+	domainProject := util.StringJoin([]string{domain, project}, "/")
+	targets := tenantTargets(domain, project)
+
+	// API密钥的索引和共享服务的标记不在租户前缀下
+	resp, err := backend.Registry().Do(ctx, registry.GET,
+		registry.WithStrKey(tenantPrefix(apt.GetApiKeyRootKey(domainProject))),
+		registry.WithPrefix(),
+		registry.WithKeyOnly())
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range resp.Kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		keyId := key[strings.LastIndex(key, "/")+1:]
+		targets = append(targets, &PurgeTarget{Name: "apiKeyIndexes", Key: apt.GenerateApiKeyIndexKey(keyId)})
+	}
+	shared, err := serviceUtil.GetSharedServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range shared {
+		if s.DomainProject() == domainProject {
+			targets = append(targets, &PurgeTarget{Name: "sharedServices",
+				Key: apt.GenerateSharedServiceKey(s.AppId, s.ServiceName)})
+		}
+	}
+	return targets, nil
+}
+
+// tenantTargets 租户前缀下的数据，以及以domain/project为key、不在租户前缀下的数据
+func tenantTargets(domain, project string) []*PurgeTarget {
+	domainProject := util.StringJoin([]string{domain, project}, "/")
+	targets := make([]*PurgeTarget, 0, len(tenantKeyRoots)+4)
+	for _, r := range tenantKeyRoots {
+		targets = append(targets, &PurgeTarget{Name: r.name, Key: tenantPrefix(r.root(domainProject)), Prefix: true})
+	}
+	return append(targets,
+		&PurgeTarget{Name: "project", Key: apt.GenerateProjectKey(domain, project)},
+		&PurgeTarget{Name: "leasePolicy", Key: apt.GenerateLeasePolicyKey(domainProject)},
+		&PurgeTarget{Name: "discoveryScope", Key: apt.GenerateDiscoveryScopeKey(domainProject)},
+		&PurgeTarget{Name: "dependencyGraphEdges", Key: apt.GenerateDependencyGraphEdgesKey(domainProject)})
+}
+
+func countTarget(ctx context.Context, t *PurgeTarget) (int64, error) {
+	opts := []registry.PluginOpOption{registry.WithStrKey(t.Key), registry.WithCountOnly()}
+	if t.Prefix {
+		opts = append(opts, registry.WithPrefix())
+	}
+	resp, err := backend.Registry().Do(ctx, append([]registry.PluginOpOption{registry.GET}, opts...)...)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
+func deleteTarget(ctx context.Context, t *PurgeTarget) error {
+	opts := []registry.PluginOpOption{registry.WithStrKey(t.Key)}
+	if t.Prefix {
+		opts = append(opts, registry.WithPrefix())
+	}
+	_, err := backend.Registry().Do(ctx, append([]registry.PluginOpOption{registry.DEL}, opts...)...)
+	return err
+}
+
+// CheckPurgeTenant 不允许清除service center自身注册所在的租户
+func CheckPurgeTenant(domain, project string) error {
+	if len(domain) == 0 || len(project) == 0 {
+		return errors.New("domain and project are required")
+	}
+	if strings.Contains(domain, "/") || strings.Contains(project, "/") {
+		return errors.New("invalid domain or project")
+	}
+	if apt.IsDefaultDomainProject(util.StringJoin([]string{domain, project}, "/")) {
+		return errors.New("can not purge the tenant of service center itself")
+	}
+	return nil
+}
+
+// PurgeTenant 创建租户数据清除任务并在后台执行
+func PurgeTenant(domain, project, operator string) (*PurgeTask, error) {
+	if err := CheckPurgeTenant(domain, project); err != nil {
+		return nil, err
+	}
+	task := &PurgeTask{
+		Id:        strconv.FormatInt(time.Now().UnixNano(), 10),
+		Domain:    domain,
+		Project:   project,
+		Status:    PURGE_RUNNING,
+		Operator:  operator,
+		StartTime: strconv.FormatInt(time.Now().Unix(), 10),
+	}
+	if err := purgeTasks.Add(task); err != nil {
+		return nil, err
+	}
+	util.Go(func(_ <-chan struct{}) {
+		runPurge(context.Background(), task)
+	})
+	return purgeTasks.Get(task.Id), nil
+}
+
+func GetPurgeTask(id string) *PurgeTask {
+	return purgeTasks.Get(id)
+}
+
+func GetPurgeTasks() []*PurgeTask {
+	return purgeTasks.List()
+}
+
+func runPurge(ctx context.Context, task *PurgeTask) {
+	report, err := purge(ctx, task)
+	purgeTasks.Update(task, func(t *PurgeTask) {
+		t.Report = report
+		t.EndTime = strconv.FormatInt(time.Now().Unix(), 10)
+		switch {
+		case err != nil:
+			t.Status, t.Error = PURGE_FAILED, err.Error()
+		case !report.Verified:
+			t.Status, t.Error = PURGE_FAILED, "keys remain after purge"
+		default:
+			t.Status = PURGE_SUCCEEDED
+		}
+	})
+	if err != nil {
+		util.Logger().Errorf(err, "purge task %s of %s failed, operator %s",
+			task.Id, task.DomainProject(), task.Operator)
+		return
+	}
+	util.Logger().Warnf(nil, "purge task %s of %s finished in %d round(s), verified %v, operator %s",
+		task.Id, task.DomainProject(), report.Rounds, report.Verified, task.Operator)
+}
+
+// purge 每轮统计、删除并重新统计所有目标，有残留时进行下一轮；进度按目标计数
+func purge(ctx context.Context, task *PurgeTask) (*PurgeReport, error) {
+	targets, err := PurgeTargets(ctx, task.Domain, task.Project)
+	if err != nil {
+		return nil, err
+	}
+	report := &PurgeReport{}
+	purgeTasks.Update(task, func(t *PurgeTask) {
+		t.Total = len(targets)
+		t.Report = report
+	})
+
+	pending := targets
+	for round := 1; round <= MAX_PURGE_ROUNDS && len(pending) > 0; round++ {
+		var remains []*PurgeTarget
+		for _, target := range pending {
+			before, err := countTarget(ctx, target)
+			if err != nil {
+				return report, err
+			}
+			if before > 0 {
+				if err := deleteTarget(ctx, target); err != nil {
+					return report, err
+				}
+			}
+			after, err := countTarget(ctx, target)
+			if err != nil {
+				return report, err
+			}
+			purgeTasks.Update(task, func(t *PurgeTask) {
+				if round == 1 {
+					target.Scanned = before
+					report.Targets = append(report.Targets, target)
+					t.Done++
+				}
+				if before > after {
+					target.Deleted += before - after
+				}
+				target.Remaining = after
+			})
+			if after > 0 {
+				remains = append(remains, target)
+			}
+		}
+		purgeTasks.Update(task, func(t *PurgeTask) {
+			report.Rounds = round
+		})
+		pending = remains
+	}
+	purgeTasks.Update(task, func(t *PurgeTask) {
+		report.Verified = len(pending) == 0
+	})
+	return report, purgeAuditLog(task, report)
+}
+
+// purgeAuditLog 清除审计插件中租户的记录，只能清除执行节点上的审计日志
+func purgeAuditLog(task *PurgeTask, report *PurgeReport) error {
+	purger, ok := plugin.Plugins().Instance(plugin.AUDIT_LOG).(auditlog.Purger)
+	if !ok {
+		return nil
+	}
+	n, err := purger.Purge(task.DomainProject())
+	if err != nil {
+		return err
+	}
+	purgeTasks.Update(task, func(t *PurgeTask) {
+		report.AuditRecords = &n
+	})
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package admin
+
+import (
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCheckPurgeTenant(t *testing.T) {
+	if err := CheckPurgeTenant("tenant", "project"); err != nil {
+		t.Fatalf("TestCheckPurgeTenant failed, %s", err.Error())
+	}
+	for _, c := range [][2]string{{"", "project"}, {"tenant", ""}, {"a/b", "c"}, {"default", "default"}} {
+		if err := CheckPurgeTenant(c[0], c[1]); err == nil {
+			t.Fatalf("TestCheckPurgeTenant %v should fail", c)
+		}
+	}
+}
+
+func TestPurgeTaskList(t *testing.T) {
+	l := &purgeTaskList{}
+	a := &PurgeTask{Id: "1", Domain: "tenant", Project: "project", Status: PURGE_RUNNING}
+	if err := l.Add(a); err != nil {
+		t.Fatalf("TestPurgeTaskList failed, %s", err.Error())
+	}
+	if err := l.Add(&PurgeTask{Id: "2", Domain: "tenant", Project: "project", Status: PURGE_RUNNING}); err == nil {
+		t.Fatalf("TestPurgeTaskList should refuse the running tenant")
+	}
+
+	l.Update(a, func(task *PurgeTask) {
+		task.Status = PURGE_SUCCEEDED
+		task.Report = &PurgeReport{Targets: []*PurgeTarget{{Name: "services", Scanned: 1}}}
+	})
+	got := l.Get("1")
+	if got == nil || got.Status != PURGE_SUCCEEDED || got.Report == nil || got.Report.Targets[0] == a.Report.Targets[0] {
+		t.Fatalf("TestPurgeTaskList should return a copy, %v", got)
+	}
+
+	for i := 0; i < MAX_PURGE_TASKS+5; i++ {
+		l.Add(&PurgeTask{Id: "p" + strconv.Itoa(i), Domain: "tenant", Project: strconv.Itoa(i), Status: PURGE_FAILED})
+	}
+	tasks := l.List()
+	if len(tasks) != MAX_PURGE_TASKS || tasks[0].Report != nil {
+		t.Fatalf("TestPurgeTaskList failed, %d tasks", len(tasks))
+	}
+}
+
+func TestTenantTargets(t *testing.T) {
+	targets := tenantTargets("tenant", "project")
+	keys := make(map[string]string, len(targets))
+	for _, target := range targets {
+		if target.Prefix && !strings.HasSuffix(target.Key, "/tenant/project/") {
+			t.Fatalf("TestTenantTargets failed, prefix %s of %s is not the tenant", target.Key, target.Name)
+		}
+		if !target.Prefix && !strings.Contains(target.Key, "tenant/project") {
+			t.Fatalf("TestTenantTargets failed, key %s of %s is not the tenant", target.Key, target.Name)
+		}
+		keys[target.Name] = target.Key
+	}
+	if len(keys) != len(tenantKeyRoots)+4 {
+		t.Fatalf("TestTenantTargets failed, %d targets, duplicate names", len(targets))
+	}
+	expects := map[string]string{
+		"services":             tenantPrefix(apt.GetServiceRootKey("tenant/project")),
+		"project":              apt.GenerateProjectKey("tenant", "project"),
+		"leasePolicy":          apt.GenerateLeasePolicyKey("tenant/project"),
+		"discoveryScope":       apt.GenerateDiscoveryScopeKey("tenant/project"),
+		"dependencyGraphEdges": apt.GenerateDependencyGraphEdgesKey("tenant/project"),
+	}
+	for name, key := range expects {
+		if keys[name] != key {
+			t.Fatalf("TestTenantTargets failed, %s is %s, expect %s", name, keys[name], key)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package admin
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"strings"
+)
+
+const (
+	KEY_SCOPE_TENANT = "tenant"
+	KEY_SCOPE_GLOBAL = "global"
+)
+
+// KeyPrefix 注册中心中的一类数据，tenant范围的前缀后接domain/project
+type KeyPrefix struct {
+	Name        string `json:"name"`
+	Prefix      string `json:"prefix"`
+	Scope       string `json:"scope"`
+	Description string `json:"description"`
+	// false表示单个key
+	IsPrefix bool `json:"isPrefix"`
+}
+
+type tenantKeyRoot struct {
+	name        string
+	root        func(domainProject string) string
+	description string
+}
+
+// tenantKeyRoots 租户数据所在的前缀，新增租户数据时需要加入，租户清除也按此清除
+var tenantKeyRoots = []tenantKeyRoot{
+	{"services", apt.GetServiceRootKey, "microservice definitions"},
+	{"serviceIndexes", apt.GetServiceIndexRootKey, "service key to serviceId indexes"},
+	{"serviceAliases", apt.GetServiceAliasRootKey, "service alias to serviceId indexes"},
+	{"serviceNameIndexes", apt.GetServiceNameIndexRootKey, "service name to serviceId indexes"},
+	{"rules", apt.GetServiceRuleRootKey, "black/white list rules"},
+	{"ruleIndexes", apt.GetServiceRuleIndexRootKey, "rule attribute and pattern indexes"},
+	{"tags", apt.GetServiceTagRootKey, "service tags"},
+	{"policies", apt.GetServicePolicyRootKey, "service policies"},
+	{"schemas", apt.GetServiceSchemaRootKey, "schema contents"},
+	{"schemaSummaries", apt.GetServiceSchemaSummaryRootKey, "schema summaries"},
+	{"dependencyRules", apt.GetServiceDependencyRuleRootKey, "declared dependency rules"},
+	{"dependencies", apt.GetServiceDependencyRootKey, "resolved dependencies between serviceIds"},
+	{"dependencyApprovals", apt.GetDependencyApprovalRootKey, "dependency approvals"},
+	{"dependencyUsages", apt.GetDependencyUsageRootKey, "discovery usages of dependencies"},
+	{"dependencyTraffics", apt.GetDependencyTrafficRootKey, "observed traffic of dependencies, bound to leases"},
+	{"delegations", apt.GetDelegationRootKey, "delegated controllers of services"},
+	{"delegationAudits", apt.GetDelegationAuditRootKey, "delegation audit records"},
+	{"sharedDefinitions", apt.GetSharedDefinitionRootKey, "shared schema definitions"},
+	{"deleteJournals", apt.GetServiceDeleteJournalRootKey, "journals of services being deleted"},
+	{"instances", apt.GetInstanceRootKey, "instance definitions, bound to leases"},
+	{"instanceIndexes", apt.GetInstanceIndexRootKey, "instanceId to serviceId indexes"},
+	{"instanceLeases", apt.GetInstanceLeaseRootKey, "lease ids of instances"},
+	{"staticInstances", apt.GetStaticInstanceRootKey, "instances registered without heartbeats"},
+	{"pendingInstances", apt.GetPendingInstanceRootKey, "instances waiting for admission"},
+	{"instanceStates", apt.GetInstanceStateRootKey, "states reported by heartbeats"},
+	{"instanceHistories", apt.GetInstanceHistoryRootKey, "sampled healthy instance counts of services"},
+	{"endpoints", apt.GetEndpointsRootKey, "endpoint indexes of instances"},
+	{"snapshots", apt.GetSnapshotRootKey, "registry snapshots"},
+	{"apiKeys", apt.GetApiKeyRootKey, "api keys"},
+	{"watchSubscriptions", apt.GetWatchSubscriptionRootKey, "persisted watch subscriptions"},
+}
+
+// KeyLayout 返回service center管理的所有key前缀，tenant范围的前缀后需接domain/project/；
+// 前缀以/结尾，避免services匹配到其他以services开头的前缀
+func KeyLayout() []*KeyPrefix {
+	layout := make([]*KeyPrefix, 0, len(tenantKeyRoots)+12)
+	for _, r := range tenantKeyRoots {
+		layout = append(layout, &KeyPrefix{Name: r.name, Prefix: r.root(""),
+			Scope: KEY_SCOPE_TENANT, Description: r.description, IsPrefix: true})
+	}
+	root := apt.GetRootKey()
+	globals := []struct {
+		name        string
+		prefix      string
+		description string
+	}{
+		{"domains", tenantPrefix(apt.GetDomainRootKey()), "registered domains"},
+		{"projects", apt.GetProjectRootKey(""), "registered projects of domains"},
+		{"apiKeyIndexes", tenantPrefix(util.StringJoin([]string{root, apt.REGISTRY_APIKEY_INDEX}, "/")),
+			"api key id to tenant indexes"},
+		{"sharedServices", tenantPrefix(apt.GetSharedServiceRootKey()), "services shared to all tenants"},
+		{"leasePolicies", tenantPrefix(apt.GetLeasePolicyRootKey()), "lease policies of tenants"},
+		{"discoveryScopes", tenantPrefix(apt.GetDiscoveryScopeRootKey()), "cross-environment discovery scopes of tenants"},
+		{"featureFlags", tenantPrefix(apt.GetFeatureFlagRootKey()), "feature flags"},
+		{"peers", tenantPrefix(apt.GetPeerRootKey()), "peer clusters and their CA bundles"},
+		{"migrations", tenantPrefix(apt.GetMigrationRootKey()), "data layout version and migration records"},
+		{"storageMigration", tenantPrefix(apt.GetStorageMigrationRootKey()), "state of the storage migration"},
+		{"leaders", tenantPrefix(apt.GetLeaderRootKey()), "leaders of the singleton background jobs, bound to leases"},
+		{"metrics", tenantPrefix(apt.GetMetricsRootKey()), "persisted metrics"},
+		{"dependencyGraphEdges", tenantPrefix(apt.GetDependencyGraphEdgesRootKey()),
+			"latest dependency graph edges of tenants"},
+	}
+	for _, g := range globals {
+		layout = append(layout, &KeyPrefix{Name: g.name, Prefix: g.prefix,
+			Scope: KEY_SCOPE_GLOBAL, Description: g.description, IsPrefix: true})
+	}
+	return append(layout, &KeyPrefix{Name: "system", Prefix: apt.GetSystemKey(),
+		Scope: KEY_SCOPE_GLOBAL, Description: "version of the service center data", IsPrefix: false})
+}
+
+// KeyPrefixUsage 前缀下key的数量、大小和修改版本范围，大小为key和value的字节数
+type KeyPrefixUsage struct {
+	KeyPrefix
+	Count             int64  `json:"count"`
+	Leased            int64  `json:"leased"`
+	KeyBytes          int64  `json:"keyBytes"`
+	ValueBytes        int64  `json:"valueBytes"`
+	OldestModRevision int64  `json:"oldestModRevision,omitempty"`
+	OldestKey         string `json:"oldestKey,omitempty"`
+	NewestModRevision int64  `json:"newestModRevision,omitempty"`
+	NewestKey         string `json:"newestKey,omitempty"`
+}
+
+// StorageUsage 未指定租户时，Unclassified为根前缀下不属于任何已知前缀的key数
+type StorageUsage struct {
+	Tenant       string            `json:"tenant,omitempty"`
+	Revision     int64             `json:"revision"`
+	Count        int64             `json:"count"`
+	Bytes        int64             `json:"bytes"`
+	Unclassified int64             `json:"unclassified"`
+	Prefixes     []*KeyPrefixUsage `json:"prefixes"`
+}
+
+// matchKeyPrefix 返回key所属的最长前缀，前缀之间存在包含关系(如metrics)
+func matchKeyPrefix(layout []*KeyPrefix, key string) *KeyPrefix {
+	var match *KeyPrefix
+	for _, p := range layout {
+		if p.IsPrefix && !strings.HasPrefix(key, p.Prefix) {
+			continue
+		}
+		if !p.IsPrefix && key != p.Prefix {
+			continue
+		}
+		if match == nil || len(p.Prefix) > len(match.Prefix) {
+			match = p
+		}
+	}
+	return match
+}
+
+// Add 计入一个key，调用方需保证key不属于更长的前缀
+func (u *KeyPrefixUsage) Add(key string, valueLen int, lease, modRevision int64) {
+	u.Count++
+	if lease != 0 {
+		u.Leased++
+	}
+	u.KeyBytes += int64(len(key))
+	u.ValueBytes += int64(valueLen)
+	if u.OldestModRevision == 0 || modRevision < u.OldestModRevision {
+		u.OldestModRevision, u.OldestKey = modRevision, key
+	}
+	if modRevision > u.NewestModRevision {
+		u.NewestModRevision, u.NewestKey = modRevision, key
+	}
+}
+
+// CheckStorageTenant tenant为空表示所有租户，否则为domain/project
+func CheckStorageTenant(tenant string) error {
+	if len(tenant) == 0 {
+		return nil
+	}
+	arr := strings.Split(tenant, "/")
+	if len(arr) != 2 || len(arr[0]) == 0 || len(arr[1]) == 0 {
+		return fmt.Errorf("invalid tenant '%s', expect domain/project", tenant)
+	}
+	return nil
+}
+
+// GetStorageUsage 直接从注册中心读取各前缀的key进行统计，key较多时开销较大；
+// 指定租户时只统计tenant范围的前缀，names为空时统计所有前缀
+func GetStorageUsage(ctx context.Context, tenant string, names []string) (*StorageUsage, error) {
+	if err := CheckStorageTenant(tenant); err != nil {
+		return nil, err
+	}
+	layout := KeyLayout()
+	filter := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		filter[name] = struct{}{}
+	}
+	usage := &StorageUsage{Tenant: tenant, Prefixes: make([]*KeyPrefixUsage, 0, len(layout))}
+	for _, p := range layout {
+		if _, ok := filter[p.Name]; len(filter) > 0 && !ok {
+			continue
+		}
+		if len(tenant) > 0 && p.Scope != KEY_SCOPE_TENANT {
+			continue
+		}
+		u := &KeyPrefixUsage{KeyPrefix: *p}
+		key := p.Prefix
+		if len(tenant) > 0 {
+			key = tenantPrefix(p.Prefix + tenant)
+			u.Prefix = key
+		}
+		opts := []registry.PluginOpOption{registry.WithStrKey(key)}
+		if p.IsPrefix {
+			opts = append(opts, registry.WithPrefix())
+		}
+		resp, err := backend.Registry().Do(ctx, append([]registry.PluginOpOption{registry.GET}, opts...)...)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Revision > usage.Revision {
+			usage.Revision = resp.Revision
+		}
+		for _, kv := range resp.Kvs {
+			k := util.BytesToStringWithNoCopy(kv.Key)
+			if matchKeyPrefix(layout, k) != p {
+				continue
+			}
+			u.Add(k, len(kv.Value), kv.Lease, kv.ModRevision)
+		}
+		usage.Count += u.Count
+		usage.Bytes += u.KeyBytes + u.ValueBytes
+		usage.Prefixes = append(usage.Prefixes, u)
+	}
+	if len(tenant) > 0 || len(filter) > 0 {
+		return usage, nil
+	}
+
+	resp, err := backend.Registry().Do(ctx, registry.GET,
+		registry.WithStrKey(tenantPrefix(apt.GetRootKey())),
+		registry.WithPrefix(),
+		registry.WithCountOnly())
+	if err != nil {
+		return nil, err
+	}
+	if resp.Count > usage.Count {
+		usage.Unclassified = resp.Count - usage.Count
+	}
+	return usage, nil
+}
@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package admin
+
+import (
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"strings"
+	"testing"
+)
+
+func TestKeyLayout(t *testing.T) {
+	layout := KeyLayout()
+	names := make(map[string]struct{}, len(layout))
+	prefixes := make(map[string]struct{}, len(layout))
+	for _, p := range layout {
+		if _, ok := names[p.Name]; ok {
+			t.Fatalf("TestKeyLayout failed, duplicate name %s", p.Name)
+		}
+		if _, ok := prefixes[p.Prefix]; ok {
+			t.Fatalf("TestKeyLayout failed, duplicate prefix %s", p.Prefix)
+		}
+		if p.IsPrefix && !strings.HasSuffix(p.Prefix, "/") {
+			t.Fatalf("TestKeyLayout failed, prefix %s does not end with /", p.Prefix)
+		}
+		names[p.Name], prefixes[p.Prefix] = struct{}{}, struct{}{}
+	}
+
+	cases := map[string]string{
+		apt.GenerateServiceKey("default/default", "1"):                "services",
+		apt.GenerateInstanceKey("default/default", "1", "2"):          "instances",
+		apt.GenerateWatchSubscriptionKey("default/default", "1", "a"): "watchSubscriptions",
+		apt.GenerateMetricsKey("name", "1", "default"):                "metrics",
+		apt.GenerateDependencyGraphEdgesKey("default/default"):        "dependencyGraphEdges",
+		apt.GetSystemKey(): "system",
+	}
+	for key, name := range cases {
+		p := matchKeyPrefix(layout, key)
+		if p == nil || p.Name != name {
+			t.Fatalf("TestKeyLayout failed, %s should match %s, got %v", key, name, p)
+		}
+	}
+	if p := matchKeyPrefix(layout, apt.GetRootKey()+"/unknown/a"); p != nil {
+		t.Fatalf("TestKeyLayout failed, unknown key matched %s", p.Name)
+	}
+}
+
+func TestKeyPrefixUsage(t *testing.T) {
+	u := &KeyPrefixUsage{}
+	u.Add("/a", 3, 0, 5)
+	u.Add("/b", 2, 1, 3)
+	u.Add("/c", 1, 0, 8)
+	if u.Count != 3 || u.Leased != 1 || u.KeyBytes != 6 || u.ValueBytes != 6 {
+		t.Fatalf("TestKeyPrefixUsage failed, %+v", u)
+	}
+	if u.OldestModRevision != 3 || u.OldestKey != "/b" || u.NewestModRevision != 8 || u.NewestKey != "/c" {
+		t.Fatalf("TestKeyPrefixUsage failed, %+v", u)
+	}
+}
+
+func TestCheckStorageTenant(t *testing.T) {
+	for _, tenant := range []string{"", "default/default"} {
+		if err := CheckStorageTenant(tenant); err != nil {
+			t.Fatalf("TestCheckStorageTenant failed, %s", err.Error())
+		}
+	}
+	for _, tenant := range []string{"default", "default/", "/default", "a/b/c"} {
+		if err := CheckStorageTenant(tenant); err == nil {
+			t.Fatalf("TestCheckStorageTenant %s should fail", tenant)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package alarm
+
+import (
+	roa "github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	"github.com/astaxie/beego"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var (
+	center        *Center
+	checker       *InstanceChecker
+	systemChecker *SystemChecker
+)
+
+func init() {
+	center = NewCenter()
+	center.HistorySize = beego.AppConfig.DefaultInt("alarm_history_size", DEFAULT_HISTORY_SIZE)
+	checker = NewInstanceChecker(center)
+	systemChecker = NewSystemChecker(center)
+	systemChecker.QuotaPercent = beego.AppConfig.DefaultFloat("alarm_quota_percent", DEFAULT_QUOTA_PERCENT)
+	systemChecker.CertExpireWarning = time.Duration(beego.AppConfig.DefaultInt64("alarm_cert_expire_days",
+		int64(DEFAULT_CERT_EXPIRE_WARNING/(24*time.Hour)))) * 24 * time.Hour
+	for _, h := range checker.Handlers() {
+		store.AddEventHandler(h)
+	}
+
+	if addr := beego.AppConfig.String("alarm_webhook_url"); len(addr) > 0 {
+		if f := newWebhook(addr); f != nil {
+			center.AddHandleFunc(f)
+		}
+	}
+
+	registerREST()
+}
+
+func registerREST() {
+	roa.RegisterServent(&AlarmServiceControllerV4{})
+}
+
+func GetCenter() *Center {
+	return center
+}
+
+func Run() {
+	util.Go(checker.run)
+	util.Go(systemChecker.run)
+}
+
+// newWebhook 告警产生和清除时POST到webhook
+func newWebhook(addr string) AlarmHandleFunc {
+	u, err := url.Parse(addr)
+	if err != nil || len(u.Host) == 0 {
+		util.Logger().Errorf(err, "invalid alarm_webhook_url '%s'", addr)
+		return nil
+	}
+	client, err := roa.GetClient(u.Scheme)
+	if err != nil {
+		util.Logger().Errorf(err, "create alarm webhook client failed")
+		return nil
+	}
+	return func(evt *AlarmEvent) {
+		resp, err := client.HttpDo(http.MethodPost, addr, nil, evt)
+		if err != nil {
+			util.Logger().Errorf(err, "post alarm %s event to %s failed", evt.Action, addr)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			util.Logger().Errorf(nil, "post alarm %s event to %s failed, status code %d",
+				evt.Action, addr, resp.StatusCode)
+		}
+	}
+}
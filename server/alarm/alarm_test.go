@@ -0,0 +1,328 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package alarm_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/alarm"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/admission/buildin"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/quota/buildin"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/etcd"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/uuid/dynamic"
+	"github.com/apache/incubator-servicecomb-service-center/server/service"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	sctls "github.com/apache/incubator-servicecomb-service-center/server/tls"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+)
+
+func getContext() context.Context {
+	ctx := context.TODO()
+	ctx = util.SetContext(ctx, "domain", "default")
+	ctx = util.SetContext(ctx, "project", "default")
+	ctx = util.SetContext(ctx, "noCache", "1")
+	return ctx
+}
+
+func TestCenter(t *testing.T) {
+	var events []*alarm.AlarmEvent
+	c := alarm.NewCenter()
+	c.AddHandleFunc(func(evt *alarm.AlarmEvent) {
+		events = append(events, evt)
+	})
+
+	c.Raise(&alarm.Alarm{Id: "a", DomainProject: "default/default", Message: "a"})
+	c.Raise(&alarm.Alarm{Id: "a", DomainProject: "default/default", Message: "b"})
+	c.Raise(&alarm.Alarm{Id: "b", DomainProject: "other/default"})
+	if len(events) != 2 || events[0].Action != alarm.ACTION_RAISE {
+		t.Fatalf("TestCenter failed, %v", events)
+	}
+	active := c.Active("default/default")
+	if len(active) != 1 || active[0].Message != "b" {
+		t.Fatalf("TestCenter failed, %v", active)
+	}
+	if len(c.Active("")) != 2 {
+		t.Fatalf("TestCenter failed, %v", c.Active(""))
+	}
+
+	c.Clear("a")
+	c.Clear("a")
+	if len(events) != 3 || events[2].Action != alarm.ACTION_CLEAR || len(c.Active("default/default")) != 0 {
+		t.Fatalf("TestCenter failed, %v", events)
+	}
+}
+
+func TestInstanceChecker_Check(t *testing.T) {
+	serviceResource, instanceResource := service.AssembleResources()
+
+	respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+		Service: &pb.MicroService{
+			AppId:       "alarm_group",
+			ServiceName: "alarm_service",
+			Version:     "1.0.0",
+			Level:       "BACK",
+			Status:      pb.MS_UP,
+			Properties: map[string]string{
+				pb.PROP_MIN_HEALTHY_INSTANCES: "1",
+			},
+		},
+	})
+	if err != nil || respCreate.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("create service failed, %v", respCreate.Response)
+	}
+	serviceId := respCreate.ServiceId
+
+	c := alarm.NewCenter()
+	checker := alarm.NewInstanceChecker(c)
+	checker.Mark("default/default", serviceId)
+	checker.Check(getContext())
+	active := c.Active("default/default")
+	if len(active) != 1 || active[0].ServiceId != serviceId || active[0].Type != alarm.ALARM_INSTANCE_BELOW_THRESHOLD {
+		t.Fatalf("TestInstanceChecker_Check failed, %v", active)
+	}
+
+	respRegister, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+		Instance: &pb.MicroServiceInstance{
+			ServiceId: serviceId,
+			Endpoints: []string{"alarm:127.0.0.1:8080"},
+			HostName:  "UT-HOST",
+			Status:    pb.MSI_UP,
+		},
+	})
+	if err != nil || respRegister.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("register instance failed, %v", respRegister.Response)
+	}
+	checker.Mark("default/default", serviceId)
+	checker.Check(getContext())
+	if len(c.Active("default/default")) != 0 {
+		t.Fatalf("TestInstanceChecker_Check failed, alarm not cleared")
+	}
+
+	// 不检查未配置阈值的服务
+	if alarm.MinHealthyInstances(&pb.MicroService{}) != 0 ||
+		alarm.MinHealthyInstances(&pb.MicroService{Properties: map[string]string{pb.PROP_MIN_HEALTHY_INSTANCES: "x"}}) != 0 {
+		t.Fatalf("TestInstanceChecker_Check failed")
+	}
+}
+
+func TestCenter_Acknowledge(t *testing.T) {
+	var events []*alarm.AlarmEvent
+	c := alarm.NewCenter()
+	c.HistorySize = 3
+	c.AddHandleFunc(func(evt *alarm.AlarmEvent) {
+		events = append(events, evt)
+	})
+
+	if c.Acknowledge("a", time.Minute, "op") {
+		t.Fatalf("TestCenter_Acknowledge failed, acknowledged an inactive alarm")
+	}
+
+	c.Raise(&alarm.Alarm{Id: "a"})
+	if !c.Acknowledge("a", time.Minute, "op") || !c.Active("")[0].Acknowledged {
+		t.Fatalf("TestCenter_Acknowledge failed")
+	}
+
+	// 静默期内再次产生不通知
+	c.Clear("a")
+	c.Raise(&alarm.Alarm{Id: "a"})
+	if len(events) != 2 || !c.Active("")[0].Acknowledged {
+		t.Fatalf("TestCenter_Acknowledge failed, %v", events)
+	}
+
+	// 静默过期后重新通知
+	c.ExpireSilences(time.Now().Add(2 * time.Minute))
+	if len(events) != 3 || events[2].Action != alarm.ACTION_RAISE || c.Active("")[0].Acknowledged {
+		t.Fatalf("TestCenter_Acknowledge failed, %v", events)
+	}
+
+	history := c.History(0)
+	if len(history) != 3 || history[2].Action != alarm.ACTION_RAISE || history[0].Action != alarm.ACTION_CLEAR {
+		t.Fatalf("TestCenter_Acknowledge failed, history %v", history)
+	}
+	if h := c.History(1); len(h) != 1 || h[0] != history[2] {
+		t.Fatalf("TestCenter_Acknowledge failed, history %v", h)
+	}
+}
+
+func TestSystemChecker_Check(t *testing.T) {
+	c := alarm.NewCenter()
+	checker := alarm.NewSystemChecker(c)
+	checker.QuotaPercent = 0
+	checker.Check(getContext(), time.Now())
+
+	types := map[string]bool{}
+	for _, a := range c.Active("") {
+		types[a.Id] = true
+	}
+	if types[alarm.ALARM_BACKEND_UNAVAILABLE] || types[alarm.ALARM_SELF_PRESERVATION] {
+		t.Fatalf("TestSystemChecker_Check failed, %v", types)
+	}
+	if !types[alarm.GenerateAlarmId(alarm.ALARM_QUOTA_NEAR_LIMIT, "SERVICE")] {
+		t.Fatalf("TestSystemChecker_Check failed, quota alarm not raised, %v", types)
+	}
+	if types[alarm.GenerateAlarmId(alarm.ALARM_QUOTA_EXCEEDED, "SERVICE")] {
+		t.Fatalf("TestSystemChecker_Check failed, quota is not exceeded, %v", types)
+	}
+
+	checker.QuotaPercent = 1
+	checker.Check(getContext(), time.Now())
+	if len(c.Active("")) != 0 {
+		t.Fatalf("TestSystemChecker_Check failed, %v", c.Active(""))
+	}
+}
+
+func TestSystemChecker_CheckGuardrail(t *testing.T) {
+	c := alarm.NewCenter()
+	checker := alarm.NewSystemChecker(c)
+	checker.QuotaPercent = 1
+	g := serviceUtil.GetGuardrail()
+	defer func() {
+		g.MaxKeys = 0
+		g.Update(0, 0)
+	}()
+
+	g.MaxKeys = 1
+	checker.Check(getContext(), time.Now())
+	types := map[string]bool{}
+	for _, a := range c.Active("") {
+		types[a.Id] = true
+	}
+	if !types[alarm.ALARM_STORAGE_NEAR_LIMIT] || !types[alarm.ALARM_STORAGE_WRITES_REJECTED] {
+		t.Fatalf("TestSystemChecker_CheckGuardrail failed, %v", types)
+	}
+	if g.Level() != serviceUtil.GUARD_CRITICAL {
+		t.Fatalf("TestSystemChecker_CheckGuardrail failed, level %s", g.Level())
+	}
+
+	g.MaxKeys = 1 << 40
+	checker.Check(getContext(), time.Now())
+	if len(c.Active("")) != 0 || g.Level() != serviceUtil.GUARD_NORMAL {
+		t.Fatalf("TestSystemChecker_CheckGuardrail failed, %v", c.Active(""))
+	}
+}
+
+// writeServerCert 在SSL_ROOT下生成notAfter过期的自签名服务端证书
+func writeServerCert(t *testing.T, cn string, notAfter time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(sctls.GetSSLPath("server.cer"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(sctls.GetSSLPath("server_key.pem"),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSystemChecker_CheckCertificates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sc-alarm-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, enabled, verify := os.Getenv("SSL_ROOT"), core.ServerInfo.Config.SslEnabled, core.ServerInfo.Config.SslVerifyPeer
+	defer func() {
+		os.Setenv("SSL_ROOT", root)
+		core.ServerInfo.Config.SslEnabled, core.ServerInfo.Config.SslVerifyPeer = enabled, verify
+		os.RemoveAll(dir)
+	}()
+	os.Setenv("SSL_ROOT", dir)
+	core.ServerInfo.Config.SslEnabled, core.ServerInfo.Config.SslVerifyPeer = true, false
+
+	now := time.Now()
+	writeServerCert(t, "expiring", now.Add(10*24*time.Hour))
+	if err := sctls.Reload(); err != nil {
+		t.Fatalf("TestSystemChecker_CheckCertificates reload failed, %s", err)
+	}
+
+	c := alarm.NewCenter()
+	checker := alarm.NewSystemChecker(c)
+	checker.QuotaPercent = 1
+	id := alarm.GenerateAlarmId(alarm.ALARM_CERTIFICATE_EXPIRING, sctls.GetSSLPath("server.cer"), "expiring")
+	active := func() map[string]*alarm.Alarm {
+		m := map[string]*alarm.Alarm{}
+		for _, a := range c.Active("") {
+			m[a.Id] = a
+		}
+		return m
+	}
+
+	checker.Check(getContext(), now)
+	a, ok := active()[id]
+	if !ok || a.Fields["subject"] != "expiring" || a.Fields["file"] != "server.cer" {
+		t.Fatalf("TestSystemChecker_CheckCertificates expiring alarm not raised, %v", c.Active(""))
+	}
+
+	// 告警窗口外不告警
+	checker.CertExpireWarning = 24 * time.Hour
+	checker.Check(getContext(), now)
+	if _, ok := active()[id]; ok {
+		t.Fatalf("TestSystemChecker_CheckCertificates alarm should be cleared, %v", c.Active(""))
+	}
+
+	// 重新加载失败时告警，继续使用原有证书
+	ioutil.WriteFile(sctls.GetSSLPath("server.cer"), []byte("invalid"), 0600)
+	if sctls.Reload() == nil {
+		t.Fatalf("TestSystemChecker_CheckCertificates invalid certificate should fail to load")
+	}
+	checker.Check(getContext(), now)
+	if _, ok := active()[alarm.ALARM_CERTIFICATE_RELOAD_FAIL]; !ok {
+		t.Fatalf("TestSystemChecker_CheckCertificates reload failure alarm not raised, %v", c.Active(""))
+	}
+	// 已过期
+	checker.Check(getContext(), now.Add(11*24*time.Hour))
+	if a, ok := active()[id]; !ok || a.Message != "certificate expiring in server.cer has expired" {
+		t.Fatalf("TestSystemChecker_CheckCertificates expired alarm not raised, %v", c.Active(""))
+	}
+
+	writeServerCert(t, "expiring", now.Add(10*24*time.Hour))
+	if err := sctls.Reload(); err != nil {
+		t.Fatalf("TestSystemChecker_CheckCertificates reload failed, %s", err)
+	}
+	checker.Check(getContext(), now)
+	if _, ok := active()[alarm.ALARM_CERTIFICATE_RELOAD_FAIL]; ok {
+		t.Fatalf("TestSystemChecker_CheckCertificates reload failure alarm should be cleared, %v", c.Active(""))
+	}
+}
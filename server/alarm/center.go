@@ -0,0 +1,267 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package alarm
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	ALARM_INSTANCE_BELOW_THRESHOLD = "INSTANCE_BELOW_THRESHOLD"
+	ALARM_BACKEND_UNAVAILABLE      = "BACKEND_UNAVAILABLE"
+	ALARM_QUOTA_NEAR_LIMIT         = "QUOTA_NEAR_LIMIT"
+	ALARM_QUOTA_EXCEEDED           = "QUOTA_EXCEEDED"
+	ALARM_SELF_PRESERVATION        = "SELF_PRESERVATION"
+	ALARM_REPLICATION_LAG          = "REPLICATION_LAG"
+	ALARM_CERTIFICATE_EXPIRING     = "CERTIFICATE_EXPIRING"
+	ALARM_CERTIFICATE_RELOAD_FAIL  = "CERTIFICATE_RELOAD_FAIL"
+	ALARM_STORAGE_NEAR_LIMIT       = "STORAGE_NEAR_LIMIT"
+	ALARM_STORAGE_WRITES_REJECTED  = "STORAGE_WRITES_REJECTED"
+	ALARM_CACHE_RESYNC             = "CACHE_RESYNC"
+	ALARM_PEER_TLS_FAILURE         = "PEER_TLS_FAILURE"
+
+	ACTION_RAISE       = "RAISE"
+	ACTION_CLEAR       = "CLEAR"
+	ACTION_ACKNOWLEDGE = "ACKNOWLEDGE"
+
+	DEFAULT_HISTORY_SIZE = 1000
+	DEFAULT_SILENCE_TTL  = time.Hour
+	MAX_SILENCE_TTL      = 7 * 24 * time.Hour
+)
+
+// Alarm 同一Id的告警在清除前只产生一次
+type Alarm struct {
+	Id            string              `json:"id"`
+	Type          string              `json:"type"`
+	DomainProject string              `json:"domainProject,omitempty"`
+	ServiceId     string              `json:"serviceId,omitempty"`
+	Service       *pb.MicroServiceKey `json:"service,omitempty"`
+	Message       string              `json:"message"`
+	Fields        map[string]string   `json:"fields,omitempty"`
+	Since         int64               `json:"since"`
+	Update        int64               `json:"update"`
+	Acknowledged  bool                `json:"acknowledged,omitempty"`
+	SilencedUntil int64               `json:"silencedUntil,omitempty"`
+}
+
+type AlarmEvent struct {
+	Action   string `json:"action"`
+	Alarm    *Alarm `json:"alarm"`
+	Time     int64  `json:"time"`
+	Operator string `json:"operator,omitempty"`
+}
+
+type AlarmHandleFunc func(evt *AlarmEvent)
+
+func GenerateAlarmId(t string, fields ...string) string {
+	return util.StringJoin(append([]string{t}, fields...), "/")
+}
+
+// Center 保存当前生效的告警，告警产生和清除时通知所有处理函数，
+// 确认后的告警在静默期内不再通知，所有告警事件保存在有限长度的历史记录中
+type Center struct {
+	HistorySize int
+
+	handlers []AlarmHandleFunc
+	active   map[string]*Alarm
+	silences map[string]int64
+	history  []*AlarmEvent
+	lock     sync.RWMutex
+}
+
+func NewCenter() *Center {
+	return &Center{
+		HistorySize: DEFAULT_HISTORY_SIZE,
+		active:      make(map[string]*Alarm),
+		silences:    make(map[string]int64),
+	}
+}
+
+func (c *Center) AddHandleFunc(f AlarmHandleFunc) {
+	c.lock.Lock()
+	c.handlers = append(c.handlers, f)
+	c.lock.Unlock()
+}
+
+// Raise 产生告警，已生效的告警只更新内容
+func (c *Center) Raise(alarm *Alarm) {
+	now := time.Now().Unix()
+	alarm.Update = now
+
+	c.lock.Lock()
+	if until, ok := c.silences[alarm.Id]; ok {
+		alarm.Acknowledged, alarm.SilencedUntil = true, until
+	}
+	old, ok := c.active[alarm.Id]
+	if ok {
+		alarm.Since = old.Since
+		c.active[alarm.Id] = alarm
+		c.lock.Unlock()
+		return
+	}
+	alarm.Since = now
+	c.active[alarm.Id] = alarm
+	evt := c.record(ACTION_RAISE, alarm, "")
+	c.lock.Unlock()
+
+	util.Logger().Warnf(nil, "raise alarm %s: %s", alarm.Id, alarm.Message)
+	if !alarm.Acknowledged {
+		c.notify(evt)
+	}
+}
+
+// Clear 清除告警，不存在时忽略
+func (c *Center) Clear(id string) {
+	c.lock.Lock()
+	alarm, ok := c.active[id]
+	if !ok {
+		c.lock.Unlock()
+		return
+	}
+	delete(c.active, id)
+	evt := c.record(ACTION_CLEAR, alarm, "")
+	c.lock.Unlock()
+
+	util.Logger().Infof("clear alarm %s", id)
+	c.notify(evt)
+}
+
+// Acknowledge 确认生效的告警，ttl内再次产生的同一告警不再通知
+func (c *Center) Acknowledge(id string, ttl time.Duration, operator string) bool {
+	if ttl <= 0 {
+		ttl = DEFAULT_SILENCE_TTL
+	}
+	if ttl > MAX_SILENCE_TTL {
+		ttl = MAX_SILENCE_TTL
+	}
+	until := time.Now().Add(ttl).Unix()
+
+	c.lock.Lock()
+	old, ok := c.active[id]
+	if !ok {
+		c.lock.Unlock()
+		return false
+	}
+	alarm := *old
+	alarm.Acknowledged, alarm.SilencedUntil = true, until
+	c.active[id] = &alarm
+	c.silences[id] = until
+	c.record(ACTION_ACKNOWLEDGE, &alarm, operator)
+	c.lock.Unlock()
+
+	util.Logger().Infof("alarm %s is acknowledged until %s, operator %s",
+		id, time.Unix(until, 0).Format(time.RFC3339), operator)
+	return true
+}
+
+// ExpireSilences 删除过期的静默，仍在生效的告警重新通知
+func (c *Center) ExpireSilences(now time.Time) {
+	var evts []*AlarmEvent
+	c.lock.Lock()
+	for id, until := range c.silences {
+		if until > now.Unix() {
+			continue
+		}
+		delete(c.silences, id)
+		old, ok := c.active[id]
+		if !ok {
+			continue
+		}
+		alarm := *old
+		alarm.Acknowledged, alarm.SilencedUntil = false, 0
+		c.active[id] = &alarm
+		evts = append(evts, c.record(ACTION_RAISE, &alarm, ""))
+	}
+	c.lock.Unlock()
+
+	for _, evt := range evts {
+		util.Logger().Warnf(nil, "alarm %s silence expired: %s", evt.Alarm.Id, evt.Alarm.Message)
+		c.notify(evt)
+	}
+}
+
+// Active 返回domainProject下生效的告警，domainProject为空时返回全部
+func (c *Center) Active(domainProject string) []*Alarm {
+	c.lock.RLock()
+	alarms := make([]*Alarm, 0, len(c.active))
+	for _, alarm := range c.active {
+		if len(domainProject) > 0 && alarm.DomainProject != domainProject {
+			continue
+		}
+		alarms = append(alarms, alarm)
+	}
+	c.lock.RUnlock()
+
+	sort.Sort(alarmsBySince(alarms))
+	return alarms
+}
+
+// History 返回最近的limit条告警事件，按时间先后排序，limit<=0时返回全部
+func (c *Center) History(limit int) []*AlarmEvent {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	start := 0
+	if limit > 0 && limit < len(c.history) {
+		start = len(c.history) - limit
+	}
+	history := make([]*AlarmEvent, len(c.history)-start)
+	copy(history, c.history[start:])
+	return history
+}
+
+// record 必须在持有写锁时调用
+func (c *Center) record(action string, alarm *Alarm, operator string) *AlarmEvent {
+	evt := &AlarmEvent{
+		Action:   action,
+		Alarm:    alarm,
+		Time:     time.Now().Unix(),
+		Operator: operator,
+	}
+	if c.HistorySize <= 0 {
+		return evt
+	}
+	if len(c.history) >= c.HistorySize {
+		n := copy(c.history, c.history[len(c.history)-c.HistorySize+1:])
+		c.history = c.history[:n]
+	}
+	c.history = append(c.history, evt)
+	return evt
+}
+
+func (c *Center) notify(evt *AlarmEvent) {
+	c.lock.RLock()
+	handlers := c.handlers
+	c.lock.RUnlock()
+	for _, f := range handlers {
+		f(evt)
+	}
+}
+
+type alarmsBySince []*Alarm
+
+func (a alarmsBySince) Len() int      { return len(a) }
+func (a alarmsBySince) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a alarmsBySince) Less(i, j int) bool {
+	if a[i].Since == a[j].Since {
+		return a[i].Id < a[j].Id
+	}
+	return a[i].Since < a[j].Since
+}
@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package alarm
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	"net/http"
+)
+
+type AlarmServiceControllerV4 struct {
+	//
+}
+
+// URLPatterns 路由
+func (this *AlarmServiceControllerV4) URLPatterns() []rest.Route {
+	return []rest.Route{
+		{rest.HTTP_METHOD_GET, "/v4/:project/alarms", this.GetActiveAlarms},
+	}
+}
+
+// GetActiveAlarms 查询当前project下生效的告警
+func (this *AlarmServiceControllerV4) GetActiveAlarms(w http.ResponseWriter, r *http.Request) {
+	controller.WriteJsonObject(w, map[string][]*Alarm{
+		"alarms": GetCenter().Active(util.ParseDomainProject(r.Context())),
+	})
+}
@@ -0,0 +1,195 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package alarm
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const DEFAULT_CHECK_INTERVAL = 5 * time.Second
+
+type serviceRef struct {
+	domainProject string
+	serviceId     string
+}
+
+// InstanceChecker 检查配置了minHealthyInstances属性的服务，
+// 健康实例数低于阈值时产生告警，恢复后清除
+type InstanceChecker struct {
+	Interval time.Duration
+
+	center *Center
+	dirty  map[string]*serviceRef
+	lock   sync.Mutex
+}
+
+func NewInstanceChecker(center *Center) *InstanceChecker {
+	return &InstanceChecker{
+		Interval: DEFAULT_CHECK_INTERVAL,
+		center:   center,
+		dirty:    make(map[string]*serviceRef),
+	}
+}
+
+// Handlers 返回需要注册到缓存的事件处理器，服务属性变化和实例变化都需要重新检查
+func (c *InstanceChecker) Handlers() []store.KvEventHandler {
+	return []store.KvEventHandler{
+		&checkerEventHandler{store.SERVICE, c},
+		&checkerEventHandler{store.INSTANCE, c},
+	}
+}
+
+func (c *InstanceChecker) Mark(domainProject, serviceId string) {
+	c.lock.Lock()
+	c.dirty[util.StringJoin([]string{domainProject, serviceId}, "/")] = &serviceRef{
+		domainProject: domainProject,
+		serviceId:     serviceId,
+	}
+	c.lock.Unlock()
+}
+
+// MarkAll 标记所有服务，用于启动时的全量检查
+func (c *InstanceChecker) MarkAll(ctx context.Context) error {
+	resp, err := store.Store().Service().Search(ctx,
+		registry.WithStrKey(apt.GetServiceRootKey("")),
+		registry.WithPrefix(),
+		registry.WithKeyOnly())
+	if err != nil {
+		return err
+	}
+	for _, kv := range resp.Kvs {
+		serviceId, domainProject, _ := pb.GetInfoFromSvcKV(kv)
+		c.Mark(domainProject, serviceId)
+	}
+	return nil
+}
+
+func (c *InstanceChecker) run(stopCh <-chan struct{}) {
+	if err := c.MarkAll(context.Background()); err != nil {
+		util.Logger().Errorf(err, "list services failed")
+	}
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(c.Interval):
+			c.Check(context.Background())
+		}
+	}
+}
+
+// Check 重新统计变化过的服务的健康实例数
+func (c *InstanceChecker) Check(ctx context.Context) {
+	c.lock.Lock()
+	dirty := c.dirty
+	c.dirty = make(map[string]*serviceRef)
+	c.lock.Unlock()
+
+	for key, ref := range dirty {
+		id := GenerateAlarmId(ALARM_INSTANCE_BELOW_THRESHOLD, key)
+
+		service, err := serviceUtil.GetService(ctx, ref.domainProject, ref.serviceId)
+		if err != nil {
+			util.Logger().Errorf(err, "get service %s failed", key)
+			c.Mark(ref.domainProject, ref.serviceId)
+			continue
+		}
+		threshold := MinHealthyInstances(service)
+		if threshold <= 0 {
+			c.center.Clear(id)
+			continue
+		}
+
+		healthy, err := serviceUtil.GetHealthyInstanceCount(ctx, ref.domainProject, ref.serviceId)
+		if err != nil {
+			util.Logger().Errorf(err, "count service %s healthy instances failed", key)
+			c.Mark(ref.domainProject, ref.serviceId)
+			continue
+		}
+		if healthy >= threshold {
+			c.center.Clear(id)
+			continue
+		}
+
+		c.center.Raise(&Alarm{
+			Id:            id,
+			Type:          ALARM_INSTANCE_BELOW_THRESHOLD,
+			DomainProject: ref.domainProject,
+			ServiceId:     ref.serviceId,
+			Service:       pb.MicroServiceToKey(ref.domainProject, service),
+			Message: fmt.Sprintf("service %s/%s/%s has %d healthy instance(s), less than %d",
+				service.AppId, service.ServiceName, service.Version, healthy, threshold),
+			Fields: map[string]string{
+				"threshold": strconv.Itoa(threshold),
+				"healthy":   strconv.Itoa(healthy),
+			},
+		})
+	}
+}
+
+// MinHealthyInstances 解析服务属性中的阈值，未配置或非法时返回0
+func MinHealthyInstances(service *pb.MicroService) int {
+	if service == nil {
+		return 0
+	}
+	v, ok := service.Properties[pb.PROP_MIN_HEALTHY_INSTANCES]
+	if !ok || len(v) == 0 {
+		return 0
+	}
+	threshold, err := strconv.Atoi(v)
+	if err != nil {
+		util.Logger().Warnf(err, "invalid property %s of service %s", pb.PROP_MIN_HEALTHY_INSTANCES, service.ServiceId)
+		return 0
+	}
+	return threshold
+}
+
+type checkerEventHandler struct {
+	t       store.StoreType
+	checker *InstanceChecker
+}
+
+func (h *checkerEventHandler) Type() store.StoreType {
+	return h.t
+}
+
+func (h *checkerEventHandler) OnEvent(evt *store.KvEvent) {
+	if evt.Action == pb.EVT_INIT {
+		return
+	}
+	var serviceId, domainProject string
+	switch h.t {
+	case store.SERVICE:
+		serviceId, domainProject, _ = pb.GetInfoFromSvcKV(evt.KV)
+	default:
+		serviceId, _, domainProject, _ = pb.GetInfoFromInstKV(evt.KV)
+	}
+	if len(serviceId) == 0 {
+		return
+	}
+	h.checker.Mark(domainProject, serviceId)
+}
@@ -0,0 +1,337 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package alarm
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/quota"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/apache/incubator-servicecomb-service-center/server/service/uplink"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	sctls "github.com/apache/incubator-servicecomb-service-center/server/tls"
+	"golang.org/x/net/context"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	DEFAULT_SYSTEM_CHECK_INTERVAL = 10 * time.Second
+	DEFAULT_QUOTA_PERCENT         = 0.9
+	BACKEND_CHECK_TIMEOUT         = 5 * time.Second
+	DEFAULT_CERT_EXPIRE_WARNING   = 30 * 24 * time.Hour
+)
+
+// SystemChecker 周期检查后端连接、存储使用量、配额使用量、自我保护状态、上行同步延迟、证书有效期和对端集群的TLS状态
+type SystemChecker struct {
+	Interval          time.Duration
+	QuotaPercent      float64
+	CertExpireWarning time.Duration
+
+	center *Center
+}
+
+func NewSystemChecker(center *Center) *SystemChecker {
+	return &SystemChecker{
+		Interval:          DEFAULT_SYSTEM_CHECK_INTERVAL,
+		QuotaPercent:      DEFAULT_QUOTA_PERCENT,
+		CertExpireWarning: DEFAULT_CERT_EXPIRE_WARNING,
+		center:            center,
+	}
+}
+
+func (c *SystemChecker) run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(c.Interval):
+			c.Check(context.Background(), time.Now())
+		}
+	}
+}
+
+func (c *SystemChecker) Check(ctx context.Context, now time.Time) {
+	c.center.ExpireSilences(now)
+	c.checkCertificates(now)
+	c.checkPeers()
+
+	if !c.checkBackend(ctx) {
+		// 后端不可用时配额统计不可信
+		return
+	}
+	c.checkGuardrail(ctx)
+	c.checkQuota(ctx, quota.MicroServiceQuotaType, store.Store().Service(), apt.GetServiceRootKey(""))
+	c.checkQuota(ctx, quota.MicroServiceInstanceQuotaType, store.Store().Instance(), apt.GetInstanceRootKey(""))
+	c.checkSelfPreservation()
+	c.checkCacheResync(store.Store().ResyncStats())
+	c.checkReplication(now)
+}
+
+func (c *SystemChecker) checkBackend(ctx context.Context) bool {
+	tctx, cancel := context.WithTimeout(ctx, BACKEND_CHECK_TIMEOUT)
+	defer cancel()
+	_, err := backend.Registry().Do(tctx, registry.GET,
+		registry.WithStrKey(apt.GetServiceRootKey("")),
+		registry.WithPrefix(),
+		registry.WithCountOnly())
+	if err == nil {
+		c.center.Clear(ALARM_BACKEND_UNAVAILABLE)
+		return true
+	}
+	c.center.Raise(&Alarm{
+		Id:      ALARM_BACKEND_UNAVAILABLE,
+		Type:    ALARM_BACKEND_UNAVAILABLE,
+		Message: fmt.Sprintf("registry backend is unavailable, %s", err.Error()),
+	})
+	return false
+}
+
+// checkGuardrail 刷新存储保护级别，接近上限时告警，拒绝写入时另外告警
+func (c *SystemChecker) checkGuardrail(ctx context.Context) {
+	g := serviceUtil.GetGuardrail()
+	if !g.Enabled() {
+		return
+	}
+	tctx, cancel := context.WithTimeout(ctx, BACKEND_CHECK_TIMEOUT)
+	defer cancel()
+	usage, err := g.Refresh(tctx)
+	if err != nil {
+		util.Logger().Errorf(err, "refresh registry storage usage failed")
+		return
+	}
+	c.raiseGuardrail(g, usage)
+}
+
+func (c *SystemChecker) raiseGuardrail(g *serviceUtil.Guardrail, usage serviceUtil.GuardrailUsage) {
+	if usage.Level == serviceUtil.GUARD_NORMAL {
+		c.center.Clear(ALARM_STORAGE_NEAR_LIMIT)
+		c.center.Clear(ALARM_STORAGE_WRITES_REJECTED)
+		return
+	}
+	fields := map[string]string{
+		"keys":      strconv.FormatInt(usage.Keys, 10),
+		"maxKeys":   strconv.FormatInt(g.MaxKeys, 10),
+		"dbSize":    strconv.FormatInt(usage.DbSize, 10),
+		"maxDbSize": strconv.FormatInt(g.MaxDbSize, 10),
+		"level":     usage.Level.String(),
+	}
+	c.center.Raise(&Alarm{
+		Id:   ALARM_STORAGE_NEAR_LIMIT,
+		Type: ALARM_STORAGE_NEAR_LIMIT,
+		Message: fmt.Sprintf("registry storage is near the limit, keys %d of %d, db size %d of %d",
+			usage.Keys, g.MaxKeys, usage.DbSize, g.MaxDbSize),
+		Fields: fields,
+	})
+	if usage.Level == serviceUtil.GUARD_WARNING {
+		c.center.Clear(ALARM_STORAGE_WRITES_REJECTED)
+		return
+	}
+	c.center.Raise(&Alarm{
+		Id:      ALARM_STORAGE_WRITES_REJECTED,
+		Type:    ALARM_STORAGE_WRITES_REJECTED,
+		Message: fmt.Sprintf("registry storage guard level is %s, the low priority writes are rejected", usage.Level),
+		Fields:  fields,
+	})
+}
+
+func (c *SystemChecker) checkQuota(ctx context.Context, t quota.ResourceType, indexer *store.Indexer, key string) {
+	id := GenerateAlarmId(ALARM_QUOTA_NEAR_LIMIT, t.String())
+	exceededId := GenerateAlarmId(ALARM_QUOTA_EXCEEDED, t.String())
+	limiter, ok := plugin.Plugins().Quota().(quota.QuotaLimiter)
+	if !ok {
+		return
+	}
+	limit := limiter.GetLimit(t)
+	if limit <= 0 {
+		c.center.Clear(id)
+		c.center.Clear(exceededId)
+		return
+	}
+
+	resp, err := indexer.Search(ctx,
+		registry.WithStrKey(key),
+		registry.WithPrefix(),
+		registry.WithCountOnly())
+	if err != nil {
+		util.Logger().Errorf(err, "count %s failed", t)
+		return
+	}
+	fields := map[string]string{
+		"resource": t.String(),
+		"used":     strconv.FormatInt(resp.Count, 10),
+		"limit":    strconv.FormatInt(limit, 10),
+	}
+	// 超过上限说明配额插件允许了宽限超额
+	if resp.Count > limit {
+		c.center.Raise(&Alarm{
+			Id:   exceededId,
+			Type: ALARM_QUOTA_EXCEEDED,
+			Message: fmt.Sprintf("%s quota is exceeded, used %d of %d",
+				t, resp.Count, limit),
+			Fields: fields,
+		})
+	} else {
+		c.center.Clear(exceededId)
+	}
+
+	if float64(resp.Count) < float64(limit)*c.QuotaPercent {
+		c.center.Clear(id)
+		return
+	}
+	c.center.Raise(&Alarm{
+		Id:   id,
+		Type: ALARM_QUOTA_NEAR_LIMIT,
+		Message: fmt.Sprintf("%s quota is near the limit, used %d of %d",
+			t, resp.Count, limit),
+		Fields: fields,
+	})
+}
+
+func (c *SystemChecker) checkSelfPreservation() {
+	if !store.Store().SelfPreservationEnabled() {
+		c.center.Clear(ALARM_SELF_PRESERVATION)
+		return
+	}
+	c.center.Raise(&Alarm{
+		Id:      ALARM_SELF_PRESERVATION,
+		Type:    ALARM_SELF_PRESERVATION,
+		Message: "self preservation is active, the expired instances are retained",
+	})
+}
+
+// checkCacheResync 缓存与etcd不一致时告警，一小时内未再重新同步后清除
+func (c *SystemChecker) checkCacheResync(stats []store.ResyncStat) {
+	for _, stat := range stats {
+		id := GenerateAlarmId(ALARM_CACHE_RESYNC, stat.Type.String())
+		if stat.Resyncs == 0 && stat.Diverged == 0 {
+			c.center.Clear(id)
+			continue
+		}
+		message := fmt.Sprintf("%s cache diverged from registry, %d key(s), resynced %d time(s) in the last hour",
+			stat.Type, stat.Diverged, stat.Resyncs)
+		if stat.Limited {
+			message += ", the resyncs reach the limit"
+		}
+		c.center.Raise(&Alarm{
+			Id:      id,
+			Type:    ALARM_CACHE_RESYNC,
+			Message: message,
+			Fields: map[string]string{
+				"cache":    stat.Type.String(),
+				"diverged": strconv.Itoa(stat.Diverged),
+				"resyncs":  strconv.Itoa(stat.Resyncs),
+				"limited":  strconv.FormatBool(stat.Limited),
+			},
+		})
+	}
+}
+
+func (c *SystemChecker) checkReplication(now time.Time) {
+	r := uplink.GetReplicator()
+	if !r.Enabled() {
+		return
+	}
+	lag := r.Lag(now)
+	if lag <= r.Interval*uplink.DEFAULT_RETRY_TIMES {
+		c.center.Clear(ALARM_REPLICATION_LAG)
+		return
+	}
+	c.center.Raise(&Alarm{
+		Id:   ALARM_REPLICATION_LAG,
+		Type: ALARM_REPLICATION_LAG,
+		Message: fmt.Sprintf("replication to central cluster %s lags %s",
+			r.Addr, lag-lag%time.Second),
+		Fields: map[string]string{
+			"lag": strconv.FormatInt(int64(lag/time.Second), 10),
+		},
+	})
+}
+
+// checkCertificates 证书在CertExpireWarning内过期或重新加载失败时告警
+func (c *SystemChecker) checkCertificates(now time.Time) {
+	if !apt.ServerInfo.Config.SslEnabled {
+		return
+	}
+	status := sctls.Status()
+	if len(status.LastError) == 0 {
+		c.center.Clear(ALARM_CERTIFICATE_RELOAD_FAIL)
+	} else {
+		c.center.Raise(&Alarm{
+			Id:      ALARM_CERTIFICATE_RELOAD_FAIL,
+			Type:    ALARM_CERTIFICATE_RELOAD_FAIL,
+			Message: fmt.Sprintf("reload tls certificates failed, %s", status.LastError),
+		})
+	}
+
+	for _, cert := range status.Certificates {
+		id := GenerateAlarmId(ALARM_CERTIFICATE_EXPIRING, cert.File, cert.Subject)
+		left := time.Unix(cert.NotAfter, 0).Sub(now)
+		if left > c.CertExpireWarning {
+			c.center.Clear(id)
+			continue
+		}
+		message := fmt.Sprintf("certificate %s in %s expires in %d days",
+			cert.Subject, filepath.Base(cert.File), int64(left/(24*time.Hour)))
+		if left <= 0 {
+			message = fmt.Sprintf("certificate %s in %s has expired", cert.Subject, filepath.Base(cert.File))
+		}
+		c.center.Raise(&Alarm{
+			Id:      id,
+			Type:    ALARM_CERTIFICATE_EXPIRING,
+			Message: message,
+			Fields: map[string]string{
+				"file":     filepath.Base(cert.File),
+				"subject":  cert.Subject,
+				"notAfter": strconv.FormatInt(cert.NotAfter, 10),
+			},
+		})
+	}
+}
+
+// checkPeers 对端集群最近一次握手或认证失败时告警，之后访问成功或对端被删除时清除
+func (c *SystemChecker) checkPeers() {
+	peers := make(map[string]struct{})
+	for _, st := range sctls.PeerStatuses() {
+		id := GenerateAlarmId(ALARM_PEER_TLS_FAILURE, st.Name)
+		peers[id] = struct{}{}
+		if !st.Failing() {
+			c.center.Clear(id)
+			continue
+		}
+		c.center.Raise(&Alarm{
+			Id:      id,
+			Type:    ALARM_PEER_TLS_FAILURE,
+			Message: fmt.Sprintf("tls %s with peer %s failed, %s", st.LastReason, st.Name, st.LastError),
+			Fields: map[string]string{
+				"peer":     st.Name,
+				"reason":   st.LastReason,
+				"failures": strconv.FormatInt(st.Failures, 10),
+			},
+		})
+	}
+	for _, a := range c.center.Active("") {
+		if _, ok := peers[a.Id]; a.Type == ALARM_PEER_TLS_FAILURE && !ok {
+			c.center.Clear(a.Id)
+		}
+	}
+}
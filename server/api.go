@@ -63,6 +63,7 @@ type APIServer struct {
 	Endpoints map[APIType]string
 	restSrv   *rest.Server
 	rpcSrv    *rpc.Server
+	extraSrvs []*rest.Server
 	isClose   bool
 	forked    bool
 	err       chan error
@@ -222,6 +223,28 @@ func (s *APIServer) startRESTServer() (err error) {
 	return
 }
 
+// startListenerServers 启动独立的admin和metrics监听，不参与自注册
+func (s *APIServer) startListenerServers() (err error) {
+	for _, l := range rs.Listeners() {
+		srv, err := rs.NewListenerServer(l)
+		if err != nil {
+			return err
+		}
+		s.extraSrvs = append(s.extraSrvs, srv)
+		util.Logger().Infof("Local listen %s address: %s, ssl %t, auth %t.", l.Name, l.Addr, l.SslEnabled, l.AuthEnabled)
+
+		go func(name string) {
+			err := srv.Serve()
+			if s.isClose {
+				return
+			}
+			util.Logger().Errorf(err, "error to start %s server", name)
+			s.err <- err
+		}(l.Name)
+	}
+	return
+}
+
 func (s *APIServer) startRPCServer() (err error) {
 	ep, ok := s.Endpoints[RPC]
 	if !ok {
@@ -265,6 +288,12 @@ func (s *APIServer) Start() {
 		return
 	}
 
+	err = s.startListenerServers()
+	if err != nil {
+		s.err <- err
+		return
+	}
+
 	s.graceDone()
 
 	// 自注册
@@ -295,6 +324,10 @@ func (s *APIServer) Stop() {
 		s.restSrv.Shutdown()
 	}
 
+	for _, srv := range s.extraSrvs {
+		srv.Shutdown()
+	}
+
 	if s.rpcSrv != nil {
 		s.rpcSrv.GracefulStop()
 	}
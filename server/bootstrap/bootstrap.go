@@ -25,6 +25,8 @@ import _ "github.com/apache/incubator-servicecomb-service-center/server/rest/con
 // registry
 import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/etcd"
 import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/embededetcd"
+import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/dualwrite"
+import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/memory"
 
 // cipher
 import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/security/buildin"
@@ -37,31 +39,105 @@ import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/i
 import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/auth/buildin"
 import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/auth/dynamic"
 
+// admission
+import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/admission/buildin"
+import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/admission/remote"
+
+// governance
+import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/governance/buildin"
+import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/governance/kie"
+
+// cmdb
+import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/cmdb/rest"
+
+// naming
+import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/naming/buildin"
+
+// auditlog
+import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/auditlog/buildin"
+import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/auditlog/file"
+
+// metrics
+import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/metrics/statsd"
+import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/metrics/graphite"
+
 // uuid
 import _ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/uuid/dynamic"
 
 // module
 import _ "github.com/apache/incubator-servicecomb-service-center/server/govern"
+import _ "github.com/apache/incubator-servicecomb-service-center/server/admin"
+import _ "github.com/apache/incubator-servicecomb-service-center/server/alarm"
+import _ "github.com/apache/incubator-servicecomb-service-center/server/cmdb"
 
 import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/chain"
+	roa "github.com/apache/incubator-servicecomb-service-center/pkg/rest"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/chaos"
 	"github.com/apache/incubator-servicecomb-service-center/server/handler/auth"
+	"github.com/apache/incubator-servicecomb-service-center/server/handler/bodylimit"
 	"github.com/apache/incubator-servicecomb-service-center/server/handler/cache"
 	"github.com/apache/incubator-servicecomb-service-center/server/handler/context"
 	"github.com/apache/incubator-servicecomb-service-center/server/interceptor"
 	"github.com/apache/incubator-servicecomb-service-center/server/interceptor/access"
 	"github.com/apache/incubator-servicecomb-service-center/server/interceptor/cors"
+	"github.com/apache/incubator-servicecomb-service-center/server/interceptor/locale"
 	"github.com/apache/incubator-servicecomb-service-center/server/interceptor/ratelimiter"
+	"github.com/apache/incubator-servicecomb-service-center/server/metering"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/gateway"
+	"github.com/astaxie/beego"
+	"strings"
 )
 
 func init() {
 	util.Logger().Info("BootStrap ServiceComb.io Edition")
 
-	interceptor.RegisterInterceptFunc(access.Intercept)
-	interceptor.RegisterInterceptFunc(ratelimiter.Intercept)
-	interceptor.RegisterInterceptFunc(cors.Intercept)
+	interceptor.RegisterNamedInterceptFunc("access", access.Intercept)
+	interceptor.RegisterNamedInterceptFunc("ratelimit", ratelimiter.Intercept)
+	interceptor.RegisterNamedInterceptFunc("cors", cors.Intercept)
+	interceptor.RegisterNamedInterceptFunc("locale", locale.Intercept)
 
+	bodylimit.RegisterHandlers()
 	auth.RegisterHandlers()
 	context.RegisterHandlers()
 	cache.RegisterHandlers()
+	metering.RegisterHandlers()
+	chaos.RegisterHandlers()
+
+	gateway.RegisterRoutes()
+
+	useMiddlewares()
+}
+
+// useMiddlewares 按配置调整拦截器和处理链的顺序和启停，未配置时按注册顺序全部启用
+func useMiddlewares() {
+	if names := configNames("rest_interceptors"); names != nil {
+		if missing := interceptor.UseInterceptors(names); len(missing) > 0 {
+			util.Logger().Warnf(nil, "rest_interceptors %v are not registered, skip them", missing)
+		}
+	}
+	if names := configNames("rest_handlers"); names != nil {
+		if missing := chain.UseHandlers(roa.SERVER_CHAIN_NAME, names); len(missing) > 0 {
+			// chaos和metering未开启时不注册
+			util.Logger().Warnf(nil, "rest_handlers %v are not registered or disabled, skip them", missing)
+		}
+		if _, ok := util.ListToMap(names)["context"]; !ok {
+			util.Logger().Warnf(nil, "rest_handlers without 'context', the requests can not be parsed to domain/project")
+		}
+	}
+}
+
+func configNames(key string) []string {
+	s := strings.TrimSpace(beego.AppConfig.String(key))
+	if len(s) == 0 {
+		return nil
+	}
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+	return names
 }
@@ -0,0 +1,213 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package chaos
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/chain"
+	roa "github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	nf "github.com/apache/incubator-servicecomb-service-center/server/service/notification"
+	"github.com/astaxie/beego"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_FAULT_DURATION = 10 * time.Minute
+	MAX_FAULT_DURATION     = 24 * time.Hour
+	MAX_FAULT_LATENCY      = time.Minute
+)
+
+// Fault 注入到租户REST请求的故障，到期后自动失效；故障只保存在当前节点的内存中
+type Fault struct {
+	Domain    string  `json:"domain"`
+	Project   string  `json:"project"`
+	Latency   string  `json:"latency,omitempty"`
+	ErrorRate float64 `json:"errorRate,omitempty"`
+	Duration  string  `json:"duration,omitempty"`
+	ExpireAt  int64   `json:"expireAt"`
+	Operator  string  `json:"operator,omitempty"`
+	Timestamp string  `json:"timestamp,omitempty"`
+
+	latency time.Duration
+}
+
+func (f *Fault) DomainProject() string {
+	return f.Domain + "/" + f.Project
+}
+
+func (f *Fault) Check() error {
+	if len(f.Domain) == 0 || len(f.Project) == 0 {
+		return fmt.Errorf("domain and project are required")
+	}
+	if len(f.Latency) > 0 {
+		d, err := time.ParseDuration(f.Latency)
+		if err != nil || d < 0 || d > MAX_FAULT_LATENCY {
+			return fmt.Errorf("invalid latency '%s', should be in [0, %s]", f.Latency, MAX_FAULT_LATENCY)
+		}
+		f.latency = d
+	}
+	if f.ErrorRate < 0 || f.ErrorRate > 1 {
+		return fmt.Errorf("invalid errorRate %v, should be in [0, 1]", f.ErrorRate)
+	}
+	if f.latency == 0 && f.ErrorRate == 0 {
+		return fmt.Errorf("latency or errorRate is required")
+	}
+	if len(f.Duration) > 0 {
+		d, err := time.ParseDuration(f.Duration)
+		if err != nil || d <= 0 || d > MAX_FAULT_DURATION {
+			return fmt.Errorf("invalid duration '%s', should be in (0, %s]", f.Duration, MAX_FAULT_DURATION)
+		}
+	}
+	return nil
+}
+
+func (f *Fault) expired(now time.Time) bool {
+	return now.Unix() >= f.ExpireAt
+}
+
+// Faults 按租户保存的故障
+type Faults struct {
+	lock   sync.RWMutex
+	faults map[string]*Fault
+}
+
+func NewFaults() *Faults {
+	return &Faults{faults: make(map[string]*Fault)}
+}
+
+// Put 覆盖租户的故障，f需先通过Check，未指定duration时默认持续DEFAULT_FAULT_DURATION
+func (fs *Faults) Put(f *Fault, now time.Time) {
+	d := DEFAULT_FAULT_DURATION
+	if len(f.Duration) > 0 {
+		d, _ = time.ParseDuration(f.Duration)
+	}
+	f.ExpireAt = now.Add(d).Unix()
+	f.Timestamp = strconv.FormatInt(now.Unix(), 10)
+	fs.lock.Lock()
+	fs.faults[f.DomainProject()] = f
+	fs.lock.Unlock()
+}
+
+func (fs *Faults) Get(domainProject string, now time.Time) *Fault {
+	fs.lock.RLock()
+	f, ok := fs.faults[domainProject]
+	fs.lock.RUnlock()
+	if !ok || f.expired(now) {
+		return nil
+	}
+	return f
+}
+
+// Delete 返回故障是否存在
+func (fs *Faults) Delete(domainProject string) bool {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+	_, ok := fs.faults[domainProject]
+	delete(fs.faults, domainProject)
+	return ok
+}
+
+// List 返回未到期的故障并清理已到期的故障
+func (fs *Faults) List(now time.Time) []*Fault {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+	faults := make([]*Fault, 0, len(fs.faults))
+	for key, f := range fs.faults {
+		if f.expired(now) {
+			delete(fs.faults, key)
+			continue
+		}
+		faults = append(faults, f)
+	}
+	sort.Slice(faults, func(i, j int) bool {
+		return faults[i].DomainProject() < faults[j].DomainProject()
+	})
+	return faults
+}
+
+var faults = NewFaults()
+
+func GetFaults() *Faults {
+	return faults
+}
+
+// Enabled 测试模式，chaos_mode_enabled = true时开启，生产环境禁止开启
+func Enabled() bool {
+	return beego.AppConfig.DefaultBool("chaos_mode_enabled", false)
+}
+
+func init() {
+	if !Enabled() {
+		return
+	}
+	util.Logger().Warnf(nil, "chaos mode is enabled, DO NOT use it in production")
+	registerREST()
+}
+
+// DisconnectWatchers 断开当前节点上租户的所有实例watch连接，返回断开的个数
+func DisconnectWatchers(domainProject string) int {
+	return nf.GetNotifyService().CloseSubscribers(nf.INSTANCE, apt.GetInstanceRootKey(domainProject)+"/")
+}
+
+// ChaosHandler 按租户的故障延迟或拒绝REST请求，管理接口不受影响，需注册在context handler之后
+type ChaosHandler struct {
+}
+
+func (h *ChaosHandler) Handle(i *chain.Invocation) {
+	pattern := i.Context().Value(roa.CTX_MATCH_PATTERN).(string)
+	if strings.Contains(pattern, "/admin/") {
+		i.Next()
+		return
+	}
+	r := i.Context().Value(roa.CTX_REQUEST).(*http.Request)
+	f := faults.Get(util.ParseDomainProject(r.Context()), time.Now())
+	if f == nil {
+		i.Next()
+		return
+	}
+
+	if f.latency > 0 {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(f.latency):
+		}
+	}
+	if f.ErrorRate > 0 && rand.Float64() < f.ErrorRate {
+		w := i.Context().Value(roa.CTX_RESPONSE).(http.ResponseWriter)
+		controller.WriteError(w, scerr.ErrUnavailableBackend, "injected by chaos mode")
+		i.Fail(nil)
+		return
+	}
+	i.Next()
+}
+
+func RegisterHandlers() {
+	if !Enabled() {
+		return
+	}
+	chain.RegisterNamedHandler(roa.SERVER_CHAIN_NAME, "chaos", &ChaosHandler{})
+}
@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package chaos_test
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/server/chaos"
+	"testing"
+	"time"
+)
+
+func TestFault_Check(t *testing.T) {
+	for _, f := range []*chaos.Fault{
+		{Domain: "d", Project: "p"},
+		{Domain: "d", Latency: "1s"},
+		{Domain: "d", Project: "p", Latency: "-1s"},
+		{Domain: "d", Project: "p", Latency: "2m"},
+		{Domain: "d", Project: "p", ErrorRate: 1.5},
+		{Domain: "d", Project: "p", ErrorRate: 0.5, Duration: "48h"},
+		{Domain: "d", Project: "p", ErrorRate: 0.5, Duration: "x"},
+	} {
+		if err := f.Check(); err == nil {
+			t.Fatalf("Check %+v should fail", f)
+		}
+	}
+
+	f := &chaos.Fault{Domain: "d", Project: "p", Latency: "100ms", ErrorRate: 0.1, Duration: "1h"}
+	if err := f.Check(); err != nil {
+		t.Fatalf("Check failed, %s", err)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	fs := chaos.NewFaults()
+	f := &chaos.Fault{Domain: "d", Project: "p", ErrorRate: 1}
+	if err := f.Check(); err != nil {
+		t.Fatalf("Check failed, %s", err)
+	}
+	fs.Put(f, now)
+	if f.ExpireAt != now.Add(chaos.DEFAULT_FAULT_DURATION).Unix() {
+		t.Fatalf("default duration failed, %d", f.ExpireAt)
+	}
+	if fs.Get("d/p", now) != f || fs.Get("d/q", now) != nil {
+		t.Fatalf("Get failed")
+	}
+
+	expired := now.Add(chaos.DEFAULT_FAULT_DURATION)
+	if fs.Get("d/p", expired) != nil {
+		t.Fatalf("Get expired fault failed")
+	}
+	if l := fs.List(expired); len(l) != 0 {
+		t.Fatalf("List should clean the expired faults, %v", l)
+	}
+	if fs.Delete("d/p") {
+		t.Fatalf("Delete cleaned fault should fail")
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package chaos
+
+import (
+	"encoding/json"
+	roa "github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+func registerREST() {
+	roa.RegisterServent(&ChaosServiceControllerV4{})
+}
+
+// ChaosServiceControllerV4 测试模式的故障注入接口，只允许默认domain访问，只对当前节点生效
+type ChaosServiceControllerV4 struct {
+	//
+}
+
+// URLPatterns 路由
+func (this *ChaosServiceControllerV4) URLPatterns() []roa.Route {
+	return []roa.Route{
+		{roa.HTTP_METHOD_GET, "/v4/:project/admin/chaos/faults", this.GetFaults},
+		{roa.HTTP_METHOD_PUT, "/v4/:project/admin/chaos/faults/:domain/:targetProject", this.PutFault},
+		{roa.HTTP_METHOD_DELETE, "/v4/:project/admin/chaos/faults/:domain/:targetProject", this.DeleteFault},
+		{roa.HTTP_METHOD_POST, "/v4/:project/admin/chaos/disconnect/:domain/:targetProject", this.Disconnect},
+	}
+}
+
+func checkAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if util.ParseDomain(r.Context()) == core.REGISTRY_DOMAIN {
+		return true
+	}
+	util.Logger().Errorf(nil, "access chaos api %s refused, domain %s, operator %s",
+		r.URL.Path, util.ParseDomain(r.Context()), util.GetIPFromContext(r.Context()))
+	controller.WriteError(w, scerr.ErrUnauthorized, "Administrator permission required.")
+	return false
+}
+
+// GetFaults 查询当前节点上未到期的故障
+func (this *ChaosServiceControllerV4) GetFaults(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	controller.WriteJsonObject(w, map[string][]*Fault{
+		"faults": GetFaults().List(time.Now()),
+	})
+}
+
+// PutFault 覆盖租户的故障，延迟和错误率可同时生效
+func (this *ChaosServiceControllerV4) PutFault(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &Fault{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	query := r.URL.Query()
+	request.Domain, request.Project = query.Get(":domain"), query.Get(":targetProject")
+	if err := request.Check(); err != nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	request.Operator = util.GetIPFromContext(r.Context())
+	GetFaults().Put(request, time.Now())
+	util.Logger().Warnf(nil, "inject fault to %s, latency %s, error rate %v, expire at %d, operator %s.",
+		request.DomainProject(), request.Latency, request.ErrorRate, request.ExpireAt, request.Operator)
+	controller.WriteJsonObject(w, request)
+}
+
+// DeleteFault 删除租户的故障
+func (this *ChaosServiceControllerV4) DeleteFault(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	domainProject := util.StringJoin([]string{query.Get(":domain"), query.Get(":targetProject")}, "/")
+	if !GetFaults().Delete(domainProject) {
+		controller.WriteError(w, scerr.ErrInvalidParams, "Fault does not exist.")
+		return
+	}
+	util.Logger().Infof("delete fault of %s successfully, operator %s.",
+		domainProject, util.GetIPFromContext(r.Context()))
+	controller.WriteJsonObject(w, nil)
+}
+
+// Disconnect 强制断开租户在当前节点上的实例watch连接，客户端应重连并重新Find
+func (this *ChaosServiceControllerV4) Disconnect(w http.ResponseWriter, r *http.Request) {
+	if !checkAdmin(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	domainProject := util.StringJoin([]string{query.Get(":domain"), query.Get(":targetProject")}, "/")
+	n := DisconnectWatchers(domainProject)
+	util.Logger().Warnf(nil, "disconnect %d watcher(s) of %s, operator %s.",
+		n, domainProject, util.GetIPFromContext(r.Context()))
+	controller.WriteJsonObject(w, map[string]int{"disconnected": n})
+}
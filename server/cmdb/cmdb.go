@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmdb
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/cmdb"
+	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"time"
+)
+
+var syncer *Syncer
+
+func init() {
+	syncer = NewSyncer(func(ctx context.Context, record *cmdb.ServiceRecord) error {
+		return plugin.Plugins().CMDB().Sync(ctx, record)
+	})
+	syncer.MaxRetries = beego.AppConfig.DefaultInt("cmdb_max_retries", DEFAULT_MAX_RETRIES)
+	syncer.RetryInterval = time.Duration(beego.AppConfig.DefaultInt64("cmdb_retry_interval", 0)) * time.Second
+	if syncer.RetryInterval <= 0 {
+		syncer.RetryInterval = DEFAULT_RETRY_INTERVAL
+	}
+	if !Enabled() {
+		return
+	}
+	store.AddEventHandleFunc(store.SERVICE, onServiceEvent)
+	store.AddEventHandleFunc(store.SERVICE_TAG, onTagEvent)
+	registerREST()
+	util.Logger().Infof("cmdb sync enabled, plugin '%s'", beego.AppConfig.String("cmdb_plugin"))
+}
+
+// Enabled 配置了cmdb_plugin时才同步
+func Enabled() bool {
+	return len(beego.AppConfig.String("cmdb_plugin")) > 0
+}
+
+func GetSyncer() *Syncer {
+	return syncer
+}
+
+func Run() {
+	if !Enabled() {
+		return
+	}
+	util.Go(syncer.run)
+}
+
+func onServiceEvent(evt *store.KvEvent) {
+	switch evt.Action {
+	case pb.EVT_CREATE, pb.EVT_UPDATE, pb.EVT_DELETE:
+	default:
+		return
+	}
+	serviceId, domainProject, data := pb.GetInfoFromSvcKV(evt.KV)
+	if len(serviceId) == 0 {
+		return
+	}
+	var service *pb.MicroService
+	if data != nil {
+		service = &pb.MicroService{}
+		if err := json.Unmarshal(data, service); err != nil {
+			util.Logger().Errorf(err, "unmarshal service %s/%s failed", domainProject, serviceId)
+			service = nil
+		}
+	}
+	syncer.Enqueue(domainProject, serviceId, string(evt.Action), service)
+}
+
+// onTagEvent tag变化时同步一次服务元数据
+func onTagEvent(evt *store.KvEvent) {
+	if evt.Action == pb.EVT_INIT {
+		return
+	}
+	serviceId, domainProject, _ := pb.GetInfoFromTagKV(evt.KV)
+	if len(serviceId) == 0 {
+		return
+	}
+	syncer.Enqueue(domainProject, serviceId, string(pb.EVT_UPDATE), nil)
+}
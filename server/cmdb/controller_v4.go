@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmdb
+
+import (
+	roa "github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	"net/http"
+)
+
+func registerREST() {
+	roa.RegisterServent(&CMDBServiceControllerV4{})
+}
+
+type CMDBServiceControllerV4 struct {
+	//
+}
+
+// URLPatterns 路由
+func (this *CMDBServiceControllerV4) URLPatterns() []roa.Route {
+	return []roa.Route{
+		{roa.HTTP_METHOD_GET, "/v4/:project/cmdb/status", this.ListStatus},
+		{roa.HTTP_METHOD_GET, "/v4/:project/cmdb/status/:serviceId", this.GetStatus},
+	}
+}
+
+// ListStatus 查询project下服务的同步状态，可按status过滤
+func (this *CMDBServiceControllerV4) ListStatus(w http.ResponseWriter, r *http.Request) {
+	controller.WriteJsonObject(w, map[string][]*SyncStatus{
+		"services": GetSyncer().List(util.ParseDomainProject(r.Context()), r.URL.Query().Get("status")),
+	})
+}
+
+// GetStatus 查询单个服务的同步状态
+func (this *CMDBServiceControllerV4) GetStatus(w http.ResponseWriter, r *http.Request) {
+	serviceId := r.URL.Query().Get(":serviceId")
+	st := GetSyncer().Status(util.ParseDomainProject(r.Context()), serviceId)
+	if st == nil {
+		controller.WriteError(w, scerr.ErrServiceNotExists, "No sync status of the service.")
+		return
+	}
+	controller.WriteJsonObject(w, st)
+}
@@ -0,0 +1,313 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmdb
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/cmdb"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	STATUS_PENDING = "PENDING"
+	STATUS_SYNCED  = "SYNCED"
+	STATUS_FAILED  = "FAILED"
+
+	// 服务properties中负责人的key
+	PROP_OWNER = "owner"
+
+	DEFAULT_MAX_RETRIES    = 5
+	DEFAULT_RETRY_INTERVAL = 10 * time.Second
+	maxRetryInterval       = 10 * time.Minute
+)
+
+type SyncFunc func(ctx context.Context, record *cmdb.ServiceRecord) error
+
+// SyncStatus 服务最近一次同步到CMDB的状态
+type SyncStatus struct {
+	ServiceId    string `json:"serviceId"`
+	Action       string `json:"action"`
+	Status       string `json:"status"`
+	Attempts     int    `json:"attempts,omitempty"`
+	LastError    string `json:"lastError,omitempty"`
+	LastSyncTime int64  `json:"lastSyncTime,omitempty"`
+}
+
+type task struct {
+	domainProject string
+	serviceId     string
+	action        string
+	service       *pb.MicroService
+	attempts      int
+	next          time.Time
+}
+
+// Syncer 按服务合并待同步的变更，失败时以指数退避重试，超过MaxRetries后标记为FAILED
+type Syncer struct {
+	MaxRetries    int
+	RetryInterval time.Duration
+
+	sync   SyncFunc
+	queue  map[string]*task
+	status map[string]map[string]*SyncStatus
+	notify chan struct{}
+	lock   sync.Mutex
+}
+
+func NewSyncer(f SyncFunc) *Syncer {
+	return &Syncer{
+		MaxRetries:    DEFAULT_MAX_RETRIES,
+		RetryInterval: DEFAULT_RETRY_INTERVAL,
+		sync:          f,
+		queue:         make(map[string]*task),
+		status:        make(map[string]map[string]*SyncStatus),
+		notify:        make(chan struct{}, 1),
+	}
+}
+
+// Enqueue 记录服务的变更，同一服务未同步的变更合并为一次，
+// 未同步的CREATE后续的UPDATE仍视为CREATE，DELETE后的UPDATE忽略
+func (s *Syncer) Enqueue(domainProject, serviceId, action string, service *pb.MicroService) {
+	key := util.StringJoin([]string{domainProject, serviceId}, "/")
+
+	s.lock.Lock()
+	if old, ok := s.queue[key]; ok && action == string(pb.EVT_UPDATE) {
+		switch old.action {
+		case string(pb.EVT_CREATE), string(pb.EVT_DELETE):
+			action = old.action
+		}
+		if service == nil {
+			service = old.service
+		}
+	}
+	s.queue[key] = &task{
+		domainProject: domainProject,
+		serviceId:     serviceId,
+		action:        action,
+		service:       service,
+		next:          time.Now(),
+	}
+	s.setStatus(domainProject, &SyncStatus{
+		ServiceId: serviceId,
+		Action:    action,
+		Status:    STATUS_PENDING,
+	})
+	s.lock.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Syncer) setStatus(domainProject string, st *SyncStatus) {
+	m, ok := s.status[domainProject]
+	if !ok {
+		m = make(map[string]*SyncStatus)
+		s.status[domainProject] = m
+	}
+	if old, ok := m[st.ServiceId]; ok && st.LastSyncTime == 0 {
+		st.LastSyncTime = old.LastSyncTime
+	}
+	m[st.ServiceId] = st
+}
+
+// Status 查询服务的同步状态
+func (s *Syncer) Status(domainProject, serviceId string) *SyncStatus {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	st, ok := s.status[domainProject][serviceId]
+	if !ok {
+		return nil
+	}
+	c := *st
+	return &c
+}
+
+// List 查询project下所有服务的同步状态，status为空时返回全部
+func (s *Syncer) List(domainProject, status string) []*SyncStatus {
+	s.lock.Lock()
+	l := make([]*SyncStatus, 0, len(s.status[domainProject]))
+	for _, st := range s.status[domainProject] {
+		if len(status) > 0 && st.Status != status {
+			continue
+		}
+		c := *st
+		l = append(l, &c)
+	}
+	s.lock.Unlock()
+
+	sort.Slice(l, func(i, j int) bool {
+		return l[i].ServiceId < l[j].ServiceId
+	})
+	return l
+}
+
+func (s *Syncer) run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-s.notify:
+		case <-time.After(s.RetryInterval):
+		}
+		s.Process(time.Now())
+	}
+}
+
+// Process 同步所有到期的变更
+func (s *Syncer) Process(now time.Time) {
+	ctx := context.Background()
+
+	s.lock.Lock()
+	tasks := make([]*task, 0, len(s.queue))
+	for key, t := range s.queue {
+		if t.next.After(now) {
+			continue
+		}
+		delete(s.queue, key)
+		tasks = append(tasks, t)
+	}
+	s.lock.Unlock()
+
+	for _, t := range tasks {
+		record, err := s.record(ctx, t)
+		if err == nil && record == nil {
+			// 服务已删除，由DELETE事件同步
+			s.drop(t)
+			continue
+		}
+		if err == nil {
+			err = s.sync(ctx, record)
+		}
+		s.done(t, now, err)
+	}
+}
+
+func (s *Syncer) drop(t *task) {
+	key := util.StringJoin([]string{t.domainProject, t.serviceId}, "/")
+	s.lock.Lock()
+	if _, ok := s.queue[key]; !ok {
+		delete(s.status[t.domainProject], t.serviceId)
+	}
+	s.lock.Unlock()
+}
+
+func (s *Syncer) done(t *task, now time.Time, err error) {
+	key := util.StringJoin([]string{t.domainProject, t.serviceId}, "/")
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err == nil {
+		if t.action == string(pb.EVT_DELETE) {
+			if _, ok := s.queue[key]; !ok {
+				delete(s.status[t.domainProject], t.serviceId)
+			}
+			return
+		}
+		if _, ok := s.queue[key]; ok {
+			return
+		}
+		s.setStatus(t.domainProject, &SyncStatus{
+			ServiceId:    t.serviceId,
+			Action:       t.action,
+			Status:       STATUS_SYNCED,
+			LastSyncTime: now.Unix(),
+		})
+		return
+	}
+
+	t.attempts++
+	util.Logger().Errorf(err, "sync service %s %s to cmdb failed, attempts %d", key, t.action, t.attempts)
+	st := &SyncStatus{
+		ServiceId: t.serviceId,
+		Action:    t.action,
+		Status:    STATUS_PENDING,
+		Attempts:  t.attempts,
+		LastError: err.Error(),
+	}
+	if _, ok := s.queue[key]; ok {
+		// 同步期间有新的变更，以新的变更为准
+		return
+	}
+	if t.attempts > s.MaxRetries {
+		st.Status = STATUS_FAILED
+		s.setStatus(t.domainProject, st)
+		return
+	}
+	interval := s.RetryInterval << uint(t.attempts-1)
+	if interval > maxRetryInterval || interval <= 0 {
+		interval = maxRetryInterval
+	}
+	t.next = now.Add(interval)
+	s.queue[key] = t
+	s.setStatus(t.domainProject, st)
+}
+
+// record 组装推送的服务元数据，CREATE和UPDATE使用缓存中最新的服务信息
+func (s *Syncer) record(ctx context.Context, t *task) (*cmdb.ServiceRecord, error) {
+	record := &cmdb.ServiceRecord{
+		Action:        t.action,
+		DomainProject: t.domainProject,
+		ServiceId:     t.serviceId,
+		Service:       t.service,
+		Timestamp:     time.Now().Unix(),
+	}
+	if t.action == string(pb.EVT_DELETE) {
+		if t.service != nil {
+			record.Owner = t.service.Properties[PROP_OWNER]
+		}
+		return record, nil
+	}
+
+	service, err := serviceUtil.GetServiceInCache(ctx, t.domainProject, t.serviceId)
+	if err != nil {
+		return nil, err
+	}
+	if service == nil {
+		return nil, nil
+	}
+	record.Service = service
+	record.Owner = service.Properties[PROP_OWNER]
+
+	record.Tags, err = serviceUtil.GetTagsUtils(ctx, t.domainProject, t.serviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := serviceUtil.GetAllInstancesOfOneService(ctx, t.domainProject, t.serviceId)
+	if err != nil {
+		return nil, err
+	}
+	exist := make(map[string]struct{})
+	for _, instance := range instances {
+		for _, ep := range instance.Endpoints {
+			if _, ok := exist[ep]; ok {
+				continue
+			}
+			exist[ep] = struct{}{}
+			record.Endpoints = append(record.Endpoints, ep)
+		}
+	}
+	return record, nil
+}
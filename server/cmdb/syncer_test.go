@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmdb_test
+
+import (
+	"errors"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/cmdb"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	infra "github.com/apache/incubator-servicecomb-service-center/server/infra/cmdb"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/admission/buildin"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/quota/buildin"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/etcd"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/uuid/dynamic"
+	"github.com/apache/incubator-servicecomb-service-center/server/service"
+	"golang.org/x/net/context"
+	"testing"
+	"time"
+)
+
+func getContext() context.Context {
+	ctx := context.TODO()
+	ctx = util.SetContext(ctx, "domain", "default")
+	ctx = util.SetContext(ctx, "project", "default")
+	ctx = util.SetContext(ctx, "noCache", "1")
+	return ctx
+}
+
+func TestSyncer_Process(t *testing.T) {
+	serviceResource, instanceResource := service.AssembleResources()
+
+	respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+		Service: &pb.MicroService{
+			AppId:       "cmdb_group",
+			ServiceName: "cmdb_service",
+			Version:     "1.0.0",
+			Level:       "BACK",
+			Status:      pb.MS_UP,
+			Properties:  map[string]string{cmdb.PROP_OWNER: "alice"},
+		},
+		Tags: map[string]string{"team": "a"},
+	})
+	if err != nil || respCreate.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("create service failed, %v", respCreate.Response)
+	}
+	serviceId := respCreate.ServiceId
+
+	respReg, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+		Instance: &pb.MicroServiceInstance{
+			ServiceId: serviceId,
+			HostName:  "cmdb-host",
+			Endpoints: []string{"rest:127.0.0.1:8080"},
+			Status:    pb.MSI_UP,
+		},
+	})
+	if err != nil || respReg.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("register instance failed, %v", respReg.Response)
+	}
+
+	var (
+		records []*infra.ServiceRecord
+		fail    bool
+	)
+	s := cmdb.NewSyncer(func(ctx context.Context, record *infra.ServiceRecord) error {
+		if fail {
+			return errors.New("cmdb unavailable")
+		}
+		records = append(records, record)
+		return nil
+	})
+	s.MaxRetries = 1
+	s.RetryInterval = time.Second
+
+	// 合并未同步的变更
+	s.Enqueue("default/default", serviceId, string(pb.EVT_CREATE), nil)
+	s.Enqueue("default/default", serviceId, string(pb.EVT_UPDATE), nil)
+	if st := s.Status("default/default", serviceId); st == nil || st.Status != cmdb.STATUS_PENDING {
+		t.Fatalf("TestSyncer_Process failed, %v", st)
+	}
+	now := time.Now()
+	s.Process(now)
+	if len(records) != 1 || records[0].Action != string(pb.EVT_CREATE) {
+		t.Fatalf("TestSyncer_Process failed, %v", records)
+	}
+	r := records[0]
+	if r.Owner != "alice" || r.Tags["team"] != "a" || len(r.Endpoints) != 1 || r.Service.ServiceName != "cmdb_service" {
+		t.Fatalf("TestSyncer_Process failed, %v", r)
+	}
+	if st := s.Status("default/default", serviceId); st == nil || st.Status != cmdb.STATUS_SYNCED {
+		t.Fatalf("TestSyncer_Process failed, %v", st)
+	}
+
+	// 失败重试，超过次数后标记为FAILED
+	fail = true
+	s.Enqueue("default/default", serviceId, string(pb.EVT_UPDATE), nil)
+	now = time.Now()
+	s.Process(now)
+	st := s.Status("default/default", serviceId)
+	if st == nil || st.Status != cmdb.STATUS_PENDING || st.Attempts != 1 || len(st.LastError) == 0 {
+		t.Fatalf("TestSyncer_Process failed, %v", st)
+	}
+	s.Process(now)
+	if st := s.Status("default/default", serviceId); st.Attempts != 1 {
+		t.Fatalf("TestSyncer_Process failed, retry before interval, %v", st)
+	}
+	s.Process(now.Add(time.Second))
+	st = s.Status("default/default", serviceId)
+	if st.Status != cmdb.STATUS_FAILED || st.Attempts != 2 {
+		t.Fatalf("TestSyncer_Process failed, %v", st)
+	}
+	if l := s.List("default/default", cmdb.STATUS_FAILED); len(l) != 1 {
+		t.Fatalf("TestSyncer_Process failed, %v", l)
+	}
+
+	// 删除后不再保留状态
+	fail = false
+	s.Enqueue("default/default", serviceId, string(pb.EVT_DELETE), &pb.MicroService{ServiceId: serviceId})
+	s.Enqueue("default/default", serviceId, string(pb.EVT_UPDATE), nil)
+	s.Process(time.Now())
+	if len(records) != 2 || records[1].Action != string(pb.EVT_DELETE) {
+		t.Fatalf("TestSyncer_Process failed, %v", records)
+	}
+	if st := s.Status("default/default", serviceId); st != nil {
+		t.Fatalf("TestSyncer_Process failed, %v", st)
+	}
+}
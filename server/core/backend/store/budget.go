@@ -0,0 +1,342 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package store
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// 超出预算后淘汰到预算的80%，避免频繁淘汰
+	DEFAULT_BUDGET_LOW_WATERMARK = 0.8
+	DEFAULT_EVICT_WARN_INTERVAL  = time.Minute
+	// 估算KeyValue结构体本身占用的内存
+	kv_struct_size = 64
+)
+
+type tenantState int
+
+const (
+	tenantCached tenantState = iota
+	tenantEvicted
+	tenantReloading
+)
+
+// tenantUsage 单个租户(domain/project)在缓存中的内存占用
+type tenantUsage struct {
+	bytes int64
+	// 淘汰时的内存占用，用于判断重新加载时是否超出预算
+	evictedBytes int64
+	lastAccess   int64
+	state        tenantState
+	// 重新加载期间收到事件的key，这些key以事件为准
+	touched map[string]struct{}
+}
+
+func (t *tenantUsage) access() {
+	atomic.StoreInt64(&t.lastAccess, time.Now().UnixNano())
+}
+
+func sizeOfKv(kv *mvccpb.KeyValue) int64 {
+	return int64(len(kv.Key) + len(kv.Value) + kv_struct_size)
+}
+
+// tenantOf 解析key所属的租户，key没有包含完整的domain/project时返回空
+func tenantOf(root, key string) string {
+	if !strings.HasPrefix(key, root) {
+		return ""
+	}
+	rest := key[len(root):]
+	i := strings.Index(rest, "/")
+	if i <= 0 || i == len(rest)-1 {
+		return ""
+	}
+	j := strings.Index(rest[i+1:], "/")
+	if j < 0 {
+		return rest
+	}
+	return rest[:i+1+j]
+}
+
+func (c *KvCache) budgetEnabled() bool {
+	return c.budget > 0
+}
+
+func (c *KvCache) tenantOf(key string) string {
+	return tenantOf(c.owner.Cfg.Key, key)
+}
+
+// usage 需在持有写锁时调用
+func (c *KvCache) usage(tenant string) *tenantUsage {
+	u, ok := c.tenants[tenant]
+	if !ok {
+		u = &tenantUsage{lastAccess: time.Now().UnixNano()}
+		c.tenants[tenant] = u
+	}
+	return u
+}
+
+// account 统计kv变更带来的内存变化，需在持有写锁时调用
+func (c *KvCache) account(key string, prevKv, kv *mvccpb.KeyValue) {
+	var delta int64
+	if prevKv != nil {
+		delta -= sizeOfKv(prevKv)
+	}
+	if kv != nil {
+		delta += sizeOfKv(kv)
+	}
+	c.bytes += delta
+	if !c.budgetEnabled() {
+		return
+	}
+	if tenant := c.tenantOf(key); len(tenant) > 0 {
+		c.usage(tenant).bytes += delta
+	}
+}
+
+// skip 租户已被淘汰时，事件不再写入缓存，需在持有写锁时调用
+func (c *KvCache) skip(key string) bool {
+	if !c.budgetEnabled() {
+		return false
+	}
+	tenant := c.tenantOf(key)
+	if len(tenant) == 0 {
+		return false
+	}
+	u, ok := c.tenants[tenant]
+	if !ok {
+		return false
+	}
+	switch u.state {
+	case tenantEvicted:
+		return true
+	case tenantReloading:
+		u.touched[key] = struct{}{}
+	}
+	return false
+}
+
+// Touch 记录租户的访问时间，返回该key的数据是否不在缓存中(已被淘汰或正在重新加载)
+func (c *KvCache) Touch(key string) bool {
+	if !c.budgetEnabled() {
+		return false
+	}
+	tenant := c.tenantOf(key)
+
+	c.rwMux.RLock()
+	if len(tenant) == 0 {
+		// 跨租户查询，只要有租户被淘汰就不能使用缓存
+		evicted := c.evictedCount > 0
+		c.rwMux.RUnlock()
+		return evicted
+	}
+	u, ok := c.tenants[tenant]
+	if !ok {
+		c.rwMux.RUnlock()
+		return false
+	}
+	u.access()
+	state, fit := u.state, c.bytes+u.evictedBytes <= c.lowWatermark()
+	c.rwMux.RUnlock()
+
+	if state == tenantEvicted && fit {
+		c.owner.reload(tenant)
+	}
+	return state != tenantCached
+}
+
+func (c *KvCache) lowWatermark() int64 {
+	return int64(float64(c.budget) * DEFAULT_BUDGET_LOW_WATERMARK)
+}
+
+func (c *KvCache) overBudget() bool {
+	return c.budgetEnabled() && c.bytes > c.budget
+}
+
+// victims 按最近访问时间选出需要淘汰的租户，需在持有写锁时调用
+func (c *KvCache) victims() map[string]*tenantUsage {
+	candidates := make([]string, 0, len(c.tenants))
+	for tenant, u := range c.tenants {
+		if u.state != tenantCached || u.bytes <= 0 || tenant == protectedTenant {
+			continue
+		}
+		candidates = append(candidates, tenant)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return c.tenants[candidates[i]].lastAccess < c.tenants[candidates[j]].lastAccess
+	})
+
+	low, bytes := c.lowWatermark(), c.bytes
+	victims := make(map[string]*tenantUsage)
+	for _, tenant := range candidates {
+		if bytes <= low {
+			break
+		}
+		u := c.tenants[tenant]
+		bytes -= u.bytes
+		victims[tenant] = u
+	}
+	return victims
+}
+
+// evict 淘汰最久未访问的租户，直到内存占用回落到预算的低水位
+func (c *KvCache) evict() []string {
+	store := c.Lock()
+	if !c.overBudget() {
+		c.Unlock()
+		return nil
+	}
+
+	victims := c.victims()
+	if len(victims) == 0 {
+		warn := time.Now().Sub(c.lastEvictWarn) >= DEFAULT_EVICT_WARN_INTERVAL
+		if warn {
+			c.lastEvictWarn = time.Now()
+		}
+		bytes := c.bytes
+		c.Unlock()
+		if warn {
+			util.Logger().Warnf(nil, "cache %s is over budget(%d > %d bytes), but no tenant can be evicted",
+				c.owner.Cfg.Key, bytes, c.budget)
+		}
+		return nil
+	}
+
+	for key, kv := range store {
+		if _, ok := victims[c.tenantOf(key)]; !ok {
+			continue
+		}
+		c.bytes -= sizeOfKv(kv)
+		delete(store, key)
+	}
+
+	tenants := make([]string, 0, len(victims))
+	for tenant, u := range victims {
+		u.evictedBytes, u.bytes, u.state = u.bytes, 0, tenantEvicted
+		c.evictedCount++
+		tenants = append(tenants, tenant)
+	}
+	c.Unlock()
+
+	util.Logger().Warnf(nil, "cache %s is over budget(%d bytes), evict %d cold tenant(s): %v",
+		c.owner.Cfg.Key, c.budget, len(tenants), tenants)
+	return tenants
+}
+
+// beginReload 标记租户进入重新加载状态，返回false表示无需加载
+func (c *KvCache) beginReload(tenant string) bool {
+	c.Lock()
+	defer c.Unlock()
+	u, ok := c.tenants[tenant]
+	if !ok || u.state != tenantEvicted {
+		return false
+	}
+	u.state = tenantReloading
+	u.touched = make(map[string]struct{})
+	return true
+}
+
+// endReload 写入从etcd重新加载的数据，重新加载期间已由事件更新过的key以事件为准；
+// 加载失败(kvs为nil)时清除加载期间写入的数据，恢复为淘汰状态等待下次访问
+func (c *KvCache) endReload(tenant string, kvs []*mvccpb.KeyValue) (added []*mvccpb.KeyValue, ok bool) {
+	store := c.Lock()
+	defer c.Unlock()
+	u, exist := c.tenants[tenant]
+	if !exist || u.state != tenantReloading {
+		return nil, false
+	}
+	defer u.access()
+
+	if kvs == nil {
+		for key := range u.touched {
+			if kv, ok := store[key]; ok {
+				c.account(key, kv, nil)
+				delete(store, key)
+			}
+		}
+		u.state, u.touched = tenantEvicted, nil
+		return nil, false
+	}
+
+	added = make([]*mvccpb.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		if _, ok := u.touched[key]; ok {
+			continue
+		}
+		if _, ok := store[key]; ok {
+			continue
+		}
+		store[key] = kv
+		c.account(key, nil, kv)
+		added = append(added, kv)
+	}
+	u.state, u.touched, u.evictedBytes = tenantCached, nil, 0
+	c.evictedCount--
+	return added, true
+}
+
+// Evicted 租户是否已被淘汰，淘汰后的事件不再建立索引
+func (c *KvCache) Evicted(key string) bool {
+	return c.stateOf(key) == tenantEvicted
+}
+
+func (c *KvCache) stateOf(key string) (state tenantState) {
+	if !c.budgetEnabled() {
+		return tenantCached
+	}
+	tenant := c.tenantOf(key)
+	c.rwMux.RLock()
+	if u, ok := c.tenants[tenant]; ok {
+		state = u.state
+	}
+	c.rwMux.RUnlock()
+	return
+}
+
+// cached 租户数据是否完整地保存在缓存中，需在持有锁时调用
+func (c *KvCache) cached(key string) bool {
+	if !c.budgetEnabled() {
+		return true
+	}
+	u, ok := c.tenants[c.tenantOf(key)]
+	return !ok || u.state == tenantCached
+}
+
+// Bytes 估算的缓存内存占用
+func (c *KvCache) Bytes() (b int64) {
+	c.rwMux.RLock()
+	b = c.bytes
+	c.rwMux.RUnlock()
+	return
+}
+
+// EvictedTenants 被淘汰的租户数
+func (c *KvCache) EvictedTenants() (n int) {
+	c.rwMux.RLock()
+	n = c.evictedCount
+	c.rwMux.RUnlock()
+	return
+}
+
+var protectedTenant = apt.REGISTRY_DOMAIN + "/" + apt.REGISTRY_PROJECT
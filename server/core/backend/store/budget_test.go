@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package store
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"strings"
+	"testing"
+)
+
+func newBudgetCache(budget int64) *KvCache {
+	cfg := DefaultKvCacherConfig()
+	cfg.Key, cfg.Budget = "/r/", budget
+	c := &KvCacher{Cfg: cfg}
+	c.cache = NewKvCache(c, 10)
+	return c.cache
+}
+
+func budgetKv(key string, valueSize int) *mvccpb.KeyValue {
+	return &mvccpb.KeyValue{Key: []byte(key), Value: []byte(strings.Repeat("v", valueSize))}
+}
+
+// putKv 经cacher的事件处理写入缓存，kv为nil时删除
+func putKv(c *KvCache, key string, kv *mvccpb.KeyValue) {
+	evt := &Event{Key: key, Type: proto.EVT_CREATE, Object: kv}
+	switch {
+	case kv == nil:
+		evt.Type, evt.Object = proto.EVT_DELETE, &mvccpb.KeyValue{Key: []byte(key)}
+	case c.Have(key):
+		evt.Type = proto.EVT_UPDATE
+	}
+	c.owner.onEvents([]*Event{evt})
+}
+
+func TestTenantOf(t *testing.T) {
+	for key, tenant := range map[string]string{
+		"/r/d/p/svc/1": "d/p",
+		"/r/d/p/":      "d/p",
+		"/r/d/p":       "d/p",
+		"/r/d/":        "",
+		"/r/d":         "",
+		"/r/":          "",
+		"/x/d/p/svc":   "",
+	} {
+		if tenantOf("/r/", key) != tenant {
+			t.Fatalf("TestTenantOf failed, tenant of %s should be '%s' but '%s'", key, tenant, tenantOf("/r/", key))
+		}
+	}
+}
+
+func TestKvCacheAccount(t *testing.T) {
+	c := newBudgetCache(1 << 20)
+	a1, a2, b1 := budgetKv("/r/a/p/1", 100), budgetKv("/r/a/p/2", 200), budgetKv("/r/b/p/1", 300)
+	putKv(c, "/r/a/p/1", a1)
+	putKv(c, "/r/a/p/2", a2)
+	putKv(c, "/r/b/p/1", b1)
+	if c.Bytes() != sizeOfKv(a1)+sizeOfKv(a2)+sizeOfKv(b1) {
+		t.Fatalf("TestKvCacheAccount failed, bytes %d", c.Bytes())
+	}
+	if c.tenants["a/p"].bytes != sizeOfKv(a1)+sizeOfKv(a2) || c.tenants["b/p"].bytes != sizeOfKv(b1) {
+		t.Fatalf("TestKvCacheAccount failed, tenant bytes %d, %d", c.tenants["a/p"].bytes, c.tenants["b/p"].bytes)
+	}
+
+	// 更新按差值计算，删除后归零
+	a1New := budgetKv("/r/a/p/1", 500)
+	putKv(c, "/r/a/p/1", a1New)
+	if c.tenants["a/p"].bytes != sizeOfKv(a1New)+sizeOfKv(a2) {
+		t.Fatalf("TestKvCacheAccount failed, update tenant bytes %d", c.tenants["a/p"].bytes)
+	}
+	putKv(c, "/r/a/p/1", nil)
+	putKv(c, "/r/a/p/2", nil)
+	if c.tenants["a/p"].bytes != 0 || c.Bytes() != sizeOfKv(b1) {
+		t.Fatalf("TestKvCacheAccount failed, delete tenant bytes %d, total %d", c.tenants["a/p"].bytes, c.Bytes())
+	}
+
+	// 不完整的key只计入总量
+	putKv(c, "/r/x", budgetKv("/r/x", 10))
+	if len(c.tenants) != 2 || c.Bytes() != sizeOfKv(b1)+sizeOfKv(budgetKv("/r/x", 10)) {
+		t.Fatalf("TestKvCacheAccount failed, key without tenant, %d tenants, %d bytes", len(c.tenants), c.Bytes())
+	}
+
+	// 未设置预算时不按租户统计
+	c = newBudgetCache(0)
+	putKv(c, "/r/a/p/1", a1)
+	if c.Bytes() != sizeOfKv(a1) || len(c.tenants) != 0 || c.Touch("/r/a/p/1") {
+		t.Fatalf("TestKvCacheAccount failed, budget disabled, %d tenants, %d bytes", len(c.tenants), c.Bytes())
+	}
+}
+
+func TestKvCacheEvict(t *testing.T) {
+	protected := "/r/" + protectedTenant + "/1"
+	keys := map[string]string{"a": "/r/a/p/1", "b": "/r/b/p/1", "c": "/r/c/p/1"}
+	size := sizeOfKv(budgetKv(keys["a"], 1000))
+
+	// 4份数据，预算3.5份，低水位2.8份
+	c := newBudgetCache(size*7/2 + 1)
+	putKv(c, protected, budgetKv(protected, 1000-len(protected)+len(keys["a"])))
+	for _, name := range []string{"a", "b", "c"} {
+		putKv(c, keys[name], budgetKv(keys[name], 1000))
+	}
+	if !c.overBudget() {
+		t.Fatalf("TestKvCacheEvict failed, should be over budget, %d bytes", c.Bytes())
+	}
+	// 访问顺序: 自身租户最久未访问，其次a、b、c
+	for i, tenant := range []string{protectedTenant, "a/p", "b/p", "c/p"} {
+		c.tenants[tenant].lastAccess = int64(i + 1)
+	}
+
+	// 按最近访问时间淘汰a、b，回落到低水位后停止，自身租户不淘汰
+	evicted := c.evict()
+	if len(evicted) != 2 || !c.Evicted(keys["a"]) || !c.Evicted(keys["b"]) || c.Evicted(keys["c"]) || c.Evicted(protected) {
+		t.Fatalf("TestKvCacheEvict failed, evicted %v", evicted)
+	}
+	if c.Bytes() != 2*size || c.Bytes() > c.lowWatermark() || c.EvictedTenants() != 2 {
+		t.Fatalf("TestKvCacheEvict failed, %d bytes, %d evicted tenants", c.Bytes(), c.EvictedTenants())
+	}
+	if c.Have(keys["a"]) || c.Have(keys["b"]) || !c.Have(keys["c"]) || !c.Have(protected) {
+		t.Fatalf("TestKvCacheEvict failed, evicted data still in cache")
+	}
+	if u := c.tenants["a/p"]; u.bytes != 0 || u.evictedBytes != size {
+		t.Fatalf("TestKvCacheEvict failed, evicted tenant bytes %d, %d", u.bytes, u.evictedBytes)
+	}
+	// 淘汰后不再缓存该租户的事件，跨租户查询不能使用缓存
+	putKv(c, "/r/a/p/2", budgetKv("/r/a/p/2", 10))
+	if c.Have("/r/a/p/2") || !c.Touch("/r") || c.Touch(keys["c"]) {
+		t.Fatalf("TestKvCacheEvict failed, event of evicted tenant cached")
+	}
+	if evicted := c.evict(); len(evicted) != 0 {
+		t.Fatalf("TestKvCacheEvict failed, evict again under budget, %v", evicted)
+	}
+
+	// 重新加载期间收到事件的key以事件为准
+	if !c.beginReload("a/p") || c.beginReload("c/p") {
+		t.Fatalf("TestKvCacheEvict failed, begin reload")
+	}
+	event := budgetKv("/r/a/p/2", 20)
+	putKv(c, "/r/a/p/2", event)
+	added, ok := c.endReload("a/p", []*mvccpb.KeyValue{budgetKv(keys["a"], 1000), budgetKv("/r/a/p/2", 10)})
+	if !ok || len(added) != 1 || string(added[0].Key) != keys["a"] || c.Evicted(keys["a"]) || c.EvictedTenants() != 1 {
+		t.Fatalf("TestKvCacheEvict failed, end reload, added %d", len(added))
+	}
+	if c.tenants["a/p"].bytes != size+sizeOfKv(event) || c.Bytes() != 3*size+sizeOfKv(event) {
+		t.Fatalf("TestKvCacheEvict failed, reload bytes %d, %d", c.tenants["a/p"].bytes, c.Bytes())
+	}
+
+	// 加载失败时清除加载期间写入的数据，恢复为淘汰状态
+	c.beginReload("b/p")
+	putKv(c, "/r/b/p/2", budgetKv("/r/b/p/2", 10))
+	if _, ok := c.endReload("b/p", nil); ok || !c.Evicted(keys["b"]) || c.Have("/r/b/p/2") {
+		t.Fatalf("TestKvCacheEvict failed, reload failure should restore evicted state")
+	}
+	if c.tenants["b/p"].bytes != 0 || c.Bytes() != 3*size+sizeOfKv(event) {
+		t.Fatalf("TestKvCacheEvict failed, reload failure bytes %d, %d", c.tenants["b/p"].bytes, c.Bytes())
+	}
+}
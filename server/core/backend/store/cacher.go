@@ -20,9 +20,11 @@ import (
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
 	"github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
 	"github.com/coreos/etcd/mvcc/mvccpb"
 	"golang.org/x/net/context"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -41,6 +43,7 @@ type Cache interface {
 	Version() int64
 	Data(interface{}) interface{}
 	Have(interface{}) bool
+	Size() int
 }
 
 type Cacher interface {
@@ -65,6 +68,10 @@ func (n *nullCache) Have(interface{}) bool {
 	return false
 }
 
+func (n *nullCache) Size() int {
+	return 0
+}
+
 type nullCacher struct {
 }
 
@@ -91,6 +98,12 @@ type KvCache struct {
 	rwMux       sync.RWMutex
 	lastRefresh time.Time
 	lastMaxSize int
+	// 内存预算(字节)，0表示不限制
+	budget        int64
+	bytes         int64
+	tenants       map[string]*tenantUsage
+	evictedCount  int
+	lastEvictWarn time.Time
 }
 
 func (c *KvCache) Version() int64 {
@@ -139,7 +152,13 @@ func (c *KvCache) Unlock() {
 		c.lastMaxSize = c.size
 		c.lastRefresh = time.Now()
 	}
+	over := c.overBudget()
+	ReportCacheUsage(c.owner.Cfg.Type, c.bytes, c.evictedCount)
 	c.rwMux.Unlock()
+
+	if over {
+		c.owner.notifyEvict()
+	}
 }
 
 func (c *KvCache) Size() (l int) {
@@ -161,13 +180,18 @@ type KvCacher struct {
 	once    sync.Once
 	cache   *KvCache
 	goroute *util.GoRoutine
+	evictCh chan struct{}
+
+	resync    resyncState
+	resyncing int32
 }
 
 func (c *KvCacher) needList() bool {
 	rev := c.lw.Revision()
 	defer func() { c.lastRev = rev }()
 
-	if rev == 0 {
+	// 与etcd不一致时强制全量重新list
+	if atomic.CompareAndSwapInt32(&c.resyncing, 1, 0) || rev == 0 {
 		c.noEventInterval = 0
 		return true
 	}
@@ -315,7 +339,12 @@ func (c *KvCacher) filter(rev int64, items []*mvccpb.KeyValue) []*Event {
 
 	newStore := make(map[string]*mvccpb.KeyValue)
 	for _, kv := range items {
-		newStore[util.BytesToStringWithNoCopy(kv.Key)] = kv
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		if !cache.cached(key) {
+			// 被淘汰的租户不缓存，也不因为重新list产生事件
+			continue
+		}
+		newStore[key] = kv
 	}
 	filterStopCh := make(chan struct{})
 	eventsCh := make(chan [event_block_size]*Event, max/event_block_size+2)
@@ -428,6 +457,17 @@ func (c *KvCacher) onEvents(evts []*Event) {
 		key := util.BytesToStringWithNoCopy(kv.Key)
 		prevKv, ok := store[key]
 
+		if cache.skip(key) {
+			// 租户已被淘汰，只通知不缓存
+			kvEvts[idx] = &KvEvent{
+				Revision: evt.Revision,
+				Action:   evt.Type,
+				KV:       kv,
+			}
+			idx++
+			continue
+		}
+
 		switch evt.Type {
 		case proto.EVT_CREATE, proto.EVT_UPDATE:
 			util.Logger().Debugf("sync %s event and notify watcher, cache key %s, %+v", evt.Type, key, kv)
@@ -445,7 +485,9 @@ func (c *KvCacher) onEvents(evts []*Event) {
 				t = proto.EVT_UPDATE
 			}
 
-			store[key] = kv
+			cached := c.encode(kv)
+			store[key] = cached
+			cache.account(key, prevKv, cached)
 			kvEvts[idx] = &KvEvent{
 				Revision: evt.Revision,
 				Action:   t,
@@ -460,6 +502,7 @@ func (c *KvCacher) onEvents(evts []*Event) {
 
 			util.Logger().Debugf("sync %s event and notify watcher, remove key %s, %+v", evt.Type, key, kv)
 			delete(store, key)
+			cache.account(key, prevKv, nil)
 			kvEvts[idx] = &KvEvent{
 				Revision: evt.Revision,
 				Action:   evt.Type,
@@ -473,6 +516,22 @@ func (c *KvCacher) onEvents(evts []*Event) {
 	c.onKvEvents(kvEvts[:idx])
 }
 
+// encode 按配置的存储形式转换缓存值，CREATE/UPDATE事件中仍为原始值
+func (c *KvCacher) encode(kv *mvccpb.KeyValue) *mvccpb.KeyValue {
+	if c.Cfg.Serializer == nil {
+		return kv
+	}
+	value, err := c.Cfg.Serializer.Encode(kv.Value)
+	if err != nil {
+		util.Logger().Warnf(err, "encode cache value by %s failed, keep the raw value, key %s",
+			c.Cfg.Serializer.Name(), util.BytesToStringWithNoCopy(kv.Key))
+		return kv
+	}
+	encoded := *kv
+	encoded.Value = value
+	return &encoded
+}
+
 func (c *KvCacher) onKvEvents(evts []*KvEvent) {
 	if c.Cfg.OnEvent == nil {
 		return
@@ -508,6 +567,78 @@ func (c *KvCacher) run() {
 	})
 
 	c.goroute.Do(c.deferHandle)
+
+	if c.cache.budgetEnabled() {
+		c.goroute.Do(c.handleEvict)
+	}
+
+	if c.Cfg.VerifyInterval > 0 {
+		c.goroute.Do(c.verify)
+	}
+}
+
+func (c *KvCacher) notifyEvict() {
+	select {
+	case c.evictCh <- struct{}{}:
+	default:
+	}
+}
+
+// handleEvict 缓存超出内存预算时淘汰冷租户
+func (c *KvCacher) handleEvict(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-c.evictCh:
+			tenants := c.cache.evict()
+			if len(tenants) == 0 {
+				continue
+			}
+			ReportCacheEviction(c.Cfg.Type, len(tenants))
+			if c.Cfg.OnEvict == nil {
+				continue
+			}
+			for _, tenant := range tenants {
+				c.Cfg.OnEvict(c.Cfg.Key + tenant + "/")
+			}
+		}
+	}
+}
+
+// reload 被淘汰的租户再次被访问且预算允许时，从etcd重新加载到缓存
+func (c *KvCacher) reload(tenant string) {
+	if !c.cache.beginReload(tenant) {
+		return
+	}
+	c.goroute.Do(func(stopCh <-chan struct{}) {
+		prefix := c.Cfg.Key + tenant + "/"
+		ctx, cancel := context.WithTimeout(context.Background(), c.Cfg.Timeout)
+		defer cancel()
+
+		var kvs []*mvccpb.KeyValue
+		resp, err := c.lw.Client.Do(ctx, registry.GET, registry.WithStrKey(prefix), registry.WithPrefix())
+		if err != nil {
+			util.Logger().Errorf(err, "reload tenant %s to cache %s failed", tenant, c.Cfg.Key)
+		} else {
+			kvs = make([]*mvccpb.KeyValue, 0, len(resp.Kvs))
+			for _, kv := range resp.Kvs {
+				kvs = append(kvs, c.encode(kv))
+			}
+		}
+
+		added, ok := c.cache.endReload(tenant, kvs)
+		if !ok {
+			if c.Cfg.OnEvict != nil {
+				c.Cfg.OnEvict(prefix)
+			}
+			return
+		}
+		util.Logger().Infof("reload tenant %s to cache %s, %d items", tenant, c.Cfg.Key, len(added))
+		if c.Cfg.OnReload != nil {
+			c.Cfg.OnReload(added)
+		}
+	})
 }
 
 func (c *KvCacher) Cache() Cache {
@@ -535,6 +666,8 @@ func NewKvCache(c *KvCacher, size int) *KvCache {
 		lastMaxSize: size,
 		store:       make(map[string]*mvccpb.KeyValue, size),
 		lastRefresh: time.Now(),
+		budget:      c.Cfg.Budget,
+		tenants:     make(map[string]*tenantUsage),
 	}
 }
 
@@ -552,6 +685,7 @@ func NewKvCacher(opts ...KvCacherCfgOption) *KvCacher {
 			Key:    cfg.Key,
 		},
 		goroute: util.NewGo(make(chan struct{})),
+		evictCh: make(chan struct{}, 1),
 	}
 	cacher.cache = NewKvCache(cacher, cfg.InitSize)
 	return cacher
@@ -17,7 +17,6 @@
 package store
 
 import (
-	"encoding/json"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	"github.com/coreos/etcd/mvcc/mvccpb"
@@ -106,7 +105,7 @@ func (iedh *InstanceEventDeferHandler) OnCondition(cache Cache, evts []*Event) b
 			iedh.deferCh <- evt
 		case pb.EVT_DELETE:
 			var instance pb.MicroServiceInstance
-			err := json.Unmarshal(kv.Value, &instance)
+			err := Unmarshal(kv.Value, &instance)
 			if err != nil {
 				util.Logger().Errorf(err, "unmarshal instance file failed, key is %s", key)
 				continue
@@ -151,6 +150,12 @@ func (iedh *InstanceEventDeferHandler) check(stopCh <-chan struct{}) {
 	}
 }
 
+func (iedh *InstanceEventDeferHandler) Enabled() bool {
+	iedh.mux.RLock()
+	defer iedh.mux.RUnlock()
+	return iedh.enabled
+}
+
 func (iedh *InstanceEventDeferHandler) HandleChan() <-chan *Event {
 	return iedh.deferCh
 }
@@ -67,6 +67,12 @@ func (i *Indexer) Search(ctx context.Context, opts ...registry.PluginOpOption) (
 		return backend.Registry().Do(ctx, opts...)
 	}
 
+	if c, ok := i.Cache().(*KvCache); ok && c.Touch(key) {
+		util.Logger().Debugf("tenant of key %s is evicted from %s cache, request etcd server",
+			key, i.cacheType)
+		return backend.Registry().Do(ctx, opts...)
+	}
+
 	if op.Prefix {
 		resp, err := i.searchPrefixKeyWithCache(ctx, op)
 		if err != nil {
@@ -204,6 +210,9 @@ func (i *Indexer) buildIndex() {
 				case pb.EVT_DELETE:
 					i.deletePrefixKey(prefix, key)
 				default:
+					if c, ok := i.Cache().(*KvCache); ok && c.Evicted(key) {
+						break
+					}
 					i.addPrefixKey(prefix, key)
 				}
 				i.prefixLock.Unlock()
@@ -275,6 +284,43 @@ func (i *Indexer) deletePrefixKey(prefix, key string) {
 	delete(m, key)
 }
 
+// OnEvict 租户被淘汰出缓存后，删除该租户下的所有索引
+func (i *Indexer) OnEvict(tenantPrefix string) {
+	i.prefixLock.Lock()
+	i.purgePrefixKey(tenantPrefix)
+	for key := tenantPrefix; ; {
+		prefix := key[:strings.LastIndex(key[:len(key)-1], "/")+1]
+		m, ok := i.prefixIndex[prefix]
+		if !ok {
+			break
+		}
+		delete(m, key)
+		if _, ok := defaultRootKeys[prefix]; ok || len(m) > 0 {
+			break
+		}
+		delete(i.prefixIndex, prefix)
+		key = prefix
+	}
+	i.prefixLock.Unlock()
+}
+
+// OnReload 租户重新加载到缓存后，重建索引
+func (i *Indexer) OnReload(kvs []*mvccpb.KeyValue) {
+	i.prefixLock.Lock()
+	for _, kv := range kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		i.addPrefixKey(key[:strings.LastIndex(key[:len(key)-1], "/")+1], key)
+	}
+	i.prefixLock.Unlock()
+}
+
+func (i *Indexer) purgePrefixKey(key string) {
+	for k := range i.prefixIndex[key] {
+		i.purgePrefixKey(k)
+	}
+	delete(i.prefixIndex, key)
+}
+
 func (i *Indexer) Run() {
 	i.prefixLock.Lock()
 	if !i.isClose {
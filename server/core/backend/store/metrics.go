@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package store
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	cacheMemoryBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "cache",
+			Name:      "memory_bytes",
+			Help:      "Estimated memory usage of the registry cache",
+		}, []string{"type"})
+
+	cacheEvictedTenants = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "cache",
+			Name:      "evicted_tenants",
+			Help:      "Number of tenants evicted from the registry cache",
+		}, []string{"type"})
+
+	cacheEvictions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "cache",
+			Name:      "eviction_total",
+			Help:      "Counter of tenants evicted from the registry cache because of over budget",
+		}, []string{"type"})
+
+	cacheDivergedKeys = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "cache",
+			Name:      "diverged_keys",
+			Help:      "Number of keys diverged from the registry found by the last cache verification",
+		}, []string{"type"})
+
+	cacheResyncs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "cache",
+			Name:      "resync_total",
+			Help:      "Counter of the registry cache full resyncs because of divergence",
+		}, []string{"type"})
+)
+
+func init() {
+	prometheus.MustRegister(cacheMemoryBytes, cacheEvictedTenants, cacheEvictions, cacheDivergedKeys, cacheResyncs)
+}
+
+func ReportCacheUsage(t StoreType, bytes int64, evicted int) {
+	cacheMemoryBytes.WithLabelValues(t.String()).Set(float64(bytes))
+	cacheEvictedTenants.WithLabelValues(t.String()).Set(float64(evicted))
+}
+
+func ReportCacheEviction(t StoreType, n int) {
+	cacheEvictions.WithLabelValues(t.String()).Add(float64(n))
+}
+
+func ReportCacheDivergence(t StoreType, n int) {
+	cacheDivergedKeys.WithLabelValues(t.String()).Set(float64(n))
+}
+
+func ReportCacheResync(t StoreType) {
+	cacheResyncs.WithLabelValues(t.String()).Inc()
+}
@@ -18,6 +18,7 @@ package store
 
 import (
 	"fmt"
+	"github.com/coreos/etcd/mvcc/mvccpb"
 	"time"
 )
 
@@ -28,6 +29,7 @@ const (
 )
 
 type KvCacherCfg struct {
+	Type               StoreType
 	Key                string
 	InitSize           int
 	NoEventMaxInterval int
@@ -35,15 +37,33 @@ type KvCacherCfg struct {
 	Period             time.Duration
 	OnEvent            KvEventFunc
 	DeferHander        DeferHandler
+	// Budget 缓存的内存预算(字节)，超出后按租户淘汰，0表示不限制
+	Budget   int64
+	OnEvict  func(prefix string)
+	OnReload func(kvs []*mvccpb.KeyValue)
+	// Serializer 缓存值的存储形式，为空时保持etcd中的原始值
+	Serializer Serializer
+	// VerifyInterval 与etcd交叉检查的周期，0表示不检查
+	VerifyInterval time.Duration
+	// MaxResyncs 每小时因不一致重新list的次数上限
+	MaxResyncs int
 }
 
 func (cfg KvCacherCfg) String() string {
-	return fmt.Sprintf("{key: %s, timeout: %s, period: %s}",
-		cfg.Key, cfg.Timeout, cfg.Period)
+	serializer := SERIALIZER_JSON
+	if cfg.Serializer != nil {
+		serializer = cfg.Serializer.Name()
+	}
+	return fmt.Sprintf("{key: %s, timeout: %s, period: %s, budget: %d, serializer: %s, verify: %s}",
+		cfg.Key, cfg.Timeout, cfg.Period, cfg.Budget, serializer, cfg.VerifyInterval)
 }
 
 type KvCacherCfgOption func(*KvCacherCfg)
 
+func WithType(t StoreType) KvCacherCfgOption {
+	return func(cfg *KvCacherCfg) { cfg.Type = t }
+}
+
 func WithKey(key string) KvCacherCfgOption {
 	return func(cfg *KvCacherCfg) { cfg.Key = key }
 }
@@ -68,11 +88,32 @@ func WithDeferHandler(h DeferHandler) KvCacherCfgOption {
 	return func(cfg *KvCacherCfg) { cfg.DeferHander = h }
 }
 
+func WithBudget(bytes int64) KvCacherCfgOption {
+	return func(cfg *KvCacherCfg) { cfg.Budget = bytes }
+}
+
+func WithEvictFunc(f func(prefix string)) KvCacherCfgOption {
+	return func(cfg *KvCacherCfg) { cfg.OnEvict = f }
+}
+
+func WithReloadFunc(f func(kvs []*mvccpb.KeyValue)) KvCacherCfgOption {
+	return func(cfg *KvCacherCfg) { cfg.OnReload = f }
+}
+
+func WithSerializer(s Serializer) KvCacherCfgOption {
+	return func(cfg *KvCacherCfg) { cfg.Serializer = s }
+}
+
+func WithVerify(interval time.Duration, maxResyncs int) KvCacherCfgOption {
+	return func(cfg *KvCacherCfg) { cfg.VerifyInterval, cfg.MaxResyncs = interval, maxResyncs }
+}
+
 func DefaultKvCacherConfig() KvCacherCfg {
 	return KvCacherCfg{
 		Key:                "/",
 		Timeout:            DEFAULT_LISTWATCH_TIMEOUT,
 		Period:             time.Second,
 		NoEventMaxInterval: DEFAULT_MAX_NO_EVENT_INTERVAL,
+		MaxResyncs:         DEFAULT_MAX_RESYNCS_PER_HOUR,
 	}
 }
@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package store
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"golang.org/x/net/context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DEFAULT_VERIFY_INTERVAL      = 5 * time.Minute
+	DEFAULT_MAX_RESYNCS_PER_HOUR = 3
+	resync_window                = time.Hour
+)
+
+// ResyncStat 缓存与etcd交叉检查的结果
+type ResyncStat struct {
+	Type StoreType `json:"type"`
+	// Diverged 最近一次检查确认不一致的key数
+	Diverged int `json:"diverged"`
+	// Resyncs 最近一小时内重新list的次数
+	Resyncs int `json:"resyncs"`
+	// Limited 已达到重新list的次数上限，不一致未修复
+	Limited    bool  `json:"limited,omitempty"`
+	LastResync int64 `json:"lastResync,omitempty"`
+}
+
+type resyncState struct {
+	lock    sync.RWMutex
+	suspect map[string]int64
+	history []time.Time
+	stat    ResyncStat
+}
+
+// diff 对比etcd中的key及ModRevision与缓存，返回不一致的key和etcd中的ModRevision，
+// etcd中已不存在的key为-1；被淘汰租户的key不参与对比
+func (c *KvCache) diff(kvs []*mvccpb.KeyValue) map[string]int64 {
+	diverged := make(map[string]int64)
+	c.rwMux.RLock()
+	defer c.rwMux.RUnlock()
+	keys := make(map[string]struct{}, len(kvs))
+	for _, kv := range kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		if !c.cached(key) {
+			continue
+		}
+		keys[key] = struct{}{}
+		if old, ok := c.store[key]; !ok || old.ModRevision != kv.ModRevision {
+			diverged[key] = kv.ModRevision
+		}
+	}
+	for key := range c.store {
+		if _, ok := keys[key]; !ok && c.cached(key) {
+			diverged[key] = -1
+		}
+	}
+	return diverged
+}
+
+// confirm 同一个key在连续两次检查中都不一致且etcd中未变化时才确认，
+// 避免把检查期间正常到达的事件误判为丢失
+func (s *resyncState) confirm(diverged map[string]int64) int {
+	n := 0
+	for key, rev := range diverged {
+		if old, ok := s.suspect[key]; ok && old == rev {
+			n++
+		}
+	}
+	s.suspect = diverged
+	return n
+}
+
+// allow 限制每小时重新list的次数
+func (s *resyncState) allow(now time.Time, max int) bool {
+	history := s.history[:0]
+	for _, t := range s.history {
+		if now.Sub(t) < resync_window {
+			history = append(history, t)
+		}
+	}
+	s.history = history
+	if max > 0 && len(s.history) >= max {
+		return false
+	}
+	s.history = append(s.history, now)
+	return true
+}
+
+func (s *resyncState) Stat() ResyncStat {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	stat := s.stat
+	stat.Resyncs = 0
+	for _, t := range s.history {
+		if time.Since(t) < resync_window {
+			stat.Resyncs++
+		}
+	}
+	return stat
+}
+
+func (c *KvCacher) verifySkipped() bool {
+	if c.lw.Revision() == 0 {
+		// 等待重新list
+		return true
+	}
+	// 自我保护期间缓存中保留了已删除的实例
+	h, ok := c.Cfg.DeferHander.(*InstanceEventDeferHandler)
+	return ok && h.Enabled()
+}
+
+// verify 周期对比缓存与etcd，watch静默丢失事件(如compact、网络异常)时
+// 缓存会一直不一致，确认后在下一次list时全量重新同步
+func (c *KvCacher) verify(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(c.Cfg.VerifyInterval):
+			if c.verifySkipped() {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), c.Cfg.Timeout)
+			resp, err := c.lw.Client.Do(ctx, registry.GET,
+				registry.WithStrKey(c.Cfg.Key),
+				registry.WithPrefix(),
+				registry.WithKeyOnly())
+			cancel()
+			if err != nil {
+				util.Logger().Errorf(err, "verify cache %s failed", c.Cfg.Key)
+				continue
+			}
+			c.checkDivergence(c.cache.diff(resp.Kvs), time.Now())
+		}
+	}
+}
+
+func (c *KvCacher) checkDivergence(diverged map[string]int64, now time.Time) {
+	s := &c.resync
+	s.lock.Lock()
+	n := s.confirm(diverged)
+	s.stat.Type, s.stat.Diverged, s.stat.Limited = c.Cfg.Type, n, false
+	if n == 0 {
+		s.lock.Unlock()
+		ReportCacheDivergence(c.Cfg.Type, 0)
+		return
+	}
+	allowed := s.allow(now, c.Cfg.MaxResyncs)
+	if allowed {
+		s.stat.LastResync = now.Unix()
+		s.suspect = nil
+	} else {
+		s.stat.Limited = true
+	}
+	s.lock.Unlock()
+
+	ReportCacheDivergence(c.Cfg.Type, n)
+	if !allowed {
+		util.Logger().Errorf(nil, "cache %s diverged from registry, %d key(s), but resyncs reach the limit %d per hour",
+			c.Cfg.Key, n, c.Cfg.MaxResyncs)
+		return
+	}
+	util.Logger().Warnf(nil, "cache %s diverged from registry, %d key(s), resync it", c.Cfg.Key, n)
+	atomic.StoreInt32(&c.resyncing, 1)
+	ReportCacheResync(c.Cfg.Type)
+}
+
+func (c *KvCacher) ResyncStat() ResyncStat {
+	return c.resync.Stat()
+}
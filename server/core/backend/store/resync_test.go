@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package store
+
+import (
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"testing"
+	"time"
+)
+
+func TestKvCacher_CheckDivergence(t *testing.T) {
+	c := &KvCacher{Cfg: DefaultKvCacherConfig()}
+	c.cache = NewKvCache(c, 10)
+	c.cache.store["/a"] = &mvccpb.KeyValue{Key: []byte("/a"), ModRevision: 1}
+	c.cache.store["/b"] = &mvccpb.KeyValue{Key: []byte("/b"), ModRevision: 2}
+
+	backend := []*mvccpb.KeyValue{
+		{Key: []byte("/a"), ModRevision: 1},
+		{Key: []byte("/b"), ModRevision: 3},
+		{Key: []byte("/c"), ModRevision: 4},
+	}
+	diverged := c.cache.diff(backend)
+	if len(diverged) != 2 || diverged["/b"] != 3 || diverged["/c"] != 4 {
+		t.Fatalf("diff failed, %v", diverged)
+	}
+
+	now := time.Now()
+	c.checkDivergence(diverged, now)
+	if c.resyncing != 0 || c.ResyncStat().Diverged != 0 {
+		t.Fatalf("the first divergence should not be confirmed")
+	}
+	// '/b'在etcd中又变化了，只确认'/c'
+	c.checkDivergence(map[string]int64{"/b": 5, "/c": 4}, now)
+	stat := c.ResyncStat()
+	if c.resyncing != 1 || stat.Diverged != 1 || stat.Resyncs != 1 {
+		t.Fatalf("divergence should be confirmed, %v", stat)
+	}
+	if !c.needList() || c.resyncing != 0 {
+		t.Fatalf("need list after divergence confirmed")
+	}
+
+	for i := 1; i < c.Cfg.MaxResyncs; i++ {
+		c.checkDivergence(map[string]int64{"/c": 4}, now)
+		c.checkDivergence(map[string]int64{"/c": 4}, now)
+	}
+	c.resyncing = 0
+	c.checkDivergence(map[string]int64{"/c": 4}, now)
+	c.checkDivergence(map[string]int64{"/c": 4}, now)
+	stat = c.ResyncStat()
+	if c.resyncing != 0 || !stat.Limited || stat.Resyncs != c.Cfg.MaxResyncs {
+		t.Fatalf("resyncs should be limited, %v", stat)
+	}
+
+	c.checkDivergence(c.cache.diff([]*mvccpb.KeyValue{backend[0], {Key: []byte("/b"), ModRevision: 2}}), now)
+	if stat = c.ResyncStat(); stat.Diverged != 0 || stat.Limited {
+		t.Fatalf("divergence should be cleared, %v", stat)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"sync"
+)
+
+const (
+	SERIALIZER_JSON  = "json"
+	SERIALIZER_PROTO = "proto"
+)
+
+// Serializer 缓存值的存储形式，写入缓存时Encode，读取时统一使用Unmarshal解码
+type Serializer interface {
+	Name() string
+	// Encode 将etcd中的json值转换为缓存的存储形式
+	Encode(value []byte) ([]byte, error)
+}
+
+type SerializerNewFunc func(newValue func() proto.Message) Serializer
+
+var serializers = map[string]SerializerNewFunc{
+	SERIALIZER_JSON: func(func() proto.Message) Serializer {
+		return &jsonSerializer{}
+	},
+	SERIALIZER_PROTO: func(newValue func() proto.Message) Serializer {
+		return &protoSerializer{newValue: newValue}
+	},
+}
+
+// RegisterSerializer 注册自定义的缓存值存储形式，需在缓存启动前调用
+func RegisterSerializer(name string, f SerializerNewFunc) {
+	serializers[name] = f
+}
+
+func NewSerializer(name string, newValue func() proto.Message) (Serializer, error) {
+	f, ok := serializers[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported cache serializer '%s'", name)
+	}
+	return f(newValue), nil
+}
+
+// jsonSerializer 保持etcd中的原始值
+type jsonSerializer struct {
+}
+
+func (s *jsonSerializer) Name() string {
+	return SERIALIZER_JSON
+}
+
+func (s *jsonSerializer) Encode(value []byte) ([]byte, error) {
+	return value, nil
+}
+
+// protoSerializer 转换为protobuf编码，体积更小，解码时分配的对象也更少
+type protoSerializer struct {
+	newValue func() proto.Message
+}
+
+func (s *protoSerializer) Name() string {
+	return SERIALIZER_PROTO
+}
+
+func (s *protoSerializer) Encode(value []byte) ([]byte, error) {
+	v := s.newValue()
+	if err := json.Unmarshal(value, v); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(v)
+}
+
+// bufferPool 只复用proto.Buffer解码器本身，每次解码省去一次Buffer的分配，
+// 解码出的对象仍由调用方分配；与proto.Unmarshal的差别见BenchmarkUnmarshalProtoNoPool
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return proto.NewBuffer(nil)
+	},
+}
+
+// Unmarshal 解码缓存或etcd中的值，json值总是以'{'开头，其余按protobuf解码；
+// 缓存未命中时会直接返回etcd中的json值，因此调用方不需要关心缓存的存储形式
+func Unmarshal(data []byte, v proto.Message) error {
+	if len(data) > 0 && data[0] == '{' {
+		return json.Unmarshal(data, v)
+	}
+	buf := bufferPool.Get().(*proto.Buffer)
+	buf.SetBuf(data)
+	err := buf.Unmarshal(v)
+	buf.SetBuf(nil)
+	bufferPool.Put(buf)
+	return err
+}
@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package store
+
+import (
+	"encoding/json"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/golang/protobuf/proto"
+	"testing"
+)
+
+func newInstanceValue() proto.Message {
+	return &pb.MicroServiceInstance{}
+}
+
+func testInstanceJSON(t testing.TB) []byte {
+	data, err := json.Marshal(&pb.MicroServiceInstance{
+		InstanceId: "4d2f3c8a6f0a11e8a0f6fa163e4f6b0a",
+		ServiceId:  "6f0a11e8a0f6fa163e4f6b0a4d2f3c8a",
+		Endpoints:  []string{"rest://127.0.0.1:8080", "highway://127.0.0.1:7070"},
+		HostName:   "UT-HOST",
+		Status:     pb.MSI_UP,
+		Properties: map[string]string{
+			"region": "cn-north-1",
+			"zone":   "az1",
+		},
+		HealthCheck: &pb.HealthCheck{
+			Mode:     "push",
+			Interval: 30,
+			Times:    3,
+		},
+		Timestamp:    "1530000000",
+		ModTimestamp: "1530000000",
+	})
+	if err != nil {
+		t.Fatalf("marshal instance failed, %s", err)
+	}
+	return data
+}
+
+func TestUnmarshal(t *testing.T) {
+	raw := testInstanceJSON(t)
+
+	s, err := NewSerializer(SERIALIZER_PROTO, newInstanceValue)
+	if err != nil {
+		t.Fatalf("NewSerializer failed, %s", err)
+	}
+	encoded, err := s.Encode(raw)
+	if err != nil {
+		t.Fatalf("Encode failed, %s", err)
+	}
+	if len(encoded) >= len(raw) {
+		t.Fatalf("encoded value should be smaller, %d >= %d", len(encoded), len(raw))
+	}
+
+	for _, data := range [][]byte{raw, encoded} {
+		instance := &pb.MicroServiceInstance{}
+		if err := Unmarshal(data, instance); err != nil {
+			t.Fatalf("Unmarshal failed, %s", err)
+		}
+		if instance.HostName != "UT-HOST" || len(instance.Endpoints) != 2 ||
+			instance.Properties["zone"] != "az1" || instance.HealthCheck.Times != 3 {
+			t.Fatalf("Unmarshal returns unexpected instance %v", instance)
+		}
+	}
+
+	if _, err := NewSerializer("unknown", newInstanceValue); err == nil {
+		t.Fatalf("NewSerializer should return error when the serializer is unknown")
+	}
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	data := testInstanceJSON(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := Unmarshal(data, &pb.MicroServiceInstance{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalProto(b *testing.B) {
+	s, _ := NewSerializer(SERIALIZER_PROTO, newInstanceValue)
+	data, err := s.Encode(testInstanceJSON(b))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := Unmarshal(data, &pb.MicroServiceInstance{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkUnmarshalProtoNoPool 不复用解码器，与BenchmarkUnmarshalProto对比bufferPool的效果
+func BenchmarkUnmarshalProtoNoPool(b *testing.B) {
+	s, _ := NewSerializer(SERIALIZER_PROTO, newInstanceValue)
+	data, err := s.Encode(testInstanceJSON(b))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := proto.Unmarshal(data, &pb.MicroServiceInstance{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalProtoParallel(b *testing.B) {
+	s, _ := NewSerializer(SERIALIZER_PROTO, newInstanceValue)
+	data, err := s.Encode(testInstanceJSON(b))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(p *testing.PB) {
+		for p.Next() {
+			if err := Unmarshal(data, &pb.MicroServiceInstance{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
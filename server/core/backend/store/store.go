@@ -22,9 +22,13 @@ import (
 	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/astaxie/beego"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
 	"strconv"
 	"sync"
+	"time"
 )
 
 const (
@@ -33,55 +37,73 @@ const (
 	SERVICE
 	SERVICE_INDEX
 	SERVICE_ALIAS
+	SERVICE_NAME_INDEX
 	SERVICE_TAG
+	SERVICE_POLICY
 	RULE
 	RULE_INDEX
 	DEPENDENCY
 	DEPENDENCY_RULE
+	DEPENDENCY_APPROVAL
+	DEPENDENCY_USAGE
 	SCHEMA // big data should not be stored in memory.
 	SCHEMA_SUMMARY
+	SHARED_DEFINITION // the same as schema, not cached
 	INSTANCE
 	LEASE
 	ENDPOINTS
+	INSTANCE_STATE
 	typeEnd
 )
 
 const TIME_FORMAT = "15:04:05.000"
 
 var TypeNames = []string{
-	SERVICE:         "SERVICE",
-	INSTANCE:        "INSTANCE",
-	DOMAIN:          "DOMAIN",
-	SCHEMA:          "SCHEMA",
-	SCHEMA_SUMMARY:  "SCHEMA_SUMMARY",
-	RULE:            "RULE",
-	LEASE:           "LEASE",
-	SERVICE_INDEX:   "SERVICE_INDEX",
-	SERVICE_ALIAS:   "SERVICE_ALIAS",
-	SERVICE_TAG:     "SERVICE_TAG",
-	RULE_INDEX:      "RULE_INDEX",
-	DEPENDENCY:      "DEPENDENCY",
-	DEPENDENCY_RULE: "DEPENDENCY_RULE",
-	PROJECT:         "PROJECT",
-	ENDPOINTS:       "ENDPOINTS",
+	SERVICE:             "SERVICE",
+	INSTANCE:            "INSTANCE",
+	DOMAIN:              "DOMAIN",
+	SCHEMA:              "SCHEMA",
+	SCHEMA_SUMMARY:      "SCHEMA_SUMMARY",
+	SHARED_DEFINITION:   "SHARED_DEFINITION",
+	RULE:                "RULE",
+	LEASE:               "LEASE",
+	SERVICE_INDEX:       "SERVICE_INDEX",
+	SERVICE_ALIAS:       "SERVICE_ALIAS",
+	SERVICE_NAME_INDEX:  "SERVICE_NAME_INDEX",
+	SERVICE_TAG:         "SERVICE_TAG",
+	SERVICE_POLICY:      "SERVICE_POLICY",
+	RULE_INDEX:          "RULE_INDEX",
+	DEPENDENCY:          "DEPENDENCY",
+	DEPENDENCY_RULE:     "DEPENDENCY_RULE",
+	DEPENDENCY_APPROVAL: "DEPENDENCY_APPROVAL",
+	DEPENDENCY_USAGE:    "DEPENDENCY_USAGE",
+	PROJECT:             "PROJECT",
+	ENDPOINTS:           "ENDPOINTS",
+	INSTANCE_STATE:      "INSTANCE_STATE",
 }
 
 var TypeRoots = map[StoreType]string{
-	SERVICE:         apt.GetServiceRootKey(""),
-	INSTANCE:        apt.GetInstanceRootKey(""),
-	DOMAIN:          apt.GetDomainRootKey() + "/",
-	SCHEMA:          apt.GetServiceSchemaRootKey(""),
-	SCHEMA_SUMMARY:  apt.GetServiceSchemaSummaryRootKey(""),
-	RULE:            apt.GetServiceRuleRootKey(""),
-	LEASE:           apt.GetInstanceLeaseRootKey(""),
-	SERVICE_INDEX:   apt.GetServiceIndexRootKey(""),
-	SERVICE_ALIAS:   apt.GetServiceAliasRootKey(""),
-	SERVICE_TAG:     apt.GetServiceTagRootKey(""),
-	RULE_INDEX:      apt.GetServiceRuleIndexRootKey(""),
-	DEPENDENCY:      apt.GetServiceDependencyRootKey(""),
-	DEPENDENCY_RULE: apt.GetServiceDependencyRuleRootKey(""),
-	PROJECT:         apt.GetProjectRootKey(""),
-	ENDPOINTS:       apt.GetEndpointsRootKey(""),
+	SERVICE:             apt.GetServiceRootKey(""),
+	INSTANCE:            apt.GetInstanceRootKey(""),
+	DOMAIN:              apt.GetDomainRootKey() + "/",
+	SCHEMA:              apt.GetServiceSchemaRootKey(""),
+	SCHEMA_SUMMARY:      apt.GetServiceSchemaSummaryRootKey(""),
+	SHARED_DEFINITION:   apt.GetSharedDefinitionRootKey(""),
+	RULE:                apt.GetServiceRuleRootKey(""),
+	LEASE:               apt.GetInstanceLeaseRootKey(""),
+	SERVICE_INDEX:       apt.GetServiceIndexRootKey(""),
+	SERVICE_ALIAS:       apt.GetServiceAliasRootKey(""),
+	SERVICE_NAME_INDEX:  apt.GetServiceNameIndexRootKey(""),
+	SERVICE_TAG:         apt.GetServiceTagRootKey(""),
+	SERVICE_POLICY:      apt.GetServicePolicyRootKey(""),
+	RULE_INDEX:          apt.GetServiceRuleIndexRootKey(""),
+	DEPENDENCY:          apt.GetServiceDependencyRootKey(""),
+	DEPENDENCY_RULE:     apt.GetServiceDependencyRuleRootKey(""),
+	DEPENDENCY_APPROVAL: apt.GetDependencyApprovalRootKey(""),
+	DEPENDENCY_USAGE:    apt.GetDependencyUsageRootKey(""),
+	PROJECT:             apt.GetProjectRootKey(""),
+	ENDPOINTS:           apt.GetEndpointsRootKey(""),
+	INSTANCE_STATE:      apt.GetInstanceStateRootKey(""),
 }
 
 var store = &KvStore{}
@@ -102,16 +124,18 @@ func (st StoreType) String() string {
 }
 
 type KvStore struct {
-	indexers     map[StoreType]*Indexer
-	asyncTaskSvc *async.AsyncTaskService
-	lock         sync.RWMutex
-	ready        chan struct{}
-	isClose      bool
+	indexers         map[StoreType]*Indexer
+	asyncTaskSvc     *async.AsyncTaskService
+	selfPreservation *InstanceEventDeferHandler
+	lock             sync.RWMutex
+	ready            chan struct{}
+	isClose          bool
 }
 
 func (s *KvStore) Initialize() {
 	s.indexers = make(map[StoreType]*Indexer)
 	s.asyncTaskSvc = async.NewAsyncTaskService()
+	s.selfPreservation = &InstanceEventDeferHandler{Percent: DEFAULT_SELF_PRESERVATION_PERCENT}
 	s.ready = make(chan struct{})
 
 	for i := StoreType(0); i != typeEnd; i++ {
@@ -133,9 +157,15 @@ func (s *KvStore) dispatchEvent(t StoreType, evt *KvEvent) {
 
 func (s *KvStore) newStore(t StoreType, opts ...KvCacherCfgOption) {
 	opts = append(opts,
+		WithType(t),
 		WithKey(TypeRoots[t]),
 		WithInitSize(s.StoreSize(t)),
+		WithBudget(s.StoreBudget(t)),
+		WithSerializer(s.StoreSerializer(t)),
+		WithVerify(s.StoreVerifyInterval(), beego.AppConfig.DefaultInt("cache_max_resyncs_per_hour", DEFAULT_MAX_RESYNCS_PER_HOUR)),
 		WithEventFunc(func(evt *KvEvent) { s.dispatchEvent(t, evt) }),
+		WithEvictFunc(func(prefix string) { s.indexers[t].OnEvict(prefix) }),
+		WithReloadFunc(func(kvs []*mvccpb.KeyValue) { s.indexers[t].OnReload(kvs) }),
 	)
 	s.newIndexer(t, NewKvCacher(opts...))
 }
@@ -159,15 +189,83 @@ func (s *KvStore) StoreSize(t StoreType) int {
 	switch t {
 	case DOMAIN:
 		return 10
-	case INSTANCE, LEASE:
+	case INSTANCE, LEASE, INSTANCE_STATE:
 		return 1000
 	default:
 		return 100
 	}
 }
 
+// StoreBudget 缓存的内存预算(字节)，0表示不限制
+func (s *KvStore) StoreBudget(t StoreType) int64 {
+	var key string
+	switch t {
+	case SERVICE:
+		key = "cache_service_budget_mb"
+	case INSTANCE:
+		key = "cache_instance_budget_mb"
+	case DEPENDENCY_RULE:
+		key = "cache_dependency_rule_budget_mb"
+	default:
+		return 0
+	}
+	mb := beego.AppConfig.DefaultInt64(key, 0)
+	if mb <= 0 {
+		return 0
+	}
+	return mb * 1024 * 1024
+}
+
+// StoreSerializer 缓存值的存储形式，目前只有实例缓存支持配置，为空时保持原始值
+func (s *KvStore) StoreSerializer(t StoreType) Serializer {
+	if t != INSTANCE {
+		return nil
+	}
+	name := beego.AppConfig.DefaultString("cache_instance_serializer", SERIALIZER_JSON)
+	if name == SERIALIZER_JSON {
+		return nil
+	}
+	serializer, err := NewSerializer(name, func() proto.Message { return &pb.MicroServiceInstance{} })
+	if err != nil {
+		util.Logger().Errorf(err, "use the raw values in %s cache", t)
+		return nil
+	}
+	return serializer
+}
+
+// StoreVerifyInterval 缓存与etcd交叉检查的周期，0表示不检查
+func (s *KvStore) StoreVerifyInterval() time.Duration {
+	v := beego.AppConfig.DefaultString("cache_verify_interval", DEFAULT_VERIFY_INTERVAL.String())
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		util.Logger().Errorf(err, "invalid cache_verify_interval '%s', use %s", v, DEFAULT_VERIFY_INTERVAL)
+		return DEFAULT_VERIFY_INTERVAL
+	}
+	return d
+}
+
+// ResyncStats 各缓存与etcd交叉检查的结果
+func (s *KvStore) ResyncStats() []ResyncStat {
+	var stats []ResyncStat
+	for t := StoreType(0); t != typeEnd; t++ {
+		i, ok := s.indexers[t]
+		if !ok {
+			continue
+		}
+		if c, ok := i.cacher.(*KvCacher); ok && c.Cfg.VerifyInterval > 0 {
+			stats = append(stats, c.ResyncStat())
+		}
+	}
+	return stats
+}
+
 func (s *KvStore) SelfPreservationHandler() DeferHandler {
-	return &InstanceEventDeferHandler{Percent: DEFAULT_SELF_PRESERVATION_PERCENT}
+	return s.selfPreservation
+}
+
+// SelfPreservationEnabled 实例缓存是否处于自我保护状态
+func (s *KvStore) SelfPreservationEnabled() bool {
+	return s.selfPreservation.Enabled()
 }
 
 func (s *KvStore) store() {
@@ -175,7 +273,7 @@ func (s *KvStore) store() {
 		switch t {
 		case INSTANCE:
 			s.newStore(t, WithDeferHandler(s.SelfPreservationHandler()))
-		case SCHEMA:
+		case SCHEMA, SHARED_DEFINITION:
 			continue
 		default:
 			s.newStore(t)
@@ -252,10 +350,18 @@ func (s *KvStore) ServiceAlias() *Indexer {
 	return s.indexers[SERVICE_ALIAS]
 }
 
+func (s *KvStore) ServiceNameIndex() *Indexer {
+	return s.indexers[SERVICE_NAME_INDEX]
+}
+
 func (s *KvStore) ServiceTag() *Indexer {
 	return s.indexers[SERVICE_TAG]
 }
 
+func (s *KvStore) ServicePolicy() *Indexer {
+	return s.indexers[SERVICE_POLICY]
+}
+
 func (s *KvStore) Rule() *Indexer {
 	return s.indexers[RULE]
 }
@@ -276,6 +382,22 @@ func (s *KvStore) DependencyRule() *Indexer {
 	return s.indexers[DEPENDENCY_RULE]
 }
 
+func (s *KvStore) DependencyApproval() *Indexer {
+	return s.indexers[DEPENDENCY_APPROVAL]
+}
+
+func (s *KvStore) DependencyUsage() *Indexer {
+	return s.indexers[DEPENDENCY_USAGE]
+}
+
+func (s *KvStore) InstanceState() *Indexer {
+	return s.indexers[INSTANCE_STATE]
+}
+
+func (s *KvStore) SharedDefinition() *Indexer {
+	return s.indexers[SHARED_DEFINITION]
+}
+
 func (s *KvStore) Domain() *Indexer {
 	return s.indexers[DOMAIN]
 }
@@ -288,6 +410,25 @@ func (s *KvStore) Endpoints() *Indexer {
 	return s.indexers[ENDPOINTS]
 }
 
+type CacheStat struct {
+	Type     string `json:"type"`
+	Revision int64  `json:"revision"`
+	Size     int    `json:"size"`
+}
+
+func (s *KvStore) Stats() []*CacheStat {
+	stats := make([]*CacheStat, 0, typeEnd)
+	for t := StoreType(0); t != typeEnd; t++ {
+		cache := s.indexers[t].Cache()
+		stats = append(stats, &CacheStat{
+			Type:     t.String(),
+			Revision: cache.Version(),
+			Size:     cache.Size(),
+		})
+	}
+	return stats
+}
+
 func (s *KvStore) KeepAlive(ctx context.Context, opts ...registry.PluginOpOption) (int64, error) {
 	op := registry.OpPut(opts...)
 
@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package core
+
+import (
+	"errors"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrBodyTooLarge 读取的请求体超过上限
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// ParseBodyLimits 解析按API配置的请求体上限，格式为"METHOD pattern=bytes"，多个用','分隔，
+// 如"PUT /v4/:project/registry/microservices/:serviceId/schemas/:schemaId/content=10485760"
+func ParseBodyLimits(s string) map[string]int64 {
+	limits := make(map[string]int64)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		i := strings.LastIndex(item, "=")
+		if i <= 0 {
+			util.Logger().Errorf(nil, "invalid max_body_bytes_apis item '%s'", item)
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(item[i+1:]), 10, 64)
+		if err != nil || n <= 0 {
+			util.Logger().Errorf(err, "invalid max_body_bytes_apis item '%s'", item)
+			continue
+		}
+		api := strings.Fields(item[:i])
+		if len(api) != 2 {
+			util.Logger().Errorf(nil, "invalid max_body_bytes_apis item '%s'", item)
+			continue
+		}
+		limits[strings.ToUpper(api[0])+" "+api[1]] = n
+	}
+	return limits
+}
+
+// BodyLimit 返回API的请求体上限，pattern为路由注册的路径
+func BodyLimit(method, pattern string) int64 {
+	if n, ok := ServerInfo.Config.MaxBodyBytesApis[method+" "+pattern]; ok {
+		return n
+	}
+	return ServerInfo.Config.MaxBodyBytes
+}
+
+// MaxBodyLimit 所有API中最大的请求体上限，用于路由匹配前的限制
+func MaxBodyLimit() int64 {
+	max := ServerInfo.Config.MaxBodyBytes
+	for _, n := range ServerInfo.Config.MaxBodyBytesApis {
+		if n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+type limitedBody struct {
+	io.ReadCloser
+	n   int64 // 剩余可读的字节数
+	err error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	// 多读一个字节，以区分恰好等于上限和超过上限
+	if int64(len(p)) > b.n+1 {
+		p = p[:b.n+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) <= b.n {
+		b.n -= int64(n)
+		b.err = err
+		return n, err
+	}
+	n, b.n, b.err = int(b.n), 0, ErrBodyTooLarge
+	return n, b.err
+}
+
+// LimitBody 限制请求体的大小，读取超过limit字节时返回ErrBodyTooLarge
+func LimitBody(body io.ReadCloser, limit int64) io.ReadCloser {
+	return &limitedBody{ReadCloser: body, n: limit}
+}
@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package core
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestParseBodyLimits(t *testing.T) {
+	limits := ParseBodyLimits(" PUT /v4/:project/schemas/:schemaId/content=10485760, get /a=20," +
+		"bad, POST /b=abc, POST /c=-1, /d=5, PUT /e /f=1,")
+	if len(limits) != 2 || limits["PUT /v4/:project/schemas/:schemaId/content"] != 10485760 || limits["GET /a"] != 20 {
+		t.Fatalf("TestParseBodyLimits failed, %v", limits)
+	}
+	if limits := ParseBodyLimits(""); len(limits) != 0 {
+		t.Fatalf("TestParseBodyLimits failed, %v", limits)
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	max, apis := ServerInfo.Config.MaxBodyBytes, ServerInfo.Config.MaxBodyBytesApis
+	defer func() {
+		ServerInfo.Config.MaxBodyBytes, ServerInfo.Config.MaxBodyBytesApis = max, apis
+	}()
+	ServerInfo.Config.MaxBodyBytes = 100
+	ServerInfo.Config.MaxBodyBytesApis = ParseBodyLimits("PUT /a=1000")
+
+	if n := BodyLimit("PUT", "/a"); n != 1000 {
+		t.Fatalf("TestBodyLimit failed, override %d", n)
+	}
+	if n := BodyLimit("POST", "/a"); n != 100 {
+		t.Fatalf("TestBodyLimit failed, method should match, %d", n)
+	}
+	if n := BodyLimit("PUT", "/b"); n != 100 {
+		t.Fatalf("TestBodyLimit failed, default %d", n)
+	}
+	if n := MaxBodyLimit(); n != 1000 {
+		t.Fatalf("TestBodyLimit failed, max %d", n)
+	}
+}
+
+func TestLimitBody(t *testing.T) {
+	body := LimitBody(ioutil.NopCloser(strings.NewReader("12345")), 5)
+	data, err := ioutil.ReadAll(body)
+	if err != nil || string(data) != "12345" {
+		t.Fatalf("TestLimitBody failed, read %s, %v", data, err)
+	}
+
+	body = LimitBody(ioutil.NopCloser(strings.NewReader("123456")), 5)
+	data, err = ioutil.ReadAll(body)
+	if err != ErrBodyTooLarge || string(data) != "12345" {
+		t.Fatalf("TestLimitBody failed, read %s, %v", data, err)
+	}
+	if _, err := body.Read(make([]byte, 1)); err != ErrBodyTooLarge {
+		t.Fatalf("TestLimitBody failed, read after exceeded, %v", err)
+	}
+}
@@ -36,20 +36,47 @@ var (
 	ServiceRuleValidator          validate.Validator
 	ServicePathValidator          validate.Validator
 	HealthCheckInfoValidator      validate.Validator
+	StatusReasonValidator         validate.Validator
 	MicroServiceKeyValidator      validate.Validator
 	DataCenterInfoValidator       validate.Validator
+	PlatformValidator             validate.Validator
+	InstanceEndpointValidator     validate.Validator
 	GetMSExistsReqValidator       validate.Validator
+	BatchExistenceReqValidator    validate.Validator
 	GetSchemaExistsReqValidator   validate.Validator
 	GetServiceReqValidator        validate.Validator
+	GetServicesReqValidator       validate.Validator
 	GetSchemaReqValidator         validate.Validator
 	ConsumerMsValidator           validate.Validator
 	ProviderMsValidator           validate.Validator
 	TagReqValidator               validate.Validator
+	DiscoveryPolicyValidator      validate.Validator
+	DiscoveryPolicyReqValidator   validate.Validator
+	DependencyApprovalValidator   validate.Validator
+	DependencyHistoryValidator    validate.Validator
+	DelegationReqValidator        validate.Validator
+	BroadcastReqValidator         validate.Validator
+	SnapshotReqValidator          validate.Validator
+	CreateApiKeyReqValidator      validate.Validator
+	ApiKeyReqValidator            validate.Validator
 	FindInstanceReqValidator      validate.Validator
 	GetInstanceValidator          validate.Validator
+	PromoteInstancesReqValidator  validate.Validator
+	SearchInstancesReqValidator   validate.Validator
+	UnregisterInstancesValidator  validate.Validator
+	DependencyTrafficReqValidator validate.Validator
+	StartupOrderReqValidator      validate.Validator
+	TopologyReqValidator          validate.Validator
+	InstanceHistoryReqValidator   validate.Validator
 	SchemasValidator              validate.Validator
 	SchemaValidator               validate.Validator
 	FrameWKValidator              validate.Validator
+	ServiceCatalogValidator       validate.Validator
+	ServiceCatalogReqValidator    validate.Validator
+	ServiceRetirementValidator    validate.Validator
+	ServiceRetirementReqValidator validate.Validator
+	SchemaFreezeReqValidator      validate.Validator
+	SharedDefinitionValidator     validate.Validator
 
 	SchemaIdRule *validate.ValidateRule
 	TagRule      *validate.ValidateRule
@@ -65,6 +92,8 @@ func init() {
 	// version模糊规则: 1.0, 1.0+, 1.0-2.0, latest
 	versionFuzzyRegex, _ := regexp.Compile(`^[0-9]*$|^[0-9]+(\.[0-9]+)*\+{0,1}$|^[0-9]+(\.[0-9]+)*-[0-9]+(\.[0-9]+)*$|^latest$`)
 	pathRegex, _ := regexp.Compile(`^[A-Za-z0-9.,?'\\/+&amp;%$#=~_\-@{}]*$`)
+	urlRegex, _ := regexp.Compile(`^(https?://[^\s]+)?$`)
+	dateRegex, _ := regexp.Compile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
 	descriptionRegex, _ := regexp.Compile(`^[\p{Han}\w\s。.:*,\-：”“"]*$`)
 	levelRegex, _ := regexp.Compile(`^(FRONT|MIDDLE|BACK)$`)
 	statusRegex, _ := regexp.Compile("^(" + pb.MS_UP + "|" + pb.MS_DOWN + ")*$")
@@ -80,21 +109,35 @@ func init() {
 	// map/slice的长度由validator中的min/max/length控制
 	schemaIdRegex, _ := regexp.Compile(`^[a-zA-Z0-9]{1,160}$|^[a-zA-Z0-9][a-zA-Z0-9_\-.]{0,158}[a-zA-Z0-9]$`) //length:{1,160}
 	instStatusRegex, _ := regexp.Compile("^(" + util.StringJoin([]string{
-		pb.MSI_UP, pb.MSI_DOWN, pb.MSI_STARTING, pb.MSI_OUTOFSERVICE}, "|") + ")$")
+		pb.MSI_UP, pb.MSI_DOWN, pb.MSI_STARTING, pb.MSI_OUTOFSERVICE, pb.MSI_STANDBY, pb.MSI_PENDING}, "|") + ")$")
+	reasonCodeRegex, _ := regexp.Compile(`^[A-Z0-9_]*$`)
 	tagRegex, _ := regexp.Compile(`^[a-zA-Z][a-zA-Z0-9_\-.]{0,63}$`)
-	hbModeRegex, _ := regexp.Compile(`^(push|pull)$`)
+	hbModeRegex, _ := regexp.Compile(`^(push|pull|static)$`)
 	numberAllowEmptyRegex, _ := regexp.Compile(`^[0-9]*$`)
 	numberRegex, _ := regexp.Compile(`^[0-9]+$`)
-	epRegex, _ := regexp.Compile(`^[A-Za-z0-9:/?=&%_.-]+$`)
+	// IPv6地址带中括号，如rest://[2001:db8::1]:8080
+	epRegex, _ := regexp.Compile(`^[A-Za-z0-9:/?=&%_.\[\]-]+$`)
+	// 协议为URI scheme，统一为小写，没有协议前缀的endpoint协议为空
+	protocolRegex, _ := regexp.Compile(`^[a-z][a-z0-9+.-]{0,31}$`)
+	protocolAllowEmptyRegex, _ := regexp.Compile(`^([a-z][a-z0-9+.-]{0,31})?$`)
+	epAddressRegex, _ := regexp.Compile(`^[A-Za-z0-9:/%_.\[\]-]+$`)
+	epParamRegex, _ := regexp.Compile(`^[A-Za-z0-9:/%_.-]*$`)
 	simpleNameAllowEmptyRegex, _ := regexp.Compile(`^[A-Za-z0-9_.-]*$`)
 	simpleNameRegex, _ := regexp.Compile(`^[A-Za-z0-9_.-]+$`)
 	regionRegex, _ := regexp.Compile(`^[A-Za-z0-9_.-]+$`)
+	platformRegex, _ := regexp.Compile(`^[A-Za-z0-9_]*$`)
+	platformPolicyRegex, _ := regexp.Compile("^(" + pb.PLATFORM_PREFER + "|" + pb.PLATFORM_REQUIRE + ")?$")
+	orderRegex, _ := regexp.Compile("^(" + pb.ORDER_RANDOM + "|" + pb.ORDER_LEAST_RECENT + "|" + pb.ORDER_ZONE + "|" + pb.ORDER_WEIGHT + ")?$")
+	zoneRegex, _ := regexp.Compile(`^[A-Za-z0-9_.-]*$`)
+	addressFamilyRegex, _ := regexp.Compile("^(" + pb.ADDRESS_FAMILY_IPV4 + "|" + pb.ADDRESS_FAMILY_IPV6 + ")?$")
 	ruleRegex, _ := regexp.Compile(`^(WHITE|BLACK)$`)
 	ruleAttrRegex, _ := regexp.Compile(`((^tag_[a-zA-Z][a-zA-Z0-9_\-.]{0,63}$)|(^ServiceId$)|(^AppId$)|(^ServiceName$)|(^Version$)|(^Description$)|(^Level$)|(^Status$))`)
 	SchemaSummaryRegex, _ := regexp.Compile(`(a-zA-Z0-9)*`)
 
 	ServiceIdRule := &validate.ValidateRule{Min: 1, Length: 64, Regexp: serviceIdRegex}
 	InstanceStatusRule := &validate.ValidateRule{Regexp: instStatusRegex}
+	reasonCodeRule := &validate.ValidateRule{Length: 64, Regexp: reasonCodeRegex}
+	reasonMessageRule := &validate.ValidateRule{Length: 256}
 	SchemaIdRule = &validate.ValidateRule{Regexp: schemaIdRegex}
 	nameRule := &validate.ValidateRule{Min: 1, Max: 128, Regexp: nameRegex}
 	versionFuzzyRule := &validate.ValidateRule{Min: 1, Max: 128, Regexp: versionFuzzyRegex}
@@ -110,6 +153,15 @@ func init() {
 	FrameWKValidator.AddRule("Name", &validate.ValidateRule{Min: 1, Max: 64, Regexp: nameRegex})
 	FrameWKValidator.AddRule("Version", &validate.ValidateRule{Length: 64, Regexp: frameversionRegex})
 
+	catalogUrlRule := &validate.ValidateRule{Length: 512, Regexp: urlRegex}
+	ServiceCatalogValidator.AddRule("DocsUrl", catalogUrlRule)
+	ServiceCatalogValidator.AddRule("RepoUrl", catalogUrlRule)
+	ServiceCatalogValidator.AddRule("RunbookUrl", catalogUrlRule)
+	ServiceCatalogValidator.AddRule("DashboardUrl", catalogUrlRule)
+
+	ServiceRetirementValidator.AddRule("TargetDate", &validate.ValidateRule{Min: 1, Regexp: dateRegex})
+	ServiceRetirementValidator.AddRule("Message", &validate.ValidateRule{Length: 256})
+
 	MicroServiceValidator.AddRules(MicroServiceKeyValidator.GetRules())
 	MicroServiceValidator.AddRule("Description", &validate.ValidateRule{Length: 256, Regexp: descriptionRegex})
 	MicroServiceValidator.AddRule("Level", &validate.ValidateRule{Min: 1, Regexp: levelRegex})
@@ -119,10 +171,15 @@ func init() {
 	MicroServiceValidator.AddRule("Alias", &validate.ValidateRule{Length: 128, Regexp: aliasRegex})
 	MicroServiceValidator.AddRule("RegisterBy", &validate.ValidateRule{Min: 1, Length: 64, Regexp: registerByRegex})
 	MicroServiceValidator.AddSub("Framework", &FrameWKValidator)
+	MicroServiceValidator.AddSub("Catalog", &ServiceCatalogValidator)
+	MicroServiceValidator.AddSub("Retirement", &ServiceRetirementValidator)
 
 	GetMSExistsReqValidator.AddRules(MicroServiceKeyValidator.GetRules())
 	GetMSExistsReqValidator.AddRule("Version", versionFuzzyRule)
 
+	BatchExistenceReqValidator.AddRule("Services", &validate.ValidateRule{Min: 1, Max: 100})
+	BatchExistenceReqValidator.AddSub("Services", &MicroServiceKeyValidator)
+
 	GetSchemaExistsReqValidator.AddRule("ServiceId", ServiceIdRule)
 	GetSchemaExistsReqValidator.AddRule("SchemaId", SchemaIdRule)
 
@@ -133,16 +190,34 @@ func init() {
 
 	SchemasValidator.AddRule("ServiceId", ServiceIdRule)
 	SchemasValidator.AddSub("Schemas", &subSchemaValidor)
+	SchemasValidator.AddRule("ExpectedRevision", &validate.ValidateRule{Max: 20, Regexp: numberAllowEmptyRegex})
 
 	SchemaValidator.AddRules(subSchemaValidor.GetRules())
 	SchemaValidator.AddRule("ServiceId", ServiceIdRule)
 	SchemaValidator.AddRule("Summary", &validate.ValidateRule{Max: 512, Regexp: SchemaSummaryRegex})
+	SchemaValidator.AddRule("ExpectedRevision", &validate.ValidateRule{Max: 20, Regexp: numberAllowEmptyRegex})
 
 	GetServiceReqValidator.AddRule("ServiceId", ServiceIdRule)
 
+	GetServicesReqValidator.AddRule("ServiceName", &validate.ValidateRule{Max: 128, Regexp: nameRegex})
+
+	ServiceCatalogReqValidator.AddRule("ServiceId", ServiceIdRule)
+	ServiceCatalogReqValidator.AddSub("Catalog", &ServiceCatalogValidator)
+
+	ServiceRetirementReqValidator.AddRule("ServiceId", ServiceIdRule)
+	ServiceRetirementReqValidator.AddSub("Retirement", &ServiceRetirementValidator)
+
+	var schemaFreezeValidator validate.Validator
+	schemaFreezeValidator.AddRule("Reason", &validate.ValidateRule{Length: 256})
+	SchemaFreezeReqValidator.AddRule("ServiceId", ServiceIdRule)
+	SchemaFreezeReqValidator.AddSub("Freeze", &schemaFreezeValidator)
+
 	GetSchemaReqValidator.AddRule("ServiceId", ServiceIdRule)
 	GetSchemaReqValidator.AddRule("SchemaId", SchemaIdRule)
 
+	SharedDefinitionValidator.AddRule("Name", SchemaIdRule)
+	SharedDefinitionValidator.AddRule("Content", &validate.ValidateRule{Min: 1})
+
 	ConsumerMsValidator.AddRules(MicroServiceKeyValidator.GetRules())
 
 	ProviderMsValidator.AddRules(MicroServiceKeyValidator.GetRules())
@@ -155,6 +230,40 @@ func init() {
 	TagReqValidator.AddRule("Key", &validate.ValidateRule{Regexp: tagRegex})
 	TagReqValidator.AddRule("Value", &validate.ValidateRule{Regexp: tagRegex})
 
+	DiscoveryPolicyValidator.AddRule("MaxInstances", &validate.ValidateRule{Max: math.MaxInt16, Regexp: numberRegex})
+	DiscoveryPolicyValidator.AddRule("PreferredTags", &validate.ValidateRule{Max: 64})
+	DiscoveryPolicyValidator.AddRule("ExcludeProperties", &validate.ValidateRule{Max: 64})
+	DiscoveryPolicyValidator.AddRule("Order", &validate.ValidateRule{Regexp: orderRegex})
+
+	DiscoveryPolicyReqValidator.AddRule("ServiceId", ServiceIdRule)
+	DiscoveryPolicyReqValidator.AddRule("Domain", &validate.ValidateRule{Min: 1, Length: 64, Regexp: simpleNameAllowEmptyRegex})
+	DiscoveryPolicyReqValidator.AddRule("Project", &validate.ValidateRule{Min: 1, Length: 64, Regexp: simpleNameAllowEmptyRegex})
+	DiscoveryPolicyReqValidator.AddSub("Policy", &DiscoveryPolicyValidator)
+
+	DependencyApprovalValidator.AddRule("ProviderServiceId", ServiceIdRule)
+	DependencyApprovalValidator.AddRule("ConsumerServiceId", ServiceIdRule)
+
+	DependencyHistoryValidator.AddRule("ServiceId", ServiceIdRule)
+	DependencyHistoryValidator.AddRule("Revision", &validate.ValidateRule{Max: 20, Regexp: numberAllowEmptyRegex})
+	DependencyHistoryValidator.AddRule("Timestamp", &validate.ValidateRule{Max: 20, Regexp: numberAllowEmptyRegex})
+
+	DelegationReqValidator.AddRule("ServiceId", ServiceIdRule)
+	DelegationReqValidator.AddRule("ControllerServiceId", ServiceIdRule)
+	DelegationReqValidator.AddRule("InstanceId", &validate.ValidateRule{Min: 1, Max: 64, Regexp: simpleNameAllowEmptyRegex})
+
+	var broadcastMessageValidator validate.Validator
+	broadcastMessageValidator.AddRule("Type", &validate.ValidateRule{Min: 1, Max: 64, Regexp: simpleNameRegex})
+	broadcastMessageValidator.AddRule("Content", &validate.ValidateRule{Min: 1, Max: 4096})
+	broadcastMessageValidator.AddRule("EffectiveAt", &validate.ValidateRule{Max: 20, Regexp: numberAllowEmptyRegex})
+
+	BroadcastReqValidator.AddRule("ServiceId", ServiceIdRule)
+	BroadcastReqValidator.AddSub("Message", &broadcastMessageValidator)
+
+	SnapshotReqValidator.AddRule("SnapshotId", ServiceIdRule)
+
+	CreateApiKeyReqValidator.AddRule("Name", &validate.ValidateRule{Length: 64, Regexp: simpleNameAllowEmptyRegex})
+	ApiKeyReqValidator.AddRule("KeyId", ServiceIdRule)
+
 	HealthCheckInfoValidator.AddRule("Mode", &validate.ValidateRule{Regexp: hbModeRegex})
 	HealthCheckInfoValidator.AddRule("Port", &validate.ValidateRule{Max: math.MaxInt16, Regexp: numberAllowEmptyRegex})
 	HealthCheckInfoValidator.AddRule("Times", &validate.ValidateRule{Max: math.MaxInt32, Regexp: numberRegex})
@@ -167,12 +276,30 @@ func init() {
 	MicroServiceInstanceValidator.AddRule("HostName", &validate.ValidateRule{Length: 64, Regexp: simpleNameRegex})
 	MicroServiceInstanceValidator.AddSub("HealthCheck", &HealthCheckInfoValidator)
 	MicroServiceInstanceValidator.AddRule("Status", InstanceStatusRule)
+	MicroServiceInstanceValidator.AddRule("ActivateTime", &validate.ValidateRule{Max: 20, Regexp: numberAllowEmptyRegex})
 	MicroServiceInstanceValidator.AddSub("DataCenterInfo", &DataCenterInfoValidator)
+	MicroServiceInstanceValidator.AddSub("StatusReason", &StatusReasonValidator)
+	MicroServiceInstanceValidator.AddSub("Platform", &PlatformValidator)
+	MicroServiceInstanceValidator.AddRule("Capacity", &validate.ValidateRule{Max: math.MaxInt32, Regexp: numberRegex})
+	MicroServiceInstanceValidator.AddSub("EndpointInfos", &InstanceEndpointValidator)
+	// UpdateInstanceStatusRequest、UpdateInstanceCapacityRequest复用实例的validator
+	MicroServiceInstanceValidator.AddRule("ReasonCode", reasonCodeRule)
+	MicroServiceInstanceValidator.AddRule("ReasonMessage", reasonMessageRule)
 
 	DataCenterInfoValidator.AddRule("Name", &validate.ValidateRule{Length: 128, Regexp: simpleNameRegex})
 	DataCenterInfoValidator.AddRule("Region", &validate.ValidateRule{Length: 128, Regexp: regionRegex})
 	DataCenterInfoValidator.AddRule("AvailableZone", &validate.ValidateRule{Length: 128, Regexp: regionRegex})
 
+	InstanceEndpointValidator.AddRule("Protocol", &validate.ValidateRule{Regexp: protocolAllowEmptyRegex})
+	InstanceEndpointValidator.AddRule("Address", &validate.ValidateRule{Min: 1, Max: 255, Regexp: epAddressRegex})
+	InstanceEndpointValidator.AddRule("Params", &validate.ValidateRule{Max: 16, Regexp: epParamRegex})
+
+	PlatformValidator.AddRule("Os", &validate.ValidateRule{Length: 32, Regexp: platformRegex})
+	PlatformValidator.AddRule("Arch", &validate.ValidateRule{Length: 32, Regexp: platformRegex})
+
+	StatusReasonValidator.AddRule("Code", reasonCodeRule)
+	StatusReasonValidator.AddRule("Message", reasonMessageRule)
+
 	ServiceRuleValidator.AddRule("RuleType", &validate.ValidateRule{Regexp: ruleRegex})
 	ServiceRuleValidator.AddRule("Attribute", &validate.ValidateRule{Regexp: ruleAttrRegex})
 	ServiceRuleValidator.AddRule("Pattern", &validate.ValidateRule{Max: 64, Min: 1})
@@ -183,11 +310,56 @@ func init() {
 	FindInstanceReqValidator.AddRule("ServiceName", &validate.ValidateRule{Min: 1, Max: 128, Regexp: serviceNameForFindRegex})
 	FindInstanceReqValidator.AddRule("VersionRule", versionFuzzyRule)
 	FindInstanceReqValidator.AddRule("Tags", TagRule)
+	FindInstanceReqValidator.AddSub("Platform", &PlatformValidator)
+	FindInstanceReqValidator.AddRule("PlatformPolicy", &validate.ValidateRule{Regexp: platformPolicyRegex})
+	FindInstanceReqValidator.AddRule("Order", &validate.ValidateRule{Regexp: orderRegex})
+	FindInstanceReqValidator.AddRule("Zone", &validate.ValidateRule{Max: 128, Regexp: zoneRegex})
+	FindInstanceReqValidator.AddRule("AddressFamily", &validate.ValidateRule{Regexp: addressFamilyRegex})
+	FindInstanceReqValidator.AddRule("Protocols", &validate.ValidateRule{Max: 8, Regexp: protocolRegex})
 
 	GetInstanceValidator.AddRule("ConsumerServiceId", ServiceIdRule)
 	GetInstanceValidator.AddRule("ProviderServiceId", ServiceIdRule)
 	GetInstanceValidator.AddRule("ProviderInstanceId", &validate.ValidateRule{Min: 1, Max: 64, Regexp: simpleNameAllowEmptyRegex})
 	GetInstanceValidator.AddRule("Tags", TagRule)
+
+	PromoteInstancesReqValidator.AddRule("ServiceId", ServiceIdRule)
+	PromoteInstancesReqValidator.AddRule("InstanceIds", &validate.ValidateRule{Max: 100, Regexp: simpleNameRegex})
+
+	instStatusAllowEmptyRegex, _ := regexp.Compile("^(" + util.StringJoin([]string{
+		pb.MSI_UP, pb.MSI_DOWN, pb.MSI_STARTING, pb.MSI_OUTOFSERVICE, pb.MSI_STANDBY, pb.MSI_PENDING}, "|") + ")?$")
+	SearchInstancesReqValidator.AddRule("Status", &validate.ValidateRule{Regexp: instStatusAllowEmptyRegex})
+	SearchInstancesReqValidator.AddRule("Datacenter", &validate.ValidateRule{Length: 128, Regexp: simpleNameAllowEmptyRegex})
+	SearchInstancesReqValidator.AddRule("Region", &validate.ValidateRule{Length: 128, Regexp: simpleNameAllowEmptyRegex})
+	SearchInstancesReqValidator.AddRule("AvailableZone", &validate.ValidateRule{Length: 128, Regexp: simpleNameAllowEmptyRegex})
+	SearchInstancesReqValidator.AddRule("Properties", &validate.ValidateRule{Max: 64})
+	SearchInstancesReqValidator.AddRule("Offset", &validate.ValidateRule{Regexp: numberRegex})
+	SearchInstancesReqValidator.AddRule("Limit", &validate.ValidateRule{Max: 1000, Regexp: numberRegex})
+
+	UnregisterInstancesValidator.AddRule("ServiceId", ServiceIdRule)
+	UnregisterInstancesValidator.AddRule("Status", &validate.ValidateRule{Regexp: instStatusAllowEmptyRegex})
+	UnregisterInstancesValidator.AddRule("Properties", &validate.ValidateRule{Max: 64})
+	UnregisterInstancesValidator.AddRule("RegisteredBefore", &validate.ValidateRule{Regexp: numberRegex})
+
+	var dependencyTrafficValidator validate.Validator
+	dependencyTrafficValidator.AddRule("ConsumerServiceId", ServiceIdRule)
+	dependencyTrafficValidator.AddRule("ProviderServiceId", ServiceIdRule)
+	dependencyTrafficValidator.AddRule("ErrorRate", &validate.ValidateRule{Max: 1})
+	dependencyTrafficValidator.AddRule("Source", &validate.ValidateRule{Length: 64, Regexp: simpleNameAllowEmptyRegex})
+	DependencyTrafficReqValidator.AddRule("Traffics", &validate.ValidateRule{Min: 1, Max: 1000})
+	DependencyTrafficReqValidator.AddSub("Traffics", &dependencyTrafficValidator)
+	DependencyTrafficReqValidator.AddRule("Ttl", &validate.ValidateRule{Max: 86400, Regexp: numberRegex})
+
+	StartupOrderReqValidator.AddRule("AppId", MicroServiceKeyValidator.GetRule("AppId"))
+	StartupOrderReqValidator.AddRule("Environment", MicroServiceKeyValidator.GetRule("Environment"))
+
+	TopologyReqValidator.AddRule("AppId", &validate.ValidateRule{Max: 160, Regexp: nameRegex})
+	TopologyReqValidator.AddRule("Offset", &validate.ValidateRule{Regexp: numberRegex})
+	TopologyReqValidator.AddRule("Limit", &validate.ValidateRule{Max: 1000, Regexp: numberRegex})
+
+	// 查询范围，如30m、24h、7d
+	historyWindowRegex, _ := regexp.Compile(`^([1-9][0-9]{0,3}[mhd])?$`)
+	InstanceHistoryReqValidator.AddRule("ServiceId", ServiceIdRule)
+	InstanceHistoryReqValidator.AddRule("Window", &validate.ValidateRule{Regexp: historyWindowRegex})
 }
 
 func Validate(v interface{}) error {
@@ -203,29 +375,75 @@ func Validate(v interface{}) error {
 	switch t := v.(type) {
 	case (*pb.MicroService):
 		return MicroServiceValidator.Validate(v)
-	case *pb.MicroServiceInstance, *pb.UpdateInstanceStatusRequest:
+	case *pb.MicroServiceInstance, *pb.UpdateInstanceStatusRequest,
+		*pb.UpdateInstanceCapacityRequest:
 		return MicroServiceInstanceValidator.Validate(v)
 	case (*pb.AddOrUpdateServiceRule):
 		return ServiceRuleValidator.Validate(v)
 	case *pb.GetServiceRequest, *pb.UpdateServicePropsRequest,
 		*pb.DeleteServiceRequest, *pb.GetDependenciesRequest,
-		*pb.GetAllSchemaRequest:
+		*pb.GetAllSchemaRequest, *pb.GetSchemaComplianceRequest:
 		return GetServiceReqValidator.Validate(v)
+	case *pb.GetServicesRequest:
+		return GetServicesReqValidator.Validate(v)
 	case *pb.AddServiceTagsRequest, *pb.DeleteServiceTagsRequest,
 		*pb.UpdateServiceTagRequest, *pb.GetServiceTagsRequest:
 		return TagReqValidator.Validate(v)
+	case *pb.UpdateServiceCatalogRequest:
+		return ServiceCatalogReqValidator.Validate(v)
+	case *pb.UpdateServiceRetirementRequest:
+		return ServiceRetirementReqValidator.Validate(v)
+	case *pb.UpdateSchemaFreezeRequest:
+		return SchemaFreezeReqValidator.Validate(v)
+	case *pb.GetDiscoveryPolicyRequest, *pb.UpdateDiscoveryPolicyRequest,
+		*pb.DeleteDiscoveryPolicyRequest:
+		return DiscoveryPolicyReqValidator.Validate(v)
+	case *pb.ApproveDependencyRequest, *pb.RevokeDependencyApprovalRequest,
+		*pb.GetDependencyApprovalsRequest, *pb.GetProviderAccessorsRequest:
+		return DependencyApprovalValidator.Validate(v)
+	case *pb.GetDependencyHistoryRequest:
+		return DependencyHistoryValidator.Validate(v)
+	case *pb.GrantDelegationRequest, *pb.RevokeDelegationRequest,
+		*pb.GetDelegationsRequest, *pb.DelegateUnregisterInstanceRequest:
+		return DelegationReqValidator.Validate(v)
+	case *pb.BroadcastRequest:
+		return BroadcastReqValidator.Validate(v)
+	case *pb.GetSnapshotRequest, *pb.DeleteSnapshotRequest:
+		return SnapshotReqValidator.Validate(v)
+	case *pb.CreateApiKeyRequest:
+		return CreateApiKeyReqValidator.Validate(v)
+	case *pb.RotateApiKeyRequest, *pb.DeleteApiKeyRequest:
+		return ApiKeyReqValidator.Validate(v)
 	case *pb.GetSchemaRequest, *pb.DeleteSchemaRequest:
 		return GetSchemaReqValidator.Validate(v)
 	case *pb.ModifySchemaRequest:
 		return SchemaValidator.Validate(v)
 	case *pb.ModifySchemasRequest:
 		return SchemasValidator.Validate(v)
+	case *pb.ModifySharedDefinitionRequest, *pb.DeleteSharedDefinitionRequest:
+		return SharedDefinitionValidator.Validate(v)
 	case *pb.FindInstancesRequest:
 		return FindInstanceReqValidator.Validate(v)
 	case *pb.GetOneInstanceRequest, *pb.GetInstancesRequest:
 		return GetInstanceValidator.Validate(v)
+	case *pb.PromoteInstancesRequest:
+		return PromoteInstancesReqValidator.Validate(v)
+	case *pb.SearchInstancesRequest:
+		return SearchInstancesReqValidator.Validate(v)
+	case *pb.UnregisterInstancesRequest:
+		return UnregisterInstancesValidator.Validate(v)
+	case *pb.ReportDependencyTrafficRequest:
+		return DependencyTrafficReqValidator.Validate(v)
+	case *pb.BatchGetExistenceRequest:
+		return BatchExistenceReqValidator.Validate(v)
 	case *pb.GetAppsRequest:
 		return MicroServiceKeyValidator.Validate(v)
+	case *pb.GetStartupOrderRequest:
+		return StartupOrderReqValidator.Validate(v)
+	case *pb.GetTopologyRequest, *pb.GetBlastRadiusRequest:
+		return TopologyReqValidator.Validate(v)
+	case *pb.GetInstanceHistoryRequest:
+		return InstanceHistoryReqValidator.Validate(v)
 	default:
 		util.Logger().Errorf(nil, "No validator for %T.", t)
 		return nil
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package core
+
+import (
+	"fmt"
+	"github.com/astaxie/beego"
+	"sync"
+	"time"
+)
+
+// ConnTuning 前端连接的保活、空闲超时和并发流参数，时长为空或0表示不启用；
+// 运行时修改只对新建立的连接生效，gRPC的存量连接会收到GOAWAY后由客户端重连
+type ConnTuning struct {
+	// REST，http2仅在TLS监听上协商
+	TCPKeepAlivePeriod        string `json:"tcpKeepAlivePeriod"`
+	HTTP2Enabled              bool   `json:"http2Enabled"`
+	HTTP2MaxConcurrentStreams uint32 `json:"http2MaxConcurrentStreams"`
+	HTTP2IdleTimeout          string `json:"http2IdleTimeout"`
+	// gRPC
+	GRPCKeepAliveInterval    string `json:"grpcKeepAliveInterval"`
+	GRPCKeepAliveTimeout     string `json:"grpcKeepAliveTimeout"`
+	GRPCKeepAliveMinTime     string `json:"grpcKeepAliveMinTime"`
+	GRPCMaxConnectionIdle    string `json:"grpcMaxConnectionIdle"`
+	GRPCMaxConcurrentStreams uint32 `json:"grpcMaxConcurrentStreams"`
+}
+
+func ParseTuningDuration(s string) time.Duration {
+	d, _ := time.ParseDuration(s)
+	return d
+}
+
+func (t *ConnTuning) Check() error {
+	for name, v := range map[string]string{
+		"tcpKeepAlivePeriod":    t.TCPKeepAlivePeriod,
+		"http2IdleTimeout":      t.HTTP2IdleTimeout,
+		"grpcKeepAliveInterval": t.GRPCKeepAliveInterval,
+		"grpcKeepAliveTimeout":  t.GRPCKeepAliveTimeout,
+		"grpcKeepAliveMinTime":  t.GRPCKeepAliveMinTime,
+		"grpcMaxConnectionIdle": t.GRPCMaxConnectionIdle,
+	} {
+		if len(v) == 0 {
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid %s '%s'", name, v)
+		}
+	}
+	// grpc不允许1s以下的ping间隔
+	if d := ParseTuningDuration(t.GRPCKeepAliveInterval); d > 0 && d < time.Second {
+		return fmt.Errorf("grpcKeepAliveInterval '%s' is less than 1s", t.GRPCKeepAliveInterval)
+	}
+	return nil
+}
+
+type ConnTuningListener func(prev, cur ConnTuning) error
+
+var (
+	connTuningLock      sync.RWMutex
+	connTuning          = loadConnTuning()
+	connTuningListeners []ConnTuningListener
+)
+
+func loadConnTuning() ConnTuning {
+	return ConnTuning{
+		TCPKeepAlivePeriod:        beego.AppConfig.DefaultString("tcp_keepalive_period", "1m"),
+		HTTP2Enabled:              beego.AppConfig.DefaultBool("http2_enabled", false),
+		HTTP2MaxConcurrentStreams: uint32(beego.AppConfig.DefaultInt("http2_max_concurrent_streams", 250)),
+		HTTP2IdleTimeout:          beego.AppConfig.DefaultString("http2_idle_timeout", ""),
+		GRPCKeepAliveInterval:     beego.AppConfig.DefaultString("grpc_keepalive_interval", "30s"),
+		GRPCKeepAliveTimeout:      beego.AppConfig.DefaultString("grpc_keepalive_timeout", "20s"),
+		GRPCKeepAliveMinTime:      beego.AppConfig.DefaultString("grpc_keepalive_min_time", "10s"),
+		GRPCMaxConnectionIdle:     beego.AppConfig.DefaultString("grpc_max_connection_idle", ""),
+		GRPCMaxConcurrentStreams:  uint32(beego.AppConfig.DefaultInt("grpc_max_concurrent_streams", 0)),
+	}
+}
+
+func GetConnTuning() ConnTuning {
+	connTuningLock.RLock()
+	defer connTuningLock.RUnlock()
+	return connTuning
+}
+
+// OnConnTuningChanged 注册运行时调整连接参数的回调，由前端在创建时注册
+func OnConnTuningChanged(f ConnTuningListener) {
+	connTuningLock.Lock()
+	connTuningListeners = append(connTuningListeners, f)
+	connTuningLock.Unlock()
+}
+
+// SetConnTuning 通知所有前端应用新的参数，部分前端应用失败时返回第一个错误，
+// 已生效的前端不回滚，参数仍以本次设置为准
+func SetConnTuning(t ConnTuning) error {
+	if err := t.Check(); err != nil {
+		return err
+	}
+	connTuningLock.Lock()
+	defer connTuningLock.Unlock()
+	prev := connTuning
+	if t.HTTP2Enabled != prev.HTTP2Enabled {
+		return fmt.Errorf("http2Enabled can only be changed by http2_enabled and restart")
+	}
+	connTuning = t
+	var err error
+	for _, f := range connTuningListeners {
+		if e := f(prev, t); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
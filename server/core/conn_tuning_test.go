@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConnTuningCheck(t *testing.T) {
+	for _, c := range []struct {
+		tuning ConnTuning
+		ok     bool
+	}{
+		{ConnTuning{}, true},
+		{ConnTuning{TCPKeepAlivePeriod: "1m", GRPCKeepAliveInterval: "30s", GRPCMaxConnectionIdle: "0"}, true},
+		{ConnTuning{HTTP2IdleTimeout: "abc"}, false},
+		{ConnTuning{GRPCKeepAliveTimeout: "-1s"}, false},
+		{ConnTuning{GRPCKeepAliveInterval: "500ms"}, false},
+	} {
+		if err := c.tuning.Check(); (err == nil) != c.ok {
+			t.Fatalf("TestConnTuningCheck failed, %v, %v", c.tuning, err)
+		}
+	}
+}
+
+func TestSetConnTuning(t *testing.T) {
+	connTuningLock.Lock()
+	old, oldListeners := connTuning, connTuningListeners
+	connTuning, connTuningListeners = ConnTuning{GRPCKeepAliveInterval: "30s"}, nil
+	connTuningLock.Unlock()
+	defer func() {
+		connTuningLock.Lock()
+		connTuning, connTuningListeners = old, oldListeners
+		connTuningLock.Unlock()
+	}()
+
+	var calls []ConnTuning
+	OnConnTuningChanged(func(prev, cur ConnTuning) error {
+		calls = append(calls, prev, cur)
+		return nil
+	})
+	OnConnTuningChanged(func(prev, cur ConnTuning) error {
+		return errors.New("apply failed")
+	})
+
+	// 参数非法或修改http2开关时不通知前端
+	if SetConnTuning(ConnTuning{GRPCKeepAliveInterval: "1ms"}) == nil ||
+		SetConnTuning(ConnTuning{GRPCKeepAliveInterval: "30s", HTTP2Enabled: true}) == nil || len(calls) != 0 {
+		t.Fatalf("TestSetConnTuning failed, invalid tuning applied, %v", calls)
+	}
+
+	// 部分前端应用失败时返回错误，参数仍以本次设置为准
+	cur := ConnTuning{GRPCKeepAliveInterval: "1m", GRPCMaxConcurrentStreams: 100}
+	if err := SetConnTuning(cur); err == nil || err.Error() != "apply failed" {
+		t.Fatalf("TestSetConnTuning failed, error of the listener should be returned, %v", err)
+	}
+	if len(calls) != 2 || calls[0].GRPCKeepAliveInterval != "30s" || calls[1] != cur || GetConnTuning() != cur {
+		t.Fatalf("TestSetConnTuning failed, calls %v, current %v", calls, GetConnTuning())
+	}
+}
@@ -41,8 +41,11 @@ func newInfo() *pb.ServerInformation {
 	return &pb.ServerInformation{
 		Version: "0",
 		Config: &pb.ServerConfig{
-			MaxHeaderBytes: int64(beego.AppConfig.DefaultInt("max_header_bytes", 16384)),
-			MaxBodyBytes:   beego.AppConfig.DefaultInt64("max_body_bytes", 2097152),
+			MaxHeaderBytes:   int64(beego.AppConfig.DefaultInt("max_header_bytes", 16384)),
+			MaxBodyBytes:     beego.AppConfig.DefaultInt64("max_body_bytes", 2097152),
+			MaxBodyBytesApis: ParseBodyLimits(beego.AppConfig.DefaultString("max_body_bytes_apis", "")),
+
+			RetryDisabledApis: ParseApis(beego.AppConfig.DefaultString("manager_retry_disabled_apis", "")),
 
 			ReadHeaderTimeout: beego.AppConfig.DefaultString("read_header_timeout", "60s"),
 			ReadTimeout:       beego.AppConfig.DefaultString("read_timeout", "60s"),
@@ -54,6 +57,36 @@ func newInfo() *pb.ServerInformation {
 			LimitIPLookup: beego.AppConfig.DefaultString("limit_iplookups",
 				"RemoteAddr,X-Forwarded-For,X-Real-IP"),
 
+			WatchMaxSubscribers:          beego.AppConfig.DefaultInt64("watch_max_subscribers", 0),
+			WatchMaxSubscribersPerTenant: beego.AppConfig.DefaultInt64("watch_max_subscribers_per_tenant", 0),
+			WatchCompactWindow:           beego.AppConfig.DefaultString("watch_compact_window", "1s"),
+			WatchHistorySize:             beego.AppConfig.DefaultInt64("watch_history_size", 10000),
+
+			BroadcastWebhookEnabled: beego.AppConfig.DefaultBool("broadcast_webhook_enabled", false),
+
+			KeepAliveGracePeriod: beego.AppConfig.DefaultString("keepalive_grace_period", "5s"),
+
+			CorsAllowOrigins:     beego.AppConfig.DefaultString("cors_allow_origins", "*"),
+			CorsAllowMethods:     beego.AppConfig.String("cors_allow_methods"),
+			CorsAllowHeaders:     beego.AppConfig.String("cors_allow_headers"),
+			CorsExposeHeaders:    beego.AppConfig.String("cors_expose_headers"),
+			CorsAllowCredentials: beego.AppConfig.DefaultBool("cors_allow_credentials", false),
+			CorsMaxAge:           beego.AppConfig.DefaultInt64("cors_max_age", 1500),
+
+			TrustedProxies: beego.AppConfig.String("trusted_proxies"),
+
+			AutoCreateDependency: beego.AppConfig.DefaultBool("auto_create_dependency", true),
+
+			EnrichInstanceMetadata: beego.AppConfig.DefaultBool("enrich_instance_metadata", false),
+
+			SchemaSummaryGenerate: beego.AppConfig.DefaultBool("schema_summary_generate", false),
+			SchemaSummaryVerify:   beego.AppConfig.DefaultBool("schema_summary_verify", false),
+
+			SchemaProxyEnabled:      beego.AppConfig.DefaultBool("schema_proxy_enabled", false),
+			SchemaProxyAllowedHosts: beego.AppConfig.DefaultString("schema_proxy_allowed_hosts", ""),
+			SchemaProxyTimeout:      beego.AppConfig.DefaultString("schema_proxy_timeout", "5s"),
+			SchemaProxyCacheTTL:     beego.AppConfig.DefaultString("schema_proxy_cache_ttl", "5m"),
+
 			SslEnabled:    beego.AppConfig.DefaultInt("ssl_mode", 1) != 0,
 			SslMinVersion: beego.AppConfig.DefaultString("ssl_min_version", "TLSv1.2"),
 			SslVerifyPeer: beego.AppConfig.DefaultInt("ssl_verify_client", 1) != 0,
@@ -70,6 +103,14 @@ func newInfo() *pb.ServerInformation {
 			LogFormat:      beego.AppConfig.DefaultString("log_format", "text"),
 			LogSys:         beego.AppConfig.DefaultBool("log_sys", false),
 
+			AccessLogEnabled:       beego.AppConfig.DefaultBool("access_log_enabled", false),
+			AccessLogFile:          beego.AppConfig.String("access_log_file"),
+			AccessLogFormat:        beego.AppConfig.DefaultString("access_log_format", "text"),
+			AccessLogSampleRate:    beego.AppConfig.DefaultFloat("access_log_sample_rate", 1),
+			AccessLogSlowThreshold: beego.AppConfig.DefaultString("access_log_slow_threshold", "1s"),
+
+			MigrationDryRun: beego.AppConfig.DefaultBool("migration_dry_run", false),
+
 			PluginsDir: beego.AppConfig.DefaultString("plugins_dir", "./plugins"),
 		},
 	}
@@ -34,13 +34,38 @@ const (
 	REGISTRY_DOMAIN_KEY         = "domains"
 	REGISTRY_PROJECT_KEY        = "projects"
 	REGISTRY_ALIAS_KEY          = "alias"
+	REGISTRY_NAME_INDEX_KEY     = "name-indexes"
 	REGISTRY_TAG_KEY            = "tags"
+	REGISTRY_POLICY_KEY         = "policies"
 	REGISTRY_SCHEMA_KEY         = "schemas"
 	REGISTRY_SCHEMA_SUMMARY_KEY = "schema-sum"
+	REGISTRY_SHARED_DEF_KEY     = "shared-defs"
 	REGISTRY_LEASE_KEY          = "leases"
+	REGISTRY_STATIC_KEY         = "statics"
+	REGISTRY_PENDING_KEY        = "pendings"
+	REGISTRY_STATE_KEY          = "states"
 	REGISTRY_DEPENDENCY_KEY     = "deps"
 	REGISTRY_DEPS_RULE_KEY      = "dep-rules"
+	REGISTRY_APPROVAL_KEY       = "approvals"
+	REGISTRY_DEP_USAGE_KEY      = "dep-usages"
+	REGISTRY_DEP_TRAFFIC_KEY    = "dep-traffics"
+	REGISTRY_DEL_JOURNAL_KEY    = "del-journals"
 	REGISTRY_METRICS_KEY        = "metrics"
+	REGISTRY_SNAPSHOT_KEY       = "snapshots"
+	REGISTRY_APIKEY_KEY         = "apikeys"
+	REGISTRY_APIKEY_INDEX       = "apikey-index"
+	REGISTRY_SHARED_SERVICE_KEY = "shared-services"
+	REGISTRY_DELEGATION_KEY     = "delegations"
+	REGISTRY_DELEGATION_AUDIT   = "delegation-audits"
+	REGISTRY_MIGRATION_KEY      = "migrations"
+	REGISTRY_LEASE_POLICY_KEY   = "lease-policies"
+	REGISTRY_DISCOVERY_SCOPE    = "discovery-scopes"
+	REGISTRY_FEATURE_FLAG_KEY   = "feature-flags"
+	REGISTRY_STORAGE_MIGRATION  = "storage-migration"
+	REGISTRY_WATCH_SUB_KEY      = "watch-subs"
+	REGISTRY_LEADER_KEY         = "leaders"
+	REGISTRY_HISTORY_KEY        = "histories"
+	REGISTRY_PEER_KEY           = "peers"
 	ENDPOINTS_ROOT_KEY          = "eps"
 )
 
@@ -78,6 +103,15 @@ func GetServiceAliasRootKey(domainProject string) string {
 	}, "/")
 }
 
+func GetServiceNameIndexRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_SERVICE_KEY,
+		REGISTRY_NAME_INDEX_KEY,
+		domainProject,
+	}, "/")
+}
+
 func GetServiceRuleRootKey(domainProject string) string {
 	return util.StringJoin([]string{
 		GetRootKey(),
@@ -105,6 +139,69 @@ func GetServiceTagRootKey(domainProject string) string {
 	}, "/")
 }
 
+func GetServicePolicyRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_SERVICE_KEY,
+		REGISTRY_POLICY_KEY,
+		domainProject,
+	}, "/")
+}
+
+func GetDependencyApprovalRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_SERVICE_KEY,
+		REGISTRY_APPROVAL_KEY,
+		domainProject,
+	}, "/")
+}
+
+func GetDependencyUsageRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_SERVICE_KEY,
+		REGISTRY_DEP_USAGE_KEY,
+		domainProject,
+	}, "/")
+}
+
+func GetDelegationRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_SERVICE_KEY,
+		REGISTRY_DELEGATION_KEY,
+		domainProject,
+	}, "/")
+}
+
+func GetDelegationAuditRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_SERVICE_KEY,
+		REGISTRY_DELEGATION_AUDIT,
+		domainProject,
+	}, "/")
+}
+
+func GetSharedDefinitionRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_SERVICE_KEY,
+		REGISTRY_SHARED_DEF_KEY,
+		domainProject,
+	}, "/")
+}
+
+func GetServiceDeleteJournalRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_SERVICE_KEY,
+		REGISTRY_DEL_JOURNAL_KEY,
+		domainProject,
+	}, "/")
+}
+
 func GetServiceSchemaRootKey(domainProject string) string {
 	return util.StringJoin([]string{
 		GetRootKey(),
@@ -201,6 +298,15 @@ func GenerateServiceRuleKey(domainProject string, serviceId string, ruleId strin
 	}, "/")
 }
 
+// GenerateServiceNameIndexKey 按serviceName跨appId索引服务，serviceId为空时为该名称的前缀
+func GenerateServiceNameIndexKey(domainProject string, serviceName string, serviceId string) string {
+	return util.StringJoin([]string{
+		GetServiceNameIndexRootKey(domainProject),
+		serviceName,
+		serviceId,
+	}, "/")
+}
+
 func GenerateServiceTagKey(domainProject string, serviceId string) string {
 	return util.StringJoin([]string{
 		GetServiceTagRootKey(domainProject),
@@ -208,6 +314,59 @@ func GenerateServiceTagKey(domainProject string, serviceId string) string {
 	}, "/")
 }
 
+func GenerateServicePolicyKey(domainProject string, serviceId string) string {
+	return util.StringJoin([]string{
+		GetServicePolicyRootKey(domainProject),
+		serviceId,
+	}, "/")
+}
+
+func GenerateDependencyApprovalKey(domainProject string, providerId string, consumerId string) string {
+	return util.StringJoin([]string{
+		GetDependencyApprovalRootKey(domainProject),
+		providerId,
+		consumerId,
+	}, "/")
+}
+
+func GenerateDependencyUsageKey(domainProject string, consumerId string, providerId string) string {
+	return util.StringJoin([]string{
+		GetDependencyUsageRootKey(domainProject),
+		consumerId,
+		providerId,
+	}, "/")
+}
+
+func GenerateDelegationKey(domainProject string, serviceId string, controllerId string) string {
+	return util.StringJoin([]string{
+		GetDelegationRootKey(domainProject),
+		serviceId,
+		controllerId,
+	}, "/")
+}
+
+func GenerateDelegationAuditKey(domainProject string, serviceId string, auditId string) string {
+	return util.StringJoin([]string{
+		GetDelegationAuditRootKey(domainProject),
+		serviceId,
+		auditId,
+	}, "/")
+}
+
+func GenerateSharedDefinitionKey(domainProject string, name string) string {
+	return util.StringJoin([]string{
+		GetSharedDefinitionRootKey(domainProject),
+		name,
+	}, "/")
+}
+
+func GenerateServiceDeleteJournalKey(domainProject string, serviceId string) string {
+	return util.StringJoin([]string{
+		GetServiceDeleteJournalRootKey(domainProject),
+		serviceId,
+	}, "/")
+}
+
 func GenerateServiceSchemaKey(domainProject string, serviceId string, schemaId string) string {
 	return util.StringJoin([]string{
 		GetServiceSchemaRootKey(domainProject),
@@ -256,6 +415,77 @@ func GenerateInstanceLeaseKey(domainProject string, serviceId string, instanceId
 	}, "/")
 }
 
+func GetStaticInstanceRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_INSTANCE_KEY,
+		REGISTRY_STATIC_KEY,
+		domainProject,
+	}, "/")
+}
+
+// GenerateStaticInstanceKey 静态实例的索引，用于配额统计和主动探测
+func GenerateStaticInstanceKey(domainProject string, serviceId string, instanceId string) string {
+	return util.StringJoin([]string{
+		GetStaticInstanceRootKey(domainProject),
+		serviceId,
+		instanceId,
+	}, "/")
+}
+
+func GetPendingInstanceRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_INSTANCE_KEY,
+		REGISTRY_PENDING_KEY,
+		domainProject,
+	}, "/")
+}
+
+// GeneratePendingInstanceKey 预注册实例的计划激活索引，value为计划激活时间
+func GeneratePendingInstanceKey(domainProject string, serviceId string, instanceId string) string {
+	return util.StringJoin([]string{
+		GetPendingInstanceRootKey(domainProject),
+		serviceId,
+		instanceId,
+	}, "/")
+}
+
+func GetInstanceStateRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_INSTANCE_KEY,
+		REGISTRY_STATE_KEY,
+		domainProject,
+	}, "/")
+}
+
+// GenerateInstanceStateKey 随心跳上报的实例状态，value为InstanceState
+func GenerateInstanceStateKey(domainProject string, serviceId string, instanceId string) string {
+	return util.StringJoin([]string{
+		GetInstanceStateRootKey(domainProject),
+		serviceId,
+		instanceId,
+	}, "/")
+}
+
+func GetInstanceHistoryRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_INSTANCE_KEY,
+		REGISTRY_HISTORY_KEY,
+		domainProject,
+	}, "/")
+}
+
+// GenerateInstanceHistoryKey 服务健康实例数的采样历史，value为环形缓冲区
+func GenerateInstanceHistoryKey(domainProject string, serviceId string) string {
+	return util.StringJoin([]string{
+		GetInstanceHistoryRootKey(domainProject),
+		serviceId,
+	}, "/")
+}
+
 func GenerateServiceDependencyRuleKey(serviceType string, domainProject string, in *pb.MicroServiceKey) string {
 	appId := in.AppId
 	if len(strings.TrimSpace(appId)) == 0 {
@@ -347,6 +577,44 @@ func GetSystemKey() string {
 	}, "/")
 }
 
+func GetMigrationRootKey() string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_MIGRATION_KEY,
+	}, "/")
+}
+
+// GenerateDataVersionKey 记录已完成迁移的数据布局版本
+func GenerateDataVersionKey() string {
+	return util.StringJoin([]string{
+		GetMigrationRootKey(),
+		"version",
+	}, "/")
+}
+
+func GenerateMigrationRecordKey(version string) string {
+	return util.StringJoin([]string{
+		GetMigrationRootKey(),
+		"records",
+		version,
+	}, "/")
+}
+
+func GetLeaderRootKey() string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_LEADER_KEY,
+	}, "/")
+}
+
+// GenerateLeaderKey 选举的leader，value为持有者，随租约过期
+func GenerateLeaderKey(name string) string {
+	return util.StringJoin([]string{
+		GetLeaderRootKey(),
+		name,
+	}, "/")
+}
+
 func GetMetricsRootKey() string {
 	return util.StringJoin([]string{
 		GetRootKey(),
@@ -363,6 +631,159 @@ func GenerateMetricsKey(name, utc, domain string) string {
 	}, "/")
 }
 
+// GetDependencyGraphEdgesRootKey 各租户最近一次依赖图快照的边，用于计算新增和删除的边
+func GetDependencyGraphEdgesRootKey() string {
+	return util.StringJoin([]string{
+		GetMetricsRootKey(),
+		"dependency-graph-edges",
+	}, "/")
+}
+
+func GenerateDependencyGraphEdgesKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetDependencyGraphEdgesRootKey(),
+		domainProject,
+	}, "/")
+}
+
+func GetSnapshotRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_SNAPSHOT_KEY,
+		domainProject,
+	}, "/")
+}
+
+func GenerateSnapshotKey(domainProject string, snapshotId string) string {
+	return util.StringJoin([]string{
+		GetSnapshotRootKey(domainProject),
+		snapshotId,
+	}, "/")
+}
+
+func GetApiKeyRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_APIKEY_KEY,
+		domainProject,
+	}, "/")
+}
+
+func GenerateApiKeyKey(domainProject string, keyId string) string {
+	return util.StringJoin([]string{
+		GetApiKeyRootKey(domainProject),
+		keyId,
+	}, "/")
+}
+
+// GenerateApiKeyIndexKey 鉴权时由keyId查询所属的domainProject
+func GenerateApiKeyIndexKey(keyId string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_APIKEY_INDEX,
+		keyId,
+	}, "/")
+}
+
+// GetSharedServiceRootKey 共享服务不属于任何租户
+func GetSharedServiceRootKey() string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_SHARED_SERVICE_KEY,
+	}, "/")
+}
+
+func GenerateSharedServiceKey(appId, serviceName string) string {
+	return util.StringJoin([]string{
+		GetSharedServiceRootKey(),
+		appId,
+		serviceName,
+	}, "/")
+}
+
+// GetLeasePolicyRootKey 租约策略由管理员按domain/project配置
+func GetLeasePolicyRootKey() string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_LEASE_POLICY_KEY,
+	}, "/")
+}
+
+func GenerateLeasePolicyKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetLeasePolicyRootKey(),
+		domainProject,
+	}, "/")
+}
+
+// GetDiscoveryScopeRootKey 跨环境发现的范围由管理员按domain/project配置
+func GetDiscoveryScopeRootKey() string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_DISCOVERY_SCOPE,
+	}, "/")
+}
+
+func GenerateDiscoveryScopeKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetDiscoveryScopeRootKey(),
+		domainProject,
+	}, "/")
+}
+
+// GetFeatureFlagRootKey 特性开关由管理员配置，对所有租户生效
+func GetFeatureFlagRootKey() string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_FEATURE_FLAG_KEY,
+	}, "/")
+}
+
+func GenerateFeatureFlagKey(name string) string {
+	return util.StringJoin([]string{
+		GetFeatureFlagRootKey(),
+		name,
+	}, "/")
+}
+
+// GetPeerRootKey 登记的对端集群及其CA，集群内所有节点共享
+func GetPeerRootKey() string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_PEER_KEY,
+	}, "/")
+}
+
+func GeneratePeerKey(name string) string {
+	return util.StringJoin([]string{
+		GetPeerRootKey(),
+		name,
+	}, "/")
+}
+
+// GetStorageMigrationRootKey 双写迁移自身的数据，只保存在目标后端，不参与复制和一致性检查
+func GetStorageMigrationRootKey() string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_STORAGE_MIGRATION,
+	}, "/")
+}
+
+func GenerateStorageMigrationStateKey() string {
+	return util.StringJoin([]string{
+		GetStorageMigrationRootKey(),
+		"state",
+	}, "/")
+}
+
+func GenerateStorageMigrationLeaseKey(leaseID string) string {
+	return util.StringJoin([]string{
+		GetStorageMigrationRootKey(),
+		"leases",
+		leaseID,
+	}, "/")
+}
+
 func GetProjectRootKey(domain string) string {
 	return util.StringJoin([]string{
 		GetRootKey(),
@@ -396,3 +817,39 @@ func GenerateEndpointsIndexKey(domainProject, region, availableZone, nodeIP, end
 		endpoints,
 	}, "/")
 }
+
+func GetWatchSubscriptionRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_SERVICE_KEY,
+		REGISTRY_WATCH_SUB_KEY,
+		domainProject,
+	}, "/")
+}
+
+// GenerateWatchSubscriptionKey 消费者的持久化watch订阅，value为WatchSubscription，随租约过期
+func GenerateWatchSubscriptionKey(domainProject string, serviceId string, name string) string {
+	return util.StringJoin([]string{
+		GetWatchSubscriptionRootKey(domainProject),
+		serviceId,
+		name,
+	}, "/")
+}
+
+func GetDependencyTrafficRootKey(domainProject string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		REGISTRY_SERVICE_KEY,
+		REGISTRY_DEP_TRAFFIC_KEY,
+		domainProject,
+	}, "/")
+}
+
+// GenerateDependencyTrafficKey 外部上报的消费者到提供者的调用流量，value为DependencyTraffic，随租约过期
+func GenerateDependencyTrafficKey(domainProject string, consumerId string, providerId string) string {
+	return util.StringJoin([]string{
+		GetDependencyTrafficRootKey(domainProject),
+		consumerId,
+		providerId,
+	}, "/")
+}
@@ -0,0 +1,167 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package core
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/astaxie/beego"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// PropertiesLimits 实例properties的限制，0表示不限制
+type PropertiesLimits struct {
+	MaxKeys        int
+	MaxKeyLength   int
+	MaxValueLength int
+	MaxBytes       int
+}
+
+var (
+	DefaultPropertiesLimits PropertiesLimits
+	// 租户级别的限制，key为domain或domain/project
+	TenantPropertiesLimits map[string]PropertiesLimits
+	PropertiesKeyRegex     *regexp.Regexp
+)
+
+func init() {
+	DefaultPropertiesLimits = PropertiesLimits{
+		MaxKeys:        beego.AppConfig.DefaultInt("instance_properties_max_keys", 128),
+		MaxKeyLength:   beego.AppConfig.DefaultInt("instance_properties_max_key_length", 128),
+		MaxValueLength: beego.AppConfig.DefaultInt("instance_properties_max_value_length", 4096),
+		MaxBytes:       beego.AppConfig.DefaultInt("instance_properties_max_bytes", 65536),
+	}
+	TenantPropertiesLimits = ParsePropertiesLimits(DefaultPropertiesLimits,
+		beego.AppConfig.String("instance_properties_limits_overrides"))
+	if pattern := beego.AppConfig.String("instance_properties_key_pattern"); len(pattern) > 0 {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			util.Logger().Errorf(err, "invalid instance_properties_key_pattern %s", pattern)
+		} else {
+			PropertiesKeyRegex = regex
+		}
+	}
+}
+
+// ParsePropertiesLimits 解析租户级别的限制，格式如 domain1/project1:max_keys=16,max_bytes=4096;domain2:max_keys=256
+// 未指定的项沿用默认限制
+func ParsePropertiesLimits(def PropertiesLimits, s string) map[string]PropertiesLimits {
+	limits := make(map[string]PropertiesLimits)
+	for _, item := range strings.Split(s, ";") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		idx := strings.Index(item, ":")
+		if idx <= 0 {
+			util.Logger().Errorf(nil, "invalid instance properties limits override '%s'", item)
+			continue
+		}
+		tenant, l := strings.TrimSpace(item[:idx]), def
+		for _, kv := range strings.Split(item[idx+1:], ",") {
+			arr := strings.SplitN(kv, "=", 2)
+			if len(arr) != 2 {
+				util.Logger().Errorf(nil, "invalid instance properties limits override '%s'", item)
+				continue
+			}
+			v, err := strconv.Atoi(strings.TrimSpace(arr[1]))
+			if err != nil || v < 0 {
+				util.Logger().Errorf(err, "invalid instance properties limits override '%s'", item)
+				continue
+			}
+			switch strings.TrimSpace(arr[0]) {
+			case "max_keys":
+				l.MaxKeys = v
+			case "max_key_length":
+				l.MaxKeyLength = v
+			case "max_value_length":
+				l.MaxValueLength = v
+			case "max_bytes":
+				l.MaxBytes = v
+			default:
+				util.Logger().Errorf(nil, "unknown instance properties limit '%s'", arr[0])
+			}
+		}
+		limits[tenant] = l
+	}
+	return limits
+}
+
+// GetPropertiesLimits 依次查找domain/project、domain的限制，都没有时使用默认限制
+func GetPropertiesLimits(domainProject string) PropertiesLimits {
+	if l, ok := TenantPropertiesLimits[domainProject]; ok {
+		return l
+	}
+	if idx := strings.Index(domainProject, "/"); idx > 0 {
+		if l, ok := TenantPropertiesLimits[domainProject[:idx]]; ok {
+			return l
+		}
+	}
+	return DefaultPropertiesLimits
+}
+
+// PropertiesError properties超出限制或key不合法，Key为出错的key，总量超限时为空
+type PropertiesError struct {
+	Key    string
+	Reason string
+}
+
+// Field 出错的字段，如properties或properties.{key}
+func (e *PropertiesError) Field() string {
+	if len(e.Key) == 0 {
+		return "properties"
+	}
+	return "properties." + e.Key
+}
+
+func (e *PropertiesError) Error() string {
+	if len(e.Key) == 0 {
+		return "properties " + e.Reason
+	}
+	return fmt.Sprintf("property '%s' %s", e.Key, e.Reason)
+}
+
+// ValidateProperties 按租户的限制校验实例properties
+func ValidateProperties(domainProject string, properties map[string]string) *PropertiesError {
+	l := GetPropertiesLimits(domainProject)
+	if l.MaxKeys > 0 && len(properties) > l.MaxKeys {
+		return &PropertiesError{Reason: fmt.Sprintf("exceed the max keys %d", l.MaxKeys)}
+	}
+	size := 0
+	for k, v := range properties {
+		if len(k) == 0 {
+			return &PropertiesError{Reason: "contain an empty key"}
+		}
+		if l.MaxKeyLength > 0 && utf8.RuneCountInString(k) > l.MaxKeyLength {
+			return &PropertiesError{Key: k, Reason: fmt.Sprintf("key exceeds the max length %d", l.MaxKeyLength)}
+		}
+		if PropertiesKeyRegex != nil && !PropertiesKeyRegex.MatchString(k) {
+			return &PropertiesError{Key: k, Reason: fmt.Sprintf("key does not match %s", PropertiesKeyRegex)}
+		}
+		if l.MaxValueLength > 0 && utf8.RuneCountInString(v) > l.MaxValueLength {
+			return &PropertiesError{Key: k, Reason: fmt.Sprintf("value exceeds the max length %d", l.MaxValueLength)}
+		}
+		size += len(k) + len(v)
+	}
+	if l.MaxBytes > 0 && size > l.MaxBytes {
+		return &PropertiesError{Reason: fmt.Sprintf("exceed the max size %d bytes", l.MaxBytes)}
+	}
+	return nil
+}
@@ -34,21 +34,102 @@ const (
 	EVT_DELETE EventType = "DELETE"
 	EVT_EXPIRE EventType = "EXPIRE"
 	EVT_ERROR  EventType = "ERROR"
-	MS_UP      string    = "UP"
-	MS_DOWN    string    = "DOWN"
+	// list-watch全量快照推送完毕的标记，仅用于watch推送
+	EVT_INIT_DONE EventType = "INIT_DONE"
+	// 提供者黑白名单变化，仅用于watch推送
+	EVT_RULE_CHANGED EventType = "RULE_CHANGED"
+	// 提供者的实例或黑白名单已变化，只携带提供者key和revision，仅用于失效通知推送
+	EVT_INVALIDATE EventType = "INVALIDATE"
+	// 提供者发给消费者的通知，仅用于watch推送
+	EVT_BROADCAST EventType = "BROADCAST"
+	MS_UP         string    = "UP"
+	MS_DOWN       string    = "DOWN"
 
 	MSI_UP           string = "UP"
 	MSI_DOWN         string = "DOWN"
 	MSI_STARTING     string = "STARTING"
 	MSI_OUTOFSERVICE string = "OUTOFSERVICE"
+	// 待命实例保持心跳和注册，但不会被发现，提升为UP后才对消费者可见
+	MSI_STANDBY string = "STANDBY"
+	// 预注册的实例，用于提前登记切换后的地址，不会被发现，到达计划时间或调用激活接口后变为UP
+	MSI_PENDING string = "PENDING"
+
+	// 实例状态变更的预置原因码，也允许自定义
+	REASON_DEPLOYMENT          string = "DEPLOYMENT"
+	REASON_HEALTH_CHECK_FAILED string = "HEALTH_CHECK_FAILED"
+	REASON_MAINTENANCE         string = "MAINTENANCE"
+	REASON_MANUAL              string = "MANUAL"
+	REASON_PROMOTION           string = "PROMOTION"
+	REASON_ACTIVATION          string = "ACTIVATION"
+
+	// 按消费者平台发现实例的策略
+	PLATFORM_PREFER  string = "prefer"
+	PLATFORM_REQUIRE string = "require"
+
+	// 发现实例的返回顺序，总是取第一个实例的消费者也能分散到不同实例
+	ORDER_RANDOM       string = "random"
+	ORDER_LEAST_RECENT string = "leastRecent"
+	ORDER_ZONE         string = "zone"
+	ORDER_WEIGHT       string = "weight"
+
+	// 发现实例时只返回指定地址族的endpoint，供只能访问单栈的消费者使用
+	ADDRESS_FAMILY_IPV4 string = "ipv4"
+	ADDRESS_FAMILY_IPV6 string = "ipv6"
 
 	CHECK_BY_HEARTBEAT string = "push"
 	CHECK_BY_PLATFORM  string = "pull"
+	// 静态实例由外部管理，没有心跳和租约，状态通过接口或主动探测维护
+	CHECK_BY_STATIC string = "static"
 
 	EXISTENCE_MS     string = "microservice"
 	EXISTENCE_SCHEMA string = "schema"
 
-	PROP_ALLOW_CROSS_APP = "allowCrossApp"
+	PROP_ALLOW_CROSS_APP       = "allowCrossApp"
+	PROP_MIN_HEALTHY_INSTANCES = "minHealthyInstances"
+	PROP_REQUIRE_APPROVAL      = "requireApproval"
+	PROP_SCHEMA_SOURCE         = "schemaSource"
+	PROP_BROADCAST_WEBHOOK     = "broadcastWebhook"
+	PROP_SCHEMA_VISIBILITY     = "schemaVisibility"
+	// 消费者对提供者的依赖重要程度，格式为{提供者}:{程度},...，提供者为serviceName、appId/serviceName或*
+	PROP_DEPENDENCY_CRITICALITY = "dependencyCriticality"
+	// 实例的权重，按weight顺序发现时使用，未设置时为1，0表示尽量排在最后
+	PROP_INSTANCE_WEIGHT = "weight"
+
+	// 实例的保留属性，由服务端根据注册请求写入
+	PROP_RESERVED_PREFIX = "sc."
+	PROP_REGISTER_IP     = "sc.registerIp"
+	PROP_TLS_IDENTITY    = "sc.tlsIdentity"
+	PROP_USER_AGENT      = "sc.userAgent"
+	PROP_DELEGATED_BY    = "sc.delegatedBy"
+
+	APPROVAL_PENDING  string = "PENDING"
+	APPROVAL_APPROVED string = "APPROVED"
+
+	DELEGATION_REGISTER   string = "register"
+	DELEGATION_UNREGISTER string = "unregister"
+
+	// 契约的可见范围：租户内所有服务、依赖规则中声明的消费者、服务自身
+	SCHEMA_VISIBILITY_TENANT    string = "tenant"
+	SCHEMA_VISIBILITY_CONSUMERS string = "consumers"
+	SCHEMA_VISIBILITY_OWNERS    string = "owners"
+
+	// 契约合规检查项：有描述、定义了错误响应、声明了版本header
+	SCHEMA_CHECK_DESCRIPTION     string = "description"
+	SCHEMA_CHECK_ERROR_RESPONSES string = "errorResponses"
+	SCHEMA_CHECK_VERSION_HEADER  string = "versionHeader"
+
+	// 契约合规徽章：全部通过、部分通过、全部未通过、没有可检查的契约
+	COMPLIANCE_BADGE_PASSING string = "passing"
+	COMPLIANCE_BADGE_PARTIAL string = "partial"
+	COMPLIANCE_BADGE_FAILING string = "failing"
+	COMPLIANCE_BADGE_UNKNOWN string = "unknown"
+
+	CRITICALITY_HIGH   string = "high"
+	CRITICALITY_NORMAL string = "normal"
+	CRITICALITY_LOW    string = "low"
+
+	PERMISSION_ALLOW string = "ALLOW"
+	PERMISSION_DENY  string = "DENY"
 
 	Response_SUCCESS int32 = 0
 
@@ -70,7 +151,9 @@ type SerivceInstanceCtrlServerEx interface {
 
 	WebSocketWatch(ctx context.Context, in *WatchInstanceRequest, conn *websocket.Conn)
 	WebSocketListAndWatch(ctx context.Context, in *WatchInstanceRequest, conn *websocket.Conn)
+	WebSocketWatchInvalidations(ctx context.Context, in *WatchInstanceRequest, conn *websocket.Conn)
 	ClusterHealth(ctx context.Context) (*GetInstancesResponse, error)
+	ActivateInstances(ctx context.Context, in *PromoteInstancesRequest) (*PromoteInstancesResponse, error)
 }
 
 type GovernServiceCtrlServerEx interface {
@@ -84,6 +167,10 @@ type MicroServiceDependency struct {
 type ServerConfig struct {
 	MaxHeaderBytes int64 `json:"maxHeaderBytes"`
 	MaxBodyBytes   int64 `json:"maxBodyBytes"`
+	// 按API覆盖MaxBodyBytes，key为"METHOD pattern"
+	MaxBodyBytesApis map[string]int64 `json:"maxBodyBytesApis,omitempty"`
+	// 不重试后端临时错误的API，key为"METHOD pattern"
+	RetryDisabledApis map[string]struct{} `json:"retryDisabledApis,omitempty"`
 
 	ReadHeaderTimeout string `json:"readHeaderTimeout"`
 	ReadTimeout       string `json:"readTimeout"`
@@ -94,6 +181,36 @@ type ServerConfig struct {
 	LimitConnections int64  `json:"limitConnections"`
 	LimitIPLookup    string `json:"limitIPLookup"`
 
+	WatchMaxSubscribers          int64  `json:"watchMaxSubscribers"`
+	WatchMaxSubscribersPerTenant int64  `json:"watchMaxSubscribersPerTenant"`
+	WatchCompactWindow           string `json:"watchCompactWindow"`
+	WatchHistorySize             int64  `json:"watchHistorySize"`
+
+	BroadcastWebhookEnabled bool `json:"broadcastWebhookEnabled,string"`
+
+	KeepAliveGracePeriod string `json:"keepAliveGracePeriod"`
+
+	CorsAllowOrigins     string `json:"corsAllowOrigins"`
+	CorsAllowMethods     string `json:"corsAllowMethods"`
+	CorsAllowHeaders     string `json:"corsAllowHeaders"`
+	CorsExposeHeaders    string `json:"corsExposeHeaders"`
+	CorsAllowCredentials bool   `json:"corsAllowCredentials,string"`
+	CorsMaxAge           int64  `json:"corsMaxAge"`
+
+	TrustedProxies string `json:"trustedProxies"`
+
+	AutoCreateDependency bool `json:"autoCreateDependency,string"`
+
+	EnrichInstanceMetadata bool `json:"enrichInstanceMetadata,string"`
+
+	SchemaSummaryGenerate bool `json:"schemaSummaryGenerate,string"`
+	SchemaSummaryVerify   bool `json:"schemaSummaryVerify,string"`
+
+	SchemaProxyEnabled      bool   `json:"schemaProxyEnabled,string"`
+	SchemaProxyAllowedHosts string `json:"schemaProxyAllowedHosts"`
+	SchemaProxyTimeout      string `json:"schemaProxyTimeout"`
+	SchemaProxyCacheTTL     string `json:"schemaProxyCacheTTL"`
+
 	SslEnabled    bool   `json:"sslEnabled,string"`
 	SslMinVersion string `json:"sslMinVersion"`
 	SslVerifyPeer bool   `json:"sslVerifyPeer,string"`
@@ -110,6 +227,14 @@ type ServerConfig struct {
 	LogFormat      string `json:"-"`
 	LogSys         bool   `json:"-"`
 
+	AccessLogEnabled       bool    `json:"-"`
+	AccessLogFile          string  `json:"-"`
+	AccessLogFormat        string  `json:"-"`
+	AccessLogSampleRate    float64 `json:"-"`
+	AccessLogSlowThreshold string  `json:"-"`
+
+	MigrationDryRun bool `json:"-"`
+
 	PluginsDir string `json:"-"`
 }
 
@@ -130,6 +255,12 @@ func CreateResponse(code int32, message string) *Response {
 	return resp
 }
 
+func CreateResponseWithDetails(code int32, message string, details ...*ErrorDetail) *Response {
+	resp := CreateResponse(code, message)
+	resp.Details = details
+	return resp
+}
+
 func KvToResponse(kv *mvccpb.KeyValue) (keys []string, data []byte) {
 	keys = strings.Split(util.BytesToStringWithNoCopy(kv.Key), "/")
 	data = kv.Value
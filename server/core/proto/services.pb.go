@@ -15,6 +15,8 @@ It has these top-level messages:
 	HeartbeatSetElement
 	HeartbeatSetResponse
 	InstanceHbRst
+	KeepAliveRequest
+	KeepAliveResponse
 	StService
 	StInstance
 	StApp
@@ -24,12 +26,18 @@ It has these top-level messages:
 	MicroServiceKey
 	MicroService
 	FrameWorkProperty
+	ServiceCatalog
+	ServiceRetirement
 	ServiceRule
 	AddOrUpdateServiceRule
 	ServicePath
 	Response
+	ErrorDetail
 	GetExistenceRequest
 	GetExistenceResponse
+	BatchGetExistenceRequest
+	BatchGetExistenceResponse
+	ServiceExistence
 	CreateServiceRequest
 	CreateServiceResponse
 	DeleteServiceRequest
@@ -40,6 +48,10 @@ It has these top-level messages:
 	GetServicesResponse
 	UpdateServicePropsRequest
 	UpdateServicePropsResponse
+	UpdateServiceCatalogRequest
+	UpdateServiceCatalogResponse
+	UpdateServiceRetirementRequest
+	UpdateServiceRetirementResponse
 	GetServiceRulesRequest
 	GetServiceRulesResponse
 	UpdateServiceRuleRequest
@@ -48,6 +60,8 @@ It has these top-level messages:
 	AddServiceRulesResponse
 	DeleteServiceRulesRequest
 	DeleteServiceRulesResponse
+	ReplaceServiceRulesRequest
+	ReplaceServiceRulesResponse
 	GetServiceTagsRequest
 	GetServiceTagsResponse
 	UpdateServiceTagRequest
@@ -56,8 +70,17 @@ It has these top-level messages:
 	AddServiceTagsResponse
 	DeleteServiceTagsRequest
 	DeleteServiceTagsResponse
+	DiscoveryPolicy
+	GetDiscoveryPolicyRequest
+	GetDiscoveryPolicyResponse
+	UpdateDiscoveryPolicyRequest
+	UpdateDiscoveryPolicyResponse
+	DeleteDiscoveryPolicyRequest
+	DeleteDiscoveryPolicyResponse
 	HealthCheck
 	MicroServiceInstance
+	Platform
+	StatusReason
 	DataCenterInfo
 	MicroServiceInstanceKey
 	RegisterInstanceRequest
@@ -68,16 +91,23 @@ It has these top-level messages:
 	HeartbeatResponse
 	FindInstancesRequest
 	FindInstancesResponse
+	RetiringVersion
+	GovernanceConfig
 	GetOneInstanceRequest
 	GetOneInstanceResponse
 	GetInstancesRequest
 	GetInstancesResponse
 	UpdateInstanceStatusRequest
 	UpdateInstanceStatusResponse
+	PromoteInstancesRequest
+	PromoteInstancesResponse
+	UpdateInstanceCapacityRequest
+	UpdateInstanceCapacityResponse
 	UpdateInstancePropsRequest
 	UpdateInstancePropsResponse
 	WatchInstanceRequest
 	WatchInstanceResponse
+	WatchEventEnvelope
 	GetSchemaRequest
 	GetAllSchemaRequest
 	GetSchemaResponse
@@ -86,6 +116,13 @@ It has these top-level messages:
 	DeleteSchemaResponse
 	ModifySchemaRequest
 	ModifySchemaResponse
+	SharedDefinition
+	ModifySharedDefinitionRequest
+	ModifySharedDefinitionResponse
+	GetSharedDefinitionsRequest
+	GetSharedDefinitionsResponse
+	DeleteSharedDefinitionRequest
+	DeleteSharedDefinitionResponse
 	AddDependenciesRequest
 	AddDependenciesResponse
 	CreateDependenciesRequest
@@ -95,6 +132,20 @@ It has these top-level messages:
 	GetDependenciesRequest
 	GetConDependenciesResponse
 	GetProDependenciesResponse
+	DependencyApproval
+	ApproveDependencyRequest
+	ApproveDependencyResponse
+	RevokeDependencyApprovalRequest
+	RevokeDependencyApprovalResponse
+	GetDependencyApprovalsRequest
+	GetDependencyApprovalsResponse
+	DependencyUsage
+	ProviderAccessor
+	GetProviderAccessorsRequest
+	GetProviderAccessorsResponse
+	GetDependencyDiffResponse
+	GetDependencyHistoryRequest
+	GetDependencyHistoryResponse
 	ServiceDetail
 	GetServiceDetailResponse
 	DelServicesRequest
@@ -102,12 +153,78 @@ It has these top-level messages:
 	DelServicesResponse
 	GetAppsRequest
 	GetAppsResponse
+	SearchInstancesRequest
+	SearchInstancesResponse
+	ApplyServiceRequest
+	ApplyChange
+	ApplyServiceResponse
+	Snapshot
+	CreateSnapshotRequest
+	CreateSnapshotResponse
+	GetSnapshotRequest
+	GetSnapshotResponse
+	DeleteSnapshotRequest
+	DeleteSnapshotResponse
+	ApiKey
+	CreateApiKeyRequest
+	CreateApiKeyResponse
+	GetApiKeysRequest
+	GetApiKeysResponse
+	RotateApiKeyRequest
+	RotateApiKeyResponse
+	DeleteApiKeyRequest
+	DeleteApiKeyResponse
+	GetStartupOrderRequest
+	StartupOrderGroup
+	GetStartupOrderResponse
+	DelegationGrant
+	DelegationAudit
+	GrantDelegationRequest
+	GrantDelegationResponse
+	RevokeDelegationRequest
+	RevokeDelegationResponse
+	GetDelegationsRequest
+	GetDelegationsResponse
+	DelegateRegisterInstanceRequest
+	DelegateUnregisterInstanceRequest
+	BroadcastMessage
+	BroadcastRequest
+	BroadcastResponse
+	GetTopologyRequest
+	TopologyNode
+	TopologyEdge
+	GetTopologyResponse
+	InstanceState
+	GetBlastRadiusRequest
+	BlastRadiusItem
+	GetBlastRadiusResponse
+	GetSchemaComplianceRequest
+	SchemaComplianceCheck
+	SchemaCompliance
+	SchemaComplianceReport
+	GetSchemaComplianceResponse
+	UnregisterInstancesRequest
+	UnregisterInstancesResponse
+	DependencyTraffic
+	ReportDependencyTrafficRequest
+	ReportDependencyTrafficResponse
+	DependencyLintIssue
+	LintDependenciesRequest
+	LintDependenciesResponse
+	InstanceCountSample
+	GetInstanceHistoryRequest
+	GetInstanceHistoryResponse
+	SchemaFreeze
+	UpdateSchemaFreezeRequest
+	UpdateSchemaFreezeResponse
+	InstanceEndpoint
 */
 package proto
 
 import proto1 "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import _ "google.golang.org/genproto/googleapis/api/annotations"
 
 import (
 	context "golang.org/x/net/context"
@@ -126,8 +243,9 @@ var _ = math.Inf
 const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type ModifySchemasRequest struct {
-	ServiceId string    `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
-	Schemas   []*Schema `protobuf:"bytes,2,rep,name=schemas" json:"schemas,omitempty"`
+	ServiceId        string    `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Schemas          []*Schema `protobuf:"bytes,2,rep,name=schemas" json:"schemas,omitempty"`
+	ExpectedRevision string    `protobuf:"bytes,3,opt,name=expectedRevision" json:"expectedRevision,omitempty"`
 }
 
 func (m *ModifySchemasRequest) Reset()                    { *m = ModifySchemasRequest{} }
@@ -149,6 +267,13 @@ func (m *ModifySchemasRequest) GetSchemas() []*Schema {
 	return nil
 }
 
+func (m *ModifySchemasRequest) GetExpectedRevision() string {
+	if m != nil {
+		return m.ExpectedRevision
+	}
+	return ""
+}
+
 type Schema struct {
 	SchemaId string `protobuf:"bytes,1,opt,name=schemaId" json:"schemaId,omitempty"`
 	Summary  string `protobuf:"bytes,2,opt,name=summary" json:"summary,omitempty"`
@@ -183,6 +308,7 @@ func (m *Schema) GetSchema() string {
 
 type ModifySchemasResponse struct {
 	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Revision int64     `protobuf:"varint,2,opt,name=revision" json:"revision,omitempty"`
 }
 
 func (m *ModifySchemasResponse) Reset()                    { *m = ModifySchemasResponse{} }
@@ -197,6 +323,13 @@ func (m *ModifySchemasResponse) GetResponse() *Response {
 	return nil
 }
 
+func (m *ModifySchemasResponse) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 type HeartbeatSetRequest struct {
 	Instances []*HeartbeatSetElement `protobuf:"bytes,1,rep,name=instances" json:"instances,omitempty"`
 }
@@ -214,8 +347,9 @@ func (m *HeartbeatSetRequest) GetInstances() []*HeartbeatSetElement {
 }
 
 type HeartbeatSetElement struct {
-	ServiceId  string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
-	InstanceId string `protobuf:"bytes,2,opt,name=instanceId" json:"instanceId,omitempty"`
+	ServiceId  string            `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	InstanceId string            `protobuf:"bytes,2,opt,name=instanceId" json:"instanceId,omitempty"`
+	State      map[string]string `protobuf:"bytes,3,rep,name=state" json:"state,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *HeartbeatSetElement) Reset()                    { *m = HeartbeatSetElement{} }
@@ -237,6 +371,13 @@ func (m *HeartbeatSetElement) GetInstanceId() string {
 	return ""
 }
 
+func (m *HeartbeatSetElement) GetState() map[string]string {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
 type HeartbeatSetResponse struct {
 	Response  *Response        `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
 	Instances []*InstanceHbRst `protobuf:"bytes,2,rep,name=instances" json:"instances,omitempty"`
@@ -293,6 +434,47 @@ func (m *InstanceHbRst) GetErrMessage() string {
 	return ""
 }
 
+// 流上收到的实例绑定到该流，之后每条消息(可不带实例)均为所有已绑定的实例续约
+type KeepAliveRequest struct {
+	Instances []*HeartbeatSetElement `protobuf:"bytes,1,rep,name=instances" json:"instances,omitempty"`
+}
+
+func (m *KeepAliveRequest) Reset()                    { *m = KeepAliveRequest{} }
+func (m *KeepAliveRequest) String() string            { return proto1.CompactTextString(m) }
+func (*KeepAliveRequest) ProtoMessage()               {}
+func (*KeepAliveRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *KeepAliveRequest) GetInstances() []*HeartbeatSetElement {
+	if m != nil {
+		return m.Instances
+	}
+	return nil
+}
+
+type KeepAliveResponse struct {
+	Response  *Response        `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Instances []*InstanceHbRst `protobuf:"bytes,2,rep,name=instances" json:"instances,omitempty"`
+}
+
+func (m *KeepAliveResponse) Reset()                    { *m = KeepAliveResponse{} }
+func (m *KeepAliveResponse) String() string            { return proto1.CompactTextString(m) }
+func (*KeepAliveResponse) ProtoMessage()               {}
+func (*KeepAliveResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *KeepAliveResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *KeepAliveResponse) GetInstances() []*InstanceHbRst {
+	if m != nil {
+		return m.Instances
+	}
+	return nil
+}
+
 type StService struct {
 	Count       int64 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
 	OnlineCount int64 `protobuf:"varint,2,opt,name=onlineCount" json:"onlineCount,omitempty"`
@@ -301,7 +483,7 @@ type StService struct {
 func (m *StService) Reset()                    { *m = StService{} }
 func (m *StService) String() string            { return proto1.CompactTextString(m) }
 func (*StService) ProtoMessage()               {}
-func (*StService) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*StService) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *StService) GetCount() int64 {
 	if m != nil {
@@ -318,13 +500,14 @@ func (m *StService) GetOnlineCount() int64 {
 }
 
 type StInstance struct {
-	Count int64 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	Count    int64 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	Capacity int64 `protobuf:"varint,2,opt,name=capacity" json:"capacity,omitempty"`
 }
 
 func (m *StInstance) Reset()                    { *m = StInstance{} }
 func (m *StInstance) String() string            { return proto1.CompactTextString(m) }
 func (*StInstance) ProtoMessage()               {}
-func (*StInstance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*StInstance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *StInstance) GetCount() int64 {
 	if m != nil {
@@ -333,6 +516,13 @@ func (m *StInstance) GetCount() int64 {
 	return 0
 }
 
+func (m *StInstance) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
 type StApp struct {
 	Count int64 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
 }
@@ -340,7 +530,7 @@ type StApp struct {
 func (m *StApp) Reset()                    { *m = StApp{} }
 func (m *StApp) String() string            { return proto1.CompactTextString(m) }
 func (*StApp) ProtoMessage()               {}
-func (*StApp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*StApp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *StApp) GetCount() int64 {
 	if m != nil {
@@ -358,7 +548,7 @@ type Statistics struct {
 func (m *Statistics) Reset()                    { *m = Statistics{} }
 func (m *Statistics) String() string            { return proto1.CompactTextString(m) }
 func (*Statistics) ProtoMessage()               {}
-func (*Statistics) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*Statistics) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *Statistics) GetServices() *StService {
 	if m != nil {
@@ -391,7 +581,7 @@ type GetServicesInfoRequest struct {
 func (m *GetServicesInfoRequest) Reset()                    { *m = GetServicesInfoRequest{} }
 func (m *GetServicesInfoRequest) String() string            { return proto1.CompactTextString(m) }
 func (*GetServicesInfoRequest) ProtoMessage()               {}
-func (*GetServicesInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*GetServicesInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *GetServicesInfoRequest) GetOptions() []string {
 	if m != nil {
@@ -430,7 +620,7 @@ type GetServicesInfoResponse struct {
 func (m *GetServicesInfoResponse) Reset()                    { *m = GetServicesInfoResponse{} }
 func (m *GetServicesInfoResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GetServicesInfoResponse) ProtoMessage()               {}
-func (*GetServicesInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*GetServicesInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *GetServicesInfoResponse) GetResponse() *Response {
 	if m != nil {
@@ -466,7 +656,7 @@ type MicroServiceKey struct {
 func (m *MicroServiceKey) Reset()                    { *m = MicroServiceKey{} }
 func (m *MicroServiceKey) String() string            { return proto1.CompactTextString(m) }
 func (*MicroServiceKey) ProtoMessage()               {}
-func (*MicroServiceKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*MicroServiceKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *MicroServiceKey) GetTenant() string {
 	if m != nil {
@@ -536,12 +726,15 @@ type MicroService struct {
 	Environment  string             `protobuf:"bytes,16,opt,name=environment" json:"environment,omitempty"`
 	RegisterBy   string             `protobuf:"bytes,17,opt,name=registerBy" json:"registerBy,omitempty"`
 	Framework    *FrameWorkProperty `protobuf:"bytes,18,opt,name=framework" json:"framework,omitempty"`
+	Catalog      *ServiceCatalog    `protobuf:"bytes,19,opt,name=catalog" json:"catalog,omitempty"`
+	Retirement   *ServiceRetirement `protobuf:"bytes,20,opt,name=retirement" json:"retirement,omitempty"`
+	SchemaFreeze *SchemaFreeze      `protobuf:"bytes,21,opt,name=schemaFreeze" json:"schemaFreeze,omitempty"`
 }
 
 func (m *MicroService) Reset()                    { *m = MicroService{} }
 func (m *MicroService) String() string            { return proto1.CompactTextString(m) }
 func (*MicroService) ProtoMessage()               {}
-func (*MicroService) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*MicroService) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *MicroService) GetServiceId() string {
 	if m != nil {
@@ -669,6 +862,27 @@ func (m *MicroService) GetFramework() *FrameWorkProperty {
 	return nil
 }
 
+func (m *MicroService) GetCatalog() *ServiceCatalog {
+	if m != nil {
+		return m.Catalog
+	}
+	return nil
+}
+
+func (m *MicroService) GetRetirement() *ServiceRetirement {
+	if m != nil {
+		return m.Retirement
+	}
+	return nil
+}
+
+func (m *MicroService) GetSchemaFreeze() *SchemaFreeze {
+	if m != nil {
+		return m.SchemaFreeze
+	}
+	return nil
+}
+
 type FrameWorkProperty struct {
 	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Version string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
@@ -677,7 +891,7 @@ type FrameWorkProperty struct {
 func (m *FrameWorkProperty) Reset()                    { *m = FrameWorkProperty{} }
 func (m *FrameWorkProperty) String() string            { return proto1.CompactTextString(m) }
 func (*FrameWorkProperty) ProtoMessage()               {}
-func (*FrameWorkProperty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*FrameWorkProperty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *FrameWorkProperty) GetName() string {
 	if m != nil {
@@ -693,6 +907,88 @@ func (m *FrameWorkProperty) GetVersion() string {
 	return ""
 }
 
+// 服务目录信息，供UI展示
+type ServiceCatalog struct {
+	DocsUrl      string `protobuf:"bytes,1,opt,name=docsUrl" json:"docsUrl,omitempty"`
+	RepoUrl      string `protobuf:"bytes,2,opt,name=repoUrl" json:"repoUrl,omitempty"`
+	RunbookUrl   string `protobuf:"bytes,3,opt,name=runbookUrl" json:"runbookUrl,omitempty"`
+	DashboardUrl string `protobuf:"bytes,4,opt,name=dashboardUrl" json:"dashboardUrl,omitempty"`
+}
+
+func (m *ServiceCatalog) Reset()                    { *m = ServiceCatalog{} }
+func (m *ServiceCatalog) String() string            { return proto1.CompactTextString(m) }
+func (*ServiceCatalog) ProtoMessage()               {}
+func (*ServiceCatalog) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *ServiceCatalog) GetDocsUrl() string {
+	if m != nil {
+		return m.DocsUrl
+	}
+	return ""
+}
+
+func (m *ServiceCatalog) GetRepoUrl() string {
+	if m != nil {
+		return m.RepoUrl
+	}
+	return ""
+}
+
+func (m *ServiceCatalog) GetRunbookUrl() string {
+	if m != nil {
+		return m.RunbookUrl
+	}
+	return ""
+}
+
+func (m *ServiceCatalog) GetDashboardUrl() string {
+	if m != nil {
+		return m.DashboardUrl
+	}
+	return ""
+}
+
+// 版本下线计划，targetDate格式为YYYY-MM-DD
+type ServiceRetirement struct {
+	TargetDate        string `protobuf:"bytes,1,opt,name=targetDate" json:"targetDate,omitempty"`
+	Message           string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	ExcludeFromLatest bool   `protobuf:"varint,3,opt,name=excludeFromLatest" json:"excludeFromLatest,omitempty"`
+	Timestamp         string `protobuf:"bytes,4,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *ServiceRetirement) Reset()                    { *m = ServiceRetirement{} }
+func (m *ServiceRetirement) String() string            { return proto1.CompactTextString(m) }
+func (*ServiceRetirement) ProtoMessage()               {}
+func (*ServiceRetirement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *ServiceRetirement) GetTargetDate() string {
+	if m != nil {
+		return m.TargetDate
+	}
+	return ""
+}
+
+func (m *ServiceRetirement) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ServiceRetirement) GetExcludeFromLatest() bool {
+	if m != nil {
+		return m.ExcludeFromLatest
+	}
+	return false
+}
+
+func (m *ServiceRetirement) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
 type ServiceRule struct {
 	RuleId       string `protobuf:"bytes,1,opt,name=ruleId" json:"ruleId,omitempty"`
 	RuleType     string `protobuf:"bytes,2,opt,name=ruleType" json:"ruleType,omitempty"`
@@ -706,7 +1002,7 @@ type ServiceRule struct {
 func (m *ServiceRule) Reset()                    { *m = ServiceRule{} }
 func (m *ServiceRule) String() string            { return proto1.CompactTextString(m) }
 func (*ServiceRule) ProtoMessage()               {}
-func (*ServiceRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*ServiceRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *ServiceRule) GetRuleId() string {
 	if m != nil {
@@ -767,7 +1063,7 @@ type AddOrUpdateServiceRule struct {
 func (m *AddOrUpdateServiceRule) Reset()                    { *m = AddOrUpdateServiceRule{} }
 func (m *AddOrUpdateServiceRule) String() string            { return proto1.CompactTextString(m) }
 func (*AddOrUpdateServiceRule) ProtoMessage()               {}
-func (*AddOrUpdateServiceRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*AddOrUpdateServiceRule) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *AddOrUpdateServiceRule) GetRuleType() string {
 	if m != nil {
@@ -805,7 +1101,7 @@ type ServicePath struct {
 func (m *ServicePath) Reset()                    { *m = ServicePath{} }
 func (m *ServicePath) String() string            { return proto1.CompactTextString(m) }
 func (*ServicePath) ProtoMessage()               {}
-func (*ServicePath) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*ServicePath) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *ServicePath) GetPath() string {
 	if m != nil {
@@ -822,14 +1118,15 @@ func (m *ServicePath) GetProperty() map[string]string {
 }
 
 type Response struct {
-	Code    int32  `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
-	Message string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	Code    int32          `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	Message string         `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	Details []*ErrorDetail `protobuf:"bytes,3,rep,name=details" json:"details,omitempty"`
 }
 
 func (m *Response) Reset()                    { *m = Response{} }
 func (m *Response) String() string            { return proto1.CompactTextString(m) }
 func (*Response) ProtoMessage()               {}
-func (*Response) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*Response) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *Response) GetCode() int32 {
 	if m != nil {
@@ -845,6 +1142,61 @@ func (m *Response) GetMessage() string {
 	return ""
 }
 
+func (m *Response) GetDetails() []*ErrorDetail {
+	if m != nil {
+		return m.Details
+	}
+	return nil
+}
+
+type ErrorDetail struct {
+	Code       int32  `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	Field      string `protobuf:"bytes,2,opt,name=field" json:"field,omitempty"`
+	Reason     string `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
+	Constraint string `protobuf:"bytes,4,opt,name=constraint" json:"constraint,omitempty"`
+	Value      string `protobuf:"bytes,5,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *ErrorDetail) Reset()                    { *m = ErrorDetail{} }
+func (m *ErrorDetail) String() string            { return proto1.CompactTextString(m) }
+func (*ErrorDetail) ProtoMessage()               {}
+func (*ErrorDetail) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ErrorDetail) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *ErrorDetail) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *ErrorDetail) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ErrorDetail) GetConstraint() string {
+	if m != nil {
+		return m.Constraint
+	}
+	return ""
+}
+
+func (m *ErrorDetail) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
 type GetExistenceRequest struct {
 	Type        string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
 	AppId       string `protobuf:"bytes,2,opt,name=appId" json:"appId,omitempty"`
@@ -858,7 +1210,7 @@ type GetExistenceRequest struct {
 func (m *GetExistenceRequest) Reset()                    { *m = GetExistenceRequest{} }
 func (m *GetExistenceRequest) String() string            { return proto1.CompactTextString(m) }
 func (*GetExistenceRequest) ProtoMessage()               {}
-func (*GetExistenceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*GetExistenceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *GetExistenceRequest) GetType() string {
 	if m != nil {
@@ -919,7 +1271,7 @@ type GetExistenceResponse struct {
 func (m *GetExistenceResponse) Reset()                    { *m = GetExistenceResponse{} }
 func (m *GetExistenceResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GetExistenceResponse) ProtoMessage()               {}
-func (*GetExistenceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*GetExistenceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *GetExistenceResponse) GetResponse() *Response {
 	if m != nil {
@@ -949,6 +1301,70 @@ func (m *GetExistenceResponse) GetSummary() string {
 	return ""
 }
 
+type BatchGetExistenceRequest struct {
+	Services []*MicroServiceKey `protobuf:"bytes,1,rep,name=services" json:"services,omitempty"`
+}
+
+func (m *BatchGetExistenceRequest) Reset()                    { *m = BatchGetExistenceRequest{} }
+func (m *BatchGetExistenceRequest) String() string            { return proto1.CompactTextString(m) }
+func (*BatchGetExistenceRequest) ProtoMessage()               {}
+func (*BatchGetExistenceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *BatchGetExistenceRequest) GetServices() []*MicroServiceKey {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+type BatchGetExistenceResponse struct {
+	Response *Response           `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Services []*ServiceExistence `protobuf:"bytes,2,rep,name=services" json:"services,omitempty"`
+}
+
+func (m *BatchGetExistenceResponse) Reset()                    { *m = BatchGetExistenceResponse{} }
+func (m *BatchGetExistenceResponse) String() string            { return proto1.CompactTextString(m) }
+func (*BatchGetExistenceResponse) ProtoMessage()               {}
+func (*BatchGetExistenceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *BatchGetExistenceResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *BatchGetExistenceResponse) GetServices() []*ServiceExistence {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+type ServiceExistence struct {
+	Service   *MicroServiceKey `protobuf:"bytes,1,opt,name=service" json:"service,omitempty"`
+	ServiceId string           `protobuf:"bytes,2,opt,name=serviceId" json:"serviceId,omitempty"`
+}
+
+func (m *ServiceExistence) Reset()                    { *m = ServiceExistence{} }
+func (m *ServiceExistence) String() string            { return proto1.CompactTextString(m) }
+func (*ServiceExistence) ProtoMessage()               {}
+func (*ServiceExistence) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *ServiceExistence) GetService() *MicroServiceKey {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+func (m *ServiceExistence) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
 type CreateServiceRequest struct {
 	Service   *MicroService             `protobuf:"bytes,1,opt,name=service" json:"service,omitempty"`
 	Rules     []*AddOrUpdateServiceRule `protobuf:"bytes,2,rep,name=rules" json:"rules,omitempty"`
@@ -959,7 +1375,7 @@ type CreateServiceRequest struct {
 func (m *CreateServiceRequest) Reset()                    { *m = CreateServiceRequest{} }
 func (m *CreateServiceRequest) String() string            { return proto1.CompactTextString(m) }
 func (*CreateServiceRequest) ProtoMessage()               {}
-func (*CreateServiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*CreateServiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *CreateServiceRequest) GetService() *MicroService {
 	if m != nil {
@@ -997,7 +1413,7 @@ type CreateServiceResponse struct {
 func (m *CreateServiceResponse) Reset()                    { *m = CreateServiceResponse{} }
 func (m *CreateServiceResponse) String() string            { return proto1.CompactTextString(m) }
 func (*CreateServiceResponse) ProtoMessage()               {}
-func (*CreateServiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*CreateServiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *CreateServiceResponse) GetResponse() *Response {
 	if m != nil {
@@ -1021,7 +1437,7 @@ type DeleteServiceRequest struct {
 func (m *DeleteServiceRequest) Reset()                    { *m = DeleteServiceRequest{} }
 func (m *DeleteServiceRequest) String() string            { return proto1.CompactTextString(m) }
 func (*DeleteServiceRequest) ProtoMessage()               {}
-func (*DeleteServiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*DeleteServiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *DeleteServiceRequest) GetServiceId() string {
 	if m != nil {
@@ -1044,7 +1460,7 @@ type DeleteServiceResponse struct {
 func (m *DeleteServiceResponse) Reset()                    { *m = DeleteServiceResponse{} }
 func (m *DeleteServiceResponse) String() string            { return proto1.CompactTextString(m) }
 func (*DeleteServiceResponse) ProtoMessage()               {}
-func (*DeleteServiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*DeleteServiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *DeleteServiceResponse) GetResponse() *Response {
 	if m != nil {
@@ -1060,7 +1476,7 @@ type GetServiceRequest struct {
 func (m *GetServiceRequest) Reset()                    { *m = GetServiceRequest{} }
 func (m *GetServiceRequest) String() string            { return proto1.CompactTextString(m) }
 func (*GetServiceRequest) ProtoMessage()               {}
-func (*GetServiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*GetServiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *GetServiceRequest) GetServiceId() string {
 	if m != nil {
@@ -1077,7 +1493,7 @@ type GetServiceResponse struct {
 func (m *GetServiceResponse) Reset()                    { *m = GetServiceResponse{} }
 func (m *GetServiceResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GetServiceResponse) ProtoMessage()               {}
-func (*GetServiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*GetServiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 func (m *GetServiceResponse) GetResponse() *Response {
 	if m != nil {
@@ -1094,12 +1510,20 @@ func (m *GetServiceResponse) GetService() *MicroService {
 }
 
 type GetServicesRequest struct {
+	ServiceName string `protobuf:"bytes,1,opt,name=serviceName" json:"serviceName,omitempty"`
 }
 
 func (m *GetServicesRequest) Reset()                    { *m = GetServicesRequest{} }
 func (m *GetServicesRequest) String() string            { return proto1.CompactTextString(m) }
 func (*GetServicesRequest) ProtoMessage()               {}
-func (*GetServicesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*GetServicesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *GetServicesRequest) GetServiceName() string {
+	if m != nil {
+		return m.ServiceName
+	}
+	return ""
+}
 
 type GetServicesResponse struct {
 	Response *Response       `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
@@ -1109,7 +1533,7 @@ type GetServicesResponse struct {
 func (m *GetServicesResponse) Reset()                    { *m = GetServicesResponse{} }
 func (m *GetServicesResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GetServicesResponse) ProtoMessage()               {}
-func (*GetServicesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*GetServicesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
 func (m *GetServicesResponse) GetResponse() *Response {
 	if m != nil {
@@ -1126,14 +1550,16 @@ func (m *GetServicesResponse) GetServices() []*MicroService {
 }
 
 type UpdateServicePropsRequest struct {
-	ServiceId  string            `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
-	Properties map[string]string `protobuf:"bytes,2,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ServiceId        string            `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Properties       map[string]string `protobuf:"bytes,2,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Merge            bool              `protobuf:"varint,3,opt,name=merge" json:"merge,omitempty"`
+	RemoveProperties []string          `protobuf:"bytes,4,rep,name=removeProperties" json:"removeProperties,omitempty"`
 }
 
 func (m *UpdateServicePropsRequest) Reset()                    { *m = UpdateServicePropsRequest{} }
 func (m *UpdateServicePropsRequest) String() string            { return proto1.CompactTextString(m) }
 func (*UpdateServicePropsRequest) ProtoMessage()               {}
-func (*UpdateServicePropsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*UpdateServicePropsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *UpdateServicePropsRequest) GetServiceId() string {
 	if m != nil {
@@ -1149,6 +1575,20 @@ func (m *UpdateServicePropsRequest) GetProperties() map[string]string {
 	return nil
 }
 
+func (m *UpdateServicePropsRequest) GetMerge() bool {
+	if m != nil {
+		return m.Merge
+	}
+	return false
+}
+
+func (m *UpdateServicePropsRequest) GetRemoveProperties() []string {
+	if m != nil {
+		return m.RemoveProperties
+	}
+	return nil
+}
+
 type UpdateServicePropsResponse struct {
 	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
 }
@@ -1156,7 +1596,7 @@ type UpdateServicePropsResponse struct {
 func (m *UpdateServicePropsResponse) Reset()                    { *m = UpdateServicePropsResponse{} }
 func (m *UpdateServicePropsResponse) String() string            { return proto1.CompactTextString(m) }
 func (*UpdateServicePropsResponse) ProtoMessage()               {}
-func (*UpdateServicePropsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*UpdateServicePropsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
 
 func (m *UpdateServicePropsResponse) GetResponse() *Response {
 	if m != nil {
@@ -1165,6 +1605,91 @@ func (m *UpdateServicePropsResponse) GetResponse() *Response {
 	return nil
 }
 
+type UpdateServiceCatalogRequest struct {
+	ServiceId string          `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Catalog   *ServiceCatalog `protobuf:"bytes,2,opt,name=catalog" json:"catalog,omitempty"`
+}
+
+func (m *UpdateServiceCatalogRequest) Reset()                    { *m = UpdateServiceCatalogRequest{} }
+func (m *UpdateServiceCatalogRequest) String() string            { return proto1.CompactTextString(m) }
+func (*UpdateServiceCatalogRequest) ProtoMessage()               {}
+func (*UpdateServiceCatalogRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *UpdateServiceCatalogRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *UpdateServiceCatalogRequest) GetCatalog() *ServiceCatalog {
+	if m != nil {
+		return m.Catalog
+	}
+	return nil
+}
+
+type UpdateServiceCatalogResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *UpdateServiceCatalogResponse) Reset()                    { *m = UpdateServiceCatalogResponse{} }
+func (m *UpdateServiceCatalogResponse) String() string            { return proto1.CompactTextString(m) }
+func (*UpdateServiceCatalogResponse) ProtoMessage()               {}
+func (*UpdateServiceCatalogResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *UpdateServiceCatalogResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+// retirement为空表示取消下线计划
+type UpdateServiceRetirementRequest struct {
+	ServiceId  string             `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Retirement *ServiceRetirement `protobuf:"bytes,2,opt,name=retirement" json:"retirement,omitempty"`
+}
+
+func (m *UpdateServiceRetirementRequest) Reset()         { *m = UpdateServiceRetirementRequest{} }
+func (m *UpdateServiceRetirementRequest) String() string { return proto1.CompactTextString(m) }
+func (*UpdateServiceRetirementRequest) ProtoMessage()    {}
+func (*UpdateServiceRetirementRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{42}
+}
+
+func (m *UpdateServiceRetirementRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *UpdateServiceRetirementRequest) GetRetirement() *ServiceRetirement {
+	if m != nil {
+		return m.Retirement
+	}
+	return nil
+}
+
+type UpdateServiceRetirementResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *UpdateServiceRetirementResponse) Reset()         { *m = UpdateServiceRetirementResponse{} }
+func (m *UpdateServiceRetirementResponse) String() string { return proto1.CompactTextString(m) }
+func (*UpdateServiceRetirementResponse) ProtoMessage()    {}
+func (*UpdateServiceRetirementResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{43}
+}
+
+func (m *UpdateServiceRetirementResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
 type GetServiceRulesRequest struct {
 	ServiceId string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
 }
@@ -1172,7 +1697,7 @@ type GetServiceRulesRequest struct {
 func (m *GetServiceRulesRequest) Reset()                    { *m = GetServiceRulesRequest{} }
 func (m *GetServiceRulesRequest) String() string            { return proto1.CompactTextString(m) }
 func (*GetServiceRulesRequest) ProtoMessage()               {}
-func (*GetServiceRulesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*GetServiceRulesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
 
 func (m *GetServiceRulesRequest) GetServiceId() string {
 	if m != nil {
@@ -1189,7 +1714,7 @@ type GetServiceRulesResponse struct {
 func (m *GetServiceRulesResponse) Reset()                    { *m = GetServiceRulesResponse{} }
 func (m *GetServiceRulesResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GetServiceRulesResponse) ProtoMessage()               {}
-func (*GetServiceRulesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*GetServiceRulesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
 
 func (m *GetServiceRulesResponse) GetResponse() *Response {
 	if m != nil {
@@ -1214,7 +1739,7 @@ type UpdateServiceRuleRequest struct {
 func (m *UpdateServiceRuleRequest) Reset()                    { *m = UpdateServiceRuleRequest{} }
 func (m *UpdateServiceRuleRequest) String() string            { return proto1.CompactTextString(m) }
 func (*UpdateServiceRuleRequest) ProtoMessage()               {}
-func (*UpdateServiceRuleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*UpdateServiceRuleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
 
 func (m *UpdateServiceRuleRequest) GetServiceId() string {
 	if m != nil {
@@ -1244,7 +1769,7 @@ type UpdateServiceRuleResponse struct {
 func (m *UpdateServiceRuleResponse) Reset()                    { *m = UpdateServiceRuleResponse{} }
 func (m *UpdateServiceRuleResponse) String() string            { return proto1.CompactTextString(m) }
 func (*UpdateServiceRuleResponse) ProtoMessage()               {}
-func (*UpdateServiceRuleResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*UpdateServiceRuleResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
 
 func (m *UpdateServiceRuleResponse) GetResponse() *Response {
 	if m != nil {
@@ -1261,7 +1786,7 @@ type AddServiceRulesRequest struct {
 func (m *AddServiceRulesRequest) Reset()                    { *m = AddServiceRulesRequest{} }
 func (m *AddServiceRulesRequest) String() string            { return proto1.CompactTextString(m) }
 func (*AddServiceRulesRequest) ProtoMessage()               {}
-func (*AddServiceRulesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*AddServiceRulesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
 
 func (m *AddServiceRulesRequest) GetServiceId() string {
 	if m != nil {
@@ -1285,7 +1810,7 @@ type AddServiceRulesResponse struct {
 func (m *AddServiceRulesResponse) Reset()                    { *m = AddServiceRulesResponse{} }
 func (m *AddServiceRulesResponse) String() string            { return proto1.CompactTextString(m) }
 func (*AddServiceRulesResponse) ProtoMessage()               {}
-func (*AddServiceRulesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*AddServiceRulesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
 
 func (m *AddServiceRulesResponse) GetResponse() *Response {
 	if m != nil {
@@ -1309,7 +1834,7 @@ type DeleteServiceRulesRequest struct {
 func (m *DeleteServiceRulesRequest) Reset()                    { *m = DeleteServiceRulesRequest{} }
 func (m *DeleteServiceRulesRequest) String() string            { return proto1.CompactTextString(m) }
 func (*DeleteServiceRulesRequest) ProtoMessage()               {}
-func (*DeleteServiceRulesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*DeleteServiceRulesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
 
 func (m *DeleteServiceRulesRequest) GetServiceId() string {
 	if m != nil {
@@ -1332,7 +1857,7 @@ type DeleteServiceRulesResponse struct {
 func (m *DeleteServiceRulesResponse) Reset()                    { *m = DeleteServiceRulesResponse{} }
 func (m *DeleteServiceRulesResponse) String() string            { return proto1.CompactTextString(m) }
 func (*DeleteServiceRulesResponse) ProtoMessage()               {}
-func (*DeleteServiceRulesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*DeleteServiceRulesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
 
 func (m *DeleteServiceRulesResponse) GetResponse() *Response {
 	if m != nil {
@@ -1341,6 +1866,70 @@ func (m *DeleteServiceRulesResponse) GetResponse() *Response {
 	return nil
 }
 
+type ReplaceServiceRulesRequest struct {
+	ServiceId string                    `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Rules     []*AddOrUpdateServiceRule `protobuf:"bytes,2,rep,name=rules" json:"rules,omitempty"`
+	DryRun    bool                      `protobuf:"varint,3,opt,name=dryRun" json:"dryRun,omitempty"`
+}
+
+func (m *ReplaceServiceRulesRequest) Reset()                    { *m = ReplaceServiceRulesRequest{} }
+func (m *ReplaceServiceRulesRequest) String() string            { return proto1.CompactTextString(m) }
+func (*ReplaceServiceRulesRequest) ProtoMessage()               {}
+func (*ReplaceServiceRulesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *ReplaceServiceRulesRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *ReplaceServiceRulesRequest) GetRules() []*AddOrUpdateServiceRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *ReplaceServiceRulesRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type ReplaceServiceRulesResponse struct {
+	Response *Response      `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Changes  []*ApplyChange `protobuf:"bytes,2,rep,name=changes" json:"changes,omitempty"`
+	RuleIds  []string       `protobuf:"bytes,3,rep,name=ruleIds" json:"ruleIds,omitempty"`
+}
+
+func (m *ReplaceServiceRulesResponse) Reset()                    { *m = ReplaceServiceRulesResponse{} }
+func (m *ReplaceServiceRulesResponse) String() string            { return proto1.CompactTextString(m) }
+func (*ReplaceServiceRulesResponse) ProtoMessage()               {}
+func (*ReplaceServiceRulesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *ReplaceServiceRulesResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ReplaceServiceRulesResponse) GetChanges() []*ApplyChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *ReplaceServiceRulesResponse) GetRuleIds() []string {
+	if m != nil {
+		return m.RuleIds
+	}
+	return nil
+}
+
 type GetServiceTagsRequest struct {
 	ServiceId string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
 }
@@ -1348,7 +1937,7 @@ type GetServiceTagsRequest struct {
 func (m *GetServiceTagsRequest) Reset()                    { *m = GetServiceTagsRequest{} }
 func (m *GetServiceTagsRequest) String() string            { return proto1.CompactTextString(m) }
 func (*GetServiceTagsRequest) ProtoMessage()               {}
-func (*GetServiceTagsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*GetServiceTagsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
 
 func (m *GetServiceTagsRequest) GetServiceId() string {
 	if m != nil {
@@ -1365,7 +1954,7 @@ type GetServiceTagsResponse struct {
 func (m *GetServiceTagsResponse) Reset()                    { *m = GetServiceTagsResponse{} }
 func (m *GetServiceTagsResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GetServiceTagsResponse) ProtoMessage()               {}
-func (*GetServiceTagsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+func (*GetServiceTagsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
 
 func (m *GetServiceTagsResponse) GetResponse() *Response {
 	if m != nil {
@@ -1390,7 +1979,7 @@ type UpdateServiceTagRequest struct {
 func (m *UpdateServiceTagRequest) Reset()                    { *m = UpdateServiceTagRequest{} }
 func (m *UpdateServiceTagRequest) String() string            { return proto1.CompactTextString(m) }
 func (*UpdateServiceTagRequest) ProtoMessage()               {}
-func (*UpdateServiceTagRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+func (*UpdateServiceTagRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
 
 func (m *UpdateServiceTagRequest) GetServiceId() string {
 	if m != nil {
@@ -1420,7 +2009,7 @@ type UpdateServiceTagResponse struct {
 func (m *UpdateServiceTagResponse) Reset()                    { *m = UpdateServiceTagResponse{} }
 func (m *UpdateServiceTagResponse) String() string            { return proto1.CompactTextString(m) }
 func (*UpdateServiceTagResponse) ProtoMessage()               {}
-func (*UpdateServiceTagResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+func (*UpdateServiceTagResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
 
 func (m *UpdateServiceTagResponse) GetResponse() *Response {
 	if m != nil {
@@ -1437,7 +2026,7 @@ type AddServiceTagsRequest struct {
 func (m *AddServiceTagsRequest) Reset()                    { *m = AddServiceTagsRequest{} }
 func (m *AddServiceTagsRequest) String() string            { return proto1.CompactTextString(m) }
 func (*AddServiceTagsRequest) ProtoMessage()               {}
-func (*AddServiceTagsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+func (*AddServiceTagsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
 
 func (m *AddServiceTagsRequest) GetServiceId() string {
 	if m != nil {
@@ -1460,7 +2049,7 @@ type AddServiceTagsResponse struct {
 func (m *AddServiceTagsResponse) Reset()                    { *m = AddServiceTagsResponse{} }
 func (m *AddServiceTagsResponse) String() string            { return proto1.CompactTextString(m) }
 func (*AddServiceTagsResponse) ProtoMessage()               {}
-func (*AddServiceTagsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+func (*AddServiceTagsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
 
 func (m *AddServiceTagsResponse) GetResponse() *Response {
 	if m != nil {
@@ -1477,7 +2066,7 @@ type DeleteServiceTagsRequest struct {
 func (m *DeleteServiceTagsRequest) Reset()                    { *m = DeleteServiceTagsRequest{} }
 func (m *DeleteServiceTagsRequest) String() string            { return proto1.CompactTextString(m) }
 func (*DeleteServiceTagsRequest) ProtoMessage()               {}
-func (*DeleteServiceTagsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+func (*DeleteServiceTagsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
 
 func (m *DeleteServiceTagsRequest) GetServiceId() string {
 	if m != nil {
@@ -1500,7 +2089,7 @@ type DeleteServiceTagsResponse struct {
 func (m *DeleteServiceTagsResponse) Reset()                    { *m = DeleteServiceTagsResponse{} }
 func (m *DeleteServiceTagsResponse) String() string            { return proto1.CompactTextString(m) }
 func (*DeleteServiceTagsResponse) ProtoMessage()               {}
-func (*DeleteServiceTagsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+func (*DeleteServiceTagsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
 
 func (m *DeleteServiceTagsResponse) GetResponse() *Response {
 	if m != nil {
@@ -1509,6 +2098,215 @@ func (m *DeleteServiceTagsResponse) GetResponse() *Response {
 	return nil
 }
 
+// 消费者的服务发现策略
+type DiscoveryPolicy struct {
+	MaxInstances      int32             `protobuf:"varint,1,opt,name=maxInstances" json:"maxInstances,omitempty"`
+	PreferredTags     map[string]string `protobuf:"bytes,2,rep,name=preferredTags" json:"preferredTags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExcludeProperties map[string]string `protobuf:"bytes,3,rep,name=excludeProperties" json:"excludeProperties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	NoDependency      bool              `protobuf:"varint,4,opt,name=noDependency" json:"noDependency,omitempty"`
+	Order             string            `protobuf:"bytes,5,opt,name=order" json:"order,omitempty"`
+}
+
+func (m *DiscoveryPolicy) Reset()                    { *m = DiscoveryPolicy{} }
+func (m *DiscoveryPolicy) String() string            { return proto1.CompactTextString(m) }
+func (*DiscoveryPolicy) ProtoMessage()               {}
+func (*DiscoveryPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *DiscoveryPolicy) GetMaxInstances() int32 {
+	if m != nil {
+		return m.MaxInstances
+	}
+	return 0
+}
+
+func (m *DiscoveryPolicy) GetPreferredTags() map[string]string {
+	if m != nil {
+		return m.PreferredTags
+	}
+	return nil
+}
+
+func (m *DiscoveryPolicy) GetExcludeProperties() map[string]string {
+	if m != nil {
+		return m.ExcludeProperties
+	}
+	return nil
+}
+
+func (m *DiscoveryPolicy) GetNoDependency() bool {
+	if m != nil {
+		return m.NoDependency
+	}
+	return false
+}
+
+func (m *DiscoveryPolicy) GetOrder() string {
+	if m != nil {
+		return m.Order
+	}
+	return ""
+}
+
+type GetDiscoveryPolicyRequest struct {
+	ServiceId string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Domain    string `protobuf:"bytes,2,opt,name=domain" json:"domain,omitempty"`
+	Project   string `protobuf:"bytes,3,opt,name=project" json:"project,omitempty"`
+}
+
+func (m *GetDiscoveryPolicyRequest) Reset()                    { *m = GetDiscoveryPolicyRequest{} }
+func (m *GetDiscoveryPolicyRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetDiscoveryPolicyRequest) ProtoMessage()               {}
+func (*GetDiscoveryPolicyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *GetDiscoveryPolicyRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *GetDiscoveryPolicyRequest) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *GetDiscoveryPolicyRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+type GetDiscoveryPolicyResponse struct {
+	Response *Response        `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Policy   *DiscoveryPolicy `protobuf:"bytes,2,opt,name=policy" json:"policy,omitempty"`
+}
+
+func (m *GetDiscoveryPolicyResponse) Reset()                    { *m = GetDiscoveryPolicyResponse{} }
+func (m *GetDiscoveryPolicyResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetDiscoveryPolicyResponse) ProtoMessage()               {}
+func (*GetDiscoveryPolicyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *GetDiscoveryPolicyResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetDiscoveryPolicyResponse) GetPolicy() *DiscoveryPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+type UpdateDiscoveryPolicyRequest struct {
+	ServiceId string           `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Policy    *DiscoveryPolicy `protobuf:"bytes,2,opt,name=policy" json:"policy,omitempty"`
+	Domain    string           `protobuf:"bytes,3,opt,name=domain" json:"domain,omitempty"`
+	Project   string           `protobuf:"bytes,4,opt,name=project" json:"project,omitempty"`
+}
+
+func (m *UpdateDiscoveryPolicyRequest) Reset()                    { *m = UpdateDiscoveryPolicyRequest{} }
+func (m *UpdateDiscoveryPolicyRequest) String() string            { return proto1.CompactTextString(m) }
+func (*UpdateDiscoveryPolicyRequest) ProtoMessage()               {}
+func (*UpdateDiscoveryPolicyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *UpdateDiscoveryPolicyRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *UpdateDiscoveryPolicyRequest) GetPolicy() *DiscoveryPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *UpdateDiscoveryPolicyRequest) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *UpdateDiscoveryPolicyRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+type UpdateDiscoveryPolicyResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *UpdateDiscoveryPolicyResponse) Reset()                    { *m = UpdateDiscoveryPolicyResponse{} }
+func (m *UpdateDiscoveryPolicyResponse) String() string            { return proto1.CompactTextString(m) }
+func (*UpdateDiscoveryPolicyResponse) ProtoMessage()               {}
+func (*UpdateDiscoveryPolicyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *UpdateDiscoveryPolicyResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type DeleteDiscoveryPolicyRequest struct {
+	ServiceId string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Domain    string `protobuf:"bytes,2,opt,name=domain" json:"domain,omitempty"`
+	Project   string `protobuf:"bytes,3,opt,name=project" json:"project,omitempty"`
+}
+
+func (m *DeleteDiscoveryPolicyRequest) Reset()                    { *m = DeleteDiscoveryPolicyRequest{} }
+func (m *DeleteDiscoveryPolicyRequest) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteDiscoveryPolicyRequest) ProtoMessage()               {}
+func (*DeleteDiscoveryPolicyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *DeleteDiscoveryPolicyRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *DeleteDiscoveryPolicyRequest) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *DeleteDiscoveryPolicyRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+type DeleteDiscoveryPolicyResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *DeleteDiscoveryPolicyResponse) Reset()                    { *m = DeleteDiscoveryPolicyResponse{} }
+func (m *DeleteDiscoveryPolicyResponse) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteDiscoveryPolicyResponse) ProtoMessage()               {}
+func (*DeleteDiscoveryPolicyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *DeleteDiscoveryPolicyResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
 type HealthCheck struct {
 	Mode     string `protobuf:"bytes,1,opt,name=mode" json:"mode,omitempty"`
 	Port     int32  `protobuf:"varint,2,opt,name=port" json:"port,omitempty"`
@@ -1520,7 +2318,7 @@ type HealthCheck struct {
 func (m *HealthCheck) Reset()                    { *m = HealthCheck{} }
 func (m *HealthCheck) String() string            { return proto1.CompactTextString(m) }
 func (*HealthCheck) ProtoMessage()               {}
-func (*HealthCheck) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+func (*HealthCheck) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
 
 func (m *HealthCheck) GetMode() string {
 	if m != nil {
@@ -1558,22 +2356,28 @@ func (m *HealthCheck) GetUrl() string {
 }
 
 type MicroServiceInstance struct {
-	InstanceId     string            `protobuf:"bytes,1,opt,name=instanceId" json:"instanceId,omitempty"`
-	ServiceId      string            `protobuf:"bytes,2,opt,name=serviceId" json:"serviceId,omitempty"`
-	Endpoints      []string          `protobuf:"bytes,3,rep,name=endpoints" json:"endpoints,omitempty"`
-	HostName       string            `protobuf:"bytes,4,opt,name=hostName" json:"hostName,omitempty"`
-	Status         string            `protobuf:"bytes,5,opt,name=status" json:"status,omitempty"`
-	Properties     map[string]string `protobuf:"bytes,6,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	HealthCheck    *HealthCheck      `protobuf:"bytes,7,opt,name=healthCheck" json:"healthCheck,omitempty"`
-	Timestamp      string            `protobuf:"bytes,8,opt,name=timestamp" json:"timestamp,omitempty"`
-	DataCenterInfo *DataCenterInfo   `protobuf:"bytes,9,opt,name=dataCenterInfo" json:"dataCenterInfo,omitempty"`
-	ModTimestamp   string            `protobuf:"bytes,10,opt,name=modTimestamp" json:"modTimestamp,omitempty"`
+	InstanceId     string              `protobuf:"bytes,1,opt,name=instanceId" json:"instanceId,omitempty"`
+	ServiceId      string              `protobuf:"bytes,2,opt,name=serviceId" json:"serviceId,omitempty"`
+	Endpoints      []string            `protobuf:"bytes,3,rep,name=endpoints" json:"endpoints,omitempty"`
+	HostName       string              `protobuf:"bytes,4,opt,name=hostName" json:"hostName,omitempty"`
+	Status         string              `protobuf:"bytes,5,opt,name=status" json:"status,omitempty"`
+	Properties     map[string]string   `protobuf:"bytes,6,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	HealthCheck    *HealthCheck        `protobuf:"bytes,7,opt,name=healthCheck" json:"healthCheck,omitempty"`
+	Timestamp      string              `protobuf:"bytes,8,opt,name=timestamp" json:"timestamp,omitempty"`
+	DataCenterInfo *DataCenterInfo     `protobuf:"bytes,9,opt,name=dataCenterInfo" json:"dataCenterInfo,omitempty"`
+	ModTimestamp   string              `protobuf:"bytes,10,opt,name=modTimestamp" json:"modTimestamp,omitempty"`
+	StatusReason   *StatusReason       `protobuf:"bytes,11,opt,name=statusReason" json:"statusReason,omitempty"`
+	Platform       *Platform           `protobuf:"bytes,12,opt,name=platform" json:"platform,omitempty"`
+	Capacity       int32               `protobuf:"varint,13,opt,name=capacity" json:"capacity,omitempty"`
+	ActivateTime   string              `protobuf:"bytes,14,opt,name=activateTime" json:"activateTime,omitempty"`
+	State          *InstanceState      `protobuf:"bytes,15,opt,name=state" json:"state,omitempty"`
+	EndpointInfos  []*InstanceEndpoint `protobuf:"bytes,16,rep,name=endpointInfos" json:"endpointInfos,omitempty"`
 }
 
 func (m *MicroServiceInstance) Reset()                    { *m = MicroServiceInstance{} }
 func (m *MicroServiceInstance) String() string            { return proto1.CompactTextString(m) }
 func (*MicroServiceInstance) ProtoMessage()               {}
-func (*MicroServiceInstance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+func (*MicroServiceInstance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
 
 func (m *MicroServiceInstance) GetInstanceId() string {
 	if m != nil {
@@ -1645,6 +2449,104 @@ func (m *MicroServiceInstance) GetModTimestamp() string {
 	return ""
 }
 
+func (m *MicroServiceInstance) GetStatusReason() *StatusReason {
+	if m != nil {
+		return m.StatusReason
+	}
+	return nil
+}
+
+func (m *MicroServiceInstance) GetPlatform() *Platform {
+	if m != nil {
+		return m.Platform
+	}
+	return nil
+}
+
+func (m *MicroServiceInstance) GetCapacity() int32 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *MicroServiceInstance) GetActivateTime() string {
+	if m != nil {
+		return m.ActivateTime
+	}
+	return ""
+}
+
+func (m *MicroServiceInstance) GetState() *InstanceState {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+func (m *MicroServiceInstance) GetEndpointInfos() []*InstanceEndpoint {
+	if m != nil {
+		return m.EndpointInfos
+	}
+	return nil
+}
+
+type Platform struct {
+	Os   string `protobuf:"bytes,1,opt,name=os" json:"os,omitempty"`
+	Arch string `protobuf:"bytes,2,opt,name=arch" json:"arch,omitempty"`
+}
+
+func (m *Platform) Reset()                    { *m = Platform{} }
+func (m *Platform) String() string            { return proto1.CompactTextString(m) }
+func (*Platform) ProtoMessage()               {}
+func (*Platform) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+
+func (m *Platform) GetOs() string {
+	if m != nil {
+		return m.Os
+	}
+	return ""
+}
+
+func (m *Platform) GetArch() string {
+	if m != nil {
+		return m.Arch
+	}
+	return ""
+}
+
+type StatusReason struct {
+	Code      string `protobuf:"bytes,1,opt,name=code" json:"code,omitempty"`
+	Message   string `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	Timestamp string `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *StatusReason) Reset()                    { *m = StatusReason{} }
+func (m *StatusReason) String() string            { return proto1.CompactTextString(m) }
+func (*StatusReason) ProtoMessage()               {}
+func (*StatusReason) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+
+func (m *StatusReason) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *StatusReason) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *StatusReason) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
 type DataCenterInfo struct {
 	Name          string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Region        string `protobuf:"bytes,2,opt,name=region" json:"region,omitempty"`
@@ -1654,7 +2556,7 @@ type DataCenterInfo struct {
 func (m *DataCenterInfo) Reset()                    { *m = DataCenterInfo{} }
 func (m *DataCenterInfo) String() string            { return proto1.CompactTextString(m) }
 func (*DataCenterInfo) ProtoMessage()               {}
-func (*DataCenterInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+func (*DataCenterInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
 
 func (m *DataCenterInfo) GetName() string {
 	if m != nil {
@@ -1685,7 +2587,7 @@ type MicroServiceInstanceKey struct {
 func (m *MicroServiceInstanceKey) Reset()                    { *m = MicroServiceInstanceKey{} }
 func (m *MicroServiceInstanceKey) String() string            { return proto1.CompactTextString(m) }
 func (*MicroServiceInstanceKey) ProtoMessage()               {}
-func (*MicroServiceInstanceKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+func (*MicroServiceInstanceKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
 
 func (m *MicroServiceInstanceKey) GetInstanceId() string {
 	if m != nil {
@@ -1708,7 +2610,7 @@ type RegisterInstanceRequest struct {
 func (m *RegisterInstanceRequest) Reset()                    { *m = RegisterInstanceRequest{} }
 func (m *RegisterInstanceRequest) String() string            { return proto1.CompactTextString(m) }
 func (*RegisterInstanceRequest) ProtoMessage()               {}
-func (*RegisterInstanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+func (*RegisterInstanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
 
 func (m *RegisterInstanceRequest) GetInstance() *MicroServiceInstance {
 	if m != nil {
@@ -1725,7 +2627,7 @@ type RegisterInstanceResponse struct {
 func (m *RegisterInstanceResponse) Reset()                    { *m = RegisterInstanceResponse{} }
 func (m *RegisterInstanceResponse) String() string            { return proto1.CompactTextString(m) }
 func (*RegisterInstanceResponse) ProtoMessage()               {}
-func (*RegisterInstanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+func (*RegisterInstanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
 
 func (m *RegisterInstanceResponse) GetResponse() *Response {
 	if m != nil {
@@ -1749,7 +2651,7 @@ type UnregisterInstanceRequest struct {
 func (m *UnregisterInstanceRequest) Reset()                    { *m = UnregisterInstanceRequest{} }
 func (m *UnregisterInstanceRequest) String() string            { return proto1.CompactTextString(m) }
 func (*UnregisterInstanceRequest) ProtoMessage()               {}
-func (*UnregisterInstanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+func (*UnregisterInstanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
 
 func (m *UnregisterInstanceRequest) GetServiceId() string {
 	if m != nil {
@@ -1772,7 +2674,7 @@ type UnregisterInstanceResponse struct {
 func (m *UnregisterInstanceResponse) Reset()                    { *m = UnregisterInstanceResponse{} }
 func (m *UnregisterInstanceResponse) String() string            { return proto1.CompactTextString(m) }
 func (*UnregisterInstanceResponse) ProtoMessage()               {}
-func (*UnregisterInstanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+func (*UnregisterInstanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
 
 func (m *UnregisterInstanceResponse) GetResponse() *Response {
 	if m != nil {
@@ -1782,14 +2684,15 @@ func (m *UnregisterInstanceResponse) GetResponse() *Response {
 }
 
 type HeartbeatRequest struct {
-	ServiceId  string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
-	InstanceId string `protobuf:"bytes,2,opt,name=instanceId" json:"instanceId,omitempty"`
+	ServiceId  string            `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	InstanceId string            `protobuf:"bytes,2,opt,name=instanceId" json:"instanceId,omitempty"`
+	State      map[string]string `protobuf:"bytes,3,rep,name=state" json:"state,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *HeartbeatRequest) Reset()                    { *m = HeartbeatRequest{} }
 func (m *HeartbeatRequest) String() string            { return proto1.CompactTextString(m) }
 func (*HeartbeatRequest) ProtoMessage()               {}
-func (*HeartbeatRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
 
 func (m *HeartbeatRequest) GetServiceId() string {
 	if m != nil {
@@ -1805,6 +2708,13 @@ func (m *HeartbeatRequest) GetInstanceId() string {
 	return ""
 }
 
+func (m *HeartbeatRequest) GetState() map[string]string {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
 type HeartbeatResponse struct {
 	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
 }
@@ -1812,7 +2722,7 @@ type HeartbeatResponse struct {
 func (m *HeartbeatResponse) Reset()                    { *m = HeartbeatResponse{} }
 func (m *HeartbeatResponse) String() string            { return proto1.CompactTextString(m) }
 func (*HeartbeatResponse) ProtoMessage()               {}
-func (*HeartbeatResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
 
 func (m *HeartbeatResponse) GetResponse() *Response {
 	if m != nil {
@@ -1822,17 +2732,26 @@ func (m *HeartbeatResponse) GetResponse() *Response {
 }
 
 type FindInstancesRequest struct {
-	ConsumerServiceId string   `protobuf:"bytes,1,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
-	AppId             string   `protobuf:"bytes,2,opt,name=appId" json:"appId,omitempty"`
-	ServiceName       string   `protobuf:"bytes,3,opt,name=serviceName" json:"serviceName,omitempty"`
-	VersionRule       string   `protobuf:"bytes,4,opt,name=versionRule" json:"versionRule,omitempty"`
-	Tags              []string `protobuf:"bytes,5,rep,name=tags" json:"tags,omitempty"`
+	ConsumerServiceId string    `protobuf:"bytes,1,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
+	AppId             string    `protobuf:"bytes,2,opt,name=appId" json:"appId,omitempty"`
+	ServiceName       string    `protobuf:"bytes,3,opt,name=serviceName" json:"serviceName,omitempty"`
+	VersionRule       string    `protobuf:"bytes,4,opt,name=versionRule" json:"versionRule,omitempty"`
+	Tags              []string  `protobuf:"bytes,5,rep,name=tags" json:"tags,omitempty"`
+	NoDependency      bool      `protobuf:"varint,6,opt,name=noDependency" json:"noDependency,omitempty"`
+	WithGovernance    bool      `protobuf:"varint,7,opt,name=withGovernance" json:"withGovernance,omitempty"`
+	Platform          *Platform `protobuf:"bytes,8,opt,name=platform" json:"platform,omitempty"`
+	PlatformPolicy    string    `protobuf:"bytes,9,opt,name=platformPolicy" json:"platformPolicy,omitempty"`
+	Order             string    `protobuf:"bytes,10,opt,name=order" json:"order,omitempty"`
+	Zone              string    `protobuf:"bytes,11,opt,name=zone" json:"zone,omitempty"`
+	AddressFamily     string    `protobuf:"bytes,12,opt,name=addressFamily" json:"addressFamily,omitempty"`
+	Protocols         []string  `protobuf:"bytes,13,rep,name=protocols" json:"protocols,omitempty"`
+	CrossEnvironment  bool      `protobuf:"varint,14,opt,name=crossEnvironment" json:"crossEnvironment,omitempty"`
 }
 
 func (m *FindInstancesRequest) Reset()                    { *m = FindInstancesRequest{} }
 func (m *FindInstancesRequest) String() string            { return proto1.CompactTextString(m) }
 func (*FindInstancesRequest) ProtoMessage()               {}
-func (*FindInstancesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+func (*FindInstancesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
 
 func (m *FindInstancesRequest) GetConsumerServiceId() string {
 	if m != nil {
@@ -1869,16 +2788,82 @@ func (m *FindInstancesRequest) GetTags() []string {
 	return nil
 }
 
-type FindInstancesResponse struct {
-	Response  *Response               `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
-	Instances []*MicroServiceInstance `protobuf:"bytes,2,rep,name=instances" json:"instances,omitempty"`
+func (m *FindInstancesRequest) GetNoDependency() bool {
+	if m != nil {
+		return m.NoDependency
+	}
+	return false
 }
 
-func (m *FindInstancesResponse) Reset()                    { *m = FindInstancesResponse{} }
-func (m *FindInstancesResponse) String() string            { return proto1.CompactTextString(m) }
-func (*FindInstancesResponse) ProtoMessage()               {}
-func (*FindInstancesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
-
+func (m *FindInstancesRequest) GetWithGovernance() bool {
+	if m != nil {
+		return m.WithGovernance
+	}
+	return false
+}
+
+func (m *FindInstancesRequest) GetPlatform() *Platform {
+	if m != nil {
+		return m.Platform
+	}
+	return nil
+}
+
+func (m *FindInstancesRequest) GetPlatformPolicy() string {
+	if m != nil {
+		return m.PlatformPolicy
+	}
+	return ""
+}
+
+func (m *FindInstancesRequest) GetOrder() string {
+	if m != nil {
+		return m.Order
+	}
+	return ""
+}
+
+func (m *FindInstancesRequest) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
+func (m *FindInstancesRequest) GetAddressFamily() string {
+	if m != nil {
+		return m.AddressFamily
+	}
+	return ""
+}
+
+func (m *FindInstancesRequest) GetProtocols() []string {
+	if m != nil {
+		return m.Protocols
+	}
+	return nil
+}
+
+func (m *FindInstancesRequest) GetCrossEnvironment() bool {
+	if m != nil {
+		return m.CrossEnvironment
+	}
+	return false
+}
+
+type FindInstancesResponse struct {
+	Response     *Response               `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Instances    []*MicroServiceInstance `protobuf:"bytes,2,rep,name=instances" json:"instances,omitempty"`
+	Governance   []*GovernanceConfig     `protobuf:"bytes,3,rep,name=governance" json:"governance,omitempty"`
+	Deprecations []*RetiringVersion      `protobuf:"bytes,4,rep,name=deprecations" json:"deprecations,omitempty"`
+	Revision     int64                   `protobuf:"varint,5,opt,name=revision" json:"revision,omitempty"`
+}
+
+func (m *FindInstancesResponse) Reset()                    { *m = FindInstancesResponse{} }
+func (m *FindInstancesResponse) String() string            { return proto1.CompactTextString(m) }
+func (*FindInstancesResponse) ProtoMessage()               {}
+func (*FindInstancesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+
 func (m *FindInstancesResponse) GetResponse() *Response {
 	if m != nil {
 		return m.Response
@@ -1893,6 +2878,107 @@ func (m *FindInstancesResponse) GetInstances() []*MicroServiceInstance {
 	return nil
 }
 
+func (m *FindInstancesResponse) GetGovernance() []*GovernanceConfig {
+	if m != nil {
+		return m.Governance
+	}
+	return nil
+}
+
+func (m *FindInstancesResponse) GetDeprecations() []*RetiringVersion {
+	if m != nil {
+		return m.Deprecations
+	}
+	return nil
+}
+
+func (m *FindInstancesResponse) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+type RetiringVersion struct {
+	ServiceId   string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	ServiceName string `protobuf:"bytes,2,opt,name=serviceName" json:"serviceName,omitempty"`
+	Version     string `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
+	TargetDate  string `protobuf:"bytes,4,opt,name=targetDate" json:"targetDate,omitempty"`
+	Message     string `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *RetiringVersion) Reset()                    { *m = RetiringVersion{} }
+func (m *RetiringVersion) String() string            { return proto1.CompactTextString(m) }
+func (*RetiringVersion) ProtoMessage()               {}
+func (*RetiringVersion) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
+
+func (m *RetiringVersion) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *RetiringVersion) GetServiceName() string {
+	if m != nil {
+		return m.ServiceName
+	}
+	return ""
+}
+
+func (m *RetiringVersion) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *RetiringVersion) GetTargetDate() string {
+	if m != nil {
+		return m.TargetDate
+	}
+	return ""
+}
+
+func (m *RetiringVersion) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type GovernanceConfig struct {
+	Key    string            `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value  string            `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	Labels map[string]string `protobuf:"bytes,3,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *GovernanceConfig) Reset()                    { *m = GovernanceConfig{} }
+func (m *GovernanceConfig) String() string            { return proto1.CompactTextString(m) }
+func (*GovernanceConfig) ProtoMessage()               {}
+func (*GovernanceConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+
+func (m *GovernanceConfig) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GovernanceConfig) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *GovernanceConfig) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 type GetOneInstanceRequest struct {
 	ConsumerServiceId  string   `protobuf:"bytes,1,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
 	ProviderServiceId  string   `protobuf:"bytes,2,opt,name=providerServiceId" json:"providerServiceId,omitempty"`
@@ -1903,7 +2989,7 @@ type GetOneInstanceRequest struct {
 func (m *GetOneInstanceRequest) Reset()                    { *m = GetOneInstanceRequest{} }
 func (m *GetOneInstanceRequest) String() string            { return proto1.CompactTextString(m) }
 func (*GetOneInstanceRequest) ProtoMessage()               {}
-func (*GetOneInstanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+func (*GetOneInstanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
 
 func (m *GetOneInstanceRequest) GetConsumerServiceId() string {
 	if m != nil {
@@ -1941,7 +3027,7 @@ type GetOneInstanceResponse struct {
 func (m *GetOneInstanceResponse) Reset()                    { *m = GetOneInstanceResponse{} }
 func (m *GetOneInstanceResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GetOneInstanceResponse) ProtoMessage()               {}
-func (*GetOneInstanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+func (*GetOneInstanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
 
 func (m *GetOneInstanceResponse) GetResponse() *Response {
 	if m != nil {
@@ -1966,7 +3052,7 @@ type GetInstancesRequest struct {
 func (m *GetInstancesRequest) Reset()                    { *m = GetInstancesRequest{} }
 func (m *GetInstancesRequest) String() string            { return proto1.CompactTextString(m) }
 func (*GetInstancesRequest) ProtoMessage()               {}
-func (*GetInstancesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+func (*GetInstancesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
 
 func (m *GetInstancesRequest) GetConsumerServiceId() string {
 	if m != nil {
@@ -1997,7 +3083,7 @@ type GetInstancesResponse struct {
 func (m *GetInstancesResponse) Reset()                    { *m = GetInstancesResponse{} }
 func (m *GetInstancesResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GetInstancesResponse) ProtoMessage()               {}
-func (*GetInstancesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+func (*GetInstancesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
 
 func (m *GetInstancesResponse) GetResponse() *Response {
 	if m != nil {
@@ -2014,15 +3100,17 @@ func (m *GetInstancesResponse) GetInstances() []*MicroServiceInstance {
 }
 
 type UpdateInstanceStatusRequest struct {
-	ServiceId  string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
-	InstanceId string `protobuf:"bytes,2,opt,name=instanceId" json:"instanceId,omitempty"`
-	Status     string `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+	ServiceId     string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	InstanceId    string `protobuf:"bytes,2,opt,name=instanceId" json:"instanceId,omitempty"`
+	Status        string `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+	ReasonCode    string `protobuf:"bytes,4,opt,name=reasonCode" json:"reasonCode,omitempty"`
+	ReasonMessage string `protobuf:"bytes,5,opt,name=reasonMessage" json:"reasonMessage,omitempty"`
 }
 
 func (m *UpdateInstanceStatusRequest) Reset()                    { *m = UpdateInstanceStatusRequest{} }
 func (m *UpdateInstanceStatusRequest) String() string            { return proto1.CompactTextString(m) }
 func (*UpdateInstanceStatusRequest) ProtoMessage()               {}
-func (*UpdateInstanceStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+func (*UpdateInstanceStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
 
 func (m *UpdateInstanceStatusRequest) GetServiceId() string {
 	if m != nil {
@@ -2045,6 +3133,20 @@ func (m *UpdateInstanceStatusRequest) GetStatus() string {
 	return ""
 }
 
+func (m *UpdateInstanceStatusRequest) GetReasonCode() string {
+	if m != nil {
+		return m.ReasonCode
+	}
+	return ""
+}
+
+func (m *UpdateInstanceStatusRequest) GetReasonMessage() string {
+	if m != nil {
+		return m.ReasonMessage
+	}
+	return ""
+}
+
 type UpdateInstanceStatusResponse struct {
 	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
 }
@@ -2052,7 +3154,7 @@ type UpdateInstanceStatusResponse struct {
 func (m *UpdateInstanceStatusResponse) Reset()                    { *m = UpdateInstanceStatusResponse{} }
 func (m *UpdateInstanceStatusResponse) String() string            { return proto1.CompactTextString(m) }
 func (*UpdateInstanceStatusResponse) ProtoMessage()               {}
-func (*UpdateInstanceStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+func (*UpdateInstanceStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
 
 func (m *UpdateInstanceStatusResponse) GetResponse() *Response {
 	if m != nil {
@@ -2061,16 +3163,116 @@ func (m *UpdateInstanceStatusResponse) GetResponse() *Response {
 	return nil
 }
 
+type PromoteInstancesRequest struct {
+	ServiceId   string   `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	InstanceIds []string `protobuf:"bytes,2,rep,name=instanceIds" json:"instanceIds,omitempty"`
+}
+
+func (m *PromoteInstancesRequest) Reset()                    { *m = PromoteInstancesRequest{} }
+func (m *PromoteInstancesRequest) String() string            { return proto1.CompactTextString(m) }
+func (*PromoteInstancesRequest) ProtoMessage()               {}
+func (*PromoteInstancesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
+
+func (m *PromoteInstancesRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *PromoteInstancesRequest) GetInstanceIds() []string {
+	if m != nil {
+		return m.InstanceIds
+	}
+	return nil
+}
+
+type PromoteInstancesResponse struct {
+	Response    *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	InstanceIds []string  `protobuf:"bytes,2,rep,name=instanceIds" json:"instanceIds,omitempty"`
+}
+
+func (m *PromoteInstancesResponse) Reset()                    { *m = PromoteInstancesResponse{} }
+func (m *PromoteInstancesResponse) String() string            { return proto1.CompactTextString(m) }
+func (*PromoteInstancesResponse) ProtoMessage()               {}
+func (*PromoteInstancesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
+
+func (m *PromoteInstancesResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *PromoteInstancesResponse) GetInstanceIds() []string {
+	if m != nil {
+		return m.InstanceIds
+	}
+	return nil
+}
+
+type UpdateInstanceCapacityRequest struct {
+	ServiceId  string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	InstanceId string `protobuf:"bytes,2,opt,name=instanceId" json:"instanceId,omitempty"`
+	Capacity   int32  `protobuf:"varint,3,opt,name=capacity" json:"capacity,omitempty"`
+}
+
+func (m *UpdateInstanceCapacityRequest) Reset()                    { *m = UpdateInstanceCapacityRequest{} }
+func (m *UpdateInstanceCapacityRequest) String() string            { return proto1.CompactTextString(m) }
+func (*UpdateInstanceCapacityRequest) ProtoMessage()               {}
+func (*UpdateInstanceCapacityRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
+
+func (m *UpdateInstanceCapacityRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *UpdateInstanceCapacityRequest) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+func (m *UpdateInstanceCapacityRequest) GetCapacity() int32 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+type UpdateInstanceCapacityResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *UpdateInstanceCapacityResponse) Reset()         { *m = UpdateInstanceCapacityResponse{} }
+func (m *UpdateInstanceCapacityResponse) String() string { return proto1.CompactTextString(m) }
+func (*UpdateInstanceCapacityResponse) ProtoMessage()    {}
+func (*UpdateInstanceCapacityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{94}
+}
+
+func (m *UpdateInstanceCapacityResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
 type UpdateInstancePropsRequest struct {
-	ServiceId  string            `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
-	InstanceId string            `protobuf:"bytes,2,opt,name=instanceId" json:"instanceId,omitempty"`
-	Properties map[string]string `protobuf:"bytes,3,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ServiceId        string            `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	InstanceId       string            `protobuf:"bytes,2,opt,name=instanceId" json:"instanceId,omitempty"`
+	Properties       map[string]string `protobuf:"bytes,3,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Merge            bool              `protobuf:"varint,4,opt,name=merge" json:"merge,omitempty"`
+	RemoveProperties []string          `protobuf:"bytes,5,rep,name=removeProperties" json:"removeProperties,omitempty"`
 }
 
 func (m *UpdateInstancePropsRequest) Reset()                    { *m = UpdateInstancePropsRequest{} }
 func (m *UpdateInstancePropsRequest) String() string            { return proto1.CompactTextString(m) }
 func (*UpdateInstancePropsRequest) ProtoMessage()               {}
-func (*UpdateInstancePropsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+func (*UpdateInstancePropsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
 
 func (m *UpdateInstancePropsRequest) GetServiceId() string {
 	if m != nil {
@@ -2093,6 +3295,20 @@ func (m *UpdateInstancePropsRequest) GetProperties() map[string]string {
 	return nil
 }
 
+func (m *UpdateInstancePropsRequest) GetMerge() bool {
+	if m != nil {
+		return m.Merge
+	}
+	return false
+}
+
+func (m *UpdateInstancePropsRequest) GetRemoveProperties() []string {
+	if m != nil {
+		return m.RemoveProperties
+	}
+	return nil
+}
+
 type UpdateInstancePropsResponse struct {
 	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
 }
@@ -2100,7 +3316,7 @@ type UpdateInstancePropsResponse struct {
 func (m *UpdateInstancePropsResponse) Reset()                    { *m = UpdateInstancePropsResponse{} }
 func (m *UpdateInstancePropsResponse) String() string            { return proto1.CompactTextString(m) }
 func (*UpdateInstancePropsResponse) ProtoMessage()               {}
-func (*UpdateInstancePropsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+func (*UpdateInstancePropsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
 
 func (m *UpdateInstancePropsResponse) GetResponse() *Response {
 	if m != nil {
@@ -2111,12 +3327,18 @@ func (m *UpdateInstancePropsResponse) GetResponse() *Response {
 
 type WatchInstanceRequest struct {
 	SelfServiceId string `protobuf:"bytes,1,opt,name=selfServiceId" json:"selfServiceId,omitempty"`
+	Format        string `protobuf:"bytes,2,opt,name=format" json:"format,omitempty"`
+	Version       int32  `protobuf:"varint,3,opt,name=version" json:"version,omitempty"`
+	Compact       bool   `protobuf:"varint,4,opt,name=compact" json:"compact,omitempty"`
+	Revision      int64  `protobuf:"varint,5,opt,name=revision" json:"revision,omitempty"`
+	Group         string `protobuf:"bytes,6,opt,name=group" json:"group,omitempty"`
+	Subscription  string `protobuf:"bytes,7,opt,name=subscription" json:"subscription,omitempty"`
 }
 
 func (m *WatchInstanceRequest) Reset()                    { *m = WatchInstanceRequest{} }
 func (m *WatchInstanceRequest) String() string            { return proto1.CompactTextString(m) }
 func (*WatchInstanceRequest) ProtoMessage()               {}
-func (*WatchInstanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+func (*WatchInstanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
 
 func (m *WatchInstanceRequest) GetSelfServiceId() string {
 	if m != nil {
@@ -2125,17 +3347,63 @@ func (m *WatchInstanceRequest) GetSelfServiceId() string {
 	return ""
 }
 
+func (m *WatchInstanceRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *WatchInstanceRequest) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *WatchInstanceRequest) GetCompact() bool {
+	if m != nil {
+		return m.Compact
+	}
+	return false
+}
+
+func (m *WatchInstanceRequest) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *WatchInstanceRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
+}
+
+func (m *WatchInstanceRequest) GetSubscription() string {
+	if m != nil {
+		return m.Subscription
+	}
+	return ""
+}
+
 type WatchInstanceResponse struct {
-	Response *Response             `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
-	Action   string                `protobuf:"bytes,2,opt,name=action" json:"action,omitempty"`
-	Key      *MicroServiceKey      `protobuf:"bytes,3,opt,name=key" json:"key,omitempty"`
-	Instance *MicroServiceInstance `protobuf:"bytes,4,opt,name=instance" json:"instance,omitempty"`
+	Response   *Response             `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Action     string                `protobuf:"bytes,2,opt,name=action" json:"action,omitempty"`
+	Key        *MicroServiceKey      `protobuf:"bytes,3,opt,name=key" json:"key,omitempty"`
+	Instance   *MicroServiceInstance `protobuf:"bytes,4,opt,name=instance" json:"instance,omitempty"`
+	Permission string                `protobuf:"bytes,5,opt,name=permission" json:"permission,omitempty"`
+	Revision   int64                 `protobuf:"varint,6,opt,name=revision" json:"revision,omitempty"`
+	Suppressed int32                 `protobuf:"varint,7,opt,name=suppressed" json:"suppressed,omitempty"`
+	Broadcast  *BroadcastMessage     `protobuf:"bytes,8,opt,name=broadcast" json:"broadcast,omitempty"`
 }
 
 func (m *WatchInstanceResponse) Reset()                    { *m = WatchInstanceResponse{} }
 func (m *WatchInstanceResponse) String() string            { return proto1.CompactTextString(m) }
 func (*WatchInstanceResponse) ProtoMessage()               {}
-func (*WatchInstanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+func (*WatchInstanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
 
 func (m *WatchInstanceResponse) GetResponse() *Response {
 	if m != nil {
@@ -2165,39 +3433,125 @@ func (m *WatchInstanceResponse) GetInstance() *MicroServiceInstance {
 	return nil
 }
 
-type GetSchemaRequest struct {
-	ServiceId string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
-	SchemaId  string `protobuf:"bytes,2,opt,name=schemaId" json:"schemaId,omitempty"`
+func (m *WatchInstanceResponse) GetPermission() string {
+	if m != nil {
+		return m.Permission
+	}
+	return ""
 }
 
-func (m *GetSchemaRequest) Reset()                    { *m = GetSchemaRequest{} }
-func (m *GetSchemaRequest) String() string            { return proto1.CompactTextString(m) }
-func (*GetSchemaRequest) ProtoMessage()               {}
-func (*GetSchemaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+func (m *WatchInstanceResponse) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
 
-func (m *GetSchemaRequest) GetServiceId() string {
+func (m *WatchInstanceResponse) GetSuppressed() int32 {
 	if m != nil {
-		return m.ServiceId
+		return m.Suppressed
 	}
-	return ""
+	return 0
 }
 
-func (m *GetSchemaRequest) GetSchemaId() string {
+func (m *WatchInstanceResponse) GetBroadcast() *BroadcastMessage {
 	if m != nil {
-		return m.SchemaId
+		return m.Broadcast
 	}
-	return ""
+	return nil
 }
 
-type GetAllSchemaRequest struct {
-	ServiceId  string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
-	WithSchema bool   `protobuf:"varint,2,opt,name=withSchema" json:"withSchema,omitempty"`
+// 带类型和版本的watch事件，内部结构变化时按版本兼容
+type WatchEventEnvelope struct {
+	Type     string                 `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Version  int32                  `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`
+	Revision int64                  `protobuf:"varint,3,opt,name=revision" json:"revision,omitempty"`
+	Event    *WatchInstanceResponse `protobuf:"bytes,4,opt,name=event" json:"event,omitempty"`
+}
+
+func (m *WatchEventEnvelope) Reset()                    { *m = WatchEventEnvelope{} }
+func (m *WatchEventEnvelope) String() string            { return proto1.CompactTextString(m) }
+func (*WatchEventEnvelope) ProtoMessage()               {}
+func (*WatchEventEnvelope) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
+
+func (m *WatchEventEnvelope) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *WatchEventEnvelope) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *WatchEventEnvelope) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *WatchEventEnvelope) GetEvent() *WatchInstanceResponse {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type GetSchemaRequest struct {
+	ServiceId         string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	SchemaId          string `protobuf:"bytes,2,opt,name=schemaId" json:"schemaId,omitempty"`
+	Resolve           bool   `protobuf:"varint,3,opt,name=resolve" json:"resolve,omitempty"`
+	ConsumerServiceId string `protobuf:"bytes,4,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
+}
+
+func (m *GetSchemaRequest) Reset()                    { *m = GetSchemaRequest{} }
+func (m *GetSchemaRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetSchemaRequest) ProtoMessage()               {}
+func (*GetSchemaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
+
+func (m *GetSchemaRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *GetSchemaRequest) GetSchemaId() string {
+	if m != nil {
+		return m.SchemaId
+	}
+	return ""
+}
+
+func (m *GetSchemaRequest) GetResolve() bool {
+	if m != nil {
+		return m.Resolve
+	}
+	return false
+}
+
+func (m *GetSchemaRequest) GetConsumerServiceId() string {
+	if m != nil {
+		return m.ConsumerServiceId
+	}
+	return ""
+}
+
+type GetAllSchemaRequest struct {
+	ServiceId         string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	WithSchema        bool   `protobuf:"varint,2,opt,name=withSchema" json:"withSchema,omitempty"`
+	ConsumerServiceId string `protobuf:"bytes,3,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
 }
 
 func (m *GetAllSchemaRequest) Reset()                    { *m = GetAllSchemaRequest{} }
 func (m *GetAllSchemaRequest) String() string            { return proto1.CompactTextString(m) }
 func (*GetAllSchemaRequest) ProtoMessage()               {}
-func (*GetAllSchemaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+func (*GetAllSchemaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
 
 func (m *GetAllSchemaRequest) GetServiceId() string {
 	if m != nil {
@@ -2213,16 +3567,24 @@ func (m *GetAllSchemaRequest) GetWithSchema() bool {
 	return false
 }
 
+func (m *GetAllSchemaRequest) GetConsumerServiceId() string {
+	if m != nil {
+		return m.ConsumerServiceId
+	}
+	return ""
+}
+
 type GetSchemaResponse struct {
 	Response      *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
 	Schema        string    `protobuf:"bytes,2,opt,name=schema" json:"schema,omitempty"`
 	SchemaSummary string    `protobuf:"bytes,3,opt,name=schemaSummary" json:"schemaSummary,omitempty"`
+	Revision      int64     `protobuf:"varint,4,opt,name=revision" json:"revision,omitempty"`
 }
 
 func (m *GetSchemaResponse) Reset()                    { *m = GetSchemaResponse{} }
 func (m *GetSchemaResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GetSchemaResponse) ProtoMessage()               {}
-func (*GetSchemaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+func (*GetSchemaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
 
 func (m *GetSchemaResponse) GetResponse() *Response {
 	if m != nil {
@@ -2245,15 +3607,23 @@ func (m *GetSchemaResponse) GetSchemaSummary() string {
 	return ""
 }
 
+func (m *GetSchemaResponse) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 type GetAllSchemaResponse struct {
 	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
 	Schema   []*Schema `protobuf:"bytes,2,rep,name=schema" json:"schema,omitempty"`
+	Revision int64     `protobuf:"varint,3,opt,name=revision" json:"revision,omitempty"`
 }
 
 func (m *GetAllSchemaResponse) Reset()                    { *m = GetAllSchemaResponse{} }
 func (m *GetAllSchemaResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GetAllSchemaResponse) ProtoMessage()               {}
-func (*GetAllSchemaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+func (*GetAllSchemaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
 
 func (m *GetAllSchemaResponse) GetResponse() *Response {
 	if m != nil {
@@ -2269,6 +3639,13 @@ func (m *GetAllSchemaResponse) GetSchema() []*Schema {
 	return nil
 }
 
+func (m *GetAllSchemaResponse) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 type DeleteSchemaRequest struct {
 	ServiceId string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
 	SchemaId  string `protobuf:"bytes,2,opt,name=schemaId" json:"schemaId,omitempty"`
@@ -2277,7 +3654,7 @@ type DeleteSchemaRequest struct {
 func (m *DeleteSchemaRequest) Reset()                    { *m = DeleteSchemaRequest{} }
 func (m *DeleteSchemaRequest) String() string            { return proto1.CompactTextString(m) }
 func (*DeleteSchemaRequest) ProtoMessage()               {}
-func (*DeleteSchemaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+func (*DeleteSchemaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
 
 func (m *DeleteSchemaRequest) GetServiceId() string {
 	if m != nil {
@@ -2300,7 +3677,7 @@ type DeleteSchemaResponse struct {
 func (m *DeleteSchemaResponse) Reset()                    { *m = DeleteSchemaResponse{} }
 func (m *DeleteSchemaResponse) String() string            { return proto1.CompactTextString(m) }
 func (*DeleteSchemaResponse) ProtoMessage()               {}
-func (*DeleteSchemaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
+func (*DeleteSchemaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
 
 func (m *DeleteSchemaResponse) GetResponse() *Response {
 	if m != nil {
@@ -2310,16 +3687,17 @@ func (m *DeleteSchemaResponse) GetResponse() *Response {
 }
 
 type ModifySchemaRequest struct {
-	ServiceId string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
-	SchemaId  string `protobuf:"bytes,2,opt,name=schemaId" json:"schemaId,omitempty"`
-	Schema    string `protobuf:"bytes,3,opt,name=schema" json:"schema,omitempty"`
-	Summary   string `protobuf:"bytes,4,opt,name=summary" json:"summary,omitempty"`
+	ServiceId        string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	SchemaId         string `protobuf:"bytes,2,opt,name=schemaId" json:"schemaId,omitempty"`
+	Schema           string `protobuf:"bytes,3,opt,name=schema" json:"schema,omitempty"`
+	Summary          string `protobuf:"bytes,4,opt,name=summary" json:"summary,omitempty"`
+	ExpectedRevision string `protobuf:"bytes,5,opt,name=expectedRevision" json:"expectedRevision,omitempty"`
 }
 
 func (m *ModifySchemaRequest) Reset()                    { *m = ModifySchemaRequest{} }
 func (m *ModifySchemaRequest) String() string            { return proto1.CompactTextString(m) }
 func (*ModifySchemaRequest) ProtoMessage()               {}
-func (*ModifySchemaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
+func (*ModifySchemaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
 
 func (m *ModifySchemaRequest) GetServiceId() string {
 	if m != nil {
@@ -2349,14 +3727,22 @@ func (m *ModifySchemaRequest) GetSummary() string {
 	return ""
 }
 
+func (m *ModifySchemaRequest) GetExpectedRevision() string {
+	if m != nil {
+		return m.ExpectedRevision
+	}
+	return ""
+}
+
 type ModifySchemaResponse struct {
 	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Revision int64     `protobuf:"varint,2,opt,name=revision" json:"revision,omitempty"`
 }
 
 func (m *ModifySchemaResponse) Reset()                    { *m = ModifySchemaResponse{} }
 func (m *ModifySchemaResponse) String() string            { return proto1.CompactTextString(m) }
 func (*ModifySchemaResponse) ProtoMessage()               {}
-func (*ModifySchemaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
+func (*ModifySchemaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{107} }
 
 func (m *ModifySchemaResponse) GetResponse() *Response {
 	if m != nil {
@@ -2365,6 +3751,158 @@ func (m *ModifySchemaResponse) GetResponse() *Response {
 	return nil
 }
 
+func (m *ModifySchemaResponse) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+// 同一租户下契约共享的模型定义，契约中以 $ref: 'shared:<name>' 引用
+type SharedDefinition struct {
+	Name         string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Content      string `protobuf:"bytes,2,opt,name=content" json:"content,omitempty"`
+	ModTimestamp string `protobuf:"bytes,3,opt,name=modTimestamp" json:"modTimestamp,omitempty"`
+}
+
+func (m *SharedDefinition) Reset()                    { *m = SharedDefinition{} }
+func (m *SharedDefinition) String() string            { return proto1.CompactTextString(m) }
+func (*SharedDefinition) ProtoMessage()               {}
+func (*SharedDefinition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{108} }
+
+func (m *SharedDefinition) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SharedDefinition) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *SharedDefinition) GetModTimestamp() string {
+	if m != nil {
+		return m.ModTimestamp
+	}
+	return ""
+}
+
+type ModifySharedDefinitionRequest struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Content string `protobuf:"bytes,2,opt,name=content" json:"content,omitempty"`
+}
+
+func (m *ModifySharedDefinitionRequest) Reset()         { *m = ModifySharedDefinitionRequest{} }
+func (m *ModifySharedDefinitionRequest) String() string { return proto1.CompactTextString(m) }
+func (*ModifySharedDefinitionRequest) ProtoMessage()    {}
+func (*ModifySharedDefinitionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{109}
+}
+
+func (m *ModifySharedDefinitionRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ModifySharedDefinitionRequest) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+type ModifySharedDefinitionResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *ModifySharedDefinitionResponse) Reset()         { *m = ModifySharedDefinitionResponse{} }
+func (m *ModifySharedDefinitionResponse) String() string { return proto1.CompactTextString(m) }
+func (*ModifySharedDefinitionResponse) ProtoMessage()    {}
+func (*ModifySharedDefinitionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{110}
+}
+
+func (m *ModifySharedDefinitionResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type GetSharedDefinitionsRequest struct {
+}
+
+func (m *GetSharedDefinitionsRequest) Reset()                    { *m = GetSharedDefinitionsRequest{} }
+func (m *GetSharedDefinitionsRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetSharedDefinitionsRequest) ProtoMessage()               {}
+func (*GetSharedDefinitionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{111} }
+
+type GetSharedDefinitionsResponse struct {
+	Response    *Response           `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Definitions []*SharedDefinition `protobuf:"bytes,2,rep,name=definitions" json:"definitions,omitempty"`
+}
+
+func (m *GetSharedDefinitionsResponse) Reset()                    { *m = GetSharedDefinitionsResponse{} }
+func (m *GetSharedDefinitionsResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetSharedDefinitionsResponse) ProtoMessage()               {}
+func (*GetSharedDefinitionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{112} }
+
+func (m *GetSharedDefinitionsResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetSharedDefinitionsResponse) GetDefinitions() []*SharedDefinition {
+	if m != nil {
+		return m.Definitions
+	}
+	return nil
+}
+
+type DeleteSharedDefinitionRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *DeleteSharedDefinitionRequest) Reset()         { *m = DeleteSharedDefinitionRequest{} }
+func (m *DeleteSharedDefinitionRequest) String() string { return proto1.CompactTextString(m) }
+func (*DeleteSharedDefinitionRequest) ProtoMessage()    {}
+func (*DeleteSharedDefinitionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{113}
+}
+
+func (m *DeleteSharedDefinitionRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteSharedDefinitionResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *DeleteSharedDefinitionResponse) Reset()         { *m = DeleteSharedDefinitionResponse{} }
+func (m *DeleteSharedDefinitionResponse) String() string { return proto1.CompactTextString(m) }
+func (*DeleteSharedDefinitionResponse) ProtoMessage()    {}
+func (*DeleteSharedDefinitionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{114}
+}
+
+func (m *DeleteSharedDefinitionResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
 type AddDependenciesRequest struct {
 	Dependencies []*ConsumerDependency `protobuf:"bytes,1,rep,name=dependencies" json:"dependencies,omitempty"`
 }
@@ -2372,7 +3910,7 @@ type AddDependenciesRequest struct {
 func (m *AddDependenciesRequest) Reset()                    { *m = AddDependenciesRequest{} }
 func (m *AddDependenciesRequest) String() string            { return proto1.CompactTextString(m) }
 func (*AddDependenciesRequest) ProtoMessage()               {}
-func (*AddDependenciesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
+func (*AddDependenciesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{115} }
 
 func (m *AddDependenciesRequest) GetDependencies() []*ConsumerDependency {
 	if m != nil {
@@ -2388,7 +3926,7 @@ type AddDependenciesResponse struct {
 func (m *AddDependenciesResponse) Reset()                    { *m = AddDependenciesResponse{} }
 func (m *AddDependenciesResponse) String() string            { return proto1.CompactTextString(m) }
 func (*AddDependenciesResponse) ProtoMessage()               {}
-func (*AddDependenciesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+func (*AddDependenciesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{116} }
 
 func (m *AddDependenciesResponse) GetResponse() *Response {
 	if m != nil {
@@ -2404,7 +3942,7 @@ type CreateDependenciesRequest struct {
 func (m *CreateDependenciesRequest) Reset()                    { *m = CreateDependenciesRequest{} }
 func (m *CreateDependenciesRequest) String() string            { return proto1.CompactTextString(m) }
 func (*CreateDependenciesRequest) ProtoMessage()               {}
-func (*CreateDependenciesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
+func (*CreateDependenciesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{117} }
 
 func (m *CreateDependenciesRequest) GetDependencies() []*ConsumerDependency {
 	if m != nil {
@@ -2423,7 +3961,7 @@ type DependencyKey struct {
 func (m *DependencyKey) Reset()                    { *m = DependencyKey{} }
 func (m *DependencyKey) String() string            { return proto1.CompactTextString(m) }
 func (*DependencyKey) ProtoMessage()               {}
-func (*DependencyKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
+func (*DependencyKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{118} }
 
 func (m *DependencyKey) GetAppId() string {
 	if m != nil {
@@ -2461,7 +3999,7 @@ type ConsumerDependency struct {
 func (m *ConsumerDependency) Reset()                    { *m = ConsumerDependency{} }
 func (m *ConsumerDependency) String() string            { return proto1.CompactTextString(m) }
 func (*ConsumerDependency) ProtoMessage()               {}
-func (*ConsumerDependency) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+func (*ConsumerDependency) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{119} }
 
 func (m *ConsumerDependency) GetConsumer() *DependencyKey {
 	if m != nil {
@@ -2484,7 +4022,7 @@ type CreateDependenciesResponse struct {
 func (m *CreateDependenciesResponse) Reset()                    { *m = CreateDependenciesResponse{} }
 func (m *CreateDependenciesResponse) String() string            { return proto1.CompactTextString(m) }
 func (*CreateDependenciesResponse) ProtoMessage()               {}
-func (*CreateDependenciesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
+func (*CreateDependenciesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{120} }
 
 func (m *CreateDependenciesResponse) GetResponse() *Response {
 	if m != nil {
@@ -2500,7 +4038,7 @@ type GetDependenciesRequest struct {
 func (m *GetDependenciesRequest) Reset()                    { *m = GetDependenciesRequest{} }
 func (m *GetDependenciesRequest) String() string            { return proto1.CompactTextString(m) }
 func (*GetDependenciesRequest) ProtoMessage()               {}
-func (*GetDependenciesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+func (*GetDependenciesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{121} }
 
 func (m *GetDependenciesRequest) GetServiceId() string {
 	if m != nil {
@@ -2510,14 +4048,15 @@ func (m *GetDependenciesRequest) GetServiceId() string {
 }
 
 type GetConDependenciesResponse struct {
-	Response  *Response       `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
-	Providers []*MicroService `protobuf:"bytes,2,rep,name=providers" json:"providers,omitempty"`
+	Response  *Response            `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Providers []*MicroService      `protobuf:"bytes,2,rep,name=providers" json:"providers,omitempty"`
+	Traffics  []*DependencyTraffic `protobuf:"bytes,3,rep,name=traffics" json:"traffics,omitempty"`
 }
 
 func (m *GetConDependenciesResponse) Reset()                    { *m = GetConDependenciesResponse{} }
 func (m *GetConDependenciesResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GetConDependenciesResponse) ProtoMessage()               {}
-func (*GetConDependenciesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+func (*GetConDependenciesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{122} }
 
 func (m *GetConDependenciesResponse) GetResponse() *Response {
 	if m != nil {
@@ -2533,15 +4072,23 @@ func (m *GetConDependenciesResponse) GetProviders() []*MicroService {
 	return nil
 }
 
+func (m *GetConDependenciesResponse) GetTraffics() []*DependencyTraffic {
+	if m != nil {
+		return m.Traffics
+	}
+	return nil
+}
+
 type GetProDependenciesResponse struct {
-	Response  *Response       `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
-	Consumers []*MicroService `protobuf:"bytes,2,rep,name=consumers" json:"consumers,omitempty"`
+	Response  *Response            `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Consumers []*MicroService      `protobuf:"bytes,2,rep,name=consumers" json:"consumers,omitempty"`
+	Traffics  []*DependencyTraffic `protobuf:"bytes,3,rep,name=traffics" json:"traffics,omitempty"`
 }
 
 func (m *GetProDependenciesResponse) Reset()                    { *m = GetProDependenciesResponse{} }
 func (m *GetProDependenciesResponse) String() string            { return proto1.CompactTextString(m) }
 func (*GetProDependenciesResponse) ProtoMessage()               {}
-func (*GetProDependenciesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+func (*GetProDependenciesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{123} }
 
 func (m *GetProDependenciesResponse) GetResponse() *Response {
 	if m != nil {
@@ -2557,1649 +4104,5765 @@ func (m *GetProDependenciesResponse) GetConsumers() []*MicroService {
 	return nil
 }
 
-// 服务详情
-type ServiceDetail struct {
-	MicroService         *MicroService           `protobuf:"bytes,1,opt,name=microService" json:"microService,omitempty"`
-	Instances            []*MicroServiceInstance `protobuf:"bytes,2,rep,name=instances" json:"instances,omitempty"`
-	SchemaInfos          []*Schema               `protobuf:"bytes,3,rep,name=schemaInfos" json:"schemaInfos,omitempty"`
-	Rules                []*ServiceRule          `protobuf:"bytes,4,rep,name=rules" json:"rules,omitempty"`
-	Providers            []*MicroService         `protobuf:"bytes,5,rep,name=providers" json:"providers,omitempty"`
-	Consumers            []*MicroService         `protobuf:"bytes,6,rep,name=consumers" json:"consumers,omitempty"`
-	Tags                 map[string]string       `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	MicroServiceVersions []string                `protobuf:"bytes,8,rep,name=microServiceVersions" json:"microServiceVersions,omitempty"`
-	Statics              *Statistics             `protobuf:"bytes,9,opt,name=statics" json:"statics,omitempty"`
-}
-
-func (m *ServiceDetail) Reset()                    { *m = ServiceDetail{} }
-func (m *ServiceDetail) String() string            { return proto1.CompactTextString(m) }
-func (*ServiceDetail) ProtoMessage()               {}
-func (*ServiceDetail) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
-
-func (m *ServiceDetail) GetMicroService() *MicroService {
+func (m *GetProDependenciesResponse) GetTraffics() []*DependencyTraffic {
 	if m != nil {
-		return m.MicroService
+		return m.Traffics
 	}
 	return nil
 }
 
-func (m *ServiceDetail) GetInstances() []*MicroServiceInstance {
-	if m != nil {
-		return m.Instances
-	}
-	return nil
+// 提供者对消费者依赖的审批，status: PENDING/APPROVED
+type DependencyApproval struct {
+	ConsumerServiceId string           `protobuf:"bytes,1,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
+	Consumer          *MicroServiceKey `protobuf:"bytes,2,opt,name=consumer" json:"consumer,omitempty"`
+	Status            string           `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+	Timestamp         string           `protobuf:"bytes,4,opt,name=timestamp" json:"timestamp,omitempty"`
 }
 
-func (m *ServiceDetail) GetSchemaInfos() []*Schema {
+func (m *DependencyApproval) Reset()                    { *m = DependencyApproval{} }
+func (m *DependencyApproval) String() string            { return proto1.CompactTextString(m) }
+func (*DependencyApproval) ProtoMessage()               {}
+func (*DependencyApproval) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{124} }
+
+func (m *DependencyApproval) GetConsumerServiceId() string {
 	if m != nil {
-		return m.SchemaInfos
+		return m.ConsumerServiceId
 	}
-	return nil
+	return ""
 }
 
-func (m *ServiceDetail) GetRules() []*ServiceRule {
+func (m *DependencyApproval) GetConsumer() *MicroServiceKey {
 	if m != nil {
-		return m.Rules
+		return m.Consumer
 	}
 	return nil
 }
 
-func (m *ServiceDetail) GetProviders() []*MicroService {
+func (m *DependencyApproval) GetStatus() string {
 	if m != nil {
-		return m.Providers
+		return m.Status
 	}
-	return nil
+	return ""
 }
 
-func (m *ServiceDetail) GetConsumers() []*MicroService {
+func (m *DependencyApproval) GetTimestamp() string {
 	if m != nil {
-		return m.Consumers
+		return m.Timestamp
 	}
-	return nil
+	return ""
 }
 
-func (m *ServiceDetail) GetTags() map[string]string {
-	if m != nil {
-		return m.Tags
-	}
-	return nil
+type ApproveDependencyRequest struct {
+	ProviderServiceId string `protobuf:"bytes,1,opt,name=providerServiceId" json:"providerServiceId,omitempty"`
+	ConsumerServiceId string `protobuf:"bytes,2,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
 }
 
-func (m *ServiceDetail) GetMicroServiceVersions() []string {
+func (m *ApproveDependencyRequest) Reset()                    { *m = ApproveDependencyRequest{} }
+func (m *ApproveDependencyRequest) String() string            { return proto1.CompactTextString(m) }
+func (*ApproveDependencyRequest) ProtoMessage()               {}
+func (*ApproveDependencyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{125} }
+
+func (m *ApproveDependencyRequest) GetProviderServiceId() string {
 	if m != nil {
-		return m.MicroServiceVersions
+		return m.ProviderServiceId
 	}
-	return nil
+	return ""
 }
 
-func (m *ServiceDetail) GetStatics() *Statistics {
+func (m *ApproveDependencyRequest) GetConsumerServiceId() string {
 	if m != nil {
-		return m.Statics
+		return m.ConsumerServiceId
 	}
-	return nil
+	return ""
 }
 
-// 服务详情返回信息
-type GetServiceDetailResponse struct {
-	Response *Response      `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
-	Service  *ServiceDetail `protobuf:"bytes,2,opt,name=service" json:"service,omitempty"`
+type ApproveDependencyResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
 }
 
-func (m *GetServiceDetailResponse) Reset()                    { *m = GetServiceDetailResponse{} }
-func (m *GetServiceDetailResponse) String() string            { return proto1.CompactTextString(m) }
-func (*GetServiceDetailResponse) ProtoMessage()               {}
-func (*GetServiceDetailResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+func (m *ApproveDependencyResponse) Reset()                    { *m = ApproveDependencyResponse{} }
+func (m *ApproveDependencyResponse) String() string            { return proto1.CompactTextString(m) }
+func (*ApproveDependencyResponse) ProtoMessage()               {}
+func (*ApproveDependencyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{126} }
 
-func (m *GetServiceDetailResponse) GetResponse() *Response {
+func (m *ApproveDependencyResponse) GetResponse() *Response {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *GetServiceDetailResponse) GetService() *ServiceDetail {
-	if m != nil {
-		return m.Service
-	}
-	return nil
+type RevokeDependencyApprovalRequest struct {
+	ProviderServiceId string `protobuf:"bytes,1,opt,name=providerServiceId" json:"providerServiceId,omitempty"`
+	ConsumerServiceId string `protobuf:"bytes,2,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
 }
 
-// 删除服务请求
-type DelServicesRequest struct {
-	ServiceIds []string `protobuf:"bytes,1,rep,name=serviceIds" json:"serviceIds,omitempty"`
-	Force      bool     `protobuf:"varint,2,opt,name=force" json:"force,omitempty"`
+func (m *RevokeDependencyApprovalRequest) Reset()         { *m = RevokeDependencyApprovalRequest{} }
+func (m *RevokeDependencyApprovalRequest) String() string { return proto1.CompactTextString(m) }
+func (*RevokeDependencyApprovalRequest) ProtoMessage()    {}
+func (*RevokeDependencyApprovalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{127}
 }
 
-func (m *DelServicesRequest) Reset()                    { *m = DelServicesRequest{} }
-func (m *DelServicesRequest) String() string            { return proto1.CompactTextString(m) }
-func (*DelServicesRequest) ProtoMessage()               {}
-func (*DelServicesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
-
-func (m *DelServicesRequest) GetServiceIds() []string {
+func (m *RevokeDependencyApprovalRequest) GetProviderServiceId() string {
 	if m != nil {
-		return m.ServiceIds
+		return m.ProviderServiceId
 	}
-	return nil
+	return ""
 }
 
-func (m *DelServicesRequest) GetForce() bool {
+func (m *RevokeDependencyApprovalRequest) GetConsumerServiceId() string {
 	if m != nil {
-		return m.Force
+		return m.ConsumerServiceId
 	}
-	return false
+	return ""
 }
 
-// 删除服务响应内容
-type DelServicesRspInfo struct {
-	ErrMessage string `protobuf:"bytes,1,opt,name=errMessage" json:"errMessage,omitempty"`
-	ServiceId  string `protobuf:"bytes,2,opt,name=serviceId" json:"serviceId,omitempty"`
+type RevokeDependencyApprovalResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
 }
 
-func (m *DelServicesRspInfo) Reset()                    { *m = DelServicesRspInfo{} }
-func (m *DelServicesRspInfo) String() string            { return proto1.CompactTextString(m) }
-func (*DelServicesRspInfo) ProtoMessage()               {}
-func (*DelServicesRspInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
+func (m *RevokeDependencyApprovalResponse) Reset()         { *m = RevokeDependencyApprovalResponse{} }
+func (m *RevokeDependencyApprovalResponse) String() string { return proto1.CompactTextString(m) }
+func (*RevokeDependencyApprovalResponse) ProtoMessage()    {}
+func (*RevokeDependencyApprovalResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{128}
+}
 
-func (m *DelServicesRspInfo) GetErrMessage() string {
+func (m *RevokeDependencyApprovalResponse) GetResponse() *Response {
 	if m != nil {
-		return m.ErrMessage
+		return m.Response
 	}
-	return ""
+	return nil
 }
 
-func (m *DelServicesRspInfo) GetServiceId() string {
+type GetDependencyApprovalsRequest struct {
+	ProviderServiceId string `protobuf:"bytes,1,opt,name=providerServiceId" json:"providerServiceId,omitempty"`
+}
+
+func (m *GetDependencyApprovalsRequest) Reset()         { *m = GetDependencyApprovalsRequest{} }
+func (m *GetDependencyApprovalsRequest) String() string { return proto1.CompactTextString(m) }
+func (*GetDependencyApprovalsRequest) ProtoMessage()    {}
+func (*GetDependencyApprovalsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{129}
+}
+
+func (m *GetDependencyApprovalsRequest) GetProviderServiceId() string {
 	if m != nil {
-		return m.ServiceId
+		return m.ProviderServiceId
 	}
 	return ""
 }
 
-// 删除服务响应
-type DelServicesResponse struct {
-	Response *Response             `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
-	Services []*DelServicesRspInfo `protobuf:"bytes,2,rep,name=services" json:"services,omitempty"`
+type GetDependencyApprovalsResponse struct {
+	Response  *Response             `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Approvals []*DependencyApproval `protobuf:"bytes,2,rep,name=approvals" json:"approvals,omitempty"`
 }
 
-func (m *DelServicesResponse) Reset()                    { *m = DelServicesResponse{} }
-func (m *DelServicesResponse) String() string            { return proto1.CompactTextString(m) }
-func (*DelServicesResponse) ProtoMessage()               {}
-func (*DelServicesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
+func (m *GetDependencyApprovalsResponse) Reset()         { *m = GetDependencyApprovalsResponse{} }
+func (m *GetDependencyApprovalsResponse) String() string { return proto1.CompactTextString(m) }
+func (*GetDependencyApprovalsResponse) ProtoMessage()    {}
+func (*GetDependencyApprovalsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{130}
+}
 
-func (m *DelServicesResponse) GetResponse() *Response {
+func (m *GetDependencyApprovalsResponse) GetResponse() *Response {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *DelServicesResponse) GetServices() []*DelServicesRspInfo {
+func (m *GetDependencyApprovalsResponse) GetApprovals() []*DependencyApproval {
 	if m != nil {
-		return m.Services
+		return m.Approvals
 	}
 	return nil
 }
 
-type GetAppsRequest struct {
-	Environment string `protobuf:"bytes,1,opt,name=environment" json:"environment,omitempty"`
+// 消费者通过Find实际发现的提供者
+type DependencyUsage struct {
+	ProviderServiceId string `protobuf:"bytes,1,opt,name=providerServiceId" json:"providerServiceId,omitempty"`
+	VersionRule       string `protobuf:"bytes,2,opt,name=versionRule" json:"versionRule,omitempty"`
+	Timestamp         string `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
 }
 
-func (m *GetAppsRequest) Reset()                    { *m = GetAppsRequest{} }
-func (m *GetAppsRequest) String() string            { return proto1.CompactTextString(m) }
-func (*GetAppsRequest) ProtoMessage()               {}
-func (*GetAppsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
+func (m *DependencyUsage) Reset()                    { *m = DependencyUsage{} }
+func (m *DependencyUsage) String() string            { return proto1.CompactTextString(m) }
+func (*DependencyUsage) ProtoMessage()               {}
+func (*DependencyUsage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{131} }
 
-func (m *GetAppsRequest) GetEnvironment() string {
+func (m *DependencyUsage) GetProviderServiceId() string {
 	if m != nil {
-		return m.Environment
+		return m.ProviderServiceId
 	}
 	return ""
 }
 
-type GetAppsResponse struct {
-	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
-	AppIds   []string  `protobuf:"bytes,2,rep,name=appIds" json:"appIds,omitempty"`
+func (m *DependencyUsage) GetVersionRule() string {
+	if m != nil {
+		return m.VersionRule
+	}
+	return ""
 }
 
-func (m *GetAppsResponse) Reset()                    { *m = GetAppsResponse{} }
-func (m *GetAppsResponse) String() string            { return proto1.CompactTextString(m) }
-func (*GetAppsResponse) ProtoMessage()               {}
-func (*GetAppsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
+func (m *DependencyUsage) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
 
-func (m *GetAppsResponse) GetResponse() *Response {
+// 发现过提供者实例的消费者，lastAccessTimestamp精度为发现记录的刷新间隔(1小时)
+type ProviderAccessor struct {
+	ConsumerServiceId   string           `protobuf:"bytes,1,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
+	Consumer            *MicroServiceKey `protobuf:"bytes,2,opt,name=consumer" json:"consumer,omitempty"`
+	InstanceCount       int64            `protobuf:"varint,3,opt,name=instanceCount" json:"instanceCount,omitempty"`
+	LastAccessTimestamp string           `protobuf:"bytes,4,opt,name=lastAccessTimestamp" json:"lastAccessTimestamp,omitempty"`
+}
+
+func (m *ProviderAccessor) Reset()                    { *m = ProviderAccessor{} }
+func (m *ProviderAccessor) String() string            { return proto1.CompactTextString(m) }
+func (*ProviderAccessor) ProtoMessage()               {}
+func (*ProviderAccessor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{132} }
+
+func (m *ProviderAccessor) GetConsumerServiceId() string {
 	if m != nil {
-		return m.Response
+		return m.ConsumerServiceId
 	}
-	return nil
+	return ""
 }
 
-func (m *GetAppsResponse) GetAppIds() []string {
+func (m *ProviderAccessor) GetConsumer() *MicroServiceKey {
 	if m != nil {
-		return m.AppIds
+		return m.Consumer
 	}
 	return nil
 }
 
-func init() {
-	proto1.RegisterType((*ModifySchemasRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.ModifySchemasRequest")
-	proto1.RegisterType((*Schema)(nil), "com.huawei.paas.cse.serviceregistry.api.Schema")
-	proto1.RegisterType((*ModifySchemasResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.ModifySchemasResponse")
-	proto1.RegisterType((*HeartbeatSetRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.HeartbeatSetRequest")
-	proto1.RegisterType((*HeartbeatSetElement)(nil), "com.huawei.paas.cse.serviceregistry.api.HeartbeatSetElement")
-	proto1.RegisterType((*HeartbeatSetResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.HeartbeatSetResponse")
-	proto1.RegisterType((*InstanceHbRst)(nil), "com.huawei.paas.cse.serviceregistry.api.InstanceHbRst")
-	proto1.RegisterType((*StService)(nil), "com.huawei.paas.cse.serviceregistry.api.StService")
-	proto1.RegisterType((*StInstance)(nil), "com.huawei.paas.cse.serviceregistry.api.StInstance")
-	proto1.RegisterType((*StApp)(nil), "com.huawei.paas.cse.serviceregistry.api.StApp")
-	proto1.RegisterType((*Statistics)(nil), "com.huawei.paas.cse.serviceregistry.api.Statistics")
-	proto1.RegisterType((*GetServicesInfoRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServicesInfoRequest")
-	proto1.RegisterType((*GetServicesInfoResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServicesInfoResponse")
-	proto1.RegisterType((*MicroServiceKey)(nil), "com.huawei.paas.cse.serviceregistry.api.MicroServiceKey")
-	proto1.RegisterType((*MicroService)(nil), "com.huawei.paas.cse.serviceregistry.api.MicroService")
-	proto1.RegisterType((*FrameWorkProperty)(nil), "com.huawei.paas.cse.serviceregistry.api.FrameWorkProperty")
-	proto1.RegisterType((*ServiceRule)(nil), "com.huawei.paas.cse.serviceregistry.api.ServiceRule")
-	proto1.RegisterType((*AddOrUpdateServiceRule)(nil), "com.huawei.paas.cse.serviceregistry.api.AddOrUpdateServiceRule")
-	proto1.RegisterType((*ServicePath)(nil), "com.huawei.paas.cse.serviceregistry.api.ServicePath")
-	proto1.RegisterType((*Response)(nil), "com.huawei.paas.cse.serviceregistry.api.Response")
-	proto1.RegisterType((*GetExistenceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetExistenceRequest")
-	proto1.RegisterType((*GetExistenceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetExistenceResponse")
-	proto1.RegisterType((*CreateServiceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.CreateServiceRequest")
-	proto1.RegisterType((*CreateServiceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.CreateServiceResponse")
-	proto1.RegisterType((*DeleteServiceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteServiceRequest")
-	proto1.RegisterType((*DeleteServiceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteServiceResponse")
-	proto1.RegisterType((*GetServiceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceRequest")
-	proto1.RegisterType((*GetServiceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceResponse")
-	proto1.RegisterType((*GetServicesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServicesRequest")
-	proto1.RegisterType((*GetServicesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServicesResponse")
-	proto1.RegisterType((*UpdateServicePropsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServicePropsRequest")
-	proto1.RegisterType((*UpdateServicePropsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServicePropsResponse")
-	proto1.RegisterType((*GetServiceRulesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceRulesRequest")
-	proto1.RegisterType((*GetServiceRulesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceRulesResponse")
-	proto1.RegisterType((*UpdateServiceRuleRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServiceRuleRequest")
-	proto1.RegisterType((*UpdateServiceRuleResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServiceRuleResponse")
-	proto1.RegisterType((*AddServiceRulesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.AddServiceRulesRequest")
-	proto1.RegisterType((*AddServiceRulesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.AddServiceRulesResponse")
-	proto1.RegisterType((*DeleteServiceRulesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteServiceRulesRequest")
-	proto1.RegisterType((*DeleteServiceRulesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteServiceRulesResponse")
-	proto1.RegisterType((*GetServiceTagsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceTagsRequest")
-	proto1.RegisterType((*GetServiceTagsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceTagsResponse")
-	proto1.RegisterType((*UpdateServiceTagRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServiceTagRequest")
-	proto1.RegisterType((*UpdateServiceTagResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServiceTagResponse")
-	proto1.RegisterType((*AddServiceTagsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.AddServiceTagsRequest")
-	proto1.RegisterType((*AddServiceTagsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.AddServiceTagsResponse")
-	proto1.RegisterType((*DeleteServiceTagsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteServiceTagsRequest")
-	proto1.RegisterType((*DeleteServiceTagsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteServiceTagsResponse")
-	proto1.RegisterType((*HealthCheck)(nil), "com.huawei.paas.cse.serviceregistry.api.HealthCheck")
-	proto1.RegisterType((*MicroServiceInstance)(nil), "com.huawei.paas.cse.serviceregistry.api.MicroServiceInstance")
-	proto1.RegisterType((*DataCenterInfo)(nil), "com.huawei.paas.cse.serviceregistry.api.DataCenterInfo")
-	proto1.RegisterType((*MicroServiceInstanceKey)(nil), "com.huawei.paas.cse.serviceregistry.api.MicroServiceInstanceKey")
-	proto1.RegisterType((*RegisterInstanceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.RegisterInstanceRequest")
-	proto1.RegisterType((*RegisterInstanceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.RegisterInstanceResponse")
-	proto1.RegisterType((*UnregisterInstanceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UnregisterInstanceRequest")
-	proto1.RegisterType((*UnregisterInstanceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UnregisterInstanceResponse")
-	proto1.RegisterType((*HeartbeatRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.HeartbeatRequest")
-	proto1.RegisterType((*HeartbeatResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.HeartbeatResponse")
-	proto1.RegisterType((*FindInstancesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.FindInstancesRequest")
-	proto1.RegisterType((*FindInstancesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.FindInstancesResponse")
-	proto1.RegisterType((*GetOneInstanceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetOneInstanceRequest")
-	proto1.RegisterType((*GetOneInstanceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetOneInstanceResponse")
-	proto1.RegisterType((*GetInstancesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetInstancesRequest")
-	proto1.RegisterType((*GetInstancesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetInstancesResponse")
-	proto1.RegisterType((*UpdateInstanceStatusRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateInstanceStatusRequest")
-	proto1.RegisterType((*UpdateInstanceStatusResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateInstanceStatusResponse")
-	proto1.RegisterType((*UpdateInstancePropsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateInstancePropsRequest")
-	proto1.RegisterType((*UpdateInstancePropsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateInstancePropsResponse")
-	proto1.RegisterType((*WatchInstanceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.WatchInstanceRequest")
-	proto1.RegisterType((*WatchInstanceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.WatchInstanceResponse")
-	proto1.RegisterType((*GetSchemaRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetSchemaRequest")
-	proto1.RegisterType((*GetAllSchemaRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetAllSchemaRequest")
-	proto1.RegisterType((*GetSchemaResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetSchemaResponse")
-	proto1.RegisterType((*GetAllSchemaResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetAllSchemaResponse")
-	proto1.RegisterType((*DeleteSchemaRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteSchemaRequest")
-	proto1.RegisterType((*DeleteSchemaResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteSchemaResponse")
-	proto1.RegisterType((*ModifySchemaRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.ModifySchemaRequest")
-	proto1.RegisterType((*ModifySchemaResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.ModifySchemaResponse")
-	proto1.RegisterType((*AddDependenciesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.AddDependenciesRequest")
-	proto1.RegisterType((*AddDependenciesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.AddDependenciesResponse")
-	proto1.RegisterType((*CreateDependenciesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.CreateDependenciesRequest")
-	proto1.RegisterType((*DependencyKey)(nil), "com.huawei.paas.cse.serviceregistry.api.DependencyKey")
-	proto1.RegisterType((*ConsumerDependency)(nil), "com.huawei.paas.cse.serviceregistry.api.ConsumerDependency")
-	proto1.RegisterType((*CreateDependenciesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.CreateDependenciesResponse")
-	proto1.RegisterType((*GetDependenciesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetDependenciesRequest")
-	proto1.RegisterType((*GetConDependenciesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetConDependenciesResponse")
-	proto1.RegisterType((*GetProDependenciesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetProDependenciesResponse")
-	proto1.RegisterType((*ServiceDetail)(nil), "com.huawei.paas.cse.serviceregistry.api.ServiceDetail")
-	proto1.RegisterType((*GetServiceDetailResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceDetailResponse")
-	proto1.RegisterType((*DelServicesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DelServicesRequest")
-	proto1.RegisterType((*DelServicesRspInfo)(nil), "com.huawei.paas.cse.serviceregistry.api.DelServicesRspInfo")
-	proto1.RegisterType((*DelServicesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DelServicesResponse")
-	proto1.RegisterType((*GetAppsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetAppsRequest")
-	proto1.RegisterType((*GetAppsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetAppsResponse")
+func (m *ProviderAccessor) GetInstanceCount() int64 {
+	if m != nil {
+		return m.InstanceCount
+	}
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *ProviderAccessor) GetLastAccessTimestamp() string {
+	if m != nil {
+		return m.LastAccessTimestamp
+	}
+	return ""
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type GetProviderAccessorsRequest struct {
+	ProviderServiceId string `protobuf:"bytes,1,opt,name=providerServiceId" json:"providerServiceId,omitempty"`
+	Window            string `protobuf:"bytes,2,opt,name=window" json:"window,omitempty"`
+}
 
-// Client API for ServiceCtrl service
+func (m *GetProviderAccessorsRequest) Reset()                    { *m = GetProviderAccessorsRequest{} }
+func (m *GetProviderAccessorsRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetProviderAccessorsRequest) ProtoMessage()               {}
+func (*GetProviderAccessorsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{133} }
 
-type ServiceCtrlClient interface {
-	Exist(ctx context.Context, in *GetExistenceRequest, opts ...grpc.CallOption) (*GetExistenceResponse, error)
-	Create(ctx context.Context, in *CreateServiceRequest, opts ...grpc.CallOption) (*CreateServiceResponse, error)
-	Delete(ctx context.Context, in *DeleteServiceRequest, opts ...grpc.CallOption) (*DeleteServiceResponse, error)
-	GetOne(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceResponse, error)
-	GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error)
-	UpdateProperties(ctx context.Context, in *UpdateServicePropsRequest, opts ...grpc.CallOption) (*UpdateServicePropsResponse, error)
-	AddRule(ctx context.Context, in *AddServiceRulesRequest, opts ...grpc.CallOption) (*AddServiceRulesResponse, error)
-	GetRule(ctx context.Context, in *GetServiceRulesRequest, opts ...grpc.CallOption) (*GetServiceRulesResponse, error)
-	UpdateRule(ctx context.Context, in *UpdateServiceRuleRequest, opts ...grpc.CallOption) (*UpdateServiceRuleResponse, error)
-	DeleteRule(ctx context.Context, in *DeleteServiceRulesRequest, opts ...grpc.CallOption) (*DeleteServiceRulesResponse, error)
-	AddTags(ctx context.Context, in *AddServiceTagsRequest, opts ...grpc.CallOption) (*AddServiceTagsResponse, error)
-	GetTags(ctx context.Context, in *GetServiceTagsRequest, opts ...grpc.CallOption) (*GetServiceTagsResponse, error)
-	UpdateTag(ctx context.Context, in *UpdateServiceTagRequest, opts ...grpc.CallOption) (*UpdateServiceTagResponse, error)
-	DeleteTags(ctx context.Context, in *DeleteServiceTagsRequest, opts ...grpc.CallOption) (*DeleteServiceTagsResponse, error)
-	GetSchemaInfo(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*GetSchemaResponse, error)
-	GetAllSchemaInfo(ctx context.Context, in *GetAllSchemaRequest, opts ...grpc.CallOption) (*GetAllSchemaResponse, error)
-	DeleteSchema(ctx context.Context, in *DeleteSchemaRequest, opts ...grpc.CallOption) (*DeleteSchemaResponse, error)
-	ModifySchema(ctx context.Context, in *ModifySchemaRequest, opts ...grpc.CallOption) (*ModifySchemaResponse, error)
-	ModifySchemas(ctx context.Context, in *ModifySchemasRequest, opts ...grpc.CallOption) (*ModifySchemasResponse, error)
-	AddDependenciesForMicroServices(ctx context.Context, in *AddDependenciesRequest, opts ...grpc.CallOption) (*AddDependenciesResponse, error)
-	CreateDependenciesForMicroServices(ctx context.Context, in *CreateDependenciesRequest, opts ...grpc.CallOption) (*CreateDependenciesResponse, error)
-	GetProviderDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetProDependenciesResponse, error)
-	GetConsumerDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetConDependenciesResponse, error)
-	DeleteServices(ctx context.Context, in *DelServicesRequest, opts ...grpc.CallOption) (*DelServicesResponse, error)
+func (m *GetProviderAccessorsRequest) GetProviderServiceId() string {
+	if m != nil {
+		return m.ProviderServiceId
+	}
+	return ""
 }
 
-type serviceCtrlClient struct {
-	cc *grpc.ClientConn
+func (m *GetProviderAccessorsRequest) GetWindow() string {
+	if m != nil {
+		return m.Window
+	}
+	return ""
 }
 
-func NewServiceCtrlClient(cc *grpc.ClientConn) ServiceCtrlClient {
-	return &serviceCtrlClient{cc}
+type GetProviderAccessorsResponse struct {
+	Response  *Response           `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Accessors []*ProviderAccessor `protobuf:"bytes,2,rep,name=accessors" json:"accessors,omitempty"`
 }
 
-func (c *serviceCtrlClient) Exist(ctx context.Context, in *GetExistenceRequest, opts ...grpc.CallOption) (*GetExistenceResponse, error) {
-	out := new(GetExistenceResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/exist", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetProviderAccessorsResponse) Reset()                    { *m = GetProviderAccessorsResponse{} }
+func (m *GetProviderAccessorsResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetProviderAccessorsResponse) ProtoMessage()               {}
+func (*GetProviderAccessorsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{134} }
+
+func (m *GetProviderAccessorsResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
 	}
-	return out, nil
+	return nil
 }
 
-func (c *serviceCtrlClient) Create(ctx context.Context, in *CreateServiceRequest, opts ...grpc.CallOption) (*CreateServiceResponse, error) {
-	out := new(CreateServiceResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/create", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetProviderAccessorsResponse) GetAccessors() []*ProviderAccessor {
+	if m != nil {
+		return m.Accessors
 	}
-	return out, nil
+	return nil
 }
 
-func (c *serviceCtrlClient) Delete(ctx context.Context, in *DeleteServiceRequest, opts ...grpc.CallOption) (*DeleteServiceResponse, error) {
-	out := new(DeleteServiceResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/delete", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type GetDependencyDiffResponse struct {
+	Response   *Response          `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Unused     []*MicroServiceKey `protobuf:"bytes,2,rep,name=unused" json:"unused,omitempty"`
+	Undeclared []*MicroService    `protobuf:"bytes,3,rep,name=undeclared" json:"undeclared,omitempty"`
+}
+
+func (m *GetDependencyDiffResponse) Reset()                    { *m = GetDependencyDiffResponse{} }
+func (m *GetDependencyDiffResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetDependencyDiffResponse) ProtoMessage()               {}
+func (*GetDependencyDiffResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{135} }
+
+func (m *GetDependencyDiffResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
 	}
-	return out, nil
+	return nil
+}
+
+func (m *GetDependencyDiffResponse) GetUnused() []*MicroServiceKey {
+	if m != nil {
+		return m.Unused
+	}
+	return nil
+}
+
+func (m *GetDependencyDiffResponse) GetUndeclared() []*MicroService {
+	if m != nil {
+		return m.Undeclared
+	}
+	return nil
+}
+
+// 查询消费者在历史revision时的依赖规则，revision和timestamp二选一，
+// timestamp按采样(1分钟)换算为revision，只能查询未被压缩的revision
+type GetDependencyHistoryRequest struct {
+	ServiceId string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Revision  string `protobuf:"bytes,2,opt,name=revision" json:"revision,omitempty"`
+	Timestamp string `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *GetDependencyHistoryRequest) Reset()                    { *m = GetDependencyHistoryRequest{} }
+func (m *GetDependencyHistoryRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetDependencyHistoryRequest) ProtoMessage()               {}
+func (*GetDependencyHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{136} }
+
+func (m *GetDependencyHistoryRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *GetDependencyHistoryRequest) GetRevision() string {
+	if m != nil {
+		return m.Revision
+	}
+	return ""
+}
+
+func (m *GetDependencyHistoryRequest) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+type GetDependencyHistoryResponse struct {
+	Response  *Response          `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Revision  int64              `protobuf:"varint,2,opt,name=revision" json:"revision,omitempty"`
+	Consumer  *MicroServiceKey   `protobuf:"bytes,3,opt,name=consumer" json:"consumer,omitempty"`
+	Providers []*MicroServiceKey `protobuf:"bytes,4,rep,name=providers" json:"providers,omitempty"`
+}
+
+func (m *GetDependencyHistoryResponse) Reset()                    { *m = GetDependencyHistoryResponse{} }
+func (m *GetDependencyHistoryResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetDependencyHistoryResponse) ProtoMessage()               {}
+func (*GetDependencyHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{137} }
+
+func (m *GetDependencyHistoryResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetDependencyHistoryResponse) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *GetDependencyHistoryResponse) GetConsumer() *MicroServiceKey {
+	if m != nil {
+		return m.Consumer
+	}
+	return nil
+}
+
+func (m *GetDependencyHistoryResponse) GetProviders() []*MicroServiceKey {
+	if m != nil {
+		return m.Providers
+	}
+	return nil
+}
+
+// 服务详情
+type ServiceDetail struct {
+	MicroService         *MicroService           `protobuf:"bytes,1,opt,name=microService" json:"microService,omitempty"`
+	Instances            []*MicroServiceInstance `protobuf:"bytes,2,rep,name=instances" json:"instances,omitempty"`
+	SchemaInfos          []*Schema               `protobuf:"bytes,3,rep,name=schemaInfos" json:"schemaInfos,omitempty"`
+	Rules                []*ServiceRule          `protobuf:"bytes,4,rep,name=rules" json:"rules,omitempty"`
+	Providers            []*MicroService         `protobuf:"bytes,5,rep,name=providers" json:"providers,omitempty"`
+	Consumers            []*MicroService         `protobuf:"bytes,6,rep,name=consumers" json:"consumers,omitempty"`
+	Tags                 map[string]string       `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	MicroServiceVersions []string                `protobuf:"bytes,8,rep,name=microServiceVersions" json:"microServiceVersions,omitempty"`
+	Statics              *Statistics             `protobuf:"bytes,9,opt,name=statics" json:"statics,omitempty"`
+}
+
+func (m *ServiceDetail) Reset()                    { *m = ServiceDetail{} }
+func (m *ServiceDetail) String() string            { return proto1.CompactTextString(m) }
+func (*ServiceDetail) ProtoMessage()               {}
+func (*ServiceDetail) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{138} }
+
+func (m *ServiceDetail) GetMicroService() *MicroService {
+	if m != nil {
+		return m.MicroService
+	}
+	return nil
+}
+
+func (m *ServiceDetail) GetInstances() []*MicroServiceInstance {
+	if m != nil {
+		return m.Instances
+	}
+	return nil
+}
+
+func (m *ServiceDetail) GetSchemaInfos() []*Schema {
+	if m != nil {
+		return m.SchemaInfos
+	}
+	return nil
+}
+
+func (m *ServiceDetail) GetRules() []*ServiceRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *ServiceDetail) GetProviders() []*MicroService {
+	if m != nil {
+		return m.Providers
+	}
+	return nil
+}
+
+func (m *ServiceDetail) GetConsumers() []*MicroService {
+	if m != nil {
+		return m.Consumers
+	}
+	return nil
+}
+
+func (m *ServiceDetail) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *ServiceDetail) GetMicroServiceVersions() []string {
+	if m != nil {
+		return m.MicroServiceVersions
+	}
+	return nil
+}
+
+func (m *ServiceDetail) GetStatics() *Statistics {
+	if m != nil {
+		return m.Statics
+	}
+	return nil
+}
+
+// 服务详情返回信息
+type GetServiceDetailResponse struct {
+	Response *Response      `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Service  *ServiceDetail `protobuf:"bytes,2,opt,name=service" json:"service,omitempty"`
+}
+
+func (m *GetServiceDetailResponse) Reset()                    { *m = GetServiceDetailResponse{} }
+func (m *GetServiceDetailResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetServiceDetailResponse) ProtoMessage()               {}
+func (*GetServiceDetailResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{139} }
+
+func (m *GetServiceDetailResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetServiceDetailResponse) GetService() *ServiceDetail {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+// 删除服务请求
+type DelServicesRequest struct {
+	ServiceIds []string `protobuf:"bytes,1,rep,name=serviceIds" json:"serviceIds,omitempty"`
+	Force      bool     `protobuf:"varint,2,opt,name=force" json:"force,omitempty"`
+}
+
+func (m *DelServicesRequest) Reset()                    { *m = DelServicesRequest{} }
+func (m *DelServicesRequest) String() string            { return proto1.CompactTextString(m) }
+func (*DelServicesRequest) ProtoMessage()               {}
+func (*DelServicesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{140} }
+
+func (m *DelServicesRequest) GetServiceIds() []string {
+	if m != nil {
+		return m.ServiceIds
+	}
+	return nil
+}
+
+func (m *DelServicesRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+// 删除服务响应内容
+type DelServicesRspInfo struct {
+	ErrMessage string `protobuf:"bytes,1,opt,name=errMessage" json:"errMessage,omitempty"`
+	ServiceId  string `protobuf:"bytes,2,opt,name=serviceId" json:"serviceId,omitempty"`
+}
+
+func (m *DelServicesRspInfo) Reset()                    { *m = DelServicesRspInfo{} }
+func (m *DelServicesRspInfo) String() string            { return proto1.CompactTextString(m) }
+func (*DelServicesRspInfo) ProtoMessage()               {}
+func (*DelServicesRspInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{141} }
+
+func (m *DelServicesRspInfo) GetErrMessage() string {
+	if m != nil {
+		return m.ErrMessage
+	}
+	return ""
+}
+
+func (m *DelServicesRspInfo) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+// 删除服务响应
+type DelServicesResponse struct {
+	Response *Response             `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Services []*DelServicesRspInfo `protobuf:"bytes,2,rep,name=services" json:"services,omitempty"`
+}
+
+func (m *DelServicesResponse) Reset()                    { *m = DelServicesResponse{} }
+func (m *DelServicesResponse) String() string            { return proto1.CompactTextString(m) }
+func (*DelServicesResponse) ProtoMessage()               {}
+func (*DelServicesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{142} }
+
+func (m *DelServicesResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *DelServicesResponse) GetServices() []*DelServicesRspInfo {
+	if m != nil {
+		return m.Services
+	}
+	return nil
+}
+
+type GetAppsRequest struct {
+	Environment string `protobuf:"bytes,1,opt,name=environment" json:"environment,omitempty"`
+}
+
+func (m *GetAppsRequest) Reset()                    { *m = GetAppsRequest{} }
+func (m *GetAppsRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetAppsRequest) ProtoMessage()               {}
+func (*GetAppsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{143} }
+
+func (m *GetAppsRequest) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+type GetAppsResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	AppIds   []string  `protobuf:"bytes,2,rep,name=appIds" json:"appIds,omitempty"`
+}
+
+func (m *GetAppsResponse) Reset()                    { *m = GetAppsResponse{} }
+func (m *GetAppsResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetAppsResponse) ProtoMessage()               {}
+func (*GetAppsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{144} }
+
+func (m *GetAppsResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetAppsResponse) GetAppIds() []string {
+	if m != nil {
+		return m.AppIds
+	}
+	return nil
+}
+
+// 跨服务查询实例，条件之间为与关系
+type SearchInstancesRequest struct {
+	Status        string            `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
+	Datacenter    string            `protobuf:"bytes,2,opt,name=datacenter" json:"datacenter,omitempty"`
+	Region        string            `protobuf:"bytes,3,opt,name=region" json:"region,omitempty"`
+	AvailableZone string            `protobuf:"bytes,4,opt,name=availableZone" json:"availableZone,omitempty"`
+	Properties    map[string]string `protobuf:"bytes,5,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Cidr          string            `protobuf:"bytes,6,opt,name=cidr" json:"cidr,omitempty"`
+	Offset        int64             `protobuf:"varint,7,opt,name=offset" json:"offset,omitempty"`
+	Limit         int64             `protobuf:"varint,8,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *SearchInstancesRequest) Reset()                    { *m = SearchInstancesRequest{} }
+func (m *SearchInstancesRequest) String() string            { return proto1.CompactTextString(m) }
+func (*SearchInstancesRequest) ProtoMessage()               {}
+func (*SearchInstancesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{145} }
+
+func (m *SearchInstancesRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *SearchInstancesRequest) GetDatacenter() string {
+	if m != nil {
+		return m.Datacenter
+	}
+	return ""
+}
+
+func (m *SearchInstancesRequest) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *SearchInstancesRequest) GetAvailableZone() string {
+	if m != nil {
+		return m.AvailableZone
+	}
+	return ""
+}
+
+func (m *SearchInstancesRequest) GetProperties() map[string]string {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *SearchInstancesRequest) GetCidr() string {
+	if m != nil {
+		return m.Cidr
+	}
+	return ""
+}
+
+func (m *SearchInstancesRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *SearchInstancesRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type SearchInstancesResponse struct {
+	Response  *Response               `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Instances []*MicroServiceInstance `protobuf:"bytes,2,rep,name=instances" json:"instances,omitempty"`
+	Total     int64                   `protobuf:"varint,3,opt,name=total" json:"total,omitempty"`
+}
+
+func (m *SearchInstancesResponse) Reset()                    { *m = SearchInstancesResponse{} }
+func (m *SearchInstancesResponse) String() string            { return proto1.CompactTextString(m) }
+func (*SearchInstancesResponse) ProtoMessage()               {}
+func (*SearchInstancesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{146} }
+
+func (m *SearchInstancesResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *SearchInstancesResponse) GetInstances() []*MicroServiceInstance {
+	if m != nil {
+		return m.Instances
+	}
+	return nil
+}
+
+func (m *SearchInstancesResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type ApplyServiceRequest struct {
+	Service   *MicroService             `protobuf:"bytes,1,opt,name=service" json:"service,omitempty"`
+	Schemas   []*Schema                 `protobuf:"bytes,2,rep,name=schemas" json:"schemas,omitempty"`
+	Tags      map[string]string         `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Rules     []*AddOrUpdateServiceRule `protobuf:"bytes,4,rep,name=rules" json:"rules,omitempty"`
+	Providers []*DependencyKey          `protobuf:"bytes,5,rep,name=providers" json:"providers,omitempty"`
+	DryRun    bool                      `protobuf:"varint,6,opt,name=dryRun" json:"dryRun,omitempty"`
+}
+
+func (m *ApplyServiceRequest) Reset()                    { *m = ApplyServiceRequest{} }
+func (m *ApplyServiceRequest) String() string            { return proto1.CompactTextString(m) }
+func (*ApplyServiceRequest) ProtoMessage()               {}
+func (*ApplyServiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{147} }
+
+func (m *ApplyServiceRequest) GetService() *MicroService {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+func (m *ApplyServiceRequest) GetSchemas() []*Schema {
+	if m != nil {
+		return m.Schemas
+	}
+	return nil
+}
+
+func (m *ApplyServiceRequest) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *ApplyServiceRequest) GetRules() []*AddOrUpdateServiceRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+func (m *ApplyServiceRequest) GetProviders() []*DependencyKey {
+	if m != nil {
+		return m.Providers
+	}
+	return nil
+}
+
+func (m *ApplyServiceRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type ApplyChange struct {
+	Kind   string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	Action string `protobuf:"bytes,2,opt,name=action" json:"action,omitempty"`
+	Name   string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ApplyChange) Reset()                    { *m = ApplyChange{} }
+func (m *ApplyChange) String() string            { return proto1.CompactTextString(m) }
+func (*ApplyChange) ProtoMessage()               {}
+func (*ApplyChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{148} }
+
+func (m *ApplyChange) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ApplyChange) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ApplyChange) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ApplyServiceResponse struct {
+	Response  *Response      `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	ServiceId string         `protobuf:"bytes,2,opt,name=serviceId" json:"serviceId,omitempty"`
+	Changes   []*ApplyChange `protobuf:"bytes,3,rep,name=changes" json:"changes,omitempty"`
+}
+
+func (m *ApplyServiceResponse) Reset()                    { *m = ApplyServiceResponse{} }
+func (m *ApplyServiceResponse) String() string            { return proto1.CompactTextString(m) }
+func (*ApplyServiceResponse) ProtoMessage()               {}
+func (*ApplyServiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{149} }
+
+func (m *ApplyServiceResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ApplyServiceResponse) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *ApplyServiceResponse) GetChanges() []*ApplyChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+type Snapshot struct {
+	SnapshotId      string `protobuf:"bytes,1,opt,name=snapshotId" json:"snapshotId,omitempty"`
+	Revision        int64  `protobuf:"varint,2,opt,name=revision" json:"revision,omitempty"`
+	Timestamp       string `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	ExpireTimestamp string `protobuf:"bytes,4,opt,name=expireTimestamp" json:"expireTimestamp,omitempty"`
+}
+
+func (m *Snapshot) Reset()                    { *m = Snapshot{} }
+func (m *Snapshot) String() string            { return proto1.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()               {}
+func (*Snapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{150} }
+
+func (m *Snapshot) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return ""
+}
+
+func (m *Snapshot) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *Snapshot) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+func (m *Snapshot) GetExpireTimestamp() string {
+	if m != nil {
+		return m.ExpireTimestamp
+	}
+	return ""
+}
+
+type CreateSnapshotRequest struct {
+	Ttl int64 `protobuf:"varint,1,opt,name=ttl" json:"ttl,omitempty"`
+}
+
+func (m *CreateSnapshotRequest) Reset()                    { *m = CreateSnapshotRequest{} }
+func (m *CreateSnapshotRequest) String() string            { return proto1.CompactTextString(m) }
+func (*CreateSnapshotRequest) ProtoMessage()               {}
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{151} }
+
+func (m *CreateSnapshotRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+type CreateSnapshotResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Snapshot *Snapshot `protobuf:"bytes,2,opt,name=snapshot" json:"snapshot,omitempty"`
+}
+
+func (m *CreateSnapshotResponse) Reset()                    { *m = CreateSnapshotResponse{} }
+func (m *CreateSnapshotResponse) String() string            { return proto1.CompactTextString(m) }
+func (*CreateSnapshotResponse) ProtoMessage()               {}
+func (*CreateSnapshotResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{152} }
+
+func (m *CreateSnapshotResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *CreateSnapshotResponse) GetSnapshot() *Snapshot {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+type GetSnapshotRequest struct {
+	SnapshotId string `protobuf:"bytes,1,opt,name=snapshotId" json:"snapshotId,omitempty"`
+}
+
+func (m *GetSnapshotRequest) Reset()                    { *m = GetSnapshotRequest{} }
+func (m *GetSnapshotRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetSnapshotRequest) ProtoMessage()               {}
+func (*GetSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{153} }
+
+func (m *GetSnapshotRequest) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return ""
+}
+
+type GetSnapshotResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Snapshot *Snapshot `protobuf:"bytes,2,opt,name=snapshot" json:"snapshot,omitempty"`
+}
+
+func (m *GetSnapshotResponse) Reset()                    { *m = GetSnapshotResponse{} }
+func (m *GetSnapshotResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetSnapshotResponse) ProtoMessage()               {}
+func (*GetSnapshotResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{154} }
+
+func (m *GetSnapshotResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetSnapshotResponse) GetSnapshot() *Snapshot {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+type DeleteSnapshotRequest struct {
+	SnapshotId string `protobuf:"bytes,1,opt,name=snapshotId" json:"snapshotId,omitempty"`
+}
+
+func (m *DeleteSnapshotRequest) Reset()                    { *m = DeleteSnapshotRequest{} }
+func (m *DeleteSnapshotRequest) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteSnapshotRequest) ProtoMessage()               {}
+func (*DeleteSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{155} }
+
+func (m *DeleteSnapshotRequest) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return ""
+}
+
+type DeleteSnapshotResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *DeleteSnapshotResponse) Reset()                    { *m = DeleteSnapshotResponse{} }
+func (m *DeleteSnapshotResponse) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteSnapshotResponse) ProtoMessage()               {}
+func (*DeleteSnapshotResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{156} }
+
+func (m *DeleteSnapshotResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type ApiKey struct {
+	KeyId           string `protobuf:"bytes,1,opt,name=keyId" json:"keyId,omitempty"`
+	Name            string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Domain          string `protobuf:"bytes,3,opt,name=domain" json:"domain,omitempty"`
+	Project         string `protobuf:"bytes,4,opt,name=project" json:"project,omitempty"`
+	Timestamp       string `protobuf:"bytes,5,opt,name=timestamp" json:"timestamp,omitempty"`
+	ExpireTimestamp string `protobuf:"bytes,6,opt,name=expireTimestamp" json:"expireTimestamp,omitempty"`
+	RotateTimestamp string `protobuf:"bytes,7,opt,name=rotateTimestamp" json:"rotateTimestamp,omitempty"`
+}
+
+func (m *ApiKey) Reset()                    { *m = ApiKey{} }
+func (m *ApiKey) String() string            { return proto1.CompactTextString(m) }
+func (*ApiKey) ProtoMessage()               {}
+func (*ApiKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{157} }
+
+func (m *ApiKey) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *ApiKey) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ApiKey) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *ApiKey) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *ApiKey) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+func (m *ApiKey) GetExpireTimestamp() string {
+	if m != nil {
+		return m.ExpireTimestamp
+	}
+	return ""
+}
+
+func (m *ApiKey) GetRotateTimestamp() string {
+	if m != nil {
+		return m.RotateTimestamp
+	}
+	return ""
+}
+
+type CreateApiKeyRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Ttl  int64  `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+}
+
+func (m *CreateApiKeyRequest) Reset()                    { *m = CreateApiKeyRequest{} }
+func (m *CreateApiKeyRequest) String() string            { return proto1.CompactTextString(m) }
+func (*CreateApiKeyRequest) ProtoMessage()               {}
+func (*CreateApiKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{158} }
+
+func (m *CreateApiKeyRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateApiKeyRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+type CreateApiKeyResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	ApiKey   *ApiKey   `protobuf:"bytes,2,opt,name=apiKey" json:"apiKey,omitempty"`
+	Token    string    `protobuf:"bytes,3,opt,name=token" json:"token,omitempty"`
+}
+
+func (m *CreateApiKeyResponse) Reset()                    { *m = CreateApiKeyResponse{} }
+func (m *CreateApiKeyResponse) String() string            { return proto1.CompactTextString(m) }
+func (*CreateApiKeyResponse) ProtoMessage()               {}
+func (*CreateApiKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{159} }
+
+func (m *CreateApiKeyResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *CreateApiKeyResponse) GetApiKey() *ApiKey {
+	if m != nil {
+		return m.ApiKey
+	}
+	return nil
+}
+
+func (m *CreateApiKeyResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type GetApiKeysRequest struct {
+}
+
+func (m *GetApiKeysRequest) Reset()                    { *m = GetApiKeysRequest{} }
+func (m *GetApiKeysRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetApiKeysRequest) ProtoMessage()               {}
+func (*GetApiKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{160} }
+
+type GetApiKeysResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	ApiKeys  []*ApiKey `protobuf:"bytes,2,rep,name=apiKeys" json:"apiKeys,omitempty"`
+}
+
+func (m *GetApiKeysResponse) Reset()                    { *m = GetApiKeysResponse{} }
+func (m *GetApiKeysResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetApiKeysResponse) ProtoMessage()               {}
+func (*GetApiKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{161} }
+
+func (m *GetApiKeysResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetApiKeysResponse) GetApiKeys() []*ApiKey {
+	if m != nil {
+		return m.ApiKeys
+	}
+	return nil
+}
+
+type RotateApiKeyRequest struct {
+	KeyId       string `protobuf:"bytes,1,opt,name=keyId" json:"keyId,omitempty"`
+	Ttl         int64  `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+	GracePeriod int64  `protobuf:"varint,3,opt,name=gracePeriod" json:"gracePeriod,omitempty"`
+}
+
+func (m *RotateApiKeyRequest) Reset()                    { *m = RotateApiKeyRequest{} }
+func (m *RotateApiKeyRequest) String() string            { return proto1.CompactTextString(m) }
+func (*RotateApiKeyRequest) ProtoMessage()               {}
+func (*RotateApiKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{162} }
+
+func (m *RotateApiKeyRequest) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *RotateApiKeyRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *RotateApiKeyRequest) GetGracePeriod() int64 {
+	if m != nil {
+		return m.GracePeriod
+	}
+	return 0
+}
+
+type RotateApiKeyResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	ApiKey   *ApiKey   `protobuf:"bytes,2,opt,name=apiKey" json:"apiKey,omitempty"`
+	Token    string    `protobuf:"bytes,3,opt,name=token" json:"token,omitempty"`
+}
+
+func (m *RotateApiKeyResponse) Reset()                    { *m = RotateApiKeyResponse{} }
+func (m *RotateApiKeyResponse) String() string            { return proto1.CompactTextString(m) }
+func (*RotateApiKeyResponse) ProtoMessage()               {}
+func (*RotateApiKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{163} }
+
+func (m *RotateApiKeyResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *RotateApiKeyResponse) GetApiKey() *ApiKey {
+	if m != nil {
+		return m.ApiKey
+	}
+	return nil
+}
+
+func (m *RotateApiKeyResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type DeleteApiKeyRequest struct {
+	KeyId string `protobuf:"bytes,1,opt,name=keyId" json:"keyId,omitempty"`
+}
+
+func (m *DeleteApiKeyRequest) Reset()                    { *m = DeleteApiKeyRequest{} }
+func (m *DeleteApiKeyRequest) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteApiKeyRequest) ProtoMessage()               {}
+func (*DeleteApiKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{164} }
+
+func (m *DeleteApiKeyRequest) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+type DeleteApiKeyResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *DeleteApiKeyResponse) Reset()                    { *m = DeleteApiKeyResponse{} }
+func (m *DeleteApiKeyResponse) String() string            { return proto1.CompactTextString(m) }
+func (*DeleteApiKeyResponse) ProtoMessage()               {}
+func (*DeleteApiKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{165} }
+
+func (m *DeleteApiKeyResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+// 按依赖关系计算应用内服务的启动顺序，停止顺序与之相反
+type GetStartupOrderRequest struct {
+	AppId       string `protobuf:"bytes,1,opt,name=appId" json:"appId,omitempty"`
+	Environment string `protobuf:"bytes,2,opt,name=environment" json:"environment,omitempty"`
+}
+
+func (m *GetStartupOrderRequest) Reset()                    { *m = GetStartupOrderRequest{} }
+func (m *GetStartupOrderRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetStartupOrderRequest) ProtoMessage()               {}
+func (*GetStartupOrderRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{166} }
+
+func (m *GetStartupOrderRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *GetStartupOrderRequest) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+type StartupOrderGroup struct {
+	ServiceNames []string `protobuf:"bytes,1,rep,name=serviceNames" json:"serviceNames,omitempty"`
+}
+
+func (m *StartupOrderGroup) Reset()                    { *m = StartupOrderGroup{} }
+func (m *StartupOrderGroup) String() string            { return proto1.CompactTextString(m) }
+func (*StartupOrderGroup) ProtoMessage()               {}
+func (*StartupOrderGroup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{167} }
+
+func (m *StartupOrderGroup) GetServiceNames() []string {
+	if m != nil {
+		return m.ServiceNames
+	}
+	return nil
+}
+
+type GetStartupOrderResponse struct {
+	Response *Response            `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Order    []string             `protobuf:"bytes,2,rep,name=order" json:"order,omitempty"`
+	Layers   []*StartupOrderGroup `protobuf:"bytes,3,rep,name=layers" json:"layers,omitempty"`
+	Cycles   []*StartupOrderGroup `protobuf:"bytes,4,rep,name=cycles" json:"cycles,omitempty"`
+}
+
+func (m *GetStartupOrderResponse) Reset()                    { *m = GetStartupOrderResponse{} }
+func (m *GetStartupOrderResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetStartupOrderResponse) ProtoMessage()               {}
+func (*GetStartupOrderResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{168} }
+
+func (m *GetStartupOrderResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetStartupOrderResponse) GetOrder() []string {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+func (m *GetStartupOrderResponse) GetLayers() []*StartupOrderGroup {
+	if m != nil {
+		return m.Layers
+	}
+	return nil
+}
+
+func (m *GetStartupOrderResponse) GetCycles() []*StartupOrderGroup {
+	if m != nil {
+		return m.Cycles
+	}
+	return nil
+}
+
+// 服务授权控制器(如FaaS平台)代为注册/注销本服务的实例
+type DelegationGrant struct {
+	ControllerServiceId string           `protobuf:"bytes,1,opt,name=controllerServiceId" json:"controllerServiceId,omitempty"`
+	Controller          *MicroServiceKey `protobuf:"bytes,2,opt,name=controller" json:"controller,omitempty"`
+	Timestamp           string           `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *DelegationGrant) Reset()                    { *m = DelegationGrant{} }
+func (m *DelegationGrant) String() string            { return proto1.CompactTextString(m) }
+func (*DelegationGrant) ProtoMessage()               {}
+func (*DelegationGrant) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{169} }
+
+func (m *DelegationGrant) GetControllerServiceId() string {
+	if m != nil {
+		return m.ControllerServiceId
+	}
+	return ""
+}
+
+func (m *DelegationGrant) GetController() *MicroServiceKey {
+	if m != nil {
+		return m.Controller
+	}
+	return nil
+}
+
+func (m *DelegationGrant) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+// 代理注册/注销的审计记录
+type DelegationAudit struct {
+	ControllerServiceId string `protobuf:"bytes,1,opt,name=controllerServiceId" json:"controllerServiceId,omitempty"`
+	ServiceId           string `protobuf:"bytes,2,opt,name=serviceId" json:"serviceId,omitempty"`
+	InstanceId          string `protobuf:"bytes,3,opt,name=instanceId" json:"instanceId,omitempty"`
+	Action              string `protobuf:"bytes,4,opt,name=action" json:"action,omitempty"`
+	Result              string `protobuf:"bytes,5,opt,name=result" json:"result,omitempty"`
+	Operator            string `protobuf:"bytes,6,opt,name=operator" json:"operator,omitempty"`
+	Timestamp           string `protobuf:"bytes,7,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *DelegationAudit) Reset()                    { *m = DelegationAudit{} }
+func (m *DelegationAudit) String() string            { return proto1.CompactTextString(m) }
+func (*DelegationAudit) ProtoMessage()               {}
+func (*DelegationAudit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{170} }
+
+func (m *DelegationAudit) GetControllerServiceId() string {
+	if m != nil {
+		return m.ControllerServiceId
+	}
+	return ""
+}
+
+func (m *DelegationAudit) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *DelegationAudit) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+func (m *DelegationAudit) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *DelegationAudit) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+func (m *DelegationAudit) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *DelegationAudit) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+type GrantDelegationRequest struct {
+	ServiceId           string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	ControllerServiceId string `protobuf:"bytes,2,opt,name=controllerServiceId" json:"controllerServiceId,omitempty"`
+	ConsumerServiceId   string `protobuf:"bytes,3,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
+}
+
+func (m *GrantDelegationRequest) Reset()                    { *m = GrantDelegationRequest{} }
+func (m *GrantDelegationRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GrantDelegationRequest) ProtoMessage()               {}
+func (*GrantDelegationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{171} }
+
+func (m *GrantDelegationRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *GrantDelegationRequest) GetControllerServiceId() string {
+	if m != nil {
+		return m.ControllerServiceId
+	}
+	return ""
+}
+
+func (m *GrantDelegationRequest) GetConsumerServiceId() string {
+	if m != nil {
+		return m.ConsumerServiceId
+	}
+	return ""
+}
+
+type GrantDelegationResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *GrantDelegationResponse) Reset()                    { *m = GrantDelegationResponse{} }
+func (m *GrantDelegationResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GrantDelegationResponse) ProtoMessage()               {}
+func (*GrantDelegationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{172} }
+
+func (m *GrantDelegationResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type RevokeDelegationRequest struct {
+	ServiceId           string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	ControllerServiceId string `protobuf:"bytes,2,opt,name=controllerServiceId" json:"controllerServiceId,omitempty"`
+	ConsumerServiceId   string `protobuf:"bytes,3,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
+}
+
+func (m *RevokeDelegationRequest) Reset()                    { *m = RevokeDelegationRequest{} }
+func (m *RevokeDelegationRequest) String() string            { return proto1.CompactTextString(m) }
+func (*RevokeDelegationRequest) ProtoMessage()               {}
+func (*RevokeDelegationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{173} }
+
+func (m *RevokeDelegationRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *RevokeDelegationRequest) GetControllerServiceId() string {
+	if m != nil {
+		return m.ControllerServiceId
+	}
+	return ""
+}
+
+func (m *RevokeDelegationRequest) GetConsumerServiceId() string {
+	if m != nil {
+		return m.ConsumerServiceId
+	}
+	return ""
+}
+
+type RevokeDelegationResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *RevokeDelegationResponse) Reset()                    { *m = RevokeDelegationResponse{} }
+func (m *RevokeDelegationResponse) String() string            { return proto1.CompactTextString(m) }
+func (*RevokeDelegationResponse) ProtoMessage()               {}
+func (*RevokeDelegationResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{174} }
+
+func (m *RevokeDelegationResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type GetDelegationsRequest struct {
+	ServiceId string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+}
+
+func (m *GetDelegationsRequest) Reset()                    { *m = GetDelegationsRequest{} }
+func (m *GetDelegationsRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetDelegationsRequest) ProtoMessage()               {}
+func (*GetDelegationsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{175} }
+
+func (m *GetDelegationsRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+type GetDelegationsResponse struct {
+	Response *Response          `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Grants   []*DelegationGrant `protobuf:"bytes,2,rep,name=grants" json:"grants,omitempty"`
+	Audits   []*DelegationAudit `protobuf:"bytes,3,rep,name=audits" json:"audits,omitempty"`
+}
+
+func (m *GetDelegationsResponse) Reset()                    { *m = GetDelegationsResponse{} }
+func (m *GetDelegationsResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetDelegationsResponse) ProtoMessage()               {}
+func (*GetDelegationsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{176} }
+
+func (m *GetDelegationsResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetDelegationsResponse) GetGrants() []*DelegationGrant {
+	if m != nil {
+		return m.Grants
+	}
+	return nil
+}
+
+func (m *GetDelegationsResponse) GetAudits() []*DelegationAudit {
+	if m != nil {
+		return m.Audits
+	}
+	return nil
+}
+
+type DelegateRegisterInstanceRequest struct {
+	ControllerServiceId string                `protobuf:"bytes,1,opt,name=controllerServiceId" json:"controllerServiceId,omitempty"`
+	Instance            *MicroServiceInstance `protobuf:"bytes,2,opt,name=instance" json:"instance,omitempty"`
+}
+
+func (m *DelegateRegisterInstanceRequest) Reset()         { *m = DelegateRegisterInstanceRequest{} }
+func (m *DelegateRegisterInstanceRequest) String() string { return proto1.CompactTextString(m) }
+func (*DelegateRegisterInstanceRequest) ProtoMessage()    {}
+func (*DelegateRegisterInstanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{177}
+}
+
+func (m *DelegateRegisterInstanceRequest) GetControllerServiceId() string {
+	if m != nil {
+		return m.ControllerServiceId
+	}
+	return ""
+}
+
+func (m *DelegateRegisterInstanceRequest) GetInstance() *MicroServiceInstance {
+	if m != nil {
+		return m.Instance
+	}
+	return nil
+}
+
+type DelegateUnregisterInstanceRequest struct {
+	ControllerServiceId string `protobuf:"bytes,1,opt,name=controllerServiceId" json:"controllerServiceId,omitempty"`
+	ServiceId           string `protobuf:"bytes,2,opt,name=serviceId" json:"serviceId,omitempty"`
+	InstanceId          string `protobuf:"bytes,3,opt,name=instanceId" json:"instanceId,omitempty"`
+}
+
+func (m *DelegateUnregisterInstanceRequest) Reset()         { *m = DelegateUnregisterInstanceRequest{} }
+func (m *DelegateUnregisterInstanceRequest) String() string { return proto1.CompactTextString(m) }
+func (*DelegateUnregisterInstanceRequest) ProtoMessage()    {}
+func (*DelegateUnregisterInstanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{178}
+}
+
+func (m *DelegateUnregisterInstanceRequest) GetControllerServiceId() string {
+	if m != nil {
+		return m.ControllerServiceId
+	}
+	return ""
+}
+
+func (m *DelegateUnregisterInstanceRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *DelegateUnregisterInstanceRequest) GetInstanceId() string {
+	if m != nil {
+		return m.InstanceId
+	}
+	return ""
+}
+
+// 提供者发给所有消费者的通知，如维护窗口、版本下线
+type BroadcastMessage struct {
+	Id          string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Type        string `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	Content     string `protobuf:"bytes,3,opt,name=content" json:"content,omitempty"`
+	EffectiveAt string `protobuf:"bytes,4,opt,name=effectiveAt" json:"effectiveAt,omitempty"`
+	Timestamp   string `protobuf:"bytes,5,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *BroadcastMessage) Reset()                    { *m = BroadcastMessage{} }
+func (m *BroadcastMessage) String() string            { return proto1.CompactTextString(m) }
+func (*BroadcastMessage) ProtoMessage()               {}
+func (*BroadcastMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{179} }
+
+func (m *BroadcastMessage) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *BroadcastMessage) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *BroadcastMessage) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *BroadcastMessage) GetEffectiveAt() string {
+	if m != nil {
+		return m.EffectiveAt
+	}
+	return ""
+}
+
+func (m *BroadcastMessage) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+type BroadcastRequest struct {
+	ServiceId string            `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Message   *BroadcastMessage `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *BroadcastRequest) Reset()                    { *m = BroadcastRequest{} }
+func (m *BroadcastRequest) String() string            { return proto1.CompactTextString(m) }
+func (*BroadcastRequest) ProtoMessage()               {}
+func (*BroadcastRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{180} }
+
+func (m *BroadcastRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *BroadcastRequest) GetMessage() *BroadcastMessage {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+type BroadcastResponse struct {
+	Response   *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Id         string    `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	Recipients int32     `protobuf:"varint,3,opt,name=recipients" json:"recipients,omitempty"`
+}
+
+func (m *BroadcastResponse) Reset()                    { *m = BroadcastResponse{} }
+func (m *BroadcastResponse) String() string            { return proto1.CompactTextString(m) }
+func (*BroadcastResponse) ProtoMessage()               {}
+func (*BroadcastResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{181} }
+
+func (m *BroadcastResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *BroadcastResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *BroadcastResponse) GetRecipients() int32 {
+	if m != nil {
+		return m.Recipients
+	}
+	return 0
+}
+
+type GetTopologyRequest struct {
+	AppId  string `protobuf:"bytes,1,opt,name=appId" json:"appId,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+	Limit  int64  `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *GetTopologyRequest) Reset()                    { *m = GetTopologyRequest{} }
+func (m *GetTopologyRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetTopologyRequest) ProtoMessage()               {}
+func (*GetTopologyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{182} }
+
+func (m *GetTopologyRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *GetTopologyRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *GetTopologyRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type TopologyNode struct {
+	ServiceId       string           `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Service         *MicroServiceKey `protobuf:"bytes,2,opt,name=service" json:"service,omitempty"`
+	InstanceCount   int64            `protobuf:"varint,3,opt,name=instanceCount" json:"instanceCount,omitempty"`
+	UpInstanceCount int64            `protobuf:"varint,4,opt,name=upInstanceCount" json:"upInstanceCount,omitempty"`
+	Health          float64          `protobuf:"fixed64,5,opt,name=health" json:"health,omitempty"`
+}
+
+func (m *TopologyNode) Reset()                    { *m = TopologyNode{} }
+func (m *TopologyNode) String() string            { return proto1.CompactTextString(m) }
+func (*TopologyNode) ProtoMessage()               {}
+func (*TopologyNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{183} }
+
+func (m *TopologyNode) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *TopologyNode) GetService() *MicroServiceKey {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+func (m *TopologyNode) GetInstanceCount() int64 {
+	if m != nil {
+		return m.InstanceCount
+	}
+	return 0
+}
+
+func (m *TopologyNode) GetUpInstanceCount() int64 {
+	if m != nil {
+		return m.UpInstanceCount
+	}
+	return 0
+}
+
+func (m *TopologyNode) GetHealth() float64 {
+	if m != nil {
+		return m.Health
+	}
+	return 0
+}
+
+type TopologyEdge struct {
+	ConsumerServiceId   string             `protobuf:"bytes,1,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
+	ProviderServiceId   string             `protobuf:"bytes,2,opt,name=providerServiceId" json:"providerServiceId,omitempty"`
+	Declared            bool               `protobuf:"varint,3,opt,name=declared" json:"declared,omitempty"`
+	LastAccessTimestamp string             `protobuf:"bytes,4,opt,name=lastAccessTimestamp" json:"lastAccessTimestamp,omitempty"`
+	Stale               bool               `protobuf:"varint,5,opt,name=stale" json:"stale,omitempty"`
+	Traffic             *DependencyTraffic `protobuf:"bytes,6,opt,name=traffic" json:"traffic,omitempty"`
+}
+
+func (m *TopologyEdge) Reset()                    { *m = TopologyEdge{} }
+func (m *TopologyEdge) String() string            { return proto1.CompactTextString(m) }
+func (*TopologyEdge) ProtoMessage()               {}
+func (*TopologyEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{184} }
+
+func (m *TopologyEdge) GetConsumerServiceId() string {
+	if m != nil {
+		return m.ConsumerServiceId
+	}
+	return ""
+}
+
+func (m *TopologyEdge) GetProviderServiceId() string {
+	if m != nil {
+		return m.ProviderServiceId
+	}
+	return ""
+}
+
+func (m *TopologyEdge) GetDeclared() bool {
+	if m != nil {
+		return m.Declared
+	}
+	return false
+}
+
+func (m *TopologyEdge) GetLastAccessTimestamp() string {
+	if m != nil {
+		return m.LastAccessTimestamp
+	}
+	return ""
+}
+
+func (m *TopologyEdge) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
+}
+
+func (m *TopologyEdge) GetTraffic() *DependencyTraffic {
+	if m != nil {
+		return m.Traffic
+	}
+	return nil
+}
+
+type GetTopologyResponse struct {
+	Response  *Response       `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Nodes     []*TopologyNode `protobuf:"bytes,2,rep,name=nodes" json:"nodes,omitempty"`
+	Edges     []*TopologyEdge `protobuf:"bytes,3,rep,name=edges" json:"edges,omitempty"`
+	Total     int64           `protobuf:"varint,4,opt,name=total" json:"total,omitempty"`
+	Revision  int64           `protobuf:"varint,5,opt,name=revision" json:"revision,omitempty"`
+	Timestamp string          `protobuf:"bytes,6,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *GetTopologyResponse) Reset()                    { *m = GetTopologyResponse{} }
+func (m *GetTopologyResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetTopologyResponse) ProtoMessage()               {}
+func (*GetTopologyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{185} }
+
+func (m *GetTopologyResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetTopologyResponse) GetNodes() []*TopologyNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *GetTopologyResponse) GetEdges() []*TopologyEdge {
+	if m != nil {
+		return m.Edges
+	}
+	return nil
+}
+
+func (m *GetTopologyResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *GetTopologyResponse) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *GetTopologyResponse) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+type InstanceState struct {
+	Values    map[string]string `protobuf:"bytes,1,rep,name=values" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Timestamp string            `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *InstanceState) Reset()                    { *m = InstanceState{} }
+func (m *InstanceState) String() string            { return proto1.CompactTextString(m) }
+func (*InstanceState) ProtoMessage()               {}
+func (*InstanceState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{186} }
+
+func (m *InstanceState) GetValues() map[string]string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *InstanceState) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+// 按依赖计算的影响范围，直接消费者计权重，间接消费者每多一层权重减半，
+// 权重由消费者properties中dependencyCriticality声明的依赖重要程度决定
+type GetBlastRadiusRequest struct {
+	AppId  string `protobuf:"bytes,1,opt,name=appId" json:"appId,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+	Limit  int64  `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *GetBlastRadiusRequest) Reset()                    { *m = GetBlastRadiusRequest{} }
+func (m *GetBlastRadiusRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetBlastRadiusRequest) ProtoMessage()               {}
+func (*GetBlastRadiusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{187} }
+
+func (m *GetBlastRadiusRequest) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *GetBlastRadiusRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *GetBlastRadiusRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type BlastRadiusItem struct {
+	ServiceId           string           `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Service             *MicroServiceKey `protobuf:"bytes,2,opt,name=service" json:"service,omitempty"`
+	DirectConsumers     int64            `protobuf:"varint,3,opt,name=directConsumers" json:"directConsumers,omitempty"`
+	TransitiveConsumers int64            `protobuf:"varint,4,opt,name=transitiveConsumers" json:"transitiveConsumers,omitempty"`
+	CriticalConsumers   int64            `protobuf:"varint,5,opt,name=criticalConsumers" json:"criticalConsumers,omitempty"`
+	Score               float64          `protobuf:"fixed64,6,opt,name=score" json:"score,omitempty"`
+}
+
+func (m *BlastRadiusItem) Reset()                    { *m = BlastRadiusItem{} }
+func (m *BlastRadiusItem) String() string            { return proto1.CompactTextString(m) }
+func (*BlastRadiusItem) ProtoMessage()               {}
+func (*BlastRadiusItem) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{188} }
+
+func (m *BlastRadiusItem) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *BlastRadiusItem) GetService() *MicroServiceKey {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+func (m *BlastRadiusItem) GetDirectConsumers() int64 {
+	if m != nil {
+		return m.DirectConsumers
+	}
+	return 0
+}
+
+func (m *BlastRadiusItem) GetTransitiveConsumers() int64 {
+	if m != nil {
+		return m.TransitiveConsumers
+	}
+	return 0
+}
+
+func (m *BlastRadiusItem) GetCriticalConsumers() int64 {
+	if m != nil {
+		return m.CriticalConsumers
+	}
+	return 0
+}
+
+func (m *BlastRadiusItem) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type GetBlastRadiusResponse struct {
+	Response  *Response          `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Items     []*BlastRadiusItem `protobuf:"bytes,2,rep,name=items" json:"items,omitempty"`
+	Total     int64              `protobuf:"varint,3,opt,name=total" json:"total,omitempty"`
+	Revision  int64              `protobuf:"varint,4,opt,name=revision" json:"revision,omitempty"`
+	Timestamp string             `protobuf:"bytes,5,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *GetBlastRadiusResponse) Reset()                    { *m = GetBlastRadiusResponse{} }
+func (m *GetBlastRadiusResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetBlastRadiusResponse) ProtoMessage()               {}
+func (*GetBlastRadiusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{189} }
+
+func (m *GetBlastRadiusResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetBlastRadiusResponse) GetItems() []*BlastRadiusItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *GetBlastRadiusResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *GetBlastRadiusResponse) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *GetBlastRadiusResponse) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+// 服务契约的合规检查结果，契约变化时重新计算
+type GetSchemaComplianceRequest struct {
+	ServiceId string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+}
+
+func (m *GetSchemaComplianceRequest) Reset()                    { *m = GetSchemaComplianceRequest{} }
+func (m *GetSchemaComplianceRequest) String() string            { return proto1.CompactTextString(m) }
+func (*GetSchemaComplianceRequest) ProtoMessage()               {}
+func (*GetSchemaComplianceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{190} }
+
+func (m *GetSchemaComplianceRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+type SchemaComplianceCheck struct {
+	Name       string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Passed     bool     `protobuf:"varint,2,opt,name=passed" json:"passed,omitempty"`
+	Violations []string `protobuf:"bytes,3,rep,name=violations" json:"violations,omitempty"`
+}
+
+func (m *SchemaComplianceCheck) Reset()                    { *m = SchemaComplianceCheck{} }
+func (m *SchemaComplianceCheck) String() string            { return proto1.CompactTextString(m) }
+func (*SchemaComplianceCheck) ProtoMessage()               {}
+func (*SchemaComplianceCheck) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{191} }
+
+func (m *SchemaComplianceCheck) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SchemaComplianceCheck) GetPassed() bool {
+	if m != nil {
+		return m.Passed
+	}
+	return false
+}
+
+func (m *SchemaComplianceCheck) GetViolations() []string {
+	if m != nil {
+		return m.Violations
+	}
+	return nil
+}
+
+type SchemaCompliance struct {
+	SchemaId string                   `protobuf:"bytes,1,opt,name=schemaId" json:"schemaId,omitempty"`
+	Passed   bool                     `protobuf:"varint,2,opt,name=passed" json:"passed,omitempty"`
+	Checks   []*SchemaComplianceCheck `protobuf:"bytes,3,rep,name=checks" json:"checks,omitempty"`
+}
+
+func (m *SchemaCompliance) Reset()                    { *m = SchemaCompliance{} }
+func (m *SchemaCompliance) String() string            { return proto1.CompactTextString(m) }
+func (*SchemaCompliance) ProtoMessage()               {}
+func (*SchemaCompliance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{192} }
+
+func (m *SchemaCompliance) GetSchemaId() string {
+	if m != nil {
+		return m.SchemaId
+	}
+	return ""
+}
+
+func (m *SchemaCompliance) GetPassed() bool {
+	if m != nil {
+		return m.Passed
+	}
+	return false
+}
+
+func (m *SchemaCompliance) GetChecks() []*SchemaComplianceCheck {
+	if m != nil {
+		return m.Checks
+	}
+	return nil
+}
+
+type SchemaComplianceReport struct {
+	ServiceId string              `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Badge     string              `protobuf:"bytes,2,opt,name=badge" json:"badge,omitempty"`
+	Score     float64             `protobuf:"fixed64,3,opt,name=score" json:"score,omitempty"`
+	Checks    []string            `protobuf:"bytes,4,rep,name=checks" json:"checks,omitempty"`
+	Schemas   []*SchemaCompliance `protobuf:"bytes,5,rep,name=schemas" json:"schemas,omitempty"`
+	Revision  int64               `protobuf:"varint,6,opt,name=revision" json:"revision,omitempty"`
+	Timestamp string              `protobuf:"bytes,7,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *SchemaComplianceReport) Reset()                    { *m = SchemaComplianceReport{} }
+func (m *SchemaComplianceReport) String() string            { return proto1.CompactTextString(m) }
+func (*SchemaComplianceReport) ProtoMessage()               {}
+func (*SchemaComplianceReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{193} }
+
+func (m *SchemaComplianceReport) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *SchemaComplianceReport) GetBadge() string {
+	if m != nil {
+		return m.Badge
+	}
+	return ""
+}
+
+func (m *SchemaComplianceReport) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SchemaComplianceReport) GetChecks() []string {
+	if m != nil {
+		return m.Checks
+	}
+	return nil
+}
+
+func (m *SchemaComplianceReport) GetSchemas() []*SchemaCompliance {
+	if m != nil {
+		return m.Schemas
+	}
+	return nil
+}
+
+func (m *SchemaComplianceReport) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *SchemaComplianceReport) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+type GetSchemaComplianceResponse struct {
+	Response *Response               `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Report   *SchemaComplianceReport `protobuf:"bytes,2,opt,name=report" json:"report,omitempty"`
+}
+
+func (m *GetSchemaComplianceResponse) Reset()                    { *m = GetSchemaComplianceResponse{} }
+func (m *GetSchemaComplianceResponse) String() string            { return proto1.CompactTextString(m) }
+func (*GetSchemaComplianceResponse) ProtoMessage()               {}
+func (*GetSchemaComplianceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{194} }
+
+func (m *GetSchemaComplianceResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetSchemaComplianceResponse) GetReport() *SchemaComplianceReport {
+	if m != nil {
+		return m.Report
+	}
+	return nil
+}
+
+type UnregisterInstancesRequest struct {
+	ServiceId        string            `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Status           string            `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	Properties       map[string]string `protobuf:"bytes,3,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Cidr             string            `protobuf:"bytes,4,opt,name=cidr" json:"cidr,omitempty"`
+	RegisteredBefore int64             `protobuf:"varint,5,opt,name=registeredBefore" json:"registeredBefore,omitempty"`
+	DryRun           bool              `protobuf:"varint,6,opt,name=dryRun" json:"dryRun,omitempty"`
+}
+
+func (m *UnregisterInstancesRequest) Reset()                    { *m = UnregisterInstancesRequest{} }
+func (m *UnregisterInstancesRequest) String() string            { return proto1.CompactTextString(m) }
+func (*UnregisterInstancesRequest) ProtoMessage()               {}
+func (*UnregisterInstancesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{195} }
+
+func (m *UnregisterInstancesRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *UnregisterInstancesRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *UnregisterInstancesRequest) GetProperties() map[string]string {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *UnregisterInstancesRequest) GetCidr() string {
+	if m != nil {
+		return m.Cidr
+	}
+	return ""
+}
+
+func (m *UnregisterInstancesRequest) GetRegisteredBefore() int64 {
+	if m != nil {
+		return m.RegisteredBefore
+	}
+	return 0
+}
+
+func (m *UnregisterInstancesRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type UnregisterInstancesResponse struct {
+	Response    *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	InstanceIds []string  `protobuf:"bytes,2,rep,name=instanceIds" json:"instanceIds,omitempty"`
+}
+
+func (m *UnregisterInstancesResponse) Reset()                    { *m = UnregisterInstancesResponse{} }
+func (m *UnregisterInstancesResponse) String() string            { return proto1.CompactTextString(m) }
+func (*UnregisterInstancesResponse) ProtoMessage()               {}
+func (*UnregisterInstancesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{196} }
+
+func (m *UnregisterInstancesResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *UnregisterInstancesResponse) GetInstanceIds() []string {
+	if m != nil {
+		return m.InstanceIds
+	}
+	return nil
+}
+
+type DependencyTraffic struct {
+	ConsumerServiceId string  `protobuf:"bytes,1,opt,name=consumerServiceId" json:"consumerServiceId,omitempty"`
+	ProviderServiceId string  `protobuf:"bytes,2,opt,name=providerServiceId" json:"providerServiceId,omitempty"`
+	CallRate          float64 `protobuf:"fixed64,3,opt,name=callRate" json:"callRate,omitempty"`
+	ErrorRate         float64 `protobuf:"fixed64,4,opt,name=errorRate" json:"errorRate,omitempty"`
+	LatencyP99        float64 `protobuf:"fixed64,5,opt,name=latencyP99" json:"latencyP99,omitempty"`
+	Source            string  `protobuf:"bytes,6,opt,name=source" json:"source,omitempty"`
+	Timestamp         string  `protobuf:"bytes,7,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *DependencyTraffic) Reset()                    { *m = DependencyTraffic{} }
+func (m *DependencyTraffic) String() string            { return proto1.CompactTextString(m) }
+func (*DependencyTraffic) ProtoMessage()               {}
+func (*DependencyTraffic) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{197} }
+
+func (m *DependencyTraffic) GetConsumerServiceId() string {
+	if m != nil {
+		return m.ConsumerServiceId
+	}
+	return ""
+}
+
+func (m *DependencyTraffic) GetProviderServiceId() string {
+	if m != nil {
+		return m.ProviderServiceId
+	}
+	return ""
+}
+
+func (m *DependencyTraffic) GetCallRate() float64 {
+	if m != nil {
+		return m.CallRate
+	}
+	return 0
+}
+
+func (m *DependencyTraffic) GetErrorRate() float64 {
+	if m != nil {
+		return m.ErrorRate
+	}
+	return 0
+}
+
+func (m *DependencyTraffic) GetLatencyP99() float64 {
+	if m != nil {
+		return m.LatencyP99
+	}
+	return 0
+}
+
+func (m *DependencyTraffic) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *DependencyTraffic) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+type ReportDependencyTrafficRequest struct {
+	Traffics []*DependencyTraffic `protobuf:"bytes,1,rep,name=traffics" json:"traffics,omitempty"`
+	Ttl      int64                `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+}
+
+func (m *ReportDependencyTrafficRequest) Reset()         { *m = ReportDependencyTrafficRequest{} }
+func (m *ReportDependencyTrafficRequest) String() string { return proto1.CompactTextString(m) }
+func (*ReportDependencyTrafficRequest) ProtoMessage()    {}
+func (*ReportDependencyTrafficRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{198}
+}
+
+func (m *ReportDependencyTrafficRequest) GetTraffics() []*DependencyTraffic {
+	if m != nil {
+		return m.Traffics
+	}
+	return nil
+}
+
+func (m *ReportDependencyTrafficRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+type ReportDependencyTrafficResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Accepted int32     `protobuf:"varint,2,opt,name=accepted" json:"accepted,omitempty"`
+}
+
+func (m *ReportDependencyTrafficResponse) Reset()         { *m = ReportDependencyTrafficResponse{} }
+func (m *ReportDependencyTrafficResponse) String() string { return proto1.CompactTextString(m) }
+func (*ReportDependencyTrafficResponse) ProtoMessage()    {}
+func (*ReportDependencyTrafficResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{199}
+}
+
+func (m *ReportDependencyTrafficResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ReportDependencyTrafficResponse) GetAccepted() int32 {
+	if m != nil {
+		return m.Accepted
+	}
+	return 0
+}
+
+type DependencyLintIssue struct {
+	Index   int32  `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	Field   string `protobuf:"bytes,2,opt,name=field" json:"field,omitempty"`
+	Level   string `protobuf:"bytes,3,opt,name=level" json:"level,omitempty"`
+	Code    string `protobuf:"bytes,4,opt,name=code" json:"code,omitempty"`
+	Message string `protobuf:"bytes,5,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *DependencyLintIssue) Reset()         { *m = DependencyLintIssue{} }
+func (m *DependencyLintIssue) String() string { return proto1.CompactTextString(m) }
+func (*DependencyLintIssue) ProtoMessage()    {}
+func (*DependencyLintIssue) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{200}
+}
+
+func (m *DependencyLintIssue) GetIndex() int32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *DependencyLintIssue) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *DependencyLintIssue) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *DependencyLintIssue) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *DependencyLintIssue) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type LintDependenciesRequest struct {
+	Dependencies []*ConsumerDependency `protobuf:"bytes,1,rep,name=dependencies" json:"dependencies,omitempty"`
+}
+
+func (m *LintDependenciesRequest) Reset()         { *m = LintDependenciesRequest{} }
+func (m *LintDependenciesRequest) String() string { return proto1.CompactTextString(m) }
+func (*LintDependenciesRequest) ProtoMessage()    {}
+func (*LintDependenciesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{201}
+}
+
+func (m *LintDependenciesRequest) GetDependencies() []*ConsumerDependency {
+	if m != nil {
+		return m.Dependencies
+	}
+	return nil
+}
+
+type LintDependenciesResponse struct {
+	Response *Response              `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Issues   []*DependencyLintIssue `protobuf:"bytes,2,rep,name=issues" json:"issues,omitempty"`
+	Errors   int32                  `protobuf:"varint,3,opt,name=errors" json:"errors,omitempty"`
+	Warnings int32                  `protobuf:"varint,4,opt,name=warnings" json:"warnings,omitempty"`
+}
+
+func (m *LintDependenciesResponse) Reset()         { *m = LintDependenciesResponse{} }
+func (m *LintDependenciesResponse) String() string { return proto1.CompactTextString(m) }
+func (*LintDependenciesResponse) ProtoMessage()    {}
+func (*LintDependenciesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{202}
+}
+
+func (m *LintDependenciesResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *LintDependenciesResponse) GetIssues() []*DependencyLintIssue {
+	if m != nil {
+		return m.Issues
+	}
+	return nil
+}
+
+func (m *LintDependenciesResponse) GetErrors() int32 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+func (m *LintDependenciesResponse) GetWarnings() int32 {
+	if m != nil {
+		return m.Warnings
+	}
+	return 0
+}
+
+type InstanceCountSample struct {
+	Timestamp string `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	Instances int32  `protobuf:"varint,2,opt,name=instances" json:"instances,omitempty"`
+}
+
+func (m *InstanceCountSample) Reset()         { *m = InstanceCountSample{} }
+func (m *InstanceCountSample) String() string { return proto1.CompactTextString(m) }
+func (*InstanceCountSample) ProtoMessage()    {}
+func (*InstanceCountSample) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{203}
+}
+
+func (m *InstanceCountSample) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+func (m *InstanceCountSample) GetInstances() int32 {
+	if m != nil {
+		return m.Instances
+	}
+	return 0
+}
+
+type GetInstanceHistoryRequest struct {
+	ServiceId string `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Window    string `protobuf:"bytes,2,opt,name=window" json:"window,omitempty"`
+}
+
+func (m *GetInstanceHistoryRequest) Reset()         { *m = GetInstanceHistoryRequest{} }
+func (m *GetInstanceHistoryRequest) String() string { return proto1.CompactTextString(m) }
+func (*GetInstanceHistoryRequest) ProtoMessage()    {}
+func (*GetInstanceHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{204}
+}
+
+func (m *GetInstanceHistoryRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *GetInstanceHistoryRequest) GetWindow() string {
+	if m != nil {
+		return m.Window
+	}
+	return ""
+}
+
+type GetInstanceHistoryResponse struct {
+	Response *Response              `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Interval int64                  `protobuf:"varint,2,opt,name=interval" json:"interval,omitempty"`
+	Samples  []*InstanceCountSample `protobuf:"bytes,3,rep,name=samples" json:"samples,omitempty"`
+}
+
+func (m *GetInstanceHistoryResponse) Reset()         { *m = GetInstanceHistoryResponse{} }
+func (m *GetInstanceHistoryResponse) String() string { return proto1.CompactTextString(m) }
+func (*GetInstanceHistoryResponse) ProtoMessage()    {}
+func (*GetInstanceHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{205}
+}
+
+func (m *GetInstanceHistoryResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetInstanceHistoryResponse) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *GetInstanceHistoryResponse) GetSamples() []*InstanceCountSample {
+	if m != nil {
+		return m.Samples
+	}
+	return nil
+}
+
+type SchemaFreeze struct {
+	Reason    string `protobuf:"bytes,1,opt,name=reason" json:"reason,omitempty"`
+	Operator  string `protobuf:"bytes,2,opt,name=operator" json:"operator,omitempty"`
+	Timestamp string `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *SchemaFreeze) Reset()         { *m = SchemaFreeze{} }
+func (m *SchemaFreeze) String() string { return proto1.CompactTextString(m) }
+func (*SchemaFreeze) ProtoMessage()    {}
+func (*SchemaFreeze) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{206}
+}
+
+func (m *SchemaFreeze) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *SchemaFreeze) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *SchemaFreeze) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+type UpdateSchemaFreezeRequest struct {
+	ServiceId string        `protobuf:"bytes,1,opt,name=serviceId" json:"serviceId,omitempty"`
+	Freeze    *SchemaFreeze `protobuf:"bytes,2,opt,name=freeze" json:"freeze,omitempty"`
+}
+
+func (m *UpdateSchemaFreezeRequest) Reset()         { *m = UpdateSchemaFreezeRequest{} }
+func (m *UpdateSchemaFreezeRequest) String() string { return proto1.CompactTextString(m) }
+func (*UpdateSchemaFreezeRequest) ProtoMessage()    {}
+func (*UpdateSchemaFreezeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{207}
+}
+
+func (m *UpdateSchemaFreezeRequest) GetServiceId() string {
+	if m != nil {
+		return m.ServiceId
+	}
+	return ""
+}
+
+func (m *UpdateSchemaFreezeRequest) GetFreeze() *SchemaFreeze {
+	if m != nil {
+		return m.Freeze
+	}
+	return nil
+}
+
+type UpdateSchemaFreezeResponse struct {
+	Response *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+}
+
+func (m *UpdateSchemaFreezeResponse) Reset()         { *m = UpdateSchemaFreezeResponse{} }
+func (m *UpdateSchemaFreezeResponse) String() string { return proto1.CompactTextString(m) }
+func (*UpdateSchemaFreezeResponse) ProtoMessage()    {}
+func (*UpdateSchemaFreezeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{208}
+}
+
+func (m *UpdateSchemaFreezeResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type InstanceEndpoint struct {
+	Protocol string            `protobuf:"bytes,1,opt,name=protocol" json:"protocol,omitempty"`
+	Address  string            `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
+	Params   map[string]string `protobuf:"bytes,3,rep,name=params" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *InstanceEndpoint) Reset()                    { *m = InstanceEndpoint{} }
+func (m *InstanceEndpoint) String() string            { return proto1.CompactTextString(m) }
+func (*InstanceEndpoint) ProtoMessage()               {}
+func (*InstanceEndpoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{209} }
+
+func (m *InstanceEndpoint) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+func (m *InstanceEndpoint) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *InstanceEndpoint) GetParams() map[string]string {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func init() {
+	proto1.RegisterType((*ModifySchemasRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.ModifySchemasRequest")
+	proto1.RegisterType((*Schema)(nil), "com.huawei.paas.cse.serviceregistry.api.Schema")
+	proto1.RegisterType((*ModifySchemasResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.ModifySchemasResponse")
+	proto1.RegisterType((*HeartbeatSetRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.HeartbeatSetRequest")
+	proto1.RegisterType((*HeartbeatSetElement)(nil), "com.huawei.paas.cse.serviceregistry.api.HeartbeatSetElement")
+	proto1.RegisterType((*HeartbeatSetResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.HeartbeatSetResponse")
+	proto1.RegisterType((*InstanceHbRst)(nil), "com.huawei.paas.cse.serviceregistry.api.InstanceHbRst")
+	proto1.RegisterType((*KeepAliveRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.KeepAliveRequest")
+	proto1.RegisterType((*KeepAliveResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.KeepAliveResponse")
+	proto1.RegisterType((*StService)(nil), "com.huawei.paas.cse.serviceregistry.api.StService")
+	proto1.RegisterType((*StInstance)(nil), "com.huawei.paas.cse.serviceregistry.api.StInstance")
+	proto1.RegisterType((*StApp)(nil), "com.huawei.paas.cse.serviceregistry.api.StApp")
+	proto1.RegisterType((*Statistics)(nil), "com.huawei.paas.cse.serviceregistry.api.Statistics")
+	proto1.RegisterType((*GetServicesInfoRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServicesInfoRequest")
+	proto1.RegisterType((*GetServicesInfoResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServicesInfoResponse")
+	proto1.RegisterType((*MicroServiceKey)(nil), "com.huawei.paas.cse.serviceregistry.api.MicroServiceKey")
+	proto1.RegisterType((*MicroService)(nil), "com.huawei.paas.cse.serviceregistry.api.MicroService")
+	proto1.RegisterType((*FrameWorkProperty)(nil), "com.huawei.paas.cse.serviceregistry.api.FrameWorkProperty")
+	proto1.RegisterType((*ServiceCatalog)(nil), "com.huawei.paas.cse.serviceregistry.api.ServiceCatalog")
+	proto1.RegisterType((*ServiceRetirement)(nil), "com.huawei.paas.cse.serviceregistry.api.ServiceRetirement")
+	proto1.RegisterType((*ServiceRule)(nil), "com.huawei.paas.cse.serviceregistry.api.ServiceRule")
+	proto1.RegisterType((*AddOrUpdateServiceRule)(nil), "com.huawei.paas.cse.serviceregistry.api.AddOrUpdateServiceRule")
+	proto1.RegisterType((*ServicePath)(nil), "com.huawei.paas.cse.serviceregistry.api.ServicePath")
+	proto1.RegisterType((*Response)(nil), "com.huawei.paas.cse.serviceregistry.api.Response")
+	proto1.RegisterType((*ErrorDetail)(nil), "com.huawei.paas.cse.serviceregistry.api.ErrorDetail")
+	proto1.RegisterType((*GetExistenceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetExistenceRequest")
+	proto1.RegisterType((*GetExistenceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetExistenceResponse")
+	proto1.RegisterType((*BatchGetExistenceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.BatchGetExistenceRequest")
+	proto1.RegisterType((*BatchGetExistenceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.BatchGetExistenceResponse")
+	proto1.RegisterType((*ServiceExistence)(nil), "com.huawei.paas.cse.serviceregistry.api.ServiceExistence")
+	proto1.RegisterType((*CreateServiceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.CreateServiceRequest")
+	proto1.RegisterType((*CreateServiceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.CreateServiceResponse")
+	proto1.RegisterType((*DeleteServiceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteServiceRequest")
+	proto1.RegisterType((*DeleteServiceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteServiceResponse")
+	proto1.RegisterType((*GetServiceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceRequest")
+	proto1.RegisterType((*GetServiceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceResponse")
+	proto1.RegisterType((*GetServicesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServicesRequest")
+	proto1.RegisterType((*GetServicesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServicesResponse")
+	proto1.RegisterType((*UpdateServicePropsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServicePropsRequest")
+	proto1.RegisterType((*UpdateServicePropsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServicePropsResponse")
+	proto1.RegisterType((*UpdateServiceCatalogRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServiceCatalogRequest")
+	proto1.RegisterType((*UpdateServiceCatalogResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServiceCatalogResponse")
+	proto1.RegisterType((*UpdateServiceRetirementRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServiceRetirementRequest")
+	proto1.RegisterType((*UpdateServiceRetirementResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServiceRetirementResponse")
+	proto1.RegisterType((*GetServiceRulesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceRulesRequest")
+	proto1.RegisterType((*GetServiceRulesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceRulesResponse")
+	proto1.RegisterType((*UpdateServiceRuleRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServiceRuleRequest")
+	proto1.RegisterType((*UpdateServiceRuleResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServiceRuleResponse")
+	proto1.RegisterType((*AddServiceRulesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.AddServiceRulesRequest")
+	proto1.RegisterType((*AddServiceRulesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.AddServiceRulesResponse")
+	proto1.RegisterType((*DeleteServiceRulesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteServiceRulesRequest")
+	proto1.RegisterType((*DeleteServiceRulesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteServiceRulesResponse")
+	proto1.RegisterType((*ReplaceServiceRulesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.ReplaceServiceRulesRequest")
+	proto1.RegisterType((*ReplaceServiceRulesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.ReplaceServiceRulesResponse")
+	proto1.RegisterType((*GetServiceTagsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceTagsRequest")
+	proto1.RegisterType((*GetServiceTagsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceTagsResponse")
+	proto1.RegisterType((*UpdateServiceTagRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServiceTagRequest")
+	proto1.RegisterType((*UpdateServiceTagResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateServiceTagResponse")
+	proto1.RegisterType((*AddServiceTagsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.AddServiceTagsRequest")
+	proto1.RegisterType((*AddServiceTagsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.AddServiceTagsResponse")
+	proto1.RegisterType((*DeleteServiceTagsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteServiceTagsRequest")
+	proto1.RegisterType((*DeleteServiceTagsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteServiceTagsResponse")
+	proto1.RegisterType((*DiscoveryPolicy)(nil), "com.huawei.paas.cse.serviceregistry.api.DiscoveryPolicy")
+	proto1.RegisterType((*GetDiscoveryPolicyRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetDiscoveryPolicyRequest")
+	proto1.RegisterType((*GetDiscoveryPolicyResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetDiscoveryPolicyResponse")
+	proto1.RegisterType((*UpdateDiscoveryPolicyRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateDiscoveryPolicyRequest")
+	proto1.RegisterType((*UpdateDiscoveryPolicyResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateDiscoveryPolicyResponse")
+	proto1.RegisterType((*DeleteDiscoveryPolicyRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteDiscoveryPolicyRequest")
+	proto1.RegisterType((*DeleteDiscoveryPolicyResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteDiscoveryPolicyResponse")
+	proto1.RegisterType((*HealthCheck)(nil), "com.huawei.paas.cse.serviceregistry.api.HealthCheck")
+	proto1.RegisterType((*MicroServiceInstance)(nil), "com.huawei.paas.cse.serviceregistry.api.MicroServiceInstance")
+	proto1.RegisterType((*Platform)(nil), "com.huawei.paas.cse.serviceregistry.api.Platform")
+	proto1.RegisterType((*StatusReason)(nil), "com.huawei.paas.cse.serviceregistry.api.StatusReason")
+	proto1.RegisterType((*DataCenterInfo)(nil), "com.huawei.paas.cse.serviceregistry.api.DataCenterInfo")
+	proto1.RegisterType((*MicroServiceInstanceKey)(nil), "com.huawei.paas.cse.serviceregistry.api.MicroServiceInstanceKey")
+	proto1.RegisterType((*RegisterInstanceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.RegisterInstanceRequest")
+	proto1.RegisterType((*RegisterInstanceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.RegisterInstanceResponse")
+	proto1.RegisterType((*UnregisterInstanceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UnregisterInstanceRequest")
+	proto1.RegisterType((*UnregisterInstanceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UnregisterInstanceResponse")
+	proto1.RegisterType((*HeartbeatRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.HeartbeatRequest")
+	proto1.RegisterType((*HeartbeatResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.HeartbeatResponse")
+	proto1.RegisterType((*FindInstancesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.FindInstancesRequest")
+	proto1.RegisterType((*FindInstancesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.FindInstancesResponse")
+	proto1.RegisterType((*RetiringVersion)(nil), "com.huawei.paas.cse.serviceregistry.api.RetiringVersion")
+	proto1.RegisterType((*GovernanceConfig)(nil), "com.huawei.paas.cse.serviceregistry.api.GovernanceConfig")
+	proto1.RegisterType((*GetOneInstanceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetOneInstanceRequest")
+	proto1.RegisterType((*GetOneInstanceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetOneInstanceResponse")
+	proto1.RegisterType((*GetInstancesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetInstancesRequest")
+	proto1.RegisterType((*GetInstancesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetInstancesResponse")
+	proto1.RegisterType((*UpdateInstanceStatusRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateInstanceStatusRequest")
+	proto1.RegisterType((*UpdateInstanceStatusResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateInstanceStatusResponse")
+	proto1.RegisterType((*PromoteInstancesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.PromoteInstancesRequest")
+	proto1.RegisterType((*PromoteInstancesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.PromoteInstancesResponse")
+	proto1.RegisterType((*UpdateInstanceCapacityRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateInstanceCapacityRequest")
+	proto1.RegisterType((*UpdateInstanceCapacityResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateInstanceCapacityResponse")
+	proto1.RegisterType((*UpdateInstancePropsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateInstancePropsRequest")
+	proto1.RegisterType((*UpdateInstancePropsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateInstancePropsResponse")
+	proto1.RegisterType((*WatchInstanceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.WatchInstanceRequest")
+	proto1.RegisterType((*WatchInstanceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.WatchInstanceResponse")
+	proto1.RegisterType((*WatchEventEnvelope)(nil), "com.huawei.paas.cse.serviceregistry.api.WatchEventEnvelope")
+	proto1.RegisterType((*GetSchemaRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetSchemaRequest")
+	proto1.RegisterType((*GetAllSchemaRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetAllSchemaRequest")
+	proto1.RegisterType((*GetSchemaResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetSchemaResponse")
+	proto1.RegisterType((*GetAllSchemaResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetAllSchemaResponse")
+	proto1.RegisterType((*DeleteSchemaRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteSchemaRequest")
+	proto1.RegisterType((*DeleteSchemaResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteSchemaResponse")
+	proto1.RegisterType((*ModifySchemaRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.ModifySchemaRequest")
+	proto1.RegisterType((*ModifySchemaResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.ModifySchemaResponse")
+	proto1.RegisterType((*SharedDefinition)(nil), "com.huawei.paas.cse.serviceregistry.api.SharedDefinition")
+	proto1.RegisterType((*ModifySharedDefinitionRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.ModifySharedDefinitionRequest")
+	proto1.RegisterType((*ModifySharedDefinitionResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.ModifySharedDefinitionResponse")
+	proto1.RegisterType((*GetSharedDefinitionsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetSharedDefinitionsRequest")
+	proto1.RegisterType((*GetSharedDefinitionsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetSharedDefinitionsResponse")
+	proto1.RegisterType((*DeleteSharedDefinitionRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteSharedDefinitionRequest")
+	proto1.RegisterType((*DeleteSharedDefinitionResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteSharedDefinitionResponse")
+	proto1.RegisterType((*AddDependenciesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.AddDependenciesRequest")
+	proto1.RegisterType((*AddDependenciesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.AddDependenciesResponse")
+	proto1.RegisterType((*CreateDependenciesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.CreateDependenciesRequest")
+	proto1.RegisterType((*DependencyKey)(nil), "com.huawei.paas.cse.serviceregistry.api.DependencyKey")
+	proto1.RegisterType((*ConsumerDependency)(nil), "com.huawei.paas.cse.serviceregistry.api.ConsumerDependency")
+	proto1.RegisterType((*CreateDependenciesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.CreateDependenciesResponse")
+	proto1.RegisterType((*GetDependenciesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetDependenciesRequest")
+	proto1.RegisterType((*GetConDependenciesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetConDependenciesResponse")
+	proto1.RegisterType((*GetProDependenciesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetProDependenciesResponse")
+	proto1.RegisterType((*DependencyApproval)(nil), "com.huawei.paas.cse.serviceregistry.api.DependencyApproval")
+	proto1.RegisterType((*ApproveDependencyRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.ApproveDependencyRequest")
+	proto1.RegisterType((*ApproveDependencyResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.ApproveDependencyResponse")
+	proto1.RegisterType((*RevokeDependencyApprovalRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.RevokeDependencyApprovalRequest")
+	proto1.RegisterType((*RevokeDependencyApprovalResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.RevokeDependencyApprovalResponse")
+	proto1.RegisterType((*GetDependencyApprovalsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetDependencyApprovalsRequest")
+	proto1.RegisterType((*GetDependencyApprovalsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetDependencyApprovalsResponse")
+	proto1.RegisterType((*DependencyUsage)(nil), "com.huawei.paas.cse.serviceregistry.api.DependencyUsage")
+	proto1.RegisterType((*ProviderAccessor)(nil), "com.huawei.paas.cse.serviceregistry.api.ProviderAccessor")
+	proto1.RegisterType((*GetProviderAccessorsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetProviderAccessorsRequest")
+	proto1.RegisterType((*GetProviderAccessorsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetProviderAccessorsResponse")
+	proto1.RegisterType((*GetDependencyDiffResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetDependencyDiffResponse")
+	proto1.RegisterType((*GetDependencyHistoryRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetDependencyHistoryRequest")
+	proto1.RegisterType((*GetDependencyHistoryResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetDependencyHistoryResponse")
+	proto1.RegisterType((*ServiceDetail)(nil), "com.huawei.paas.cse.serviceregistry.api.ServiceDetail")
+	proto1.RegisterType((*GetServiceDetailResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetServiceDetailResponse")
+	proto1.RegisterType((*DelServicesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DelServicesRequest")
+	proto1.RegisterType((*DelServicesRspInfo)(nil), "com.huawei.paas.cse.serviceregistry.api.DelServicesRspInfo")
+	proto1.RegisterType((*DelServicesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DelServicesResponse")
+	proto1.RegisterType((*GetAppsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetAppsRequest")
+	proto1.RegisterType((*GetAppsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetAppsResponse")
+	proto1.RegisterType((*SearchInstancesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.SearchInstancesRequest")
+	proto1.RegisterType((*SearchInstancesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.SearchInstancesResponse")
+	proto1.RegisterType((*ApplyServiceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.ApplyServiceRequest")
+	proto1.RegisterType((*ApplyChange)(nil), "com.huawei.paas.cse.serviceregistry.api.ApplyChange")
+	proto1.RegisterType((*ApplyServiceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.ApplyServiceResponse")
+	proto1.RegisterType((*Snapshot)(nil), "com.huawei.paas.cse.serviceregistry.api.Snapshot")
+	proto1.RegisterType((*CreateSnapshotRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.CreateSnapshotRequest")
+	proto1.RegisterType((*CreateSnapshotResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.CreateSnapshotResponse")
+	proto1.RegisterType((*GetSnapshotRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetSnapshotRequest")
+	proto1.RegisterType((*GetSnapshotResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetSnapshotResponse")
+	proto1.RegisterType((*DeleteSnapshotRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteSnapshotRequest")
+	proto1.RegisterType((*DeleteSnapshotResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteSnapshotResponse")
+	proto1.RegisterType((*ApiKey)(nil), "com.huawei.paas.cse.serviceregistry.api.ApiKey")
+	proto1.RegisterType((*CreateApiKeyRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.CreateApiKeyRequest")
+	proto1.RegisterType((*CreateApiKeyResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.CreateApiKeyResponse")
+	proto1.RegisterType((*GetApiKeysRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetApiKeysRequest")
+	proto1.RegisterType((*GetApiKeysResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetApiKeysResponse")
+	proto1.RegisterType((*RotateApiKeyRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.RotateApiKeyRequest")
+	proto1.RegisterType((*RotateApiKeyResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.RotateApiKeyResponse")
+	proto1.RegisterType((*DeleteApiKeyRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteApiKeyRequest")
+	proto1.RegisterType((*DeleteApiKeyResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.DeleteApiKeyResponse")
+	proto1.RegisterType((*GetStartupOrderRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetStartupOrderRequest")
+	proto1.RegisterType((*StartupOrderGroup)(nil), "com.huawei.paas.cse.serviceregistry.api.StartupOrderGroup")
+	proto1.RegisterType((*GetStartupOrderResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetStartupOrderResponse")
+	proto1.RegisterType((*DelegationGrant)(nil), "com.huawei.paas.cse.serviceregistry.api.DelegationGrant")
+	proto1.RegisterType((*DelegationAudit)(nil), "com.huawei.paas.cse.serviceregistry.api.DelegationAudit")
+	proto1.RegisterType((*GrantDelegationRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GrantDelegationRequest")
+	proto1.RegisterType((*GrantDelegationResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GrantDelegationResponse")
+	proto1.RegisterType((*RevokeDelegationRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.RevokeDelegationRequest")
+	proto1.RegisterType((*RevokeDelegationResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.RevokeDelegationResponse")
+	proto1.RegisterType((*GetDelegationsRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetDelegationsRequest")
+	proto1.RegisterType((*GetDelegationsResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetDelegationsResponse")
+	proto1.RegisterType((*DelegateRegisterInstanceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DelegateRegisterInstanceRequest")
+	proto1.RegisterType((*DelegateUnregisterInstanceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.DelegateUnregisterInstanceRequest")
+	proto1.RegisterType((*BroadcastMessage)(nil), "com.huawei.paas.cse.serviceregistry.api.BroadcastMessage")
+	proto1.RegisterType((*BroadcastRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.BroadcastRequest")
+	proto1.RegisterType((*BroadcastResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.BroadcastResponse")
+	proto1.RegisterType((*GetTopologyRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetTopologyRequest")
+	proto1.RegisterType((*TopologyNode)(nil), "com.huawei.paas.cse.serviceregistry.api.TopologyNode")
+	proto1.RegisterType((*TopologyEdge)(nil), "com.huawei.paas.cse.serviceregistry.api.TopologyEdge")
+	proto1.RegisterType((*GetTopologyResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetTopologyResponse")
+	proto1.RegisterType((*InstanceState)(nil), "com.huawei.paas.cse.serviceregistry.api.InstanceState")
+	proto1.RegisterType((*GetBlastRadiusRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetBlastRadiusRequest")
+	proto1.RegisterType((*BlastRadiusItem)(nil), "com.huawei.paas.cse.serviceregistry.api.BlastRadiusItem")
+	proto1.RegisterType((*GetBlastRadiusResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetBlastRadiusResponse")
+	proto1.RegisterType((*GetSchemaComplianceRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetSchemaComplianceRequest")
+	proto1.RegisterType((*SchemaComplianceCheck)(nil), "com.huawei.paas.cse.serviceregistry.api.SchemaComplianceCheck")
+	proto1.RegisterType((*SchemaCompliance)(nil), "com.huawei.paas.cse.serviceregistry.api.SchemaCompliance")
+	proto1.RegisterType((*SchemaComplianceReport)(nil), "com.huawei.paas.cse.serviceregistry.api.SchemaComplianceReport")
+	proto1.RegisterType((*GetSchemaComplianceResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetSchemaComplianceResponse")
+	proto1.RegisterType((*UnregisterInstancesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UnregisterInstancesRequest")
+	proto1.RegisterType((*UnregisterInstancesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UnregisterInstancesResponse")
+	proto1.RegisterType((*DependencyTraffic)(nil), "com.huawei.paas.cse.serviceregistry.api.DependencyTraffic")
+	proto1.RegisterType((*ReportDependencyTrafficRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.ReportDependencyTrafficRequest")
+	proto1.RegisterType((*ReportDependencyTrafficResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.ReportDependencyTrafficResponse")
+	proto1.RegisterType((*DependencyLintIssue)(nil), "com.huawei.paas.cse.serviceregistry.api.DependencyLintIssue")
+	proto1.RegisterType((*LintDependenciesRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.LintDependenciesRequest")
+	proto1.RegisterType((*LintDependenciesResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.LintDependenciesResponse")
+	proto1.RegisterType((*InstanceCountSample)(nil), "com.huawei.paas.cse.serviceregistry.api.InstanceCountSample")
+	proto1.RegisterType((*GetInstanceHistoryRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.GetInstanceHistoryRequest")
+	proto1.RegisterType((*GetInstanceHistoryResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.GetInstanceHistoryResponse")
+	proto1.RegisterType((*SchemaFreeze)(nil), "com.huawei.paas.cse.serviceregistry.api.SchemaFreeze")
+	proto1.RegisterType((*UpdateSchemaFreezeRequest)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateSchemaFreezeRequest")
+	proto1.RegisterType((*UpdateSchemaFreezeResponse)(nil), "com.huawei.paas.cse.serviceregistry.api.UpdateSchemaFreezeResponse")
+	proto1.RegisterType((*InstanceEndpoint)(nil), "com.huawei.paas.cse.serviceregistry.api.InstanceEndpoint")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for ServiceCtrl service
+
+type ServiceCtrlClient interface {
+	Exist(ctx context.Context, in *GetExistenceRequest, opts ...grpc.CallOption) (*GetExistenceResponse, error)
+	BatchExist(ctx context.Context, in *BatchGetExistenceRequest, opts ...grpc.CallOption) (*BatchGetExistenceResponse, error)
+	Create(ctx context.Context, in *CreateServiceRequest, opts ...grpc.CallOption) (*CreateServiceResponse, error)
+	Delete(ctx context.Context, in *DeleteServiceRequest, opts ...grpc.CallOption) (*DeleteServiceResponse, error)
+	GetOne(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceResponse, error)
+	GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error)
+	UpdateProperties(ctx context.Context, in *UpdateServicePropsRequest, opts ...grpc.CallOption) (*UpdateServicePropsResponse, error)
+	UpdateCatalog(ctx context.Context, in *UpdateServiceCatalogRequest, opts ...grpc.CallOption) (*UpdateServiceCatalogResponse, error)
+	UpdateRetirement(ctx context.Context, in *UpdateServiceRetirementRequest, opts ...grpc.CallOption) (*UpdateServiceRetirementResponse, error)
+	UpdateSchemaFreeze(ctx context.Context, in *UpdateSchemaFreezeRequest, opts ...grpc.CallOption) (*UpdateSchemaFreezeResponse, error)
+	AddRule(ctx context.Context, in *AddServiceRulesRequest, opts ...grpc.CallOption) (*AddServiceRulesResponse, error)
+	GetRule(ctx context.Context, in *GetServiceRulesRequest, opts ...grpc.CallOption) (*GetServiceRulesResponse, error)
+	UpdateRule(ctx context.Context, in *UpdateServiceRuleRequest, opts ...grpc.CallOption) (*UpdateServiceRuleResponse, error)
+	DeleteRule(ctx context.Context, in *DeleteServiceRulesRequest, opts ...grpc.CallOption) (*DeleteServiceRulesResponse, error)
+	ReplaceRules(ctx context.Context, in *ReplaceServiceRulesRequest, opts ...grpc.CallOption) (*ReplaceServiceRulesResponse, error)
+	AddTags(ctx context.Context, in *AddServiceTagsRequest, opts ...grpc.CallOption) (*AddServiceTagsResponse, error)
+	GetTags(ctx context.Context, in *GetServiceTagsRequest, opts ...grpc.CallOption) (*GetServiceTagsResponse, error)
+	UpdateTag(ctx context.Context, in *UpdateServiceTagRequest, opts ...grpc.CallOption) (*UpdateServiceTagResponse, error)
+	DeleteTags(ctx context.Context, in *DeleteServiceTagsRequest, opts ...grpc.CallOption) (*DeleteServiceTagsResponse, error)
+	GetDiscoveryPolicy(ctx context.Context, in *GetDiscoveryPolicyRequest, opts ...grpc.CallOption) (*GetDiscoveryPolicyResponse, error)
+	UpdateDiscoveryPolicy(ctx context.Context, in *UpdateDiscoveryPolicyRequest, opts ...grpc.CallOption) (*UpdateDiscoveryPolicyResponse, error)
+	DeleteDiscoveryPolicy(ctx context.Context, in *DeleteDiscoveryPolicyRequest, opts ...grpc.CallOption) (*DeleteDiscoveryPolicyResponse, error)
+	GetSchemaInfo(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*GetSchemaResponse, error)
+	GetAllSchemaInfo(ctx context.Context, in *GetAllSchemaRequest, opts ...grpc.CallOption) (*GetAllSchemaResponse, error)
+	DeleteSchema(ctx context.Context, in *DeleteSchemaRequest, opts ...grpc.CallOption) (*DeleteSchemaResponse, error)
+	ModifySchema(ctx context.Context, in *ModifySchemaRequest, opts ...grpc.CallOption) (*ModifySchemaResponse, error)
+	ModifySchemas(ctx context.Context, in *ModifySchemasRequest, opts ...grpc.CallOption) (*ModifySchemasResponse, error)
+	ModifySharedDefinition(ctx context.Context, in *ModifySharedDefinitionRequest, opts ...grpc.CallOption) (*ModifySharedDefinitionResponse, error)
+	GetSharedDefinitions(ctx context.Context, in *GetSharedDefinitionsRequest, opts ...grpc.CallOption) (*GetSharedDefinitionsResponse, error)
+	DeleteSharedDefinition(ctx context.Context, in *DeleteSharedDefinitionRequest, opts ...grpc.CallOption) (*DeleteSharedDefinitionResponse, error)
+	AddDependenciesForMicroServices(ctx context.Context, in *AddDependenciesRequest, opts ...grpc.CallOption) (*AddDependenciesResponse, error)
+	CreateDependenciesForMicroServices(ctx context.Context, in *CreateDependenciesRequest, opts ...grpc.CallOption) (*CreateDependenciesResponse, error)
+	GetProviderDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetProDependenciesResponse, error)
+	GetConsumerDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetConDependenciesResponse, error)
+	ApproveDependency(ctx context.Context, in *ApproveDependencyRequest, opts ...grpc.CallOption) (*ApproveDependencyResponse, error)
+	RevokeDependencyApproval(ctx context.Context, in *RevokeDependencyApprovalRequest, opts ...grpc.CallOption) (*RevokeDependencyApprovalResponse, error)
+	GetDependencyApprovals(ctx context.Context, in *GetDependencyApprovalsRequest, opts ...grpc.CallOption) (*GetDependencyApprovalsResponse, error)
+	GetDependencyDiff(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependencyDiffResponse, error)
+	GetDependencyHistory(ctx context.Context, in *GetDependencyHistoryRequest, opts ...grpc.CallOption) (*GetDependencyHistoryResponse, error)
+	GetProviderAccessors(ctx context.Context, in *GetProviderAccessorsRequest, opts ...grpc.CallOption) (*GetProviderAccessorsResponse, error)
+	ReportDependencyTraffic(ctx context.Context, in *ReportDependencyTrafficRequest, opts ...grpc.CallOption) (*ReportDependencyTrafficResponse, error)
+	LintDependencies(ctx context.Context, in *LintDependenciesRequest, opts ...grpc.CallOption) (*LintDependenciesResponse, error)
+	GrantDelegation(ctx context.Context, in *GrantDelegationRequest, opts ...grpc.CallOption) (*GrantDelegationResponse, error)
+	RevokeDelegation(ctx context.Context, in *RevokeDelegationRequest, opts ...grpc.CallOption) (*RevokeDelegationResponse, error)
+	GetDelegations(ctx context.Context, in *GetDelegationsRequest, opts ...grpc.CallOption) (*GetDelegationsResponse, error)
+	Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
+	DeleteServices(ctx context.Context, in *DelServicesRequest, opts ...grpc.CallOption) (*DelServicesResponse, error)
+	Apply(ctx context.Context, in *ApplyServiceRequest, opts ...grpc.CallOption) (*ApplyServiceResponse, error)
+}
+
+type serviceCtrlClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewServiceCtrlClient(cc *grpc.ClientConn) ServiceCtrlClient {
+	return &serviceCtrlClient{cc}
+}
+
+func (c *serviceCtrlClient) Exist(ctx context.Context, in *GetExistenceRequest, opts ...grpc.CallOption) (*GetExistenceResponse, error) {
+	out := new(GetExistenceResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/exist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) BatchExist(ctx context.Context, in *BatchGetExistenceRequest, opts ...grpc.CallOption) (*BatchGetExistenceResponse, error) {
+	out := new(BatchGetExistenceResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/batchExist", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) Create(ctx context.Context, in *CreateServiceRequest, opts ...grpc.CallOption) (*CreateServiceResponse, error) {
+	out := new(CreateServiceResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) Delete(ctx context.Context, in *DeleteServiceRequest, opts ...grpc.CallOption) (*DeleteServiceResponse, error) {
+	out := new(DeleteServiceResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetOne(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceResponse, error) {
+	out := new(GetServiceResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getOne", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error) {
+	out := new(GetServicesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getServices", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) UpdateProperties(ctx context.Context, in *UpdateServicePropsRequest, opts ...grpc.CallOption) (*UpdateServicePropsResponse, error) {
+	out := new(UpdateServicePropsResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/updateProperties", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) UpdateCatalog(ctx context.Context, in *UpdateServiceCatalogRequest, opts ...grpc.CallOption) (*UpdateServiceCatalogResponse, error) {
+	out := new(UpdateServiceCatalogResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/updateCatalog", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) UpdateRetirement(ctx context.Context, in *UpdateServiceRetirementRequest, opts ...grpc.CallOption) (*UpdateServiceRetirementResponse, error) {
+	out := new(UpdateServiceRetirementResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/updateRetirement", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) UpdateSchemaFreeze(ctx context.Context, in *UpdateSchemaFreezeRequest, opts ...grpc.CallOption) (*UpdateSchemaFreezeResponse, error) {
+	out := new(UpdateSchemaFreezeResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/updateSchemaFreeze", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) AddRule(ctx context.Context, in *AddServiceRulesRequest, opts ...grpc.CallOption) (*AddServiceRulesResponse, error) {
+	out := new(AddServiceRulesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/addRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetRule(ctx context.Context, in *GetServiceRulesRequest, opts ...grpc.CallOption) (*GetServiceRulesResponse, error) {
+	out := new(GetServiceRulesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) UpdateRule(ctx context.Context, in *UpdateServiceRuleRequest, opts ...grpc.CallOption) (*UpdateServiceRuleResponse, error) {
+	out := new(UpdateServiceRuleResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/updateRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) DeleteRule(ctx context.Context, in *DeleteServiceRulesRequest, opts ...grpc.CallOption) (*DeleteServiceRulesResponse, error) {
+	out := new(DeleteServiceRulesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/deleteRule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) ReplaceRules(ctx context.Context, in *ReplaceServiceRulesRequest, opts ...grpc.CallOption) (*ReplaceServiceRulesResponse, error) {
+	out := new(ReplaceServiceRulesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/replaceRules", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) AddTags(ctx context.Context, in *AddServiceTagsRequest, opts ...grpc.CallOption) (*AddServiceTagsResponse, error) {
+	out := new(AddServiceTagsResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/addTags", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetTags(ctx context.Context, in *GetServiceTagsRequest, opts ...grpc.CallOption) (*GetServiceTagsResponse, error) {
+	out := new(GetServiceTagsResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getTags", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) UpdateTag(ctx context.Context, in *UpdateServiceTagRequest, opts ...grpc.CallOption) (*UpdateServiceTagResponse, error) {
+	out := new(UpdateServiceTagResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/updateTag", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) DeleteTags(ctx context.Context, in *DeleteServiceTagsRequest, opts ...grpc.CallOption) (*DeleteServiceTagsResponse, error) {
+	out := new(DeleteServiceTagsResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/deleteTags", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetDiscoveryPolicy(ctx context.Context, in *GetDiscoveryPolicyRequest, opts ...grpc.CallOption) (*GetDiscoveryPolicyResponse, error) {
+	out := new(GetDiscoveryPolicyResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getDiscoveryPolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) UpdateDiscoveryPolicy(ctx context.Context, in *UpdateDiscoveryPolicyRequest, opts ...grpc.CallOption) (*UpdateDiscoveryPolicyResponse, error) {
+	out := new(UpdateDiscoveryPolicyResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/updateDiscoveryPolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) DeleteDiscoveryPolicy(ctx context.Context, in *DeleteDiscoveryPolicyRequest, opts ...grpc.CallOption) (*DeleteDiscoveryPolicyResponse, error) {
+	out := new(DeleteDiscoveryPolicyResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/deleteDiscoveryPolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetSchemaInfo(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*GetSchemaResponse, error) {
+	out := new(GetSchemaResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getSchemaInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetAllSchemaInfo(ctx context.Context, in *GetAllSchemaRequest, opts ...grpc.CallOption) (*GetAllSchemaResponse, error) {
+	out := new(GetAllSchemaResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getAllSchemaInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) DeleteSchema(ctx context.Context, in *DeleteSchemaRequest, opts ...grpc.CallOption) (*DeleteSchemaResponse, error) {
+	out := new(DeleteSchemaResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/deleteSchema", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) ModifySchema(ctx context.Context, in *ModifySchemaRequest, opts ...grpc.CallOption) (*ModifySchemaResponse, error) {
+	out := new(ModifySchemaResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/modifySchema", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) ModifySchemas(ctx context.Context, in *ModifySchemasRequest, opts ...grpc.CallOption) (*ModifySchemasResponse, error) {
+	out := new(ModifySchemasResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/modifySchemas", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) ModifySharedDefinition(ctx context.Context, in *ModifySharedDefinitionRequest, opts ...grpc.CallOption) (*ModifySharedDefinitionResponse, error) {
+	out := new(ModifySharedDefinitionResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/modifySharedDefinition", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetSharedDefinitions(ctx context.Context, in *GetSharedDefinitionsRequest, opts ...grpc.CallOption) (*GetSharedDefinitionsResponse, error) {
+	out := new(GetSharedDefinitionsResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getSharedDefinitions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) DeleteSharedDefinition(ctx context.Context, in *DeleteSharedDefinitionRequest, opts ...grpc.CallOption) (*DeleteSharedDefinitionResponse, error) {
+	out := new(DeleteSharedDefinitionResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/deleteSharedDefinition", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) AddDependenciesForMicroServices(ctx context.Context, in *AddDependenciesRequest, opts ...grpc.CallOption) (*AddDependenciesResponse, error) {
+	out := new(AddDependenciesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/addDependenciesForMicroServices", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) CreateDependenciesForMicroServices(ctx context.Context, in *CreateDependenciesRequest, opts ...grpc.CallOption) (*CreateDependenciesResponse, error) {
+	out := new(CreateDependenciesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/createDependenciesForMicroServices", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetProviderDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetProDependenciesResponse, error) {
+	out := new(GetProDependenciesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getProviderDependencies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetConsumerDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetConDependenciesResponse, error) {
+	out := new(GetConDependenciesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getConsumerDependencies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) ApproveDependency(ctx context.Context, in *ApproveDependencyRequest, opts ...grpc.CallOption) (*ApproveDependencyResponse, error) {
+	out := new(ApproveDependencyResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/approveDependency", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) RevokeDependencyApproval(ctx context.Context, in *RevokeDependencyApprovalRequest, opts ...grpc.CallOption) (*RevokeDependencyApprovalResponse, error) {
+	out := new(RevokeDependencyApprovalResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/revokeDependencyApproval", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetDependencyApprovals(ctx context.Context, in *GetDependencyApprovalsRequest, opts ...grpc.CallOption) (*GetDependencyApprovalsResponse, error) {
+	out := new(GetDependencyApprovalsResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getDependencyApprovals", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetDependencyDiff(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetDependencyDiffResponse, error) {
+	out := new(GetDependencyDiffResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getDependencyDiff", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetDependencyHistory(ctx context.Context, in *GetDependencyHistoryRequest, opts ...grpc.CallOption) (*GetDependencyHistoryResponse, error) {
+	out := new(GetDependencyHistoryResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getDependencyHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetProviderAccessors(ctx context.Context, in *GetProviderAccessorsRequest, opts ...grpc.CallOption) (*GetProviderAccessorsResponse, error) {
+	out := new(GetProviderAccessorsResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getProviderAccessors", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) ReportDependencyTraffic(ctx context.Context, in *ReportDependencyTrafficRequest, opts ...grpc.CallOption) (*ReportDependencyTrafficResponse, error) {
+	out := new(ReportDependencyTrafficResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/reportDependencyTraffic", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) LintDependencies(ctx context.Context, in *LintDependenciesRequest, opts ...grpc.CallOption) (*LintDependenciesResponse, error) {
+	out := new(LintDependenciesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/lintDependencies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GrantDelegation(ctx context.Context, in *GrantDelegationRequest, opts ...grpc.CallOption) (*GrantDelegationResponse, error) {
+	out := new(GrantDelegationResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/grantDelegation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) RevokeDelegation(ctx context.Context, in *RevokeDelegationRequest, opts ...grpc.CallOption) (*RevokeDelegationResponse, error) {
+	out := new(RevokeDelegationResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/revokeDelegation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) GetDelegations(ctx context.Context, in *GetDelegationsRequest, opts ...grpc.CallOption) (*GetDelegationsResponse, error) {
+	out := new(GetDelegationsResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getDelegations", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error) {
+	out := new(BroadcastResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/broadcast", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) DeleteServices(ctx context.Context, in *DelServicesRequest, opts ...grpc.CallOption) (*DelServicesResponse, error) {
+	out := new(DelServicesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/deleteServices", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceCtrlClient) Apply(ctx context.Context, in *ApplyServiceRequest, opts ...grpc.CallOption) (*ApplyServiceResponse, error) {
+	out := new(ApplyServiceResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/apply", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for ServiceCtrl service
+
+type ServiceCtrlServer interface {
+	Exist(context.Context, *GetExistenceRequest) (*GetExistenceResponse, error)
+	BatchExist(context.Context, *BatchGetExistenceRequest) (*BatchGetExistenceResponse, error)
+	Create(context.Context, *CreateServiceRequest) (*CreateServiceResponse, error)
+	Delete(context.Context, *DeleteServiceRequest) (*DeleteServiceResponse, error)
+	GetOne(context.Context, *GetServiceRequest) (*GetServiceResponse, error)
+	GetServices(context.Context, *GetServicesRequest) (*GetServicesResponse, error)
+	UpdateProperties(context.Context, *UpdateServicePropsRequest) (*UpdateServicePropsResponse, error)
+	UpdateCatalog(context.Context, *UpdateServiceCatalogRequest) (*UpdateServiceCatalogResponse, error)
+	UpdateRetirement(context.Context, *UpdateServiceRetirementRequest) (*UpdateServiceRetirementResponse, error)
+	UpdateSchemaFreeze(context.Context, *UpdateSchemaFreezeRequest) (*UpdateSchemaFreezeResponse, error)
+	AddRule(context.Context, *AddServiceRulesRequest) (*AddServiceRulesResponse, error)
+	GetRule(context.Context, *GetServiceRulesRequest) (*GetServiceRulesResponse, error)
+	UpdateRule(context.Context, *UpdateServiceRuleRequest) (*UpdateServiceRuleResponse, error)
+	DeleteRule(context.Context, *DeleteServiceRulesRequest) (*DeleteServiceRulesResponse, error)
+	ReplaceRules(context.Context, *ReplaceServiceRulesRequest) (*ReplaceServiceRulesResponse, error)
+	AddTags(context.Context, *AddServiceTagsRequest) (*AddServiceTagsResponse, error)
+	GetTags(context.Context, *GetServiceTagsRequest) (*GetServiceTagsResponse, error)
+	UpdateTag(context.Context, *UpdateServiceTagRequest) (*UpdateServiceTagResponse, error)
+	DeleteTags(context.Context, *DeleteServiceTagsRequest) (*DeleteServiceTagsResponse, error)
+	GetDiscoveryPolicy(context.Context, *GetDiscoveryPolicyRequest) (*GetDiscoveryPolicyResponse, error)
+	UpdateDiscoveryPolicy(context.Context, *UpdateDiscoveryPolicyRequest) (*UpdateDiscoveryPolicyResponse, error)
+	DeleteDiscoveryPolicy(context.Context, *DeleteDiscoveryPolicyRequest) (*DeleteDiscoveryPolicyResponse, error)
+	GetSchemaInfo(context.Context, *GetSchemaRequest) (*GetSchemaResponse, error)
+	GetAllSchemaInfo(context.Context, *GetAllSchemaRequest) (*GetAllSchemaResponse, error)
+	DeleteSchema(context.Context, *DeleteSchemaRequest) (*DeleteSchemaResponse, error)
+	ModifySchema(context.Context, *ModifySchemaRequest) (*ModifySchemaResponse, error)
+	ModifySchemas(context.Context, *ModifySchemasRequest) (*ModifySchemasResponse, error)
+	ModifySharedDefinition(context.Context, *ModifySharedDefinitionRequest) (*ModifySharedDefinitionResponse, error)
+	GetSharedDefinitions(context.Context, *GetSharedDefinitionsRequest) (*GetSharedDefinitionsResponse, error)
+	DeleteSharedDefinition(context.Context, *DeleteSharedDefinitionRequest) (*DeleteSharedDefinitionResponse, error)
+	AddDependenciesForMicroServices(context.Context, *AddDependenciesRequest) (*AddDependenciesResponse, error)
+	CreateDependenciesForMicroServices(context.Context, *CreateDependenciesRequest) (*CreateDependenciesResponse, error)
+	GetProviderDependencies(context.Context, *GetDependenciesRequest) (*GetProDependenciesResponse, error)
+	GetConsumerDependencies(context.Context, *GetDependenciesRequest) (*GetConDependenciesResponse, error)
+	ApproveDependency(context.Context, *ApproveDependencyRequest) (*ApproveDependencyResponse, error)
+	RevokeDependencyApproval(context.Context, *RevokeDependencyApprovalRequest) (*RevokeDependencyApprovalResponse, error)
+	GetDependencyApprovals(context.Context, *GetDependencyApprovalsRequest) (*GetDependencyApprovalsResponse, error)
+	GetDependencyDiff(context.Context, *GetDependenciesRequest) (*GetDependencyDiffResponse, error)
+	GetDependencyHistory(context.Context, *GetDependencyHistoryRequest) (*GetDependencyHistoryResponse, error)
+	GetProviderAccessors(context.Context, *GetProviderAccessorsRequest) (*GetProviderAccessorsResponse, error)
+	ReportDependencyTraffic(context.Context, *ReportDependencyTrafficRequest) (*ReportDependencyTrafficResponse, error)
+	LintDependencies(context.Context, *LintDependenciesRequest) (*LintDependenciesResponse, error)
+	GrantDelegation(context.Context, *GrantDelegationRequest) (*GrantDelegationResponse, error)
+	RevokeDelegation(context.Context, *RevokeDelegationRequest) (*RevokeDelegationResponse, error)
+	GetDelegations(context.Context, *GetDelegationsRequest) (*GetDelegationsResponse, error)
+	Broadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error)
+	DeleteServices(context.Context, *DelServicesRequest) (*DelServicesResponse, error)
+	Apply(context.Context, *ApplyServiceRequest) (*ApplyServiceResponse, error)
+}
+
+func RegisterServiceCtrlServer(s *grpc.Server, srv ServiceCtrlServer) {
+	s.RegisterService(&_ServiceCtrl_serviceDesc, srv)
+}
+
+func _ServiceCtrl_Exist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExistenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).Exist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/Exist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).Exist(ctx, req.(*GetExistenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_BatchExist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetExistenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).BatchExist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/BatchExist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).BatchExist(ctx, req.(*BatchGetExistenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).Create(ctx, req.(*CreateServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).Delete(ctx, req.(*DeleteServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetOne_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetOne(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetOne",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetOne(ctx, req.(*GetServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetServices(ctx, req.(*GetServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_UpdateProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServicePropsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).UpdateProperties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/UpdateProperties",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).UpdateProperties(ctx, req.(*UpdateServicePropsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_UpdateCatalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceCatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).UpdateCatalog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/UpdateCatalog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).UpdateCatalog(ctx, req.(*UpdateServiceCatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_UpdateRetirement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceRetirementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).UpdateRetirement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/UpdateRetirement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).UpdateRetirement(ctx, req.(*UpdateServiceRetirementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_UpdateSchemaFreeze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSchemaFreezeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).UpdateSchemaFreeze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/UpdateSchemaFreeze",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).UpdateSchemaFreeze(ctx, req.(*UpdateSchemaFreezeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_AddRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddServiceRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).AddRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/AddRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).AddRule(ctx, req.(*AddServiceRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetRule(ctx, req.(*GetServiceRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_UpdateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).UpdateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/UpdateRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).UpdateRule(ctx, req.(*UpdateServiceRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_DeleteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteServiceRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).DeleteRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/DeleteRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).DeleteRule(ctx, req.(*DeleteServiceRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_ReplaceRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceServiceRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).ReplaceRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/ReplaceRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).ReplaceRules(ctx, req.(*ReplaceServiceRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddServiceTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/AddTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).AddTags(ctx, req.(*AddServiceTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetTags(ctx, req.(*GetServiceTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_UpdateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).UpdateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/UpdateTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).UpdateTag(ctx, req.(*UpdateServiceTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_DeleteTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteServiceTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).DeleteTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/DeleteTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).DeleteTags(ctx, req.(*DeleteServiceTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetDiscoveryPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDiscoveryPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetDiscoveryPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetDiscoveryPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetDiscoveryPolicy(ctx, req.(*GetDiscoveryPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_UpdateDiscoveryPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDiscoveryPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).UpdateDiscoveryPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/UpdateDiscoveryPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).UpdateDiscoveryPolicy(ctx, req.(*UpdateDiscoveryPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_DeleteDiscoveryPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDiscoveryPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).DeleteDiscoveryPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/DeleteDiscoveryPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).DeleteDiscoveryPolicy(ctx, req.(*DeleteDiscoveryPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetSchemaInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetSchemaInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetSchemaInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetSchemaInfo(ctx, req.(*GetSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetAllSchemaInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetAllSchemaInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetAllSchemaInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetAllSchemaInfo(ctx, req.(*GetAllSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_DeleteSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).DeleteSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/DeleteSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).DeleteSchema(ctx, req.(*DeleteSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_ModifySchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifySchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).ModifySchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/ModifySchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).ModifySchema(ctx, req.(*ModifySchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_ModifySchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifySchemasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).ModifySchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/ModifySchemas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).ModifySchemas(ctx, req.(*ModifySchemasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_ModifySharedDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifySharedDefinitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).ModifySharedDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/ModifySharedDefinition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).ModifySharedDefinition(ctx, req.(*ModifySharedDefinitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetSharedDefinitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedDefinitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetSharedDefinitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetSharedDefinitions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetSharedDefinitions(ctx, req.(*GetSharedDefinitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_DeleteSharedDefinition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSharedDefinitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).DeleteSharedDefinition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/DeleteSharedDefinition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).DeleteSharedDefinition(ctx, req.(*DeleteSharedDefinitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_AddDependenciesForMicroServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).AddDependenciesForMicroServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/AddDependenciesForMicroServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).AddDependenciesForMicroServices(ctx, req.(*AddDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_CreateDependenciesForMicroServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).CreateDependenciesForMicroServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/CreateDependenciesForMicroServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).CreateDependenciesForMicroServices(ctx, req.(*CreateDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetProviderDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetProviderDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetProviderDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetProviderDependencies(ctx, req.(*GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetConsumerDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetConsumerDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetConsumerDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetConsumerDependencies(ctx, req.(*GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_ApproveDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).ApproveDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/ApproveDependency",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).ApproveDependency(ctx, req.(*ApproveDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_RevokeDependencyApproval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeDependencyApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).RevokeDependencyApproval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/RevokeDependencyApproval",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).RevokeDependencyApproval(ctx, req.(*RevokeDependencyApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetDependencyApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependencyApprovalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetDependencyApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetDependencyApprovals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetDependencyApprovals(ctx, req.(*GetDependencyApprovalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetDependencyDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetDependencyDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetDependencyDiff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetDependencyDiff(ctx, req.(*GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetDependencyHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDependencyHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetDependencyHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetDependencyHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetDependencyHistory(ctx, req.(*GetDependencyHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetProviderAccessors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProviderAccessorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetProviderAccessors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetProviderAccessors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetProviderAccessors(ctx, req.(*GetProviderAccessorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_ReportDependencyTraffic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportDependencyTrafficRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).ReportDependencyTraffic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/ReportDependencyTraffic",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).ReportDependencyTraffic(ctx, req.(*ReportDependencyTrafficRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_LintDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LintDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).LintDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/LintDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).LintDependencies(ctx, req.(*LintDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GrantDelegation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantDelegationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GrantDelegation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GrantDelegation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GrantDelegation(ctx, req.(*GrantDelegationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_RevokeDelegation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeDelegationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).RevokeDelegation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/RevokeDelegation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).RevokeDelegation(ctx, req.(*RevokeDelegationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetDelegations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDelegationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetDelegations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetDelegations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetDelegations(ctx, req.(*GetDelegationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_Broadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).Broadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/Broadcast",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).Broadcast(ctx, req.(*BroadcastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_DeleteServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).DeleteServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/DeleteServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).DeleteServices(ctx, req.(*DelServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_Apply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).Apply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/Apply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).Apply(ctx, req.(*ApplyServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ServiceCtrl_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "com.huawei.paas.cse.serviceregistry.api.ServiceCtrl",
+	HandlerType: (*ServiceCtrlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "exist",
+			Handler:    _ServiceCtrl_Exist_Handler,
+		},
+		{
+			MethodName: "batchExist",
+			Handler:    _ServiceCtrl_BatchExist_Handler,
+		},
+		{
+			MethodName: "create",
+			Handler:    _ServiceCtrl_Create_Handler,
+		},
+		{
+			MethodName: "delete",
+			Handler:    _ServiceCtrl_Delete_Handler,
+		},
+		{
+			MethodName: "getOne",
+			Handler:    _ServiceCtrl_GetOne_Handler,
+		},
+		{
+			MethodName: "getServices",
+			Handler:    _ServiceCtrl_GetServices_Handler,
+		},
+		{
+			MethodName: "updateProperties",
+			Handler:    _ServiceCtrl_UpdateProperties_Handler,
+		},
+		{
+			MethodName: "updateCatalog",
+			Handler:    _ServiceCtrl_UpdateCatalog_Handler,
+		},
+		{
+			MethodName: "updateRetirement",
+			Handler:    _ServiceCtrl_UpdateRetirement_Handler,
+		},
+		{
+			MethodName: "updateSchemaFreeze",
+			Handler:    _ServiceCtrl_UpdateSchemaFreeze_Handler,
+		},
+		{
+			MethodName: "addRule",
+			Handler:    _ServiceCtrl_AddRule_Handler,
+		},
+		{
+			MethodName: "getRule",
+			Handler:    _ServiceCtrl_GetRule_Handler,
+		},
+		{
+			MethodName: "updateRule",
+			Handler:    _ServiceCtrl_UpdateRule_Handler,
+		},
+		{
+			MethodName: "deleteRule",
+			Handler:    _ServiceCtrl_DeleteRule_Handler,
+		},
+		{
+			MethodName: "replaceRules",
+			Handler:    _ServiceCtrl_ReplaceRules_Handler,
+		},
+		{
+			MethodName: "addTags",
+			Handler:    _ServiceCtrl_AddTags_Handler,
+		},
+		{
+			MethodName: "getTags",
+			Handler:    _ServiceCtrl_GetTags_Handler,
+		},
+		{
+			MethodName: "updateTag",
+			Handler:    _ServiceCtrl_UpdateTag_Handler,
+		},
+		{
+			MethodName: "deleteTags",
+			Handler:    _ServiceCtrl_DeleteTags_Handler,
+		},
+		{
+			MethodName: "getDiscoveryPolicy",
+			Handler:    _ServiceCtrl_GetDiscoveryPolicy_Handler,
+		},
+		{
+			MethodName: "updateDiscoveryPolicy",
+			Handler:    _ServiceCtrl_UpdateDiscoveryPolicy_Handler,
+		},
+		{
+			MethodName: "deleteDiscoveryPolicy",
+			Handler:    _ServiceCtrl_DeleteDiscoveryPolicy_Handler,
+		},
+		{
+			MethodName: "getSchemaInfo",
+			Handler:    _ServiceCtrl_GetSchemaInfo_Handler,
+		},
+		{
+			MethodName: "getAllSchemaInfo",
+			Handler:    _ServiceCtrl_GetAllSchemaInfo_Handler,
+		},
+		{
+			MethodName: "deleteSchema",
+			Handler:    _ServiceCtrl_DeleteSchema_Handler,
+		},
+		{
+			MethodName: "modifySchema",
+			Handler:    _ServiceCtrl_ModifySchema_Handler,
+		},
+		{
+			MethodName: "modifySchemas",
+			Handler:    _ServiceCtrl_ModifySchemas_Handler,
+		},
+		{
+			MethodName: "modifySharedDefinition",
+			Handler:    _ServiceCtrl_ModifySharedDefinition_Handler,
+		},
+		{
+			MethodName: "getSharedDefinitions",
+			Handler:    _ServiceCtrl_GetSharedDefinitions_Handler,
+		},
+		{
+			MethodName: "deleteSharedDefinition",
+			Handler:    _ServiceCtrl_DeleteSharedDefinition_Handler,
+		},
+		{
+			MethodName: "addDependenciesForMicroServices",
+			Handler:    _ServiceCtrl_AddDependenciesForMicroServices_Handler,
+		},
+		{
+			MethodName: "createDependenciesForMicroServices",
+			Handler:    _ServiceCtrl_CreateDependenciesForMicroServices_Handler,
+		},
+		{
+			MethodName: "getProviderDependencies",
+			Handler:    _ServiceCtrl_GetProviderDependencies_Handler,
+		},
+		{
+			MethodName: "getConsumerDependencies",
+			Handler:    _ServiceCtrl_GetConsumerDependencies_Handler,
+		},
+		{
+			MethodName: "approveDependency",
+			Handler:    _ServiceCtrl_ApproveDependency_Handler,
+		},
+		{
+			MethodName: "revokeDependencyApproval",
+			Handler:    _ServiceCtrl_RevokeDependencyApproval_Handler,
+		},
+		{
+			MethodName: "getDependencyApprovals",
+			Handler:    _ServiceCtrl_GetDependencyApprovals_Handler,
+		},
+		{
+			MethodName: "getDependencyDiff",
+			Handler:    _ServiceCtrl_GetDependencyDiff_Handler,
+		},
+		{
+			MethodName: "getDependencyHistory",
+			Handler:    _ServiceCtrl_GetDependencyHistory_Handler,
+		},
+		{
+			MethodName: "getProviderAccessors",
+			Handler:    _ServiceCtrl_GetProviderAccessors_Handler,
+		},
+		{
+			MethodName: "reportDependencyTraffic",
+			Handler:    _ServiceCtrl_ReportDependencyTraffic_Handler,
+		},
+		{
+			MethodName: "lintDependencies",
+			Handler:    _ServiceCtrl_LintDependencies_Handler,
+		},
+		{
+			MethodName: "grantDelegation",
+			Handler:    _ServiceCtrl_GrantDelegation_Handler,
+		},
+		{
+			MethodName: "revokeDelegation",
+			Handler:    _ServiceCtrl_RevokeDelegation_Handler,
+		},
+		{
+			MethodName: "getDelegations",
+			Handler:    _ServiceCtrl_GetDelegations_Handler,
+		},
+		{
+			MethodName: "broadcast",
+			Handler:    _ServiceCtrl_Broadcast_Handler,
+		},
+		{
+			MethodName: "deleteServices",
+			Handler:    _ServiceCtrl_DeleteServices_Handler,
+		},
+		{
+			MethodName: "apply",
+			Handler:    _ServiceCtrl_Apply_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "services.proto",
 }
 
-func (c *serviceCtrlClient) GetOne(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceResponse, error) {
-	out := new(GetServiceResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getOne", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
+// Client API for ServiceInstanceCtrl service
 
-func (c *serviceCtrlClient) GetServices(ctx context.Context, in *GetServicesRequest, opts ...grpc.CallOption) (*GetServicesResponse, error) {
-	out := new(GetServicesResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getServices", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ServiceInstanceCtrlClient interface {
+	Register(ctx context.Context, in *RegisterInstanceRequest, opts ...grpc.CallOption) (*RegisterInstanceResponse, error)
+	Unregister(ctx context.Context, in *UnregisterInstanceRequest, opts ...grpc.CallOption) (*UnregisterInstanceResponse, error)
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	Find(ctx context.Context, in *FindInstancesRequest, opts ...grpc.CallOption) (*FindInstancesResponse, error)
+	GetInstances(ctx context.Context, in *GetInstancesRequest, opts ...grpc.CallOption) (*GetInstancesResponse, error)
+	GetOneInstance(ctx context.Context, in *GetOneInstanceRequest, opts ...grpc.CallOption) (*GetOneInstanceResponse, error)
+	UpdateStatus(ctx context.Context, in *UpdateInstanceStatusRequest, opts ...grpc.CallOption) (*UpdateInstanceStatusResponse, error)
+	UpdateInstanceProperties(ctx context.Context, in *UpdateInstancePropsRequest, opts ...grpc.CallOption) (*UpdateInstancePropsResponse, error)
+	Watch(ctx context.Context, in *WatchInstanceRequest, opts ...grpc.CallOption) (ServiceInstanceCtrl_WatchClient, error)
+	WatchInvalidations(ctx context.Context, in *WatchInstanceRequest, opts ...grpc.CallOption) (ServiceInstanceCtrl_WatchInvalidationsClient, error)
+	HeartbeatSet(ctx context.Context, in *HeartbeatSetRequest, opts ...grpc.CallOption) (*HeartbeatSetResponse, error)
+	PromoteInstances(ctx context.Context, in *PromoteInstancesRequest, opts ...grpc.CallOption) (*PromoteInstancesResponse, error)
+	UnregisterInstances(ctx context.Context, in *UnregisterInstancesRequest, opts ...grpc.CallOption) (*UnregisterInstancesResponse, error)
+	UpdateCapacity(ctx context.Context, in *UpdateInstanceCapacityRequest, opts ...grpc.CallOption) (*UpdateInstanceCapacityResponse, error)
+	DelegateRegister(ctx context.Context, in *DelegateRegisterInstanceRequest, opts ...grpc.CallOption) (*RegisterInstanceResponse, error)
+	DelegateUnregister(ctx context.Context, in *DelegateUnregisterInstanceRequest, opts ...grpc.CallOption) (*UnregisterInstanceResponse, error)
+	KeepAlive(ctx context.Context, opts ...grpc.CallOption) (ServiceInstanceCtrl_KeepAliveClient, error)
 }
 
-func (c *serviceCtrlClient) UpdateProperties(ctx context.Context, in *UpdateServicePropsRequest, opts ...grpc.CallOption) (*UpdateServicePropsResponse, error) {
-	out := new(UpdateServicePropsResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/updateProperties", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type serviceInstanceCtrlClient struct {
+	cc *grpc.ClientConn
 }
 
-func (c *serviceCtrlClient) AddRule(ctx context.Context, in *AddServiceRulesRequest, opts ...grpc.CallOption) (*AddServiceRulesResponse, error) {
-	out := new(AddServiceRulesResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/addRule", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func NewServiceInstanceCtrlClient(cc *grpc.ClientConn) ServiceInstanceCtrlClient {
+	return &serviceInstanceCtrlClient{cc}
 }
 
-func (c *serviceCtrlClient) GetRule(ctx context.Context, in *GetServiceRulesRequest, opts ...grpc.CallOption) (*GetServiceRulesResponse, error) {
-	out := new(GetServiceRulesResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getRule", in, out, c.cc, opts...)
+func (c *serviceInstanceCtrlClient) Register(ctx context.Context, in *RegisterInstanceRequest, opts ...grpc.CallOption) (*RegisterInstanceResponse, error) {
+	out := new(RegisterInstanceResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/register", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceCtrlClient) UpdateRule(ctx context.Context, in *UpdateServiceRuleRequest, opts ...grpc.CallOption) (*UpdateServiceRuleResponse, error) {
-	out := new(UpdateServiceRuleResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/updateRule", in, out, c.cc, opts...)
+func (c *serviceInstanceCtrlClient) Unregister(ctx context.Context, in *UnregisterInstanceRequest, opts ...grpc.CallOption) (*UnregisterInstanceResponse, error) {
+	out := new(UnregisterInstanceResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/unregister", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceCtrlClient) DeleteRule(ctx context.Context, in *DeleteServiceRulesRequest, opts ...grpc.CallOption) (*DeleteServiceRulesResponse, error) {
-	out := new(DeleteServiceRulesResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/deleteRule", in, out, c.cc, opts...)
+func (c *serviceInstanceCtrlClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	out := new(HeartbeatResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/heartbeat", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceCtrlClient) AddTags(ctx context.Context, in *AddServiceTagsRequest, opts ...grpc.CallOption) (*AddServiceTagsResponse, error) {
-	out := new(AddServiceTagsResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/addTags", in, out, c.cc, opts...)
+func (c *serviceInstanceCtrlClient) Find(ctx context.Context, in *FindInstancesRequest, opts ...grpc.CallOption) (*FindInstancesResponse, error) {
+	out := new(FindInstancesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/find", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceCtrlClient) GetTags(ctx context.Context, in *GetServiceTagsRequest, opts ...grpc.CallOption) (*GetServiceTagsResponse, error) {
-	out := new(GetServiceTagsResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getTags", in, out, c.cc, opts...)
+func (c *serviceInstanceCtrlClient) GetInstances(ctx context.Context, in *GetInstancesRequest, opts ...grpc.CallOption) (*GetInstancesResponse, error) {
+	out := new(GetInstancesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/getInstances", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceCtrlClient) UpdateTag(ctx context.Context, in *UpdateServiceTagRequest, opts ...grpc.CallOption) (*UpdateServiceTagResponse, error) {
-	out := new(UpdateServiceTagResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/updateTag", in, out, c.cc, opts...)
+func (c *serviceInstanceCtrlClient) GetOneInstance(ctx context.Context, in *GetOneInstanceRequest, opts ...grpc.CallOption) (*GetOneInstanceResponse, error) {
+	out := new(GetOneInstanceResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/getOneInstance", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceCtrlClient) DeleteTags(ctx context.Context, in *DeleteServiceTagsRequest, opts ...grpc.CallOption) (*DeleteServiceTagsResponse, error) {
-	out := new(DeleteServiceTagsResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/deleteTags", in, out, c.cc, opts...)
+func (c *serviceInstanceCtrlClient) UpdateStatus(ctx context.Context, in *UpdateInstanceStatusRequest, opts ...grpc.CallOption) (*UpdateInstanceStatusResponse, error) {
+	out := new(UpdateInstanceStatusResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/updateStatus", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceCtrlClient) GetSchemaInfo(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*GetSchemaResponse, error) {
-	out := new(GetSchemaResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getSchemaInfo", in, out, c.cc, opts...)
+func (c *serviceInstanceCtrlClient) UpdateInstanceProperties(ctx context.Context, in *UpdateInstancePropsRequest, opts ...grpc.CallOption) (*UpdateInstancePropsResponse, error) {
+	out := new(UpdateInstancePropsResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/updateInstanceProperties", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceCtrlClient) GetAllSchemaInfo(ctx context.Context, in *GetAllSchemaRequest, opts ...grpc.CallOption) (*GetAllSchemaResponse, error) {
-	out := new(GetAllSchemaResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getAllSchemaInfo", in, out, c.cc, opts...)
+func (c *serviceInstanceCtrlClient) Watch(ctx context.Context, in *WatchInstanceRequest, opts ...grpc.CallOption) (ServiceInstanceCtrl_WatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ServiceInstanceCtrl_serviceDesc.Streams[0], c.cc, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/watch", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *serviceCtrlClient) DeleteSchema(ctx context.Context, in *DeleteSchemaRequest, opts ...grpc.CallOption) (*DeleteSchemaResponse, error) {
-	out := new(DeleteSchemaResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/deleteSchema", in, out, c.cc, opts...)
-	if err != nil {
+	x := &serviceInstanceCtrlWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *serviceCtrlClient) ModifySchema(ctx context.Context, in *ModifySchemaRequest, opts ...grpc.CallOption) (*ModifySchemaResponse, error) {
-	out := new(ModifySchemaResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/modifySchema", in, out, c.cc, opts...)
-	if err != nil {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return x, nil
 }
 
-func (c *serviceCtrlClient) ModifySchemas(ctx context.Context, in *ModifySchemasRequest, opts ...grpc.CallOption) (*ModifySchemasResponse, error) {
-	out := new(ModifySchemasResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/modifySchemas", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ServiceInstanceCtrl_WatchClient interface {
+	Recv() (*WatchInstanceResponse, error)
+	grpc.ClientStream
 }
 
-func (c *serviceCtrlClient) AddDependenciesForMicroServices(ctx context.Context, in *AddDependenciesRequest, opts ...grpc.CallOption) (*AddDependenciesResponse, error) {
-	out := new(AddDependenciesResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/addDependenciesForMicroServices", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type serviceInstanceCtrlWatchClient struct {
+	grpc.ClientStream
 }
 
-func (c *serviceCtrlClient) CreateDependenciesForMicroServices(ctx context.Context, in *CreateDependenciesRequest, opts ...grpc.CallOption) (*CreateDependenciesResponse, error) {
-	out := new(CreateDependenciesResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/createDependenciesForMicroServices", in, out, c.cc, opts...)
-	if err != nil {
+func (x *serviceInstanceCtrlWatchClient) Recv() (*WatchInstanceResponse, error) {
+	m := new(WatchInstanceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *serviceCtrlClient) GetProviderDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetProDependenciesResponse, error) {
-	out := new(GetProDependenciesResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getProviderDependencies", in, out, c.cc, opts...)
+func (c *serviceInstanceCtrlClient) WatchInvalidations(ctx context.Context, in *WatchInstanceRequest, opts ...grpc.CallOption) (ServiceInstanceCtrl_WatchInvalidationsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ServiceInstanceCtrl_serviceDesc.Streams[1], c.cc, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/watchInvalidations", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *serviceCtrlClient) GetConsumerDependencies(ctx context.Context, in *GetDependenciesRequest, opts ...grpc.CallOption) (*GetConDependenciesResponse, error) {
-	out := new(GetConDependenciesResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/getConsumerDependencies", in, out, c.cc, opts...)
-	if err != nil {
+	x := &serviceInstanceCtrlWatchInvalidationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *serviceCtrlClient) DeleteServices(ctx context.Context, in *DelServicesRequest, opts ...grpc.CallOption) (*DelServicesResponse, error) {
-	out := new(DelServicesResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/deleteServices", in, out, c.cc, opts...)
-	if err != nil {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return x, nil
 }
 
-// Server API for ServiceCtrl service
-
-type ServiceCtrlServer interface {
-	Exist(context.Context, *GetExistenceRequest) (*GetExistenceResponse, error)
-	Create(context.Context, *CreateServiceRequest) (*CreateServiceResponse, error)
-	Delete(context.Context, *DeleteServiceRequest) (*DeleteServiceResponse, error)
-	GetOne(context.Context, *GetServiceRequest) (*GetServiceResponse, error)
-	GetServices(context.Context, *GetServicesRequest) (*GetServicesResponse, error)
-	UpdateProperties(context.Context, *UpdateServicePropsRequest) (*UpdateServicePropsResponse, error)
-	AddRule(context.Context, *AddServiceRulesRequest) (*AddServiceRulesResponse, error)
-	GetRule(context.Context, *GetServiceRulesRequest) (*GetServiceRulesResponse, error)
-	UpdateRule(context.Context, *UpdateServiceRuleRequest) (*UpdateServiceRuleResponse, error)
-	DeleteRule(context.Context, *DeleteServiceRulesRequest) (*DeleteServiceRulesResponse, error)
-	AddTags(context.Context, *AddServiceTagsRequest) (*AddServiceTagsResponse, error)
-	GetTags(context.Context, *GetServiceTagsRequest) (*GetServiceTagsResponse, error)
-	UpdateTag(context.Context, *UpdateServiceTagRequest) (*UpdateServiceTagResponse, error)
-	DeleteTags(context.Context, *DeleteServiceTagsRequest) (*DeleteServiceTagsResponse, error)
-	GetSchemaInfo(context.Context, *GetSchemaRequest) (*GetSchemaResponse, error)
-	GetAllSchemaInfo(context.Context, *GetAllSchemaRequest) (*GetAllSchemaResponse, error)
-	DeleteSchema(context.Context, *DeleteSchemaRequest) (*DeleteSchemaResponse, error)
-	ModifySchema(context.Context, *ModifySchemaRequest) (*ModifySchemaResponse, error)
-	ModifySchemas(context.Context, *ModifySchemasRequest) (*ModifySchemasResponse, error)
-	AddDependenciesForMicroServices(context.Context, *AddDependenciesRequest) (*AddDependenciesResponse, error)
-	CreateDependenciesForMicroServices(context.Context, *CreateDependenciesRequest) (*CreateDependenciesResponse, error)
-	GetProviderDependencies(context.Context, *GetDependenciesRequest) (*GetProDependenciesResponse, error)
-	GetConsumerDependencies(context.Context, *GetDependenciesRequest) (*GetConDependenciesResponse, error)
-	DeleteServices(context.Context, *DelServicesRequest) (*DelServicesResponse, error)
+type ServiceInstanceCtrl_WatchInvalidationsClient interface {
+	Recv() (*WatchInstanceResponse, error)
+	grpc.ClientStream
 }
 
-func RegisterServiceCtrlServer(s *grpc.Server, srv ServiceCtrlServer) {
-	s.RegisterService(&_ServiceCtrl_serviceDesc, srv)
+type serviceInstanceCtrlWatchInvalidationsClient struct {
+	grpc.ClientStream
 }
 
-func _ServiceCtrl_Exist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetExistenceRequest)
-	if err := dec(in); err != nil {
+func (x *serviceInstanceCtrlWatchInvalidationsClient) Recv() (*WatchInstanceResponse, error) {
+	m := new(WatchInstanceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceCtrlServer).Exist(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/Exist",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).Exist(ctx, req.(*GetExistenceRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
 }
 
-func _ServiceCtrl_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateServiceRequest)
-	if err := dec(in); err != nil {
+func (c *serviceInstanceCtrlClient) HeartbeatSet(ctx context.Context, in *HeartbeatSetRequest, opts ...grpc.CallOption) (*HeartbeatSetResponse, error) {
+	out := new(HeartbeatSetResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/heartbeatSet", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceCtrlServer).Create(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/Create",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).Create(ctx, req.(*CreateServiceRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _ServiceCtrl_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteServiceRequest)
-	if err := dec(in); err != nil {
+func (c *serviceInstanceCtrlClient) PromoteInstances(ctx context.Context, in *PromoteInstancesRequest, opts ...grpc.CallOption) (*PromoteInstancesResponse, error) {
+	out := new(PromoteInstancesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/promoteInstances", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceCtrlServer).Delete(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/Delete",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).Delete(ctx, req.(*DeleteServiceRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _ServiceCtrl_GetOne_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetServiceRequest)
-	if err := dec(in); err != nil {
+func (c *serviceInstanceCtrlClient) UnregisterInstances(ctx context.Context, in *UnregisterInstancesRequest, opts ...grpc.CallOption) (*UnregisterInstancesResponse, error) {
+	out := new(UnregisterInstancesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/unregisterInstances", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceCtrlServer).GetOne(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetOne",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).GetOne(ctx, req.(*GetServiceRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _ServiceCtrl_GetServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetServicesRequest)
-	if err := dec(in); err != nil {
+func (c *serviceInstanceCtrlClient) UpdateCapacity(ctx context.Context, in *UpdateInstanceCapacityRequest, opts ...grpc.CallOption) (*UpdateInstanceCapacityResponse, error) {
+	out := new(UpdateInstanceCapacityResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/updateCapacity", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceCtrlServer).GetServices(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetServices",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).GetServices(ctx, req.(*GetServicesRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _ServiceCtrl_UpdateProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateServicePropsRequest)
-	if err := dec(in); err != nil {
+func (c *serviceInstanceCtrlClient) DelegateRegister(ctx context.Context, in *DelegateRegisterInstanceRequest, opts ...grpc.CallOption) (*RegisterInstanceResponse, error) {
+	out := new(RegisterInstanceResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/delegateRegister", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceCtrlServer).UpdateProperties(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/UpdateProperties",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).UpdateProperties(ctx, req.(*UpdateServicePropsRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _ServiceCtrl_AddRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddServiceRulesRequest)
-	if err := dec(in); err != nil {
+func (c *serviceInstanceCtrlClient) DelegateUnregister(ctx context.Context, in *DelegateUnregisterInstanceRequest, opts ...grpc.CallOption) (*UnregisterInstanceResponse, error) {
+	out := new(UnregisterInstanceResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/delegateUnregister", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceCtrlServer).AddRule(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/AddRule",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).AddRule(ctx, req.(*AddServiceRulesRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _ServiceCtrl_GetRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetServiceRulesRequest)
-	if err := dec(in); err != nil {
+func (c *serviceInstanceCtrlClient) KeepAlive(ctx context.Context, opts ...grpc.CallOption) (ServiceInstanceCtrl_KeepAliveClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ServiceInstanceCtrl_serviceDesc.Streams[2], c.cc, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/keepAlive", opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceCtrlServer).GetRule(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetRule",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).GetRule(ctx, req.(*GetServiceRulesRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	x := &serviceInstanceCtrlKeepAliveClient{stream}
+	return x, nil
 }
 
-func _ServiceCtrl_UpdateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateServiceRuleRequest)
-	if err := dec(in); err != nil {
+type ServiceInstanceCtrl_KeepAliveClient interface {
+	Send(*KeepAliveRequest) error
+	Recv() (*KeepAliveResponse, error)
+	grpc.ClientStream
+}
+
+type serviceInstanceCtrlKeepAliveClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceInstanceCtrlKeepAliveClient) Send(m *KeepAliveRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *serviceInstanceCtrlKeepAliveClient) Recv() (*KeepAliveResponse, error) {
+	m := new(KeepAliveResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(ServiceCtrlServer).UpdateRule(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/UpdateRule",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).UpdateRule(ctx, req.(*UpdateServiceRuleRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
 }
 
-func _ServiceCtrl_DeleteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteServiceRulesRequest)
+// Server API for ServiceInstanceCtrl service
+
+type ServiceInstanceCtrlServer interface {
+	Register(context.Context, *RegisterInstanceRequest) (*RegisterInstanceResponse, error)
+	Unregister(context.Context, *UnregisterInstanceRequest) (*UnregisterInstanceResponse, error)
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	Find(context.Context, *FindInstancesRequest) (*FindInstancesResponse, error)
+	GetInstances(context.Context, *GetInstancesRequest) (*GetInstancesResponse, error)
+	GetOneInstance(context.Context, *GetOneInstanceRequest) (*GetOneInstanceResponse, error)
+	UpdateStatus(context.Context, *UpdateInstanceStatusRequest) (*UpdateInstanceStatusResponse, error)
+	UpdateInstanceProperties(context.Context, *UpdateInstancePropsRequest) (*UpdateInstancePropsResponse, error)
+	Watch(*WatchInstanceRequest, ServiceInstanceCtrl_WatchServer) error
+	WatchInvalidations(*WatchInstanceRequest, ServiceInstanceCtrl_WatchInvalidationsServer) error
+	HeartbeatSet(context.Context, *HeartbeatSetRequest) (*HeartbeatSetResponse, error)
+	PromoteInstances(context.Context, *PromoteInstancesRequest) (*PromoteInstancesResponse, error)
+	UnregisterInstances(context.Context, *UnregisterInstancesRequest) (*UnregisterInstancesResponse, error)
+	UpdateCapacity(context.Context, *UpdateInstanceCapacityRequest) (*UpdateInstanceCapacityResponse, error)
+	DelegateRegister(context.Context, *DelegateRegisterInstanceRequest) (*RegisterInstanceResponse, error)
+	DelegateUnregister(context.Context, *DelegateUnregisterInstanceRequest) (*UnregisterInstanceResponse, error)
+	KeepAlive(ServiceInstanceCtrl_KeepAliveServer) error
+}
+
+func RegisterServiceInstanceCtrlServer(s *grpc.Server, srv ServiceInstanceCtrlServer) {
+	s.RegisterService(&_ServiceInstanceCtrl_serviceDesc, srv)
+}
+
+func _ServiceInstanceCtrl_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterInstanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).DeleteRule(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).Register(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/DeleteRule",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/Register",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).DeleteRule(ctx, req.(*DeleteServiceRulesRequest))
+		return srv.(ServiceInstanceCtrlServer).Register(ctx, req.(*RegisterInstanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddServiceTagsRequest)
+func _ServiceInstanceCtrl_Unregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterInstanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).AddTags(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).Unregister(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/AddTags",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/Unregister",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).AddTags(ctx, req.(*AddServiceTagsRequest))
+		return srv.(ServiceInstanceCtrlServer).Unregister(ctx, req.(*UnregisterInstanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_GetTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetServiceTagsRequest)
+func _ServiceInstanceCtrl_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).GetTags(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).Heartbeat(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetTags",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/Heartbeat",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).GetTags(ctx, req.(*GetServiceTagsRequest))
+		return srv.(ServiceInstanceCtrlServer).Heartbeat(ctx, req.(*HeartbeatRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_UpdateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateServiceTagRequest)
+func _ServiceInstanceCtrl_Find_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindInstancesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).UpdateTag(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).Find(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/UpdateTag",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/Find",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).UpdateTag(ctx, req.(*UpdateServiceTagRequest))
+		return srv.(ServiceInstanceCtrlServer).Find(ctx, req.(*FindInstancesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_DeleteTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteServiceTagsRequest)
+func _ServiceInstanceCtrl_GetInstances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstancesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).DeleteTags(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).GetInstances(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/DeleteTags",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/GetInstances",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).DeleteTags(ctx, req.(*DeleteServiceTagsRequest))
+		return srv.(ServiceInstanceCtrlServer).GetInstances(ctx, req.(*GetInstancesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_GetSchemaInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetSchemaRequest)
+func _ServiceInstanceCtrl_GetOneInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOneInstanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).GetSchemaInfo(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).GetOneInstance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetSchemaInfo",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/GetOneInstance",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).GetSchemaInfo(ctx, req.(*GetSchemaRequest))
+		return srv.(ServiceInstanceCtrlServer).GetOneInstance(ctx, req.(*GetOneInstanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_GetAllSchemaInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAllSchemaRequest)
+func _ServiceInstanceCtrl_UpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateInstanceStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).GetAllSchemaInfo(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).UpdateStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetAllSchemaInfo",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/UpdateStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).GetAllSchemaInfo(ctx, req.(*GetAllSchemaRequest))
+		return srv.(ServiceInstanceCtrlServer).UpdateStatus(ctx, req.(*UpdateInstanceStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_DeleteSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteSchemaRequest)
+func _ServiceInstanceCtrl_UpdateInstanceProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateInstancePropsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).DeleteSchema(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).UpdateInstanceProperties(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/DeleteSchema",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/UpdateInstanceProperties",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).DeleteSchema(ctx, req.(*DeleteSchemaRequest))
+		return srv.(ServiceInstanceCtrlServer).UpdateInstanceProperties(ctx, req.(*UpdateInstancePropsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_ModifySchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ModifySchemaRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ServiceCtrlServer).ModifySchema(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/ModifySchema",
+func _ServiceInstanceCtrl_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchInstanceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).ModifySchema(ctx, req.(*ModifySchemaRequest))
+	return srv.(ServiceInstanceCtrlServer).Watch(m, &serviceInstanceCtrlWatchServer{stream})
+}
+
+type ServiceInstanceCtrl_WatchServer interface {
+	Send(*WatchInstanceResponse) error
+	grpc.ServerStream
+}
+
+type serviceInstanceCtrlWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceInstanceCtrlWatchServer) Send(m *WatchInstanceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ServiceInstanceCtrl_WatchInvalidations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchInstanceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(ServiceInstanceCtrlServer).WatchInvalidations(m, &serviceInstanceCtrlWatchInvalidationsServer{stream})
 }
 
-func _ServiceCtrl_ModifySchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ModifySchemasRequest)
+type ServiceInstanceCtrl_WatchInvalidationsServer interface {
+	Send(*WatchInstanceResponse) error
+	grpc.ServerStream
+}
+
+type serviceInstanceCtrlWatchInvalidationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceInstanceCtrlWatchInvalidationsServer) Send(m *WatchInstanceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ServiceInstanceCtrl_HeartbeatSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatSetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).ModifySchemas(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).HeartbeatSet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/ModifySchemas",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/HeartbeatSet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).ModifySchemas(ctx, req.(*ModifySchemasRequest))
+		return srv.(ServiceInstanceCtrlServer).HeartbeatSet(ctx, req.(*HeartbeatSetRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_AddDependenciesForMicroServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddDependenciesRequest)
+func _ServiceInstanceCtrl_PromoteInstances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteInstancesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).AddDependenciesForMicroServices(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).PromoteInstances(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/AddDependenciesForMicroServices",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/PromoteInstances",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).AddDependenciesForMicroServices(ctx, req.(*AddDependenciesRequest))
+		return srv.(ServiceInstanceCtrlServer).PromoteInstances(ctx, req.(*PromoteInstancesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_CreateDependenciesForMicroServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDependenciesRequest)
+func _ServiceInstanceCtrl_UnregisterInstances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterInstancesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).CreateDependenciesForMicroServices(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).UnregisterInstances(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/CreateDependenciesForMicroServices",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/UnregisterInstances",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).CreateDependenciesForMicroServices(ctx, req.(*CreateDependenciesRequest))
+		return srv.(ServiceInstanceCtrlServer).UnregisterInstances(ctx, req.(*UnregisterInstancesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_GetProviderDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDependenciesRequest)
+func _ServiceInstanceCtrl_UpdateCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateInstanceCapacityRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).GetProviderDependencies(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).UpdateCapacity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetProviderDependencies",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/UpdateCapacity",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).GetProviderDependencies(ctx, req.(*GetDependenciesRequest))
+		return srv.(ServiceInstanceCtrlServer).UpdateCapacity(ctx, req.(*UpdateInstanceCapacityRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_GetConsumerDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDependenciesRequest)
+func _ServiceInstanceCtrl_DelegateRegister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegateRegisterInstanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).GetConsumerDependencies(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).DelegateRegister(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/GetConsumerDependencies",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/DelegateRegister",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).GetConsumerDependencies(ctx, req.(*GetDependenciesRequest))
+		return srv.(ServiceInstanceCtrlServer).DelegateRegister(ctx, req.(*DelegateRegisterInstanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceCtrl_DeleteServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DelServicesRequest)
+func _ServiceInstanceCtrl_DelegateUnregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegateUnregisterInstanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceCtrlServer).DeleteServices(ctx, in)
+		return srv.(ServiceInstanceCtrlServer).DelegateUnregister(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceCtrl/DeleteServices",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/DelegateUnregister",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceCtrlServer).DeleteServices(ctx, req.(*DelServicesRequest))
+		return srv.(ServiceInstanceCtrlServer).DelegateUnregister(ctx, req.(*DelegateUnregisterInstanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _ServiceCtrl_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "com.huawei.paas.cse.serviceregistry.api.ServiceCtrl",
-	HandlerType: (*ServiceCtrlServer)(nil),
+func _ServiceInstanceCtrl_KeepAlive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceInstanceCtrlServer).KeepAlive(&serviceInstanceCtrlKeepAliveServer{stream})
+}
+
+type ServiceInstanceCtrl_KeepAliveServer interface {
+	Send(*KeepAliveResponse) error
+	Recv() (*KeepAliveRequest, error)
+	grpc.ServerStream
+}
+
+type serviceInstanceCtrlKeepAliveServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceInstanceCtrlKeepAliveServer) Send(m *KeepAliveResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *serviceInstanceCtrlKeepAliveServer) Recv() (*KeepAliveRequest, error) {
+	m := new(KeepAliveRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _ServiceInstanceCtrl_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl",
+	HandlerType: (*ServiceInstanceCtrlServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "exist",
-			Handler:    _ServiceCtrl_Exist_Handler,
-		},
-		{
-			MethodName: "create",
-			Handler:    _ServiceCtrl_Create_Handler,
-		},
-		{
-			MethodName: "delete",
-			Handler:    _ServiceCtrl_Delete_Handler,
-		},
-		{
-			MethodName: "getOne",
-			Handler:    _ServiceCtrl_GetOne_Handler,
-		},
-		{
-			MethodName: "getServices",
-			Handler:    _ServiceCtrl_GetServices_Handler,
-		},
-		{
-			MethodName: "updateProperties",
-			Handler:    _ServiceCtrl_UpdateProperties_Handler,
-		},
-		{
-			MethodName: "addRule",
-			Handler:    _ServiceCtrl_AddRule_Handler,
-		},
-		{
-			MethodName: "getRule",
-			Handler:    _ServiceCtrl_GetRule_Handler,
+			MethodName: "register",
+			Handler:    _ServiceInstanceCtrl_Register_Handler,
 		},
 		{
-			MethodName: "updateRule",
-			Handler:    _ServiceCtrl_UpdateRule_Handler,
+			MethodName: "unregister",
+			Handler:    _ServiceInstanceCtrl_Unregister_Handler,
 		},
 		{
-			MethodName: "deleteRule",
-			Handler:    _ServiceCtrl_DeleteRule_Handler,
+			MethodName: "heartbeat",
+			Handler:    _ServiceInstanceCtrl_Heartbeat_Handler,
 		},
 		{
-			MethodName: "addTags",
-			Handler:    _ServiceCtrl_AddTags_Handler,
+			MethodName: "find",
+			Handler:    _ServiceInstanceCtrl_Find_Handler,
 		},
 		{
-			MethodName: "getTags",
-			Handler:    _ServiceCtrl_GetTags_Handler,
+			MethodName: "getInstances",
+			Handler:    _ServiceInstanceCtrl_GetInstances_Handler,
 		},
 		{
-			MethodName: "updateTag",
-			Handler:    _ServiceCtrl_UpdateTag_Handler,
+			MethodName: "getOneInstance",
+			Handler:    _ServiceInstanceCtrl_GetOneInstance_Handler,
 		},
 		{
-			MethodName: "deleteTags",
-			Handler:    _ServiceCtrl_DeleteTags_Handler,
+			MethodName: "updateStatus",
+			Handler:    _ServiceInstanceCtrl_UpdateStatus_Handler,
 		},
 		{
-			MethodName: "getSchemaInfo",
-			Handler:    _ServiceCtrl_GetSchemaInfo_Handler,
+			MethodName: "updateInstanceProperties",
+			Handler:    _ServiceInstanceCtrl_UpdateInstanceProperties_Handler,
 		},
 		{
-			MethodName: "getAllSchemaInfo",
-			Handler:    _ServiceCtrl_GetAllSchemaInfo_Handler,
+			MethodName: "heartbeatSet",
+			Handler:    _ServiceInstanceCtrl_HeartbeatSet_Handler,
 		},
 		{
-			MethodName: "deleteSchema",
-			Handler:    _ServiceCtrl_DeleteSchema_Handler,
+			MethodName: "promoteInstances",
+			Handler:    _ServiceInstanceCtrl_PromoteInstances_Handler,
 		},
 		{
-			MethodName: "modifySchema",
-			Handler:    _ServiceCtrl_ModifySchema_Handler,
+			MethodName: "unregisterInstances",
+			Handler:    _ServiceInstanceCtrl_UnregisterInstances_Handler,
 		},
 		{
-			MethodName: "modifySchemas",
-			Handler:    _ServiceCtrl_ModifySchemas_Handler,
+			MethodName: "updateCapacity",
+			Handler:    _ServiceInstanceCtrl_UpdateCapacity_Handler,
 		},
 		{
-			MethodName: "addDependenciesForMicroServices",
-			Handler:    _ServiceCtrl_AddDependenciesForMicroServices_Handler,
+			MethodName: "delegateRegister",
+			Handler:    _ServiceInstanceCtrl_DelegateRegister_Handler,
 		},
 		{
-			MethodName: "createDependenciesForMicroServices",
-			Handler:    _ServiceCtrl_CreateDependenciesForMicroServices_Handler,
+			MethodName: "delegateUnregister",
+			Handler:    _ServiceInstanceCtrl_DelegateUnregister_Handler,
 		},
+	},
+	Streams: []grpc.StreamDesc{
 		{
-			MethodName: "getProviderDependencies",
-			Handler:    _ServiceCtrl_GetProviderDependencies_Handler,
+			StreamName:    "watch",
+			Handler:       _ServiceInstanceCtrl_Watch_Handler,
+			ServerStreams: true,
 		},
 		{
-			MethodName: "getConsumerDependencies",
-			Handler:    _ServiceCtrl_GetConsumerDependencies_Handler,
+			StreamName:    "watchInvalidations",
+			Handler:       _ServiceInstanceCtrl_WatchInvalidations_Handler,
+			ServerStreams: true,
 		},
 		{
-			MethodName: "deleteServices",
-			Handler:    _ServiceCtrl_DeleteServices_Handler,
+			StreamName:    "keepAlive",
+			Handler:       _ServiceInstanceCtrl_KeepAlive_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "services.proto",
 }
 
-// Client API for ServiceInstanceCtrl service
+// Client API for GovernServiceCtrl service
 
-type ServiceInstanceCtrlClient interface {
-	Register(ctx context.Context, in *RegisterInstanceRequest, opts ...grpc.CallOption) (*RegisterInstanceResponse, error)
-	Unregister(ctx context.Context, in *UnregisterInstanceRequest, opts ...grpc.CallOption) (*UnregisterInstanceResponse, error)
-	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
-	Find(ctx context.Context, in *FindInstancesRequest, opts ...grpc.CallOption) (*FindInstancesResponse, error)
-	GetInstances(ctx context.Context, in *GetInstancesRequest, opts ...grpc.CallOption) (*GetInstancesResponse, error)
-	GetOneInstance(ctx context.Context, in *GetOneInstanceRequest, opts ...grpc.CallOption) (*GetOneInstanceResponse, error)
-	UpdateStatus(ctx context.Context, in *UpdateInstanceStatusRequest, opts ...grpc.CallOption) (*UpdateInstanceStatusResponse, error)
-	UpdateInstanceProperties(ctx context.Context, in *UpdateInstancePropsRequest, opts ...grpc.CallOption) (*UpdateInstancePropsResponse, error)
-	Watch(ctx context.Context, in *WatchInstanceRequest, opts ...grpc.CallOption) (ServiceInstanceCtrl_WatchClient, error)
-	HeartbeatSet(ctx context.Context, in *HeartbeatSetRequest, opts ...grpc.CallOption) (*HeartbeatSetResponse, error)
+type GovernServiceCtrlClient interface {
+	GetServiceDetail(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceDetailResponse, error)
+	GetServicesInfo(ctx context.Context, in *GetServicesInfoRequest, opts ...grpc.CallOption) (*GetServicesInfoResponse, error)
+	GetApplications(ctx context.Context, in *GetAppsRequest, opts ...grpc.CallOption) (*GetAppsResponse, error)
+	SearchInstances(ctx context.Context, in *SearchInstancesRequest, opts ...grpc.CallOption) (*SearchInstancesResponse, error)
+	CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*CreateSnapshotResponse, error)
+	GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*GetSnapshotResponse, error)
+	DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*DeleteSnapshotResponse, error)
+	CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error)
+	GetApiKeys(ctx context.Context, in *GetApiKeysRequest, opts ...grpc.CallOption) (*GetApiKeysResponse, error)
+	RotateApiKey(ctx context.Context, in *RotateApiKeyRequest, opts ...grpc.CallOption) (*RotateApiKeyResponse, error)
+	DeleteApiKey(ctx context.Context, in *DeleteApiKeyRequest, opts ...grpc.CallOption) (*DeleteApiKeyResponse, error)
+	GetStartupOrder(ctx context.Context, in *GetStartupOrderRequest, opts ...grpc.CallOption) (*GetStartupOrderResponse, error)
+	GetTopology(ctx context.Context, in *GetTopologyRequest, opts ...grpc.CallOption) (*GetTopologyResponse, error)
+	GetBlastRadius(ctx context.Context, in *GetBlastRadiusRequest, opts ...grpc.CallOption) (*GetBlastRadiusResponse, error)
+	GetSchemaCompliance(ctx context.Context, in *GetSchemaComplianceRequest, opts ...grpc.CallOption) (*GetSchemaComplianceResponse, error)
+	GetInstanceHistory(ctx context.Context, in *GetInstanceHistoryRequest, opts ...grpc.CallOption) (*GetInstanceHistoryResponse, error)
 }
 
-type serviceInstanceCtrlClient struct {
+type governServiceCtrlClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewServiceInstanceCtrlClient(cc *grpc.ClientConn) ServiceInstanceCtrlClient {
-	return &serviceInstanceCtrlClient{cc}
+func NewGovernServiceCtrlClient(cc *grpc.ClientConn) GovernServiceCtrlClient {
+	return &governServiceCtrlClient{cc}
 }
 
-func (c *serviceInstanceCtrlClient) Register(ctx context.Context, in *RegisterInstanceRequest, opts ...grpc.CallOption) (*RegisterInstanceResponse, error) {
-	out := new(RegisterInstanceResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/register", in, out, c.cc, opts...)
+func (c *governServiceCtrlClient) GetServiceDetail(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceDetailResponse, error) {
+	out := new(GetServiceDetailResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getServiceDetail", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceInstanceCtrlClient) Unregister(ctx context.Context, in *UnregisterInstanceRequest, opts ...grpc.CallOption) (*UnregisterInstanceResponse, error) {
-	out := new(UnregisterInstanceResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/unregister", in, out, c.cc, opts...)
+func (c *governServiceCtrlClient) GetServicesInfo(ctx context.Context, in *GetServicesInfoRequest, opts ...grpc.CallOption) (*GetServicesInfoResponse, error) {
+	out := new(GetServicesInfoResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getServicesInfo", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceInstanceCtrlClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
-	out := new(HeartbeatResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/heartbeat", in, out, c.cc, opts...)
+func (c *governServiceCtrlClient) GetApplications(ctx context.Context, in *GetAppsRequest, opts ...grpc.CallOption) (*GetAppsResponse, error) {
+	out := new(GetAppsResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getApplications", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceInstanceCtrlClient) Find(ctx context.Context, in *FindInstancesRequest, opts ...grpc.CallOption) (*FindInstancesResponse, error) {
-	out := new(FindInstancesResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/find", in, out, c.cc, opts...)
+func (c *governServiceCtrlClient) SearchInstances(ctx context.Context, in *SearchInstancesRequest, opts ...grpc.CallOption) (*SearchInstancesResponse, error) {
+	out := new(SearchInstancesResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/searchInstances", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceInstanceCtrlClient) GetInstances(ctx context.Context, in *GetInstancesRequest, opts ...grpc.CallOption) (*GetInstancesResponse, error) {
-	out := new(GetInstancesResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/getInstances", in, out, c.cc, opts...)
+func (c *governServiceCtrlClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*CreateSnapshotResponse, error) {
+	out := new(CreateSnapshotResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/createSnapshot", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceInstanceCtrlClient) GetOneInstance(ctx context.Context, in *GetOneInstanceRequest, opts ...grpc.CallOption) (*GetOneInstanceResponse, error) {
-	out := new(GetOneInstanceResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/getOneInstance", in, out, c.cc, opts...)
+func (c *governServiceCtrlClient) GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*GetSnapshotResponse, error) {
+	out := new(GetSnapshotResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getSnapshot", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceInstanceCtrlClient) UpdateStatus(ctx context.Context, in *UpdateInstanceStatusRequest, opts ...grpc.CallOption) (*UpdateInstanceStatusResponse, error) {
-	out := new(UpdateInstanceStatusResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/updateStatus", in, out, c.cc, opts...)
+func (c *governServiceCtrlClient) DeleteSnapshot(ctx context.Context, in *DeleteSnapshotRequest, opts ...grpc.CallOption) (*DeleteSnapshotResponse, error) {
+	out := new(DeleteSnapshotResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/deleteSnapshot", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceInstanceCtrlClient) UpdateInstanceProperties(ctx context.Context, in *UpdateInstancePropsRequest, opts ...grpc.CallOption) (*UpdateInstancePropsResponse, error) {
-	out := new(UpdateInstancePropsResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/updateInstanceProperties", in, out, c.cc, opts...)
+func (c *governServiceCtrlClient) CreateApiKey(ctx context.Context, in *CreateApiKeyRequest, opts ...grpc.CallOption) (*CreateApiKeyResponse, error) {
+	out := new(CreateApiKeyResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/createApiKey", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *serviceInstanceCtrlClient) Watch(ctx context.Context, in *WatchInstanceRequest, opts ...grpc.CallOption) (ServiceInstanceCtrl_WatchClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_ServiceInstanceCtrl_serviceDesc.Streams[0], c.cc, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/watch", opts...)
+func (c *governServiceCtrlClient) GetApiKeys(ctx context.Context, in *GetApiKeysRequest, opts ...grpc.CallOption) (*GetApiKeysResponse, error) {
+	out := new(GetApiKeysResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getApiKeys", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &serviceInstanceCtrlWatchClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
+	return out, nil
+}
+
+func (c *governServiceCtrlClient) RotateApiKey(ctx context.Context, in *RotateApiKeyRequest, opts ...grpc.CallOption) (*RotateApiKeyResponse, error) {
+	out := new(RotateApiKeyResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/rotateApiKey", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
+	return out, nil
+}
+
+func (c *governServiceCtrlClient) DeleteApiKey(ctx context.Context, in *DeleteApiKeyRequest, opts ...grpc.CallOption) (*DeleteApiKeyResponse, error) {
+	out := new(DeleteApiKeyResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/deleteApiKey", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return x, nil
+	return out, nil
 }
 
-type ServiceInstanceCtrl_WatchClient interface {
-	Recv() (*WatchInstanceResponse, error)
-	grpc.ClientStream
+func (c *governServiceCtrlClient) GetStartupOrder(ctx context.Context, in *GetStartupOrderRequest, opts ...grpc.CallOption) (*GetStartupOrderResponse, error) {
+	out := new(GetStartupOrderResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getStartupOrder", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+func (c *governServiceCtrlClient) GetTopology(ctx context.Context, in *GetTopologyRequest, opts ...grpc.CallOption) (*GetTopologyResponse, error) {
+	out := new(GetTopologyResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getTopology", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type serviceInstanceCtrlWatchClient struct {
-	grpc.ClientStream
+func (c *governServiceCtrlClient) GetBlastRadius(ctx context.Context, in *GetBlastRadiusRequest, opts ...grpc.CallOption) (*GetBlastRadiusResponse, error) {
+	out := new(GetBlastRadiusResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getBlastRadius", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (x *serviceInstanceCtrlWatchClient) Recv() (*WatchInstanceResponse, error) {
-	m := new(WatchInstanceResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (c *governServiceCtrlClient) GetSchemaCompliance(ctx context.Context, in *GetSchemaComplianceRequest, opts ...grpc.CallOption) (*GetSchemaComplianceResponse, error) {
+	out := new(GetSchemaComplianceResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getSchemaCompliance", in, out, c.cc, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-func (c *serviceInstanceCtrlClient) HeartbeatSet(ctx context.Context, in *HeartbeatSetRequest, opts ...grpc.CallOption) (*HeartbeatSetResponse, error) {
-	out := new(HeartbeatSetResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/heartbeatSet", in, out, c.cc, opts...)
+func (c *governServiceCtrlClient) GetInstanceHistory(ctx context.Context, in *GetInstanceHistoryRequest, opts ...grpc.CallOption) (*GetInstanceHistoryResponse, error) {
+	out := new(GetInstanceHistoryResponse)
+	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getInstanceHistory", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// Server API for ServiceInstanceCtrl service
+// Server API for GovernServiceCtrl service
 
-type ServiceInstanceCtrlServer interface {
-	Register(context.Context, *RegisterInstanceRequest) (*RegisterInstanceResponse, error)
-	Unregister(context.Context, *UnregisterInstanceRequest) (*UnregisterInstanceResponse, error)
-	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
-	Find(context.Context, *FindInstancesRequest) (*FindInstancesResponse, error)
-	GetInstances(context.Context, *GetInstancesRequest) (*GetInstancesResponse, error)
-	GetOneInstance(context.Context, *GetOneInstanceRequest) (*GetOneInstanceResponse, error)
-	UpdateStatus(context.Context, *UpdateInstanceStatusRequest) (*UpdateInstanceStatusResponse, error)
-	UpdateInstanceProperties(context.Context, *UpdateInstancePropsRequest) (*UpdateInstancePropsResponse, error)
-	Watch(*WatchInstanceRequest, ServiceInstanceCtrl_WatchServer) error
-	HeartbeatSet(context.Context, *HeartbeatSetRequest) (*HeartbeatSetResponse, error)
+type GovernServiceCtrlServer interface {
+	GetServiceDetail(context.Context, *GetServiceRequest) (*GetServiceDetailResponse, error)
+	GetServicesInfo(context.Context, *GetServicesInfoRequest) (*GetServicesInfoResponse, error)
+	GetApplications(context.Context, *GetAppsRequest) (*GetAppsResponse, error)
+	SearchInstances(context.Context, *SearchInstancesRequest) (*SearchInstancesResponse, error)
+	CreateSnapshot(context.Context, *CreateSnapshotRequest) (*CreateSnapshotResponse, error)
+	GetSnapshot(context.Context, *GetSnapshotRequest) (*GetSnapshotResponse, error)
+	DeleteSnapshot(context.Context, *DeleteSnapshotRequest) (*DeleteSnapshotResponse, error)
+	CreateApiKey(context.Context, *CreateApiKeyRequest) (*CreateApiKeyResponse, error)
+	GetApiKeys(context.Context, *GetApiKeysRequest) (*GetApiKeysResponse, error)
+	RotateApiKey(context.Context, *RotateApiKeyRequest) (*RotateApiKeyResponse, error)
+	DeleteApiKey(context.Context, *DeleteApiKeyRequest) (*DeleteApiKeyResponse, error)
+	GetStartupOrder(context.Context, *GetStartupOrderRequest) (*GetStartupOrderResponse, error)
+	GetTopology(context.Context, *GetTopologyRequest) (*GetTopologyResponse, error)
+	GetBlastRadius(context.Context, *GetBlastRadiusRequest) (*GetBlastRadiusResponse, error)
+	GetSchemaCompliance(context.Context, *GetSchemaComplianceRequest) (*GetSchemaComplianceResponse, error)
+	GetInstanceHistory(context.Context, *GetInstanceHistoryRequest) (*GetInstanceHistoryResponse, error)
 }
 
-func RegisterServiceInstanceCtrlServer(s *grpc.Server, srv ServiceInstanceCtrlServer) {
-	s.RegisterService(&_ServiceInstanceCtrl_serviceDesc, srv)
+func RegisterGovernServiceCtrlServer(s *grpc.Server, srv GovernServiceCtrlServer) {
+	s.RegisterService(&_GovernServiceCtrl_serviceDesc, srv)
 }
 
-func _ServiceInstanceCtrl_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterInstanceRequest)
+func _GovernServiceCtrl_GetServiceDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceInstanceCtrlServer).Register(ctx, in)
+		return srv.(GovernServiceCtrlServer).GetServiceDetail(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/Register",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetServiceDetail",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceInstanceCtrlServer).Register(ctx, req.(*RegisterInstanceRequest))
+		return srv.(GovernServiceCtrlServer).GetServiceDetail(ctx, req.(*GetServiceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceInstanceCtrl_Unregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UnregisterInstanceRequest)
+func _GovernServiceCtrl_GetServicesInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServicesInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceInstanceCtrlServer).Unregister(ctx, in)
+		return srv.(GovernServiceCtrlServer).GetServicesInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/Unregister",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetServicesInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceInstanceCtrlServer).Unregister(ctx, req.(*UnregisterInstanceRequest))
+		return srv.(GovernServiceCtrlServer).GetServicesInfo(ctx, req.(*GetServicesInfoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceInstanceCtrl_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HeartbeatRequest)
+func _GovernServiceCtrl_GetApplications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAppsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceInstanceCtrlServer).Heartbeat(ctx, in)
+		return srv.(GovernServiceCtrlServer).GetApplications(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/Heartbeat",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetApplications",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceInstanceCtrlServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+		return srv.(GovernServiceCtrlServer).GetApplications(ctx, req.(*GetAppsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceInstanceCtrl_Find_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FindInstancesRequest)
+func _GovernServiceCtrl_SearchInstances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchInstancesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceInstanceCtrlServer).Find(ctx, in)
+		return srv.(GovernServiceCtrlServer).SearchInstances(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/Find",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/SearchInstances",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceInstanceCtrlServer).Find(ctx, req.(*FindInstancesRequest))
+		return srv.(GovernServiceCtrlServer).SearchInstances(ctx, req.(*SearchInstancesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceInstanceCtrl_GetInstances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetInstancesRequest)
+func _GovernServiceCtrl_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSnapshotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceInstanceCtrlServer).GetInstances(ctx, in)
+		return srv.(GovernServiceCtrlServer).CreateSnapshot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/GetInstances",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/CreateSnapshot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceInstanceCtrlServer).GetInstances(ctx, req.(*GetInstancesRequest))
+		return srv.(GovernServiceCtrlServer).CreateSnapshot(ctx, req.(*CreateSnapshotRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceInstanceCtrl_GetOneInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetOneInstanceRequest)
+func _GovernServiceCtrl_GetSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSnapshotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceInstanceCtrlServer).GetOneInstance(ctx, in)
+		return srv.(GovernServiceCtrlServer).GetSnapshot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/GetOneInstance",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetSnapshot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceInstanceCtrlServer).GetOneInstance(ctx, req.(*GetOneInstanceRequest))
+		return srv.(GovernServiceCtrlServer).GetSnapshot(ctx, req.(*GetSnapshotRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceInstanceCtrl_UpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateInstanceStatusRequest)
+func _GovernServiceCtrl_DeleteSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSnapshotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceInstanceCtrlServer).UpdateStatus(ctx, in)
+		return srv.(GovernServiceCtrlServer).DeleteSnapshot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/UpdateStatus",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/DeleteSnapshot",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceInstanceCtrlServer).UpdateStatus(ctx, req.(*UpdateInstanceStatusRequest))
+		return srv.(GovernServiceCtrlServer).DeleteSnapshot(ctx, req.(*DeleteSnapshotRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceInstanceCtrl_UpdateInstanceProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateInstancePropsRequest)
+func _GovernServiceCtrl_CreateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApiKeyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceInstanceCtrlServer).UpdateInstanceProperties(ctx, in)
+		return srv.(GovernServiceCtrlServer).CreateApiKey(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/UpdateInstanceProperties",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/CreateApiKey",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceInstanceCtrlServer).UpdateInstanceProperties(ctx, req.(*UpdateInstancePropsRequest))
+		return srv.(GovernServiceCtrlServer).CreateApiKey(ctx, req.(*CreateApiKeyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceInstanceCtrl_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(WatchInstanceRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _GovernServiceCtrl_GetApiKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetApiKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(ServiceInstanceCtrlServer).Watch(m, &serviceInstanceCtrlWatchServer{stream})
-}
-
-type ServiceInstanceCtrl_WatchServer interface {
-	Send(*WatchInstanceResponse) error
-	grpc.ServerStream
-}
-
-type serviceInstanceCtrlWatchServer struct {
-	grpc.ServerStream
-}
-
-func (x *serviceInstanceCtrlWatchServer) Send(m *WatchInstanceResponse) error {
-	return x.ServerStream.SendMsg(m)
+	if interceptor == nil {
+		return srv.(GovernServiceCtrlServer).GetApiKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetApiKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GovernServiceCtrlServer).GetApiKeys(ctx, req.(*GetApiKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ServiceInstanceCtrl_HeartbeatSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HeartbeatSetRequest)
+func _GovernServiceCtrl_RotateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateApiKeyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceInstanceCtrlServer).HeartbeatSet(ctx, in)
+		return srv.(GovernServiceCtrlServer).RotateApiKey(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl/HeartbeatSet",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/RotateApiKey",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceInstanceCtrlServer).HeartbeatSet(ctx, req.(*HeartbeatSetRequest))
+		return srv.(GovernServiceCtrlServer).RotateApiKey(ctx, req.(*RotateApiKeyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _ServiceInstanceCtrl_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl",
-	HandlerType: (*ServiceInstanceCtrlServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "register",
-			Handler:    _ServiceInstanceCtrl_Register_Handler,
-		},
-		{
-			MethodName: "unregister",
-			Handler:    _ServiceInstanceCtrl_Unregister_Handler,
-		},
-		{
-			MethodName: "heartbeat",
-			Handler:    _ServiceInstanceCtrl_Heartbeat_Handler,
-		},
-		{
-			MethodName: "find",
-			Handler:    _ServiceInstanceCtrl_Find_Handler,
-		},
-		{
-			MethodName: "getInstances",
-			Handler:    _ServiceInstanceCtrl_GetInstances_Handler,
-		},
-		{
-			MethodName: "getOneInstance",
-			Handler:    _ServiceInstanceCtrl_GetOneInstance_Handler,
-		},
-		{
-			MethodName: "updateStatus",
-			Handler:    _ServiceInstanceCtrl_UpdateStatus_Handler,
-		},
-		{
-			MethodName: "updateInstanceProperties",
-			Handler:    _ServiceInstanceCtrl_UpdateInstanceProperties_Handler,
-		},
-		{
-			MethodName: "heartbeatSet",
-			Handler:    _ServiceInstanceCtrl_HeartbeatSet_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "watch",
-			Handler:       _ServiceInstanceCtrl_Watch_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "services.proto",
-}
-
-// Client API for GovernServiceCtrl service
-
-type GovernServiceCtrlClient interface {
-	GetServiceDetail(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceDetailResponse, error)
-	GetServicesInfo(ctx context.Context, in *GetServicesInfoRequest, opts ...grpc.CallOption) (*GetServicesInfoResponse, error)
-	GetApplications(ctx context.Context, in *GetAppsRequest, opts ...grpc.CallOption) (*GetAppsResponse, error)
-}
-
-type governServiceCtrlClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewGovernServiceCtrlClient(cc *grpc.ClientConn) GovernServiceCtrlClient {
-	return &governServiceCtrlClient{cc}
-}
-
-func (c *governServiceCtrlClient) GetServiceDetail(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*GetServiceDetailResponse, error) {
-	out := new(GetServiceDetailResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getServiceDetail", in, out, c.cc, opts...)
-	if err != nil {
+func _GovernServiceCtrl_DeleteApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteApiKeyRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(GovernServiceCtrlServer).DeleteApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/DeleteApiKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GovernServiceCtrlServer).DeleteApiKey(ctx, req.(*DeleteApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *governServiceCtrlClient) GetServicesInfo(ctx context.Context, in *GetServicesInfoRequest, opts ...grpc.CallOption) (*GetServicesInfoResponse, error) {
-	out := new(GetServicesInfoResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getServicesInfo", in, out, c.cc, opts...)
-	if err != nil {
+func _GovernServiceCtrl_GetStartupOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStartupOrderRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(GovernServiceCtrlServer).GetStartupOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetStartupOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GovernServiceCtrlServer).GetStartupOrder(ctx, req.(*GetStartupOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *governServiceCtrlClient) GetApplications(ctx context.Context, in *GetAppsRequest, opts ...grpc.CallOption) (*GetAppsResponse, error) {
-	out := new(GetAppsResponse)
-	err := grpc.Invoke(ctx, "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/getApplications", in, out, c.cc, opts...)
-	if err != nil {
+func _GovernServiceCtrl_GetTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopologyRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// Server API for GovernServiceCtrl service
-
-type GovernServiceCtrlServer interface {
-	GetServiceDetail(context.Context, *GetServiceRequest) (*GetServiceDetailResponse, error)
-	GetServicesInfo(context.Context, *GetServicesInfoRequest) (*GetServicesInfoResponse, error)
-	GetApplications(context.Context, *GetAppsRequest) (*GetAppsResponse, error)
-}
-
-func RegisterGovernServiceCtrlServer(s *grpc.Server, srv GovernServiceCtrlServer) {
-	s.RegisterService(&_GovernServiceCtrl_serviceDesc, srv)
+	if interceptor == nil {
+		return srv.(GovernServiceCtrlServer).GetTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GovernServiceCtrlServer).GetTopology(ctx, req.(*GetTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _GovernServiceCtrl_GetServiceDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetServiceRequest)
+func _GovernServiceCtrl_GetBlastRadius_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlastRadiusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GovernServiceCtrlServer).GetServiceDetail(ctx, in)
+		return srv.(GovernServiceCtrlServer).GetBlastRadius(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetServiceDetail",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetBlastRadius",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GovernServiceCtrlServer).GetServiceDetail(ctx, req.(*GetServiceRequest))
+		return srv.(GovernServiceCtrlServer).GetBlastRadius(ctx, req.(*GetBlastRadiusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GovernServiceCtrl_GetServicesInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetServicesInfoRequest)
+func _GovernServiceCtrl_GetSchemaCompliance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaComplianceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GovernServiceCtrlServer).GetServicesInfo(ctx, in)
+		return srv.(GovernServiceCtrlServer).GetSchemaCompliance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetServicesInfo",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetSchemaCompliance",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GovernServiceCtrlServer).GetServicesInfo(ctx, req.(*GetServicesInfoRequest))
+		return srv.(GovernServiceCtrlServer).GetSchemaCompliance(ctx, req.(*GetSchemaComplianceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GovernServiceCtrl_GetApplications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAppsRequest)
+func _GovernServiceCtrl_GetInstanceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstanceHistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GovernServiceCtrlServer).GetApplications(ctx, in)
+		return srv.(GovernServiceCtrlServer).GetInstanceHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetApplications",
+		FullMethod: "/com.huawei.paas.cse.serviceregistry.api.GovernServiceCtrl/GetInstanceHistory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GovernServiceCtrlServer).GetApplications(ctx, req.(*GetAppsRequest))
+		return srv.(GovernServiceCtrlServer).GetInstanceHistory(ctx, req.(*GetInstanceHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -4220,6 +9883,58 @@ var _GovernServiceCtrl_serviceDesc = grpc.ServiceDesc{
 			MethodName: "getApplications",
 			Handler:    _GovernServiceCtrl_GetApplications_Handler,
 		},
+		{
+			MethodName: "searchInstances",
+			Handler:    _GovernServiceCtrl_SearchInstances_Handler,
+		},
+		{
+			MethodName: "createSnapshot",
+			Handler:    _GovernServiceCtrl_CreateSnapshot_Handler,
+		},
+		{
+			MethodName: "getSnapshot",
+			Handler:    _GovernServiceCtrl_GetSnapshot_Handler,
+		},
+		{
+			MethodName: "deleteSnapshot",
+			Handler:    _GovernServiceCtrl_DeleteSnapshot_Handler,
+		},
+		{
+			MethodName: "createApiKey",
+			Handler:    _GovernServiceCtrl_CreateApiKey_Handler,
+		},
+		{
+			MethodName: "getApiKeys",
+			Handler:    _GovernServiceCtrl_GetApiKeys_Handler,
+		},
+		{
+			MethodName: "rotateApiKey",
+			Handler:    _GovernServiceCtrl_RotateApiKey_Handler,
+		},
+		{
+			MethodName: "deleteApiKey",
+			Handler:    _GovernServiceCtrl_DeleteApiKey_Handler,
+		},
+		{
+			MethodName: "getStartupOrder",
+			Handler:    _GovernServiceCtrl_GetStartupOrder_Handler,
+		},
+		{
+			MethodName: "getTopology",
+			Handler:    _GovernServiceCtrl_GetTopology_Handler,
+		},
+		{
+			MethodName: "getBlastRadius",
+			Handler:    _GovernServiceCtrl_GetBlastRadius_Handler,
+		},
+		{
+			MethodName: "getSchemaCompliance",
+			Handler:    _GovernServiceCtrl_GetSchemaCompliance_Handler,
+		},
+		{
+			MethodName: "getInstanceHistory",
+			Handler:    _GovernServiceCtrl_GetInstanceHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "services.proto",
@@ -4228,219 +9943,556 @@ var _GovernServiceCtrl_serviceDesc = grpc.ServiceDesc{
 func init() { proto1.RegisterFile("services.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 3422 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5c, 0xdb, 0x6f, 0x24, 0x47,
-	0xd5, 0x57, 0x8d, 0x67, 0x3c, 0x9e, 0xe3, 0xf5, 0xee, 0xba, 0xd6, 0x5e, 0xf7, 0x4e, 0xf2, 0xe5,
-	0x5b, 0xb5, 0x22, 0x7d, 0x79, 0x88, 0xfc, 0x25, 0x0e, 0x49, 0x96, 0xbd, 0x64, 0x63, 0x7b, 0x37,
-	0x5e, 0xef, 0x66, 0xb3, 0x9b, 0x1e, 0x27, 0x4b, 0x12, 0x20, 0x6a, 0xcf, 0x94, 0x67, 0x3a, 0x3b,
-	0xd3, 0x3d, 0xe9, 0xee, 0xf1, 0x66, 0x24, 0x24, 0x94, 0x90, 0x40, 0x20, 0x90, 0x10, 0x01, 0x4f,
-	0x79, 0x00, 0x01, 0x79, 0x04, 0x09, 0x84, 0x14, 0xa1, 0x88, 0x08, 0x81, 0x78, 0x41, 0x44, 0x3c,
-	0x21, 0x9e, 0xf8, 0x0f, 0x78, 0xe3, 0x0f, 0x00, 0xd5, 0xa5, 0xbb, 0xab, 0x2f, 0x9e, 0x9d, 0xea,
-	0x76, 0x27, 0xe2, 0xc9, 0x5d, 0xd5, 0xae, 0x53, 0xa7, 0xce, 0xad, 0x4e, 0xfd, 0xea, 0x4c, 0xc3,
-	0x51, 0x8f, 0xb8, 0xfb, 0x56, 0x9b, 0x78, 0xab, 0x43, 0xd7, 0xf1, 0x1d, 0xfc, 0x7f, 0x6d, 0x67,
-	0xb0, 0xda, 0x1b, 0x99, 0x77, 0x88, 0xb5, 0x3a, 0x34, 0x4d, 0x6f, 0xb5, 0xed, 0x91, 0x55, 0xf1,
-	0x3f, 0x2e, 0xe9, 0x5a, 0x9e, 0xef, 0x8e, 0x57, 0xcd, 0xa1, 0xa5, 0x7f, 0x1d, 0x96, 0xae, 0x3b,
-	0x1d, 0x6b, 0x6f, 0xdc, 0x6a, 0xf7, 0xc8, 0xc0, 0xf4, 0x0c, 0xf2, 0xea, 0x88, 0x78, 0x3e, 0xbe,
-	0x17, 0x1a, 0xe2, 0xdf, 0xb7, 0x3b, 0x1a, 0x3a, 0x8d, 0x1e, 0x68, 0x18, 0x51, 0x07, 0xde, 0x86,
-	0xba, 0xc7, 0xff, 0x5f, 0xab, 0x9c, 0x9e, 0x79, 0x60, 0x7e, 0xed, 0xff, 0x57, 0xa7, 0x9c, 0x70,
-	0x95, 0xcf, 0x63, 0x04, 0xe3, 0xf5, 0xe7, 0x61, 0x96, 0x77, 0xe1, 0x26, 0xcc, 0xf1, 0xce, 0x70,
-	0xc6, 0xb0, 0x8d, 0x35, 0xa8, 0x7b, 0xa3, 0xc1, 0xc0, 0x74, 0xc7, 0x5a, 0x85, 0xbd, 0x0a, 0x9a,
-	0xf8, 0x24, 0xcc, 0xf2, 0xff, 0xd2, 0x66, 0xd8, 0x0b, 0xd1, 0xd2, 0xf7, 0x60, 0x39, 0xb1, 0x30,
-	0x6f, 0xe8, 0xd8, 0x1e, 0xc1, 0xd7, 0x61, 0xce, 0x15, 0xcf, 0x6c, 0x9a, 0xf9, 0xb5, 0x87, 0xa7,
-	0x66, 0x3e, 0x20, 0x62, 0x84, 0x24, 0xf4, 0x57, 0xe1, 0xc4, 0x15, 0x62, 0xba, 0xfe, 0x2e, 0x31,
-	0xfd, 0x16, 0xf1, 0x03, 0xf9, 0xbd, 0x08, 0x0d, 0xcb, 0xf6, 0x7c, 0xd3, 0x6e, 0x13, 0x4f, 0x43,
-	0x4c, 0x46, 0xe7, 0xa7, 0x9e, 0x46, 0x26, 0x78, 0xb9, 0x4f, 0x06, 0xc4, 0xf6, 0x8d, 0x88, 0x9c,
-	0xde, 0x8a, 0x4f, 0x29, 0xfe, 0xe3, 0x2e, 0x2a, 0xbb, 0x0f, 0x20, 0xa0, 0xb0, 0xdd, 0x11, 0x42,
-	0x94, 0x7a, 0xf4, 0x8f, 0x11, 0x2c, 0xc5, 0x17, 0x52, 0x8a, 0xbc, 0xf0, 0x8e, 0x2c, 0x18, 0x6e,
-	0x3c, 0x8f, 0x4d, 0x4d, 0x6f, 0x5b, 0x8c, 0xbc, 0xb2, 0x6b, 0x78, 0x31, 0x91, 0x0c, 0x60, 0x21,
-	0xf6, 0xae, 0x98, 0x30, 0xe8, 0x7b, 0xe2, 0xba, 0xd7, 0x89, 0xe7, 0x99, 0x5d, 0x22, 0x0c, 0x4b,
-	0xea, 0xd1, 0x37, 0xa1, 0xd1, 0xf2, 0x5b, 0x9c, 0x1c, 0x5e, 0x82, 0x5a, 0xdb, 0x19, 0xd9, 0x3e,
-	0x9b, 0x66, 0xc6, 0xe0, 0x0d, 0x7c, 0x1a, 0xe6, 0x1d, 0xbb, 0x6f, 0xd9, 0x64, 0x93, 0xbd, 0xab,
-	0xb0, 0x77, 0x72, 0x97, 0xae, 0x03, 0xb4, 0xfc, 0x80, 0xeb, 0x6c, 0x2a, 0xfa, 0xff, 0x40, 0xad,
-	0xe5, 0xaf, 0x0f, 0x87, 0x07, 0xbc, 0xfe, 0x17, 0xa2, 0x34, 0x4c, 0xdf, 0xf2, 0x7c, 0xab, 0xed,
-	0xe1, 0x67, 0x60, 0x2e, 0x88, 0x03, 0x42, 0x55, 0x6b, 0xd3, 0xfb, 0x65, 0xb0, 0x1e, 0x23, 0xa4,
-	0x81, 0x9f, 0x8d, 0xeb, 0x8a, 0x12, 0x7c, 0x44, 0x81, 0x60, 0xb0, 0x36, 0x49, 0x51, 0x78, 0x03,
-	0xaa, 0xe6, 0x70, 0xe8, 0x31, 0x99, 0xce, 0xaf, 0xad, 0x2a, 0x50, 0x5b, 0x1f, 0x0e, 0x0d, 0x36,
-	0x56, 0x7f, 0x1b, 0xc1, 0xc9, 0x2d, 0x12, 0xf0, 0xeb, 0x6d, 0xdb, 0x7b, 0x4e, 0xe0, 0x76, 0x1a,
-	0xd4, 0x9d, 0xa1, 0x6f, 0x39, 0x36, 0x77, 0xba, 0x86, 0x11, 0x34, 0xa9, 0x00, 0xcd, 0xe1, 0x30,
-	0xd4, 0x36, 0x6f, 0x50, 0x2d, 0x89, 0xd9, 0x9e, 0x31, 0x07, 0x81, 0xa6, 0xe5, 0x2e, 0x6a, 0x48,
-	0x4c, 0xd6, 0x37, 0xec, 0xfe, 0x58, 0xab, 0x9e, 0x46, 0x0f, 0xcc, 0x19, 0x51, 0x87, 0xfe, 0xb3,
-	0x0a, 0xac, 0xa4, 0x58, 0x29, 0xc7, 0x71, 0x3a, 0xb0, 0x68, 0xf6, 0xfb, 0xc1, 0x4c, 0x97, 0x88,
-	0x6f, 0x5a, 0x7d, 0x65, 0x07, 0x12, 0xc3, 0xf9, 0x68, 0x23, 0x4d, 0x10, 0xb7, 0x00, 0xbc, 0xd0,
-	0xa0, 0x84, 0x96, 0x54, 0x74, 0x1e, 0x0c, 0x35, 0x24, 0x32, 0xfa, 0xa7, 0x08, 0x8e, 0x5d, 0xb7,
-	0xda, 0xae, 0x23, 0x26, 0xbb, 0x46, 0x58, 0xdc, 0xf6, 0x89, 0x6d, 0x0a, 0x8b, 0x6e, 0x18, 0xa2,
-	0x45, 0x35, 0x38, 0x74, 0x9d, 0x57, 0x48, 0xdb, 0x0f, 0x22, 0xbd, 0x68, 0x46, 0x1a, 0x9c, 0x99,
-	0xa0, 0xc1, 0x6a, 0x5a, 0x83, 0x1a, 0xd4, 0xf7, 0x89, 0xeb, 0x59, 0x8e, 0xad, 0xd5, 0x38, 0x45,
-	0xd1, 0xa4, 0x63, 0x89, 0xbd, 0x6f, 0xb9, 0x8e, 0x4d, 0x03, 0xa8, 0x36, 0xcb, 0xc7, 0x4a, 0x5d,
-	0x6c, 0xce, 0xbe, 0x65, 0x7a, 0x5a, 0x5d, 0xcc, 0x49, 0x1b, 0xfa, 0x9f, 0xeb, 0x70, 0x44, 0x5e,
-	0xcf, 0x5d, 0xa2, 0x4d, 0x5e, 0xd3, 0x93, 0x18, 0xaf, 0xa6, 0x18, 0xef, 0x10, 0xaf, 0xed, 0x5a,
-	0xcc, 0xb8, 0xc5, 0xb2, 0xe4, 0x2e, 0x3a, 0x67, 0x9f, 0xec, 0x93, 0xbe, 0x58, 0x14, 0x6f, 0xb0,
-	0x6d, 0x54, 0xec, 0xdb, 0x75, 0xee, 0x1e, 0xa2, 0x89, 0xaf, 0x42, 0x6d, 0x68, 0xfa, 0x3d, 0x4f,
-	0x03, 0x66, 0x51, 0x5f, 0x50, 0xb5, 0xa8, 0x9b, 0xa6, 0xdf, 0x33, 0x38, 0x09, 0xb6, 0x25, 0xfb,
-	0xa6, 0x3f, 0xf2, 0xb4, 0x39, 0xb1, 0x25, 0xb3, 0x16, 0x26, 0x00, 0x43, 0xd7, 0x19, 0x12, 0xd7,
-	0xb7, 0x88, 0xa7, 0x35, 0xd8, 0x44, 0x97, 0xa7, 0x9e, 0x48, 0x16, 0xf8, 0xea, 0xcd, 0x90, 0xce,
-	0x65, 0xdb, 0x77, 0xc7, 0x86, 0x44, 0x98, 0x2a, 0xc3, 0xb7, 0x06, 0xc4, 0xf3, 0xcd, 0xc1, 0x50,
-	0x9b, 0xe7, 0xca, 0x08, 0x3b, 0xe8, 0xfe, 0x33, 0x74, 0x9d, 0x7d, 0xab, 0x43, 0x5c, 0x4f, 0x3b,
-	0xa2, 0xe8, 0x3e, 0x97, 0xc8, 0x90, 0xd8, 0x1d, 0x62, 0xb7, 0xc7, 0xd7, 0xc8, 0xd8, 0x88, 0x08,
-	0x45, 0x76, 0xb2, 0x20, 0xd9, 0x09, 0x5d, 0xf0, 0xd3, 0x1b, 0x2d, 0xdf, 0x35, 0x7d, 0xd2, 0x1d,
-	0x6b, 0x47, 0x8b, 0x2c, 0x38, 0xa2, 0x23, 0x16, 0x1c, 0x75, 0x60, 0x1d, 0x8e, 0x0c, 0x9c, 0xce,
-	0x4e, 0xb8, 0xe6, 0x63, 0x8c, 0x87, 0x58, 0x5f, 0xd2, 0xd4, 0x8f, 0xa7, 0x4d, 0xfd, 0x3e, 0x00,
-	0x3e, 0x3d, 0x71, 0x37, 0xc6, 0xda, 0x22, 0xdf, 0xf3, 0xa2, 0x1e, 0xfc, 0x25, 0x68, 0xec, 0xb9,
-	0xe6, 0x80, 0xdc, 0x71, 0xdc, 0xdb, 0x1a, 0x66, 0x81, 0xe1, 0xec, 0xd4, 0x6b, 0x79, 0x8a, 0x8e,
-	0xbc, 0xe5, 0xb8, 0xb7, 0x85, 0xe2, 0xc6, 0x46, 0x44, 0xac, 0x79, 0x01, 0x8e, 0x25, 0xf4, 0x89,
-	0x8f, 0xc3, 0xcc, 0x6d, 0x32, 0x16, 0xae, 0x44, 0x1f, 0xa9, 0x84, 0xf7, 0xcd, 0xfe, 0x88, 0x04,
-	0x4e, 0xc4, 0x1a, 0x67, 0x2b, 0x67, 0x10, 0x1d, 0x9e, 0x90, 0x8e, 0xca, 0x70, 0x7d, 0x1d, 0x16,
-	0x53, 0xdc, 0x61, 0x0c, 0x55, 0x9b, 0x7a, 0x25, 0xa7, 0xc0, 0x9e, 0x65, 0x77, 0xac, 0xc4, 0xdc,
-	0x51, 0xff, 0x07, 0x82, 0xf9, 0x60, 0xf7, 0x1c, 0xf5, 0x09, 0x75, 0x00, 0x77, 0xd4, 0x8f, 0x62,
-	0x81, 0x68, 0xd1, 0x0c, 0x97, 0x3e, 0xed, 0x8c, 0x87, 0x01, 0x1f, 0x61, 0x9b, 0x5a, 0xad, 0xe9,
-	0xfb, 0xae, 0xb5, 0x3b, 0xf2, 0x83, 0x60, 0x10, 0x75, 0xb0, 0xa8, 0x68, 0xfa, 0x3e, 0x71, 0xc3,
-	0x50, 0x20, 0x9a, 0x53, 0x84, 0x82, 0x98, 0x3f, 0xcc, 0x26, 0xfd, 0x21, 0x69, 0x3c, 0xf5, 0xb4,
-	0xf1, 0xe8, 0xef, 0x22, 0x38, 0xb9, 0xde, 0xe9, 0xdc, 0x70, 0x9f, 0x1b, 0x76, 0x4c, 0x9f, 0xc8,
-	0x4b, 0x95, 0x97, 0x84, 0x26, 0x2d, 0xa9, 0x32, 0x61, 0x49, 0x33, 0x13, 0x97, 0x54, 0x4d, 0x2d,
-	0x49, 0xff, 0x24, 0x12, 0x38, 0x0d, 0x3c, 0x54, 0x5d, 0x34, 0xf4, 0x04, 0xea, 0xa2, 0xcf, 0xf8,
-	0xab, 0x30, 0x27, 0x82, 0xc2, 0x58, 0x6c, 0x93, 0x1b, 0x79, 0x82, 0x5a, 0x10, 0x6a, 0x84, 0xdf,
-	0x85, 0x34, 0x9b, 0xe7, 0x60, 0x21, 0xf6, 0x4a, 0xc9, 0xe8, 0xce, 0xc0, 0x5c, 0x98, 0x27, 0x60,
-	0xa8, 0xb6, 0x9d, 0x0e, 0x17, 0x5f, 0xcd, 0x60, 0xcf, 0x54, 0x38, 0x03, 0x91, 0x7d, 0x0a, 0x5b,
-	0x13, 0x4d, 0xfd, 0xef, 0x08, 0x4e, 0x6c, 0x11, 0xff, 0xf2, 0x6b, 0xd4, 0x2f, 0x69, 0x72, 0x25,
-	0x32, 0x1f, 0x0c, 0x55, 0x3f, 0x52, 0x02, 0x7b, 0x2e, 0x61, 0xe3, 0x89, 0x6d, 0x74, 0xb5, 0xe4,
-	0x46, 0x27, 0x9f, 0xe0, 0x66, 0x13, 0x27, 0xb8, 0x44, 0x00, 0xaa, 0xa7, 0x02, 0x90, 0xfe, 0x5b,
-	0x04, 0x4b, 0xf1, 0x95, 0x95, 0x93, 0x48, 0xc5, 0xd6, 0x50, 0x99, 0xb4, 0x86, 0x99, 0x83, 0x4f,
-	0xa1, 0xd5, 0xd8, 0x29, 0x54, 0xff, 0xd5, 0x0c, 0x2c, 0x6d, 0xba, 0x44, 0x72, 0x0e, 0xa1, 0x96,
-	0x1b, 0x50, 0x17, 0xb4, 0x05, 0xeb, 0x8f, 0xe6, 0x8a, 0xff, 0x46, 0x40, 0x05, 0x3f, 0x07, 0x35,
-	0xea, 0x60, 0xc1, 0xd9, 0xe9, 0xe2, 0xd4, 0xe4, 0xb2, 0x1d, 0xd8, 0xe0, 0xd4, 0xf0, 0x4b, 0x50,
-	0xf5, 0xcd, 0x2e, 0xcd, 0xf8, 0x28, 0xd5, 0xad, 0xa9, 0xa9, 0x66, 0x2d, 0x7a, 0x75, 0xc7, 0xec,
-	0x8a, 0x7d, 0x99, 0x11, 0xc5, 0x2f, 0xc9, 0xe7, 0x88, 0x2a, 0x9b, 0xe1, 0x42, 0x2e, 0x31, 0x64,
-	0x9c, 0x28, 0x9a, 0x8f, 0x43, 0x23, 0x9c, 0x4f, 0xc9, 0x07, 0xdf, 0x44, 0xb0, 0x9c, 0x60, 0xff,
-	0x73, 0x30, 0x38, 0xfd, 0x2a, 0x2c, 0x5d, 0x22, 0x7d, 0x92, 0xb2, 0x9c, 0xbb, 0xe6, 0x94, 0x7b,
-	0x8e, 0xdb, 0xe6, 0xcb, 0x9a, 0x33, 0x78, 0x43, 0xdf, 0x83, 0xe5, 0x04, 0xad, 0x72, 0x40, 0x8f,
-	0x87, 0x61, 0x31, 0x3a, 0xf5, 0x4c, 0xc5, 0xb0, 0xfe, 0x1b, 0x04, 0x58, 0x1e, 0x53, 0x8e, 0xa8,
-	0x25, 0x77, 0xab, 0x1c, 0x86, 0xbb, 0xe9, 0x4b, 0x32, 0xd7, 0x01, 0x3a, 0xa6, 0x7f, 0xc4, 0x83,
-	0x70, 0xd4, 0x5d, 0xce, 0x6a, 0x9e, 0x95, 0xce, 0xf3, 0xdc, 0xdd, 0x73, 0x2e, 0x27, 0x24, 0xa3,
-	0xff, 0x13, 0xc1, 0xa9, 0x58, 0x10, 0xa0, 0x7b, 0xd8, 0x94, 0xa8, 0x9f, 0x1b, 0xcb, 0xdf, 0x39,
-	0x43, 0xc6, 0xd4, 0x0c, 0x1d, 0x38, 0xeb, 0xa4, 0x64, 0xbe, 0x60, 0x6e, 0xa8, 0xdf, 0x86, 0x66,
-	0xd6, 0xbc, 0xe5, 0x78, 0xc5, 0x63, 0x32, 0x2c, 0x41, 0x83, 0xab, 0x37, 0xb5, 0x6b, 0xac, 0xa4,
-	0x06, 0x96, 0x63, 0x51, 0x57, 0xe3, 0xbb, 0x87, 0xf2, 0x31, 0x4f, 0xda, 0x32, 0xf4, 0x0f, 0x11,
-	0x68, 0xe9, 0xfd, 0x64, 0x2a, 0x4b, 0x8a, 0x12, 0xe4, 0x4a, 0x2c, 0x41, 0x6e, 0x41, 0x95, 0x3e,
-	0x09, 0xdc, 0xa1, 0xf0, 0xde, 0xc6, 0x88, 0xe9, 0xaf, 0x24, 0x2c, 0x9e, 0xb3, 0x59, 0x8e, 0x09,
-	0x7c, 0x8f, 0x67, 0xca, 0xca, 0x36, 0x50, 0xd2, 0xb6, 0xae, 0xbf, 0x81, 0x60, 0x25, 0xc5, 0x4f,
-	0x39, 0xa6, 0xa5, 0x41, 0xdd, 0x60, 0x5a, 0xe4, 0x6b, 0x68, 0x18, 0x41, 0x53, 0x6f, 0xc1, 0xa9,
-	0xf8, 0xae, 0x34, 0xbd, 0x58, 0x34, 0xa8, 0xbb, 0x71, 0xa2, 0xa2, 0x49, 0x3d, 0x3b, 0x8b, 0x68,
-	0x39, 0x6a, 0x7d, 0x14, 0x96, 0x23, 0x07, 0xa5, 0xd9, 0xc6, 0x74, 0x8e, 0xfd, 0xef, 0x18, 0x50,
-	0xc9, 0xc7, 0x95, 0x23, 0xfc, 0xaf, 0x88, 0xf4, 0x8d, 0x5b, 0xcf, 0xf6, 0xd4, 0xa4, 0xb2, 0xb9,
-	0x4b, 0x26, 0x70, 0xf9, 0x73, 0xac, 0x97, 0x61, 0x25, 0x66, 0x9b, 0x3b, 0x66, 0x77, 0x3a, 0xc5,
-	0x8b, 0x49, 0x2a, 0x19, 0x93, 0xcc, 0x48, 0x93, 0xe8, 0x56, 0x22, 0x06, 0xb1, 0x09, 0xca, 0x31,
-	0x82, 0xbf, 0x20, 0x58, 0x8e, 0x7c, 0x69, 0x6a, 0x2b, 0xc0, 0x5f, 0x8e, 0xe9, 0xe6, 0x8a, 0x8a,
-	0x67, 0xa7, 0xe7, 0x3a, 0x3c, 0xd5, 0x74, 0xe5, 0x48, 0x55, 0xa2, 0x6d, 0xea, 0x4f, 0x83, 0x16,
-	0xf3, 0xd4, 0xe9, 0x25, 0x87, 0xa1, 0x7a, 0x9b, 0x8c, 0x03, 0xd7, 0x67, 0xcf, 0x34, 0x9a, 0x67,
-	0x50, 0x2b, 0x87, 0xf3, 0x31, 0xcc, 0x5f, 0x21, 0x66, 0xdf, 0xef, 0x6d, 0xf6, 0x48, 0xfb, 0x36,
-	0x65, 0x67, 0x10, 0x1c, 0xd4, 0x1b, 0x06, 0x7b, 0x66, 0xc8, 0x83, 0xe3, 0x72, 0xac, 0xba, 0x66,
-	0xb0, 0x67, 0x7a, 0x84, 0xb4, 0x6c, 0x9f, 0xb8, 0xfb, 0x66, 0x9f, 0x19, 0x6b, 0xcd, 0x08, 0xdb,
-	0x54, 0x1f, 0x0c, 0x7b, 0x61, 0x07, 0xc8, 0x9a, 0xc1, 0x1b, 0x54, 0x6f, 0x23, 0xb7, 0x2f, 0x0e,
-	0xd4, 0xf4, 0x51, 0xff, 0x6b, 0x15, 0x96, 0xb2, 0x4e, 0x3e, 0x89, 0xab, 0x2b, 0x94, 0xba, 0xba,
-	0x9a, 0x7c, 0xba, 0xbd, 0x17, 0x1a, 0xc4, 0xee, 0x0c, 0x1d, 0xcb, 0xf6, 0xf9, 0x59, 0xaf, 0x61,
-	0x44, 0x1d, 0x94, 0xf1, 0x9e, 0xe3, 0xf9, 0x12, 0x90, 0x1e, 0xb6, 0x25, 0x50, 0xb7, 0x16, 0x03,
-	0x75, 0x07, 0xb1, 0xa4, 0x70, 0x96, 0xd9, 0xf8, 0xf5, 0x42, 0x87, 0xbb, 0x89, 0xe0, 0xee, 0xf3,
-	0x30, 0xdf, 0x8b, 0x54, 0xc2, 0x60, 0x04, 0x95, 0x34, 0x46, 0x52, 0xa7, 0x21, 0x13, 0x8a, 0x83,
-	0x64, 0x73, 0x49, 0x90, 0xec, 0x65, 0x38, 0xda, 0x31, 0x7d, 0x73, 0x93, 0x50, 0x35, 0x6e, 0xdb,
-	0x7b, 0x8e, 0xd6, 0x60, 0x13, 0x3f, 0x3e, 0x3d, 0x72, 0x1c, 0x1b, 0x6e, 0x24, 0xc8, 0xa5, 0x50,
-	0x38, 0x48, 0xa3, 0x70, 0x45, 0x53, 0xe1, 0x5d, 0x38, 0x1a, 0x67, 0x22, 0x13, 0xe4, 0xa4, 0x99,
-	0x19, 0xe9, 0x46, 0x18, 0xa7, 0x68, 0xe1, 0xfb, 0x61, 0xc1, 0xdc, 0x37, 0xad, 0xbe, 0xb9, 0xdb,
-	0x27, 0x2f, 0x3a, 0x76, 0x10, 0x85, 0xe3, 0x9d, 0xfa, 0x2d, 0x58, 0xc9, 0xd2, 0xe8, 0x35, 0x32,
-	0x2e, 0x66, 0xb7, 0xba, 0x0f, 0x2b, 0x86, 0x80, 0xa2, 0x43, 0x0c, 0x40, 0x84, 0x90, 0x17, 0xa8,
-	0xb7, 0xf1, 0x2e, 0xe1, 0xf3, 0x05, 0xb1, 0x85, 0x90, 0x9c, 0xfe, 0x6d, 0x04, 0x5a, 0x7a, 0xda,
-	0x72, 0x76, 0xf0, 0xbb, 0xdd, 0xcf, 0xbf, 0x00, 0xa7, 0x9e, 0xb3, 0xdd, 0x03, 0x64, 0x50, 0xec,
-	0xea, 0x9f, 0x1e, 0x92, 0x32, 0x48, 0x97, 0x13, 0x53, 0x6f, 0xc2, 0xf1, 0xb0, 0xcc, 0xe0, 0x70,
-	0xd8, 0xdf, 0x85, 0x45, 0x89, 0x62, 0x39, 0x5c, 0xff, 0x1a, 0xc1, 0xd2, 0x53, 0x96, 0xdd, 0x09,
-	0xa4, 0x13, 0x6e, 0x60, 0x0f, 0xc2, 0x62, 0xdb, 0xb1, 0xbd, 0xd1, 0x80, 0xb8, 0xad, 0xc4, 0x12,
-	0xd2, 0x2f, 0x72, 0x03, 0xb2, 0xa7, 0x61, 0x5e, 0x20, 0xb0, 0x34, 0xcd, 0x0d, 0x10, 0x71, 0xa9,
-	0x8b, 0xc1, 0xbf, 0x34, 0xc9, 0xa8, 0xf1, 0xad, 0x92, 0x3e, 0xeb, 0x7f, 0x44, 0xb0, 0x9c, 0x60,
-	0xba, 0x1c, 0xdb, 0x7d, 0x29, 0x5d, 0xd3, 0x71, 0x68, 0xf8, 0x9e, 0xfe, 0x11, 0x62, 0xc9, 0xf7,
-	0x0d, 0x9b, 0x24, 0xad, 0x5e, 0x4d, 0xf6, 0x0f, 0xc2, 0x62, 0x70, 0x5f, 0xd7, 0x4a, 0x04, 0x9a,
-	0xf4, 0x0b, 0xbc, 0x0a, 0x38, 0xe8, 0xdc, 0x8e, 0x8c, 0x8f, 0xab, 0x26, 0xe3, 0x4d, 0x28, 0xff,
-	0xaa, 0x24, 0xff, 0x3f, 0xf0, 0xf4, 0x3f, 0xc6, 0x79, 0x39, 0x0a, 0x90, 0x63, 0x60, 0xe5, 0x70,
-	0x63, 0xe0, 0x5b, 0x1c, 0xea, 0x2a, 0x68, 0xf8, 0x6a, 0xc2, 0xc7, 0x12, 0x18, 0x2d, 0x09, 0x73,
-	0x29, 0xce, 0xc7, 0x7f, 0xa1, 0x2d, 0x7b, 0x70, 0x0f, 0x3f, 0xad, 0x04, 0x2f, 0x5b, 0x2c, 0x89,
-	0x3a, 0x94, 0x38, 0x28, 0x65, 0x68, 0x33, 0x72, 0x86, 0xa6, 0x0f, 0xe0, 0xde, 0xec, 0x49, 0xcb,
-	0x09, 0x95, 0xef, 0x56, 0x02, 0xcc, 0x2d, 0x98, 0x4f, 0x01, 0x62, 0xbc, 0xdb, 0x1a, 0xbd, 0x58,
-	0xb6, 0xc9, 0x2f, 0x2b, 0x5a, 0x8a, 0x10, 0x64, 0x16, 0x5b, 0x65, 0x62, 0x90, 0xfd, 0xa4, 0xd2,
-	0x4b, 0x05, 0x21, 0xcf, 0xc3, 0xd2, 0x2d, 0xd3, 0x6f, 0xf7, 0x92, 0xc1, 0xf2, 0x7e, 0x58, 0xf0,
-	0x48, 0x7f, 0x2f, 0xe9, 0xab, 0xf1, 0x4e, 0xfd, 0xc3, 0x0a, 0x2c, 0x27, 0x86, 0x97, 0xe3, 0x66,
-	0x27, 0x61, 0xd6, 0x6c, 0xfb, 0x52, 0x9e, 0xc9, 0x5b, 0xf8, 0x2a, 0x17, 0x2c, 0x07, 0x00, 0xcf,
-	0xe4, 0x72, 0xbc, 0x6b, 0x64, 0xcc, 0x55, 0x22, 0x47, 0xc5, 0xea, 0xe1, 0x46, 0xc5, 0xa7, 0xe1,
-	0xf8, 0x16, 0xf1, 0x45, 0x2d, 0xeb, 0x54, 0x96, 0x2d, 0xdf, 0x2b, 0x56, 0xe2, 0xf7, 0x8a, 0x7a,
-	0x8b, 0x85, 0xd8, 0xf5, 0x7e, 0x5f, 0x85, 0xe0, 0x7d, 0x00, 0x77, 0x2c, 0xbf, 0xc7, 0x87, 0x88,
-	0x6b, 0x20, 0xa9, 0x47, 0xff, 0x09, 0xe2, 0x97, 0x34, 0x82, 0x64, 0x69, 0x6a, 0xf4, 0x22, 0x06,
-	0xc2, 0xea, 0x5b, 0x66, 0x6d, 0xec, 0xa9, 0x25, 0xee, 0x4b, 0xc5, 0x71, 0x21, 0xd6, 0xa9, 0xff,
-	0x92, 0xc7, 0x74, 0x69, 0xe1, 0xe5, 0x70, 0xb9, 0x25, 0x71, 0x99, 0xab, 0x5a, 0x39, 0x28, 0x2a,
-	0xbe, 0x01, 0x27, 0x04, 0xf8, 0x70, 0x48, 0x9a, 0x27, 0xe1, 0xe5, 0x5f, 0x99, 0x02, 0xd0, 0x5f,
-	0x47, 0x70, 0x42, 0xae, 0x86, 0x2e, 0xcc, 0xf8, 0x41, 0x65, 0xd7, 0x13, 0xae, 0xc8, 0x49, 0xbc,
-	0xd2, 0xbc, 0x3c, 0xcc, 0xe6, 0xe4, 0x7a, 0xa7, 0x13, 0x16, 0x6a, 0x59, 0x51, 0xc6, 0xf2, 0x32,
-	0x1c, 0xe9, 0x48, 0xdd, 0xa2, 0x2a, 0xfb, 0xdc, 0xf4, 0x57, 0xdd, 0x22, 0xab, 0x89, 0x8a, 0xc0,
-	0x8c, 0x18, 0x41, 0xbd, 0xc7, 0xb0, 0xf6, 0xf8, 0xd4, 0xe5, 0x2c, 0xf2, 0x6b, 0x70, 0x8a, 0xdf,
-	0x5c, 0x7f, 0x2e, 0xeb, 0xfc, 0x06, 0x82, 0x85, 0x58, 0x25, 0x5c, 0x74, 0xae, 0x41, 0x13, 0xce,
-	0x35, 0x95, 0x89, 0x85, 0x26, 0x33, 0x13, 0x4b, 0x33, 0xab, 0xe9, 0x72, 0x91, 0x4f, 0x10, 0xe0,
-	0x34, 0xab, 0xd8, 0x80, 0xb9, 0x20, 0xfd, 0x14, 0x92, 0xce, 0x5b, 0xde, 0x17, 0xd2, 0x89, 0xd7,
-	0x0c, 0x56, 0x0e, 0xa9, 0x66, 0x90, 0x1e, 0xbb, 0xb3, 0x94, 0x58, 0xe6, 0xdd, 0x64, 0x96, 0xb9,
-	0x4c, 0xbe, 0xc2, 0xf8, 0x3d, 0x82, 0xe6, 0x16, 0xf1, 0x37, 0x1d, 0xfb, 0x33, 0xe0, 0x12, 0xb7,
-	0xd2, 0x82, 0xce, 0x79, 0xe3, 0x2d, 0xc9, 0x59, 0x2c, 0xe1, 0xa6, 0xeb, 0x7c, 0x46, 0x4b, 0x08,
-	0xec, 0xa6, 0xe8, 0x12, 0x42, 0x3a, 0xfa, 0x4f, 0x67, 0x61, 0x21, 0x56, 0xba, 0x8d, 0x5f, 0x80,
-	0x23, 0x03, 0xe9, 0x9f, 0x8b, 0x15, 0x17, 0xc5, 0x48, 0x95, 0x7a, 0x02, 0xc2, 0xcf, 0xc2, 0xbc,
-	0xd8, 0x43, 0xec, 0x3d, 0x27, 0xc8, 0xe0, 0x95, 0xf7, 0x63, 0x99, 0x46, 0x74, 0xa7, 0x5d, 0x2d,
-	0x7c, 0xa7, 0x1d, 0x37, 0xc0, 0xda, 0xe1, 0x18, 0x60, 0xdc, 0x24, 0x66, 0x0f, 0xc7, 0x24, 0xf0,
-	0x8e, 0x38, 0x23, 0xd7, 0x19, 0xbd, 0x27, 0xf3, 0xfd, 0x02, 0x20, 0x55, 0xa9, 0xb5, 0x06, 0x4b,
-	0xb2, 0x2d, 0x3c, 0xcf, 0xa3, 0xb1, 0xa7, 0xcd, 0xb1, 0x93, 0x78, 0xe6, 0x3b, 0x7c, 0x1d, 0xea,
-	0xac, 0xd6, 0xbf, 0xed, 0x09, 0x54, 0x3c, 0xd7, 0xef, 0x05, 0x02, 0x1a, 0xf9, 0x2f, 0xb4, 0x3e,
-	0x46, 0xa0, 0x45, 0xf7, 0x99, 0xe2, 0x27, 0x0e, 0x25, 0x79, 0xf9, 0xcd, 0x64, 0x9d, 0x51, 0xde,
-	0x9f, 0x60, 0x84, 0x85, 0x46, 0x57, 0x01, 0x5f, 0x22, 0xfd, 0x44, 0xa1, 0x11, 0x4d, 0xf2, 0xc3,
-	0x58, 0x1c, 0xfc, 0xa4, 0x45, 0xea, 0x39, 0xa0, 0x0c, 0xcc, 0x88, 0xd3, 0xf2, 0x86, 0x0c, 0xee,
-	0x8f, 0xff, 0xa8, 0x09, 0x25, 0x7f, 0xd4, 0x74, 0x17, 0x04, 0xfe, 0x77, 0x88, 0xe5, 0xbe, 0x65,
-	0x97, 0x3c, 0xdd, 0x4a, 0x95, 0x3c, 0x9d, 0x53, 0xd8, 0x69, 0x93, 0x6b, 0x96, 0x0a, 0x9f, 0xd6,
-	0xe0, 0x28, 0x3d, 0x6a, 0x0c, 0x23, 0x24, 0x22, 0x91, 0x62, 0xa0, 0x74, 0x8a, 0xf1, 0x1a, 0x1c,
-	0x0b, 0xc7, 0x94, 0x77, 0x0c, 0xa6, 0xb9, 0x52, 0x70, 0xc7, 0x29, 0x5a, 0x6b, 0x7f, 0xbb, 0x27,
-	0x2c, 0x70, 0xde, 0xf4, 0xdd, 0x3e, 0x7e, 0x13, 0x41, 0x8d, 0xbc, 0x66, 0x79, 0x3e, 0x3e, 0xaf,
-	0x72, 0xb7, 0x9f, 0xac, 0x12, 0x6e, 0x5e, 0xc8, 0x39, 0x5a, 0xb0, 0xfb, 0x2d, 0x04, 0xb3, 0x6d,
-	0x96, 0xb3, 0xe0, 0x0b, 0x85, 0x4a, 0x44, 0x9b, 0x4f, 0xe4, 0x1d, 0x2e, 0x71, 0xd2, 0x61, 0x27,
-	0x27, 0x05, 0x4e, 0xb2, 0xea, 0x2c, 0x15, 0x38, 0xc9, 0x2e, 0xad, 0x7c, 0x1d, 0xc1, 0x6c, 0x97,
-	0xa1, 0xbc, 0xf8, 0x6c, 0x8e, 0xba, 0x8b, 0x80, 0x8d, 0x73, 0xb9, 0xc6, 0x0a, 0x1e, 0xde, 0x46,
-	0x30, 0xdf, 0x8d, 0xea, 0x11, 0x71, 0x1e, 0x62, 0x81, 0x5f, 0x34, 0xcf, 0xe7, 0x1b, 0x2c, 0x58,
-	0xf9, 0x00, 0xc1, 0xf1, 0x11, 0x83, 0xbb, 0x22, 0xcc, 0x0c, 0x6f, 0x14, 0xaf, 0x12, 0x6c, 0x6e,
-	0x16, 0xa2, 0x21, 0xb8, 0xfb, 0x2e, 0x82, 0xba, 0xd9, 0xe9, 0xb0, 0x2b, 0x93, 0x8b, 0x39, 0x2a,
-	0x31, 0xe4, 0xd2, 0xa5, 0xe6, 0x93, 0xf9, 0x09, 0x48, 0xec, 0x74, 0x89, 0xaf, 0xc8, 0x4e, 0x76,
-	0x91, 0xa1, 0x02, 0x3b, 0x07, 0x15, 0x1b, 0xfe, 0x00, 0x01, 0x70, 0xdd, 0x31, 0x8e, 0xd6, 0xf3,
-	0x49, 0x5c, 0x2a, 0x03, 0x6c, 0x6e, 0x14, 0x21, 0x21, 0xb8, 0xfa, 0x11, 0x02, 0xe0, 0xae, 0xce,
-	0xb8, 0xda, 0xc8, 0xe9, 0xaf, 0xb2, 0xa8, 0x36, 0x0b, 0xd1, 0x10, 0x7c, 0x7d, 0x87, 0xdb, 0x12,
-	0x4d, 0x56, 0xf0, 0x13, 0xc5, 0xaa, 0x7a, 0x9a, 0x17, 0x73, 0x8f, 0x97, 0x98, 0xe9, 0x12, 0x5f,
-	0x91, 0x99, 0xcc, 0xa2, 0xb6, 0xe6, 0xc5, 0x82, 0xe5, 0x63, 0xf8, 0xfb, 0x08, 0x1a, 0xdc, 0x8e,
-	0x76, 0xcc, 0x2e, 0x7e, 0x32, 0x9f, 0x0d, 0x44, 0xa5, 0x62, 0xcd, 0xf5, 0x02, 0x14, 0x24, 0xd3,
-	0xe6, 0x46, 0xc4, 0x44, 0xb4, 0x9e, 0xcf, 0x00, 0x64, 0x29, 0x6d, 0x14, 0x21, 0x21, 0xb8, 0xfa,
-	0x26, 0x82, 0x85, 0x6e, 0x80, 0xd1, 0xb2, 0x24, 0xed, 0x8b, 0x4a, 0xb2, 0x97, 0xc1, 0xbc, 0xe6,
-	0xd9, 0x3c, 0x43, 0x05, 0x23, 0xef, 0x21, 0x38, 0xde, 0x95, 0x90, 0x58, 0xc6, 0x8b, 0xd2, 0x46,
-	0x90, 0x44, 0xaf, 0xd5, 0x52, 0x8d, 0x34, 0x04, 0xfc, 0x0e, 0x82, 0x23, 0x1d, 0x09, 0x1a, 0x55,
-	0xe0, 0x26, 0x03, 0xa2, 0x6d, 0x5e, 0xc8, 0x39, 0x5a, 0xe2, 0x66, 0x20, 0xa1, 0x97, 0x0a, 0xdc,
-	0x64, 0xe0, 0xae, 0x0a, 0xdc, 0x64, 0x42, 0xa6, 0xef, 0x22, 0x58, 0x90, 0xb9, 0xf1, 0x70, 0x3e,
-	0x82, 0x9e, 0x7a, 0x0e, 0x94, 0xfd, 0x4d, 0x8d, 0x9f, 0x23, 0xf8, 0x5f, 0x33, 0x0e, 0x7d, 0x3e,
-	0xe5, 0xb8, 0xf2, 0xd1, 0xd5, 0x53, 0xdb, 0x6e, 0x33, 0x80, 0x2a, 0xb5, 0xed, 0x36, 0x13, 0xea,
-	0xf9, 0x05, 0x02, 0xbd, 0x9d, 0x82, 0xdc, 0x52, 0x9c, 0x6e, 0x28, 0xe6, 0xa6, 0x59, 0xcc, 0x6e,
-	0x16, 0xa2, 0x21, 0xf8, 0xfd, 0x31, 0x82, 0x95, 0x2e, 0x43, 0xae, 0x18, 0x92, 0x20, 0xff, 0x8f,
-	0x5a, 0xba, 0x50, 0x8c, 0xc3, 0x09, 0xe0, 0x99, 0xe0, 0x30, 0x85, 0xc3, 0x7e, 0xf6, 0x1c, 0x1e,
-	0x84, 0x50, 0xbe, 0x83, 0xe0, 0x68, 0x47, 0x0e, 0xc0, 0x2a, 0xd9, 0x71, 0xfa, 0x44, 0xde, 0x3c,
-	0x9f, 0x6f, 0x30, 0xe7, 0x66, 0xed, 0xd3, 0x79, 0x38, 0x91, 0x40, 0xc7, 0xd8, 0xf9, 0xee, 0x3d,
-	0x44, 0xcf, 0x95, 0xbc, 0x02, 0x4b, 0x61, 0xc3, 0x3c, 0xa0, 0x26, 0x4e, 0x61, 0xc3, 0x3c, 0xb0,
-	0xbc, 0x8d, 0x66, 0x5d, 0xa3, 0xb0, 0x2a, 0x4c, 0x25, 0x83, 0x3f, 0xa8, 0x4a, 0x4d, 0x25, 0x83,
-	0x3f, 0xb8, 0x1c, 0xed, 0x0d, 0x04, 0x8d, 0x5e, 0x50, 0xee, 0xa5, 0xb0, 0x5d, 0x26, 0x8b, 0xce,
-	0x14, 0xb6, 0xcb, 0x74, 0x75, 0xd9, 0x5b, 0x08, 0xaa, 0x7b, 0x96, 0xdd, 0x51, 0x88, 0xbb, 0x59,
-	0xd5, 0x63, 0x0a, 0x71, 0x37, 0xbb, 0x8e, 0x8b, 0x6e, 0x4b, 0x5d, 0xa9, 0x28, 0x46, 0x6d, 0xcb,
-	0x4e, 0xb1, 0x73, 0x21, 0xe7, 0x68, 0xc1, 0xcd, 0xfb, 0x08, 0x8e, 0x76, 0x63, 0xf5, 0x4e, 0x6a,
-	0xa9, 0x68, 0xba, 0xc4, 0x4b, 0x2d, 0x15, 0xcd, 0x2a, 0xb4, 0xfa, 0x00, 0xc1, 0x11, 0x9e, 0x8a,
-	0xf2, 0xaa, 0x17, 0x7c, 0x29, 0x67, 0xb5, 0x48, 0xac, 0x52, 0xa7, 0x79, 0xb9, 0x20, 0x15, 0xc1,
-	0xdd, 0x87, 0x08, 0xb4, 0x51, 0xaa, 0x36, 0x44, 0x1c, 0x9a, 0x37, 0x0f, 0xa1, 0xae, 0xa5, 0x79,
-	0xa9, 0x18, 0x91, 0x08, 0x5f, 0xa8, 0xdd, 0x31, 0xfd, 0x76, 0x4f, 0xc1, 0xe0, 0xb3, 0xaa, 0x50,
-	0x14, 0x0c, 0x3e, 0xb3, 0x0a, 0xe5, 0x21, 0xc4, 0x4c, 0xbe, 0x27, 0x7d, 0xa7, 0x0a, 0xe7, 0xfb,
-	0xac, 0x96, 0xba, 0xc9, 0x67, 0x7d, 0x1c, 0x6b, 0xed, 0x4f, 0x33, 0xb0, 0xb8, 0xe5, 0xec, 0x13,
-	0xd7, 0x96, 0xd1, 0xba, 0xf7, 0x79, 0x36, 0x1d, 0xbf, 0xb1, 0x29, 0x02, 0x0e, 0xad, 0xe7, 0x18,
-	0x9b, 0x00, 0xc0, 0x7f, 0x88, 0xe0, 0x58, 0x37, 0xfe, 0xa5, 0xa2, 0x5c, 0x90, 0x83, 0xfc, 0xb9,
-	0xa5, 0x5c, 0x90, 0x43, 0xfc, 0x23, 0x49, 0x6f, 0x72, 0xb6, 0xd6, 0x87, 0xc3, 0xbe, 0xd5, 0x36,
-	0xf9, 0xa7, 0x9a, 0x1e, 0x57, 0x3a, 0x39, 0x44, 0x88, 0x6e, 0xf3, 0x8c, 0xfa, 0x40, 0xce, 0xc6,
-	0xc6, 0x43, 0x30, 0xed, 0x17, 0xf3, 0x5e, 0xac, 0xb1, 0x2f, 0xec, 0xed, 0xce, 0xb2, 0x3f, 0x8f,
-	0xfc, 0x27, 0x00, 0x00, 0xff, 0xff, 0xc4, 0x56, 0xf4, 0x2b, 0x7a, 0x4f, 0x00, 0x00,
+	// 8809 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd5, 0x3d, 0x59, 0x90, 0x24, 0x47,
+	0x75, 0x51, 0x7d, 0xcc, 0xee, 0xe4, 0xde, 0xb9, 0x57, 0x6f, 0x6b, 0x75, 0xa5, 0xb0, 0x24, 0x06,
+	0x34, 0xb3, 0x5a, 0x1d, 0x7b, 0x4a, 0xab, 0x39, 0xf6, 0x94, 0xf6, 0x50, 0xcd, 0xec, 0x2e, 0x12,
+	0xc8, 0x72, 0x4d, 0x77, 0x4d, 0x4f, 0xb1, 0x3d, 0x5d, 0xad, 0xaa, 0xee, 0x59, 0x0d, 0xf2, 0x86,
+	0x0d, 0x18, 0x03, 0xc6, 0x07, 0x61, 0x7c, 0x60, 0x7f, 0xd8, 0x11, 0xc6, 0x40, 0x38, 0x8c, 0x09,
+	0x13, 0x60, 0x03, 0x81, 0x21, 0x0c, 0x01, 0x76, 0x18, 0x03, 0xc6, 0x81, 0x0c, 0x36, 0xd8, 0xc2,
+	0xe1, 0xb0, 0x0d, 0x36, 0xb6, 0x3f, 0xcc, 0xaf, 0x23, 0x70, 0x9e, 0x55, 0x99, 0x55, 0xd5, 0x3d,
+	0x95, 0x55, 0x5d, 0xbb, 0xe8, 0x6b, 0x3a, 0xb3, 0x26, 0x5f, 0xbe, 0x97, 0xc7, 0xcb, 0xf7, 0x5e,
+	0xbe, 0xf7, 0x12, 0x6c, 0xf5, 0x6d, 0x6f, 0xd5, 0x69, 0xd8, 0xfe, 0x64, 0xd7, 0x73, 0x7b, 0x2e,
+	0xbc, 0xaf, 0xe1, 0xae, 0x4c, 0x2e, 0xf7, 0xad, 0xeb, 0xb6, 0x33, 0xd9, 0xb5, 0x2c, 0x7f, 0xb2,
+	0xe1, 0xdb, 0x93, 0xfc, 0x7f, 0x3c, 0xbb, 0xe5, 0xf8, 0x3d, 0x6f, 0x6d, 0xd2, 0xea, 0x3a, 0xf5,
+	0xfd, 0x2d, 0xd7, 0x6d, 0xb5, 0xed, 0x29, 0xfc, 0x7b, 0xca, 0xea, 0x74, 0xdc, 0x9e, 0xd5, 0x73,
+	0xdc, 0x0e, 0x07, 0x83, 0xfe, 0xd0, 0x00, 0xbb, 0xce, 0xbb, 0x4d, 0x67, 0x69, 0x6d, 0xbe, 0xb1,
+	0x6c, 0xaf, 0x58, 0xbe, 0x69, 0xbf, 0xd0, 0xb7, 0xfd, 0x1e, 0xdc, 0x0f, 0xc6, 0x39, 0xb4, 0xb3,
+	0xcd, 0x9a, 0x71, 0x97, 0x71, 0xff, 0xb8, 0x19, 0x56, 0xc0, 0xb3, 0x60, 0x83, 0xcf, 0xfe, 0xbf,
+	0x56, 0xba, 0xab, 0x7c, 0xff, 0xa6, 0x83, 0x53, 0x93, 0x29, 0xf1, 0x99, 0x64, 0xfd, 0x98, 0xa2,
+	0x3d, 0x9c, 0x00, 0xdb, 0xed, 0x17, 0xbb, 0x76, 0xa3, 0x67, 0x37, 0x4d, 0x7b, 0xd5, 0xf1, 0x31,
+	0x72, 0xb5, 0x32, 0xed, 0x2f, 0x56, 0x8f, 0xae, 0x80, 0x31, 0xd6, 0x1c, 0xd6, 0xc1, 0x46, 0x06,
+	0x20, 0xc0, 0x2e, 0x28, 0xc3, 0x1a, 0x46, 0xae, 0xbf, 0xb2, 0x62, 0x79, 0x6b, 0x18, 0x39, 0xf2,
+	0x49, 0x14, 0xe1, 0x1e, 0x30, 0xc6, 0xfe, 0x8b, 0xf7, 0xc0, 0x4b, 0xe8, 0x6d, 0x06, 0xd8, 0x1d,
+	0x19, 0x05, 0xbf, 0x8b, 0x07, 0xc9, 0x86, 0xe7, 0xc1, 0x46, 0x8f, 0xff, 0xa6, 0xfd, 0x6c, 0x3a,
+	0xf8, 0x60, 0x6a, 0x4a, 0x05, 0x10, 0x33, 0x00, 0x41, 0xd0, 0xf6, 0x04, 0x91, 0x04, 0xb7, 0xb2,
+	0x19, 0x94, 0xd1, 0x0b, 0x60, 0xe7, 0x19, 0xdb, 0xf2, 0x7a, 0x8b, 0xb6, 0xd5, 0x9b, 0xb7, 0x7b,
+	0x62, 0x22, 0x9e, 0x05, 0xe3, 0x4e, 0xc7, 0xef, 0x59, 0x1d, 0x3c, 0xf7, 0x18, 0x05, 0x32, 0xd8,
+	0xc7, 0x53, 0xa3, 0x20, 0x03, 0x3c, 0xd9, 0xb6, 0x57, 0xec, 0x4e, 0xcf, 0x0c, 0xc1, 0xa1, 0xff,
+	0x32, 0xd4, 0x3e, 0xf9, 0xbf, 0xac, 0x33, 0xf9, 0x77, 0x00, 0x20, 0x40, 0xe0, 0xcf, 0x6c, 0x88,
+	0xa5, 0x1a, 0xf8, 0x1c, 0xa8, 0xe2, 0xdf, 0x3d, 0x1b, 0x0f, 0x32, 0xc1, 0xf6, 0x74, 0x1e, 0x6c,
+	0x27, 0xe7, 0x09, 0xa4, 0x93, 0x1d, 0xfc, 0x2f, 0x26, 0x83, 0x5a, 0x3f, 0x0c, 0x40, 0x58, 0x09,
+	0xb7, 0x83, 0xf2, 0x35, 0x7b, 0x8d, 0x23, 0x49, 0x7e, 0xc2, 0x5d, 0xa0, 0xba, 0x6a, 0xb5, 0xfb,
+	0x36, 0xc7, 0x8c, 0x15, 0x8e, 0x96, 0x0e, 0x1b, 0xe8, 0x33, 0x78, 0xb1, 0xab, 0x43, 0x5c, 0xcc,
+	0x2c, 0x2f, 0xc8, 0x53, 0xc6, 0xf6, 0xc7, 0xa3, 0xa9, 0xe1, 0x9d, 0xe5, 0x2d, 0xcf, 0x2c, 0x9a,
+	0xbe, 0x32, 0x59, 0x2b, 0x60, 0x8b, 0xf2, 0x2d, 0xe7, 0x2c, 0xe1, 0xef, 0xb6, 0xe7, 0x9d, 0xb7,
+	0x7d, 0xdf, 0x6a, 0xd9, 0x7c, 0x3f, 0x48, 0x35, 0xa8, 0x03, 0xb6, 0x3f, 0x69, 0xdb, 0xdd, 0xe9,
+	0xb6, 0xb3, 0x6a, 0xdf, 0x8c, 0xb5, 0xf8, 0x29, 0x03, 0xec, 0x90, 0x3a, 0x7c, 0x35, 0xcd, 0xcc,
+	0x2c, 0x18, 0x9f, 0xc7, 0x54, 0xd1, 0x16, 0x64, 0xf9, 0x35, 0xdc, 0x7e, 0xa7, 0x47, 0xd1, 0x2d,
+	0x9b, 0xac, 0x00, 0xef, 0x02, 0x9b, 0xdc, 0x4e, 0xdb, 0xe9, 0xd8, 0xb3, 0xf4, 0x1b, 0xdb, 0xfb,
+	0x72, 0x15, 0x7a, 0x9c, 0x2c, 0x6b, 0xd1, 0xc5, 0x00, 0x28, 0x98, 0x7d, 0x34, 0xac, 0xae, 0xd5,
+	0x70, 0x7a, 0x6b, 0x82, 0x7d, 0x88, 0x32, 0xba, 0x1d, 0x54, 0xe7, 0x7b, 0xd3, 0xdd, 0x6e, 0x72,
+	0x53, 0xf4, 0x43, 0x83, 0x6d, 0x1b, 0x4c, 0x8e, 0xd3, 0xf0, 0xe1, 0x05, 0xcc, 0x3f, 0xf9, 0x81,
+	0xc2, 0xc7, 0xf5, 0x60, 0x7a, 0x0e, 0x2e, 0x68, 0x35, 0x03, 0x18, 0xf0, 0x69, 0x75, 0x60, 0x09,
+	0xc0, 0x87, 0x34, 0x00, 0x0a, 0xba, 0xa5, 0x51, 0x85, 0x33, 0xa0, 0x62, 0x75, 0xbb, 0x3e, 0x5d,
+	0x9a, 0x9b, 0x0e, 0x4e, 0x6a, 0x40, 0xc3, 0xa3, 0x60, 0xd2, 0xb6, 0xe8, 0x5d, 0x06, 0xd8, 0x73,
+	0xda, 0x16, 0xf8, 0xfa, 0x67, 0x3b, 0x4b, 0xae, 0x58, 0xcb, 0xf8, 0x94, 0x70, 0xbb, 0xf4, 0x28,
+	0xa4, 0x2b, 0x19, 0x9f, 0x12, 0xbc, 0x48, 0x06, 0x10, 0x37, 0x0e, 0x36, 0x0d, 0x2b, 0x90, 0x19,
+	0xe4, 0xbd, 0x5d, 0xb0, 0x56, 0xc4, 0x86, 0x91, 0xab, 0xc8, 0x7e, 0xa4, 0x63, 0x7d, 0xb1, 0xd3,
+	0x5e, 0xab, 0x55, 0xf0, 0xf7, 0x8d, 0x66, 0x58, 0x81, 0x3e, 0x50, 0x02, 0x7b, 0x63, 0xa8, 0x14,
+	0xb3, 0xca, 0x9b, 0x60, 0x87, 0xd5, 0x6e, 0x8b, 0x9e, 0xe6, 0xec, 0x9e, 0xe5, 0xb4, 0xb5, 0x57,
+	0x3b, 0x6f, 0xce, 0x5a, 0x9b, 0x71, 0x80, 0x70, 0x1e, 0x00, 0x3f, 0x58, 0x50, 0x7c, 0x96, 0x74,
+	0xe6, 0x5c, 0x34, 0x35, 0x25, 0x30, 0xe8, 0xab, 0x06, 0xd8, 0x76, 0xde, 0x69, 0x78, 0x2e, 0xef,
+	0xec, 0x49, 0x9b, 0x9e, 0xda, 0x3d, 0xbb, 0x63, 0xf1, 0x15, 0x8d, 0x4f, 0x6d, 0x56, 0x22, 0x33,
+	0x88, 0x85, 0x98, 0x37, 0x63, 0x11, 0x41, 0x9c, 0xf3, 0xbc, 0x18, 0xce, 0x60, 0x79, 0xc8, 0x0c,
+	0x56, 0xe2, 0x33, 0x88, 0x21, 0xae, 0xda, 0x1e, 0x3d, 0x9d, 0xab, 0x0c, 0x22, 0x2f, 0x92, 0xb6,
+	0x76, 0x67, 0xd5, 0xf1, 0xdc, 0x0e, 0xe1, 0x5b, 0xb5, 0x31, 0xd6, 0x56, 0xaa, 0xa2, 0x7d, 0xb6,
+	0x1d, 0x2c, 0x10, 0x6d, 0xe0, 0x7d, 0x92, 0x02, 0x7a, 0x79, 0x1c, 0x6c, 0x96, 0xe9, 0x59, 0x87,
+	0x69, 0x67, 0x5d, 0x7a, 0x12, 0xe2, 0x95, 0x18, 0xe2, 0x4d, 0xdb, 0x6f, 0x78, 0x0e, 0x5d, 0xdc,
+	0x9c, 0x2c, 0xb9, 0x8a, 0xf4, 0xd9, 0xb6, 0x57, 0xed, 0x36, 0x27, 0x8a, 0x15, 0xa8, 0x10, 0xc5,
+	0x25, 0xbc, 0x0d, 0x6c, 0x7b, 0x08, 0x81, 0xed, 0x1c, 0xa8, 0x76, 0xad, 0xde, 0xb2, 0x5f, 0x03,
+	0x74, 0x45, 0x3d, 0xac, 0xbb, 0xa2, 0x2e, 0xe1, 0xc6, 0x26, 0x03, 0x41, 0x05, 0x32, 0x3c, 0xf9,
+	0x7d, 0xbf, 0xb6, 0x91, 0x0b, 0x64, 0xb4, 0x04, 0x6d, 0x00, 0xf0, 0x5c, 0x76, 0x6d, 0xaf, 0xe7,
+	0x60, 0x7e, 0x32, 0x4e, 0x3b, 0x3a, 0x99, 0xba, 0x23, 0x79, 0xc0, 0x27, 0x2f, 0x05, 0x70, 0x98,
+	0x14, 0x21, 0x01, 0x26, 0x93, 0xd1, 0x73, 0x56, 0x30, 0x37, 0xb0, 0x56, 0xba, 0xb5, 0x4d, 0x6c,
+	0x32, 0x82, 0x0a, 0x72, 0x58, 0xe0, 0xff, 0x5d, 0x75, 0x9a, 0x78, 0x28, 0x6b, 0x9b, 0x35, 0xb7,
+	0xcf, 0x9c, 0xdd, 0xb5, 0x3b, 0x4d, 0xbb, 0xd3, 0x58, 0xc3, 0x4b, 0xd8, 0x0c, 0x01, 0x85, 0xeb,
+	0x64, 0x8b, 0xb4, 0x4e, 0x08, 0xc1, 0x4f, 0xcd, 0xcc, 0xf7, 0x3c, 0x2c, 0xd7, 0xb4, 0xd6, 0x6a,
+	0x5b, 0xf3, 0x10, 0x1c, 0xc2, 0xe1, 0x04, 0x87, 0x15, 0x10, 0x81, 0xcd, 0x2b, 0x6e, 0x73, 0x21,
+	0xa0, 0x79, 0x1b, 0xc5, 0x41, 0xa9, 0x8b, 0x2e, 0xf5, 0xed, 0xf1, 0xa5, 0x8e, 0x45, 0x07, 0xd6,
+	0xbd, 0xed, 0xcd, 0xac, 0xd5, 0x76, 0x30, 0xd1, 0x21, 0xac, 0x81, 0x6f, 0x00, 0xe3, 0x4b, 0x1e,
+	0x5e, 0x96, 0xd7, 0x5d, 0xef, 0x5a, 0x0d, 0x52, 0xc6, 0x70, 0x34, 0x35, 0x2d, 0xa7, 0x48, 0xcb,
+	0xab, 0xb8, 0x25, 0x9f, 0x38, 0x3c, 0x78, 0x01, 0x30, 0x7c, 0xcc, 0x6c, 0x68, 0x58, 0x3d, 0xab,
+	0xed, 0xb6, 0x6a, 0x3b, 0x29, 0xdc, 0x43, 0xba, 0xab, 0x6f, 0x96, 0x35, 0x37, 0x05, 0x1c, 0x2c,
+	0xd3, 0x60, 0xd4, 0x7b, 0x8e, 0x47, 0x05, 0x92, 0xda, 0x2e, 0x4d, 0x6c, 0xc5, 0x49, 0x18, 0x40,
+	0x30, 0x25, 0x68, 0xf0, 0x19, 0xb0, 0x99, 0xed, 0x9a, 0x53, 0x9e, 0x6d, 0xbf, 0xc5, 0xae, 0xed,
+	0xa6, 0xd0, 0x1f, 0xd1, 0xd4, 0x95, 0x58, 0x63, 0x53, 0x01, 0x55, 0x7f, 0x0c, 0x6c, 0x8b, 0xac,
+	0x6c, 0x1d, 0x51, 0x98, 0x34, 0x8f, 0xac, 0x13, 0x2d, 0x49, 0x7a, 0x1a, 0xec, 0x88, 0xcd, 0x13,
+	0x84, 0xa0, 0xd2, 0x21, 0xfc, 0x89, 0x41, 0xa0, 0xbf, 0x65, 0xc6, 0x54, 0x52, 0x18, 0x13, 0x39,
+	0x9a, 0xb7, 0xaa, 0x73, 0x42, 0xfe, 0xb9, 0xe9, 0x36, 0xfc, 0xcb, 0x5e, 0x9b, 0xc3, 0x10, 0x45,
+	0xf2, 0xc5, 0xb3, 0xbb, 0x2e, 0xf9, 0xc2, 0xc1, 0xf0, 0x22, 0x5d, 0x8b, 0xfd, 0xce, 0xa2, 0xeb,
+	0x5e, 0x23, 0x1f, 0xb9, 0x18, 0x1b, 0xd6, 0x90, 0x15, 0xdf, 0xb4, 0xfc, 0xe5, 0x45, 0xd7, 0xf2,
+	0x9a, 0xe4, 0x3f, 0x18, 0x7b, 0x54, 0xea, 0xd0, 0x6f, 0x61, 0xd1, 0x33, 0x36, 0x91, 0x04, 0x72,
+	0xcf, 0xf2, 0x5a, 0x76, 0x6f, 0x8e, 0xe8, 0x32, 0x0c, 0x21, 0xa9, 0x86, 0xe0, 0xb4, 0xc2, 0xa5,
+	0x67, 0x8e, 0x13, 0x2f, 0xc2, 0xd7, 0x83, 0x1d, 0xf6, 0x8b, 0x8d, 0x76, 0xbf, 0x69, 0x9f, 0xf2,
+	0xdc, 0x95, 0xa7, 0xf0, 0x3f, 0xfb, 0x3d, 0x8a, 0xda, 0x46, 0x33, 0xfe, 0x41, 0x65, 0x42, 0x95,
+	0x08, 0x13, 0x42, 0xff, 0x64, 0x80, 0x4d, 0x02, 0xb7, 0x7e, 0xdb, 0x26, 0x1c, 0xd3, 0xc3, 0x7f,
+	0x83, 0xc3, 0x83, 0x97, 0xa8, 0x66, 0x89, 0x7f, 0x2d, 0xac, 0x75, 0x05, 0x3a, 0x41, 0x99, 0xf4,
+	0x60, 0xf5, 0x7a, 0x9e, 0xb3, 0xd8, 0xef, 0x89, 0xd3, 0x23, 0xac, 0xa0, 0xc7, 0x28, 0x2e, 0xd9,
+	0x5e, 0x70, 0x76, 0xf0, 0x62, 0x8a, 0xb3, 0x43, 0xc1, 0x7d, 0x2c, 0xca, 0x40, 0xa3, 0xdc, 0x66,
+	0x43, 0x9c, 0xdb, 0xa0, 0x5f, 0xc6, 0x12, 0xda, 0x74, 0xb3, 0x79, 0xd1, 0xbb, 0xdc, 0x6d, 0xe2,
+	0xf1, 0x90, 0x49, 0x95, 0x49, 0x32, 0x86, 0x91, 0x54, 0x1a, 0x42, 0x52, 0x79, 0x28, 0x49, 0x95,
+	0x18, 0x49, 0xe8, 0x73, 0xe1, 0x80, 0x93, 0x93, 0x8a, 0xac, 0x6a, 0x72, 0x56, 0x89, 0x55, 0x4d,
+	0x7e, 0xc3, 0x9f, 0x04, 0x1b, 0xf9, 0x29, 0xb2, 0xc6, 0xe5, 0xaa, 0x99, 0x2c, 0xa7, 0xa0, 0x38,
+	0x9b, 0x38, 0xa3, 0x0e, 0x60, 0xd6, 0x8f, 0x81, 0x2d, 0xca, 0x27, 0xad, 0xbd, 0x89, 0x37, 0xd6,
+	0xc6, 0x40, 0xb2, 0xc4, 0xd8, 0x37, 0xdc, 0x26, 0x1b, 0xbf, 0xaa, 0x49, 0x7f, 0x0f, 0x59, 0xb8,
+	0x17, 0xf0, 0x06, 0xa4, 0xc2, 0x9d, 0xcf, 0x75, 0xf7, 0xf4, 0x87, 0xfb, 0x49, 0xcf, 0x73, 0x3d,
+	0x2e, 0x2c, 0x0a, 0x20, 0xe8, 0x1d, 0x78, 0x2c, 0xa5, 0x0f, 0x89, 0xd8, 0x60, 0x42, 0x96, 0x1c,
+	0xbb, 0x1d, 0x88, 0x3c, 0xb4, 0x40, 0x97, 0xb9, 0x6d, 0xf9, 0x81, 0x2d, 0x88, 0x97, 0xc8, 0xa6,
+	0x6c, 0x60, 0xc2, 0x30, 0xe3, 0x72, 0x30, 0xb7, 0x66, 0xd3, 0x27, 0xd5, 0x84, 0xc3, 0x52, 0x95,
+	0x86, 0x05, 0x7d, 0xcb, 0x00, 0x3b, 0xb1, 0xec, 0x7d, 0xf2, 0x45, 0x72, 0x42, 0x11, 0x35, 0x83,
+	0xeb, 0x00, 0x18, 0x9f, 0x5e, 0xb8, 0xba, 0xe8, 0xef, 0x02, 0x44, 0x30, 0x45, 0xe4, 0xab, 0x46,
+	0x45, 0x3e, 0xd9, 0x92, 0x35, 0x16, 0xb1, 0x64, 0x45, 0x8e, 0xe2, 0x0d, 0xb1, 0xa3, 0x18, 0x7d,
+	0xda, 0x00, 0xbb, 0x54, 0xca, 0x8a, 0x51, 0x29, 0x14, 0x1a, 0x4a, 0xc3, 0x68, 0x28, 0x0f, 0xb6,
+	0xc6, 0x55, 0x14, 0x6b, 0x1c, 0xea, 0x82, 0xda, 0x8c, 0xd5, 0x6b, 0x2c, 0x27, 0xcd, 0xcc, 0x82,
+	0xa2, 0x9f, 0x92, 0xa5, 0x78, 0x38, 0x93, 0x34, 0x44, 0x84, 0xaf, 0x00, 0x12, 0xfa, 0xbc, 0x01,
+	0xf6, 0x25, 0x74, 0x59, 0xcc, 0x90, 0x5d, 0x96, 0x48, 0x60, 0x4c, 0xe2, 0x88, 0x2e, 0x93, 0x08,
+	0x71, 0x0c, 0x69, 0xf8, 0x39, 0x03, 0x6c, 0x8f, 0x7e, 0x86, 0x26, 0x1e, 0x64, 0x56, 0xc7, 0x31,
+	0xcf, 0x3e, 0x5a, 0x02, 0xd0, 0xf0, 0x29, 0x47, 0x1f, 0x2b, 0x83, 0x5d, 0xb3, 0x78, 0x53, 0x86,
+	0x2c, 0x9b, 0xcf, 0xdc, 0xc5, 0x28, 0x2a, 0x8f, 0x64, 0x42, 0x25, 0xc4, 0xe3, 0x32, 0xa8, 0x12,
+	0xb6, 0x2f, 0x06, 0xf1, 0x44, 0x6a, 0x70, 0xc9, 0xc7, 0x8a, 0xc9, 0xa0, 0xc1, 0x37, 0xe2, 0xbd,
+	0x6f, 0xb5, 0x7c, 0x6d, 0x23, 0x65, 0x12, 0xd1, 0x93, 0x0b, 0x18, 0x12, 0x63, 0xe2, 0x14, 0x28,
+	0x06, 0x2e, 0x99, 0x43, 0x2a, 0xb4, 0x87, 0xc7, 0x32, 0x0d, 0x43, 0x82, 0x61, 0xa4, 0x7e, 0x08,
+	0x8c, 0x07, 0xfd, 0x69, 0x9d, 0x0c, 0x78, 0xe9, 0xec, 0x8e, 0xa0, 0x7f, 0x0b, 0xb8, 0x05, 0x3a,
+	0x07, 0x76, 0xcd, 0xd9, 0x6d, 0x3b, 0xb6, 0x72, 0xd6, 0x55, 0x8d, 0x97, 0x5c, 0xaf, 0xc1, 0xc8,
+	0xda, 0x68, 0xb2, 0x02, 0x5a, 0x02, 0xbb, 0x23, 0xb0, 0x0a, 0xa1, 0x08, 0x3d, 0x08, 0x76, 0x84,
+	0xc6, 0x9b, 0x54, 0x08, 0xa3, 0x4f, 0x18, 0x00, 0xca, 0x6d, 0x8a, 0x19, 0x6a, 0x69, 0xbb, 0x95,
+	0x46, 0xb1, 0xdd, 0xd0, 0xa3, 0x32, 0xd6, 0xc1, 0x75, 0x50, 0xe4, 0xfc, 0x33, 0x62, 0xe7, 0x1f,
+	0xfa, 0x24, 0x3b, 0x63, 0xc3, 0x86, 0xc5, 0xd0, 0xfb, 0x74, 0x8c, 0xab, 0x66, 0x24, 0x38, 0xe4,
+	0xa8, 0x1f, 0x2d, 0x81, 0x7d, 0x0a, 0x9b, 0x20, 0xb2, 0x57, 0xca, 0x8b, 0x30, 0x4f, 0x31, 0x54,
+	0x30, 0x84, 0xcc, 0xd4, 0x08, 0x0d, 0xec, 0x75, 0xa8, 0xd5, 0x02, 0xef, 0x84, 0x15, 0xdb, 0xe3,
+	0x46, 0x7b, 0xbc, 0x13, 0x68, 0x81, 0xdc, 0xa3, 0x61, 0xc5, 0xc5, 0x5d, 0xb5, 0xc3, 0xa6, 0x94,
+	0xf3, 0x8c, 0x9b, 0xb1, 0xfa, 0x9c, 0xca, 0x23, 0xba, 0x06, 0xea, 0x49, 0x98, 0x17, 0xb3, 0xf3,
+	0xb0, 0x82, 0x70, 0x9b, 0xd2, 0x9b, 0xd0, 0xe0, 0x53, 0xcd, 0x8f, 0x64, 0x30, 0x28, 0x8d, 0xc6,
+	0x60, 0x80, 0x56, 0xc0, 0xfe, 0x64, 0x7c, 0x8a, 0xa1, 0xff, 0xb7, 0x0d, 0x70, 0x87, 0x7a, 0x88,
+	0x85, 0xb6, 0x86, 0x54, 0x43, 0xa0, 0x1a, 0x38, 0x4a, 0xa3, 0x34, 0x70, 0x60, 0x11, 0xee, 0xce,
+	0x81, 0xb8, 0x15, 0x33, 0x1c, 0x8f, 0xca, 0x06, 0x7d, 0x72, 0x9e, 0xfb, 0xa9, 0xb9, 0xf1, 0xde,
+	0x58, 0xc3, 0x62, 0x58, 0xd4, 0x39, 0x55, 0x60, 0xd1, 0x36, 0x90, 0x4a, 0x52, 0x0a, 0xfa, 0xa0,
+	0x01, 0x6a, 0x71, 0x11, 0x26, 0xd5, 0xbc, 0x87, 0x96, 0x82, 0x92, 0x62, 0x29, 0x98, 0x07, 0x15,
+	0xf2, 0x8b, 0x5b, 0xec, 0x73, 0x8b, 0x53, 0x14, 0x18, 0x7a, 0x73, 0x84, 0x85, 0x32, 0x34, 0x8b,
+	0x59, 0x02, 0xbf, 0xc4, 0x4c, 0x06, 0xda, 0x6b, 0xa0, 0x20, 0x49, 0x92, 0x78, 0x0f, 0xec, 0x8d,
+	0xe1, 0x53, 0xcc, 0xd2, 0xc2, 0xca, 0x94, 0x49, 0x67, 0x91, 0xd1, 0x80, 0x95, 0x29, 0x5e, 0x44,
+	0xf3, 0x60, 0x9f, 0x2a, 0x08, 0xa5, 0x1f, 0x16, 0x62, 0x5c, 0x53, 0x81, 0xf2, 0x22, 0x61, 0xf4,
+	0x49, 0x40, 0x8b, 0x99, 0xd6, 0x3f, 0x30, 0x40, 0xdd, 0xb4, 0xbb, 0x6d, 0xab, 0x61, 0xff, 0xb8,
+	0x4c, 0x2d, 0xd9, 0x43, 0x4d, 0x7c, 0xfa, 0xf6, 0x3b, 0xfc, 0xac, 0xe5, 0x25, 0xf4, 0x4d, 0x7c,
+	0x28, 0x25, 0xe2, 0x5a, 0xcc, 0xb4, 0x5f, 0xc0, 0xa7, 0xd8, 0xb2, 0xd5, 0x69, 0x65, 0xe0, 0x29,
+	0xd3, 0xdd, 0x6e, 0x7b, 0x6d, 0x96, 0x36, 0x36, 0x05, 0x10, 0x79, 0xc6, 0xcb, 0xea, 0x8c, 0x3f,
+	0x02, 0x76, 0x87, 0x5c, 0x92, 0x68, 0x19, 0xe9, 0xb8, 0xeb, 0x8f, 0x94, 0x7b, 0x56, 0xd6, 0xae,
+	0x98, 0xa1, 0x78, 0x8e, 0xab, 0x6d, 0x6c, 0x1c, 0xce, 0xa6, 0x06, 0x95, 0x8c, 0x5d, 0x54, 0x71,
+	0xcb, 0xae, 0x5b, 0x3d, 0x0f, 0xf6, 0x2a, 0xab, 0x08, 0x43, 0x49, 0xb7, 0x72, 0x79, 0x27, 0xa5,
+	0x84, 0x4e, 0xca, 0xb2, 0x0d, 0xcb, 0x89, 0x1c, 0x04, 0xb4, 0x83, 0x62, 0x76, 0xe2, 0x57, 0xb0,
+	0x9e, 0x18, 0x32, 0xb4, 0xd4, 0xab, 0x00, 0xbe, 0x49, 0x99, 0x9b, 0x33, 0x3a, 0x7b, 0x30, 0xde,
+	0xd7, 0xe8, 0xa6, 0xa6, 0x25, 0x1f, 0x17, 0x05, 0xae, 0x4d, 0xf4, 0x14, 0xa8, 0x29, 0xec, 0x32,
+	0xfd, 0xc8, 0x41, 0x50, 0xc1, 0x34, 0x08, 0xfe, 0x4b, 0x7f, 0x93, 0x23, 0x35, 0x01, 0x5a, 0x31,
+	0x98, 0xff, 0x47, 0x19, 0x6c, 0x9b, 0x73, 0xfc, 0x06, 0x56, 0x13, 0xbc, 0xb5, 0x4b, 0x6e, 0xdb,
+	0x69, 0xb0, 0xbb, 0x42, 0xeb, 0xc5, 0xb3, 0x92, 0xbf, 0x0f, 0x31, 0xda, 0x2a, 0x75, 0xf0, 0x05,
+	0xb0, 0xa5, 0xeb, 0xd9, 0x4b, 0xb6, 0xe7, 0xd9, 0xcd, 0x85, 0x70, 0xea, 0x9f, 0x4c, 0x7f, 0x4d,
+	0xaa, 0x76, 0x8a, 0xf5, 0x1e, 0x09, 0x1a, 0x9b, 0x7d, 0xb5, 0x07, 0x78, 0x23, 0xb8, 0x5c, 0x91,
+	0x14, 0x1d, 0x66, 0xc4, 0xb9, 0x98, 0xb9, 0xdb, 0x93, 0x51, 0x88, 0xac, 0xeb, 0x78, 0x4f, 0x64,
+	0x54, 0x3a, 0x6e, 0x78, 0xb9, 0xcb, 0xfd, 0x3c, 0x94, 0x3a, 0xb2, 0x14, 0x5d, 0xaf, 0x69, 0x7b,
+	0xc2, 0x08, 0x4d, 0x0b, 0xf5, 0x27, 0x00, 0x8c, 0x53, 0xa7, 0x75, 0x69, 0x37, 0x07, 0xf6, 0x24,
+	0x23, 0xaa, 0xa9, 0xbd, 0xed, 0xc3, 0xcc, 0x30, 0x32, 0x02, 0xa9, 0x45, 0xca, 0xa6, 0xbb, 0x62,
+	0x39, 0xe2, 0x32, 0x8f, 0x97, 0x64, 0x4f, 0x8c, 0xb2, 0xe2, 0x89, 0x81, 0x3e, 0x8b, 0x0f, 0xf5,
+	0xa4, 0xde, 0x8a, 0x39, 0x1c, 0x2e, 0x81, 0xb1, 0x2e, 0xed, 0x80, 0xab, 0x39, 0x87, 0xb3, 0x2e,
+	0x08, 0x93, 0xc3, 0x41, 0x7f, 0x6e, 0x08, 0x6d, 0x2f, 0xd3, 0x80, 0x8d, 0x1c, 0x21, 0x69, 0x0a,
+	0xca, 0x83, 0xa6, 0xa0, 0xa2, 0x4e, 0x41, 0x07, 0xdc, 0x3e, 0x80, 0x82, 0x62, 0x78, 0x49, 0x07,
+	0xec, 0x67, 0x7c, 0xeb, 0x26, 0x2d, 0x31, 0x4c, 0xdf, 0x80, 0xfe, 0x8a, 0xa1, 0x6f, 0x0d, 0x6c,
+	0x3a, 0x63, 0x5b, 0xed, 0xde, 0xf2, 0xec, 0xb2, 0xdd, 0xb8, 0x46, 0x58, 0xf7, 0x8a, 0xb8, 0xd3,
+	0xc2, 0xac, 0x9b, 0xfc, 0xa6, 0x77, 0x86, 0xae, 0xc7, 0x94, 0xed, 0xaa, 0x49, 0x7f, 0x93, 0x3b,
+	0x12, 0xa7, 0xd3, 0xc3, 0x5d, 0x58, 0xec, 0x9a, 0xba, 0x6a, 0x06, 0x65, 0xb2, 0x59, 0xe9, 0xad,
+	0x29, 0x9d, 0xba, 0xaa, 0xc9, 0x0a, 0x64, 0x53, 0xf7, 0xbd, 0x36, 0x67, 0x22, 0xe4, 0x27, 0xfa,
+	0xfe, 0x06, 0xb0, 0x2b, 0xc9, 0x3a, 0x1c, 0x71, 0xf6, 0x34, 0x62, 0xce, 0x9e, 0xc3, 0xaf, 0x6f,
+	0xf0, 0x57, 0xcc, 0xba, 0xba, 0x2e, 0xc6, 0x47, 0x08, 0x84, 0x61, 0x05, 0x41, 0x7c, 0xd9, 0xf5,
+	0x7b, 0x92, 0xcf, 0x54, 0x50, 0x96, 0xfc, 0x77, 0xaa, 0x8a, 0xff, 0xce, 0x8a, 0x62, 0x16, 0x1b,
+	0xa3, 0xdc, 0xf9, 0x7c, 0x2e, 0x03, 0xf8, 0x50, 0x8b, 0xd8, 0x15, 0xb0, 0x69, 0x39, 0x9c, 0x12,
+	0x7a, 0x4f, 0xa6, 0x23, 0x23, 0x4b, 0xd3, 0x69, 0xca, 0x80, 0xd4, 0xeb, 0xed, 0x8d, 0xd1, 0xeb,
+	0xed, 0xe7, 0xc1, 0x56, 0xbc, 0xad, 0xac, 0x59, 0x9b, 0x4c, 0x23, 0xf1, 0xe7, 0xab, 0x8d, 0x6b,
+	0x9a, 0x98, 0xe6, 0x94, 0xe6, 0x66, 0x04, 0x5c, 0xec, 0xfe, 0x1c, 0x24, 0x78, 0xeb, 0x10, 0x17,
+	0x13, 0x3a, 0xe6, 0x26, 0xbb, 0x2e, 0xdd, 0xa4, 0xeb, 0x62, 0x22, 0x35, 0x36, 0x15, 0x50, 0x64,
+	0xdf, 0x60, 0x0d, 0xa7, 0xb7, 0xe4, 0x7a, 0x2b, 0xb5, 0xcd, 0x9a, 0xfb, 0xe6, 0x12, 0x6f, 0x68,
+	0x06, 0x20, 0x14, 0xe7, 0xd5, 0x2d, 0x6c, 0x03, 0x88, 0x32, 0xa1, 0xd4, 0x6a, 0xf4, 0x9c, 0x55,
+	0xcc, 0xa5, 0x08, 0x69, 0xb5, 0xad, 0x8c, 0x52, 0xb9, 0x0e, 0x3e, 0x25, 0xdc, 0xca, 0xb7, 0x51,
+	0x5c, 0xf4, 0xfd, 0x76, 0xa9, 0xd7, 0x38, 0xf7, 0x22, 0xc7, 0x93, 0xb7, 0x45, 0x2c, 0x71, 0x32,
+	0xd6, 0x7e, 0x6d, 0xbb, 0xe6, 0x15, 0x9d, 0x80, 0x7a, 0x92, 0x43, 0x31, 0x55, 0x78, 0x79, 0x6d,
+	0xac, 0x93, 0x60, 0xa3, 0x18, 0x43, 0xb8, 0x15, 0x94, 0x5c, 0x9f, 0x37, 0xc3, 0xbf, 0x08, 0x7b,
+	0xb1, 0xbc, 0xc6, 0x32, 0x6f, 0x44, 0x7f, 0xa3, 0x67, 0xc1, 0x66, 0x79, 0x2a, 0x95, 0xab, 0xf6,
+	0xf1, 0x75, 0x2f, 0xfe, 0x95, 0x85, 0x5e, 0x8e, 0xfa, 0xa0, 0x2c, 0x82, 0xad, 0xea, 0x4a, 0x4d,
+	0x74, 0xf5, 0xa1, 0x57, 0xf6, 0xad, 0xd0, 0xd3, 0x87, 0x97, 0xe0, 0x6b, 0xc0, 0x16, 0x6b, 0xd5,
+	0x72, 0xda, 0xd6, 0x62, 0xdb, 0x7e, 0xd6, 0xed, 0x08, 0xb5, 0x46, 0xad, 0x44, 0x57, 0xc1, 0xde,
+	0xa4, 0x6d, 0x4f, 0xfc, 0x3f, 0x73, 0x31, 0x37, 0xd4, 0x03, 0x7b, 0x4d, 0xee, 0x9a, 0x16, 0x5c,
+	0xa6, 0xf1, 0x93, 0xe8, 0x19, 0xc2, 0x92, 0x59, 0x15, 0x3f, 0x18, 0x72, 0x5e, 0xd2, 0x05, 0xe0,
+	0xd0, 0xbb, 0x0d, 0x50, 0x8b, 0x77, 0x5b, 0x8c, 0xd4, 0xb3, 0x8e, 0xa7, 0x3f, 0x7a, 0x06, 0xec,
+	0xbb, 0xdc, 0xf1, 0x06, 0x8c, 0x41, 0xae, 0x20, 0x02, 0x7a, 0x13, 0x90, 0x00, 0xba, 0x98, 0x83,
+	0xf7, 0xdf, 0x0d, 0xb0, 0x3d, 0x08, 0x22, 0x18, 0x09, 0xfe, 0xf0, 0x59, 0x35, 0x54, 0x65, 0x4e,
+	0x3f, 0x98, 0x41, 0x68, 0xab, 0xa3, 0x8c, 0x53, 0x59, 0x04, 0x3b, 0x24, 0xf8, 0xc5, 0x0c, 0xe6,
+	0xaf, 0x54, 0xc0, 0xae, 0x53, 0x4e, 0xa7, 0x19, 0xe8, 0x72, 0x62, 0x40, 0x5f, 0x0f, 0x76, 0x10,
+	0x7f, 0x9a, 0xfe, 0x8a, 0xed, 0xcd, 0x47, 0x06, 0x36, 0xfe, 0x21, 0xb3, 0xb7, 0x0c, 0xfe, 0x0f,
+	0xee, 0x1e, 0x43, 0x0c, 0x67, 0xc2, 0x0f, 0x4b, 0xaa, 0xa2, 0xbe, 0x39, 0x44, 0xa3, 0xac, 0x32,
+	0x95, 0x98, 0x5e, 0xab, 0x47, 0x95, 0xaf, 0xb1, 0x04, 0xe5, 0xeb, 0x5e, 0xb0, 0xf5, 0xba, 0xd3,
+	0x5b, 0x3e, 0x4d, 0x24, 0xc1, 0x0e, 0xdd, 0xda, 0x1b, 0xe8, 0x7f, 0x45, 0x6a, 0x95, 0xd3, 0x6d,
+	0x63, 0xfe, 0xd3, 0x0d, 0x77, 0x2b, 0x7e, 0x33, 0xf1, 0x93, 0x0a, 0x03, 0xe3, 0x66, 0xa4, 0x36,
+	0xd4, 0x0d, 0x81, 0xa4, 0x1b, 0x12, 0x62, 0xdf, 0x42, 0x58, 0x23, 0xf3, 0x41, 0xa6, 0xbf, 0x29,
+	0xdf, 0x6c, 0x36, 0xf1, 0x84, 0xf9, 0xa7, 0xac, 0x15, 0xa7, 0xbd, 0x46, 0xcf, 0x60, 0xc2, 0x37,
+	0xe5, 0x4a, 0xb2, 0xfe, 0x69, 0x24, 0x5f, 0xc3, 0x6d, 0x13, 0x97, 0x62, 0x2a, 0xbb, 0x05, 0x15,
+	0xe4, 0x52, 0x10, 0xb3, 0x29, 0x1f, 0x9f, 0x3f, 0xa1, 0x17, 0xd1, 0x56, 0x3a, 0x1c, 0xb1, 0x7a,
+	0xf4, 0xfe, 0x32, 0xd8, 0x1d, 0x59, 0x11, 0xc5, 0xf0, 0xab, 0x37, 0xc6, 0x83, 0x70, 0x46, 0xe6,
+	0x1c, 0x81, 0x79, 0x3a, 0x68, 0x85, 0x53, 0x5f, 0xd6, 0x3c, 0xd4, 0xc3, 0xf5, 0x31, 0xeb, 0x76,
+	0x96, 0x9c, 0x96, 0x29, 0x01, 0x83, 0x6f, 0x02, 0x9b, 0x9b, 0x76, 0xd7, 0xb3, 0x1b, 0x2c, 0x82,
+	0x92, 0xfb, 0x75, 0x1c, 0xd6, 0x18, 0x8a, 0x9e, 0xe3, 0x39, 0x9d, 0xd6, 0x15, 0xbe, 0xca, 0x15,
+	0x68, 0x4a, 0x68, 0x60, 0x35, 0x12, 0x1a, 0xf8, 0x41, 0x03, 0x6c, 0x8b, 0xb4, 0x5e, 0x87, 0xf1,
+	0x45, 0x76, 0x60, 0x69, 0xa8, 0xbf, 0x5a, 0x59, 0xf5, 0x57, 0x53, 0x1d, 0x5f, 0x2b, 0xc3, 0x1c,
+	0x5f, 0xab, 0x8a, 0x18, 0x81, 0x5e, 0xc6, 0x1c, 0x3a, 0x3a, 0x84, 0x69, 0x39, 0x1f, 0x7c, 0x0e,
+	0x8c, 0x61, 0x71, 0xc0, 0x0e, 0x7c, 0x0f, 0x4f, 0x66, 0x9e, 0xb5, 0xc9, 0xa7, 0x28, 0x1c, 0xc6,
+	0x8d, 0x39, 0xd0, 0xfa, 0x11, 0xb0, 0x49, 0xaa, 0xd6, 0xe2, 0xc7, 0x9f, 0x34, 0xa8, 0x55, 0xfc,
+	0x62, 0xc7, 0x8e, 0x9e, 0x9e, 0x7a, 0xcc, 0x12, 0xff, 0xb7, 0x88, 0x03, 0x98, 0x8f, 0x08, 0x2c,
+	0xf1, 0x0f, 0x70, 0x12, 0x40, 0x51, 0x79, 0x36, 0x3c, 0xc3, 0xd8, 0x5c, 0x25, 0x7c, 0x09, 0x18,
+	0x66, 0x25, 0x64, 0x98, 0xe8, 0x0b, 0xcc, 0x2e, 0xaf, 0x60, 0x5e, 0xcc, 0xa6, 0x96, 0x65, 0xa9,
+	0xd2, 0x68, 0x65, 0xa9, 0x77, 0x30, 0xcf, 0x92, 0x9c, 0x27, 0x95, 0xde, 0xe0, 0x43, 0xc9, 0x3b,
+	0x4c, 0x1a, 0xcc, 0x5d, 0x2a, 0x1e, 0xaf, 0x3e, 0xfe, 0x48, 0x1c, 0x46, 0xb9, 0x3b, 0x85, 0xac,
+	0x16, 0xf5, 0xfd, 0xd1, 0xc8, 0x53, 0xa1, 0x3d, 0xa0, 0xac, 0xd8, 0x03, 0x68, 0xc4, 0x08, 0xd1,
+	0x4b, 0x66, 0x89, 0x4e, 0x52, 0x11, 0x11, 0x23, 0xa2, 0x86, 0x9c, 0x75, 0xac, 0x74, 0x5e, 0x61,
+	0x2c, 0x6a, 0x65, 0xe8, 0x79, 0x11, 0x45, 0xbd, 0x18, 0x11, 0xe9, 0x19, 0xb0, 0x17, 0x6b, 0x70,
+	0x2b, 0x6e, 0xd8, 0x5f, 0xca, 0x51, 0xc2, 0xcc, 0x37, 0x1c, 0x13, 0x61, 0xd4, 0x97, 0xab, 0xd0,
+	0x7b, 0xb0, 0x7a, 0x10, 0x87, 0x5d, 0xcc, 0x72, 0x5a, 0x1f, 0x9b, 0x35, 0x61, 0x21, 0x14, 0xb8,
+	0xcc, 0x72, 0xbd, 0x7c, 0x34, 0x8b, 0x42, 0x56, 0xfc, 0xcb, 0xaa, 0xe2, 0x8f, 0x5c, 0xe1, 0xdc,
+	0x12, 0xef, 0xba, 0x98, 0x49, 0xfd, 0x7a, 0x49, 0x38, 0x2f, 0x89, 0x1e, 0x35, 0xbc, 0xbd, 0xd6,
+	0xa3, 0xd4, 0x57, 0xcc, 0x5e, 0xec, 0x18, 0x9b, 0xd7, 0xf4, 0x06, 0x4b, 0x42, 0x2b, 0x9d, 0x3b,
+	0x58, 0x65, 0x3d, 0x77, 0xb0, 0x6a, 0x31, 0xee, 0x60, 0xed, 0x28, 0x47, 0x29, 0xd4, 0x1f, 0xec,
+	0x15, 0xcc, 0x85, 0xaf, 0x12, 0x1f, 0xee, 0xe8, 0x59, 0x8c, 0x79, 0x88, 0x6f, 0xb7, 0x97, 0xa2,
+	0x47, 0x81, 0x5a, 0x49, 0x38, 0x14, 0x91, 0xc6, 0x2d, 0x11, 0x33, 0xca, 0x4b, 0x51, 0x71, 0xa8,
+	0x1a, 0x8a, 0x43, 0xf8, 0x0b, 0x46, 0x17, 0xaf, 0xca, 0x1e, 0x1f, 0x61, 0x51, 0x1c, 0x26, 0xb2,
+	0x91, 0xe1, 0x6a, 0x79, 0x6e, 0x5f, 0x44, 0xc5, 0xb0, 0x02, 0x51, 0x60, 0xfc, 0xfe, 0x62, 0x18,
+	0x7f, 0xc2, 0x23, 0x62, 0xe4, 0x3a, 0xf4, 0x6d, 0x2c, 0x87, 0x47, 0x08, 0x2c, 0x86, 0x31, 0xe0,
+	0xa1, 0x20, 0x06, 0xb6, 0xd0, 0x60, 0xc3, 0x4a, 0xf0, 0x1c, 0x9b, 0xfb, 0x72, 0x4e, 0x47, 0x72,
+	0xba, 0x6a, 0x64, 0xb1, 0xa0, 0x32, 0x52, 0xb1, 0x80, 0x6c, 0x46, 0xbc, 0x64, 0x57, 0x1c, 0x5f,
+	0x8a, 0xd7, 0x95, 0x6a, 0x94, 0xd9, 0x19, 0x8b, 0xcc, 0x0e, 0x6e, 0xeb, 0xf7, 0xbb, 0x5d, 0xa2,
+	0x47, 0xd9, 0x4d, 0x3a, 0x0b, 0x55, 0x53, 0xaa, 0x81, 0x57, 0xc1, 0xf8, 0xa2, 0xe7, 0x5a, 0xcd,
+	0x86, 0xe5, 0xf7, 0xb8, 0x76, 0x98, 0x5e, 0x89, 0x98, 0x11, 0x2d, 0xf9, 0xb9, 0x65, 0x86, 0xb0,
+	0xa8, 0x53, 0x30, 0x9d, 0xdc, 0x93, 0xab, 0x58, 0xe7, 0xc2, 0xea, 0x97, 0xdd, 0xc6, 0x1b, 0x2f,
+	0x31, 0x10, 0x25, 0x12, 0x3a, 0x27, 0xad, 0x48, 0x99, 0xb2, 0x72, 0x84, 0xb2, 0x05, 0x50, 0xb5,
+	0x09, 0x68, 0x3e, 0xda, 0x8f, 0xa7, 0xc6, 0x3a, 0x71, 0xc9, 0x99, 0x0c, 0x18, 0xfa, 0x35, 0x22,
+	0xd8, 0xdb, 0x3d, 0x9e, 0xbb, 0x25, 0x15, 0xaf, 0x94, 0x63, 0x42, 0x4a, 0xf1, 0x98, 0x10, 0x3c,
+	0xd0, 0x6e, 0x7b, 0x55, 0xf8, 0xb0, 0x8a, 0x62, 0xb2, 0x4c, 0x57, 0x19, 0x20, 0xd3, 0xa1, 0xb7,
+	0x32, 0xc9, 0x70, 0xba, 0xdd, 0xd6, 0xc1, 0x0c, 0x4f, 0x3e, 0xb1, 0x05, 0xb0, 0x26, 0xdc, 0x9d,
+	0x5c, 0xaa, 0x49, 0xc6, 0xa1, 0x3c, 0x08, 0x87, 0x3f, 0x33, 0x98, 0x6b, 0x38, 0x47, 0xa0, 0xb0,
+	0xad, 0xea, 0x87, 0xe8, 0x06, 0x89, 0x6b, 0x28, 0xcf, 0xa3, 0xbf, 0xe6, 0x79, 0x88, 0x0d, 0xb7,
+	0xad, 0x2a, 0x95, 0xca, 0x7a, 0xa9, 0x44, 0x54, 0xcb, 0x2f, 0x33, 0xa1, 0x56, 0x1a, 0xc2, 0x62,
+	0x28, 0x38, 0x2d, 0x51, 0x90, 0x29, 0x61, 0x90, 0x20, 0x79, 0xc8, 0xe2, 0x47, 0x17, 0xc1, 0x4e,
+	0xee, 0x32, 0x31, 0x9a, 0x85, 0x8a, 0xec, 0x20, 0x54, 0xa1, 0xc8, 0xc1, 0x41, 0x7f, 0x84, 0xd7,
+	0xb1, 0x9c, 0x7f, 0x28, 0xff, 0x0e, 0x1b, 0x90, 0xe9, 0x68, 0x70, 0x34, 0x56, 0x62, 0x1e, 0xa6,
+	0xea, 0x80, 0x3c, 0x4c, 0x6f, 0x8d, 0x64, 0x8d, 0xba, 0x15, 0xe9, 0x92, 0x9a, 0x60, 0xfb, 0xfc,
+	0xb2, 0xe5, 0xd9, 0xcd, 0x39, 0x7b, 0xc9, 0xe9, 0x38, 0xf4, 0xe4, 0x1a, 0x10, 0x81, 0x8c, 0x37,
+	0x6d, 0x4f, 0xf8, 0x3e, 0x8f, 0x9b, 0xa2, 0x18, 0xbb, 0x5e, 0x2b, 0x27, 0x84, 0xa7, 0x9e, 0x07,
+	0xb7, 0x73, 0x42, 0x23, 0x7d, 0x49, 0x21, 0x84, 0xe9, 0xbb, 0x24, 0xe2, 0xee, 0x20, 0x70, 0xc5,
+	0xac, 0xac, 0xdb, 0xc1, 0x6d, 0x84, 0x39, 0x45, 0x7a, 0x13, 0x72, 0x25, 0xd9, 0xfd, 0xfb, 0x93,
+	0xbf, 0x17, 0xa5, 0xda, 0x6e, 0x6a, 0x86, 0xbd, 0xe8, 0x87, 0xc5, 0x45, 0x47, 0x4d, 0x86, 0x86,
+	0x1e, 0x12, 0x8e, 0x00, 0x1a, 0x73, 0x45, 0x66, 0x64, 0x50, 0xa3, 0xa2, 0xdc, 0x07, 0x88, 0x37,
+	0x5a, 0x60, 0xb0, 0x76, 0x42, 0xa5, 0xf2, 0x79, 0x6a, 0x5f, 0x0c, 0xaa, 0x79, 0xdc, 0xe3, 0xb1,
+	0xf4, 0x91, 0x69, 0xfc, 0x6c, 0x0a, 0x8d, 0xe1, 0xa6, 0x02, 0x10, 0x2d, 0x53, 0x3f, 0x65, 0xb5,
+	0xeb, 0x62, 0x88, 0xfc, 0x69, 0xb0, 0x8f, 0x05, 0x9a, 0xdd, 0x12, 0x3a, 0xdf, 0x6e, 0x80, 0x2d,
+	0x4a, 0xfe, 0x8d, 0xf0, 0x9a, 0xc2, 0x18, 0x72, 0x4d, 0xa1, 0x65, 0x24, 0x8d, 0x84, 0xe6, 0x56,
+	0xe2, 0xa1, 0xb9, 0x9f, 0xc3, 0xa2, 0x5e, 0x1c, 0x55, 0x68, 0x62, 0x6d, 0x98, 0xd7, 0xf2, 0x91,
+	0xce, 0x9a, 0x54, 0x24, 0x80, 0xa3, 0x66, 0x2a, 0x29, 0x8d, 0x28, 0x53, 0x09, 0xb9, 0xdc, 0x4b,
+	0x9a, 0xc4, 0x22, 0xe3, 0x3a, 0x92, 0x96, 0xcb, 0x70, 0xcf, 0xe3, 0xf7, 0x97, 0xa8, 0x83, 0x19,
+	0x1e, 0xe8, 0x9b, 0x80, 0x25, 0x9c, 0x8f, 0x0f, 0x74, 0xc6, 0xf0, 0x33, 0x29, 0x23, 0xcc, 0x15,
+	0xb0, 0xb1, 0xe7, 0x59, 0x4b, 0x4b, 0x2c, 0x8d, 0x52, 0x59, 0x2b, 0x3c, 0x27, 0x9c, 0xbc, 0x05,
+	0x06, 0xc2, 0x0c, 0x60, 0x89, 0xa1, 0xc1, 0xda, 0xf8, 0x4d, 0x1a, 0x1a, 0xb1, 0x1e, 0xf3, 0x0e,
+	0x4d, 0x00, 0xa7, 0xb0, 0xa1, 0xf9, 0x1a, 0xde, 0x9b, 0xe1, 0xf7, 0xe9, 0x2e, 0x99, 0x0c, 0xab,
+	0xad, 0x69, 0x51, 0x5e, 0x90, 0x76, 0x72, 0x29, 0xa7, 0xb2, 0x1c, 0xee, 0xe5, 0x41, 0x26, 0xd4,
+	0xe1, 0x69, 0x42, 0x56, 0x41, 0x8d, 0x51, 0x61, 0x4b, 0x5c, 0x31, 0xb4, 0x93, 0xc7, 0x2d, 0xdf,
+	0xc6, 0x20, 0xcb, 0x77, 0xe2, 0x18, 0x94, 0x06, 0x69, 0x3f, 0x6f, 0x06, 0xfb, 0x12, 0xfa, 0x2d,
+	0x86, 0x45, 0xdc, 0x00, 0x77, 0x62, 0x09, 0xd4, 0xbd, 0x66, 0xc7, 0x67, 0xee, 0x66, 0x90, 0xfa,
+	0x02, 0xb8, 0x6b, 0x70, 0xf7, 0xc5, 0x50, 0x8c, 0xa5, 0x4f, 0x99, 0x29, 0x06, 0xfd, 0xf9, 0x99,
+	0xe8, 0x25, 0xd2, 0xde, 0x1d, 0x83, 0xe0, 0x15, 0x75, 0x2b, 0x34, 0x6e, 0x89, 0x3e, 0x38, 0x53,
+	0x38, 0x96, 0x61, 0x03, 0x07, 0xe3, 0x1c, 0x42, 0x43, 0x3f, 0x03, 0xb6, 0x85, 0xff, 0x70, 0x59,
+	0xe4, 0xdd, 0xd1, 0x98, 0xfd, 0x88, 0x0b, 0x42, 0x29, 0xee, 0x82, 0x30, 0xdc, 0x2b, 0xea, 0x7f,
+	0x0c, 0xb0, 0xfd, 0x12, 0x87, 0x3a, 0xdd, 0x68, 0xd8, 0xbe, 0xef, 0x7a, 0x3f, 0x16, 0x1c, 0xe4,
+	0x35, 0x60, 0x8b, 0x30, 0x92, 0xb1, 0x6c, 0x93, 0x4c, 0x4d, 0x56, 0x2b, 0xe1, 0x01, 0xb0, 0xb3,
+	0x6d, 0xf9, 0x3d, 0x86, 0xf9, 0x42, 0x84, 0xb3, 0x24, 0x7d, 0x42, 0x0d, 0xaa, 0x4b, 0x44, 0x49,
+	0xce, 0xb6, 0x16, 0x09, 0x9b, 0xbb, 0xee, 0x74, 0x9a, 0xee, 0x75, 0x61, 0xd1, 0x60, 0x25, 0xf4,
+	0x17, 0x4c, 0x23, 0x49, 0xe8, 0xa5, 0x98, 0x15, 0x7a, 0x15, 0xaf, 0x50, 0xd1, 0x87, 0xb6, 0x3e,
+	0x12, 0xc5, 0xd2, 0x0c, 0x61, 0xa1, 0xf7, 0x95, 0x98, 0x9f, 0x7d, 0xb0, 0x46, 0xe7, 0x9c, 0xa5,
+	0xa5, 0x02, 0x1d, 0xdf, 0xfb, 0x9d, 0x3e, 0xb1, 0x65, 0x96, 0x72, 0x26, 0x4b, 0xe1, 0x70, 0xe0,
+	0x65, 0x00, 0xfa, 0x18, 0xef, 0x46, 0x9b, 0x68, 0x45, 0xfc, 0xec, 0xcd, 0x78, 0x9e, 0x4b, 0x80,
+	0x50, 0x9f, 0xae, 0xa1, 0x70, 0x50, 0xce, 0xe0, 0x36, 0xae, 0xb7, 0x96, 0xda, 0xe0, 0xa1, 0x98,
+	0x03, 0xc6, 0x25, 0xbb, 0xe7, 0xf0, 0xbd, 0xfa, 0xc9, 0x12, 0x5d, 0x55, 0x09, 0xfd, 0xde, 0x74,
+	0xc3, 0x85, 0xb2, 0xe9, 0xcb, 0x23, 0xdb, 0xf4, 0x57, 0x64, 0xc9, 0xb4, 0x92, 0x73, 0x11, 0x48,
+	0x4a, 0xc0, 0xef, 0x8d, 0x81, 0x2d, 0x4a, 0x2a, 0x50, 0xe2, 0x71, 0xbc, 0x22, 0xfd, 0x7f, 0xbe,
+	0x2c, 0x2f, 0x0a, 0xa8, 0x62, 0x3d, 0x83, 0x9e, 0xc6, 0xda, 0x1e, 0x33, 0x8f, 0x51, 0x7f, 0xdf,
+	0x72, 0x36, 0x33, 0xa4, 0x0c, 0x23, 0x8c, 0xf4, 0xae, 0xe4, 0x8e, 0xf4, 0x56, 0x55, 0x8b, 0xea,
+	0x88, 0x54, 0x0b, 0x45, 0x28, 0x1f, 0x1b, 0x91, 0x50, 0xbe, 0xc0, 0x7d, 0x23, 0x36, 0x50, 0x78,
+	0x4f, 0x64, 0xcb, 0x28, 0x1b, 0x4b, 0x99, 0x73, 0x10, 0xec, 0x92, 0xd7, 0x02, 0x77, 0x73, 0x22,
+	0x89, 0x41, 0xc9, 0xa5, 0x65, 0xe2, 0x37, 0xbc, 0x6b, 0x37, 0xd0, 0xdc, 0xb1, 0x0d, 0x9f, 0xbb,
+	0xde, 0x67, 0xca, 0x3f, 0x2b, 0x60, 0x64, 0x8f, 0x30, 0xfc, 0x8c, 0x01, 0x6a, 0x61, 0x80, 0x29,
+	0xcf, 0x82, 0x56, 0x18, 0xab, 0x8f, 0x24, 0x7c, 0xc9, 0x9a, 0xd2, 0x37, 0xc8, 0xf8, 0x72, 0x8e,
+	0xe8, 0x42, 0xed, 0x68, 0xc6, 0x17, 0x72, 0x45, 0x26, 0x38, 0xaf, 0x48, 0x91, 0x2c, 0xd5, 0x0c,
+	0xc8, 0xc7, 0x63, 0xaa, 0xb0, 0xfc, 0x2e, 0x75, 0x17, 0x57, 0x73, 0x8d, 0x1b, 0xd1, 0x5c, 0xe3,
+	0xeb, 0x78, 0x70, 0x7f, 0xd6, 0xa0, 0x66, 0xfd, 0xa2, 0x33, 0xcb, 0x5c, 0x8d, 0x65, 0x96, 0xd1,
+	0x11, 0x55, 0xa3, 0x34, 0x4b, 0xf9, 0x65, 0x0e, 0x82, 0xad, 0xe4, 0x86, 0xa5, 0xdb, 0x95, 0xb3,
+	0xe9, 0xc8, 0xc6, 0x23, 0x23, 0x6e, 0x3c, 0x7a, 0x11, 0x6c, 0x0b, 0xda, 0x14, 0x77, 0xfb, 0x4b,
+	0xac, 0x60, 0xc2, 0x23, 0x84, 0x97, 0xd0, 0xcf, 0x96, 0xc1, 0x9e, 0x79, 0x9b, 0xc4, 0x14, 0xc4,
+	0xbc, 0x5e, 0x42, 0xd5, 0xd4, 0x88, 0x7a, 0xf7, 0x90, 0xc8, 0x95, 0x06, 0x8d, 0x0f, 0x10, 0x6e,
+	0x11, 0x61, 0x8d, 0x14, 0x19, 0x50, 0x1e, 0x1e, 0x19, 0x50, 0x49, 0x88, 0x0c, 0x80, 0xae, 0xe2,
+	0x54, 0x51, 0xd5, 0x8c, 0xf4, 0x4c, 0x26, 0x65, 0xa8, 0x43, 0x05, 0x09, 0x9d, 0x70, 0x9a, 0x1e,
+	0xbf, 0xb9, 0xa7, 0xbf, 0x09, 0x09, 0xee, 0xd2, 0x92, 0x6f, 0xb3, 0x24, 0x7c, 0x65, 0x93, 0x97,
+	0x68, 0xf2, 0x64, 0x67, 0xc5, 0x61, 0x97, 0xc4, 0x65, 0x93, 0x15, 0xf2, 0x3a, 0x54, 0x7c, 0xc7,
+	0x00, 0x7b, 0x63, 0x78, 0xbf, 0x0a, 0x7d, 0x71, 0x49, 0x58, 0x9b, 0xdb, 0xe3, 0xf1, 0x6e, 0x78,
+	0x70, 0x68, 0x01, 0xbd, 0xa7, 0x02, 0x76, 0xd2, 0xac, 0x04, 0x45, 0x27, 0x8e, 0x1b, 0xe1, 0x23,
+	0x25, 0xcf, 0x2a, 0xc9, 0xe2, 0x4e, 0xe9, 0x65, 0x5f, 0x58, 0x27, 0x57, 0xdc, 0x65, 0x55, 0x88,
+	0x18, 0x55, 0xea, 0x8a, 0x85, 0xb8, 0x3c, 0x31, 0x82, 0xec, 0xd5, 0x61, 0x42, 0x8c, 0x31, 0x39,
+	0x21, 0x46, 0xf6, 0xa3, 0xf3, 0x3c, 0xd8, 0x24, 0xa5, 0xa8, 0xa0, 0x81, 0xf0, 0x58, 0x11, 0x14,
+	0x57, 0x34, 0xe4, 0xf7, 0x40, 0x3f, 0x15, 0x71, 0x9d, 0x53, 0x96, 0xae, 0x73, 0xbe, 0x61, 0x80,
+	0x5d, 0xea, 0xa0, 0xdf, 0x8a, 0x7c, 0x98, 0x52, 0xbe, 0x8e, 0xf2, 0x08, 0xf2, 0x75, 0x90, 0x1c,
+	0x58, 0x1b, 0xe7, 0x3b, 0x56, 0xd7, 0x5f, 0x76, 0xd9, 0xc1, 0xcc, 0x7f, 0x87, 0xe1, 0x50, 0x61,
+	0xcd, 0x50, 0xdd, 0x63, 0xa8, 0x96, 0x04, 0xef, 0x07, 0xdb, 0xec, 0x17, 0xbb, 0x8e, 0x67, 0x47,
+	0xcd, 0x01, 0xd1, 0x6a, 0xf4, 0xda, 0x20, 0x91, 0x20, 0xef, 0x57, 0x6c, 0x62, 0x3c, 0xf5, 0xbd,
+	0x5e, 0x9b, 0x3f, 0x3d, 0x41, 0x7e, 0xa2, 0x3f, 0x35, 0xc0, 0x9e, 0xe8, 0xff, 0x16, 0x33, 0x27,
+	0x18, 0x9c, 0x18, 0x06, 0x2e, 0x1a, 0xa5, 0x07, 0x17, 0xe0, 0x16, 0x80, 0x40, 0x0f, 0xb3, 0x44,
+	0x78, 0x11, 0x02, 0xd7, 0x19, 0x7d, 0xf4, 0x71, 0x9e, 0x06, 0xef, 0xd5, 0x45, 0xeb, 0xa1, 0x20,
+	0x8d, 0xa2, 0x26, 0xb9, 0x2d, 0xb0, 0x27, 0xda, 0xb0, 0x18, 0x53, 0xe8, 0x37, 0x0d, 0x30, 0x36,
+	0xdd, 0x75, 0xf8, 0x65, 0x1e, 0xe6, 0x29, 0xe1, 0x65, 0x1e, 0x2d, 0x04, 0xdc, 0xa0, 0xa4, 0x86,
+	0x24, 0xea, 0x05, 0xcb, 0xab, 0x1b, 0xa4, 0x9a, 0x62, 0x83, 0x8c, 0x25, 0x6e, 0x10, 0xf2, 0x9f,
+	0x1e, 0x79, 0x6a, 0xcb, 0x8e, 0x66, 0xbf, 0x8e, 0x56, 0xa3, 0x63, 0x60, 0x27, 0xdb, 0x1e, 0x8c,
+	0xba, 0x61, 0x7e, 0x05, 0x7c, 0x73, 0x95, 0xc2, 0xcd, 0xf5, 0x45, 0x43, 0x64, 0x61, 0x15, 0xad,
+	0x0b, 0xf3, 0xde, 0xb1, 0x68, 0x07, 0x7c, 0xb1, 0x4d, 0x69, 0xf0, 0x33, 0x8a, 0x17, 0x6f, 0xce,
+	0x44, 0x82, 0x6b, 0xb6, 0x98, 0x10, 0x56, 0x40, 0x3b, 0xa9, 0x0b, 0x15, 0xfb, 0xd7, 0xc0, 0x37,
+	0xe1, 0xa3, 0x2c, 0x7f, 0x66, 0x50, 0x5b, 0x0c, 0x65, 0x58, 0x48, 0x60, 0xa8, 0xe9, 0x0b, 0x09,
+	0x9c, 0x34, 0xd1, 0x1e, 0x3d, 0x0f, 0x76, 0x9a, 0x74, 0x72, 0xd5, 0x99, 0x4c, 0x5e, 0xae, 0xb1,
+	0xb9, 0x24, 0x4a, 0x41, 0xcb, 0xc3, 0x22, 0xf3, 0x25, 0xdb, 0x73, 0xdc, 0x26, 0x97, 0x99, 0xe4,
+	0x2a, 0x3a, 0xdb, 0x6a, 0x0f, 0xaf, 0xca, 0xd9, 0x7e, 0x9d, 0xf0, 0xd2, 0x4a, 0x31, 0x4e, 0xa1,
+	0x07, 0x56, 0xa1, 0x24, 0xa3, 0x4b, 0x2c, 0x7f, 0x55, 0xcf, 0xf2, 0x7a, 0xfd, 0xee, 0x45, 0x12,
+	0x93, 0x27, 0xa1, 0x95, 0xec, 0x3a, 0x20, 0x6b, 0x70, 0xa5, 0xb8, 0x06, 0x77, 0x08, 0xec, 0x90,
+	0xc1, 0x9d, 0x0e, 0xfc, 0x7f, 0x43, 0xf7, 0x02, 0xa1, 0x56, 0x2b, 0x75, 0xe8, 0x43, 0xfc, 0xa1,
+	0x20, 0x05, 0x97, 0x62, 0x26, 0x3a, 0x08, 0x46, 0x64, 0x2a, 0x20, 0x0f, 0x46, 0x34, 0x49, 0x20,
+	0xd6, 0x1a, 0x11, 0x1b, 0x75, 0xaf, 0x5c, 0x63, 0x04, 0x9b, 0x1c, 0x12, 0x81, 0xd9, 0x58, 0x6b,
+	0x84, 0x52, 0x6e, 0x2e, 0x98, 0x0c, 0x12, 0xb1, 0xba, 0x6c, 0x23, 0x6b, 0xa3, 0x45, 0x23, 0xe8,
+	0x4e, 0x7b, 0x16, 0xbb, 0xd5, 0x20, 0xbe, 0x56, 0x9e, 0xdb, 0x6e, 0xc7, 0xaf, 0x21, 0x92, 0x3e,
+	0xc1, 0x37, 0xd0, 0x8c, 0xf2, 0xbc, 0x3a, 0xf7, 0x2d, 0x8c, 0x04, 0x6b, 0x1d, 0x93, 0xf4, 0x0f,
+	0x14, 0xec, 0xa7, 0xfb, 0x4d, 0x27, 0x0b, 0xf6, 0xc3, 0xe5, 0x50, 0x35, 0x5e, 0xa1, 0x9c, 0x14,
+	0xae, 0xc3, 0x25, 0xeb, 0x8a, 0x22, 0x59, 0x53, 0x85, 0xdd, 0xef, 0xb7, 0x7b, 0x22, 0xad, 0x07,
+	0x2b, 0x11, 0xd1, 0x92, 0x68, 0xb5, 0x56, 0xcf, 0x15, 0xda, 0x71, 0x50, 0x56, 0xa9, 0xdd, 0x10,
+	0xa5, 0xf6, 0x37, 0x48, 0x20, 0x1a, 0x99, 0xa1, 0x90, 0xe4, 0x74, 0x36, 0xff, 0x01, 0x43, 0x52,
+	0x1a, 0x3c, 0x24, 0x7a, 0xee, 0xbb, 0xcb, 0x78, 0xb3, 0x45, 0xf1, 0x2a, 0x86, 0xc5, 0xfc, 0xa6,
+	0x41, 0x32, 0x11, 0xb0, 0x0b, 0xe4, 0x1f, 0xaf, 0x31, 0x70, 0x48, 0xae, 0x82, 0x28, 0x62, 0xc5,
+	0x0c, 0x02, 0xcb, 0x2f, 0x18, 0xf6, 0x93, 0xd2, 0xcb, 0xe7, 0x3d, 0x25, 0xee, 0x1e, 0x24, 0xb5,
+	0x2b, 0xec, 0x26, 0xad, 0x45, 0xd6, 0x83, 0xaf, 0x7d, 0x93, 0x16, 0x61, 0x45, 0x26, 0x87, 0x43,
+	0x20, 0x5a, 0x64, 0x77, 0x0b, 0x76, 0x9a, 0x05, 0x22, 0x65, 0x0f, 0x26, 0x87, 0x43, 0x24, 0xa3,
+	0x3b, 0xf9, 0x37, 0x7b, 0x50, 0x6e, 0x0b, 0x7d, 0x56, 0x52, 0x60, 0x04, 0xe7, 0xfb, 0x0c, 0x70,
+	0xb7, 0x40, 0x78, 0x70, 0x2a, 0x8a, 0x9b, 0xcc, 0xfd, 0xd0, 0x7b, 0x0d, 0xb0, 0x3d, 0x1a, 0xab,
+	0x41, 0x72, 0xad, 0x38, 0xa2, 0x4f, 0xfc, 0x2b, 0x88, 0xcc, 0x28, 0xa9, 0x91, 0x19, 0xc2, 0xbf,
+	0xb7, 0xac, 0xba, 0x14, 0x13, 0xb1, 0x60, 0x69, 0xc9, 0x26, 0x69, 0x6b, 0xec, 0xe9, 0xd0, 0x2b,
+	0x30, 0xac, 0x1a, 0xae, 0x60, 0x90, 0x50, 0xd7, 0x10, 0xa5, 0x74, 0xcc, 0x61, 0x5e, 0x4d, 0xea,
+	0x92, 0x2b, 0x50, 0x25, 0x08, 0xe4, 0xfe, 0x55, 0x03, 0xec, 0x90, 0xf0, 0x28, 0x66, 0xab, 0xb1,
+	0xa1, 0x2e, 0x05, 0x43, 0x4d, 0x83, 0x44, 0x1b, 0x4e, 0xd7, 0xb1, 0x59, 0x1e, 0x2a, 0x1a, 0x94,
+	0x13, 0xd6, 0xa0, 0x37, 0x50, 0x7d, 0x60, 0xc1, 0xed, 0xba, 0x6d, 0xb7, 0xb5, 0x36, 0x5c, 0x3e,
+	0x0b, 0xed, 0xb5, 0xa5, 0x64, 0x7b, 0x6d, 0x59, 0xb2, 0xd7, 0xa2, 0xef, 0x1b, 0x60, 0xb3, 0x80,
+	0x7b, 0x81, 0xc4, 0xa3, 0x0e, 0x1f, 0x72, 0x33, 0x7a, 0x05, 0x33, 0x82, 0xd7, 0x36, 0xd2, 0x39,
+	0x6d, 0x60, 0xb5, 0xb2, 0xdf, 0x3d, 0xab, 0xfc, 0x1f, 0x0b, 0xe8, 0x88, 0x56, 0x93, 0x01, 0x60,
+	0x99, 0xac, 0xe8, 0x22, 0x33, 0x4c, 0x5e, 0x42, 0xbf, 0x5f, 0x0a, 0x49, 0x3d, 0xd9, 0x6c, 0xd9,
+	0x85, 0x46, 0x51, 0x63, 0x79, 0x41, 0x72, 0x21, 0x20, 0xf6, 0xc2, 0xa0, 0xac, 0xef, 0x7f, 0x42,
+	0xe6, 0x0e, 0xff, 0x68, 0xb3, 0xe0, 0xe0, 0x8d, 0x26, 0x2b, 0xc0, 0x05, 0xb0, 0x81, 0xbb, 0xf5,
+	0x51, 0x91, 0x24, 0x9f, 0x87, 0xa0, 0x00, 0x85, 0xbe, 0x56, 0xa2, 0x66, 0x9c, 0x70, 0xb1, 0x15,
+	0xb3, 0x05, 0x9e, 0x04, 0xd5, 0x0e, 0x5e, 0x6f, 0xfa, 0x0e, 0x93, 0xf2, 0x6a, 0x35, 0x19, 0x0c,
+	0x02, 0xcc, 0x6e, 0x86, 0x36, 0x47, 0x7d, 0x60, 0x64, 0x3d, 0x98, 0x0c, 0x46, 0x68, 0xbb, 0xaf,
+	0x48, 0xb6, 0xfb, 0xa1, 0x11, 0x8f, 0x43, 0xdf, 0x02, 0x23, 0xba, 0xeb, 0x16, 0x25, 0x11, 0x17,
+	0x7c, 0x16, 0x8c, 0x51, 0x33, 0xb0, 0x70, 0x00, 0x9f, 0xc9, 0x96, 0xd0, 0x6b, 0xf2, 0x0a, 0x05,
+	0xc2, 0x93, 0x3d, 0x30, 0x88, 0x2a, 0x2e, 0xa5, 0x08, 0x2e, 0x24, 0x15, 0x84, 0xd4, 0x48, 0xcb,
+	0x5c, 0xfd, 0x46, 0x2a, 0xbf, 0xcc, 0x90, 0xe5, 0x69, 0x5a, 0x4d, 0x27, 0x8c, 0x9b, 0x1f, 0x05,
+	0x1b, 0xfa, 0x40, 0x09, 0x6c, 0x93, 0x40, 0x9f, 0xed, 0xd9, 0x2b, 0xb7, 0x80, 0x13, 0x61, 0x1e,
+	0xd3, 0x74, 0x30, 0xdb, 0xed, 0xcd, 0x06, 0x9e, 0x03, 0x0c, 0xcb, 0x68, 0x35, 0xd9, 0xc2, 0x78,
+	0xbf, 0x74, 0x7c, 0x87, 0x9c, 0x6d, 0xe1, 0x7f, 0xb3, 0x15, 0x93, 0xf4, 0x89, 0x32, 0x1b, 0x0f,
+	0xd7, 0x35, 0xac, 0x76, 0xf8, 0xff, 0x6c, 0x21, 0xc5, 0x3f, 0xd0, 0x0d, 0xdf, 0x70, 0x3d, 0x9b,
+	0xae, 0x26, 0xc3, 0x64, 0x05, 0xf4, 0x4e, 0x26, 0x0b, 0x2a, 0x73, 0x50, 0x54, 0xda, 0xed, 0xaa,
+	0x83, 0xe7, 0x40, 0x5f, 0x14, 0x8c, 0x4c, 0xa2, 0xc9, 0xc0, 0x24, 0xdf, 0x87, 0x0d, 0x8b, 0xce,
+	0x5b, 0x47, 0x5a, 0x38, 0x4a, 0xfd, 0xbb, 0xd9, 0x5d, 0xd5, 0xac, 0xbb, 0xd2, 0x6d, 0x3b, 0xa9,
+	0x33, 0x7b, 0xa1, 0x06, 0xd8, 0x1d, 0x6d, 0x18, 0xe4, 0xb3, 0x4c, 0x4a, 0xed, 0xd6, 0xb5, 0x7c,
+	0xe6, 0x5e, 0x46, 0x6f, 0x7d, 0x58, 0x89, 0x9c, 0xd8, 0xab, 0x8e, 0xdb, 0xe6, 0xf9, 0x70, 0x58,
+	0xae, 0x0c, 0xa9, 0x06, 0xfd, 0x0e, 0x79, 0xab, 0x2a, 0xd2, 0x8b, 0x12, 0xb6, 0x66, 0xc4, 0xc3,
+	0xd6, 0x12, 0x3b, 0xba, 0x02, 0xc6, 0x1a, 0x04, 0x3b, 0xc1, 0xdb, 0x1e, 0xd7, 0xbc, 0xc9, 0x8b,
+	0x10, 0x69, 0x72, 0x68, 0xe8, 0xdd, 0x78, 0x2d, 0xc5, 0xc7, 0x8f, 0xe6, 0xeb, 0x5c, 0xf7, 0x35,
+	0xa2, 0x45, 0xab, 0x19, 0x24, 0xd2, 0x63, 0x85, 0x70, 0xc1, 0x96, 0xa5, 0x05, 0x4b, 0x88, 0xe2,
+	0xc8, 0xb3, 0xd4, 0x2c, 0xbc, 0x44, 0x24, 0x37, 0x71, 0x3f, 0x59, 0xd5, 0x0d, 0x84, 0x8a, 0xe2,
+	0x1c, 0xdc, 0x54, 0x0e, 0x8b, 0x7a, 0x1e, 0xae, 0xa2, 0x7f, 0xc9, 0x60, 0xb1, 0x62, 0xb1, 0xe1,
+	0x28, 0xca, 0xdd, 0x62, 0xcc, 0xb3, 0x83, 0x2c, 0xa9, 0x3a, 0xf7, 0x9e, 0xc9, 0x13, 0x66, 0x72,
+	0x70, 0xe8, 0x95, 0x52, 0x52, 0x52, 0x3a, 0x3f, 0x75, 0xfa, 0x59, 0xee, 0xe2, 0x50, 0x52, 0x5c,
+	0x1c, 0x72, 0x66, 0x76, 0x18, 0x88, 0x4e, 0x2a, 0x47, 0x84, 0x8a, 0xe4, 0x88, 0x40, 0xf3, 0x3a,
+	0x30, 0x58, 0x76, 0x73, 0xc6, 0x5e, 0x22, 0xab, 0x8d, 0x31, 0xd0, 0x58, 0xfd, 0xc0, 0xcb, 0xda,
+	0x9c, 0xee, 0x09, 0xf4, 0x45, 0x9e, 0x24, 0x8a, 0x6e, 0x55, 0xfe, 0x92, 0x1f, 0x61, 0x6d, 0x25,
+	0x26, 0xcb, 0x15, 0x2d, 0xd8, 0xe2, 0x93, 0xaa, 0x6d, 0x5a, 0x3d, 0xb1, 0xd7, 0x83, 0x32, 0xcd,
+	0xa6, 0x4b, 0xde, 0xbc, 0x34, 0x45, 0xf6, 0x2c, 0xc3, 0x0c, 0x2b, 0x08, 0xcb, 0xc4, 0xdc, 0x91,
+	0xe0, 0x79, 0xe9, 0xc8, 0x11, 0x2e, 0x9b, 0x4b, 0x35, 0x74, 0x01, 0xba, 0x7d, 0xe2, 0x57, 0x35,
+	0xc6, 0x17, 0x20, 0x2d, 0xad, 0xb3, 0x77, 0x7f, 0xc1, 0x00, 0x77, 0xb0, 0x6d, 0x10, 0x97, 0x69,
+	0xf9, 0xba, 0x97, 0x43, 0x69, 0x8c, 0xd1, 0x85, 0xd2, 0x24, 0x5c, 0x4a, 0xfd, 0xa2, 0x41, 0x02,
+	0x35, 0x06, 0x20, 0x53, 0x98, 0xbf, 0x2d, 0xf1, 0xbc, 0xee, 0xf6, 0xf8, 0xc9, 0x51, 0x35, 0x83,
+	0x32, 0x4d, 0x1f, 0x15, 0x22, 0xf2, 0x14, 0x49, 0xd0, 0xea, 0xfb, 0x7d, 0xca, 0xac, 0x1d, 0x5c,
+	0xf7, 0x22, 0x4f, 0x6c, 0xcf, 0x0a, 0x03, 0x9e, 0x23, 0x0d, 0xde, 0x48, 0x2f, 0xcb, 0x6f, 0xa4,
+	0x8b, 0x1c, 0xab, 0x95, 0xe4, 0x1c, 0xab, 0x91, 0xe4, 0x68, 0x6f, 0x01, 0x7b, 0x49, 0xe7, 0xb7,
+	0x24, 0x22, 0xf2, 0x87, 0x06, 0xa8, 0xc5, 0x3b, 0x2f, 0x66, 0x2e, 0x16, 0xc0, 0x98, 0x43, 0x06,
+	0x58, 0x88, 0x4d, 0xc7, 0x33, 0x2c, 0xb3, 0x60, 0x96, 0x4c, 0x0e, 0x8b, 0xec, 0x0b, 0xba, 0x89,
+	0x84, 0x61, 0x80, 0x97, 0xc8, 0xcc, 0x5f, 0xb7, 0xbc, 0x8e, 0xd3, 0x69, 0x89, 0xec, 0xd9, 0x41,
+	0x19, 0x3d, 0x0d, 0x76, 0x2a, 0x4a, 0xf1, 0x3c, 0xde, 0x2a, 0xd1, 0xb0, 0x0e, 0x23, 0x7a, 0xc7,
+	0xbb, 0x5f, 0xf5, 0x88, 0x22, 0x10, 0xa5, 0xf4, 0x59, 0x4f, 0x53, 0xa7, 0x7e, 0x01, 0x55, 0xcb,
+	0x7b, 0x7d, 0x50, 0xc4, 0xc3, 0x77, 0x59, 0x8a, 0xfc, 0x18, 0xcc, 0xc2, 0x76, 0x4a, 0x90, 0x86,
+	0x9c, 0x7b, 0x87, 0x04, 0x69, 0xc8, 0xaf, 0x60, 0x81, 0x84, 0x0e, 0x91, 0x38, 0xe1, 0x8e, 0x6b,
+	0xab, 0x64, 0xd2, 0x38, 0x9b, 0x02, 0x18, 0xfa, 0x29, 0xb0, 0x59, 0x7e, 0xc9, 0x5c, 0x7a, 0xdc,
+	0xd7, 0x50, 0x1e, 0xf7, 0x95, 0xaf, 0x17, 0x4a, 0xc3, 0xae, 0x17, 0x62, 0x97, 0x29, 0xef, 0x32,
+	0x82, 0xf7, 0xa7, 0xe4, 0x27, 0xd3, 0x53, 0xcd, 0xcb, 0x79, 0x30, 0xb6, 0xc4, 0x9e, 0x67, 0x2f,
+	0xe5, 0x79, 0x9e, 0x9d, 0x03, 0x91, 0x1e, 0xc7, 0x53, 0x30, 0x29, 0xc6, 0x9c, 0xfe, 0x2f, 0x58,
+	0xc0, 0x8e, 0x26, 0xa2, 0x26, 0xc3, 0x28, 0x32, 0x80, 0x0a, 0x01, 0x5b, 0x94, 0x09, 0x7b, 0xe2,
+	0xf9, 0x43, 0x45, 0x6a, 0x02, 0x5e, 0x24, 0xe9, 0x17, 0xbb, 0x96, 0x67, 0xad, 0xe8, 0xa7, 0x5f,
+	0x8c, 0x22, 0x30, 0x79, 0x89, 0xc2, 0xe1, 0x1a, 0x39, 0x03, 0x4a, 0x74, 0x6e, 0xa9, 0x5a, 0x47,
+	0xde, 0x38, 0xf8, 0xc5, 0x8b, 0xc1, 0x8b, 0xdc, 0xb3, 0x3d, 0xaf, 0x0d, 0x3f, 0x64, 0x80, 0xaa,
+	0x4d, 0x9e, 0xbe, 0x85, 0xc7, 0x75, 0x9e, 0xff, 0x89, 0xbe, 0x31, 0x5c, 0x7f, 0x2c, 0x63, 0x6b,
+	0x3e, 0xf6, 0x77, 0xbd, 0xed, 0x1b, 0xff, 0xfa, 0xbe, 0x52, 0x1d, 0xd6, 0xa6, 0x56, 0x1f, 0x9e,
+	0x9a, 0x98, 0x12, 0x0d, 0xa6, 0xec, 0xe0, 0x55, 0xde, 0x4f, 0x19, 0x00, 0x2c, 0xd2, 0x64, 0x3f,
+	0x14, 0xdb, 0xe9, 0xf4, 0xfa, 0xe3, 0x80, 0x67, 0x91, 0xeb, 0x33, 0x79, 0x40, 0x70, 0xbc, 0xef,
+	0xa1, 0x78, 0xdf, 0x8e, 0x06, 0xe2, 0x7d, 0xd4, 0x98, 0x80, 0x7f, 0x6c, 0x60, 0xa5, 0x85, 0x3a,
+	0x96, 0xc0, 0xc7, 0x72, 0x3d, 0x8d, 0x5b, 0x7f, 0x3c, 0x6b, 0x73, 0x8e, 0xee, 0x7d, 0x14, 0xdd,
+	0xbb, 0xd1, 0xfe, 0x08, 0xba, 0x34, 0x20, 0x40, 0xf8, 0x58, 0x13, 0x94, 0x3f, 0x8d, 0x51, 0x6e,
+	0x52, 0x57, 0x01, 0x0d, 0x94, 0x93, 0x1e, 0xa2, 0xd5, 0x40, 0x39, 0xf1, 0xed, 0x59, 0x74, 0x80,
+	0xa2, 0x3c, 0x31, 0x71, 0xff, 0x30, 0x94, 0xa7, 0x5e, 0x0a, 0x58, 0xd0, 0x0d, 0xf8, 0x71, 0x8c,
+	0x7b, 0x8b, 0xe6, 0xe9, 0x84, 0x47, 0x33, 0x3c, 0x69, 0x25, 0x10, 0x3f, 0x96, 0xa9, 0xad, 0x8a,
+	0x35, 0x4c, 0x8f, 0xf5, 0x47, 0x0d, 0xb0, 0xa9, 0x15, 0x3e, 0xf9, 0x0a, 0xb3, 0x74, 0x2f, 0x64,
+	0xa0, 0xfa, 0xf1, 0x6c, 0x8d, 0x39, 0xf2, 0xaf, 0xa1, 0xc8, 0xdf, 0x01, 0x87, 0xae, 0x12, 0xf8,
+	0x1d, 0xcc, 0x2e, 0xfb, 0x94, 0x39, 0x4b, 0x2f, 0xfa, 0xcc, 0xe4, 0x7f, 0xae, 0xb5, 0x3e, 0x9b,
+	0x0b, 0x06, 0xa7, 0xe1, 0x71, 0x4a, 0xc3, 0xe1, 0xfa, 0x43, 0x69, 0x27, 0x60, 0x2a, 0xd4, 0x15,
+	0xc9, 0x06, 0xf8, 0x07, 0x03, 0x6c, 0x61, 0xd4, 0xf1, 0x27, 0x49, 0xe1, 0x5c, 0x36, 0xb4, 0xd4,
+	0x17, 0x56, 0xeb, 0x27, 0x73, 0x42, 0xe1, 0xe4, 0x1d, 0xa3, 0xe4, 0x3d, 0x52, 0x3f, 0x90, 0x9a,
+	0x3c, 0xfe, 0xe2, 0x2a, 0xa1, 0xed, 0xdf, 0x82, 0x99, 0x0b, 0x9f, 0x18, 0x85, 0xa7, 0xb3, 0x21,
+	0x16, 0x7b, 0x40, 0xb5, 0x7e, 0x26, 0x3f, 0xa0, 0xcc, 0x73, 0x18, 0xbe, 0xa6, 0x4a, 0xe8, 0xfc,
+	0x67, 0x03, 0xc0, 0x7e, 0x4c, 0x7c, 0xd0, 0x5f, 0xa3, 0x71, 0x29, 0x48, 0x7f, 0x8d, 0x26, 0xc8,
+	0x2f, 0x68, 0x9a, 0xd2, 0x77, 0xac, 0xfe, 0x68, 0x6a, 0xfa, 0x98, 0x61, 0xea, 0x01, 0x26, 0x1d,
+	0x11, 0x12, 0xff, 0xd2, 0xa0, 0x32, 0x08, 0x8d, 0xb0, 0x3e, 0x91, 0xe1, 0x8d, 0x38, 0xf9, 0x55,
+	0xc8, 0xfa, 0x13, 0xd9, 0x01, 0x70, 0x8a, 0x8e, 0x50, 0x8a, 0x1e, 0x42, 0x93, 0xe9, 0x67, 0x8c,
+	0xb4, 0x27, 0x94, 0x7c, 0x11, 0x53, 0x82, 0xf9, 0x9f, 0x26, 0x25, 0xc9, 0xcf, 0xd7, 0x6a, 0x50,
+	0x32, 0xe0, 0x19, 0x5b, 0xf4, 0x28, 0xa5, 0xe4, 0x00, 0xd4, 0xa4, 0x04, 0x7e, 0x1b, 0x8b, 0x29,
+	0x7c, 0x6f, 0x11, 0x4a, 0xa6, 0x33, 0x6e, 0x86, 0xf0, 0x61, 0xda, 0xfa, 0x4c, 0x1e, 0x10, 0x9c,
+	0x9a, 0x93, 0x94, 0x9a, 0x13, 0xf5, 0x43, 0x7a, 0xd4, 0x4c, 0xbd, 0xc4, 0x9e, 0xb2, 0xbc, 0x71,
+	0x94, 0x3e, 0x4c, 0x0b, 0xbf, 0x85, 0x89, 0x63, 0x52, 0x01, 0x25, 0x6e, 0x26, 0xe3, 0xd1, 0x2e,
+	0xcf, 0xd4, 0x6c, 0x2e, 0x18, 0x9c, 0xbc, 0x27, 0x28, 0x79, 0x47, 0x27, 0x0e, 0x67, 0x23, 0xcf,
+	0xbf, 0x01, 0x5f, 0x36, 0xc0, 0x66, 0x8f, 0xbd, 0x41, 0x4a, 0x41, 0xc3, 0x59, 0x0d, 0x4d, 0x62,
+	0xd0, 0x33, 0xab, 0xf5, 0xb9, 0x7c, 0x40, 0xd4, 0x4d, 0x55, 0xcf, 0xb8, 0xa9, 0x30, 0x7b, 0xa0,
+	0x6f, 0xfd, 0x3d, 0x9e, 0xef, 0x09, 0xc9, 0xfa, 0x89, 0xcc, 0xed, 0x39, 0x1d, 0x87, 0x29, 0x1d,
+	0x07, 0xd1, 0x03, 0xa9, 0xe9, 0x20, 0x21, 0x3d, 0x84, 0x8c, 0xcf, 0x33, 0xde, 0xa0, 0x49, 0x46,
+	0xe2, 0xdb, 0xab, 0xf5, 0x13, 0x39, 0x5f, 0x39, 0x45, 0x8f, 0x50, 0x32, 0xa6, 0xa0, 0x1e, 0x19,
+	0xf0, 0xab, 0x06, 0x18, 0x67, 0x8c, 0x01, 0x43, 0x83, 0x4f, 0x64, 0xdb, 0xd4, 0xe1, 0x43, 0xa8,
+	0xf5, 0xe9, 0x1c, 0x10, 0x22, 0x42, 0xc4, 0x43, 0x5a, 0x94, 0x4c, 0xbd, 0x84, 0x35, 0xcc, 0x1b,
+	0xf0, 0x6f, 0x03, 0x5e, 0x40, 0xa7, 0x65, 0x3a, 0xdb, 0x3e, 0x96, 0x67, 0x66, 0x26, 0x0f, 0x08,
+	0x4e, 0xd2, 0x71, 0x4a, 0xd2, 0xa3, 0x13, 0x0f, 0xeb, 0x93, 0x84, 0xb9, 0xc0, 0x37, 0xb1, 0xc0,
+	0xd0, 0x8a, 0x3d, 0xb0, 0xa8, 0xc1, 0xe7, 0x06, 0xbe, 0x05, 0xa9, 0xc1, 0xe7, 0x06, 0xbf, 0xf0,
+	0x88, 0x0e, 0x51, 0xea, 0x1e, 0x84, 0x53, 0xe9, 0x85, 0x5a, 0x46, 0xc1, 0xf7, 0x0c, 0xb0, 0xbb,
+	0x9f, 0xf4, 0x6e, 0x21, 0xd4, 0x95, 0x47, 0x07, 0x90, 0x77, 0x2a, 0x2f, 0x18, 0x4e, 0xe1, 0x09,
+	0x4a, 0xe1, 0x91, 0xba, 0x2e, 0x85, 0x47, 0xc5, 0x93, 0x8e, 0xdf, 0xc5, 0x94, 0x36, 0x93, 0x5e,
+	0x30, 0xd4, 0xa0, 0x74, 0xd8, 0x8b, 0x8b, 0x1a, 0x94, 0x0e, 0x7d, 0x48, 0x51, 0xcc, 0xe5, 0x84,
+	0xf6, 0x5c, 0xfe, 0x35, 0xd6, 0x4c, 0x5a, 0xe2, 0x6a, 0x91, 0x46, 0x84, 0x1f, 0xd1, 0x62, 0x69,
+	0x72, 0x52, 0xd4, 0xfa, 0xd1, 0x2c, 0x4d, 0x39, 0x05, 0xb3, 0x94, 0x82, 0xc7, 0xe0, 0x31, 0x4d,
+	0xf1, 0x95, 0xd4, 0xf1, 0x3b, 0xea, 0x1b, 0xf0, 0xaf, 0xb0, 0x2e, 0xd2, 0x92, 0x52, 0xe6, 0x52,
+	0x82, 0xb4, 0xd4, 0xd7, 0x68, 0xc2, 0x62, 0x3d, 0x53, 0x54, 0x2c, 0x57, 0xaf, 0x38, 0xa6, 0xe0,
+	0x01, 0x5d, 0xb2, 0xe0, 0xd7, 0x0d, 0x72, 0xf5, 0x10, 0x66, 0xb8, 0x85, 0xc7, 0x75, 0x39, 0x5a,
+	0x46, 0x3a, 0x92, 0xd2, 0xea, 0x8a, 0xe9, 0x99, 0xc8, 0x35, 0x3d, 0xdf, 0x30, 0x68, 0x5e, 0xd7,
+	0x20, 0x39, 0xad, 0x06, 0x49, 0x09, 0x39, 0x78, 0x35, 0x48, 0x4a, 0xca, 0x88, 0x8b, 0x4e, 0x51,
+	0x92, 0x9e, 0xa8, 0xe7, 0x21, 0x89, 0xc8, 0x13, 0x64, 0x0b, 0xc9, 0x54, 0xf9, 0x30, 0x1b, 0x62,
+	0xbe, 0xbe, 0x91, 0x2b, 0xd2, 0x5c, 0x3d, 0x89, 0x91, 0xf6, 0x9a, 0x23, 0xd4, 0x60, 0xa9, 0x7c,
+	0xcf, 0x4a, 0x62, 0x22, 0x5c, 0x78, 0x4a, 0x17, 0xaf, 0xe4, 0x64, 0xaf, 0xf5, 0xd3, 0xb9, 0xe1,
+	0x70, 0x42, 0x5f, 0x4f, 0x09, 0xbd, 0xb7, 0x7e, 0x77, 0x84, 0x50, 0x29, 0xf5, 0xec, 0xd4, 0x4b,
+	0xc4, 0x4d, 0xe6, 0x06, 0xd7, 0x6e, 0x77, 0xb5, 0x12, 0x32, 0xea, 0x6a, 0xd8, 0x62, 0x86, 0x24,
+	0xec, 0xd5, 0xb0, 0xc5, 0x0c, 0x4b, 0xeb, 0x8b, 0x10, 0xa5, 0x69, 0x3f, 0xac, 0x0f, 0xa6, 0x89,
+	0xe8, 0x17, 0x7b, 0x9a, 0x89, 0xa9, 0x71, 0xa1, 0xee, 0x81, 0x92, 0x7f, 0x8e, 0x86, 0xe7, 0xe8,
+	0x45, 0xaf, 0xa5, 0xf4, 0xdc, 0x33, 0xb1, 0xfe, 0x1c, 0xc1, 0xaf, 0x18, 0xe0, 0x4e, 0x4b, 0xcd,
+	0x82, 0x7b, 0xca, 0xf5, 0x64, 0x6f, 0x38, 0x5f, 0xcf, 0x2c, 0x91, 0x70, 0xa1, 0xab, 0x67, 0x96,
+	0x48, 0xba, 0x94, 0x45, 0xf7, 0x52, 0x8a, 0xee, 0x42, 0xb7, 0xc5, 0x28, 0x0a, 0xff, 0x99, 0xac,
+	0xb7, 0xbf, 0x33, 0x00, 0x6a, 0xc4, 0xb2, 0xb4, 0xc6, 0x28, 0x9a, 0xd1, 0xb4, 0xc2, 0x27, 0x11,
+	0x35, 0x9b, 0x0b, 0x86, 0x4a, 0x57, 0x7d, 0x3d, 0xba, 0x48, 0x0e, 0x8c, 0x56, 0x98, 0x07, 0x4e,
+	0x86, 0xa5, 0x67, 0x6b, 0xc9, 0x47, 0xc9, 0xe0, 0xfc, 0xa9, 0xe8, 0x28, 0xa5, 0xe4, 0x61, 0x78,
+	0x30, 0xbd, 0x3d, 0x33, 0xf0, 0x6c, 0xe4, 0xd4, 0xc5, 0xee, 0xed, 0x6f, 0x3e, 0x75, 0x03, 0x12,
+	0xe7, 0x66, 0xa0, 0x2e, 0x4c, 0x12, 0xf1, 0xbf, 0x06, 0xd8, 0x61, 0x45, 0xb3, 0x82, 0x6a, 0xa8,
+	0x5b, 0x83, 0x32, 0x99, 0x6a, 0xa8, 0x5b, 0x03, 0x93, 0x92, 0xa2, 0x2b, 0x94, 0xb0, 0x4b, 0xf5,
+	0x0b, 0xc3, 0x09, 0x8b, 0xf9, 0xfc, 0xdc, 0x98, 0x0a, 0x72, 0x4f, 0x4e, 0xbd, 0x14, 0xf3, 0x1f,
+	0xba, 0x01, 0xdf, 0x59, 0x02, 0x35, 0x6f, 0x40, 0x7e, 0x50, 0x78, 0x46, 0xc3, 0xaa, 0x32, 0x34,
+	0xc3, 0x69, 0xfd, 0xec, 0x08, 0x20, 0xa9, 0x23, 0x31, 0x31, 0xea, 0x91, 0xf8, 0x6f, 0x7c, 0x70,
+	0xb4, 0x12, 0xd3, 0x8c, 0x6a, 0x1c, 0x1c, 0x43, 0xf3, 0x9e, 0x6a, 0x1c, 0x1c, 0xc3, 0xf3, 0x9d,
+	0xa2, 0x19, 0x3a, 0x06, 0xc7, 0xe1, 0xd1, 0xec, 0x63, 0x40, 0xec, 0xa7, 0x3b, 0x5a, 0xd1, 0x4c,
+	0x8f, 0xf9, 0xb7, 0xf1, 0x4c, 0x36, 0x1a, 0xe5, 0x34, 0x93, 0xc2, 0xca, 0x08, 0xd3, 0x5b, 0x19,
+	0x03, 0x3e, 0xbc, 0xf6, 0x40, 0x93, 0x90, 0xf1, 0x7d, 0x26, 0xcf, 0xc4, 0x32, 0x27, 0xea, 0xc9,
+	0x33, 0x83, 0x12, 0x3e, 0xea, 0xc9, 0x33, 0x03, 0xd3, 0x37, 0x66, 0xd0, 0xeb, 0x24, 0x3a, 0x97,
+	0x39, 0x45, 0xdf, 0x63, 0xa4, 0xc6, 0x52, 0x8f, 0xea, 0x91, 0x3a, 0x28, 0x3f, 0xaa, 0x1e, 0xa9,
+	0x03, 0xf3, 0x9f, 0xe6, 0x59, 0xb1, 0x01, 0x41, 0xff, 0x88, 0x8f, 0x1f, 0x2f, 0xd9, 0x43, 0x4f,
+	0xe3, 0x52, 0x6d, 0xb8, 0xc3, 0x61, 0xfd, 0x4c, 0x7e, 0x40, 0x9c, 0xe4, 0x49, 0x4a, 0xf2, 0xfd,
+	0xf5, 0x7b, 0x86, 0xc8, 0x0c, 0x53, 0xdc, 0x21, 0x91, 0x9b, 0x90, 0xb7, 0xb7, 0x23, 0xde, 0x6e,
+	0x1a, 0xe6, 0xcb, 0x01, 0x5e, 0x7a, 0x1a, 0xe6, 0xcb, 0x41, 0xae, 0x76, 0xe8, 0x75, 0x94, 0x92,
+	0x9f, 0x40, 0x77, 0x0d, 0xa3, 0x84, 0xa0, 0x4e, 0xc8, 0xc0, 0x5b, 0x6f, 0x5b, 0x4b, 0x8d, 0x4d,
+	0xd6, 0xe1, 0x2a, 0x89, 0xd1, 0xd6, 0x3a, 0xd7, 0x4c, 0xc9, 0x61, 0xd1, 0xc8, 0xa4, 0x34, 0x3c,
+	0x55, 0x3f, 0xa7, 0xb1, 0xd7, 0x82, 0xb0, 0x5d, 0x7a, 0x60, 0x44, 0xe3, 0x38, 0x6f, 0xc0, 0x1f,
+	0x18, 0xc4, 0xad, 0x59, 0x0d, 0x41, 0xd6, 0x98, 0xb1, 0x01, 0x61, 0xd5, 0x1a, 0x33, 0x36, 0x28,
+	0xfe, 0x59, 0x50, 0x3b, 0x31, 0x4a, 0x6a, 0xff, 0xc6, 0x00, 0x5b, 0x5b, 0x4a, 0x34, 0xb3, 0xde,
+	0x15, 0x41, 0x3c, 0x7c, 0xba, 0x7e, 0x22, 0x73, 0x7b, 0xd5, 0x0a, 0x0d, 0x1f, 0xce, 0x42, 0x27,
+	0xfc, 0x82, 0x21, 0x3d, 0x98, 0x06, 0x33, 0x44, 0xa0, 0xea, 0x1b, 0xf7, 0x62, 0xe1, 0xa9, 0xe2,
+	0xee, 0x1d, 0xa5, 0xbf, 0x1b, 0x08, 0x50, 0xa6, 0x2a, 0xc7, 0x27, 0xf0, 0xb4, 0x34, 0x65, 0x33,
+	0xbd, 0x8e, 0x47, 0x4b, 0x3c, 0x83, 0x66, 0xfd, 0x78, 0xb6, 0xc6, 0xaa, 0xdf, 0xd3, 0xc4, 0xba,
+	0x7e, 0x4f, 0x1f, 0x34, 0x68, 0xe8, 0x59, 0x7b, 0x4d, 0xc3, 0xd0, 0x95, 0x90, 0x97, 0x4e, 0xc3,
+	0xd0, 0x95, 0x94, 0x60, 0x0d, 0xdd, 0x49, 0xf1, 0xdd, 0x57, 0xdf, 0x15, 0xc1, 0x97, 0xa2, 0x86,
+	0xf1, 0x3c, 0xf8, 0x91, 0xfd, 0x60, 0x67, 0x24, 0x44, 0x9c, 0xba, 0xf3, 0x7d, 0xcb, 0x20, 0x3e,
+	0x91, 0x2c, 0x98, 0x40, 0x6b, 0xcf, 0x27, 0x46, 0x91, 0x6b, 0xed, 0xf9, 0x28, 0x04, 0xd5, 0x66,
+	0x87, 0xd6, 0x91, 0x26, 0x84, 0x57, 0xf0, 0xa4, 0xb4, 0xa2, 0x02, 0x4f, 0x61, 0x32, 0x33, 0xaf,
+	0x90, 0x8b, 0xf5, 0x20, 0x50, 0x42, 0xc7, 0x89, 0x63, 0x50, 0x8c, 0xbc, 0x8e, 0x13, 0x47, 0x02,
+	0x0c, 0x4e, 0xdf, 0x69, 0x4a, 0xdf, 0xf4, 0xc4, 0x89, 0xd4, 0x1b, 0x25, 0x20, 0x2b, 0xa4, 0x9a,
+	0x30, 0xb2, 0xbf, 0xc7, 0xdb, 0x7e, 0xd9, 0xb6, 0xbc, 0xde, 0x22, 0xd6, 0xf7, 0x35, 0xb6, 0xfd,
+	0x19, 0xd1, 0x46, 0x7f, 0xdb, 0x4b, 0x4d, 0x39, 0x35, 0x0b, 0x94, 0x9a, 0x0b, 0xf5, 0xb3, 0x39,
+	0xa9, 0x99, 0x0a, 0x28, 0x21, 0x73, 0xf7, 0x61, 0x03, 0x54, 0x96, 0x48, 0xf6, 0xc1, 0xf4, 0xdb,
+	0xe2, 0x14, 0xfe, 0xf7, 0x68, 0x7c, 0x8f, 0x86, 0x99, 0x35, 0xd2, 0x7c, 0x1d, 0x2f, 0xd3, 0x30,
+	0xcb, 0x26, 0x3e, 0x4d, 0x36, 0xb7, 0xa4, 0x67, 0xa9, 0xf5, 0xae, 0x22, 0x62, 0x08, 0x3f, 0x96,
+	0xb1, 0x75, 0x6e, 0xf1, 0x34, 0xa4, 0xe8, 0x3f, 0xd9, 0xf9, 0x28, 0xbd, 0x5a, 0xae, 0x77, 0x3e,
+	0xc6, 0x1f, 0x6a, 0xd7, 0x3b, 0x1f, 0x13, 0x9e, 0x4b, 0x47, 0x57, 0x29, 0x5d, 0x4f, 0xc3, 0x8b,
+	0xd9, 0xe9, 0x0a, 0xbf, 0x9e, 0x95, 0xf6, 0x10, 0x16, 0x7d, 0x36, 0x73, 0x8f, 0x2f, 0x16, 0x6a,
+	0x36, 0x97, 0xf1, 0xc1, 0x60, 0xe5, 0x1d, 0x6f, 0x6d, 0xa7, 0xbd, 0xe4, 0x27, 0xb5, 0xd1, 0x05,
+	0x4a, 0xf6, 0x99, 0xfa, 0xa9, 0xbc, 0x9b, 0x8b, 0xc7, 0xd1, 0xfd, 0x9f, 0x01, 0x6a, 0xfd, 0xd8,
+	0x63, 0xc1, 0xdc, 0x13, 0x73, 0x76, 0x04, 0x4f, 0x25, 0xd7, 0xe7, 0xf2, 0x01, 0xe1, 0x74, 0x5f,
+	0xa6, 0x74, 0x5f, 0xd4, 0x10, 0x72, 0x07, 0xd0, 0xad, 0xba, 0x68, 0xbe, 0x0b, 0x9f, 0xd5, 0xd7,
+	0x89, 0x67, 0xb6, 0x06, 0x5b, 0x49, 0x7a, 0xec, 0xb8, 0x9e, 0xf3, 0x5d, 0xd7, 0x03, 0x06, 0xfc,
+	0x75, 0x03, 0xc0, 0xeb, 0xec, 0xdb, 0xaa, 0xd5, 0x76, 0x9a, 0x5c, 0x92, 0xbb, 0xe5, 0x78, 0x7d,
+	0x0c, 0xef, 0x87, 0x80, 0x13, 0xcf, 0xdb, 0x3a, 0x4e, 0xfe, 0x67, 0xa4, 0x66, 0xfa, 0xec, 0x4c,
+	0x6d, 0xad, 0xfa, 0x15, 0xd7, 0xf7, 0x45, 0xd6, 0x41, 0x80, 0x21, 0x9d, 0xd6, 0x97, 0xb1, 0xfa,
+	0xd2, 0x8d, 0x3c, 0xe7, 0xae, 0x21, 0xca, 0x0c, 0x78, 0x65, 0x5e, 0x43, 0x94, 0x19, 0xf4, 0x96,
+	0x7c, 0x06, 0xa7, 0x5b, 0x4e, 0x07, 0x21, 0xeb, 0x87, 0x98, 0x0f, 0x0b, 0x87, 0x62, 0xf6, 0x2a,
+	0x3b, 0x3c, 0x95, 0x71, 0x77, 0x45, 0x5e, 0x94, 0xaf, 0x9f, 0xce, 0x0d, 0x47, 0x24, 0xee, 0xa3,
+	0x04, 0x9e, 0xab, 0x9f, 0xc9, 0xbb, 0x51, 0xc5, 0x93, 0xf4, 0xc4, 0x38, 0xb2, 0xb3, 0x1f, 0x0f,
+	0x6f, 0x85, 0xb3, 0x23, 0x08, 0xf7, 0xd5, 0xe1, 0x4e, 0x83, 0x23, 0x6c, 0x85, 0x71, 0x7e, 0xe2,
+	0xa0, 0x3e, 0xd1, 0xf0, 0xdd, 0x25, 0xb0, 0xbd, 0x19, 0x49, 0x1e, 0xa5, 0x61, 0x9f, 0x5e, 0x27,
+	0xef, 0xd4, 0x28, 0xc4, 0xef, 0x65, 0x4a, 0xdd, 0x22, 0x7a, 0x2e, 0x66, 0x24, 0x59, 0x47, 0xb1,
+	0xd6, 0x16, 0xd0, 0xdf, 0x5b, 0x02, 0xb0, 0x19, 0xcb, 0x4b, 0x05, 0xcf, 0x69, 0x8f, 0x46, 0xc1,
+	0x02, 0xbb, 0x43, 0x47, 0xa4, 0x31, 0x61, 0xe5, 0x1d, 0x91, 0xf5, 0x45, 0xfa, 0x9f, 0xc7, 0x22,
+	0xfd, 0x35, 0xdb, 0xee, 0x4e, 0xb7, 0x9d, 0x55, 0x5b, 0x43, 0xa4, 0x7f, 0x52, 0xb4, 0xd1, 0x17,
+	0xe9, 0xa5, 0xa6, 0x8c, 0xde, 0xfb, 0x8d, 0x03, 0xc6, 0xc1, 0x2f, 0xef, 0x05, 0x3b, 0x4e, 0x13,
+	0x2f, 0xa4, 0x8e, 0x1c, 0xfb, 0xf5, 0x79, 0xe6, 0x7b, 0xa3, 0xbe, 0x48, 0x93, 0x27, 0x64, 0x66,
+	0x3a, 0x43, 0x5b, 0xf5, 0x81, 0x0f, 0x61, 0x9e, 0x84, 0xf7, 0xb2, 0xd9, 0x69, 0x51, 0xa4, 0x87,
+	0x84, 0xcd, 0x7c, 0x8a, 0xd8, 0xf5, 0xc2, 0x18, 0x16, 0xea, 0x3e, 0x94, 0xc5, 0xc5, 0x93, 0xb6,
+	0xcc, 0xe3, 0x3e, 0xce, 0x01, 0x24, 0xfb, 0x04, 0x24, 0x91, 0x01, 0x7f, 0x97, 0xa1, 0x4e, 0x0c,
+	0x00, 0x4e, 0x83, 0x4b, 0x0c, 0x87, 0xb4, 0x7c, 0x97, 0xc2, 0x57, 0x30, 0xea, 0x87, 0xf5, 0x1b,
+	0x72, 0x54, 0xf7, 0x51, 0x54, 0x77, 0xc2, 0x1d, 0x0a, 0xaa, 0x16, 0xfe, 0x17, 0x62, 0xc4, 0xd9,
+	0xe6, 0xab, 0x6f, 0x27, 0x68, 0x0c, 0x6e, 0xf2, 0x6b, 0x11, 0xf5, 0x27, 0xb2, 0x03, 0xe0, 0x18,
+	0xdf, 0x41, 0x31, 0xae, 0xc1, 0x3d, 0x0a, 0xc6, 0x21, 0x57, 0x26, 0xb6, 0xa7, 0x86, 0x92, 0x25,
+	0x1d, 0x6a, 0x07, 0xce, 0xa9, 0xa9, 0xbb, 0x35, 0x54, 0x9e, 0xe4, 0xf4, 0xec, 0xe8, 0x6e, 0x8a,
+	0xf3, 0x6d, 0x48, 0xc5, 0x59, 0x24, 0xff, 0xa6, 0x0c, 0xf4, 0x4f, 0x78, 0x04, 0x98, 0xc0, 0x59,
+	0x2f, 0x02, 0x2c, 0x82, 0xf0, 0xf1, 0x6c, 0x8d, 0x55, 0xd3, 0x3a, 0xbc, 0x27, 0x19, 0x5b, 0xbc,
+	0x03, 0x83, 0xac, 0xe5, 0x37, 0xe0, 0xe7, 0x42, 0x53, 0x9f, 0xfe, 0x70, 0x27, 0x66, 0x4a, 0xd7,
+	0x18, 0xee, 0xe4, 0x84, 0xe9, 0x82, 0x80, 0x89, 0x54, 0x04, 0x7c, 0x04, 0x4b, 0xc9, 0x0d, 0x29,
+	0xf1, 0xb7, 0x86, 0x94, 0x9c, 0x90, 0x6d, 0xbc, 0xfe, 0x58, 0xc6, 0xd6, 0xaa, 0xed, 0x0f, 0xed,
+	0x8a, 0xec, 0x47, 0x87, 0xf8, 0x28, 0x93, 0x75, 0xf2, 0x01, 0x03, 0x80, 0x56, 0x90, 0xcb, 0x5b,
+	0x8f, 0x61, 0xab, 0x69, 0xc1, 0xf5, 0x62, 0x1c, 0x23, 0xc9, 0xc3, 0xd1, 0x7e, 0x8a, 0xe8, 0x1e,
+	0x98, 0x88, 0x28, 0xfc, 0x0c, 0x89, 0xa8, 0x90, 0xf2, 0x6b, 0x6b, 0x0c, 0x6a, 0x42, 0xe2, 0x6f,
+	0x8d, 0x41, 0x4d, 0x4a, 0xea, 0x2d, 0x8e, 0x15, 0x74, 0x4f, 0x12, 0xae, 0xd4, 0xfd, 0x9b, 0xc6,
+	0x4d, 0xd0, 0xa6, 0x64, 0x8c, 0x3f, 0x16, 0xb8, 0x72, 0x6a, 0x63, 0x9f, 0x90, 0x8e, 0x5b, 0xdb,
+	0x95, 0x33, 0x82, 0x3d, 0x57, 0x9c, 0x84, 0xf9, 0x3a, 0x19, 0x7b, 0xf8, 0x25, 0x7e, 0x14, 0x4a,
+	0x39, 0x9e, 0x35, 0x8f, 0xc2, 0x78, 0xc6, 0x6e, 0xcd, 0xa3, 0x30, 0x21, 0xcd, 0x36, 0x9a, 0xa2,
+	0xc8, 0xbf, 0x16, 0xde, 0x17, 0x3b, 0x5f, 0xa6, 0x5e, 0xa2, 0x89, 0xdd, 0xa8, 0x3d, 0x83, 0xb4,
+	0x7b, 0x80, 0xa5, 0xcc, 0xfe, 0x30, 0xe3, 0x83, 0x22, 0x91, 0x9e, 0x1e, 0x1f, 0x8c, 0x64, 0xb4,
+	0xd4, 0xe3, 0x83, 0xd1, 0x0c, 0x85, 0xe8, 0x76, 0x8a, 0xfb, 0x5e, 0xb8, 0x5b, 0xc1, 0xbd, 0x27,
+	0x30, 0xfb, 0x04, 0xb3, 0xad, 0x49, 0x19, 0xca, 0xf4, 0x6c, 0x6b, 0xf1, 0xd4, 0x77, 0x7a, 0xb6,
+	0xb5, 0x84, 0xb4, 0x6d, 0xe2, 0xa0, 0x81, 0xfb, 0x14, 0x94, 0x17, 0xc9, 0x7f, 0x3e, 0xe0, 0x31,
+	0x1c, 0xbf, 0x83, 0x95, 0xb2, 0x56, 0x3c, 0x39, 0x15, 0x9c, 0xd5, 0x77, 0x06, 0x8f, 0x65, 0x4a,
+	0xab, 0xcf, 0xe5, 0x03, 0xa2, 0xc6, 0x3c, 0xc1, 0x07, 0xd3, 0x89, 0x81, 0x53, 0x8d, 0x90, 0x8a,
+	0x57, 0x58, 0x10, 0x47, 0x24, 0x05, 0x88, 0x5e, 0x10, 0x47, 0x72, 0x4e, 0x12, 0x3d, 0x67, 0xb0,
+	0x01, 0x39, 0x48, 0x44, 0x88, 0x03, 0x3c, 0x94, 0x92, 0x34, 0x21, 0xd7, 0x08, 0xd7, 0x8a, 0x99,
+	0x03, 0xe0, 0xbe, 0x94, 0x68, 0x3c, 0x5b, 0xa5, 0xb9, 0x2a, 0x16, 0xc7, 0xe8, 0x9f, 0x87, 0xfe,
+	0x1f, 0x95, 0xed, 0x8e, 0x52, 0xf8, 0xce, 0x00, 0x00,
 }
@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package core
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"strings"
+)
+
+// ParseApis 解析API列表，格式为"METHOD pattern"，多个用','分隔，
+// 如"POST /v4/:project/registry/microservices"
+func ParseApis(s string) map[string]struct{} {
+	apis := make(map[string]struct{})
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		api := strings.Fields(item)
+		if len(api) != 2 {
+			util.Logger().Errorf(nil, "invalid api '%s'", item)
+			continue
+		}
+		apis[strings.ToUpper(api[0])+" "+api[1]] = struct{}{}
+	}
+	return apis
+}
+
+// RetryDisabled 返回API是否关闭了后端临时错误的重试，pattern为路由注册的路径
+func RetryDisabled(method, pattern string) bool {
+	_, ok := ServerInfo.Config.RetryDisabledApis[method+" "+pattern]
+	return ok
+}
@@ -0,0 +1,258 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package error
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/astaxie/beego"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	BUILTIN_BUNDLE_VERSION         = "builtin"
+	DEFAULT_BUNDLE_RELOAD_INTERVAL = 30 * time.Second
+	bundleFileExt                  = ".json"
+)
+
+var bundleLoader = &BundleLoader{}
+
+// Bundle 一种语言的消息包，errors包含所有已注册的错误码，
+// messages为前端和SDK使用的界面文案，缺少的项使用英文消息包中的文案
+type Bundle struct {
+	Language string            `json:"language"`
+	Version  string            `json:"version"`
+	Errors   map[int32]string  `json:"errors"`
+	Messages map[string]string `json:"messages"`
+}
+
+// bundleFile 消息包文件{lang}.json的内容，未声明version时使用内容的摘要
+type bundleFile struct {
+	Version  string            `json:"version"`
+	Errors   map[int32]string  `json:"errors"`
+	Messages map[string]string `json:"messages"`
+}
+
+type BundleStatus struct {
+	Dir        string            `json:"dir"`
+	Versions   map[string]string `json:"versions"`
+	LastReload int64             `json:"lastReload,omitempty"`
+	LastError  string            `json:"lastError,omitempty"`
+}
+
+// BundleLoader 从message_bundle_dir加载消息包，加载失败时继续使用原有的消息包
+type BundleLoader struct {
+	mux        sync.Mutex
+	dir        string
+	modTimes   map[string]time.Time
+	lastReload time.Time
+	lastErr    error
+}
+
+func (l *BundleLoader) Reload(dir string) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.lastReload = time.Now()
+	l.lastErr = l.load(dir)
+	return l.lastErr
+}
+
+func (l *BundleLoader) load(dir string) error {
+	files := make(map[string]*bundleFile)
+	modTimes := make(map[string]time.Time)
+	if len(dir) > 0 {
+		infos, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, fi := range infos {
+			if fi.IsDir() || filepath.Ext(fi.Name()) != bundleFileExt {
+				continue
+			}
+			file := filepath.Join(dir, fi.Name())
+			f, err := readBundleFile(file)
+			if err != nil {
+				return err
+			}
+			files[strings.ToLower(strings.TrimSuffix(fi.Name(), bundleFileExt))] = f
+			modTimes[file] = fi.ModTime()
+		}
+	}
+
+	localeLock.Lock()
+	bundleFiles = files
+	localeLock.Unlock()
+	l.dir, l.modTimes = dir, modTimes
+	util.Logger().Infof("%d message bundle(s) loaded from '%s'.", len(files), dir)
+	return nil
+}
+
+func readBundleFile(file string) (*bundleFile, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	f := &bundleFile{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("parse %s failed, %s", file, err.Error())
+	}
+	for code := range f.Errors {
+		if _, ok := errors[code]; !ok {
+			return nil, fmt.Errorf("unknown error code %d in %s", code, file)
+		}
+	}
+	if len(f.Version) == 0 {
+		sum := sha1.Sum(data)
+		f.Version = hex.EncodeToString(sum[:6])
+	}
+	return f, nil
+}
+
+func (f *bundleFile) getError(code int32) (string, bool) {
+	if f == nil {
+		return "", false
+	}
+	msg, ok := f.Errors[code]
+	return msg, ok
+}
+
+// Changed 消息包目录中的文件有增删或修改
+func (l *BundleLoader) Changed(dir string) bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if dir != l.dir {
+		return true
+	}
+	if len(dir) == 0 {
+		return false
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*"+bundleFileExt))
+	count := 0
+	for _, file := range files {
+		fi, err := os.Stat(file)
+		if err != nil || fi.IsDir() {
+			continue
+		}
+		count++
+		if t, ok := l.modTimes[file]; !ok || !t.Equal(fi.ModTime()) {
+			return true
+		}
+	}
+	return count != len(l.modTimes)
+}
+
+func (l *BundleLoader) Status() *BundleStatus {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	status := &BundleStatus{Dir: l.dir, Versions: make(map[string]string)}
+	localeLock.RLock()
+	for lang, f := range bundleFiles {
+		status.Versions[lang] = f.Version
+	}
+	localeLock.RUnlock()
+	if !l.lastReload.IsZero() {
+		status.LastReload = l.lastReload.Unix()
+	}
+	if l.lastErr != nil {
+		status.LastError = l.lastErr.Error()
+	}
+	return status
+}
+
+func (l *BundleLoader) run(stopCh <-chan struct{}, interval time.Duration) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(interval):
+			dir := beego.AppConfig.DefaultString("message_bundle_dir", "")
+			if !l.Changed(dir) {
+				continue
+			}
+			util.Logger().Warnf(nil, "message bundles in '%s' changed, reload them.", dir)
+			if err := l.Reload(dir); err != nil {
+				util.Logger().Errorf(err, "reload message bundles failed, keep the old ones.")
+			}
+		}
+	}
+}
+
+// GetBundle 返回语言(或其基础语言)的消息包，不支持的语言返回英文消息包
+func GetBundle(lang string) *Bundle {
+	lang = supportedLanguage(lang)
+	if len(lang) == 0 {
+		lang = DEFAULT_LANGUAGE
+	}
+	b := &Bundle{
+		Language: lang,
+		Version:  BUILTIN_BUNDLE_VERSION,
+		Errors:   make(map[int32]string, len(errors)),
+		Messages: make(map[string]string),
+	}
+	for code := range errors {
+		b.Errors[code] = LocalizedMessage(lang, code)
+	}
+	localeLock.RLock()
+	for _, l := range []string{DEFAULT_LANGUAGE, lang} {
+		f, ok := bundleFiles[l]
+		if !ok {
+			continue
+		}
+		for k, v := range f.Messages {
+			b.Messages[k] = v
+		}
+		if l == lang {
+			b.Version = f.Version
+		}
+	}
+	localeLock.RUnlock()
+	return b
+}
+
+// ReloadBundles 手动重新加载消息包
+func ReloadBundles() error {
+	return bundleLoader.Reload(beego.AppConfig.DefaultString("message_bundle_dir", ""))
+}
+
+func BundleLoaderStatus() *BundleStatus {
+	return bundleLoader.Status()
+}
+
+// RunBundleLoader 加载消息包并定期检查消息包目录，文件变化时自动重新加载，
+// message_bundle_reload_interval为0时只在启动时加载
+func RunBundleLoader() {
+	if err := ReloadBundles(); err != nil {
+		util.Logger().Errorf(err, "load message bundles failed.")
+	}
+	interval := time.Duration(beego.AppConfig.DefaultInt64("message_bundle_reload_interval",
+		int64(DEFAULT_BUNDLE_RELOAD_INTERVAL/time.Second))) * time.Second
+	if interval <= 0 {
+		return
+	}
+	util.Go(func(stopCh <-chan struct{}) {
+		bundleLoader.run(stopCh, interval)
+	})
+}
@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package error
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeBundle(t *testing.T, dir, name, content string) {
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+		t.Fatalf("write bundle failed, %s", err.Error())
+	}
+}
+
+func TestBundleLoader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bundles")
+	if err != nil {
+		t.Fatalf("create dir failed, %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+	loader := &BundleLoader{}
+	defer loader.Reload("")
+
+	writeBundle(t, dir, "en.json", `{"messages": {"ui.title": "Service Center", "ui.logout": "Logout"}}`)
+	writeBundle(t, dir, "fr.json", `{"version": "1.0.0", "errors": {"400001": "Paramètre invalide"}, "messages": {"ui.title": "Centre de services"}}`)
+	if !loader.Changed(dir) {
+		t.Fatalf("new dir should be changed")
+	}
+	if err := loader.Reload(dir); err != nil {
+		t.Fatalf("load bundles failed, %s", err.Error())
+	}
+	if loader.Changed(dir) {
+		t.Fatalf("bundles should not be changed")
+	}
+	if !HasLanguage("fr-FR") {
+		t.Fatalf("fr-FR should be supported")
+	}
+	if msg := LocalizedMessage("fr-FR", ErrInvalidParams); msg != "Paramètre invalide" {
+		t.Fatalf("unexpected message %s", msg)
+	}
+	if msg := LocalizedMessage("fr", ErrServiceNotExists); msg != errors[ErrServiceNotExists] {
+		t.Fatalf("unexpected fallback message %s", msg)
+	}
+
+	b := GetBundle("fr-FR")
+	if b.Language != "fr" || b.Version != "1.0.0" || len(b.Errors) != len(errors) {
+		t.Fatalf("unexpected bundle %s/%s", b.Language, b.Version)
+	}
+	if b.Messages["ui.title"] != "Centre de services" || b.Messages["ui.logout"] != "Logout" {
+		t.Fatalf("unexpected messages %v", b.Messages)
+	}
+	b = GetBundle("de")
+	if b.Language != DEFAULT_LANGUAGE || len(b.Version) == 0 || b.Version == BUILTIN_BUNDLE_VERSION {
+		t.Fatalf("unexpected default bundle %s/%s", b.Language, b.Version)
+	}
+	b = GetBundle("zh")
+	if b.Version != BUILTIN_BUNDLE_VERSION || b.Errors[ErrInvalidParams] != "无效的参数" {
+		t.Fatalf("unexpected builtin bundle %s", b.Version)
+	}
+
+	// 加载失败时继续使用原有的消息包
+	writeBundle(t, dir, "de.json", `{"errors": {"1": "unknown"}}`)
+	if !loader.Changed(dir) {
+		t.Fatalf("added file should be changed")
+	}
+	if err := loader.Reload(dir); err == nil {
+		t.Fatalf("unknown error code should be rejected")
+	}
+	if HasLanguage("de") || !HasLanguage("fr") {
+		t.Fatalf("old bundles should be kept")
+	}
+	if status := loader.Status(); len(status.LastError) == 0 || status.Versions["fr"] != "1.0.0" {
+		t.Fatalf("unexpected status %v", status)
+	}
+}
@@ -20,7 +20,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/validate"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	"net/http"
+	"sort"
 )
 
 var errors = map[int32]string{
@@ -48,17 +51,47 @@ var errors = map[int32]string{
 	ErrNotEnoughQuota: "Not enough quota",
 
 	ErrUnauthorized: "Request unauthorized",
+	ErrForbidden:    "Request forbidden",
 
 	ErrInternal:           "Internal server error",
 	ErrUnavailableBackend: "Registry service is unavailable",
 	ErrUnavailableQuota:   "Quota service is unavailable",
 
 	ErrEndpointAlreadyExists: "Endpoint more belong to other service",
+
+	ErrPolicyNotExists:   "Discovery policy does not exist",
+	ErrAlarmNotExists:    "Alarm does not exist",
+	ErrApprovalNotExists: "Dependency approval does not exist",
+
+	ErrSharedDefinitionNotExists: "Shared definition does not exist",
+	ErrSnapshotNotExists:         "Snapshot does not exist or has expired",
+	ErrApiKeyNotExists:           "API key does not exist",
+	ErrSchemaSummaryMismatch:     "Schema summary does not match the content",
+	ErrSchemaRevisionConflict:    "Schema revision does not match the expected revision",
+	ErrInvalidProperties:         "Instance properties are invalid or exceed the limits",
+	ErrRevisionCompacted:         "Revision has been compacted or is out of the history",
+	ErrPropertiesConflict:        "Properties are modified concurrently, please retry",
+	ErrDelegationNotExists:       "Delegation grant does not exist",
+	ErrSchemaFrozen:              "Schemas of the micro-service are frozen",
+
+	ErrAdmissionDenied:      "Request denied by admission",
+	ErrUnavailableAdmission: "Admission service is unavailable",
+
+	ErrStorageLimited: "Registry storage is near the limit",
+
+	ErrUnavailableSchemaSource: "Schema source is unavailable",
+
+	ErrRequestBodyTooLarge: "Request body is too large",
+
+	ErrLockBusy: "Resource is locked by another operation, please retry",
+
+	ErrNamingViolation: "Service naming does not conform to the naming rules",
 }
 
 const (
 	ErrInvalidParams int32 = 400001
 	ErrUnauthorized  int32 = 401002
+	ErrForbidden     int32 = 403004
 	ErrInternal      int32 = 500003
 
 	ErrServiceAlreadyExists int32 = 400010
@@ -87,14 +120,43 @@ const (
 
 	ErrEndpointAlreadyExists int32 = 400025
 
+	ErrPolicyNotExists   int32 = 400026
+	ErrAlarmNotExists    int32 = 400027
+	ErrApprovalNotExists int32 = 400028
+
+	ErrSharedDefinitionNotExists int32 = 400029
+	ErrSnapshotNotExists         int32 = 400030
+	ErrApiKeyNotExists           int32 = 400031
+	ErrSchemaSummaryMismatch     int32 = 400032
+	ErrSchemaRevisionConflict    int32 = 400033
+	ErrInvalidProperties         int32 = 400034
+	ErrRevisionCompacted         int32 = 400035
+	ErrPropertiesConflict        int32 = 400036
+	ErrDelegationNotExists       int32 = 400037
+	ErrSchemaFrozen              int32 = 400038
+
 	ErrNotEnoughQuota   int32 = 400100
 	ErrUnavailableQuota int32 = 500101
+
+	ErrAdmissionDenied      int32 = 400110
+	ErrUnavailableAdmission int32 = 500111
+
+	ErrStorageLimited int32 = 400120
+
+	ErrUnavailableSchemaSource int32 = 500130
+
+	ErrRequestBodyTooLarge int32 = 413140
+
+	ErrLockBusy int32 = 500150
+
+	ErrNamingViolation int32 = 400160
 )
 
 type Error struct {
-	Code    int32  `json:"errorCode,string"`
-	Message string `json:"errorMessage"`
-	Detail  string `json:"detail,omitempty"`
+	Code    int32             `json:"errorCode,string"`
+	Message string            `json:"errorMessage"`
+	Detail  string            `json:"detail,omitempty"`
+	Details []*pb.ErrorDetail `json:"details,omitempty"`
 }
 
 func (e Error) Error() string {
@@ -113,10 +175,22 @@ func (e Error) StatusCode() int {
 	if e.Code >= 500000 {
 		return http.StatusInternalServerError
 	}
+	if e.Code == ErrRequestBodyTooLarge {
+		return http.StatusRequestEntityTooLarge
+	}
+	if e.Code == ErrForbidden {
+		return http.StatusForbidden
+	}
 	return http.StatusBadRequest
 }
 
 func (e Error) HttpWrite(w http.ResponseWriter) {
+	if lang := w.Header().Get("Content-Language"); len(lang) > 0 {
+		e.Message = LocalizedMessage(lang, e.Code)
+	}
+	// 错误响应不可缓存，去掉可缓存读接口预先设置的校验头
+	w.Header().Del("ETag")
+	w.Header().Del("Cache-Control")
 	status := e.StatusCode()
 	w.Header().Add("X-Response-Status", fmt.Sprint(status))
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
@@ -132,6 +206,85 @@ func NewError(code int32, detail string) *Error {
 	}
 }
 
+func (e *Error) WithDetails(details ...*pb.ErrorDetail) *Error {
+	e.Details = append(e.Details, details...)
+	return e
+}
+
+func NewDetail(code int32, field, reason string) *pb.ErrorDetail {
+	return &pb.ErrorDetail{
+		Code:   code,
+		Field:  field,
+		Reason: reason,
+	}
+}
+
+// ValidationDetails converts the validation error to error details, one
+// detail per violated field, the field path is prefixed by 'prefix' if not empty
+func ValidationDetails(prefix string, err error) []*pb.ErrorDetail {
+	if err == nil {
+		return nil
+	}
+	switch ve := err.(type) {
+	case validate.ValidateErrors:
+		details := make([]*pb.ErrorDetail, 0, len(ve))
+		for _, e := range ve {
+			details = append(details, violationDetail(prefix, e))
+		}
+		return details
+	case *validate.ValidateError:
+		return []*pb.ErrorDetail{violationDetail(prefix, ve)}
+	default:
+		return []*pb.ErrorDetail{NewDetail(ErrInvalidParams, prefixField(prefix, ""), err.Error())}
+	}
+}
+
+func violationDetail(prefix string, e *validate.ValidateError) *pb.ErrorDetail {
+	detail := NewDetail(ErrInvalidParams, prefixField(prefix, e.Field), e.Rule.String())
+	detail.Constraint = e.Constraint
+	detail.Value = e.Value
+	return detail
+}
+
+func prefixField(prefix, field string) string {
+	switch {
+	case len(prefix) == 0:
+		return field
+	case len(field) == 0:
+		return prefix
+	default:
+		return prefix + "." + field
+	}
+}
+
+type ErrorCode struct {
+	Code      int32  `json:"code"`
+	Message   string `json:"message"`
+	Status    int    `json:"status"`
+	Retryable bool   `json:"retryable"`
+}
+
+// Codes returns all the registered error codes in order, the messages are localized by lang
+func Codes(lang string) []*ErrorCode {
+	codes := make([]int32, 0, len(errors))
+	for code := range errors {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	ecs := make([]*ErrorCode, 0, len(codes))
+	for _, code := range codes {
+		status := NewError(code, "").StatusCode()
+		ecs = append(ecs, &ErrorCode{
+			Code:      code,
+			Message:   LocalizedMessage(lang, code),
+			Status:    status,
+			Retryable: status == http.StatusInternalServerError,
+		})
+	}
+	return ecs
+}
+
 func RegisterErrors(errs map[int32]string) {
 	for err, msg := range errs {
 		if err < 400000 || err >= 600000 {
@@ -0,0 +1,171 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package error
+
+import (
+	"strings"
+	"sync"
+)
+
+const DEFAULT_LANGUAGE = "en"
+
+var (
+	locales = map[string]map[int32]string{
+		"zh": {
+			ErrInvalidParams: "无效的参数",
+
+			ErrServiceAlreadyExists: "微服务已存在",
+			ErrServiceNotExists:     "微服务不存在",
+			ErrDeployedInstance:     "微服务存在已部署的实例",
+			ErrDependedOnConsumer:   "微服务被消费者依赖",
+
+			ErrUndefinedSchemaId:    "未定义的契约ID",
+			ErrModifySchemaNotAllow: "不允许修改契约",
+			ErrSchemaNotExists:      "契约不存在",
+
+			ErrInstanceNotExists: "实例不存在",
+			ErrPermissionDeny:    "拒绝访问微服务",
+
+			ErrTagNotExists: "标签不存在",
+
+			ErrRuleAlreadyExists:  "规则已存在",
+			ErrBlackAndWhiteRule:  "不能同时存在黑名单和白名单规则",
+			ErrModifyRuleNotAllow: "不允许修改规则类型",
+			ErrRuleNotExists:      "规则不存在",
+
+			ErrNotEnoughQuota: "配额不足",
+
+			ErrUnauthorized: "请求未授权",
+			ErrForbidden:    "没有权限执行该操作",
+
+			ErrInternal:           "服务内部错误",
+			ErrUnavailableBackend: "注册中心服务不可用",
+			ErrUnavailableQuota:   "配额服务不可用",
+
+			ErrEndpointAlreadyExists: "地址已被其他微服务使用",
+
+			ErrPolicyNotExists:   "服务发现策略不存在",
+			ErrAlarmNotExists:    "告警不存在",
+			ErrApprovalNotExists: "依赖审批不存在",
+
+			ErrSharedDefinitionNotExists: "共享定义不存在",
+			ErrSnapshotNotExists:         "快照不存在或已过期",
+			ErrApiKeyNotExists:           "API密钥不存在",
+			ErrSchemaSummaryMismatch:     "契约摘要与内容不一致",
+			ErrSchemaRevisionConflict:    "契约版本与期望版本不一致，契约已被修改",
+			ErrInvalidProperties:         "实例属性不合法或超出限制",
+			ErrRevisionCompacted:         "版本已被压缩或超出历史记录范围",
+			ErrPropertiesConflict:        "属性被并发修改，请重试",
+			ErrDelegationNotExists:       "代理授权不存在",
+			ErrSchemaFrozen:              "微服务的契约已冻结",
+
+			ErrAdmissionDenied:      "请求被准入控制拒绝",
+			ErrUnavailableAdmission: "准入控制服务不可用",
+
+			ErrStorageLimited: "注册中心存储接近上限",
+
+			ErrUnavailableSchemaSource: "契约源不可用",
+
+			ErrRequestBodyTooLarge: "请求体超过大小限制",
+
+			ErrLockBusy: "资源被其他操作锁定，请重试",
+
+			ErrNamingViolation: "服务命名不符合命名规范",
+		},
+	}
+	// 从message_bundle_dir加载的消息包，优先于内置的消息
+	bundleFiles = map[string]*bundleFile{}
+	localeLock  sync.RWMutex
+)
+
+// RegisterLocaleErrors adds or overrides the localized messages of the language
+func RegisterLocaleErrors(lang string, errs map[int32]string) {
+	lang = strings.ToLower(lang)
+	localeLock.Lock()
+	m, ok := locales[lang]
+	if !ok {
+		m = make(map[int32]string, len(errs))
+		locales[lang] = m
+	}
+	for err, msg := range errs {
+		m[err] = msg
+	}
+	localeLock.Unlock()
+}
+
+// Languages returns the languages which have message catalog
+func Languages() []string {
+	localeLock.RLock()
+	langs := make([]string, 0, len(locales)+len(bundleFiles)+1)
+	langs = append(langs, DEFAULT_LANGUAGE)
+	for lang := range locales {
+		langs = append(langs, lang)
+	}
+	for lang := range bundleFiles {
+		if _, ok := locales[lang]; !ok && lang != DEFAULT_LANGUAGE {
+			langs = append(langs, lang)
+		}
+	}
+	localeLock.RUnlock()
+	return langs
+}
+
+// HasLanguage checks the catalog of language 'zh-CN' or its base language 'zh'
+func HasLanguage(lang string) bool {
+	return len(supportedLanguage(lang)) > 0
+}
+
+func supportedLanguage(lang string) string {
+	lang = strings.ToLower(lang)
+	if lang == DEFAULT_LANGUAGE {
+		return lang
+	}
+	localeLock.RLock()
+	defer localeLock.RUnlock()
+	if hasLocale(lang) {
+		return lang
+	}
+	if i := strings.Index(lang, "-"); i > 0 && hasLocale(lang[:i]) {
+		return lang[:i]
+	}
+	return ""
+}
+
+func hasLocale(lang string) bool {
+	if _, ok := locales[lang]; ok {
+		return true
+	}
+	_, ok := bundleFiles[lang]
+	return ok
+}
+
+// LocalizedMessage returns the message of code in language,
+// falls back to the default message if not found.
+func LocalizedMessage(lang string, code int32) string {
+	if lang = supportedLanguage(lang); len(lang) > 0 {
+		localeLock.RLock()
+		msg, ok := bundleFiles[lang].getError(code)
+		if !ok && lang != DEFAULT_LANGUAGE {
+			msg, ok = locales[lang][code]
+		}
+		localeLock.RUnlock()
+		if ok {
+			return msg
+		}
+	}
+	return errors[code]
+}
@@ -0,0 +1,171 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package govern
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+)
+
+// canManageApiKeys 密钥只能由租户自身(鉴权插件认证的身份)或管理员管理，
+// 通过API密钥认证的调用方不能再创建、查询、轮换或删除密钥
+func canManageApiKeys(ctx context.Context, operation string) bool {
+	keyId, _ := ctx.Value(serviceUtil.CTX_API_KEY).(string)
+	if len(keyId) == 0 {
+		return true
+	}
+	util.Logger().Errorf(nil, "%s api keys of %s refused, caller is authenticated by api key %s, operator %s.",
+		operation, util.ParseDomainProject(ctx), keyId, util.GetIPFromContext(ctx))
+	return false
+}
+
+// CreateApiKey 创建当前domain/project范围的API密钥，token只在创建和轮换时返回
+func (governService *GovernService) CreateApiKey(ctx context.Context, in *pb.CreateApiKeyRequest) (*pb.CreateApiKeyResponse, error) {
+	if !canManageApiKeys(ctx, "create") {
+		return &pb.CreateApiKeyResponse{
+			Response: pb.CreateResponse(scerr.ErrForbidden, "API key caller is not allowed to manage api keys."),
+		}, nil
+	}
+	if in == nil || in.Ttl < 0 {
+		return &pb.CreateApiKeyResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Invalid api key ttl."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		return &pb.CreateApiKeyResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	apiKey, token, err := serviceUtil.CreateApiKey(ctx, domainProject, in.Name, in.Ttl)
+	if err != nil {
+		util.Logger().Errorf(err, "create api key of %s failed.", domainProject)
+		return &pb.CreateApiKeyResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, err.Error()),
+		}, err
+	}
+
+	util.Logger().Infof("create api key %s of %s successfully, operator %s.",
+		apiKey.KeyId, domainProject, util.GetIPFromContext(ctx))
+	return &pb.CreateApiKeyResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Create api key successfully."),
+		ApiKey:   apiKey,
+		Token:    token,
+	}, nil
+}
+
+func (governService *GovernService) GetApiKeys(ctx context.Context, in *pb.GetApiKeysRequest) (*pb.GetApiKeysResponse, error) {
+	if !canManageApiKeys(ctx, "get") {
+		return &pb.GetApiKeysResponse{
+			Response: pb.CreateResponse(scerr.ErrForbidden, "API key caller is not allowed to manage api keys."),
+		}, nil
+	}
+	domainProject := util.ParseDomainProject(ctx)
+	apiKeys, err := serviceUtil.GetApiKeys(ctx, domainProject)
+	if err != nil {
+		util.Logger().Errorf(err, "get api keys of %s failed.", domainProject)
+		return &pb.GetApiKeysResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	return &pb.GetApiKeysResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Get api keys successfully."),
+		ApiKeys:  apiKeys,
+	}, nil
+}
+
+// RotateApiKey 轮换secret，旧token在gracePeriod(秒)内仍可使用
+func (governService *GovernService) RotateApiKey(ctx context.Context, in *pb.RotateApiKeyRequest) (*pb.RotateApiKeyResponse, error) {
+	if !canManageApiKeys(ctx, "rotate") {
+		return &pb.RotateApiKeyResponse{
+			Response: pb.CreateResponse(scerr.ErrForbidden, "API key caller is not allowed to manage api keys."),
+		}, nil
+	}
+	if in == nil || in.Ttl < 0 || in.GracePeriod < 0 {
+		return &pb.RotateApiKeyResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Invalid api key ttl or grace period."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		return &pb.RotateApiKeyResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	apiKey, token, err := serviceUtil.RotateApiKey(ctx, domainProject, in.KeyId, in.Ttl, in.GracePeriod)
+	if err != nil {
+		util.Logger().Errorf(err, "rotate api key %s of %s failed.", in.KeyId, domainProject)
+		return &pb.RotateApiKeyResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, err.Error()),
+		}, err
+	}
+	if apiKey == nil {
+		return &pb.RotateApiKeyResponse{
+			Response: pb.CreateResponse(scerr.ErrApiKeyNotExists, "API key does not exist."),
+		}, nil
+	}
+
+	util.Logger().Infof("rotate api key %s of %s successfully, grace period %ds, operator %s.",
+		in.KeyId, domainProject, in.GracePeriod, util.GetIPFromContext(ctx))
+	return &pb.RotateApiKeyResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Rotate api key successfully."),
+		ApiKey:   apiKey,
+		Token:    token,
+	}, nil
+}
+
+func (governService *GovernService) DeleteApiKey(ctx context.Context, in *pb.DeleteApiKeyRequest) (*pb.DeleteApiKeyResponse, error) {
+	if !canManageApiKeys(ctx, "delete") {
+		return &pb.DeleteApiKeyResponse{
+			Response: pb.CreateResponse(scerr.ErrForbidden, "API key caller is not allowed to manage api keys."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		return &pb.DeleteApiKeyResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	ok, err := serviceUtil.DeleteApiKey(ctx, domainProject, in.KeyId)
+	if err != nil {
+		util.Logger().Errorf(err, "delete api key %s of %s failed.", in.KeyId, domainProject)
+		return &pb.DeleteApiKeyResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, err.Error()),
+		}, err
+	}
+	if !ok {
+		return &pb.DeleteApiKeyResponse{
+			Response: pb.CreateResponse(scerr.ErrApiKeyNotExists, "API key does not exist."),
+		}, nil
+	}
+
+	util.Logger().Infof("delete api key %s of %s successfully, operator %s.",
+		in.KeyId, domainProject, util.GetIPFromContext(ctx))
+	return &pb.DeleteApiKeyResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Delete api key successfully."),
+	}, nil
+}
@@ -0,0 +1,196 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package govern
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"golang.org/x/net/context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 间接消费者每多一层，权重衰减的比例
+const BLAST_RADIUS_DECAY = 0.5
+
+var criticalityWeights = map[string]float64{
+	pb.CRITICALITY_HIGH:   2,
+	pb.CRITICALITY_NORMAL: 1,
+	pb.CRITICALITY_LOW:    0.5,
+}
+
+type consumerEdge struct {
+	consumerId string
+	weight     float64
+}
+
+// parseCriticality 解析消费者声明的依赖重要程度，key为serviceName、appId/serviceName或*，
+// 无法识别的程度忽略
+func parseCriticality(consumer *pb.MicroService) map[string]float64 {
+	if consumer.Properties == nil {
+		return nil
+	}
+	v := consumer.Properties[pb.PROP_DEPENDENCY_CRITICALITY]
+	if len(v) == 0 {
+		return nil
+	}
+	m := make(map[string]float64)
+	for _, item := range strings.Split(v, ",") {
+		idx := strings.LastIndex(item, ":")
+		if idx <= 0 {
+			continue
+		}
+		w, ok := criticalityWeights[strings.ToLower(strings.TrimSpace(item[idx+1:]))]
+		if !ok {
+			continue
+		}
+		m[strings.TrimSpace(item[:idx])] = w
+	}
+	return m
+}
+
+// edgeWeight 依次匹配appId/serviceName、serviceName、*，未声明时为normal
+func edgeWeight(criticality map[string]float64, provider *pb.MicroService) float64 {
+	for _, key := range []string{provider.AppId + "/" + provider.ServiceName, provider.ServiceName, "*"} {
+		if w, ok := criticality[key]; ok {
+			return w
+		}
+	}
+	return criticalityWeights[pb.CRITICALITY_NORMAL]
+}
+
+// consumersGraph 按提供者索引消费者，忽略只通过发现记录到且已stale的依赖
+func consumersGraph(t *topology) map[string][]consumerEdge {
+	graph := make(map[string][]consumerEdge)
+	criticalities := make(map[string]map[string]float64)
+	for _, edge := range t.edges {
+		if edge.Stale && !edge.Declared {
+			continue
+		}
+		consumer, provider := t.services[edge.ConsumerServiceId], t.services[edge.ProviderServiceId]
+		if consumer == nil || provider == nil {
+			continue
+		}
+		criticality, ok := criticalities[consumer.ServiceId]
+		if !ok {
+			criticality = parseCriticality(consumer)
+			criticalities[consumer.ServiceId] = criticality
+		}
+		graph[provider.ServiceId] = append(graph[provider.ServiceId],
+			consumerEdge{consumerId: consumer.ServiceId, weight: edgeWeight(criticality, provider)})
+	}
+	return graph
+}
+
+// blastRadius 从提供者出发逐层查找消费者，每个消费者按最短路径计一次，
+// 贡献为其依赖边的权重(同层多条边取最大)乘以衰减比例的(层数-1)次方
+func blastRadius(graph map[string][]consumerEdge, providerId string) *pb.BlastRadiusItem {
+	item := &pb.BlastRadiusItem{ServiceId: providerId}
+	visited := map[string]struct{}{providerId: {}}
+	frontier := []string{providerId}
+	factor := 1.0
+	for depth := 1; len(frontier) > 0; depth++ {
+		weights := make(map[string]float64)
+		var next []string
+		for _, id := range frontier {
+			for _, edge := range graph[id] {
+				if _, ok := visited[edge.consumerId]; ok {
+					continue
+				}
+				w, ok := weights[edge.consumerId]
+				if !ok {
+					next = append(next, edge.consumerId)
+				}
+				if edge.weight > w {
+					weights[edge.consumerId] = edge.weight
+				}
+			}
+		}
+		for _, id := range next {
+			visited[id] = struct{}{}
+			w := weights[id]
+			item.Score += w * factor
+			if w >= criticalityWeights[pb.CRITICALITY_HIGH] {
+				item.CriticalConsumers++
+			}
+			if depth == 1 {
+				item.DirectConsumers++
+			} else {
+				item.TransitiveConsumers++
+			}
+		}
+		frontier = next
+		factor *= BLAST_RADIUS_DECAY
+	}
+	return item
+}
+
+// GetBlastRadius 按影响范围从大到小返回服务，消费者包括其他应用的服务，appId只过滤返回的提供者
+func (governService *GovernService) GetBlastRadius(ctx context.Context, in *pb.GetBlastRadiusRequest) (*pb.GetBlastRadiusResponse, error) {
+	if in == nil {
+		util.Logger().Errorf(nil, "get blast radius failed: invalid params.")
+		return &pb.GetBlastRadiusResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "get blast radius failed: invalid parameters.")
+		return &pb.GetBlastRadiusResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	t, err := loadTopology(ctx, domainProject, "")
+	if err != nil {
+		util.Logger().Errorf(err, "get blast radius of %s failed.", domainProject)
+		return &pb.GetBlastRadiusResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	graph := consumersGraph(t)
+	items := make([]*pb.BlastRadiusItem, 0, len(t.nodes))
+	for _, node := range t.nodes {
+		if len(in.AppId) > 0 && node.Service.AppId != in.AppId {
+			continue
+		}
+		item := blastRadius(graph, node.ServiceId)
+		item.Service = node.Service
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].DirectConsumers > items[j].DirectConsumers
+	})
+
+	total := int64(len(items))
+	start, end := pageRange(total, in.Offset, in.Limit)
+	return &pb.GetBlastRadiusResponse{
+		Response:  pb.CreateResponse(pb.Response_SUCCESS, "Get blast radius successfully."),
+		Items:     items[start:end],
+		Total:     total,
+		Revision:  t.revision,
+		Timestamp: strconv.FormatInt(t.buildTime.Unix(), 10),
+	}, nil
+}
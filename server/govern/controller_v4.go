@@ -17,6 +17,9 @@
 package govern
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
@@ -25,6 +28,7 @@ import (
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
 	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	"strconv"
 	"strings"
 )
 
@@ -37,9 +41,25 @@ type GovernServiceControllerV4 struct {
 func (governService *GovernServiceControllerV4) URLPatterns() []rest.Route {
 	return []rest.Route{
 		{rest.HTTP_METHOD_GET, "/v4/:project/govern/microservices/:serviceId", governService.GetServiceDetail},
+		{rest.HTTP_METHOD_GET, "/v4/:project/govern/microservices/:serviceId/compliance", governService.GetSchemaCompliance},
+		{rest.HTTP_METHOD_GET, "/v4/:project/govern/microservices/:serviceId/instance-history", governService.GetInstanceHistory},
 		{rest.HTTP_METHOD_GET, "/v4/:project/govern/relations", governService.GetGraph},
 		{rest.HTTP_METHOD_GET, "/v4/:project/govern/microservices", governService.GetAllServicesInfo},
 		{rest.HTTP_METHOD_GET, "/v4/:project/govern/apps", governService.GetAllApplications},
+		{rest.HTTP_METHOD_GET, "/v4/:project/govern/apps/:appId/startup-order", governService.GetStartupOrder},
+		{rest.HTTP_METHOD_GET, "/v4/:project/govern/topology", governService.GetTopology},
+		{rest.HTTP_METHOD_GET, "/v4/:project/govern/blast-radius", governService.GetBlastRadius},
+		{rest.HTTP_METHOD_GET, "/v4/:project/govern/instances", governService.SearchInstances},
+		{rest.HTTP_METHOD_GET, "/v4/:project/govern/export/backstage", governService.ExportBackstage},
+		{rest.HTTP_METHOD_GET, "/v4/:project/govern/export/openlineage", governService.ExportOpenLineage},
+		{rest.HTTP_METHOD_POST, "/v4/:project/govern/discovery/simulate", governService.SimulateDiscovery},
+		{rest.HTTP_METHOD_POST, "/v4/:project/govern/snapshots", governService.CreateSnapshot},
+		{rest.HTTP_METHOD_GET, "/v4/:project/govern/snapshots/:snapshotId", governService.GetSnapshot},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/govern/snapshots/:snapshotId", governService.DeleteSnapshot},
+		{rest.HTTP_METHOD_POST, "/v4/:project/govern/apikeys", governService.CreateApiKey},
+		{rest.HTTP_METHOD_GET, "/v4/:project/govern/apikeys", governService.GetApiKeys},
+		{rest.HTTP_METHOD_POST, "/v4/:project/govern/apikeys/:keyId/rotate", governService.RotateApiKey},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/govern/apikeys/:keyId", governService.DeleteApiKey},
 	}
 }
 
@@ -146,6 +166,32 @@ func (governService *GovernServiceControllerV4) GetServiceDetail(w http.Response
 	controller.WriteResponse(w, respInternal, resp)
 }
 
+// GetSchemaCompliance 查询服务契约的合规报告和徽章
+func (governService *GovernServiceControllerV4) GetSchemaCompliance(w http.ResponseWriter, r *http.Request) {
+	request := &pb.GetSchemaComplianceRequest{
+		ServiceId: r.URL.Query().Get(":serviceId"),
+	}
+	resp, _ := GovernServiceAPI.GetSchemaCompliance(r.Context(), request)
+
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+// GetInstanceHistory 查询服务健康实例数的历史样本，window如24h、7d
+func (governService *GovernServiceControllerV4) GetInstanceHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.GetInstanceHistoryRequest{
+		ServiceId: query.Get(":serviceId"),
+		Window:    query.Get("window"),
+	}
+	resp, _ := GovernServiceAPI.GetInstanceHistory(r.Context(), request)
+
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
 func (governService *GovernServiceControllerV4) GetAllServicesInfo(w http.ResponseWriter, r *http.Request) {
 	request := &pb.GetServicesInfoRequest{}
 	ctx := r.Context()
@@ -178,3 +224,273 @@ func (governService *GovernServiceControllerV4) GetAllApplications(w http.Respon
 	resp.Response = nil
 	controller.WriteResponse(w, respInternal, resp)
 }
+
+// GetStartupOrder 查询应用内服务的启动顺序，停止时按相反顺序
+func (governService *GovernServiceControllerV4) GetStartupOrder(w http.ResponseWriter, r *http.Request) {
+	request := &pb.GetStartupOrderRequest{
+		AppId:       r.URL.Query().Get(":appId"),
+		Environment: r.URL.Query().Get("env"),
+	}
+	resp, _ := GovernServiceAPI.GetStartupOrder(r.Context(), request)
+
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+// GetTopology 拓扑视图一次查询所需的服务和依赖，按服务分页
+func (governService *GovernServiceControllerV4) GetTopology(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.GetTopologyRequest{
+		AppId: query.Get("appId"),
+	}
+	var err error
+	if offset := query.Get("offset"); len(offset) > 0 {
+		if request.Offset, err = strconv.ParseInt(offset, 10, 64); err != nil {
+			controller.WriteError(w, scerr.ErrInvalidParams, "parameter offset must be a number")
+			return
+		}
+	}
+	if limit := query.Get("limit"); len(limit) > 0 {
+		if request.Limit, err = strconv.ParseInt(limit, 10, 64); err != nil {
+			controller.WriteError(w, scerr.ErrInvalidParams, "parameter limit must be a number")
+			return
+		}
+	}
+	resp, _ := GovernServiceAPI.GetTopology(r.Context(), request)
+
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+// GetBlastRadius 按依赖计算的影响范围对服务排序，按服务分页
+func (governService *GovernServiceControllerV4) GetBlastRadius(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.GetBlastRadiusRequest{
+		AppId: query.Get("appId"),
+	}
+	var err error
+	if offset := query.Get("offset"); len(offset) > 0 {
+		if request.Offset, err = strconv.ParseInt(offset, 10, 64); err != nil {
+			controller.WriteError(w, scerr.ErrInvalidParams, "parameter offset must be a number")
+			return
+		}
+	}
+	if limit := query.Get("limit"); len(limit) > 0 {
+		if request.Limit, err = strconv.ParseInt(limit, 10, 64); err != nil {
+			controller.WriteError(w, scerr.ErrInvalidParams, "parameter limit must be a number")
+			return
+		}
+	}
+	resp, _ := GovernServiceAPI.GetBlastRadius(r.Context(), request)
+
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+// SearchInstances 跨服务查询实例，properties格式为key:value,key:value
+func (governService *GovernServiceControllerV4) SearchInstances(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.SearchInstancesRequest{
+		Status:        query.Get("status"),
+		Datacenter:    query.Get("datacenter"),
+		Region:        query.Get("region"),
+		AvailableZone: query.Get("availableZone"),
+		Cidr:          query.Get("cidr"),
+	}
+	if props := query.Get("properties"); len(props) > 0 {
+		request.Properties = make(map[string]string)
+		for _, prop := range strings.Split(props, ",") {
+			kv := strings.SplitN(prop, ":", 2)
+			if len(kv) != 2 || len(kv[0]) == 0 {
+				controller.WriteError(w, scerr.ErrInvalidParams, "parameter properties must be in key:value format")
+				return
+			}
+			request.Properties[kv[0]] = kv[1]
+		}
+	}
+	var err error
+	if offset := query.Get("offset"); len(offset) > 0 {
+		if request.Offset, err = strconv.ParseInt(offset, 10, 64); err != nil {
+			controller.WriteError(w, scerr.ErrInvalidParams, "parameter offset must be a number")
+			return
+		}
+	}
+	if limit := query.Get("limit"); len(limit) > 0 {
+		if request.Limit, err = strconv.ParseInt(limit, 10, 64); err != nil {
+			controller.WriteError(w, scerr.ErrInvalidParams, "parameter limit must be a number")
+			return
+		}
+	}
+	resp, _ := GovernServiceAPI.SearchInstances(r.Context(), request)
+
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+// ExportBackstage 导出Backstage的catalog-info.yaml，appId为空时导出所有应用
+func (governService *GovernServiceControllerV4) ExportBackstage(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	ctx := r.Context()
+	domainProject := util.ParseDomainProject(ctx)
+	entities, err := BackstageEntities(ctx, domainProject, query.Get("appId"), query.Get("owner"))
+	if err != nil {
+		util.Logger().Errorf(err, "export backstage entities of %s failed", domainProject)
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	data, err := MarshalBackstage(entities)
+	if err != nil {
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	w.Header().Add("X-Response-Status", fmt.Sprint(http.StatusOK))
+	w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+	w.Header().Set("Content-Disposition", "attachment; filename=catalog-info.yaml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// ExportOpenLineage 导出OpenLineage的JobEvent，可逐个POST到OpenLineage的HTTP接口
+func (governService *GovernServiceControllerV4) ExportOpenLineage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	domainProject := util.ParseDomainProject(ctx)
+	events, err := LineageEvents(ctx, domainProject, r.URL.Query().Get("appId"))
+	if err != nil {
+		util.Logger().Errorf(err, "export lineage events of %s failed", domainProject)
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	controller.WriteJsonObject(w, map[string][]*LineageEvent{"events": events})
+}
+
+// SimulateDiscovery 模拟消费者发现提供者实例的过程，用于排查实例未被发现的原因
+func (governService *GovernServiceControllerV4) SimulateDiscovery(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &SimulateDiscoveryRequest{}
+	if err := json.Unmarshal(message, request); err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	result, e := SimulateDiscovery(r.Context(), request)
+	if e != nil {
+		util.Logger().Errorf(e, "simulate discovery failed")
+		controller.WriteError(w, e.Code, e.Detail)
+		return
+	}
+	controller.WriteJsonObject(w, result)
+}
+
+// CreateSnapshot 创建租户的只读快照，请求体可为空
+func (governService *GovernServiceControllerV4) CreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &pb.CreateSnapshotRequest{}
+	if len(message) > 0 {
+		if err := json.Unmarshal(message, request); err != nil {
+			util.Logger().Error("Unmarshal error", err)
+			controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+			return
+		}
+	}
+	resp, _ := GovernServiceAPI.CreateSnapshot(r.Context(), request)
+
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (governService *GovernServiceControllerV4) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+	request := &pb.GetSnapshotRequest{
+		SnapshotId: r.URL.Query().Get(":snapshotId"),
+	}
+	resp, _ := GovernServiceAPI.GetSnapshot(r.Context(), request)
+
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (governService *GovernServiceControllerV4) DeleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	request := &pb.DeleteSnapshotRequest{
+		SnapshotId: r.URL.Query().Get(":snapshotId"),
+	}
+	resp, _ := GovernServiceAPI.DeleteSnapshot(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+// CreateApiKey 创建API密钥，请求体可为空
+func (governService *GovernServiceControllerV4) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &pb.CreateApiKeyRequest{}
+	if len(message) > 0 {
+		if err := json.Unmarshal(message, request); err != nil {
+			util.Logger().Error("Unmarshal error", err)
+			controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+			return
+		}
+	}
+	resp, _ := GovernServiceAPI.CreateApiKey(r.Context(), request)
+
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (governService *GovernServiceControllerV4) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	resp, _ := GovernServiceAPI.GetApiKeys(r.Context(), &pb.GetApiKeysRequest{})
+
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+// RotateApiKey 轮换API密钥，请求体可为空
+func (governService *GovernServiceControllerV4) RotateApiKey(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &pb.RotateApiKeyRequest{}
+	if len(message) > 0 {
+		if err := json.Unmarshal(message, request); err != nil {
+			util.Logger().Error("Unmarshal error", err)
+			controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+			return
+		}
+	}
+	request.KeyId = r.URL.Query().Get(":keyId")
+	resp, _ := GovernServiceAPI.RotateApiKey(r.Context(), request)
+
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (governService *GovernServiceControllerV4) DeleteApiKey(w http.ResponseWriter, r *http.Request) {
+	request := &pb.DeleteApiKeyRequest{
+		KeyId: r.URL.Query().Get(":keyId"),
+	}
+	resp, _ := GovernServiceAPI.DeleteApiKey(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
@@ -0,0 +1,439 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package govern
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/scheduler"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"github.com/astaxie/beego"
+	"github.com/ghodss/yaml"
+	"golang.org/x/net/context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	BACKSTAGE_API_VERSION       = "backstage.io/v1alpha1"
+	BACKSTAGE_ANNOTATION_PREFIX = "servicecomb.apache.org/"
+	// owner是Backstage实体的必填项，服务属性和请求中都没有指定时使用
+	DEFAULT_EXPORT_OWNER = "unknown"
+
+	OPENLINEAGE_PRODUCER         = "https://github.com/apache/incubator-servicecomb-service-center"
+	OPENLINEAGE_JOB_EVENT_SCHEMA = "https://openlineage.io/spec/2-0-2/OpenLineage.json#/$defs/JobEvent"
+	OPENLINEAGE_DOC_FACET_SCHEMA = "https://openlineage.io/spec/facets/1-0-1/DocumentationJobFacet.json#/$defs/DocumentationJobFacet"
+
+	DEFAULT_LINEAGE_EXPORT_INTERVAL = time.Hour
+)
+
+var (
+	exportNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+	exportNameSeparators   = regexp.MustCompile(`[_.-]{2,}`)
+)
+
+// exportName 转换为Backstage实体名称的格式：字母数字以-_.分隔，不超过63个字符
+func exportName(s string) string {
+	s = exportNameInvalidChars.ReplaceAllString(s, "-")
+	s = exportNameSeparators.ReplaceAllStringFunc(s, func(m string) string {
+		return m[:1]
+	})
+	if len(s) > 63 {
+		s = s[:63]
+	}
+	return strings.Trim(s, "_.-")
+}
+
+// exportComponent 同一应用下同名服务的所有版本导出为一个组件，描述等信息取最新版本
+type exportComponent struct {
+	name      string
+	latest    *pb.MicroService
+	versions  []string
+	providers map[string]struct{}
+}
+
+func componentName(service *pb.MicroService) string {
+	return exportName(service.AppId + "." + service.ServiceName)
+}
+
+func isRegistryService(service *pb.MicroService) bool {
+	return service.AppId == apt.REGISTRY_APP_ID && service.ServiceName == apt.REGISTRY_SERVICE_NAME
+}
+
+// exportComponents 按组件聚合拓扑中的服务和依赖，不包括SC自身和已stale的发现依赖
+func exportComponents(t *topology) []*exportComponent {
+	m := make(map[string]*exportComponent, len(t.nodes))
+	for _, node := range t.nodes {
+		service, ok := t.services[node.ServiceId]
+		if !ok || isRegistryService(service) {
+			continue
+		}
+		name := componentName(service)
+		c, ok := m[name]
+		if !ok {
+			c = &exportComponent{name: name, latest: service, providers: make(map[string]struct{})}
+			m[name] = c
+		}
+		c.versions = append(c.versions, service.Version)
+		if serviceUtil.Larger(service.Version, c.latest.Version) {
+			c.latest = service
+		}
+	}
+	for _, edge := range t.edges {
+		if edge.Stale && !edge.Declared {
+			continue
+		}
+		consumer, provider := t.services[edge.ConsumerServiceId], t.services[edge.ProviderServiceId]
+		if consumer == nil || provider == nil || isRegistryService(provider) {
+			continue
+		}
+		c, ok := m[componentName(consumer)]
+		if !ok {
+			continue
+		}
+		if name := componentName(provider); name != c.name {
+			c.providers[name] = struct{}{}
+		}
+	}
+
+	components := make([]*exportComponent, 0, len(m))
+	for _, c := range m {
+		sort.Strings(c.versions)
+		components = append(components, c)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].name < components[j].name
+	})
+	return components
+}
+
+func (c *exportComponent) providerNames() []string {
+	names := make([]string, 0, len(c.providers))
+	for name := range c.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type BackstageEntity struct {
+	ApiVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Metadata   *BackstageMetadata `json:"metadata"`
+	Spec       interface{}        `json:"spec"`
+}
+
+type BackstageMetadata struct {
+	Name        string            `json:"name"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Links       []*BackstageLink  `json:"links,omitempty"`
+}
+
+type BackstageLink struct {
+	Url   string `json:"url"`
+	Title string `json:"title,omitempty"`
+}
+
+type BackstageComponentSpec struct {
+	Type      string   `json:"type"`
+	Lifecycle string   `json:"lifecycle"`
+	Owner     string   `json:"owner"`
+	System    string   `json:"system,omitempty"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+type BackstageSystemSpec struct {
+	Owner string `json:"owner"`
+}
+
+// lifecycle 已下线的服务为deprecated，开发和测试环境为experimental
+func lifecycle(service *pb.MicroService) string {
+	switch {
+	case service.Retirement != nil:
+		return "deprecated"
+	case service.Environment == pb.ENV_DEV || service.Environment == pb.ENV_TEST:
+		return "experimental"
+	default:
+		return "production"
+	}
+}
+
+func catalogLinks(catalog *pb.ServiceCatalog) []*BackstageLink {
+	if catalog == nil {
+		return nil
+	}
+	var links []*BackstageLink
+	for _, l := range []*BackstageLink{
+		{Url: catalog.DocsUrl, Title: "Docs"},
+		{Url: catalog.RepoUrl, Title: "Repository"},
+		{Url: catalog.RunbookUrl, Title: "Runbook"},
+		{Url: catalog.DashboardUrl, Title: "Dashboard"},
+	} {
+		if len(l.Url) > 0 {
+			links = append(links, l)
+		}
+	}
+	return links
+}
+
+// BackstageEntities 导出租户下appId(为空时为所有应用)的服务，每个应用一个System，
+// 每个服务一个Component，dependsOn为声明和最近发现到的依赖，owner优先取服务的owner属性
+func BackstageEntities(ctx context.Context, domainProject, appId, owner string) ([]*BackstageEntity, error) {
+	t, err := loadTopology(ctx, domainProject, appId)
+	if err != nil {
+		return nil, err
+	}
+	if len(owner) == 0 {
+		owner = DEFAULT_EXPORT_OWNER
+	}
+
+	var entities []*BackstageEntity
+	systems := make(map[string]struct{})
+	for _, c := range exportComponents(t) {
+		service := c.latest
+		system := exportName(service.AppId)
+		if _, ok := systems[system]; !ok {
+			systems[system] = struct{}{}
+			entities = append(entities, &BackstageEntity{
+				ApiVersion: BACKSTAGE_API_VERSION,
+				Kind:       "System",
+				Metadata:   &BackstageMetadata{Name: system, Title: service.AppId},
+				Spec:       &BackstageSystemSpec{Owner: owner},
+			})
+		}
+
+		componentOwner := owner
+		if v := service.Properties["owner"]; len(v) > 0 {
+			componentOwner = v
+		}
+		var dependsOn []string
+		for _, name := range c.providerNames() {
+			dependsOn = append(dependsOn, "component:"+name)
+		}
+		annotations := map[string]string{
+			BACKSTAGE_ANNOTATION_PREFIX + "domain-project": domainProject,
+			BACKSTAGE_ANNOTATION_PREFIX + "app-id":         service.AppId,
+			BACKSTAGE_ANNOTATION_PREFIX + "service-name":   service.ServiceName,
+			BACKSTAGE_ANNOTATION_PREFIX + "service-id":     service.ServiceId,
+			BACKSTAGE_ANNOTATION_PREFIX + "versions":       strings.Join(c.versions, ","),
+		}
+		if len(service.Environment) > 0 {
+			annotations[BACKSTAGE_ANNOTATION_PREFIX+"environment"] = service.Environment
+		}
+		if service.Catalog != nil && len(service.Catalog.RepoUrl) > 0 {
+			annotations["backstage.io/source-location"] = "url:" + service.Catalog.RepoUrl
+		}
+		entities = append(entities, &BackstageEntity{
+			ApiVersion: BACKSTAGE_API_VERSION,
+			Kind:       "Component",
+			Metadata: &BackstageMetadata{
+				Name:        c.name,
+				Title:       service.ServiceName,
+				Description: service.Description,
+				Annotations: annotations,
+				Links:       catalogLinks(service.Catalog),
+			},
+			Spec: &BackstageComponentSpec{
+				Type:      "service",
+				Lifecycle: lifecycle(service),
+				Owner:     componentOwner,
+				System:    system,
+				DependsOn: dependsOn,
+			},
+		})
+	}
+	return entities, nil
+}
+
+// MarshalBackstage 输出为多文档的catalog-info.yaml
+func MarshalBackstage(entities []*BackstageEntity) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	for i, entity := range entities {
+		data, err := yaml.Marshal(entity)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// LineageEvent OpenLineage的JobEvent，只描述服务之间静态的依赖关系，不包括运行信息
+type LineageEvent struct {
+	EventTime string            `json:"eventTime"`
+	Producer  string            `json:"producer"`
+	SchemaURL string            `json:"schemaURL"`
+	Job       *LineageJob       `json:"job"`
+	Inputs    []*LineageDataset `json:"inputs"`
+	Outputs   []*LineageDataset `json:"outputs"`
+}
+
+type LineageJob struct {
+	Namespace string                 `json:"namespace"`
+	Name      string                 `json:"name"`
+	Facets    map[string]interface{} `json:"facets,omitempty"`
+}
+
+type LineageDataset struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func lineageNamespace(domainProject string) string {
+	return "servicecomb://" + domainProject
+}
+
+// LineageEvents 每个服务一个job，输出为服务自身的接口，输入为其依赖的服务的接口，
+// 名称与Backstage组件名称一致
+func LineageEvents(ctx context.Context, domainProject, appId string) ([]*LineageEvent, error) {
+	t, err := loadTopology(ctx, domainProject, appId)
+	if err != nil {
+		return nil, err
+	}
+	namespace := lineageNamespace(domainProject)
+	eventTime := t.buildTime.UTC().Format(time.RFC3339)
+
+	components := exportComponents(t)
+	events := make([]*LineageEvent, 0, len(components))
+	for _, c := range components {
+		inputs := make([]*LineageDataset, 0, len(c.providers))
+		for _, name := range c.providerNames() {
+			inputs = append(inputs, &LineageDataset{Namespace: namespace, Name: name})
+		}
+		job := &LineageJob{Namespace: namespace, Name: c.name}
+		if len(c.latest.Description) > 0 {
+			job.Facets = map[string]interface{}{
+				"documentation": map[string]string{
+					"_producer":   OPENLINEAGE_PRODUCER,
+					"_schemaURL":  OPENLINEAGE_DOC_FACET_SCHEMA,
+					"description": c.latest.Description,
+				},
+			}
+		}
+		events = append(events, &LineageEvent{
+			EventTime: eventTime,
+			Producer:  OPENLINEAGE_PRODUCER,
+			SchemaURL: OPENLINEAGE_JOB_EVENT_SCHEMA,
+			Job:       job,
+			Inputs:    inputs,
+			Outputs:   []*LineageDataset{{Namespace: namespace, Name: c.name}},
+		})
+	}
+	return events, nil
+}
+
+// lineageTenants 返回有服务的所有租户
+func lineageTenants(ctx context.Context) ([]string, error) {
+	resp, err := store.Store().Service().Search(ctx,
+		registry.WithStrKey(apt.GetServiceRootKey("")),
+		registry.WithPrefix(),
+		registry.WithKeyOnly())
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]struct{})
+	for _, kv := range resp.Kvs {
+		_, domainProject, _ := pb.GetInfoFromSvcKV(kv)
+		m[domainProject] = struct{}{}
+	}
+	tenants := make([]string, 0, len(m))
+	for domainProject := range m {
+		tenants = append(tenants, domainProject)
+	}
+	sort.Strings(tenants)
+	return tenants, nil
+}
+
+// PublishLineage 把所有租户的JobEvent逐个POST到OpenLineage的HTTP接口，
+// 如Marquez的http://host:5000/api/v1/lineage，返回发送的事件数
+func PublishLineage(ctx context.Context, addr string) (int, error) {
+	u, err := url.Parse(addr)
+	if err != nil || len(u.Host) == 0 {
+		return 0, fmt.Errorf("invalid lineage_export_url '%s'", addr)
+	}
+	client, err := rest.GetClient(u.Scheme)
+	if err != nil {
+		return 0, err
+	}
+	tenants, err := lineageTenants(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, domainProject := range tenants {
+		events, err := LineageEvents(ctx, domainProject, "")
+		if err != nil {
+			return n, err
+		}
+		for _, evt := range events {
+			resp, err := client.HttpDo(http.MethodPost, addr, nil, evt)
+			if err != nil {
+				return n, err
+			}
+			resp.Body.Close()
+			if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+				return n, fmt.Errorf("post lineage of %s/%s failed, status code %d",
+					domainProject, evt.Job.Name, resp.StatusCode)
+			}
+			n++
+		}
+	}
+	return n, nil
+}
+
+// RunLineageExport 配置了lineage_export_url时，由一个节点定期发布依赖关系
+func RunLineageExport() {
+	addr := beego.AppConfig.String("lineage_export_url")
+	if len(addr) == 0 {
+		return
+	}
+	interval := time.Duration(beego.AppConfig.DefaultInt64("lineage_export_interval",
+		int64(DEFAULT_LINEAGE_EXPORT_INTERVAL/time.Second))) * time.Second
+	if interval <= 0 {
+		return
+	}
+	scheduler.Register(&scheduler.Job{
+		Name:      "lineage_export",
+		Priority:  scheduler.PRIORITY_LOW,
+		Interval:  interval,
+		Immediate: true,
+		Singleton: true,
+		Func: func(ctx context.Context) error {
+			n, err := PublishLineage(ctx, addr)
+			if err != nil {
+				return err
+			}
+			util.Logger().Infof("%d lineage event(s) are published to %s", n, addr)
+			return nil
+		},
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	"github.com/apache/incubator-servicecomb-service-center/server/govern"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/admission/buildin"
 	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/quota/buildin"
 	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/etcd"
 	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/uuid/dynamic"
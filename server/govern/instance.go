@@ -0,0 +1,146 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package govern
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"net"
+	"sort"
+)
+
+const DEFAULT_SEARCH_LIMIT = 100
+
+type instanceFilter struct {
+	*pb.SearchInstancesRequest
+	ipNet *net.IPNet
+}
+
+func (f *instanceFilter) Match(instance *pb.MicroServiceInstance) bool {
+	if len(f.Status) > 0 && instance.Status != f.Status {
+		return false
+	}
+	if len(f.Datacenter) > 0 || len(f.Region) > 0 || len(f.AvailableZone) > 0 {
+		dc := instance.DataCenterInfo
+		if dc == nil {
+			return false
+		}
+		if (len(f.Datacenter) > 0 && dc.Name != f.Datacenter) ||
+			(len(f.Region) > 0 && dc.Region != f.Region) ||
+			(len(f.AvailableZone) > 0 && dc.AvailableZone != f.AvailableZone) {
+			return false
+		}
+	}
+	for k, v := range f.Properties {
+		if pv, ok := instance.Properties[k]; !ok || pv != v {
+			return false
+		}
+	}
+	if f.ipNet != nil {
+		for _, ep := range instance.Endpoints {
+			if ip := serviceUtil.EndpointIP(ep); ip != nil && f.ipNet.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func (governService *GovernService) SearchInstances(ctx context.Context, in *pb.SearchInstancesRequest) (*pb.SearchInstancesResponse, error) {
+	if in == nil {
+		util.Logger().Errorf(nil, "search instances failed: invalid params.")
+		return &pb.SearchInstancesResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "search instances failed: invalid parameters.")
+		return &pb.SearchInstancesResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	filter := &instanceFilter{SearchInstancesRequest: in}
+	if len(in.Cidr) > 0 {
+		_, filter.ipNet, err = net.ParseCIDR(in.Cidr)
+		if err != nil {
+			util.Logger().Errorf(err, "search instances failed: invalid cidr %s.", in.Cidr)
+			return &pb.SearchInstancesResponse{
+				Response: pb.CreateResponse(scerr.ErrInvalidParams, "Invalid parameter cidr."),
+			}, nil
+		}
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	opts := append(serviceUtil.FromContext(ctx),
+		registry.WithStrKey(apt.GetInstanceRootKey(domainProject)+"/"),
+		registry.WithPrefix())
+	resp, err := store.Store().Instance().Search(ctx, opts...)
+	if err != nil {
+		util.Logger().Errorf(err, "search instances failed: query instances from etcd failed.")
+		return &pb.SearchInstancesResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	instances := make([]*pb.MicroServiceInstance, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		instance := &pb.MicroServiceInstance{}
+		err := store.Unmarshal(kv.Value, instance)
+		if err != nil {
+			util.Logger().Errorf(err, "unmarshal instance %s failed.", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		if filter.Match(instance) {
+			instances = append(instances, instance)
+		}
+	}
+	// 缓存中的顺序不固定，排序后分页
+	sort.Slice(instances, func(i, j int) bool {
+		if instances[i].ServiceId != instances[j].ServiceId {
+			return instances[i].ServiceId < instances[j].ServiceId
+		}
+		return instances[i].InstanceId < instances[j].InstanceId
+	})
+
+	total := int64(len(instances))
+	limit := in.Limit
+	if limit == 0 {
+		limit = DEFAULT_SEARCH_LIMIT
+	}
+	start, end := in.Offset, in.Offset+limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	return &pb.SearchInstancesResponse{
+		Response:  pb.CreateResponse(pb.Response_SUCCESS, "Search instances successfully."),
+		Instances: instances[start:end],
+		Total:     total,
+	}, nil
+}
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package govern
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+)
+
+// GetSchemaCompliance 返回服务契约的合规报告和徽章，检查项由schema_compliance_checks配置
+func (governService *GovernService) GetSchemaCompliance(ctx context.Context, in *pb.GetSchemaComplianceRequest) (*pb.GetSchemaComplianceResponse, error) {
+	if in == nil {
+		util.Logger().Errorf(nil, "get schema compliance failed: invalid params.")
+		return &pb.GetSchemaComplianceResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "get schema compliance failed, serviceId %s: invalid parameters.", in.ServiceId)
+		return &pb.GetSchemaComplianceResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	service, err := serviceUtil.GetService(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "get schema compliance failed, serviceId %s: get service failed.", in.ServiceId)
+		return &pb.GetSchemaComplianceResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if service == nil {
+		util.Logger().Errorf(nil, "get schema compliance failed, serviceId %s: service does not exist.", in.ServiceId)
+		return &pb.GetSchemaComplianceResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+
+	report, err := serviceUtil.GetComplianceReport(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "get schema compliance failed, serviceId %s: check schemas failed.", in.ServiceId)
+		return &pb.GetSchemaComplianceResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	return &pb.GetSchemaComplianceResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Get schema compliance successfully."),
+		Report:   report,
+	}, nil
+}
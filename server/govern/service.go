@@ -178,7 +178,7 @@ func (governService *GovernService) GetApplications(ctx context.Context, in *pb.
 	err := apt.Validate(in)
 	if err != nil {
 		return &pb.GetAppsResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 
@@ -407,12 +407,11 @@ func statistics(ctx context.Context) (*pb.Statistics, error) {
 	result.Services.Count = int64(len(svcWithNonVersion) - scSvcCount)
 	result.Apps.Count = int64(len(app))
 
-	// instance
+	// instance，需要实例内容以汇总容量提示
 	key = apt.GetInstanceRootKey(domainProject)
 	instOpts := append(opts,
 		registry.WithStrKey(key),
-		registry.WithPrefix(),
-		registry.WithKeyOnly())
+		registry.WithPrefix())
 	respIns, err := store.Store().Instance().Search(ctx, instOpts...)
 	if err != nil {
 		return nil, err
@@ -424,6 +423,15 @@ func statistics(ctx context.Context) (*pb.Statistics, error) {
 		if _, ok := onlineServices[serviceId]; !ok {
 			onlineServices[serviceId] = nil
 		}
+		if serviceId == apt.Service.ServiceId {
+			continue
+		}
+		instance := &pb.MicroServiceInstance{}
+		if err := store.Unmarshal(kv.Value, instance); err != nil {
+			util.Logger().Errorf(err, "unmarshal instance %s failed.", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		result.Instances.Capacity += int64(instance.Capacity)
 	}
 
 	key = apt.GenerateInstanceKey(domainProject, apt.Service.ServiceId, "")
@@ -17,9 +17,14 @@
 package govern_test
 
 import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/govern"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"strings"
 )
 
 var _ = Describe("'Govern' service", func() {
@@ -65,6 +70,50 @@ var _ = Describe("'Govern' service", func() {
 		})
 	})
 
+	Describe("execute 'statistics' operation", func() {
+		Context("when instances have capacity hints", func() {
+			It("should be aggregated", func() {
+				respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						AppId:       "govern_capacity_group",
+						ServiceName: "govern_capacity_name",
+						Version:     "1.0.0",
+						Level:       "FRONT",
+						Status:      pb.MS_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				resp, err := governService.GetServicesInfo(getContext(), &pb.GetServicesInfoRequest{
+					Options: []string{"statistics"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				capacity := resp.Statistics.Instances.Capacity
+
+				respIns, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: respCreate.ServiceId,
+						HostName:  "UT-GOVERN-CAPACITY",
+						Endpoints: []string{"rest://127.0.0.13:8080"},
+						Status:    pb.MSI_UP,
+						Capacity:  50,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respIns.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				resp, err = governService.GetServicesInfo(getContext(), &pb.GetServicesInfoRequest{
+					Options: []string{"statistics"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.Statistics.Instances.Capacity).To(Equal(capacity + 50))
+			})
+		})
+	})
+
 	Describe("execute 'get detail' operation", func() {
 		var (
 			serviceId string
@@ -165,4 +214,727 @@ var _ = Describe("'Govern' service", func() {
 			})
 		})
 	})
+
+	Describe("execute 'startup order' operation", func() {
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				resp, err := governService.GetStartupOrder(getContext(), &pb.GetStartupOrderRequest{})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				resp, err = governService.GetStartupOrder(getContext(), &pb.GetStartupOrderRequest{
+					AppId:       "startup_order_app",
+					Environment: "non-exist-env",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				for _, name := range []string{"gateway", "order", "store", "ping", "pong"} {
+					resp, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+						Service: &pb.MicroService{
+							AppId:       "startup_order_app",
+							ServiceName: name,
+							Version:     "1.0.0",
+							Level:       "BACK",
+							Status:      pb.MS_UP,
+						},
+					})
+					Expect(err).To(BeNil())
+					Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				}
+				resp, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						AppId:       "startup_order_other_app",
+						ServiceName: "auth",
+						Version:     "1.0.0",
+						Level:       "BACK",
+						Status:      pb.MS_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				key := func(app, name string) *pb.DependencyKey {
+					return &pb.DependencyKey{AppId: app, ServiceName: name, Version: "1.0.0"}
+				}
+				app := "startup_order_app"
+				respDep, err := serviceResource.CreateDependenciesForMicroServices(getContext(), &pb.CreateDependenciesRequest{
+					Dependencies: []*pb.ConsumerDependency{
+						{
+							Consumer: key(app, "gateway"),
+							Providers: []*pb.DependencyKey{key(app, "order"), key(app, "store"),
+								key("startup_order_other_app", "auth")},
+						},
+						{Consumer: key(app, "order"), Providers: []*pb.DependencyKey{key(app, "store")}},
+						{Consumer: key(app, "ping"), Providers: []*pb.DependencyKey{key(app, "pong")}},
+						{Consumer: key(app, "pong"), Providers: []*pb.DependencyKey{key(app, "ping")}},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respDep.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respOrder, err := governService.GetStartupOrder(getContext(), &pb.GetStartupOrderRequest{
+					AppId: app,
+				})
+				Expect(err).To(BeNil())
+				Expect(respOrder.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respOrder.Order).To(Equal([]string{"ping", "pong", "store", "order", "gateway"}))
+				Expect(len(respOrder.Layers)).To(Equal(3))
+				Expect(respOrder.Layers[0].ServiceNames).To(Equal([]string{"ping", "pong", "store"}))
+				Expect(len(respOrder.Cycles)).To(Equal(1))
+				Expect(respOrder.Cycles[0].ServiceNames).To(Equal([]string{"ping", "pong"}))
+
+				By("env is different")
+				respOrder, err = governService.GetStartupOrder(getContext(), &pb.GetStartupOrderRequest{
+					AppId:       app,
+					Environment: pb.ENV_PROD,
+				})
+				Expect(err).To(BeNil())
+				Expect(respOrder.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respOrder.Order)).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("execute 'topology' operation", func() {
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				resp, err := governService.GetTopology(getContext(), &pb.GetTopologyRequest{
+					AppId: "topology_app",
+					Limit: 1001,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				ids := make(map[string]string)
+				for _, name := range []string{"topology_consumer", "topology_provider"} {
+					resp, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+						Service: &pb.MicroService{
+							AppId:       "topology_app",
+							ServiceName: name,
+							Version:     "1.0.0",
+							Level:       "BACK",
+							Status:      pb.MS_UP,
+						},
+					})
+					Expect(err).To(BeNil())
+					Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+					ids[name] = resp.ServiceId
+				}
+				respInst, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: ids["topology_provider"],
+						HostName:  "topology-host",
+						Endpoints: []string{"rest://127.0.0.1:8080"},
+						Status:    pb.MSI_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respInst.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				key := func(name string) *pb.DependencyKey {
+					return &pb.DependencyKey{AppId: "topology_app", ServiceName: name, Version: "1.0.0"}
+				}
+				respDep, err := serviceResource.CreateDependenciesForMicroServices(getContext(), &pb.CreateDependenciesRequest{
+					Dependencies: []*pb.ConsumerDependency{
+						{Consumer: key("topology_consumer"), Providers: []*pb.DependencyKey{key("topology_provider")}},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respDep.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				resp, err := governService.GetTopology(getContext(), &pb.GetTopologyRequest{
+					AppId: "topology_app",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.Total).To(Equal(int64(2)))
+				Expect(resp.Nodes[0].ServiceId).To(Equal(ids["topology_consumer"]))
+				Expect(resp.Nodes[1].ServiceId).To(Equal(ids["topology_provider"]))
+				Expect(resp.Nodes[1].InstanceCount).To(Equal(int64(1)))
+				Expect(resp.Nodes[1].Health).To(Equal(float64(1)))
+				Expect(len(resp.Edges)).To(Equal(1))
+				Expect(resp.Edges[0].ConsumerServiceId).To(Equal(ids["topology_consumer"]))
+				Expect(resp.Edges[0].ProviderServiceId).To(Equal(ids["topology_provider"]))
+				Expect(resp.Edges[0].Declared).To(BeTrue())
+				Expect(resp.Edges[0].Stale).To(BeTrue())
+
+				By("paginate")
+				resp, err = governService.GetTopology(getContext(), &pb.GetTopologyRequest{
+					AppId:  "topology_app",
+					Offset: 1,
+					Limit:  1,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.Total).To(Equal(int64(2)))
+				Expect(len(resp.Nodes)).To(Equal(1))
+				Expect(resp.Nodes[0].ServiceId).To(Equal(ids["topology_provider"]))
+				Expect(len(resp.Edges)).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("execute 'blast radius' operation", func() {
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				resp, err := governService.GetBlastRadius(getContext(), &pb.GetBlastRadiusRequest{
+					AppId: "blast_app",
+					Limit: 1001,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be ranked", func() {
+				// blast_front -(high)-> blast_middle -(low)-> blast_backend
+				ids := make(map[string]string)
+				criticality := map[string]string{
+					"blast_front":   "blast_middle:high",
+					"blast_middle":  "*:low",
+					"blast_backend": "",
+				}
+				for _, name := range []string{"blast_front", "blast_middle", "blast_backend"} {
+					resp, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+						Service: &pb.MicroService{
+							AppId:       "blast_app",
+							ServiceName: name,
+							Version:     "1.0.0",
+							Level:       "BACK",
+							Status:      pb.MS_UP,
+							Properties: map[string]string{
+								pb.PROP_DEPENDENCY_CRITICALITY: criticality[name],
+							},
+						},
+					})
+					Expect(err).To(BeNil())
+					Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+					ids[name] = resp.ServiceId
+				}
+
+				key := func(name string) *pb.DependencyKey {
+					return &pb.DependencyKey{AppId: "blast_app", ServiceName: name, Version: "1.0.0"}
+				}
+				respDep, err := serviceResource.CreateDependenciesForMicroServices(getContext(), &pb.CreateDependenciesRequest{
+					Dependencies: []*pb.ConsumerDependency{
+						{Consumer: key("blast_front"), Providers: []*pb.DependencyKey{key("blast_middle")}},
+						{Consumer: key("blast_middle"), Providers: []*pb.DependencyKey{key("blast_backend")}},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respDep.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				resp, err := governService.GetBlastRadius(getContext(), &pb.GetBlastRadiusRequest{
+					AppId: "blast_app",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.Total).To(Equal(int64(3)))
+				Expect(resp.Items[0].ServiceId).To(Equal(ids["blast_middle"]))
+				Expect(resp.Items[0].DirectConsumers).To(Equal(int64(1)))
+				Expect(resp.Items[0].CriticalConsumers).To(Equal(int64(1)))
+				Expect(resp.Items[0].Score).To(Equal(float64(2)))
+				Expect(resp.Items[1].ServiceId).To(Equal(ids["blast_backend"]))
+				Expect(resp.Items[1].DirectConsumers).To(Equal(int64(1)))
+				Expect(resp.Items[1].TransitiveConsumers).To(Equal(int64(1)))
+				Expect(resp.Items[1].CriticalConsumers).To(Equal(int64(1)))
+				Expect(resp.Items[1].Score).To(Equal(1.5))
+				Expect(resp.Items[2].ServiceId).To(Equal(ids["blast_front"]))
+				Expect(resp.Items[2].Score).To(Equal(float64(0)))
+
+				By("paginate")
+				resp, err = governService.GetBlastRadius(getContext(), &pb.GetBlastRadiusRequest{
+					AppId:  "blast_app",
+					Offset: 1,
+					Limit:  1,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.Total).To(Equal(int64(3)))
+				Expect(len(resp.Items)).To(Equal(1))
+				Expect(resp.Items[0].ServiceId).To(Equal(ids["blast_backend"]))
+			})
+		})
+	})
+
+	Describe("execute 'search instances' operation", func() {
+		var (
+			serviceId string
+		)
+
+		It("should be passed", func() {
+			resp, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "govern_service_group",
+					ServiceName: "govern_search_service",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = resp.ServiceId
+
+			for _, instance := range []*pb.MicroServiceInstance{
+				{
+					Endpoints:      []string{"rest://10.1.0.1:8080"},
+					Status:         pb.MSI_UP,
+					DataCenterInfo: &pb.DataCenterInfo{Name: "dc1", Region: "r1", AvailableZone: "az1"},
+				},
+				{
+					Endpoints:      []string{"highway:10.2.0.1:8080"},
+					Status:         pb.MSI_DOWN,
+					DataCenterInfo: &pb.DataCenterInfo{Name: "dc1", Region: "r1", AvailableZone: "az2"},
+				},
+				{
+					Endpoints: []string{"rest://192.168.0.1:80?sslEnabled=true"},
+					Status:    pb.MSI_UP,
+				},
+			} {
+				instance.ServiceId = serviceId
+				instance.HostName = "UT-HOST"
+				instance.Properties = map[string]string{"govern_search": "true"}
+				respReg, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+					Instance: instance,
+				})
+				Expect(err).To(BeNil())
+				Expect(respReg.Response.Code).To(Equal(pb.Response_SUCCESS))
+			}
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				resp, err := governService.SearchInstances(getContext(), &pb.SearchInstancesRequest{
+					Status: "unknown",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				resp, err = governService.SearchInstances(getContext(), &pb.SearchInstancesRequest{
+					Cidr: "10.0.0.0",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				resp, err = governService.SearchInstances(getContext(), &pb.SearchInstancesRequest{
+					Limit: 1001,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				search := func(in *pb.SearchInstancesRequest) *pb.SearchInstancesResponse {
+					in.Properties = map[string]string{"govern_search": "true"}
+					resp, err := governService.SearchInstances(getContext(), in)
+					Expect(err).To(BeNil())
+					Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+					return resp
+				}
+
+				By("properties")
+				Expect(search(&pb.SearchInstancesRequest{}).Total).To(Equal(int64(3)))
+
+				By("status")
+				Expect(search(&pb.SearchInstancesRequest{Status: pb.MSI_UP}).Total).To(Equal(int64(2)))
+
+				By("datacenter")
+				Expect(search(&pb.SearchInstancesRequest{Datacenter: "dc1"}).Total).To(Equal(int64(2)))
+				resp := search(&pb.SearchInstancesRequest{AvailableZone: "az2"})
+				Expect(resp.Total).To(Equal(int64(1)))
+				Expect(resp.Instances[0].Endpoints[0]).To(Equal("highway:10.2.0.1:8080"))
+
+				By("cidr")
+				Expect(search(&pb.SearchInstancesRequest{Cidr: "10.0.0.0/8"}).Total).To(Equal(int64(2)))
+				Expect(search(&pb.SearchInstancesRequest{Cidr: "192.168.0.0/24"}).Total).To(Equal(int64(1)))
+
+				By("pagination")
+				resp = search(&pb.SearchInstancesRequest{Offset: 1, Limit: 1})
+				Expect(resp.Total).To(Equal(int64(3)))
+				Expect(len(resp.Instances)).To(Equal(1))
+				resp = search(&pb.SearchInstancesRequest{Offset: 5})
+				Expect(len(resp.Instances)).To(Equal(0))
+			})
+		})
+
+		It("clean", func() {
+			resp, err := serviceResource.Delete(getContext(), &pb.DeleteServiceRequest{
+				ServiceId: serviceId,
+				Force:     true,
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+		})
+	})
+
+	Describe("execute 'snapshot' operation", func() {
+		Context("when read as of a snapshot", func() {
+			It("should see the data before the snapshot", func() {
+				respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						AppId:       "govern_snapshot",
+						ServiceName: "govern_snapshot_service",
+						Version:     "1.0.0",
+						Level:       "FRONT",
+						Status:      pb.MS_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+				serviceId := respCreate.ServiceId
+
+				By("invalid ttl")
+				resp, err := governService.CreateSnapshot(getContext(), &pb.CreateSnapshotRequest{Ttl: -1})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("create snapshot")
+				resp, err = governService.CreateSnapshot(getContext(), &pb.CreateSnapshotRequest{})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.Snapshot.Revision).To(BeNumerically(">", 0))
+				snapshot := resp.Snapshot
+
+				respGetSnapshot, err := governService.GetSnapshot(getContext(), &pb.GetSnapshotRequest{
+					SnapshotId: snapshot.SnapshotId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGetSnapshot.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGetSnapshot.Snapshot.Revision).To(Equal(snapshot.Revision))
+
+				By("change after snapshot")
+				respDelete, err := serviceResource.Delete(getContext(), &pb.DeleteServiceRequest{
+					ServiceId: serviceId,
+					Force:     true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respDelete.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err := serviceResource.GetOne(getContext(), &pb.GetServiceRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+
+				By("read as of snapshot")
+				ctx := util.SetContext(getContext(), serviceUtil.CTX_REVISION, snapshot.Revision)
+				respGet, err = serviceResource.GetOne(ctx, &pb.GetServiceRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGet.Service.ServiceName).To(Equal("govern_snapshot_service"))
+
+				By("release snapshot")
+				respDeleteSnapshot, err := governService.DeleteSnapshot(getContext(), &pb.DeleteSnapshotRequest{
+					SnapshotId: snapshot.SnapshotId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respDeleteSnapshot.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGetSnapshot, err = governService.GetSnapshot(getContext(), &pb.GetSnapshotRequest{
+					SnapshotId: snapshot.SnapshotId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGetSnapshot.Response.Code).To(Equal(scerr.ErrSnapshotNotExists))
+
+				respDeleteSnapshot, err = governService.DeleteSnapshot(getContext(), &pb.DeleteSnapshotRequest{
+					SnapshotId: snapshot.SnapshotId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respDeleteSnapshot.Response.Code).To(Equal(scerr.ErrSnapshotNotExists))
+			})
+		})
+	})
+
+	Describe("execute 'api key' operation", func() {
+		Context("when manage api keys", func() {
+			It("should be passed", func() {
+				By("invalid request")
+				resp, err := governService.CreateApiKey(getContext(), &pb.CreateApiKeyRequest{Ttl: -1})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				resp, err = governService.CreateApiKey(getContext(), &pb.CreateApiKeyRequest{Name: "invalid name"})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("create api key")
+				resp, err = governService.CreateApiKey(getContext(), &pb.CreateApiKeyRequest{
+					Name: "govern_api_key",
+					Ttl:  3600,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.ApiKey.Domain).To(Equal("default"))
+				Expect(resp.ApiKey.Project).To(Equal("default"))
+				Expect(resp.ApiKey.ExpireTimestamp).NotTo(BeEmpty())
+				keyId, token := resp.ApiKey.KeyId, resp.Token
+
+				apiKey, err := serviceUtil.VerifyApiKey(getContext(), token)
+				Expect(err).To(BeNil())
+				Expect(apiKey.KeyId).To(Equal(keyId))
+
+				_, err = serviceUtil.VerifyApiKey(getContext(), token+"0")
+				Expect(err).To(Equal(serviceUtil.ErrInvalidApiKey))
+
+				respGet, err := governService.GetApiKeys(getContext(), &pb.GetApiKeysRequest{})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				found := false
+				for _, k := range respGet.ApiKeys {
+					if k.KeyId == keyId {
+						found = true
+					}
+				}
+				Expect(found).To(BeTrue())
+
+				By("rotate with grace period")
+				respRotate, err := governService.RotateApiKey(getContext(), &pb.RotateApiKeyRequest{
+					KeyId:       keyId,
+					GracePeriod: 60,
+				})
+				Expect(err).To(BeNil())
+				Expect(respRotate.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respRotate.Token).NotTo(Equal(token))
+				Expect(respRotate.ApiKey.RotateTimestamp).NotTo(BeEmpty())
+
+				_, err = serviceUtil.VerifyApiKey(getContext(), token)
+				Expect(err).To(BeNil())
+				_, err = serviceUtil.VerifyApiKey(getContext(), respRotate.Token)
+				Expect(err).To(BeNil())
+
+				By("rotate without grace period")
+				respRotate, err = governService.RotateApiKey(getContext(), &pb.RotateApiKeyRequest{
+					KeyId: keyId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respRotate.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				_, err = serviceUtil.VerifyApiKey(getContext(), token)
+				Expect(err).To(Equal(serviceUtil.ErrInvalidApiKey))
+				_, err = serviceUtil.VerifyApiKey(getContext(), respRotate.Token)
+				Expect(err).To(BeNil())
+
+				By("revoke api key")
+				respDelete, err := governService.DeleteApiKey(getContext(), &pb.DeleteApiKeyRequest{
+					KeyId: keyId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respDelete.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				_, err = serviceUtil.VerifyApiKey(getContext(), respRotate.Token)
+				Expect(err).To(Equal(serviceUtil.ErrInvalidApiKey))
+
+				respDelete, err = governService.DeleteApiKey(getContext(), &pb.DeleteApiKeyRequest{
+					KeyId: keyId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respDelete.Response.Code).To(Equal(scerr.ErrApiKeyNotExists))
+
+				respRotate, err = governService.RotateApiKey(getContext(), &pb.RotateApiKeyRequest{
+					KeyId: keyId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respRotate.Response.Code).To(Equal(scerr.ErrApiKeyNotExists))
+			})
+		})
+
+		Context("when the caller is authenticated by an api key", func() {
+			It("should be forbidden", func() {
+				resp, err := governService.CreateApiKey(getContext(), &pb.CreateApiKeyRequest{Name: "owner_key"})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				keyId := resp.ApiKey.KeyId
+				keyCtx := util.SetContext(getContext(), serviceUtil.CTX_API_KEY, keyId)
+
+				By("create api key")
+				resp, err = governService.CreateApiKey(keyCtx, &pb.CreateApiKeyRequest{Name: "escalated_key"})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrForbidden))
+
+				By("get api keys")
+				respGet, err := governService.GetApiKeys(keyCtx, &pb.GetApiKeysRequest{})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(scerr.ErrForbidden))
+
+				By("rotate api key")
+				respRotate, err := governService.RotateApiKey(keyCtx, &pb.RotateApiKeyRequest{KeyId: keyId})
+				Expect(err).To(BeNil())
+				Expect(respRotate.Response.Code).To(Equal(scerr.ErrForbidden))
+
+				By("delete api key")
+				respDelete, err := governService.DeleteApiKey(keyCtx, &pb.DeleteApiKeyRequest{KeyId: keyId})
+				Expect(err).To(BeNil())
+				Expect(respDelete.Response.Code).To(Equal(scerr.ErrForbidden))
+
+				respDelete, err = governService.DeleteApiKey(getContext(), &pb.DeleteApiKeyRequest{KeyId: keyId})
+				Expect(err).To(BeNil())
+				Expect(respDelete.Response.Code).To(Equal(pb.Response_SUCCESS))
+			})
+		})
+	})
+
+	Describe("execute 'export' operation", func() {
+		Context("when export the catalog and lineage", func() {
+			It("should be passed", func() {
+				ids := make(map[string]string)
+				for _, service := range []*pb.MicroService{
+					{ServiceName: "export_consumer", Version: "1.0.0"},
+					{ServiceName: "export_consumer", Version: "1.1.0", Description: "export consumer",
+						Properties: map[string]string{"owner": "team-a"}},
+					{ServiceName: "export_provider", Version: "1.0.0"},
+				} {
+					service.AppId = "export_app"
+					service.Level = "BACK"
+					service.Status = pb.MS_UP
+					resp, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{Service: service})
+					Expect(err).To(BeNil())
+					Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+					ids[service.ServiceName+"/"+service.Version] = resp.ServiceId
+				}
+				respDep, err := serviceResource.CreateDependenciesForMicroServices(getContext(), &pb.CreateDependenciesRequest{
+					Dependencies: []*pb.ConsumerDependency{
+						{
+							Consumer: &pb.DependencyKey{AppId: "export_app", ServiceName: "export_consumer", Version: "1.1.0"},
+							Providers: []*pb.DependencyKey{
+								{AppId: "export_app", ServiceName: "export_provider", Version: "1.0.0"},
+							},
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respDep.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				By("backstage")
+				entities, err := govern.BackstageEntities(getContext(), "default/default", "export_app", "")
+				Expect(err).To(BeNil())
+				Expect(len(entities)).To(Equal(3))
+				Expect(entities[0].Kind).To(Equal("System"))
+				Expect(entities[0].Metadata.Name).To(Equal("export_app"))
+
+				consumer := entities[1]
+				Expect(consumer.Kind).To(Equal("Component"))
+				Expect(consumer.Metadata.Name).To(Equal("export_app.export_consumer"))
+				Expect(consumer.Metadata.Description).To(Equal("export consumer"))
+				Expect(consumer.Metadata.Annotations["servicecomb.apache.org/service-id"]).To(Equal(ids["export_consumer/1.1.0"]))
+				Expect(consumer.Metadata.Annotations["servicecomb.apache.org/versions"]).To(Equal("1.0.0,1.1.0"))
+				spec := consumer.Spec.(*govern.BackstageComponentSpec)
+				Expect(spec.Owner).To(Equal("team-a"))
+				Expect(spec.Lifecycle).To(Equal("production"))
+				Expect(spec.System).To(Equal("export_app"))
+				Expect(spec.DependsOn).To(Equal([]string{"component:export_app.export_provider"}))
+
+				spec = entities[2].Spec.(*govern.BackstageComponentSpec)
+				Expect(spec.Owner).To(Equal(govern.DEFAULT_EXPORT_OWNER))
+				Expect(spec.Lifecycle).To(Equal("production"))
+				Expect(len(spec.DependsOn)).To(Equal(0))
+
+				data, err := govern.MarshalBackstage(entities)
+				Expect(err).To(BeNil())
+				Expect(strings.Count(string(data), "---\n")).To(Equal(2))
+				Expect(string(data)).To(ContainSubstring("apiVersion: backstage.io/v1alpha1"))
+
+				By("openlineage")
+				events, err := govern.LineageEvents(getContext(), "default/default", "export_app")
+				Expect(err).To(BeNil())
+				Expect(len(events)).To(Equal(2))
+				Expect(events[0].Job.Namespace).To(Equal("servicecomb://default/default"))
+				Expect(events[0].Job.Name).To(Equal("export_app.export_consumer"))
+				Expect(events[0].Job.Facets).NotTo(BeNil())
+				Expect(len(events[0].Inputs)).To(Equal(1))
+				Expect(events[0].Inputs[0].Name).To(Equal("export_app.export_provider"))
+				Expect(events[0].Outputs[0].Name).To(Equal("export_app.export_consumer"))
+				Expect(events[1].Job.Facets).To(BeNil())
+				Expect(len(events[1].Inputs)).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("execute 'simulate discovery' operation", func() {
+		Context("when simulate the discovery", func() {
+			It("should explain the excluded instances", func() {
+				ids := make(map[string]string)
+				for _, service := range []*pb.MicroService{
+					{ServiceName: "simulate_consumer", Version: "1.0.0"},
+					{ServiceName: "simulate_provider", Version: "1.0.0"},
+					{ServiceName: "simulate_provider", Version: "2.0.0"},
+				} {
+					service.AppId = "simulate_app"
+					service.Level = "BACK"
+					service.Status = pb.MS_UP
+					resp, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{Service: service})
+					Expect(err).To(BeNil())
+					Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+					ids[service.ServiceName+"/"+service.Version] = resp.ServiceId
+				}
+				instanceIds := make(map[string]string)
+				for name, instance := range map[string]*pb.MicroServiceInstance{
+					"old":     {ServiceId: ids["simulate_provider/1.0.0"], Endpoints: []string{"rest://127.0.0.1:8080"}},
+					"up":      {ServiceId: ids["simulate_provider/2.0.0"], Endpoints: []string{"rest://127.0.0.2:8080"}},
+					"standby": {ServiceId: ids["simulate_provider/2.0.0"], Endpoints: []string{"rest://127.0.0.3:8080"}, Status: pb.MSI_STANDBY},
+					"highway": {ServiceId: ids["simulate_provider/2.0.0"], Endpoints: []string{"highway://127.0.0.4:7070"}},
+				} {
+					instance.HostName = "simulate-" + name
+					if len(instance.Status) == 0 {
+						instance.Status = pb.MSI_UP
+					}
+					resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{Instance: instance})
+					Expect(err).To(BeNil())
+					Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+					instanceIds[resp.InstanceId] = name
+				}
+
+				By("consumer does not exist")
+				_, e := govern.SimulateDiscovery(getContext(), &govern.SimulateDiscoveryRequest{
+					ConsumerServiceId: "notexist",
+					Provider:          &pb.MicroServiceKey{AppId: "simulate_app", ServiceName: "simulate_provider", Version: "2.0.0+"},
+				})
+				Expect(e).NotTo(BeNil())
+				Expect(e.Code).To(Equal(scerr.ErrServiceNotExists))
+
+				By("explain")
+				result, e := govern.SimulateDiscovery(getContext(), &govern.SimulateDiscoveryRequest{
+					Consumer:  &pb.MicroServiceKey{AppId: "simulate_app", ServiceName: "simulate_consumer", Version: "1.0.0"},
+					Provider:  &pb.MicroServiceKey{AppId: "simulate_app", ServiceName: "simulate_provider", Version: "2.0.0+"},
+					Protocols: []string{"rest"},
+				})
+				Expect(e).To(BeNil())
+				Expect(result.ConsumerServiceId).To(Equal(ids["simulate_consumer/1.0.0"]))
+				Expect(len(result.Providers)).To(Equal(2))
+				for _, provider := range result.Providers {
+					if provider.ServiceId == ids["simulate_provider/1.0.0"] {
+						Expect(provider.Stage).To(Equal(govern.SIMULATE_STAGE_VERSION_RULE))
+						continue
+					}
+					Expect(provider.Stage).To(Equal(""))
+				}
+				Expect(len(result.Instances)).To(Equal(1))
+				Expect(instanceIds[result.Instances[0].InstanceId]).To(Equal("up"))
+				stages := make(map[string]string)
+				for _, excluded := range result.Excluded {
+					Expect(len(excluded.Reason)).NotTo(Equal(0))
+					stages[instanceIds[excluded.InstanceId]] = excluded.Stage
+				}
+				Expect(stages).To(Equal(map[string]string{
+					"old":     govern.SIMULATE_STAGE_VERSION_RULE,
+					"standby": govern.SIMULATE_STAGE_STATUS,
+					"highway": govern.SIMULATE_STAGE_PROTOCOL,
+				}))
+			})
+		})
+	})
 })
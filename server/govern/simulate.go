@@ -0,0 +1,305 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package govern
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"net/http"
+)
+
+// 实例被过滤的阶段，与发现流程的执行顺序一致
+const (
+	SIMULATE_STAGE_VERSION_RULE   = "versionRule"
+	SIMULATE_STAGE_TAG            = "tag"
+	SIMULATE_STAGE_RULE           = "rule"
+	SIMULATE_STAGE_STATUS         = "status"
+	SIMULATE_STAGE_ADDRESS_FAMILY = "addressFamily"
+	SIMULATE_STAGE_PROTOCOL       = "protocol"
+	SIMULATE_STAGE_PLATFORM       = "platform"
+	SIMULATE_STAGE_POLICY         = "discoveryPolicy"
+)
+
+// SimulateDiscoveryRequest 消费者可以用serviceId或服务key指定，
+// 提供者key的version为版本规则，其余参数与发现实例接口相同
+type SimulateDiscoveryRequest struct {
+	ConsumerServiceId string              `json:"consumerServiceId,omitempty"`
+	Consumer          *pb.MicroServiceKey `json:"consumer,omitempty"`
+	Provider          *pb.MicroServiceKey `json:"provider"`
+	Tags              []string            `json:"tags,omitempty"`
+	AddressFamily     string              `json:"addressFamily,omitempty"`
+	Protocols         []string            `json:"protocols,omitempty"`
+	Platform          *pb.Platform        `json:"platform,omitempty"`
+	PlatformPolicy    string              `json:"platformPolicy,omitempty"`
+	CrossEnvironment  bool                `json:"crossEnvironment,omitempty"`
+}
+
+// SimulatedProvider 版本规则解析出的候选提供者，Stage和Reason为空表示通过了服务级的检查
+type SimulatedProvider struct {
+	ServiceId string              `json:"serviceId"`
+	Service   *pb.MicroServiceKey `json:"service"`
+	Stage     string              `json:"stage,omitempty"`
+	Reason    string              `json:"reason,omitempty"`
+}
+
+// ExcludedInstance 被过滤的实例及过滤它的阶段，每个实例只记录第一个过滤它的阶段
+type ExcludedInstance struct {
+	ServiceId  string   `json:"serviceId"`
+	InstanceId string   `json:"instanceId"`
+	Status     string   `json:"status,omitempty"`
+	Endpoints  []string `json:"endpoints,omitempty"`
+	Stage      string   `json:"stage"`
+	Reason     string   `json:"reason"`
+}
+
+type SimulateDiscoveryResult struct {
+	ConsumerServiceId     string                     `json:"consumerServiceId"`
+	ProviderDomainProject string                     `json:"providerDomainProject"`
+	Providers             []*SimulatedProvider       `json:"providers"`
+	Instances             []*pb.MicroServiceInstance `json:"instances"`
+	Excluded              []*ExcludedInstance        `json:"excluded"`
+}
+
+func (r *SimulateDiscoveryResult) exclude(instance *pb.MicroServiceInstance, stage, reason string) {
+	r.Excluded = append(r.Excluded, &ExcludedInstance{
+		ServiceId:  instance.ServiceId,
+		InstanceId: instance.InstanceId,
+		Status:     instance.Status,
+		Endpoints:  instance.Endpoints,
+		Stage:      stage,
+		Reason:     reason,
+	})
+}
+
+// filter 对比过滤前后的实例，记录被过滤掉的实例；过滤函数可能返回裁剪后的副本，按实例id对比
+func (r *SimulateDiscoveryResult) filter(before, after []*pb.MicroServiceInstance, stage string,
+	reason func(instance *pb.MicroServiceInstance) string) []*pb.MicroServiceInstance {
+	kept := make(map[string]struct{}, len(after))
+	for _, instance := range after {
+		kept[instance.ServiceId+"/"+instance.InstanceId] = struct{}{}
+	}
+	for _, instance := range before {
+		if _, ok := kept[instance.ServiceId+"/"+instance.InstanceId]; !ok {
+			r.exclude(instance, stage, reason(instance))
+		}
+	}
+	return after
+}
+
+func simulateConsumer(ctx context.Context, domainProject string, in *SimulateDiscoveryRequest) (*pb.MicroService, *scerr.Error) {
+	consumerId := in.ConsumerServiceId
+	if len(consumerId) == 0 && in.Consumer != nil {
+		key := *in.Consumer
+		key.Tenant = domainProject
+		id, err := serviceUtil.GetServiceId(ctx, &key)
+		if err != nil {
+			return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+		}
+		consumerId = id
+	}
+	if len(consumerId) == 0 {
+		return nil, scerr.NewError(scerr.ErrServiceNotExists, "Consumer does not exist.")
+	}
+	service, err := serviceUtil.GetService(ctx, domainProject, consumerId)
+	if err != nil {
+		return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+	}
+	if service == nil {
+		return nil, scerr.NewError(scerr.ErrServiceNotExists, "Consumer does not exist.")
+	}
+	return service, nil
+}
+
+// simulateProviders 返回版本规则匹配的提供者和同名的所有版本，规则在本租户没有匹配时与发现一样尝试共享服务
+func simulateProviders(ctx context.Context, domainProject string, consumer *pb.MicroService,
+	in *SimulateDiscoveryRequest) (string, []string, []string, error) {
+	scope, err := serviceUtil.FindDiscoveryScope(ctx, domainProject)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	provider := in.Provider
+	find := func(domainProject, versionRule string) ([]string, error) {
+		return serviceUtil.FindProviderIds(ctx, versionRule, &pb.MicroServiceKey{
+			Tenant:      domainProject,
+			Environment: consumer.Environment,
+			AppId:       provider.AppId,
+			ServiceName: provider.ServiceName,
+			Alias:       provider.ServiceName,
+		}, scope, in.CrossEnvironment)
+	}
+	providerDomainProject := domainProject
+	matched, err := find(domainProject, provider.Version)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if len(matched) == 0 {
+		sharedService, err := serviceUtil.FindSharedService(ctx, provider.AppId, provider.ServiceName)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if sharedService != nil && sharedService.DomainProject() != domainProject {
+			providerDomainProject = sharedService.DomainProject()
+			if matched, err = find(providerDomainProject, provider.Version); err != nil {
+				return "", nil, nil, err
+			}
+		}
+	}
+	all, err := find(providerDomainProject, "0+")
+	if err != nil {
+		return "", nil, nil, err
+	}
+	// 别名匹配的提供者不在按服务名查询的结果中
+	exist := make(map[string]struct{}, len(all))
+	for _, id := range all {
+		exist[id] = struct{}{}
+	}
+	for _, id := range matched {
+		if _, ok := exist[id]; !ok {
+			all = append(all, id)
+		}
+	}
+	return providerDomainProject, matched, all, nil
+}
+
+// checkSimulatedProvider 与发现时对提供者的检查一致，返回过滤的阶段和原因
+func checkSimulatedProvider(ctx context.Context, domainProject string, consumer *pb.MicroService,
+	providerDomainProject string, provider *pb.MicroService, tags []string) (string, string, error) {
+	if len(tags) > 0 {
+		providerTags, err := serviceUtil.GetTagsUtils(ctx, providerDomainProject, provider.ServiceId)
+		if err != nil {
+			return "", "", err
+		}
+		for _, tag := range tags {
+			if _, ok := providerTags[tag]; !ok {
+				return SIMULATE_STAGE_TAG, fmt.Sprintf("provider tags do not contain '%s'", tag), nil
+			}
+		}
+	}
+	forbid := serviceUtil.AccessibleAcrossDomain(ctx, domainProject, consumer.ServiceId, providerDomainProject, provider.ServiceId)
+	if forbid != nil {
+		if forbid.StatusCode() == http.StatusInternalServerError {
+			return "", "", forbid
+		}
+		return SIMULATE_STAGE_RULE, forbid.Detail, nil
+	}
+	return "", "", nil
+}
+
+// SimulateDiscovery 按发现实例的流程执行一次，返回会被发现的实例和其余实例被过滤的原因，
+// 不记录依赖关系；maxInstances截断与实例顺序有关，随机顺序下每次被截断的实例可能不同
+func SimulateDiscovery(ctx context.Context, in *SimulateDiscoveryRequest) (*SimulateDiscoveryResult, *scerr.Error) {
+	if in == nil || in.Provider == nil || len(in.Provider.ServiceName) == 0 || len(in.Provider.Version) == 0 {
+		return nil, scerr.NewError(scerr.ErrInvalidParams, "provider serviceName and version rule are required")
+	}
+	domainProject := util.ParseDomainProject(ctx)
+	consumer, e := simulateConsumer(ctx, domainProject, in)
+	if e != nil {
+		return nil, e
+	}
+	providerDomainProject, matched, all, err := simulateProviders(ctx, domainProject, consumer, in)
+	if err != nil {
+		return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+	}
+
+	isMatched := make(map[string]struct{}, len(matched))
+	for _, id := range matched {
+		isMatched[id] = struct{}{}
+	}
+	result := &SimulateDiscoveryResult{
+		ConsumerServiceId:     consumer.ServiceId,
+		ProviderDomainProject: providerDomainProject,
+		Providers:             make([]*SimulatedProvider, 0, len(all)),
+		Excluded:              make([]*ExcludedInstance, 0),
+	}
+	instances := make([]*pb.MicroServiceInstance, 0)
+	for _, providerId := range all {
+		provider, err := serviceUtil.GetService(ctx, providerDomainProject, providerId)
+		if err != nil {
+			return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+		}
+		if provider == nil {
+			continue
+		}
+		candidates, err := serviceUtil.GetAllInstancesOfOneService(ctx, providerDomainProject, providerId)
+		if err != nil {
+			return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+		}
+		p := &SimulatedProvider{ServiceId: providerId, Service: pb.MicroServiceToKey(providerDomainProject, provider)}
+		result.Providers = append(result.Providers, p)
+
+		if _, ok := isMatched[providerId]; !ok {
+			p.Stage = SIMULATE_STAGE_VERSION_RULE
+			p.Reason = fmt.Sprintf("version %s does not match rule '%s'", provider.Version, in.Provider.Version)
+		} else {
+			p.Stage, p.Reason, err = checkSimulatedProvider(ctx, domainProject, consumer, providerDomainProject, provider, in.Tags)
+			if err != nil {
+				return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+			}
+		}
+		if len(p.Stage) > 0 {
+			for _, instance := range candidates {
+				result.exclude(instance, p.Stage, p.Reason)
+			}
+			continue
+		}
+
+		discoverable := make([]*pb.MicroServiceInstance, 0, len(candidates))
+		for _, instance := range candidates {
+			if !serviceUtil.IsDiscoverable(instance) {
+				result.exclude(instance, SIMULATE_STAGE_STATUS, fmt.Sprintf("instance status %s is not discoverable", instance.Status))
+				continue
+			}
+			discoverable = append(discoverable, instance)
+		}
+		serviceUtil.AttachInstanceStates(ctx, providerDomainProject, providerId, discoverable)
+		instances = append(instances, discoverable...)
+	}
+
+	policy, err := serviceUtil.GetDiscoveryPolicy(ctx, domainProject, consumer.ServiceId)
+	if err != nil {
+		return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+	}
+	instances = result.filter(instances, serviceUtil.ApplyAddressFamily(in.AddressFamily, instances),
+		SIMULATE_STAGE_ADDRESS_FAMILY, func(*pb.MicroServiceInstance) string {
+			return fmt.Sprintf("no endpoint of address family %s", in.AddressFamily)
+		})
+	instances = result.filter(instances, serviceUtil.ApplyProtocols(in.Protocols, instances),
+		SIMULATE_STAGE_PROTOCOL, func(*pb.MicroServiceInstance) string {
+			return fmt.Sprintf("no endpoint of protocols %v", in.Protocols)
+		})
+	instances = serviceUtil.ApplyInstanceOrder(serviceUtil.InstanceOrder("", policy), "", instances)
+	instances = result.filter(instances, serviceUtil.ApplyPlatformPolicy(in.Platform, in.PlatformPolicy, instances),
+		SIMULATE_STAGE_PLATFORM, func(instance *pb.MicroServiceInstance) string {
+			return fmt.Sprintf("platform %s/%s does not match the required %s/%s",
+				instance.Platform.GetOs(), instance.Platform.GetArch(), in.Platform.GetOs(), in.Platform.GetArch())
+		})
+	instances = result.filter(instances, serviceUtil.ApplyDiscoveryPolicy(policy, instances),
+		SIMULATE_STAGE_POLICY, func(instance *pb.MicroServiceInstance) string {
+			for k, v := range policy.ExcludeProperties {
+				if pv, ok := instance.Properties[k]; ok && pv == v {
+					return fmt.Sprintf("property %s=%s is excluded", k, v)
+				}
+			}
+			return fmt.Sprintf("exceeds maxInstances %d", policy.MaxInstances)
+		})
+	result.Instances = instances
+	return result, nil
+}
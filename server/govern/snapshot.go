@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package govern
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+)
+
+// CreateSnapshot 固定当前租户的revision，有效期内的读请求可带snapshot参数按该revision读取
+func (governService *GovernService) CreateSnapshot(ctx context.Context, in *pb.CreateSnapshotRequest) (*pb.CreateSnapshotResponse, error) {
+	if in == nil || in.Ttl < 0 {
+		return &pb.CreateSnapshotResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Invalid snapshot ttl."),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	snapshot, err := serviceUtil.CreateSnapshot(ctx, domainProject, in.Ttl)
+	if err != nil {
+		util.Logger().Errorf(err, "create snapshot of %s failed.", domainProject)
+		return &pb.CreateSnapshotResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, err.Error()),
+		}, err
+	}
+
+	util.Logger().Infof("create snapshot %s of %s at revision %d successfully, operator %s.",
+		snapshot.SnapshotId, domainProject, snapshot.Revision, util.GetIPFromContext(ctx))
+	return &pb.CreateSnapshotResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Create snapshot successfully."),
+		Snapshot: snapshot,
+	}, nil
+}
+
+func (governService *GovernService) GetSnapshot(ctx context.Context, in *pb.GetSnapshotRequest) (*pb.GetSnapshotResponse, error) {
+	err := apt.Validate(in)
+	if err != nil {
+		return &pb.GetSnapshotResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	snapshot, err := serviceUtil.GetSnapshot(ctx, domainProject, in.SnapshotId)
+	if err != nil {
+		util.Logger().Errorf(err, "get snapshot %s of %s failed.", in.SnapshotId, domainProject)
+		return &pb.GetSnapshotResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if snapshot == nil {
+		return &pb.GetSnapshotResponse{
+			Response: pb.CreateResponse(scerr.ErrSnapshotNotExists, "Snapshot does not exist or has expired."),
+		}, nil
+	}
+	return &pb.GetSnapshotResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Get snapshot successfully."),
+		Snapshot: snapshot,
+	}, nil
+}
+
+func (governService *GovernService) DeleteSnapshot(ctx context.Context, in *pb.DeleteSnapshotRequest) (*pb.DeleteSnapshotResponse, error) {
+	err := apt.Validate(in)
+	if err != nil {
+		return &pb.DeleteSnapshotResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	ok, err := serviceUtil.DeleteSnapshot(ctx, domainProject, in.SnapshotId)
+	if err != nil {
+		util.Logger().Errorf(err, "delete snapshot %s of %s failed.", in.SnapshotId, domainProject)
+		return &pb.DeleteSnapshotResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, err.Error()),
+		}, err
+	}
+	if !ok {
+		return &pb.DeleteSnapshotResponse{
+			Response: pb.CreateResponse(scerr.ErrSnapshotNotExists, "Snapshot does not exist or has expired."),
+		}, nil
+	}
+
+	util.Logger().Infof("delete snapshot %s of %s successfully, operator %s.",
+		in.SnapshotId, domainProject, util.GetIPFromContext(ctx))
+	return &pb.DeleteSnapshotResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Delete snapshot successfully."),
+	}, nil
+}
@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package govern
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"sort"
+	"sync"
+)
+
+// GetStartupOrder 按依赖关系计算应用内服务的启动顺序，同一服务的多个版本视为一个节点，
+// 只考虑同应用同环境的provider；相互依赖的服务无法排序，放在同一层并在cycles中返回
+func (governService *GovernService) GetStartupOrder(ctx context.Context, in *pb.GetStartupOrderRequest) (*pb.GetStartupOrderResponse, error) {
+	if in == nil {
+		util.Logger().Errorf(nil, "get startup order failed: invalid params.")
+		return &pb.GetStartupOrderResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "get startup order failed: invalid parameters.")
+		return &pb.GetStartupOrderResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	all, err := serviceUtil.GetServicesByDomain(ctx, domainProject)
+	if err != nil {
+		util.Logger().Errorf(err, "get startup order failed: get services of app %s failed.", in.AppId)
+		return &pb.GetStartupOrderResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	env := in.Environment
+	if len(env) == 0 {
+		env = pb.ENV_DEV
+	}
+	services := make([]*pb.MicroService, 0, len(all))
+	names := make(map[string]string, len(all))
+	deps := make(map[string]map[string]struct{}, len(all))
+	for _, service := range all {
+		serviceEnv := service.Environment
+		if len(serviceEnv) == 0 {
+			serviceEnv = pb.ENV_DEV
+		}
+		if service.AppId != in.AppId || serviceEnv != env ||
+			apt.IsSCKey(pb.MicroServiceToKey(domainProject, service)) {
+			continue
+		}
+		services = append(services, service)
+		names[service.ServiceId] = service.ServiceName
+		if _, ok := deps[service.ServiceName]; !ok {
+			deps[service.ServiceName] = make(map[string]struct{})
+		}
+	}
+
+	var lock sync.Mutex
+	err = util.ParallelDo(len(services), serviceUtil.DEFAULT_DEPENDENCY_WORKERS, func(i int) error {
+		consumer := services[i]
+		dr := serviceUtil.NewConsumerDependencyRelation(ctx, domainProject, consumer.ServiceId, consumer)
+		providerIds, err := dr.GetDependencyProviderIds()
+		if err != nil {
+			return err
+		}
+		lock.Lock()
+		for _, providerId := range providerIds {
+			if name, ok := names[providerId]; ok && name != consumer.ServiceName {
+				deps[consumer.ServiceName][name] = struct{}{}
+			}
+		}
+		lock.Unlock()
+		return nil
+	})
+	if err != nil {
+		util.Logger().Errorf(err, "get startup order failed: get providers in app %s failed.", in.AppId)
+		return &pb.GetStartupOrderResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	layers, cycles := startupOrder(deps)
+	resp := &pb.GetStartupOrderResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Get startup order successfully."),
+		Order:    make([]string, 0, len(deps)),
+	}
+	for _, layer := range layers {
+		resp.Order = append(resp.Order, layer...)
+		resp.Layers = append(resp.Layers, &pb.StartupOrderGroup{ServiceNames: layer})
+	}
+	for _, cycle := range cycles {
+		resp.Cycles = append(resp.Cycles, &pb.StartupOrderGroup{ServiceNames: cycle})
+	}
+	return resp, nil
+}
+
+// startupOrder 求依赖图(consumer -> providers)的强连通分量并按依赖分层，
+// provider所在的层总是先于consumer，同层服务可并行启动，成员多于一个的分量即为环
+func startupOrder(deps map[string]map[string]struct{}) (layers [][]string, cycles [][]string) {
+	var (
+		index   = make(map[string]int, len(deps))
+		lowLink = make(map[string]int, len(deps))
+		onStack = make(map[string]bool, len(deps))
+		sccOf   = make(map[string]int, len(deps))
+		stack   []string
+		sccs    [][]string
+		connect func(v string)
+	)
+	// tarjan算法，分量按逆拓扑序输出，即被依赖的分量先输出
+	connect = func(v string) {
+		index[v] = len(index)
+		lowLink[v] = index[v]
+		stack = append(stack, v)
+		onStack[v] = true
+		for w := range deps[v] {
+			if _, ok := index[w]; !ok {
+				connect(w)
+				if lowLink[w] < lowLink[v] {
+					lowLink[v] = lowLink[w]
+				}
+			} else if onStack[w] && index[w] < lowLink[v] {
+				lowLink[v] = index[w]
+			}
+		}
+		if lowLink[v] != index[v] {
+			return
+		}
+		scc := []string{}
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			sccOf[w] = len(sccs)
+			scc = append(scc, w)
+			if w == v {
+				break
+			}
+		}
+		sort.Strings(scc)
+		sccs = append(sccs, scc)
+	}
+	for v := range deps {
+		if _, ok := index[v]; !ok {
+			connect(v)
+		}
+	}
+
+	levels := make([]int, len(sccs))
+	for i, scc := range sccs {
+		for _, v := range scc {
+			for w := range deps[v] {
+				if j := sccOf[w]; j != i && levels[j]+1 > levels[i] {
+					levels[i] = levels[j] + 1
+				}
+			}
+		}
+		for len(layers) <= levels[i] {
+			layers = append(layers, []string{})
+		}
+		layers[levels[i]] = append(layers[levels[i]], scc...)
+		if len(scc) > 1 {
+			cycles = append(cycles, scc)
+		}
+	}
+	for _, layer := range layers {
+		sort.Strings(layer)
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+	return
+}
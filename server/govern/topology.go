@@ -0,0 +1,293 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package govern
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_TOPOLOGY_LIMIT = 100
+	// 缓存的拓扑在revision未变化时的最长有效时间，保证stale状态及时刷新
+	DEFAULT_TOPOLOGY_CACHE_TTL = 30 * time.Second
+	// 超过该时间没有被发现过的依赖标记为stale
+	DEFAULT_TOPOLOGY_STALE_WINDOW = 24 * time.Hour
+)
+
+var topologyCache = &topologyCacher{items: make(map[string]*topology)}
+
+// topology 租户(及应用)的完整拓扑，分页在其上进行
+type topology struct {
+	nodes []*pb.TopologyNode
+	edges []*pb.TopologyEdge
+	// 租户下的所有服务，key为serviceId
+	services  map[string]*pb.MicroService
+	revision  int64
+	buildTime time.Time
+}
+
+// topologyCacher 按租户和应用缓存拓扑，store的revision变化后失效
+type topologyCacher struct {
+	lock  sync.RWMutex
+	items map[string]*topology
+}
+
+func (c *topologyCacher) Get(key string, rev int64) *topology {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	t, ok := c.items[key]
+	if !ok || t.revision != rev || time.Since(t.buildTime) > DEFAULT_TOPOLOGY_CACHE_TTL {
+		return nil
+	}
+	return t
+}
+
+func (c *topologyCacher) Set(key string, t *topology) {
+	c.lock.Lock()
+	for k, old := range c.items {
+		if old.revision != t.revision || time.Since(old.buildTime) > DEFAULT_TOPOLOGY_CACHE_TTL {
+			delete(c.items, k)
+		}
+	}
+	c.items[key] = t
+	c.lock.Unlock()
+}
+
+// GetTopology 返回拓扑视图需要的服务(含健康度)和依赖(含最近发现时间)，按服务分页，
+// 只返回与本页服务相关的依赖，对端服务可能在其他页
+func (governService *GovernService) GetTopology(ctx context.Context, in *pb.GetTopologyRequest) (*pb.GetTopologyResponse, error) {
+	if in == nil {
+		util.Logger().Errorf(nil, "get topology failed: invalid params.")
+		return &pb.GetTopologyResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "get topology failed: invalid parameters.")
+		return &pb.GetTopologyResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	t, err := loadTopology(ctx, domainProject, in.AppId)
+	if err != nil {
+		util.Logger().Errorf(err, "get topology of %s/%s failed.", domainProject, in.AppId)
+		return &pb.GetTopologyResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	total := int64(len(t.nodes))
+	start, end := pageRange(total, in.Offset, in.Limit)
+	nodes := t.nodes[start:end]
+	inPage := make(map[string]struct{}, len(nodes))
+	for _, node := range nodes {
+		inPage[node.ServiceId] = struct{}{}
+	}
+	edges := make([]*pb.TopologyEdge, 0)
+	for _, edge := range t.edges {
+		_, c := inPage[edge.ConsumerServiceId]
+		_, p := inPage[edge.ProviderServiceId]
+		if c || p {
+			edges = append(edges, edge)
+		}
+	}
+
+	return &pb.GetTopologyResponse{
+		Response:  pb.CreateResponse(pb.Response_SUCCESS, "Get topology successfully."),
+		Nodes:     nodes,
+		Edges:     edges,
+		Total:     total,
+		Revision:  t.revision,
+		Timestamp: strconv.FormatInt(t.buildTime.Unix(), 10),
+	}, nil
+}
+
+// loadTopology 优先使用缓存的拓扑，指定revision或不使用缓存的请求不读写拓扑缓存
+func loadTopology(ctx context.Context, domainProject, appId string) (*topology, error) {
+	cacheable := ctx.Value("noCache") != "1" && ctx.Value(serviceUtil.CTX_REVISION) == nil
+	cacheKey := util.StringJoin([]string{domainProject, appId}, "/")
+	rev := store.Revision()
+
+	if cacheable {
+		if t := topologyCache.Get(cacheKey, rev); t != nil {
+			return t, nil
+		}
+	}
+	t, err := buildTopology(ctx, domainProject, appId)
+	if err != nil {
+		return nil, err
+	}
+	t.revision = rev
+	if cacheable {
+		topologyCache.Set(cacheKey, t)
+	}
+	return t, nil
+}
+
+// pageRange limit为0时使用默认分页大小
+func pageRange(total, offset, limit int64) (int64, int64) {
+	if limit == 0 {
+		limit = DEFAULT_TOPOLOGY_LIMIT
+	}
+	start, end := offset, offset+limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// buildTopology 计算租户下appId(为空时为所有应用)的服务和以这些服务为消费者的依赖，
+// 依赖包括依赖规则中声明的和通过发现记录到的
+func buildTopology(ctx context.Context, domainProject, appId string) (*topology, error) {
+	all, err := serviceUtil.GetServicesByDomain(ctx, domainProject)
+	if err != nil {
+		return nil, err
+	}
+	exists := make(map[string]*pb.MicroService, len(all))
+	services := make([]*pb.MicroService, 0, len(all))
+	for _, service := range all {
+		exists[service.ServiceId] = service
+		if len(appId) > 0 && service.AppId != appId {
+			continue
+		}
+		services = append(services, service)
+	}
+
+	now := time.Now()
+	nodes := make([]*pb.TopologyNode, len(services))
+	edges := make([][]*pb.TopologyEdge, len(services))
+	err = util.ParallelDo(len(services), serviceUtil.DEFAULT_DEPENDENCY_WORKERS, func(i int) error {
+		service := services[i]
+		instances, err := serviceUtil.GetAllInstancesOfOneService(ctx, domainProject, service.ServiceId)
+		if err != nil {
+			return err
+		}
+		node := &pb.TopologyNode{
+			ServiceId:     service.ServiceId,
+			Service:       pb.MicroServiceToKey(domainProject, service),
+			InstanceCount: int64(len(instances)),
+			Health:        serviceUtil.InstancesHealth(instances),
+		}
+		for _, instance := range instances {
+			if instance.Status == pb.MSI_UP {
+				node.UpInstanceCount++
+			}
+		}
+		nodes[i] = node
+
+		dr := serviceUtil.NewConsumerDependencyRelation(ctx, domainProject, service.ServiceId, service)
+		providerIds, err := dr.GetDependencyProviderIds()
+		if err != nil {
+			return err
+		}
+		usages, err := serviceUtil.GetDependencyUsages(ctx, domainProject, service.ServiceId)
+		if err != nil {
+			return err
+		}
+		m := make(map[string]*pb.TopologyEdge, len(providerIds)+len(usages))
+		for _, providerId := range providerIds {
+			m[providerId] = &pb.TopologyEdge{
+				ConsumerServiceId: service.ServiceId,
+				ProviderServiceId: providerId,
+				Declared:          true,
+			}
+		}
+		for _, usage := range usages {
+			edge, ok := m[usage.ProviderServiceId]
+			if !ok {
+				edge = &pb.TopologyEdge{
+					ConsumerServiceId: service.ServiceId,
+					ProviderServiceId: usage.ProviderServiceId,
+				}
+				m[usage.ProviderServiceId] = edge
+			}
+			edge.LastAccessTimestamp = usage.Timestamp
+		}
+		// 上报的流量也说明依赖在使用中，查询失败时不影响拓扑
+		traffics, err := serviceUtil.GetConsumerTraffics(ctx, domainProject, service.ServiceId)
+		if err != nil {
+			util.Logger().Errorf(err, "get dependency traffics of %s failed", service.ServiceId)
+		}
+		for _, traffic := range traffics {
+			edge, ok := m[traffic.ProviderServiceId]
+			if !ok {
+				edge = &pb.TopologyEdge{
+					ConsumerServiceId: service.ServiceId,
+					ProviderServiceId: traffic.ProviderServiceId,
+				}
+				m[traffic.ProviderServiceId] = edge
+			}
+			edge.Traffic = traffic
+			if len(edge.LastAccessTimestamp) == 0 || edge.LastAccessTimestamp < traffic.Timestamp {
+				edge.LastAccessTimestamp = traffic.Timestamp
+			}
+		}
+		for providerId, edge := range m {
+			if _, ok := exists[providerId]; !ok || providerId == service.ServiceId {
+				continue
+			}
+			ts, _ := strconv.ParseInt(edge.LastAccessTimestamp, 10, 64)
+			edge.Stale = now.Sub(time.Unix(ts, 0)) > DEFAULT_TOPOLOGY_STALE_WINDOW
+			edges[i] = append(edges[i], edge)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i].Service, nodes[j].Service
+		if a.AppId != b.AppId {
+			return a.AppId < b.AppId
+		}
+		if a.ServiceName != b.ServiceName {
+			return a.ServiceName < b.ServiceName
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		return nodes[i].ServiceId < nodes[j].ServiceId
+	})
+	t := &topology{nodes: nodes, services: exists, buildTime: now}
+	for _, es := range edges {
+		t.edges = append(t.edges, es...)
+	}
+	sort.Slice(t.edges, func(i, j int) bool {
+		if t.edges[i].ConsumerServiceId != t.edges[j].ConsumerServiceId {
+			return t.edges[i].ConsumerServiceId < t.edges[j].ConsumerServiceId
+		}
+		return t.edges[i].ProviderServiceId < t.edges[j].ProviderServiceId
+	})
+	return t, nil
+}
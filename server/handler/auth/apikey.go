@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package auth
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	HEADER_API_KEY       = "X-Api-Key"
+	AUTHORIZATION_SCHEME = "ApiKey "
+)
+
+// apiKeyFromRequest 支持X-Api-Key头或Authorization: ApiKey {token}
+func apiKeyFromRequest(r *http.Request) string {
+	if token := r.Header.Get(HEADER_API_KEY); len(token) > 0 {
+		return token
+	}
+	authorization := r.Header.Get("Authorization")
+	if strings.HasPrefix(authorization, AUTHORIZATION_SCHEME) {
+		return strings.TrimSpace(authorization[len(AUTHORIZATION_SCHEME):])
+	}
+	return ""
+}
+
+func requestProject(r *http.Request) string {
+	if strings.Index(r.RequestURI, "/v4/") != 0 {
+		return core.REGISTRY_PROJECT
+	}
+	path, err := url.PathUnescape(r.RequestURI)
+	if err != nil {
+		return ""
+	}
+	path = path[len("/v4/"):]
+	if end := strings.Index(path, "/"); end >= 0 {
+		path = path[:end]
+	}
+	if project := strings.TrimSpace(path); len(project) > 0 {
+		return project
+	}
+	return core.REGISTRY_PROJECT
+}
+
+// identifyApiKey 校验API密钥，并以密钥的domain/project作为请求的租户
+func identifyApiKey(r *http.Request, token string) error {
+	apiKey, err := serviceUtil.VerifyApiKey(r.Context(), token)
+	if err != nil {
+		return err
+	}
+
+	domain := r.Header.Get("X-Domain-Name")
+	if len(domain) == 0 {
+		domain = r.Header.Get("X-Tenant-Name")
+	}
+	if len(domain) > 0 && domain != apiKey.Domain {
+		return fmt.Errorf("API key %s is not allowed to access domain %s.", apiKey.KeyId, domain)
+	}
+	if project := requestProject(r); project != apiKey.Project {
+		return fmt.Errorf("API key %s is not allowed to access project %s.", apiKey.KeyId, project)
+	}
+
+	util.SetRequestContext(r, "domain", apiKey.Domain)
+	util.SetRequestContext(r, "project", apiKey.Project)
+	util.SetRequestContext(r, serviceUtil.CTX_API_KEY, apiKey.KeyId)
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
 	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	rs "github.com/apache/incubator-servicecomb-service-center/server/rest"
 	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
 	"net/http"
 )
@@ -31,7 +32,16 @@ type AuthRequest struct {
 
 func (h *AuthRequest) Handle(i *chain.Invocation) {
 	r := i.Context().Value(rest.CTX_REQUEST).(*http.Request)
-	err := plugin.Plugins().Auth().Identify(r)
+	if !rs.AuthEnabled(r) {
+		i.Next()
+		return
+	}
+	var err error
+	if token := apiKeyFromRequest(r); len(token) > 0 {
+		err = identifyApiKey(r, token)
+	} else {
+		err = plugin.Plugins().Auth().Identify(r)
+	}
 	if err == nil {
 		i.Next()
 		return
@@ -46,5 +56,5 @@ func (h *AuthRequest) Handle(i *chain.Invocation) {
 }
 
 func RegisterHandlers() {
-	chain.RegisterHandler(rest.SERVER_CHAIN_NAME, &AuthRequest{})
+	chain.RegisterNamedHandler(rest.SERVER_CHAIN_NAME, "auth", &AuthRequest{})
 }
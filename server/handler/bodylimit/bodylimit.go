@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package bodylimit
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/chain"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	"net/http"
+)
+
+// BodyLimitHandler 按API限制请求体大小，声明的长度超过上限时直接返回413，
+// 未声明长度的请求在读取超过上限时失败
+type BodyLimitHandler struct {
+}
+
+func (h *BodyLimitHandler) Handle(i *chain.Invocation) {
+	r := i.Context().Value(rest.CTX_REQUEST).(*http.Request)
+	w := i.Context().Value(rest.CTX_RESPONSE).(http.ResponseWriter)
+	pattern := i.Context().Value(rest.CTX_MATCH_PATTERN).(string)
+
+	limit := core.BodyLimit(r.Method, pattern)
+	if r.ContentLength > limit {
+		controller.WriteError(w, scerr.ErrRequestBodyTooLarge,
+			fmt.Sprintf("Content-Length %d exceeds the limit %d bytes of %s %s.", r.ContentLength, limit, r.Method, pattern))
+		i.Fail(nil)
+		return
+	}
+	r.Body = core.LimitBody(r.Body, limit)
+	i.Next()
+}
+
+func RegisterHandlers() {
+	chain.RegisterNamedHandler(rest.SERVER_CHAIN_NAME, "bodylimit", &BodyLimitHandler{})
+}
@@ -0,0 +1,100 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package bodylimit
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/chain"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// readHandler 模拟controller读取请求体
+type readHandler struct {
+}
+
+func (h *readHandler) Handle(i *chain.Invocation) {
+	r := i.Context().Value(rest.CTX_REQUEST).(*http.Request)
+	w := i.Context().Value(rest.CTX_RESPONSE).(http.ResponseWriter)
+	if _, err := ioutil.ReadAll(r.Body); err != nil {
+		controller.WriteBodyError(w, err)
+		i.Fail(err)
+		return
+	}
+	controller.WriteJsonObject(w, nil)
+	i.Next()
+}
+
+func serve(r *http.Request, pattern string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	done := make(chan struct{})
+	inv := chain.NewInvocation(context.Background(),
+		chain.NewChain("_test_bodylimit_", []chain.Handler{&BodyLimitHandler{}, &readHandler{}}))
+	inv.WithContext(rest.CTX_REQUEST, r).
+		WithContext(rest.CTX_RESPONSE, w).
+		WithContext(rest.CTX_MATCH_PATTERN, pattern)
+	inv.Invoke(func(chain.Result) { close(done) })
+	<-done
+	return w
+}
+
+func TestBodyLimitHandler(t *testing.T) {
+	max, apis := core.ServerInfo.Config.MaxBodyBytes, core.ServerInfo.Config.MaxBodyBytesApis
+	defer func() {
+		core.ServerInfo.Config.MaxBodyBytes, core.ServerInfo.Config.MaxBodyBytesApis = max, apis
+	}()
+	core.ServerInfo.Config.MaxBodyBytes = 4
+	core.ServerInfo.Config.MaxBodyBytesApis = core.ParseBodyLimits("PUT /large=8")
+
+	cases := []struct {
+		pattern string
+		body    string
+		chunked bool
+		status  int
+	}{
+		{"/small", "1234", false, http.StatusOK},
+		{"/small", "12345", false, http.StatusRequestEntityTooLarge},
+		{"/small", "12345", true, http.StatusRequestEntityTooLarge},
+		{"/large", "12345678", false, http.StatusOK},
+		{"/large", "123456789", false, http.StatusRequestEntityTooLarge},
+		{"/large", "123456789", true, http.StatusRequestEntityTooLarge},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest(http.MethodPut, c.pattern, strings.NewReader(c.body))
+		if c.chunked {
+			// 未声明长度的请求在读取时才能发现超过上限
+			r.ContentLength = -1
+		}
+		w := serve(r, c.pattern)
+		if w.Code != c.status {
+			t.Fatalf("TestBodyLimitHandler %s %d bytes chunked %v, status %d, expect %d",
+				c.pattern, len(c.body), c.chunked, w.Code, c.status)
+		}
+		if c.status == http.StatusRequestEntityTooLarge &&
+			!strings.Contains(w.Body.String(), fmt.Sprintf(`"errorCode":"%d"`, scerr.ErrRequestBodyTooLarge)) {
+			t.Fatalf("TestBodyLimitHandler %s, unexpected body %s", c.pattern, w.Body.String())
+		}
+	}
+}
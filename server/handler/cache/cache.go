@@ -20,12 +20,49 @@ import (
 	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/chain"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"github.com/astaxie/beego"
+	"github.com/prometheus/client_golang/prometheus"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
+const (
+	// 处理请求时注册中心的revision，与X-Resource-Revision不同，不受rev、snapshot参数影响
+	HEADER_REGISTRY_REVISION = "X-Registry-Revision"
+	// 读接口响应所用数据的最大mod revision，数据变化时变大，没有读到数据时不返回
+	HEADER_MOD_REVISION = "X-Mod-Revision"
+)
+
+// 默认开启HTTP缓存的热点读接口
+var DEFAULT_CACHEABLE_ROUTES = []string{
+	"/v4/:project/registry/microservices/:serviceId",
+	"/v4/:project/registry/microservices/:serviceId/schemas",
+	"/v4/:project/registry/microservices/:serviceId/schemas/:schemaId",
+	"/v4/:project/registry/instances",
+}
+
+var httpCacheRequests = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "service_center",
+		Subsystem: "http",
+		Name:      "cache_requests_total",
+		Help:      "Counter of the conditional requests of the cacheable read apis",
+	}, []string{"route", "result"})
+
+func init() {
+	prometheus.MustRegister(httpCacheRequests)
+}
+
 type CacheResponse struct {
+	// 可缓存接口的路由，为空时不设置Cache-Control和ETag
+	Routes       map[string]struct{}
+	CacheControl string
 }
 
 func (l *CacheResponse) Handle(i *chain.Invocation) {
@@ -33,11 +70,29 @@ func (l *CacheResponse) Handle(i *chain.Invocation) {
 	w := i.Context().Value(rest.CTX_RESPONSE).(http.ResponseWriter)
 
 	scRev := store.Revision()
+	if r.Method == http.MethodGet {
+		recorder := serviceUtil.NewModRevisionRecorder(func(rev int64) {
+			w.Header().Set(HEADER_MOD_REVISION, fmt.Sprint(rev))
+		})
+		defer recorder.Close()
+		w.Header().Set(HEADER_REGISTRY_REVISION, fmt.Sprint(scRev))
+		i.WithContext(serviceUtil.CTX_MOD_REVISION, recorder)
+	}
+
+	if snapshotId := r.URL.Query().Get("snapshot"); len(snapshotId) > 0 {
+		l.pinRevision(i, w, r, snapshotId)
+		return
+	}
+
 	w.Header().Set("X-Resource-Revision", fmt.Sprint(scRev))
 
 	noCache := r.URL.Query().Get("noCache") == "1"
 	rev, _ := strconv.ParseInt(r.URL.Query().Get("rev"), 10, 64)
 
+	if l.validate(i, w, r, scRev, noCache || rev > scRev) {
+		return
+	}
+
 	if rev == scRev && r.Method == http.MethodGet {
 		w.WriteHeader(http.StatusNotModified)
 		i.Fail(nil)
@@ -60,6 +115,100 @@ func (l *CacheResponse) Handle(i *chain.Invocation) {
 	i.Next()
 }
 
+// validate 可缓存的读接口以后端revision作为ETag，revision未变化时直接返回304，不再进入业务处理；
+// 业务处理返回错误时，错误响应会去掉ETag和Cache-Control，只有2xx响应可被缓存
+func (l *CacheResponse) validate(i *chain.Invocation, w http.ResponseWriter, r *http.Request, scRev int64, noCache bool) bool {
+	if len(l.Routes) == 0 || r.Method != http.MethodGet {
+		return false
+	}
+	route, _ := i.Context().Value(rest.CTX_MATCH_PATTERN).(string)
+	if _, ok := l.Routes[route]; !ok {
+		return false
+	}
+
+	etag := fmt.Sprintf(`W/"%d"`, scRev)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", l.CacheControl)
+	w.Header().Set("Vary", "X-Domain-Name, Authorization, X-Api-Key")
+
+	if noCache || !matchETag(r.Header.Get("If-None-Match"), etag) {
+		httpCacheRequests.WithLabelValues(route, "miss").Inc()
+		return false
+	}
+	httpCacheRequests.WithLabelValues(route, "hit").Inc()
+	w.WriteHeader(http.StatusNotModified)
+	i.Fail(nil)
+	return true
+}
+
+// matchETag If-None-Match使用弱比较
+func matchETag(ifNoneMatch, etag string) bool {
+	if len(ifNoneMatch) == 0 {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// pinRevision 读请求带snapshot参数时，按快照的revision读取
+func (l *CacheResponse) pinRevision(i *chain.Invocation, w http.ResponseWriter, r *http.Request, snapshotId string) {
+	if r.Method != http.MethodGet {
+		controller.WriteError(w, scerr.ErrInvalidParams, "Parameter snapshot is only allowed in GET requests.")
+		i.Fail(nil)
+		return
+	}
+
+	domainProject := util.ParseDomainProject(r.Context())
+	snapshot, err := serviceUtil.GetSnapshot(r.Context(), domainProject, snapshotId)
+	if err != nil {
+		util.Logger().Errorf(err, "get snapshot %s of %s failed", snapshotId, domainProject)
+		controller.WriteError(w, scerr.ErrUnavailableBackend, err.Error())
+		i.Fail(nil)
+		return
+	}
+	if snapshot == nil {
+		controller.WriteError(w, scerr.ErrSnapshotNotExists, "Snapshot does not exist or has expired.")
+		i.Fail(nil)
+		return
+	}
+
+	w.Header().Set("X-Resource-Revision", fmt.Sprint(snapshot.Revision))
+	i.WithContext(serviceUtil.CTX_REVISION, snapshot.Revision)
+	i.Next()
+}
+
+// NewCacheResponse routes为逗号分隔的路由，为空时使用默认的热点读接口，maxAge为负数时按0处理
+func NewCacheResponse(routes string, maxAge int) *CacheResponse {
+	patterns := DEFAULT_CACHEABLE_ROUTES
+	if len(routes) > 0 {
+		patterns = strings.Split(routes, ",")
+	}
+	h := &CacheResponse{Routes: make(map[string]struct{}, len(patterns))}
+	for _, route := range patterns {
+		if route = strings.TrimSpace(route); len(route) > 0 {
+			h.Routes[route] = struct{}{}
+		}
+	}
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	// 响应按租户和鉴权信息区分，只允许客户端缓存，不允许共享缓存
+	h.CacheControl = fmt.Sprintf("private, max-age=%d, must-revalidate", maxAge)
+	return h
+}
+
 func RegisterHandlers() {
-	chain.RegisterHandler(rest.SERVER_CHAIN_NAME, &CacheResponse{})
+	h := &CacheResponse{}
+	if beego.AppConfig.DefaultBool("http_cache", false) {
+		h = NewCacheResponse(beego.AppConfig.String("http_cache_routes"),
+			beego.AppConfig.DefaultInt("http_cache_max_age", 0))
+		util.Logger().Infof("http cache is enabled, %s, routes %v", h.CacheControl, h.Routes)
+	}
+	chain.RegisterNamedHandler(rest.SERVER_CHAIN_NAME, "cache", h)
 }
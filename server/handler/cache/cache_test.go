@@ -0,0 +1,150 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cache
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/chain"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	"golang.org/x/net/context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testRoute = "/v4/:project/registry/microservices/:serviceId"
+
+// writeHandler 模拟controller，fail时返回错误响应
+type writeHandler struct {
+	fail  bool
+	calls int
+}
+
+func (h *writeHandler) Handle(i *chain.Invocation) {
+	w := i.Context().Value(rest.CTX_RESPONSE).(http.ResponseWriter)
+	h.calls++
+	if h.fail {
+		controller.WriteError(w, scerr.ErrServiceNotExists, "not exists")
+	} else {
+		controller.WriteJsonObject(w, nil)
+	}
+	i.Next()
+}
+
+func serve(h *CacheResponse, next *writeHandler, r *http.Request, pattern string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	done := make(chan struct{})
+	inv := chain.NewInvocation(context.Background(),
+		chain.NewChain("_test_cache_", []chain.Handler{h, next}))
+	inv.WithContext(rest.CTX_REQUEST, r).
+		WithContext(rest.CTX_RESPONSE, w).
+		WithContext(rest.CTX_MATCH_PATTERN, pattern)
+	inv.Invoke(func(chain.Result) { close(done) })
+	<-done
+	return w
+}
+
+func TestMatchETag(t *testing.T) {
+	cases := []struct {
+		ifNoneMatch string
+		etag        string
+		match       bool
+	}{
+		{"", `W/"1"`, false},
+		{`W/"1"`, `W/"1"`, true},
+		{`"1"`, `W/"1"`, true},
+		{`W/"2"`, `W/"1"`, false},
+		{`W/"2", W/"1"`, `W/"1"`, true},
+		{`W/"2",W/"3"`, `W/"1"`, false},
+		{"*", `W/"1"`, true},
+		{`W/"10"`, `W/"1"`, false},
+	}
+	for _, c := range cases {
+		if matchETag(c.ifNoneMatch, c.etag) != c.match {
+			t.Fatalf("TestMatchETag '%s' with '%s', expect %v", c.ifNoneMatch, c.etag, c.match)
+		}
+	}
+}
+
+func TestNewCacheResponse(t *testing.T) {
+	h := NewCacheResponse("", 10)
+	if len(h.Routes) != len(DEFAULT_CACHEABLE_ROUTES) {
+		t.Fatalf("TestNewCacheResponse default routes %v", h.Routes)
+	}
+	if h.CacheControl != "private, max-age=10, must-revalidate" {
+		t.Fatalf("TestNewCacheResponse cache control %s", h.CacheControl)
+	}
+
+	h = NewCacheResponse(" /a , /b,,", -1)
+	if _, ok := h.Routes["/a"]; !ok || len(h.Routes) != 2 {
+		t.Fatalf("TestNewCacheResponse routes %v", h.Routes)
+	}
+	if _, ok := h.Routes["/b"]; !ok {
+		t.Fatalf("TestNewCacheResponse routes %v", h.Routes)
+	}
+	if h.CacheControl != "private, max-age=0, must-revalidate" {
+		t.Fatalf("TestNewCacheResponse cache control %s", h.CacheControl)
+	}
+}
+
+func TestCacheResponse(t *testing.T) {
+	h := NewCacheResponse(testRoute, 5)
+
+	// rev大于当前revision时按noCache处理，总是进入业务处理
+	r := httptest.NewRequest(http.MethodGet, "/v4/default/registry/microservices/1?rev=1", nil)
+	next := &writeHandler{}
+	w := serve(h, next, r, testRoute)
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || next.calls != 1 || len(etag) == 0 {
+		t.Fatalf("TestCacheResponse miss, status %d, calls %d, etag '%s'", w.Code, next.calls, etag)
+	}
+	if w.Header().Get("Cache-Control") != h.CacheControl {
+		t.Fatalf("TestCacheResponse cache control '%s'", w.Header().Get("Cache-Control"))
+	}
+
+	conditional := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/v4/default/registry/microservices/1", nil)
+		r.Header.Set("If-None-Match", ifNoneMatch)
+		return serve(h, next, r, testRoute)
+	}
+
+	next.calls = 0
+	w = conditional(etag)
+	if w.Code != http.StatusNotModified || next.calls != 0 || w.Header().Get("ETag") != etag {
+		t.Fatalf("TestCacheResponse hit, status %d, calls %d, etag '%s'", w.Code, next.calls, w.Header().Get("ETag"))
+	}
+
+	// 错误响应不带ETag和Cache-Control
+	next.fail = true
+	r = httptest.NewRequest(http.MethodGet, "/v4/default/registry/microservices/1?rev=1", nil)
+	w = serve(h, next, r, testRoute)
+	if w.Code != http.StatusBadRequest || next.calls != 1 {
+		t.Fatalf("TestCacheResponse error, status %d, calls %d", w.Code, next.calls)
+	}
+	if len(w.Header().Get("ETag")) > 0 || len(w.Header().Get("Cache-Control")) > 0 {
+		t.Fatalf("TestCacheResponse error response with cache headers %v", w.Header())
+	}
+
+	// 未配置的路由不设置缓存头
+	next.fail = false
+	r = httptest.NewRequest(http.MethodGet, "/v4/default/registry/microservices?rev=1", nil)
+	w = serve(h, next, r, "/v4/:project/registry/microservices")
+	if w.Code != http.StatusOK || len(w.Header().Get("ETag")) > 0 {
+		t.Fatalf("TestCacheResponse not cacheable route, status %d, etag '%s'", w.Code, w.Header().Get("ETag"))
+	}
+}
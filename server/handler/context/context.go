@@ -20,7 +20,10 @@ import (
 	"github.com/apache/incubator-servicecomb-service-center/pkg/chain"
 	roa "github.com/apache/incubator-servicecomb-service-center/pkg/rest"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
 	"net/http"
+	"strings"
 )
 
 type ContextHandler struct {
@@ -49,21 +52,42 @@ func (c *ContextHandler) Handle(i *chain.Invocation) {
 	}
 
 	i.WithContext("x-remote-ip", util.GetRealIP(r))
+	i.WithContext("x-remote-scheme", util.GetRealScheme(r))
+	i.WithContext("x-user-agent", r.UserAgent())
+	i.WithContext("x-tls-identity", tlsIdentity(r))
+	if core.RetryDisabled(r.Method, pattern) {
+		i.WithContext(registry.CTX_NO_RETRY, "1")
+	}
 
 	i.Next()
 }
 
+// tlsIdentity 返回客户端证书的CN
+func tlsIdentity(r *http.Request) string {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return ""
+	}
+	return r.TLS.PeerCertificates[0].Subject.CommonName
+}
+
 func IsSkip(url string) bool {
-	l, vl, hl := len(url), len("/version"), len("/health")
+	l, vl, hl, el := len(url), len("/version"), len("/health"), len("/errors")
 	if l >= vl && url[l-vl:] == "/version" {
 		return true
 	}
 	if l >= hl && url[l-hl:] == "/health" {
 		return true
 	}
+	if l >= el && url[l-el:] == "/errors" {
+		return true
+	}
 	return false
 }
 
 func RegisterHandlers() {
-	chain.RegisterHandler(roa.SERVER_CHAIN_NAME, &ContextHandler{})
+	proxies := strings.Split(core.ServerInfo.Config.TrustedProxies, ",")
+	if err := util.SetTrustedProxies(proxies); err != nil {
+		util.Logger().Errorf(err, "invalid trusted_proxies config '%s'", core.ServerInfo.Config.TrustedProxies)
+	}
+	chain.RegisterNamedHandler(roa.SERVER_CHAIN_NAME, "context", &ContextHandler{})
 }
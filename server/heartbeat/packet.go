@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package heartbeat
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// 心跳报文格式(大端序)：
+// | version(1) | timestamp(8) | len(1) domain/project | len(1) serviceId | len(1) instanceId | hmac-sha256(32) |
+// hmac使用租户密钥对前面所有字节签名，租户密钥为HMAC-SHA256(heartbeat_udp_secret, domain/project)
+const (
+	PACKET_VERSION byte = 1
+
+	// 应答报文：| version(1) | code(1) |，只应答通过校验的报文
+	ACK_OK        byte = 0
+	ACK_NOT_EXIST byte = 1
+	ACK_ERROR     byte = 2
+
+	MAX_PACKET_SIZE = 1 + 8 + 3*(1+255) + sha256.Size
+)
+
+var (
+	ErrInvalidPacket = errors.New("invalid heartbeat packet")
+	ErrInvalidHMAC   = errors.New("heartbeat packet hmac mismatch")
+	ErrExpiredPacket = errors.New("heartbeat packet timestamp out of range")
+)
+
+type Packet struct {
+	Timestamp     int64
+	DomainProject string
+	ServiceId     string
+	InstanceId    string
+}
+
+// TenantKey 由全局密钥派生租户密钥，可单独下发给租户的实例
+func TenantKey(secret []byte, domainProject string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(domainProject))
+	return mac.Sum(nil)
+}
+
+func sign(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// Encode 使用租户密钥生成心跳报文
+func Encode(key []byte, p *Packet) ([]byte, error) {
+	fields := []string{p.DomainProject, p.ServiceId, p.InstanceId}
+	buf := make([]byte, 9, MAX_PACKET_SIZE)
+	buf[0] = PACKET_VERSION
+	binary.BigEndian.PutUint64(buf[1:9], uint64(p.Timestamp))
+	for _, f := range fields {
+		if len(f) == 0 || len(f) > 255 {
+			return nil, ErrInvalidPacket
+		}
+		buf = append(buf, byte(len(f)))
+		buf = append(buf, f...)
+	}
+	return append(buf, sign(key, buf)...), nil
+}
+
+// Decode 解析报文但不校验签名
+func Decode(data []byte) (*Packet, error) {
+	if len(data) < 9+sha256.Size {
+		return nil, ErrInvalidPacket
+	}
+	if data[0] != PACKET_VERSION {
+		return nil, ErrInvalidPacket
+	}
+	p := &Packet{Timestamp: int64(binary.BigEndian.Uint64(data[1:9]))}
+	body := data[9 : len(data)-sha256.Size]
+	fields := make([]string, 0, 3)
+	for len(fields) < 3 {
+		if len(body) == 0 || int(body[0]) == 0 || len(body) < 1+int(body[0]) {
+			return nil, ErrInvalidPacket
+		}
+		// 读缓冲区会被复用，需要拷贝
+		fields = append(fields, string(body[1:1+int(body[0])]))
+		body = body[1+int(body[0]):]
+	}
+	if len(body) > 0 {
+		return nil, ErrInvalidPacket
+	}
+	p.DomainProject, p.ServiceId, p.InstanceId = fields[0], fields[1], fields[2]
+	return p, nil
+}
+
+// Verify 解析并校验签名和时间戳，maxSkew为允许的最大时钟偏差
+func Verify(secret, data []byte, now time.Time, maxSkew time.Duration) (*Packet, error) {
+	p, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	n := len(data) - sha256.Size
+	if !hmac.Equal(sign(TenantKey(secret, p.DomainProject), data[:n]), data[n:]) {
+		return nil, ErrInvalidHMAC
+	}
+	skew := now.Sub(time.Unix(p.Timestamp, 0))
+	if skew > maxSkew || skew < -maxSkew {
+		return nil, ErrExpiredPacket
+	}
+	return p, nil
+}
+
+func EncodeAck(code byte) []byte {
+	return []byte{PACKET_VERSION, code}
+}
+
+func DecodeAck(data []byte) (byte, error) {
+	if len(data) != 2 || data[0] != PACKET_VERSION || data[1] > ACK_ERROR {
+		return 0, ErrInvalidPacket
+	}
+	return data[1], nil
+}
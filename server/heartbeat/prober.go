@@ -0,0 +1,250 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package heartbeat
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"github.com/astaxie/beego"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_PROBE_SCAN_INTERVAL = 5 * time.Second
+	DEFAULT_PROBE_INTERVAL      = 30
+	DEFAULT_PROBE_TIMES         = 3
+	DEFAULT_PROBE_TIMEOUT       = 5 * time.Second
+	DEFAULT_PROBE_CONCURRENCY   = 16
+)
+
+var probeResults = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "service_center",
+		Subsystem: "heartbeat",
+		Name:      "static_probes_total",
+		Help:      "Counter of the active probes of static instances",
+	}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(probeResults)
+}
+
+type probeState struct {
+	next     time.Time
+	failures int32
+}
+
+// Prober 主动探测配置了healthCheck.url或port的静态实例，连续失败times次后将UP的实例置为DOWN，
+// 探测恢复后只恢复由探测置为DOWN的实例，不覆盖通过接口设置的状态
+type Prober struct {
+	Timeout     time.Duration
+	Concurrency int
+
+	mux    sync.Mutex
+	states map[string]*probeState
+	client *http.Client
+}
+
+func NewProber() *Prober {
+	return &Prober{
+		Timeout:     DEFAULT_PROBE_TIMEOUT,
+		Concurrency: DEFAULT_PROBE_CONCURRENCY,
+		states:      make(map[string]*probeState),
+	}
+}
+
+// probeAddress 返回探测的协议和地址，未配置探测时返回空
+func probeAddress(instance *pb.MicroServiceInstance) (string, string) {
+	hc := instance.HealthCheck
+	if hc == nil || (len(hc.Url) == 0 && hc.Port <= 0) || len(instance.Endpoints) == 0 {
+		return "", ""
+	}
+	address, err := util.ParseEndpoint(instance.Endpoints[0])
+	if err != nil || len(address) == 0 {
+		return "", ""
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	if hc.Port > 0 {
+		port = strconv.Itoa(int(hc.Port))
+	}
+	if len(port) == 0 {
+		return "", ""
+	}
+	address = net.JoinHostPort(host, port)
+	if len(hc.Url) > 0 {
+		if !strings.HasPrefix(hc.Url, "/") {
+			address += "/"
+		}
+		return "http", "http://" + address + hc.Url
+	}
+	return "tcp", address
+}
+
+func (p *Prober) probe(network, address string) error {
+	if network == "tcp" {
+		conn, err := net.DialTimeout(network, address, p.Timeout)
+		if err != nil {
+			return err
+		}
+		conn.Close()
+		return nil
+	}
+	resp, err := p.client.Get(address)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (p *Prober) updateStatus(domainProject string, instance *pb.MicroServiceInstance, status, reason, message string) {
+	arr := strings.SplitN(domainProject, "/", 2)
+	ctx := util.SetContext(context.Background(), "domain", arr[0])
+	ctx = util.SetContext(ctx, "project", arr[1])
+	resp, err := core.InstanceAPI.UpdateStatus(ctx, &pb.UpdateInstanceStatusRequest{
+		ServiceId:     instance.ServiceId,
+		InstanceId:    instance.InstanceId,
+		Status:        status,
+		ReasonCode:    reason,
+		ReasonMessage: message,
+	})
+	if err != nil || resp.Response.Code != pb.Response_SUCCESS {
+		util.Logger().Errorf(err, "update static instance %s/%s status to %s failed",
+			instance.ServiceId, instance.InstanceId, status)
+		return
+	}
+	util.Logger().Warnf(nil, "static instance %s/%s status is changed to %s by the active probe, %s",
+		instance.ServiceId, instance.InstanceId, status, message)
+}
+
+func (p *Prober) check(domainProject string, instance *pb.MicroServiceInstance, state *probeState, network, address string) {
+	times := DEFAULT_PROBE_TIMES
+	if instance.HealthCheck.Times > 0 {
+		times = int(instance.HealthCheck.Times)
+	}
+	err := p.probe(network, address)
+	if err == nil {
+		probeResults.WithLabelValues("success").Inc()
+		state.failures = 0
+		if instance.Status == pb.MSI_DOWN && instance.StatusReason != nil &&
+			instance.StatusReason.Code == pb.REASON_HEALTH_CHECK_FAILED {
+			p.updateStatus(domainProject, instance, pb.MSI_UP, "", "")
+		}
+		return
+	}
+
+	probeResults.WithLabelValues("failure").Inc()
+	state.failures++
+	if int(state.failures) >= times && instance.Status == pb.MSI_UP {
+		p.updateStatus(domainProject, instance, pb.MSI_DOWN, pb.REASON_HEALTH_CHECK_FAILED,
+			fmt.Sprintf("probe %s failed %d times, %s", address, state.failures, err.Error()))
+	}
+}
+
+// Probe 探测一轮到期的静态实例
+func (p *Prober) Probe(ctx context.Context, now time.Time) error {
+	all, err := serviceUtil.GetStaticInstances(ctx)
+	if err != nil {
+		return err
+	}
+	if p.client == nil {
+		p.client = &http.Client{Timeout: p.Timeout}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, p.Concurrency)
+	seen := make(map[string]struct{})
+	p.mux.Lock()
+	for domainProject, instances := range all {
+		for _, instance := range instances {
+			network, address := probeAddress(instance)
+			if len(network) == 0 {
+				continue
+			}
+			key := util.StringJoin([]string{domainProject, instance.ServiceId, instance.InstanceId}, "/")
+			seen[key] = struct{}{}
+			state, ok := p.states[key]
+			if !ok {
+				state = &probeState{}
+				p.states[key] = state
+			}
+			if now.Before(state.next) {
+				continue
+			}
+			interval := DEFAULT_PROBE_INTERVAL
+			if instance.HealthCheck.Interval > 0 {
+				interval = int(instance.HealthCheck.Interval)
+			}
+			state.next = now.Add(time.Duration(interval) * time.Second)
+
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(domainProject string, instance *pb.MicroServiceInstance, state *probeState, network, address string) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				p.check(domainProject, instance, state, network, address)
+			}(domainProject, instance, state, network, address)
+		}
+	}
+	for key := range p.states {
+		if _, ok := seen[key]; !ok {
+			delete(p.states, key)
+		}
+	}
+	p.mux.Unlock()
+	wg.Wait()
+	return nil
+}
+
+func (p *Prober) run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(DEFAULT_PROBE_SCAN_INTERVAL):
+			if err := p.Probe(context.Background(), time.Now()); err != nil {
+				util.Logger().Errorf(err, "probe static instances failed")
+			}
+		}
+	}
+}
+
+// RunProber 启动静态实例的主动探测，static_instance_probe=false时关闭
+func RunProber() {
+	if !beego.AppConfig.DefaultBool("static_instance_probe", true) {
+		return
+	}
+	util.Go(NewProber().run)
+}
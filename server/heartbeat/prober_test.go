@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package heartbeat_test
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/heartbeat"
+	"github.com/apache/incubator-servicecomb-service-center/server/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func getStatus(t *testing.T, serviceId, instanceId string) (string, string) {
+	resp, err := core.InstanceAPI.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+		ConsumerServiceId:  serviceId,
+		ProviderServiceId:  serviceId,
+		ProviderInstanceId: instanceId,
+	})
+	if err != nil || resp.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("get instance failed, %v %v", resp, err)
+	}
+	if resp.Instance.StatusReason == nil {
+		return resp.Instance.Status, ""
+	}
+	return resp.Instance.Status, resp.Instance.StatusReason.Code
+}
+
+func TestProber(t *testing.T) {
+	core.ServiceAPI, core.InstanceAPI = service.AssembleResources()
+
+	var healthy int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" || atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	respCreate, err := core.ServiceAPI.Create(getContext(), &pb.CreateServiceRequest{
+		Service: &pb.MicroService{
+			AppId:       "heartbeat_probe_group",
+			ServiceName: "heartbeat_probe_service",
+			Version:     "1.0.0",
+			Level:       "FRONT",
+			Status:      pb.MS_UP,
+		},
+	})
+	if err != nil || respCreate.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("create service failed, %v %v", respCreate, err)
+	}
+	serviceId := respCreate.ServiceId
+	respRegister, err := core.InstanceAPI.Register(getContext(), &pb.RegisterInstanceRequest{
+		Instance: &pb.MicroServiceInstance{
+			ServiceId: serviceId,
+			HostName:  "heartbeat-probe-vm",
+			Endpoints: []string{"rest://" + strings.TrimPrefix(srv.URL, "http://")},
+			HealthCheck: &pb.HealthCheck{
+				Mode:     pb.CHECK_BY_STATIC,
+				Url:      "/health",
+				Interval: 10,
+				Times:    2,
+			},
+		},
+	})
+	if err != nil || respRegister.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("register instance failed, %v %v", respRegister, err)
+	}
+	instanceId := respRegister.InstanceId
+
+	p := heartbeat.NewProber()
+	now := time.Now()
+	probe := func() {
+		if err := p.Probe(getContext(), now); err != nil {
+			t.Fatalf("probe failed, %s", err)
+		}
+		now = now.Add(10 * time.Second)
+	}
+
+	// 未到times次失败不改变状态
+	probe()
+	if status, _ := getStatus(t, serviceId, instanceId); status != pb.MSI_UP {
+		t.Fatalf("instance status changed after first failure, %s", status)
+	}
+	probe()
+	if status, reason := getStatus(t, serviceId, instanceId); status != pb.MSI_DOWN || reason != pb.REASON_HEALTH_CHECK_FAILED {
+		t.Fatalf("instance is not down, %s %s", status, reason)
+	}
+
+	atomic.StoreInt32(&healthy, 1)
+	probe()
+	if status, reason := getStatus(t, serviceId, instanceId); status != pb.MSI_UP || len(reason) != 0 {
+		t.Fatalf("instance is not recovered, %s %s", status, reason)
+	}
+
+	// 通过接口设置的状态不被探测覆盖
+	respStatus, err := core.InstanceAPI.UpdateStatus(getContext(), &pb.UpdateInstanceStatusRequest{
+		ServiceId:  serviceId,
+		InstanceId: instanceId,
+		Status:     pb.MSI_DOWN,
+		ReasonCode: pb.REASON_MAINTENANCE,
+	})
+	if err != nil || respStatus.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("update status failed, %v %v", respStatus, err)
+	}
+	probe()
+	if status, reason := getStatus(t, serviceId, instanceId); status != pb.MSI_DOWN || reason != pb.REASON_MAINTENANCE {
+		t.Fatalf("manual status is overridden, %s %s", status, reason)
+	}
+}
@@ -0,0 +1,220 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package heartbeat
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/astaxie/beego"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_MAX_SKEW = 30 * time.Second
+	DEFAULT_WORKERS  = 64
+	// 等待处理的报文超过队列长度时直接丢弃，由实例的重试和租约ttl容忍
+	DEFAULT_QUEUE_SIZE = 10000
+)
+
+var (
+	udpPackets = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "heartbeat",
+			Name:      "udp_packets_total",
+			Help:      "Counter of the heartbeat packets received by the udp listener",
+		}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(udpPackets)
+}
+
+type datagram struct {
+	data []byte
+	addr *net.UDPAddr
+}
+
+// UDPListener 轻量心跳通道，报文通过HMAC校验后续约实例的租约
+type UDPListener struct {
+	Addr    string
+	Secret  []byte
+	MaxSkew time.Duration
+	Workers int
+
+	conn  *net.UDPConn
+	queue chan *datagram
+}
+
+func NewUDPListener(addr string, secret []byte) *UDPListener {
+	return &UDPListener{
+		Addr:    addr,
+		Secret:  secret,
+		MaxSkew: DEFAULT_MAX_SKEW,
+		Workers: DEFAULT_WORKERS,
+	}
+}
+
+func (l *UDPListener) Listen() (err error) {
+	addr, err := net.ResolveUDPAddr("udp", l.Addr)
+	if err != nil {
+		return
+	}
+	l.conn, err = net.ListenUDP("udp", addr)
+	if err != nil {
+		return
+	}
+	l.queue = make(chan *datagram, DEFAULT_QUEUE_SIZE)
+	return
+}
+
+func (l *UDPListener) LocalAddr() net.Addr {
+	return l.conn.LocalAddr()
+}
+
+// Serve 读取报文直到stopCh关闭
+func (l *UDPListener) Serve(stopCh <-chan struct{}) {
+	for i := 0; i < l.Workers; i++ {
+		go l.work(stopCh)
+	}
+	go func() {
+		<-stopCh
+		l.conn.Close()
+	}()
+
+	buf := make([]byte, MAX_PACKET_SIZE+1)
+	for {
+		n, addr, err := l.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+			util.Logger().Errorf(err, "read heartbeat packet failed")
+			continue
+		}
+		if n > MAX_PACKET_SIZE {
+			udpPackets.WithLabelValues("invalid").Inc()
+			continue
+		}
+		data := make([]byte, n)
+		copy(data, buf[:n])
+		select {
+		case l.queue <- &datagram{data: data, addr: addr}:
+		default:
+			udpPackets.WithLabelValues("dropped").Inc()
+		}
+	}
+}
+
+func (l *UDPListener) work(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case d := <-l.queue:
+			l.handle(d)
+		}
+	}
+}
+
+func (l *UDPListener) handle(d *datagram) {
+	p, err := Verify(l.Secret, d.data, time.Now(), l.MaxSkew)
+	switch err {
+	case nil:
+	case ErrInvalidHMAC:
+		udpPackets.WithLabelValues("unauthorized").Inc()
+		return
+	case ErrExpiredPacket:
+		udpPackets.WithLabelValues("expired").Inc()
+		return
+	default:
+		udpPackets.WithLabelValues("invalid").Inc()
+		return
+	}
+
+	arr := strings.SplitN(p.DomainProject, "/", 2)
+	if len(arr) != 2 {
+		udpPackets.WithLabelValues("invalid").Inc()
+		return
+	}
+	ctx := util.SetContext(context.Background(), "domain", arr[0])
+	ctx = util.SetContext(ctx, "project", arr[1])
+	ctx = util.SetContext(ctx, "x-remote-ip", d.addr.IP.String())
+
+	code, result := ACK_OK, "ok"
+	resp, _ := core.InstanceAPI.Heartbeat(ctx, &pb.HeartbeatRequest{
+		ServiceId:  p.ServiceId,
+		InstanceId: p.InstanceId,
+	})
+	switch {
+	case resp.Response.Code == pb.Response_SUCCESS:
+	case resp.Response.Code == scerr.ErrInstanceNotExists:
+		code, result = ACK_NOT_EXIST, "not_exist"
+	default:
+		code, result = ACK_ERROR, "error"
+	}
+	udpPackets.WithLabelValues(result).Inc()
+
+	// 应答比请求小，不会被用于放大攻击
+	if _, err := l.conn.WriteToUDP(EncodeAck(code), d.addr); err != nil {
+		util.Logger().Warnf(err, "send heartbeat ack to %s failed", d.addr)
+	}
+}
+
+// Run 配置了heartbeat_udp_addr时启动UDP心跳通道
+func Run() {
+	addr := beego.AppConfig.String("heartbeat_udp_addr")
+	if len(addr) == 0 {
+		return
+	}
+	secret := beego.AppConfig.String("heartbeat_udp_secret")
+	if len(secret) > 0 {
+		decrypt, err := plugin.Plugins().Cipher().Decrypt(secret)
+		if err != nil {
+			util.Logger().Errorf(err, "decrypt heartbeat udp secret failed")
+			return
+		}
+		secret = decrypt
+	}
+	if len(secret) == 0 {
+		util.Logger().Errorf(nil, "heartbeat udp listener is disabled, heartbeat_udp_secret is empty")
+		return
+	}
+
+	l := NewUDPListener(addr, []byte(secret))
+	l.MaxSkew = time.Duration(beego.AppConfig.DefaultInt("heartbeat_udp_max_skew", 30)) * time.Second
+	l.Workers = beego.AppConfig.DefaultInt("heartbeat_udp_workers", DEFAULT_WORKERS)
+	if l.Workers <= 0 {
+		l.Workers = DEFAULT_WORKERS
+	}
+	if err := l.Listen(); err != nil {
+		util.Logger().Errorf(err, "start heartbeat udp listener %s failed", addr)
+		return
+	}
+	util.Logger().Infof("Local listen heartbeat udp address: %s, workers %d, max skew %s.",
+		l.LocalAddr(), l.Workers, l.MaxSkew)
+	util.Go(l.Serve)
+}
@@ -0,0 +1,165 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package heartbeat_test
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/heartbeat"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/admission/buildin"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/quota/buildin"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/etcd"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/uuid/dynamic"
+	"github.com/apache/incubator-servicecomb-service-center/server/service"
+	"golang.org/x/net/context"
+	"net"
+	"testing"
+	"time"
+)
+
+var secret = []byte("heartbeat_test_secret")
+
+func getContext() context.Context {
+	ctx := context.TODO()
+	ctx = util.SetContext(ctx, "domain", "default")
+	ctx = util.SetContext(ctx, "project", "default")
+	ctx = util.SetContext(ctx, "noCache", "1")
+	return ctx
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Now()
+	p := &heartbeat.Packet{
+		Timestamp:     now.Unix(),
+		DomainProject: "default/default",
+		ServiceId:     "svc",
+		InstanceId:    "inst",
+	}
+	data, err := heartbeat.Encode(heartbeat.TenantKey(secret, p.DomainProject), p)
+	if err != nil {
+		t.Fatalf("encode failed, %s", err)
+	}
+
+	v, err := heartbeat.Verify(secret, data, now, time.Minute)
+	if err != nil || *v != *p {
+		t.Fatalf("verify failed, %v %v", v, err)
+	}
+
+	if _, err := heartbeat.Verify(secret, data, now.Add(2*time.Minute), time.Minute); err != heartbeat.ErrExpiredPacket {
+		t.Fatalf("expired packet passed, %v", err)
+	}
+
+	// 其他租户的密钥不能签名本租户的报文
+	other, _ := heartbeat.Encode(heartbeat.TenantKey(secret, "other/default"), p)
+	if _, err := heartbeat.Verify(secret, other, now, time.Minute); err != heartbeat.ErrInvalidHMAC {
+		t.Fatalf("packet signed by other tenant passed, %v", err)
+	}
+
+	tampered := append([]byte{}, data...)
+	tampered[len(tampered)-40] ^= 1
+	if _, err := heartbeat.Verify(secret, tampered, now, time.Minute); err != heartbeat.ErrInvalidHMAC {
+		t.Fatalf("tampered packet passed, %v", err)
+	}
+
+	for _, bad := range [][]byte{nil, data[:20], append([]byte{2}, data[1:]...)} {
+		if _, err := heartbeat.Verify(secret, bad, now, time.Minute); err != heartbeat.ErrInvalidPacket {
+			t.Fatalf("invalid packet passed, %v", err)
+		}
+	}
+
+	if _, err := heartbeat.Encode(secret, &heartbeat.Packet{DomainProject: "default/default"}); err != heartbeat.ErrInvalidPacket {
+		t.Fatalf("encode empty field, %v", err)
+	}
+}
+
+func send(t *testing.T, addr net.Addr, data []byte) (byte, error) {
+	conn, err := net.Dial("udp", addr.String())
+	if err != nil {
+		t.Fatalf("dial failed, %s", err)
+	}
+	defer conn.Close()
+	conn.Write(data)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 16)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return 0, err
+	}
+	return heartbeat.DecodeAck(buf[:n])
+}
+
+func TestUDPListener(t *testing.T) {
+	core.ServiceAPI, core.InstanceAPI = service.AssembleResources()
+
+	respCreate, err := core.ServiceAPI.Create(getContext(), &pb.CreateServiceRequest{
+		Service: &pb.MicroService{
+			AppId:       "heartbeat_udp_group",
+			ServiceName: "heartbeat_udp_service",
+			Version:     "1.0.0",
+			Level:       "FRONT",
+			Status:      pb.MS_UP,
+		},
+	})
+	if err != nil || respCreate.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("create service failed, %v %v", respCreate, err)
+	}
+	respRegister, err := core.InstanceAPI.Register(getContext(), &pb.RegisterInstanceRequest{
+		Instance: &pb.MicroServiceInstance{
+			ServiceId: respCreate.ServiceId,
+			HostName:  "heartbeat-udp-host",
+			Endpoints: []string{"rest://127.0.0.1:8080"},
+			Status:    pb.MSI_UP,
+		},
+	})
+	if err != nil || respRegister.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("register instance failed, %v %v", respRegister, err)
+	}
+
+	l := heartbeat.NewUDPListener("127.0.0.1:0", secret)
+	l.Workers = 2
+	if err := l.Listen(); err != nil {
+		t.Fatalf("listen failed, %s", err)
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go l.Serve(stopCh)
+
+	key := heartbeat.TenantKey(secret, "default/default")
+	p := &heartbeat.Packet{
+		Timestamp:     time.Now().Unix(),
+		DomainProject: "default/default",
+		ServiceId:     respCreate.ServiceId,
+		InstanceId:    respRegister.InstanceId,
+	}
+	data, _ := heartbeat.Encode(key, p)
+	if code, err := send(t, l.LocalAddr(), data); err != nil || code != heartbeat.ACK_OK {
+		t.Fatalf("heartbeat failed, %d %v", code, err)
+	}
+
+	p.InstanceId = "notexistins"
+	data, _ = heartbeat.Encode(key, p)
+	if code, err := send(t, l.LocalAddr(), data); err != nil || code != heartbeat.ACK_NOT_EXIST {
+		t.Fatalf("heartbeat not exist instance, %d %v", code, err)
+	}
+
+	// 未通过校验的报文不应答
+	data, _ = heartbeat.Encode([]byte("wrong"), p)
+	if _, err := send(t, l.LocalAddr(), data); err == nil {
+		t.Fatalf("unauthorized packet was acked")
+	}
+}
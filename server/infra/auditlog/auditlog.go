@@ -21,3 +21,25 @@ import "net/http"
 type AuditLogger interface {
 	Record(r *http.Request, responseHeaders http.Header)
 }
+
+// Verifier is implemented by the audit loggers which chain the records of
+// each tenant, Verify checks the records of the tenant, or all the tenants
+// if domainProject is empty.
+type Verifier interface {
+	Verify(domainProject string) ([]*VerifyResult, error)
+}
+
+// Purger is implemented by the audit loggers which can remove all the
+// records of a tenant when the tenant data is purged.
+type Purger interface {
+	Purge(domainProject string) (int64, error)
+}
+
+type VerifyResult struct {
+	DomainProject string `json:"domainProject"`
+	Records       int64  `json:"records"`
+	Valid         bool   `json:"valid"`
+	// BrokenAt is the sequence of the first record failed to verify
+	BrokenAt int64  `json:"brokenAt,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmdb
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"golang.org/x/net/context"
+)
+
+// ServiceRecord is the service metadata pushed to CMDB
+type ServiceRecord struct {
+	Action        string            `json:"action"`
+	DomainProject string            `json:"domainProject"`
+	ServiceId     string            `json:"serviceId"`
+	Service       *pb.MicroService  `json:"service,omitempty"`
+	Owner         string            `json:"owner,omitempty"`
+	Tags          map[string]string `json:"tags,omitempty"`
+	Endpoints     []string          `json:"endpoints,omitempty"`
+	Timestamp     int64             `json:"timestamp"`
+}
+
+// CMDB pushes the create/update/delete of services to an external inventory system,
+// an error returned means the record should be retried later.
+type CMDB interface {
+	Sync(ctx context.Context, record *ServiceRecord) error
+}
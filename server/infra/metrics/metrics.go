@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package metrics
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+const DEFAULT_PREFIX = "servicecenter"
+
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is the current value of a time series, the labels are sorted by name.
+type Sample struct {
+	Name   string
+	Labels []Label
+	Value  float64
+}
+
+// Path returns the dotted name '{prefix}.{name}.{label}.{value}...' used by
+// statsd and graphite, the illegal characters are replaced by '_'.
+func (s *Sample) Path(prefix string) string {
+	parts := make([]string, 0, 2+2*len(s.Labels))
+	if len(prefix) > 0 {
+		parts = append(parts, prefix)
+	}
+	parts = append(parts, sanitize(s.Name))
+	for _, l := range s.Labels {
+		parts = append(parts, sanitize(l.Name), sanitize(l.Value))
+	}
+	return strings.Join(parts, ".")
+}
+
+func sanitize(s string) string {
+	if len(s) == 0 {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+// Valid returns false if the value is NaN or Inf, which can not be pushed.
+func (s *Sample) Valid() bool {
+	return !math.IsNaN(s.Value) && !math.IsInf(s.Value, 0)
+}
+
+func FormatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Exporter pushes the registry telemetry to the monitoring systems which do
+// not scrape the Prometheus endpoint.
+type Exporter interface {
+	Export(samples []*Sample) error
+}
@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package naming
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"golang.org/x/net/context"
+)
+
+// Violation describes a field of the service which does not conform to the naming rules
+type Violation struct {
+	// Field is one of appId, serviceName and version
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%s '%s' %s", v.Field, v.Value, v.Reason)
+}
+
+// Policy checks the appId, serviceName and version of a service before it is
+// created, no violation means the service conforms to the naming rules of the
+// domain. The violations of the services which already exist are tolerated by
+// the caller, so the rules can be tightened without breaking them.
+type Policy interface {
+	Check(ctx context.Context, domainProject string, service *pb.MicroService) []*Violation
+}
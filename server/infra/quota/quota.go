@@ -26,6 +26,17 @@ type QuotaManager interface {
 	RemandQuotas(ctx context.Context, quotaType ResourceType)
 }
 
+// QuotaLimiter 可选接口，查询资源的配额上限，返回0表示不限制
+type QuotaLimiter interface {
+	GetLimit(quotaType ResourceType) int64
+}
+
+// QuotaWarning 可选接口，超过配额上限但在宽限超额比例内申请成功时，
+// Apply4Quotas返回的reporter实现该接口
+type QuotaWarning interface {
+	Warning() string
+}
+
 type QuotaReporter interface {
 	ReportUsedQuota(ctx context.Context) error
 	Close()
@@ -37,6 +48,7 @@ const (
 	TagQuotaType
 	MicroServiceQuotaType
 	MicroServiceInstanceQuotaType
+	StaticInstanceQuotaType
 	typeEnd
 )
 
@@ -54,6 +66,8 @@ func (r ResourceType) String() string {
 		return "SERVICE"
 	case MicroServiceInstanceQuotaType:
 		return "INSTANCE"
+	case StaticInstanceQuotaType:
+		return "STATIC_INSTANCE"
 	default:
 		return "RESOURCE" + fmt.Sprint(r)
 	}
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package registry
+
+import "golang.org/x/net/context"
+
+const (
+	MIGRATION_SOURCE = "source"
+	MIGRATION_TARGET = "target"
+)
+
+// MigrationCheck 一次主备后端的一致性检查结果，以当前读写的后端为准
+type MigrationCheck struct {
+	Timestamp string `json:"timestamp"`
+	Keys      int64  `json:"keys"`
+	// 备后端缺少的key数
+	Missing int64 `json:"missing"`
+	// 备后端多出的key数
+	Extra int64 `json:"extra"`
+	// 值不同的key数
+	Different int64 `json:"different"`
+	// 部分不一致的key，便于定位
+	Samples    []string `json:"samples,omitempty"`
+	Consistent bool     `json:"consistent"`
+}
+
+// MigrationStatus 双写迁移的状态，Primary为当前读写的后端，变更按顺序异步复制到另一个后端
+type MigrationStatus struct {
+	Source  string `json:"source"`
+	Target  string `json:"target"`
+	Primary string `json:"primary"`
+	// 待复制的变更数和其中最早一个的等待时间
+	Pending    int     `json:"pending"`
+	LagSeconds float64 `json:"lagSeconds"`
+	// 复制失败和队列满被丢弃的变更数，非0时需要Sync后才能切换
+	Failed    int64           `json:"failed"`
+	Dropped   int64           `json:"dropped"`
+	LastCheck *MigrationCheck `json:"lastCheck,omitempty"`
+	// 最近一次切换的时间
+	CutoverTime string `json:"cutoverTime,omitempty"`
+}
+
+// Migrator 支持在两个后端之间双写迁移的registry插件实现该接口
+type Migrator interface {
+	MigrationStatus() *MigrationStatus
+	// CheckConsistency 比较两个后端的所有key
+	CheckConsistency(ctx context.Context) (*MigrationCheck, error)
+	// Sync 把当前读写的后端中缺少或不同的key复制到另一个后端，删除多出的key，返回修正的key数
+	Sync(ctx context.Context) (int64, error)
+	// Cutover 切换读写的后端，force为false时要求没有待复制的变更且最近一次检查一致
+	Cutover(ctx context.Context, force bool) error
+}
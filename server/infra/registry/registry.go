@@ -17,6 +17,7 @@
 package registry
 
 import (
+	"errors"
 	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"github.com/astaxie/beego"
@@ -30,6 +31,22 @@ var defaultRegistryConfig Config
 
 func init() {
 	defaultRegistryConfig.ClusterAddresses = beego.AppConfig.DefaultString("manager_cluster", "sc-0=http://127.0.0.1:2380")
+	defaultRegistryConfig.ReadClusterAddresses = beego.AppConfig.DefaultString("manager_read_cluster", "")
+	defaultRegistryConfig.MigrationClusterAddresses = beego.AppConfig.DefaultString("manager_migration_cluster", "")
+	defaultRegistryConfig.ReadMaxLag = beego.AppConfig.DefaultInt64("manager_read_max_lag", DEFAULT_READ_MAX_LAG)
+	defaultRegistryConfig.RetryTimes = beego.AppConfig.DefaultInt("manager_retry_times", DEFAULT_RETRY_TIMES)
+	defaultRegistryConfig.RetryInterval = DEFAULT_RETRY_INTERVAL
+	if d, err := time.ParseDuration(beego.AppConfig.DefaultString("manager_retry_interval",
+		DEFAULT_RETRY_INTERVAL.String())); err == nil && d > 0 {
+		defaultRegistryConfig.RetryInterval = d
+	}
+	defaultRegistryConfig.LeaseRate = beego.AppConfig.DefaultFloat("manager_lease_rate", DEFAULT_LEASE_RATE)
+	defaultRegistryConfig.LeaseBurst = beego.AppConfig.DefaultInt("manager_lease_burst", DEFAULT_LEASE_BURST)
+	defaultRegistryConfig.LeaseQueueTimeout = DEFAULT_LEASE_QUEUE_TIMEOUT
+	if d, err := time.ParseDuration(beego.AppConfig.DefaultString("manager_lease_queue_timeout",
+		DEFAULT_LEASE_QUEUE_TIMEOUT.String())); err == nil && d >= 0 {
+		defaultRegistryConfig.LeaseQueueTimeout = d
+	}
 }
 
 type ActionType int
@@ -147,6 +164,27 @@ const (
 	REQUEST_TIMEOUT = 300
 
 	DEFAULT_PAGE_COUNT = 4096 // grpc does not allow to transport a large body more then 4MB in a request.
+
+	DEFAULT_READ_MAX_LAG = 1000
+
+	DEFAULT_RETRY_TIMES    = 2
+	DEFAULT_RETRY_INTERVAL = 100 * time.Millisecond
+
+	DEFAULT_LEASE_RATE          = 200
+	DEFAULT_LEASE_BURST         = 400
+	DEFAULT_LEASE_QUEUE_TIMEOUT = 3 * time.Second
+
+	// 上下文中该值为"1"时不重试后端的临时错误
+	CTX_NO_RETRY = "noRetry"
+)
+
+var (
+	// 查询的revision已被压缩
+	ErrRevisionCompacted = errors.New("required revision has been compacted")
+	// 查询的revision大于当前revision
+	ErrFutureRevision = errors.New("required revision is a future revision")
+	// 租约操作超出准入限制
+	ErrLeaseThrottled = errors.New("lease operation is throttled")
 )
 
 type Registry interface {
@@ -168,9 +206,35 @@ type Registry interface {
 	Close()
 }
 
+// BackendStatus 后端存储的状态，DbSize为存储大小(bytes)，多节点时取最大值
+type BackendStatus struct {
+	DbSize int64
+}
+
+// StatusReporter 支持查询后端存储状态的registry插件实现该接口
+type StatusReporter interface {
+	Status(ctx context.Context) (*BackendStatus, error)
+}
+
 type Config struct {
 	EmbedMode        string
 	ClusterAddresses string
+	// 只读副本地址，配置后读请求优先路由到副本
+	ReadClusterAddresses string
+	// 双写迁移的目标集群地址，registry_plugin为dualwrite时使用
+	MigrationClusterAddresses string
+	// 副本落后主集群的revision数超过该值时不再路由读请求，<=0不检查
+	ReadMaxLag int64
+	// 幂等读和CAS写遇到临时错误时的重试次数，<=0不重试
+	RetryTimes int
+	// 首次重试的间隔，之后按指数增长并加入随机抖动
+	RetryInterval time.Duration
+	// 租约创建、续约和撤销每秒准入的数量，<=0不限制
+	LeaseRate float64
+	// 令牌桶容量，即允许突发的租约操作数
+	LeaseBurst int
+	// 租约操作排队的最长时间，预计等待超过该时间的操作直接拒绝
+	LeaseQueueTimeout time.Duration
 }
 
 type PluginOp struct {
@@ -308,6 +372,10 @@ func WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(ctx, REQUEST_TIMEOUT*time.Second)
 }
 
+func RetryDisabled(ctx context.Context) bool {
+	return ctx.Value(CTX_NO_RETRY) == "1"
+}
+
 func RegistryConfig() *Config {
 	return &defaultRegistryConfig
 }
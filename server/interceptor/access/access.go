@@ -35,7 +35,8 @@ func init() {
 func Intercept(w http.ResponseWriter, r *http.Request) error {
 	w.Header().Add("server", serverName)
 
-	r.Body = http.MaxBytesReader(w, r.Body, core.ServerInfo.Config.MaxBodyBytes)
+	// 各API的上限在路由匹配后由bodylimit handler限制
+	r.Body = core.LimitBody(r.Body, core.MaxBodyLimit())
 
 	if !validate.IsRequestURI(r.RequestURI) {
 		err := fmt.Errorf("Invalid Request URI %s", r.RequestURI)
@@ -19,6 +19,7 @@ package cors
 import (
 	"errors"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
 	"io"
 	"net/http"
 	"strconv"
@@ -32,7 +33,8 @@ func init() {
 }
 
 type CORS struct {
-	allowOrigin      string
+	allowAllOrigins  bool
+	allowOrigins     map[string]struct{}
 	allowMethods     map[string]struct{}
 	allowHeaders     map[string]struct{}
 	allowCredentials bool
@@ -43,14 +45,63 @@ type CORS struct {
 
 func New() *CORS {
 	c := new(CORS)
-	c.allowOrigin = "*"
+	c.allowAllOrigins = true
 	c.allowCredentials = false
-	c.allowHeaders = map[string]struct{}{"origin": {}, "content-type": {}, "x-domain-name": {}, "x-consumerid": {}}
-	c.allowMethods = map[string]struct{}{"GET": {}, "POST": {}, "PUT": {}, "DELETE": {}, "UPDATE": {}}
+	c.allowHeaders = map[string]struct{}{"origin": {}, "content-type": {}, "x-domain-name": {}, "x-consumerid": {}, "x-api-key": {}, "if-none-match": {}}
+	c.allowMethods = map[string]struct{}{"GET": {}, "POST": {}, "PUT": {}, "DELETE": {}, "PATCH": {}, "UPDATE": {}}
 	c.maxAge = 1500
+	c.LoadConfig()
 	return c
 }
 
+func (cors *CORS) LoadConfig() {
+	cfg := core.ServerInfo.Config
+	if origins := splitList(cfg.CorsAllowOrigins, false); len(origins) > 0 {
+		cors.setAllowOrigins(origins)
+	}
+	if methods := splitList(cfg.CorsAllowMethods, false); len(methods) > 0 {
+		for i, m := range methods {
+			methods[i] = strings.ToUpper(m)
+		}
+		cors.allowMethods = util.ListToMap(methods)
+	}
+	if headers := splitList(cfg.CorsAllowHeaders, true); len(headers) > 0 {
+		cors.allowHeaders = util.ListToMap(headers)
+	}
+	cors.exposeHeaders = util.StringJoin(splitList(cfg.CorsExposeHeaders, false), ",")
+	cors.allowCredentials = cfg.CorsAllowCredentials
+	if cfg.CorsMaxAge > 0 {
+		cors.maxAge = int(cfg.CorsMaxAge)
+	}
+
+	util.Logger().Infof("CORS Load config, origins: %s, methods: %v, headers: %v, credentials: %v, max age: %d",
+		cfg.CorsAllowOrigins, cors.AllowMethods(), cors.AllowHeaders(), cors.allowCredentials, cors.maxAge)
+}
+
+func splitList(s string, lower bool) []string {
+	arr := strings.Split(s, ",")
+	list := make([]string, 0, len(arr))
+	for _, v := range arr {
+		v = strings.TrimSpace(v)
+		if len(v) == 0 {
+			continue
+		}
+		if lower {
+			v = strings.ToLower(v)
+		}
+		list = append(list, v)
+	}
+	return list
+}
+
+func (cors *CORS) isOriginAllowed(origin string) bool {
+	if cors.allowAllOrigins {
+		return true
+	}
+	_, ok := cors.allowOrigins[origin]
+	return ok
+}
+
 func (cors *CORS) AllowMethods() []string {
 	return util.MapToList(cors.allowMethods)
 }
@@ -60,6 +111,11 @@ func (cors *CORS) AllowHeaders() []string {
 }
 
 func (cors *CORS) handlePreflightRequest(w http.ResponseWriter, r *http.Request) {
+	if !cors.isOriginAllowed(r.Header.Get("Origin")) {
+		cors.invalid(w, r)
+		util.Logger().Warnf(nil, "origin '%s' is not allowed", r.Header.Get("Origin"))
+		return
+	}
 	acrm := r.Header.Get("Access-Control-Request-Method")
 	if acrm == "" {
 		cors.invalid(w, r)
@@ -103,6 +159,9 @@ func (cors *CORS) invalid(w http.ResponseWriter, r *http.Request) {
 }
 
 func (cors *CORS) handleActualRequest(w http.ResponseWriter, r *http.Request) {
+	if !cors.isOriginAllowed(r.Header.Get("Origin")) {
+		return
+	}
 	if cors.exposeHeaders != "" {
 		w.Header().Add("Access-Control-Expose-Headers", cors.exposeHeaders)
 	}
@@ -112,7 +171,13 @@ func (cors *CORS) handleActualRequest(w http.ResponseWriter, r *http.Request) {
 }
 
 func (cors *CORS) addAllowOriginHeader(w http.ResponseWriter, r *http.Request) {
-	w.Header().Add("Access-Control-Allow-Origin", cors.allowOrigin)
+	// the wildcard is not allowed if the request is with credentials
+	if cors.allowAllOrigins && !cors.allowCredentials {
+		w.Header().Add("Access-Control-Allow-Origin", "*")
+		return
+	}
+	w.Header().Add("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+	w.Header().Add("Vary", "Origin")
 	return
 }
 
@@ -122,6 +187,22 @@ func (cors *CORS) addAllowCookiesHeader(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+func (cors *CORS) setAllowOrigins(origins []string) {
+	cors.allowAllOrigins = false
+	cors.allowOrigins = make(map[string]struct{}, len(origins))
+	for _, origin := range origins {
+		if origin == "*" {
+			cors.allowAllOrigins = true
+			continue
+		}
+		cors.allowOrigins[origin] = struct{}{}
+	}
+}
+
+func SetAllowOrigins(origins []string) {
+	cors.setAllowOrigins(origins)
+}
+
 func SetAllowMethods(methods []string) {
 	cors.allowMethods = util.ListToMap(methods)
 }
@@ -22,7 +22,10 @@ import (
 	"net/http"
 )
 
-var interceptors []*Interception
+var (
+	interceptors      []*Interception
+	namedInterceptors = make(map[string]InterceptorFunc)
+)
 
 type InterceptorFunc func(http.ResponseWriter, *http.Request) error
 
@@ -56,6 +59,31 @@ func RegisterInterceptFunc(intc InterceptorFunc) {
 	util.Logger().Infof("Intercept %s", intc.Name())
 }
 
+// RegisterNamedInterceptFunc installs the interceptor and makes it addressable
+// by name, so that the interceptors can be reordered or trimmed by UseInterceptors
+func RegisterNamedInterceptFunc(name string, intc InterceptorFunc) {
+	RegisterInterceptFunc(intc)
+	namedInterceptors[name] = intc
+}
+
+// UseInterceptors replaces the installed interceptors with the named ones in
+// the given order, the names not registered are skipped and returned.
+// It must be called before the server starts
+func UseInterceptors(names []string) (missing []string) {
+	list := make([]*Interception, 0, len(names))
+	for _, name := range names {
+		intc, ok := namedInterceptors[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		list = append(list, &Interception{function: intc})
+	}
+	interceptors = list
+	util.Logger().Infof("Use interceptors %v", names)
+	return
+}
+
 func InvokeInterceptors(w http.ResponseWriter, req *http.Request) (err error) {
 	var intc *Interception
 	defer func() {
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package locale
+
+import (
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Negotiate returns the best language matched the Accept-Language header,
+// e.g. 'zh-CN,zh;q=0.9,en;q=0.8', return empty if none is supported.
+func Negotiate(acceptLanguage string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		part = strings.TrimSpace(part)
+		if len(part) == 0 {
+			continue
+		}
+		lang, q := part, 1.0
+		if i := strings.Index(part, ";"); i >= 0 {
+			lang = strings.TrimSpace(part[:i])
+			param := strings.TrimSpace(part[i+1:])
+			if strings.HasPrefix(param, "q=") {
+				v, err := strconv.ParseFloat(param[2:], 64)
+				if err != nil {
+					continue
+				}
+				q = v
+			}
+		}
+		if q <= bestQ || lang == "*" || !scerr.HasLanguage(lang) {
+			continue
+		}
+		best, bestQ = lang, q
+	}
+	return best
+}
+
+func Intercept(w http.ResponseWriter, r *http.Request) error {
+	al := r.Header.Get("Accept-Language")
+	if len(al) == 0 {
+		return nil
+	}
+	lang := Negotiate(al)
+	if len(lang) == 0 || strings.EqualFold(lang, scerr.DEFAULT_LANGUAGE) {
+		return nil
+	}
+	w.Header().Set("Content-Language", lang)
+	return nil
+}
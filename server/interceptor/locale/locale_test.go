@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package locale
+
+import (
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	if Negotiate("zh-CN,zh;q=0.9,en;q=0.8") != "zh-CN" {
+		t.FailNow()
+	}
+	if Negotiate("fr;q=1,en;q=0.5,zh;q=0.8") != "zh" {
+		t.FailNow()
+	}
+	if Negotiate("fr,de") != "" {
+		t.FailNow()
+	}
+	if Negotiate("*") != "" {
+		t.FailNow()
+	}
+}
+
+func TestIntercept(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "zh-CN")
+	w := httptest.NewRecorder()
+	Intercept(w, r)
+	if w.Header().Get("Content-Language") != "zh-CN" {
+		t.FailNow()
+	}
+
+	scerr.NewError(scerr.ErrInvalidParams, "detail").HttpWrite(w)
+	if w.Code != http.StatusBadRequest || w.Body.String() != "{\"errorCode\":\"400001\",\"errorMessage\":\"无效的参数\",\"detail\":\"detail\"}\n" {
+		t.Fatalf("unexpected body %s", w.Body.String())
+	}
+
+	r.Header.Set("Accept-Language", "en-US,en")
+	w = httptest.NewRecorder()
+	Intercept(w, r)
+	if w.Header().Get("Content-Language") != "" {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package metering
+
+import (
+	"encoding/csv"
+	"fmt"
+	roa "github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const DEFAULT_QUERY_RANGE = 24 * time.Hour
+
+func registerREST() {
+	roa.RegisterServent(&MeteringServiceControllerV4{})
+}
+
+type MeteringServiceControllerV4 struct {
+	//
+}
+
+// URLPatterns 路由
+func (this *MeteringServiceControllerV4) URLPatterns() []roa.Route {
+	return []roa.Route{
+		{roa.HTTP_METHOD_GET, "/v4/:project/metering/usage", this.GetUsage},
+	}
+}
+
+// GetUsage 查询[start, end)内的用量，默认domain可查询所有租户，format=csv时导出csv
+func (this *MeteringServiceControllerV4) GetUsage(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	end := time.Now()
+	if v := query.Get("end"); len(v) > 0 {
+		ts, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			controller.WriteError(w, scerr.ErrInvalidParams, "parameter end must be a unix timestamp")
+			return
+		}
+		end = time.Unix(ts, 0)
+	}
+	start := end.Add(-DEFAULT_QUERY_RANGE)
+	if v := query.Get("start"); len(v) > 0 {
+		ts, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			controller.WriteError(w, scerr.ErrInvalidParams, "parameter start must be a unix timestamp")
+			return
+		}
+		start = time.Unix(ts, 0)
+	}
+	if !start.Before(end) {
+		controller.WriteError(w, scerr.ErrInvalidParams, "parameter start must be less than end")
+		return
+	}
+
+	domain, project := util.ParseDomain(r.Context()), util.ParseProject(r.Context())
+	if domain == core.REGISTRY_DOMAIN {
+		domain, project = query.Get("domain"), query.Get("project")
+	}
+
+	usages, err := GetMeter().Query(r.Context(), start, end, domain, project)
+	if err != nil {
+		util.Logger().Errorf(err, "query usage failed")
+		controller.WriteError(w, scerr.ErrUnavailableBackend, err.Error())
+		return
+	}
+
+	if query.Get("format") != "csv" {
+		controller.WriteJsonObject(w, map[string][]*Usage{"usages": usages})
+		return
+	}
+	w.Header().Add("X-Response-Status", fmt.Sprint(http.StatusOK))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=usage-%d-%d.csv", start.Unix(), end.Unix()))
+	w.WriteHeader(http.StatusOK)
+	WriteCSV(w, usages)
+}
+
+// WriteCSV 导出用量，watch连接时长单位为小时
+func WriteCSV(w io.Writer, usages []*Usage) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"domain", "project", "period", "apiCalls", "services", "instances", "watchConnectionHours"})
+	for _, u := range usages {
+		cw.Write([]string{
+			u.Domain,
+			u.Project,
+			time.Unix(u.Period, 0).UTC().Format(time.RFC3339),
+			strconv.FormatInt(u.ApiCalls, 10),
+			strconv.FormatInt(u.Services, 10),
+			strconv.FormatInt(u.Instances, 10),
+			strconv.FormatFloat(u.WatchConnectionHours(), 'f', 3, 64),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,324 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package metering
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/service/notification"
+	"golang.org/x/net/context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_PERIOD          = time.Hour
+	DEFAULT_SAMPLE_INTERVAL = time.Minute
+	// 持久化时并发冲突的重试次数
+	MAX_SAVE_RETRIES = 3
+
+	USAGE_METRICS_NAME = "usage"
+)
+
+// Usage 租户在一个计量周期内的用量，对象数为周期内的峰值
+type Usage struct {
+	Domain       string `json:"domain"`
+	Project      string `json:"project"`
+	Period       int64  `json:"period"`
+	ApiCalls     int64  `json:"apiCalls"`
+	Services     int64  `json:"services"`
+	Instances    int64  `json:"instances"`
+	WatchSeconds int64  `json:"watchSeconds"`
+}
+
+// WatchConnectionHours watch连接时长(小时)
+func (u *Usage) WatchConnectionHours() float64 {
+	return float64(u.WatchSeconds) / float64(time.Hour/time.Second)
+}
+
+func (u *Usage) Merge(delta *Usage) {
+	u.ApiCalls += delta.ApiCalls
+	u.WatchSeconds += delta.WatchSeconds
+	if delta.Services > u.Services {
+		u.Services = delta.Services
+	}
+	if delta.Instances > u.Instances {
+		u.Instances = delta.Instances
+	}
+}
+
+func (u *Usage) key() string {
+	return apt.GenerateMetricsKey(USAGE_METRICS_NAME, strconv.FormatInt(u.Period, 10),
+		u.Domain+"/"+u.Project)
+}
+
+type usageKey struct {
+	period        int64
+	domainProject string
+}
+
+// Meter 在内存中累计各租户的用量增量，定期合并到etcd，多个实例的增量相加
+type Meter struct {
+	Period         time.Duration
+	SampleInterval time.Duration
+
+	lock       sync.Mutex
+	pending    map[usageKey]*Usage
+	lastSample time.Time
+}
+
+func (m *Meter) periodOf(t time.Time) int64 {
+	p := int64(m.Period / time.Second)
+	ts := t.Unix()
+	return ts - ts%p
+}
+
+func (m *Meter) usage(domainProject string, t time.Time) *Usage {
+	k := usageKey{period: m.periodOf(t), domainProject: domainProject}
+	u, ok := m.pending[k]
+	if !ok {
+		arr := strings.SplitN(domainProject, "/", 2)
+		u = &Usage{Domain: arr[0], Period: k.period}
+		if len(arr) > 1 {
+			u.Project = arr[1]
+		}
+		m.pending[k] = u
+	}
+	return u
+}
+
+// AddApiCall 记录一次API调用
+func (m *Meter) AddApiCall(domainProject string, t time.Time) {
+	if len(domainProject) == 0 || domainProject[0] == '/' {
+		return
+	}
+	m.lock.Lock()
+	m.usage(domainProject, t).ApiCalls++
+	m.lock.Unlock()
+}
+
+// AddWatchTime 记录watch连接时长
+func (m *Meter) AddWatchTime(domainProject string, t time.Time, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	m.lock.Lock()
+	m.usage(domainProject, t).WatchSeconds += int64(d / time.Second)
+	m.lock.Unlock()
+}
+
+// SetObjects 记录租户当前的服务和实例数
+func (m *Meter) SetObjects(domainProject string, t time.Time, services, instances int64) {
+	m.lock.Lock()
+	m.usage(domainProject, t).Merge(&Usage{Services: services, Instances: instances})
+	m.lock.Unlock()
+}
+
+func (m *Meter) take() map[usageKey]*Usage {
+	m.lock.Lock()
+	pending := m.pending
+	m.pending = make(map[usageKey]*Usage)
+	m.lock.Unlock()
+	return pending
+}
+
+func (m *Meter) putBack(k usageKey, u *Usage) {
+	m.lock.Lock()
+	if p, ok := m.pending[k]; ok {
+		p.Merge(u)
+	} else {
+		m.pending[k] = u
+	}
+	m.lock.Unlock()
+}
+
+// Sample 采样当前的watch连接数和各租户的对象数
+func (m *Meter) Sample(ctx context.Context, now time.Time) {
+	m.lock.Lock()
+	last := m.lastSample
+	m.lastSample = now
+	m.lock.Unlock()
+
+	if !last.IsZero() {
+		elapsed := now.Sub(last)
+		prefix := apt.GetInstanceRootKey("")
+		for _, t := range []notification.NotifyType{notification.INSTANCE, notification.INVALIDATION} {
+			for subject, c := range notification.GetNotifyService().SubjectSubscribers(t) {
+				if !strings.HasPrefix(subject, prefix) {
+					continue
+				}
+				domainProject := strings.Trim(subject[len(prefix):], "/")
+				m.AddWatchTime(domainProject, now, time.Duration(c)*elapsed)
+			}
+		}
+	}
+
+	domainProjects, err := listDomainProjects(ctx)
+	if err != nil {
+		util.Logger().Errorf(err, "list projects for metering failed")
+		return
+	}
+	for _, domainProject := range domainProjects {
+		services, err := countObjects(ctx, store.Store().Service(), apt.GetServiceRootKey(domainProject)+"/")
+		if err != nil {
+			util.Logger().Errorf(err, "count services of %s failed", domainProject)
+			continue
+		}
+		instances, err := countObjects(ctx, store.Store().Instance(), apt.GetInstanceRootKey(domainProject)+"/")
+		if err != nil {
+			util.Logger().Errorf(err, "count instances of %s failed", domainProject)
+			continue
+		}
+		m.SetObjects(domainProject, now, services, instances)
+	}
+}
+
+// Flush 将累计的增量合并到etcd，失败的增量留到下次合并
+func (m *Meter) Flush(ctx context.Context) {
+	for k, u := range m.take() {
+		if err := saveUsage(ctx, u); err != nil {
+			util.Logger().Errorf(err, "save usage of %s at %d failed", k.domainProject, k.period)
+			m.putBack(k, u)
+		}
+	}
+}
+
+// Query 查询周期开始时间在[start, end)内的用量，domain、project为空时不过滤
+func (m *Meter) Query(ctx context.Context, start, end time.Time, domain, project string) ([]*Usage, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateMetricsKey(USAGE_METRICS_NAME, strconv.FormatInt(m.periodOf(start), 10), "")),
+		registry.WithStrEndKey(apt.GenerateMetricsKey(USAGE_METRICS_NAME, strconv.FormatInt(end.Unix(), 10), "")),
+		registry.WithAscendOrder())
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]*Usage, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		u := &Usage{}
+		if err := json.Unmarshal(kv.Value, u); err != nil {
+			util.Logger().Errorf(err, "invalid usage %s", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		if u.Period >= end.Unix() ||
+			(len(domain) > 0 && u.Domain != domain) ||
+			(len(project) > 0 && u.Project != project) {
+			continue
+		}
+		usages = append(usages, u)
+	}
+	return usages, nil
+}
+
+func (m *Meter) run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			m.Flush(context.Background())
+			return
+		case <-time.After(m.SampleInterval):
+			ctx := context.Background()
+			m.Sample(ctx, time.Now())
+			m.Flush(ctx)
+		}
+	}
+}
+
+// saveUsage 以CAS方式把u合并到etcd中已有的用量
+func saveUsage(ctx context.Context, u *Usage) error {
+	key := u.key()
+	for i := 0; i < MAX_SAVE_RETRIES; i++ {
+		resp, err := backend.Registry().Do(ctx, registry.GET, registry.WithStrKey(key))
+		if err != nil {
+			return err
+		}
+
+		merged := *u
+		cmp := registry.OpCmp(registry.CmpStrVer(key), registry.CMP_EQUAL, 0)
+		if len(resp.Kvs) > 0 {
+			old := &Usage{}
+			if err := json.Unmarshal(resp.Kvs[0].Value, old); err != nil {
+				return err
+			}
+			old.Merge(u)
+			merged = *old
+			cmp = registry.OpCmp(registry.CmpStrModRev(key), registry.CMP_EQUAL, resp.Kvs[0].ModRevision)
+		}
+
+		data, err := json.Marshal(&merged)
+		if err != nil {
+			return err
+		}
+		txnResp, err := backend.Registry().TxnWithCmp(ctx,
+			[]registry.PluginOp{registry.OpPut(registry.WithStrKey(key), registry.WithValue(data))},
+			[]registry.CompareOp{cmp}, nil)
+		if err != nil {
+			return err
+		}
+		if txnResp.Succeeded {
+			return nil
+		}
+	}
+	return errors.New("usage is modified concurrently")
+}
+
+func listDomainProjects(ctx context.Context) ([]string, error) {
+	prefix := apt.GetProjectRootKey("")
+	resp, err := store.Store().Project().Search(ctx,
+		registry.WithStrKey(prefix),
+		registry.WithPrefix(),
+		registry.WithKeyOnly())
+	if err != nil {
+		return nil, err
+	}
+	domainProjects := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		if len(key) <= len(prefix) || strings.Count(key[len(prefix):], "/") != 1 {
+			continue
+		}
+		domainProjects = append(domainProjects, key[len(prefix):])
+	}
+	return domainProjects, nil
+}
+
+func countObjects(ctx context.Context, indexer *store.Indexer, prefix string) (int64, error) {
+	resp, err := indexer.Search(ctx,
+		registry.WithStrKey(prefix),
+		registry.WithPrefix(),
+		registry.WithCountOnly())
+	if err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
+func NewMeter() *Meter {
+	return &Meter{
+		Period:         DEFAULT_PERIOD,
+		SampleInterval: DEFAULT_SAMPLE_INTERVAL,
+		pending:        make(map[usageKey]*Usage),
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package metering_test
+
+import (
+	"bytes"
+	"github.com/apache/incubator-servicecomb-service-center/server/metering"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/etcd"
+	"golang.org/x/net/context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMeter_Flush(t *testing.T) {
+	ctx := context.TODO()
+	period := time.Unix(1499997600, 0)
+	domainProject := "meter_domain/meter_project"
+
+	m1, m2 := metering.NewMeter(), metering.NewMeter()
+	for i := 0; i < 3; i++ {
+		m1.AddApiCall(domainProject, period)
+	}
+	m1.AddApiCall("/", period)
+	m1.AddWatchTime(domainProject, period, 90*time.Minute)
+	m1.SetObjects(domainProject, period, 2, 5)
+	m1.Flush(ctx)
+
+	m2.AddApiCall(domainProject, period.Add(time.Minute))
+	m2.SetObjects(domainProject, period, 3, 4)
+	m2.Flush(ctx)
+
+	m2.AddApiCall(domainProject, period.Add(time.Hour))
+	m2.Flush(ctx)
+
+	usages, err := m1.Query(ctx, period, period.Add(time.Hour), "meter_domain", "")
+	if err != nil {
+		t.Fatalf("query usage failed, %v", err)
+	}
+	if len(usages) != 1 {
+		t.Fatalf("query usage failed, %v", usages)
+	}
+	u := usages[0]
+	if u.Project != "meter_project" || u.Period != period.Unix() ||
+		u.ApiCalls != 4 || u.Services != 3 || u.Instances != 5 || u.WatchConnectionHours() != 1.5 {
+		t.Fatalf("TestMeter_Flush failed, %v", u)
+	}
+
+	usages, err = m1.Query(ctx, period, period.Add(2*time.Hour), "", "meter_project")
+	if err != nil || len(usages) != 2 || usages[1].ApiCalls != 1 {
+		t.Fatalf("query usage failed, %v %v", err, usages)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	err := metering.WriteCSV(buf, []*metering.Usage{
+		{Domain: "d", Project: "p", Period: 1500000000, ApiCalls: 10, Services: 1, Instances: 2, WatchSeconds: 5400},
+	})
+	if err != nil {
+		t.Fatalf("TestWriteCSV failed, %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[1] != "d,p,2017-07-14T02:40:00Z,10,1,2,1.500" {
+		t.Fatalf("TestWriteCSV failed, %v", lines)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package metering
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/chain"
+	roa "github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/astaxie/beego"
+	"net/http"
+	"time"
+)
+
+var meter *Meter
+
+func init() {
+	meter = NewMeter()
+	if p := beego.AppConfig.DefaultInt64("metering_period", 0); p > 0 {
+		meter.Period = time.Duration(p) * time.Second
+	}
+	if i := beego.AppConfig.DefaultInt64("metering_sample_interval", 0); i > 0 {
+		meter.SampleInterval = time.Duration(i) * time.Second
+	}
+	if !Enabled() {
+		return
+	}
+	registerREST()
+}
+
+// Enabled 默认开启，metering_enabled = false时关闭
+func Enabled() bool {
+	return beego.AppConfig.DefaultBool("metering_enabled", true)
+}
+
+func GetMeter() *Meter {
+	return meter
+}
+
+// MeteringHandler 按租户记录API调用次数，需注册在context handler之后
+type MeteringHandler struct {
+}
+
+func (h *MeteringHandler) Handle(i *chain.Invocation) {
+	r := i.Context().Value(roa.CTX_REQUEST).(*http.Request)
+	meter.AddApiCall(util.ParseDomainProject(r.Context()), time.Now())
+	i.Next()
+}
+
+func RegisterHandlers() {
+	if !Enabled() {
+		return
+	}
+	chain.RegisterNamedHandler(roa.SERVER_CHAIN_NAME, "metering", &MeteringHandler{})
+}
+
+func Run() {
+	if !Enabled() {
+		return
+	}
+	util.Go(meter.run)
+}
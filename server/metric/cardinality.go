@@ -0,0 +1,280 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package metric
+
+import (
+	"github.com/astaxie/beego"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	DEFAULT_TENANT_LABEL = "domain"
+	// 排在前N之外的租户合并后的label值
+	OTHER_TENANT = "other"
+)
+
+var (
+	limiter = &CardinalityLimiter{families: make(map[string]bool)}
+
+	// Gatherer 按租户数限制聚合后的指标，/metrics和推送都使用
+	Gatherer prometheus.Gatherer = prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		mfs, err := prometheus.DefaultGatherer.Gather()
+		if err != nil {
+			return nil, err
+		}
+		return limiter.Limit(mfs), nil
+	})
+)
+
+func initCardinalityLimiter() {
+	limiter.TenantLimit = beego.AppConfig.DefaultInt("metrics_tenant_limit", 0)
+	limiter.TenantLabel = beego.AppConfig.DefaultString("metrics_tenant_label", DEFAULT_TENANT_LABEL)
+	for _, name := range strings.Split(beego.AppConfig.String("metrics_tenant_unlimited_families"), ",") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			limiter.families[name] = false
+		}
+	}
+}
+
+// FamilyCardinality 带租户label的指标族的当前基数
+type FamilyCardinality struct {
+	Name string `json:"name"`
+	// 聚合前的租户数和时间序列数
+	Tenants int `json:"tenants"`
+	Series  int `json:"series"`
+	// 是否开启聚合，关闭时保留所有租户
+	Enabled bool `json:"enabled"`
+	// 当前是否因租户数超过上限而聚合
+	Aggregated bool `json:"aggregated"`
+}
+
+type CardinalityStatus struct {
+	TenantLimit int                  `json:"tenantLimit"`
+	TenantLabel string               `json:"tenantLabel"`
+	Families    []*FamilyCardinality `json:"families"`
+}
+
+// CardinalityLimiter 指标族的租户数超过TenantLimit时，只保留数值最大的前TenantLimit个租户，
+// 其余租户的时间序列合并到other，TenantLimit<=0时不限制
+type CardinalityLimiter struct {
+	TenantLimit int
+	TenantLabel string
+
+	lock sync.RWMutex
+	// 单独设置了开关的指标族，未设置的默认开启
+	families map[string]bool
+}
+
+func (l *CardinalityLimiter) Enabled(family string) bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	enabled, ok := l.families[family]
+	return !ok || enabled
+}
+
+func (l *CardinalityLimiter) SetEnabled(family string, enabled bool) {
+	l.lock.Lock()
+	l.families[family] = enabled
+	l.lock.Unlock()
+}
+
+func (l *CardinalityLimiter) tenantIndex(m *dto.Metric) int {
+	for i, pair := range m.GetLabel() {
+		if pair.GetName() == l.TenantLabel {
+			return i
+		}
+	}
+	return -1
+}
+
+// tenants 返回指标族中的租户及其数值，没有租户label时返回nil
+func (l *CardinalityLimiter) tenants(mf *dto.MetricFamily) map[string]float64 {
+	var tenants map[string]float64
+	for _, m := range mf.GetMetric() {
+		i := l.tenantIndex(m)
+		if i < 0 {
+			continue
+		}
+		if tenants == nil {
+			tenants = make(map[string]float64)
+		}
+		tenants[m.GetLabel()[i].GetValue()] += metricValue(mf.GetType(), m)
+	}
+	return tenants
+}
+
+func (l *CardinalityLimiter) Limit(mfs []*dto.MetricFamily) []*dto.MetricFamily {
+	if l.TenantLimit <= 0 {
+		return mfs
+	}
+	for i, mf := range mfs {
+		if !l.Enabled(mf.GetName()) {
+			continue
+		}
+		tenants := l.tenants(mf)
+		if len(tenants) <= l.TenantLimit {
+			continue
+		}
+		mfs[i] = l.aggregate(mf, topTenants(tenants, l.TenantLimit))
+	}
+	return mfs
+}
+
+// Status 返回所有带租户label的指标族的基数
+func (l *CardinalityLimiter) Status(mfs []*dto.MetricFamily) *CardinalityStatus {
+	status := &CardinalityStatus{
+		TenantLimit: l.TenantLimit,
+		TenantLabel: l.TenantLabel,
+		Families:    []*FamilyCardinality{},
+	}
+	for _, mf := range mfs {
+		tenants := l.tenants(mf)
+		if tenants == nil {
+			continue
+		}
+		enabled := l.Enabled(mf.GetName())
+		status.Families = append(status.Families, &FamilyCardinality{
+			Name:       mf.GetName(),
+			Tenants:    len(tenants),
+			Series:     len(mf.GetMetric()),
+			Enabled:    enabled,
+			Aggregated: enabled && l.TenantLimit > 0 && len(tenants) > l.TenantLimit,
+		})
+	}
+	return status
+}
+
+// metricValue 租户排序使用的数值，summary和histogram使用采样数
+func metricValue(t dto.MetricType, m *dto.Metric) float64 {
+	switch t {
+	case dto.MetricType_COUNTER:
+		return m.GetCounter().GetValue()
+	case dto.MetricType_GAUGE:
+		return m.GetGauge().GetValue()
+	case dto.MetricType_SUMMARY:
+		return float64(m.GetSummary().GetSampleCount())
+	case dto.MetricType_HISTOGRAM:
+		return float64(m.GetHistogram().GetSampleCount())
+	default:
+		return m.GetUntyped().GetValue()
+	}
+}
+
+func topTenants(tenants map[string]float64, n int) map[string]struct{} {
+	names := make([]string, 0, len(tenants))
+	for name := range tenants {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if tenants[names[i]] != tenants[names[j]] {
+			return tenants[names[i]] > tenants[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	top := make(map[string]struct{}, n)
+	for _, name := range names[:n] {
+		top[name] = struct{}{}
+	}
+	return top
+}
+
+func labelsKey(pairs []*dto.LabelPair) string {
+	values := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		values = append(values, pair.GetName()+"="+pair.GetValue())
+	}
+	return strings.Join(values, "\xff")
+}
+
+// aggregate 前N之外租户的时间序列按其余label合并，summary合并后不再有分位数
+func (l *CardinalityLimiter) aggregate(mf *dto.MetricFamily, top map[string]struct{}) *dto.MetricFamily {
+	result := &dto.MetricFamily{Name: mf.Name, Help: mf.Help, Type: mf.Type}
+	merged := make(map[string]*dto.Metric)
+	for _, m := range mf.GetMetric() {
+		i := l.tenantIndex(m)
+		if i < 0 {
+			result.Metric = append(result.Metric, m)
+			continue
+		}
+		if _, ok := top[m.GetLabel()[i].GetValue()]; ok {
+			result.Metric = append(result.Metric, m)
+			continue
+		}
+		labels := make([]*dto.LabelPair, len(m.GetLabel()))
+		copy(labels, m.GetLabel())
+		labels[i] = &dto.LabelPair{Name: proto.String(l.TenantLabel), Value: proto.String(OTHER_TENANT)}
+		key := labelsKey(labels)
+		other, ok := merged[key]
+		if !ok {
+			other = &dto.Metric{Label: labels}
+			merged[key] = other
+			result.Metric = append(result.Metric, other)
+		}
+		mergeMetric(mf.GetType(), other, m)
+	}
+	return result
+}
+
+func mergeMetric(t dto.MetricType, dst, src *dto.Metric) {
+	switch t {
+	case dto.MetricType_COUNTER:
+		dst.Counter = &dto.Counter{Value: proto.Float64(dst.GetCounter().GetValue() + src.GetCounter().GetValue())}
+	case dto.MetricType_GAUGE:
+		dst.Gauge = &dto.Gauge{Value: proto.Float64(dst.GetGauge().GetValue() + src.GetGauge().GetValue())}
+	case dto.MetricType_SUMMARY:
+		dst.Summary = &dto.Summary{
+			SampleCount: proto.Uint64(dst.GetSummary().GetSampleCount() + src.GetSummary().GetSampleCount()),
+			SampleSum:   proto.Float64(dst.GetSummary().GetSampleSum() + src.GetSummary().GetSampleSum()),
+		}
+	case dto.MetricType_HISTOGRAM:
+		// 同一指标族的桶边界相同
+		h := &dto.Histogram{
+			SampleCount: proto.Uint64(dst.GetHistogram().GetSampleCount() + src.GetHistogram().GetSampleCount()),
+			SampleSum:   proto.Float64(dst.GetHistogram().GetSampleSum() + src.GetHistogram().GetSampleSum()),
+		}
+		buckets := dst.GetHistogram().GetBucket()
+		for j, b := range src.GetHistogram().GetBucket() {
+			count := b.GetCumulativeCount()
+			if j < len(buckets) {
+				count += buckets[j].GetCumulativeCount()
+			}
+			h.Bucket = append(h.Bucket, &dto.Bucket{UpperBound: b.UpperBound, CumulativeCount: proto.Uint64(count)})
+		}
+		dst.Histogram = h
+	default:
+		dst.Untyped = &dto.Untyped{Value: proto.Float64(dst.GetUntyped().GetValue() + src.GetUntyped().GetValue())}
+	}
+}
+
+// GetCardinality 查询带租户label的指标族的基数和聚合开关
+func GetCardinality() (*CardinalityStatus, error) {
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+	return limiter.Status(mfs), nil
+}
+
+// SetFamilyLimited 运行时开关指标族的租户聚合，只对本节点生效，重启后恢复配置
+func SetFamilyLimited(family string, enabled bool) {
+	limiter.SetEnabled(family, enabled)
+}
@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package metric
+
+import (
+	"github.com/golang/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+	"testing"
+)
+
+func tenantGauges(name string, values map[string]float64) *dto.MetricFamily {
+	mf := &dto.MetricFamily{Name: proto.String(name), Type: dto.MetricType_GAUGE.Enum()}
+	for domain, v := range values {
+		mf.Metric = append(mf.Metric, &dto.Metric{
+			Label: []*dto.LabelPair{
+				{Name: proto.String("domain"), Value: proto.String(domain)},
+				{Name: proto.String("result"), Value: proto.String("ok")},
+			},
+			Gauge: &dto.Gauge{Value: proto.Float64(v)},
+		})
+	}
+	return mf
+}
+
+func TestCardinalityLimiter(t *testing.T) {
+	l := &CardinalityLimiter{TenantLimit: 2, TenantLabel: "domain", families: make(map[string]bool)}
+	values := map[string]float64{"a": 10, "b": 5, "c": 1, "d": 2}
+
+	mfs := l.Limit([]*dto.MetricFamily{tenantGauges("x", values), tenantGauges("y", map[string]float64{"a": 1})})
+	if len(mfs[0].Metric) != 3 || len(mfs[1].Metric) != 1 {
+		t.Fatalf("TestCardinalityLimiter failed, %v", mfs)
+	}
+	got := make(map[string]float64)
+	for _, m := range mfs[0].Metric {
+		got[m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+		if m.GetLabel()[1].GetValue() != "ok" {
+			t.Fatalf("TestCardinalityLimiter failed, %v", m)
+		}
+	}
+	if got["a"] != 10 || got["b"] != 5 || got[OTHER_TENANT] != 3 {
+		t.Fatalf("TestCardinalityLimiter failed, %v", got)
+	}
+
+	l.SetEnabled("x", false)
+	mfs = l.Limit([]*dto.MetricFamily{tenantGauges("x", values)})
+	if len(mfs[0].Metric) != 4 {
+		t.Fatalf("TestCardinalityLimiter disabled failed, %v", mfs)
+	}
+	status := l.Status([]*dto.MetricFamily{tenantGauges("x", values), tenantGauges("z", values)})
+	if len(status.Families) != 2 || status.Families[0].Aggregated || !status.Families[1].Aggregated ||
+		status.Families[1].Tenants != 4 {
+		t.Fatalf("TestCardinalityLimiter status failed, %v", status.Families)
+	}
+}
+
+func TestMergeHistogram(t *testing.T) {
+	h := func(count uint64) *dto.Metric {
+		return &dto.Metric{Histogram: &dto.Histogram{
+			SampleCount: proto.Uint64(count),
+			SampleSum:   proto.Float64(float64(count)),
+			Bucket:      []*dto.Bucket{{UpperBound: proto.Float64(1), CumulativeCount: proto.Uint64(count)}},
+		}}
+	}
+	dst := &dto.Metric{}
+	mergeMetric(dto.MetricType_HISTOGRAM, dst, h(1))
+	mergeMetric(dto.MetricType_HISTOGRAM, dst, h(2))
+	if dst.GetHistogram().GetSampleCount() != 3 || dst.GetHistogram().GetBucket()[0].GetCumulativeCount() != 3 {
+		t.Fatalf("TestMergeHistogram failed, %v", dst)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package metric
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MAX_TRACE_ID_LENGTH OpenMetrics限制exemplar的label总长度为128
+const MAX_TRACE_ID_LENGTH = 64
+
+var (
+	exemplarEnabled bool
+	exemplars       = make(map[string]*Exemplar)
+	exemplarsLock   sync.RWMutex
+)
+
+// Exemplar 时间序列最近一次采样关联的trace
+type Exemplar struct {
+	TraceId   string
+	Value     float64
+	Timestamp time.Time
+}
+
+func ExemplarEnabled() bool {
+	return exemplarEnabled
+}
+
+// TraceIdOf 从zipkin(X-B3-TraceId)或W3C(traceparent)头中取trace id
+func TraceIdOf(r *http.Request) string {
+	traceId := r.Header.Get("X-B3-TraceId")
+	if len(traceId) == 0 {
+		// version-traceid-parentid-flags
+		if parts := strings.Split(r.Header.Get("traceparent"), "-"); len(parts) == 4 {
+			traceId = parts[1]
+		}
+	}
+	if len(traceId) > MAX_TRACE_ID_LENGTH {
+		return ""
+	}
+	return traceId
+}
+
+// ObserveExemplar 记录时间序列最近一次采样的trace id，名称和labels需与metric一致
+func ObserveExemplar(name string, labels prometheus.Labels, traceId string, value float64) {
+	if !exemplarEnabled || len(traceId) == 0 {
+		return
+	}
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	pairs := make([]string, 0, len(names))
+	for _, k := range names {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+	key := seriesKey(name, pairs)
+
+	exemplarsLock.Lock()
+	exemplars[key] = &Exemplar{TraceId: traceId, Value: value, Timestamp: time.Now()}
+	exemplarsLock.Unlock()
+}
+
+// lookupExemplar 采集的label已按名称排序
+func lookupExemplar(name string, labels []*dto.LabelPair) *Exemplar {
+	if !exemplarEnabled {
+		return nil
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, l := range labels {
+		pairs = append(pairs, l.GetName()+"="+l.GetValue())
+	}
+	key := seriesKey(name, pairs)
+
+	exemplarsLock.RLock()
+	e := exemplars[key]
+	exemplarsLock.RUnlock()
+	return e
+}
+
+func seriesKey(name string, pairs []string) string {
+	return name + "\xff" + strings.Join(pairs, "\xff")
+}
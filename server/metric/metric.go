@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package metric
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/metrics"
+	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/astaxie/beego"
+	dto "github.com/prometheus/client_model/go"
+	"time"
+)
+
+const DEFAULT_PUSH_INTERVAL = 30 * time.Second
+
+var pusher *Pusher
+
+func init() {
+	exemplarEnabled = beego.AppConfig.DefaultBool("metrics_exemplar_enabled", false)
+	initCardinalityLimiter()
+
+	pusher = &Pusher{
+		Interval: DEFAULT_PUSH_INTERVAL,
+		Export: func(samples []*metrics.Sample) error {
+			return plugin.Plugins().Metrics().Export(samples)
+		},
+	}
+	v := beego.AppConfig.DefaultString("metrics_push_interval", DEFAULT_PUSH_INTERVAL.String())
+	if d, err := time.ParseDuration(v); err == nil && d > 0 {
+		pusher.Interval = d
+	} else {
+		util.Logger().Errorf(err, "invalid metrics_push_interval '%s', use %s", v, DEFAULT_PUSH_INTERVAL)
+	}
+}
+
+// PushEnabled 配置了metrics_plugin时才推送
+func PushEnabled() bool {
+	return len(beego.AppConfig.String("metrics_plugin")) > 0
+}
+
+func Run() {
+	if !PushEnabled() {
+		return
+	}
+	util.Logger().Infof("push metrics every %s, plugin '%s'", pusher.Interval, beego.AppConfig.String("metrics_plugin"))
+	util.Go(pusher.run)
+}
+
+// Pusher 周期采集所有metrics，推送到不采集prometheus接口的监控系统
+type Pusher struct {
+	Interval time.Duration
+	Export   func(samples []*metrics.Sample) error
+}
+
+func (p *Pusher) run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			if err := p.Push(); err != nil {
+				util.Logger().Errorf(err, "push metrics failed")
+			}
+		}
+	}
+}
+
+func (p *Pusher) Push() error {
+	mfs, err := Gatherer.Gather()
+	if err != nil {
+		return err
+	}
+	return p.Export(Flatten(mfs))
+}
+
+// Flatten 展开为单值的时间序列，summary和histogram展开为分位数/桶、_sum和_count
+func Flatten(mfs []*dto.MetricFamily) []*metrics.Sample {
+	var samples []*metrics.Sample
+	add := func(name string, pairs []*dto.LabelPair, value float64, extra ...metrics.Label) {
+		labels := make([]metrics.Label, 0, len(pairs)+len(extra))
+		for _, l := range pairs {
+			labels = append(labels, metrics.Label{Name: l.GetName(), Value: l.GetValue()})
+		}
+		samples = append(samples, &metrics.Sample{Name: name, Labels: append(labels, extra...), Value: value})
+	}
+	for _, mf := range mfs {
+		name := mf.GetName()
+		for _, m := range mf.GetMetric() {
+			labels := m.GetLabel()
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, labels, m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, labels, m.GetGauge().GetValue())
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					add(name, labels, q.GetValue(), metrics.Label{Name: "quantile", Value: formatFloat(q.GetQuantile())})
+				}
+				add(name+"_sum", labels, s.GetSampleSum())
+				add(name+"_count", labels, float64(s.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				for _, b := range h.GetBucket() {
+					add(name+"_bucket", labels, float64(b.GetCumulativeCount()), metrics.Label{Name: "le", Value: formatFloat(b.GetUpperBound())})
+				}
+				add(name+"_sum", labels, h.GetSampleSum())
+				add(name+"_count", labels, float64(h.GetSampleCount()))
+			default:
+				add(name, labels, m.GetUntyped().GetValue())
+			}
+		}
+	}
+	return samples
+}
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package metric
+
+import (
+	"bytes"
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func testMetricFamilies() []*dto.MetricFamily {
+	labels := []*dto.LabelPair{
+		{Name: proto.String("api"), Value: proto.String("/v4/:project/registry/instances")},
+		{Name: proto.String("method"), Value: proto.String("GET")},
+	}
+	return []*dto.MetricFamily{
+		{
+			Name: proto.String("service_center_http_request_total"),
+			Help: proto.String("Counter of requests"),
+			Type: dto.MetricType_COUNTER.Enum(),
+			Metric: []*dto.Metric{
+				{Label: labels, Counter: &dto.Counter{Value: proto.Float64(3)}},
+			},
+		},
+		{
+			Name: proto.String("service_center_http_request_durations_microseconds"),
+			Type: dto.MetricType_SUMMARY.Enum(),
+			Metric: []*dto.Metric{
+				{Label: labels, Summary: &dto.Summary{
+					SampleCount: proto.Uint64(3),
+					SampleSum:   proto.Float64(30),
+					Quantile:    []*dto.Quantile{{Quantile: proto.Float64(0.5), Value: proto.Float64(10)}},
+				}},
+			},
+		},
+	}
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	exemplarEnabled = true
+	defer func() { exemplarEnabled = false }()
+
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	traceId := TraceIdOf(r)
+	if traceId != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("TraceIdOf failed, %s", traceId)
+	}
+	ObserveExemplar("service_center_http_request_total", prometheus.Labels{
+		"method": "GET", "api": "/v4/:project/registry/instances",
+	}, traceId, 1)
+
+	buf := bytes.NewBuffer(nil)
+	if err := WriteOpenMetrics(buf, testMetricFamilies()); err != nil {
+		t.Fatalf("WriteOpenMetrics failed, %s", err)
+	}
+	out := buf.String()
+	for _, expect := range []string{
+		"# TYPE service_center_http_request counter\n",
+		`service_center_http_request_total{api="/v4/:project/registry/instances",method="GET"} 3 # {trace_id="4bf92f3577b34da6a3ce929d0e0e4736"} 1 `,
+		`service_center_http_request_durations_microseconds{api="/v4/:project/registry/instances",method="GET",quantile="0.5"} 10` + "\n",
+		"service_center_http_request_durations_microseconds_count{",
+	} {
+		if !strings.Contains(out, expect) {
+			t.Fatalf("WriteOpenMetrics failed, '%s' not found in\n%s", expect, out)
+		}
+	}
+	if !strings.HasSuffix(out, "# EOF\n") {
+		t.Fatalf("WriteOpenMetrics failed, no EOF")
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	samples := Flatten(testMetricFamilies())
+	if len(samples) != 4 {
+		t.Fatalf("Flatten failed, %d samples", len(samples))
+	}
+	path := samples[1].Path("sc")
+	if path != "sc.service_center_http_request_durations_microseconds.api._v4__project_registry_instances.method.GET.quantile.0_5" {
+		t.Fatalf("Path failed, %s", path)
+	}
+	if samples[3].Name != "service_center_http_request_durations_microseconds_count" || samples[3].Value != 3 {
+		t.Fatalf("Flatten failed, %v", samples[3])
+	}
+}
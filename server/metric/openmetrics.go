@@ -0,0 +1,159 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package metric
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	OPENMETRICS_MEDIA_TYPE   = "application/openmetrics-text"
+	OPENMETRICS_CONTENT_TYPE = OPENMETRICS_MEDIA_TYPE + "; version=1.0.0; charset=utf-8"
+)
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// Handler 默认输出prometheus text格式，Accept协商为OpenMetrics时输出OpenMetrics格式
+func Handler() http.Handler {
+	h := promhttp.HandlerFor(Gatherer, promhttp.HandlerOpts{})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept"), OPENMETRICS_MEDIA_TYPE) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		mfs, err := Gatherer.Gather()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", OPENMETRICS_CONTENT_TYPE)
+		WriteOpenMetrics(w, mfs)
+	})
+}
+
+// WriteOpenMetrics 按OpenMetrics 1.0格式输出，counter附带最近一次请求的exemplar
+func WriteOpenMetrics(out io.Writer, mfs []*dto.MetricFamily) error {
+	w := bufio.NewWriter(out)
+	for _, mf := range mfs {
+		name := mf.GetName()
+		family := name
+		if mf.GetType() == dto.MetricType_COUNTER {
+			family = strings.TrimSuffix(name, "_total")
+		}
+		fmt.Fprintf(w, "# TYPE %s %s\n", family, openMetricsType(mf.GetType()))
+		if help := mf.GetHelp(); len(help) > 0 {
+			fmt.Fprintf(w, "# HELP %s %s\n", family, labelEscaper.Replace(help))
+		}
+		for _, m := range mf.GetMetric() {
+			labels := m.GetLabel()
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				writeSample(w, family+"_total", labels, nil, m.GetCounter().GetValue(), lookupExemplar(name, labels))
+			case dto.MetricType_GAUGE:
+				writeSample(w, name, labels, nil, m.GetGauge().GetValue(), nil)
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					writeSample(w, name, labels, []string{"quantile", formatFloat(q.GetQuantile())}, q.GetValue(), nil)
+				}
+				writeSample(w, name+"_sum", labels, nil, s.GetSampleSum(), nil)
+				writeSample(w, name+"_count", labels, nil, float64(s.GetSampleCount()), nil)
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				inf := false
+				for _, b := range h.GetBucket() {
+					inf = inf || math.IsInf(b.GetUpperBound(), 1)
+					writeSample(w, name+"_bucket", labels, []string{"le", formatFloat(b.GetUpperBound())},
+						float64(b.GetCumulativeCount()), nil)
+				}
+				// OpenMetrics要求必须有+Inf桶
+				if !inf {
+					writeSample(w, name+"_bucket", labels, []string{"le", "+Inf"}, float64(h.GetSampleCount()), nil)
+				}
+				writeSample(w, name+"_sum", labels, nil, h.GetSampleSum(), nil)
+				writeSample(w, name+"_count", labels, nil, float64(h.GetSampleCount()), nil)
+			default:
+				writeSample(w, name, labels, nil, m.GetUntyped().GetValue(), nil)
+			}
+		}
+	}
+	w.WriteString("# EOF\n")
+	return w.Flush()
+}
+
+func openMetricsType(t dto.MetricType) string {
+	switch t {
+	case dto.MetricType_COUNTER:
+		return "counter"
+	case dto.MetricType_GAUGE:
+		return "gauge"
+	case dto.MetricType_SUMMARY:
+		return "summary"
+	case dto.MetricType_HISTOGRAM:
+		return "histogram"
+	default:
+		return "unknown"
+	}
+}
+
+func writeSample(w *bufio.Writer, name string, labels []*dto.LabelPair, extra []string, value float64, e *Exemplar) {
+	w.WriteString(name)
+	if len(labels) > 0 || len(extra) > 0 {
+		w.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, `%s="%s"`, l.GetName(), labelEscaper.Replace(l.GetValue()))
+		}
+		if len(extra) > 0 {
+			if len(labels) > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, `%s="%s"`, extra[0], extra[1])
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(value))
+	if e != nil {
+		fmt.Fprintf(w, ` # {trace_id="%s"} %s %s`, labelEscaper.Replace(e.TraceId), formatFloat(e.Value),
+			strconv.FormatFloat(float64(e.Timestamp.UnixNano())/1e9, 'f', 3, 64))
+	}
+	w.WriteByte('\n')
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package migration
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+)
+
+// 内置的迁移，新增时版本号递增，已发布的迁移不能修改
+func init() {
+	Register(&Migration{
+		Version:     1,
+		Description: "backfill the serviceName index of the services registered before it was introduced",
+		Func:        backfillServiceNameIndex,
+	})
+}
+
+func backfillServiceNameIndex(ctx context.Context, dryRun bool) (int, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GetServiceNameIndexRootKey("")),
+		registry.WithPrefix(),
+		registry.WithKeyOnly())
+	if err != nil {
+		return 0, err
+	}
+	indexes := make(map[string]struct{}, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		indexes[util.BytesToStringWithNoCopy(kv.Key)] = struct{}{}
+	}
+
+	resp, err = backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GetServiceRootKey("")),
+		registry.WithPrefix())
+	if err != nil {
+		return 0, err
+	}
+	var opts []registry.PluginOp
+	for _, kv := range resp.Kvs {
+		serviceId, domainProject, data := pb.GetInfoFromSvcKV(kv)
+		service := &pb.MicroService{}
+		if err := json.Unmarshal(data, service); err != nil {
+			util.Logger().Errorf(err, "invalid service %s, skip it", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		key := apt.GenerateServiceNameIndexKey(domainProject, service.ServiceName, serviceId)
+		if _, ok := indexes[key]; ok {
+			continue
+		}
+		opts = append(opts, registry.OpPut(registry.WithStrKey(key), registry.WithStrValue(serviceId)))
+	}
+	if dryRun || len(opts) == 0 {
+		return len(opts), nil
+	}
+	return len(opts), backend.BatchCommit(ctx, opts)
+}
@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package migration
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"golang.org/x/net/context"
+)
+
+var defaultMigrator = NewMigrator()
+
+func GetMigrator() *Migrator {
+	return defaultMigrator
+}
+
+// Register 注册到默认迁移器，需在启动迁移前调用
+func Register(migration *Migration) {
+	if err := defaultMigrator.Register(migration); err != nil {
+		util.Logger().Errorf(err, "register migration %d failed", migration.Version)
+	}
+}
+
+func Run(ctx context.Context, dryRun bool) (*Result, error) {
+	return defaultMigrator.Run(ctx, dryRun)
+}
+
+func GetStatus(ctx context.Context) (*Status, error) {
+	return defaultMigrator.Status(ctx)
+}
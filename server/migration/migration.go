@@ -0,0 +1,227 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package migration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Migration 一次数据布局的变更，Version从1开始递增且不能重复；
+// Func需可重复执行，dryRun时不写入，只返回将要变更的key数
+type Migration struct {
+	Version     int
+	Description string
+	Func        func(ctx context.Context, dryRun bool) (changed int, err error)
+}
+
+// Record 迁移的执行记录，未执行的迁移只有版本和描述
+type Record struct {
+	Version     int    `json:"version"`
+	Description string `json:"description"`
+	Changed     int    `json:"changed"`
+	Timestamp   string `json:"timestamp,omitempty"`
+	Duration    string `json:"duration,omitempty"`
+}
+
+type Result struct {
+	DryRun  bool      `json:"dryRun"`
+	From    int       `json:"from"`
+	To      int       `json:"to"`
+	Applied []*Record `json:"applied"`
+	Error   string    `json:"error,omitempty"`
+}
+
+type Status struct {
+	Current int       `json:"current"`
+	Latest  int       `json:"latest"`
+	Pending []*Record `json:"pending"`
+	Records []*Record `json:"records"`
+}
+
+type Migrator struct {
+	lock       sync.RWMutex
+	migrations []*Migration
+}
+
+func (m *Migrator) Register(migration *Migration) error {
+	if migration == nil || migration.Func == nil {
+		return errors.New("invalid migration")
+	}
+	if migration.Version <= 0 {
+		return fmt.Errorf("invalid migration version %d", migration.Version)
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, exist := range m.migrations {
+		if exist.Version == migration.Version {
+			return fmt.Errorf("migration version %d is already registered", migration.Version)
+		}
+	}
+	m.migrations = append(m.migrations, migration)
+	sort.Slice(m.migrations, func(i, j int) bool {
+		return m.migrations[i].Version < m.migrations[j].Version
+	})
+	return nil
+}
+
+func (m *Migrator) Migrations() []*Migration {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return append([]*Migration{}, m.migrations...)
+}
+
+// Latest 当前版本的程序所需的数据布局版本
+func (m *Migrator) Latest() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Run 按版本顺序执行未完成的迁移，每完成一个即在同一事务中写入记录和数据版本，
+// 失败时停止，下次从失败的版本继续；调用方需持有全局锁，迁移内不能再获取全局锁
+func (m *Migrator) Run(ctx context.Context, dryRun bool) (*Result, error) {
+	current, err := CurrentVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{DryRun: dryRun, From: current, To: current, Applied: []*Record{}}
+	if latest := m.Latest(); current > latest {
+		util.Logger().Warnf(nil, "data version %d is newer than %d required by this release, skip migrations",
+			current, latest)
+		return result, nil
+	}
+
+	for _, migration := range m.Migrations() {
+		if migration.Version <= current {
+			continue
+		}
+		start := time.Now()
+		changed, err := migration.Func(ctx, dryRun)
+		if err != nil {
+			result.Error = fmt.Sprintf("migration %d failed, %s", migration.Version, err.Error())
+			return result, errors.New(result.Error)
+		}
+		record := &Record{
+			Version:     migration.Version,
+			Description: migration.Description,
+			Changed:     changed,
+			Timestamp:   strconv.FormatInt(start.Unix(), 10),
+			Duration:    time.Since(start).String(),
+		}
+		if !dryRun {
+			if err := commit(ctx, record); err != nil {
+				result.Error = fmt.Sprintf("commit migration %d failed, %s", migration.Version, err.Error())
+				return result, errors.New(result.Error)
+			}
+			util.Logger().Infof("migrate data to version %d successfully, %d key(s) changed, %s: %s",
+				record.Version, record.Changed, record.Duration, record.Description)
+		}
+		result.Applied = append(result.Applied, record)
+		result.To = migration.Version
+	}
+	return result, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	current, err := CurrentVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records, err := GetRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := &Status{Current: current, Latest: m.Latest(), Pending: []*Record{}, Records: records}
+	for _, migration := range m.Migrations() {
+		if migration.Version <= current {
+			continue
+		}
+		status.Pending = append(status.Pending, &Record{
+			Version:     migration.Version,
+			Description: migration.Description,
+		})
+	}
+	return status, nil
+}
+
+func commit(ctx context.Context, record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	version := strconv.Itoa(record.Version)
+	_, err = backend.Registry().Txn(ctx, []registry.PluginOp{
+		registry.OpPut(registry.WithStrKey(apt.GenerateMigrationRecordKey(version)), registry.WithValue(data)),
+		registry.OpPut(registry.WithStrKey(apt.GenerateDataVersionKey()), registry.WithStrValue(version)),
+	})
+	return err
+}
+
+// CurrentVersion 已完成迁移的数据布局版本，未记录时为0
+func CurrentVersion(ctx context.Context) (int, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateDataVersionKey()))
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, nil
+	}
+	return strconv.Atoi(util.BytesToStringWithNoCopy(resp.Kvs[0].Value))
+}
+
+func GetRecords(ctx context.Context) ([]*Record, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateMigrationRecordKey("")),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*Record, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		record := &Record{}
+		if err := json.Unmarshal(kv.Value, record); err != nil {
+			util.Logger().Errorf(err, "invalid migration record %s, skip it", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Version < records[j].Version
+	})
+	return records, nil
+}
+
+func NewMigrator() *Migrator {
+	return &Migrator{}
+}
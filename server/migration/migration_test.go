@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package migration
+
+import (
+	"golang.org/x/net/context"
+	"testing"
+)
+
+func TestMigratorRegister(t *testing.T) {
+	m := NewMigrator()
+	noop := func(ctx context.Context, dryRun bool) (int, error) { return 0, nil }
+	if m.Latest() != 0 {
+		t.Fatalf("latest version should be 0 without migrations")
+	}
+	if m.Register(nil) == nil {
+		t.Fatalf("register nil migration should fail")
+	}
+	if m.Register(&Migration{Version: 1}) == nil {
+		t.Fatalf("register migration without func should fail")
+	}
+	if m.Register(&Migration{Version: 0, Func: noop}) == nil {
+		t.Fatalf("register migration with invalid version should fail")
+	}
+	if err := m.Register(&Migration{Version: 3, Func: noop}); err != nil {
+		t.Fatalf("register migration failed, %v", err)
+	}
+	if err := m.Register(&Migration{Version: 1, Func: noop}); err != nil {
+		t.Fatalf("register migration failed, %v", err)
+	}
+	if m.Register(&Migration{Version: 3, Func: noop}) == nil {
+		t.Fatalf("register duplicate version should fail")
+	}
+	l := m.Migrations()
+	if len(l) != 2 || l[0].Version != 1 || l[1].Version != 3 || m.Latest() != 3 {
+		t.Fatalf("unexpected migrations %v", l)
+	}
+}
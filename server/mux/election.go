@@ -0,0 +1,244 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mux
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// 后台单例任务的选举名
+	JOB_LEADER = "jobs"
+	// 未配置leader_election_ttl时leader租约的有效期，单位秒
+	DEFAULT_LEADER_TTL = 30
+)
+
+var (
+	leaderGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "leader",
+			Name:      "is_leader",
+			Help:      "Whether the node is the leader of the election",
+		}, []string{"name"})
+
+	leaderChanges = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "leader",
+			Name:      "changes_total",
+			Help:      "Counter of the node becoming or stepping down from the leader",
+		}, []string{"name", "action"})
+
+	// 本节点参与的选举，key为选举名
+	elections = &electionRegistry{items: make(map[string]*Election)}
+)
+
+func init() {
+	prometheus.MustRegister(leaderGauge, leaderChanges)
+}
+
+type electionRegistry struct {
+	lock  sync.RWMutex
+	items map[string]*Election
+}
+
+func (r *electionRegistry) Get(name string) (*Election, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	e, ok := r.items[name]
+	return e, ok
+}
+
+func (r *electionRegistry) Set(e *Election) {
+	r.lock.Lock()
+	r.items[e.Name] = e
+	r.lock.Unlock()
+}
+
+// Election 基于租约的leader选举，leader每ttl/3续约一次，异常退出时租约过期，
+// 其他节点在ttl+ttl/3内接替；续约失败时立即放弃leader，宁可短暂无leader也不双主
+type Election struct {
+	Name string
+	TTL  int64
+
+	id      string
+	lock    sync.RWMutex
+	leaseID int64
+	since   time.Time
+}
+
+func holderId() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "UNKNOWN"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// NewElection ttl<=0时使用默认值，同名的选举会被替换
+func NewElection(name string, ttl int64) *Election {
+	if ttl <= 0 {
+		ttl = DEFAULT_LEADER_TTL
+	}
+	e := &Election{Name: name, TTL: ttl, id: holderId()}
+	elections.Set(e)
+	return e
+}
+
+func (e *Election) ID() string {
+	return e.id
+}
+
+func (e *Election) IsLeader() bool {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.leaseID != 0
+}
+
+// Since 成为leader的时间，不是leader时为零值
+func (e *Election) Since() time.Time {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.since
+}
+
+func (e *Election) setLeader(leaseID int64) {
+	e.lock.Lock()
+	e.leaseID = leaseID
+	if leaseID != 0 {
+		e.since = time.Now()
+	} else {
+		e.since = time.Time{}
+	}
+	e.lock.Unlock()
+
+	if leaseID != 0 {
+		leaderGauge.WithLabelValues(e.Name).Set(1)
+		leaderChanges.WithLabelValues(e.Name, "elected").Inc()
+		util.Logger().Infof("%s becomes the leader of %s", e.id, e.Name)
+		return
+	}
+	leaderGauge.WithLabelValues(e.Name).Set(0)
+	leaderChanges.WithLabelValues(e.Name, "stepped_down").Inc()
+	util.Logger().Warnf(nil, "%s steps down from the leader of %s", e.id, e.Name)
+}
+
+func (e *Election) currentLease() int64 {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.leaseID
+}
+
+// campaign 是leader时续约，否则尝试创建leader key
+func (e *Election) campaign(ctx context.Context) {
+	if leaseID := e.currentLease(); leaseID != 0 {
+		if _, err := backend.Registry().LeaseRenew(ctx, leaseID); err != nil {
+			util.Logger().Errorf(err, "renew the lease of leader %s failed", e.Name)
+			e.setLeader(0)
+		}
+		return
+	}
+
+	leaseID, err := backend.Registry().LeaseGrant(ctx, e.TTL)
+	if err != nil {
+		util.Logger().Errorf(err, "grant the lease of election %s failed", e.Name)
+		return
+	}
+	ok, err := backend.Registry().PutNoOverride(ctx,
+		registry.WithStrKey(apt.GenerateLeaderKey(e.Name)),
+		registry.WithStrValue(e.id),
+		registry.WithLease(leaseID))
+	if err != nil || !ok {
+		if err != nil {
+			util.Logger().Errorf(err, "campaign for the leader of %s failed", e.Name)
+		}
+		if err := backend.Registry().LeaseRevoke(ctx, leaseID); err != nil {
+			util.Logger().Errorf(err, "revoke the lease of election %s failed", e.Name)
+		}
+		return
+	}
+	e.setLeader(leaseID)
+}
+
+// Resign 放弃leader，撤销租约后其他节点在下一次竞选时接替
+func (e *Election) Resign(ctx context.Context) {
+	leaseID := e.currentLease()
+	if leaseID == 0 {
+		return
+	}
+	e.setLeader(0)
+	if err := backend.Registry().LeaseRevoke(ctx, leaseID); err != nil {
+		util.Logger().Errorf(err, "revoke the lease of leader %s failed", e.Name)
+	}
+}
+
+// Run 周期性地竞选或续约，stopCh关闭后主动放弃leader
+func (e *Election) Run(stopCh <-chan struct{}) {
+	interval := time.Duration(e.TTL) * time.Second / 3
+	for {
+		e.campaign(context.Background())
+		select {
+		case <-stopCh:
+			e.Resign(context.Background())
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+type LeaderStatus struct {
+	Name   string `json:"name"`
+	Holder string `json:"holder"`
+	// 本节点是leader时成为leader的时间
+	Since int64 `json:"since,omitempty"`
+	Self  bool  `json:"self"`
+}
+
+// Leaders 查询集群中所有选举当前的leader，持有者格式为"hostname-pid"
+func Leaders(ctx context.Context) ([]*LeaderStatus, error) {
+	root := apt.GetLeaderRootKey() + "/"
+	resp, err := backend.Registry().Do(ctx, registry.GET,
+		registry.WithStrKey(root),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	status := make([]*LeaderStatus, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		ls := &LeaderStatus{
+			Name:   strings.TrimPrefix(util.BytesToStringWithNoCopy(kv.Key), root),
+			Holder: util.BytesToStringWithNoCopy(kv.Value),
+		}
+		if e, ok := elections.Get(ls.Name); ok && e.ID() == ls.Holder && e.IsLeader() {
+			ls.Self = true
+			ls.Since = e.Since().Unix()
+		}
+		status = append(status, ls)
+	}
+	return status, nil
+}
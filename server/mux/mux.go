@@ -18,7 +18,17 @@ package mux
 
 import (
 	"github.com/apache/incubator-servicecomb-service-center/pkg/etcdsync"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/astaxie/beego"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
 	"reflect"
+	"strings"
+	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -36,6 +46,162 @@ const (
 	GLOBAL_LOCK MuxType = "/global"
 )
 
-func Lock(t MuxType) (*etcdsync.Locker, error) {
-	return etcdsync.Lock(t.String())
+// 未配置lock_wait_timeout时等待锁的最长时间
+const DEFAULT_LOCK_WAIT_TIMEOUT = 30 * time.Second
+
+var (
+	lockWaitTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "service_center",
+			Subsystem: "lock",
+			Name:      "wait_seconds",
+			Help:      "Histogram of the time waiting for the distributed locks",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"key", "result"})
+
+	lockHoldTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "service_center",
+			Subsystem: "lock",
+			Name:      "hold_seconds",
+			Help:      "Histogram of the time holding the distributed locks",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"key"})
+
+	// 本节点持有和等待中的锁
+	locals = &localLocks{
+		held:    make(map[string]*Locker),
+		waiting: make(map[string]int),
+	}
+)
+
+func init() {
+	prometheus.MustRegister(lockWaitTime, lockHoldTime)
+}
+
+// Locker 释放时记录持有时间
+type Locker struct {
+	*etcdsync.Locker
+	t        MuxType
+	lockTime time.Time
+}
+
+func (l *Locker) Unlock() error {
+	lockHoldTime.WithLabelValues(l.t.String()).Observe(time.Since(l.lockTime).Seconds())
+	locals.Release(l)
+	return l.Locker.Unlock()
+}
+
+type localLocks struct {
+	lock    sync.Mutex
+	held    map[string]*Locker
+	waiting map[string]int
+}
+
+func (ll *localLocks) Wait(t MuxType, delta int) {
+	ll.lock.Lock()
+	ll.waiting[t.String()] += delta
+	if ll.waiting[t.String()] <= 0 {
+		delete(ll.waiting, t.String())
+	}
+	ll.lock.Unlock()
+}
+
+func (ll *localLocks) Hold(l *Locker) {
+	ll.lock.Lock()
+	ll.held[l.t.String()] = l
+	ll.lock.Unlock()
+}
+
+func (ll *localLocks) Release(l *Locker) {
+	ll.lock.Lock()
+	if ll.held[l.t.String()] == l {
+		delete(ll.held, l.t.String())
+	}
+	ll.lock.Unlock()
+}
+
+func lockWaitTimeout() time.Duration {
+	v := beego.AppConfig.DefaultString("lock_wait_timeout", DEFAULT_LOCK_WAIT_TIMEOUT.String())
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		util.Logger().Errorf(err, "invalid lock_wait_timeout '%s', use %s", v, DEFAULT_LOCK_WAIT_TIMEOUT)
+		return DEFAULT_LOCK_WAIT_TIMEOUT
+	}
+	return d
+}
+
+// Lock 等待时间超过lock_wait_timeout时返回etcdsync.ErrLockTimeout
+func Lock(t MuxType) (*Locker, error) {
+	return LockWithTimeout(t, lockWaitTimeout())
+}
+
+// LockWithTimeout timeout<=0时一直等待
+func LockWithTimeout(t MuxType, timeout time.Duration) (*Locker, error) {
+	start := time.Now()
+	locals.Wait(t, 1)
+	l, err := etcdsync.LockWithTimeout(t.String(), timeout)
+	locals.Wait(t, -1)
+	if err != nil {
+		result := "failure"
+		if IsBusy(err) {
+			result = "timeout"
+		}
+		lockWaitTime.WithLabelValues(t.String(), result).Observe(time.Since(start).Seconds())
+		return nil, err
+	}
+	lockWaitTime.WithLabelValues(t.String(), "success").Observe(time.Since(start).Seconds())
+
+	locker := &Locker{Locker: l, t: t, lockTime: time.Now()}
+	locals.Hold(locker)
+	return locker, nil
+}
+
+// IsBusy 等待锁超时
+func IsBusy(err error) bool {
+	return err == etcdsync.ErrLockTimeout
+}
+
+// ErrorCode 获取锁失败时返回给客户端的错误码，等待超时时客户端可以重试
+func ErrorCode(err error) int32 {
+	if IsBusy(err) {
+		return scerr.ErrLockBusy
+	}
+	return scerr.ErrInternal
+}
+
+type LockStatus struct {
+	Key    string `json:"key"`
+	Holder string `json:"holder"`
+	// 持有者为本节点时的加锁时间
+	Since int64 `json:"since,omitempty"`
+	// 本节点等待该锁的请求数
+	Waiting int `json:"waiting,omitempty"`
+}
+
+// Locks 查询集群中当前被持有的锁，持有者格式为"hostname-pid-加锁时间"
+func Locks(ctx context.Context) ([]*LockStatus, error) {
+	resp, err := backend.Registry().Do(ctx, registry.GET,
+		registry.WithStrKey(etcdsync.ROOT_PATH+"/"),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	locals.lock.Lock()
+	defer locals.lock.Unlock()
+	status := make([]*LockStatus, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		key := strings.TrimPrefix(util.BytesToStringWithNoCopy(kv.Key), etcdsync.ROOT_PATH)
+		ls := &LockStatus{
+			Key:     key,
+			Holder:  util.BytesToStringWithNoCopy(kv.Value),
+			Waiting: locals.waiting[key],
+		}
+		if l, ok := locals.held[key]; ok && l.ID() == ls.Holder {
+			ls.Since = l.lockTime.Unix()
+		}
+		status = append(status, ls)
+	}
+	return status, nil
 }
@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package buildin
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"golang.org/x/net/context"
+	"strings"
+	"sync"
+)
+
+type ServiceAdmitFunc func(ctx context.Context, domainProject string, in *pb.CreateServiceRequest) *scerr.Error
+type InstanceAdmitFunc func(ctx context.Context, domainProject string, in *pb.RegisterInstanceRequest) *scerr.Error
+
+var (
+	serviceAdmitFuncs  = make(map[string][]ServiceAdmitFunc)
+	instanceAdmitFuncs = make(map[string][]InstanceAdmitFunc)
+	lock               sync.RWMutex
+)
+
+func init() {
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.ADMISSION, "buildin", New})
+}
+
+// RegisterServiceAdmitFunc registers an in-process hook for CreateService,
+// empty domain means the hook takes effect in all domains.
+func RegisterServiceAdmitFunc(domain string, f ServiceAdmitFunc) {
+	lock.Lock()
+	serviceAdmitFuncs[domain] = append(serviceAdmitFuncs[domain], f)
+	lock.Unlock()
+}
+
+// RegisterInstanceAdmitFunc registers an in-process hook for RegisterInstance,
+// empty domain means the hook takes effect in all domains.
+func RegisterInstanceAdmitFunc(domain string, f InstanceAdmitFunc) {
+	lock.Lock()
+	instanceAdmitFuncs[domain] = append(instanceAdmitFuncs[domain], f)
+	lock.Unlock()
+}
+
+func New() mgr.PluginInstance {
+	return &BuildInAdmission{}
+}
+
+type BuildInAdmission struct {
+}
+
+func (ba *BuildInAdmission) AdmitService(ctx context.Context, domainProject string, in *pb.CreateServiceRequest) *scerr.Error {
+	domain := strings.Split(domainProject, "/")[0]
+
+	lock.RLock()
+	fs := append(append([]ServiceAdmitFunc{}, serviceAdmitFuncs[""]...), serviceAdmitFuncs[domain]...)
+	lock.RUnlock()
+
+	for _, f := range fs {
+		if err := f(ctx, domainProject, in); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ba *BuildInAdmission) AdmitInstance(ctx context.Context, domainProject string, in *pb.RegisterInstanceRequest) *scerr.Error {
+	domain := strings.Split(domainProject, "/")[0]
+
+	lock.RLock()
+	fs := append(append([]InstanceAdmitFunc{}, instanceAdmitFuncs[""]...), instanceAdmitFuncs[domain]...)
+	lock.RUnlock()
+
+	for _, f := range fs {
+		if err := f(ctx, domainProject, in); err != nil {
+			return err
+		}
+	}
+	return nil
+}
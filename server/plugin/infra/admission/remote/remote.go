@@ -0,0 +1,152 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+const (
+	KIND_SERVICE  = "service"
+	KIND_INSTANCE = "instance"
+)
+
+func init() {
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.ADMISSION, "remote", New})
+}
+
+// AdmissionReview is posted to the webhook
+type AdmissionReview struct {
+	Kind          string                   `json:"kind"`
+	DomainProject string                   `json:"domainProject"`
+	Service       *pb.CreateServiceRequest `json:"service,omitempty"`
+	Instance      *pb.MicroServiceInstance `json:"instance,omitempty"`
+}
+
+// AdmissionResult is returned by the webhook, the non-empty
+// service or instance will replace the original one.
+type AdmissionResult struct {
+	Allowed  bool                     `json:"allowed"`
+	Reason   string                   `json:"reason,omitempty"`
+	Service  *pb.CreateServiceRequest `json:"service,omitempty"`
+	Instance *pb.MicroServiceInstance `json:"instance,omitempty"`
+}
+
+func New() mgr.PluginInstance {
+	ra := &RemoteAdmission{
+		URL:      beego.AppConfig.String("admission_url"),
+		FailOpen: beego.AppConfig.DefaultBool("admission_fail_open", false),
+	}
+	u, err := url.Parse(ra.URL)
+	if err != nil || len(u.Host) == 0 {
+		util.Logger().Errorf(err, "invalid admission_url '%s'", ra.URL)
+		return ra
+	}
+	ra.client, err = rest.GetClient(u.Scheme)
+	if err != nil {
+		util.Logger().Errorf(err, "create admission client failed")
+	}
+	return ra
+}
+
+type RemoteAdmission struct {
+	URL      string
+	FailOpen bool
+	client   *rest.HttpClient
+}
+
+func (ra *RemoteAdmission) AdmitService(ctx context.Context, domainProject string, in *pb.CreateServiceRequest) *scerr.Error {
+	result, err := ra.review(&AdmissionReview{
+		Kind:          KIND_SERVICE,
+		DomainProject: domainProject,
+		Service:       in,
+	})
+	if err != nil {
+		return err
+	}
+	if result.Service != nil && result.Service.Service != nil {
+		*in = *result.Service
+	}
+	return nil
+}
+
+func (ra *RemoteAdmission) AdmitInstance(ctx context.Context, domainProject string, in *pb.RegisterInstanceRequest) *scerr.Error {
+	result, err := ra.review(&AdmissionReview{
+		Kind:          KIND_INSTANCE,
+		DomainProject: domainProject,
+		Instance:      in.Instance,
+	})
+	if err != nil {
+		return err
+	}
+	if result.Instance != nil {
+		in.Instance = result.Instance
+	}
+	return nil
+}
+
+func (ra *RemoteAdmission) review(review *AdmissionReview) (*AdmissionResult, *scerr.Error) {
+	result, err := ra.post(review)
+	if err != nil {
+		util.Logger().Errorf(err, "request admission webhook %s failed, %s %s",
+			ra.URL, review.Kind, review.DomainProject)
+		if ra.FailOpen {
+			return &AdmissionResult{Allowed: true}, nil
+		}
+		return nil, scerr.NewError(scerr.ErrUnavailableAdmission, err.Error())
+	}
+	if !result.Allowed {
+		return nil, scerr.NewError(scerr.ErrAdmissionDenied, result.Reason)
+	}
+	return result, nil
+}
+
+func (ra *RemoteAdmission) post(review *AdmissionReview) (*AdmissionResult, error) {
+	if ra.client == nil {
+		return nil, fmt.Errorf("admission client is not available")
+	}
+	resp, err := ra.client.HttpDo(http.MethodPost, ra.URL, nil, review)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d, %s", resp.StatusCode, util.BytesToStringWithNoCopy(body))
+	}
+
+	result := &AdmissionResult{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,37 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package buildin
+
+import (
+	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"net/http"
+)
+
+func init() {
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.AUDIT_LOG, "buildin", New})
+}
+
+func New() mgr.PluginInstance {
+	return &BuildInAuditLogger{}
+}
+
+// BuildInAuditLogger 默认不记录审计日志，保证插件实例只创建一次
+type BuildInAuditLogger struct {
+}
+
+func (al *BuildInAuditLogger) Record(r *http.Request, responseHeaders http.Header) {
+}
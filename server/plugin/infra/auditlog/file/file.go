@@ -0,0 +1,496 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package file
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/auditlog"
+	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/astaxie/beego"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	AUDIT_LOG_FILE_NAME = "audit.log"
+	MAX_LINE_SIZE       = 1024 * 1024
+)
+
+func init() {
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.AUDIT_LOG, "file", New})
+}
+
+func New() mgr.PluginInstance {
+	key := beego.AppConfig.String("auditlog_key")
+	if len(key) > 0 {
+		decrypt, err := mgr.Plugins().Cipher().Decrypt(key)
+		if err != nil {
+			util.Logger().Errorf(err, "decrypt auditlog key failed, audit log is disabled")
+			return &FileAuditLogger{err: err}
+		}
+		key = decrypt
+	}
+	l, err := NewFileAuditLogger(auditLogFile(), []byte(key), beego.AppConfig.DefaultBool("auditlog_encrypt", false))
+	if err != nil {
+		util.Logger().Errorf(err, "create audit logger failed, audit log is disabled")
+		return &FileAuditLogger{err: err}
+	}
+	util.Logger().Infof("audit log is enabled, file %s, signed %v, encrypted %v", l.file, len(key) > 0, l.encrypt)
+	return l
+}
+
+func auditLogFile() string {
+	if file := beego.AppConfig.String("auditlog_file"); len(file) > 0 {
+		return os.ExpandEnv(file)
+	}
+	if logFile := core.ServerInfo.Config.LogFilePath; len(logFile) > 0 {
+		return filepath.Join(filepath.Dir(os.ExpandEnv(logFile)), AUDIT_LOG_FILE_NAME)
+	}
+	return AUDIT_LOG_FILE_NAME
+}
+
+// Record 一次API调用的审计内容
+type Record struct {
+	Time     string `json:"time"`
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	Status   string `json:"status,omitempty"`
+	RemoteIP string `json:"remoteIp"`
+	Caller   string `json:"caller,omitempty"`
+	ApiKeyId string `json:"apiKeyId,omitempty"`
+}
+
+// entry 审计日志文件中的一行，每个租户的记录按Seq串成hash链，
+// Hash覆盖租户、序号、上一条记录的Hash和记录内容(加密时为密文)
+type entry struct {
+	Tenant string `json:"tenant"`
+	Seq    int64  `json:"seq"`
+	Data   string `json:"data,omitempty"`
+	Sealed string `json:"sealed,omitempty"`
+	Prev   string `json:"prev"`
+	Hash   string `json:"hash"`
+}
+
+func (e *entry) payload() string {
+	if len(e.Sealed) > 0 {
+		return e.Sealed
+	}
+	return e.Data
+}
+
+type chainHead struct {
+	Seq  int64
+	Hash string
+}
+
+type tenantKey struct {
+	mac  []byte
+	aead cipher.AEAD
+}
+
+// FileAuditLogger 将审计记录追加写入文件；配置了密钥时按租户派生签名和加密密钥，
+// 没有密钥时hash链只能发现意外的修改。文件不能被截断式转储，否则链会断开
+type FileAuditLogger struct {
+	file    string
+	master  []byte
+	encrypt bool
+	err     error
+
+	lock  sync.Mutex
+	out   io.Writer
+	heads map[string]*chainHead
+	keys  map[string]*tenantKey
+}
+
+// Record 写入失败时不推进链头，下一条记录仍接在最后一条成功写入的记录之后
+func (l *FileAuditLogger) Record(r *http.Request, responseHeaders http.Header) {
+	if l.err != nil {
+		return
+	}
+	ctx := r.Context()
+	apiKeyId, _ := ctx.Value("x-api-key-id").(string)
+	data, err := json.Marshal(&Record{
+		Time:     time.Now().Format(time.RFC3339Nano),
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		Status:   responseHeaders.Get("X-Response-Status"),
+		RemoteIP: util.GetRealIP(r),
+		Caller:   r.Header.Get("X-ConsumerId"),
+		ApiKeyId: apiKeyId,
+	})
+	if err != nil {
+		return
+	}
+	tenant := util.ParseDomainProject(ctx)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	e, err := l.next(tenant, data)
+	if err != nil {
+		util.Logger().Errorf(err, "seal audit record of %s failed", tenant)
+		return
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	if _, err := l.out.Write(append(line, '\n')); err != nil {
+		util.Logger().Errorf(err, "write audit record of %s failed", tenant)
+		return
+	}
+	l.heads[tenant] = &chainHead{Seq: e.Seq, Hash: e.Hash}
+}
+
+func (l *FileAuditLogger) next(tenant string, data []byte) (*entry, error) {
+	e := &entry{Tenant: tenant, Seq: 1}
+	if head, ok := l.heads[tenant]; ok {
+		e.Seq, e.Prev = head.Seq+1, head.Hash
+	}
+	if l.encrypt {
+		sealed, err := l.seal(e, data)
+		if err != nil {
+			return nil, err
+		}
+		e.Sealed = sealed
+	} else {
+		e.Data = util.BytesToStringWithNoCopy(data)
+	}
+	e.Hash = l.digest(e)
+	return e, nil
+}
+
+func (l *FileAuditLogger) key(tenant string) *tenantKey {
+	if k, ok := l.keys[tenant]; ok {
+		return k
+	}
+	derive := func(usage string) []byte {
+		h := hmac.New(sha256.New, l.master)
+		h.Write([]byte(usage + ":" + tenant))
+		return h.Sum(nil)
+	}
+	k := &tenantKey{mac: derive("audit-mac")}
+	if l.encrypt {
+		block, _ := aes.NewCipher(derive("audit-enc"))
+		k.aead, _ = cipher.NewGCM(block)
+	}
+	l.keys[tenant] = k
+	return k
+}
+
+// additionalData 密文绑定租户和序号，不能被挪到其它位置
+func additionalData(e *entry) []byte {
+	return []byte(e.Tenant + "/" + strconv.FormatInt(e.Seq, 10))
+}
+
+func (l *FileAuditLogger) seal(e *entry, data []byte) (string, error) {
+	aead := l.key(e.Tenant).aead
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, data, additionalData(e))), nil
+}
+
+func (l *FileAuditLogger) open(e *entry) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(e.Sealed)
+	if err != nil {
+		return nil, err
+	}
+	aead := l.key(e.Tenant).aead
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("sealed data is too short")
+	}
+	n := aead.NonceSize()
+	return aead.Open(nil, sealed[:n], sealed[n:], additionalData(e))
+}
+
+func (l *FileAuditLogger) digest(e *entry) string {
+	content := []byte(fmt.Sprintf("%s\n%d\n%s\n%s", e.Tenant, e.Seq, e.Prev, e.payload()))
+	if len(l.master) == 0 {
+		sum := sha256.Sum256(content)
+		return hex.EncodeToString(sum[:])
+	}
+	h := hmac.New(sha256.New, l.key(e.Tenant).mac)
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func scan(r io.Reader, f func(line int, e *entry, err error)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MAX_LINE_SIZE)
+	line := 0
+	for scanner.Scan() {
+		line++
+		e := &entry{}
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			f(line, nil, err)
+			continue
+		}
+		f(line, e, nil)
+	}
+	return scanner.Err()
+}
+
+type verifyState struct {
+	result *auditlog.VerifyResult
+	head   chainHead
+}
+
+func (s *verifyState) broken(seq int64, format string, args ...interface{}) {
+	if !s.result.Valid {
+		return
+	}
+	s.result.Valid = false
+	s.result.BrokenAt = seq
+	s.result.Reason = fmt.Sprintf(format, args...)
+}
+
+// Verify 检查记录的序号连续、链接正确、Hash和密文未被修改，以及文件末尾的记录未被删除；
+// 无法解析的行无法确定所属租户，所有租户的结果都视为不可信
+func (l *FileAuditLogger) Verify(domainProject string) ([]*auditlog.VerifyResult, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	f, err := os.Open(l.file)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	// 只读取快照时已完整写入的记录
+	l.lock.Lock()
+	heads := make(map[string]chainHead, len(l.heads))
+	for tenant, head := range l.heads {
+		heads[tenant] = *head
+	}
+	var size int64
+	if f != nil {
+		defer f.Close()
+		fi, err := f.Stat()
+		if err != nil {
+			l.lock.Unlock()
+			return nil, err
+		}
+		size = fi.Size()
+	}
+	l.lock.Unlock()
+
+	states := make(map[string]*verifyState)
+	state := func(tenant string) *verifyState {
+		s, ok := states[tenant]
+		if !ok {
+			s = &verifyState{result: &auditlog.VerifyResult{DomainProject: tenant, Valid: true}}
+			states[tenant] = s
+		}
+		return s
+	}
+	malformed := 0
+	if f != nil {
+		err = scan(io.LimitReader(f, size), func(line int, e *entry, err error) {
+			if err != nil {
+				if malformed == 0 {
+					malformed = line
+				}
+				return
+			}
+			if len(domainProject) > 0 && e.Tenant != domainProject {
+				return
+			}
+			l.lock.Lock()
+			defer l.lock.Unlock()
+			s := state(e.Tenant)
+			s.result.Records++
+			switch {
+			case e.Seq != s.head.Seq+1:
+				s.broken(e.Seq, "record %d is missing or out of order", s.head.Seq+1)
+			case e.Prev != s.head.Hash:
+				s.broken(e.Seq, "record %d is not chained to the previous record", e.Seq)
+			case e.Hash != l.digest(e):
+				s.broken(e.Seq, "record %d is modified", e.Seq)
+			case len(e.Sealed) > 0 && l.encrypt:
+				if _, err := l.open(e); err != nil {
+					s.broken(e.Seq, "record %d can not be decrypted, %s", e.Seq, err.Error())
+				}
+			}
+			// 从当前记录继续校验，后续记录仍计数
+			s.head = chainHead{Seq: e.Seq, Hash: e.Hash}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for tenant, head := range heads {
+		if len(domainProject) > 0 && tenant != domainProject {
+			continue
+		}
+		s := state(tenant)
+		if s.head.Seq < head.Seq {
+			s.broken(s.head.Seq+1, "%d record(s) at the tail are missing", head.Seq-s.head.Seq)
+		}
+	}
+	if malformed > 0 && len(states) == 0 {
+		state(domainProject)
+	}
+
+	results := make([]*auditlog.VerifyResult, 0, len(states))
+	for _, s := range states {
+		if malformed > 0 {
+			s.broken(0, "line %d of the audit log is malformed", malformed)
+		}
+		results = append(results, s.result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].DomainProject < results[j].DomainProject
+	})
+	return results, nil
+}
+
+// Purge 清除租户的所有记录：其他租户的行原样写入同目录的临时文件后替换原文件，
+// 各租户的链相互独立，清除后其他租户的记录仍可校验；只清除本节点的审计日志
+func (l *FileAuditLogger) Purge(domainProject string) (int64, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	f, err := os.Open(l.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer f.Close()
+	tmp, err := ioutil.TempFile(filepath.Dir(l.file), filepath.Base(l.file)+".purge")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	var purged int64
+	w := bufio.NewWriter(tmp)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), MAX_LINE_SIZE)
+	for scanner.Scan() {
+		e := &entry{}
+		// 无法解析的行不能确定所属租户，保留给Verify报告
+		if err := json.Unmarshal(scanner.Bytes(), e); err == nil && e.Tenant == domainProject {
+			purged++
+			continue
+		}
+		w.Write(scanner.Bytes())
+		w.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), l.file); err != nil {
+		return 0, err
+	}
+
+	// 原文件已被替换，后续记录追加到新文件
+	out, err := os.OpenFile(l.file, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		util.Logger().Errorf(err, "reopen audit log %s failed, audit log is disabled", l.file)
+		l.err = err
+		return purged, err
+	}
+	if c, ok := l.out.(io.Closer); ok {
+		c.Close()
+	}
+	l.out = out
+	delete(l.heads, domainProject)
+	delete(l.keys, domainProject)
+	return purged, nil
+}
+
+// load 重启后从文件恢复每个租户的链头，损坏的记录由Verify报告
+func (l *FileAuditLogger) load() error {
+	f, err := os.Open(l.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	return scan(f, func(line int, e *entry, err error) {
+		if err != nil {
+			util.Logger().Warnf(nil, "line %d of audit log %s is malformed", line, l.file)
+			return
+		}
+		l.heads[e.Tenant] = &chainHead{Seq: e.Seq, Hash: e.Hash}
+	})
+}
+
+func NewFileAuditLogger(file string, key []byte, encrypt bool) (*FileAuditLogger, error) {
+	if encrypt && len(key) == 0 {
+		return nil, errors.New("auditlog_encrypt requires auditlog_key")
+	}
+	l := &FileAuditLogger{
+		file:    file,
+		master:  key,
+		encrypt: encrypt,
+		heads:   make(map[string]*chainHead),
+		keys:    make(map[string]*tenantKey),
+	}
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	out, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	l.out = out
+	return l, nil
+}
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package file
+
+import (
+	"bytes"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func record(l *FileAuditLogger, domain string, n int) {
+	for i := 0; i < n; i++ {
+		r, _ := http.NewRequest(http.MethodGet, "/v4/default/registry/microservices", nil)
+		r = util.SetRequestContext(r, "domain", domain)
+		r = util.SetRequestContext(r, "project", "default")
+		l.Record(r, http.Header{"X-Response-Status": []string{"200"}})
+	}
+}
+
+func verify(t *testing.T, l *FileAuditLogger, domainProject string) []string {
+	results, err := l.Verify(domainProject)
+	if err != nil {
+		t.Fatalf("Verify failed, %s", err.Error())
+	}
+	var reasons []string
+	for _, result := range results {
+		reasons = append(reasons, result.Reason)
+	}
+	return reasons
+}
+
+func TestFileAuditLogger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auditlog")
+	if err != nil {
+		t.Fatalf("TempDir failed, %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, AUDIT_LOG_FILE_NAME)
+
+	if _, err := NewFileAuditLogger(file, nil, true); err == nil {
+		t.Fatalf("NewFileAuditLogger should fail when encrypt without key")
+	}
+	l, err := NewFileAuditLogger(file, []byte("key"), true)
+	if err != nil {
+		t.Fatalf("NewFileAuditLogger failed, %s", err.Error())
+	}
+	record(l, "a", 3)
+	record(l, "b", 2)
+	if reasons := verify(t, l, ""); len(reasons) != 2 || reasons[0] != "" || reasons[1] != "" {
+		t.Fatalf("Verify failed, %v", reasons)
+	}
+	data, _ := ioutil.ReadFile(file)
+	if bytes.Contains(data, []byte("microservices")) {
+		t.Fatalf("records are not encrypted")
+	}
+
+	// 重启后接续原来的链
+	l, err = NewFileAuditLogger(file, []byte("key"), true)
+	if err != nil {
+		t.Fatalf("NewFileAuditLogger failed, %s", err.Error())
+	}
+	record(l, "a", 1)
+	results, _ := l.Verify("a/default")
+	if len(results) != 1 || !results[0].Valid || results[0].Records != 4 {
+		t.Fatalf("Verify failed, %v", results[0])
+	}
+
+	// 删除中间的记录
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	ioutil.WriteFile(file, []byte(strings.Join(append(lines[:1:1], lines[2:]...), "\n")+"\n"), 0600)
+	if reasons := verify(t, l, "a/default"); len(reasons) != 1 || !strings.Contains(reasons[0], "missing") {
+		t.Fatalf("Verify failed, %v", reasons)
+	}
+
+	// 使用其它密钥无法通过校验
+	ioutil.WriteFile(file, data, 0600)
+	other, _ := NewFileAuditLogger(file, []byte("other"), true)
+	if reasons := verify(t, other, "b/default"); len(reasons) != 1 || !strings.Contains(reasons[0], "modified") {
+		t.Fatalf("Verify failed, %v", reasons)
+	}
+}
+
+func TestFileAuditLogger_Purge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auditlog")
+	if err != nil {
+		t.Fatalf("TempDir failed, %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, AUDIT_LOG_FILE_NAME)
+
+	l, err := NewFileAuditLogger(file, []byte("key"), false)
+	if err != nil {
+		t.Fatalf("NewFileAuditLogger failed, %s", err.Error())
+	}
+	record(l, "a", 3)
+	record(l, "b", 2)
+	record(l, "a", 1)
+
+	n, err := l.Purge("a/default")
+	if err != nil || n != 4 {
+		t.Fatalf("Purge failed, %d, %v", n, err)
+	}
+	data, _ := ioutil.ReadFile(file)
+	if bytes.Contains(data, []byte(`"a/default"`)) || strings.Count(string(data), "\n") != 2 {
+		t.Fatalf("records of a/default remain, %s", data)
+	}
+	results, _ := l.Verify("")
+	if len(results) != 1 || results[0].DomainProject != "b/default" || !results[0].Valid || results[0].Records != 2 {
+		t.Fatalf("Verify failed after purge, %v", results)
+	}
+
+	// 清除后的记录追加到新文件，租户重新开始新的链
+	record(l, "a", 1)
+	record(l, "b", 1)
+	if reasons := verify(t, l, ""); len(reasons) != 2 || reasons[0] != "" || reasons[1] != "" {
+		t.Fatalf("Verify failed after purge, %v", reasons)
+	}
+	results, _ = l.Verify("a/default")
+	if len(results) != 1 || results[0].Records != 1 {
+		t.Fatalf("Verify failed after purge, %v", results)
+	}
+
+	// 重启后仍可校验
+	l, err = NewFileAuditLogger(file, []byte("key"), false)
+	if err != nil {
+		t.Fatalf("NewFileAuditLogger failed, %s", err.Error())
+	}
+	if reasons := verify(t, l, ""); len(reasons) != 2 || reasons[0] != "" || reasons[1] != "" {
+		t.Fatalf("Verify failed after restart, %v", reasons)
+	}
+
+	if n, err := l.Purge("c/default"); err != nil || n != 0 {
+		t.Fatalf("Purge unknown tenant failed, %d, %v", n, err)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/cmdb"
+	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"text/template"
+)
+
+func init() {
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.CMDB, "rest", New})
+}
+
+var funcs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return util.BytesToStringWithNoCopy(b), err
+	},
+}
+
+func New() mgr.PluginInstance {
+	rc := &RestCMDB{
+		URL:         beego.AppConfig.String("cmdb_url"),
+		Method:      beego.AppConfig.DefaultString("cmdb_method", http.MethodPost),
+		ContentType: beego.AppConfig.DefaultString("cmdb_content_type", "application/json"),
+	}
+	var err error
+	rc.urlTemplate, err = template.New("url").Funcs(funcs).Parse(rc.URL)
+	if err != nil {
+		util.Logger().Errorf(err, "invalid cmdb_url '%s'", rc.URL)
+		return rc
+	}
+	if file := beego.AppConfig.String("cmdb_template"); len(file) > 0 {
+		rc.bodyTemplate, err = template.New("body").Funcs(funcs).ParseFiles(file)
+		if err != nil {
+			util.Logger().Errorf(err, "invalid cmdb_template '%s'", file)
+			return rc
+		}
+		rc.bodyTemplate = rc.bodyTemplate.Lookup(filepath.Base(file))
+	}
+	u, err := url.Parse(rc.URL)
+	if err != nil || len(u.Host) == 0 {
+		util.Logger().Errorf(err, "invalid cmdb_url '%s'", rc.URL)
+		return rc
+	}
+	rc.client, err = rest.GetClient(u.Scheme)
+	if err != nil {
+		util.Logger().Errorf(err, "create cmdb client failed")
+	}
+	return rc
+}
+
+// RestCMDB renders the record with the mapping template and sends it to CMDB,
+// both of the url and the body are go templates, the body is the json of record
+// if no template is configured.
+type RestCMDB struct {
+	URL         string
+	Method      string
+	ContentType string
+
+	urlTemplate  *template.Template
+	bodyTemplate *template.Template
+	client       *rest.HttpClient
+}
+
+func (rc *RestCMDB) Sync(ctx context.Context, record *cmdb.ServiceRecord) error {
+	if rc.client == nil || rc.urlTemplate == nil {
+		return fmt.Errorf("cmdb client is not available")
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := rc.urlTemplate.Execute(buf, record); err != nil {
+		return err
+	}
+	addr := buf.String()
+
+	var (
+		body    interface{} = record
+		headers map[string]string
+	)
+	if rc.bodyTemplate != nil {
+		buf = bytes.NewBuffer(nil)
+		if err := rc.bodyTemplate.Execute(buf, record); err != nil {
+			return err
+		}
+		body = buf.Bytes()
+		headers = map[string]string{"Content-Type": rc.ContentType}
+	}
+
+	resp, err := rc.client.HttpDo(rc.Method, addr, headers, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d, %s", resp.StatusCode, util.BytesToStringWithNoCopy(data))
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package buildin
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"golang.org/x/net/context"
+	"sync"
+)
+
+// ConfigSource is a generic in-process governance config source
+type ConfigSource func(ctx context.Context, domainProject string, provider *pb.MicroService) ([]*pb.GovernanceConfig, error)
+
+var (
+	sources []ConfigSource
+	lock    sync.RWMutex
+)
+
+func init() {
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.GOVERNANCE, "buildin", New})
+}
+
+// RegisterConfigSource registers a config source, the configs returned by
+// the sources are merged in order, the later one overrides the same key.
+func RegisterConfigSource(s ConfigSource) {
+	lock.Lock()
+	sources = append(sources, s)
+	lock.Unlock()
+}
+
+func New() mgr.PluginInstance {
+	return &BuildInGovernance{}
+}
+
+type BuildInGovernance struct {
+}
+
+func (bg *BuildInGovernance) GetConfigs(ctx context.Context, domainProject string, provider *pb.MicroService) ([]*pb.GovernanceConfig, error) {
+	lock.RLock()
+	ss := append([]ConfigSource{}, sources...)
+	lock.RUnlock()
+
+	var configs []*pb.GovernanceConfig
+	index := make(map[string]int)
+	for _, s := range ss {
+		cs, err := s(ctx, domainProject, provider)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range cs {
+			if i, ok := index[c.Key]; ok {
+				configs[i] = c
+				continue
+			}
+			index[c.Key] = len(configs)
+			configs = append(configs, c)
+		}
+	}
+	return configs, nil
+}
@@ -0,0 +1,190 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package kie
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	LABEL_APP         = "app"
+	LABEL_SERVICE     = "service"
+	LABEL_ENVIRONMENT = "environment"
+
+	STATUS_ENABLED = "enabled"
+)
+
+func init() {
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.GOVERNANCE, "kie", New})
+}
+
+// KVDoc is the key value returned by ServiceComb-Kie
+type KVDoc struct {
+	Key    string            `json:"key"`
+	Value  string            `json:"value"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Status string            `json:"status,omitempty"`
+}
+
+type KVResponse struct {
+	Total int      `json:"total"`
+	Data  []*KVDoc `json:"data"`
+}
+
+type cacheItem struct {
+	configs []*pb.GovernanceConfig
+	expire  time.Time
+}
+
+func New() mgr.PluginInstance {
+	kg := &KieGovernance{
+		URL:   strings.TrimRight(beego.AppConfig.String("governance_url"), "/"),
+		TTL:   time.Duration(beego.AppConfig.DefaultInt("governance_cache_ttl", 30)) * time.Second,
+		cache: make(map[string]*cacheItem),
+	}
+	u, err := url.Parse(kg.URL)
+	if err != nil || len(u.Host) == 0 {
+		util.Logger().Errorf(err, "invalid governance_url '%s'", kg.URL)
+		return kg
+	}
+	kg.client, err = rest.GetClient(u.Scheme)
+	if err != nil {
+		util.Logger().Errorf(err, "create kie client failed")
+	}
+	return kg
+}
+
+// KieGovernance queries the configs labeled with app, service and environment
+// of the provider from ServiceComb-Kie, the result is cached for TTL.
+type KieGovernance struct {
+	URL    string
+	TTL    time.Duration
+	client *rest.HttpClient
+	cache  map[string]*cacheItem
+	lock   sync.RWMutex
+}
+
+func (kg *KieGovernance) GetConfigs(ctx context.Context, domainProject string, provider *pb.MicroService) ([]*pb.GovernanceConfig, error) {
+	labels := map[string]string{
+		LABEL_APP:     provider.AppId,
+		LABEL_SERVICE: provider.ServiceName,
+	}
+	if len(provider.Environment) > 0 {
+		labels[LABEL_ENVIRONMENT] = provider.Environment
+	}
+	key := util.StringJoin([]string{domainProject, provider.Environment, provider.AppId, provider.ServiceName}, "/")
+
+	if configs, ok := kg.getCache(key); ok {
+		return configs, nil
+	}
+
+	configs, err := kg.list(domainProject, labels)
+	if err != nil {
+		return nil, err
+	}
+	kg.setCache(key, configs)
+	return configs, nil
+}
+
+func (kg *KieGovernance) getCache(key string) ([]*pb.GovernanceConfig, bool) {
+	if kg.TTL <= 0 {
+		return nil, false
+	}
+	kg.lock.RLock()
+	item, ok := kg.cache[key]
+	kg.lock.RUnlock()
+	if !ok || time.Now().After(item.expire) {
+		return nil, false
+	}
+	return item.configs, true
+}
+
+func (kg *KieGovernance) setCache(key string, configs []*pb.GovernanceConfig) {
+	if kg.TTL <= 0 {
+		return
+	}
+	now := time.Now()
+	kg.lock.Lock()
+	for k, item := range kg.cache {
+		if now.After(item.expire) {
+			delete(kg.cache, k)
+		}
+	}
+	kg.cache[key] = &cacheItem{configs: configs, expire: now.Add(kg.TTL)}
+	kg.lock.Unlock()
+}
+
+func (kg *KieGovernance) list(domainProject string, labels map[string]string) ([]*pb.GovernanceConfig, error) {
+	if kg.client == nil {
+		return nil, fmt.Errorf("kie client is not available")
+	}
+	domain, project := domainProject, ""
+	if i := strings.Index(domainProject, "/"); i >= 0 {
+		domain, project = domainProject[:i], domainProject[i+1:]
+	}
+
+	query := url.Values{}
+	for k, v := range labels {
+		query.Add("label", k+":"+v)
+	}
+	query.Set("status", STATUS_ENABLED)
+	reqURL := fmt.Sprintf("%s/v1/%s/kie/kv?%s", kg.URL, url.PathEscape(project), query.Encode())
+
+	resp, err := kg.client.HttpDo(http.MethodGet, reqURL, map[string]string{"X-Domain-Name": domain}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d, %s", resp.StatusCode, util.BytesToStringWithNoCopy(body))
+	}
+
+	kvs := &KVResponse{}
+	if err := json.Unmarshal(body, kvs); err != nil {
+		return nil, err
+	}
+	configs := make([]*pb.GovernanceConfig, 0, len(kvs.Data))
+	for _, kv := range kvs.Data {
+		if len(kv.Status) > 0 && kv.Status != STATUS_ENABLED {
+			continue
+		}
+		configs = append(configs, &pb.GovernanceConfig{
+			Key:    kv.Key,
+			Value:  kv.Value,
+			Labels: kv.Labels,
+		})
+	}
+	return configs, nil
+}
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package graphite
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/metrics"
+	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/astaxie/beego"
+	"net"
+	"time"
+)
+
+const DEFAULT_TIMEOUT = 10 * time.Second
+
+func init() {
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.METRICS, "graphite", New})
+}
+
+func New() mgr.PluginInstance {
+	return &GraphiteExporter{
+		Addr:    beego.AppConfig.DefaultString("metrics_push_addr", "127.0.0.1:2003"),
+		Prefix:  beego.AppConfig.DefaultString("metrics_push_prefix", metrics.DEFAULT_PREFIX),
+		Timeout: DEFAULT_TIMEOUT,
+	}
+}
+
+// GraphiteExporter sends the samples in the graphite plaintext protocol over tcp,
+// a new connection is used for every push.
+type GraphiteExporter struct {
+	Addr    string
+	Prefix  string
+	Timeout time.Duration
+}
+
+func (e *GraphiteExporter) Export(samples []*metrics.Sample) error {
+	conn, err := net.DialTimeout("tcp", e.Addr, e.Timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetWriteDeadline(time.Now().Add(e.Timeout))
+
+	now := time.Now().Unix()
+	w := bufio.NewWriter(conn)
+	for _, s := range samples {
+		if !s.Valid() {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s %s %d\n", s.Path(e.Prefix), metrics.FormatValue(s.Value), now); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package statsd
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/metrics"
+	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/astaxie/beego"
+	"net"
+)
+
+// MAX_PACKET_SIZE keeps the udp packets under the common MTU
+const MAX_PACKET_SIZE = 1432
+
+func init() {
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.METRICS, "statsd", New})
+}
+
+func New() mgr.PluginInstance {
+	return &StatsdExporter{
+		Addr:   beego.AppConfig.DefaultString("metrics_push_addr", "127.0.0.1:8125"),
+		Prefix: beego.AppConfig.DefaultString("metrics_push_prefix", metrics.DEFAULT_PREFIX),
+	}
+}
+
+// StatsdExporter sends the samples as statsd gauges over udp, the counters
+// are sent as the cumulative values rather than the deltas.
+type StatsdExporter struct {
+	Addr   string
+	Prefix string
+}
+
+func (e *StatsdExporter) Export(samples []*metrics.Sample) error {
+	conn, err := net.Dial("udp", e.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	buf := bytes.NewBuffer(make([]byte, 0, MAX_PACKET_SIZE))
+	for _, s := range samples {
+		// 负数的gauge会被statsd当作减量
+		if !s.Valid() || s.Value < 0 {
+			continue
+		}
+		line := fmt.Sprintf("%s:%s|g\n", s.Path(e.Prefix), metrics.FormatValue(s.Value))
+		if buf.Len() > 0 && buf.Len()+len(line) > MAX_PACKET_SIZE {
+			if _, err := conn.Write(buf.Bytes()); err != nil {
+				return err
+			}
+			buf.Reset()
+		}
+		buf.WriteString(line)
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	_, err = conn.Write(buf.Bytes())
+	return err
+}
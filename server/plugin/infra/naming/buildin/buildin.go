@@ -0,0 +1,229 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package buildin
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/naming"
+	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	FIELD_APP_ID       = "app_id"
+	FIELD_SERVICE_NAME = "service_name"
+	FIELD_VERSION      = "version"
+)
+
+// 配置中的字段名与服务字段的对应关系
+var fields = []struct {
+	name  string
+	field string
+	value func(service *pb.MicroService) string
+}{
+	{FIELD_APP_ID, "appId", func(service *pb.MicroService) string { return service.AppId }},
+	{FIELD_SERVICE_NAME, "serviceName", func(service *pb.MicroService) string { return service.ServiceName }},
+	{FIELD_VERSION, "version", func(service *pb.MicroService) string { return service.Version }},
+}
+
+func init() {
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.NAMING, "buildin", New})
+}
+
+// Rule 一个字段的命名规则，零值表示不限制
+type Rule struct {
+	Pattern   *regexp.Regexp
+	MaxLength int
+	// 允许的字符集合，正则字符类的写法，如 a-z0-9-
+	Charset      string
+	charsetRegex *regexp.Regexp
+}
+
+func (r *Rule) set(rule, value string) error {
+	switch rule {
+	case "pattern":
+		if len(value) == 0 {
+			r.Pattern = nil
+			return nil
+		}
+		regex, err := regexp.Compile(value)
+		if err != nil {
+			return err
+		}
+		r.Pattern = regex
+	case "max_length":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid max length '%s'", value)
+		}
+		r.MaxLength = n
+	case "charset":
+		if len(value) == 0 {
+			r.Charset, r.charsetRegex = "", nil
+			return nil
+		}
+		regex, err := regexp.Compile("^[" + value + "]$")
+		if err != nil {
+			return err
+		}
+		r.Charset, r.charsetRegex = value, regex
+	default:
+		return fmt.Errorf("unknown rule '%s'", rule)
+	}
+	return nil
+}
+
+// Check 返回不符合规则的原因，符合时返回空
+func (r *Rule) Check(value string) string {
+	if r.MaxLength > 0 && utf8.RuneCountInString(value) > r.MaxLength {
+		return fmt.Sprintf("exceeds the max length %d", r.MaxLength)
+	}
+	if r.charsetRegex != nil {
+		for _, c := range value {
+			if !r.charsetRegex.MatchString(string(c)) {
+				return fmt.Sprintf("contains the character '%c' out of the charset [%s]", c, r.Charset)
+			}
+		}
+	}
+	if r.Pattern != nil && !r.Pattern.MatchString(value) {
+		return fmt.Sprintf("does not match the pattern %s", r.Pattern)
+	}
+	return ""
+}
+
+// Rules 租户的命名规则，key为app_id、service_name或version
+type Rules map[string]Rule
+
+func (rs Rules) copy() Rules {
+	c := make(Rules, len(rs))
+	for k, v := range rs {
+		c[k] = v
+	}
+	return c
+}
+
+// set key为{field}_{rule}，如service_name_max_length
+func (rs Rules) set(key, value string) error {
+	for _, f := range fields {
+		if !strings.HasPrefix(key, f.name+"_") {
+			continue
+		}
+		r := rs[f.name]
+		if err := r.set(key[len(f.name)+1:], value); err != nil {
+			return err
+		}
+		rs[f.name] = r
+		return nil
+	}
+	return fmt.Errorf("unknown naming rule '%s'", key)
+}
+
+// ParseOverrides 解析租户级别的规则，格式如 domain1/project1:service_name_pattern=^[a-z]+$;domain1:app_id_max_length=32，
+// 每项只包含一条规则，正则中可以有','但不能有';'，未指定的规则沿用默认规则
+func ParseOverrides(def Rules, s string) map[string]Rules {
+	overrides := make(map[string]Rules)
+	for _, item := range strings.Split(s, ";") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		idx := strings.Index(item, ":")
+		if idx <= 0 {
+			util.Logger().Errorf(nil, "invalid naming rules override '%s'", item)
+			continue
+		}
+		kv := strings.SplitN(item[idx+1:], "=", 2)
+		if len(kv) != 2 {
+			util.Logger().Errorf(nil, "invalid naming rules override '%s'", item)
+			continue
+		}
+		tenant := strings.TrimSpace(item[:idx])
+		rules, ok := overrides[tenant]
+		if !ok {
+			rules = def.copy()
+		}
+		if err := rules.set(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])); err != nil {
+			util.Logger().Errorf(err, "invalid naming rules override '%s'", item)
+			continue
+		}
+		overrides[tenant] = rules
+	}
+	return overrides
+}
+
+func New() mgr.PluginInstance {
+	def := make(Rules)
+	for _, f := range fields {
+		for _, rule := range []string{"pattern", "max_length", "charset"} {
+			key := f.name + "_" + rule
+			value := beego.AppConfig.String("naming_" + key)
+			if len(value) == 0 {
+				continue
+			}
+			if err := def.set(key, value); err != nil {
+				util.Logger().Errorf(err, "invalid naming_%s '%s'", key, value)
+			}
+		}
+	}
+	return &BuildinPolicy{
+		Default:   def,
+		Overrides: ParseOverrides(def, beego.AppConfig.String("naming_rules_overrides")),
+	}
+}
+
+// BuildinPolicy 按app.conf中配置的规则检查服务命名，租户没有单独配置时使用默认规则
+type BuildinPolicy struct {
+	Default Rules
+	// key为domain或domain/project
+	Overrides map[string]Rules
+}
+
+// Rules 依次查找domain/project、domain的规则，都没有时使用默认规则
+func (p *BuildinPolicy) Rules(domainProject string) Rules {
+	if rs, ok := p.Overrides[domainProject]; ok {
+		return rs
+	}
+	if idx := strings.Index(domainProject, "/"); idx > 0 {
+		if rs, ok := p.Overrides[domainProject[:idx]]; ok {
+			return rs
+		}
+	}
+	return p.Default
+}
+
+func (p *BuildinPolicy) Check(ctx context.Context, domainProject string, service *pb.MicroService) []*naming.Violation {
+	rules := p.Rules(domainProject)
+	var violations []*naming.Violation
+	for _, f := range fields {
+		rule, ok := rules[f.name]
+		if !ok {
+			continue
+		}
+		value := f.value(service)
+		if reason := rule.Check(value); len(reason) > 0 {
+			violations = append(violations, &naming.Violation{Field: f.field, Value: value, Reason: reason})
+		}
+	}
+	return violations
+}
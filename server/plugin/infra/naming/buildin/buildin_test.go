@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package buildin
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"golang.org/x/net/context"
+	"testing"
+)
+
+func TestBuildinPolicy(t *testing.T) {
+	def := make(Rules)
+	for k, v := range map[string]string{
+		"service_name_charset":    "a-z0-9-",
+		"service_name_max_length": "16",
+		"version_pattern":         `^[0-9]+\.[0-9]+\.[0-9]+$`,
+	} {
+		if err := def.set(k, v); err != nil {
+			t.Fatalf("set rule %s failed, %s", k, err.Error())
+		}
+	}
+	if err := def.set("service_name_unknown", "1"); err == nil {
+		t.Fatalf("set unknown rule should fail")
+	}
+	p := &BuildinPolicy{
+		Default:   def,
+		Overrides: ParseOverrides(def, "acme:app_id_pattern=^acme-[a-z]{1,8}$; acme:service_name_max_length=0;bad;acme/dev:x=1"),
+	}
+
+	ctx := context.Background()
+	service := &pb.MicroService{AppId: "default", ServiceName: "order-service", Version: "1.0.0"}
+	if vs := p.Check(ctx, "default/default", service); len(vs) != 0 {
+		t.Fatalf("Check conforming service failed, %v", vs)
+	}
+
+	service = &pb.MicroService{AppId: "default", ServiceName: "Order_Service", Version: "1.0"}
+	vs := p.Check(ctx, "default/default", service)
+	if len(vs) != 2 || vs[0].Field != "serviceName" || vs[1].Field != "version" {
+		t.Fatalf("Check violating service failed, %v", vs)
+	}
+	if vs[0].Reason != "contains the character 'O' out of the charset [a-z0-9-]" {
+		t.Fatalf("unexpected reason, %s", vs[0].Reason)
+	}
+
+	// 租户规则继承默认规则并覆盖max_length
+	service = &pb.MicroService{AppId: "default", ServiceName: "a-very-long-service-name", Version: "1.0.0"}
+	if vs := p.Check(ctx, "default/default", service); len(vs) != 1 {
+		t.Fatalf("Check max length failed, %v", vs)
+	}
+	vs = p.Check(ctx, "acme/dev", service)
+	if len(vs) != 1 || vs[0].Field != "appId" {
+		t.Fatalf("Check domain rules failed, %v", vs)
+	}
+	service.AppId = "acme-shop"
+	if vs := p.Check(ctx, "acme/dev", service); len(vs) != 0 {
+		t.Fatalf("Check domain rules failed, %v", vs)
+	}
+	if len(p.Overrides) != 1 {
+		t.Fatalf("ParseOverrides failed, %v", p.Overrides)
+	}
+}
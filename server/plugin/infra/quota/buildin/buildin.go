@@ -25,6 +25,8 @@ import (
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
 	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
 	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"github.com/astaxie/beego"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/net/context"
 	"strings"
 )
@@ -32,15 +34,33 @@ import (
 const (
 	SERVICE_NUM_MAX_LIMIT            = 12000
 	INSTANCE_NUM_MAX_LIMIT           = 150000
+	STATIC_INSTANCE_NUM_MAX_LIMIT    = 10000
 	RULE_NUM_MAX_LIMIT_PER_SERVICE   = 100
 	SCHEMA_NUM_MAX_LIMIT_PER_SERVICE = 1000
 	TAG_NUM_MAX_LIMIT_PER_SERVICE    = 100
 )
 
+// 服务、实例总数超过上限后仍允许的超额百分比，0表示超过上限即拒绝
+var overagePercent int64
+
+var overageApplies = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "service_center",
+		Subsystem: "quota",
+		Name:      "overage_total",
+		Help:      "Counter of quota applications allowed in the grace overage",
+	}, []string{"resource"})
+
 func init() {
 	core.SchemaIdRule.Length = SCHEMA_NUM_MAX_LIMIT_PER_SERVICE
 	core.TagRule.Length = TAG_NUM_MAX_LIMIT_PER_SERVICE
 
+	overagePercent = beego.AppConfig.DefaultInt64("quota_overage_percent", 0)
+	if overagePercent < 0 {
+		overagePercent = 0
+	}
+	prometheus.MustRegister(overageApplies)
+
 	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.QUOTA, "buildin", New})
 }
 
@@ -59,16 +79,35 @@ func (q *BuildInQuota) Apply4Quotas(ctx context.Context, quotaType quota.Resourc
 	}
 	switch quotaType {
 	case quota.MicroServiceInstanceQuotaType:
-		isOk, err := instanceQuotaCheck(ctx, data)
-		return nil, isOk, err
+		return instanceQuotaCheck(ctx, data)
+	case quota.StaticInstanceQuotaType:
+		return staticInstanceQuotaCheck(ctx, data)
 	case quota.MicroServiceQuotaType:
-		isOk, err := serviceQuotaCheck(ctx, data)
-		return nil, isOk, err
+		return serviceQuotaCheck(ctx, data)
 	default:
 		return ResourceLimitHandler(ctx, quotaType, domainProject, serviceId, quotaSize)
 	}
 }
 
+func (q *BuildInQuota) GetLimit(quotaType quota.ResourceType) int64 {
+	switch quotaType {
+	case quota.MicroServiceInstanceQuotaType:
+		return getInstanceMaxLimit()
+	case quota.StaticInstanceQuotaType:
+		return STATIC_INSTANCE_NUM_MAX_LIMIT
+	case quota.MicroServiceQuotaType:
+		return getServiceMaxLimit()
+	case quota.RuleQuotaType:
+		return RULE_NUM_MAX_LIMIT_PER_SERVICE
+	case quota.SchemaQuotaType:
+		return SCHEMA_NUM_MAX_LIMIT_PER_SERVICE
+	case quota.TagQuotaType:
+		return TAG_NUM_MAX_LIMIT_PER_SERVICE
+	default:
+		return 0
+	}
+}
+
 //向配额中心上报配额使用量
 func (q *BuildInQuota) RemandQuotas(ctx context.Context, quotaType quota.ResourceType) {
 }
@@ -129,20 +168,50 @@ type QuotaApplyData struct {
 type GetCurUsedNum func(context.Context, *QuotaApplyData) (int64, error)
 type GetLimitQuota func() int64
 
-func quotaCheck(ctx context.Context, data *QuotaApplyData, getLimitQuota GetLimitQuota, getCurUsedNum GetCurUsedNum) (bool, error) {
+// overageReporter 在宽限超额内申请成功时返回，用于在响应中提示
+type overageReporter struct {
+	quotaType quota.ResourceType
+	used      int64
+	limit     int64
+}
+
+func (r *overageReporter) ReportUsedQuota(ctx context.Context) error {
+	return nil
+}
+
+func (r *overageReporter) Close() {
+}
+
+func (r *overageReporter) Warning() string {
+	return fmt.Sprintf("%s quota is exceeded, used %d of %d, the grace overage is %d%%",
+		r.quotaType, r.used, r.limit, overagePercent)
+}
+
+func softLimit(limit int64) int64 {
+	return limit + limit*overagePercent/100
+}
+
+func quotaCheck(ctx context.Context, quotaType quota.ResourceType, data *QuotaApplyData, getLimitQuota GetLimitQuota, getCurUsedNum GetCurUsedNum) (quota.QuotaReporter, bool, error) {
 	limitQuota := getLimitQuota()
 	curNum, err := getCurUsedNum(ctx, data)
 	if err != nil {
-		return false, err
+		return nil, false, err
+	}
+	used := curNum + data.quotaSize
+	if used <= limitQuota {
+		return nil, true, nil
 	}
-	if curNum+data.quotaSize > limitQuota {
-		return false, nil
+	if used > softLimit(limitQuota) {
+		return nil, false, nil
 	}
-	return true, nil
+	util.Logger().Warnf(nil, "%s quota(%d) is exceeded by domain %s, used %d, allowed in the grace overage",
+		quotaType, limitQuota, data.domain, used)
+	overageApplies.WithLabelValues(quotaType.String()).Inc()
+	return &overageReporter{quotaType: quotaType, used: used, limit: limitQuota}, true, nil
 }
 
-func instanceQuotaCheck(ctx context.Context, data *QuotaApplyData) (isOk bool, err error) {
-	isOk, err = quotaCheck(ctx, data, getInstanceMaxLimit, getAllInstancesNum)
+func instanceQuotaCheck(ctx context.Context, data *QuotaApplyData) (reporter quota.QuotaReporter, isOk bool, err error) {
+	reporter, isOk, err = quotaCheck(ctx, quota.MicroServiceInstanceQuotaType, data, getInstanceMaxLimit, getAllInstancesNum)
 	if err != nil {
 		util.Logger().Errorf(err, "instance quota check failed")
 		return
@@ -169,13 +238,43 @@ func getInstancesNum(ctx context.Context, key string) (int64, error) {
 	return resp.Count, nil
 }
 
+// getAllInstancesNum 静态实例单独统计，不占用实例配额
 func getAllInstancesNum(ctx context.Context, data *QuotaApplyData) (int64, error) {
 	key := core.GetInstanceRootKey("")
-	return getInstancesNum(ctx, key)
+	num, err := getInstancesNum(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	staticNum, err := serviceUtil.GetStaticInstancesCount(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+	return num - staticNum, nil
+}
+
+func staticInstanceQuotaCheck(ctx context.Context, data *QuotaApplyData) (reporter quota.QuotaReporter, isOk bool, err error) {
+	reporter, isOk, err = quotaCheck(ctx, quota.StaticInstanceQuotaType, data, getStaticInstanceMaxLimit, getAllStaticInstancesNum)
+	if err != nil {
+		util.Logger().Errorf(err, "static instance quota check failed")
+		return
+	}
+	if !isOk {
+		util.Logger().Errorf(err, "no quota to create static instance")
+		return
+	}
+	return
+}
+
+func getStaticInstanceMaxLimit() int64 {
+	return STATIC_INSTANCE_NUM_MAX_LIMIT
+}
+
+func getAllStaticInstancesNum(ctx context.Context, data *QuotaApplyData) (int64, error) {
+	return serviceUtil.GetStaticInstancesCount(ctx, "")
 }
 
-func serviceQuotaCheck(ctx context.Context, data *QuotaApplyData) (isOk bool, err error) {
-	isOk, err = quotaCheck(ctx, data, getServiceMaxLimit, getAllServicesNum)
+func serviceQuotaCheck(ctx context.Context, data *QuotaApplyData) (reporter quota.QuotaReporter, isOk bool, err error) {
+	reporter, isOk, err = quotaCheck(ctx, quota.MicroServiceQuotaType, data, getServiceMaxLimit, getAllServicesNum)
 	if err != nil {
 		util.Logger().Errorf(err, "service quota check failed")
 		return
@@ -0,0 +1,205 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dualwrite
+
+import (
+	"bytes"
+	"errors"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"golang.org/x/net/context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const MAX_CHECK_SAMPLES = 10
+
+var errSwitchedDuringCheck = errors.New("registry backend is switched during the check")
+
+// readAll 读取后端的所有数据，不包括迁移自身的数据
+func readAll(ctx context.Context, b registry.Registry) (map[string]*mvccpb.KeyValue, error) {
+	resp, err := b.Do(ctx, registry.GET,
+		registry.WithStrKey(core.GetRootKey()+"/"),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	exclude := core.GetStorageMigrationRootKey() + "/"
+	kvs := make(map[string]*mvccpb.KeyValue, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		if strings.HasPrefix(key, exclude) {
+			continue
+		}
+		kvs[key] = kv
+	}
+	return kvs, nil
+}
+
+// diffKeys 返回不一致的key，依次为另一个后端缺少、多出和值不同的
+func diffKeys(primary, secondary map[string]*mvccpb.KeyValue) (missing, extra, different []string) {
+	for key, kv := range primary {
+		skv, ok := secondary[key]
+		switch {
+		case !ok:
+			missing = append(missing, key)
+		case !bytes.Equal(kv.Value, skv.Value):
+			different = append(different, key)
+		}
+	}
+	for key := range secondary {
+		if _, ok := primary[key]; !ok {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(extra)
+	sort.Strings(different)
+	return
+}
+
+// diff 读取主后端后等待之前的变更复制完再读取另一个后端，读取期间新的变更造成的差异再逐个确认
+func (r *DualWriteRegistry) diff(ctx context.Context) (int, map[string]*mvccpb.KeyValue, [3][]string, error) {
+	var result [3][]string
+	p := r.primaryIndex()
+	primary, err := readAll(ctx, r.backends[p])
+	if err != nil {
+		return p, nil, result, err
+	}
+	if !r.mirror.Barrier(DEFAULT_DRAIN_TIMEOUT) {
+		util.Logger().Warnf(nil, "mirror queue is not drained before the consistency check")
+	}
+	secondary, err := readAll(ctx, r.backends[TARGET-p])
+	if err != nil {
+		return p, nil, result, err
+	}
+
+	missing, extra, different := diffKeys(primary, secondary)
+	for i, keys := range [][]string{missing, extra, different} {
+		for _, key := range keys {
+			resp, err := r.backends[p].Do(ctx, registry.GET, registry.WithStrKey(key))
+			if err != nil {
+				return p, nil, result, err
+			}
+			skv, ok := secondary[key]
+			switch {
+			case len(resp.Kvs) == 0 && !ok:
+				continue
+			case len(resp.Kvs) > 0 && ok && bytes.Equal(resp.Kvs[0].Value, skv.Value):
+				continue
+			case len(resp.Kvs) > 0:
+				primary[key] = resp.Kvs[0]
+			default:
+				delete(primary, key)
+			}
+			result[i] = append(result[i], key)
+		}
+	}
+	if r.primaryIndex() != p {
+		return p, nil, result, errSwitchedDuringCheck
+	}
+	return p, primary, result, nil
+}
+
+// CheckConsistency 同一时间只执行一次检查或同步
+func (r *DualWriteRegistry) CheckConsistency(ctx context.Context) (*registry.MigrationCheck, error) {
+	r.checkLock.Lock()
+	defer r.checkLock.Unlock()
+	return r.check(ctx)
+}
+
+func (r *DualWriteRegistry) check(ctx context.Context) (*registry.MigrationCheck, error) {
+	_, primary, result, err := r.diff(ctx)
+	if err != nil {
+		util.Logger().Errorf(err, "check the consistency of storage migration failed")
+		return nil, err
+	}
+	check := &registry.MigrationCheck{
+		Timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+		Keys:      int64(len(primary)),
+		Missing:   int64(len(result[0])),
+		Extra:     int64(len(result[1])),
+		Different: int64(len(result[2])),
+	}
+	for _, keys := range result {
+		for _, key := range keys {
+			if len(check.Samples) >= MAX_CHECK_SAMPLES {
+				break
+			}
+			check.Samples = append(check.Samples, key)
+		}
+	}
+	inconsistent := check.Missing + check.Extra + check.Different
+	check.Consistent = inconsistent == 0
+	r.lastCheck.Store(check)
+	inconsistentGauge.Set(float64(inconsistent))
+	if !check.Consistent {
+		util.Logger().Warnf(nil, "storage migration is inconsistent, %d missing, %d extra, %d different, samples %v",
+			check.Missing, check.Extra, check.Different, check.Samples)
+	}
+	return check, nil
+}
+
+// Sync 不一致的key按主后端的当前值修正，修正操作在写锁内读取并入队，保证排在之后的变更前面
+func (r *DualWriteRegistry) Sync(ctx context.Context) (int64, error) {
+	r.checkLock.Lock()
+	defer r.checkLock.Unlock()
+
+	r.mirror.ResetLosses()
+	p, _, result, err := r.diff(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var fixed int64
+	for _, keys := range result {
+		for _, key := range keys {
+			if err := r.syncKey(ctx, p, key); err != nil {
+				util.Logger().Errorf(err, "sync key %s of storage migration failed", key)
+				return fixed, err
+			}
+			fixed++
+		}
+	}
+	util.Logger().Warnf(nil, "sync %d key(s) from %s backend to %s backend", fixed, sideName(p), sideName(TARGET-p))
+	_, err = r.check(ctx)
+	return fixed, err
+}
+
+func (r *DualWriteRegistry) syncKey(ctx context.Context, p int, key string) error {
+	r.switchLock.Lock()
+	defer r.switchLock.Unlock()
+	if r.primaryIndex() != p {
+		return errSwitchedDuringCheck
+	}
+	resp, err := r.backends[p].Do(ctx, registry.GET, registry.WithStrKey(key))
+	if err != nil {
+		return err
+	}
+	op := registry.OpDel(registry.WithStrKey(key))
+	if len(resp.Kvs) > 0 {
+		kv := resp.Kvs[0]
+		op = registry.OpPut(registry.WithStrKey(key), registry.WithValue(kv.Value), registry.WithLease(kv.Lease))
+	}
+	if !r.mirror.EnqueueWait(&mutation{From: p, Ops: []registry.PluginOp{op}}, DEFAULT_DRAIN_TIMEOUT) {
+		return errors.New("mirror queue is full")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dualwrite
+
+import (
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"reflect"
+	"testing"
+)
+
+func kvs(pairs ...string) map[string]*mvccpb.KeyValue {
+	m := make(map[string]*mvccpb.KeyValue, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		m[pairs[i]] = &mvccpb.KeyValue{Key: []byte(pairs[i]), Value: []byte(pairs[i+1])}
+	}
+	return m
+}
+
+func TestDiffKeys(t *testing.T) {
+	missing, extra, different := diffKeys(
+		kvs("/a", "1", "/b", "2", "/c", "3", "/e", "5"),
+		kvs("/a", "1", "/c", "x", "/d", "4", "/e", "x"))
+	if !reflect.DeepEqual(missing, []string{"/b"}) {
+		t.Fatalf("TestDiffKeys failed, missing %v", missing)
+	}
+	if !reflect.DeepEqual(extra, []string{"/d"}) {
+		t.Fatalf("TestDiffKeys failed, extra %v", extra)
+	}
+	if !reflect.DeepEqual(different, []string{"/c", "/e"}) {
+		t.Fatalf("TestDiffKeys failed, different %v", different)
+	}
+
+	missing, extra, different = diffKeys(kvs("/a", "1"), kvs("/a", "1"))
+	if len(missing)+len(extra)+len(different) != 0 {
+		t.Fatalf("TestDiffKeys failed")
+	}
+}
+
+func TestLeaseEntry(t *testing.T) {
+	e := &leaseEntry{Source: 1, Target: 2}
+	if e.id(SOURCE) != 1 || e.id(TARGET) != 2 {
+		t.Fatalf("TestLeaseEntry failed")
+	}
+}
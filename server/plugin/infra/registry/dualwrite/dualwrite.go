@@ -0,0 +1,468 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dualwrite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/apache/incubator-servicecomb-service-center/server/scheduler"
+	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	SOURCE = 0
+	TARGET = 1
+
+	DEFAULT_SOURCE_PLUGIN  = "etcd"
+	DEFAULT_TARGET_PLUGIN  = "etcd_migration"
+	DEFAULT_QUEUE_SIZE     = 10000
+	DEFAULT_CHECK_INTERVAL = 5 * time.Minute
+	// 其他节点切换后，本节点最多延迟该时间跟随
+	DEFAULT_STATE_INTERVAL = 5 * time.Second
+	// 切换前等待复制队列清空的最长时间
+	DEFAULT_DRAIN_TIMEOUT = 30 * time.Second
+)
+
+// ErrSwitched 切换后进行中的Watch返回该错误，缓存从新的主后端重新list
+var ErrSwitched = errors.New("registry backend is switched")
+
+func init() {
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.REGISTRY, "dualwrite", NewRegistry})
+}
+
+// migrationState 保存在目标后端，所有节点按其切换主后端
+type migrationState struct {
+	Primary   string `json:"primary"`
+	Timestamp string `json:"timestamp"`
+}
+
+// DualWriteRegistry 在两个后端间迁移：读请求只访问主后端，变更写入主后端成功后按顺序异步复制到另一个后端，
+// 租约ID在两个后端不同，由leases映射；迁移开始时主后端为source，Cutover后为target，可以再切换回来
+type DualWriteRegistry struct {
+	names    [2]string
+	backends [2]registry.Registry
+	primary  int32
+
+	// 变更持有读锁，切换时持有写锁，保证切换前主后端的变更都已进入复制队列
+	switchLock sync.RWMutex
+	// 切换时关闭，通知进行中的Watch返回
+	switched    chan struct{}
+	cutoverTime string
+
+	mirror *mirror
+	leases *leaseMapper
+
+	checkLock sync.Mutex
+	lastCheck atomic.Value
+
+	err       chan error
+	ready     chan int
+	goroutine *util.GoRoutine
+}
+
+func (r *DualWriteRegistry) Err() <-chan error {
+	return r.err
+}
+
+func (r *DualWriteRegistry) Ready() <-chan int {
+	return r.ready
+}
+
+func (r *DualWriteRegistry) Close() {
+	r.goroutine.Close(true)
+	// 启动失败时mirror和后端可能未创建
+	if r.mirror != nil {
+		r.mirror.Stop()
+	}
+	for _, b := range r.backends {
+		if b != nil {
+			b.Close()
+		}
+	}
+	util.Logger().Debugf("dual write registry stopped.")
+}
+
+func (r *DualWriteRegistry) primaryIndex() int {
+	return int(atomic.LoadInt32(&r.primary))
+}
+
+func (r *DualWriteRegistry) Primary() registry.Registry {
+	return r.backends[r.primaryIndex()]
+}
+
+func sideName(i int) string {
+	if i == TARGET {
+		return registry.MIGRATION_TARGET
+	}
+	return registry.MIGRATION_SOURCE
+}
+
+// Status 后端存储状态取主后端的
+func (r *DualWriteRegistry) Status(ctx context.Context) (*registry.BackendStatus, error) {
+	reporter, ok := r.Primary().(registry.StatusReporter)
+	if !ok {
+		return nil, fmt.Errorf("registry plugin %s does not report status", r.names[r.primaryIndex()])
+	}
+	return reporter.Status(ctx)
+}
+
+func withOp(op registry.PluginOp) registry.PluginOpOption {
+	return func(o *registry.PluginOp) { *o = op }
+}
+
+func (r *DualWriteRegistry) Do(ctx context.Context, opts ...registry.PluginOpOption) (*registry.PluginResponse, error) {
+	op := registry.OptionsToOp(opts...)
+	if op.Action == registry.Get {
+		return r.Primary().Do(ctx, opts...)
+	}
+
+	r.switchLock.RLock()
+	defer r.switchLock.RUnlock()
+	p := r.primaryIndex()
+	pop := op
+	pop.Lease = r.leases.Resolve(ctx, p, op.Lease)
+	resp, err := r.backends[p].Do(ctx, withOp(pop))
+	if err != nil {
+		return nil, err
+	}
+	r.mirror.Enqueue(&mutation{From: p, Ops: []registry.PluginOp{op}})
+	return resp, nil
+}
+
+func (r *DualWriteRegistry) PutNoOverride(ctx context.Context, opts ...registry.PluginOpOption) (bool, error) {
+	op := registry.OptionsToOp(opts...)
+
+	r.switchLock.RLock()
+	defer r.switchLock.RUnlock()
+	p := r.primaryIndex()
+	pop := op
+	pop.Lease = r.leases.Resolve(ctx, p, op.Lease)
+	ok, err := r.backends[p].PutNoOverride(ctx, withOp(pop))
+	if err != nil || !ok {
+		return ok, err
+	}
+	op.Action = registry.Put
+	r.mirror.Enqueue(&mutation{From: p, Ops: []registry.PluginOp{op}})
+	return true, nil
+}
+
+func (r *DualWriteRegistry) Txn(ctx context.Context, ops []registry.PluginOp) (*registry.PluginResponse, error) {
+	r.switchLock.RLock()
+	defer r.switchLock.RUnlock()
+	p := r.primaryIndex()
+	resp, err := r.backends[p].Txn(ctx, r.leases.ResolveOps(ctx, p, ops))
+	if err != nil {
+		return nil, err
+	}
+	r.mirror.Enqueue(&mutation{From: p, Ops: ops})
+	return resp, nil
+}
+
+// TxnWithCmp 比较条件只在主后端执行，另一个后端的revision不同，按主后端的结果复制对应分支的操作
+func (r *DualWriteRegistry) TxnWithCmp(ctx context.Context, success []registry.PluginOp, cmps []registry.CompareOp,
+	fail []registry.PluginOp) (*registry.PluginResponse, error) {
+	r.switchLock.RLock()
+	defer r.switchLock.RUnlock()
+	p := r.primaryIndex()
+	resp, err := r.backends[p].TxnWithCmp(ctx, r.leases.ResolveOps(ctx, p, success), cmps,
+		r.leases.ResolveOps(ctx, p, fail))
+	if err != nil {
+		return nil, err
+	}
+	ops := fail
+	if resp.Succeeded {
+		ops = success
+	}
+	if len(ops) > 0 {
+		r.mirror.Enqueue(&mutation{From: p, Ops: ops})
+	}
+	return resp, nil
+}
+
+func (r *DualWriteRegistry) LeaseGrant(ctx context.Context, TTL int64) (int64, error) {
+	r.switchLock.RLock()
+	defer r.switchLock.RUnlock()
+	p := r.primaryIndex()
+	leaseID, err := r.backends[p].LeaseGrant(ctx, TTL)
+	if err != nil {
+		return 0, err
+	}
+	r.mirror.Enqueue(&mutation{From: p, Action: LEASE_GRANT, Lease: leaseID, TTL: TTL})
+	return leaseID, nil
+}
+
+func (r *DualWriteRegistry) LeaseRenew(ctx context.Context, leaseID int64) (int64, error) {
+	r.switchLock.RLock()
+	defer r.switchLock.RUnlock()
+	p := r.primaryIndex()
+	TTL, err := r.backends[p].LeaseRenew(ctx, r.leases.Resolve(ctx, p, leaseID))
+	if err != nil {
+		return 0, err
+	}
+	r.mirror.Enqueue(&mutation{From: p, Action: LEASE_RENEW, Lease: leaseID, TTL: TTL})
+	return TTL, nil
+}
+
+func (r *DualWriteRegistry) LeaseRevoke(ctx context.Context, leaseID int64) error {
+	r.switchLock.RLock()
+	defer r.switchLock.RUnlock()
+	p := r.primaryIndex()
+	if err := r.backends[p].LeaseRevoke(ctx, r.leases.Resolve(ctx, p, leaseID)); err != nil {
+		return err
+	}
+	r.mirror.Enqueue(&mutation{From: p, Action: LEASE_REVOKE, Lease: leaseID})
+	return nil
+}
+
+// Watch 只监听主后端，切换后返回ErrSwitched，两个后端的revision不同，由调用方重新list
+func (r *DualWriteRegistry) Watch(ctx context.Context, opts ...registry.PluginOpOption) error {
+	r.switchLock.RLock()
+	b, switched := r.Primary(), r.switched
+	r.switchLock.RUnlock()
+
+	wCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-switched:
+			cancel()
+		case <-wCtx.Done():
+		}
+	}()
+	err := b.Watch(wCtx, opts...)
+	select {
+	case <-switched:
+		return ErrSwitched
+	default:
+		return err
+	}
+}
+
+func (r *DualWriteRegistry) MigrationStatus() *registry.MigrationStatus {
+	r.switchLock.RLock()
+	cutoverTime := r.cutoverTime
+	r.switchLock.RUnlock()
+	status := &registry.MigrationStatus{
+		Source:      r.names[SOURCE],
+		Target:      r.names[TARGET],
+		Primary:     sideName(r.primaryIndex()),
+		CutoverTime: cutoverTime,
+	}
+	status.Pending, status.LagSeconds = r.mirror.Lag()
+	status.Failed, status.Dropped = r.mirror.Losses()
+	status.LastCheck, _ = r.lastCheck.Load().(*registry.MigrationCheck)
+	return status
+}
+
+// Cutover 切换主后端，先写入目标后端中的状态，其他节点定期读取后跟随切换
+func (r *DualWriteRegistry) Cutover(ctx context.Context, force bool) error {
+	r.switchLock.Lock()
+	defer r.switchLock.Unlock()
+
+	if !force {
+		if !r.mirror.Drain(DEFAULT_DRAIN_TIMEOUT) {
+			return errors.New("mirror queue is not drained, retry later or force")
+		}
+		if failed, dropped := r.mirror.Losses(); failed > 0 || dropped > 0 {
+			return fmt.Errorf("%d mutation(s) failed and %d dropped, sync first or force", failed, dropped)
+		}
+		check, _ := r.lastCheck.Load().(*registry.MigrationCheck)
+		if check == nil || !check.Consistent {
+			return errors.New("the last consistency check is not passed, check or sync first or force")
+		}
+	}
+
+	next := TARGET - r.primaryIndex()
+	if err := r.saveState(ctx, next); err != nil {
+		return err
+	}
+	r.switchTo(next)
+	return nil
+}
+
+// switchTo 调用方持有switchLock写锁
+func (r *DualWriteRegistry) switchTo(i int) {
+	if r.primaryIndex() == i {
+		return
+	}
+	atomic.StoreInt32(&r.primary, int32(i))
+	r.cutoverTime = strconv.FormatInt(time.Now().Unix(), 10)
+	close(r.switched)
+	r.switched = make(chan struct{})
+	primaryGauge.Set(float64(i))
+	util.Logger().Warnf(nil, "registry primary backend is switched to %s %s", sideName(i), r.names[i])
+}
+
+func (r *DualWriteRegistry) saveState(ctx context.Context, primary int) error {
+	data, err := json.Marshal(&migrationState{
+		Primary:   sideName(primary),
+		Timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.backends[TARGET].Do(ctx, registry.PUT,
+		registry.WithStrKey(core.GenerateStorageMigrationStateKey()),
+		registry.WithValue(data))
+	return err
+}
+
+func (r *DualWriteRegistry) loadState(ctx context.Context) (int, error) {
+	resp, err := r.backends[TARGET].Do(ctx, registry.GET,
+		registry.WithStrKey(core.GenerateStorageMigrationStateKey()))
+	if err != nil {
+		return SOURCE, err
+	}
+	if len(resp.Kvs) == 0 {
+		return SOURCE, nil
+	}
+	state := &migrationState{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, state); err != nil {
+		return SOURCE, err
+	}
+	if state.Primary == registry.MIGRATION_TARGET {
+		return TARGET, nil
+	}
+	return SOURCE, nil
+}
+
+// followState 跟随其他节点的切换，切换前等待本节点的复制队列清空
+func (r *DualWriteRegistry) followState(ctx context.Context) {
+	i, err := r.loadState(ctx)
+	if err != nil {
+		util.Logger().Errorf(err, "load storage migration state failed")
+		return
+	}
+	if i == r.primaryIndex() {
+		return
+	}
+	r.switchLock.Lock()
+	if !r.mirror.Drain(DEFAULT_DRAIN_TIMEOUT) {
+		util.Logger().Warnf(nil, "mirror queue is not drained before following the cutover")
+	}
+	r.switchTo(i)
+	r.switchLock.Unlock()
+}
+
+func (r *DualWriteRegistry) run() {
+	r.goroutine.Do(func(stopCh <-chan struct{}) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(DEFAULT_STATE_INTERVAL):
+				r.followState(ctx)
+			}
+		}
+	})
+	scheduler.Register(&scheduler.Job{
+		Name:      "storage_migration_check",
+		Priority:  scheduler.PRIORITY_LOW,
+		Interval:  checkInterval(),
+		Singleton: true,
+		Func: func(ctx context.Context) error {
+			_, err := r.CheckConsistency(ctx)
+			return err
+		},
+	})
+}
+
+func checkInterval() time.Duration {
+	v := beego.AppConfig.DefaultString("migration_check_interval", DEFAULT_CHECK_INTERVAL.String())
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		util.Logger().Errorf(err, "invalid migration_check_interval '%s', use %s", v, DEFAULT_CHECK_INTERVAL)
+		return DEFAULT_CHECK_INTERVAL
+	}
+	return d
+}
+
+func newBackend(name string) (registry.Registry, error) {
+	p := mgr.Plugins().Get(mgr.REGISTRY, name)
+	if p == nil || p.Name == "dualwrite" {
+		return nil, fmt.Errorf("invalid registry plugin '%s'", name)
+	}
+	util.Logger().Infof("new storage migration backend '%s'", name)
+	b, ok := p.New().(registry.Registry)
+	if !ok {
+		return nil, fmt.Errorf("registry plugin '%s' is not a registry", name)
+	}
+	return b, nil
+}
+
+func NewRegistry() mgr.PluginInstance {
+	inst := &DualWriteRegistry{
+		names: [2]string{
+			beego.AppConfig.DefaultString("migration_source_plugin", DEFAULT_SOURCE_PLUGIN),
+			beego.AppConfig.DefaultString("migration_target_plugin", DEFAULT_TARGET_PLUGIN),
+		},
+		switched:  make(chan struct{}),
+		err:       make(chan error, 1),
+		ready:     make(chan int),
+		goroutine: util.NewGo(make(chan struct{})),
+	}
+	util.Logger().Warnf(nil, "starting service center in storage migration mode, from %s to %s",
+		inst.names[SOURCE], inst.names[TARGET])
+
+	for i, name := range inst.names {
+		b, err := newBackend(name)
+		if err != nil {
+			util.Logger().Errorf(err, "start storage migration failed")
+			inst.err <- err
+			return inst
+		}
+		select {
+		case err := <-b.Err():
+			util.Logger().Errorf(err, "start storage migration %s backend %s failed", sideName(i), name)
+			inst.err <- err
+			return inst
+		case <-b.Ready():
+		}
+		inst.backends[i] = b
+	}
+
+	inst.leases = newLeaseMapper(inst.backends)
+	inst.mirror = newMirror(inst.backends, inst.leases, beego.AppConfig.DefaultInt("migration_queue_size", DEFAULT_QUEUE_SIZE))
+
+	ctx, cancel := registry.WithTimeout(context.Background())
+	primary, err := inst.loadState(ctx)
+	cancel()
+	if err != nil {
+		util.Logger().Errorf(err, "load storage migration state failed")
+		inst.err <- err
+		return inst
+	}
+	inst.primary = int32(primary)
+	primaryGauge.Set(float64(primary))
+
+	inst.mirror.Run()
+	inst.run()
+	close(inst.ready)
+	return inst
+}
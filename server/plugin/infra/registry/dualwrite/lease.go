@@ -0,0 +1,210 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dualwrite
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"strconv"
+	"sync"
+)
+
+const MAX_CACHED_LEASES = 100000
+
+// leaseEntry 同一租约在两个后端的ID
+type leaseEntry struct {
+	Source int64 `json:"source"`
+	Target int64 `json:"target"`
+}
+
+func (e *leaseEntry) id(i int) int64 {
+	if i == TARGET {
+		return e.Target
+	}
+	return e.Source
+}
+
+// leaseMapper 调用方持有的是创建租约时主后端的ID，切换前后两种ID都可能出现；
+// 映射以两个ID为key保存在目标后端，附加目标后端的租约随之过期，所有节点共享
+type leaseMapper struct {
+	backends [2]registry.Registry
+	lock     sync.RWMutex
+	entries  map[int64]*leaseEntry
+}
+
+func leaseKey(id int64) string {
+	return core.GenerateStorageMigrationLeaseKey(strconv.FormatInt(id, 10))
+}
+
+func (m *leaseMapper) cache(e *leaseEntry) {
+	m.lock.Lock()
+	if len(m.entries) >= MAX_CACHED_LEASES {
+		// 过期而未撤销的租约不会被Forget，超过上限时整体清空，之后从目标后端重新加载
+		m.entries = make(map[int64]*leaseEntry)
+	}
+	m.entries[e.Source] = e
+	m.entries[e.Target] = e
+	m.lock.Unlock()
+}
+
+func (m *leaseMapper) get(ctx context.Context, id int64) (*leaseEntry, error) {
+	m.lock.RLock()
+	e, ok := m.entries[id]
+	m.lock.RUnlock()
+	if ok {
+		return e, nil
+	}
+	resp, err := m.backends[TARGET].Do(ctx, registry.GET, registry.WithStrKey(leaseKey(id)))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	e = &leaseEntry{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, e); err != nil {
+		return nil, err
+	}
+	m.cache(e)
+	return e, nil
+}
+
+// Peer 返回租约在后端i的ID，没有映射时返回0
+func (m *leaseMapper) Peer(ctx context.Context, i int, id int64) int64 {
+	e, err := m.get(ctx, id)
+	if err != nil || e == nil {
+		return 0
+	}
+	return e.id(i)
+}
+
+// Resolve 返回租约在后端i的ID，没有映射时说明是后端i创建的ID
+func (m *leaseMapper) Resolve(ctx context.Context, i int, id int64) int64 {
+	if id == 0 {
+		return 0
+	}
+	if peer := m.Peer(ctx, i, id); peer != 0 {
+		return peer
+	}
+	return id
+}
+
+// ResolveOps 有租约时返回替换了租约ID的副本，复制时仍使用调用方的ID
+func (m *leaseMapper) ResolveOps(ctx context.Context, i int, ops []registry.PluginOp) []registry.PluginOp {
+	var resolved []registry.PluginOp
+	for j, op := range ops {
+		if op.Lease == 0 {
+			continue
+		}
+		if resolved == nil {
+			resolved = make([]registry.PluginOp, len(ops))
+			copy(resolved, ops)
+		}
+		resolved[j].Lease = m.Resolve(ctx, i, op.Lease)
+	}
+	if resolved == nil {
+		return ops
+	}
+	return resolved
+}
+
+// Mirror 返回from后端的租约在另一个后端的ID，没有映射时在另一个后端创建，ttl为0时从from后端续约得到，
+// 多个节点同时创建时以先写入映射的为准
+func (m *leaseMapper) Mirror(ctx context.Context, from int, id, ttl int64) (int64, bool, error) {
+	to := TARGET - from
+	e, err := m.get(ctx, id)
+	if err != nil {
+		return 0, false, err
+	}
+	if e != nil {
+		return e.id(to), false, nil
+	}
+
+	if ttl == 0 {
+		if ttl, err = m.backends[from].LeaseRenew(ctx, id); err != nil {
+			return 0, false, err
+		}
+	}
+	peer, err := m.backends[to].LeaseGrant(ctx, ttl)
+	if err != nil {
+		return 0, false, err
+	}
+	e = &leaseEntry{Source: id, Target: peer}
+	if from == TARGET {
+		e = &leaseEntry{Source: peer, Target: id}
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return 0, false, err
+	}
+	ok, err := m.backends[TARGET].PutNoOverride(ctx,
+		registry.WithStrKey(leaseKey(id)),
+		registry.WithValue(data),
+		registry.WithLease(e.Target))
+	if err != nil {
+		m.backends[to].LeaseRevoke(ctx, peer)
+		return 0, false, err
+	}
+	if !ok {
+		m.backends[to].LeaseRevoke(ctx, peer)
+		e, err = m.get(ctx, id)
+		if err != nil {
+			return 0, false, err
+		}
+		if e == nil {
+			return 0, false, fmt.Errorf("lease %d is mapped by other node but not found", id)
+		}
+		return e.id(to), false, nil
+	}
+	_, err = m.backends[TARGET].Do(ctx, registry.PUT,
+		registry.WithStrKey(leaseKey(peer)),
+		registry.WithValue(data),
+		registry.WithLease(e.Target))
+	if err != nil {
+		util.Logger().Errorf(err, "save the mapping of lease %d failed", peer)
+	}
+	m.cache(e)
+	return peer, true, nil
+}
+
+// Forget 租约撤销或失效后删除映射
+func (m *leaseMapper) Forget(ctx context.Context, id int64) {
+	e, _ := m.get(ctx, id)
+	ids := []int64{id}
+	if e != nil {
+		ids = []int64{e.Source, e.Target}
+	}
+	m.lock.Lock()
+	for _, i := range ids {
+		delete(m.entries, i)
+	}
+	m.lock.Unlock()
+	for _, i := range ids {
+		m.backends[TARGET].Do(ctx, registry.DEL, registry.WithStrKey(leaseKey(i)))
+	}
+}
+
+func newLeaseMapper(backends [2]registry.Registry) *leaseMapper {
+	return &leaseMapper{
+		backends: backends,
+		entries:  make(map[int64]*leaseEntry),
+	}
+}
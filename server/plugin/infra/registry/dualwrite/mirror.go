@@ -0,0 +1,297 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package dualwrite
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	MUTATION_OPS = iota
+	LEASE_GRANT
+	LEASE_RENEW
+	LEASE_REVOKE
+	// 复制队列中的屏障，之前的变更都处理完后通知等待方
+	BARRIER
+
+	// 复制失败后的重试次数，首次间隔1s，之后翻倍
+	MIRROR_RETRY_TIMES = 3
+)
+
+var (
+	pendingGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "registry",
+			Name:      "migration_pending",
+			Help:      "Number of the mutations waiting to be mirrored to the secondary backend",
+		})
+
+	lagGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "registry",
+			Name:      "migration_lag_seconds",
+			Help:      "Waiting time of the mutation being mirrored to the secondary backend",
+		})
+
+	mirrored = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "registry",
+			Name:      "migration_mirrored_total",
+			Help:      "Counter of the mutations mirrored to the secondary backend, by success, failure or dropped",
+		}, []string{"result"})
+
+	inconsistentGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "registry",
+			Name:      "migration_inconsistent_keys",
+			Help:      "Number of the inconsistent keys found by the last consistency check",
+		})
+
+	primaryGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "registry",
+			Name:      "migration_primary",
+			Help:      "The primary backend of the storage migration, 0 is the source and 1 is the target",
+		})
+)
+
+func init() {
+	prometheus.MustRegister(pendingGauge, lagGauge, mirrored, inconsistentGauge, primaryGauge)
+}
+
+// mutation 主后端上成功的一次变更，租约ID为调用方看到的ID
+type mutation struct {
+	From   int
+	Action int
+	Ops    []registry.PluginOp
+	Lease  int64
+	TTL    int64
+
+	time time.Time
+	done chan struct{}
+}
+
+// mirror 按主后端上的提交顺序把变更复制到另一个后端，单个goroutine处理保证顺序
+type mirror struct {
+	backends [2]registry.Registry
+	leases   *leaseMapper
+	queue    chan *mutation
+
+	pending int64
+	// 正在处理的变更的入队时间(UnixNano)
+	head    int64
+	failed  int64
+	dropped int64
+
+	goroutine *util.GoRoutine
+}
+
+// Enqueue 队列满时丢弃并计数，不阻塞主后端的写操作，丢失的变更由Sync修正
+func (m *mirror) Enqueue(mu *mutation) {
+	mu.time = time.Now()
+	atomic.AddInt64(&m.pending, 1)
+	select {
+	case m.queue <- mu:
+		pendingGauge.Inc()
+	default:
+		atomic.AddInt64(&m.pending, -1)
+		atomic.AddInt64(&m.dropped, 1)
+		mirrored.WithLabelValues("dropped").Inc()
+	}
+}
+
+// EnqueueWait 等待队列空闲，用于Sync
+func (m *mirror) EnqueueWait(mu *mutation, timeout time.Duration) bool {
+	mu.time = time.Now()
+	atomic.AddInt64(&m.pending, 1)
+	select {
+	case m.queue <- mu:
+		pendingGauge.Inc()
+		return true
+	case <-time.After(timeout):
+		atomic.AddInt64(&m.pending, -1)
+		return false
+	}
+}
+
+// Barrier 等待当前已入队的变更处理完
+func (m *mirror) Barrier(timeout time.Duration) bool {
+	mu := &mutation{Action: BARRIER, done: make(chan struct{})}
+	start := time.Now()
+	if !m.EnqueueWait(mu, timeout) {
+		return false
+	}
+	select {
+	case <-mu.done:
+		return true
+	case <-time.After(timeout - time.Since(start)):
+		return false
+	}
+}
+
+// Drain 等待队列清空，调用方持有switchLock写锁，期间不会有新的变更入队
+func (m *mirror) Drain(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for atomic.LoadInt64(&m.pending) > 0 {
+		if time.Now().After(deadline) {
+			return false
+		}
+		<-time.After(100 * time.Millisecond)
+	}
+	return true
+}
+
+// Lag 返回待复制的变更数和正在处理的变更的等待时间(秒)
+func (m *mirror) Lag() (int, float64) {
+	pending := atomic.LoadInt64(&m.pending)
+	head := atomic.LoadInt64(&m.head)
+	if pending == 0 || head == 0 {
+		return int(pending), 0
+	}
+	return int(pending), time.Since(time.Unix(0, head)).Seconds()
+}
+
+func (m *mirror) Losses() (failed int64, dropped int64) {
+	return atomic.LoadInt64(&m.failed), atomic.LoadInt64(&m.dropped)
+}
+
+// ResetLosses Sync开始时调用，之前丢失的变更由Sync修正
+func (m *mirror) ResetLosses() {
+	atomic.StoreInt64(&m.failed, 0)
+	atomic.StoreInt64(&m.dropped, 0)
+}
+
+func (m *mirror) apply(ctx context.Context, mu *mutation) error {
+	to := TARGET - mu.From
+	switch mu.Action {
+	case LEASE_GRANT:
+		_, _, err := m.leases.Mirror(ctx, mu.From, mu.Lease, mu.TTL)
+		return err
+	case LEASE_RENEW:
+		id, created, err := m.leases.Mirror(ctx, mu.From, mu.Lease, mu.TTL)
+		if err != nil || created {
+			return err
+		}
+		if _, err := m.backends[to].LeaseRenew(ctx, id); err != nil {
+			// 复制延迟等原因导致另一个后端的租约已过期，重新创建，已删除的key由Sync修正
+			m.leases.Forget(ctx, mu.Lease)
+			_, _, err = m.leases.Mirror(ctx, mu.From, mu.Lease, mu.TTL)
+			return err
+		}
+		return nil
+	case LEASE_REVOKE:
+		if id := m.leases.Peer(ctx, to, mu.Lease); id != 0 {
+			m.backends[to].LeaseRevoke(ctx, id)
+		}
+		m.leases.Forget(ctx, mu.Lease)
+		return nil
+	}
+
+	ops := make([]registry.PluginOp, len(mu.Ops))
+	for i, op := range mu.Ops {
+		if op.Lease != 0 {
+			id, _, err := m.leases.Mirror(ctx, mu.From, op.Lease, 0)
+			if err != nil {
+				return err
+			}
+			op.Lease = id
+		}
+		ops[i] = op
+	}
+	if len(ops) == 1 {
+		_, err := m.backends[to].Do(ctx, withOp(ops[0]))
+		return err
+	}
+	_, err := m.backends[to].Txn(ctx, ops)
+	return err
+}
+
+func (m *mirror) handle(ctx context.Context, mu *mutation) {
+	defer func() {
+		atomic.AddInt64(&m.pending, -1)
+		pendingGauge.Dec()
+	}()
+	if mu.Action == BARRIER {
+		close(mu.done)
+		return
+	}
+	atomic.StoreInt64(&m.head, mu.time.UnixNano())
+	lagGauge.Set(time.Since(mu.time).Seconds())
+
+	err := m.apply(ctx, mu)
+	for i, d := 0, time.Second; err != nil && i < MIRROR_RETRY_TIMES; i, d = i+1, d*2 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(d):
+		}
+		err = m.apply(ctx, mu)
+	}
+	if err != nil {
+		atomic.AddInt64(&m.failed, 1)
+		mirrored.WithLabelValues("failure").Inc()
+		util.Logger().Errorf(err, "mirror mutation %d of %d op(s) to %s backend failed",
+			mu.Action, len(mu.Ops), sideName(TARGET-mu.From))
+		return
+	}
+	mirrored.WithLabelValues("success").Inc()
+}
+
+func (m *mirror) Run() {
+	m.goroutine.Do(func(stopCh <-chan struct{}) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case mu := <-m.queue:
+				m.handle(ctx, mu)
+				if atomic.LoadInt64(&m.pending) == 0 {
+					atomic.StoreInt64(&m.head, 0)
+					lagGauge.Set(0)
+				}
+			}
+		}
+	})
+}
+
+func (m *mirror) Stop() {
+	m.goroutine.Close(true)
+}
+
+func newMirror(backends [2]registry.Registry, leases *leaseMapper, size int) *mirror {
+	if size <= 0 {
+		size = DEFAULT_QUEUE_SIZE
+	}
+	return &mirror{
+		backends:  backends,
+		leases:    leases,
+		queue:     make(chan *mutation, size),
+		goroutine: util.NewGo(make(chan struct{})),
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
 	"github.com/coreos/etcd/etcdserver/etcdserverpb"
 	"github.com/coreos/etcd/lease"
+	"github.com/coreos/etcd/mvcc"
 	"github.com/coreos/etcd/mvcc/mvccpb"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -68,6 +69,10 @@ func (s *EtcdEmbed) Close() {
 	util.Logger().Debugf("embedded etcd client stopped.")
 }
 
+func (s *EtcdEmbed) Status(ctx context.Context) (*registry.BackendStatus, error) {
+	return &registry.BackendStatus{DbSize: s.Server.Server.Backend().Size()}, nil
+}
+
 func (s *EtcdEmbed) getPrefixEndKey(prefix []byte) []byte {
 	l := len(prefix)
 	endBytes := make([]byte, l+1)
@@ -253,6 +258,17 @@ func (s *EtcdEmbed) PutNoOverride(ctx context.Context, opts ...registry.PluginOp
 	return resp.Succeeded, nil
 }
 
+func toRevisionError(err error) error {
+	switch err {
+	case mvcc.ErrCompacted:
+		return registry.ErrRevisionCompacted
+	case mvcc.ErrFutureRev:
+		return registry.ErrFutureRevision
+	default:
+		return err
+	}
+}
+
 func (s *EtcdEmbed) Do(ctx context.Context, opts ...registry.PluginOpOption) (*registry.PluginResponse, error) {
 	op := registry.OptionsToOp(opts...)
 
@@ -265,6 +281,7 @@ func (s *EtcdEmbed) Do(ctx context.Context, opts ...registry.PluginOpOption) (*r
 		var etcdResp *etcdserverpb.RangeResponse
 		etcdResp, err = s.Server.Server.Range(otCtx, s.toGetRequest(op))
 		if err != nil {
+			err = toRevisionError(err)
 			break
 		}
 		resp = &registry.PluginResponse{
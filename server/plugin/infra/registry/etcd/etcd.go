@@ -43,12 +43,17 @@ var clientTLSConfig *tls.Config
 
 func init() {
 	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.REGISTRY, "etcd", NewRegistry})
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.REGISTRY, "etcd_migration", NewMigrationRegistry})
 }
 
 type EtcdClient struct {
 	Client *clientv3.Client
 	err    chan error
 	ready  chan int
+	// 只读副本，未配置时为nil
+	replica *readReplica
+	// 租约操作的准入控制
+	leases *leaseLimiter
 }
 
 func (s *EtcdClient) Err() <-chan error {
@@ -60,6 +65,9 @@ func (s *EtcdClient) Ready() <-chan int {
 }
 
 func (s *EtcdClient) Close() {
+	if s.replica != nil {
+		s.replica.Stop()
+	}
 	if s.Client != nil {
 		s.Client.Close()
 	}
@@ -83,6 +91,35 @@ func (c *EtcdClient) CompactCluster(ctx context.Context) {
 
 }
 
+func (c *EtcdClient) Status(ctx context.Context) (*registry.BackendStatus, error) {
+	var (
+		status  = &registry.BackendStatus{}
+		lastErr error
+		ok      bool
+	)
+	mapi := clientv3.NewMaintenance(c.Client)
+	for _, ep := range c.Client.Endpoints() {
+		otCtx, cancel := registry.WithTimeout(ctx)
+		resp, err := mapi.Status(otCtx, ep)
+		cancel()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		ok = true
+		if resp.DbSize > status.DbSize {
+			status.DbSize = resp.DbSize
+		}
+	}
+	if !ok {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no available etcd endpoint")
+		}
+		return nil, lastErr
+	}
+	return status, nil
+}
+
 func (c *EtcdClient) Compact(ctx context.Context, revision int64) error {
 	otCtx, cancel := registry.WithTimeout(ctx)
 	defer cancel()
@@ -223,14 +260,14 @@ func (c *EtcdClient) PutNoOverride(ctx context.Context, opts ...registry.PluginO
 	return resp.Succeeded, nil
 }
 
-func (c *EtcdClient) paging(ctx context.Context, op registry.PluginOp) (*clientv3.GetResponse, error) {
+func (c *EtcdClient) paging(ctx context.Context, kv clientv3.KV, op registry.PluginOp, extra ...clientv3.OpOption) (*clientv3.GetResponse, error) {
 	var etcdResp *clientv3.GetResponse
 	key := util.BytesToStringWithNoCopy(op.Key)
 
 	start := time.Now()
 	tempOp := op
 	tempOp.CountOnly = true
-	coutResp, err := c.Client.Get(ctx, key, c.toGetRequest(tempOp)...)
+	coutResp, err := kv.Get(ctx, key, append(c.toGetRequest(tempOp), extra...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -261,6 +298,7 @@ func (c *EtcdClient) paging(ctx context.Context, op registry.PluginOp) (*clientv
 
 	baseOps := []clientv3.OpOption{}
 	baseOps = append(baseOps, c.toGetRequest(tempOp)...)
+	baseOps = append(baseOps, extra...)
 
 	nextKey := key
 	for i := int64(0); i < pageCount; i++ {
@@ -269,7 +307,7 @@ func (c *EtcdClient) paging(ctx context.Context, op registry.PluginOp) (*clientv
 			limit = remainCount
 		}
 		ops := append(baseOps, clientv3.WithLimit(int64(limit)))
-		recordResp, err := c.Client.Get(ctx, nextKey, ops...)
+		recordResp, err := kv.Get(ctx, nextKey, ops...)
 		if err != nil {
 			return nil, err
 		}
@@ -313,20 +351,13 @@ func (c *EtcdClient) Do(ctx context.Context, opts ...registry.PluginOpOption) (*
 	switch op.Action {
 	case registry.Get:
 		var etcdResp *clientv3.GetResponse
-		key := util.BytesToStringWithNoCopy(op.Key)
-
-		if (op.Prefix || len(op.EndKey) > 0) && !op.CountOnly {
-			etcdResp, err = c.paging(ctx, op)
-			if err != nil {
-				break
-			}
-		}
-
-		if etcdResp == nil {
-			etcdResp, err = c.Client.Get(otCtx, key, c.toGetRequest(op)...)
-			if err != nil {
-				break
-			}
+		err = withRetry(ctx, "get", isTransient, func() (err error) {
+			etcdResp, err = c.get(ctx, op)
+			return
+		})
+		if err != nil {
+			err = toRevisionError(err)
+			break
 		}
 
 		resp = &registry.PluginResponse{
@@ -366,6 +397,55 @@ func (c *EtcdClient) Do(ctx context.Context, opts ...registry.PluginOpOption) (*
 	return resp, nil
 }
 
+func toRevisionError(err error) error {
+	switch err {
+	case rpctypes.ErrCompacted:
+		return registry.ErrRevisionCompacted
+	case rpctypes.ErrFutureRev:
+		return registry.ErrFutureRevision
+	default:
+		return err
+	}
+}
+
+// get 按读路由依次尝试：只读副本、主集群、主集群本地读(可能读到旧数据)
+func (c *EtcdClient) get(ctx context.Context, op registry.PluginOp) (etcdResp *clientv3.GetResponse, err error) {
+	for _, route := range c.readRoutes(op) {
+		etcdResp, err = c.getFrom(ctx, route, op)
+		if err == nil {
+			return
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		route.OnError(err)
+	}
+	return
+}
+
+func (c *EtcdClient) getFrom(ctx context.Context, route *readRoute, op registry.PluginOp) (*clientv3.GetResponse, error) {
+	var extra []clientv3.OpOption
+	if route.Serializable {
+		extra = append(extra, clientv3.WithSerializable())
+	}
+	if route.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, route.Timeout)
+		defer cancel()
+	}
+
+	if (op.Prefix || len(op.EndKey) > 0) && !op.CountOnly {
+		etcdResp, err := c.paging(ctx, route.KV, op, extra...)
+		if err != nil || etcdResp != nil {
+			return etcdResp, err
+		}
+	}
+
+	otCtx, cancel := registry.WithTimeout(ctx)
+	defer cancel()
+	return route.KV.Get(otCtx, util.BytesToStringWithNoCopy(op.Key), append(c.toGetRequest(op), extra...)...)
+}
+
 func (c *EtcdClient) Txn(ctx context.Context, opts []registry.PluginOp) (*registry.PluginResponse, error) {
 	resp, err := c.TxnWithCmp(ctx, opts, nil, nil)
 	if err != nil {
@@ -378,24 +458,35 @@ func (c *EtcdClient) Txn(ctx context.Context, opts []registry.PluginOp) (*regist
 }
 
 func (c *EtcdClient) TxnWithCmp(ctx context.Context, success []registry.PluginOp, cmps []registry.CompareOp, fail []registry.PluginOp) (*registry.PluginResponse, error) {
-	otCtx, cancel := registry.WithTimeout(ctx)
-	defer cancel()
-
 	start := time.Now()
 	etcdCmps := c.toCompares(cmps)
 	etcdSuccessOps := c.toTxnRequest(success)
 	etcdFailOps := c.toTxnRequest(fail)
 
 	kvc := clientv3.NewKV(c.Client)
-	txn := kvc.Txn(otCtx)
-	if len(etcdCmps) > 0 {
-		txn.If(etcdCmps...)
+	var resp *clientv3.TxnResponse
+	commit := func() (err error) {
+		// 每次提交使用独立的超时，避免重试共用已耗尽的期限
+		otCtx, cancel := registry.WithTimeout(ctx)
+		defer cancel()
+		txn := kvc.Txn(otCtx)
+		if len(etcdCmps) > 0 {
+			txn.If(etcdCmps...)
+		}
+		txn.Then(etcdSuccessOps...)
+		if len(etcdFailOps) > 0 {
+			txn.Else(etcdFailOps...)
+		}
+		resp, err = txn.Commit()
+		return
 	}
-	txn.Then(etcdSuccessOps...)
-	if len(etcdFailOps) > 0 {
-		txn.Else(etcdFailOps...)
+	var err error
+	if len(etcdCmps) > 0 {
+		// 只有CAS写可以重试，且仅限确定未被受理的错误
+		err = withRetry(ctx, "txn", isUnapplied, commit)
+	} else {
+		err = commit()
 	}
-	resp, err := txn.Commit()
 	if err != nil {
 		return nil, err
 	}
@@ -407,6 +498,9 @@ func (c *EtcdClient) TxnWithCmp(ctx context.Context, success []registry.PluginOp
 }
 
 func (c *EtcdClient) LeaseGrant(ctx context.Context, TTL int64) (int64, error) {
+	if err := c.leases.Wait(ctx, "grant"); err != nil {
+		return 0, err
+	}
 	otCtx, cancel := registry.WithTimeout(ctx)
 	defer cancel()
 	start := time.Now()
@@ -419,21 +513,26 @@ func (c *EtcdClient) LeaseGrant(ctx context.Context, TTL int64) (int64, error) {
 }
 
 func (c *EtcdClient) LeaseRenew(ctx context.Context, leaseID int64) (int64, error) {
-	otCtx, cancel := registry.WithTimeout(ctx)
-	defer cancel()
-	start := time.Now()
-	etcdResp, err := c.Client.KeepAliveOnce(otCtx, clientv3.LeaseID(leaseID))
-	if err != nil {
-		if err.Error() == grpc.ErrorDesc(rpctypes.ErrGRPCLeaseNotFound) {
-			return 0, err
+	return c.leases.Renew(ctx, leaseID, func() (int64, error) {
+		otCtx, cancel := registry.WithTimeout(ctx)
+		defer cancel()
+		start := time.Now()
+		etcdResp, err := c.Client.KeepAliveOnce(otCtx, clientv3.LeaseID(leaseID))
+		if err != nil {
+			if err.Error() == grpc.ErrorDesc(rpctypes.ErrGRPCLeaseNotFound) {
+				return 0, err
+			}
+			return 0, errorsEx.RaiseError(err)
 		}
-		return 0, errorsEx.RaiseError(err)
-	}
-	util.LogNilOrWarnf(start, "registry client renew lease %d", leaseID)
-	return etcdResp.TTL, nil
+		util.LogNilOrWarnf(start, "registry client renew lease %d", leaseID)
+		return etcdResp.TTL, nil
+	})
 }
 
 func (c *EtcdClient) LeaseRevoke(ctx context.Context, leaseID int64) error {
+	if err := c.leases.Wait(ctx, "revoke"); err != nil {
+		return err
+	}
 	otCtx, cancel := registry.WithTimeout(ctx)
 	defer cancel()
 	start := time.Now()
@@ -553,14 +652,24 @@ func setResponseAndCallback(pResp *registry.PluginResponse, kvs []*mvccpb.KeyVal
 
 func NewRegistry() mgr.PluginInstance {
 	util.Logger().Warnf(nil, "starting service center in proxy mode")
+	cfg := registry.RegistryConfig()
+	return newRegistry(cfg.ClusterAddresses, cfg.ReadClusterAddresses)
+}
 
+// NewMigrationRegistry 双写迁移的目标集群manager_migration_cluster，不使用只读副本
+func NewMigrationRegistry() mgr.PluginInstance {
+	return newRegistry(registry.RegistryConfig().MigrationClusterAddresses, "")
+}
+
+func newRegistry(clusterAddresses, readClusterAddresses string) *EtcdClient {
+	cfg := registry.RegistryConfig()
 	inst := &EtcdClient{
-		err:   make(chan error, 1),
-		ready: make(chan int),
+		err:    make(chan error, 1),
+		ready:  make(chan int),
+		leases: newLeaseLimiter(cfg.LeaseRate, cfg.LeaseBurst, cfg.LeaseQueueTimeout),
 	}
-	addrs := strings.Split(registry.RegistryConfig().ClusterAddresses, ",")
-
-	if core.ServerInfo.Config.SslEnabled && strings.Index(registry.RegistryConfig().ClusterAddresses, "https://") >= 0 {
+	if core.ServerInfo.Config.SslEnabled && (strings.Index(clusterAddresses, "https://") >= 0 ||
+		strings.Index(readClusterAddresses, "https://") >= 0) {
 		var err error
 		// go client tls限制，提供身份证书、不认证服务端、不校验CN
 		clientTLSConfig, err = sctls.GetClientTLSConfig()
@@ -571,17 +680,7 @@ func NewRegistry() mgr.PluginInstance {
 		}
 	}
 
-	endpoints := []string{}
-	for _, addr := range addrs {
-		if strings.Index(addr, "://") > 0 {
-			// 如果配置格式为"sr-0=http(s)://IP:Port"，则需要分离IP:Port部分
-			endpoints = append(endpoints, addr[strings.Index(addr, "://")+3:])
-		} else {
-			endpoints = append(endpoints, addr)
-		}
-
-	}
-
+	endpoints := parseEndpoints(clusterAddresses)
 	inv, _ := time.ParseDuration(core.ServerInfo.Config.AutoSyncInterval)
 	client, err := newClient(endpoints, inv)
 	if err != nil {
@@ -593,10 +692,33 @@ func NewRegistry() mgr.PluginInstance {
 	util.Logger().Warnf(nil, "get etcd client %+v completed, auto sync endpoints interval is %s.",
 		endpoints, core.ServerInfo.Config.AutoSyncInterval)
 	inst.Client = client
+
+	if len(readClusterAddresses) > 0 {
+		// 副本不可用不影响启动，由健康检查负责连接与切换
+		inst.replica = newReadReplica(client, parseEndpoints(readClusterAddresses))
+		inst.replica.Run()
+	}
 	close(inst.ready)
 	return inst
 }
 
+func parseEndpoints(addrs string) []string {
+	endpoints := []string{}
+	for _, addr := range strings.Split(addrs, ",") {
+		addr = strings.TrimSpace(addr)
+		if len(addr) == 0 {
+			continue
+		}
+		if strings.Index(addr, "://") > 0 {
+			// 如果配置格式为"sr-0=http(s)://IP:Port"，则需要分离IP:Port部分
+			endpoints = append(endpoints, addr[strings.Index(addr, "://")+3:])
+		} else {
+			endpoints = append(endpoints, addr)
+		}
+	}
+	return endpoints
+}
+
 func newClient(endpoints []string, autoSyncInterval time.Duration) (*clientv3.Client, error) {
 	client, err := clientv3.New(clientv3.Config{
 		Endpoints:        endpoints,
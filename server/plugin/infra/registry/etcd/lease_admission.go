@@ -0,0 +1,174 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package etcd
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"sync"
+	"time"
+)
+
+var (
+	leaseQueueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "registry",
+			Name:      "lease_queue_depth",
+			Help:      "Number of the lease operations waiting for admission",
+		})
+
+	leaseAdmissions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "registry",
+			Name:      "lease_admissions_total",
+			Help:      "Counter of the lease operations by admitted, queued, rejected or shed(duplicate renewals)",
+		}, []string{"operation", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(leaseQueueDepth, leaseAdmissions)
+}
+
+type leaseRenewal struct {
+	done chan struct{}
+	ttl  int64
+	err  error
+}
+
+// leaseLimiter 租约操作的准入控制：令牌桶限制速率，令牌不足时排队等待，
+// 预计等待超过期限的操作直接拒绝；同一租约的续约在排队或执行中时，重复的续约共享其结果
+type leaseLimiter struct {
+	lock     sync.Mutex
+	rate     float64
+	burst    float64
+	timeout  time.Duration
+	tokens   float64
+	last     time.Time
+	renewals map[int64]*leaseRenewal
+}
+
+func newLeaseLimiter(rate float64, burst int, timeout time.Duration) *leaseLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &leaseLimiter{
+		rate:     rate,
+		burst:    float64(burst),
+		timeout:  timeout,
+		tokens:   float64(burst),
+		last:     time.Now(),
+		renewals: make(map[int64]*leaseRenewal),
+	}
+}
+
+func (l *leaseLimiter) enabled() bool {
+	return l != nil && l.rate > 0
+}
+
+// reserve 预占一个令牌，返回需要等待的时间，需在持有锁时调用
+func (l *leaseLimiter) reserve(now time.Time, deadline time.Time) (time.Duration, bool) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0, true
+	}
+	d := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	if now.Add(d).After(deadline) {
+		l.tokens++
+		return 0, false
+	}
+	return d, true
+}
+
+// Wait 等待准入，期限为lease_queue_timeout和ctx期限中较早的一个
+func (l *leaseLimiter) Wait(ctx context.Context, operation string) error {
+	if !l.enabled() {
+		return nil
+	}
+	now := time.Now()
+	deadline := now.Add(l.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	l.lock.Lock()
+	d, ok := l.reserve(now, deadline)
+	l.lock.Unlock()
+	if !ok {
+		leaseAdmissions.WithLabelValues(operation, "rejected").Inc()
+		util.Logger().Warnf(nil, "registry lease %s is throttled", operation)
+		return registry.ErrLeaseThrottled
+	}
+	if d == 0 {
+		leaseAdmissions.WithLabelValues(operation, "admitted").Inc()
+		return nil
+	}
+
+	leaseQueueDepth.Inc()
+	defer leaseQueueDepth.Dec()
+	select {
+	case <-ctx.Done():
+		// 归还预占的令牌
+		l.lock.Lock()
+		l.tokens++
+		l.lock.Unlock()
+		leaseAdmissions.WithLabelValues(operation, "rejected").Inc()
+		return ctx.Err()
+	case <-time.After(d):
+	}
+	leaseAdmissions.WithLabelValues(operation, "queued").Inc()
+	return nil
+}
+
+// Renew 同一租约已有续约在排队或执行时，不再占用令牌，等待并返回其结果
+func (l *leaseLimiter) Renew(ctx context.Context, leaseID int64, renew func() (int64, error)) (int64, error) {
+	if !l.enabled() {
+		return renew()
+	}
+	l.lock.Lock()
+	if r, ok := l.renewals[leaseID]; ok {
+		l.lock.Unlock()
+		leaseAdmissions.WithLabelValues("renew", "shed").Inc()
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-r.done:
+			return r.ttl, r.err
+		}
+	}
+	r := &leaseRenewal{done: make(chan struct{})}
+	l.renewals[leaseID] = r
+	l.lock.Unlock()
+
+	if r.err = l.Wait(ctx, "renew"); r.err == nil {
+		r.ttl, r.err = renew()
+	}
+
+	l.lock.Lock()
+	delete(l.renewals, leaseID)
+	l.lock.Unlock()
+	close(r.done)
+	return r.ttl, r.err
+}
@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package etcd
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLeaseLimiterWait(t *testing.T) {
+	ctx := context.Background()
+
+	var l *leaseLimiter
+	if err := l.Wait(ctx, "grant"); err != nil {
+		t.Fatalf("TestLeaseLimiterWait failed, nil limiter %v", err)
+	}
+
+	l = newLeaseLimiter(10, 2, 150*time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if err := l.Wait(ctx, "grant"); err != nil {
+			t.Fatalf("TestLeaseLimiterWait failed, burst %v", err)
+		}
+	}
+	// 第3个需要等待100ms，在期限内
+	start := time.Now()
+	if err := l.Wait(ctx, "grant"); err != nil {
+		t.Fatalf("TestLeaseLimiterWait failed, queued %v", err)
+	}
+	if time.Since(start) < 50*time.Millisecond {
+		t.Fatalf("TestLeaseLimiterWait failed, not queued")
+	}
+
+	// 等待超过期限时直接拒绝
+	l = newLeaseLimiter(10, 1, 50*time.Millisecond)
+	l.Wait(ctx, "grant")
+	if err := l.Wait(ctx, "grant"); err != registry.ErrLeaseThrottled {
+		t.Fatalf("TestLeaseLimiterWait failed, expect throttled but %v", err)
+	}
+}
+
+func TestLeaseLimiterRenew(t *testing.T) {
+	l := newLeaseLimiter(1, 1, time.Second)
+	var calls int32
+	release := make(chan struct{})
+	renew := func() (int64, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 30, nil
+	}
+
+	var wg sync.WaitGroup
+	ttls := make([]int64, 5)
+	for i := range ttls {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ttls[i], _ = l.Renew(context.Background(), 1, renew)
+		}(i)
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("TestLeaseLimiterRenew failed, %d renewals", calls)
+	}
+	for _, ttl := range ttls {
+		if ttl != 30 {
+			t.Fatalf("TestLeaseLimiterRenew failed, ttl %d", ttl)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package etcd
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/coreos/etcd/clientv3"
+	"golang.org/x/net/context"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DEFAULT_READ_HEALTH_CHECK_INTERVAL = 10 * time.Second
+	DEFAULT_READ_REPLICA_TIMEOUT       = 5 * time.Second
+)
+
+// readRoute 一条读请求路由
+type readRoute struct {
+	Name string
+	KV   clientv3.KV
+	// Serializable 由被访问的成员本地应答，不经过raft，可能读到旧数据
+	Serializable bool
+	Timeout      time.Duration
+	OnError      func(err error)
+}
+
+// readRoutes 读请求的路由顺序：
+// 1. 只读副本健康时优先读副本
+// 2. 主集群一致性读
+// 3. 主集群失去quorum等情况下，降级为本地读
+// 指定MODE_NO_CACHE的请求要求读到最新数据，只走主集群一致性读
+// 指定revision的请求(快照读)的revision来自主集群，同样只走主集群
+func (c *EtcdClient) readRoutes(op registry.PluginOp) []*readRoute {
+	primary := &readRoute{
+		Name: "primary",
+		KV:   c.Client,
+		OnError: func(err error) {
+			util.Logger().Errorf(err, "read from primary endpoints failed, key %s", op.Key)
+		},
+	}
+	if op.Mode == registry.MODE_NO_CACHE || op.Revision > 0 {
+		return []*readRoute{primary}
+	}
+
+	routes := make([]*readRoute, 0, 3)
+	if c.replica != nil && c.replica.Healthy() {
+		routes = append(routes, &readRoute{
+			Name:         "replica",
+			KV:           c.replica.Client(),
+			Serializable: true,
+			Timeout:      DEFAULT_READ_REPLICA_TIMEOUT,
+			OnError: func(err error) {
+				c.replica.MarkUnhealthy(err)
+			},
+		})
+	}
+	return append(routes, primary, &readRoute{
+		Name:         "stale",
+		KV:           c.Client,
+		Serializable: true,
+		OnError: func(err error) {
+			util.Logger().Errorf(err, "stale read from primary endpoints failed, key %s", op.Key)
+		},
+	})
+}
+
+// readReplica 定期检查只读副本的健康状态与落后程度，不健康时读请求切回主集群
+type readReplica struct {
+	primary   *clientv3.Client
+	endpoints []string
+	client    atomic.Value
+	healthy   int32
+	goroutine *util.GoRoutine
+}
+
+func (r *readReplica) Client() *clientv3.Client {
+	c, _ := r.client.Load().(*clientv3.Client)
+	return c
+}
+
+func (r *readReplica) Healthy() bool {
+	return atomic.LoadInt32(&r.healthy) == 1 && r.Client() != nil
+}
+
+func (r *readReplica) MarkUnhealthy(err error) {
+	if atomic.CompareAndSwapInt32(&r.healthy, 1, 0) {
+		util.Logger().Errorf(err, "read replica %v is unhealthy, route read requests to primary endpoints",
+			r.endpoints)
+	}
+}
+
+func (r *readReplica) markHealthy() {
+	if atomic.CompareAndSwapInt32(&r.healthy, 0, 1) {
+		util.Logger().Warnf(nil, "read replica %v is healthy, route read requests to it",
+			r.endpoints)
+	}
+}
+
+// check 副本可访问且落后主集群不超过ReadMaxLag个revision时认为健康
+func (r *readReplica) check(ctx context.Context) error {
+	replica := r.Client()
+	if replica == nil {
+		// 只读副本不自动同步endpoints，避免读请求被同步到主集群成员
+		c, err := newClient(r.endpoints, 0)
+		if err != nil {
+			return err
+		}
+		util.Logger().Warnf(nil, "get etcd read client %+v completed.", r.endpoints)
+		r.client.Store(c)
+		replica = c
+	}
+
+	key := core.GetRootKey()
+	otCtx, cancel := context.WithTimeout(ctx, DEFAULT_READ_REPLICA_TIMEOUT)
+	defer cancel()
+	replicaResp, err := replica.Get(otCtx, key, clientv3.WithSerializable(), clientv3.WithCountOnly())
+	if err != nil {
+		return err
+	}
+
+	maxLag := registry.RegistryConfig().ReadMaxLag
+	if maxLag <= 0 {
+		return nil
+	}
+	otCtx, cancel = registry.WithTimeout(ctx)
+	defer cancel()
+	primaryResp, err := r.primary.Get(otCtx, key, clientv3.WithCountOnly())
+	if err != nil {
+		// 主集群不可用时，副本仍可提供读服务
+		return nil
+	}
+	if lag := primaryResp.Header.Revision - replicaResp.Header.Revision; lag > maxLag {
+		return fmt.Errorf("read replica is %d revisions behind the primary, exceeds %d", lag, maxLag)
+	}
+	return nil
+}
+
+func (r *readReplica) Run() {
+	r.goroutine.Do(func(stopCh <-chan struct{}) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		for {
+			if err := r.check(ctx); err != nil {
+				r.MarkUnhealthy(err)
+			} else {
+				r.markHealthy()
+			}
+
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(DEFAULT_READ_HEALTH_CHECK_INTERVAL):
+			}
+		}
+	})
+}
+
+func (r *readReplica) Stop() {
+	r.goroutine.Close(true)
+	if c := r.Client(); c != nil {
+		c.Close()
+	}
+}
+
+func newReadReplica(primary *clientv3.Client, endpoints []string) *readReplica {
+	return &readReplica{
+		primary:   primary,
+		endpoints: endpoints,
+		goroutine: util.NewGo(make(chan struct{})),
+	}
+}
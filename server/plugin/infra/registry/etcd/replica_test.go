@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package etcd
+
+import (
+	"errors"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+	"golang.org/x/net/context"
+	"testing"
+)
+
+// fakeKV 只实现Get，返回固定revision或指定的错误
+type fakeKV struct {
+	clientv3.KV
+	rev   int64
+	err   error
+	calls int
+}
+
+func (kv *fakeKV) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	kv.calls++
+	if kv.err != nil {
+		return nil, kv.err
+	}
+	return &clientv3.GetResponse{Header: &etcdserverpb.ResponseHeader{Revision: kv.rev}, Count: 1}, nil
+}
+
+func newReplicaClient(primary, replica *fakeKV) *EtcdClient {
+	c := &EtcdClient{Client: &clientv3.Client{KV: primary}}
+	c.replica = newReadReplica(c.Client, []string{"127.0.0.1:2479"})
+	c.replica.client.Store(&clientv3.Client{KV: replica})
+	return c
+}
+
+func routeNames(routes []*readRoute) (names []string) {
+	for _, route := range routes {
+		names = append(names, route.Name)
+	}
+	return
+}
+
+func TestReadRoutes(t *testing.T) {
+	c := newReplicaClient(&fakeKV{}, &fakeKV{})
+	op := registry.PluginOp{Key: []byte("/a")}
+	if names := routeNames(c.readRoutes(op)); len(names) != 2 || names[0] != "primary" || names[1] != "stale" {
+		t.Fatalf("TestReadRoutes failed, unhealthy replica should not be routed, %v", names)
+	}
+	c.replica.markHealthy()
+	if names := routeNames(c.readRoutes(op)); len(names) != 3 || names[0] != "replica" {
+		t.Fatalf("TestReadRoutes failed, healthy replica should be routed first, %v", names)
+	}
+	// 要求最新数据或指定revision的读只走主集群
+	for _, op := range []registry.PluginOp{
+		{Key: []byte("/a"), Mode: registry.MODE_NO_CACHE},
+		{Key: []byte("/a"), Revision: 1},
+	} {
+		if names := routeNames(c.readRoutes(op)); len(names) != 1 || names[0] != "primary" {
+			t.Fatalf("TestReadRoutes failed, %v should only read primary, %v", op, names)
+		}
+	}
+}
+
+func TestReadReplicaFailover(t *testing.T) {
+	cfg := registry.RegistryConfig()
+	defer func(lag int64) { cfg.ReadMaxLag = lag }(cfg.ReadMaxLag)
+	cfg.ReadMaxLag = 5
+
+	ctx := context.Background()
+	primary, replica := &fakeKV{rev: 10}, &fakeKV{rev: 8}
+	c := newReplicaClient(primary, replica)
+	op := registry.PluginOp{Key: []byte("/a")}
+
+	if err := c.replica.check(ctx); err != nil {
+		t.Fatalf("TestReadReplicaFailover failed, replica within max lag, %v", err)
+	}
+	c.replica.markHealthy()
+	resp, err := c.get(ctx, op)
+	if err != nil || resp.Header.Revision != 8 || !c.replica.Healthy() {
+		t.Fatalf("TestReadReplicaFailover failed, should read from replica, %v", err)
+	}
+
+	// 副本读失败时切回主集群，后续请求不再访问副本
+	replica.err = errors.New("unavailable")
+	resp, err = c.get(ctx, op)
+	if err != nil || resp.Header.Revision != 10 || c.replica.Healthy() {
+		t.Fatalf("TestReadReplicaFailover failed, should fail over to primary, %v", err)
+	}
+	replica.calls = 0
+	if resp, err = c.get(ctx, op); err != nil || resp.Header.Revision != 10 || replica.calls != 0 {
+		t.Fatalf("TestReadReplicaFailover failed, unhealthy replica read %d times", replica.calls)
+	}
+
+	// 副本恢复但落后过多时仍不健康
+	replica.err, replica.rev = nil, 4
+	if err := c.replica.check(ctx); err == nil {
+		t.Fatalf("TestReadReplicaFailover failed, replica lags too far behind")
+	}
+
+	// 追上后恢复路由到副本，主集群不可用不影响副本的健康状态
+	replica.rev, primary.err = 9, errors.New("no leader")
+	if err := c.replica.check(ctx); err != nil {
+		t.Fatalf("TestReadReplicaFailover failed, replica recovered, %v", err)
+	}
+	c.replica.markHealthy()
+	if resp, err = c.get(ctx, op); err != nil || resp.Header.Revision != 9 {
+		t.Fatalf("TestReadReplicaFailover failed, should read from recovered replica, %v", err)
+	}
+
+	// 主集群和副本都不可用时返回错误
+	replica.err = errors.New("unavailable")
+	if _, err = c.get(ctx, op); err == nil || c.replica.Healthy() {
+		t.Fatalf("TestReadReplicaFailover failed, all routes failed but no error")
+	}
+}
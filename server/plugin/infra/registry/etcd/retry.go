@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package etcd
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"math/rand"
+	"time"
+)
+
+var retries = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "service_center",
+		Subsystem: "registry",
+		Name:      "retries_total",
+		Help:      "Counter of the retries of backend operations after transient errors",
+	}, []string{"operation", "result"})
+
+func init() {
+	prometheus.MustRegister(retries)
+}
+
+// isTransient 无leader、连接不可用等重试可能成功的错误
+func isTransient(err error) bool {
+	switch err {
+	case rpctypes.ErrNoLeader, rpctypes.ErrTimeout,
+		rpctypes.ErrTimeoutDueToLeaderFail, rpctypes.ErrTimeoutDueToConnectionLost:
+		return true
+	}
+	return grpc.Code(err) == codes.Unavailable
+}
+
+// isUnapplied 请求未被etcd受理(无leader时拒绝提案)，重试不会重复提交；
+// 超时、连接断开等错误下txn可能已提交，CAS写不能重试
+func isUnapplied(err error) bool {
+	return err == rpctypes.ErrNoLeader
+}
+
+// retryDelay 第attempt次重试的等待时间：从interval开始指数增长，并在[0.5, 1.5)倍间随机抖动，避免多个节点同时重试
+func retryDelay(interval time.Duration, attempt int) time.Duration {
+	interval <<= uint(attempt - 1)
+	return interval/2 + time.Duration(rand.Int63n(int64(interval)))
+}
+
+// withRetry 对retryable判定可重试的错误进行重试，幂等读使用isTransient，CAS写使用isUnapplied
+func withRetry(ctx context.Context, operation string, retryable func(error) bool, f func() error) error {
+	cfg := registry.RegistryConfig()
+	err := f()
+	if err == nil || cfg.RetryTimes <= 0 || registry.RetryDisabled(ctx) {
+		return err
+	}
+	for i := 1; i <= cfg.RetryTimes && retryable(err); i++ {
+		d := retryDelay(cfg.RetryInterval, i)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(d):
+		}
+		util.Logger().Warnf(err, "registry %s failed, retry %d/%d after %s", operation, i, cfg.RetryTimes, d)
+		err = f()
+		if err != nil {
+			retries.WithLabelValues(operation, "failure").Inc()
+		} else {
+			retries.WithLabelValues(operation, "success").Inc()
+		}
+	}
+	return err
+}
@@ -0,0 +1,165 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package etcd
+
+import (
+	"errors"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"testing"
+	"time"
+)
+
+func TestIsTransient(t *testing.T) {
+	for _, err := range []error{rpctypes.ErrNoLeader, rpctypes.ErrTimeout,
+		rpctypes.ErrTimeoutDueToLeaderFail, rpctypes.ErrTimeoutDueToConnectionLost,
+		grpc.Errorf(codes.Unavailable, "transport is closing")} {
+		if !isTransient(err) {
+			t.Fatalf("TestIsTransient failed, %v should be transient", err)
+		}
+	}
+	for _, err := range []error{errors.New("error"), rpctypes.ErrCompacted,
+		grpc.Errorf(codes.InvalidArgument, "bad request")} {
+		if isTransient(err) {
+			t.Fatalf("TestIsTransient failed, %v should not be transient", err)
+		}
+	}
+
+	// 超时后txn可能已提交，只有无leader可以确定未受理
+	if !isUnapplied(rpctypes.ErrNoLeader) {
+		t.Fatalf("TestIsTransient failed, no leader should be unapplied")
+	}
+	for _, err := range []error{rpctypes.ErrTimeout, rpctypes.ErrTimeoutDueToConnectionLost,
+		grpc.Errorf(codes.Unavailable, "transport is closing")} {
+		if isUnapplied(err) {
+			t.Fatalf("TestIsTransient failed, %v may be applied", err)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	interval := 100 * time.Millisecond
+	for i := 1; i <= 4; i++ {
+		base := interval << uint(i-1)
+		for j := 0; j < 100; j++ {
+			d := retryDelay(interval, i)
+			if d < base/2 || d >= base*3/2 {
+				t.Fatalf("TestRetryDelay failed, attempt %d delay %s out of [%s, %s)", i, d, base/2, base*3/2)
+			}
+		}
+	}
+}
+
+func withRetryConfig(times int, interval time.Duration) func() {
+	cfg := registry.RegistryConfig()
+	old := *cfg
+	cfg.RetryTimes, cfg.RetryInterval = times, interval
+	return func() { *cfg = old }
+}
+
+func TestWithRetry(t *testing.T) {
+	defer withRetryConfig(3, time.Millisecond)()
+	ctx := context.Background()
+
+	// 读在临时错误后重试直到成功
+	calls := 0
+	err := withRetry(ctx, "get", isTransient, func() error {
+		calls++
+		if calls < 3 {
+			return rpctypes.ErrTimeout
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("TestWithRetry failed, get retried %d times, %v", calls, err)
+	}
+
+	// 重试次数用尽后返回最后的错误
+	calls = 0
+	err = withRetry(ctx, "get", isTransient, func() error {
+		calls++
+		return rpctypes.ErrNoLeader
+	})
+	if err != rpctypes.ErrNoLeader || calls != 4 {
+		t.Fatalf("TestWithRetry failed, get exhausted after %d calls, %v", calls, err)
+	}
+
+	// 非临时错误不重试
+	calls = 0
+	err = withRetry(ctx, "get", isTransient, func() error {
+		calls++
+		return rpctypes.ErrCompacted
+	})
+	if err != rpctypes.ErrCompacted || calls != 1 {
+		t.Fatalf("TestWithRetry failed, non transient error retried %d times", calls)
+	}
+
+	// 请求上下文关闭了重试
+	calls = 0
+	err = withRetry(context.WithValue(ctx, registry.CTX_NO_RETRY, "1"), "get", isTransient, func() error {
+		calls++
+		return rpctypes.ErrTimeout
+	})
+	if err != rpctypes.ErrTimeout || calls != 1 {
+		t.Fatalf("TestWithRetry failed, retry disabled but called %d times", calls)
+	}
+}
+
+func TestWithRetryCAS(t *testing.T) {
+	defer withRetryConfig(3, time.Millisecond)()
+	ctx := context.Background()
+
+	// 模拟CAS写: value为空时写入成功，lost为true时已提交但响应超时
+	var (
+		value      string
+		calls      int
+		lost       bool
+		rejections int
+	)
+	commit := func() error {
+		calls++
+		if rejections > 0 {
+			rejections--
+			return rpctypes.ErrNoLeader
+		}
+		if value != "" {
+			return errors.New("compare failed")
+		}
+		value = "new"
+		if lost {
+			return rpctypes.ErrTimeout
+		}
+		return nil
+	}
+
+	// 已提交的txn重试会比较失败，所以超时不重试，直接返回超时错误
+	value, calls, lost = "", 0, true
+	err := withRetry(ctx, "txn", isUnapplied, commit)
+	if err != rpctypes.ErrTimeout || calls != 1 || value != "new" {
+		t.Fatalf("TestWithRetryCAS failed, applied txn retried %d times, %v", calls, err)
+	}
+
+	// 无leader时请求未被受理，重试后提交成功
+	value, calls, lost, rejections = "", 0, false, 2
+	err = withRetry(ctx, "txn", isUnapplied, commit)
+	if err != nil || calls != 3 || value != "new" {
+		t.Fatalf("TestWithRetryCAS failed, unapplied txn retried %d times, %v", calls, err)
+	}
+}
@@ -0,0 +1,648 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	mgr "github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"golang.org/x/net/context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// 保留的最近变更数，watch或查询的revision早于保留范围时返回ErrRevisionCompacted
+	DEFAULT_HISTORY_SIZE = 10000
+	// 检查租约过期的间隔
+	EXPIRE_INTERVAL = time.Second
+)
+
+var (
+	ErrLeaseNotFound = errors.New("requested lease not found")
+	ErrClosed        = errors.New("registry is closed")
+
+	clockLock    sync.RWMutex
+	defaultClock Clock = realClock{}
+)
+
+func init() {
+	mgr.RegisterPlugin(mgr.Plugin{mgr.STATIC, mgr.REGISTRY, "memory", NewRegistry})
+}
+
+// Clock 判断租约是否过期使用的时钟
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// SetClock 替换之后创建的实例使用的时钟，测试中可以手动推进时钟使租约过期
+func SetClock(c Clock) {
+	clockLock.Lock()
+	defaultClock = c
+	clockLock.Unlock()
+}
+
+func getClock() Clock {
+	clockLock.RLock()
+	defer clockLock.RUnlock()
+	return defaultClock
+}
+
+type lease struct {
+	TTL    int64
+	expire time.Time
+	keys   map[string]struct{}
+}
+
+type event struct {
+	action registry.ActionType
+	kv     *mvccpb.KeyValue
+	prevKv *mvccpb.KeyValue
+}
+
+// MemoryRegistry 数据只保存在进程内存中的registry，用于本地开发和集成测试，重启后数据丢失
+type MemoryRegistry struct {
+	lock    sync.RWMutex
+	kvs     map[string]*mvccpb.KeyValue
+	rev     int64
+	history []*event
+	// 累计的变更数，用于判断一次写操作是否有实际变更
+	total   int64
+	leases  map[int64]*lease
+	leaseID int64
+	// 每次变更后关闭并替换，通知watch
+	changed chan struct{}
+
+	clock     Clock
+	err       chan error
+	ready     chan int
+	closed    chan struct{}
+	closeOnce sync.Once
+	goroutine *util.GoRoutine
+}
+
+func (r *MemoryRegistry) Err() <-chan error {
+	return r.err
+}
+
+func (r *MemoryRegistry) Ready() <-chan int {
+	return r.ready
+}
+
+func (r *MemoryRegistry) Close() {
+	r.closeOnce.Do(func() {
+		close(r.closed)
+		r.goroutine.Close(true)
+		util.Logger().Debugf("memory registry stopped.")
+	})
+}
+
+// Status 存储大小为所有key和value的字节数
+func (r *MemoryRegistry) Status(ctx context.Context) (*registry.BackendStatus, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var size int64
+	for _, kv := range r.kvs {
+		size += int64(len(kv.Key) + len(kv.Value))
+	}
+	return &registry.BackendStatus{DbSize: size}, nil
+}
+
+// Revision 返回当前的revision
+func (r *MemoryRegistry) Revision() int64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.rev
+}
+
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// 全部为0xff时表示到最后
+	return []byte{0}
+}
+
+// match 与etcd一致，Prefix时为前缀，有EndKey时为[Key, EndKey)，否则为单个key
+func match(op registry.PluginOp, key []byte) bool {
+	switch {
+	case op.Prefix:
+		return bytes.HasPrefix(key, op.Key)
+	case len(op.EndKey) > 0:
+		return bytes.Compare(key, op.Key) >= 0 && bytes.Compare(key, op.EndKey) < 0
+	default:
+		return bytes.Equal(key, op.Key)
+	}
+}
+
+// snapshot 在当前数据上撤销rev之后的变更，得到rev时的数据，调用方持有锁
+func (r *MemoryRegistry) snapshot(op registry.PluginOp) (map[string]*mvccpb.KeyValue, error) {
+	kvs := make(map[string]*mvccpb.KeyValue)
+	if op.Prefix || len(op.EndKey) > 0 {
+		for key, kv := range r.kvs {
+			if match(op, kv.Key) {
+				kvs[key] = kv
+			}
+		}
+	} else if kv, ok := r.kvs[util.BytesToStringWithNoCopy(op.Key)]; ok {
+		kvs[string(op.Key)] = kv
+	}
+	if op.Revision <= 0 || op.Revision >= r.rev {
+		if op.Revision > r.rev {
+			return nil, registry.ErrFutureRevision
+		}
+		return kvs, nil
+	}
+	if len(r.history) == 0 || r.history[0].kv.ModRevision > op.Revision+1 {
+		return nil, registry.ErrRevisionCompacted
+	}
+	for i := len(r.history) - 1; i >= 0 && r.history[i].kv.ModRevision > op.Revision; i-- {
+		evt := r.history[i]
+		if !match(op, evt.kv.Key) {
+			continue
+		}
+		key := string(evt.kv.Key)
+		if evt.prevKv == nil {
+			delete(kvs, key)
+			continue
+		}
+		kvs[key] = evt.prevKv
+	}
+	return kvs, nil
+}
+
+func (r *MemoryRegistry) get(op registry.PluginOp) (*registry.PluginResponse, error) {
+	kvs, err := r.snapshot(op)
+	if err != nil {
+		return nil, err
+	}
+	resp := &registry.PluginResponse{Count: int64(len(kvs)), Revision: r.rev}
+	if op.CountOnly {
+		return resp, nil
+	}
+	resp.Kvs = make([]*mvccpb.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		if op.KeyOnly {
+			kv = &mvccpb.KeyValue{Key: kv.Key, CreateRevision: kv.CreateRevision,
+				ModRevision: kv.ModRevision, Version: kv.Version, Lease: kv.Lease}
+		}
+		resp.Kvs = append(resp.Kvs, kv)
+	}
+	sort.Slice(resp.Kvs, func(i, j int) bool {
+		less := bytes.Compare(resp.Kvs[i].Key, resp.Kvs[j].Key) < 0
+		if op.SortOrder == registry.SORT_DESCEND {
+			return !less
+		}
+		return less
+	})
+	return resp, nil
+}
+
+// record 记录变更并通知watch，调用方持有写锁
+func (r *MemoryRegistry) record(evt *event) {
+	r.history = append(r.history, evt)
+	r.total++
+	if len(r.history) > DEFAULT_HISTORY_SIZE {
+		r.history = append([]*event(nil), r.history[len(r.history)-DEFAULT_HISTORY_SIZE:]...)
+	}
+}
+
+func (r *MemoryRegistry) notify() {
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+func (r *MemoryRegistry) detach(kv *mvccpb.KeyValue) {
+	if kv == nil || kv.Lease == 0 {
+		return
+	}
+	if l, ok := r.leases[kv.Lease]; ok {
+		delete(l.keys, string(kv.Key))
+	}
+}
+
+// put 写入rev版本，调用方持有写锁并已检查租约
+func (r *MemoryRegistry) put(op registry.PluginOp, rev int64) {
+	key := string(op.Key)
+	prev := r.kvs[key]
+	kv := &mvccpb.KeyValue{
+		Key:            []byte(key),
+		Value:          append([]byte(nil), op.Value...),
+		CreateRevision: rev,
+		ModRevision:    rev,
+		Version:        1,
+		Lease:          op.Lease,
+	}
+	if prev != nil {
+		kv.CreateRevision = prev.CreateRevision
+		kv.Version = prev.Version + 1
+	}
+	r.detach(prev)
+	if op.Lease > 0 {
+		r.leases[op.Lease].keys[key] = struct{}{}
+	}
+	r.kvs[key] = kv
+	r.record(&event{action: registry.Put, kv: kv, prevKv: prev})
+}
+
+func (r *MemoryRegistry) del(op registry.PluginOp, rev int64) {
+	var keys []string
+	if op.Prefix || len(op.EndKey) > 0 {
+		for key, kv := range r.kvs {
+			if match(op, kv.Key) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+	} else if _, ok := r.kvs[string(op.Key)]; ok {
+		keys = append(keys, string(op.Key))
+	}
+	for _, key := range keys {
+		prev := r.kvs[key]
+		delete(r.kvs, key)
+		r.detach(prev)
+		r.record(&event{
+			action: registry.Delete,
+			kv:     &mvccpb.KeyValue{Key: prev.Key, ModRevision: rev},
+			prevKv: prev,
+		})
+	}
+}
+
+func (r *MemoryRegistry) checkLeases(ops []registry.PluginOp) error {
+	for _, op := range ops {
+		if op.Action != registry.Put || op.Lease == 0 {
+			continue
+		}
+		if _, ok := r.leases[op.Lease]; !ok {
+			return ErrLeaseNotFound
+		}
+	}
+	return nil
+}
+
+// apply 在同一个revision中执行写操作，没有实际变更时不增加revision
+func (r *MemoryRegistry) apply(ops []registry.PluginOp) {
+	rev := r.rev + 1
+	n := r.total
+	for _, op := range ops {
+		switch op.Action {
+		case registry.Put:
+			r.put(op, rev)
+		case registry.Delete:
+			r.del(op, rev)
+		}
+	}
+	if r.total != n {
+		r.rev = rev
+		r.notify()
+	}
+}
+
+func (r *MemoryRegistry) Do(ctx context.Context, opts ...registry.PluginOpOption) (*registry.PluginResponse, error) {
+	op := registry.OptionsToOp(opts...)
+	if op.Action == registry.Get {
+		r.lock.RLock()
+		defer r.lock.RUnlock()
+		return r.get(op)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err := r.checkLeases([]registry.PluginOp{op}); err != nil {
+		return nil, err
+	}
+	r.apply([]registry.PluginOp{op})
+	return &registry.PluginResponse{Action: op.Action, Revision: r.rev, Succeeded: true}, nil
+}
+
+func (r *MemoryRegistry) PutNoOverride(ctx context.Context, opts ...registry.PluginOpOption) (bool, error) {
+	op := registry.OpPut(opts...)
+	resp, err := r.TxnWithCmp(ctx, []registry.PluginOp{op}, []registry.CompareOp{
+		registry.OpCmp(registry.CmpCreateRev(op.Key), registry.CMP_EQUAL, 0),
+	}, nil)
+	if err != nil {
+		return false, err
+	}
+	return resp.Succeeded, nil
+}
+
+func (r *MemoryRegistry) Txn(ctx context.Context, ops []registry.PluginOp) (*registry.PluginResponse, error) {
+	return r.TxnWithCmp(ctx, ops, nil, nil)
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint32:
+		return int64(n), true
+	case uint64:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func compareResult(c int, result registry.CompareResult) bool {
+	switch result {
+	case registry.CMP_EQUAL:
+		return c == 0
+	case registry.CMP_GREATER:
+		return c > 0
+	case registry.CMP_LESS:
+		return c < 0
+	case registry.CMP_NOT_EQUAL:
+		return c != 0
+	}
+	return false
+}
+
+func (r *MemoryRegistry) compare(cmp registry.CompareOp) bool {
+	kv := r.kvs[util.BytesToStringWithNoCopy(cmp.Key)]
+	if cmp.Type == registry.CMP_VALUE {
+		// 与etcd一致，key不存在时比较值总是失败
+		if kv == nil {
+			return false
+		}
+		var v []byte
+		switch t := cmp.Value.(type) {
+		case []byte:
+			v = t
+		case string:
+			v = []byte(t)
+		default:
+			return false
+		}
+		return compareResult(bytes.Compare(kv.Value, v), cmp.Result)
+	}
+
+	v, ok := toInt64(cmp.Value)
+	if !ok {
+		return false
+	}
+	var actual int64
+	if kv != nil {
+		switch cmp.Type {
+		case registry.CMP_VERSION:
+			actual = kv.Version
+		case registry.CMP_CREATE:
+			actual = kv.CreateRevision
+		case registry.CMP_MOD:
+			actual = kv.ModRevision
+		}
+	}
+	switch {
+	case actual > v:
+		return compareResult(1, cmp.Result)
+	case actual < v:
+		return compareResult(-1, cmp.Result)
+	default:
+		return compareResult(0, cmp.Result)
+	}
+}
+
+func (r *MemoryRegistry) TxnWithCmp(ctx context.Context, success []registry.PluginOp, cmps []registry.CompareOp,
+	fail []registry.PluginOp) (*registry.PluginResponse, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	succeeded := true
+	for _, cmp := range cmps {
+		if !r.compare(cmp) {
+			succeeded = false
+			break
+		}
+	}
+	ops := success
+	if !succeeded {
+		ops = fail
+	}
+	if err := r.checkLeases(ops); err != nil {
+		return nil, err
+	}
+	r.apply(ops)
+	return &registry.PluginResponse{Succeeded: succeeded, Revision: r.rev}, nil
+}
+
+func (r *MemoryRegistry) LeaseGrant(ctx context.Context, TTL int64) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.leaseID++
+	r.leases[r.leaseID] = &lease{
+		TTL:    TTL,
+		expire: r.clock.Now().Add(time.Duration(TTL) * time.Second),
+		keys:   make(map[string]struct{}),
+	}
+	return r.leaseID, nil
+}
+
+func (r *MemoryRegistry) LeaseRenew(ctx context.Context, leaseID int64) (int64, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	l, ok := r.leases[leaseID]
+	if !ok {
+		return 0, ErrLeaseNotFound
+	}
+	l.expire = r.clock.Now().Add(time.Duration(l.TTL) * time.Second)
+	return l.TTL, nil
+}
+
+func (r *MemoryRegistry) LeaseRevoke(ctx context.Context, leaseID int64) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.leases[leaseID]; !ok {
+		return ErrLeaseNotFound
+	}
+	r.revoke(leaseID)
+	return nil
+}
+
+// revoke 删除租约和附加的key，调用方持有写锁
+func (r *MemoryRegistry) revoke(leaseID int64) {
+	l := r.leases[leaseID]
+	delete(r.leases, leaseID)
+	if len(l.keys) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(l.keys))
+	for key := range l.keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	ops := make([]registry.PluginOp, 0, len(keys))
+	for _, key := range keys {
+		ops = append(ops, registry.OpDel(registry.WithStrKey(key)))
+	}
+	r.apply(ops)
+}
+
+// ExpireLeases 立即删除按时钟已过期的租约，返回删除的key，ModRevision为删除时的revision
+func (r *MemoryRegistry) ExpireLeases() []*mvccpb.KeyValue {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	now := r.clock.Now()
+	var expired []int64
+	for id, l := range r.leases {
+		if !now.Before(l.expire) {
+			expired = append(expired, id)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i] < expired[j] })
+	total := r.total
+	for _, id := range expired {
+		r.revoke(id)
+		util.Logger().Debugf("lease %d expired", id)
+	}
+	// 变更数超过历史记录长度时只返回保留的部分
+	n := len(r.history) - int(r.total-total)
+	if n < 0 {
+		n = 0
+	}
+	kvs := make([]*mvccpb.KeyValue, 0, len(r.history)-n)
+	for _, evt := range r.history[n:] {
+		kvs = append(kvs, evt.kv)
+	}
+	return kvs
+}
+
+// events 返回revision不小于rev的匹配的变更，rev早于保留范围时返回ErrRevisionCompacted
+func (r *MemoryRegistry) events(op registry.PluginOp, rev int64) ([]*event, <-chan struct{}, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	if rev <= r.rev && (len(r.history) == 0 || r.history[0].kv.ModRevision > rev) {
+		return nil, nil, registry.ErrRevisionCompacted
+	}
+	i := sort.Search(len(r.history), func(i int) bool {
+		return r.history[i].kv.ModRevision >= rev
+	})
+	var evts []*event
+	for ; i < len(r.history); i++ {
+		if match(op, r.history[i].kv.Key) {
+			evts = append(evts, r.history[i])
+		}
+	}
+	return evts, r.changed, nil
+}
+
+// Watch 与etcd插件一致，连续的同类变更合并为一次回调，删除事件返回删除前的数据
+func (r *MemoryRegistry) Watch(ctx context.Context, opts ...registry.PluginOpOption) error {
+	op := registry.OpGet(opts...)
+	if len(op.Key) == 0 {
+		return fmt.Errorf("no key has been watched")
+	}
+	if op.Prefix && !strings.HasSuffix(util.BytesToStringWithNoCopy(op.Key), "/") {
+		op.Key = append(append([]byte(nil), op.Key...), '/')
+	}
+
+	next := op.Revision
+	if next <= 0 {
+		next = r.Revision() + 1
+	}
+	for {
+		evts, changed, err := r.events(op, next)
+		if err != nil {
+			return err
+		}
+		var pResp *registry.PluginResponse
+		var kvs []*mvccpb.KeyValue
+		for _, evt := range evts {
+			if pResp != nil && pResp.Action != evt.action {
+				if err := callback(op.WatchCallback, pResp, kvs); err != nil {
+					return err
+				}
+				kvs = nil
+			}
+			kv := evt.kv
+			if evt.action == registry.Delete {
+				kv = evt.prevKv
+			}
+			pResp = &registry.PluginResponse{Action: evt.action, Revision: evt.kv.ModRevision, Succeeded: true}
+			kvs = append(kvs, kv)
+			next = evt.kv.ModRevision + 1
+		}
+		if pResp != nil {
+			if err := callback(op.WatchCallback, pResp, kvs); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.closed:
+			return ErrClosed
+		case <-changed:
+		}
+	}
+}
+
+func callback(cb registry.WatchCallback, pResp *registry.PluginResponse, kvs []*mvccpb.KeyValue) error {
+	pResp.Kvs = kvs
+	pResp.Count = int64(len(kvs))
+	return cb("key information changed", pResp)
+}
+
+func (r *MemoryRegistry) run() {
+	r.goroutine.Do(func(stopCh <-chan struct{}) {
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(EXPIRE_INTERVAL):
+				r.ExpireLeases()
+			}
+		}
+	})
+}
+
+func NewRegistry() mgr.PluginInstance {
+	util.Logger().Warnf(nil, "starting service center in memory mode, the data is lost after restart")
+	inst := &MemoryRegistry{
+		kvs:       make(map[string]*mvccpb.KeyValue),
+		leases:    make(map[int64]*lease),
+		changed:   make(chan struct{}),
+		clock:     getClock(),
+		err:       make(chan error, 1),
+		ready:     make(chan int),
+		closed:    make(chan struct{}),
+		goroutine: util.NewGo(make(chan struct{})),
+	}
+	inst.run()
+	close(inst.ready)
+	return inst
+}
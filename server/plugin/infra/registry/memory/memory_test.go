@@ -0,0 +1,144 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package memory
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	c.now = c.now.Add(d)
+	c.lock.Unlock()
+}
+
+func TestMemoryRegistry(t *testing.T) {
+	r := NewRegistry().(*MemoryRegistry)
+	defer r.Close()
+	ctx := context.Background()
+
+	resp, err := r.Do(ctx, registry.PUT, registry.WithStrKey("/a/1"), registry.WithStrValue("1"))
+	if err != nil || resp.Revision != 1 {
+		t.Fatalf("TestMemoryRegistry failed, %v", err)
+	}
+	r.Do(ctx, registry.PUT, registry.WithStrKey("/a/2"), registry.WithStrValue("2"))
+	r.Do(ctx, registry.PUT, registry.WithStrKey("/a/1"), registry.WithStrValue("3"))
+
+	resp, err = r.Do(ctx, registry.GET, registry.WithStrKey("/a/"), registry.WithPrefix())
+	if err != nil || resp.Count != 2 || string(resp.Kvs[0].Value) != "3" || resp.Kvs[0].Version != 2 {
+		t.Fatalf("TestMemoryRegistry failed, %v", resp)
+	}
+	resp, err = r.Do(ctx, registry.GET, registry.WithStrKey("/a/"), registry.WithPrefix(), registry.WithRev(1))
+	if err != nil || resp.Count != 1 || string(resp.Kvs[0].Value) != "1" {
+		t.Fatalf("TestMemoryRegistry failed, get rev 1 %v", resp)
+	}
+	_, err = r.Do(ctx, registry.GET, registry.WithStrKey("/a/1"), registry.WithRev(10))
+	if err != registry.ErrFutureRevision {
+		t.Fatalf("TestMemoryRegistry failed, %v", err)
+	}
+
+	ok, err := r.PutNoOverride(ctx, registry.WithStrKey("/a/1"), registry.WithStrValue("4"))
+	if err != nil || ok {
+		t.Fatalf("TestMemoryRegistry failed, put no override")
+	}
+	resp, err = r.TxnWithCmp(ctx,
+		[]registry.PluginOp{registry.OpDel(registry.WithStrKey("/a/1"))},
+		[]registry.CompareOp{registry.OpCmp(registry.CmpStrVal("/a/1"), registry.CMP_EQUAL, "3")},
+		nil)
+	if err != nil || !resp.Succeeded {
+		t.Fatalf("TestMemoryRegistry failed, txn %v", err)
+	}
+	resp, _ = r.Do(ctx, registry.GET, registry.WithStrKey("/a/"), registry.WithPrefix(), registry.WithCountOnly())
+	if resp.Count != 1 {
+		t.Fatalf("TestMemoryRegistry failed, count %d", resp.Count)
+	}
+}
+
+func TestLeaseExpire(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	SetClock(clock)
+	defer SetClock(realClock{})
+	r := NewRegistry().(*MemoryRegistry)
+	defer r.Close()
+	ctx := context.Background()
+
+	_, err := r.Do(ctx, registry.PUT, registry.WithStrKey("/b"), registry.WithLease(100))
+	if err != ErrLeaseNotFound {
+		t.Fatalf("TestLeaseExpire failed, %v", err)
+	}
+	id, _ := r.LeaseGrant(ctx, 30)
+	r.Do(ctx, registry.PUT, registry.WithStrKey("/b"), registry.WithLease(id))
+
+	clock.Advance(20 * time.Second)
+	if kvs := r.ExpireLeases(); len(kvs) != 0 {
+		t.Fatalf("TestLeaseExpire failed, expired before ttl")
+	}
+	if ttl, err := r.LeaseRenew(ctx, id); err != nil || ttl != 30 {
+		t.Fatalf("TestLeaseExpire failed, renew %v", err)
+	}
+	clock.Advance(30 * time.Second)
+	kvs := r.ExpireLeases()
+	if len(kvs) != 1 || string(kvs[0].Key) != "/b" || kvs[0].ModRevision != r.Revision() {
+		t.Fatalf("TestLeaseExpire failed, %v", kvs)
+	}
+	if _, err := r.LeaseRenew(ctx, id); err != ErrLeaseNotFound {
+		t.Fatalf("TestLeaseExpire failed, renew expired lease")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	r := NewRegistry().(*MemoryRegistry)
+	defer r.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r.Do(ctx, registry.PUT, registry.WithStrKey("/c/1"))
+	ch := make(chan *registry.PluginResponse, 10)
+	go r.Watch(ctx, registry.WithStrKey("/c"), registry.WithPrefix(), registry.WithRev(1),
+		registry.WithWatchCallback(func(message string, evt *registry.PluginResponse) error {
+			ch <- evt
+			return nil
+		}))
+	r.Do(ctx, registry.PUT, registry.WithStrKey("/d/1"))
+	r.Do(ctx, registry.DEL, registry.WithStrKey("/c/1"))
+
+	for _, action := range []registry.ActionType{registry.Put, registry.Delete} {
+		select {
+		case evt := <-ch:
+			if evt.Action != action || string(evt.Kvs[0].Key) != "/c/1" {
+				t.Fatalf("TestWatch failed, %v", evt)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("TestWatch failed, timed out")
+		}
+	}
+}
@@ -19,8 +19,13 @@ package plugin
 import (
 	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/admission"
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/auditlog"
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/auth"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/cmdb"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/governance"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/metrics"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/naming"
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/quota"
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/security"
@@ -41,16 +46,26 @@ const (
 	CIPHER
 	QUOTA
 	REGISTRY
+	ADMISSION
+	GOVERNANCE
+	CMDB
+	METRICS
+	NAMING
 	typeEnd
 )
 
 var pluginNames = map[PluginName]string{
-	UUID:      "uuid",
-	AUDIT_LOG: "auditlog",
-	AUTH:      "auth",
-	CIPHER:    "cipher",
-	QUOTA:     "quota",
-	REGISTRY:  "registry",
+	UUID:       "uuid",
+	AUDIT_LOG:  "auditlog",
+	AUTH:       "auth",
+	CIPHER:     "cipher",
+	QUOTA:      "quota",
+	REGISTRY:   "registry",
+	ADMISSION:  "admission",
+	GOVERNANCE: "governance",
+	CMDB:       "cmdb",
+	METRICS:    "metrics",
+	NAMING:     "naming",
 }
 
 var pluginMgr = &PluginManager{}
@@ -219,6 +234,26 @@ func (pm *PluginManager) Quota() quota.QuotaManager {
 	return pm.Instance(QUOTA).(quota.QuotaManager)
 }
 
+func (pm *PluginManager) Admission() admission.Admission {
+	return pm.Instance(ADMISSION).(admission.Admission)
+}
+
+func (pm *PluginManager) Governance() governance.Governance {
+	return pm.Instance(GOVERNANCE).(governance.Governance)
+}
+
+func (pm *PluginManager) CMDB() cmdb.CMDB {
+	return pm.Instance(CMDB).(cmdb.CMDB)
+}
+
+func (pm *PluginManager) Metrics() metrics.Exporter {
+	return pm.Instance(METRICS).(metrics.Exporter)
+}
+
+func (pm *PluginManager) Naming() naming.Policy {
+	return pm.Instance(NAMING).(naming.Policy)
+}
+
 func Plugins() *PluginManager {
 	return pluginMgr
 }
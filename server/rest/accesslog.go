@@ -0,0 +1,213 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rest
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const ACCESS_LOG_FILE_NAME = "access.log"
+
+var (
+	accessLog     *accessLogger
+	accessLogOnce sync.Once
+)
+
+// AccessLogEntry 一次API调用的访问日志
+type AccessLogEntry struct {
+	Time      string  `json:"time"`
+	Method    string  `json:"method"`
+	Path      string  `json:"path"`
+	Route     string  `json:"route,omitempty"`
+	Domain    string  `json:"domain,omitempty"`
+	Project   string  `json:"project,omitempty"`
+	Caller    string  `json:"caller,omitempty"`
+	RemoteIP  string  `json:"remoteIp"`
+	Status    int     `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Bytes     int64   `json:"bytes"`
+}
+
+func (e *AccessLogEntry) Text() string {
+	return fmt.Sprintf("%s %s %s %d %.3fms %dB domain=%s project=%s caller=%s ip=%s route=%s",
+		e.Time, e.Method, e.Path, e.Status, e.LatencyMs, e.Bytes,
+		e.Domain, e.Project, e.Caller, e.RemoteIP, e.Route)
+}
+
+// accessLogWriter 记录响应码和响应字节数，websocket升级需要透传Hijack
+type accessLogWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *accessLogWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *accessLogWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *accessLogWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *accessLogWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijack")
+	}
+	// 升级后的连接不再经过ResponseWriter，记为101
+	w.status = http.StatusSwitchingProtocols
+	return h.Hijack()
+}
+
+type accessLogger struct {
+	lock          sync.Mutex
+	out           io.Writer
+	json          bool
+	sampleRate    float64
+	slowThreshold time.Duration
+}
+
+// Sampled 失败和慢请求总是记录，成功的请求按采样率记录
+func (l *accessLogger) Sampled(status int, latency time.Duration) bool {
+	if status >= http.StatusBadRequest || status == 0 {
+		return true
+	}
+	if l.slowThreshold > 0 && latency >= l.slowThreshold {
+		return true
+	}
+	return l.sampleRate >= 1 || rand.Float64() < l.sampleRate
+}
+
+func (l *accessLogger) Log(e *AccessLogEntry) {
+	var line []byte
+	if l.json {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return
+		}
+		line = append(data, '\n')
+	} else {
+		line = []byte(e.Text() + "\n")
+	}
+	l.lock.Lock()
+	l.out.Write(line)
+	l.lock.Unlock()
+}
+
+func accessLogFile() string {
+	cfg := core.ServerInfo.Config
+	if len(cfg.AccessLogFile) > 0 {
+		return os.ExpandEnv(cfg.AccessLogFile)
+	}
+	if len(cfg.LogFilePath) == 0 {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(os.ExpandEnv(cfg.LogFilePath)), ACCESS_LOG_FILE_NAME)
+}
+
+func newAccessLogger() *accessLogger {
+	cfg := core.ServerInfo.Config
+	l := &accessLogger{
+		out:        os.Stdout,
+		json:       cfg.AccessLogFormat == "json",
+		sampleRate: cfg.AccessLogSampleRate,
+	}
+	if l.sampleRate <= 0 || l.sampleRate > 1 {
+		util.Logger().Warnf(nil, "invalid access_log_sample_rate %v, use 1", l.sampleRate)
+		l.sampleRate = 1
+	}
+	if d, err := time.ParseDuration(cfg.AccessLogSlowThreshold); err == nil {
+		l.slowThreshold = d
+	}
+
+	// 与日志文件在同一目录时由logrotate负责转储，这里使用追加模式以兼容copy-truncate
+	if file := accessLogFile(); len(file) > 0 {
+		f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			util.Logger().Errorf(err, "open access log file %s failed, write to stdout", file)
+			return l
+		}
+		l.out = f
+	}
+	util.Logger().Infof("access log is enabled, sample rate %v, slow threshold %s",
+		l.sampleRate, l.slowThreshold)
+	return l
+}
+
+func getAccessLogger() *accessLogger {
+	if !core.ServerInfo.Config.AccessLogEnabled {
+		return nil
+	}
+	accessLogOnce.Do(func() {
+		accessLog = newAccessLogger()
+	})
+	return accessLog
+}
+
+func RecordAccessLog(w *accessLogWriter, r *http.Request, start time.Time) {
+	l := getAccessLogger()
+	if l == nil {
+		return
+	}
+	latency := time.Since(start)
+	if !l.Sampled(w.status, latency) {
+		return
+	}
+	ctx := r.Context()
+	route, _ := ctx.Value(rest.CTX_MATCH_PATTERN).(string)
+	l.Log(&AccessLogEntry{
+		Time:      start.Format(time.RFC3339Nano),
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		Route:     route,
+		Domain:    util.ParseDomain(ctx),
+		Project:   util.ParseProject(ctx),
+		Caller:    r.Header.Get("X-ConsumerId"),
+		RemoteIP:  util.GetRealIP(r),
+		Status:    w.status,
+		LatencyMs: float64(latency.Nanoseconds()) / float64(time.Millisecond),
+		Bytes:     w.bytes,
+	})
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAccessLogger_Sampled(t *testing.T) {
+	l := &accessLogger{sampleRate: 0, slowThreshold: time.Second}
+	if l.Sampled(http.StatusOK, time.Millisecond) {
+		t.Fatalf("TestAccessLogger_Sampled failed, success request should be sampled")
+	}
+	if !l.Sampled(http.StatusInternalServerError, time.Millisecond) || !l.Sampled(http.StatusOK, 2*time.Second) {
+		t.Fatalf("TestAccessLogger_Sampled failed, failed and slow requests should always be logged")
+	}
+	l.sampleRate = 1
+	if !l.Sampled(http.StatusOK, time.Millisecond) {
+		t.Fatalf("TestAccessLogger_Sampled failed, sample rate 1 should log all")
+	}
+}
+
+func TestRecordAccessLog(t *testing.T) {
+	enabled := core.ServerInfo.Config.AccessLogEnabled
+	defer func() {
+		core.ServerInfo.Config.AccessLogEnabled = enabled
+		accessLog = nil
+	}()
+	core.ServerInfo.Config.AccessLogEnabled = true
+	accessLogOnce.Do(func() {})
+
+	buf := bytes.NewBuffer(nil)
+	accessLog = &accessLogger{out: buf, json: true, sampleRate: 1}
+	r := httptest.NewRequest(http.MethodPost, "/v4/default/registry/microservices", nil)
+	r.Header.Set("X-ConsumerId", "consumer")
+	r = util.SetRequestContext(r, "domain", "default")
+	r = util.SetRequestContext(r, "project", "p")
+	w := &accessLogWriter{ResponseWriter: httptest.NewRecorder()}
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte("12345"))
+	RecordAccessLog(w, r, time.Now())
+
+	e := &AccessLogEntry{}
+	if err := json.Unmarshal(buf.Bytes(), e); err != nil {
+		t.Fatalf("TestRecordAccessLog failed, %s, %s", buf.String(), err)
+	}
+	if e.Method != http.MethodPost || e.Path != "/v4/default/registry/microservices" || e.Status != http.StatusCreated ||
+		e.Bytes != 5 || e.Domain != "default" || e.Project != "p" || e.Caller != "consumer" || e.RemoteIP != "192.0.2.1" {
+		t.Fatalf("TestRecordAccessLog failed, %s", buf.String())
+	}
+
+	// 文本格式，未写状态码时为200
+	buf.Reset()
+	accessLog.json = false
+	w = &accessLogWriter{ResponseWriter: httptest.NewRecorder()}
+	w.Write([]byte("ok"))
+	RecordAccessLog(w, httptest.NewRequest(http.MethodGet, "/version", nil), time.Now())
+	if line := buf.String(); !strings.Contains(line, " GET /version 200 ") || !strings.HasSuffix(line, "\n") {
+		t.Fatalf("TestRecordAccessLog failed, text format %s", line)
+	}
+
+	// 未开启时不记录
+	buf.Reset()
+	core.ServerInfo.Config.AccessLogEnabled = false
+	RecordAccessLog(w, r, time.Now())
+	if buf.Len() != 0 {
+		t.Fatalf("TestRecordAccessLog failed, logged when disabled")
+	}
+}
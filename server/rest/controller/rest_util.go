@@ -17,19 +17,43 @@
 package controller
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
-	"github.com/apache/incubator-servicecomb-service-center/server/error"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
 	"net/http"
 )
 
 func WriteError(w http.ResponseWriter, code int32, detail string) {
-	err := error.NewError(code, detail)
+	err := scerr.NewError(code, detail)
 	err.HttpWrite(w)
 }
 
+// WriteBodyError 读取请求体失败，超过大小上限时返回413，其他为参数错误
+func WriteBodyError(w http.ResponseWriter, err error) {
+	if err == core.ErrBodyTooLarge {
+		WriteError(w, scerr.ErrRequestBodyTooLarge, err.Error())
+		return
+	}
+	WriteError(w, scerr.ErrInvalidParams, err.Error())
+}
+
+// ReadContent 读取请求体原文，声明了长度时按长度一次分配，避免ioutil.ReadAll扩容时的多次复制
+func ReadContent(r *http.Request) (string, error) {
+	size := r.ContentLength
+	if size < 0 {
+		size = 0
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, size+bytes.MinRead))
+	if _, err := buf.ReadFrom(r.Body); err != nil {
+		return "", err
+	}
+	return util.BytesToStringWithNoCopy(buf.Bytes()), nil
+}
+
 func WriteJsonObject(w http.ResponseWriter, obj interface{}) {
 	if obj == nil {
 		w.Header().Add("X-Response-Status", fmt.Sprint(http.StatusOK))
@@ -40,7 +64,7 @@ func WriteJsonObject(w http.ResponseWriter, obj interface{}) {
 
 	objJson, err := json.Marshal(obj)
 	if err != nil {
-		WriteError(w, error.ErrInternal, err.Error())
+		WriteError(w, scerr.ErrInternal, err.Error())
 		return
 	}
 	w.Header().Add("X-Response-Status", fmt.Sprint(http.StatusOK))
@@ -55,5 +79,7 @@ func WriteResponse(w http.ResponseWriter, resp *pb.Response, obj interface{}) {
 		return
 	}
 
-	WriteError(w, resp.GetCode(), resp.GetMessage())
+	err := scerr.NewError(resp.GetCode(), resp.GetMessage())
+	err.Details = resp.GetDetails()
+	err.HttpWrite(w)
 }
@@ -31,5 +31,11 @@ func (this *DependencyService) URLPatterns() []rest.Route {
 		{rest.HTTP_METHOD_PUT, "/registry/v3/dependencies", this.CreateDependenciesForMicroServices},
 		{rest.HTTP_METHOD_GET, "/registry/v3/microservices/:consumerId/providers", this.GetConProDependencies},
 		{rest.HTTP_METHOD_GET, "/registry/v3/microservices/:providerId/consumers", this.GetProConDependencies},
+		{rest.HTTP_METHOD_GET, "/registry/v3/microservices/:consumerId/dependency-diff", this.GetDependencyDiff},
+		{rest.HTTP_METHOD_GET, "/registry/v3/microservices/:consumerId/dependency-history", this.GetDependencyHistory},
+		{rest.HTTP_METHOD_GET, "/registry/v3/microservices/:providerId/accessors", this.GetProviderAccessors},
+		{rest.HTTP_METHOD_GET, "/registry/v3/microservices/:providerId/approvals", this.GetApprovals},
+		{rest.HTTP_METHOD_PUT, "/registry/v3/microservices/:providerId/approvals/:consumerId", this.ApproveDependency},
+		{rest.HTTP_METHOD_DELETE, "/registry/v3/microservices/:providerId/approvals/:consumerId", this.RevokeApproval},
 	}
 }
@@ -33,6 +33,7 @@ func (this *MicroServiceInstanceService) URLPatterns() []rest.Route {
 		{rest.HTTP_METHOD_POST, "/registry/v3/microservices/:serviceId/instances", this.RegisterInstance},
 		{rest.HTTP_METHOD_DELETE, "/registry/v3/microservices/:serviceId/instances/:instanceId", this.UnregisterInstance},
 		{rest.HTTP_METHOD_PUT, "/registry/v3/microservices/:serviceId/instances/:instanceId/properties", this.UpdateMetadata},
+		{rest.HTTP_METHOD_PATCH, "/registry/v3/microservices/:serviceId/instances/:instanceId/properties", this.PatchMetadata},
 		{rest.HTTP_METHOD_PUT, "/registry/v3/microservices/:serviceId/instances/:instanceId/status", this.UpdateStatus},
 		{rest.HTTP_METHOD_PUT, "/registry/v3/microservices/:serviceId/instances/:instanceId/heartbeat", this.Heartbeat},
 		{rest.HTTP_METHOD_PUT, "/registry/v3/heartbeats", this.HeartbeatSet},
@@ -29,5 +29,6 @@ func (this *WatchService) URLPatterns() []rest.Route {
 	return []rest.Route{
 		{rest.HTTP_METHOD_GET, "/registry/v3/microservices/:serviceId/watcher", this.Watch},
 		{rest.HTTP_METHOD_GET, "/registry/v3/microservices/:serviceId/listwatcher", this.ListAndWatch},
+		{rest.HTTP_METHOD_GET, "/registry/v3/microservices/:serviceId/invalidations", this.WatchInvalidations},
 	}
 }
@@ -34,6 +34,7 @@ func (this *MainService) URLPatterns() []rest.Route {
 	return []rest.Route{
 		{rest.HTTP_METHOD_GET, "/version", this.GetVersion},
 		{rest.HTTP_METHOD_GET, "/health", this.ClusterHealth},
+		{rest.HTTP_METHOD_GET, "/errors", this.GetErrorCodes},
 	}
 }
 
@@ -32,7 +32,14 @@ func (this *MicroServiceService) URLPatterns() []rest.Route {
 		{rest.HTTP_METHOD_GET, "/registry/v3/microservices/:serviceId", this.GetServiceOne},
 		{rest.HTTP_METHOD_POST, "/registry/v3/microservices", this.Register},
 		{rest.HTTP_METHOD_PUT, "/registry/v3/microservices/:serviceId/properties", this.Update},
+		{rest.HTTP_METHOD_PATCH, "/registry/v3/microservices/:serviceId/properties", this.Patch},
+		{rest.HTTP_METHOD_PUT, "/registry/v3/microservices/:serviceId/catalog", this.UpdateCatalog},
+		{rest.HTTP_METHOD_PUT, "/registry/v3/microservices/:serviceId/retirement", this.UpdateRetirement},
+		{rest.HTTP_METHOD_DELETE, "/registry/v3/microservices/:serviceId/retirement", this.CancelRetirement},
+		{rest.HTTP_METHOD_PUT, "/registry/v3/microservices/:serviceId/schema-freeze", this.FreezeSchemas},
+		{rest.HTTP_METHOD_DELETE, "/registry/v3/microservices/:serviceId/schema-freeze", this.UnfreezeSchemas},
 		{rest.HTTP_METHOD_DELETE, "/registry/v3/microservices/:serviceId", this.Unregister},
 		{rest.HTTP_METHOD_DELETE, "/registry/v3/microservices", this.UnregisterServices},
+		{rest.HTTP_METHOD_PUT, "/registry/v3/apply", this.Apply},
 	}
 }
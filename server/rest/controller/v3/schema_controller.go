@@ -32,5 +32,8 @@ func (this *SchemaService) URLPatterns() []rest.Route {
 		{rest.HTTP_METHOD_DELETE, "/registry/v3/microservices/:serviceId/schemas/:schemaId", this.DeleteSchemas},
 		{rest.HTTP_METHOD_POST, "/registry/v3/microservices/:serviceId/schemas", this.ModifySchemas},
 		{rest.HTTP_METHOD_GET, "/registry/v3/microservices/:serviceId/schemas", this.GetAllSchemas},
+		{rest.HTTP_METHOD_GET, "/registry/v3/definitions", this.GetSharedDefinitions},
+		{rest.HTTP_METHOD_PUT, "/registry/v3/definitions/:name", this.ModifySharedDefinition},
+		{rest.HTTP_METHOD_DELETE, "/registry/v3/definitions/:name", this.DeleteSharedDefinition},
 	}
 }
@@ -30,6 +30,8 @@ func initRouter() {
 	roa.RegisterServent(&SchemaService{})
 	roa.RegisterServent(&DependencyService{})
 	roa.RegisterServent(&TagService{})
+	roa.RegisterServent(&DelegationService{})
+	roa.RegisterServent(&BroadcastService{})
 	roa.RegisterServent(&RuleService{})
 	roa.RegisterServent(&MicroServiceInstanceService{})
 	roa.RegisterServent(&WatchService{})
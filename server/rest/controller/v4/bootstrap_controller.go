@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package v4
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"net/http"
+)
+
+type BootstrapService struct {
+	//
+}
+
+func (this *BootstrapService) URLPatterns() []rest.Route {
+	return []rest.Route{
+		{rest.HTTP_METHOD_GET, "/v4/:project/bootstrap", this.GetBootstrap},
+	}
+}
+
+// GetBootstrap 返回客户端启动需要的配置，指定serviceName时按appId/serviceName/version查询serviceId，
+// 服务不存在时serviceId为空，客户端需先注册
+func (this *BootstrapService) GetBootstrap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	var serviceId string
+	if serviceName := query.Get("serviceName"); len(serviceName) > 0 {
+		resp, _ := core.ServiceAPI.Exist(ctx, &pb.GetExistenceRequest{
+			Type:        "microservice",
+			Environment: query.Get("env"),
+			AppId:       query.Get("appId"),
+			ServiceName: serviceName,
+			Version:     query.Get("version"),
+		})
+		switch resp.Response.GetCode() {
+		case pb.Response_SUCCESS:
+			serviceId = resp.ServiceId
+		case scerr.ErrServiceNotExists:
+		default:
+			controller.WriteResponse(w, resp.Response, nil)
+			return
+		}
+	}
+
+	result := serviceUtil.GetClientBootstrap(ctx, util.ParseDomainProject(ctx), serviceId)
+	result.Watch = watchHint(r, serviceId)
+	controller.WriteJsonObject(w, result)
+}
+
+// watchHint 经过可信代理时按代理转发的协议生成地址
+func watchHint(r *http.Request, serviceId string) *serviceUtil.WatchHint {
+	scheme := "ws"
+	switch util.GetSchemeFromContext(r.Context()) {
+	case "https":
+		scheme = "wss"
+	case "":
+		if core.ServerInfo.Config.SslEnabled {
+			scheme = "wss"
+		}
+	}
+	if len(serviceId) == 0 {
+		serviceId = "{serviceId}"
+	}
+	prefix := fmt.Sprintf("%s://%s/v4/%s/registry/microservices/%s",
+		scheme, r.Host, util.ParseProject(r.Context()), serviceId)
+	return &serviceUtil.WatchHint{
+		Watcher:     prefix + "/watcher",
+		ListWatcher: prefix + "/listwatcher",
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package v4
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	"io/ioutil"
+	"net/http"
+)
+
+type DelegationService struct {
+	//
+}
+
+func (this *DelegationService) URLPatterns() []rest.Route {
+	return []rest.Route{
+		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:serviceId/delegations", this.GetDelegations},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/delegations/:controllerId", this.GrantDelegation},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/registry/microservices/:serviceId/delegations/:controllerId", this.RevokeDelegation},
+		{rest.HTTP_METHOD_POST, "/v4/:project/registry/delegations/:controllerId/microservices/:serviceId/instances", this.DelegateRegister},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/registry/delegations/:controllerId/microservices/:serviceId/instances/:instanceId", this.DelegateUnregister},
+	}
+}
+
+func (this *DelegationService) GetDelegations(w http.ResponseWriter, r *http.Request) {
+	request := &pb.GetDelegationsRequest{
+		ServiceId: r.URL.Query().Get(":serviceId"),
+	}
+	resp, _ := core.ServiceAPI.GetDelegations(r.Context(), request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (this *DelegationService) GrantDelegation(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.GrantDelegationRequest{
+		ServiceId:           query.Get(":serviceId"),
+		ControllerServiceId: query.Get(":controllerId"),
+		ConsumerServiceId:   r.Header.Get("X-ConsumerId"),
+	}
+	resp, _ := core.ServiceAPI.GrantDelegation(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+func (this *DelegationService) RevokeDelegation(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.RevokeDelegationRequest{
+		ServiceId:           query.Get(":serviceId"),
+		ControllerServiceId: query.Get(":controllerId"),
+		ConsumerServiceId:   r.Header.Get("X-ConsumerId"),
+	}
+	resp, _ := core.ServiceAPI.RevokeDelegation(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+func (this *DelegationService) DelegateRegister(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("delegate register instance failed, body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+
+	request := &pb.DelegateRegisterInstanceRequest{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("delegate register instance failed, Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, "Unmarshal error")
+		return
+	}
+	query := r.URL.Query()
+	request.ControllerServiceId = query.Get(":controllerId")
+	if request.GetInstance() != nil {
+		request.Instance.ServiceId = query.Get(":serviceId")
+	}
+
+	resp, _ := core.InstanceAPI.DelegateRegister(r.Context(), request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (this *DelegationService) DelegateUnregister(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.DelegateUnregisterInstanceRequest{
+		ControllerServiceId: query.Get(":controllerId"),
+		ServiceId:           query.Get(":serviceId"),
+		InstanceId:          query.Get(":instanceId"),
+	}
+	resp, _ := core.InstanceAPI.DelegateUnregister(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
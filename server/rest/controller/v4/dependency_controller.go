@@ -36,8 +36,16 @@ func (this *DependencyService) URLPatterns() []rest.Route {
 	return []rest.Route{
 		{rest.HTTP_METHOD_POST, "/v4/:project/registry/dependencies", this.AddDependenciesForMicroServices},
 		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/dependencies", this.CreateDependenciesForMicroServices},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/dependencies/traffic", this.ReportDependencyTraffic},
+		{rest.HTTP_METHOD_POST, "/v4/:project/registry/dependencies/lint", this.LintDependencies},
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:consumerId/providers", this.GetConProDependencies},
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:providerId/consumers", this.GetProConDependencies},
+		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:consumerId/dependency-diff", this.GetDependencyDiff},
+		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:consumerId/dependency-history", this.GetDependencyHistory},
+		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:providerId/accessors", this.GetProviderAccessors},
+		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:providerId/approvals", this.GetApprovals},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:providerId/approvals/:consumerId", this.ApproveDependency},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/registry/microservices/:providerId/approvals/:consumerId", this.RevokeApproval},
 	}
 }
 
@@ -45,7 +53,7 @@ func (this *DependencyService) AddDependenciesForMicroServices(w http.ResponseWr
 	requestBody, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		util.Logger().Error("body err", err)
-		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		controller.WriteBodyError(w, err)
 		return
 	}
 	request := &pb.AddDependenciesRequest{}
@@ -64,7 +72,7 @@ func (this *DependencyService) CreateDependenciesForMicroServices(w http.Respons
 	requestBody, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		util.Logger().Error("body err", err)
-		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		controller.WriteBodyError(w, err)
 		return
 	}
 	request := &pb.CreateDependenciesRequest{}
@@ -79,6 +87,48 @@ func (this *DependencyService) CreateDependenciesForMicroServices(w http.Respons
 	controller.WriteResponse(w, resp.Response, nil)
 }
 
+func (this *DependencyService) ReportDependencyTraffic(w http.ResponseWriter, r *http.Request) {
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &pb.ReportDependencyTrafficRequest{}
+	err = json.Unmarshal(requestBody, request)
+	if err != nil {
+		util.Logger().Error("Invalid json", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	resp, _ := core.ServiceAPI.ReportDependencyTraffic(r.Context(), request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (this *DependencyService) LintDependencies(w http.ResponseWriter, r *http.Request) {
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &pb.LintDependenciesRequest{}
+	err = json.Unmarshal(requestBody, request)
+	if err != nil {
+		util.Logger().Error("Invalid json", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	resp, _ := core.ServiceAPI.LintDependencies(r.Context(), request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
 func (this *DependencyService) GetConProDependencies(w http.ResponseWriter, r *http.Request) {
 	request := &pb.GetDependenciesRequest{
 		ServiceId: r.URL.Query().Get(":consumerId"),
@@ -98,3 +148,68 @@ func (this *DependencyService) GetProConDependencies(w http.ResponseWriter, r *h
 	resp.Response = nil
 	controller.WriteResponse(w, respInternal, resp)
 }
+
+func (this *DependencyService) GetDependencyDiff(w http.ResponseWriter, r *http.Request) {
+	request := &pb.GetDependenciesRequest{
+		ServiceId: r.URL.Query().Get(":consumerId"),
+	}
+	resp, _ := core.ServiceAPI.GetDependencyDiff(r.Context(), request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (this *DependencyService) GetDependencyHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.GetDependencyHistoryRequest{
+		ServiceId: query.Get(":consumerId"),
+		Revision:  query.Get("revision"),
+		Timestamp: query.Get("timestamp"),
+	}
+	resp, _ := core.ServiceAPI.GetDependencyHistory(r.Context(), request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (this *DependencyService) GetProviderAccessors(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.GetProviderAccessorsRequest{
+		ProviderServiceId: query.Get(":providerId"),
+		Window:            query.Get("window"),
+	}
+	resp, _ := core.ServiceAPI.GetProviderAccessors(r.Context(), request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (this *DependencyService) GetApprovals(w http.ResponseWriter, r *http.Request) {
+	request := &pb.GetDependencyApprovalsRequest{
+		ProviderServiceId: r.URL.Query().Get(":providerId"),
+	}
+	resp, _ := core.ServiceAPI.GetDependencyApprovals(r.Context(), request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (this *DependencyService) ApproveDependency(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.ApproveDependencyRequest{
+		ProviderServiceId: query.Get(":providerId"),
+		ConsumerServiceId: query.Get(":consumerId"),
+	}
+	resp, _ := core.ServiceAPI.ApproveDependency(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+func (this *DependencyService) RevokeApproval(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.RevokeDependencyApprovalRequest{
+		ProviderServiceId: query.Get(":providerId"),
+		ConsumerServiceId: query.Get(":consumerId"),
+	}
+	resp, _ := core.ServiceAPI.RevokeDependencyApproval(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
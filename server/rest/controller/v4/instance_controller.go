@@ -24,9 +24,12 @@ import (
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
 	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type MicroServiceInstanceService struct {
@@ -40,17 +43,23 @@ func (this *MicroServiceInstanceService) URLPatterns() []rest.Route {
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId", this.GetOneInstance},
 		{rest.HTTP_METHOD_POST, "/v4/:project/registry/microservices/:serviceId/instances", this.RegisterInstance},
 		{rest.HTTP_METHOD_DELETE, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId", this.UnregisterInstance},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/registry/microservices/:serviceId/instances", this.UnregisterInstances},
 		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/properties", this.UpdateMetadata},
+		{rest.HTTP_METHOD_PATCH, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/properties", this.PatchMetadata},
 		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/status", this.UpdateStatus},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/capacity", this.UpdateCapacity},
 		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/heartbeat", this.Heartbeat},
 		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/heartbeats", this.HeartbeatSet},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/promote", this.PromoteInstance},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/promote", this.PromoteInstances},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/activate", this.ActivateInstances},
 	}
 }
 func (this *MicroServiceInstanceService) RegisterInstance(w http.ResponseWriter, r *http.Request) {
 	message, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		util.Logger().Error("register instance failed, body err", err)
-		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		controller.WriteBodyError(w, err)
 		return
 	}
 
@@ -73,10 +82,25 @@ func (this *MicroServiceInstanceService) RegisterInstance(w http.ResponseWriter,
 
 //TODO 什么样的服务允许更新服务心跳，只能是本服务才可以更新自己，如何屏蔽其他服务伪造的心跳更新？
 func (this *MicroServiceInstanceService) Heartbeat(w http.ResponseWriter, r *http.Request) {
-	request := &pb.HeartbeatRequest{
-		ServiceId:  r.URL.Query().Get(":serviceId"),
-		InstanceId: r.URL.Query().Get(":instanceId"),
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("heartbeat failed, body err", err)
+		controller.WriteBodyError(w, err)
+		return
 	}
+
+	request := &pb.HeartbeatRequest{}
+	// body可选，用于随心跳上报实例状态
+	if len(message) > 0 {
+		err = json.Unmarshal(message, request)
+		if err != nil {
+			util.Logger().Error("heartbeat failed, Unmarshal error", err)
+			controller.WriteError(w, scerr.ErrInvalidParams, "Unmarshal error")
+			return
+		}
+	}
+	request.ServiceId = r.URL.Query().Get(":serviceId")
+	request.InstanceId = r.URL.Query().Get(":instanceId")
 	resp, _ := core.InstanceAPI.Heartbeat(r.Context(), request)
 	controller.WriteResponse(w, resp.Response, nil)
 }
@@ -85,7 +109,7 @@ func (this *MicroServiceInstanceService) HeartbeatSet(w http.ResponseWriter, r *
 	message, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		util.Logger().Error("register instance failed, body err", err)
-		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		controller.WriteBodyError(w, err)
 		return
 	}
 
@@ -117,6 +141,40 @@ func (this *MicroServiceInstanceService) UnregisterInstance(w http.ResponseWrite
 	controller.WriteResponse(w, resp.Response, nil)
 }
 
+// UnregisterInstances 批量注销匹配条件的实例，properties格式为key:value,key:value，
+// registeredBefore为unix秒，dryRun=true时只返回匹配的实例
+func (this *MicroServiceInstanceService) UnregisterInstances(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.UnregisterInstancesRequest{
+		ServiceId: query.Get(":serviceId"),
+		Status:    query.Get("status"),
+		Cidr:      query.Get("cidr"),
+		DryRun:    query.Get("dryRun") == "true",
+	}
+	if props := query.Get("properties"); len(props) > 0 {
+		request.Properties = make(map[string]string)
+		for _, prop := range strings.Split(props, ",") {
+			kv := strings.SplitN(prop, ":", 2)
+			if len(kv) != 2 || len(kv[0]) == 0 {
+				controller.WriteError(w, scerr.ErrInvalidParams, "parameter properties must be in key:value format")
+				return
+			}
+			request.Properties[kv[0]] = kv[1]
+		}
+	}
+	if before := query.Get("registeredBefore"); len(before) > 0 {
+		var err error
+		if request.RegisteredBefore, err = strconv.ParseInt(before, 10, 64); err != nil {
+			controller.WriteError(w, scerr.ErrInvalidParams, "parameter registeredBefore must be a number")
+			return
+		}
+	}
+	resp, _ := core.InstanceAPI.UnregisterInstances(r.Context(), request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
 func (this *MicroServiceInstanceService) FindInstances(w http.ResponseWriter, r *http.Request) {
 	var ids []string
 	keys := r.URL.Query().Get("tags")
@@ -129,13 +187,49 @@ func (this *MicroServiceInstanceService) FindInstances(w http.ResponseWriter, r
 		ServiceName:       r.URL.Query().Get("serviceName"),
 		VersionRule:       r.URL.Query().Get("version"),
 		Tags:              ids,
+		NoDependency:      r.URL.Query().Get("noDependency") == "true",
+		WithGovernance:    r.URL.Query().Get("withGovernance") == "true",
+		PlatformPolicy:    r.URL.Query().Get("platformPolicy"),
+		Order:             r.URL.Query().Get("order"),
+		Zone:              r.URL.Query().Get("zone"),
+		AddressFamily:     r.URL.Query().Get("addressFamily"),
+		CrossEnvironment:  r.URL.Query().Get("crossEnvironment") == "true",
+	}
+	if protocols := r.URL.Query().Get("protocols"); len(protocols) > 0 {
+		request.Protocols = strings.Split(protocols, ",")
+	}
+	platform := &pb.Platform{Os: r.URL.Query().Get("os"), Arch: r.URL.Query().Get("arch")}
+	if len(platform.Os) > 0 || len(platform.Arch) > 0 {
+		request.Platform = platform
 	}
 	resp, _ := core.InstanceAPI.Find(r.Context(), request)
+	writeDeprecationHeaders(w, resp.Deprecations)
 	respInternal := resp.Response
 	resp.Response = nil
 	controller.WriteResponse(w, respInternal, resp)
 }
 
+// writeDeprecationHeaders 匹配到计划下线的版本时，按RFC 8594返回Deprecation和最早的Sunset日期
+func writeDeprecationHeaders(w http.ResponseWriter, deprecations []*pb.RetiringVersion) {
+	if len(deprecations) == 0 {
+		return
+	}
+	var sunset time.Time
+	for _, d := range deprecations {
+		t, err := serviceUtil.ParseRetirementDate(d.TargetDate)
+		if err != nil {
+			continue
+		}
+		if sunset.IsZero() || t.Before(sunset) {
+			sunset = t
+		}
+	}
+	w.Header().Set("Deprecation", "true")
+	if !sunset.IsZero() {
+		w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+	}
+}
+
 func (this *MicroServiceInstanceService) GetOneInstance(w http.ResponseWriter, r *http.Request) {
 	var ids []string
 	keys := r.URL.Query().Get("tags")
@@ -174,19 +268,96 @@ func (this *MicroServiceInstanceService) GetInstances(w http.ResponseWriter, r *
 func (this *MicroServiceInstanceService) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 	status := r.URL.Query().Get("value")
 	request := &pb.UpdateInstanceStatusRequest{
+		ServiceId:     r.URL.Query().Get(":serviceId"),
+		InstanceId:    r.URL.Query().Get(":instanceId"),
+		Status:        status,
+		ReasonCode:    r.URL.Query().Get("reasonCode"),
+		ReasonMessage: r.URL.Query().Get("reasonMessage"),
+	}
+	resp, _ := core.InstanceAPI.UpdateStatus(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+// UpdateCapacity 更新实例的容量提示，value为0时清除
+func (this *MicroServiceInstanceService) UpdateCapacity(w http.ResponseWriter, r *http.Request) {
+	capacity, err := strconv.ParseInt(r.URL.Query().Get("value"), 10, 32)
+	if err != nil {
+		util.Logger().Error("parse capacity error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, "Invalid capacity value.")
+		return
+	}
+	request := &pb.UpdateInstanceCapacityRequest{
 		ServiceId:  r.URL.Query().Get(":serviceId"),
 		InstanceId: r.URL.Query().Get(":instanceId"),
-		Status:     status,
+		Capacity:   int32(capacity),
 	}
-	resp, _ := core.InstanceAPI.UpdateStatus(r.Context(), request)
+	resp, _ := core.InstanceAPI.UpdateCapacity(r.Context(), request)
 	controller.WriteResponse(w, resp.Response, nil)
 }
 
+// PromoteInstance 将单个待命实例提升为UP
+func (this *MicroServiceInstanceService) PromoteInstance(w http.ResponseWriter, r *http.Request) {
+	request := &pb.PromoteInstancesRequest{
+		ServiceId:   r.URL.Query().Get(":serviceId"),
+		InstanceIds: []string{r.URL.Query().Get(":instanceId")},
+	}
+	resp, _ := core.InstanceAPI.PromoteInstances(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+// PromoteInstances 批量提升待命实例，body为空时提升服务的所有待命实例
+func (this *MicroServiceInstanceService) PromoteInstances(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &pb.PromoteInstancesRequest{}
+	if len(message) > 0 {
+		err = json.Unmarshal(message, request)
+		if err != nil {
+			util.Logger().Error("Unmarshal error", err)
+			controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+			return
+		}
+	}
+	request.ServiceId = r.URL.Query().Get(":serviceId")
+	resp, _ := core.InstanceAPI.PromoteInstances(r.Context(), request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+// ActivateInstances 批量激活预注册实例，body为空时激活服务的所有预注册实例
+func (this *MicroServiceInstanceService) ActivateInstances(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &pb.PromoteInstancesRequest{}
+	if len(message) > 0 {
+		err = json.Unmarshal(message, request)
+		if err != nil {
+			util.Logger().Error("Unmarshal error", err)
+			controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+			return
+		}
+	}
+	request.ServiceId = r.URL.Query().Get(":serviceId")
+	resp, _ := core.InstanceAPI.ActivateInstances(r.Context(), request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
 func (this *MicroServiceInstanceService) UpdateMetadata(w http.ResponseWriter, r *http.Request) {
 	message, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		util.Logger().Error("body err", err)
-		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		controller.WriteBodyError(w, err)
 		return
 	}
 	request := &pb.UpdateInstancePropsRequest{
@@ -202,3 +373,28 @@ func (this *MicroServiceInstanceService) UpdateMetadata(w http.ResponseWriter, r
 	resp, err := core.InstanceAPI.UpdateInstanceProperties(r.Context(), request)
 	controller.WriteResponse(w, resp.Response, nil)
 }
+
+// PatchMetadata 按JSON Merge Patch(RFC 7396)合并实例properties，值为null的属性被删除
+func (this *MicroServiceInstanceService) PatchMetadata(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	properties, removed, err := parsePropertiesPatch(message)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, "Unmarshal error")
+		return
+	}
+	request := &pb.UpdateInstancePropsRequest{
+		ServiceId:        r.URL.Query().Get(":serviceId"),
+		InstanceId:       r.URL.Query().Get(":instanceId"),
+		Properties:       properties,
+		Merge:            true,
+		RemoveProperties: removed,
+	}
+	resp, _ := core.InstanceAPI.UpdateInstanceProperties(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
@@ -23,6 +23,7 @@ import (
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	"github.com/gorilla/websocket"
 	"net/http"
+	"strconv"
 )
 
 type WatchService struct {
@@ -33,6 +34,7 @@ func (this *WatchService) URLPatterns() []rest.Route {
 	return []rest.Route{
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:serviceId/watcher", this.Watch},
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:serviceId/listwatcher", this.ListAndWatch},
+		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:serviceId/invalidations", this.WatchInvalidations},
 	}
 }
 
@@ -53,6 +55,30 @@ func upgrade(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
 	return conn, err
 }
 
+// watchRequest format为json或proto时事件封装为带版本的信封，version为空时使用最新版本，
+// compact为true时合并同一实例的频繁变化，revision为Find返回的revision时先补发其后的事件，
+// group非空时同一服务同组的多个副本共享一个订阅，subscription非空时重连从该订阅记录的revision续接
+func watchRequest(r *http.Request) *pb.WatchInstanceRequest {
+	query := r.URL.Query()
+	version, err := strconv.ParseInt(query.Get("version"), 10, 32)
+	if err != nil && len(query.Get("version")) > 0 {
+		version = -1
+	}
+	revision, err := strconv.ParseInt(query.Get("revision"), 10, 64)
+	if err != nil && len(query.Get("revision")) > 0 {
+		revision = -1
+	}
+	return &pb.WatchInstanceRequest{
+		SelfServiceId: query.Get(":serviceId"),
+		Format:        query.Get("format"),
+		Version:       int32(version),
+		Compact:       query.Get("compact") == "true",
+		Revision:      revision,
+		Group:         query.Get("group"),
+		Subscription:  query.Get("subscription"),
+	}
+}
+
 func (this *WatchService) Watch(w http.ResponseWriter, r *http.Request) {
 	conn, err := upgrade(w, r)
 	if err != nil {
@@ -61,9 +87,7 @@ func (this *WatchService) Watch(w http.ResponseWriter, r *http.Request) {
 	defer conn.Close()
 
 	r.Method = "WATCH"
-	core.InstanceAPI.WebSocketWatch(r.Context(), &pb.WatchInstanceRequest{
-		SelfServiceId: r.URL.Query().Get(":serviceId"),
-	}, conn)
+	core.InstanceAPI.WebSocketWatch(r.Context(), watchRequest(r), conn)
 }
 
 func (this *WatchService) ListAndWatch(w http.ResponseWriter, r *http.Request) {
@@ -74,7 +98,17 @@ func (this *WatchService) ListAndWatch(w http.ResponseWriter, r *http.Request) {
 	defer conn.Close()
 
 	r.Method = "WATCHLIST"
-	core.InstanceAPI.WebSocketListAndWatch(r.Context(), &pb.WatchInstanceRequest{
-		SelfServiceId: r.URL.Query().Get(":serviceId"),
-	}, conn)
+	core.InstanceAPI.WebSocketListAndWatch(r.Context(), watchRequest(r), conn)
+}
+
+// WatchInvalidations 通过websocket订阅提供者的失效通知，供客户端SDK刷新本地缓存
+func (this *WatchService) WatchInvalidations(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r.Method = "WATCH"
+	core.InstanceAPI.WebSocketWatchInvalidations(r.Context(), watchRequest(r), conn)
 }
@@ -17,7 +17,10 @@
 package v4
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"github.com/apache/incubator-servicecomb-service-center/server/core"
@@ -26,6 +29,8 @@ import (
 	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
 	"github.com/apache/incubator-servicecomb-service-center/version"
 	"net/http"
+	"strings"
+	"sync"
 )
 
 const API_VERSION = "4.0.0"
@@ -36,10 +41,19 @@ type Result struct {
 	Config     *pb.ServerConfig `json:"config,omitempty"`
 }
 
+type ErrorCodesResponse struct {
+	Errors []*scerr.ErrorCode `json:"errors"`
+}
+
 type MainService struct {
 	//
 }
 
+var (
+	openAPIOnce sync.Once
+	openAPIDoc  *OpenAPIDocument
+)
+
 func init() {
 }
 
@@ -47,6 +61,9 @@ func (this *MainService) URLPatterns() []rest.Route {
 	return []rest.Route{
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/version", this.GetVersion},
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/health", this.ClusterHealth},
+		{rest.HTTP_METHOD_GET, "/v4/:project/registry/errors", this.GetErrorCodes},
+		{rest.HTTP_METHOD_GET, "/v4/:project/registry/messages", this.GetMessageBundle},
+		{rest.HTTP_METHOD_GET, "/v4/:project/registry/openapi", this.GetOpenAPI},
 	}
 }
 
@@ -73,3 +90,44 @@ func (this *MainService) GetVersion(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json;charset=utf-8")
 	w.Write(resultJSON)
 }
+
+func (this *MainService) GetErrorCodes(w http.ResponseWriter, r *http.Request) {
+	controller.WriteJsonObject(w, &ErrorCodesResponse{
+		Errors: scerr.Codes(w.Header().Get("Content-Language")),
+	})
+}
+
+// GetMessageBundle 返回lang参数(为空时按Accept-Language协商)语言的消息包，
+// 以内容摘要作为ETag，消息包未变化时返回304
+func (this *MainService) GetMessageBundle(w http.ResponseWriter, r *http.Request) {
+	lang := r.URL.Query().Get("lang")
+	if len(lang) == 0 {
+		lang = w.Header().Get("Content-Language")
+	}
+	bundle := scerr.GetBundle(lang)
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+		return
+	}
+	sum := sha1.Sum(data)
+	etag := fmt.Sprintf(`"%s-%s"`, bundle.Language, hex.EncodeToString(sum[:8]))
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Content-Language", bundle.Language)
+	if strings.TrimPrefix(r.Header.Get("If-None-Match"), "W/") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Add("X-Response-Status", fmt.Sprint(http.StatusOK))
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// GetOpenAPI 返回由路由表生成的OpenAPI文档，路由在启动时已全部注册，只需生成一次
+func (this *MainService) GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	openAPIOnce.Do(func() {
+		openAPIDoc = NewOpenAPIDocument(rest.GetRoutes())
+	})
+	controller.WriteJsonObject(w, openAPIDoc)
+}
@@ -24,6 +24,7 @@ import (
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
 	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	"github.com/ghodss/yaml"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -36,12 +37,20 @@ type MicroServiceService struct {
 func (this *MicroServiceService) URLPatterns() []rest.Route {
 	return []rest.Route{
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/existence", this.GetExistence},
+		{rest.HTTP_METHOD_POST, "/v4/:project/registry/existence", this.BatchGetExistence},
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices", this.GetServices},
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:serviceId", this.GetServiceOne},
 		{rest.HTTP_METHOD_POST, "/v4/:project/registry/microservices", this.Register},
 		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/properties", this.Update},
+		{rest.HTTP_METHOD_PATCH, "/v4/:project/registry/microservices/:serviceId/properties", this.Patch},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/catalog", this.UpdateCatalog},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/retirement", this.UpdateRetirement},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/registry/microservices/:serviceId/retirement", this.CancelRetirement},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/schema-freeze", this.FreezeSchemas},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/registry/microservices/:serviceId/schema-freeze", this.UnfreezeSchemas},
 		{rest.HTTP_METHOD_DELETE, "/v4/:project/registry/microservices/:serviceId", this.Unregister},
 		{rest.HTTP_METHOD_DELETE, "/v4/:project/registry/microservices", this.UnregisterServices},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/apply", this.Apply},
 	}
 }
 
@@ -49,7 +58,7 @@ func (this *MicroServiceService) Register(w http.ResponseWriter, r *http.Request
 	message, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		util.Logger().Error("body err", err)
-		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		controller.WriteBodyError(w, err)
 		return
 	}
 	var request pb.CreateServiceRequest
@@ -65,13 +74,37 @@ func (this *MicroServiceService) Register(w http.ResponseWriter, r *http.Request
 	controller.WriteResponse(w, respInternal, resp)
 }
 
-func (this *MicroServiceService) Update(w http.ResponseWriter, r *http.Request) {
+// Apply 按YAML或JSON格式的服务定义注册或更新微服务，dryRun=true时只返回差异
+func (this *MicroServiceService) Apply(w http.ResponseWriter, r *http.Request) {
 	message, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	var request pb.ApplyServiceRequest
+	err = yaml.Unmarshal(message, &request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
 		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
 		return
 	}
+	if r.URL.Query().Get("dryRun") == "true" {
+		request.DryRun = true
+	}
+	resp, err := core.ServiceAPI.Apply(r.Context(), &request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (this *MicroServiceService) Update(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
 	request := &pb.UpdateServicePropsRequest{
 		ServiceId: r.URL.Query().Get(":serviceId"),
 	}
@@ -85,6 +118,138 @@ func (this *MicroServiceService) Update(w http.ResponseWriter, r *http.Request)
 	controller.WriteResponse(w, resp.Response, nil)
 }
 
+// Patch 按JSON Merge Patch(RFC 7396)合并服务properties，值为null的属性被删除
+func (this *MicroServiceService) Patch(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	properties, removed, err := parsePropertiesPatch(message)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	request := &pb.UpdateServicePropsRequest{
+		ServiceId:        r.URL.Query().Get(":serviceId"),
+		Properties:       properties,
+		Merge:            true,
+		RemoveProperties: removed,
+	}
+	resp, _ := core.ServiceAPI.UpdateProperties(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+// parsePropertiesPatch 解析{"properties": {...}}格式的merge patch，
+// 返回需要设置的属性和值为null需要删除的属性
+func parsePropertiesPatch(message []byte) (map[string]string, []string, error) {
+	patch := struct {
+		Properties map[string]*string `json:"properties"`
+	}{}
+	if err := json.Unmarshal(message, &patch); err != nil {
+		return nil, nil, err
+	}
+	properties := make(map[string]string, len(patch.Properties))
+	var removed []string
+	for k, v := range patch.Properties {
+		if v == nil {
+			removed = append(removed, k)
+			continue
+		}
+		properties[k] = *v
+	}
+	return properties, removed, nil
+}
+
+func (this *MicroServiceService) UpdateCatalog(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &pb.UpdateServiceCatalogRequest{
+		ServiceId: r.URL.Query().Get(":serviceId"),
+	}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	resp, err := core.ServiceAPI.UpdateCatalog(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+// UpdateRetirement 设置服务版本的下线计划
+func (this *MicroServiceService) UpdateRetirement(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &pb.UpdateServiceRetirementRequest{
+		ServiceId: r.URL.Query().Get(":serviceId"),
+	}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	if request.Retirement == nil {
+		controller.WriteError(w, scerr.ErrInvalidParams, "Retirement is required.")
+		return
+	}
+	resp, _ := core.ServiceAPI.UpdateRetirement(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+// CancelRetirement 取消服务版本的下线计划
+func (this *MicroServiceService) CancelRetirement(w http.ResponseWriter, r *http.Request) {
+	resp, _ := core.ServiceAPI.UpdateRetirement(r.Context(), &pb.UpdateServiceRetirementRequest{
+		ServiceId: r.URL.Query().Get(":serviceId"),
+	})
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+// FreezeSchemas 冻结服务的契约，请求体可为空
+func (this *MicroServiceService) FreezeSchemas(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &pb.UpdateSchemaFreezeRequest{
+		ServiceId: r.URL.Query().Get(":serviceId"),
+	}
+	if len(message) > 0 {
+		err = json.Unmarshal(message, request)
+		if err != nil {
+			util.Logger().Error("Unmarshal error", err)
+			controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+			return
+		}
+	}
+	if request.Freeze == nil {
+		request.Freeze = &pb.SchemaFreeze{}
+	}
+	resp, _ := core.ServiceAPI.UpdateSchemaFreeze(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+// UnfreezeSchemas 解冻服务的契约
+func (this *MicroServiceService) UnfreezeSchemas(w http.ResponseWriter, r *http.Request) {
+	resp, _ := core.ServiceAPI.UpdateSchemaFreeze(r.Context(), &pb.UpdateSchemaFreezeRequest{
+		ServiceId: r.URL.Query().Get(":serviceId"),
+	})
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
 func (this *MicroServiceService) Unregister(w http.ResponseWriter, r *http.Request) {
 	force := r.URL.Query().Get("force")
 	serviceId := r.URL.Query().Get(":serviceId")
@@ -101,7 +266,9 @@ func (this *MicroServiceService) Unregister(w http.ResponseWriter, r *http.Reque
 }
 
 func (this *MicroServiceService) GetServices(w http.ResponseWriter, r *http.Request) {
-	request := &pb.GetServicesRequest{}
+	request := &pb.GetServicesRequest{
+		ServiceName: r.URL.Query().Get("serviceName"),
+	}
 	util.Logger().Debugf("domain is %s", util.ParseDomain(r.Context()))
 	resp, _ := core.ServiceAPI.GetServices(r.Context(), request)
 	respInternal := resp.Response
@@ -127,6 +294,27 @@ func (this *MicroServiceService) GetExistence(w http.ResponseWriter, r *http.Req
 	controller.WriteResponse(w, respInternal, resp)
 }
 
+// BatchGetExistence 批量查询服务是否存在
+func (this *MicroServiceService) BatchGetExistence(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	request := &pb.BatchGetExistenceRequest{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	resp, _ := core.ServiceAPI.BatchExist(r.Context(), request)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
 func (this *MicroServiceService) GetServiceOne(w http.ResponseWriter, r *http.Request) {
 	request := &pb.GetServiceRequest{
 		ServiceId: r.URL.Query().Get(":serviceId"),
@@ -141,7 +329,7 @@ func (this *MicroServiceService) UnregisterServices(w http.ResponseWriter, r *ht
 	request_body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		util.Logger().Error("body ,err", err)
-		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		controller.WriteBodyError(w, err)
 		return
 	}
 
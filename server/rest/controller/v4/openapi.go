@@ -0,0 +1,394 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package v4
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"github.com/apache/incubator-servicecomb-service-center/version"
+	"reflect"
+	"strings"
+)
+
+// apiOperation 路由的文档描述，请求和响应的结构由pb定义反射生成，
+// 与路由、pb定义一起编译，保证文档与实现同步
+type apiOperation struct {
+	Summary  string
+	Body     interface{}
+	Response interface{}
+}
+
+var apiOperations = map[string]apiOperation{
+	"GET /v4/:project/registry/version":   {"Get the version of service center", nil, &Result{}},
+	"GET /v4/:project/registry/health":    {"Get the instances of service center cluster", nil, &pb.GetInstancesResponse{}},
+	"GET /v4/:project/registry/errors":    {"List the error codes", nil, &ErrorCodesResponse{}},
+	"GET /v4/:project/registry/openapi":   {"Get the OpenAPI document of service center", nil, nil},
+	"GET /v4/:project/registry/existence": {"Check the existence of service or schema", nil, &pb.GetExistenceResponse{}},
+	"POST /v4/:project/registry/existence": {"Check the existence of services in batch",
+		&pb.BatchGetExistenceRequest{}, &pb.BatchGetExistenceResponse{}},
+
+	"GET /v4/:project/registry/microservices":               {"List the services", nil, &pb.GetServicesResponse{}},
+	"POST /v4/:project/registry/microservices":              {"Create a service", &pb.CreateServiceRequest{}, &pb.CreateServiceResponse{}},
+	"DELETE /v4/:project/registry/microservices":            {"Delete the services", &pb.DelServicesRequest{}, &pb.DelServicesResponse{}},
+	"GET /v4/:project/registry/microservices/:serviceId":    {"Get the service", nil, &pb.GetServiceResponse{}},
+	"DELETE /v4/:project/registry/microservices/:serviceId": {"Delete the service", nil, nil},
+	"PUT /v4/:project/registry/microservices/:serviceId/properties": {"Update the service properties",
+		&pb.UpdateServicePropsRequest{}, nil},
+	"PATCH /v4/:project/registry/microservices/:serviceId/properties": {"Merge the service properties",
+		&pb.UpdateServicePropsRequest{}, nil},
+	"PUT /v4/:project/registry/microservices/:serviceId/catalog": {"Update the service catalog",
+		&pb.UpdateServiceCatalogRequest{}, nil},
+	"PUT /v4/:project/registry/microservices/:serviceId/retirement": {"Schedule the retirement of the service version",
+		&pb.UpdateServiceRetirementRequest{}, nil},
+	"DELETE /v4/:project/registry/microservices/:serviceId/retirement": {"Cancel the retirement of the service version",
+		nil, nil},
+	"PUT /v4/:project/registry/microservices/:serviceId/schema-freeze": {"Freeze the schemas of the service",
+		&pb.UpdateSchemaFreezeRequest{}, nil},
+	"DELETE /v4/:project/registry/microservices/:serviceId/schema-freeze": {"Unfreeze the schemas of the service",
+		nil, nil},
+	"PUT /v4/:project/registry/apply": {"Create or update the service from a definition manifest",
+		&pb.ApplyServiceRequest{}, &pb.ApplyServiceResponse{}},
+
+	"GET /v4/:project/registry/microservices/:serviceId/schemas": {"List the schemas of the service",
+		nil, &pb.GetAllSchemaResponse{}},
+	"POST /v4/:project/registry/microservices/:serviceId/schemas": {"Replace the schemas of the service",
+		&pb.ModifySchemasRequest{}, nil},
+	"GET /v4/:project/registry/microservices/:serviceId/schemas/:schemaId": {"Get the schema",
+		nil, &pb.GetSchemaResponse{}},
+	"PUT /v4/:project/registry/microservices/:serviceId/schemas/:schemaId": {"Create or update the schema",
+		&pb.ModifySchemaRequest{}, nil},
+	"DELETE /v4/:project/registry/microservices/:serviceId/schemas/:schemaId": {"Delete the schema", nil, nil},
+	"PUT /v4/:project/registry/microservices/:serviceId/schemas/:schemaId/content": {"Upload the schema content as the raw request body",
+		nil, nil},
+	"GET /v4/:project/registry/definitions": {"List the shared definitions", nil, &pb.GetSharedDefinitionsResponse{}},
+	"PUT /v4/:project/registry/definitions/:name": {"Create or update the shared definition",
+		&pb.ModifySharedDefinitionRequest{}, nil},
+	"DELETE /v4/:project/registry/definitions/:name": {"Delete the shared definition", nil, nil},
+
+	"POST /v4/:project/registry/dependencies": {"Add the dependencies", &pb.AddDependenciesRequest{}, nil},
+	"PUT /v4/:project/registry/dependencies":  {"Overwrite the dependencies", &pb.CreateDependenciesRequest{}, nil},
+	"PUT /v4/:project/registry/dependencies/traffic": {"Report the observed traffic of the dependencies",
+		&pb.ReportDependencyTrafficRequest{}, &pb.ReportDependencyTrafficResponse{}},
+	"POST /v4/:project/registry/dependencies/lint": {"Lint the dependencies without creating them",
+		&pb.LintDependenciesRequest{}, &pb.LintDependenciesResponse{}},
+	"GET /v4/:project/registry/microservices/:consumerId/providers": {"List the providers of the consumer",
+		nil, &pb.GetConDependenciesResponse{}},
+	"GET /v4/:project/registry/microservices/:providerId/consumers": {"List the consumers of the provider",
+		nil, &pb.GetProDependenciesResponse{}},
+	"GET /v4/:project/registry/microservices/:consumerId/dependency-diff": {"Diff the declared and used dependencies",
+		nil, &pb.GetDependencyDiffResponse{}},
+	"GET /v4/:project/registry/microservices/:consumerId/dependency-history": {"Query the dependency rules of the consumer at a past revision",
+		nil, &pb.GetDependencyHistoryResponse{}},
+	"GET /v4/:project/registry/microservices/:providerId/accessors": {"List the consumers that discovered the provider recently",
+		nil, &pb.GetProviderAccessorsResponse{}},
+	"GET /v4/:project/registry/microservices/:providerId/approvals": {"List the approvals of the provider",
+		nil, &pb.GetDependencyApprovalsResponse{}},
+	"PUT /v4/:project/registry/microservices/:providerId/approvals/:consumerId": {"Approve the consumer",
+		&pb.ApproveDependencyRequest{}, nil},
+	"DELETE /v4/:project/registry/microservices/:providerId/approvals/:consumerId": {"Revoke the approval",
+		nil, nil},
+
+	"GET /v4/:project/registry/microservices/:serviceId/tags":         {"List the tags", nil, &pb.GetServiceTagsResponse{}},
+	"POST /v4/:project/registry/microservices/:serviceId/tags":        {"Add the tags", &pb.AddServiceTagsRequest{}, nil},
+	"PUT /v4/:project/registry/microservices/:serviceId/tags/:key":    {"Update the tag", nil, nil},
+	"DELETE /v4/:project/registry/microservices/:serviceId/tags/:key": {"Delete the tags", nil, nil},
+
+	"GET /v4/:project/registry/microservices/:serviceId/rules": {"List the rules", nil, &pb.GetServiceRulesResponse{}},
+	"POST /v4/:project/registry/microservices/:serviceId/rules": {"Add the rules", &pb.AddServiceRulesRequest{},
+		&pb.AddServiceRulesResponse{}},
+	"PUT /v4/:project/registry/microservices/:serviceId/rules": {"Replace all the rules",
+		&pb.AddServiceRulesRequest{}, &pb.ReplaceServiceRulesResponse{}},
+	"PUT /v4/:project/registry/microservices/:serviceId/rules/:rule_id": {"Update the rule",
+		&pb.AddOrUpdateServiceRule{}, nil},
+	"DELETE /v4/:project/registry/microservices/:serviceId/rules/:rule_id": {"Delete the rules", nil, nil},
+
+	"GET /v4/:project/registry/microservices/:serviceId/delegations": {"List the delegation grants and audit records",
+		nil, &pb.GetDelegationsResponse{}},
+	"PUT /v4/:project/registry/microservices/:serviceId/delegations/:controllerId": {"Grant the controller to manage instances",
+		nil, nil},
+	"DELETE /v4/:project/registry/microservices/:serviceId/delegations/:controllerId": {"Revoke the delegation grant",
+		nil, nil},
+	"POST /v4/:project/registry/delegations/:controllerId/microservices/:serviceId/instances": {"Register an instance on behalf of the service",
+		&pb.DelegateRegisterInstanceRequest{}, &pb.RegisterInstanceResponse{}},
+	"DELETE /v4/:project/registry/delegations/:controllerId/microservices/:serviceId/instances/:instanceId": {"Unregister the instance on behalf of the service",
+		nil, nil},
+	"POST /v4/:project/registry/microservices/:serviceId/broadcasts": {"Broadcast a message to the consumers",
+		&pb.BroadcastRequest{}, &pb.BroadcastResponse{}},
+
+	"GET /v4/:project/registry/instances": {"Find the provider instances", nil, &pb.FindInstancesResponse{}},
+	"PUT /v4/:project/registry/heartbeats": {"Send the heartbeats of instances", &pb.HeartbeatSetRequest{},
+		&pb.HeartbeatSetResponse{}},
+	"GET /v4/:project/registry/microservices/:serviceId/instances": {"List the instances of the service",
+		nil, &pb.GetInstancesResponse{}},
+	"POST /v4/:project/registry/microservices/:serviceId/instances": {"Register an instance",
+		&pb.RegisterInstanceRequest{}, &pb.RegisterInstanceResponse{}},
+	"GET /v4/:project/registry/microservices/:serviceId/instances/:instanceId": {"Get the instance",
+		nil, &pb.GetOneInstanceResponse{}},
+	"DELETE /v4/:project/registry/microservices/:serviceId/instances/:instanceId": {"Unregister the instance",
+		nil, nil},
+	"PUT /v4/:project/registry/microservices/:serviceId/instances/:instanceId/properties": {"Update the instance properties",
+		&pb.UpdateInstancePropsRequest{}, nil},
+	"PATCH /v4/:project/registry/microservices/:serviceId/instances/:instanceId/properties": {"Merge the instance properties",
+		&pb.UpdateInstancePropsRequest{}, nil},
+	"PUT /v4/:project/registry/microservices/:serviceId/instances/:instanceId/status": {"Update the instance status",
+		nil, nil},
+	"PUT /v4/:project/registry/microservices/:serviceId/instances/:instanceId/capacity": {"Update the instance capacity hint",
+		nil, nil},
+	"PUT /v4/:project/registry/microservices/:serviceId/instances/:instanceId/heartbeat": {"Send the heartbeat of instance",
+		nil, nil},
+	"PUT /v4/:project/registry/microservices/:serviceId/instances/:instanceId/promote": {"Promote the standby instance",
+		nil, nil},
+	"PUT /v4/:project/registry/microservices/:serviceId/promote": {"Promote the standby instances of the service",
+		&pb.PromoteInstancesRequest{}, &pb.PromoteInstancesResponse{}},
+	"PUT /v4/:project/registry/microservices/:serviceId/activate": {"Activate the pending instances of the service",
+		&pb.PromoteInstancesRequest{}, &pb.PromoteInstancesResponse{}},
+	"GET /v4/:project/registry/microservices/:serviceId/watcher": {"Watch the provider instances by websocket",
+		nil, &pb.WatchInstanceResponse{}},
+	"GET /v4/:project/registry/microservices/:serviceId/listwatcher": {"List and watch the provider instances by websocket",
+		nil, &pb.WatchInstanceResponse{}},
+	"GET /v4/:project/registry/microservices/:serviceId/invalidations": {"Watch the provider invalidations by websocket",
+		nil, &pb.WatchInstanceResponse{}},
+
+	"GET /v4/:project/govern/microservices":            {"List the services statistics", nil, &pb.GetServicesInfoResponse{}},
+	"GET /v4/:project/govern/microservices/:serviceId": {"Get the service detail", nil, &pb.GetServiceDetailResponse{}},
+	"GET /v4/:project/govern/apps":                     {"List the applications", nil, &pb.GetAppsResponse{}},
+	"GET /v4/:project/govern/instances":                {"Search the instances", nil, &pb.SearchInstancesResponse{}},
+	"POST /v4/:project/govern/snapshots": {"Create a read-only snapshot",
+		&pb.CreateSnapshotRequest{}, &pb.CreateSnapshotResponse{}},
+	"GET /v4/:project/govern/snapshots/:snapshotId": {"Get the snapshot",
+		nil, &pb.GetSnapshotResponse{}},
+	"DELETE /v4/:project/govern/snapshots/:snapshotId": {"Release the snapshot",
+		nil, nil},
+	"POST /v4/:project/govern/apikeys": {"Create an api key",
+		&pb.CreateApiKeyRequest{}, &pb.CreateApiKeyResponse{}},
+	"GET /v4/:project/govern/apikeys": {"List the api keys",
+		nil, &pb.GetApiKeysResponse{}},
+	"POST /v4/:project/govern/apikeys/:keyId/rotate": {"Rotate the api key",
+		&pb.RotateApiKeyRequest{}, &pb.RotateApiKeyResponse{}},
+	"DELETE /v4/:project/govern/apikeys/:keyId": {"Revoke the api key",
+		nil, nil},
+
+	"GET /v4/:project/bootstrap": {"Get the serviceId, heartbeat, watch, rate limit and features for the client startup",
+		nil, &serviceUtil.ClientBootstrap{}},
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+type openAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Type     string         `json:"type,omitempty"`
+	Schema   *openAPISchema `json:"schema,omitempty"`
+}
+
+type openAPIResponse struct {
+	Description string         `json:"description"`
+	Schema      *openAPISchema `json:"schema,omitempty"`
+}
+
+type openAPIOperation struct {
+	Tags        []string                    `json:"tags,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	OperationId string                      `json:"operationId"`
+	Parameters  []*openAPIParameter         `json:"parameters,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenAPIDocument struct {
+	Swagger     string                                  `json:"swagger"`
+	Info        openAPIInfo                             `json:"info"`
+	Consumes    []string                                `json:"consumes"`
+	Produces    []string                                `json:"produces"`
+	Paths       map[string]map[string]*openAPIOperation `json:"paths"`
+	Definitions map[string]*openAPISchema               `json:"definitions"`
+}
+
+var (
+	responseType = reflect.TypeOf(pb.Response{})
+	errorSchema  = &openAPISchema{Ref: "#/definitions/Error"}
+)
+
+// NewOpenAPIDocument 根据已注册的v4路由生成OpenAPI(swagger 2.0)文档
+func NewOpenAPIDocument(routes []rest.URLPattern) *OpenAPIDocument {
+	doc := &OpenAPIDocument{
+		Swagger:     "2.0",
+		Info:        openAPIInfo{Title: "ServiceCenter API", Version: version.Ver().Version},
+		Consumes:    []string{"application/json"},
+		Produces:    []string{"application/json"},
+		Paths:       make(map[string]map[string]*openAPIOperation),
+		Definitions: make(map[string]*openAPISchema),
+	}
+	doc.schemaOf(reflect.TypeOf(scerr.Error{}))
+
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, "/v4/") {
+			continue
+		}
+		path, op := doc.operationOf(route)
+		ops, ok := doc.Paths[path]
+		if !ok {
+			ops = make(map[string]*openAPIOperation)
+			doc.Paths[path] = ops
+		}
+		ops[strings.ToLower(route.Method)] = op
+	}
+	return doc
+}
+
+func (doc *OpenAPIDocument) operationOf(route rest.URLPattern) (string, *openAPIOperation) {
+	api := apiOperations[route.Method+" "+route.Path]
+	op := &openAPIOperation{
+		Summary:     api.Summary,
+		OperationId: operationId(route),
+		Responses: map[string]*openAPIResponse{
+			"default": {Description: "error", Schema: errorSchema},
+		},
+	}
+
+	segments := strings.Split(route.Path, "/")
+	if len(segments) > 3 {
+		op.Tags = []string{segments[3]}
+	}
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") {
+			op.Parameters = append(op.Parameters, &openAPIParameter{
+				Name: seg[1:], In: "path", Required: true, Type: "string"})
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	if api.Body != nil {
+		op.Parameters = append(op.Parameters, &openAPIParameter{
+			Name: "body", In: "body", Required: true, Schema: doc.schemaOf(reflect.TypeOf(api.Body))})
+	}
+
+	ok := &openAPIResponse{Description: "success"}
+	if api.Response != nil {
+		ok.Schema = doc.schemaOf(reflect.TypeOf(api.Response))
+	}
+	op.Responses["200"] = ok
+	return strings.Join(segments, "/"), op
+}
+
+// operationId 由method和path生成，如GET /v4/:project/registry/microservices/:serviceId
+// 生成getV4ProjectRegistryMicroservicesServiceId
+func operationId(route rest.URLPattern) string {
+	id := []byte(strings.ToLower(route.Method))
+	upper := true
+	for _, c := range []byte(route.Path) {
+		switch {
+		case c >= 'a' && c <= 'z':
+			if upper {
+				c -= 'a' - 'A'
+			}
+		case c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		default:
+			upper = true
+			continue
+		}
+		upper = false
+		id = append(id, c)
+	}
+	return string(id)
+}
+
+func (doc *OpenAPIDocument) schemaOf(t reflect.Type) *openAPISchema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return doc.schemaOf(t.Elem())
+	case reflect.Struct:
+		name := t.Name()
+		if len(name) == 0 {
+			s := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+			doc.fieldsOf(t, s.Properties)
+			return s
+		}
+		if _, ok := doc.Definitions[name]; !ok {
+			s := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+			// 先占位，避免嵌套的结构无限递归
+			doc.Definitions[name] = s
+			doc.fieldsOf(t, s.Properties)
+		}
+		return &openAPISchema{Ref: "#/definitions/" + name}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &openAPISchema{Type: "string", Format: "byte"}
+		}
+		return &openAPISchema{Type: "array", Items: doc.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: doc.schemaOf(t.Elem())}
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Float32, reflect.Float64:
+		return &openAPISchema{Type: "number"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	default:
+		return &openAPISchema{Type: "object"}
+	}
+}
+
+func (doc *OpenAPIDocument) fieldsOf(t reflect.Type, properties map[string]*openAPISchema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := strings.Split(f.Tag.Get("json"), ",")
+		name := tag[0]
+		if f.Anonymous && len(name) == 0 {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				doc.fieldsOf(ft, properties)
+				continue
+			}
+		}
+		// 响应中的Response放在http状态码和错误中返回
+		if len(f.PkgPath) > 0 || name == "-" || f.Type.Kind() == reflect.Ptr && f.Type.Elem() == responseType {
+			continue
+		}
+		if len(name) == 0 {
+			name = f.Name
+		}
+		s := doc.schemaOf(f.Type)
+		for _, opt := range tag[1:] {
+			if opt == "string" {
+				s = &openAPISchema{Type: "string"}
+			}
+		}
+		properties[name] = s
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package v4
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"net/http"
+	"testing"
+)
+
+func TestNewOpenAPIDocument(t *testing.T) {
+	doc := NewOpenAPIDocument([]rest.URLPattern{
+		{Method: http.MethodGet, Path: "/v4/:project/registry/microservices/:serviceId"},
+		{Method: http.MethodPost, Path: "/v4/:project/registry/microservices"},
+		{Method: http.MethodGet, Path: "/version"},
+	})
+	if len(doc.Paths) != 2 || doc.Definitions["Error"] == nil {
+		t.Fatalf("TestNewOpenAPIDocument failed, only v4 routes should be documented, %v", doc.Paths)
+	}
+
+	op := doc.Paths["/v4/{project}/registry/microservices/{serviceId}"]["get"]
+	if op == nil {
+		t.Fatalf("TestNewOpenAPIDocument failed, path params should be converted, %v", doc.Paths)
+	}
+	if op.OperationId != "getV4ProjectRegistryMicroservicesServiceId" || op.Summary != "Get the service" ||
+		len(op.Tags) != 1 || op.Tags[0] != "registry" {
+		t.Fatalf("TestNewOpenAPIDocument failed, operation %s, summary %s, tags %v", op.OperationId, op.Summary, op.Tags)
+	}
+	if len(op.Parameters) != 2 || op.Parameters[0].Name != "project" || op.Parameters[1].Name != "serviceId" ||
+		op.Parameters[1].In != "path" {
+		t.Fatalf("TestNewOpenAPIDocument failed, path parameters %v", op.Parameters)
+	}
+	if op.Responses["200"].Schema.Ref != "#/definitions/GetServiceResponse" ||
+		op.Responses["default"].Schema.Ref != "#/definitions/Error" {
+		t.Fatalf("TestNewOpenAPIDocument failed, responses %v", op.Responses)
+	}
+	// 响应中的Response不出现在文档中
+	resp := doc.Definitions["GetServiceResponse"]
+	if _, ok := resp.Properties["response"]; ok || resp.Properties["service"].Ref != "#/definitions/MicroService" {
+		t.Fatalf("TestNewOpenAPIDocument failed, response properties %v", resp.Properties)
+	}
+
+	op = doc.Paths["/v4/{project}/registry/microservices"]["post"]
+	body := op.Parameters[len(op.Parameters)-1]
+	if body.In != "body" || body.Schema.Ref != "#/definitions/CreateServiceRequest" {
+		t.Fatalf("TestNewOpenAPIDocument failed, body parameter %v", body)
+	}
+	req := doc.Definitions["CreateServiceRequest"]
+	if tags := req.Properties["tags"]; tags.Type != "object" || tags.AdditionalProperties.Type != "string" {
+		t.Fatalf("TestNewOpenAPIDocument failed, map property %v", tags)
+	}
+	if rules := req.Properties["rules"]; rules.Type != "array" || rules.Items.Ref != "#/definitions/AddOrUpdateServiceRule" {
+		t.Fatalf("TestNewOpenAPIDocument failed, array property %v", rules)
+	}
+}
+
+func TestOperationId(t *testing.T) {
+	id := operationId(rest.URLPattern{Method: http.MethodDelete, Path: "/v4/:project/registry/microservices/:serviceId/tags/:key"})
+	if id != "deleteV4ProjectRegistryMicroservicesServiceIdTagsKey" {
+		t.Fatalf("TestOperationId failed, %s", id)
+	}
+}
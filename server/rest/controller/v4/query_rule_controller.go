@@ -37,6 +37,7 @@ func (this *RuleService) URLPatterns() []rest.Route {
 	return []rest.Route{
 		{rest.HTTP_METHOD_POST, "/v4/:project/registry/microservices/:serviceId/rules", this.AddRule},
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:serviceId/rules", this.GetRules},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/rules", this.ReplaceRules},
 		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/rules/:rule_id", this.UpdateRule},
 		{rest.HTTP_METHOD_DELETE, "/v4/:project/registry/microservices/:serviceId/rules/:rule_id", this.DeleteRule},
 	}
@@ -45,7 +46,7 @@ func (this *RuleService) AddRule(w http.ResponseWriter, r *http.Request) {
 	message, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		util.Logger().Error("bory err", err)
-		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		controller.WriteBodyError(w, err)
 		return
 	}
 	rule := map[string][]*pb.AddOrUpdateServiceRule{}
@@ -80,7 +81,7 @@ func (this *RuleService) UpdateRule(w http.ResponseWriter, r *http.Request) {
 	message, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		util.Logger().Error("body err", err)
-		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		controller.WriteBodyError(w, err)
 		return
 	}
 
@@ -107,3 +108,29 @@ func (this *RuleService) GetRules(w http.ResponseWriter, r *http.Request) {
 	resp.Response = nil
 	controller.WriteResponse(w, respInternal, resp)
 }
+
+// ReplaceRules 全量替换服务的黑白名单并返回变更，dryRun=true时只返回变更
+func (this *RuleService) ReplaceRules(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+	rule := map[string][]*pb.AddOrUpdateServiceRule{}
+	err = json.Unmarshal(message, &rule)
+	if err != nil {
+		util.Logger().Errorf(err, "Unmarshal error")
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+
+	resp, err := core.ServiceAPI.ReplaceRules(r.Context(), &pb.ReplaceServiceRulesRequest{
+		ServiceId: r.URL.Query().Get(":serviceId"),
+		Rules:     rule["rules"],
+		DryRun:    r.URL.Query().Get("dryRun") == "true",
+	})
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
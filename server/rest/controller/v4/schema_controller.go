@@ -18,6 +18,7 @@ package v4
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"github.com/apache/incubator-servicecomb-service-center/server/core"
@@ -38,18 +39,30 @@ func (this *SchemaService) URLPatterns() []rest.Route {
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId", this.GetSchemas},
 		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId", this.ModifySchema},
 		{rest.HTTP_METHOD_DELETE, "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId", this.DeleteSchemas},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId/content", this.UploadSchemaContent},
 		{rest.HTTP_METHOD_POST, "/v4/:project/registry/microservices/:serviceId/schemas", this.ModifySchemas},
 		{rest.HTTP_METHOD_GET, "/v4/:project/registry/microservices/:serviceId/schemas", this.GetAllSchemas},
+		{rest.HTTP_METHOD_GET, "/v4/:project/registry/definitions", this.GetSharedDefinitions},
+		{rest.HTTP_METHOD_PUT, "/v4/:project/registry/definitions/:name", this.ModifySharedDefinition},
+		{rest.HTTP_METHOD_DELETE, "/v4/:project/registry/definitions/:name", this.DeleteSharedDefinition},
 	}
 }
 
 func (this *SchemaService) GetSchemas(w http.ResponseWriter, r *http.Request) {
+	resolve := r.URL.Query().Get("resolve")
+	if resolve != "true" && resolve != "false" && resolve != "1" && resolve != "0" && strings.TrimSpace(resolve) != "" {
+		controller.WriteError(w, scerr.ErrInvalidParams, "parameter resolve must be true or false")
+		return
+	}
 	request := &pb.GetSchemaRequest{
-		ServiceId: r.URL.Query().Get(":serviceId"),
-		SchemaId:  r.URL.Query().Get(":schemaId"),
+		ServiceId:         r.URL.Query().Get(":serviceId"),
+		SchemaId:          r.URL.Query().Get(":schemaId"),
+		Resolve:           resolve == "true" || resolve == "1",
+		ConsumerServiceId: r.Header.Get("X-ConsumerId"),
 	}
 	resp, _ := core.ServiceAPI.GetSchemaInfo(r.Context(), request)
 	w.Header().Add("X-Schema-Summary", resp.SchemaSummary)
+	setRevisionTag(w, resp.Revision)
 	resp.SchemaSummary = ""
 	respInternal := resp.Response
 	resp.Response = nil
@@ -60,7 +73,7 @@ func (this *SchemaService) ModifySchema(w http.ResponseWriter, r *http.Request)
 	message, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		util.Logger().Error("body err", err)
-		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		controller.WriteBodyError(w, err)
 		return
 	}
 
@@ -73,15 +86,48 @@ func (this *SchemaService) ModifySchema(w http.ResponseWriter, r *http.Request)
 	}
 	request.ServiceId = r.URL.Query().Get(":serviceId")
 	request.SchemaId = r.URL.Query().Get(":schemaId")
+	if rev := ifMatchRevision(r); len(rev) > 0 {
+		request.ExpectedRevision = rev
+	}
 	resp, err := core.ServiceAPI.ModifySchema(r.Context(), request)
-	controller.WriteResponse(w, resp.Response, nil)
+	setRevisionTag(w, resp.Revision)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+// UploadSchemaContent 请求体为契约原文，一次读取后直接作为契约内容，不经过json编解码，
+// 摘要由X-Schema-Summary头携带，契约大小受该API的请求体上限约束
+func (this *SchemaService) UploadSchemaContent(w http.ResponseWriter, r *http.Request) {
+	content, err := controller.ReadContent(r)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	request := &pb.ModifySchemaRequest{
+		ServiceId: query.Get(":serviceId"),
+		SchemaId:  query.Get(":schemaId"),
+		Schema:    content,
+		Summary:   r.Header.Get("X-Schema-Summary"),
+	}
+	if rev := ifMatchRevision(r); len(rev) > 0 {
+		request.ExpectedRevision = rev
+	}
+	resp, _ := core.ServiceAPI.ModifySchema(r.Context(), request)
+	setRevisionTag(w, resp.Revision)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
 }
 
 func (this *SchemaService) ModifySchemas(w http.ResponseWriter, r *http.Request) {
 	message, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		util.Logger().Error("body err", err)
-		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		controller.WriteBodyError(w, err)
 		return
 	}
 	serviceId := r.URL.Query().Get(":serviceId")
@@ -93,8 +139,14 @@ func (this *SchemaService) ModifySchemas(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	request.ServiceId = serviceId
+	if rev := ifMatchRevision(r); len(rev) > 0 {
+		request.ExpectedRevision = rev
+	}
 	resp, err := core.ServiceAPI.ModifySchemas(r.Context(), request)
-	controller.WriteResponse(w, resp.Response, nil)
+	setRevisionTag(w, resp.Revision)
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
 }
 
 func (this *SchemaService) DeleteSchemas(w http.ResponseWriter, r *http.Request) {
@@ -114,11 +166,60 @@ func (this *SchemaService) GetAllSchemas(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	request := &pb.GetAllSchemaRequest{
-		ServiceId:  serviceId,
-		WithSchema: withSchema == "1",
+		ServiceId:         serviceId,
+		WithSchema:        withSchema == "1",
+		ConsumerServiceId: r.Header.Get("X-ConsumerId"),
 	}
 	resp, _ := core.ServiceAPI.GetAllSchemaInfo(r.Context(), request)
+	setRevisionTag(w, resp.Revision)
 	respInternal := resp.Response
 	resp.Response = nil
 	controller.WriteResponse(w, respInternal, resp)
 }
+
+func (this *SchemaService) GetSharedDefinitions(w http.ResponseWriter, r *http.Request) {
+	resp, _ := core.ServiceAPI.GetSharedDefinitions(r.Context(), &pb.GetSharedDefinitionsRequest{})
+	respInternal := resp.Response
+	resp.Response = nil
+	controller.WriteResponse(w, respInternal, resp)
+}
+
+func (this *SchemaService) ModifySharedDefinition(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		util.Logger().Error("body err", err)
+		controller.WriteBodyError(w, err)
+		return
+	}
+
+	request := &pb.ModifySharedDefinitionRequest{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		util.Logger().Error("Unmarshal error", err)
+		controller.WriteError(w, scerr.ErrInvalidParams, err.Error())
+		return
+	}
+	request.Name = r.URL.Query().Get(":name")
+	resp, _ := core.ServiceAPI.ModifySharedDefinition(r.Context(), request)
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+func (this *SchemaService) DeleteSharedDefinition(w http.ResponseWriter, r *http.Request) {
+	resp, _ := core.ServiceAPI.DeleteSharedDefinition(r.Context(), &pb.DeleteSharedDefinitionRequest{
+		Name: r.URL.Query().Get(":name"),
+	})
+	controller.WriteResponse(w, resp.Response, nil)
+}
+
+// ifMatchRevision 从If-Match头中取期望的契约revision，兼容W/前缀与引号
+func ifMatchRevision(r *http.Request) string {
+	tag := strings.TrimSpace(r.Header.Get("If-Match"))
+	tag = strings.TrimPrefix(tag, "W/")
+	return strings.Trim(tag, `"`)
+}
+
+func setRevisionTag(w http.ResponseWriter, revision int64) {
+	if revision > 0 {
+		w.Header().Set("ETag", fmt.Sprintf(`"%d"`, revision))
+	}
+}
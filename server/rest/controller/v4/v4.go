@@ -30,7 +30,10 @@ func initRouter() {
 	roa.RegisterServent(&SchemaService{})
 	roa.RegisterServent(&DependencyService{})
 	roa.RegisterServent(&TagService{})
+	roa.RegisterServent(&DelegationService{})
+	roa.RegisterServent(&BroadcastService{})
 	roa.RegisterServent(&RuleService{})
 	roa.RegisterServent(&MicroServiceInstanceService{})
 	roa.RegisterServent(&WatchService{})
+	roa.RegisterServent(&BootstrapService{})
 }
@@ -0,0 +1,206 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gateway
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/govern"
+	"github.com/astaxie/beego"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	PROTO_FILE = "services.proto"
+	// PREFIX gateway的路由前缀，与手写接口的路由互不覆盖
+	PREFIX = "/gateway"
+)
+
+var (
+	muxOnce sync.Once
+	mux     *runtime.ServeMux
+)
+
+// Binding services.proto中RPC的HTTP映射
+type Binding struct {
+	Service string
+	RPC     string
+	Method  string
+	// google.api.http中的路径模板
+	Template string
+}
+
+// Path 转为ROA路由，模板中的*为v4接口的project，{a.b}取最后一段作为参数名
+func (b *Binding) Path() string {
+	segs := strings.Split(b.Template, "/")
+	for i, seg := range segs {
+		switch {
+		case seg == "*":
+			segs[i] = ":project"
+		case strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}"):
+			name := seg[1 : len(seg)-1]
+			if idx := strings.LastIndex(name, "."); idx >= 0 {
+				name = name[idx+1:]
+			}
+			segs[i] = ":" + name
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+func fileDescriptor() (*descriptor.FileDescriptorProto, error) {
+	gz := proto.FileDescriptor(PROTO_FILE)
+	if gz == nil {
+		return nil, fmt.Errorf("%s is not registered", PROTO_FILE)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	fd := &descriptor.FileDescriptorProto{}
+	if err := proto.Unmarshal(b, fd); err != nil {
+		return nil, err
+	}
+	return fd, nil
+}
+
+func httpRule(md *descriptor.MethodDescriptorProto) *annotations.HttpRule {
+	if md.Options == nil {
+		return nil
+	}
+	ext, err := proto.GetExtension(md.Options, annotations.E_Http)
+	if err != nil {
+		return nil
+	}
+	rule, _ := ext.(*annotations.HttpRule)
+	return rule
+}
+
+// Bindings 返回services.proto中所有声明了google.api.http的RPC
+func Bindings() ([]*Binding, error) {
+	fd, err := fileDescriptor()
+	if err != nil {
+		return nil, err
+	}
+	var bindings []*Binding
+	for _, sd := range fd.Service {
+		for _, md := range sd.Method {
+			rule := httpRule(md)
+			if rule == nil {
+				continue
+			}
+			b := &Binding{Service: sd.GetName(), RPC: md.GetName()}
+			switch {
+			case len(rule.GetGet()) > 0:
+				b.Method, b.Template = rest.HTTP_METHOD_GET, rule.GetGet()
+			case len(rule.GetPut()) > 0:
+				b.Method, b.Template = rest.HTTP_METHOD_PUT, rule.GetPut()
+			case len(rule.GetPost()) > 0:
+				b.Method, b.Template = rest.HTTP_METHOD_POST, rule.GetPost()
+			case len(rule.GetDelete()) > 0:
+				b.Method, b.Template = rest.HTTP_METHOD_DELETE, rule.GetDelete()
+			case len(rule.GetPatch()) > 0:
+				b.Method, b.Template = rest.HTTP_METHOD_PATCH, rule.GetPatch()
+			default:
+				return nil, fmt.Errorf("unsupported http rule of %s.%s", sd.GetName(), md.GetName())
+			}
+			bindings = append(bindings, b)
+		}
+	}
+	return bindings, nil
+}
+
+func newServeMux(ctx context.Context, service pb.ServiceCtrlServer,
+	instance pb.ServiceInstanceCtrlServer, govern pb.GovernServiceCtrlServer) *runtime.ServeMux {
+	m := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &v4Marshaler{}),
+		runtime.WithForwardResponseOption(forwardResponse))
+	pb.RegisterServiceCtrlHandlerServer(ctx, m, service)
+	pb.RegisterServiceInstanceCtrlHandlerServer(ctx, m, instance)
+	pb.RegisterGovernServiceCtrlHandlerServer(ctx, m, govern)
+	return m
+}
+
+func serveMux() *runtime.ServeMux {
+	muxOnce.Do(func() {
+		mux = newServeMux(context.Background(), core.ServiceAPI, core.InstanceAPI, govern.GovernServiceAPI)
+	})
+	return mux
+}
+
+func ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// ROA将路径参数以":name"追加到query中，gateway按模板自行解析
+	query := r.URL.Query()
+	for key := range query {
+		if strings.HasPrefix(key, ":") {
+			query.Del(key)
+		}
+	}
+	r.URL.RawQuery = query.Encode()
+	// 去掉前缀后按google.api.http的模板匹配
+	r.URL.Path = strings.TrimPrefix(r.URL.Path, PREFIX)
+	r.URL.RawPath = ""
+	serveMux().ServeHTTP(w, r)
+}
+
+type GatewayService struct {
+	routes []rest.Route
+}
+
+func (s *GatewayService) URLPatterns() []rest.Route {
+	return s.routes
+}
+
+// RegisterRoutes 在PREFIX下为所有声明了google.api.http的RPC注册路由，
+// 请求同样经过REST处理链，再由grpc-gateway按google.api.http解析并直接调用服务实现
+func RegisterRoutes() {
+	if !beego.AppConfig.DefaultBool("rest_gateway", true) {
+		return
+	}
+	bindings, err := Bindings()
+	if err != nil {
+		util.Logger().Errorf(err, "load the http rules of %s failed", PROTO_FILE)
+		return
+	}
+	s := &GatewayService{}
+	for _, b := range bindings {
+		path := PREFIX + b.Path()
+		s.routes = append(s.routes, rest.Route{Method: b.Method, Path: path, Func: ServeHTTP})
+		util.Logger().Infof("rest gateway serves %s.%s by %s %s", b.Service, b.RPC, b.Method, path)
+	}
+	if len(s.routes) > 0 {
+		rest.RegisterServent(s)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gateway
+
+import (
+	"encoding/json"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBindings(t *testing.T) {
+	fd, err := fileDescriptor()
+	if err != nil {
+		t.Fatalf("load descriptor failed, %s", err)
+	}
+	bindings, err := Bindings()
+	if err != nil {
+		t.Fatalf("load bindings failed, %s", err)
+	}
+	bound := make(map[string]*Binding, len(bindings))
+	for _, b := range bindings {
+		bound[b.Service+"."+b.RPC] = b
+	}
+	for _, sd := range fd.Service {
+		for _, md := range sd.Method {
+			name := sd.GetName() + "." + md.GetName()
+			_, ok := bound[name]
+			if md.GetServerStreaming() || md.GetClientStreaming() {
+				if ok {
+					t.Fatalf("streaming rpc %s should not have http rule", name)
+				}
+				continue
+			}
+			if !ok {
+				t.Fatalf("unary rpc %s has no http rule", name)
+			}
+		}
+	}
+
+	b := bound["ServiceCtrl.exist"]
+	if b == nil || b.Method != http.MethodGet || b.Path() != "/v4/:project/registry/existence" {
+		t.Fatalf("binding of ServiceCtrl.exist is wrong, %v", b)
+	}
+}
+
+func TestPath(t *testing.T) {
+	b := &Binding{Template: "/v4/*/registry/microservices/{instance.serviceId}/instances"}
+	if p := b.Path(); p != "/v4/:project/registry/microservices/:serviceId/instances" {
+		t.Fatalf("Path failed, %s", p)
+	}
+}
+
+type fakeServiceCtrl struct {
+	pb.ServiceCtrlServer
+	request *pb.GetExistenceRequest
+}
+
+func (s *fakeServiceCtrl) Exist(ctx context.Context, in *pb.GetExistenceRequest) (*pb.GetExistenceResponse, error) {
+	s.request = in
+	if in.ServiceName != "gateway" {
+		return &pb.GetExistenceResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "not exist"),
+		}, nil
+	}
+	return &pb.GetExistenceResponse{
+		Response:  pb.CreateResponse(pb.Response_SUCCESS, "ok"),
+		ServiceId: "1",
+	}, nil
+}
+
+func TestServeHTTP(t *testing.T) {
+	svc := &fakeServiceCtrl{}
+	muxOnce.Do(func() {
+		mux = newServeMux(context.Background(), svc, nil, nil)
+	})
+
+	// ROA匹配PREFIX下的路由后将路径参数追加到query
+	r := httptest.NewRequest(http.MethodGet, PREFIX+"/v4/default/registry/existence"+
+		"?type=microservice&appId=default&serviceName=gateway&version=1.0.0&:project=default", nil)
+	w := httptest.NewRecorder()
+	ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP status %d, body %s", w.Code, w.Body.String())
+	}
+	if svc.request == nil || svc.request.Type != "microservice" || svc.request.Version != "1.0.0" {
+		t.Fatalf("ServeHTTP should populate the request, %v", svc.request)
+	}
+	resp := map[string]interface{}{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("ServeHTTP body %s, %s", w.Body.String(), err)
+	}
+	if _, ok := resp["response"]; ok || resp["serviceId"] != "1" {
+		t.Fatalf("ServeHTTP body %s is not the v4 format", w.Body.String())
+	}
+
+	r = httptest.NewRequest(http.MethodGet, PREFIX+"/v4/default/registry/existence"+
+		"?type=microservice&appId=default&serviceName=none&version=1.0.0", nil)
+	w = httptest.NewRecorder()
+	ServeHTTP(w, r)
+	e := &scerr.Error{}
+	if err := json.Unmarshal(w.Body.Bytes(), e); err != nil || w.Code != http.StatusBadRequest ||
+		e.Code != scerr.ErrServiceNotExists {
+		t.Fatalf("ServeHTTP status %d, body %s, expect %d", w.Code, w.Body.String(), scerr.ErrServiceNotExists)
+	}
+}
+
+func TestForwardResponse(t *testing.T) {
+	w := httptest.NewRecorder()
+	resp := &pb.GetExistenceResponse{
+		Response:  pb.CreateResponse(pb.Response_SUCCESS, "ok"),
+		ServiceId: "1",
+	}
+	if err := forwardResponse(context.Background(), w, resp); err != nil {
+		t.Fatalf("forwardResponse failed, %s", err)
+	}
+	if resp.Response != nil || w.Header().Get("X-Response-Status") != "200" {
+		t.Fatalf("forwardResponse should remove the response field")
+	}
+
+	resp = &pb.GetExistenceResponse{
+		Response: pb.CreateResponse(scerr.ErrServiceNotExists, "not exist"),
+	}
+	err := forwardResponse(context.Background(), w, resp)
+	if e, ok := err.(*scerr.Error); !ok || e.Code != scerr.ErrServiceNotExists {
+		t.Fatalf("forwardResponse should return the error, %v", err)
+	}
+}
+
+func TestHttpError(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   int32
+	}{
+		{scerr.NewError(scerr.ErrServiceNotExists, "not exist"), http.StatusBadRequest, scerr.ErrServiceNotExists},
+		{grpc.Errorf(codes.InvalidArgument, "bad json"), http.StatusBadRequest, scerr.ErrInvalidParams},
+		{grpc.Errorf(codes.Unknown, "etcd down"), http.StatusInternalServerError, scerr.ErrInternal},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		httpError(context.Background(), &v4Marshaler{}, w, nil, c.err)
+		if w.Code != c.status {
+			t.Fatalf("httpError %v status %d, expect %d", c.err, w.Code, c.status)
+		}
+		e := &scerr.Error{}
+		if err := json.Unmarshal(w.Body.Bytes(), e); err != nil || e.Code != c.code {
+			t.Fatalf("httpError %v body %s, expect code %d", c.err, w.Body.String(), c.code)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/rest/controller"
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"net/http"
+	"reflect"
+)
+
+func init() {
+	runtime.HTTPError = httpError
+}
+
+// v4Marshaler 与手写接口一致使用encoding/json编解码
+type v4Marshaler struct {
+	runtime.JSONBuiltin
+}
+
+func (*v4Marshaler) ContentType() string {
+	return "application/json; charset=UTF-8"
+}
+
+// forwardResponse 与controller.WriteResponse一致，Response非成功时按错误返回，
+// 成功时不返回Response字段
+func forwardResponse(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
+	v := reflect.Indirect(reflect.ValueOf(resp))
+	if v.Kind() == reflect.Struct {
+		if f := v.FieldByName("Response"); f.IsValid() && f.CanSet() {
+			if r, ok := f.Interface().(*pb.Response); ok {
+				if r.GetCode() != pb.Response_SUCCESS {
+					err := scerr.NewError(r.GetCode(), r.GetMessage())
+					err.Details = r.GetDetails()
+					return err
+				}
+				f.Set(reflect.Zero(f.Type()))
+			}
+		}
+	}
+	w.Header().Add("X-Response-Status", fmt.Sprint(http.StatusOK))
+	return nil
+}
+
+// httpError 请求解析失败时为参数错误，服务实现返回的错误按内部错误处理
+func httpError(ctx context.Context, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
+	if e, ok := err.(*scerr.Error); ok {
+		e.HttpWrite(w)
+		return
+	}
+	switch grpc.Code(err) {
+	case codes.InvalidArgument:
+		controller.WriteBodyError(w, errors.New(grpc.ErrorDesc(err)))
+	case codes.Unauthenticated:
+		controller.WriteError(w, scerr.ErrUnauthorized, grpc.ErrorDesc(err))
+	default:
+		controller.WriteError(w, scerr.ErrInternal, err.Error())
+	}
+}
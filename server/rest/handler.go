@@ -20,22 +20,50 @@ package rest
 import (
 	roa "github.com/apache/incubator-servicecomb-service-center/pkg/rest"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/auditlog"
 	"github.com/apache/incubator-servicecomb-service-center/server/interceptor"
+	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"golang.org/x/net/context"
 	"net/http"
+	"strconv"
 	"time"
 )
 
+// HEADER_REQUEST_TIMEOUT 客户端指定的请求超时时间，单位毫秒
+const HEADER_REQUEST_TIMEOUT = "X-Request-Timeout"
+
 func init() {
 	// api
-	http.Handle("/", &ServerHandler{})
+	http.Handle("/", &ServerHandler{Listener: LISTENER_REGISTRY})
 }
 
 type ServerHandler struct {
+	Listener string
 }
 
 func (s *ServerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
 
+	if core.ServerInfo.Config.AccessLogEnabled {
+		alw := &accessLogWriter{ResponseWriter: w}
+		w = alw
+		defer func() { RecordAccessLog(alw, r, start) }()
+	}
+
+	// 配置了独立的admin地址后，运维接口只在admin地址上提供
+	if !routable(s.Listener, r.URL.Path) {
+		http.NotFound(w, r)
+		return
+	}
+	// 客户端断开连接或超时后，通过ctx取消未完成的后端操作
+	if timeout := requestTimeout(r); timeout > 0 {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+	}
+	r = util.SetRequestContext(r, CTX_LISTENER, s.Listener)
+
 	err := interceptor.InvokeInterceptors(w, r)
 	if err != nil {
 		return
@@ -45,5 +73,24 @@ func (s *ServerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	ReportRequestCompleted(w, r, start)
 
+	// 请求上下文中的x-api-key-id为通过鉴权的API密钥，审计插件默认为buildin不记录
+	if al, ok := plugin.Plugins().Instance(plugin.AUDIT_LOG).(auditlog.AuditLogger); ok {
+		al.Record(r, w.Header())
+	}
+
 	util.LogNilOrWarnf(start, "%s %s", r.Method, r.RequestURI)
 }
+
+// requestTimeout 解析X-Request-Timeout，非法值视为未指定
+func requestTimeout(r *http.Request) time.Duration {
+	v := r.Header.Get(HEADER_REQUEST_TIMEOUT)
+	if len(v) == 0 {
+		return 0
+	}
+	ms, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || ms <= 0 {
+		util.Logger().Warnf(nil, "invalid %s header '%s', ignore it", HEADER_REQUEST_TIMEOUT, v)
+		return 0
+	}
+	return time.Duration(ms) * time.Millisecond
+}
@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rest
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/metric"
+	"github.com/astaxie/beego"
+	"net/http"
+	"strings"
+)
+
+const (
+	LISTENER_REGISTRY = "registry"
+	LISTENER_ADMIN    = "admin"
+	LISTENER_METRICS  = "metrics"
+
+	CTX_LISTENER = "listener"
+)
+
+// ListenerConfig 独立监听地址的TLS和鉴权配置
+type ListenerConfig struct {
+	Name        string
+	Addr        string
+	SslEnabled  bool
+	AuthEnabled bool
+}
+
+var (
+	adminListener   = loadListenerConfig(LISTENER_ADMIN)
+	metricsListener = loadListenerConfig(LISTENER_METRICS)
+)
+
+// loadListenerConfig 未配置{name}_addr时返回nil，由registry地址提供服务
+func loadListenerConfig(name string) *ListenerConfig {
+	addr := beego.AppConfig.String(name + "_addr")
+	if len(addr) == 0 {
+		return nil
+	}
+	ssl := 0
+	if core.ServerInfo.Config.SslEnabled {
+		ssl = 1
+	}
+	return &ListenerConfig{
+		Name:        name,
+		Addr:        addr,
+		SslEnabled:  beego.AppConfig.DefaultInt(name+"_ssl_mode", ssl) != 0,
+		AuthEnabled: beego.AppConfig.DefaultInt(name+"_auth", 1) != 0,
+	}
+}
+
+// Listeners 需要额外启动的监听
+func Listeners() (ls []*ListenerConfig) {
+	for _, l := range []*ListenerConfig{adminListener, metricsListener} {
+		if l != nil {
+			ls = append(ls, l)
+		}
+	}
+	return
+}
+
+// IsAdminPath 匹配/v4/{project}/admin/*
+func IsAdminPath(path string) bool {
+	arr := strings.SplitN(path, "/", 5)
+	return len(arr) >= 4 && arr[1] == "v4" && arr[3] == "admin"
+}
+
+// AuthEnabled 请求所在的监听是否需要鉴权
+func AuthEnabled(r *http.Request) bool {
+	listener, _ := r.Context().Value(CTX_LISTENER).(string)
+	if listener == LISTENER_ADMIN && adminListener != nil {
+		return adminListener.AuthEnabled
+	}
+	return true
+}
+
+// NewListenerHandler admin只提供运维接口，metrics只提供/metrics
+func NewListenerHandler(name string) http.Handler {
+	mux := http.NewServeMux()
+	switch name {
+	case LISTENER_METRICS:
+		mux.Handle("/metrics", metric.Handler())
+	default:
+		mux.Handle("/", &ServerHandler{Listener: name})
+	}
+	return mux
+}
+
+func routable(listener, path string) bool {
+	switch listener {
+	case LISTENER_ADMIN:
+		return IsAdminPath(path)
+	default:
+		return adminListener == nil || !IsAdminPath(path)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rest
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsAdminPath(t *testing.T) {
+	for path, ok := range map[string]bool{
+		"/v4/default/admin/dump":                 true,
+		"/v4/default/admin":                      true,
+		"/v4/default/registry/microservices":     false,
+		"/v4/admin/registry":                     false,
+		"/registry/v3/microservices":             false,
+		"/v4/default/administrator/microservice": false,
+	} {
+		if IsAdminPath(path) != ok {
+			t.Fatalf("TestIsAdminPath failed, %s should be %v", path, ok)
+		}
+	}
+}
+
+func TestListeners(t *testing.T) {
+	admin, metrics := adminListener, metricsListener
+	defer func() {
+		adminListener, metricsListener = admin, metrics
+	}()
+
+	// 未配置独立的admin地址时，registry地址提供所有接口
+	adminListener, metricsListener = nil, nil
+	if len(Listeners()) != 0 || !routable(LISTENER_REGISTRY, "/v4/default/admin/dump") {
+		t.Fatalf("TestListeners failed, admin apis should be served by registry listener")
+	}
+
+	adminListener = &ListenerConfig{Name: LISTENER_ADMIN, Addr: "127.0.0.1:30101", AuthEnabled: false}
+	metricsListener = &ListenerConfig{Name: LISTENER_METRICS, Addr: "127.0.0.1:30102", AuthEnabled: true}
+	if ls := Listeners(); len(ls) != 2 || ls[0] != adminListener || ls[1] != metricsListener {
+		t.Fatalf("TestListeners failed, %v", ls)
+	}
+	if routable(LISTENER_REGISTRY, "/v4/default/admin/dump") || !routable(LISTENER_REGISTRY, "/v4/default/registry/health") ||
+		!routable(LISTENER_ADMIN, "/v4/default/admin/dump") || routable(LISTENER_ADMIN, "/v4/default/registry/health") {
+		t.Fatalf("TestListeners failed, admin apis should be served by admin listener only")
+	}
+
+	// 鉴权按请求所在的监听判断
+	r := httptest.NewRequest(http.MethodGet, "/v4/default/admin/dump", nil)
+	if !AuthEnabled(r) {
+		t.Fatalf("TestListeners failed, auth should be enabled by default")
+	}
+	if AuthEnabled(util.SetRequestContext(r, CTX_LISTENER, LISTENER_ADMIN)) {
+		t.Fatalf("TestListeners failed, auth of admin listener is disabled")
+	}
+
+	// 运维接口不在registry地址上提供
+	w := httptest.NewRecorder()
+	(&ServerHandler{Listener: LISTENER_REGISTRY}).ServeHTTP(w,
+		httptest.NewRequest(http.MethodGet, "/v4/default/admin/dump", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("TestListeners failed, admin api served by registry listener, %d", w.Code)
+	}
+
+	// metrics地址只提供/metrics
+	w = httptest.NewRecorder()
+	NewListenerHandler(LISTENER_METRICS).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v4/default/registry/health", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("TestListeners failed, registry api served by metrics listener, %d", w.Code)
+	}
+}
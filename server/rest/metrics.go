@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
 	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/metric"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 	"net/http"
@@ -58,7 +59,10 @@ var (
 func init() {
 	prometheus.MustRegister(incomingRequests, successfulRequests, reqDurations)
 
-	http.Handle("/metrics", prometheus.Handler())
+	// 配置了独立的metrics地址后，registry地址不再提供/metrics
+	if metricsListener == nil {
+		http.Handle("/metrics", metric.Handler())
+	}
 }
 
 func ReportRequestCompleted(w http.ResponseWriter, r *http.Request, start time.Time) {
@@ -73,6 +77,11 @@ func ReportRequestCompleted(w http.ResponseWriter, r *http.Request, start time.T
 	success, code := codeOf(w.Header())
 
 	incomingRequests.WithLabelValues(r.Method, code, instance, route).Inc()
+	if metric.ExemplarEnabled() {
+		metric.ObserveExemplar("service_center_http_request_total", prometheus.Labels{
+			"method": r.Method, "code": code, "instance": instance, "api": route,
+		}, metric.TraceIdOf(r), 1)
+	}
 
 	if success {
 		successfulRequests.WithLabelValues(r.Method, code, instance, route).Inc()
@@ -22,6 +22,7 @@ import (
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"github.com/apache/incubator-servicecomb-service-center/server/core"
 	sctls "github.com/apache/incubator-servicecomb-service-center/server/tls"
+	"golang.org/x/net/http2"
 	"net/http"
 	"time"
 )
@@ -31,6 +32,10 @@ var (
 )
 
 func LoadConfig() (srvCfg *rest.ServerConfig, err error) {
+	return loadConfig(core.ServerInfo.Config.SslEnabled)
+}
+
+func loadConfig(sslEnabled bool) (srvCfg *rest.ServerConfig, err error) {
 	srvCfg = rest.DefaultServerConfig()
 	readHeaderTimeout, _ := time.ParseDuration(core.ServerInfo.Config.ReadHeaderTimeout)
 	readTimeout, _ := time.ParseDuration(core.ServerInfo.Config.ReadTimeout)
@@ -38,7 +43,7 @@ func LoadConfig() (srvCfg *rest.ServerConfig, err error) {
 	writeTimeout, _ := time.ParseDuration(core.ServerInfo.Config.WriteTimeout)
 	maxHeaderBytes := int(core.ServerInfo.Config.MaxHeaderBytes)
 	var tlsConfig *tls.Config
-	if core.ServerInfo.Config.SslEnabled {
+	if sslEnabled {
 		tlsConfig, err = sctls.GetServerTLSConfig()
 		if err != nil {
 			return
@@ -49,10 +54,18 @@ func LoadConfig() (srvCfg *rest.ServerConfig, err error) {
 	srvCfg.IdleTimeout = idleTimeout
 	srvCfg.WriteTimeout = writeTimeout
 	srvCfg.MaxHeaderBytes = maxHeaderBytes
+	srvCfg.KeepAliveTimeout = core.ParseTuningDuration(core.GetConnTuning().TCPKeepAlivePeriod)
 	srvCfg.TLSConfig = tlsConfig
 	return
 }
 
+func newHTTP2Server(t core.ConnTuning) *http2.Server {
+	return &http2.Server{
+		MaxConcurrentStreams: t.HTTP2MaxConcurrentStreams,
+		IdleTimeout:          core.ParseTuningDuration(t.HTTP2IdleTimeout),
+	}
+}
+
 func newDefaultServer(addr string, handler http.Handler) error {
 	if defaultRESTfulServer != nil {
 		return nil
@@ -106,7 +119,30 @@ func NewServer(ep string) (srv *rest.Server, err error) {
 		return
 	}
 	srvCfg.Addr = ipAddr
+	return listen(srvCfg)
+}
+
+// NewListenerServer 按监听各自的TLS配置创建admin或metrics的server
+func NewListenerServer(l *ListenerConfig) (srv *rest.Server, err error) {
+	srvCfg, err := loadConfig(l.SslEnabled)
+	if err != nil {
+		return
+	}
+	srvCfg.Addr = l.Addr
+	srvCfg.Handler = NewListenerHandler(l.Name)
+	return listen(srvCfg)
+}
+
+func listen(srvCfg *rest.ServerConfig) (srv *rest.Server, err error) {
 	srv = rest.NewServer(srvCfg)
+	if t := core.GetConnTuning(); t.HTTP2Enabled {
+		srv.EnableHTTP2(newHTTP2Server(t))
+	}
+	core.OnConnTuningChanged(func(_, cur core.ConnTuning) error {
+		srv.SetKeepaliveTimeout(core.ParseTuningDuration(cur.TCPKeepAlivePeriod))
+		srv.SetHTTP2(newHTTP2Server(cur))
+		return nil
+	})
 
 	if srvCfg.TLSConfig == nil {
 		err = srv.Listen()
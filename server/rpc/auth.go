@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rpc
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// METADATA_API_KEY gRPC请求通过该metadata携带API密钥
+const METADATA_API_KEY = "x-api-key"
+
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// identify 未携带API密钥时保持原有行为，携带时以密钥的domain/project作为请求的租户
+func identify(ctx context.Context, method string) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md[METADATA_API_KEY]) == 0 {
+		return ctx, nil
+	}
+	apiKey, err := serviceUtil.VerifyApiKey(ctx, md[METADATA_API_KEY][0])
+	if err != nil {
+		util.Logger().Errorf(err, "authenticate grpc request failed, %s", method)
+		return nil, grpc.Errorf(codes.Unauthenticated, "%s", err.Error())
+	}
+	ctx = util.SetContext(ctx, "domain", apiKey.Domain)
+	ctx = util.SetContext(ctx, "project", apiKey.Project)
+	ctx = util.SetContext(ctx, serviceUtil.CTX_API_KEY, apiKey.KeyId)
+	return ctx, nil
+}
+
+func unaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := identify(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func streamAuthInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := identify(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+}
@@ -0,0 +1,169 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rpc
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	nf "github.com/apache/incubator-servicecomb-service-center/server/service/notification"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"sync"
+	"time"
+)
+
+const (
+	// 逻辑子服务，registry为注册发现读写，watch为实例变化推送
+	HEALTH_SERVICE_REGISTRY = "registry"
+	HEALTH_SERVICE_WATCH    = "watch"
+
+	DEFAULT_HEALTH_CHECK_INTERVAL = 5 * time.Second
+	HEALTH_CHECK_TIMEOUT          = 3 * time.Second
+
+	instanceCtrlServiceName = "com.huawei.paas.cse.serviceregistry.api.ServiceInstanceCtrl"
+	healthServiceName       = "grpc.health.v1.Health"
+	reflectionServiceName   = "grpc.reflection.v1alpha.ServerReflection"
+)
+
+// HealthServer 实现grpc.health.v1.Health，service为空时返回整体状态，
+// 也支持registry、watch以及gRPC的完整服务名
+type HealthServer struct {
+	Interval time.Duration
+
+	mux      sync.RWMutex
+	services []string
+	statuses map[string]healthpb.HealthCheckResponse_ServingStatus
+}
+
+func NewHealthServer() *HealthServer {
+	return &HealthServer{
+		Interval: DEFAULT_HEALTH_CHECK_INTERVAL,
+		statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{
+			"":                      healthpb.HealthCheckResponse_NOT_SERVING,
+			HEALTH_SERVICE_REGISTRY: healthpb.HealthCheckResponse_NOT_SERVING,
+			HEALTH_SERVICE_WATCH:    healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+	}
+}
+
+func (s *HealthServer) Check(ctx context.Context, in *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s.mux.RLock()
+	status, ok := s.statuses[in.Service]
+	s.mux.RUnlock()
+	if !ok {
+		return nil, grpc.Errorf(codes.NotFound, "unknown service %s", in.Service)
+	}
+	return &healthpb.HealthCheckResponse{Status: status}, nil
+}
+
+func (s *HealthServer) SetServingStatus(service string, status healthpb.HealthCheckResponse_ServingStatus) {
+	s.mux.Lock()
+	s.statuses[service] = status
+	s.mux.Unlock()
+}
+
+// Register 注册Health服务，grpc服务名的状态跟随其依赖的子服务；
+// 调整连接参数时会注册到新的grpc.Server，已有服务的状态保持不变
+func (s *HealthServer) Register(srv *grpc.Server) {
+	healthpb.RegisterHealthServer(srv, s)
+	s.mux.Lock()
+	for name := range srv.GetServiceInfo() {
+		if _, ok := s.statuses[name]; ok {
+			continue
+		}
+		s.services = append(s.services, name)
+		s.statuses[name] = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	s.mux.Unlock()
+}
+
+func checkRegistry(ctx context.Context) bool {
+	tctx, cancel := context.WithTimeout(ctx, HEALTH_CHECK_TIMEOUT)
+	defer cancel()
+	_, err := backend.Registry().Do(tctx, registry.GET,
+		registry.WithStrKey(apt.GetServiceRootKey("")),
+		registry.WithPrefix(),
+		registry.WithCountOnly())
+	if err != nil {
+		util.Logger().Errorf(err, "grpc health check: registry is unavailable")
+		return false
+	}
+	return true
+}
+
+func checkWatch() bool {
+	return !nf.GetNotifyService().Closed()
+}
+
+func toServingStatus(b bool) healthpb.HealthCheckResponse_ServingStatus {
+	if b {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+// Probe 检查后端和推送服务，刷新所有服务的状态
+func (s *HealthServer) Probe(ctx context.Context) {
+	s.update(checkRegistry(ctx), checkWatch())
+}
+
+func (s *HealthServer) update(registryOk, watchOk bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.statuses[""] = toServingStatus(registryOk && watchOk)
+	s.statuses[HEALTH_SERVICE_REGISTRY] = toServingStatus(registryOk)
+	s.statuses[HEALTH_SERVICE_WATCH] = toServingStatus(watchOk)
+	for _, name := range s.services {
+		switch name {
+		case healthServiceName, reflectionServiceName:
+			s.statuses[name] = healthpb.HealthCheckResponse_SERVING
+		case instanceCtrlServiceName:
+			// 实例接口同时提供发现和watch
+			s.statuses[name] = toServingStatus(registryOk && watchOk)
+		default:
+			s.statuses[name] = toServingStatus(registryOk)
+		}
+	}
+}
+
+// Shutdown 停止服务前置为NOT_SERVING，负载均衡器不再转发新请求
+func (s *HealthServer) Shutdown() {
+	s.mux.Lock()
+	for name := range s.statuses {
+		s.statuses[name] = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	s.mux.Unlock()
+}
+
+func (s *HealthServer) Run() {
+	s.Probe(context.Background())
+	util.Go(func(stopCh <-chan struct{}) {
+		for {
+			select {
+			case <-stopCh:
+				s.Shutdown()
+				return
+			case <-time.After(s.Interval):
+				s.Probe(context.Background())
+			}
+		}
+	})
+}
@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package rpc
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net"
+	"testing"
+)
+
+const serviceCtrlServiceName = "com.huawei.paas.cse.serviceregistry.api.ServiceCtrl"
+
+type fakeServiceCtrl struct {
+	pb.ServiceCtrlServer
+}
+
+type fakeInstanceCtrl struct {
+	pb.ServiceInstanceCtrlServer
+}
+
+func TestHealthServer(t *testing.T) {
+	srv := grpc.NewServer()
+	pb.RegisterServiceCtrlServer(srv, &fakeServiceCtrl{})
+	pb.RegisterServiceInstanceCtrlServer(srv, &fakeInstanceCtrl{})
+	health := NewHealthServer()
+	health.Register(srv)
+
+	ls, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed, %s", err)
+	}
+	go srv.Serve(ls)
+	defer srv.Stop()
+	conn, err := grpc.Dial(ls.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("dial failed, %s", err)
+	}
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+	expect := func(statuses map[string]healthpb.HealthCheckResponse_ServingStatus) {
+		for service, status := range statuses {
+			resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			if err != nil {
+				t.Fatalf("check '%s' failed, %s", service, err)
+			}
+			if resp.Status != status {
+				t.Fatalf("status of '%s' is %s, expect %s", service, resp.Status, status)
+			}
+		}
+	}
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	if grpc.Code(err) != codes.NotFound {
+		t.Fatalf("check unknown service should be not found, %v", err)
+	}
+	// 未探测前均不可用
+	expect(map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":                      healthpb.HealthCheckResponse_NOT_SERVING,
+		HEALTH_SERVICE_REGISTRY: healthpb.HealthCheckResponse_NOT_SERVING,
+		serviceCtrlServiceName:  healthpb.HealthCheckResponse_NOT_SERVING,
+	})
+
+	// 推送服务不可用时只影响整体状态和实例接口
+	health.update(true, false)
+	expect(map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":                      healthpb.HealthCheckResponse_NOT_SERVING,
+		HEALTH_SERVICE_REGISTRY: healthpb.HealthCheckResponse_SERVING,
+		HEALTH_SERVICE_WATCH:    healthpb.HealthCheckResponse_NOT_SERVING,
+		serviceCtrlServiceName:  healthpb.HealthCheckResponse_SERVING,
+		instanceCtrlServiceName: healthpb.HealthCheckResponse_NOT_SERVING,
+		healthServiceName:       healthpb.HealthCheckResponse_SERVING,
+	})
+
+	health.update(true, true)
+	expect(map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":                      healthpb.HealthCheckResponse_SERVING,
+		instanceCtrlServiceName: healthpb.HealthCheckResponse_SERVING,
+	})
+
+	health.Shutdown()
+	expect(map[string]healthpb.HealthCheckResponse_ServingStatus{
+		"":                     healthpb.HealthCheckResponse_NOT_SERVING,
+		healthServiceName:      healthpb.HealthCheckResponse_NOT_SERVING,
+		serviceCtrlServiceName: healthpb.HealthCheckResponse_NOT_SERVING,
+	})
+}
@@ -17,44 +17,150 @@
 package rpc
 
 import (
+	"errors"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/rpc"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"github.com/apache/incubator-servicecomb-service-center/server/core"
 	sctls "github.com/apache/incubator-servicecomb-service-center/server/tls"
+	"github.com/astaxie/beego"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 	"net"
+	"sync"
+	"time"
 )
 
+var errListenerClosed = errors.New("grpc listener closed")
+
+// Server 由innerListener统一accept，连接分发给当前参数的grpc.Server；
+// 运行时调整参数时创建新的grpc.Server，旧的不再接收新连接并优雅退出
 type Server struct {
-	*grpc.Server
+	health        *HealthServer
 	innerListener net.Listener
+
+	lock    sync.RWMutex
+	current *grpcServer
 }
 
-func (srv *Server) Serve() error {
-	return srv.Server.Serve(srv.innerListener)
+type grpcServer struct {
+	*grpc.Server
+	listener *connListener
 }
 
-func NewServer(ep string) (_ *Server, err error) {
-	ipAddr, err := util.ParseEndpoint(ep)
-	if err != nil {
-		return
+// connListener 接收Server分发的连接
+type connListener struct {
+	addr      net.Addr
+	conns     chan net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (l *connListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, errListenerClosed
 	}
+}
+
+func (l *connListener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+	})
+	return nil
+}
 
-	var grpcSrv *grpc.Server
+func (l *connListener) Addr() net.Addr {
+	return l.addr
+}
+
+func (srv *Server) Serve() error {
+	srv.lock.RLock()
+	cur := srv.current
+	srv.lock.RUnlock()
+	go cur.Serve(cur.listener)
+
+	var delay time.Duration
+	for {
+		conn, err := srv.innerListener.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				if delay == 0 {
+					delay = 5 * time.Millisecond
+				} else if delay *= 2; delay > time.Second {
+					delay = time.Second
+				}
+				time.Sleep(delay)
+				continue
+			}
+			return err
+		}
+		delay = 0
+
+		srv.lock.RLock()
+		l := srv.current.listener
+		srv.lock.RUnlock()
+		select {
+		case l.conns <- conn:
+		case <-l.closed:
+			// 正在切换参数，客户端重连即可
+			conn.Close()
+		}
+	}
+}
+
+func newGRPCServer(addr net.Addr, health *HealthServer, t core.ConnTuning) (*grpcServer, error) {
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(unaryAuthInterceptor),
+		grpc.StreamInterceptor(streamAuthInterceptor),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              core.ParseTuningDuration(t.GRPCKeepAliveInterval),
+			Timeout:           core.ParseTuningDuration(t.GRPCKeepAliveTimeout),
+			MaxConnectionIdle: core.ParseTuningDuration(t.GRPCMaxConnectionIdle),
+		}),
+		// 允许客户端在没有活跃流时ping，避免经过负载均衡的watch连接因too_many_pings被断开
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             core.ParseTuningDuration(t.GRPCKeepAliveMinTime),
+			PermitWithoutStream: true,
+		}),
+	}
+	if t.GRPCMaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(t.GRPCMaxConcurrentStreams))
+	}
 	if core.ServerInfo.Config.SslEnabled {
 		tlsConfig, err := sctls.GetServerTLSConfig()
 		if err != nil {
 			util.Logger().Error("error to get server tls config", err)
 			return nil, err
 		}
-		creds := credentials.NewTLS(tlsConfig)
-		grpcSrv = grpc.NewServer(grpc.Creds(creds))
-	} else {
-		grpcSrv = grpc.NewServer()
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	}
+	grpcSrv := grpc.NewServer(opts...)
 
 	rpc.RegisterServer(grpcSrv)
+	if beego.AppConfig.DefaultBool("grpc_reflection", false) {
+		reflection.Register(grpcSrv)
+	}
+	health.Register(grpcSrv)
+
+	return &grpcServer{
+		Server: grpcSrv,
+		listener: &connListener{
+			addr:   addr,
+			conns:  make(chan net.Conn),
+			closed: make(chan struct{}),
+		},
+	}, nil
+}
+
+func NewServer(ep string) (_ *Server, err error) {
+	ipAddr, err := util.ParseEndpoint(ep)
+	if err != nil {
+		return
+	}
 
 	ls, err := net.Listen("tcp", ipAddr)
 	if err != nil {
@@ -62,8 +168,52 @@ func NewServer(ep string) (_ *Server, err error) {
 		return
 	}
 
-	return &Server{
-		Server:        grpcSrv,
+	health := NewHealthServer()
+	cur, err := newGRPCServer(ls.Addr(), health, core.GetConnTuning())
+	if err != nil {
+		ls.Close()
+		return
+	}
+
+	health.Run()
+
+	srv := &Server{
+		health:        health,
 		innerListener: ls,
-	}, nil
+		current:       cur,
+	}
+	core.OnConnTuningChanged(srv.tune)
+	return srv, nil
+}
+
+// tune 新连接使用新的参数，存量连接收到GOAWAY，流结束后关闭，客户端重连后生效
+func (srv *Server) tune(_, cur core.ConnTuning) error {
+	next, err := newGRPCServer(srv.innerListener.Addr(), srv.health, cur)
+	if err != nil {
+		return err
+	}
+
+	srv.lock.Lock()
+	prev := srv.current
+	srv.current = next
+	srv.lock.Unlock()
+
+	go next.Serve(next.listener)
+	prev.listener.Close()
+	go prev.GracefulStop()
+	util.Logger().Infof("grpc server is reconfigured, keepalive %s/%s, min ping interval %s, max idle %s, max streams %d",
+		cur.GRPCKeepAliveInterval, cur.GRPCKeepAliveTimeout, cur.GRPCKeepAliveMinTime,
+		cur.GRPCMaxConnectionIdle, cur.GRPCMaxConcurrentStreams)
+	return nil
+}
+
+// GracefulStop 先将健康状态置为NOT_SERVING，再等待存量请求结束
+func (srv *Server) GracefulStop() {
+	srv.health.Shutdown()
+	srv.innerListener.Close()
+
+	srv.lock.RLock()
+	cur := srv.current
+	srv.lock.RUnlock()
+	cur.GracefulStop()
 }
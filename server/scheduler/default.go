@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package scheduler
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/astaxie/beego"
+)
+
+var defaultScheduler = NewScheduler()
+
+func init() {
+	if n := beego.AppConfig.DefaultInt("scheduler_max_concurrency", 0); n > 0 {
+		defaultScheduler.MaxConcurrency = n
+	}
+	if j := beego.AppConfig.DefaultFloat("scheduler_jitter", -1); j >= 0 && j < 1 {
+		defaultScheduler.Jitter = j
+	}
+}
+
+func GetScheduler() *Scheduler {
+	return defaultScheduler
+}
+
+// Register 注册到默认调度器，允许在Run之前调用
+func Register(job *Job) {
+	if err := defaultScheduler.Register(job); err != nil {
+		util.Logger().Errorf(err, "register job %s failed", job.Name)
+	}
+}
+
+// SetElector 设置单例任务的leader判断，需在Run之前调用
+func SetElector(e Elector) {
+	defaultScheduler.Elector = e
+}
+
+func Run() {
+	util.Go(defaultScheduler.Run)
+}
@@ -0,0 +1,310 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package scheduler
+
+import (
+	"errors"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	PRIORITY_LOW    = 0
+	PRIORITY_NORMAL = 50
+	PRIORITY_HIGH   = 100
+
+	DEFAULT_MAX_CONCURRENCY = 2
+	DEFAULT_JITTER          = 0.1
+
+	RESULT_SUCCESS = "success"
+	RESULT_FAILURE = "failure"
+	RESULT_SKIPPED = "skipped"
+)
+
+var (
+	jobRuns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "scheduler",
+			Name:      "job_runs_total",
+			Help:      "Counter of background job runs",
+		}, []string{"job", "result"})
+
+	jobLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace: "service_center",
+			Subsystem: "scheduler",
+			Name:      "job_duration_seconds",
+			Help:      "Duration of background job runs",
+		}, []string{"job"})
+)
+
+func init() {
+	prometheus.MustRegister(jobRuns, jobLatency)
+}
+
+// Job 后台任务，同一任务不会并发执行，下次执行时间从本次结束开始计算
+type Job struct {
+	Name string
+	// 多个任务同时到期时优先执行Priority大的
+	Priority int
+	Interval time.Duration
+	// 注册后立即执行一次，否则首次在Interval后执行
+	Immediate bool
+	// 集群中只需一个节点执行的任务，只在Elector为leader的节点执行，其他节点到期时跳过
+	Singleton bool
+	Func      func(ctx context.Context) error
+}
+
+// Elector 判断本节点是否为单例任务的leader
+type Elector interface {
+	IsLeader() bool
+}
+
+// JobStatus 任务的最近一次执行情况
+type JobStatus struct {
+	Name           string `json:"name"`
+	Priority       int    `json:"priority"`
+	Interval       int64  `json:"interval"`
+	Running        bool   `json:"running"`
+	Runs           int64  `json:"runs"`
+	Failures       int64  `json:"failures"`
+	LastStart      int64  `json:"lastStart,omitempty"`
+	LastDurationMs int64  `json:"lastDurationMs,omitempty"`
+	LastError      string `json:"lastError,omitempty"`
+	NextRun        int64  `json:"nextRun,omitempty"`
+	Singleton      bool   `json:"singleton,omitempty"`
+	// 单例任务因本节点不是leader而跳过的次数
+	Skips int64 `json:"skips,omitempty"`
+}
+
+type jobEntry struct {
+	job     *Job
+	next    time.Time
+	running bool
+	status  JobStatus
+}
+
+// Scheduler 统一调度后台任务，限制同时执行的任务数，避免高峰期相互争抢
+type Scheduler struct {
+	MaxConcurrency int
+	// 执行间隔的随机抖动比例，避免多个实例同时执行
+	Jitter float64
+	// 为nil时单例任务在每个节点都执行
+	Elector Elector
+
+	mux     sync.Mutex
+	jobs    map[string]*jobEntry
+	running int
+	notify  chan struct{}
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		MaxConcurrency: DEFAULT_MAX_CONCURRENCY,
+		Jitter:         DEFAULT_JITTER,
+		jobs:           make(map[string]*jobEntry),
+		notify:         make(chan struct{}, 1),
+	}
+}
+
+// Register 注册任务，同名任务会被替换
+func (s *Scheduler) Register(job *Job) error {
+	if job == nil || len(job.Name) == 0 || job.Interval <= 0 || job.Func == nil {
+		return errors.New("invalid job")
+	}
+	now := time.Now()
+	e := &jobEntry{
+		job: job,
+		status: JobStatus{
+			Name:      job.Name,
+			Priority:  job.Priority,
+			Interval:  int64(job.Interval / time.Second),
+			Singleton: job.Singleton,
+		},
+	}
+	if job.Immediate {
+		e.next = now
+	} else {
+		e.next = now.Add(s.jitter(job.Interval))
+	}
+
+	s.mux.Lock()
+	if old, ok := s.jobs[job.Name]; ok {
+		e.running, e.status = old.running, old.status
+		e.status.Priority, e.status.Interval = job.Priority, int64(job.Interval/time.Second)
+		e.status.Singleton = job.Singleton
+	}
+	s.jobs[job.Name] = e
+	s.mux.Unlock()
+
+	s.wakeup()
+	return nil
+}
+
+// Status 所有任务的状态，按名称排序
+func (s *Scheduler) Status() []JobStatus {
+	s.mux.Lock()
+	l := make([]JobStatus, 0, len(s.jobs))
+	for _, e := range s.jobs {
+		st := e.status
+		st.Running = e.running
+		if !e.running {
+			st.NextRun = e.next.Unix()
+		}
+		l = append(l, st)
+	}
+	s.mux.Unlock()
+	sort.Slice(l, func(i, j int) bool { return l[i].Name < l[j].Name })
+	return l
+}
+
+func (s *Scheduler) jitter(d time.Duration) time.Duration {
+	if s.Jitter <= 0 {
+		return d
+	}
+	delta := float64(d) * s.Jitter
+	return d + time.Duration(delta*(2*rand.Float64()-1))
+}
+
+func (s *Scheduler) wakeup() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) isLeader() bool {
+	return s.Elector == nil || s.Elector.IsLeader()
+}
+
+// dispatch 按优先级启动已到期的任务，返回最近一个待执行任务的等待时间；
+// 非leader节点上到期的单例任务顺延一个周期，成为leader后在下次到期时执行
+func (s *Scheduler) dispatch(ctx context.Context, now time.Time) time.Duration {
+	leader := s.isLeader()
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	var due []*jobEntry
+	wait := time.Duration(-1)
+	for _, e := range s.jobs {
+		if e.running {
+			continue
+		}
+		if !e.next.After(now) && e.job.Singleton && !leader {
+			e.next = now.Add(s.jitter(e.job.Interval))
+			e.status.Skips++
+			jobRuns.WithLabelValues(e.job.Name, RESULT_SKIPPED).Inc()
+		}
+		if !e.next.After(now) {
+			due = append(due, e)
+			continue
+		}
+		if d := e.next.Sub(now); wait < 0 || d < wait {
+			wait = d
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].job.Priority != due[j].job.Priority {
+			return due[i].job.Priority > due[j].job.Priority
+		}
+		return due[i].next.Before(due[j].next)
+	})
+
+	max := s.MaxConcurrency
+	if max <= 0 {
+		max = DEFAULT_MAX_CONCURRENCY
+	}
+	for _, e := range due {
+		if s.running >= max {
+			break
+		}
+		e.running = true
+		s.running++
+		go s.execute(ctx, e)
+	}
+	return wait
+}
+
+func (s *Scheduler) execute(ctx context.Context, e *jobEntry) {
+	start := time.Now()
+	err := s.call(ctx, e.job)
+	cost := time.Since(start)
+
+	result := RESULT_SUCCESS
+	if err != nil {
+		result = RESULT_FAILURE
+		util.Logger().Errorf(err, "run job %s failed", e.job.Name)
+	}
+	jobRuns.WithLabelValues(e.job.Name, result).Inc()
+	jobLatency.WithLabelValues(e.job.Name).Observe(cost.Seconds())
+
+	s.mux.Lock()
+	cur := e
+	if c, ok := s.jobs[e.job.Name]; ok {
+		cur = c
+	}
+	cur.running = false
+	cur.next = time.Now().Add(s.jitter(cur.job.Interval))
+	cur.status.Runs++
+	cur.status.LastStart = start.Unix()
+	cur.status.LastDurationMs = int64(cost / time.Millisecond)
+	cur.status.LastError = ""
+	if err != nil {
+		cur.status.Failures++
+		cur.status.LastError = err.Error()
+	}
+	s.running--
+	s.mux.Unlock()
+
+	s.wakeup()
+}
+
+func (s *Scheduler) call(ctx context.Context, job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			util.LogPanic(r)
+			err = errors.New("job panic")
+		}
+	}()
+	return job.Func(ctx)
+}
+
+// Run 调度循环，stopCh关闭后不再启动新任务，执行中的任务通过ctx取消
+func (s *Scheduler) Run(stopCh <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for {
+		wait := s.dispatch(ctx, time.Now())
+		var timer <-chan time.Time
+		if wait >= 0 {
+			timer = time.After(wait)
+		}
+		select {
+		case <-stopCh:
+			return
+		case <-s.notify:
+		case <-timer:
+		}
+	}
+}
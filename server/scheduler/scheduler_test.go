@@ -0,0 +1,218 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package scheduler
+
+import (
+	"errors"
+	"golang.org/x/net/context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSchedulerRegister(t *testing.T) {
+	s := NewScheduler()
+	noop := func(ctx context.Context) error { return nil }
+	if s.Register(nil) == nil {
+		t.Fatalf("register nil job should fail")
+	}
+	if s.Register(&Job{Name: "a", Func: noop}) == nil {
+		t.Fatalf("register job without interval should fail")
+	}
+	if s.Register(&Job{Interval: time.Second, Func: noop}) == nil {
+		t.Fatalf("register job without name should fail")
+	}
+	if err := s.Register(&Job{Name: "b", Interval: time.Second, Func: noop}); err != nil {
+		t.Fatalf("register job failed, %v", err)
+	}
+	if err := s.Register(&Job{Name: "a", Interval: time.Minute, Func: noop}); err != nil {
+		t.Fatalf("register job failed, %v", err)
+	}
+	l := s.Status()
+	if len(l) != 2 || l[0].Name != "a" || l[1].Name != "b" || l[0].Interval != 60 {
+		t.Fatalf("unexpected status %v", l)
+	}
+}
+
+func TestSchedulerPriority(t *testing.T) {
+	s := NewScheduler()
+	s.MaxConcurrency = 1
+	s.Jitter = 0
+
+	var (
+		mux   sync.Mutex
+		order []string
+	)
+	done := make(chan struct{}, 3)
+	newJob := func(name string, priority int) *Job {
+		return &Job{
+			Name:      name,
+			Priority:  priority,
+			Interval:  time.Hour,
+			Immediate: true,
+			Func: func(ctx context.Context) error {
+				mux.Lock()
+				order = append(order, name)
+				mux.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				done <- struct{}{}
+				return nil
+			},
+		}
+	}
+	s.Register(newJob("low", PRIORITY_LOW))
+	s.Register(newJob("high", PRIORITY_HIGH))
+	s.Register(newJob("normal", PRIORITY_NORMAL))
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go s.Run(stopCh)
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("jobs timed out, %v", order)
+		}
+	}
+	mux.Lock()
+	defer mux.Unlock()
+	if len(order) != 3 || order[0] != "high" || order[1] != "normal" || order[2] != "low" {
+		t.Fatalf("unexpected order %v", order)
+	}
+}
+
+func TestSchedulerStatus(t *testing.T) {
+	s := NewScheduler()
+	s.Jitter = 0
+
+	done := make(chan struct{}, 2)
+	s.Register(&Job{
+		Name:      "fail",
+		Interval:  time.Hour,
+		Immediate: true,
+		Func: func(ctx context.Context) error {
+			defer func() { done <- struct{}{} }()
+			return errors.New("failed")
+		},
+	})
+	s.Register(&Job{
+		Name:      "panic",
+		Interval:  time.Hour,
+		Immediate: true,
+		Func: func(ctx context.Context) error {
+			defer func() { done <- struct{}{} }()
+			panic("panic")
+		},
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go s.Run(stopCh)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("jobs timed out")
+		}
+	}
+	// 等待状态更新
+	var l []JobStatus
+	for i := 0; i < 100; i++ {
+		l = s.Status()
+		if l[0].Runs == 1 && l[1].Runs == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for _, st := range l {
+		if st.Runs != 1 || st.Failures != 1 || len(st.LastError) == 0 || st.Running {
+			t.Fatalf("unexpected status %v", st)
+		}
+		if st.NextRun < time.Now().Add(59*time.Minute).Unix() {
+			t.Fatalf("unexpected next run %v", st)
+		}
+	}
+}
+
+type fakeElector bool
+
+func (e fakeElector) IsLeader() bool { return bool(e) }
+
+func TestSchedulerSingleton(t *testing.T) {
+	s := NewScheduler()
+	s.Jitter = 0
+	s.Elector = fakeElector(false)
+
+	ran := make(chan string, 2)
+	newJob := func(name string, singleton bool) *Job {
+		return &Job{
+			Name:      name,
+			Interval:  time.Hour,
+			Immediate: true,
+			Singleton: singleton,
+			Func: func(ctx context.Context) error {
+				ran <- name
+				return nil
+			},
+		}
+	}
+	s.Register(newJob("singleton", true))
+	s.Register(newJob("local", false))
+
+	s.dispatch(context.Background(), time.Now())
+	select {
+	case name := <-ran:
+		if name != "local" {
+			t.Fatalf("singleton job should be skipped on the follower")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("local job timed out")
+	}
+	l := s.Status()
+	if !l[1].Singleton || l[1].Skips != 1 || l[1].NextRun < time.Now().Add(59*time.Minute).Unix() {
+		t.Fatalf("unexpected status %v", l[1])
+	}
+
+	s.Elector = fakeElector(true)
+	s.Register(newJob("singleton", true))
+	s.dispatch(context.Background(), time.Now())
+	select {
+	case name := <-ran:
+		if name != "singleton" {
+			t.Fatalf("unexpected job %s", name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("singleton job should run on the leader")
+	}
+}
+
+func TestSchedulerJitter(t *testing.T) {
+	s := NewScheduler()
+	s.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := s.jitter(time.Minute)
+		if d < 30*time.Second || d > 90*time.Second {
+			t.Fatalf("jitter %v out of range", d)
+		}
+	}
+	s.Jitter = 0
+	if s.jitter(time.Minute) != time.Minute {
+		t.Fatalf("jitter should be disabled")
+	}
+}
@@ -20,14 +20,28 @@ import _ "github.com/apache/incubator-servicecomb-service-center/server/service/
 import (
 	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/alarm"
+	"github.com/apache/incubator-servicecomb-service-center/server/cmdb"
 	"github.com/apache/incubator-servicecomb-service-center/server/core"
 	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
 	st "github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/govern"
+	"github.com/apache/incubator-servicecomb-service-center/server/heartbeat"
+	"github.com/apache/incubator-servicecomb-service-center/server/metering"
+	"github.com/apache/incubator-servicecomb-service-center/server/metric"
+	"github.com/apache/incubator-servicecomb-service-center/server/migration"
 	"github.com/apache/incubator-servicecomb-service-center/server/mux"
+	"github.com/apache/incubator-servicecomb-service-center/server/scheduler"
 	nf "github.com/apache/incubator-servicecomb-service-center/server/service/notification"
+	"github.com/apache/incubator-servicecomb-service-center/server/service/sla"
+	"github.com/apache/incubator-servicecomb-service-center/server/service/uplink"
 	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	sctls "github.com/apache/incubator-servicecomb-service-center/server/tls"
 	"github.com/apache/incubator-servicecomb-service-center/version"
 	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"net"
 	"os"
 	"strings"
 	"time"
@@ -58,6 +72,41 @@ func (s *ServiceCenterServer) Run() {
 
 	s.startNotifyService()
 
+	sla.Run()
+
+	uplink.Run()
+
+	alarm.Run()
+
+	cmdb.Run()
+
+	metric.Run()
+
+	metering.Run()
+
+	sctls.Run()
+
+	scerr.RunBundleLoader()
+
+	heartbeat.Run()
+	heartbeat.RunProber()
+
+	serviceUtil.RunServiceCleanup()
+	serviceUtil.RunDependencyNormalize()
+	serviceUtil.RunDependencyGC()
+	serviceUtil.RunDependencyWriter()
+	serviceUtil.RunRetirementReport()
+	serviceUtil.RunPendingActivation()
+	serviceUtil.RunTopologyReport()
+	serviceUtil.RunRevisionTimeline()
+	serviceUtil.RunDependencyTrend()
+	serviceUtil.RunInstanceHistory()
+	serviceUtil.RunSchemaCompliance()
+	govern.RunLineageExport()
+	nf.RunSubscriptionRecorder()
+	s.startJobElection()
+	scheduler.Run()
+
 	s.startApiServer()
 
 	s.waitForQuit()
@@ -94,7 +143,8 @@ func (s *ServiceCenterServer) needUpgrade() bool {
 }
 
 func (s *ServiceCenterServer) waitForReady() {
-	lock, err := mux.Lock(mux.GLOBAL_LOCK)
+	// 其他节点升级或迁移的时间不确定，一直等待
+	lock, err := mux.LockWithTimeout(mux.GLOBAL_LOCK, 0)
 	if err != nil {
 		util.Logger().Errorf(err, "wait for server ready failed")
 		os.Exit(1)
@@ -102,17 +152,52 @@ func (s *ServiceCenterServer) waitForReady() {
 	if s.needUpgrade() {
 		core.UpgradeServerVersion()
 	}
+	s.migrate()
 	lock.Unlock()
 
 	s.store.Run()
 	<-s.store.Ready()
 }
 
+// migrate 在缓存加载前执行未完成的数据迁移，由全局锁保证只有一个实例执行，失败时不阻止启动
+func (s *ServiceCenterServer) migrate() {
+	dryRun := core.ServerInfo.Config.MigrationDryRun
+	result, err := migration.Run(context.Background(), dryRun)
+	if err != nil {
+		util.Logger().Errorf(err, "data migration failed, retry it at the next startup or by the admin api")
+		return
+	}
+	if dryRun {
+		for _, record := range result.Applied {
+			util.Logger().Warnf(nil, "pending data migration %d, %d key(s) will be changed: %s",
+				record.Version, record.Changed, record.Description)
+		}
+	}
+}
+
+// startJobElection 多节点部署时单例后台任务只在leader节点执行，ttl为0时每个节点都执行
+func (s *ServiceCenterServer) startJobElection() {
+	ttl := beego.AppConfig.DefaultInt64("leader_election_ttl", mux.DEFAULT_LEADER_TTL)
+	if ttl <= 0 {
+		return
+	}
+	election := mux.NewElection(mux.JOB_LEADER, ttl)
+	scheduler.SetElector(election)
+	util.Go(election.Run)
+}
+
 func (s *ServiceCenterServer) startNotifyService() {
+	compactWindow, _ := time.ParseDuration(core.ServerInfo.Config.WatchCompactWindow)
 	s.notifyService.Config = nf.NotifyServiceConfig{
 		AddTimeout:    30 * time.Second,
 		NotifyTimeout: 30 * time.Second,
 		MaxQueue:      100,
+
+		MaxSubscribers:           core.ServerInfo.Config.WatchMaxSubscribers,
+		MaxSubscribersPerSubject: core.ServerInfo.Config.WatchMaxSubscribersPerTenant,
+		CompactWindow:            compactWindow,
+		HistorySize:              int(core.ServerInfo.Config.WatchHistorySize),
+		HistoryStart:             st.Store().Instance().Cache().Version(),
 	}
 	s.notifyService.Start()
 }
@@ -123,7 +208,7 @@ func (s *ServiceCenterServer) startApiServer() {
 	rpcIp := beego.AppConfig.DefaultString("rpcaddr", "")
 	rpcPort := beego.AppConfig.DefaultString("rpcport", "")
 	cmpName := core.ServerInfo.Config.LoggerName
-	hostName := fmt.Sprintf("%s_%s", cmpName, strings.Replace(util.GetLocalIP(), ".", "_", -1))
+	hostName := fmt.Sprintf("%s_%s", cmpName, strings.NewReplacer(".", "_", ":", "_").Replace(util.GetLocalIP()))
 
 	s.apiServer.HostName = hostName
 	s.addEndpoint(REST, restIp, restPort)
@@ -138,7 +223,8 @@ func (s *ServiceCenterServer) addEndpoint(t APIType, ip, port string) {
 	if len(ip) == 0 {
 		return
 	}
-	address := util.StringJoin([]string{ip, port}, ":")
+	// IPv6地址需要加中括号
+	address := net.JoinHostPort(strings.Trim(ip, "[]"), port)
 	if core.ServerInfo.Config.SslEnabled {
 		address += "?sslEnabled=true"
 	}
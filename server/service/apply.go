@@ -0,0 +1,426 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/mux"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"reflect"
+	"sort"
+)
+
+const (
+	APPLY_KIND_SERVICE    = "service"
+	APPLY_KIND_SCHEMA     = "schema"
+	APPLY_KIND_TAG        = "tag"
+	APPLY_KIND_RULE       = "rule"
+	APPLY_KIND_DEPENDENCY = "dependency"
+
+	APPLY_ACTION_CREATE = "create"
+	APPLY_ACTION_UPDATE = "update"
+	APPLY_ACTION_DELETE = "delete"
+)
+
+// applyPlan 服务定义与当前状态的差异
+type applyPlan struct {
+	serviceId   string
+	create      bool
+	properties  bool
+	schemas     bool
+	addTags     map[string]string
+	deleteTags  []string
+	addRules    []*pb.AddOrUpdateServiceRule
+	updateRules map[string]*pb.AddOrUpdateServiceRule
+	deleteRules []string
+	providers   bool
+	changes     []*pb.ApplyChange
+}
+
+func (p *applyPlan) record(kind, action, name string) {
+	p.changes = append(p.changes, &pb.ApplyChange{Kind: kind, Action: action, Name: name})
+}
+
+// Apply 按服务定义(服务、schemas、tags、黑白名单、依赖)注册或更新微服务，
+// 只提交与当前状态有差异的部分，未声明的部分(为nil)保持不变
+func (s *MicroServiceService) Apply(ctx context.Context, in *pb.ApplyServiceRequest) (*pb.ApplyServiceResponse, error) {
+	if in == nil || in.Service == nil {
+		util.Logger().Errorf(nil, "apply microservice failed: invalid params.")
+		return &pb.ApplyServiceResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+
+	service := in.Service
+	serviceFlag := util.StringJoin([]string{service.AppId, service.ServiceName, service.Version}, "/")
+	domainProject := util.ParseDomainProject(ctx)
+
+	if in.Schemas != nil {
+		service.Schemas = make([]string, 0, len(in.Schemas))
+		for _, schema := range in.Schemas {
+			service.Schemas = append(service.Schemas, schema.SchemaId)
+		}
+	}
+	serviceUtil.SetServiceDefaultValue(service)
+	if err := apt.Validate(service); err != nil {
+		util.Logger().Errorf(err, "apply microservice failed, %s: invalid parameters.", serviceFlag)
+		return &pb.ApplyServiceResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("service", err)...),
+		}, nil
+	}
+
+	plan, respErr := s.planApply(ctx, domainProject, in)
+	if respErr != nil {
+		util.Logger().Errorf(respErr, "apply microservice failed, %s: diff with current state failed.", serviceFlag)
+		return &pb.ApplyServiceResponse{
+			Response: pb.CreateResponse(respErr.Code, respErr.Detail),
+		}, respErr
+	}
+
+	resp := &pb.ApplyServiceResponse{
+		ServiceId: plan.serviceId,
+		Changes:   plan.changes,
+	}
+	if in.DryRun || len(plan.changes) == 0 {
+		resp.Response = pb.CreateResponse(pb.Response_SUCCESS, "Diff microservice successfully.")
+		return resp, nil
+	}
+
+	r, err := s.executeApply(ctx, domainProject, in, plan)
+	resp.ServiceId = plan.serviceId
+	if r.Code != pb.Response_SUCCESS {
+		util.Logger().Errorf(err, "apply microservice failed, %s: %s", serviceFlag, r.Message)
+		resp.Response = r
+		return resp, err
+	}
+
+	util.Logger().Infof("apply microservice successfully, %s, serviceId %s, %d change(s). operator: %s",
+		serviceFlag, plan.serviceId, len(plan.changes), util.GetIPFromContext(ctx))
+	resp.Response = pb.CreateResponse(pb.Response_SUCCESS, "Apply microservice successfully.")
+	return resp, nil
+}
+
+func (s *MicroServiceService) planApply(ctx context.Context, domainProject string, in *pb.ApplyServiceRequest) (*applyPlan, *scerr.Error) {
+	service := in.Service
+	plan := &applyPlan{updateRules: make(map[string]*pb.AddOrUpdateServiceRule)}
+
+	serviceId, err := serviceUtil.GetServiceId(ctx, &pb.MicroServiceKey{
+		Tenant:      domainProject,
+		Environment: service.Environment,
+		AppId:       service.AppId,
+		ServiceName: service.ServiceName,
+		Alias:       service.Alias,
+		Version:     service.Version,
+	})
+	if err != nil {
+		return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+	}
+
+	var (
+		current       *pb.MicroService
+		schemas       []*pb.Schema
+		tags          map[string]string
+		rules         []*pb.ServiceRule
+		providerRules []*pb.MicroServiceKey
+	)
+	if len(serviceId) > 0 {
+		current, err = serviceUtil.GetService(ctx, domainProject, serviceId)
+		if err != nil {
+			return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+		}
+	}
+	if current == nil {
+		plan.create = true
+		plan.record(APPLY_KIND_SERVICE, APPLY_ACTION_CREATE, util.StringJoin([]string{
+			service.AppId, service.ServiceName, service.Version}, "/"))
+	} else {
+		plan.serviceId = current.ServiceId
+		if service.Properties != nil && !equalStringMap(service.Properties, current.Properties) {
+			plan.properties = true
+			plan.record(APPLY_KIND_SERVICE, APPLY_ACTION_UPDATE, "properties")
+		}
+
+		if in.Schemas != nil {
+			resp, err := s.GetAllSchemaInfo(ctx, &pb.GetAllSchemaRequest{
+				ServiceId:         current.ServiceId,
+				WithSchema:        true,
+				ConsumerServiceId: current.ServiceId,
+			})
+			if err != nil {
+				return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+			}
+			schemas = resp.Schema
+		}
+		if in.Tags != nil {
+			tags, err = serviceUtil.GetTagsUtils(ctx, domainProject, current.ServiceId)
+			if err != nil {
+				return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+			}
+		}
+		if in.Rules != nil {
+			rules, err = serviceUtil.GetRulesUtil(ctx, domainProject, current.ServiceId)
+			if err != nil {
+				return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+			}
+		}
+		if in.Providers != nil {
+			dep, err := serviceUtil.TransferToMicroServiceDependency(ctx,
+				apt.GenerateConsumerDependencyRuleKey(domainProject, pb.MicroServiceToKey(domainProject, current)))
+			if err != nil {
+				return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+			}
+			providerRules = dep.Dependency
+		}
+	}
+
+	if in.Schemas != nil {
+		plan.diffSchemas(in.Schemas, schemas)
+	}
+	if in.Tags != nil {
+		plan.diffTags(in.Tags, tags)
+	}
+	if in.Rules != nil {
+		plan.diffRules(in.Rules, rules)
+	}
+	if in.Providers != nil {
+		plan.diffProviders(domainProject, in.Providers, providerRules)
+	}
+	return plan, nil
+}
+
+func (p *applyPlan) diffSchemas(expected, current []*pb.Schema) {
+	exist := make(map[string]*pb.Schema, len(current))
+	for _, schema := range current {
+		exist[schema.SchemaId] = schema
+	}
+	for _, schema := range expected {
+		old, ok := exist[schema.SchemaId]
+		switch {
+		case !ok:
+			p.record(APPLY_KIND_SCHEMA, APPLY_ACTION_CREATE, schema.SchemaId)
+		case old.Summary != schema.Summary || old.Schema != schema.Schema:
+			p.record(APPLY_KIND_SCHEMA, APPLY_ACTION_UPDATE, schema.SchemaId)
+		default:
+			delete(exist, schema.SchemaId)
+			continue
+		}
+		p.schemas = true
+		delete(exist, schema.SchemaId)
+	}
+	for _, schemaId := range sortedKeys(exist) {
+		p.schemas = true
+		p.record(APPLY_KIND_SCHEMA, APPLY_ACTION_DELETE, schemaId)
+	}
+}
+
+func (p *applyPlan) diffTags(expected, current map[string]string) {
+	p.addTags = make(map[string]string)
+	for _, key := range sortedKeys(expected) {
+		value, ok := current[key]
+		switch {
+		case !ok:
+			p.record(APPLY_KIND_TAG, APPLY_ACTION_CREATE, key)
+		case value != expected[key]:
+			p.record(APPLY_KIND_TAG, APPLY_ACTION_UPDATE, key)
+		default:
+			continue
+		}
+		p.addTags[key] = expected[key]
+	}
+	for _, key := range sortedKeys(current) {
+		if _, ok := expected[key]; ok {
+			continue
+		}
+		p.deleteTags = append(p.deleteTags, key)
+		p.record(APPLY_KIND_TAG, APPLY_ACTION_DELETE, key)
+	}
+}
+
+func (p *applyPlan) diffRules(expected []*pb.AddOrUpdateServiceRule, current []*pb.ServiceRule) {
+	exist := make(map[string]*pb.ServiceRule, len(current))
+	for _, rule := range current {
+		exist[ruleFlag(rule.RuleType, rule.Attribute, rule.Pattern)] = rule
+	}
+	for _, rule := range expected {
+		flag := ruleFlag(rule.RuleType, rule.Attribute, rule.Pattern)
+		old, ok := exist[flag]
+		switch {
+		case !ok:
+			p.addRules = append(p.addRules, rule)
+			p.record(APPLY_KIND_RULE, APPLY_ACTION_CREATE, flag)
+		case old.Description != rule.Description:
+			p.updateRules[old.RuleId] = rule
+			p.record(APPLY_KIND_RULE, APPLY_ACTION_UPDATE, flag)
+		}
+		delete(exist, flag)
+	}
+	for _, flag := range sortedKeys(exist) {
+		p.deleteRules = append(p.deleteRules, exist[flag].RuleId)
+		p.record(APPLY_KIND_RULE, APPLY_ACTION_DELETE, flag)
+	}
+}
+
+func (p *applyPlan) diffProviders(domainProject string, expected []*pb.DependencyKey, current []*pb.MicroServiceKey) {
+	exist := make(map[string]struct{}, len(current))
+	for _, provider := range current {
+		exist[providerFlag(provider.Environment, provider.AppId, provider.ServiceName, provider.Version)] = struct{}{}
+	}
+	for _, provider := range expected {
+		if len(provider.Environment) == 0 {
+			provider.Environment = pb.ENV_DEV
+		}
+		flag := providerFlag(provider.Environment, provider.AppId, provider.ServiceName, provider.Version)
+		if _, ok := exist[flag]; ok {
+			delete(exist, flag)
+			continue
+		}
+		p.providers = true
+		p.record(APPLY_KIND_DEPENDENCY, APPLY_ACTION_CREATE, flag)
+	}
+	for _, flag := range sortedKeys(exist) {
+		p.providers = true
+		p.record(APPLY_KIND_DEPENDENCY, APPLY_ACTION_DELETE, flag)
+	}
+}
+
+// executeApply 依次提交服务、schemas、tags、黑白名单和依赖的变更
+func (s *MicroServiceService) executeApply(ctx context.Context, domainProject string, in *pb.ApplyServiceRequest, plan *applyPlan) (*pb.Response, error) {
+	service := in.Service
+	if plan.create {
+		resp, err := s.Create(ctx, &pb.CreateServiceRequest{Service: service})
+		if resp.Response.Code != pb.Response_SUCCESS {
+			return resp.Response, err
+		}
+		plan.serviceId = resp.ServiceId
+	}
+	serviceId := plan.serviceId
+
+	if plan.properties {
+		resp, err := s.UpdateProperties(ctx, &pb.UpdateServicePropsRequest{
+			ServiceId:  serviceId,
+			Properties: service.Properties,
+		})
+		if resp.Response.Code != pb.Response_SUCCESS {
+			return resp.Response, err
+		}
+	}
+
+	if plan.schemas {
+		resp, err := s.ModifySchemas(ctx, &pb.ModifySchemasRequest{ServiceId: serviceId, Schemas: in.Schemas})
+		if resp.Response.Code != pb.Response_SUCCESS {
+			return resp.Response, err
+		}
+	}
+
+	if len(plan.deleteTags) > 0 {
+		resp, err := s.DeleteTags(ctx, &pb.DeleteServiceTagsRequest{ServiceId: serviceId, Keys: plan.deleteTags})
+		if resp.Response.Code != pb.Response_SUCCESS {
+			return resp.Response, err
+		}
+	}
+	if len(plan.addTags) > 0 {
+		resp, err := s.AddTags(ctx, &pb.AddServiceTagsRequest{ServiceId: serviceId, Tags: plan.addTags})
+		if resp.Response.Code != pb.Response_SUCCESS {
+			return resp.Response, err
+		}
+	}
+
+	if len(plan.deleteRules) > 0 {
+		resp, err := s.DeleteRule(ctx, &pb.DeleteServiceRulesRequest{ServiceId: serviceId, RuleIds: plan.deleteRules})
+		if resp.Response.Code != pb.Response_SUCCESS {
+			return resp.Response, err
+		}
+	}
+	for ruleId, rule := range plan.updateRules {
+		resp, err := s.UpdateRule(ctx, &pb.UpdateServiceRuleRequest{ServiceId: serviceId, RuleId: ruleId, Rule: rule})
+		if resp.Response.Code != pb.Response_SUCCESS {
+			return resp.Response, err
+		}
+	}
+	if len(plan.addRules) > 0 {
+		resp, err := s.AddRule(ctx, &pb.AddServiceRulesRequest{ServiceId: serviceId, Rules: plan.addRules})
+		if resp.Response.Code != pb.Response_SUCCESS {
+			return resp.Response, err
+		}
+	}
+
+	if plan.providers {
+		consumer := &pb.DependencyKey{
+			Environment: service.Environment,
+			AppId:       service.AppId,
+			ServiceName: service.ServiceName,
+			Version:     service.Version,
+		}
+		if len(in.Providers) == 0 {
+			return clearProviders(ctx, domainProject, serviceId, consumer)
+		}
+		resp, err := s.CreateDependenciesForMicroServices(ctx, &pb.CreateDependenciesRequest{
+			Dependencies: []*pb.ConsumerDependency{{Consumer: consumer, Providers: in.Providers}},
+		})
+		if resp.Response.Code != pb.Response_SUCCESS {
+			return resp.Response, err
+		}
+	}
+	return pb.CreateResponse(pb.Response_SUCCESS, "Apply microservice successfully."), nil
+}
+
+// clearProviders 删除消费者的所有依赖规则，依赖接口不允许提交空的providers
+func clearProviders(ctx context.Context, domainProject, consumerId string, consumer *pb.DependencyKey) (*pb.Response, error) {
+	lock, err := mux.Lock(mux.GLOBAL_LOCK)
+	if err != nil {
+		return pb.CreateResponse(mux.ErrorCode(err), err.Error()), err
+	}
+	err = serviceUtil.CreateDependencyRule(ctx, &serviceUtil.Dependency{
+		DomainProject: domainProject,
+		Consumer:      pb.DependenciesToKeys([]*pb.DependencyKey{consumer}, domainProject)[0],
+		ProvidersRule: []*pb.MicroServiceKey{},
+		ConsumerId:    consumerId,
+	})
+	lock.Unlock()
+	if err != nil {
+		return pb.CreateResponse(scerr.ErrInternal, err.Error()), err
+	}
+	return pb.CreateResponse(pb.Response_SUCCESS, "Apply microservice successfully."), nil
+}
+
+func ruleFlag(ruleType, attribute, pattern string) string {
+	return util.StringJoin([]string{ruleType, attribute, pattern}, "/")
+}
+
+func providerFlag(env, appId, serviceName, version string) string {
+	return util.StringJoin([]string{env, appId, serviceName, version}, "/")
+}
+
+func equalStringMap(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func sortedKeys(m interface{}) []string {
+	keys := make([]string, 0)
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
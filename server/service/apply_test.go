@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service_test
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/service"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func newApplyRequest() *pb.ApplyServiceRequest {
+	return &pb.ApplyServiceRequest{
+		Service: &pb.MicroService{
+			AppId:       "apply_group",
+			ServiceName: "apply_service",
+			Version:     "1.0.0",
+			Level:       "FRONT",
+			Status:      pb.MS_UP,
+			Properties:  map[string]string{"a": "1"},
+		},
+		Schemas: []*pb.Schema{
+			{SchemaId: "apply_schema", Summary: "s1", Schema: "schema content"},
+		},
+		Tags: map[string]string{"t1": "v1"},
+		Rules: []*pb.AddOrUpdateServiceRule{
+			{RuleType: "BLACK", Attribute: "ServiceName", Pattern: "apply_black", Description: "d1"},
+		},
+		Providers: []*pb.DependencyKey{
+			{AppId: "apply_group", ServiceName: "apply_provider", Version: "1.0.0+"},
+		},
+	}
+}
+
+func countChanges(changes []*pb.ApplyChange, kind, action string) int {
+	n := 0
+	for _, c := range changes {
+		if c.Kind == kind && c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+var _ = Describe("'Apply' service", func() {
+	Describe("execute 'apply' operartion", func() {
+		var (
+			serviceId string
+		)
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				By("service is nil")
+				resp, _ := serviceResource.Apply(getContext(), &pb.ApplyServiceRequest{})
+				Expect(resp.Response.Code).ToNot(Equal(pb.Response_SUCCESS))
+
+				By("service name is empty")
+				resp, _ = serviceResource.Apply(getContext(), &pb.ApplyServiceRequest{
+					Service: &pb.MicroService{
+						AppId:   "apply_group",
+						Version: "1.0.0",
+					},
+				})
+				Expect(resp.Response.Code).ToNot(Equal(pb.Response_SUCCESS))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				By("dry run does not create the service")
+				req := newApplyRequest()
+				req.DryRun = true
+				resp, err := serviceResource.Apply(getContext(), req)
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.ServiceId).To(BeEmpty())
+				Expect(countChanges(resp.Changes, service.APPLY_KIND_SERVICE, service.APPLY_ACTION_CREATE)).To(Equal(1))
+
+				respExist, _ := serviceResource.Exist(getContext(), &pb.GetExistenceRequest{
+					Type:        "microservice",
+					AppId:       "apply_group",
+					ServiceName: "apply_service",
+					Version:     "1.0.0",
+				})
+				Expect(respExist.Response.Code).ToNot(Equal(pb.Response_SUCCESS))
+
+				By("create all")
+				resp, err = serviceResource.Apply(getContext(), newApplyRequest())
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.ServiceId).ToNot(BeEmpty())
+				Expect(countChanges(resp.Changes, service.APPLY_KIND_SCHEMA, service.APPLY_ACTION_CREATE)).To(Equal(1))
+				Expect(countChanges(resp.Changes, service.APPLY_KIND_TAG, service.APPLY_ACTION_CREATE)).To(Equal(1))
+				Expect(countChanges(resp.Changes, service.APPLY_KIND_RULE, service.APPLY_ACTION_CREATE)).To(Equal(1))
+				Expect(countChanges(resp.Changes, service.APPLY_KIND_DEPENDENCY, service.APPLY_ACTION_CREATE)).To(Equal(1))
+				serviceId = resp.ServiceId
+
+				respTags, _ := serviceResource.GetTags(getContext(), &pb.GetServiceTagsRequest{ServiceId: serviceId})
+				Expect(respTags.Tags["t1"]).To(Equal("v1"))
+				respRules, _ := serviceResource.GetRule(getContext(), &pb.GetServiceRulesRequest{ServiceId: serviceId})
+				Expect(len(respRules.Rules)).To(Equal(1))
+				respSchema, _ := serviceResource.GetSchemaInfo(getContext(), &pb.GetSchemaRequest{
+					ServiceId: serviceId,
+					SchemaId:  "apply_schema",
+				})
+				Expect(respSchema.Schema).To(Equal("schema content"))
+
+				By("apply the same manifest again")
+				resp, err = serviceResource.Apply(getContext(), newApplyRequest())
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.ServiceId).To(Equal(serviceId))
+				Expect(len(resp.Changes)).To(Equal(0))
+
+				By("dry run the changes")
+				req = newApplyRequest()
+				req.DryRun = true
+				req.Service.Properties = map[string]string{"a": "2"}
+				req.Schemas[0].Summary = "s2"
+				req.Schemas[0].Schema = "schema content 2"
+				req.Tags = map[string]string{"t2": "v2"}
+				req.Rules[0].Description = "d2"
+				req.Providers = []*pb.DependencyKey{}
+				resp, err = serviceResource.Apply(getContext(), req)
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(countChanges(resp.Changes, service.APPLY_KIND_SERVICE, service.APPLY_ACTION_UPDATE)).To(Equal(1))
+				Expect(countChanges(resp.Changes, service.APPLY_KIND_SCHEMA, service.APPLY_ACTION_UPDATE)).To(Equal(1))
+				Expect(countChanges(resp.Changes, service.APPLY_KIND_TAG, service.APPLY_ACTION_CREATE)).To(Equal(1))
+				Expect(countChanges(resp.Changes, service.APPLY_KIND_TAG, service.APPLY_ACTION_DELETE)).To(Equal(1))
+				Expect(countChanges(resp.Changes, service.APPLY_KIND_RULE, service.APPLY_ACTION_UPDATE)).To(Equal(1))
+				Expect(countChanges(resp.Changes, service.APPLY_KIND_DEPENDENCY, service.APPLY_ACTION_DELETE)).To(Equal(1))
+
+				respTags, _ = serviceResource.GetTags(getContext(), &pb.GetServiceTagsRequest{ServiceId: serviceId})
+				Expect(respTags.Tags["t1"]).To(Equal("v1"))
+
+				By("apply the changes")
+				req.DryRun = false
+				resp, err = serviceResource.Apply(getContext(), req)
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respTags, _ = serviceResource.GetTags(getContext(), &pb.GetServiceTagsRequest{ServiceId: serviceId})
+				Expect(len(respTags.Tags)).To(Equal(1))
+				Expect(respTags.Tags["t2"]).To(Equal("v2"))
+				respRules, _ = serviceResource.GetRule(getContext(), &pb.GetServiceRulesRequest{ServiceId: serviceId})
+				Expect(len(respRules.Rules)).To(Equal(1))
+				Expect(respRules.Rules[0].Description).To(Equal("d2"))
+				respService, _ := serviceResource.GetOne(getContext(), &pb.GetServiceRequest{ServiceId: serviceId})
+				Expect(respService.Service.Properties["a"]).To(Equal("2"))
+
+				By("apply again after changes")
+				req.Service.Properties = map[string]string{"a": "2"}
+				resp, err = serviceResource.Apply(getContext(), req)
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Changes)).To(Equal(0))
+
+				By("undeclared parts are kept")
+				resp, err = serviceResource.Apply(getContext(), &pb.ApplyServiceRequest{
+					Service: &pb.MicroService{
+						AppId:       "apply_group",
+						ServiceName: "apply_service",
+						Version:     "1.0.0",
+						Level:       "FRONT",
+						Status:      pb.MS_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Changes)).To(Equal(0))
+			})
+		})
+	})
+})
@@ -0,0 +1,175 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+)
+
+func (s *MicroServiceService) ApproveDependency(ctx context.Context, in *pb.ApproveDependencyRequest) (*pb.ApproveDependencyResponse, error) {
+	if in == nil || len(in.ProviderServiceId) == 0 || len(in.ConsumerServiceId) == 0 {
+		util.Logger().Errorf(nil, "approve dependency failed: invalid params.")
+		return &pb.ApproveDependencyResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	conPro := util.StringJoin([]string{in.ConsumerServiceId, in.ProviderServiceId}, "/")
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "approve dependency failed, %s(consumer/provider): invalid parameters.", conPro)
+		return &pb.ApproveDependencyResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ProviderServiceId) {
+		util.Logger().Errorf(nil, "approve dependency failed, %s(consumer/provider): provider not exist.", conPro)
+		return &pb.ApproveDependencyResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Provider does not exist."),
+		}, nil
+	}
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ConsumerServiceId) {
+		util.Logger().Errorf(nil, "approve dependency failed, %s(consumer/provider): consumer not exist.", conPro)
+		return &pb.ApproveDependencyResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Consumer does not exist."),
+		}, nil
+	}
+
+	err = serviceUtil.PutDependencyApproval(ctx, domainProject, in.ProviderServiceId, in.ConsumerServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "approve dependency failed, %s(consumer/provider): commit approval into etcd failed.", conPro)
+		return &pb.ApproveDependencyResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, "Commit operations failed."),
+		}, err
+	}
+
+	util.Logger().Infof("approve dependency successful, %s(consumer/provider), operator %s.",
+		conPro, util.GetIPFromContext(ctx))
+	return &pb.ApproveDependencyResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Approve dependency successfully."),
+	}, nil
+}
+
+func (s *MicroServiceService) RevokeDependencyApproval(ctx context.Context, in *pb.RevokeDependencyApprovalRequest) (*pb.RevokeDependencyApprovalResponse, error) {
+	if in == nil || len(in.ProviderServiceId) == 0 || len(in.ConsumerServiceId) == 0 {
+		util.Logger().Errorf(nil, "revoke dependency approval failed: invalid params.")
+		return &pb.RevokeDependencyApprovalResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	conPro := util.StringJoin([]string{in.ConsumerServiceId, in.ProviderServiceId}, "/")
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "revoke dependency approval failed, %s(consumer/provider): invalid parameters.", conPro)
+		return &pb.RevokeDependencyApprovalResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ProviderServiceId) {
+		util.Logger().Errorf(nil, "revoke dependency approval failed, %s(consumer/provider): provider not exist.", conPro)
+		return &pb.RevokeDependencyApprovalResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Provider does not exist."),
+		}, nil
+	}
+
+	approval, err := serviceUtil.GetDependencyApproval(ctx, domainProject, in.ProviderServiceId, in.ConsumerServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "revoke dependency approval failed, %s(consumer/provider): get approval failed.", conPro)
+		return &pb.RevokeDependencyApprovalResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, "Get dependency approval failed."),
+		}, err
+	}
+	if approval == nil {
+		util.Logger().Errorf(nil, "revoke dependency approval failed, %s(consumer/provider): approval not exist.", conPro)
+		return &pb.RevokeDependencyApprovalResponse{
+			Response: pb.CreateResponse(scerr.ErrApprovalNotExists, "Dependency approval does not exist."),
+		}, nil
+	}
+
+	_, err = backend.Registry().Do(ctx,
+		registry.DEL,
+		registry.WithStrKey(apt.GenerateDependencyApprovalKey(domainProject, in.ProviderServiceId, in.ConsumerServiceId)))
+	if err != nil {
+		util.Logger().Errorf(err, "revoke dependency approval failed, %s(consumer/provider): commit operations failed.", conPro)
+		return &pb.RevokeDependencyApprovalResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, "Commit operations failed."),
+		}, err
+	}
+
+	util.Logger().Infof("revoke dependency approval successful, %s(consumer/provider), operator %s.",
+		conPro, util.GetIPFromContext(ctx))
+	return &pb.RevokeDependencyApprovalResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Revoke dependency approval successfully."),
+	}, nil
+}
+
+func (s *MicroServiceService) GetDependencyApprovals(ctx context.Context, in *pb.GetDependencyApprovalsRequest) (*pb.GetDependencyApprovalsResponse, error) {
+	if in == nil || len(in.ProviderServiceId) == 0 {
+		util.Logger().Errorf(nil, "get dependency approvals failed: invalid params.")
+		return &pb.GetDependencyApprovalsResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "get dependency approvals failed, provider %s: invalid parameters.", in.ProviderServiceId)
+		return &pb.GetDependencyApprovalsResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	provider, err := serviceUtil.GetService(ctx, domainProject, in.ProviderServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "get dependency approvals failed, provider %s: get provider failed.", in.ProviderServiceId)
+		return &pb.GetDependencyApprovalsResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if provider == nil {
+		util.Logger().Errorf(nil, "get dependency approvals failed, provider %s: provider not exist.", in.ProviderServiceId)
+		return &pb.GetDependencyApprovalsResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Provider does not exist."),
+		}, nil
+	}
+
+	approvals, err := serviceUtil.GetDependencyApprovals(ctx, domainProject, provider)
+	if err != nil {
+		util.Logger().Errorf(err, "get dependency approvals failed, provider %s: get approvals failed.", in.ProviderServiceId)
+		return &pb.GetDependencyApprovalsResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	return &pb.GetDependencyApprovalsResponse{
+		Response:  pb.CreateResponse(pb.Response_SUCCESS, "Get dependency approvals successfully."),
+		Approvals: approvals,
+	}, nil
+}
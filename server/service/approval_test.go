@@ -0,0 +1,181 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service_test
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("'DependencyApproval' service", func() {
+	Describe("execute 'approve' and 'revoke' operartion", func() {
+		var (
+			consumerId string
+			providerId string
+		)
+
+		It("should be passed", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "approval_group",
+					ServiceName: "approval_consumer",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			consumerId = respCreateService.ServiceId
+
+			respCreateService, err = serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "approval_group",
+					ServiceName: "approval_provider",
+					Version:     "1.0.0",
+					Level:       "BACK",
+					Status:      pb.MS_UP,
+					Properties: map[string]string{
+						pb.PROP_REQUIRE_APPROVAL: "true",
+					},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			providerId = respCreateService.ServiceId
+
+			resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+				Instance: &pb.MicroServiceInstance{
+					ServiceId: providerId,
+					Endpoints: []string{
+						"approval:127.0.0.1:8080",
+					},
+					HostName: "UT-HOST",
+					Status:   pb.MSI_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				By("provider id is empty")
+				resp, _ := serviceResource.ApproveDependency(getContext(), &pb.ApproveDependencyRequest{
+					ConsumerServiceId: consumerId,
+				})
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("provider does not exist")
+				resp, _ = serviceResource.ApproveDependency(getContext(), &pb.ApproveDependencyRequest{
+					ProviderServiceId: "noServiceTest",
+					ConsumerServiceId: consumerId,
+				})
+				Expect(resp.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+
+				By("consumer does not exist")
+				resp, _ = serviceResource.ApproveDependency(getContext(), &pb.ApproveDependencyRequest{
+					ProviderServiceId: providerId,
+					ConsumerServiceId: "noServiceTest",
+				})
+				Expect(resp.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+
+				By("approval does not exist")
+				respRevoke, _ := serviceResource.RevokeDependencyApproval(getContext(), &pb.RevokeDependencyApprovalRequest{
+					ProviderServiceId: providerId,
+					ConsumerServiceId: consumerId,
+				})
+				Expect(respRevoke.Response.Code).To(Equal(scerr.ErrApprovalNotExists))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				find := func() []*pb.MicroServiceInstance {
+					respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+						ConsumerServiceId: consumerId,
+						AppId:             "approval_group",
+						ServiceName:       "approval_provider",
+						VersionRule:       "latest",
+					})
+					Expect(err).To(BeNil())
+					Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+					return respFind.Instances
+				}
+				status := func() string {
+					respGet, err := serviceResource.GetDependencyApprovals(getContext(), &pb.GetDependencyApprovalsRequest{
+						ProviderServiceId: providerId,
+					})
+					Expect(err).To(BeNil())
+					Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+					Expect(len(respGet.Approvals)).To(Equal(1))
+					Expect(respGet.Approvals[0].ConsumerServiceId).To(Equal(consumerId))
+					return respGet.Approvals[0].Status
+				}
+
+				By("pending consumer is denied")
+				Expect(len(find())).To(Equal(0))
+				Expect(status()).To(Equal(pb.APPROVAL_PENDING))
+
+				respGetOne, err := instanceResource.GetInstances(getContext(), &pb.GetInstancesRequest{
+					ConsumerServiceId: consumerId,
+					ProviderServiceId: providerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGetOne.Response.Code).To(Equal(scerr.ErrPermissionDeny))
+
+				By("approved consumer is allowed")
+				resp, err := serviceResource.ApproveDependency(getContext(), &pb.ApproveDependencyRequest{
+					ProviderServiceId: providerId,
+					ConsumerServiceId: consumerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(find())).To(Equal(1))
+				Expect(status()).To(Equal(pb.APPROVAL_APPROVED))
+
+				By("revoked consumer is denied again")
+				respRevoke, err := serviceResource.RevokeDependencyApproval(getContext(), &pb.RevokeDependencyApprovalRequest{
+					ProviderServiceId: providerId,
+					ConsumerServiceId: consumerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respRevoke.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(find())).To(Equal(0))
+				Expect(status()).To(Equal(pb.APPROVAL_PENDING))
+			})
+		})
+
+		It("clean", func() {
+			resp, err := serviceResource.Delete(getContext(), &pb.DeleteServiceRequest{
+				ServiceId: providerId,
+				Force:     true,
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			resp, err = serviceResource.Delete(getContext(), &pb.DeleteServiceRequest{
+				ServiceId: consumerId,
+				Force:     true,
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+		})
+	})
+})
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/uuid"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	nf "github.com/apache/incubator-servicecomb-service-center/server/service/notification"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"strconv"
+	"time"
+)
+
+// Broadcast 按依赖关系向提供者当前所有有权限访问的消费者推送通知，通知不持久化，
+// 只送达在线的watch连接，开启broadcast_webhook_enabled时同时推送到消费者的webhook
+func (s *MicroServiceService) Broadcast(ctx context.Context, in *pb.BroadcastRequest) (*pb.BroadcastResponse, error) {
+	if in == nil || len(in.ServiceId) == 0 || in.Message == nil {
+		util.Logger().Errorf(nil, "broadcast failed: invalid params.")
+		return &pb.BroadcastResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "broadcast failed, serviceId %s: invalid parameters.", in.ServiceId)
+		return &pb.BroadcastResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	provider, err := serviceUtil.GetService(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "broadcast failed, serviceId %s: get service failed.", in.ServiceId)
+		return &pb.BroadcastResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if provider == nil {
+		util.Logger().Errorf(nil, "broadcast failed, serviceId %s: service not exist.", in.ServiceId)
+		return &pb.BroadcastResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+
+	consumerIds, _, err := serviceUtil.GetConsumerIds(ctx, domainProject, provider)
+	if err != nil {
+		util.Logger().Errorf(err, "broadcast failed, serviceId %s: query consumers failed.", in.ServiceId)
+		return &pb.BroadcastResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	// 使用当前revision推送，保证不会被watcher当作快照之前的事件丢弃
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateServiceKey(domainProject, in.ServiceId)),
+		registry.WithCountOnly())
+	if err != nil {
+		util.Logger().Errorf(err, "broadcast failed, serviceId %s: get revision failed.", in.ServiceId)
+		return &pb.BroadcastResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, err.Error()),
+		}, err
+	}
+
+	message := *in.Message
+	message.Id = uuid.GenerateUuid()
+	message.Timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+	key := &pb.MicroServiceKey{
+		Environment: provider.Environment,
+		AppId:       provider.AppId,
+		ServiceName: provider.ServiceName,
+		Version:     provider.Version,
+	}
+
+	nf.PublishBroadcastEvent(domainProject, key, &message, resp.Revision, consumerIds)
+	if apt.ServerInfo.Config.BroadcastWebhookEnabled {
+		serviceUtil.PostBroadcastWebhooks(context.Background(), domainProject, key, &message, consumerIds)
+	}
+
+	util.Logger().Infof("broadcast %s[%s] of service %s to %d consumer(s) successfully, operator %s.",
+		message.Id, message.Type, in.ServiceId, len(consumerIds), util.GetIPFromContext(ctx))
+	return &pb.BroadcastResponse{
+		Response:   pb.CreateResponse(pb.Response_SUCCESS, "Broadcast successfully."),
+		Id:         message.Id,
+		Recipients: int32(len(consumerIds)),
+	}, nil
+}
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service_test
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("'Broadcast' service", func() {
+	Describe("execute 'broadcast' operartion", func() {
+		var (
+			serviceId string
+		)
+
+		It("should be passed", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "broadcast_group",
+					ServiceName: "broadcast_provider",
+					Version:     "1.0.0",
+					Level:       "BACK",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreateService.ServiceId
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				By("message is nil")
+				resp, _ := serviceResource.Broadcast(getContext(), &pb.BroadcastRequest{
+					ServiceId: serviceId,
+				})
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("message type is empty")
+				resp, _ = serviceResource.Broadcast(getContext(), &pb.BroadcastRequest{
+					ServiceId: serviceId,
+					Message:   &pb.BroadcastMessage{Content: "deprecated"},
+				})
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("service does not exist")
+				resp, _ = serviceResource.Broadcast(getContext(), &pb.BroadcastRequest{
+					ServiceId: "noServiceTest",
+					Message:   &pb.BroadcastMessage{Type: "deprecation", Content: "deprecated"},
+				})
+				Expect(resp.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				resp, err := serviceResource.Broadcast(getContext(), &pb.BroadcastRequest{
+					ServiceId: serviceId,
+					Message:   &pb.BroadcastMessage{Type: "deprecation", Content: "v1 will be removed"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Id)).ToNot(Equal(0))
+			})
+		})
+	})
+})
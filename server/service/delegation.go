@@ -0,0 +1,306 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"net/http"
+)
+
+// canManageDelegation 只有服务自身(请求中的consumerServiceId为该服务)或管理员可以授予、撤销代理
+func canManageDelegation(ctx context.Context, consumerServiceId, serviceId string) bool {
+	return util.ParseDomain(ctx) == apt.REGISTRY_DOMAIN || consumerServiceId == serviceId
+}
+
+func (s *MicroServiceService) GrantDelegation(ctx context.Context, in *pb.GrantDelegationRequest) (*pb.GrantDelegationResponse, error) {
+	if in == nil || len(in.ServiceId) == 0 || len(in.ControllerServiceId) == 0 {
+		util.Logger().Errorf(nil, "grant delegation failed: invalid params.")
+		return &pb.GrantDelegationResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	svcCtrl := util.StringJoin([]string{in.ServiceId, in.ControllerServiceId}, "/")
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "grant delegation failed, %s(service/controller): invalid parameters.", svcCtrl)
+		return &pb.GrantDelegationResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+	if !canManageDelegation(ctx, in.ConsumerServiceId, in.ServiceId) {
+		util.Logger().Errorf(nil, "grant delegation failed, %s(service/controller): consumer %s is not the service, operator %s.",
+			svcCtrl, in.ConsumerServiceId, util.GetIPFromContext(ctx))
+		return &pb.GrantDelegationResponse{
+			Response: pb.CreateResponse(scerr.ErrForbidden, "Only the service itself can grant the delegation."),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ServiceId) {
+		util.Logger().Errorf(nil, "grant delegation failed, %s(service/controller): service not exist.", svcCtrl)
+		return &pb.GrantDelegationResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ControllerServiceId) {
+		util.Logger().Errorf(nil, "grant delegation failed, %s(service/controller): controller not exist.", svcCtrl)
+		return &pb.GrantDelegationResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Controller does not exist."),
+		}, nil
+	}
+
+	err = serviceUtil.PutDelegationGrant(ctx, domainProject, in.ServiceId, in.ControllerServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "grant delegation failed, %s(service/controller): commit grant into etcd failed.", svcCtrl)
+		return &pb.GrantDelegationResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, "Commit operations failed."),
+		}, err
+	}
+
+	util.Logger().Infof("grant delegation successful, %s(service/controller), operator %s.",
+		svcCtrl, util.GetIPFromContext(ctx))
+	return &pb.GrantDelegationResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Grant delegation successfully."),
+	}, nil
+}
+
+func (s *MicroServiceService) RevokeDelegation(ctx context.Context, in *pb.RevokeDelegationRequest) (*pb.RevokeDelegationResponse, error) {
+	if in == nil || len(in.ServiceId) == 0 || len(in.ControllerServiceId) == 0 {
+		util.Logger().Errorf(nil, "revoke delegation failed: invalid params.")
+		return &pb.RevokeDelegationResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	svcCtrl := util.StringJoin([]string{in.ServiceId, in.ControllerServiceId}, "/")
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "revoke delegation failed, %s(service/controller): invalid parameters.", svcCtrl)
+		return &pb.RevokeDelegationResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+	if !canManageDelegation(ctx, in.ConsumerServiceId, in.ServiceId) {
+		util.Logger().Errorf(nil, "revoke delegation failed, %s(service/controller): consumer %s is not the service, operator %s.",
+			svcCtrl, in.ConsumerServiceId, util.GetIPFromContext(ctx))
+		return &pb.RevokeDelegationResponse{
+			Response: pb.CreateResponse(scerr.ErrForbidden, "Only the service itself can revoke the delegation."),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ServiceId) {
+		util.Logger().Errorf(nil, "revoke delegation failed, %s(service/controller): service not exist.", svcCtrl)
+		return &pb.RevokeDelegationResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+
+	grant, err := serviceUtil.GetDelegationGrant(ctx, domainProject, in.ServiceId, in.ControllerServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "revoke delegation failed, %s(service/controller): get grant failed.", svcCtrl)
+		return &pb.RevokeDelegationResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, "Get delegation grant failed."),
+		}, err
+	}
+	if grant == nil {
+		util.Logger().Errorf(nil, "revoke delegation failed, %s(service/controller): grant not exist.", svcCtrl)
+		return &pb.RevokeDelegationResponse{
+			Response: pb.CreateResponse(scerr.ErrDelegationNotExists, "Delegation grant does not exist."),
+		}, nil
+	}
+
+	_, err = backend.Registry().Do(ctx,
+		registry.DEL,
+		registry.WithStrKey(apt.GenerateDelegationKey(domainProject, in.ServiceId, in.ControllerServiceId)))
+	if err != nil {
+		util.Logger().Errorf(err, "revoke delegation failed, %s(service/controller): commit operations failed.", svcCtrl)
+		return &pb.RevokeDelegationResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, "Commit operations failed."),
+		}, err
+	}
+
+	util.Logger().Infof("revoke delegation successful, %s(service/controller), operator %s.",
+		svcCtrl, util.GetIPFromContext(ctx))
+	return &pb.RevokeDelegationResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Revoke delegation successfully."),
+	}, nil
+}
+
+func (s *MicroServiceService) GetDelegations(ctx context.Context, in *pb.GetDelegationsRequest) (*pb.GetDelegationsResponse, error) {
+	if in == nil || len(in.ServiceId) == 0 {
+		util.Logger().Errorf(nil, "get delegations failed: invalid params.")
+		return &pb.GetDelegationsResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "get delegations failed, service %s: invalid parameters.", in.ServiceId)
+		return &pb.GetDelegationsResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ServiceId) {
+		util.Logger().Errorf(nil, "get delegations failed, service %s: service not exist.", in.ServiceId)
+		return &pb.GetDelegationsResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+
+	grants, err := serviceUtil.GetDelegationGrants(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "get delegations failed, service %s: get grants failed.", in.ServiceId)
+		return &pb.GetDelegationsResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	audits, err := serviceUtil.GetDelegationAudits(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "get delegations failed, service %s: get audits failed.", in.ServiceId)
+		return &pb.GetDelegationsResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	return &pb.GetDelegationsResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Get delegations successfully."),
+		Grants:   grants,
+		Audits:   audits,
+	}, nil
+}
+
+// checkDelegation 校验控制器是否被授权代理操作服务的实例，未授权时记录审计
+func checkDelegation(ctx context.Context, domainProject string, audit *pb.DelegationAudit) *scerr.Error {
+	if !serviceUtil.ServiceExist(ctx, domainProject, audit.ControllerServiceId) {
+		return scerr.NewError(scerr.ErrServiceNotExists, "Controller does not exist.")
+	}
+	grant, err := serviceUtil.GetDelegationGrant(ctx, domainProject, audit.ServiceId, audit.ControllerServiceId)
+	if err != nil {
+		return scerr.NewError(scerr.ErrInternal, err.Error())
+	}
+	if grant == nil {
+		audit.Result = serviceUtil.DELEGATION_RESULT_DENIED
+		serviceUtil.RecordDelegationAudit(ctx, domainProject, audit)
+		return scerr.NewError(scerr.ErrPermissionDeny, "Controller is not granted by the service.")
+	}
+	return nil
+}
+
+func delegationResult(resp *pb.Response) string {
+	if resp.Code == pb.Response_SUCCESS {
+		return serviceUtil.DELEGATION_RESULT_SUCCESS
+	}
+	return serviceUtil.DELEGATION_RESULT_FAILED
+}
+
+func (s *InstanceService) DelegateRegister(ctx context.Context, in *pb.DelegateRegisterInstanceRequest) (*pb.RegisterInstanceResponse, error) {
+	if in == nil || len(in.ControllerServiceId) == 0 || in.Instance == nil || len(in.Instance.ServiceId) == 0 {
+		util.Logger().Errorf(nil, "delegate register instance failed: invalid params.")
+		return &pb.RegisterInstanceResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	svcCtrl := util.StringJoin([]string{in.Instance.ServiceId, in.ControllerServiceId}, "/")
+
+	audit := &pb.DelegationAudit{
+		ControllerServiceId: in.ControllerServiceId,
+		ServiceId:           in.Instance.ServiceId,
+		InstanceId:          in.Instance.InstanceId,
+		Action:              pb.DELEGATION_REGISTER,
+	}
+	if checkErr := checkDelegation(ctx, domainProject, audit); checkErr != nil {
+		util.Logger().Errorf(checkErr, "delegate register instance failed, %s(service/controller): check delegation failed.", svcCtrl)
+		resp := &pb.RegisterInstanceResponse{
+			Response: pb.CreateResponse(checkErr.Code, checkErr.Detail),
+		}
+		if checkErr.StatusCode() == http.StatusInternalServerError {
+			return resp, checkErr
+		}
+		return resp, nil
+	}
+
+	ctx = util.SetContext(util.CloneContext(ctx), serviceUtil.CTX_DELEGATED_BY, in.ControllerServiceId)
+	resp, err := s.Register(ctx, &pb.RegisterInstanceRequest{Instance: in.Instance})
+	if resp != nil {
+		if len(resp.InstanceId) > 0 {
+			audit.InstanceId = resp.InstanceId
+		}
+		audit.Result = delegationResult(resp.Response)
+		serviceUtil.RecordDelegationAudit(ctx, domainProject, audit)
+	}
+	return resp, err
+}
+
+func (s *InstanceService) DelegateUnregister(ctx context.Context, in *pb.DelegateUnregisterInstanceRequest) (*pb.UnregisterInstanceResponse, error) {
+	if in == nil || len(in.ControllerServiceId) == 0 || len(in.ServiceId) == 0 || len(in.InstanceId) == 0 {
+		util.Logger().Errorf(nil, "delegate unregister instance failed: invalid params.")
+		return &pb.UnregisterInstanceResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	svcCtrl := util.StringJoin([]string{in.ServiceId, in.ControllerServiceId}, "/")
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "delegate unregister instance failed, %s(service/controller): invalid parameters.", svcCtrl)
+		return &pb.UnregisterInstanceResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	audit := &pb.DelegationAudit{
+		ControllerServiceId: in.ControllerServiceId,
+		ServiceId:           in.ServiceId,
+		InstanceId:          in.InstanceId,
+		Action:              pb.DELEGATION_UNREGISTER,
+	}
+	if checkErr := checkDelegation(ctx, domainProject, audit); checkErr != nil {
+		util.Logger().Errorf(checkErr, "delegate unregister instance failed, %s(service/controller): check delegation failed.", svcCtrl)
+		resp := &pb.UnregisterInstanceResponse{
+			Response: pb.CreateResponse(checkErr.Code, checkErr.Detail),
+		}
+		if checkErr.StatusCode() == http.StatusInternalServerError {
+			return resp, checkErr
+		}
+		return resp, nil
+	}
+
+	resp, err := s.Unregister(ctx, &pb.UnregisterInstanceRequest{
+		ServiceId:  in.ServiceId,
+		InstanceId: in.InstanceId,
+	})
+	if resp != nil {
+		audit.Result = delegationResult(resp.Response)
+		serviceUtil.RecordDelegationAudit(ctx, domainProject, audit)
+	}
+	return resp, err
+}
@@ -0,0 +1,277 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service_test
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("'Delegation' service", func() {
+	Describe("execute 'grant' and 'delegate' operartion", func() {
+		var (
+			controllerId string
+			serviceId    string
+		)
+
+		It("should be passed", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "delegation_group",
+					ServiceName: "delegation_controller",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			controllerId = respCreateService.ServiceId
+
+			respCreateService, err = serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "delegation_group",
+					ServiceName: "delegation_function",
+					Version:     "1.0.0",
+					Level:       "BACK",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreateService.ServiceId
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				By("service id is empty")
+				resp, _ := serviceResource.GrantDelegation(getContext(), &pb.GrantDelegationRequest{
+					ControllerServiceId: controllerId,
+				})
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("controller does not exist")
+				resp, _ = serviceResource.GrantDelegation(getContext(), &pb.GrantDelegationRequest{
+					ServiceId:           serviceId,
+					ControllerServiceId: "noServiceTest",
+				})
+				Expect(resp.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+
+				By("grant does not exist")
+				respRevoke, _ := serviceResource.RevokeDelegation(getContext(), &pb.RevokeDelegationRequest{
+					ServiceId:           serviceId,
+					ControllerServiceId: controllerId,
+				})
+				Expect(respRevoke.Response.Code).To(Equal(scerr.ErrDelegationNotExists))
+
+				By("controller is not granted")
+				respRegister, err := instanceResource.DelegateRegister(getContext(), &pb.DelegateRegisterInstanceRequest{
+					ControllerServiceId: controllerId,
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: serviceId,
+						Endpoints: []string{
+							"delegation:127.0.0.1:8080",
+						},
+						HostName: "UT-HOST",
+						Status:   pb.MSI_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respRegister.Response.Code).To(Equal(scerr.ErrPermissionDeny))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				respGrant, err := serviceResource.GrantDelegation(getContext(), &pb.GrantDelegationRequest{
+					ServiceId:           serviceId,
+					ControllerServiceId: controllerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGrant.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				By("register on behalf of the service")
+				respRegister, err := instanceResource.DelegateRegister(getContext(), &pb.DelegateRegisterInstanceRequest{
+					ControllerServiceId: controllerId,
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: serviceId,
+						Endpoints: []string{
+							"delegation:127.0.0.1:8080",
+						},
+						HostName: "UT-HOST",
+						Status:   pb.MSI_UP,
+						Properties: map[string]string{
+							pb.PROP_DELEGATED_BY: "fake",
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respRegister.Response.Code).To(Equal(pb.Response_SUCCESS))
+				instanceId := respRegister.InstanceId
+
+				respGetOne, err := instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGetOne.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGetOne.Instance.Properties[pb.PROP_DELEGATED_BY]).To(Equal(controllerId))
+
+				By("unregister on behalf of the service")
+				respUnregister, err := instanceResource.DelegateUnregister(getContext(), &pb.DelegateUnregisterInstanceRequest{
+					ControllerServiceId: controllerId,
+					ServiceId:           serviceId,
+					InstanceId:          instanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respUnregister.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				By("grants and audits are recorded")
+				respGet, err := serviceResource.GetDelegations(getContext(), &pb.GetDelegationsRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respGet.Grants)).To(Equal(1))
+				Expect(respGet.Grants[0].ControllerServiceId).To(Equal(controllerId))
+				Expect(respGet.Grants[0].Controller.ServiceName).To(Equal("delegation_controller"))
+				Expect(len(respGet.Audits)).To(Equal(3))
+				Expect(respGet.Audits[0].Result).To(Equal(serviceUtil.DELEGATION_RESULT_DENIED))
+				Expect(respGet.Audits[1].Action).To(Equal(pb.DELEGATION_REGISTER))
+				Expect(respGet.Audits[1].InstanceId).To(Equal(instanceId))
+				Expect(respGet.Audits[1].Result).To(Equal(serviceUtil.DELEGATION_RESULT_SUCCESS))
+				Expect(respGet.Audits[2].Action).To(Equal(pb.DELEGATION_UNREGISTER))
+
+				By("revoked controller is denied")
+				respRevoke, err := serviceResource.RevokeDelegation(getContext(), &pb.RevokeDelegationRequest{
+					ServiceId:           serviceId,
+					ControllerServiceId: controllerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respRevoke.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respUnregister, err = instanceResource.DelegateUnregister(getContext(), &pb.DelegateUnregisterInstanceRequest{
+					ControllerServiceId: controllerId,
+					ServiceId:           serviceId,
+					InstanceId:          instanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respUnregister.Response.Code).To(Equal(scerr.ErrPermissionDeny))
+			})
+		})
+	})
+
+	Describe("execute 'grant' and 'revoke' operartion in a tenant", func() {
+		var (
+			controllerId string
+			serviceId    string
+		)
+
+		It("should be passed", func() {
+			ctx := util.SetContext(getContext(), "domain", "delegation_tenant")
+			respCreateService, err := serviceResource.Create(ctx, &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "delegation_group",
+					ServiceName: "delegation_controller",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			controllerId = respCreateService.ServiceId
+
+			respCreateService, err = serviceResource.Create(ctx, &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "delegation_group",
+					ServiceName: "delegation_function",
+					Version:     "1.0.0",
+					Level:       "BACK",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreateService.ServiceId
+		})
+
+		Context("when the caller is not the service itself", func() {
+			It("should be failed", func() {
+				ctx := util.SetContext(getContext(), "domain", "delegation_tenant")
+
+				By("consumer is empty")
+				respGrant, err := serviceResource.GrantDelegation(ctx, &pb.GrantDelegationRequest{
+					ServiceId:           serviceId,
+					ControllerServiceId: controllerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGrant.Response.Code).To(Equal(scerr.ErrForbidden))
+
+				By("controller grants itself")
+				respGrant, err = serviceResource.GrantDelegation(ctx, &pb.GrantDelegationRequest{
+					ServiceId:           serviceId,
+					ControllerServiceId: controllerId,
+					ConsumerServiceId:   controllerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGrant.Response.Code).To(Equal(scerr.ErrForbidden))
+
+				respGet, err := serviceResource.GetDelegations(ctx, &pb.GetDelegationsRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(len(respGet.Grants)).To(Equal(0))
+			})
+		})
+
+		Context("when the caller is the service itself", func() {
+			It("should be passed", func() {
+				ctx := util.SetContext(getContext(), "domain", "delegation_tenant")
+				respGrant, err := serviceResource.GrantDelegation(ctx, &pb.GrantDelegationRequest{
+					ServiceId:           serviceId,
+					ControllerServiceId: controllerId,
+					ConsumerServiceId:   serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGrant.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				By("controller revokes the grant")
+				respRevoke, err := serviceResource.RevokeDelegation(ctx, &pb.RevokeDelegationRequest{
+					ServiceId:           serviceId,
+					ControllerServiceId: controllerId,
+					ConsumerServiceId:   controllerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respRevoke.Response.Code).To(Equal(scerr.ErrForbidden))
+
+				respRevoke, err = serviceResource.RevokeDelegation(ctx, &pb.RevokeDelegationRequest{
+					ServiceId:           serviceId,
+					ControllerServiceId: controllerId,
+					ConsumerServiceId:   serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respRevoke.Response.Code).To(Equal(pb.Response_SUCCESS))
+			})
+		})
+	})
+})
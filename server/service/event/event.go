@@ -25,4 +25,10 @@ func init() {
 	store.AddEventHandler(NewInstanceEventHandler())
 	store.AddEventHandler(NewRuleEventHandler())
 	store.AddEventHandler(NewTagEventHandler())
+	store.AddEventHandler(NewTopologyEventHandler(store.DEPENDENCY_RULE))
+	store.AddEventHandler(NewTopologyEventHandler(store.SERVICE))
+	store.AddEventHandler(NewTopologyEventHandler(store.INSTANCE))
+	store.AddEventHandler(NewInstanceStateEventHandler())
+	store.AddEventHandler(NewSchemaComplianceEventHandler(store.SCHEMA_SUMMARY))
+	store.AddEventHandler(NewSchemaComplianceEventHandler(store.SERVICE))
 }
@@ -17,7 +17,6 @@
 package event
 
 import (
-	"encoding/json"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
 	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
@@ -69,12 +68,19 @@ func (h *InstanceEventHandler) OnEvent(evt *store.KvEvent) {
 	util.Logger().Infof("caught instance %s/%s [%s] event", providerId, providerInstanceId, action)
 
 	var instance pb.MicroServiceInstance
-	err := json.Unmarshal(data, &instance)
+	err := store.Unmarshal(data, &instance)
 	if err != nil {
 		util.Logger().Errorf(err, "unmarshal provider service instance %s/%s file failed",
 			providerId, providerInstanceId)
 		return
 	}
+	// 待命和预注册实例对消费者不可见：注册时不通知，转为待命时按删除通知
+	if !serviceUtil.IsDiscoverable(&instance) {
+		if action == pb.EVT_CREATE {
+			return
+		}
+		action = pb.EVT_DELETE
+	}
 	// 查询服务版本信息
 	ms, err := serviceUtil.GetServiceInCache(context.Background(), domainProject, providerId)
 	if ms == nil {
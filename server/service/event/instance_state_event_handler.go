@@ -0,0 +1,38 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package event
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+)
+
+// InstanceStateEventHandler 实例状态变化时刷新实例状态指标
+type InstanceStateEventHandler struct {
+}
+
+func (h *InstanceStateEventHandler) Type() store.StoreType {
+	return store.INSTANCE_STATE
+}
+
+func (h *InstanceStateEventHandler) OnEvent(evt *store.KvEvent) {
+	serviceUtil.OnInstanceStateEvent(evt)
+}
+
+func NewInstanceStateEventHandler() *InstanceStateEventHandler {
+	return &InstanceStateEventHandler{}
+}
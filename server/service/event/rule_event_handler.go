@@ -22,6 +22,7 @@ import (
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
 	nf "github.com/apache/incubator-servicecomb-service-center/server/service/notification"
 	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
 	"golang.org/x/net/context"
@@ -73,6 +74,19 @@ func (apt *RulesChangedAsyncTask) publish(ctx context.Context, domainProject, pr
 	providerKey := pb.MicroServiceToKey(domainProject, provider)
 
 	nf.PublishInstanceEvent(domainProject, pb.EVT_EXPIRE, providerKey, nil, rev, consumerIds)
+
+	// 被拒绝的消费者也需要感知，以便及时刷新本地缓存
+	for _, consumerId := range consumerIds {
+		permission := pb.PERMISSION_ALLOW
+		if err := serviceUtil.Accessible(ctx, domainProject, consumerId, providerId); err != nil {
+			if err.Code != scerr.ErrPermissionDeny {
+				util.Logger().Errorf(err, "check consumer %s permission of provider %s failed", consumerId, providerId)
+				continue
+			}
+			permission = pb.PERMISSION_DENY
+		}
+		nf.PublishRuleEvent(domainProject, providerKey, permission, rev, consumerId)
+	}
 	return nil
 }
 
@@ -91,6 +105,8 @@ func (h *RuleEventHandler) OnEvent(evt *store.KvEvent) {
 
 	kv := evt.KV
 	providerId, ruleId, domainProject, data := pb.GetInfoFromRuleKV(kv)
+	// 编译后的黑白名单在通知之前失效，推送时按新规则计算权限
+	serviceUtil.InvalidateRuleMatcher(domainProject, providerId)
 	if data == nil {
 		util.Logger().Errorf(nil,
 			"unmarshal service rule file failed, service %s rule %s [%s] event, data is nil",
@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package event
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+)
+
+// SchemaComplianceEventHandler 契约摘要变化时重新计算服务的合规报告，服务删除时清除报告，
+// 启动时加载的数据不触发计算，报告在第一次查询时计算
+type SchemaComplianceEventHandler struct {
+	storeType store.StoreType
+}
+
+func (h *SchemaComplianceEventHandler) Type() store.StoreType {
+	return h.storeType
+}
+
+func (h *SchemaComplianceEventHandler) OnEvent(evt *store.KvEvent) {
+	if evt.Action == pb.EVT_INIT {
+		return
+	}
+	switch h.storeType {
+	case store.SERVICE:
+		if evt.Action != pb.EVT_DELETE {
+			return
+		}
+		serviceId, domainProject, _ := pb.GetInfoFromSvcKV(evt.KV)
+		serviceUtil.NotifyServiceDeleted(domainProject, serviceId)
+	case store.SCHEMA_SUMMARY:
+		// key: .../{domain}/{project}/{serviceId}/{schemaId}
+		keys, _ := pb.KvToResponse(evt.KV)
+		l := len(keys)
+		if l < 4 {
+			return
+		}
+		domainProject := util.StringJoin([]string{keys[l-4], keys[l-3]}, "/")
+		serviceUtil.NotifySchemaChanged(domainProject, keys[l-2])
+	}
+}
+
+func NewSchemaComplianceEventHandler(t store.StoreType) *SchemaComplianceEventHandler {
+	return &SchemaComplianceEventHandler{storeType: t}
+}
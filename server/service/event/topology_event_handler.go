@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package event
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+)
+
+// TopologyEventHandler 依赖规则、服务或实例变化时刷新依赖拓扑指标
+type TopologyEventHandler struct {
+	storeType store.StoreType
+}
+
+func (h *TopologyEventHandler) Type() store.StoreType {
+	return h.storeType
+}
+
+func (h *TopologyEventHandler) OnEvent(evt *store.KvEvent) {
+	serviceUtil.NotifyTopologyChanged()
+}
+
+func NewTopologyEventHandler(t store.StoreType) *TopologyEventHandler {
+	return &TopologyEventHandler{storeType: t}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
 	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/quota"
@@ -34,7 +35,9 @@ import (
 	"golang.org/x/net/context"
 	"math"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -48,29 +51,73 @@ func (s *InstanceService) Register(ctx context.Context, in *pb.RegisterInstanceR
 			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
 		}, nil
 	}
+	remoteIP := util.GetIPFromContext(ctx)
+	//先以domain/project的方式组装
+	domainProject := util.ParseDomainProject(ctx)
+
+	admitErr := serviceUtil.AdmitInstance(ctx, domainProject, in)
+	if admitErr != nil {
+		util.Logger().Errorf(admitErr, "register instance failed, domain %s, operator %s: admission rejected.",
+			domainProject, remoteIP)
+		resp := &pb.RegisterInstanceResponse{
+			Response: pb.CreateResponseWithDetails(admitErr.Code, admitErr.Detail, admitErr.Details...),
+		}
+		if admitErr.StatusCode() == http.StatusInternalServerError {
+			return resp, admitErr
+		}
+		return resp, nil
+	}
+	if guardErr := serviceUtil.AdmitWrite(ctx, serviceUtil.WRITE_PRIORITY_NORMAL); guardErr != nil {
+		util.Logger().Errorf(guardErr, "register instance failed, domain %s, operator %s: storage guard rejected.",
+			domainProject, remoteIP)
+		return &pb.RegisterInstanceResponse{
+			Response: pb.CreateResponse(guardErr.Code, guardErr.Detail),
+		}, nil
+	}
+
 	instance := in.GetInstance()
+	// 状态只能通过心跳上报
+	instance.State = nil
 	if len(instance.Status) == 0 {
 		instance.Status = pb.MSI_UP
 	}
+	if instance.Status != pb.MSI_PENDING {
+		instance.ActivateTime = ""
+	}
 
-	remoteIP := util.GetIPFromContext(ctx)
 	instanceFlag := util.StringJoin([]string{instance.ServiceId, instance.HostName}, "/")
+	// endpoints和endpointInfos互相补齐后一起校验
+	if err := serviceUtil.NormalizeEndpoints(instance); err != nil {
+		util.Logger().Errorf(err, "register instance failed, service %s, operator %s: invalid endpoints.",
+			instanceFlag, remoteIP)
+		return &pb.RegisterInstanceResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(),
+				scerr.NewDetail(scerr.ErrInvalidParams, "endpointInfos", err.Error())),
+		}, nil
+	}
 	err := apt.Validate(instance)
 	if err != nil {
 		util.Logger().Errorf(err, "register instance failed, service %s, operator %s: invalid instance parameters.",
 			instanceFlag, remoteIP)
 		return &pb.RegisterInstanceResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
-	//先以domain/project的方式组装
-	domainProject := util.ParseDomainProject(ctx)
-
+	if propErr := apt.ValidateProperties(domainProject, instance.Properties); propErr != nil {
+		util.Logger().Errorf(propErr, "register instance failed, service %s, operator %s: invalid instance properties.",
+			instanceFlag, remoteIP)
+		return &pb.RegisterInstanceResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidProperties, propErr.Error(),
+				scerr.NewDetail(scerr.ErrInvalidProperties, propErr.Field(), propErr.Reason)),
+		}, nil
+	}
+	instance.Platform = serviceUtil.NormalizePlatform(instance.Platform)
 	// service id存在性校验
 	if !serviceUtil.ServiceExist(ctx, domainProject, instance.ServiceId) {
 		util.Logger().Errorf(nil, "register instance failed, service %s, operator %s: service not exist.", instanceFlag, remoteIP)
 		return &pb.RegisterInstanceResponse{
-			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+			Response: pb.CreateResponseWithDetails(scerr.ErrServiceNotExists, "Service does not exist.",
+				scerr.NewDetail(scerr.ErrServiceNotExists, "serviceId", instance.ServiceId)),
 		}, nil
 	}
 
@@ -88,7 +135,8 @@ func (s *InstanceService) Register(ctx context.Context, in *pb.RegisterInstanceR
 				util.Logger().Errorf(err, "register instance failed, service %s, operator %s: check endpoints failed.", instanceFlag, remoteIP)
 				if oldInstanceId != "" {
 					return &pb.RegisterInstanceResponse{
-						Response: pb.CreateResponse(scerr.ErrEndpointAlreadyExists, err.Error()),
+						Response: pb.CreateResponseWithDetails(scerr.ErrEndpointAlreadyExists, err.Error(),
+							scerr.NewDetail(scerr.ErrEndpointAlreadyExists, "endpoints", oldInstanceId)),
 					}, nil
 				}
 				return &pb.RegisterInstanceResponse{
@@ -105,12 +153,19 @@ func (s *InstanceService) Register(ctx context.Context, in *pb.RegisterInstanceR
 		}
 	}
 
+	// 静态实例单独统计配额
+	static := serviceUtil.IsStaticInstance(instance)
+	quotaType := quota.MicroServiceInstanceQuotaType
+	if static {
+		quotaType = quota.StaticInstanceQuotaType
+	}
+
 	var reporter quota.QuotaReporter
 	if len(oldInstanceId) == 0 {
 		if !apt.IsSCInstance(ctx) {
 			var err error
 			var ok bool
-			reporter, ok, err = plugin.Plugins().Quota().Apply4Quotas(ctx, quota.MicroServiceInstanceQuotaType, domainProject, in.Instance.ServiceId, 1)
+			reporter, ok, err = plugin.Plugins().Quota().Apply4Quotas(ctx, quotaType, domainProject, in.Instance.ServiceId, 1)
 			if reporter != nil {
 				defer reporter.Close()
 			}
@@ -138,10 +193,16 @@ func (s *InstanceService) Register(ctx context.Context, in *pb.RegisterInstanceR
 	instance.ModTimestamp = instance.Timestamp
 	util.Logger().Debug(fmt.Sprintf("instance ID [%s]", instanceId))
 
+	if apt.ServerInfo.Config.EnrichInstanceMetadata {
+		serviceUtil.EnrichInstanceProperties(ctx, instance)
+	}
+	serviceUtil.SetDelegatedBy(ctx, instance)
+
 	// 这里应该根据租约计时
 	renewalInterval := apt.REGISTRY_DEFAULT_LEASE_RENEWALINTERVAL
 	retryTimes := apt.REGISTRY_DEFAULT_LEASE_RETRYTIMES
-	if instance.GetHealthCheck() == nil {
+	hcSpecified := instance.GetHealthCheck() != nil
+	if !hcSpecified {
 		instance.HealthCheck = &pb.HealthCheck{
 			Mode:     pb.CHECK_BY_HEARTBEAT,
 			Interval: renewalInterval,
@@ -156,13 +217,42 @@ func (s *InstanceService) Register(ctx context.Context, in *pb.RegisterInstanceR
 				instance.HealthCheck.Interval*(instance.HealthCheck.Times+1) >= math.MaxInt32 {
 				util.Logger().Errorf(err, "register instance %s(%s) failed for invalid health check settings.", instance.ServiceId, instance.HostName)
 				return &pb.RegisterInstanceResponse{
-					Response: pb.CreateResponse(scerr.ErrInvalidParams, "Invalid health check settings."),
+					Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, "Invalid health check settings.",
+						scerr.NewDetail(scerr.ErrInvalidParams, "healthCheck", "interval or times is out of range")),
 				}, nil
 			}
 			renewalInterval = instance.HealthCheck.Interval
 			retryTimes = instance.HealthCheck.Times
 		case pb.CHECK_BY_PLATFORM:
 			// 默认120s
+		case pb.CHECK_BY_STATIC:
+			// 没有租约，interval和times用于主动探测
+			if instance.HealthCheck.Interval < 0 || instance.HealthCheck.Times < 0 {
+				util.Logger().Errorf(nil, "register instance %s(%s) failed for invalid health check settings.", instance.ServiceId, instance.HostName)
+				return &pb.RegisterInstanceResponse{
+					Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, "Invalid health check settings.",
+						scerr.NewDetail(scerr.ErrInvalidParams, "healthCheck", "interval or times is out of range")),
+				}, nil
+			}
+		}
+	}
+	if instance.HealthCheck.Mode == pb.CHECK_BY_HEARTBEAT {
+		policy, err := serviceUtil.FindLeasePolicy(ctx, domainProject)
+		if err != nil {
+			util.Logger().Errorf(err, "register instance failed, service %s, operator %s: query lease policy failed.",
+				instanceFlag, remoteIP)
+			return &pb.RegisterInstanceResponse{
+				Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+			}, err
+		}
+		if policy != nil {
+			interval, times := instance.HealthCheck.Interval, instance.HealthCheck.Times
+			if policy.Apply(instance.HealthCheck, hcSpecified) {
+				util.Logger().Warnf(nil, "instance %s(%s) health check interval %ds*(%d+1) is out of the lease policy of %s, override it to %ds",
+					instance.ServiceId, instance.HostName, interval, times, domainProject, instance.HealthCheck.Interval)
+			}
+			renewalInterval = instance.HealthCheck.Interval
+			retryTimes = instance.HealthCheck.Times
 		}
 	}
 	ttl := int64(renewalInterval * (retryTimes + 1))
@@ -176,11 +266,19 @@ func (s *InstanceService) Register(ctx context.Context, in *pb.RegisterInstanceR
 		}, err
 	}
 
-	leaseID, err := grantOrRenewLease(ctx, domainProject, instance.ServiceId, instanceId, ttl)
-	if err != nil {
-		return &pb.RegisterInstanceResponse{
-			Response: pb.CreateResponse(scerr.ErrInternal, "Lease grant or renew failed."),
-		}, err
+	var leaseID int64
+	if !static {
+		leaseID, err = grantOrRenewLease(ctx, domainProject, instance.ServiceId, instanceId, ttl)
+		if err == registry.ErrLeaseThrottled {
+			return &pb.RegisterInstanceResponse{
+				Response: pb.CreateResponse(scerr.ErrUnavailableBackend, "Lease operation is throttled, retry later."),
+			}, nil
+		}
+		if err != nil {
+			return &pb.RegisterInstanceResponse{
+				Response: pb.CreateResponse(scerr.ErrInternal, "Lease grant or renew failed."),
+			}, err
+		}
 	}
 
 	index := apt.GenerateInstanceIndexKey(domainProject, instanceId)
@@ -200,6 +298,12 @@ func (s *InstanceService) Register(ctx context.Context, in *pb.RegisterInstanceR
 			registry.OpPut(registry.WithStrKey(hbKey), registry.WithStrValue(fmt.Sprintf("%d", leaseID)),
 				registry.WithLease(leaseID), registry.WithIgnoreLease()))
 	}
+	if static {
+		opts = append(opts,
+			registry.OpPut(registry.WithStrKey(apt.GenerateStaticInstanceKey(domainProject, instance.ServiceId, instanceId)),
+				registry.WithStrValue(instance.ServiceId)))
+	}
+	opts = append(opts, serviceUtil.PendingInstanceIndexOps(domainProject, instance, leaseID)...)
 
 	if endpointsIndexKey != "" {
 		value := util.StringJoin([]string{
@@ -229,7 +333,7 @@ func (s *InstanceService) Register(ctx context.Context, in *pb.RegisterInstanceR
 	}
 	util.Logger().Infof("register instance successful service %s, instanceId %s, operator %s.", instanceFlag, instanceId, remoteIP)
 	return &pb.RegisterInstanceResponse{
-		Response:   pb.CreateResponse(pb.Response_SUCCESS, "Register service instance successfully."),
+		Response:   pb.CreateResponse(pb.Response_SUCCESS, withQuotaWarning("Register service instance successfully.", reporter)),
 		InstanceId: instanceId,
 	}, nil
 }
@@ -287,7 +391,17 @@ func revokeInstance(ctx context.Context, domainProject string, serviceId string,
 		return err, true
 	}
 	if leaseID == -1 {
-		return errors.New("instance's leaseId not exist."), false
+		instance, err := serviceUtil.GetInstance(ctx, domainProject, serviceId, instanceId)
+		if err != nil {
+			return err, true
+		}
+		if !serviceUtil.IsStaticInstance(instance) {
+			return errors.New("instance's leaseId not exist."), false
+		}
+		if err := serviceUtil.DeleteStaticInstance(ctx, domainProject, instance); err != nil {
+			return err, true
+		}
+		return nil, false
 	}
 
 	err = backend.Registry().LeaseRevoke(ctx, leaseID)
@@ -307,25 +421,56 @@ func (s *InstanceService) Heartbeat(ctx context.Context, in *pb.HeartbeatRequest
 	domainProject := util.ParseDomainProject(ctx)
 	instanceFlag := util.StringJoin([]string{in.ServiceId, in.InstanceId}, "/")
 
-	_, ttl, err, isInnerErr := serviceUtil.HeartbeatUtil(ctx, domainProject, in.ServiceId, in.InstanceId)
+	if err := serviceUtil.CheckInstanceState(in.State); err != nil {
+		util.Logger().Errorf(err, "heartbeat failed, instance %s: invalid state. operator: %s",
+			instanceFlag, remoteIP)
+		return &pb.HeartbeatResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+
+	leaseID, ttl, err, isInnerErr := serviceUtil.HeartbeatUtil(ctx, domainProject, in.ServiceId, in.InstanceId)
 	if err != nil {
 		util.Logger().Errorf(err, "heartbeat failed, instance %s, internal error '%v'. operator: %s",
 			instanceFlag, isInnerErr, remoteIP)
+		// 续约被限流时实例仍然存在，不能让客户端重新注册
+		if err == registry.ErrLeaseThrottled {
+			return &pb.HeartbeatResponse{
+				Response: pb.CreateResponse(scerr.ErrUnavailableBackend, "Lease operation is throttled, retry later."),
+			}, nil
+		}
 		if isInnerErr {
 			return &pb.HeartbeatResponse{
 				Response: pb.CreateResponse(scerr.ErrInternal, "Service instance does not exist."),
 			}, err
 		}
+		if instance, _ := serviceUtil.GetInstance(ctx, domainProject, in.ServiceId, in.InstanceId); serviceUtil.IsStaticInstance(instance) {
+			return &pb.HeartbeatResponse{
+				Response: pb.CreateResponse(scerr.ErrInvalidParams, "Static instance does not need heartbeat."),
+			}, nil
+		}
 		return &pb.HeartbeatResponse{
 			Response: pb.CreateResponse(scerr.ErrInstanceNotExists, "Service instance does not exist."),
 		}, nil
 	}
 	util.Logger().Infof("heartbeat successful: %s renew ttl to %d. operator: %s", instanceFlag, ttl, remoteIP)
+	if in.State != nil {
+		reportInstanceState(ctx, domainProject, in.ServiceId, in.InstanceId, leaseID, in.State)
+	}
 	return &pb.HeartbeatResponse{
 		Response: pb.CreateResponse(pb.Response_SUCCESS, "Update service instance heartbeat successfully."),
 	}, nil
 }
 
+// reportInstanceState 状态保存失败不影响心跳结果，由之后的心跳重新上报
+func reportInstanceState(ctx context.Context, domainProject, serviceId, instanceId string,
+	leaseID int64, state map[string]string) {
+	_, err := serviceUtil.ReportInstanceState(ctx, domainProject, serviceId, instanceId, leaseID, state)
+	if err != nil {
+		util.Logger().Warnf(err, "report state of instance %s/%s failed", serviceId, instanceId)
+	}
+}
+
 func grantOrRenewLease(ctx context.Context, domainProject string, serviceId string, instanceId string, ttl int64) (leaseID int64, err error) {
 	remoteIP := util.GetIPFromContext(ctx)
 	instanceFlag := util.StringJoin([]string{serviceId, instanceId}, "/")
@@ -363,6 +508,15 @@ func (s *InstanceService) HeartbeatSet(ctx context.Context, in *pb.HeartbeatSetR
 			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
 		}, nil
 	}
+	for _, element := range in.Instances {
+		if err := serviceUtil.CheckInstanceState(element.State); err != nil {
+			util.Logger().Errorf(err, "heartbeats failed, instance %s/%s: invalid state.",
+				element.ServiceId, element.InstanceId)
+			return &pb.HeartbeatSetResponse{
+				Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			}, nil
+		}
+	}
 	domainProject := util.ParseDomainProject(ctx)
 	instanceHbRstArr := []*pb.InstanceHbRst{}
 	existFlag := map[string]bool{}
@@ -382,10 +536,12 @@ func (s *InstanceService) HeartbeatSet(ctx context.Context, in *pb.HeartbeatSetR
 				InstanceId: element.InstanceId,
 				ErrMessage: "",
 			}
-			_, _, err, _ := serviceUtil.HeartbeatUtil(ctx, domainProject, element.ServiceId, element.InstanceId)
+			leaseID, _, err, _ := serviceUtil.HeartbeatUtil(ctx, domainProject, element.ServiceId, element.InstanceId)
 			if err != nil {
 				hbRst.ErrMessage = err.Error()
 				util.Logger().Errorf(err, "heartbeatset failed, %s/%s", element.ServiceId, element.InstanceId)
+			} else if element.State != nil {
+				reportInstanceState(ctx, domainProject, element.ServiceId, element.InstanceId, leaseID, element.State)
 			}
 			instancesHbRst <- hbRst
 		}(heartbeatElement)
@@ -425,7 +581,7 @@ func (s *InstanceService) GetOneInstance(ctx context.Context, in *pb.GetOneInsta
 	if checkErr != nil {
 		util.Logger().Errorf(checkErr, "get instance failed: pre check failed.")
 		resp := &pb.GetOneInstanceResponse{
-			Response: pb.CreateResponse(checkErr.Code, checkErr.Detail),
+			Response: pb.CreateResponseWithDetails(checkErr.Code, checkErr.Detail, checkErr.Details...),
 		}
 		if checkErr.StatusCode() == http.StatusInternalServerError {
 			return resp, checkErr
@@ -434,7 +590,7 @@ func (s *InstanceService) GetOneInstance(ctx context.Context, in *pb.GetOneInsta
 	}
 	conPro := util.StringJoin([]string{in.ConsumerServiceId, in.ProviderServiceId, in.ProviderInstanceId}, "/")
 
-	domainProject := util.ParseDomainProject(ctx)
+	domainProject := util.ParseTargetDomainProject(ctx)
 
 	serviceId := in.ProviderServiceId
 	instanceId := in.ProviderInstanceId
@@ -451,6 +607,7 @@ func (s *InstanceService) GetOneInstance(ctx context.Context, in *pb.GetOneInsta
 			Response: pb.CreateResponse(scerr.ErrInstanceNotExists, "Service instance does not exist."),
 		}, nil
 	}
+	serviceUtil.AttachInstanceStates(ctx, domainProject, serviceId, []*pb.MicroServiceInstance{instance})
 
 	return &pb.GetOneInstanceResponse{
 		Response: pb.CreateResponse(pb.Response_SUCCESS, "Get instance successfully."),
@@ -461,11 +618,13 @@ func (s *InstanceService) GetOneInstance(ctx context.Context, in *pb.GetOneInsta
 func (s *InstanceService) getInstancePreCheck(ctx context.Context, in interface{}) *scerr.Error {
 	err := apt.Validate(in)
 	if err != nil {
-		return scerr.NewError(scerr.ErrInvalidParams, err.Error())
+		return scerr.NewError(scerr.ErrInvalidParams, err.Error()).WithDetails(scerr.ValidationDetails("", err)...)
 	}
 	var providerServiceId, consumerServiceId string
 	var tags []string
 	domainProject := util.ParseDomainProject(ctx)
+	// 发现共享服务时提供者位于其它租户
+	providerDomainProject := util.ParseTargetDomainProject(ctx)
 
 	switch in.(type) {
 	case *pb.GetOneInstanceRequest:
@@ -478,13 +637,14 @@ func (s *InstanceService) getInstancePreCheck(ctx context.Context, in interface{
 		tags = in.(*pb.GetInstancesRequest).Tags
 	}
 
-	if !serviceUtil.ServiceExist(ctx, domainProject, providerServiceId) {
-		return scerr.NewError(scerr.ErrServiceNotExists, "Provider serviceId is invalid")
+	if !serviceUtil.ServiceExist(ctx, providerDomainProject, providerServiceId) {
+		return scerr.NewError(scerr.ErrServiceNotExists, "Provider serviceId is invalid").
+			WithDetails(scerr.NewDetail(scerr.ErrServiceNotExists, "providerServiceId", providerServiceId))
 	}
 
 	// Tag过滤
 	if len(tags) > 0 {
-		tagsFromETCD, err := serviceUtil.GetTagsUtils(ctx, domainProject, providerServiceId)
+		tagsFromETCD, err := serviceUtil.GetTagsUtils(ctx, providerDomainProject, providerServiceId)
 		if err != nil {
 			return scerr.NewError(scerr.ErrInternal, fmt.Sprintf("An error occurred in query provider tags(%s)", err.Error()))
 		}
@@ -499,7 +659,7 @@ func (s *InstanceService) getInstancePreCheck(ctx context.Context, in interface{
 	}
 	// 黑白名单
 	// 跨应用调用
-	forbid := serviceUtil.Accessible(ctx, domainProject, consumerServiceId, providerServiceId)
+	forbid := serviceUtil.AccessibleAcrossDomain(ctx, domainProject, consumerServiceId, providerDomainProject, providerServiceId)
 	if forbid != nil {
 		util.Logger().Errorf(forbid,
 			"consumer %s can't access provider %s", consumerServiceId, providerServiceId)
@@ -513,7 +673,7 @@ func (s *InstanceService) GetInstances(ctx context.Context, in *pb.GetInstancesR
 	if checkErr != nil {
 		util.Logger().Errorf(checkErr, "get instances failed: pre check failed.")
 		resp := &pb.GetInstancesResponse{
-			Response: pb.CreateResponse(checkErr.Code, checkErr.Detail),
+			Response: pb.CreateResponseWithDetails(checkErr.Code, checkErr.Detail, checkErr.Details...),
 		}
 		if checkErr.StatusCode() == http.StatusInternalServerError {
 			return resp, checkErr
@@ -522,15 +682,17 @@ func (s *InstanceService) GetInstances(ctx context.Context, in *pb.GetInstancesR
 	}
 	conPro := util.StringJoin([]string{in.ConsumerServiceId, in.ProviderServiceId}, "/")
 
-	domainProject := util.ParseDomainProject(ctx)
+	providerDomainProject := util.ParseTargetDomainProject(ctx)
 
-	instances, err := serviceUtil.GetAllInstancesOfOneService(ctx, domainProject, in.ProviderServiceId)
+	instances, err := serviceUtil.GetAllInstancesOfOneService(ctx, providerDomainProject, in.ProviderServiceId)
 	if err != nil {
 		util.Logger().Errorf(err, "get instances failed, %s(consumer/provider): get instances from etcd failed.", conPro)
 		return &pb.GetInstancesResponse{
 			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
 		}, err
 	}
+	instances = serviceUtil.ExcludeUndiscoverableInstances(instances)
+	serviceUtil.AttachInstanceStates(ctx, providerDomainProject, in.ProviderServiceId, instances)
 	return &pb.GetInstancesResponse{
 		Response:  pb.CreateResponse(pb.Response_SUCCESS, "Query service instances successfully."),
 		Instances: instances,
@@ -542,11 +704,13 @@ func (s *InstanceService) Find(ctx context.Context, in *pb.FindInstancesRequest)
 	if err != nil {
 		util.Logger().Errorf(err, "find instance failed: invalid parameters.")
 		return &pb.FindInstancesResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 
 	domainProject := util.ParseDomainProject(ctx)
+	// 查询前取revision，watch从此续接时不会漏掉查询期间的变化
+	rev := store.Store().Instance().Cache().Version()
 
 	findFlag := fmt.Sprintf("consumer %s --> provider %s/%s/%s", in.ConsumerServiceId, in.AppId, in.ServiceName, in.VersionRule)
 	service, err := serviceUtil.GetService(ctx, domainProject, in.ConsumerServiceId)
@@ -563,20 +727,57 @@ func (s *InstanceService) Find(ctx context.Context, in *pb.FindInstancesRequest)
 		}, nil
 	}
 
+	// 跨环境发现的范围
+	scope, err := serviceUtil.FindDiscoveryScope(ctx, domainProject)
+	if err != nil {
+		util.Logger().Errorf(err, "find instance failed, %s: get discovery scope failed.", findFlag)
+		return &pb.FindInstancesResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
 	// 版本规则
-	ids, err := serviceUtil.FindServiceIds(ctx, in.VersionRule, &pb.MicroServiceKey{
+	ids, err := serviceUtil.FindProviderIds(ctx, in.VersionRule, &pb.MicroServiceKey{
 		Tenant:      domainProject,
 		Environment: service.Environment,
 		AppId:       in.AppId,
 		ServiceName: in.ServiceName,
 		Alias:       in.ServiceName,
-	})
+	}, scope, in.CrossEnvironment)
 	if err != nil {
 		util.Logger().Errorf(err, "find instance failed, %s: get providers failed.", findFlag)
 		return &pb.FindInstancesResponse{
 			Response: pb.CreateResponse(scerr.ErrInternal, "Get serviceId failed."),
 		}, err
 	}
+	// 本租户没有匹配的提供者时，尝试发现管理员标记的共享服务
+	providerDomainProject := domainProject
+	if len(ids) == 0 {
+		sharedService, err := serviceUtil.FindSharedService(ctx, in.AppId, in.ServiceName)
+		if err != nil {
+			util.Logger().Errorf(err, "find instance failed, %s: get shared service failed.", findFlag)
+			return &pb.FindInstancesResponse{
+				Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+			}, err
+		}
+		if sharedService != nil && sharedService.DomainProject() != domainProject {
+			providerDomainProject = sharedService.DomainProject()
+			ids, err = serviceUtil.FindProviderIds(ctx, in.VersionRule, &pb.MicroServiceKey{
+				Tenant:      providerDomainProject,
+				Environment: service.Environment,
+				AppId:       in.AppId,
+				ServiceName: in.ServiceName,
+			}, scope, in.CrossEnvironment)
+			if err != nil {
+				util.Logger().Errorf(err, "find instance failed, %s: get shared providers in %s failed.",
+					findFlag, providerDomainProject)
+				return &pb.FindInstancesResponse{
+					Response: pb.CreateResponse(scerr.ErrInternal, "Get serviceId failed."),
+				}, err
+			}
+			ctx = util.SetTargetDomainProject(util.CloneContext(ctx), providerDomainProject)
+		}
+	}
 	if len(ids) == 0 {
 		util.Logger().Errorf(nil, "find instance failed, %s: no provider matched.", findFlag)
 		return &pb.FindInstancesResponse{
@@ -601,38 +802,105 @@ func (s *InstanceService) Find(ctx context.Context, in *pb.FindInstancesRequest)
 			instances = append(instances, resp.GetInstances()...)
 		}
 	}
-	consumer := pb.MicroServiceToKey(domainProject, service)
-	//维护version的规则,servicename 可能是别名，所以重新获取
-	providerService, _ := serviceUtil.GetService(ctx, domainProject, ids[0])
-	if providerService == nil {
-		util.Logger().Errorf(nil, "find instance failed, %s: no provider matched.", findFlag)
+	policy, err := serviceUtil.GetDiscoveryPolicy(ctx, domainProject, in.ConsumerServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "find instance failed, %s: get consumer discovery policy failed.", findFlag)
 		return &pb.FindInstancesResponse{
-			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "No provider matched."),
-		}, nil
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
 	}
-	provider := &pb.MicroServiceKey{
-		Tenant:      domainProject,
-		Environment: consumer.Environment,
-		AppId:       in.AppId,
-		ServiceName: providerService.ServiceName,
-		Version:     in.VersionRule,
+	// 先去掉消费者无法访问的地址族和协议，避免可用实例被maxInstances截断
+	instances = serviceUtil.ApplyAddressFamily(in.AddressFamily, instances)
+	instances = serviceUtil.ApplyProtocols(in.Protocols, instances)
+	// 先打散顺序，再按平台和发现策略分组，分组内保持打散后的顺序
+	instances = serviceUtil.ApplyInstanceOrder(serviceUtil.InstanceOrder(in.Order, policy), in.Zone, instances)
+	// 先按平台排序，避免匹配的实例被maxInstances截断
+	instances = serviceUtil.ApplyPlatformPolicy(in.Platform, in.PlatformPolicy, instances)
+	instances = serviceUtil.ApplyDiscoveryPolicy(policy, instances)
+
+	// 共享服务只读，不记录其它租户的依赖关系
+	shared := providerDomainProject != domainProject
+	if !shared {
+		if err := serviceUtil.RecordDependencyUsage(ctx, domainProject, in.ConsumerServiceId, in.VersionRule, ids); err != nil {
+			util.Logger().Warnf(err, "find instance, %s: record dependency usage failed.", findFlag)
+		}
 	}
 
-	err = serviceUtil.AddServiceVersionRule(ctx, domainProject, provider, consumer)
+	deprecations := serviceUtil.GetRetiringVersions(ctx, providerDomainProject, ids)
 
-	if err != nil {
-		util.Logger().Errorf(err, "find instance failed, %s: add service version rule failed.", findFlag)
+	var governance []*pb.GovernanceConfig
+	if in.WithGovernance {
+		provider, _ := serviceUtil.GetService(ctx, providerDomainProject, ids[0])
+		governance = serviceUtil.GetGovernanceConfigs(ctx, providerDomainProject, provider)
+	}
+
+	if shared || !needDependency(ctx, domainProject, in, policy) {
 		return &pb.FindInstancesResponse{
-			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
-		}, err
+			Response:     pb.CreateResponse(pb.Response_SUCCESS, "Query service instances successfully."),
+			Instances:    instances,
+			Governance:   governance,
+			Deprecations: deprecations,
+			Revision:     rev,
+		}, nil
+	}
+
+	consumer := pb.MicroServiceToKey(domainProject, service)
+	//维护version的规则,servicename 可能是别名，所以重新获取；跨环境发现时按提供者所在的环境分别记录
+	recorded := make(map[string]struct{})
+	for _, providerId := range ids {
+		providerService, _ := serviceUtil.GetService(ctx, domainProject, providerId)
+		if providerService == nil {
+			continue
+		}
+		if _, ok := recorded[providerService.Environment]; ok {
+			continue
+		}
+		recorded[providerService.Environment] = struct{}{}
+		provider := &pb.MicroServiceKey{
+			Tenant:      domainProject,
+			Environment: providerService.Environment,
+			AppId:       in.AppId,
+			ServiceName: providerService.ServiceName,
+			Version:     in.VersionRule,
+		}
+
+		err = serviceUtil.GetDependencyWriter().Add(ctx, domainProject, provider, consumer)
+
+		if err != nil {
+			util.Logger().Errorf(err, "find instance failed, %s: add service version rule failed.", findFlag)
+			return &pb.FindInstancesResponse{
+				Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+			}, err
+		}
+	}
+	if len(recorded) == 0 {
+		util.Logger().Errorf(nil, "find instance failed, %s: no provider matched.", findFlag)
+		return &pb.FindInstancesResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "No provider matched."),
+		}, nil
 	}
 
 	return &pb.FindInstancesResponse{
-		Response:  pb.CreateResponse(pb.Response_SUCCESS, "Query service instances successfully."),
-		Instances: instances,
+		Response:     pb.CreateResponse(pb.Response_SUCCESS, "Query service instances successfully."),
+		Instances:    instances,
+		Governance:   governance,
+		Deprecations: deprecations,
+		Revision:     rev,
 	}, nil
 }
 
+// needDependency 请求或consumer的发现策略指定noDependency时不记录依赖关系，
+// 否则由auto_create_dependency配置决定
+func needDependency(ctx context.Context, domainProject string, in *pb.FindInstancesRequest, policy *pb.DiscoveryPolicy) bool {
+	if in.NoDependency || policy.GetNoDependency() {
+		return false
+	}
+	if serviceUtil.FeatureEnabled(ctx, serviceUtil.FEATURE_NO_IMPLICIT_DEPENDENCY, domainProject) {
+		return false
+	}
+	return apt.ServerInfo.Config.AutoCreateDependency
+}
+
 func (s *InstanceService) UpdateStatus(ctx context.Context, in *pb.UpdateInstanceStatusRequest) (*pb.UpdateInstanceStatusResponse, error) {
 	if in == nil || len(in.ServiceId) == 0 || len(in.InstanceId) == 0 {
 		util.Logger().Errorf(nil, "update instance status failed: invalid params.")
@@ -645,7 +913,7 @@ func (s *InstanceService) UpdateStatus(ctx context.Context, in *pb.UpdateInstanc
 	if err := apt.Validate(in); err != nil {
 		util.Logger().Errorf(nil, "update instance status failed, %s.", updateStatusFlag)
 		return &pb.UpdateInstanceStatusResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 
@@ -667,6 +935,19 @@ func (s *InstanceService) UpdateStatus(ctx context.Context, in *pb.UpdateInstanc
 	}
 
 	instance.Status = in.Status
+	if in.Status != pb.MSI_PENDING {
+		instance.ActivateTime = ""
+	}
+	// 每次状态变更都刷新原因，未携带原因时清空，避免残留上一次的原因
+	instance.StatusReason = nil
+	if len(in.ReasonCode) > 0 || len(in.ReasonMessage) > 0 {
+		instance.StatusReason = &pb.StatusReason{
+			Code:      in.ReasonCode,
+			Message:   in.ReasonMessage,
+			Timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+		}
+		updateStatusFlag = util.StringJoin([]string{updateStatusFlag, in.ReasonCode}, "/")
+	}
 
 	err, isInnerErr := updateInstance(ctx, domainProject, instance)
 	if err != nil {
@@ -687,8 +968,232 @@ func (s *InstanceService) UpdateStatus(ctx context.Context, in *pb.UpdateInstanc
 	}, nil
 }
 
+// UpdateCapacity 更新实例的容量提示，供客户端按容量分配请求
+func (s *InstanceService) UpdateCapacity(ctx context.Context, in *pb.UpdateInstanceCapacityRequest) (*pb.UpdateInstanceCapacityResponse, error) {
+	if in == nil || len(in.ServiceId) == 0 || len(in.InstanceId) == 0 {
+		util.Logger().Errorf(nil, "update instance capacity failed: invalid params.")
+		return &pb.UpdateInstanceCapacityResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	domainProject := util.ParseDomainProject(ctx)
+	instanceFlag := util.StringJoin([]string{in.ServiceId, in.InstanceId, strconv.Itoa(int(in.Capacity))}, "/")
+	if err := apt.Validate(in); err != nil {
+		util.Logger().Errorf(err, "update instance capacity failed, %s.", instanceFlag)
+		return &pb.UpdateInstanceCapacityResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	instance, err := serviceUtil.GetInstance(ctx, domainProject, in.ServiceId, in.InstanceId)
+	if err != nil {
+		util.Logger().Errorf(err, "update instance capacity failed, %s: get instance from etcd failed.", instanceFlag)
+		return &pb.UpdateInstanceCapacityResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, "Update service instance capacity failed."),
+		}, err
+	}
+	if instance == nil {
+		util.Logger().Errorf(nil, "update instance capacity failed, %s: instance not exist.", instanceFlag)
+		return &pb.UpdateInstanceCapacityResponse{
+			Response: pb.CreateResponse(scerr.ErrInstanceNotExists, "Service instance does not exist."),
+		}, nil
+	}
+
+	instance.Capacity = in.Capacity
+	err, isInnerErr := updateInstance(ctx, domainProject, instance)
+	if err != nil {
+		util.Logger().Errorf(err, "update instance capacity failed, %s: update instance lease failed.", instanceFlag)
+		if isInnerErr {
+			return &pb.UpdateInstanceCapacityResponse{
+				Response: pb.CreateResponse(scerr.ErrInternal, "Update instance capacity failed."),
+			}, err
+		}
+		return &pb.UpdateInstanceCapacityResponse{
+			Response: pb.CreateResponse(scerr.ErrInstanceNotExists, "Update instance capacity failed."),
+		}, nil
+	}
+
+	util.Logger().Infof("update instance capacity successful: %s.", instanceFlag)
+	return &pb.UpdateInstanceCapacityResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Update service instance capacity successfully."),
+	}, nil
+}
+
+// PromoteInstances 将待命实例提升为UP，未指定实例时提升服务的所有待命实例
+func (s *InstanceService) PromoteInstances(ctx context.Context, in *pb.PromoteInstancesRequest) (*pb.PromoteInstancesResponse, error) {
+	return s.upInstances(ctx, in, pb.MSI_STANDBY, pb.REASON_PROMOTION, "promote")
+}
+
+// ActivateInstances 激活预注册实例，未指定实例时激活服务的所有预注册实例，不论是否到达计划时间
+func (s *InstanceService) ActivateInstances(ctx context.Context, in *pb.PromoteInstancesRequest) (*pb.PromoteInstancesResponse, error) {
+	resp, err := s.upInstances(ctx, in, pb.MSI_PENDING, pb.REASON_ACTIVATION, "activate")
+	domainProject := util.ParseDomainProject(ctx)
+	for _, instanceId := range resp.InstanceIds {
+		if err := serviceUtil.DeletePendingInstanceIndex(ctx, domainProject, in.ServiceId, instanceId); err != nil {
+			util.Logger().Warnf(err, "delete activation index of instance %s/%s failed, it will be removed when due.",
+				in.ServiceId, instanceId)
+		}
+	}
+	return resp, err
+}
+
+// UnregisterInstances 注销服务中匹配选择条件的所有实例，dryRun时只返回匹配的实例，
+// 中途失败时返回已注销的实例
+func (s *InstanceService) UnregisterInstances(ctx context.Context, in *pb.UnregisterInstancesRequest) (*pb.UnregisterInstancesResponse, error) {
+	if in == nil {
+		util.Logger().Errorf(nil, "unregister instances failed: invalid params.")
+		return &pb.UnregisterInstancesResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	if err := apt.Validate(in); err != nil {
+		util.Logger().Errorf(err, "unregister instances failed: invalid parameters.")
+		return &pb.UnregisterInstancesResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+	selector, err := serviceUtil.NewInstanceSelector(in)
+	if err != nil {
+		util.Logger().Errorf(err, "unregister instances failed: invalid selector.")
+		return &pb.UnregisterInstancesResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	remoteIP := util.GetIPFromContext(ctx)
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ServiceId) {
+		util.Logger().Errorf(nil, "unregister instances failed, service %s, operator %s: service not exist.", in.ServiceId, remoteIP)
+		return &pb.UnregisterInstancesResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+
+	instances, err := serviceUtil.GetAllInstancesOfOneService(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "unregister instances failed, service %s: get instances from etcd failed.", in.ServiceId)
+		return &pb.UnregisterInstancesResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	matched := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		if selector.Match(instance) {
+			matched = append(matched, instance.InstanceId)
+		}
+	}
+	sort.Strings(matched)
+	if in.DryRun {
+		return &pb.UnregisterInstancesResponse{
+			Response:    pb.CreateResponse(pb.Response_SUCCESS, "Dry run unregister service instances successfully."),
+			InstanceIds: matched,
+		}, nil
+	}
+
+	done := make([]string, 0, len(matched))
+	for _, instanceId := range matched {
+		err, isInnerErr := revokeInstance(ctx, domainProject, in.ServiceId, instanceId)
+		if err != nil {
+			util.Logger().Errorf(err, "unregister instances failed, service %s, operator %s: revoke instance %s failed, %d done.",
+				in.ServiceId, remoteIP, instanceId, len(done))
+			if isInnerErr {
+				return &pb.UnregisterInstancesResponse{
+					Response:    pb.CreateResponse(scerr.ErrUnavailableBackend, "Revoke instance failed."),
+					InstanceIds: done,
+				}, err
+			}
+			// 已被其他请求注销或租约已过期
+			continue
+		}
+		done = append(done, instanceId)
+	}
+
+	util.Logger().Infof("unregister %d instance(s) of service %s successfully, operator %s.",
+		len(done), in.ServiceId, remoteIP)
+	return &pb.UnregisterInstancesResponse{
+		Response:    pb.CreateResponse(pb.Response_SUCCESS, "Unregister service instances successfully."),
+		InstanceIds: done,
+	}, nil
+}
+
+// upInstances 将服务中状态为from的实例变为UP，其它状态的实例跳过，重复操作不报错
+func (s *InstanceService) upInstances(ctx context.Context, in *pb.PromoteInstancesRequest, from, reason, action string) (*pb.PromoteInstancesResponse, error) {
+	if err := apt.Validate(in); err != nil {
+		util.Logger().Errorf(err, "%s instances failed: invalid parameters.", action)
+		return &pb.PromoteInstancesResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	remoteIP := util.GetIPFromContext(ctx)
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ServiceId) {
+		util.Logger().Errorf(nil, "%s instances failed, service %s, operator %s: service not exist.", action, in.ServiceId, remoteIP)
+		return &pb.PromoteInstancesResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+
+	instances, err := serviceUtil.GetAllInstancesOfOneService(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "%s instances failed, service %s: get instances from etcd failed.", action, in.ServiceId)
+		return &pb.PromoteInstancesResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	exists := make(map[string]*pb.MicroServiceInstance, len(instances))
+	for _, instance := range instances {
+		exists[instance.InstanceId] = instance
+	}
+
+	targets := instances
+	if len(in.InstanceIds) > 0 {
+		targets = make([]*pb.MicroServiceInstance, 0, len(in.InstanceIds))
+		for _, instanceId := range in.InstanceIds {
+			instance, ok := exists[instanceId]
+			if !ok {
+				util.Logger().Errorf(nil, "%s instances failed, service %s: instance %s not exist.", action, in.ServiceId, instanceId)
+				return &pb.PromoteInstancesResponse{
+					Response: pb.CreateResponseWithDetails(scerr.ErrInstanceNotExists, "Service instance does not exist.",
+						scerr.NewDetail(scerr.ErrInstanceNotExists, "instanceIds", instanceId)),
+				}, nil
+			}
+			targets = append(targets, instance)
+		}
+	}
+
+	done := make([]string, 0, len(targets))
+	for _, instance := range targets {
+		if instance.Status != from {
+			continue
+		}
+		resp, err := s.UpdateStatus(ctx, &pb.UpdateInstanceStatusRequest{
+			ServiceId:  in.ServiceId,
+			InstanceId: instance.InstanceId,
+			Status:     pb.MSI_UP,
+			ReasonCode: reason,
+		})
+		if err != nil || resp.Response.Code != pb.Response_SUCCESS {
+			util.Logger().Errorf(err, "%s instances failed, service %s: %s instance %s failed, %d done.",
+				action, in.ServiceId, action, instance.InstanceId, len(done))
+			return &pb.PromoteInstancesResponse{
+				Response:    resp.Response,
+				InstanceIds: done,
+			}, err
+		}
+		done = append(done, instance.InstanceId)
+	}
+
+	util.Logger().Infof("%s %d %s instance(s) of service %s successfully, operator %s.",
+		action, len(done), strings.ToLower(from), in.ServiceId, remoteIP)
+	return &pb.PromoteInstancesResponse{
+		Response:    pb.CreateResponse(pb.Response_SUCCESS, "Update instances to UP successfully."),
+		InstanceIds: done,
+	}, nil
+}
+
 func (s *InstanceService) UpdateInstanceProperties(ctx context.Context, in *pb.UpdateInstancePropsRequest) (*pb.UpdateInstancePropsResponse, error) {
-	if in == nil || len(in.ServiceId) == 0 || len(in.InstanceId) == 0 || in.Properties == nil {
+	if in == nil || len(in.ServiceId) == 0 || len(in.InstanceId) == 0 || (in.Properties == nil && !in.Merge) {
 		util.Logger().Errorf(nil, "update instance properties failed: invalid params.")
 		return &pb.UpdateInstancePropsResponse{
 			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
@@ -699,6 +1204,39 @@ func (s *InstanceService) UpdateInstanceProperties(ctx context.Context, in *pb.U
 	domainProject := util.ParseDomainProject(ctx)
 	instanceFlag := util.StringJoin([]string{in.ServiceId, in.InstanceId}, "/")
 
+	if propErr := apt.ValidateProperties(domainProject, in.Properties); propErr != nil {
+		util.Logger().Errorf(propErr, "update instance properties failed, %s: invalid instance properties.", instanceFlag)
+		return &pb.UpdateInstancePropsResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidProperties, propErr.Error(),
+				scerr.NewDetail(scerr.ErrInvalidProperties, propErr.Field(), propErr.Reason)),
+		}, nil
+	}
+
+	if guardErr := serviceUtil.AdmitWrite(ctx, serviceUtil.WRITE_PRIORITY_LOW); guardErr != nil {
+		util.Logger().Errorf(guardErr, "update instance properties failed, %s: storage guard rejected.", instanceFlag)
+		return &pb.UpdateInstancePropsResponse{
+			Response: pb.CreateResponse(guardErr.Code, guardErr.Detail),
+		}, nil
+	}
+
+	if in.Merge {
+		respErr := patchInstanceProperties(ctx, domainProject, in)
+		if respErr != nil {
+			util.Logger().Errorf(respErr, "merge instance properties failed, %s.", instanceFlag)
+			resp := &pb.UpdateInstancePropsResponse{
+				Response: pb.CreateResponseWithDetails(respErr.Code, respErr.Detail, respErr.Details...),
+			}
+			if respErr.StatusCode() == http.StatusInternalServerError {
+				return resp, respErr
+			}
+			return resp, nil
+		}
+		util.Logger().Infof("merge instance properties successful: %s.", instanceFlag)
+		return &pb.UpdateInstancePropsResponse{
+			Response: pb.CreateResponse(pb.Response_SUCCESS, "Update service instance information successfully."),
+		}, nil
+	}
+
 	var instance *pb.MicroServiceInstance
 
 	instance, err = serviceUtil.GetInstance(ctx, domainProject, in.ServiceId, in.InstanceId)
@@ -715,10 +1253,14 @@ func (s *InstanceService) UpdateInstanceProperties(ctx context.Context, in *pb.U
 		}, nil
 	}
 
-	instance.Properties = map[string]string{}
+	properties := map[string]string{}
 	for property := range in.Properties {
-		instance.Properties[property] = in.Properties[property]
+		properties[property] = in.Properties[property]
 	}
+	if apt.ServerInfo.Config.EnrichInstanceMetadata {
+		serviceUtil.KeepReservedProperties(instance.Properties, properties)
+	}
+	instance.Properties = properties
 
 	err, isInnerErr := updateInstance(ctx, domainProject, instance)
 	if err != nil {
@@ -739,13 +1281,75 @@ func (s *InstanceService) UpdateInstanceProperties(ctx context.Context, in *pb.U
 	}, nil
 }
 
-func updateInstance(ctx context.Context, domainProject string, instance *pb.MicroServiceInstance) (err error, isInnerErr bool) {
-	leaseID, err := serviceUtil.GetLeaseId(ctx, domainProject, instance.ServiceId, instance.InstanceId)
-	if err != nil {
-		return err, true
+// patchInstanceProperties 读取实例后合并properties，以CAS方式写回，
+// 合并后的properties仍需满足租户的限制
+func patchInstanceProperties(ctx context.Context, domainProject string, in *pb.UpdateInstancePropsRequest) *scerr.Error {
+	key := apt.GenerateInstanceKey(domainProject, in.ServiceId, in.InstanceId)
+	for i := 0; i < MAX_PROPERTIES_PATCH_RETRIES; i++ {
+		resp, err := backend.Registry().Do(ctx, registry.GET, registry.WithStrKey(key))
+		if err != nil {
+			return scerr.NewError(scerr.ErrUnavailableBackend, err.Error())
+		}
+		if len(resp.Kvs) == 0 {
+			return scerr.NewError(scerr.ErrInstanceNotExists, "Service instance does not exist.")
+		}
+		instance := &pb.MicroServiceInstance{}
+		if err := json.Unmarshal(resp.Kvs[0].Value, instance); err != nil {
+			return scerr.NewError(scerr.ErrInternal, err.Error())
+		}
+
+		properties := serviceUtil.MergeProperties(instance.Properties, in.Properties, in.RemoveProperties)
+		if apt.ServerInfo.Config.EnrichInstanceMetadata {
+			serviceUtil.KeepReservedProperties(instance.Properties, properties)
+		}
+		if propErr := apt.ValidateProperties(domainProject, properties); propErr != nil {
+			return scerr.NewError(scerr.ErrInvalidProperties, propErr.Error()).WithDetails(
+				scerr.NewDetail(scerr.ErrInvalidProperties, propErr.Field(), propErr.Reason))
+		}
+		instance.Properties = properties
+		instance.ModTimestamp = strconv.FormatInt(time.Now().Unix(), 10)
+
+		var leaseID int64
+		if !serviceUtil.IsStaticInstance(instance) {
+			leaseID, err = serviceUtil.GetLeaseId(ctx, domainProject, instance.ServiceId, instance.InstanceId)
+			if err != nil {
+				return scerr.NewError(scerr.ErrInternal, err.Error())
+			}
+			if leaseID == -1 {
+				return scerr.NewError(scerr.ErrInstanceNotExists, "Instance's leaseId not exist.")
+			}
+		}
+
+		data, err := json.Marshal(instance)
+		if err != nil {
+			return scerr.NewError(scerr.ErrInternal, err.Error())
+		}
+		txnResp, err := backend.Registry().TxnWithCmp(ctx,
+			[]registry.PluginOp{registry.OpPut(registry.WithStrKey(key), registry.WithValue(data),
+				registry.WithLease(leaseID))},
+			[]registry.CompareOp{registry.OpCmp(registry.CmpStrModRev(key), registry.CMP_EQUAL, resp.Kvs[0].ModRevision)},
+			nil)
+		if err != nil {
+			return scerr.NewError(scerr.ErrUnavailableBackend, err.Error())
+		}
+		if txnResp.Succeeded {
+			return nil
+		}
 	}
-	if leaseID == -1 {
-		return errors.New("Instance's leaseId not exist."), false
+	return scerr.NewError(scerr.ErrPropertiesConflict,
+		fmt.Sprintf("Instance %s/%s is modified concurrently.", in.ServiceId, in.InstanceId))
+}
+
+func updateInstance(ctx context.Context, domainProject string, instance *pb.MicroServiceInstance) (err error, isInnerErr bool) {
+	var leaseID int64
+	if !serviceUtil.IsStaticInstance(instance) {
+		leaseID, err = serviceUtil.GetLeaseId(ctx, domainProject, instance.ServiceId, instance.InstanceId)
+		if err != nil {
+			return err, true
+		}
+		if leaseID == -1 {
+			return errors.New("Instance's leaseId not exist."), false
+		}
 	}
 
 	instance.ModTimestamp = strconv.FormatInt(time.Now().Unix(), 10)
@@ -774,6 +1378,25 @@ func (s *InstanceService) WatchPreOpera(ctx context.Context, in *pb.WatchInstanc
 	if !serviceUtil.ServiceExist(ctx, domainProject, in.SelfServiceId) {
 		return errors.New("Service does not exist.")
 	}
+	if in.Revision < 0 {
+		return errors.New("Invalid revision.")
+	}
+	if err := nf.CheckGroup(in.Group); err != nil {
+		return err
+	}
+	if err := serviceUtil.CheckWatchSubscription(in.Subscription); err != nil {
+		return err
+	}
+	if len(in.Subscription) > 0 {
+		// 订阅在事件历史不完整时从注册中心的历史版本续接
+		if len(in.Group) > 0 {
+			return errors.New("Subscription is not supported by group watch.")
+		}
+		return nil
+	}
+	if in.Revision > 0 {
+		return nf.GetNotifyService().CheckRevision(in.Revision)
+	}
 	return nil
 }
 
@@ -783,31 +1406,115 @@ func (s *InstanceService) Watch(in *pb.WatchInstanceRequest, stream pb.ServiceIn
 		util.Logger().Errorf(err, "establish watch failed: invalid params.")
 		return err
 	}
-	domainProject := util.ParseDomainProject(stream.Context())
-	watcher := nf.NewInstanceWatcher(in.SelfServiceId, apt.GetInstanceRootKey(domainProject)+"/")
-	err = nf.GetNotifyService().AddSubscriber(watcher)
+	watcher, err := nf.NewInstanceRequestWatcher(stream.Context(), in.SelfServiceId, in.Revision, in.Subscription)
+	if err != nil {
+		util.Logger().Errorf(err, "establish watch failed: load subscription %s error, service %s",
+			in.Subscription, in.SelfServiceId)
+		return err
+	}
+	if in.Compact {
+		watcher.EnableCompaction(nf.GetNotifyService().Config.CompactWindow)
+	}
+	if len(in.Group) > 0 {
+		group, err := nf.JoinGroup(in.Group, watcher)
+		if err != nil {
+			util.Logger().Errorf(err, "establish watch failed: join group %s error, watcher %s %s",
+				in.Group, watcher.Subject(), watcher.Id())
+			return err
+		}
+		defer nf.LeaveGroup(group, watcher)
+	} else {
+		err = nf.GetNotifyService().AddSubscriber(watcher)
+		if err != nil {
+			util.Logger().Errorf(err, "establish watch failed: notify service error, watcher %s %s",
+				watcher.Subject(), watcher.Id())
+			return err
+		}
+		defer nf.GetNotifyService().RemoveSubscriber(watcher)
+	}
 	util.Logger().Infof("start watch instance status, watcher %s %s", watcher.Subject(), watcher.Id())
 	return nf.HandleWatchJob(watcher, stream, nf.GetNotifyService().Config.NotifyTimeout)
 }
 
+// WatchInvalidations 推送消费者所有提供者的失效通知，只携带提供者key和revision
+func (s *InstanceService) WatchInvalidations(in *pb.WatchInstanceRequest, stream pb.ServiceInstanceCtrl_WatchInvalidationsServer) error {
+	var err error
+	if err = s.invalidationWatchPreOpera(stream.Context(), in); err != nil {
+		util.Logger().Errorf(err, "establish invalidation watch failed: invalid params.")
+		return err
+	}
+	domainProject := util.ParseDomainProject(stream.Context())
+	watcher := nf.NewInvalidationWatcher(in.SelfServiceId, domainProject)
+	err = nf.GetNotifyService().AddSubscriber(watcher)
+	if err != nil {
+		util.Logger().Errorf(err, "establish invalidation watch failed: notify service error, watcher %s %s",
+			watcher.Subject(), watcher.Id())
+		return err
+	}
+	defer nf.GetNotifyService().RemoveSubscriber(watcher)
+	util.Logger().Infof("start watch invalidations, watcher %s %s", watcher.Subject(), watcher.Id())
+	return nf.HandleWatchJob(&watcher.ListWatcher, stream, nf.GetNotifyService().Config.NotifyTimeout)
+}
+
 func (s *InstanceService) WebSocketWatch(ctx context.Context, in *pb.WatchInstanceRequest, conn *websocket.Conn) {
 	util.Logger().Infof("New a web socket watch with %s", in.SelfServiceId)
-	if err := s.WatchPreOpera(ctx, in); err != nil {
+	encoder, err := s.webSocketWatchPreOpera(ctx, in)
+	if err != nil {
 		nf.EstablishWebSocketError(conn, err)
 		return
 	}
-	nf.DoWebSocketWatch(ctx, in.SelfServiceId, conn)
+	nf.DoWebSocketWatch(ctx, in.SelfServiceId, in.Revision, encoder, in.Compact, in.Group, in.Subscription, conn)
 }
 
 func (s *InstanceService) WebSocketListAndWatch(ctx context.Context, in *pb.WatchInstanceRequest, conn *websocket.Conn) {
 	util.Logger().Infof("New a web socket list and watch with %s", in.SelfServiceId)
-	if err := s.WatchPreOpera(ctx, in); err != nil {
+	encoder, err := s.webSocketWatchPreOpera(ctx, in)
+	if err == nil && len(in.Subscription) > 0 {
+		err = errors.New("Subscription is not supported by list watch.")
+	}
+	if err != nil {
 		nf.EstablishWebSocketError(conn, err)
 		return
 	}
 	nf.DoWebSocketListAndWatch(ctx, in.SelfServiceId, func() ([]*pb.WatchInstanceResponse, int64) {
 		return serviceUtil.QueryAllProvidersIntances(ctx, in.SelfServiceId)
-	}, conn)
+	}, encoder, in.Compact, in.Group, conn)
+}
+
+func (s *InstanceService) WebSocketWatchInvalidations(ctx context.Context, in *pb.WatchInstanceRequest, conn *websocket.Conn) {
+	util.Logger().Infof("New a web socket invalidation watch with %s", in.SelfServiceId)
+	if err := s.invalidationWatchPreOpera(ctx, in); err != nil {
+		nf.EstablishWebSocketError(conn, err)
+		return
+	}
+	encoder, err := nf.NewEventEncoder(in.Format, in.Version)
+	if err != nil {
+		nf.EstablishWebSocketError(conn, err)
+		return
+	}
+	nf.DoWebSocketWatchInvalidations(ctx, in.SelfServiceId, encoder, conn)
+}
+
+// webSocketWatchPreOpera 校验请求并按协商的格式和版本创建事件编码器
+func (s *InstanceService) webSocketWatchPreOpera(ctx context.Context, in *pb.WatchInstanceRequest) (nf.EventEncoder, error) {
+	if err := s.WatchPreOpera(ctx, in); err != nil {
+		return nil, err
+	}
+	return nf.NewEventEncoder(in.Format, in.Version)
+}
+
+// invalidationWatchPreOpera 失效通知按消费者合并推送，不支持消费者组
+func (s *InstanceService) invalidationWatchPreOpera(ctx context.Context, in *pb.WatchInstanceRequest) error {
+	if err := s.WatchPreOpera(ctx, in); err != nil {
+		return err
+	}
+	if len(in.Group) > 0 {
+		return errors.New("Group is not supported by invalidation watch.")
+	}
+	if len(in.Subscription) > 0 {
+		return errors.New("Subscription is not supported by invalidation watch.")
+	}
+	return nil
 }
 
 func (s *InstanceService) ClusterHealth(ctx context.Context) (*pb.GetInstancesResponse, error) {
@@ -17,14 +17,22 @@
 package service_test
 
 import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"github.com/apache/incubator-servicecomb-service-center/server/core"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	gov "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/governance/buildin"
 	"github.com/apache/incubator-servicecomb-service-center/server/service"
+	nf "github.com/apache/incubator-servicecomb-service-center/server/service/notification"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"io"
+	"strings"
+	"time"
 )
 
 type grpcWatchServer struct {
@@ -39,6 +47,45 @@ func (x *grpcWatchServer) Context() context.Context {
 	return getContext()
 }
 
+type cancelableWatchServer struct {
+	grpcWatchServer
+	ctx context.Context
+}
+
+func (x *cancelableWatchServer) Context() context.Context {
+	return x.ctx
+}
+
+type recordingWatchServer struct {
+	cancelableWatchServer
+	events chan *pb.WatchInstanceResponse
+}
+
+func (x *recordingWatchServer) Send(m *pb.WatchInstanceResponse) error {
+	x.events <- m
+	return nil
+}
+
+type keepAliveServer struct {
+	grpcWatchServer
+	requests  []*pb.KeepAliveRequest
+	responses []*pb.KeepAliveResponse
+}
+
+func (x *keepAliveServer) Recv() (*pb.KeepAliveRequest, error) {
+	if len(x.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := x.requests[0]
+	x.requests = x.requests[1:]
+	return req, nil
+}
+
+func (x *keepAliveServer) Send(m *pb.KeepAliveResponse) error {
+	x.responses = append(x.responses, m)
+	return nil
+}
+
 var _ = Describe("'Instance' service", func() {
 	Describe("execute 'register' operartion", func() {
 		var (
@@ -412,6 +459,73 @@ var _ = Describe("'Instance' service", func() {
 				Expect(resp.Response.Code).ToNot(Equal(pb.Response_SUCCESS))
 			})
 		})
+
+		Context("when heartbeat with state", func() {
+			It("should be passed", func() {
+				By("invalid state")
+				resp, err := instanceResource.Heartbeat(getContext(), &pb.HeartbeatRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId1,
+					State:      map[string]string{"1load": "0.5"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("valid state")
+				resp, err = instanceResource.Heartbeat(getContext(), &pb.HeartbeatRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId1,
+					State:      map[string]string{"load": "0.5"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err := instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceId1,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGet.Instance.State).ToNot(BeNil())
+				Expect(respGet.Instance.State.Values["load"]).To(Equal("0.5"))
+
+				By("heartbeat without state")
+				resp, err = instanceResource.Heartbeat(getContext(), &pb.HeartbeatRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId1,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respAll, err := instanceResource.GetInstances(getContext(), &pb.GetInstancesRequest{
+					ConsumerServiceId: serviceId,
+					ProviderServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respAll.Response.Code).To(Equal(pb.Response_SUCCESS))
+				for _, instance := range respAll.Instances {
+					if instance.InstanceId == instanceId1 {
+						Expect(instance.State.Values["load"]).To(Equal("0.5"))
+					} else {
+						Expect(instance.State).To(BeNil())
+					}
+				}
+
+				By("batch heartbeat with invalid state")
+				respSet, err := instanceResource.HeartbeatSet(getContext(), &pb.HeartbeatSetRequest{
+					Instances: []*pb.HeartbeatSetElement{
+						{
+							ServiceId:  serviceId,
+							InstanceId: instanceId2,
+							State:      map[string]string{"load": strings.Repeat("1", 65)},
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respSet.Response.Code).To(Equal(scerr.ErrInvalidParams))
+			})
+		})
 	})
 
 	Describe("execute 'clusterHealth' operartion", func() {
@@ -536,6 +650,73 @@ var _ = Describe("'Instance' service", func() {
 			})
 		})
 
+		Context("when update instance status with a reason", func() {
+			It("should be passed", func() {
+				By("update status with reason code and message")
+				respUpdateStatus, err := instanceResource.UpdateStatus(getContext(), &pb.UpdateInstanceStatusRequest{
+					ServiceId:     serviceId,
+					InstanceId:    instanceId,
+					Status:        pb.MSI_DOWN,
+					ReasonCode:    pb.REASON_DEPLOYMENT,
+					ReasonMessage: "rolling upgrade",
+				})
+				Expect(err).To(BeNil())
+				Expect(respUpdateStatus.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err := instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGet.Instance.Status).To(Equal(pb.MSI_DOWN))
+				Expect(respGet.Instance.StatusReason).ToNot(BeNil())
+				Expect(respGet.Instance.StatusReason.Code).To(Equal(pb.REASON_DEPLOYMENT))
+				Expect(respGet.Instance.StatusReason.Message).To(Equal("rolling upgrade"))
+				Expect(respGet.Instance.StatusReason.Timestamp).ToNot(BeEmpty())
+
+				By("update status without reason will clear the last one")
+				respUpdateStatus, err = instanceResource.UpdateStatus(getContext(), &pb.UpdateInstanceStatusRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId,
+					Status:     pb.MSI_UP,
+				})
+				Expect(err).To(BeNil())
+				Expect(respUpdateStatus.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err = instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGet.Instance.StatusReason).To(BeNil())
+
+				By("invalid reason code")
+				respUpdateStatus, err = instanceResource.UpdateStatus(getContext(), &pb.UpdateInstanceStatusRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId,
+					Status:     pb.MSI_DOWN,
+					ReasonCode: "health check!",
+				})
+				Expect(err).To(BeNil())
+				Expect(respUpdateStatus.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("reason message is too long")
+				respUpdateStatus, err = instanceResource.UpdateStatus(getContext(), &pb.UpdateInstanceStatusRequest{
+					ServiceId:     serviceId,
+					InstanceId:    instanceId,
+					Status:        pb.MSI_DOWN,
+					ReasonCode:    pb.REASON_MAINTENANCE,
+					ReasonMessage: strings.Repeat("x", 257),
+				})
+				Expect(err).To(BeNil())
+				Expect(respUpdateStatus.Response.Code).To(Equal(scerr.ErrInvalidParams))
+			})
+		})
+
 		Context("when update instance properties", func() {
 			It("should be passed", func() {
 				By("update instance properties")
@@ -550,6 +731,58 @@ var _ = Describe("'Instance' service", func() {
 				Expect(err).To(BeNil())
 				Expect(respUpdateProperties.Response.Code).To(Equal(pb.Response_SUCCESS))
 
+				By("merge instance properties")
+				respUpdateProperties, err = instanceResource.UpdateInstanceProperties(getContext(), &pb.UpdateInstancePropsRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId,
+					Properties: map[string]string{
+						"merged": "1",
+					},
+					Merge: true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respUpdateProperties.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err := instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Instance.Properties["test"]).To(Equal("test"))
+				Expect(respGet.Instance.Properties["merged"]).To(Equal("1"))
+
+				respUpdateProperties, err = instanceResource.UpdateInstanceProperties(getContext(), &pb.UpdateInstancePropsRequest{
+					ServiceId:        serviceId,
+					InstanceId:       instanceId,
+					Merge:            true,
+					RemoveProperties: []string{"test"},
+				})
+				Expect(err).To(BeNil())
+				Expect(respUpdateProperties.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err = instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceId,
+				})
+				Expect(err).To(BeNil())
+				_, ok := respGet.Instance.Properties["test"]
+				Expect(ok).To(BeFalse())
+				Expect(respGet.Instance.Properties["merged"]).To(Equal("1"))
+
+				By("merge into an instance does not exist")
+				respUpdateProperties, err = instanceResource.UpdateInstanceProperties(getContext(), &pb.UpdateInstancePropsRequest{
+					ServiceId:  serviceId,
+					InstanceId: "notexistins",
+					Properties: map[string]string{
+						"test": "test",
+					},
+					Merge: true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respUpdateProperties.Response.Code).To(Equal(scerr.ErrInstanceNotExists))
+
 				By("instance does not exist")
 				respUpdateProperties, err = instanceResource.UpdateInstanceProperties(getContext(), &pb.UpdateInstancePropsRequest{
 					ServiceId:  serviceId,
@@ -841,6 +1074,165 @@ var _ = Describe("'Instance' service", func() {
 
 		})
 
+		Context("when query instances with governance configs", func() {
+			It("should be passed", func() {
+				gov.RegisterConfigSource(func(ctx context.Context, domainProject string, provider *pb.MicroService) ([]*pb.GovernanceConfig, error) {
+					if provider.ServiceName != "query_instance_service" {
+						return nil, nil
+					}
+					return []*pb.GovernanceConfig{
+						{Key: "loadbalance", Value: "RoundRobin"},
+						{Key: "timeout", Value: "3000"},
+					}, nil
+				})
+				gov.RegisterConfigSource(func(ctx context.Context, domainProject string, provider *pb.MicroService) ([]*pb.GovernanceConfig, error) {
+					if provider.ServiceName != "query_instance_service" {
+						return nil, nil
+					}
+					return []*pb.GovernanceConfig{
+						{Key: "timeout", Value: "5000"},
+					}, nil
+				})
+
+				By("without governance")
+				respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId1,
+					AppId:             "query_instance",
+					ServiceName:       "query_instance_service",
+					VersionRule:       "latest",
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Governance)).To(Equal(0))
+
+				By("with governance")
+				respFind, err = instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId1,
+					AppId:             "query_instance",
+					ServiceName:       "query_instance_service",
+					VersionRule:       "latest",
+					WithGovernance:    true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respFind.Instances[0].InstanceId).To(Equal(instanceId2))
+				Expect(len(respFind.Governance)).To(Equal(2))
+				Expect(respFind.Governance[0].Key).To(Equal("loadbalance"))
+				Expect(respFind.Governance[1].Value).To(Equal("5000"))
+
+				By("no config matched")
+				respFind, err = instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId4,
+					AppId:             "query_instance",
+					ServiceName:       "query_instance_diff_env_service",
+					VersionRule:       "1.0.0",
+					WithGovernance:    true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Instances)).To(Equal(1))
+				Expect(len(respFind.Governance)).To(Equal(0))
+			})
+		})
+
+		Context("when query retiring provider versions", func() {
+			It("should be passed", func() {
+				create := func(serviceName, version string) string {
+					resp, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+						Service: &pb.MicroService{
+							AppId:       "query_retire",
+							ServiceName: serviceName,
+							Version:     version,
+							Level:       "FRONT",
+							Status:      pb.MS_UP,
+						},
+					})
+					Expect(err).To(BeNil())
+					Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+					return resp.ServiceId
+				}
+				consumerId := create("query_retire_consumer", "1.0.0")
+				providerId1 := create("query_retire_provider", "1.0.0")
+				providerId2 := create("query_retire_provider", "2.0.0")
+
+				By("no retiring version")
+				respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: consumerId,
+					AppId:             "query_retire",
+					ServiceName:       "query_retire_provider",
+					VersionRule:       "1.0.0+",
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Deprecations)).To(Equal(0))
+
+				By("retiring version in the future")
+				respRetire, err := serviceResource.UpdateRetirement(getContext(), &pb.UpdateServiceRetirementRequest{
+					ServiceId: providerId1,
+					Retirement: &pb.ServiceRetirement{
+						TargetDate: "2999-01-01",
+						Message:    "please upgrade to 2.0.0",
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respRetire.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respFind, err = instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: consumerId,
+					AppId:             "query_retire",
+					ServiceName:       "query_retire_provider",
+					VersionRule:       "1.0.0+",
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Deprecations)).To(Equal(1))
+				Expect(respFind.Deprecations[0].ServiceId).To(Equal(providerId1))
+				Expect(respFind.Deprecations[0].TargetDate).To(Equal("2999-01-01"))
+				Expect(respFind.Deprecations[0].Message).To(Equal("please upgrade to 2.0.0"))
+
+				By("retired version excluded from latest")
+				respRetire, err = serviceResource.UpdateRetirement(getContext(), &pb.UpdateServiceRetirementRequest{
+					ServiceId: providerId2,
+					Retirement: &pb.ServiceRetirement{
+						TargetDate:        "2000-01-01",
+						ExcludeFromLatest: true,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respRetire.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respFind, err = instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: consumerId,
+					AppId:             "query_retire",
+					ServiceName:       "query_retire_provider",
+					VersionRule:       "latest",
+					NoDependency:      true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Deprecations)).To(Equal(1))
+				Expect(respFind.Deprecations[0].ServiceId).To(Equal(providerId1))
+
+				By("cancel the retirement")
+				respRetire, err = serviceResource.UpdateRetirement(getContext(), &pb.UpdateServiceRetirementRequest{
+					ServiceId: providerId2,
+				})
+				Expect(err).To(BeNil())
+				Expect(respRetire.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respFind, err = instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: consumerId,
+					AppId:             "query_retire",
+					ServiceName:       "query_retire_provider",
+					VersionRule:       "latest",
+					NoDependency:      true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Deprecations)).To(Equal(0))
+			})
+		})
+
 		Context("when query instances between diff dimensions", func() {
 			It("should be failed", func() {
 				By("diff appId")
@@ -1185,5 +1577,1214 @@ var _ = Describe("'Instance' service", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		Context("when the watchers exceed the limit", func() {
+			It("should be failed", func() {
+				IC := instanceResource.(*service.InstanceService)
+				ns := nf.GetNotifyService()
+				ns.Config = nf.NotifyServiceConfig{MaxSubscribersPerSubject: 1}
+				ns.Start()
+				defer ns.Stop()
+
+				By("the first watcher is accepted")
+				ctx, cancel := context.WithCancel(getContext())
+				done := make(chan error, 1)
+				go func() {
+					done <- IC.Watch(&pb.WatchInstanceRequest{
+						SelfServiceId: serviceId,
+					}, &cancelableWatchServer{ctx: ctx})
+				}()
+				Eventually(func() int64 {
+					return ns.Subscribers(nf.INSTANCE, "")
+				}).Should(Equal(int64(1)))
+
+				By("the second watcher is rejected")
+				err := IC.Watch(&pb.WatchInstanceRequest{
+					SelfServiceId: serviceId,
+				}, &grpcWatchServer{})
+				Expect(err).NotTo(BeNil())
+				_, ok := err.(*nf.SubscriberLimitError)
+				Expect(ok).To(BeTrue())
+
+				By("the quota is released after the first watcher closed")
+				cancel()
+				Eventually(done).Should(Receive(BeNil()))
+				Expect(ns.Subscribers(nf.INSTANCE, "")).To(Equal(int64(0)))
+			})
+		})
+
+		Context("when watch with consumer group", func() {
+			It("should share one subscriber", func() {
+				IC := instanceResource.(*service.InstanceService)
+				ns := nf.GetNotifyService()
+				ns.Config = nf.NotifyServiceConfig{MaxSubscribersPerSubject: 1}
+				ns.Start()
+				defer ns.Stop()
+
+				By("invalid group")
+				err := IC.WatchPreOpera(getContext(), &pb.WatchInstanceRequest{
+					SelfServiceId: serviceId,
+					Group:         "-group",
+				})
+				Expect(err).NotTo(BeNil())
+
+				By("group is not supported by invalidation watch")
+				err = IC.WatchInvalidations(&pb.WatchInstanceRequest{
+					SelfServiceId: serviceId,
+					Group:         "group",
+				}, &grpcWatchServer{})
+				Expect(err).NotTo(BeNil())
+
+				By("the replicas in the same group are accepted")
+				ctx, cancel := context.WithCancel(getContext())
+				done := make(chan error, 2)
+				streams := make([]*recordingWatchServer, 2)
+				for i := range streams {
+					streams[i] = &recordingWatchServer{
+						cancelableWatchServer: cancelableWatchServer{ctx: ctx},
+						events:                make(chan *pb.WatchInstanceResponse, 10),
+					}
+					go func(stream *recordingWatchServer) {
+						done <- IC.Watch(&pb.WatchInstanceRequest{
+							SelfServiceId: serviceId,
+							Group:         "group",
+						}, stream)
+					}(streams[i])
+				}
+				subject := core.GetInstanceRootKey("default/default") + "/"
+				Eventually(func() int {
+					return nf.GroupMembers(nf.INSTANCE, serviceId, subject, "group")
+				}).Should(Equal(2))
+				Expect(ns.Subscribers(nf.INSTANCE, "")).To(Equal(int64(1)))
+
+				By("the event is delivered to all replicas")
+				provider := &pb.MicroServiceKey{AppId: "watch_group", ServiceName: "a", Version: "1.0.0"}
+				instance := &pb.MicroServiceInstance{ServiceId: "a", InstanceId: "1"}
+				nf.PublishInstanceEvent("default/default", pb.EVT_CREATE, provider, instance, 10, []string{serviceId})
+				for _, stream := range streams {
+					var event *pb.WatchInstanceResponse
+					Eventually(stream.events, 2*time.Second).Should(Receive(&event))
+					Expect(event.Action).To(Equal(string(pb.EVT_CREATE)))
+				}
+
+				cancel()
+				Eventually(done).Should(Receive(BeNil()))
+				Eventually(done).Should(Receive(BeNil()))
+				Expect(ns.Subscribers(nf.INSTANCE, "")).To(Equal(int64(0)))
+			})
+		})
+
+		Context("when watch invalidations", func() {
+			It("should be passed", func() {
+				IC := instanceResource.(*service.InstanceService)
+				ns := nf.GetNotifyService()
+				ns.Config = nf.NotifyServiceConfig{}
+				ns.Start()
+				defer ns.Stop()
+
+				By("service does not exist")
+				err := IC.WatchInvalidations(&pb.WatchInstanceRequest{
+					SelfServiceId: "-1",
+				}, &grpcWatchServer{})
+				Expect(err).NotTo(BeNil())
+
+				By("the changes of the same provider are merged")
+				ctx, cancel := context.WithCancel(getContext())
+				stream := &recordingWatchServer{
+					cancelableWatchServer: cancelableWatchServer{ctx: ctx},
+					events:                make(chan *pb.WatchInstanceResponse, 10),
+				}
+				done := make(chan error, 1)
+				go func() {
+					done <- IC.WatchInvalidations(&pb.WatchInstanceRequest{
+						SelfServiceId: serviceId,
+					}, stream)
+				}()
+				Eventually(func() int64 {
+					return ns.Subscribers(nf.INVALIDATION, "")
+				}).Should(Equal(int64(1)))
+
+				providerA := &pb.MicroServiceKey{AppId: "watch_invalidation", ServiceName: "a", Version: "1.0.0"}
+				providerB := &pb.MicroServiceKey{AppId: "watch_invalidation", ServiceName: "b", Version: "1.0.0"}
+				instance := &pb.MicroServiceInstance{ServiceId: "a", InstanceId: "1"}
+				nf.PublishInstanceEvent("default/default", pb.EVT_CREATE, providerA, instance, 10, []string{serviceId})
+				nf.PublishInstanceEvent("default/default", pb.EVT_UPDATE, providerA, instance, 12, []string{serviceId})
+				nf.PublishInstanceEvent("default/default", pb.EVT_UPDATE, providerA, instance, 11, []string{serviceId})
+				nf.PublishRuleEvent("default/default", providerB, "DENY", 13, serviceId)
+
+				received := map[string]*pb.WatchInstanceResponse{}
+				for i := 0; i < 2; i++ {
+					var event *pb.WatchInstanceResponse
+					Eventually(stream.events, 2*time.Second).Should(Receive(&event))
+					Expect(event.Action).To(Equal(string(pb.EVT_INVALIDATE)))
+					Expect(event.Instance).To(BeNil())
+					received[event.Key.ServiceName] = event
+				}
+				Expect(received["a"].Revision).To(Equal(int64(12)))
+				Expect(received["b"].Revision).To(Equal(int64(13)))
+				Consistently(stream.events, nf.DEFAULT_INVALIDATION_FLUSH_INTERVAL*2).ShouldNot(Receive())
+
+				cancel()
+				Eventually(done).Should(Receive(BeNil()))
+				Expect(ns.Subscribers(nf.INVALIDATION, "")).To(Equal(int64(0)))
+			})
+		})
+	})
+
+	Describe("execute 'enrich' operartion", func() {
+		var (
+			serviceId  string
+			instanceId string
+		)
+
+		It("should be passed", func() {
+			respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "enrich_instance_service",
+					AppId:       "enrich_instance_service",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreate.ServiceId
+		})
+
+		Context("when enrich instance metadata is enabled", func() {
+			It("should be stamped", func() {
+				core.ServerInfo.Config.EnrichInstanceMetadata = true
+				defer func() {
+					core.ServerInfo.Config.EnrichInstanceMetadata = false
+				}()
+
+				ctx := util.SetContext(getContext(), "x-remote-ip", "192.168.1.1")
+				ctx = util.SetContext(ctx, "x-user-agent", "cse-java-chassis/1.0.0")
+				resp, err := instanceResource.Register(ctx, &pb.RegisterInstanceRequest{
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: serviceId,
+						Endpoints: []string{
+							"enrich:127.0.0.1:8080",
+						},
+						HostName: "UT-HOST",
+						Status:   pb.MSI_UP,
+						Properties: map[string]string{
+							"a":                 "b",
+							pb.PROP_REGISTER_IP: "fake",
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				instanceId = resp.InstanceId
+
+				respGet, err := instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGet.Instance.Properties["a"]).To(Equal("b"))
+				Expect(respGet.Instance.Properties[pb.PROP_REGISTER_IP]).To(Equal("192.168.1.1"))
+				Expect(respGet.Instance.Properties[pb.PROP_USER_AGENT]).To(Equal("cse-java-chassis/1.0.0"))
+				_, ok := respGet.Instance.Properties[pb.PROP_TLS_IDENTITY]
+				Expect(ok).To(BeFalse())
+
+				By("reserved properties can not be updated")
+				respUpdate, err := instanceResource.UpdateInstanceProperties(getContext(), &pb.UpdateInstancePropsRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId,
+					Properties: map[string]string{
+						"c":                "d",
+						pb.PROP_USER_AGENT: "fake",
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respUpdate.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err = instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respGet.Instance.Properties)).To(Equal(3))
+				Expect(respGet.Instance.Properties["c"]).To(Equal("d"))
+				Expect(respGet.Instance.Properties[pb.PROP_REGISTER_IP]).To(Equal("192.168.1.1"))
+				Expect(respGet.Instance.Properties[pb.PROP_USER_AGENT]).To(Equal("cse-java-chassis/1.0.0"))
+			})
+		})
+
+		Context("when enrich instance metadata is disabled", func() {
+			It("should not be stamped", func() {
+				ctx := util.SetContext(getContext(), "x-remote-ip", "192.168.1.1")
+				resp, err := instanceResource.Register(ctx, &pb.RegisterInstanceRequest{
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: serviceId,
+						Endpoints: []string{
+							"enrich:127.0.0.1:8081",
+						},
+						HostName: "UT-HOST",
+						Status:   pb.MSI_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err := instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: resp.InstanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respGet.Instance.Properties)).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("execute 'register' operartion for static instance", func() {
+		var (
+			serviceId  string
+			instanceId string
+		)
+
+		It("should be passed", func() {
+			respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "static_instance_service",
+					AppId:       "static_instance",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreate.ServiceId
+		})
+
+		Context("when register a static instance", func() {
+			It("should be passed", func() {
+				count, err := serviceUtil.GetStaticInstancesCount(getContext(), "default/default")
+				Expect(err).To(BeNil())
+
+				resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: serviceId,
+						HostName:  "UT-LEGACY-VM",
+						Endpoints: []string{
+							"rest://127.0.0.9:8080",
+						},
+						HealthCheck: &pb.HealthCheck{
+							Mode: pb.CHECK_BY_STATIC,
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				instanceId = resp.InstanceId
+
+				leaseId, err := serviceUtil.GetLeaseId(getContext(), "default/default", serviceId, instanceId)
+				Expect(err).To(BeNil())
+				Expect(leaseId).To(Equal(int64(-1)))
+				newCount, err := serviceUtil.GetStaticInstancesCount(getContext(), "default/default")
+				Expect(err).To(BeNil())
+				Expect(newCount).To(Equal(count + 1))
+
+				respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId,
+					AppId:             "static_instance",
+					ServiceName:       "static_instance_service",
+					VersionRule:       "1.0.0",
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Instances)).To(Equal(1))
+				Expect(respFind.Instances[0].HealthCheck.Mode).To(Equal(pb.CHECK_BY_STATIC))
+
+				By("invalid health check mode")
+				resp, err = instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: serviceId,
+						HostName:  "UT-LEGACY-VM",
+						Endpoints: []string{
+							"rest://127.0.0.9:8081",
+						},
+						HealthCheck: &pb.HealthCheck{
+							Mode: "unknown",
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+			})
+		})
+
+		Context("when heartbeat or update a static instance", func() {
+			It("should not need heartbeat", func() {
+				resp, err := instanceResource.Heartbeat(getContext(), &pb.HeartbeatRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				respStatus, err := instanceResource.UpdateStatus(getContext(), &pb.UpdateInstanceStatusRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId,
+					Status:     pb.MSI_DOWN,
+					ReasonCode: pb.REASON_MAINTENANCE,
+				})
+				Expect(err).To(BeNil())
+				Expect(respStatus.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err := instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGet.Instance.Status).To(Equal(pb.MSI_DOWN))
+			})
+		})
+
+		Context("when unregister a static instance", func() {
+			It("should be passed", func() {
+				count, err := serviceUtil.GetStaticInstancesCount(getContext(), "default/default")
+				Expect(err).To(BeNil())
+
+				resp, err := instanceResource.Unregister(getContext(), &pb.UnregisterInstanceRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				instance, err := serviceUtil.GetInstance(getContext(), "default/default", serviceId, instanceId)
+				Expect(err).To(BeNil())
+				Expect(instance).To(BeNil())
+				newCount, err := serviceUtil.GetStaticInstancesCount(getContext(), "default/default")
+				Expect(err).To(BeNil())
+				Expect(newCount).To(Equal(count - 1))
+			})
+		})
+	})
+
+	Describe("execute 'find' operartion with platform", func() {
+		var (
+			serviceId string
+		)
+
+		It("should be passed", func() {
+			respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "platform_service",
+					AppId:       "platform",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreate.ServiceId
+
+			for i, platform := range []*pb.Platform{
+				{Os: "Linux", Arch: "x86_64"},
+				{Os: "linux", Arch: "arm64"},
+				nil,
+			} {
+				resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: serviceId,
+						HostName:  "UT-PLATFORM",
+						Endpoints: []string{
+							fmt.Sprintf("rest://127.0.0.10:%d", 8080+i),
+						},
+						Platform: platform,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+			}
+
+			By("invalid platform")
+			resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+				Instance: &pb.MicroServiceInstance{
+					ServiceId: serviceId,
+					HostName:  "UT-PLATFORM",
+					Endpoints: []string{
+						"rest://127.0.0.10:8090",
+					},
+					Platform: &pb.Platform{Arch: "arm/v7"},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+		})
+
+		Context("when find with platform", func() {
+			It("should be passed", func() {
+				respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId,
+					AppId:             "platform",
+					ServiceName:       "platform_service",
+					VersionRule:       "1.0.0",
+					Platform:          &pb.Platform{Arch: "aarch64"},
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Instances)).To(Equal(3))
+				Expect(respFind.Instances[2].Platform.Arch).To(Equal("amd64"))
+				Expect(respFind.Instances[2].Platform.Os).To(Equal("linux"))
+
+				respFind, err = instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId,
+					AppId:             "platform",
+					ServiceName:       "platform_service",
+					VersionRule:       "1.0.0",
+					Platform:          &pb.Platform{Os: "linux", Arch: "arm64"},
+					PlatformPolicy:    pb.PLATFORM_REQUIRE,
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Instances)).To(Equal(2))
+				for _, instance := range respFind.Instances {
+					Expect(serviceUtil.MatchPlatform(instance, &pb.Platform{Arch: "arm64"})).To(BeTrue())
+				}
+
+				By("invalid platform policy")
+				respFind, err = instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId,
+					AppId:             "platform",
+					ServiceName:       "platform_service",
+					VersionRule:       "1.0.0",
+					Platform:          &pb.Platform{Arch: "arm64"},
+					PlatformPolicy:    "unknown",
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(scerr.ErrInvalidParams))
+			})
+		})
+	})
+
+	Describe("execute 'promote' operartion", func() {
+		var (
+			serviceId   string
+			instanceIds []string
+		)
+
+		It("should be passed", func() {
+			respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "standby_service",
+					AppId:       "standby",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreate.ServiceId
+
+			for i, status := range []string{pb.MSI_UP, pb.MSI_STANDBY, pb.MSI_STANDBY} {
+				resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: serviceId,
+						HostName:  "UT-STANDBY",
+						Endpoints: []string{
+							fmt.Sprintf("rest://127.0.0.11:%d", 8080+i),
+						},
+						Status: status,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				instanceIds = append(instanceIds, resp.InstanceId)
+			}
+		})
+
+		Context("when find standby instances", func() {
+			It("should be excluded", func() {
+				respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId,
+					AppId:             "standby",
+					ServiceName:       "standby_service",
+					VersionRule:       "1.0.0",
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Instances)).To(Equal(1))
+				Expect(respFind.Instances[0].InstanceId).To(Equal(instanceIds[0]))
+
+				respGet, err := instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceIds[1],
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGet.Instance.Status).To(Equal(pb.MSI_STANDBY))
+
+				respHb, err := instanceResource.Heartbeat(getContext(), &pb.HeartbeatRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceIds[1],
+				})
+				Expect(err).To(BeNil())
+				Expect(respHb.Response.Code).To(Equal(pb.Response_SUCCESS))
+			})
+		})
+
+		Context("when promote standby instances", func() {
+			It("should be passed", func() {
+				resp, err := instanceResource.PromoteInstances(getContext(), &pb.PromoteInstancesRequest{
+					ServiceId:   serviceId,
+					InstanceIds: []string{instanceIds[0], instanceIds[1]},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.InstanceIds).To(Equal([]string{instanceIds[1]}))
+
+				respGet, err := instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceIds[1],
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Instance.Status).To(Equal(pb.MSI_UP))
+				Expect(respGet.Instance.StatusReason.Code).To(Equal(pb.REASON_PROMOTION))
+
+				resp, err = instanceResource.PromoteInstances(getContext(), &pb.PromoteInstancesRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.InstanceIds).To(Equal([]string{instanceIds[2]}))
+
+				respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId,
+					AppId:             "standby",
+					ServiceName:       "standby_service",
+					VersionRule:       "1.0.0",
+				})
+				Expect(err).To(BeNil())
+				Expect(len(respFind.Instances)).To(Equal(3))
+
+				By("instance does not exist")
+				resp, err = instanceResource.PromoteInstances(getContext(), &pb.PromoteInstancesRequest{
+					ServiceId:   serviceId,
+					InstanceIds: []string{"notexist"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInstanceNotExists))
+
+				By("service does not exist")
+				resp, err = instanceResource.PromoteInstances(getContext(), &pb.PromoteInstancesRequest{
+					ServiceId: "notexist",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+			})
+		})
+	})
+
+	Describe("execute 'activate' operartion", func() {
+		var (
+			serviceId   string
+			instanceIds []string
+		)
+
+		It("should be passed", func() {
+			respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "pending_service",
+					AppId:       "pending",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreate.ServiceId
+
+			past := fmt.Sprint(time.Now().Add(-time.Minute).Unix())
+			for i, activateTime := range []string{"", past, ""} {
+				status := pb.MSI_PENDING
+				if i == 0 {
+					status = pb.MSI_UP
+				}
+				resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: serviceId,
+						HostName:  "UT-PENDING",
+						Endpoints: []string{
+							fmt.Sprintf("rest://127.0.0.12:%d", 8080+i),
+						},
+						Status:       status,
+						ActivateTime: activateTime,
+						HealthCheck: &pb.HealthCheck{
+							Mode: pb.CHECK_BY_STATIC,
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				instanceIds = append(instanceIds, resp.InstanceId)
+			}
+		})
+
+		Context("when find pending instances", func() {
+			It("should be excluded", func() {
+				respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId,
+					AppId:             "pending",
+					ServiceName:       "pending_service",
+					VersionRule:       "1.0.0",
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Instances)).To(Equal(1))
+				Expect(respFind.Instances[0].InstanceId).To(Equal(instanceIds[0]))
+			})
+		})
+
+		Context("when pending instances are activated", func() {
+			It("should be passed", func() {
+				By("activate as scheduled")
+				core.InstanceAPI = instanceResource
+				n, err := serviceUtil.ActivateDuePendingInstances(context.Background(), time.Now())
+				Expect(err).To(BeNil())
+				Expect(n).To(Equal(1))
+
+				respGet, err := instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceIds[1],
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Instance.Status).To(Equal(pb.MSI_UP))
+				Expect(respGet.Instance.ActivateTime).To(Equal(""))
+				Expect(respGet.Instance.StatusReason.Code).To(Equal(pb.REASON_ACTIVATION))
+
+				n, err = serviceUtil.ActivateDuePendingInstances(context.Background(), time.Now())
+				Expect(err).To(BeNil())
+				Expect(n).To(Equal(0))
+
+				By("activate by api")
+				resp, err := instanceResource.ActivateInstances(getContext(), &pb.PromoteInstancesRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(resp.InstanceIds).To(Equal([]string{instanceIds[2]}))
+
+				respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId,
+					AppId:             "pending",
+					ServiceName:       "pending_service",
+					VersionRule:       "1.0.0",
+				})
+				Expect(err).To(BeNil())
+				Expect(len(respFind.Instances)).To(Equal(3))
+			})
+		})
+	})
+
+	Describe("execute 'find' operartion with deadline", func() {
+		var (
+			serviceId string
+		)
+
+		It("should be passed", func() {
+			respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "deadline_service",
+					AppId:       "deadline",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreate.ServiceId
+		})
+
+		Context("when the request is canceled", func() {
+			It("should be failed", func() {
+				ctx, cancel := context.WithTimeout(getContext(), time.Minute)
+				respFind, err := instanceResource.Find(ctx, &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId,
+					AppId:             "deadline",
+					ServiceName:       "deadline_service",
+					VersionRule:       "1.0.0",
+				})
+				cancel()
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				ctx, cancel = context.WithCancel(getContext())
+				cancel()
+				respFind, err = instanceResource.Find(ctx, &pb.FindInstancesRequest{
+					ConsumerServiceId: serviceId,
+					AppId:             "deadline",
+					ServiceName:       "deadline_service",
+					VersionRule:       "1.0.0",
+				})
+				Expect(err).NotTo(BeNil())
+				Expect(respFind.Response.Code).To(Equal(scerr.ErrInternal))
+			})
+		})
+	})
+
+	Describe("execute 'update capacity' operartion", func() {
+		var (
+			serviceId  string
+			instanceId string
+		)
+
+		It("should be passed", func() {
+			respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "capacity_service",
+					AppId:       "capacity",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreate.ServiceId
+
+			By("capacity is negative")
+			resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+				Instance: &pb.MicroServiceInstance{
+					ServiceId: serviceId,
+					HostName:  "UT-CAPACITY",
+					Endpoints: []string{"rest://127.0.0.12:8080"},
+					Status:    pb.MSI_UP,
+					Capacity:  -1,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+			resp, err = instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+				Instance: &pb.MicroServiceInstance{
+					ServiceId: serviceId,
+					HostName:  "UT-CAPACITY",
+					Endpoints: []string{"rest://127.0.0.12:8080"},
+					Status:    pb.MSI_UP,
+					Capacity:  100,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+			instanceId = resp.InstanceId
+
+			respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+				ConsumerServiceId: serviceId,
+				AppId:             "capacity",
+				ServiceName:       "capacity_service",
+				VersionRule:       "1.0.0",
+			})
+			Expect(err).To(BeNil())
+			Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+			Expect(len(respFind.Instances)).To(Equal(1))
+			Expect(respFind.Instances[0].Capacity).To(Equal(int32(100)))
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				resp, err := instanceResource.UpdateCapacity(getContext(), &pb.UpdateInstanceCapacityRequest{
+					ServiceId: serviceId,
+					Capacity:  10,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				resp, err = instanceResource.UpdateCapacity(getContext(), &pb.UpdateInstanceCapacityRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId,
+					Capacity:   -1,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				resp, err = instanceResource.UpdateCapacity(getContext(), &pb.UpdateInstanceCapacityRequest{
+					ServiceId:  serviceId,
+					InstanceId: "notexistins",
+					Capacity:   10,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInstanceNotExists))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				resp, err := instanceResource.UpdateCapacity(getContext(), &pb.UpdateInstanceCapacityRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId,
+					Capacity:   20,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err := instanceResource.GetOneInstance(getContext(), &pb.GetOneInstanceRequest{
+					ConsumerServiceId:  serviceId,
+					ProviderServiceId:  serviceId,
+					ProviderInstanceId: instanceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGet.Instance.Capacity).To(Equal(int32(20)))
+			})
+		})
+	})
+
+	Describe("execute 'keepalive' operartion", func() {
+		var (
+			serviceId   string
+			instanceId1 string
+			instanceId2 string
+		)
+
+		It("should be passed", func() {
+			respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "keepalive_service",
+					AppId:       "keepalive",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreate.ServiceId
+
+			for _, ep := range []string{"keepalive:127.0.0.1:8080", "keepalive:127.0.0.2:8080"} {
+				resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: serviceId,
+						HostName:  "UT-KEEPALIVE",
+						Endpoints: []string{ep},
+						Status:    pb.MSI_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				if len(instanceId1) == 0 {
+					instanceId1 = resp.InstanceId
+				} else {
+					instanceId2 = resp.InstanceId
+				}
+			}
+		})
+
+		Context("when renew the leases on a stream", func() {
+			It("should be passed", func() {
+				core.ServerInfo.Config.KeepAliveGracePeriod = "10s"
+				stream := &keepAliveServer{
+					requests: []*pb.KeepAliveRequest{
+						{Instances: []*pb.HeartbeatSetElement{
+							{ServiceId: serviceId, InstanceId: instanceId1},
+							{ServiceId: serviceId, InstanceId: instanceId2},
+						}},
+						{},
+						{Instances: []*pb.HeartbeatSetElement{
+							{ServiceId: serviceId, InstanceId: "notexistins"},
+						}},
+						{},
+					},
+				}
+				err := instanceResource.KeepAlive(stream)
+				Expect(err).To(BeNil())
+				Expect(len(stream.responses)).To(Equal(4))
+
+				By("bind instances")
+				Expect(stream.responses[0].Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(stream.responses[0].Instances)).To(Equal(2))
+
+				By("renew all bound instances")
+				Expect(stream.responses[1].Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(stream.responses[1].Instances)).To(Equal(2))
+
+				By("instance does not exist")
+				Expect(stream.responses[2].Response.Code).To(Equal(scerr.ErrInstanceNotExists))
+				Expect(len(stream.responses[2].Instances)).To(Equal(3))
+
+				By("unbind the failed instance")
+				Expect(stream.responses[3].Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(stream.responses[3].Instances)).To(Equal(2))
+			})
+		})
+
+		Context("when the stream is lost", func() {
+			It("should be passed", func() {
+				By("take over by another stream within the grace period")
+				core.ServerInfo.Config.KeepAliveGracePeriod = "200ms"
+				err := instanceResource.KeepAlive(&keepAliveServer{
+					requests: []*pb.KeepAliveRequest{
+						{Instances: []*pb.HeartbeatSetElement{
+							{ServiceId: serviceId, InstanceId: instanceId1},
+						}},
+					},
+				})
+				Expect(err).To(BeNil())
+
+				core.ServerInfo.Config.KeepAliveGracePeriod = "10s"
+				err = instanceResource.KeepAlive(&keepAliveServer{
+					requests: []*pb.KeepAliveRequest{
+						{Instances: []*pb.HeartbeatSetElement{
+							{ServiceId: serviceId, InstanceId: instanceId1},
+						}},
+					},
+				})
+				Expect(err).To(BeNil())
+
+				<-time.After(500 * time.Millisecond)
+				resp, err := instanceResource.Heartbeat(getContext(), &pb.HeartbeatRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId1,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				By("expire the instances immediately")
+				core.ServerInfo.Config.KeepAliveGracePeriod = "0s"
+				defer func() {
+					core.ServerInfo.Config.KeepAliveGracePeriod = ""
+				}()
+				err = instanceResource.KeepAlive(&keepAliveServer{
+					requests: []*pb.KeepAliveRequest{
+						{Instances: []*pb.HeartbeatSetElement{
+							{ServiceId: serviceId, InstanceId: instanceId2},
+						}},
+					},
+				})
+				Expect(err).To(BeNil())
+
+				Eventually(func() int32 {
+					resp, _ := instanceResource.Heartbeat(getContext(), &pb.HeartbeatRequest{
+						ServiceId:  serviceId,
+						InstanceId: instanceId2,
+					})
+					return resp.Response.Code
+				}, 3*time.Second).Should(Equal(scerr.ErrInstanceNotExists))
+			})
+		})
+	})
+
+	Describe("execute 'properties limits' operartion", func() {
+		var (
+			serviceId  string
+			instanceId string
+		)
+
+		It("should be passed", func() {
+			respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "properties_limits_service",
+					AppId:       "properties_limits",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreate.ServiceId
+
+			By("value is too long")
+			resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+				Instance: &pb.MicroServiceInstance{
+					ServiceId:  serviceId,
+					HostName:   "UT-PROPERTIES",
+					Endpoints:  []string{"properties:127.0.0.1:8080"},
+					Status:     pb.MSI_UP,
+					Properties: map[string]string{"big": strings.Repeat("x", core.DefaultPropertiesLimits.MaxValueLength+1)},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidProperties))
+			Expect(resp.Response.Details[0].Field).To(Equal("properties.big"))
+
+			By("key is empty")
+			resp, err = instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+				Instance: &pb.MicroServiceInstance{
+					ServiceId:  serviceId,
+					HostName:   "UT-PROPERTIES",
+					Endpoints:  []string{"properties:127.0.0.1:8080"},
+					Status:     pb.MSI_UP,
+					Properties: map[string]string{"": "x"},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidProperties))
+
+			resp, err = instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+				Instance: &pb.MicroServiceInstance{
+					ServiceId:  serviceId,
+					HostName:   "UT-PROPERTIES",
+					Endpoints:  []string{"properties:127.0.0.1:8080"},
+					Status:     pb.MSI_UP,
+					Properties: map[string]string{"a": "1", "b": "2"},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+			instanceId = resp.InstanceId
+		})
+
+		Context("when the tenant overrides the limits", func() {
+			It("should be failed", func() {
+				old := core.TenantPropertiesLimits
+				core.TenantPropertiesLimits = core.ParsePropertiesLimits(core.DefaultPropertiesLimits,
+					"other:max_keys=100; default/default:max_keys=1,max_bytes=abc")
+				defer func() {
+					core.TenantPropertiesLimits = old
+				}()
+				Expect(core.GetPropertiesLimits("default/default").MaxKeys).To(Equal(1))
+				Expect(core.GetPropertiesLimits("default/default").MaxBytes).To(Equal(core.DefaultPropertiesLimits.MaxBytes))
+				Expect(core.GetPropertiesLimits("other/p1").MaxKeys).To(Equal(100))
+
+				resp, err := instanceResource.UpdateInstanceProperties(getContext(), &pb.UpdateInstancePropsRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId,
+					Properties: map[string]string{"a": "1", "b": "2"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidProperties))
+				Expect(resp.Response.Details[0].Field).To(Equal("properties"))
+
+				resp, err = instanceResource.UpdateInstanceProperties(getContext(), &pb.UpdateInstancePropsRequest{
+					ServiceId:  serviceId,
+					InstanceId: instanceId,
+					Properties: map[string]string{"a": "1"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+			})
+		})
+	})
+
+	Describe("execute 'find' operartion with shared service", func() {
+		var (
+			providerId string
+			consumerId string
+			tenantCtx  context.Context
+		)
+
+		It("should be passed", func() {
+			respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "shared_provider",
+					AppId:       "shared_app",
+					Version:     "1.0.0",
+					Level:       "BACK",
+					Status:      pb.MS_UP,
+					Properties:  map[string]string{pb.PROP_ALLOW_CROSS_APP: "true"},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+			providerId = respCreate.ServiceId
+
+			resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+				Instance: &pb.MicroServiceInstance{
+					ServiceId: providerId,
+					HostName:  "UT-SHARED",
+					Endpoints: []string{
+						"rest://127.0.0.11:8080",
+					},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			tenantCtx = util.SetContext(getContext(), "domain", "shared_tenant")
+			respCreate, err = serviceResource.Create(tenantCtx, &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "shared_consumer",
+					AppId:       "tenant_app",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+			consumerId = respCreate.ServiceId
+		})
+
+		Context("when find the shared service from other domain", func() {
+			It("should be passed", func() {
+				find := &pb.FindInstancesRequest{
+					ConsumerServiceId: consumerId,
+					AppId:             "shared_app",
+					ServiceName:       "shared_provider",
+					VersionRule:       "latest",
+				}
+
+				By("not shared")
+				respFind, err := instanceResource.Find(tenantCtx, find)
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+
+				err = serviceUtil.AddSharedService(getContext(), &serviceUtil.SharedService{
+					Domain:      "default",
+					Project:     "default",
+					AppId:       "shared_app",
+					ServiceName: "shared_provider",
+				})
+				Expect(err).To(BeNil())
+
+				By("shared")
+				respFind, err = instanceResource.Find(tenantCtx, find)
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Instances)).To(Equal(1))
+				Expect(respFind.Instances[0].ServiceId).To(Equal(providerId))
+
+				By("rules of the provider still applied")
+				respRule, err := serviceResource.AddRule(getContext(), &pb.AddServiceRulesRequest{
+					ServiceId: providerId,
+					Rules: []*pb.AddOrUpdateServiceRule{
+						{
+							RuleType:    "BLACK",
+							Attribute:   "ServiceName",
+							Pattern:     "shared_consumer",
+							Description: "test black",
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respRule.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respFind, err = instanceResource.Find(tenantCtx, find)
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respFind.Instances)).To(Equal(0))
+
+				ok, err := serviceUtil.DeleteSharedService(getContext(), "shared_app", "shared_provider")
+				Expect(err).To(BeNil())
+				Expect(ok).To(BeTrue())
+
+				By("unshared")
+				respFind, err = instanceResource.Find(tenantCtx, find)
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+			})
+		})
 	})
 })
@@ -0,0 +1,180 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"io"
+	"sync"
+	"time"
+)
+
+const DEFAULT_KEEPALIVE_GRACE_PERIOD = 5 * time.Second
+
+// keepAliveOwners 实例当前由哪个keepAlive流续约，流断开后由新的流接管或在宽限期后撤销租约
+var (
+	keepAliveOwners = make(map[string]*keepAliveSession)
+	keepAliveLock   sync.Mutex
+)
+
+type keepAliveSession struct {
+	domainProject string
+	instances     map[string]*pb.HeartbeatSetElement
+}
+
+func newKeepAliveSession(domainProject string) *keepAliveSession {
+	return &keepAliveSession{
+		domainProject: domainProject,
+		instances:     make(map[string]*pb.HeartbeatSetElement),
+	}
+}
+
+func (s *keepAliveSession) key(element *pb.HeartbeatSetElement) string {
+	return util.StringJoin([]string{s.domainProject, element.ServiceId, element.InstanceId}, "/")
+}
+
+// Bind 将实例绑定到当前流，已绑定到其它流的实例由当前流接管
+func (s *keepAliveSession) Bind(elements []*pb.HeartbeatSetElement) {
+	keepAliveLock.Lock()
+	for _, element := range elements {
+		if element == nil {
+			continue
+		}
+		key := s.key(element)
+		s.instances[key] = element
+		keepAliveOwners[key] = s
+	}
+	keepAliveLock.Unlock()
+}
+
+func (s *keepAliveSession) unbind(element *pb.HeartbeatSetElement) {
+	key := s.key(element)
+	keepAliveLock.Lock()
+	delete(s.instances, key)
+	if keepAliveOwners[key] == s {
+		delete(keepAliveOwners, key)
+	}
+	keepAliveLock.Unlock()
+}
+
+func (s *keepAliveSession) Elements() []*pb.HeartbeatSetElement {
+	keepAliveLock.Lock()
+	elements := make([]*pb.HeartbeatSetElement, 0, len(s.instances))
+	for _, element := range s.instances {
+		elements = append(elements, element)
+	}
+	keepAliveLock.Unlock()
+	return elements
+}
+
+// Lost 流断开后的处理，宽限期后仍未被接管的实例撤销租约，使其尽快下线
+func (s *keepAliveSession) Lost(grace time.Duration) {
+	if len(s.Elements()) == 0 {
+		return
+	}
+	if grace <= 0 {
+		s.revoke()
+		return
+	}
+	time.AfterFunc(grace, s.revoke)
+}
+
+func (s *keepAliveSession) revoke() {
+	ctx := context.Background()
+	for _, element := range s.Elements() {
+		key := s.key(element)
+		keepAliveLock.Lock()
+		owned := keepAliveOwners[key] == s
+		if owned {
+			delete(keepAliveOwners, key)
+		}
+		delete(s.instances, key)
+		keepAliveLock.Unlock()
+		if !owned {
+			continue
+		}
+
+		leaseID, err := serviceUtil.GetLeaseId(ctx, s.domainProject, element.ServiceId, element.InstanceId)
+		if err != nil {
+			util.Logger().Errorf(err, "revoke instance %s lease failed after keepalive stream lost", key)
+			continue
+		}
+		if leaseID == -1 {
+			continue
+		}
+		if err := backend.Registry().LeaseRevoke(ctx, leaseID); err != nil {
+			util.Logger().Errorf(err, "revoke instance %s lease failed after keepalive stream lost", key)
+			continue
+		}
+		util.Logger().Warnf(nil, "instance %s expired after keepalive stream lost", key)
+	}
+}
+
+func keepAliveGracePeriod() time.Duration {
+	d, err := time.ParseDuration(apt.ServerInfo.Config.KeepAliveGracePeriod)
+	if err != nil {
+		return DEFAULT_KEEPALIVE_GRACE_PERIOD
+	}
+	return d
+}
+
+// KeepAlive 客户端在一条流上为其所有实例续约，流断开后未被其它流接管的实例在宽限期后下线
+func (s *InstanceService) KeepAlive(stream pb.ServiceInstanceCtrl_KeepAliveServer) error {
+	ctx := stream.Context()
+	remoteIP := util.GetIPFromContext(ctx)
+	session := newKeepAliveSession(util.ParseDomainProject(ctx))
+	defer func() {
+		session.Lost(keepAliveGracePeriod())
+	}()
+
+	util.Logger().Infof("start keepalive stream, operator: %s", remoteIP)
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			util.Logger().Infof("keepalive stream closed, operator: %s", remoteIP)
+			return nil
+		}
+		if err != nil {
+			util.Logger().Errorf(err, "keepalive stream lost, operator: %s", remoteIP)
+			return err
+		}
+
+		session.Bind(in.Instances)
+		resp, err := s.HeartbeatSet(ctx, &pb.HeartbeatSetRequest{Instances: session.Elements()})
+		if err != nil {
+			return err
+		}
+		for _, rst := range resp.Instances {
+			if len(rst.ErrMessage) != 0 {
+				session.unbind(&pb.HeartbeatSetElement{ServiceId: rst.ServiceId, InstanceId: rst.InstanceId})
+			}
+		}
+		err = stream.Send(&pb.KeepAliveResponse{
+			Response:  resp.Response,
+			Instances: resp.Instances,
+		})
+		if err != nil {
+			util.Logger().Errorf(err, "keepalive stream lost, operator: %s", remoteIP)
+			return err
+		}
+	}
+}
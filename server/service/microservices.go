@@ -28,7 +28,6 @@ import (
 	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/quota"
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
-	"github.com/apache/incubator-servicecomb-service-center/server/mux"
 	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
 	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
 	"golang.org/x/net/context"
@@ -44,6 +43,9 @@ type MicroServiceService struct {
 const (
 	EXIST_TYPE_MICROSERVICE = "microservice"
 	EXIST_TYPE_SCHEMA       = "schema"
+
+	// 合并properties时因并发修改导致CAS失败的最大重试次数
+	MAX_PROPERTIES_PATCH_RETRIES = 3
 )
 
 func (s *MicroServiceService) Create(ctx context.Context, in *pb.CreateServiceRequest) (*pb.CreateServiceResponse, error) {
@@ -73,6 +75,29 @@ func (s *MicroServiceService) CreateServicePri(ctx context.Context, in *pb.Creat
 	service := in.Service
 	serviceFlag := util.StringJoin([]string{service.AppId, service.ServiceName, service.Version}, "/")
 
+	domainProject := util.ParseDomainProject(ctx)
+
+	admitErr := serviceUtil.AdmitService(ctx, domainProject, in)
+	if admitErr != nil {
+		util.Logger().Errorf(admitErr, "create microservice failed, %s: admission rejected. operator: %s",
+			serviceFlag, remoteIP)
+		resp := &pb.CreateServiceResponse{
+			Response: pb.CreateResponse(admitErr.Code, admitErr.Detail),
+		}
+		if admitErr.StatusCode() == http.StatusInternalServerError {
+			return resp, admitErr
+		}
+		return resp, nil
+	}
+	if guardErr := serviceUtil.AdmitWrite(ctx, serviceUtil.WRITE_PRIORITY_NORMAL); guardErr != nil {
+		util.Logger().Errorf(guardErr, "create microservice failed, %s: storage guard rejected. operator: %s",
+			serviceFlag, remoteIP)
+		return &pb.CreateServiceResponse{
+			Response: pb.CreateResponse(guardErr.Code, guardErr.Detail),
+		}, nil
+	}
+	service = in.Service
+
 	serviceUtil.SetServiceDefaultValue(service)
 
 	err := apt.Validate(service)
@@ -80,11 +105,20 @@ func (s *MicroServiceService) CreateServicePri(ctx context.Context, in *pb.Creat
 		util.Logger().Errorf(err, "create microservice failed, %s: invalid parameters. operator: %s",
 			serviceFlag, remoteIP)
 		return &pb.CreateServiceResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("service", err)...),
 		}, nil
 	}
-
-	domainProject := util.ParseDomainProject(ctx)
+	if namingErr := serviceUtil.CheckServiceNaming(ctx, domainProject, service); namingErr != nil {
+		util.Logger().Errorf(namingErr, "create microservice failed, %s: naming rules violated. operator: %s",
+			serviceFlag, remoteIP)
+		resp := &pb.CreateServiceResponse{
+			Response: pb.CreateResponseWithDetails(namingErr.Code, namingErr.Detail, namingErr.Details...),
+		}
+		if namingErr.StatusCode() == http.StatusInternalServerError {
+			return resp, namingErr
+		}
+		return resp, nil
+	}
 
 	serviceKey := &pb.MicroServiceKey{
 		Tenant:      domainProject,
@@ -116,6 +150,8 @@ func (s *MicroServiceService) CreateServicePri(ctx context.Context, in *pb.Creat
 		serviceId = plugin.Plugins().UUID().GetServiceId()
 	}
 	service.ServiceId = serviceId
+	// 契约冻结只能通过schema-freeze接口设置
+	service.SchemaFreeze = nil
 	service.Timestamp = strconv.FormatInt(time.Now().Unix(), 10)
 	service.ModTimestamp = service.Timestamp
 
@@ -134,6 +170,8 @@ func (s *MicroServiceService) CreateServicePri(ctx context.Context, in *pb.Creat
 	opts := []registry.PluginOp{
 		registry.OpPut(registry.WithStrKey(key), registry.WithValue(data)),
 		registry.OpPut(registry.WithKey(indexBytes), registry.WithStrValue(serviceId)),
+		registry.OpPut(registry.WithStrKey(apt.GenerateServiceNameIndexKey(domainProject, service.ServiceName, serviceId)),
+			registry.WithStrValue(serviceId)),
 	}
 	uniqueCmpOpts := []registry.CompareOp{
 		registry.OpCmp(registry.CmpVer(indexBytes), registry.CMP_EQUAL, 0),
@@ -181,7 +219,7 @@ func (s *MicroServiceService) CreateServicePri(ctx context.Context, in *pb.Creat
 	util.Logger().Infof("create microservice successful, %s, serviceId: %s. operator: %s",
 		serviceFlag, service.ServiceId, remoteIP)
 	return &pb.CreateServiceResponse{
-		Response:  pb.CreateResponse(pb.Response_SUCCESS, "Register service successfully."),
+		Response:  pb.CreateResponse(pb.Response_SUCCESS, withQuotaWarning("Register service successfully.", reporter)),
 		ServiceId: serviceId,
 	}, nil
 }
@@ -202,6 +240,14 @@ func checkQuota(ctx context.Context, domainProject string) (quota.QuotaReporter,
 	return reporter, nil
 }
 
+// withQuotaWarning 配额在宽限超额内申请成功时，在响应消息中附带提示
+func withQuotaWarning(message string, reporter quota.QuotaReporter) string {
+	if w, ok := reporter.(quota.QuotaWarning); ok {
+		return message + " Warning: " + w.Warning() + "."
+	}
+	return message
+}
+
 func (s *MicroServiceService) DeleteServicePri(ctx context.Context, ServiceId string, force bool) (*pb.Response, error) {
 	domainProject := util.ParseDomainProject(ctx)
 
@@ -250,15 +296,6 @@ func (s *MicroServiceService) DeleteServicePri(ctx context.Context, ServiceId st
 		}
 	}
 
-	consumer := &pb.MicroServiceKey{
-		Tenant:      domainProject,
-		Environment: service.Environment,
-		AppId:       service.AppId,
-		ServiceName: service.ServiceName,
-		Version:     service.Version,
-		Alias:       service.Alias,
-	}
-
 	//refresh msCache consumerCache, ensure that watch can notify consumers when no cache.
 	err = serviceUtil.RefreshDependencyCache(ctx, domainProject, ServiceId, service)
 	if err != nil {
@@ -266,58 +303,16 @@ func (s *MicroServiceService) DeleteServicePri(ctx context.Context, ServiceId st
 		return pb.CreateResponse(scerr.ErrInternal, "Refresh dependency cache failed."), err
 	}
 
-	opts := []registry.PluginOp{
-		registry.OpDel(registry.WithStrKey(apt.GenerateServiceIndexKey(consumer))),
-		registry.OpDel(registry.WithStrKey(apt.GenerateServiceAliasKey(consumer))),
-		registry.OpDel(registry.WithStrKey(apt.GenerateServiceKey(domainProject, ServiceId))),
-		registry.OpDel(registry.WithStrKey(
-			util.StringJoin([]string{apt.GetServiceRuleRootKey(domainProject), ServiceId, ""}, "/"))),
-	}
-
-	//删除依赖规则
-	lock, err := mux.Lock(mux.GLOBAL_LOCK)
-	if err != nil {
-		util.Logger().Errorf(err, "%s microservice failed, serviceId is %s: inner err, create lock failed.", title, ServiceId)
-		return pb.CreateResponse(scerr.ErrUnavailableBackend, err.Error()), err
-	}
-	optsTmp, err := serviceUtil.DeleteDependencyForService(ctx, consumer, ServiceId)
-	lock.Unlock()
+	// 先在一个事务中注销服务并记录清理日志，关联数据清理失败时可由后台任务恢复
+	err = serviceUtil.UnregisterService(ctx, domainProject, service)
 	if err != nil {
-		util.Logger().Errorf(err, "%s microservice failed, serviceId is %s: inner err, delete dependency failed.", title, ServiceId)
-		return pb.CreateResponse(scerr.ErrInternal, err.Error()), err
-	}
-	opts = append(opts, optsTmp...)
-
-	//删除黑白名单
-	opts = append(opts, registry.OpDel(
-		registry.WithStrKey(apt.GenerateServiceRuleKey(domainProject, ServiceId, "")),
-		registry.WithPrefix()))
-	opts = append(opts, registry.OpDel(
-		registry.WithStrKey(apt.GenerateRuleIndexKey(domainProject, ServiceId, "", ""))))
-
-	//删除shemas
-	opts = append(opts, registry.OpDel(
-		registry.WithStrKey(apt.GenerateServiceSchemaKey(domainProject, ServiceId, "")),
-		registry.WithPrefix()))
-	opts = append(opts, registry.OpDel(
-		registry.WithStrKey(apt.GenerateServiceSchemaSummaryKey(domainProject, ServiceId, "")),
-		registry.WithPrefix()))
-
-	//删除tags
-	opts = append(opts, registry.OpDel(
-		registry.WithStrKey(apt.GenerateServiceTagKey(domainProject, ServiceId))))
-
-	//删除实例
-	err = serviceUtil.DeleteServiceAllInstances(ctx, ServiceId)
-	if err != nil {
-		util.Logger().Errorf(err, "%s microservice failed, serviceId is %s: delete all instances failed.", title, ServiceId)
-		return pb.CreateResponse(scerr.ErrInternal, "Delete all instances failed for service."), err
+		util.Logger().Errorf(err, "%s microservice failed, serviceId is %s: commit data into etcd failed.", title, ServiceId)
+		return pb.CreateResponse(scerr.ErrUnavailableBackend, "Commit operations failed."), nil
 	}
 
-	err = backend.BatchCommit(ctx, opts)
+	err = serviceUtil.CleanupService(ctx, domainProject, service)
 	if err != nil {
-		util.Logger().Errorf(err, "%s microservice failed, serviceId is %s: commit data into etcd failed.", title, ServiceId)
-		return pb.CreateResponse(scerr.ErrUnavailableBackend, "Commit operations failed."), nil
+		util.Logger().Warnf(err, "%s microservice %s: cleanup is interrupted and will be resumed later.", title, ServiceId)
 	}
 
 	serviceUtil.RemandServiceQuota(ctx)
@@ -337,7 +332,7 @@ func (s *MicroServiceService) Delete(ctx context.Context, in *pb.DeleteServiceRe
 	if err != nil {
 		util.Logger().Errorf(err, "delete microservice failed, serviceId is %s: invalid parameters.", in.ServiceId)
 		return &pb.DeleteServiceResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 
@@ -436,7 +431,7 @@ func (s *MicroServiceService) GetOne(ctx context.Context, in *pb.GetServiceReque
 		util.Logger().Errorf(err, "get microservice failed, serviceId is %s: invalid parameters.",
 			in.ServiceId)
 		return &pb.GetServiceResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 	domainProject := util.ParseDomainProject(ctx)
@@ -467,7 +462,20 @@ func (s *MicroServiceService) GetServices(ctx context.Context, in *pb.GetService
 			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
 		}, nil
 	}
-	services, err := serviceUtil.GetAllServiceUtil(ctx)
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "get services failed: invalid params.")
+		return &pb.GetServicesResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	var services []*pb.MicroService
+	if len(in.ServiceName) > 0 {
+		services, err = serviceUtil.GetServicesByName(ctx, util.ParseDomainProject(ctx), in.ServiceName)
+	} else {
+		services, err = serviceUtil.GetAllServiceUtil(ctx)
+	}
 	if err != nil {
 		util.Logger().Errorf(err, "get services failed: inner err.")
 		return &pb.GetServicesResponse{
@@ -482,7 +490,7 @@ func (s *MicroServiceService) GetServices(ctx context.Context, in *pb.GetService
 }
 
 func (s *MicroServiceService) UpdateProperties(ctx context.Context, in *pb.UpdateServicePropsRequest) (*pb.UpdateServicePropsResponse, error) {
-	if in == nil || len(in.ServiceId) == 0 || in.Properties == nil {
+	if in == nil || len(in.ServiceId) == 0 || (in.Properties == nil && !in.Merge) {
 		util.Logger().Errorf(nil, "update service properties failed: invalid params.")
 		return &pb.UpdateServicePropsResponse{
 			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
@@ -492,11 +500,35 @@ func (s *MicroServiceService) UpdateProperties(ctx context.Context, in *pb.Updat
 	if err != nil {
 		util.Logger().Errorf(err, "update service properties failed, serviceId is %s: invalid parameters.", in.ServiceId)
 		return &pb.UpdateServicePropsResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 
 	domainProject := util.ParseDomainProject(ctx)
+	if guardErr := serviceUtil.AdmitWrite(ctx, serviceUtil.WRITE_PRIORITY_LOW); guardErr != nil {
+		util.Logger().Errorf(guardErr, "update service properties failed, serviceId is %s: storage guard rejected.", in.ServiceId)
+		return &pb.UpdateServicePropsResponse{
+			Response: pb.CreateResponse(guardErr.Code, guardErr.Detail),
+		}, nil
+	}
+
+	if in.Merge {
+		respErr := patchServiceProperties(ctx, domainProject, in)
+		if respErr != nil {
+			util.Logger().Errorf(respErr, "merge service properties failed, serviceId is %s.", in.ServiceId)
+			resp := &pb.UpdateServicePropsResponse{
+				Response: pb.CreateResponse(respErr.Code, respErr.Detail),
+			}
+			if respErr.StatusCode() == http.StatusInternalServerError {
+				return resp, respErr
+			}
+			return resp, nil
+		}
+		util.Logger().Infof("merge service properties successful: serviceId is %s.", in.ServiceId)
+		return &pb.UpdateServicePropsResponse{
+			Response: pb.CreateResponse(pb.Response_SUCCESS, "Update service successfully."),
+		}, nil
+	}
 
 	key := apt.GenerateServiceKey(domainProject, in.ServiceId)
 	service, err := serviceUtil.GetService(ctx, domainProject, in.ServiceId)
@@ -544,6 +576,278 @@ func (s *MicroServiceService) UpdateProperties(ctx context.Context, in *pb.Updat
 	}, nil
 }
 
+// patchServiceProperties 读取服务后合并properties，以CAS方式写回，避免覆盖并发修改的其它属性
+func patchServiceProperties(ctx context.Context, domainProject string, in *pb.UpdateServicePropsRequest) *scerr.Error {
+	key := apt.GenerateServiceKey(domainProject, in.ServiceId)
+	for i := 0; i < MAX_PROPERTIES_PATCH_RETRIES; i++ {
+		resp, err := backend.Registry().Do(ctx, registry.GET, registry.WithStrKey(key))
+		if err != nil {
+			return scerr.NewError(scerr.ErrUnavailableBackend, err.Error())
+		}
+		if len(resp.Kvs) == 0 {
+			return scerr.NewError(scerr.ErrServiceNotExists, "Service does not exist.")
+		}
+		service := &pb.MicroService{}
+		if err := json.Unmarshal(resp.Kvs[0].Value, service); err != nil {
+			return scerr.NewError(scerr.ErrInternal, err.Error())
+		}
+		service.Properties = serviceUtil.MergeProperties(service.Properties, in.Properties, in.RemoveProperties)
+		service.ModTimestamp = strconv.FormatInt(time.Now().Unix(), 10)
+
+		data, err := json.Marshal(service)
+		if err != nil {
+			return scerr.NewError(scerr.ErrInternal, "Service file marshal error.")
+		}
+		txnResp, err := backend.Registry().TxnWithCmp(ctx,
+			[]registry.PluginOp{registry.OpPut(registry.WithStrKey(key), registry.WithValue(data))},
+			[]registry.CompareOp{registry.OpCmp(registry.CmpStrModRev(key), registry.CMP_EQUAL, resp.Kvs[0].ModRevision)},
+			nil)
+		if err != nil {
+			return scerr.NewError(scerr.ErrUnavailableBackend, err.Error())
+		}
+		if txnResp.Succeeded {
+			return nil
+		}
+	}
+	return scerr.NewError(scerr.ErrPropertiesConflict,
+		fmt.Sprintf("Service %s is modified concurrently.", in.ServiceId))
+}
+
+func (s *MicroServiceService) UpdateCatalog(ctx context.Context, in *pb.UpdateServiceCatalogRequest) (*pb.UpdateServiceCatalogResponse, error) {
+	if in == nil || len(in.ServiceId) == 0 || in.Catalog == nil {
+		util.Logger().Errorf(nil, "update service catalog failed: invalid params.")
+		return &pb.UpdateServiceCatalogResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "update service catalog failed, serviceId is %s: invalid parameters.", in.ServiceId)
+		return &pb.UpdateServiceCatalogResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("catalog", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	key := apt.GenerateServiceKey(domainProject, in.ServiceId)
+	service, err := serviceUtil.GetService(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "update service catalog failed, serviceId is %s: query service failed.", in.ServiceId)
+		return &pb.UpdateServiceCatalogResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if service == nil {
+		util.Logger().Errorf(nil, "update service catalog failed, serviceId is %s: service not exist.", in.ServiceId)
+		return &pb.UpdateServiceCatalogResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+	service.Catalog = in.Catalog
+	service.ModTimestamp = strconv.FormatInt(time.Now().Unix(), 10)
+
+	data, err := json.Marshal(service)
+	if err != nil {
+		util.Logger().Errorf(err, "update service catalog failed, serviceId is %s: json marshal service failed.", in.ServiceId)
+		return &pb.UpdateServiceCatalogResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, "Service file marshal error."),
+		}, err
+	}
+
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(key),
+		registry.WithValue(data))
+	if err != nil {
+		util.Logger().Errorf(err, "update service catalog failed, serviceId is %s: commit data into etcd failed.", in.ServiceId)
+		return &pb.UpdateServiceCatalogResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, err.Error()),
+		}, err
+	}
+
+	util.Logger().Infof("update service catalog successful: serviceId is %s, operator is %s.",
+		in.ServiceId, util.GetIPFromContext(ctx))
+	return &pb.UpdateServiceCatalogResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Update service catalog successfully."),
+	}, nil
+}
+
+// UpdateRetirement 设置或取消服务版本的下线计划
+func (s *MicroServiceService) UpdateRetirement(ctx context.Context, in *pb.UpdateServiceRetirementRequest) (*pb.UpdateServiceRetirementResponse, error) {
+	if in == nil || len(in.ServiceId) == 0 {
+		util.Logger().Errorf(nil, "update service retirement failed: invalid params.")
+		return &pb.UpdateServiceRetirementResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err == nil && in.Retirement != nil {
+		_, err = serviceUtil.ParseRetirementDate(in.Retirement.TargetDate)
+	}
+	if err != nil {
+		util.Logger().Errorf(err, "update service retirement failed, serviceId is %s: invalid parameters.", in.ServiceId)
+		return &pb.UpdateServiceRetirementResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("retirement", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	key := apt.GenerateServiceKey(domainProject, in.ServiceId)
+	service, err := serviceUtil.GetService(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "update service retirement failed, serviceId is %s: query service failed.", in.ServiceId)
+		return &pb.UpdateServiceRetirementResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if service == nil {
+		util.Logger().Errorf(nil, "update service retirement failed, serviceId is %s: service not exist.", in.ServiceId)
+		return &pb.UpdateServiceRetirementResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	if in.Retirement != nil {
+		in.Retirement.Timestamp = now
+	}
+	service.Retirement = in.Retirement
+	service.ModTimestamp = now
+
+	data, err := json.Marshal(service)
+	if err != nil {
+		util.Logger().Errorf(err, "update service retirement failed, serviceId is %s: json marshal service failed.", in.ServiceId)
+		return &pb.UpdateServiceRetirementResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, "Service file marshal error."),
+		}, err
+	}
+
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(key),
+		registry.WithValue(data))
+	if err != nil {
+		util.Logger().Errorf(err, "update service retirement failed, serviceId is %s: commit data into etcd failed.", in.ServiceId)
+		return &pb.UpdateServiceRetirementResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, err.Error()),
+		}, err
+	}
+
+	if in.Retirement == nil {
+		util.Logger().Infof("cancel service retirement successful: serviceId is %s, operator is %s.",
+			in.ServiceId, util.GetIPFromContext(ctx))
+	} else {
+		util.Logger().Infof("update service retirement successful: serviceId is %s, target date is %s, operator is %s.",
+			in.ServiceId, in.Retirement.TargetDate, util.GetIPFromContext(ctx))
+	}
+	return &pb.UpdateServiceRetirementResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Update service retirement successfully."),
+	}, nil
+}
+
+// UpdateSchemaFreeze 冻结或解冻服务的契约，冻结期间修改和删除契约返回ErrSchemaFrozen
+func (s *MicroServiceService) UpdateSchemaFreeze(ctx context.Context, in *pb.UpdateSchemaFreezeRequest) (*pb.UpdateSchemaFreezeResponse, error) {
+	if in == nil || len(in.ServiceId) == 0 {
+		util.Logger().Errorf(nil, "update schema freeze failed: invalid params.")
+		return &pb.UpdateSchemaFreezeResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "update schema freeze failed, serviceId is %s: invalid parameters.", in.ServiceId)
+		return &pb.UpdateSchemaFreezeResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("freeze", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	key := apt.GenerateServiceKey(domainProject, in.ServiceId)
+	service, err := serviceUtil.GetService(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "update schema freeze failed, serviceId is %s: query service failed.", in.ServiceId)
+		return &pb.UpdateSchemaFreezeResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if service == nil {
+		util.Logger().Errorf(nil, "update schema freeze failed, serviceId is %s: service not exist.", in.ServiceId)
+		return &pb.UpdateSchemaFreezeResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	operator := serviceUtil.SchemaFreezeOperator(ctx)
+	if in.Freeze != nil {
+		in.Freeze.Operator, in.Freeze.Timestamp = operator, now
+	}
+	service.SchemaFreeze = in.Freeze
+	service.ModTimestamp = now
+
+	data, err := json.Marshal(service)
+	if err != nil {
+		util.Logger().Errorf(err, "update schema freeze failed, serviceId is %s: json marshal service failed.", in.ServiceId)
+		return &pb.UpdateSchemaFreezeResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, "Service file marshal error."),
+		}, err
+	}
+
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(key),
+		registry.WithValue(data))
+	if err != nil {
+		util.Logger().Errorf(err, "update schema freeze failed, serviceId is %s: commit data into etcd failed.", in.ServiceId)
+		return &pb.UpdateSchemaFreezeResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, err.Error()),
+		}, err
+	}
+
+	if in.Freeze == nil {
+		util.Logger().Infof("unfreeze schemas successful: serviceId is %s, operator is %s.",
+			in.ServiceId, operator)
+	} else {
+		util.Logger().Infof("freeze schemas successful: serviceId is %s, reason is %s, operator is %s.",
+			in.ServiceId, in.Freeze.Reason, operator)
+	}
+	return &pb.UpdateSchemaFreezeResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Update schema freeze successfully."),
+	}, nil
+}
+
+// BatchExist 批量查询服务是否存在，按environment/appId/serviceName/version精确匹配
+func (s *MicroServiceService) BatchExist(ctx context.Context, in *pb.BatchGetExistenceRequest) (*pb.BatchGetExistenceResponse, error) {
+	if err := apt.Validate(in); err != nil {
+		util.Logger().Errorf(err, "batch microservice exist failed: invalid params.")
+		return &pb.BatchGetExistenceResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+	ids, err := serviceUtil.BatchGetServiceIds(ctx, domainProject, in.Services)
+	if err != nil {
+		util.Logger().Errorf(err, "batch microservice exist failed: get service indexes failed.")
+		return &pb.BatchGetExistenceResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	services := make([]*pb.ServiceExistence, 0, len(in.Services))
+	for i, key := range in.Services {
+		services = append(services, &pb.ServiceExistence{
+			Service:   key,
+			ServiceId: ids[i],
+		})
+	}
+	return &pb.BatchGetExistenceResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Get service ids successfully."),
+		Services: services,
+	}, nil
+}
+
 func (s *MicroServiceService) Exist(ctx context.Context, in *pb.GetExistenceRequest) (*pb.GetExistenceResponse, error) {
 	if in == nil {
 		util.Logger().Errorf(nil, "exist failed: invalid params.")
@@ -17,11 +17,17 @@
 package service_test
 
 import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	"github.com/apache/incubator-servicecomb-service-center/server/core"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/admission/buildin"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"golang.org/x/net/context"
 	"strings"
+	"time"
 )
 
 var (
@@ -41,6 +47,39 @@ var _ = Describe("'Micro-service' service", func() {
 			})
 		})
 
+		Context("when service has several invalid fields", func() {
+			It("should report every violated field", func() {
+				resp, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						AppId:       "default",
+						ServiceName: "create_invalid_fields",
+						Version:     "1.0.0",
+						Level:       "FRONT",
+						Status:      "UP",
+						Alias:       "@invalid",
+						Framework: &pb.FrameWorkProperty{
+							Name:    strings.Repeat("x", 100),
+							Version: "1.0.0",
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+				Expect(len(resp.Response.Details)).To(Equal(2))
+
+				details := map[string]*pb.ErrorDetail{}
+				for _, d := range resp.Response.Details {
+					details[d.Field] = d
+				}
+				Expect(details["service.Alias"]).ToNot(BeNil())
+				Expect(details["service.Alias"].Constraint).To(Equal("pattern"))
+				Expect(details["service.Alias"].Value).To(Equal("@invalid"))
+				Expect(details["service.Framework.Name"]).ToNot(BeNil())
+				Expect(details["service.Framework.Name"].Constraint).To(Equal("max"))
+				Expect(details["service.Framework.Name"].Value).To(Equal(strings.Repeat("x", 64) + "..."))
+			})
+		})
+
 		Context("when service with rules/tags/instances", func() {
 			It("should be passed", func() {
 				By("prepare data for creating")
@@ -235,6 +274,61 @@ var _ = Describe("'Micro-service' service", func() {
 			})
 		})
 
+		Context("when admission hooks are registered", func() {
+			It("should be mutated or rejected", func() {
+				buildin.RegisterServiceAdmitFunc("admission", func(ctx context.Context, domainProject string, in *pb.CreateServiceRequest) *scerr.Error {
+					if in.Service.ServiceName == "admission_reject" {
+						return scerr.NewError(scerr.ErrAdmissionDenied, "rejected")
+					}
+					in.Service.Properties = map[string]string{"datacenter": "dc1"}
+					return nil
+				})
+				ctx := util.SetContext(getContext(), "domain", "admission")
+
+				resp, err := serviceResource.Create(ctx, &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						ServiceName: "admission_reject",
+						AppId:       "default",
+						Version:     "1.0.0",
+						Level:       "FRONT",
+						Status:      "UP",
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrAdmissionDenied))
+
+				resp, err = serviceResource.Create(ctx, &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						ServiceName: "admission_mutate",
+						AppId:       "default",
+						Version:     "1.0.0",
+						Level:       "FRONT",
+						Status:      "UP",
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err := serviceResource.GetOne(ctx, &pb.GetServiceRequest{
+					ServiceId: resp.ServiceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Service.Properties["datacenter"]).To(Equal("dc1"))
+
+				resp, err = serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						ServiceName: "admission_reject",
+						AppId:       "default",
+						Version:     "1.0.0",
+						Level:       "FRONT",
+						Status:      "UP",
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+			})
+		})
+
 		Context("when service with properties", func() {
 			It("should be passed", func() {
 				r := &pb.CreateServiceRequest{
@@ -665,6 +759,62 @@ var _ = Describe("'Micro-service' service", func() {
 		})
 	})
 
+	Describe("execute 'batch exists' operartion", func() {
+		var serviceId string
+
+		It("should be passed", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					ServiceName: "batch_exist_service",
+					AppId:       "batch_exist_appId",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      "UP",
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.ServiceId).ToNot(Equal(""))
+			serviceId = respCreateService.ServiceId
+		})
+
+		Context("when param is invalid", func() {
+			It("should be failed", func() {
+				By("services is empty")
+				resp, err := serviceResource.BatchExist(getContext(), &pb.BatchGetExistenceRequest{})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("serviceName is empty")
+				resp, err = serviceResource.BatchExist(getContext(), &pb.BatchGetExistenceRequest{
+					Services: []*pb.MicroServiceKey{
+						{AppId: "batch_exist_appId", Version: "1.0.0"},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				resp, err := serviceResource.BatchExist(getContext(), &pb.BatchGetExistenceRequest{
+					Services: []*pb.MicroServiceKey{
+						{AppId: "batch_exist_appId", ServiceName: "notExistService", Version: "1.0.0"},
+						{AppId: "batch_exist_appId", ServiceName: "batch_exist_service", Version: "1.0.0"},
+						{AppId: "batch_exist_appId", ServiceName: "batch_exist_service", Version: "2.0.0"},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Services)).To(Equal(3))
+				Expect(resp.Services[0].ServiceId).To(Equal(""))
+				Expect(resp.Services[1].ServiceId).To(Equal(serviceId))
+				Expect(resp.Services[1].Service.ServiceName).To(Equal("batch_exist_service"))
+				Expect(resp.Services[2].ServiceId).To(Equal(""))
+			})
+		})
+	})
+
 	Describe("execute 'query' operartion", func() {
 		Context("when request is nil", func() {
 			It("should be failed", func() {
@@ -682,6 +832,55 @@ var _ = Describe("'Micro-service' service", func() {
 			})
 		})
 
+		Context("when query services by serviceName", func() {
+			It("should be passed", func() {
+				var serviceIds []string
+				for _, appId := range []string{"query_name_app1", "query_name_app2"} {
+					respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+						Service: &pb.MicroService{
+							ServiceName: "query_name_service",
+							AppId:       appId,
+							Version:     "1.0.0",
+							Level:       "FRONT",
+							Status:      "UP",
+						},
+					})
+					Expect(err).To(BeNil())
+					Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+					serviceIds = append(serviceIds, respCreateService.ServiceId)
+				}
+
+				resp, err := serviceResource.GetServices(getContext(), &pb.GetServicesRequest{
+					ServiceName: "query_name_service",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Services)).To(Equal(2))
+
+				By("delete one of them")
+				respDelete, err := serviceResource.Delete(getContext(), &pb.DeleteServiceRequest{
+					ServiceId: serviceIds[0],
+					Force:     true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respDelete.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				resp, err = serviceResource.GetServices(getContext(), &pb.GetServicesRequest{
+					ServiceName: "query_name_service",
+				})
+				Expect(err).To(BeNil())
+				Expect(len(resp.Services)).To(Equal(1))
+				Expect(resp.Services[0].ServiceId).To(Equal(serviceIds[1]))
+
+				By("serviceName is invalid")
+				resp, err = serviceResource.GetServices(getContext(), &pb.GetServicesRequest{
+					ServiceName: TOO_LONG_SERVICENAME,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+			})
+		})
+
 		Context("when query a not exist service by serviceId", func() {
 			It("should be failed", func() {
 				resp, err := serviceResource.GetOne(getContext(), &pb.GetServiceRequest{
@@ -754,6 +953,50 @@ var _ = Describe("'Micro-service' service", func() {
 			})
 		})
 
+		Context("when merge the properties", func() {
+			It("should be passed", func() {
+				resp, err := serviceResource.UpdateProperties(getContext(), &pb.UpdateServicePropsRequest{
+					ServiceId:  serviceId,
+					Properties: map[string]string{"a": "1", "b": "2"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				resp, err = serviceResource.UpdateProperties(getContext(), &pb.UpdateServicePropsRequest{
+					ServiceId:        serviceId,
+					Properties:       map[string]string{"b": "20", "c": "3"},
+					Merge:            true,
+					RemoveProperties: []string{"a"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				By("only remove properties")
+				resp, err = serviceResource.UpdateProperties(getContext(), &pb.UpdateServicePropsRequest{
+					ServiceId:        serviceId,
+					Merge:            true,
+					RemoveProperties: []string{"c"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err := serviceResource.GetOne(getContext(), &pb.GetServiceRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Service.Properties).To(Equal(map[string]string{"b": "20"}))
+
+				By("service does not exist")
+				resp, err = serviceResource.UpdateProperties(getContext(), &pb.UpdateServicePropsRequest{
+					ServiceId:  "notexistservice",
+					Properties: map[string]string{"b": "20"},
+					Merge:      true,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+			})
+		})
+
 		Context("when service does not exist", func() {
 			It("should be failed", func() {
 				r := &pb.UpdateServicePropsRequest{
@@ -798,6 +1041,116 @@ var _ = Describe("'Micro-service' service", func() {
 				Expect(resp.Response.Code).ToNot(Equal(pb.Response_SUCCESS))
 			})
 		})
+
+		Context("when catalog is valid", func() {
+			It("should be passed", func() {
+				resp, err := serviceResource.UpdateCatalog(getContext(), &pb.UpdateServiceCatalogRequest{
+					ServiceId: serviceId,
+					Catalog: &pb.ServiceCatalog{
+						DocsUrl:    "http://docs.example.com/update_prop_service",
+						RepoUrl:    "https://git.example.com/update_prop_service.git",
+						RunbookUrl: "https://wiki.example.com/runbook?id=1",
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				resp2, err := serviceResource.GetOne(getContext(), &pb.GetServiceRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp2.Service.Catalog).ToNot(BeNil())
+				Expect(resp2.Service.Catalog.DocsUrl).To(Equal("http://docs.example.com/update_prop_service"))
+				Expect(resp2.Service.Catalog.DashboardUrl).To(Equal(""))
+				Expect(resp2.Service.Properties["k"]).To(Equal("v"))
+			})
+		})
+
+		Context("when catalog is invalid", func() {
+			It("should be failed", func() {
+				resp, err := serviceResource.UpdateCatalog(getContext(), &pb.UpdateServiceCatalogRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				resp, err = serviceResource.UpdateCatalog(getContext(), &pb.UpdateServiceCatalogRequest{
+					ServiceId: serviceId,
+					Catalog:   &pb.ServiceCatalog{DocsUrl: "ftp://docs.example.com"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				resp, err = serviceResource.UpdateCatalog(getContext(), &pb.UpdateServiceCatalogRequest{
+					ServiceId: "notexistservice",
+					Catalog:   &pb.ServiceCatalog{},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+			})
+		})
+
+		Context("when retirement is valid", func() {
+			It("should be passed", func() {
+				resp, err := serviceResource.UpdateRetirement(getContext(), &pb.UpdateServiceRetirementRequest{
+					ServiceId: serviceId,
+					Retirement: &pb.ServiceRetirement{
+						TargetDate:        "2030-06-30",
+						Message:           "use 2.0.0 instead",
+						ExcludeFromLatest: true,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				resp2, err := serviceResource.GetOne(getContext(), &pb.GetServiceRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp2.Service.Retirement).ToNot(BeNil())
+				Expect(resp2.Service.Retirement.TargetDate).To(Equal("2030-06-30"))
+				Expect(resp2.Service.Retirement.Timestamp).ToNot(Equal(""))
+				Expect(resp2.Service.Catalog).ToNot(BeNil())
+
+				By("cancel the retirement")
+				resp, err = serviceResource.UpdateRetirement(getContext(), &pb.UpdateServiceRetirementRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				resp2, err = serviceResource.GetOne(getContext(), &pb.GetServiceRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp2.Service.Retirement).To(BeNil())
+			})
+		})
+
+		Context("when retirement is invalid", func() {
+			It("should be failed", func() {
+				resp, err := serviceResource.UpdateRetirement(getContext(), &pb.UpdateServiceRetirementRequest{
+					ServiceId:  serviceId,
+					Retirement: &pb.ServiceRetirement{},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				resp, err = serviceResource.UpdateRetirement(getContext(), &pb.UpdateServiceRetirementRequest{
+					ServiceId:  serviceId,
+					Retirement: &pb.ServiceRetirement{TargetDate: "2030-13-01"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				resp, err = serviceResource.UpdateRetirement(getContext(), &pb.UpdateServiceRetirementRequest{
+					ServiceId:  "notexistservice",
+					Retirement: &pb.ServiceRetirement{TargetDate: "2030-06-30"},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+			})
+		})
 	})
 
 	Describe("execute 'delete' operartion", func() {
@@ -1093,4 +1446,67 @@ var _ = Describe("'Micro-service' service", func() {
 			})
 		})
 	})
+
+	Describe("execute 'resume cleanup' operartion", func() {
+		Context("when delete is interrupted", func() {
+			It("should be cleaned up", func() {
+				resp, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						ServiceName: "interrupted_delete_service",
+						AppId:       "interrupted_delete",
+						Version:     "1.0.0",
+						Level:       "FRONT",
+						Status:      pb.MS_UP,
+					},
+					Tags: map[string]string{"a": "b"},
+					Instances: []*pb.MicroServiceInstance{
+						{
+							Endpoints: []string{"interruptedDelete:127.0.0.1:8080"},
+							HostName:  "UT-HOST",
+							Status:    pb.MSI_UP,
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				serviceId := resp.ServiceId
+
+				respGet, err := serviceResource.GetOne(getContext(), &pb.GetServiceRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+
+				By("process exits after unregister")
+				err = serviceUtil.UnregisterService(getContext(), "default/default", respGet.Service)
+				Expect(err).To(BeNil())
+
+				respExist, err := serviceResource.GetOne(getContext(), &pb.GetServiceRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respExist.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+
+				tags, err := serviceUtil.GetTagsUtils(getContext(), "default/default", serviceId)
+				Expect(err).To(BeNil())
+				Expect(len(tags)).To(Equal(1))
+
+				By("resume cleanup")
+				n, err := serviceUtil.ResumeServiceCleanup(getContext(), time.Now().Add(time.Second))
+				Expect(err).To(BeNil())
+				Expect(n).To(Equal(1))
+
+				tags, err = serviceUtil.GetTagsUtils(getContext(), "default/default", serviceId)
+				Expect(err).To(BeNil())
+				Expect(len(tags)).To(Equal(0))
+
+				instances, err := serviceUtil.GetAllInstancesOfOneService(getContext(), "default/default", serviceId)
+				Expect(err).To(BeNil())
+				Expect(len(instances)).To(Equal(0))
+
+				n, err = serviceUtil.ResumeServiceCleanup(getContext(), time.Now().Add(time.Second))
+				Expect(err).To(BeNil())
+				Expect(n).To(Equal(0))
+			})
+		})
+	})
 })
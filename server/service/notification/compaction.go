@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package notification
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"sync"
+	"time"
+)
+
+// eventCompactor 合并频繁变化(如UP/DOWN抖动)的实例事件，每个实例在窗口内最多推送一次，
+// 窗口内的后续事件只保留最新状态，窗口结束时推送并携带被丢弃的事件数
+type eventCompactor struct {
+	window time.Duration
+
+	mux        sync.Mutex
+	lastSent   map[string]time.Time
+	pending    map[string]*WatchJob
+	suppressed map[string]int32
+	order      []string
+	stopCh     chan struct{}
+}
+
+func compactable(job *WatchJob) bool {
+	if job.Response == nil || job.Response.Instance == nil {
+		return false
+	}
+	switch pb.EventType(job.Response.Action) {
+	case pb.EVT_CREATE, pb.EVT_UPDATE, pb.EVT_DELETE, pb.EVT_EXPIRE:
+		return true
+	default:
+		return false
+	}
+}
+
+// Offer 返回true时立即推送，否则事件已被缓存或合并
+func (c *eventCompactor) Offer(job *WatchJob) bool {
+	if !compactable(job) {
+		return true
+	}
+	key := instanceKey(job.Response)
+	now := time.Now()
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if old, ok := c.pending[key]; ok {
+		if job.Revision >= old.Revision {
+			c.pending[key] = job
+		}
+		c.suppressed[key]++
+		return false
+	}
+	// 缓存已满时不再合并，直接推送
+	if last, ok := c.lastSent[key]; ok && now.Sub(last) < c.window && len(c.pending) < DEFAULT_MAX_QUEUE {
+		c.pending[key] = job
+		c.order = append(c.order, key)
+		return false
+	}
+	c.lastSent[key] = now
+	return true
+}
+
+// Flush 取出窗口已结束的缓存事件，事件被多个订阅者共享，设置计数前需复制
+func (c *eventCompactor) Flush() []*WatchJob {
+	now := time.Now()
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	var jobs []*WatchJob
+	order := c.order[:0]
+	for _, key := range c.order {
+		if now.Sub(c.lastSent[key]) < c.window {
+			order = append(order, key)
+			continue
+		}
+		job := c.pending[key]
+		if n := c.suppressed[key]; n > 0 {
+			resp := *job.Response
+			resp.Suppressed = n
+			job = NewWatchJob(job.Type(), job.SubscriberId(), job.Subject(), job.Revision, &resp)
+		}
+		jobs = append(jobs, job)
+		delete(c.pending, key)
+		delete(c.suppressed, key)
+		c.lastSent[key] = now
+	}
+	c.order = order
+
+	// 清理窗口外且无缓存事件的实例
+	for key, last := range c.lastSent {
+		if _, ok := c.pending[key]; !ok && now.Sub(last) >= c.window {
+			delete(c.lastSent, key)
+		}
+	}
+	return jobs
+}
+
+// Run 周期性地推送窗口已结束的事件，直到Stop
+func (c *eventCompactor) Run(send func(job *WatchJob)) {
+	ticker := time.NewTicker(c.window / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			for _, job := range c.Flush() {
+				send(job)
+			}
+		}
+	}
+}
+
+func (c *eventCompactor) Stop() {
+	close(c.stopCh)
+}
+
+func newEventCompactor(window time.Duration) *eventCompactor {
+	return &eventCompactor{
+		window:     window,
+		lastSent:   make(map[string]time.Time),
+		pending:    make(map[string]*WatchJob),
+		suppressed: make(map[string]int32),
+		stopCh:     make(chan struct{}),
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package notification
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"testing"
+	"time"
+)
+
+func statusJob(instanceId, status string, rev int64) *WatchJob {
+	return NewWatchJob(INSTANCE, "consumer", "subject", rev, &pb.WatchInstanceResponse{
+		Action:   string(pb.EVT_UPDATE),
+		Instance: &pb.MicroServiceInstance{ServiceId: "s", InstanceId: instanceId, Status: status},
+	})
+}
+
+// waitFor 轮询直到cond成立，超时则失败
+func waitFor(t *testing.T, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("wait for condition timed out")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestEventCompactor(t *testing.T) {
+	// 窗口足够长，保证窗口结束前的断言不受调度影响
+	window := time.Second
+	c := newEventCompactor(window)
+
+	// 窗口内第一次变化立即推送，之后的UP/DOWN抖动只保留最新状态
+	if !c.Offer(statusJob("i1", pb.MSI_DOWN, 1)) {
+		t.Fatalf("TestEventCompactor failed, first event should be sent")
+	}
+	latest := statusJob("i1", pb.MSI_UP, 4)
+	if c.Offer(statusJob("i1", pb.MSI_UP, 2)) || c.Offer(statusJob("i1", pb.MSI_DOWN, 3)) || c.Offer(latest) {
+		t.Fatalf("TestEventCompactor failed, events in window should be held")
+	}
+	// 乱序到达的旧事件不覆盖最新状态，但计入被丢弃的事件数
+	if c.Offer(statusJob("i1", pb.MSI_DOWN, 2)) {
+		t.Fatalf("TestEventCompactor failed, stale event should be held")
+	}
+	// 其他实例、非实例事件不受影响
+	if !c.Offer(statusJob("i2", pb.MSI_DOWN, 5)) {
+		t.Fatalf("TestEventCompactor failed, other instance should be sent")
+	}
+	if !c.Offer(NewWatchJob(INSTANCE, "consumer", "subject", 6, &pb.WatchInstanceResponse{Action: string(pb.EVT_UPDATE)})) {
+		t.Fatalf("TestEventCompactor failed, non-instance event should be sent")
+	}
+	if jobs := c.Flush(); len(jobs) != 0 {
+		t.Fatalf("TestEventCompactor failed, flushed %d events before window ends", len(jobs))
+	}
+
+	var jobs []*WatchJob
+	waitFor(t, func() bool {
+		jobs = c.Flush()
+		return len(jobs) > 0
+	})
+	if len(jobs) != 1 {
+		t.Fatalf("TestEventCompactor failed, expect 1 coalesced event but %d", len(jobs))
+	}
+	job := jobs[0]
+	if job.Revision != 4 || job.Response.Instance.Status != pb.MSI_UP || job.Response.Suppressed != 3 {
+		t.Fatalf("TestEventCompactor failed, coalesced event rev %d status %s suppressed %d",
+			job.Revision, job.Response.Instance.Status, job.Response.Suppressed)
+	}
+	// 事件被多个订阅者共享，计数设置在副本上
+	if latest.Response.Suppressed != 0 {
+		t.Fatalf("TestEventCompactor failed, shared event modified")
+	}
+
+	// 推送后开始新的窗口
+	if c.Offer(statusJob("i1", pb.MSI_DOWN, 7)) {
+		t.Fatalf("TestEventCompactor failed, event in new window should be held")
+	}
+	waitFor(t, func() bool {
+		jobs = c.Flush()
+		return len(jobs) > 0
+	})
+	if len(jobs) != 1 || jobs[0].Revision != 7 || jobs[0].Response.Suppressed != 0 {
+		t.Fatalf("TestEventCompactor failed, expect single held event without suppressed count, %v", jobs)
+	}
+
+	// 窗口外的变化立即推送
+	waitFor(t, func() bool {
+		c.Flush()
+		c.mux.Lock()
+		defer c.mux.Unlock()
+		return len(c.lastSent) == 0
+	})
+	if !c.Offer(statusJob("i1", pb.MSI_UP, 8)) {
+		t.Fatalf("TestEventCompactor failed, event after window should be sent")
+	}
+}
+
+func TestListWatcherEnableCompaction(t *testing.T) {
+	w := NewListWatcher(INSTANCE, "consumer", "subject", func() ([]*pb.WatchInstanceResponse, int64) {
+		return nil, 0
+	})
+	w.EnableCompaction(0)
+	if w.compactor != nil {
+		t.Fatalf("TestListWatcherEnableCompaction failed, compaction enabled with zero window")
+	}
+
+	w.EnableCompaction(time.Second)
+	w.listAndPublishJobs()
+	if job := (<-w.Job).(*WatchJob); job.Response.Action != string(pb.EVT_INIT_DONE) {
+		t.Fatalf("TestListWatcherEnableCompaction failed, expect INIT_DONE but %s", job.Response.Action)
+	}
+	go w.compactor.Run(func(job *WatchJob) { w.sendMessage(job) })
+	defer w.compactor.Stop()
+
+	w.OnMessage(statusJob("i1", pb.MSI_DOWN, 1))
+	w.OnMessage(statusJob("i1", pb.MSI_UP, 2))
+	w.OnMessage(statusJob("i1", pb.MSI_DOWN, 3))
+	w.OnMessage(statusJob("i1", pb.MSI_UP, 4))
+
+	if job := (<-w.Job).(*WatchJob); job.Revision != 1 || job.Response.Suppressed != 0 {
+		t.Fatalf("TestListWatcherEnableCompaction failed, expect first event but rev %d", job.Revision)
+	}
+	select {
+	case job := <-w.Job:
+		wJob := job.(*WatchJob)
+		if wJob.Revision != 4 || wJob.Response.Instance.Status != pb.MSI_UP || wJob.Response.Suppressed != 2 {
+			t.Fatalf("TestListWatcherEnableCompaction failed, coalesced event rev %d suppressed %d",
+				wJob.Revision, wJob.Response.Suppressed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("TestListWatcherEnableCompaction failed, coalesced event not flushed")
+	}
+	// 合并后的事件在Flush取出缓存后才推送，此时不应再有缓存或待推送的事件
+	w.compactor.mux.Lock()
+	pending := len(w.compactor.pending)
+	w.compactor.mux.Unlock()
+	if pending != 0 || len(w.Job) != 0 {
+		t.Fatalf("TestListWatcherEnableCompaction failed, %d events pending, %d events queued", pending, len(w.Job))
+	}
+}
@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"strconv"
+)
+
+// 信封的最新版本，事件结构不兼容变化时递增，并保留旧版本的转换
+const WATCH_EVENT_VERSION int32 = 1
+
+const (
+	EVENT_FORMAT_JSON  = "json"
+	EVENT_FORMAT_PROTO = "proto"
+)
+
+const (
+	EVENT_TYPE_INSTANCE     = "instance"
+	EVENT_TYPE_RULE         = "rule"
+	EVENT_TYPE_INVALIDATION = "invalidation"
+	EVENT_TYPE_BROADCAST    = "broadcast"
+)
+
+// EventEncoder 将watch事件编码为websocket消息
+type EventEncoder interface {
+	Encode(job *WatchJob) (messageType int, data []byte, err error)
+}
+
+// legacyEncoder 未协商格式时保持原有的json结构
+type legacyEncoder struct {
+}
+
+func (e *legacyEncoder) Encode(job *WatchJob) (int, []byte, error) {
+	data, err := json.Marshal(eventOf(job.Response))
+	return websocket.TextMessage, data, err
+}
+
+// envelopeEncoder 将事件封装为带类型和版本的信封，json为文本消息，proto为二进制消息
+type envelopeEncoder struct {
+	format  string
+	version int32
+}
+
+func (e *envelopeEncoder) Encode(job *WatchJob) (int, []byte, error) {
+	envelope := &pb.WatchEventEnvelope{
+		Type:     eventType(job.Response.Action),
+		Version:  e.version,
+		Revision: job.Revision,
+		Event:    eventOf(job.Response),
+	}
+	if e.format == EVENT_FORMAT_PROTO {
+		data, err := proto.Marshal(envelope)
+		return websocket.BinaryMessage, data, err
+	}
+	data, err := json.Marshal(envelope)
+	return websocket.TextMessage, data, err
+}
+
+type encodedEvent struct {
+	messageType int
+	data        []byte
+	err         error
+}
+
+// encoderKey 相同key的编码器对同一事件的编码结果相同，无法识别的编码器返回空
+func encoderKey(e EventEncoder) string {
+	switch v := e.(type) {
+	case *legacyEncoder:
+		return "legacy"
+	case *envelopeEncoder:
+		return v.format + "/" + strconv.Itoa(int(v.version))
+	default:
+		return ""
+	}
+}
+
+// encodeShared 同一事件推送给消费者组的多个成员时，相同格式只编码一次
+func encodeShared(e EventEncoder, job *WatchJob) (int, []byte, error) {
+	key := encoderKey(e)
+	if len(key) == 0 {
+		return e.Encode(job)
+	}
+	job.encodeLock.Lock()
+	defer job.encodeLock.Unlock()
+	if ev, ok := job.encoded[key]; ok {
+		return ev.messageType, ev.data, ev.err
+	}
+	messageType, data, err := e.Encode(job)
+	if job.encoded == nil {
+		job.encoded = make(map[string]*encodedEvent)
+	}
+	job.encoded[key] = &encodedEvent{messageType: messageType, data: data, err: err}
+	return messageType, data, err
+}
+
+// NewEventEncoder 按订阅时协商的格式和版本创建编码器，format为空时使用原有格式
+func NewEventEncoder(format string, version int32) (EventEncoder, error) {
+	if len(format) == 0 {
+		if version != 0 {
+			return nil, fmt.Errorf("Event version requires format %s or %s.", EVENT_FORMAT_JSON, EVENT_FORMAT_PROTO)
+		}
+		return &legacyEncoder{}, nil
+	}
+	if format != EVENT_FORMAT_JSON && format != EVENT_FORMAT_PROTO {
+		return nil, fmt.Errorf("Unsupported event format '%s'.", format)
+	}
+	if version == 0 {
+		version = WATCH_EVENT_VERSION
+	}
+	if version < 0 || version > WATCH_EVENT_VERSION {
+		return nil, fmt.Errorf("Unsupported event version %d, the latest is %d.", version, WATCH_EVENT_VERSION)
+	}
+	return &envelopeEncoder{format: format, version: version}, nil
+}
+
+// eventOf 去掉内部的Response，事件被多个订阅者共享，不能直接修改
+func eventOf(resp *pb.WatchInstanceResponse) *pb.WatchInstanceResponse {
+	event := *resp
+	event.Response = nil
+	return &event
+}
+
+func eventType(action string) string {
+	switch pb.EventType(action) {
+	case pb.EVT_RULE_CHANGED:
+		return EVENT_TYPE_RULE
+	case pb.EVT_INVALIDATE:
+		return EVENT_TYPE_INVALIDATION
+	case pb.EVT_BROADCAST:
+		return EVENT_TYPE_BROADCAST
+	default:
+		return EVENT_TYPE_INSTANCE
+	}
+}
@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package notification
+
+import (
+	"encoding/json"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/websocket"
+	"testing"
+)
+
+func ruleJob() *WatchJob {
+	return NewWatchJob(INSTANCE, "consumer", "subject", 10, &pb.WatchInstanceResponse{
+		Response:   pb.CreateResponse(pb.Response_SUCCESS, "Watch instance successfully."),
+		Action:     string(pb.EVT_RULE_CHANGED),
+		Key:        &pb.MicroServiceKey{AppId: "a", ServiceName: "s", Version: "1.0.0"},
+		Permission: pb.PERMISSION_DENY,
+	})
+}
+
+func TestNewEventEncoder(t *testing.T) {
+	for _, c := range []struct {
+		format  string
+		version int32
+		ok      bool
+	}{
+		{"", 0, true},
+		{"", 1, false},
+		{EVENT_FORMAT_JSON, 0, true},
+		{EVENT_FORMAT_PROTO, WATCH_EVENT_VERSION, true},
+		{EVENT_FORMAT_JSON, WATCH_EVENT_VERSION + 1, false},
+		{EVENT_FORMAT_JSON, -1, false},
+		{"xml", 0, false},
+	} {
+		e, err := NewEventEncoder(c.format, c.version)
+		if (err == nil) != c.ok {
+			t.Fatalf("TestNewEventEncoder failed, format '%s' version %d, %v", c.format, c.version, err)
+		}
+		if err == nil && len(c.format) > 0 && e.(*envelopeEncoder).version != WATCH_EVENT_VERSION {
+			t.Fatalf("TestNewEventEncoder failed, version should default to the latest")
+		}
+	}
+}
+
+func TestEventEncoder_Encode(t *testing.T) {
+	job := ruleJob()
+
+	// 未协商格式时为原有结构，不含Response
+	e, _ := NewEventEncoder("", 0)
+	messageType, data, err := e.Encode(job)
+	if err != nil || messageType != websocket.TextMessage {
+		t.Fatalf("TestEventEncoder_Encode failed, legacy %d, %v", messageType, err)
+	}
+	legacy := map[string]interface{}{}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		t.Fatalf("TestEventEncoder_Encode failed, legacy %s, %s", data, err)
+	}
+	if _, ok := legacy["response"]; ok || legacy["action"] != string(pb.EVT_RULE_CHANGED) || legacy["permission"] != pb.PERMISSION_DENY {
+		t.Fatalf("TestEventEncoder_Encode failed, legacy %s", data)
+	}
+	if job.Response.Response == nil {
+		t.Fatalf("TestEventEncoder_Encode failed, shared event modified")
+	}
+
+	e, _ = NewEventEncoder(EVENT_FORMAT_JSON, 0)
+	messageType, data, err = e.Encode(job)
+	if err != nil || messageType != websocket.TextMessage {
+		t.Fatalf("TestEventEncoder_Encode failed, json %d, %v", messageType, err)
+	}
+	envelope := &pb.WatchEventEnvelope{}
+	if err := json.Unmarshal(data, envelope); err != nil {
+		t.Fatalf("TestEventEncoder_Encode failed, json %s, %s", data, err)
+	}
+	if envelope.Type != EVENT_TYPE_RULE || envelope.Version != WATCH_EVENT_VERSION || envelope.Revision != 10 ||
+		envelope.Event.Response != nil || envelope.Event.Key.ServiceName != "s" {
+		t.Fatalf("TestEventEncoder_Encode failed, json %s", data)
+	}
+
+	e, _ = NewEventEncoder(EVENT_FORMAT_PROTO, 0)
+	messageType, data, err = e.Encode(job)
+	if err != nil || messageType != websocket.BinaryMessage {
+		t.Fatalf("TestEventEncoder_Encode failed, proto %d, %v", messageType, err)
+	}
+	envelope = &pb.WatchEventEnvelope{}
+	if err := proto.Unmarshal(data, envelope); err != nil {
+		t.Fatalf("TestEventEncoder_Encode failed, proto %s", err)
+	}
+	if envelope.Type != EVENT_TYPE_RULE || envelope.Revision != 10 || envelope.Event.Permission != pb.PERMISSION_DENY {
+		t.Fatalf("TestEventEncoder_Encode failed, proto %v", envelope)
+	}
+}
+
+func TestEncodeShared(t *testing.T) {
+	job := ruleJob()
+	jsonEncoder, _ := NewEventEncoder(EVENT_FORMAT_JSON, 0)
+	protoEncoder, _ := NewEventEncoder(EVENT_FORMAT_PROTO, 0)
+
+	_, first, _ := encodeShared(jsonEncoder, job)
+	// 编码结果被缓存，之后修改事件不影响相同格式的编码结果
+	job.Response.Permission = pb.PERMISSION_ALLOW
+	_, second, _ := encodeShared(&envelopeEncoder{format: EVENT_FORMAT_JSON, version: WATCH_EVENT_VERSION}, job)
+	if string(first) != string(second) {
+		t.Fatalf("TestEncodeShared failed, same format encoded twice")
+	}
+	// 不同格式分别编码
+	messageType, _, _ := encodeShared(protoEncoder, job)
+	if messageType != websocket.BinaryMessage || len(job.encoded) != 2 {
+		t.Fatalf("TestEncodeShared failed, %d formats cached", len(job.encoded))
+	}
+}
@@ -0,0 +1,211 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package notification
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"regexp"
+	"sync"
+)
+
+var (
+	groupNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,63}$`)
+
+	consumerGroups = &groupRegistry{groups: make(map[string]*GroupWatcher)}
+
+	groupGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "notify",
+			Name:      "consumer_groups",
+			Help:      "Number of the current consumer groups",
+		})
+
+	groupMemberGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "notify",
+			Name:      "consumer_group_members",
+			Help:      "Number of the watchers attached to consumer groups",
+		})
+)
+
+func init() {
+	prometheus.MustRegister(groupGauge, groupMemberGauge)
+}
+
+// GroupWatcher 同一服务同一消费者组的多个副本共享的订阅，在通知服务中只占一个subscriber，
+// 事件投递一次后由组分发给各成员，成员之间共享编码结果
+type GroupWatcher struct {
+	BaseSubscriber
+	Group string
+
+	lock    sync.RWMutex
+	members map[*ListWatcher]struct{}
+	closed  bool
+}
+
+// OnMessage 分发给所有成员，成员各自处理快照期间的缓存、合并和revision过滤
+func (g *GroupWatcher) OnMessage(job NotifyJob) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	for member := range g.members {
+		if member.Err() != nil {
+			continue
+		}
+		go member.OnMessage(job)
+	}
+}
+
+// Close 被通知服务移除时断开所有成员
+func (g *GroupWatcher) Close() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.closed {
+		return
+	}
+	g.closed = true
+	for member := range g.members {
+		member.Close()
+	}
+	groupMemberGauge.Sub(float64(len(g.members)))
+	g.members = nil
+	groupGauge.Dec()
+	util.Logger().Infof("consumer group %s of watcher %s %s is closed", g.Group, g.Id(), g.Subject())
+}
+
+// Members 返回当前成员数
+func (g *GroupWatcher) Members() int {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return len(g.members)
+}
+
+func (g *GroupWatcher) join(member *ListWatcher) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.closed {
+		return false
+	}
+	g.members[member] = struct{}{}
+	groupMemberGauge.Inc()
+	return true
+}
+
+// leave 返回组是否已没有成员
+func (g *GroupWatcher) leave(member *ListWatcher) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if _, ok := g.members[member]; ok {
+		delete(g.members, member)
+		groupMemberGauge.Dec()
+		member.Close()
+	}
+	return len(g.members) == 0
+}
+
+func NewGroupWatcher(nType NotifyType, id, subject, group string) *GroupWatcher {
+	return &GroupWatcher{
+		BaseSubscriber: BaseSubscriber{
+			id:      id,
+			subject: subject,
+			nType:   nType,
+		},
+		Group:   group,
+		members: make(map[*ListWatcher]struct{}),
+	}
+}
+
+// groupRegistry 按subject、服务和组名索引当前的消费者组
+type groupRegistry struct {
+	lock   sync.Mutex
+	groups map[string]*GroupWatcher
+}
+
+func groupKey(nType NotifyType, id, subject, group string) string {
+	return util.StringJoin([]string{nType.String(), subject, id, group}, "/")
+}
+
+// Join 将watcher加入同类型、同服务、同组名的共享订阅，组不存在时创建并加入通知服务，
+// 组只在创建时受subscriber数上限的限制
+func (r *groupRegistry) Join(group string, member *ListWatcher) (*GroupWatcher, error) {
+	// 成员不加入通知服务，出错时由所在连接的处理退出并离开组
+	member.SetService(GetNotifyService())
+
+	key := groupKey(member.Type(), member.Id(), member.Subject(), group)
+	r.lock.Lock()
+	g, ok := r.groups[key]
+	if !ok || !g.join(member) {
+		g = NewGroupWatcher(member.Type(), member.Id(), member.Subject(), group)
+		if err := GetNotifyService().AddSubscriber(g); err != nil {
+			r.lock.Unlock()
+			return nil, err
+		}
+		groupGauge.Inc()
+		g.join(member)
+		r.groups[key] = g
+	}
+	r.lock.Unlock()
+
+	member.OnAccept()
+	return g, nil
+}
+
+// Leave 成员离开组，最后一个成员离开时从通知服务移除组
+func (r *groupRegistry) Leave(g *GroupWatcher, member *ListWatcher) {
+	key := groupKey(g.Type(), g.Id(), g.Subject(), g.Group)
+	r.lock.Lock()
+	empty := g.leave(member)
+	if !empty || r.groups[key] != g {
+		r.lock.Unlock()
+		return
+	}
+	delete(r.groups, key)
+	r.lock.Unlock()
+	// 不能持有r.lock，通知服务移除时会调用g.Close
+	GetNotifyService().RemoveSubscriber(g)
+}
+
+// CheckGroup 组名为空表示不加入消费者组，否则以字母或数字开头且不超过64个字符
+func CheckGroup(group string) error {
+	if len(group) > 0 && !groupNameRegex.MatchString(group) {
+		return fmt.Errorf("Invalid group '%s'.", group)
+	}
+	return nil
+}
+
+// GroupMembers 返回消费者组当前的成员数，组不存在时返回0
+func GroupMembers(nType NotifyType, id, subject, group string) int {
+	consumerGroups.lock.Lock()
+	g, ok := consumerGroups.groups[groupKey(nType, id, subject, group)]
+	consumerGroups.lock.Unlock()
+	if !ok {
+		return 0
+	}
+	return g.Members()
+}
+
+// JoinGroup 以消费者组的方式订阅，同组的watcher共享一个subscriber
+func JoinGroup(group string, member *ListWatcher) (*GroupWatcher, error) {
+	return consumerGroups.Join(group, member)
+}
+
+func LeaveGroup(g *GroupWatcher, member *ListWatcher) {
+	consumerGroups.Leave(g, member)
+}
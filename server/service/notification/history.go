@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package notification
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"sync"
+)
+
+var ErrHistoryDisabled = errors.New("watch history is disabled, can not resume from the revision")
+
+// RevisionCompactedError 请求的revision之后的事件已不完整，客户端需重新Find后再watch
+type RevisionCompactedError struct {
+	Revision  int64
+	Compacted int64
+}
+
+func (e *RevisionCompactedError) Error() string {
+	return fmt.Sprintf("revision %d has been compacted, the oldest revision to resume from is %d, find again",
+		e.Revision, e.Compacted)
+}
+
+// eventHistory 最近推送给消费者的实例事件，watch从Find返回的revision续接时补发其后的事件
+type eventHistory struct {
+	lock sync.RWMutex
+	jobs []*WatchJob
+	next int
+	// 已淘汰事件的最大revision，不同缓存的事件revision不保证递增，按最大值判断
+	compacted int64
+}
+
+func (h *eventHistory) Record(job *WatchJob) {
+	h.lock.Lock()
+	if old := h.jobs[h.next]; old != nil && old.Revision > h.compacted {
+		h.compacted = old.Revision
+	}
+	h.jobs[h.next] = job
+	h.next = (h.next + 1) % len(h.jobs)
+	h.lock.Unlock()
+}
+
+func (h *eventHistory) Check(rev int64) error {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	if rev < h.compacted {
+		return &RevisionCompactedError{Revision: rev, Compacted: h.compacted}
+	}
+	return nil
+}
+
+// Since 返回subscriber在rev之后的事件，按记录顺序
+func (h *eventHistory) Since(subscriberId, subject string, rev int64) ([]*WatchJob, error) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	if rev < h.compacted {
+		return nil, &RevisionCompactedError{Revision: rev, Compacted: h.compacted}
+	}
+	var jobs []*WatchJob
+	for i := range h.jobs {
+		job := h.jobs[(h.next+i)%len(h.jobs)]
+		if job == nil || job.Revision <= rev ||
+			job.SubscriberId() != subscriberId || job.Subject() != subject {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func newEventHistory(size int, start int64) *eventHistory {
+	return &eventHistory{
+		jobs:      make([]*WatchJob, size),
+		compacted: start,
+	}
+}
+
+// CheckRevision watch从rev续接前检查其后的事件是否完整
+func (s *NotifyService) CheckRevision(rev int64) error {
+	if s.history == nil {
+		return ErrHistoryDisabled
+	}
+	return s.history.Check(rev)
+}
+
+func (s *NotifyService) recordHistory(job *WatchJob) {
+	if s.history == nil {
+		return
+	}
+	s.history.Record(job)
+}
+
+// NewInstanceResumeWatcher 先补发rev之后的事件，并以INIT_DONE结束，再推送新的事件；
+// 补发期间到达的事件与补发的事件可能重复，重复的事件不改变客户端的最终状态
+func NewInstanceResumeWatcher(selfServiceId, instanceRoot string, rev int64) *ListWatcher {
+	watcher := NewInstanceWatcher(selfServiceId, instanceRoot)
+	watcher.ListFunc = func() ([]*pb.WatchInstanceResponse, int64) {
+		history := GetNotifyService().history
+		if history == nil {
+			watcher.SetError(ErrHistoryDisabled)
+			return nil, rev
+		}
+		jobs, err := history.Since(watcher.Id(), watcher.Subject(), rev)
+		if err != nil {
+			watcher.SetError(err)
+			return nil, rev
+		}
+		results := make([]*pb.WatchInstanceResponse, 0, len(jobs))
+		for _, job := range jobs {
+			results = append(results, job.Response)
+		}
+		return results, rev
+	}
+	return watcher
+}
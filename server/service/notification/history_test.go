@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package notification
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newHistoryJob(subscriberId, subject string, rev int64) *WatchJob {
+	return NewWatchJob(INSTANCE, subscriberId, subject, rev, nil)
+}
+
+func TestEventHistorySince(t *testing.T) {
+	cases := []struct {
+		name    string
+		size    int
+		start   int64
+		records []*WatchJob
+		// 查询条件
+		subscriberId, subject string
+		rev                   int64
+		// 期望返回的revision，compacted非0时期望RevisionCompactedError
+		revs      []int64
+		compacted int64
+	}{
+		{name: "filter by subscriber and subject", size: 4,
+			records: []*WatchJob{newHistoryJob("a", "s1", 1), newHistoryJob("b", "s1", 2),
+				newHistoryJob("a", "s2", 3), newHistoryJob("a", "s1", 4)},
+			subscriberId: "a", subject: "s1", rev: 0, revs: []int64{1, 4}},
+		{name: "other subscriber", size: 4,
+			records: []*WatchJob{newHistoryJob("a", "s1", 1), newHistoryJob("b", "s1", 2),
+				newHistoryJob("a", "s2", 3)},
+			subscriberId: "b", subject: "s1", rev: 0, revs: []int64{2}},
+		{name: "other subject", size: 4,
+			records:      []*WatchJob{newHistoryJob("a", "s1", 1), newHistoryJob("a", "s2", 2)},
+			subscriberId: "a", subject: "s2", rev: 0, revs: []int64{2}},
+		{name: "after revision", size: 4,
+			records: []*WatchJob{newHistoryJob("a", "s1", 1), newHistoryJob("a", "s1", 2),
+				newHistoryJob("a", "s1", 3)},
+			subscriberId: "a", subject: "s1", rev: 2, revs: []int64{3}},
+		{name: "nothing after revision", size: 4,
+			records:      []*WatchJob{newHistoryJob("a", "s1", 1)},
+			subscriberId: "a", subject: "s1", rev: 1},
+		{name: "record order after wrap around", size: 2,
+			records: []*WatchJob{newHistoryJob("a", "s1", 1), newHistoryJob("a", "s1", 2),
+				newHistoryJob("a", "s1", 3)},
+			subscriberId: "a", subject: "s1", rev: 1, revs: []int64{2, 3}},
+		{name: "evicted revision compacted", size: 2,
+			records: []*WatchJob{newHistoryJob("a", "s1", 5), newHistoryJob("a", "s1", 6),
+				newHistoryJob("a", "s1", 7)},
+			subscriberId: "a", subject: "s1", rev: 4, compacted: 5},
+		{name: "evicted by other subscribers", size: 2,
+			records: []*WatchJob{newHistoryJob("a", "s1", 5), newHistoryJob("b", "s1", 6),
+				newHistoryJob("b", "s1", 7)},
+			subscriberId: "a", subject: "s1", rev: 4, compacted: 5},
+		{name: "compacted keeps max evicted revision", size: 2,
+			records: []*WatchJob{newHistoryJob("a", "s1", 10), newHistoryJob("a", "s1", 8),
+				newHistoryJob("a", "s1", 11), newHistoryJob("a", "s1", 12)},
+			subscriberId: "a", subject: "s1", rev: 9, compacted: 10},
+		{name: "before start revision", size: 2, start: 100,
+			subscriberId: "a", subject: "s1", rev: 99, compacted: 100},
+		{name: "from start revision", size: 2, start: 100,
+			records:      []*WatchJob{newHistoryJob("a", "s1", 101)},
+			subscriberId: "a", subject: "s1", rev: 100, revs: []int64{101}},
+	}
+	for _, c := range cases {
+		h := newEventHistory(c.size, c.start)
+		for _, job := range c.records {
+			h.Record(job)
+		}
+
+		jobs, err := h.Since(c.subscriberId, c.subject, c.rev)
+		checkErr := h.Check(c.rev)
+		if c.compacted != 0 {
+			ce, ok := err.(*RevisionCompactedError)
+			if !ok || ce.Revision != c.rev || ce.Compacted != c.compacted {
+				t.Fatalf("TestEventHistorySince %s failed, expect compacted %d but %v", c.name, c.compacted, err)
+			}
+			if !reflect.DeepEqual(checkErr, err) {
+				t.Fatalf("TestEventHistorySince %s failed, Check returns %v", c.name, checkErr)
+			}
+			continue
+		}
+		if err != nil || checkErr != nil {
+			t.Fatalf("TestEventHistorySince %s failed, %v, %v", c.name, err, checkErr)
+		}
+		var revs []int64
+		for _, job := range jobs {
+			revs = append(revs, job.Revision)
+		}
+		if !reflect.DeepEqual(revs, c.revs) {
+			t.Fatalf("TestEventHistorySince %s failed, expect %v but %v", c.name, c.revs, revs)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package notification
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"sync"
+	"time"
+)
+
+// 同一提供者在该时间内的多次变化合并为一个失效通知
+const DEFAULT_INVALIDATION_FLUSH_INTERVAL = 500 * time.Millisecond
+
+// InvalidationWatcher 失效通知的订阅者，一个连接复用推送消费者所有提供者的{key, revision}，不携带实例内容
+type InvalidationWatcher struct {
+	ListWatcher
+	Interval time.Duration
+
+	mux     sync.Mutex
+	pending map[string]*WatchJob
+	order   []string
+	stopCh  chan struct{}
+}
+
+func (w *InvalidationWatcher) OnAccept() {
+	if w.Err() != nil {
+		return
+	}
+
+	util.Logger().Debugf("accepted by notify service, %s watcher %s %s", w.Type(), w.Id(), w.Subject())
+	go w.flushLoop()
+}
+
+// OnMessage 缓存提供者最新的revision，等待下一次flush
+func (w *InvalidationWatcher) OnMessage(job NotifyJob) {
+	if w.Err() != nil {
+		return
+	}
+
+	wJob := job.(*WatchJob)
+	key := providerKey(wJob.Response.Key)
+	w.mux.Lock()
+	if old, ok := w.pending[key]; ok {
+		if wJob.Revision > old.Revision {
+			w.pending[key] = wJob
+		}
+		w.mux.Unlock()
+		return
+	}
+	if len(w.pending) >= DEFAULT_MAX_QUEUE {
+		w.mux.Unlock()
+		w.SetError(fmt.Errorf("too many pending invalidations, watcher %s %s", w.Id(), w.Subject()))
+		return
+	}
+	w.pending[key] = wJob
+	w.order = append(w.order, key)
+	w.mux.Unlock()
+}
+
+func (w *InvalidationWatcher) flushLoop() {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DEFAULT_INVALIDATION_FLUSH_INTERVAL
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+			w.flush()
+		}
+	}
+}
+
+func (w *InvalidationWatcher) flush() {
+	w.mux.Lock()
+	pending, order := w.pending, w.order
+	if len(order) == 0 {
+		w.mux.Unlock()
+		return
+	}
+	w.pending = make(map[string]*WatchJob)
+	w.order = nil
+	w.mux.Unlock()
+
+	for _, key := range order {
+		if w.Err() != nil {
+			return
+		}
+		w.sendMessage(pending[key])
+	}
+}
+
+func (w *InvalidationWatcher) Close() {
+	close(w.stopCh)
+	w.ListWatcher.Close()
+}
+
+// invalidationSubject 与实例watcher相同，按租户统计订阅者数
+func invalidationSubject(domainProject string) string {
+	return apt.GetInstanceRootKey(domainProject) + "/"
+}
+
+func providerKey(key *pb.MicroServiceKey) string {
+	if key == nil {
+		return ""
+	}
+	return util.StringJoin([]string{key.Environment, key.AppId, key.ServiceName, key.Version}, "/")
+}
+
+// publishInvalidation 有失效通知订阅者时，向消费者推送提供者的{key, revision}
+func publishInvalidation(domainProject string, serviceKey *pb.MicroServiceKey, rev int64, subscribers []string) {
+	if serviceKey == nil || GetNotifyService().Subscribers(INVALIDATION, "") == 0 {
+		return
+	}
+	response := &pb.WatchInstanceResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Watch invalidation successfully."),
+		Action:   string(pb.EVT_INVALIDATE),
+		Key:      serviceKey,
+		Revision: rev,
+	}
+	for _, consumerId := range subscribers {
+		GetNotifyService().AddJob(NewWatchJob(INVALIDATION, consumerId, invalidationSubject(domainProject), rev, response))
+	}
+}
+
+func NewInvalidationWatcher(selfServiceId, domainProject string) *InvalidationWatcher {
+	return &InvalidationWatcher{
+		ListWatcher: ListWatcher{
+			BaseSubscriber: BaseSubscriber{
+				id:      selfServiceId,
+				subject: invalidationSubject(domainProject),
+				nType:   INVALIDATION,
+			},
+			Job: make(chan NotifyJob, DEFAULT_MAX_QUEUE),
+		},
+		Interval: DEFAULT_INVALIDATION_FLUSH_INTERVAL,
+		pending:  make(map[string]*WatchJob),
+		stopCh:   make(chan struct{}),
+	}
+}
@@ -17,8 +17,12 @@
 package notification
 
 import (
+	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"sort"
+	"sync"
+	"time"
 )
 
 // 状态变化推送
@@ -26,6 +30,10 @@ type WatchJob struct {
 	BaseNotifyJob
 	Revision int64
 	Response *pb.WatchInstanceResponse
+
+	// 消费者组成员共享的编码结果，key为编码格式
+	encodeLock sync.Mutex
+	encoded    map[string]*encodedEvent
 }
 
 type ListWatcher struct {
@@ -33,8 +41,24 @@ type ListWatcher struct {
 	Job          chan NotifyJob
 	ListRevision int64
 	ListFunc     func() (results []*pb.WatchInstanceResponse, rev int64)
+	// 全量快照每页的事件数
+	PageSize int
+
+	lock    sync.Mutex
+	listing bool
+	pending []*WatchJob
+
+	compactor *eventCompactor
+	// 非空时为持久化订阅，推送成功后记录revision
+	subscription *persistentSubscription
+}
 
-	listCh chan struct{}
+// EnableCompaction 合并同一实例在window内的多次变化，需在加入通知服务前调用
+func (w *ListWatcher) EnableCompaction(window time.Duration) {
+	if window <= 0 {
+		return
+	}
+	w.compactor = newEventCompactor(window)
 }
 
 func (w *ListWatcher) OnAccept() {
@@ -43,18 +67,102 @@ func (w *ListWatcher) OnAccept() {
 	}
 
 	util.Logger().Debugf("accepted by notify service, %s watcher %s %s", w.Type(), w.Id(), w.Subject())
+	if w.subscription != nil {
+		subscriptions.Add(w.subscription)
+	}
 	go w.listAndPublishJobs()
+	if w.compactor != nil {
+		go w.compactor.Run(func(job *WatchJob) {
+			if w.Err() != nil {
+				return
+			}
+			w.sendMessage(job)
+		})
+	}
 }
 
+// listAndPublishJobs 分页推送全量快照，页与页之间插入list期间到达的增量事件，
+// 已被增量事件覆盖的实例不再出现在后续分页中，快照结束后推送INIT_DONE事件
 func (w *ListWatcher) listAndPublishJobs() {
-	defer close(w.listCh)
 	if w.ListFunc == nil {
+		w.finishList()
 		return
 	}
 	results, rev := w.ListFunc()
+	w.lock.Lock()
 	w.ListRevision = rev
-	for _, response := range results {
-		w.sendMessage(NewWatchJob(w.Type(), w.Id(), w.Subject(), w.ListRevision, response))
+	w.lock.Unlock()
+
+	pageSize := w.PageSize
+	if pageSize <= 0 {
+		pageSize = DEFAULT_SNAPSHOT_PAGE_SIZE
+	}
+	changed := make(map[string]struct{})
+	for i := 0; i < len(results); i += pageSize {
+		if w.Err() != nil {
+			return
+		}
+		end := i + pageSize
+		if end > len(results) {
+			end = len(results)
+		}
+		for _, response := range results[i:end] {
+			if key := instanceKey(response); len(key) > 0 {
+				if _, ok := changed[key]; ok {
+					continue
+				}
+			}
+			w.sendMessage(NewWatchJob(w.Type(), w.Id(), w.Subject(), rev, response))
+		}
+		for _, job := range w.takePending() {
+			// 规则变化、广播等非实例事件不覆盖快照
+			if key := instanceKey(job.Response); len(key) > 0 {
+				changed[key] = struct{}{}
+			}
+			w.sendMessage(job)
+		}
+	}
+
+	w.sendMessage(NewWatchJob(w.Type(), w.Id(), w.Subject(), rev, &pb.WatchInstanceResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "List instances successfully."),
+		Action:   string(pb.EVT_INIT_DONE),
+	}))
+	w.finishList()
+}
+
+// takePending 取出list期间缓存的增量事件，按revision排序并丢弃早于快照的事件
+func (w *ListWatcher) takePending() []*WatchJob {
+	w.lock.Lock()
+	jobs := w.pending
+	w.pending = nil
+	w.lock.Unlock()
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Revision < jobs[j].Revision
+	})
+	result := jobs[:0]
+	for _, job := range jobs {
+		if job.Revision <= w.ListRevision {
+			continue
+		}
+		result = append(result, job)
+	}
+	return result
+}
+
+func (w *ListWatcher) finishList() {
+	for {
+		w.lock.Lock()
+		if len(w.pending) == 0 {
+			w.listing = false
+			w.lock.Unlock()
+			return
+		}
+		w.lock.Unlock()
+
+		for _, job := range w.takePending() {
+			w.sendMessage(job)
+		}
 	}
 }
 
@@ -64,14 +172,30 @@ func (w *ListWatcher) OnMessage(job NotifyJob) {
 		return
 	}
 
-	<-w.listCh
+	wJob := job.(*WatchJob)
+	w.lock.Lock()
+	if w.listing {
+		// 快照未推送完时缓存增量事件，避免阻塞通知服务
+		if len(w.pending) >= DEFAULT_MAX_QUEUE {
+			w.lock.Unlock()
+			w.SetError(fmt.Errorf("too many pending events during listing, watcher %s %s", w.Id(), w.Subject()))
+			return
+		}
+		w.pending = append(w.pending, wJob)
+		w.lock.Unlock()
+		return
+	}
+	w.lock.Unlock()
 
-	if job.(*WatchJob).Revision <= w.ListRevision {
+	if wJob.Revision <= w.ListRevision {
 		util.Logger().Warnf(nil,
 			"unexpected notify %s job is coming in, watcher %s %s, job is %v, current revision is %v",
 			w.Type(), w.Id(), w.Subject(), job, w.ListRevision)
 		return
 	}
+	if w.compactor != nil && !w.compactor.Offer(wJob) {
+		return
+	}
 	w.sendMessage(job)
 }
 
@@ -83,9 +207,29 @@ func (w *ListWatcher) sendMessage(job NotifyJob) {
 }
 
 func (w *ListWatcher) Close() {
+	if w.compactor != nil {
+		w.compactor.Stop()
+	}
+	if w.subscription != nil {
+		go subscriptions.Remove(w.subscription)
+	}
 	close(w.Job)
 }
 
+// Delivered 事件推送到客户端后调用，持久化订阅记录最近推送的revision
+func (w *ListWatcher) Delivered(job *WatchJob) {
+	if w.subscription != nil {
+		w.subscription.Delivered(job)
+	}
+}
+
+func instanceKey(response *pb.WatchInstanceResponse) string {
+	if response.Instance == nil {
+		return ""
+	}
+	return response.Instance.ServiceId + "/" + response.Instance.InstanceId
+}
+
 func NewWatchJob(nType NotifyType, subscriberId, subject string, rev int64, response *pb.WatchInstanceResponse) *WatchJob {
 	return &WatchJob{
 		BaseNotifyJob: BaseNotifyJob{
@@ -112,7 +256,8 @@ func NewListWatcher(nType NotifyType, id string, subject string,
 		},
 		Job:      make(chan NotifyJob, DEFAULT_MAX_QUEUE),
 		ListFunc: listFunc,
-		listCh:   make(chan struct{}),
+		PageSize: DEFAULT_SNAPSHOT_PAGE_SIZE,
+		listing:  true,
 	}
 	return watcher
 }
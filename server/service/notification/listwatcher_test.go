@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package notification
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func instanceEvent(action pb.EventType, instanceId string) *pb.WatchInstanceResponse {
+	return &pb.WatchInstanceResponse{
+		Action:   string(action),
+		Instance: &pb.MicroServiceInstance{ServiceId: "s", InstanceId: instanceId},
+	}
+}
+
+// receiveEvent 返回"action:instanceId@revision"，非实例事件只返回action
+func receiveEvent(t *testing.T, w *ListWatcher) string {
+	select {
+	case job := <-w.Job:
+		wJob := job.(*WatchJob)
+		if wJob.Response.Instance == nil {
+			return wJob.Response.Action
+		}
+		return fmt.Sprintf("%s:%s@%d", wJob.Response.Action, wJob.Response.Instance.InstanceId, wJob.Revision)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("receive event timed out")
+		return ""
+	}
+}
+
+func TestListAndPublishJobs(t *testing.T) {
+	w := NewListWatcher(INSTANCE, "consumer", "subject", nil)
+	w.ListFunc = func() ([]*pb.WatchInstanceResponse, int64) {
+		// list期间到达的事件，早于快照的丢弃
+		w.OnMessage(NewWatchJob(INSTANCE, w.Id(), w.Subject(), 9, instanceEvent(pb.EVT_UPDATE, "i2")))
+		w.OnMessage(NewWatchJob(INSTANCE, w.Id(), w.Subject(), 11, instanceEvent(pb.EVT_UPDATE, "i5")))
+		return []*pb.WatchInstanceResponse{
+			instanceEvent(pb.EVT_INIT, "i1"), instanceEvent(pb.EVT_INIT, "i2"),
+			instanceEvent(pb.EVT_INIT, "i3"), instanceEvent(pb.EVT_INIT, "i4"),
+			instanceEvent(pb.EVT_INIT, "i5"),
+		}, 10
+	}
+	w.PageSize = 2
+	// 不缓冲，推送第一页期间到达的事件必然在该页之后取出
+	w.Job = make(chan NotifyJob)
+	go w.listAndPublishJobs()
+
+	var events []string
+	events = append(events, receiveEvent(t, w))
+	// 分页期间到达的事件：i1在本页已推送，i3、i4在后续分页中被覆盖，非实例事件不覆盖快照
+	w.OnMessage(NewWatchJob(INSTANCE, w.Id(), w.Subject(), 12, instanceEvent(pb.EVT_UPDATE, "i1")))
+	w.OnMessage(NewWatchJob(INSTANCE, w.Id(), w.Subject(), 14, instanceEvent(pb.EVT_DELETE, "i4")))
+	w.OnMessage(NewWatchJob(INSTANCE, w.Id(), w.Subject(), 13, instanceEvent(pb.EVT_UPDATE, "i3")))
+	w.OnMessage(NewWatchJob(INSTANCE, w.Id(), w.Subject(), 15, &pb.WatchInstanceResponse{Action: string(pb.EVT_UPDATE)}))
+	for i := 0; i < 7; i++ {
+		events = append(events, receiveEvent(t, w))
+	}
+
+	expected := []string{
+		// 第一页
+		"INIT:i1@10", "INIT:i2@10",
+		// 按revision排序的增量事件，i1的更新在其快照之后推送
+		"UPDATE:i5@11", "UPDATE:i1@12", "UPDATE:i3@13", "DELETE:i4@14", "UPDATE",
+		// 后续分页跳过已被覆盖的i3、i4、i5
+		"INIT_DONE",
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Fatalf("TestListAndPublishJobs failed, expect %v but %v", expected, events)
+	}
+
+	// list结束后的事件直接推送
+	go w.OnMessage(NewWatchJob(INSTANCE, w.Id(), w.Subject(), 16, instanceEvent(pb.EVT_UPDATE, "i2")))
+	if e := receiveEvent(t, w); e != "UPDATE:i2@16" {
+		t.Fatalf("TestListAndPublishJobs failed, expect UPDATE:i2 after listing but %s", e)
+	}
+}
@@ -20,21 +20,42 @@ import (
 	"container/list"
 	"errors"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
 	"sync"
 	"time"
 )
 
 var notifyTypeNames = []string{
-	NOTIFTY:  "NOTIFTY",
-	INSTANCE: "INSTANCE",
+	NOTIFTY:      "NOTIFTY",
+	INSTANCE:     "INSTANCE",
+	INVALIDATION: "INVALIDATION",
 }
 
 var notifyService *NotifyService
 
+var (
+	subscriberGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "notify",
+			Name:      "subscribers",
+			Help:      "Number of the current subscribers",
+		}, []string{"type"})
+
+	rejectedSubscribers = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "notify",
+			Name:      "rejected_subscribers_total",
+			Help:      "Counter of the subscribers rejected by the limits",
+		}, []string{"type", "scope"})
+)
+
 func init() {
 	notifyService = &NotifyService{
 		isClose: true,
 	}
+	prometheus.MustRegister(subscriberGauge, rejectedSubscribers)
 }
 
 type subscriberIndex map[string]*list.List
@@ -47,12 +68,15 @@ type NotifyService struct {
 	Config NotifyServiceConfig
 
 	services serviceIndex
+	counts   map[NotifyType]map[string]int64
+	totals   map[NotifyType]int64
 	queues   map[NotifyType]chan NotifyJob
 	waits    sync.WaitGroup
 	mutexes  map[NotifyType]*sync.Mutex
 	err      chan error
 	closeMux sync.RWMutex
 	isClose  bool
+	history  *eventHistory
 }
 
 func (s *NotifyService) Err() <-chan error {
@@ -71,6 +95,12 @@ func (s *NotifyService) AddSubscriber(n Subscriber) error {
 		return errors.New("Unknown subscribe type")
 	}
 
+	if err := s.checkLimits(n); err != nil {
+		s.mutexes[n.Type()].Unlock()
+		rejectedSubscribers.WithLabelValues(n.Type().String(), err.Scope).Inc()
+		return err
+	}
+
 	sr, ok := ss[n.Subject()]
 	if !ok {
 		sr = make(subscriberIndex)
@@ -83,6 +113,7 @@ func (s *NotifyService) AddSubscriber(n Subscriber) error {
 	}
 	ns.PushBack(n)
 	sr[n.Id()] = ns
+	s.count(n.Type(), n.Subject(), 1)
 
 	n.SetService(s)
 	s.mutexes[n.Type()].Unlock()
@@ -112,12 +143,89 @@ func (s *NotifyService) RemoveSubscriber(n Subscriber) {
 	for sr := ns.Front(); sr != nil; sr = sr.Next() {
 		if sr.Value == n {
 			ns.Remove(sr)
+			s.count(n.Type(), n.Subject(), -1)
 			n.Close()
 			break
 		}
 	}
 }
 
+// checkLimits 检查subscriber数是否超过上限，内部subscriber(NOTIFTY类型)不受限制，调用者需持有对应类型的锁
+func (s *NotifyService) checkLimits(n Subscriber) *SubscriberLimitError {
+	if n.Type() == NOTIFTY {
+		return nil
+	}
+	if s.Config.MaxSubscribers > 0 && s.totals[n.Type()] >= s.Config.MaxSubscribers {
+		return &SubscriberLimitError{Scope: LIMIT_SCOPE_GLOBAL, Limit: s.Config.MaxSubscribers}
+	}
+	if s.Config.MaxSubscribersPerSubject > 0 && s.counts[n.Type()][n.Subject()] >= s.Config.MaxSubscribersPerSubject {
+		return &SubscriberLimitError{Scope: LIMIT_SCOPE_SUBJECT, Limit: s.Config.MaxSubscribersPerSubject}
+	}
+	return nil
+}
+
+func (s *NotifyService) count(t NotifyType, subject string, delta int64) {
+	c := s.counts[t][subject] + delta
+	if c > 0 {
+		s.counts[t][subject] = c
+	} else {
+		delete(s.counts[t], subject)
+	}
+	s.totals[t] += delta
+	subscriberGauge.WithLabelValues(t.String()).Set(float64(s.totals[t]))
+}
+
+// Subscribers 返回指定类型和subject的subscriber数，subject为空时返回该类型的总数
+func (s *NotifyService) Subscribers(t NotifyType, subject string) int64 {
+	mux, ok := s.mutexes[t]
+	if !ok {
+		return 0
+	}
+	mux.Lock()
+	defer mux.Unlock()
+	if len(subject) == 0 {
+		return s.totals[t]
+	}
+	return s.counts[t][subject]
+}
+
+// SubjectSubscribers 返回指定类型下各subject的subscriber数
+func (s *NotifyService) SubjectSubscribers(t NotifyType) map[string]int64 {
+	mux, ok := s.mutexes[t]
+	if !ok {
+		return nil
+	}
+	mux.Lock()
+	defer mux.Unlock()
+	counts := make(map[string]int64, len(s.counts[t]))
+	for subject, c := range s.counts[t] {
+		counts[subject] = c
+	}
+	return counts
+}
+
+// CloseSubscribers 断开指定类型和subject下的所有subscriber，返回断开的个数
+func (s *NotifyService) CloseSubscribers(t NotifyType, subject string) int {
+	mux, ok := s.mutexes[t]
+	if !ok {
+		return 0
+	}
+	mux.Lock()
+	defer mux.Unlock()
+	n := 0
+	for _, ns := range s.services[t][subject] {
+		for e, next := ns.Front(), ns.Front(); e != nil; e = next {
+			next = e.Next()
+			e.Value.(Subscriber).Close()
+			ns.Remove(e)
+			n++
+		}
+	}
+	delete(s.services[t], subject)
+	s.count(t, subject, -int64(n))
+	return n
+}
+
 func (s *NotifyService) RemoveAllSubscribers() {
 	for t, ss := range s.services {
 		s.mutexes[t].Lock()
@@ -130,6 +238,9 @@ func (s *NotifyService) RemoveAllSubscribers() {
 				}
 			}
 		}
+		s.counts[t] = make(map[string]int64)
+		s.totals[t] = 0
+		subscriberGauge.WithLabelValues(t.String()).Set(0)
 		s.mutexes[t].Unlock()
 	}
 }
@@ -200,11 +311,17 @@ func (s *NotifyService) init() {
 	}
 
 	s.services = make(serviceIndex)
+	s.counts = make(map[NotifyType]map[string]int64)
+	s.totals = make(map[NotifyType]int64)
 	s.err = make(chan error, 1)
 	s.queues = make(map[NotifyType]chan NotifyJob)
 	s.mutexes = make(map[NotifyType]*sync.Mutex)
+	if s.Config.HistorySize > 0 {
+		s.history = newEventHistory(s.Config.HistorySize, s.Config.HistoryStart)
+	}
 	for i := NotifyType(0); i != typeEnd; i++ {
 		s.services[i] = make(subscriberSubjectIndex)
+		s.counts[i] = make(map[string]int64)
 		s.queues[i] = make(chan NotifyJob, s.Config.MaxQueue)
 		s.mutexes[i] = &sync.Mutex{}
 		s.waits.Add(1)
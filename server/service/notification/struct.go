@@ -24,11 +24,13 @@ import (
 )
 
 const (
-	DEFAULT_MAX_QUEUE = 1000
-	DEFAULT_TIMEOUT   = 30 * time.Second
+	DEFAULT_MAX_QUEUE          = 1000
+	DEFAULT_TIMEOUT            = 30 * time.Second
+	DEFAULT_SNAPSHOT_PAGE_SIZE = 100
 
 	NOTIFTY NotifyType = iota
 	INSTANCE
+	INVALIDATION
 	typeEnd
 )
 
@@ -45,11 +47,40 @@ type NotifyServiceConfig struct {
 	AddTimeout    time.Duration
 	NotifyTimeout time.Duration
 	MaxQueue      int64
+	// 同一类型的subscriber总数上限，0表示不限制
+	MaxSubscribers int64
+	// 同一subject(实例watcher即一个租户)的subscriber数上限，0表示不限制
+	MaxSubscribersPerSubject int64
+	// 开启事件合并的watcher在该时间内对同一实例只推送一次，0表示不合并
+	CompactWindow time.Duration
+	// 保留的最近实例事件数，watch可从Find返回的revision续接，0表示不保留
+	HistorySize int
+	// 服务启动时实例缓存的revision，早于该revision的事件不在历史中
+	HistoryStart int64
 }
 
 func (nsc NotifyServiceConfig) String() string {
-	return fmt.Sprintf("{acceptQueue: %d, accept: %s, notify: %s}",
-		nsc.MaxQueue, nsc.AddTimeout, nsc.NotifyTimeout)
+	return fmt.Sprintf("{acceptQueue: %d, accept: %s, notify: %s, maxSubscribers: %d, maxSubscribersPerSubject: %d, compactWindow: %s, historySize: %d}",
+		nsc.MaxQueue, nsc.AddTimeout, nsc.NotifyTimeout, nsc.MaxSubscribers, nsc.MaxSubscribersPerSubject, nsc.CompactWindow, nsc.HistorySize)
+}
+
+const (
+	LIMIT_SCOPE_GLOBAL  = "global"
+	LIMIT_SCOPE_SUBJECT = "subject"
+)
+
+// SubscriberLimitError subscriber数超过上限时返回
+type SubscriberLimitError struct {
+	Scope string
+	Limit int64
+}
+
+func (e *SubscriberLimitError) Error() string {
+	if e.Scope == LIMIT_SCOPE_SUBJECT {
+		return fmt.Sprintf("too many watchers in the current tenant, the limit is %d, please close the idle ones and retry",
+			e.Limit)
+	}
+	return fmt.Sprintf("too many watchers in service center, the limit is %d, please retry later", e.Limit)
 }
 
 type Subscriber interface {
@@ -0,0 +1,231 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package notification
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const DEFAULT_SUBSCRIPTION_FLUSH_INTERVAL = 5 * time.Second
+
+var subscriptions = &subscriptionRecorder{items: make(map[*persistentSubscription]struct{})}
+
+// persistentSubscription 连接期间的持久化订阅，推送成功后更新revision，周期写入注册中心
+type persistentSubscription struct {
+	domainProject string
+
+	lock sync.Mutex
+	sub  serviceUtil.WatchSubscription
+	// 续接的事件以INIT_DONE结束前不更新revision，避免中途断开后跳过未推送的事件
+	ready bool
+	dirty bool
+
+	flushLock sync.Mutex
+	leaseID   int64
+	renewTime time.Time
+}
+
+func (p *persistentSubscription) Delivered(job *WatchJob) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if !p.ready {
+		p.ready = job.Response.Action == string(pb.EVT_INIT_DONE)
+		if !p.ready {
+			return
+		}
+	}
+	if job.Revision > p.sub.Revision {
+		p.sub.Revision = job.Revision
+		p.dirty = true
+	}
+}
+
+func (p *persistentSubscription) markDirty() {
+	p.lock.Lock()
+	p.dirty = true
+	p.lock.Unlock()
+}
+
+// flush 订阅有变化时写入，租约在ttl的1/3后续期，租约已过期时重新申请并写入
+func (p *persistentSubscription) flush(ctx context.Context) error {
+	p.flushLock.Lock()
+	defer p.flushLock.Unlock()
+
+	p.lock.Lock()
+	sub, dirty := p.sub, p.dirty
+	p.dirty = false
+	p.lock.Unlock()
+
+	ttl := serviceUtil.WatchSubscriptionTTL()
+	if p.leaseID != 0 && time.Since(p.renewTime) > ttl/3 {
+		if _, err := backend.Registry().LeaseRenew(ctx, p.leaseID); err != nil {
+			util.Logger().Warnf(err, "renew lease of watch subscription %s/%s failed, grant a new one",
+				sub.SubscriberId, sub.Name)
+			p.leaseID = 0
+		} else {
+			p.renewTime = time.Now()
+		}
+	}
+	if p.leaseID == 0 {
+		leaseID, err := backend.Registry().LeaseGrant(ctx, int64(ttl/time.Second))
+		if err != nil {
+			p.markDirty()
+			return err
+		}
+		p.leaseID, p.renewTime = leaseID, time.Now()
+		dirty = true
+	}
+	if !dirty {
+		return nil
+	}
+
+	sub.Timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+	if err := serviceUtil.SaveWatchSubscription(ctx, p.domainProject, &sub, p.leaseID); err != nil {
+		p.markDirty()
+		return err
+	}
+	return nil
+}
+
+// subscriptionRecorder 本节点当前连接的持久化订阅
+type subscriptionRecorder struct {
+	lock  sync.Mutex
+	items map[*persistentSubscription]struct{}
+}
+
+func (r *subscriptionRecorder) Add(p *persistentSubscription) {
+	r.lock.Lock()
+	r.items[p] = struct{}{}
+	r.lock.Unlock()
+}
+
+// Remove 连接断开时写入最后推送的revision，订阅保留到租约过期
+func (r *subscriptionRecorder) Remove(p *persistentSubscription) {
+	r.lock.Lock()
+	delete(r.items, p)
+	r.lock.Unlock()
+	if err := p.flush(context.Background()); err != nil {
+		util.Logger().Errorf(err, "save watch subscription %s/%s failed", p.sub.SubscriberId, p.sub.Name)
+	}
+}
+
+func (r *subscriptionRecorder) FlushAll(ctx context.Context) {
+	r.lock.Lock()
+	items := make([]*persistentSubscription, 0, len(r.items))
+	for p := range r.items {
+		items = append(items, p)
+	}
+	r.lock.Unlock()
+
+	for _, p := range items {
+		if err := p.flush(ctx); err != nil {
+			util.Logger().Errorf(err, "save watch subscription %s/%s failed", p.sub.SubscriberId, p.sub.Name)
+		}
+	}
+}
+
+func (r *subscriptionRecorder) run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			// 停止前保存，重启后从最后推送的revision续接
+			r.FlushAll(context.Background())
+			return
+		case <-time.After(DEFAULT_SUBSCRIPTION_FLUSH_INTERVAL):
+			r.FlushAll(context.Background())
+		}
+	}
+}
+
+// RunSubscriptionRecorder 周期保存本节点持久化订阅最近推送的revision
+func RunSubscriptionRecorder() {
+	util.Go(subscriptions.run)
+}
+
+// NewInstanceRecoverWatcher 按注册中心的历史版本补发rev之后提供者实例的变化，并以INIT_DONE结束，
+// 用于本节点没有rev之后的事件历史时续接
+func NewInstanceRecoverWatcher(ctx context.Context, selfServiceId, instanceRoot string, rev int64) *ListWatcher {
+	watcher := NewInstanceWatcher(selfServiceId, instanceRoot)
+	watcher.ListFunc = func() ([]*pb.WatchInstanceResponse, int64) {
+		results, current, err := serviceUtil.QueryProvidersInstancesSince(ctx, selfServiceId, rev)
+		if err != nil {
+			util.Logger().Errorf(err, "recover watcher %s %s from revision %d failed", selfServiceId, instanceRoot, rev)
+			watcher.SetError(err)
+			return nil, rev
+		}
+		return results, current
+	}
+	return watcher
+}
+
+// NewInstanceSubscriptionWatcher 创建持久化订阅的watcher，rev为0且订阅已存在时从订阅记录的revision续接，
+// 本节点的事件历史不完整时(如节点重启后或连接到其他节点)按注册中心的历史版本续接
+func NewInstanceSubscriptionWatcher(ctx context.Context, selfServiceId, name string, rev int64) (*ListWatcher, error) {
+	domainProject := util.ParseDomainProject(ctx)
+	instanceRoot := apt.GetInstanceRootKey(domainProject) + "/"
+	sub, err := serviceUtil.GetWatchSubscription(ctx, domainProject, selfServiceId, name)
+	if err != nil {
+		return nil, err
+	}
+	if sub != nil && rev == 0 {
+		rev = sub.Revision
+	}
+
+	var watcher *ListWatcher
+	switch {
+	case rev == 0:
+		watcher = NewInstanceWatcher(selfServiceId, instanceRoot)
+		rev = store.Revision()
+	case GetNotifyService().CheckRevision(rev) == nil:
+		watcher = NewInstanceResumeWatcher(selfServiceId, instanceRoot, rev)
+	default:
+		watcher = NewInstanceRecoverWatcher(ctx, selfServiceId, instanceRoot, rev)
+	}
+	watcher.subscription = &persistentSubscription{
+		domainProject: domainProject,
+		sub: serviceUtil.WatchSubscription{
+			Name:         name,
+			SubscriberId: selfServiceId,
+			ProviderKey:  instanceRoot,
+			Revision:     rev,
+		},
+		ready: watcher.ListFunc == nil,
+		dirty: true,
+	}
+	return watcher, nil
+}
+
+// NewInstanceRequestWatcher subscription非空时使用持久化订阅，否则rev大于0时先补发rev之后的事件
+func NewInstanceRequestWatcher(ctx context.Context, serviceId string, rev int64, subscription string) (*ListWatcher, error) {
+	if len(subscription) > 0 {
+		return NewInstanceSubscriptionWatcher(ctx, serviceId, subscription, rev)
+	}
+	instanceRoot := apt.GetInstanceRootKey(util.ParseDomainProject(ctx)) + "/"
+	if rev > 0 {
+		return NewInstanceResumeWatcher(serviceId, instanceRoot, rev), nil
+	}
+	return NewInstanceWatcher(serviceId, instanceRoot), nil
+}
@@ -17,7 +17,6 @@
 package notification
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
@@ -32,6 +31,10 @@ import (
 func HandleWatchJob(watcher *ListWatcher, stream pb.ServiceInstanceCtrl_WatchServer, timeout time.Duration) (err error) {
 	for {
 		select {
+		case <-stream.Context().Done():
+			util.Logger().Warnf(nil, "watcher %s %s exit, stream is closed",
+				watcher.Subject(), watcher.Id())
+			return
 		case <-time.After(timeout):
 		// TODO grpc 长连接心跳？
 		case job := <-watcher.Job:
@@ -41,17 +44,18 @@ func HandleWatchJob(watcher *ListWatcher, stream pb.ServiceInstanceCtrl_WatchSer
 					watcher.Subject(), watcher.Id())
 				return
 			}
-			resp := job.(*WatchJob).Response
+			wJob := job.(*WatchJob)
 			util.Logger().Infof("event is coming in, watcher %s %s",
 				watcher.Subject(), watcher.Id())
 
-			err = stream.Send(resp)
+			err = stream.Send(wJob.Response)
 			if err != nil {
 				util.Logger().Errorf(err, "send message error, watcher %s %s",
 					watcher.Subject(), watcher.Id())
 				watcher.SetError(err)
 				return
 			}
+			watcher.Delivered(wJob)
 		}
 	}
 }
@@ -60,13 +64,26 @@ type WebSocketHandler struct {
 	ctx             context.Context
 	conn            *websocket.Conn
 	watcher         *ListWatcher
+	subscriber      Subscriber
+	encoder         EventEncoder
 	needPingWatcher bool
 	closed          chan struct{}
+
+	// 非空时watcher作为成员加入该消费者组，不单独注册到通知服务
+	group        string
+	groupWatcher *GroupWatcher
 }
 
 func (wh *WebSocketHandler) Init() error {
 	remoteAddr := wh.conn.RemoteAddr().String()
-	if err := GetNotifyService().AddSubscriber(wh.watcher); err != nil {
+	// 注册到通知服务的订阅者，默认为watcher
+	if wh.subscriber == nil {
+		wh.subscriber = wh.watcher
+	}
+	if wh.encoder == nil {
+		wh.encoder = &legacyEncoder{}
+	}
+	if err := wh.subscribe(); err != nil {
 		err = fmt.Errorf("establish[%s] websocket watch failed: notify service error, %s.",
 			remoteAddr, err.Error())
 		util.Logger().Errorf(nil, err.Error())
@@ -82,6 +99,30 @@ func (wh *WebSocketHandler) Init() error {
 	return nil
 }
 
+func (wh *WebSocketHandler) subscribe() (err error) {
+	if len(wh.group) == 0 {
+		return GetNotifyService().AddSubscriber(wh.subscriber)
+	}
+	wh.groupWatcher, err = JoinGroup(wh.group, wh.watcher)
+	return
+}
+
+// unsubscribe 及时释放，避免占用watcher配额
+func (wh *WebSocketHandler) unsubscribe() {
+	if wh.groupWatcher != nil {
+		LeaveGroup(wh.groupWatcher, wh.watcher)
+		return
+	}
+	GetNotifyService().RemoveSubscriber(wh.subscriber)
+}
+
+func (wh *WebSocketHandler) encode(job *WatchJob) (int, []byte, error) {
+	if wh.groupWatcher != nil {
+		return encodeShared(wh.encoder, job)
+	}
+	return wh.encoder.Encode(job)
+}
+
 func (wh *WebSocketHandler) Timeout() time.Duration {
 	return GetNotifyService().Config.NotifyTimeout
 }
@@ -191,17 +232,20 @@ func (wh *WebSocketHandler) HandleWatchWebSocketJob() {
 				return
 			}
 
-			resp := job.(*WatchJob).Response
+			wJob := job.(*WatchJob)
+			resp := wJob.Response
 
-			providerFlag := fmt.Sprintf("%s/%s/%s", resp.Key.AppId, resp.Key.ServiceName, resp.Key.Version)
-			if resp.Action != string(pb.EVT_EXPIRE) {
+			var providerFlag string
+			if resp.Key != nil {
+				providerFlag = fmt.Sprintf("%s/%s/%s", resp.Key.AppId, resp.Key.ServiceName, resp.Key.Version)
+			}
+			if resp.Instance != nil {
 				providerFlag = fmt.Sprintf("%s/%s(%s)", resp.Instance.ServiceId, resp.Instance.InstanceId, providerFlag)
 			}
 			util.Logger().Infof("event[%s] is coming in, watcher[%s] %s %s, providers' info %s",
 				resp.Action, remoteAddr, wh.watcher.Subject(), wh.watcher.Id(), providerFlag)
 
-			resp.Response = nil
-			data, err := json.Marshal(resp)
+			messageType, data, err := wh.encode(wJob)
 			if err != nil {
 				util.Logger().Errorf(err, "watcher[%s] %s %s catch an err: marshal output file error",
 					remoteAddr, wh.watcher.Subject(), wh.watcher.Id())
@@ -213,12 +257,13 @@ func (wh *WebSocketHandler) HandleWatchWebSocketJob() {
 				}
 				return
 			}
-			err = wh.conn.WriteMessage(websocket.TextMessage, data)
+			err = wh.conn.WriteMessage(messageType, data)
 			if err != nil {
 				util.Logger().Errorf(err, "watcher[%s] %s %s catch an err: write message error",
 					remoteAddr, wh.watcher.Subject(), wh.watcher.Id())
 				return
 			}
+			wh.watcher.Delivered(wJob)
 		}
 	}
 }
@@ -238,24 +283,56 @@ func (wh *WebSocketHandler) Close(code int, text string) error {
 	return nil
 }
 
-func DoWebSocketWatch(ctx context.Context, serviceId string, conn *websocket.Conn) {
-	domainProject := util.ParseDomainProject(ctx)
+// DoWebSocketWatch rev大于0时先补发rev之后的事件，group非空时加入该消费者组，
+// subscription非空时使用持久化订阅，重连时从订阅记录的revision续接
+func DoWebSocketWatch(ctx context.Context, serviceId string, rev int64, encoder EventEncoder, compact bool, group, subscription string, conn *websocket.Conn) {
+	watcher, err := NewInstanceRequestWatcher(ctx, serviceId, rev, subscription)
+	if err != nil {
+		EstablishWebSocketError(conn, err)
+		return
+	}
+	if compact {
+		watcher.EnableCompaction(GetNotifyService().Config.CompactWindow)
+	}
 	handler := &WebSocketHandler{
 		ctx:             ctx,
 		conn:            conn,
-		watcher:         NewInstanceWatcher(serviceId, apt.GetInstanceRootKey(domainProject)+"/"),
+		encoder:         encoder,
+		watcher:         watcher,
+		group:           group,
 		needPingWatcher: true,
 		closed:          make(chan struct{}),
 	}
 	processHandler(handler)
 }
 
-func DoWebSocketListAndWatch(ctx context.Context, serviceId string, f func() ([]*pb.WatchInstanceResponse, int64), conn *websocket.Conn) {
+func DoWebSocketListAndWatch(ctx context.Context, serviceId string, f func() ([]*pb.WatchInstanceResponse, int64), encoder EventEncoder, compact bool, group string, conn *websocket.Conn) {
 	domainProject := util.ParseDomainProject(ctx)
+	watcher := NewInstanceListWatcher(serviceId, apt.GetInstanceRootKey(domainProject)+"/", f)
+	if compact {
+		watcher.EnableCompaction(GetNotifyService().Config.CompactWindow)
+	}
 	handler := &WebSocketHandler{
 		ctx:             ctx,
 		conn:            conn,
-		watcher:         NewInstanceListWatcher(serviceId, apt.GetInstanceRootKey(domainProject)+"/", f),
+		encoder:         encoder,
+		watcher:         watcher,
+		group:           group,
+		needPingWatcher: true,
+		closed:          make(chan struct{}),
+	}
+	processHandler(handler)
+}
+
+// DoWebSocketWatchInvalidations 建立失效通知的websocket连接
+func DoWebSocketWatchInvalidations(ctx context.Context, serviceId string, encoder EventEncoder, conn *websocket.Conn) {
+	watcher := NewInvalidationWatcher(serviceId, util.ParseDomainProject(ctx))
+	handler := &WebSocketHandler{
+		ctx:             ctx,
+		conn:            conn,
+		encoder:         encoder,
+		watcher:         &watcher.ListWatcher,
+		subscriber:      watcher,
 		needPingWatcher: true,
 		closed:          make(chan struct{}),
 	}
@@ -268,6 +345,7 @@ func processHandler(handler *WebSocketHandler) {
 	}
 	go handler.HandleWatchWebSocketControlMessage()
 	handler.HandleWatchWebSocketJob()
+	handler.unsubscribe()
 }
 
 func EstablishWebSocketError(conn *websocket.Conn, err error) {
@@ -285,13 +363,45 @@ func PublishInstanceEvent(domainProject string, action pb.EventType, serviceKey
 		Key:      serviceKey,
 		Instance: instance,
 	}
+	publishWatchResponse(domainProject, response, rev, subscribers)
+}
+
+// PublishRuleEvent 通知消费者提供者的黑白名单已变化，以及变化后消费者是否有权限访问
+func PublishRuleEvent(domainProject string, serviceKey *pb.MicroServiceKey, permission string, rev int64, consumerId string) {
+	response := &pb.WatchInstanceResponse{
+		Response:   pb.CreateResponse(pb.Response_SUCCESS, "Watch instance successfully."),
+		Action:     string(pb.EVT_RULE_CHANGED),
+		Key:        serviceKey,
+		Permission: permission,
+	}
+	publishWatchResponse(domainProject, response, rev, []string{consumerId})
+}
+
+// PublishBroadcastEvent 向提供者的消费者推送提供者发出的通知，不触发失效通知
+func PublishBroadcastEvent(domainProject string, serviceKey *pb.MicroServiceKey, message *pb.BroadcastMessage, rev int64, subscribers []string) {
+	response := &pb.WatchInstanceResponse{
+		Response:  pb.CreateResponse(pb.Response_SUCCESS, "Watch instance successfully."),
+		Action:    string(pb.EVT_BROADCAST),
+		Key:       serviceKey,
+		Broadcast: message,
+	}
+	for _, consumerId := range subscribers {
+		job := NewWatchJob(INSTANCE, consumerId, apt.GetInstanceRootKey(domainProject)+"/", rev, response)
+		GetNotifyService().recordHistory(job)
+		GetNotifyService().AddJob(job)
+	}
+}
+
+func publishWatchResponse(domainProject string, response *pb.WatchInstanceResponse, rev int64, subscribers []string) {
 	for _, consumerId := range subscribers {
 		job := NewWatchJob(INSTANCE, consumerId, apt.GetInstanceRootKey(domainProject)+"/", rev, response)
 		util.Logger().Debugf("publish event to notify service, %v", job)
+		GetNotifyService().recordHistory(job)
 
 		// TODO add超时怎么处理？
 		GetNotifyService().AddJob(job)
 	}
+	publishInvalidation(domainProject, response.Key, rev, subscribers)
 }
 
 func NewInstanceWatcher(selfServiceId, instanceRoot string) *ListWatcher {
@@ -0,0 +1,192 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+)
+
+func (s *MicroServiceService) GetDiscoveryPolicy(ctx context.Context, in *pb.GetDiscoveryPolicyRequest) (*pb.GetDiscoveryPolicyResponse, error) {
+	if !isPolicyAdmin(ctx) {
+		util.Logger().Errorf(nil, "get discovery policy failed: administrator permission required, domain %s.",
+			util.ParseDomain(ctx))
+		return &pb.GetDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrForbidden, "Administrator permission required."),
+		}, nil
+	}
+	if in == nil || len(in.ServiceId) == 0 {
+		util.Logger().Errorf(nil, "get discovery policy failed: invalid params.")
+		return &pb.GetDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "get discovery policy failed, serviceId %s: invalid parameters.", in.ServiceId)
+		return &pb.GetDiscoveryPolicyResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.StringJoin([]string{in.Domain, in.Project}, "/")
+
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ServiceId) {
+		util.Logger().Errorf(nil, "get discovery policy failed, serviceId %s/%s: service not exist.", domainProject, in.ServiceId)
+		return &pb.GetDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+
+	policy, err := serviceUtil.GetDiscoveryPolicy(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "get discovery policy failed, serviceId %s/%s: get policy failed.", domainProject, in.ServiceId)
+		return &pb.GetDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, "Get discovery policy failed."),
+		}, err
+	}
+	if policy == nil {
+		return &pb.GetDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrPolicyNotExists, "Discovery policy does not exist."),
+		}, nil
+	}
+
+	return &pb.GetDiscoveryPolicyResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Get discovery policy successfully."),
+		Policy:   policy,
+	}, nil
+}
+
+func (s *MicroServiceService) UpdateDiscoveryPolicy(ctx context.Context, in *pb.UpdateDiscoveryPolicyRequest) (*pb.UpdateDiscoveryPolicyResponse, error) {
+	if !isPolicyAdmin(ctx) {
+		util.Logger().Errorf(nil, "update discovery policy failed: administrator permission required, domain %s.",
+			util.ParseDomain(ctx))
+		return &pb.UpdateDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrForbidden, "Administrator permission required."),
+		}, nil
+	}
+	if in == nil || len(in.ServiceId) == 0 || in.Policy == nil {
+		util.Logger().Errorf(nil, "update discovery policy failed: invalid params.")
+		return &pb.UpdateDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "update discovery policy failed, serviceId %s: invalid parameters.", in.ServiceId)
+		return &pb.UpdateDiscoveryPolicyResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("policy", err)...),
+		}, nil
+	}
+
+	domainProject := util.StringJoin([]string{in.Domain, in.Project}, "/")
+
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ServiceId) {
+		util.Logger().Errorf(nil, "update discovery policy failed, serviceId %s/%s: service not exist.", domainProject, in.ServiceId)
+		return &pb.UpdateDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+
+	err = serviceUtil.PutDiscoveryPolicy(ctx, domainProject, in.ServiceId, in.Policy)
+	if err != nil {
+		util.Logger().Errorf(err, "update discovery policy failed, serviceId %s/%s: commit policy into etcd failed.", domainProject, in.ServiceId)
+		return &pb.UpdateDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, "Commit operations failed."),
+		}, err
+	}
+
+	util.Logger().Infof("update discovery policy successful, serviceId %s/%s, policy %v, operator %s.",
+		domainProject, in.ServiceId, in.Policy, util.GetIPFromContext(ctx))
+	return &pb.UpdateDiscoveryPolicyResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Update discovery policy successfully."),
+	}, nil
+}
+
+func (s *MicroServiceService) DeleteDiscoveryPolicy(ctx context.Context, in *pb.DeleteDiscoveryPolicyRequest) (*pb.DeleteDiscoveryPolicyResponse, error) {
+	if !isPolicyAdmin(ctx) {
+		util.Logger().Errorf(nil, "delete discovery policy failed: administrator permission required, domain %s.",
+			util.ParseDomain(ctx))
+		return &pb.DeleteDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrForbidden, "Administrator permission required."),
+		}, nil
+	}
+	if in == nil || len(in.ServiceId) == 0 {
+		util.Logger().Errorf(nil, "delete discovery policy failed: invalid params.")
+		return &pb.DeleteDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "delete discovery policy failed, serviceId %s: invalid parameters.", in.ServiceId)
+		return &pb.DeleteDiscoveryPolicyResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.StringJoin([]string{in.Domain, in.Project}, "/")
+
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ServiceId) {
+		util.Logger().Errorf(nil, "delete discovery policy failed, serviceId %s/%s: service not exist.", domainProject, in.ServiceId)
+		return &pb.DeleteDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+
+	policy, err := serviceUtil.GetDiscoveryPolicy(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "delete discovery policy failed, serviceId %s/%s: get policy failed.", domainProject, in.ServiceId)
+		return &pb.DeleteDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, "Get discovery policy failed."),
+		}, err
+	}
+	if policy == nil {
+		util.Logger().Errorf(nil, "delete discovery policy failed, serviceId %s/%s: policy not exist.", domainProject, in.ServiceId)
+		return &pb.DeleteDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrPolicyNotExists, "Discovery policy does not exist."),
+		}, nil
+	}
+
+	_, err = backend.Registry().Do(ctx,
+		registry.DEL,
+		registry.WithStrKey(apt.GenerateServicePolicyKey(domainProject, in.ServiceId)))
+	if err != nil {
+		util.Logger().Errorf(err, "delete discovery policy failed, serviceId %s/%s: commit operations failed.", domainProject, in.ServiceId)
+		return &pb.DeleteDiscoveryPolicyResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, "Commit operations failed."),
+		}, err
+	}
+
+	util.Logger().Infof("delete discovery policy successful, serviceId %s/%s, operator %s.",
+		domainProject, in.ServiceId, util.GetIPFromContext(ctx))
+	return &pb.DeleteDiscoveryPolicyResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Delete discovery policy successfully."),
+	}, nil
+}
+
+// isPolicyAdmin 发现策略由平台管理员统一配置，只允许管理员所在的domain访问，
+// 请求中的domain和project指定consumer所在的租户
+func isPolicyAdmin(ctx context.Context) bool {
+	return util.ParseDomain(ctx) == apt.REGISTRY_DOMAIN
+}
@@ -0,0 +1,269 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service_test
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"strconv"
+)
+
+var _ = Describe("'DiscoveryPolicy' service", func() {
+	Describe("execute 'update' and 'find' operartion", func() {
+		var (
+			consumerId string
+			providerId string
+		)
+
+		It("should be passed", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "policy_group",
+					ServiceName: "policy_consumer",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			consumerId = respCreateService.ServiceId
+
+			respCreateService, err = serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "policy_group",
+					ServiceName: "policy_provider",
+					Version:     "1.0.0",
+					Level:       "BACK",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			providerId = respCreateService.ServiceId
+
+			for i, props := range []map[string]string{
+				{"canary": "true"},
+				{"zone": "b"},
+				{"zone": "a"},
+			} {
+				resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: providerId,
+						Endpoints: []string{
+							"policy:127.0.0.1:" + strconv.Itoa(8080+i),
+						},
+						HostName:   "UT-HOST",
+						Status:     pb.MSI_UP,
+						Properties: props,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+			}
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				By("domain is not administrator")
+				ctx := util.SetContext(getContext(), "domain", "user")
+				respGet, _ := serviceResource.GetDiscoveryPolicy(ctx, &pb.GetDiscoveryPolicyRequest{
+					Domain:    "default",
+					Project:   "default",
+					ServiceId: consumerId,
+				})
+				Expect(respGet.Response.Code).To(Equal(scerr.ErrForbidden))
+				respUpdate, _ := serviceResource.UpdateDiscoveryPolicy(ctx, &pb.UpdateDiscoveryPolicyRequest{
+					Domain:    "default",
+					Project:   "default",
+					ServiceId: consumerId,
+					Policy:    &pb.DiscoveryPolicy{},
+				})
+				Expect(respUpdate.Response.Code).To(Equal(scerr.ErrForbidden))
+				respDelete, _ := serviceResource.DeleteDiscoveryPolicy(ctx, &pb.DeleteDiscoveryPolicyRequest{
+					Domain:    "default",
+					Project:   "default",
+					ServiceId: consumerId,
+				})
+				Expect(respDelete.Response.Code).To(Equal(scerr.ErrForbidden))
+
+				By("domain is empty")
+				resp, _ := serviceResource.UpdateDiscoveryPolicy(getContext(), &pb.UpdateDiscoveryPolicyRequest{
+					ServiceId: consumerId,
+					Policy:    &pb.DiscoveryPolicy{},
+				})
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("service id is empty")
+				resp, _ = serviceResource.UpdateDiscoveryPolicy(getContext(), &pb.UpdateDiscoveryPolicyRequest{
+					Domain:  "default",
+					Project: "default",
+					Policy:  &pb.DiscoveryPolicy{},
+				})
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("max instances is negative")
+				resp, _ = serviceResource.UpdateDiscoveryPolicy(getContext(), &pb.UpdateDiscoveryPolicyRequest{
+					Domain:    "default",
+					Project:   "default",
+					ServiceId: consumerId,
+					Policy:    &pb.DiscoveryPolicy{MaxInstances: -1},
+				})
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("service does not exist")
+				resp, _ = serviceResource.UpdateDiscoveryPolicy(getContext(), &pb.UpdateDiscoveryPolicyRequest{
+					Domain:    "default",
+					Project:   "default",
+					ServiceId: "noServiceTest",
+					Policy:    &pb.DiscoveryPolicy{},
+				})
+				Expect(resp.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+
+				By("policy does not exist")
+				respGet, _ = serviceResource.GetDiscoveryPolicy(getContext(), &pb.GetDiscoveryPolicyRequest{
+					Domain:    "default",
+					Project:   "default",
+					ServiceId: consumerId,
+				})
+				Expect(respGet.Response.Code).To(Equal(scerr.ErrPolicyNotExists))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				find := func() []*pb.MicroServiceInstance {
+					respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+						ConsumerServiceId: consumerId,
+						AppId:             "policy_group",
+						ServiceName:       "policy_provider",
+						VersionRule:       "latest",
+					})
+					Expect(err).To(BeNil())
+					Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+					return respFind.Instances
+				}
+				Expect(len(find())).To(Equal(3))
+
+				resp, err := serviceResource.UpdateDiscoveryPolicy(getContext(), &pb.UpdateDiscoveryPolicyRequest{
+					Domain:    "default",
+					Project:   "default",
+					ServiceId: consumerId,
+					Policy: &pb.DiscoveryPolicy{
+						MaxInstances:      1,
+						PreferredTags:     map[string]string{"zone": "a"},
+						ExcludeProperties: map[string]string{"canary": "true"},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respGet, err := serviceResource.GetDiscoveryPolicy(getContext(), &pb.GetDiscoveryPolicyRequest{
+					Domain:    "default",
+					Project:   "default",
+					ServiceId: consumerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGet.Policy.MaxInstances).To(Equal(int32(1)))
+
+				instances := find()
+				Expect(len(instances)).To(Equal(1))
+				Expect(instances[0].Properties["zone"]).To(Equal("a"))
+
+				respDel, err := serviceResource.DeleteDiscoveryPolicy(getContext(), &pb.DeleteDiscoveryPolicyRequest{
+					Domain:    "default",
+					Project:   "default",
+					ServiceId: consumerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(respDel.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				Expect(len(find())).To(Equal(3))
+
+				respDel, _ = serviceResource.DeleteDiscoveryPolicy(getContext(), &pb.DeleteDiscoveryPolicyRequest{
+					Domain:    "default",
+					Project:   "default",
+					ServiceId: consumerId,
+				})
+				Expect(respDel.Response.Code).To(Equal(scerr.ErrPolicyNotExists))
+			})
+		})
+
+		Context("when noDependency is set", func() {
+			It("should not record the dependency", func() {
+				respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						AppId:       "policy_group",
+						ServiceName: "policy_nodep_consumer",
+						Version:     "1.0.0",
+						Level:       "FRONT",
+						Status:      pb.MS_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+				id := respCreateService.ServiceId
+
+				find := func(noDependency bool) int {
+					respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+						ConsumerServiceId: id,
+						AppId:             "policy_group",
+						ServiceName:       "policy_provider",
+						VersionRule:       "latest",
+						NoDependency:      noDependency,
+					})
+					Expect(err).To(BeNil())
+					Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+					respCon, err := serviceResource.GetConsumerDependencies(getContext(), &pb.GetDependenciesRequest{
+						ServiceId: id,
+					})
+					Expect(err).To(BeNil())
+					Expect(respCon.Response.Code).To(Equal(pb.Response_SUCCESS))
+					return len(respCon.Providers)
+				}
+
+				By("per request")
+				Expect(find(true)).To(Equal(0))
+
+				By("per consumer")
+				resp, err := serviceResource.UpdateDiscoveryPolicy(getContext(), &pb.UpdateDiscoveryPolicyRequest{
+					Domain:    "default",
+					Project:   "default",
+					ServiceId: id,
+					Policy:    &pb.DiscoveryPolicy{NoDependency: true},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(find(false)).To(Equal(0))
+
+				respDel, err := serviceResource.DeleteDiscoveryPolicy(getContext(), &pb.DeleteDiscoveryPolicyRequest{
+					Domain:    "default",
+					Project:   "default",
+					ServiceId: id,
+				})
+				Expect(err).To(BeNil())
+				Expect(respDel.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(find(false)).To(Equal(1))
+			})
+		})
+	})
+})
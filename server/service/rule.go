@@ -73,12 +73,12 @@ func (s *MicroServiceService) AddRule(ctx context.Context, in *pb.AddServiceRule
 		}, err
 	}
 	ruleIds := []string{}
-	for _, rule := range in.Rules {
+	for i, rule := range in.Rules {
 		err := apt.Validate(rule)
 		if err != nil {
 			util.Logger().Errorf(err, "add rule failed, serviceId is %s: invalid rule.", in.ServiceId)
 			return &pb.AddServiceRulesResponse{
-				Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+				Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("rules["+strconv.Itoa(i)+"]", err)...),
 			}, nil
 		}
 		//黑白名单只能存在一种，黑名单 or 白名单
@@ -170,7 +170,7 @@ func (s *MicroServiceService) UpdateRule(ctx context.Context, in *pb.UpdateServi
 	if err != nil {
 		util.Logger().Errorf(err, "update rule failed, serviceId is %s, ruleId is %s: invalid service rule.", in.ServiceId, in.RuleId)
 		return &pb.UpdateServiceRuleResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("rule", err)...),
 		}, nil
 	}
 
@@ -344,3 +344,178 @@ func (s *MicroServiceService) DeleteRule(ctx context.Context, in *pb.DeleteServi
 		Response: pb.CreateResponse(pb.Response_SUCCESS, "Delete service rules successfully."),
 	}, nil
 }
+
+// ReplaceRules 用期望的黑白名单全量替换服务当前的规则，差异在同一个事务中提交，
+// 返回计算出的变更；dryRun时只返回变更
+func (s *MicroServiceService) ReplaceRules(ctx context.Context, in *pb.ReplaceServiceRulesRequest) (*pb.ReplaceServiceRulesResponse, error) {
+	if in == nil || len(in.ServiceId) == 0 {
+		util.Logger().Errorf(nil, "replace rules failed: invalid parameters.")
+		return &pb.ReplaceServiceRulesResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	// service id存在性校验
+	if !serviceUtil.ServiceExist(ctx, domainProject, in.ServiceId) {
+		util.Logger().Errorf(nil, "replace rules failed, serviceId is %s: service not exist.", in.ServiceId)
+		return &pb.ReplaceServiceRulesResponse{
+			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
+		}, nil
+	}
+
+	ruleType := ""
+	indexes := make(map[string]struct{}, len(in.Rules))
+	for i, rule := range in.Rules {
+		err := apt.Validate(rule)
+		if err != nil {
+			util.Logger().Errorf(err, "replace rules failed, serviceId is %s: invalid rule.", in.ServiceId)
+			return &pb.ReplaceServiceRulesResponse{
+				Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("rules["+strconv.Itoa(i)+"]", err)...),
+			}, nil
+		}
+		//黑白名单只能存在一种，黑名单 or 白名单
+		if len(ruleType) == 0 {
+			ruleType = rule.RuleType
+		} else if ruleType != rule.RuleType {
+			util.Logger().Errorf(nil, "replace rules failed, serviceId is %s:can only exist one type, BLACK or WHITE.", in.ServiceId)
+			return &pb.ReplaceServiceRulesResponse{
+				Response: pb.CreateResponse(scerr.ErrBlackAndWhiteRule, "Service can only contain one rule type, BLACK or WHITE."),
+			}, nil
+		}
+		//同一服务，attribute和pattern确定一个rule
+		index := util.StringJoin([]string{rule.Attribute, rule.Pattern}, "/")
+		if _, ok := indexes[index]; ok {
+			util.Logger().Errorf(nil, "replace rules failed, serviceId is %s: duplicate rule %s.", in.ServiceId, index)
+			return &pb.ReplaceServiceRulesResponse{
+				Response: pb.CreateResponse(scerr.ErrInvalidParams, "Duplicate rule "+index+"."),
+			}, nil
+		}
+		indexes[index] = struct{}{}
+	}
+
+	// 直接读etcd计算差异，避免缓存未同步时漏删或重复添加
+	rules, err := serviceUtil.GetRulesUtil(util.SetContext(util.CloneContext(ctx), "noCache", "1"), domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "replace rules failed, serviceId is %s: get rules failed.", in.ServiceId)
+		return &pb.ReplaceServiceRulesResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	plan := &applyPlan{updateRules: make(map[string]*pb.AddOrUpdateServiceRule)}
+	plan.diffRules(in.Rules, rules)
+
+	if in.DryRun || len(plan.changes) == 0 {
+		return &pb.ReplaceServiceRulesResponse{
+			Response: pb.CreateResponse(pb.Response_SUCCESS, "Diff service rules successfully."),
+			Changes:  plan.changes,
+		}, nil
+	}
+
+	if n := len(plan.addRules) - len(plan.deleteRules); n > 0 {
+		_, ok, err := plugin.Plugins().Quota().Apply4Quotas(ctx, quota.RuleQuotaType, domainProject, in.ServiceId, int16(n))
+		if err != nil {
+			util.Logger().Errorf(err, "check can apply resource failed.%s", in.ServiceId)
+			return &pb.ReplaceServiceRulesResponse{
+				Response: pb.CreateResponse(scerr.ErrUnavailableQuota, err.Error()),
+			}, err
+		}
+		if !ok {
+			util.Logger().Errorf(nil, "replace rules failed, serviceId is %s: not enough quota.", in.ServiceId)
+			return &pb.ReplaceServiceRulesResponse{
+				Response: pb.CreateResponse(scerr.ErrNotEnoughQuota, "no size to add rule, max size is 100 for one servivce"),
+			}, nil
+		}
+	}
+
+	opts, ruleIds, err := replaceRulesOps(domainProject, in.ServiceId, rules, plan)
+	if err != nil {
+		util.Logger().Errorf(err, "replace rules failed, serviceId is %s: marshal rule failed.", in.ServiceId)
+		return &pb.ReplaceServiceRulesResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, "Service rule file marshal error."),
+		}, err
+	}
+	_, err = backend.Registry().Txn(ctx, opts)
+	if err != nil {
+		util.Logger().Errorf(err, "replace rules failed, serviceId is %s: commit data into etcd failed.", in.ServiceId)
+		return &pb.ReplaceServiceRulesResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, "Commit operations failed."),
+		}, err
+	}
+
+	util.Logger().Infof("replace rules successful, serviceId %s, %d change(s). operator: %s",
+		in.ServiceId, len(plan.changes), util.GetIPFromContext(ctx))
+	return &pb.ReplaceServiceRulesResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Replace service rules successfully."),
+		Changes:  plan.changes,
+		RuleIds:  ruleIds,
+	}, nil
+}
+
+// replaceRulesOps 生成删除、更新和新增规则的事务操作，
+// 同一个index键被删除后又被新规则使用时只保留PUT，etcd不允许事务中出现重复的键
+func replaceRulesOps(domainProject, serviceId string, current []*pb.ServiceRule, plan *applyPlan) ([]registry.PluginOp, []string, error) {
+	exist := make(map[string]*pb.ServiceRule, len(current))
+	for _, rule := range current {
+		exist[rule.RuleId] = rule
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	puts := make(map[string]registry.PluginOp)
+	dels := make(map[string]registry.PluginOp)
+	put := func(rule *pb.ServiceRule) error {
+		data, err := json.Marshal(rule)
+		if err != nil {
+			return err
+		}
+		key := apt.GenerateServiceRuleKey(domainProject, serviceId, rule.RuleId)
+		indexKey := apt.GenerateRuleIndexKey(domainProject, serviceId, rule.Attribute, rule.Pattern)
+		puts[key] = registry.OpPut(registry.WithStrKey(key), registry.WithValue(data))
+		puts[indexKey] = registry.OpPut(registry.WithStrKey(indexKey), registry.WithStrValue(rule.RuleId))
+		return nil
+	}
+
+	for _, ruleId := range plan.deleteRules {
+		rule := exist[ruleId]
+		key := apt.GenerateServiceRuleKey(domainProject, serviceId, ruleId)
+		indexKey := apt.GenerateRuleIndexKey(domainProject, serviceId, rule.Attribute, rule.Pattern)
+		dels[key] = registry.OpDel(registry.WithStrKey(key))
+		dels[indexKey] = registry.OpDel(registry.WithStrKey(indexKey))
+	}
+	for ruleId, update := range plan.updateRules {
+		rule := *exist[ruleId]
+		rule.Description = update.Description
+		rule.ModTimestamp = timestamp
+		if err := put(&rule); err != nil {
+			return nil, nil, err
+		}
+	}
+	ruleIds := make([]string, 0, len(plan.addRules))
+	for _, add := range plan.addRules {
+		rule := &pb.ServiceRule{
+			RuleId:       uuid.GenerateUuid(),
+			RuleType:     add.RuleType,
+			Attribute:    add.Attribute,
+			Pattern:      add.Pattern,
+			Description:  add.Description,
+			Timestamp:    timestamp,
+			ModTimestamp: timestamp,
+		}
+		if err := put(rule); err != nil {
+			return nil, nil, err
+		}
+		ruleIds = append(ruleIds, rule.RuleId)
+	}
+
+	opts := make([]registry.PluginOp, 0, len(puts)+len(dels))
+	for key, op := range dels {
+		if _, ok := puts[key]; !ok {
+			opts = append(opts, op)
+		}
+	}
+	for _, op := range puts {
+		opts = append(opts, op)
+	}
+	return opts, ruleIds, nil
+}
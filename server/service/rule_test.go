@@ -20,9 +20,14 @@ import (
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
 	"github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/quota/buildin"
+	"github.com/apache/incubator-servicecomb-service-center/server/service"
+	"github.com/apache/incubator-servicecomb-service-center/server/service/event"
+	nf "github.com/apache/incubator-servicecomb-service-center/server/service/notification"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"golang.org/x/net/context"
 	"strconv"
+	"time"
 )
 
 var _ = Describe("'Rule' service", func() {
@@ -522,6 +527,167 @@ var _ = Describe("'Rule' service", func() {
 		})
 	})
 
+	Describe("execute 'replace' operartion", func() {
+		var serviceId string
+
+		It("should be passed", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "replace_rule_group",
+					ServiceName: "replace_rule_service",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreateService.ServiceId
+
+			respAddRule, err := serviceResource.AddRule(getContext(), &pb.AddServiceRulesRequest{
+				ServiceId: serviceId,
+				Rules: []*pb.AddOrUpdateServiceRule{
+					{RuleType: "BLACK", Attribute: "ServiceName", Pattern: "Keep*", Description: "keep"},
+					{RuleType: "BLACK", Attribute: "ServiceName", Pattern: "Update*", Description: "old"},
+					{RuleType: "BLACK", Attribute: "ServiceName", Pattern: "Delete*", Description: "delete"},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respAddRule.Response.Code).To(Equal(pb.Response_SUCCESS))
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				By("service does not exist")
+				resp, err := serviceResource.ReplaceRules(getContext(), &pb.ReplaceServiceRulesRequest{
+					ServiceId: "notexistservice",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+
+				By("black and white")
+				resp, err = serviceResource.ReplaceRules(getContext(), &pb.ReplaceServiceRulesRequest{
+					ServiceId: serviceId,
+					Rules: []*pb.AddOrUpdateServiceRule{
+						{RuleType: "BLACK", Attribute: "ServiceName", Pattern: "A*"},
+						{RuleType: "WHITE", Attribute: "ServiceName", Pattern: "B*"},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrBlackAndWhiteRule))
+
+				By("duplicate rules")
+				resp, err = serviceResource.ReplaceRules(getContext(), &pb.ReplaceServiceRulesRequest{
+					ServiceId: serviceId,
+					Rules: []*pb.AddOrUpdateServiceRule{
+						{RuleType: "BLACK", Attribute: "ServiceName", Pattern: "A*"},
+						{RuleType: "BLACK", Attribute: "ServiceName", Pattern: "A*"},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				rules := []*pb.AddOrUpdateServiceRule{
+					{RuleType: "BLACK", Attribute: "ServiceName", Pattern: "Keep*", Description: "keep"},
+					{RuleType: "BLACK", Attribute: "ServiceName", Pattern: "Update*", Description: "new"},
+					{RuleType: "BLACK", Attribute: "ServiceName", Pattern: "Add*", Description: "add"},
+				}
+
+				By("dry run")
+				resp, err := serviceResource.ReplaceRules(getContext(), &pb.ReplaceServiceRulesRequest{
+					ServiceId: serviceId,
+					Rules:     rules,
+					DryRun:    true,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Changes)).To(Equal(3))
+				Expect(len(resp.RuleIds)).To(Equal(0))
+
+				respGetRule, err := serviceResource.GetRule(getContext(), &pb.GetServiceRulesRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(len(respGetRule.Rules)).To(Equal(3))
+
+				By("replace")
+				resp, err = serviceResource.ReplaceRules(getContext(), &pb.ReplaceServiceRulesRequest{
+					ServiceId: serviceId,
+					Rules:     rules,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.RuleIds)).To(Equal(1))
+				changes := map[string]string{}
+				for _, change := range resp.Changes {
+					changes[change.Name] = change.Action
+				}
+				Expect(changes).To(Equal(map[string]string{
+					"BLACK/ServiceName/Update*": "update",
+					"BLACK/ServiceName/Add*":    "create",
+					"BLACK/ServiceName/Delete*": "delete",
+				}))
+
+				respGetRule, err = serviceResource.GetRule(getContext(), &pb.GetServiceRulesRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(len(respGetRule.Rules)).To(Equal(3))
+				for _, rule := range respGetRule.Rules {
+					Expect(rule.Pattern).NotTo(Equal("Delete*"))
+					if rule.Pattern == "Update*" {
+						Expect(rule.Description).To(Equal("new"))
+					}
+				}
+
+				By("no change")
+				resp, err = serviceResource.ReplaceRules(getContext(), &pb.ReplaceServiceRulesRequest{
+					ServiceId: serviceId,
+					Rules:     rules,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Changes)).To(Equal(0))
+
+				By("change rule type")
+				resp, err = serviceResource.ReplaceRules(getContext(), &pb.ReplaceServiceRulesRequest{
+					ServiceId: serviceId,
+					Rules: []*pb.AddOrUpdateServiceRule{
+						{RuleType: "WHITE", Attribute: "ServiceName", Pattern: "Keep*", Description: "white"},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Changes)).To(Equal(4))
+
+				respGetRule, err = serviceResource.GetRule(getContext(), &pb.GetServiceRulesRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(len(respGetRule.Rules)).To(Equal(1))
+				Expect(respGetRule.Rules[0].RuleType).To(Equal("WHITE"))
+
+				By("remove all")
+				resp, err = serviceResource.ReplaceRules(getContext(), &pb.ReplaceServiceRulesRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Changes)).To(Equal(1))
+
+				respGetRule, err = serviceResource.GetRule(getContext(), &pb.GetServiceRulesRequest{
+					ServiceId: serviceId,
+				})
+				Expect(err).To(BeNil())
+				Expect(len(respGetRule.Rules)).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("execute 'permission' operartion", func() {
 		var (
 			consumerVersion string
@@ -679,4 +845,92 @@ var _ = Describe("'Rule' service", func() {
 			})
 		})
 	})
+
+	Describe("publish the rules changed event", func() {
+		It("should notify the consumers with the permission", func() {
+			create := func(serviceName string) string {
+				resp, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						AppId:       "rule_event",
+						ServiceName: serviceName,
+						Version:     "1.0.0",
+						Level:       "FRONT",
+						Status:      pb.MS_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				return resp.ServiceId
+			}
+			providerId := create("rule_event_provider")
+			consumerId := create("rule_event_consumer")
+
+			respDep, err := serviceResource.CreateDependenciesForMicroServices(getContext(), &pb.CreateDependenciesRequest{
+				Dependencies: []*pb.ConsumerDependency{
+					{
+						Consumer: &pb.DependencyKey{AppId: "rule_event", ServiceName: "rule_event_consumer", Version: "1.0.0"},
+						Providers: []*pb.DependencyKey{
+							{AppId: "rule_event", ServiceName: "rule_event_provider", Version: "1.0.0"},
+						},
+					},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respDep.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			respAddRule, err := serviceResource.AddRule(getContext(), &pb.AddServiceRulesRequest{
+				ServiceId: providerId,
+				Rules: []*pb.AddOrUpdateServiceRule{
+					{
+						RuleType:    "BLACK",
+						Attribute:   "ServiceName",
+						Pattern:     "rule_event_consumer",
+						Description: "test rule event",
+					},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respAddRule.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			ns := nf.GetNotifyService()
+			ns.Config = nf.NotifyServiceConfig{}
+			ns.Start()
+			defer ns.Stop()
+
+			ctx, cancel := context.WithCancel(getContext())
+			stream := &recordingWatchServer{
+				cancelableWatchServer: cancelableWatchServer{ctx: ctx},
+				events:                make(chan *pb.WatchInstanceResponse, 10),
+			}
+			done := make(chan error, 1)
+			go func() {
+				done <- instanceResource.(*service.InstanceService).Watch(&pb.WatchInstanceRequest{
+					SelfServiceId: consumerId,
+				}, stream)
+			}()
+			Eventually(func() int64 {
+				return ns.Subscribers(nf.INSTANCE, "")
+			}).Should(Equal(int64(1)))
+
+			task := event.NewRulesChangedAsyncTask("default/default", providerId, 20)
+			Expect(task.Do(getContext())).To(BeNil())
+
+			By("the consumer receives the rule changed event")
+			var ruleChanged *pb.WatchInstanceResponse
+			for ruleChanged == nil {
+				var resp *pb.WatchInstanceResponse
+				Eventually(stream.events, 2*time.Second).Should(Receive(&resp))
+				if resp.Action == string(pb.EVT_RULE_CHANGED) {
+					ruleChanged = resp
+				}
+			}
+			Expect(ruleChanged.Permission).To(Equal(pb.PERMISSION_DENY))
+			Expect(ruleChanged.Instance).To(BeNil())
+			Expect(ruleChanged.Key.AppId).To(Equal("rule_event"))
+			Expect(ruleChanged.Key.ServiceName).To(Equal("rule_event_provider"))
+
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+		})
+	})
 })
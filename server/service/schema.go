@@ -17,6 +17,8 @@
 package service
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
@@ -28,8 +30,10 @@ import (
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
 	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
 	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"github.com/coreos/etcd/mvcc/mvccpb"
 	"golang.org/x/net/context"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -45,19 +49,41 @@ func (s *MicroServiceService) GetSchemaInfo(ctx context.Context, in *pb.GetSchem
 	if err != nil {
 		util.Logger().Errorf(nil, "get schema failed, serviceId %s, schemaId %s: invalid params.", in.ServiceId, in.SchemaId)
 		return &pb.GetSchemaResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 
 	domainProject := util.ParseDomainProject(ctx)
 
-	if !serviceUtil.ServiceExist(ctx, domainProject, in.ServiceId) {
+	service, err := serviceUtil.GetService(ctx, domainProject, in.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "get schema failed, serviceId %s, schemaId %s: get service failed.", in.ServiceId, in.SchemaId)
+		return &pb.GetSchemaResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if service == nil {
 		util.Logger().Errorf(nil, "get schema failed, serviceId %s, schemaId %s: service not exist.", in.ServiceId, in.SchemaId)
 		return &pb.GetSchemaResponse{
 			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
 		}, nil
 	}
 
+	allowed, err := serviceUtil.CanReadSchema(ctx, domainProject, service, in.ConsumerServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "get schema failed, serviceId %s, schemaId %s: check schema visibility failed.", in.ServiceId, in.SchemaId)
+		return &pb.GetSchemaResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if !allowed {
+		util.Logger().Errorf(nil, "get schema failed, serviceId %s, schemaId %s: consumer '%s' is out of the schema visibility '%s'.",
+			in.ServiceId, in.SchemaId, in.ConsumerServiceId, serviceUtil.SchemaVisibility(service))
+		return &pb.GetSchemaResponse{
+			Response: pb.CreateResponse(scerr.ErrPermissionDeny, "Schema is not visible to the consumer."),
+		}, nil
+	}
+
 	key := apt.GenerateServiceSchemaKey(domainProject, in.ServiceId, in.SchemaId)
 	opts := append(serviceUtil.FromContext(ctx), registry.WithStrKey(key))
 	resp, errDo := store.Store().Schema().Search(ctx, opts...)
@@ -68,11 +94,9 @@ func (s *MicroServiceService) GetSchemaInfo(ctx context.Context, in *pb.GetSchem
 		}, errDo
 	}
 	if resp.Count == 0 {
-		util.Logger().Errorf(errDo, "get schema failed, serviceId %s, schemaId %s: schema not exists.", in.ServiceId, in.SchemaId)
-		return &pb.GetSchemaResponse{
-			Response: pb.CreateResponse(scerr.ErrSchemaNotExists, "Do not have this schema info."),
-		}, nil
+		return s.getSchemaFromSource(ctx, domainProject, service, in)
 	}
+	serviceUtil.RecordModRevision(ctx, resp.Kvs)
 
 	schemaSummary, err := getSchemaSummary(ctx, domainProject, in.ServiceId, in.SchemaId)
 	if err != nil {
@@ -82,10 +106,52 @@ func (s *MicroServiceService) GetSchemaInfo(ctx context.Context, in *pb.GetSchem
 		}, err
 	}
 
+	return resolveSchemaResponse(ctx, domainProject, in, util.BytesToStringWithNoCopy(resp.Kvs[0].Value),
+		schemaSummary, resp.Kvs[0].ModRevision)
+}
+
+// getSchemaFromSource 契约未上传时从服务配置的外部契约源读取，摘要按内容计算，版本为0
+func (s *MicroServiceService) getSchemaFromSource(ctx context.Context, domainProject string, service *pb.MicroService,
+	in *pb.GetSchemaRequest) (*pb.GetSchemaResponse, error) {
+	schema, ok, err := serviceUtil.FetchSchemaFromSource(ctx, domainProject, service, in.SchemaId)
+	if !ok {
+		util.Logger().Errorf(nil, "get schema failed, serviceId %s, schemaId %s: schema not exists.", in.ServiceId, in.SchemaId)
+		return &pb.GetSchemaResponse{
+			Response: pb.CreateResponse(scerr.ErrSchemaNotExists, "Do not have this schema info."),
+		}, nil
+	}
+	if err != nil {
+		util.Logger().Errorf(err, "get schema failed, serviceId %s, schemaId %s: fetch from schema source failed.", in.ServiceId, in.SchemaId)
+		return &pb.GetSchemaResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableSchemaSource, err.Error()),
+		}, nil
+	}
+	return resolveSchemaResponse(ctx, domainProject, in, schema, computeSchemaSummary(schema), 0)
+}
+
+func resolveSchemaResponse(ctx context.Context, domainProject string, in *pb.GetSchemaRequest,
+	schema, schemaSummary string, revision int64) (*pb.GetSchemaResponse, error) {
+	var err error
+	if in.Resolve {
+		schema, err = serviceUtil.BundleSchema(ctx, domainProject, schema)
+		if err != nil {
+			util.Logger().Errorf(err, "get schema failed, serviceId %s, schemaId %s: resolve shared definitions failed.", in.ServiceId, in.SchemaId)
+			if _, ok := err.(*serviceUtil.SharedDefinitionNotFoundError); ok {
+				return &pb.GetSchemaResponse{
+					Response: pb.CreateResponse(scerr.ErrSharedDefinitionNotExists, err.Error()),
+				}, nil
+			}
+			return &pb.GetSchemaResponse{
+				Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+			}, err
+		}
+	}
+
 	return &pb.GetSchemaResponse{
 		Response:      pb.CreateResponse(pb.Response_SUCCESS, "Get schema info successfully."),
-		Schema:        util.BytesToStringWithNoCopy(resp.Kvs[0].Value),
+		Schema:        schema,
 		SchemaSummary: schemaSummary,
+		Revision:      revision,
 	}, nil
 }
 
@@ -101,7 +167,7 @@ func (s *MicroServiceService) GetAllSchemaInfo(ctx context.Context, in *pb.GetAl
 	if err != nil {
 		util.Logger().Errorf(nil, "get schema failed, serviceId %s: invalid params.", in.ServiceId)
 		return &pb.GetAllSchemaResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 
@@ -121,6 +187,21 @@ func (s *MicroServiceService) GetAllSchemaInfo(ctx context.Context, in *pb.GetAl
 		}, nil
 	}
 
+	allowed, err := serviceUtil.CanReadSchema(ctx, domainProject, service, in.ConsumerServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "get all schemas failed: check schema visibility failed. %s", in.ServiceId)
+		return &pb.GetAllSchemaResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if !allowed {
+		util.Logger().Errorf(nil, "get all schemas failed: consumer '%s' is out of the schema visibility '%s'. %s",
+			in.ConsumerServiceId, serviceUtil.SchemaVisibility(service), in.ServiceId)
+		return &pb.GetAllSchemaResponse{
+			Response: pb.CreateResponse(scerr.ErrPermissionDeny, "Schema is not visible to the consumer."),
+		}, nil
+	}
+
 	schemasList := service.Schemas
 	if schemasList == nil || len(schemasList) == 0 {
 		util.Logger().Infof("service %s schemaId set is empty.", in.ServiceId)
@@ -140,19 +221,21 @@ func (s *MicroServiceService) GetAllSchemaInfo(ctx context.Context, in *pb.GetAl
 		}, errDo
 	}
 
-	respWithSchema := &registry.PluginResponse{}
-	if in.WithSchema {
-		key := apt.GenerateServiceSchemaKey(domainProject, in.ServiceId, "")
-		opts := append(serviceUtil.FromContext(ctx), registry.WithStrKey(key), registry.WithPrefix())
-		respWithSchema, errDo = store.Store().Schema().Search(ctx, opts...)
-		if errDo != nil {
-			util.Logger().Errorf(errDo, "get schema failed, serviceId %s: get schema info failed.", in.ServiceId)
-			return &pb.GetAllSchemaResponse{
-				Response: pb.CreateResponse(scerr.ErrInternal, "Get schema info failed."),
-			}, errDo
-		}
+	key = apt.GenerateServiceSchemaKey(domainProject, in.ServiceId, "")
+	opts = append(serviceUtil.FromContext(ctx), registry.WithStrKey(key), registry.WithPrefix())
+	if !in.WithSchema {
+		// 只需要计算revision
+		opts = append(opts, registry.WithKeyOnly())
 	}
-
+	respWithSchema, errDo := store.Store().Schema().Search(ctx, opts...)
+	if errDo != nil {
+		util.Logger().Errorf(errDo, "get schema failed, serviceId %s: get schema info failed.", in.ServiceId)
+		return &pb.GetAllSchemaResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, "Get schema info failed."),
+		}, errDo
+	}
+	serviceUtil.RecordModRevision(ctx, resp.Kvs)
+	serviceUtil.RecordModRevision(ctx, respWithSchema.Kvs)
 	schemas := make([]*pb.Schema, 0, len(schemasList))
 	for _, schemaId := range schemasList {
 		tempSchema := &pb.Schema{}
@@ -164,10 +247,12 @@ func (s *MicroServiceService) GetAllSchemaInfo(ctx context.Context, in *pb.GetAl
 			}
 		}
 
-		for _, contentSchema := range respWithSchema.Kvs {
-			schemaIdOfSchema, schemaData := pb.GetInfoFromSchemaKV(contentSchema)
-			if schemaId == schemaIdOfSchema {
-				tempSchema.Schema = util.BytesToStringWithNoCopy(schemaData)
+		if in.WithSchema {
+			for _, contentSchema := range respWithSchema.Kvs {
+				schemaIdOfSchema, schemaData := pb.GetInfoFromSchemaKV(contentSchema)
+				if schemaId == schemaIdOfSchema {
+					tempSchema.Schema = util.BytesToStringWithNoCopy(schemaData)
+				}
 			}
 		}
 		schemas = append(schemas, tempSchema)
@@ -176,6 +261,7 @@ func (s *MicroServiceService) GetAllSchemaInfo(ctx context.Context, in *pb.GetAl
 	return &pb.GetAllSchemaResponse{
 		Response: pb.CreateResponse(pb.Response_SUCCESS, "Get all schema info successfully."),
 		Schema:   schemas,
+		Revision: schemasRevision(respWithSchema.Kvs),
 	}, nil
 
 }
@@ -191,17 +277,30 @@ func (s *MicroServiceService) DeleteSchema(ctx context.Context, request *pb.Dele
 	if err != nil {
 		util.Logger().Errorf(err, "delete schema failed, serviceId %s, schemaId %s: invalid params.", request.ServiceId, request.SchemaId)
 		return &pb.DeleteSchemaResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 	domainProject := util.ParseDomainProject(ctx)
 
-	if !serviceUtil.ServiceExist(ctx, domainProject, request.ServiceId) {
+	service, err := serviceUtil.GetService(ctx, domainProject, request.ServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "delete schema failed, serviceId %s, schemaId %s: get service failed.", request.ServiceId, request.SchemaId)
+		return &pb.DeleteSchemaResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if service == nil {
 		util.Logger().Errorf(nil, "delete schema failed, serviceId %s, schemaId %s: service not exist.", request.ServiceId, request.SchemaId)
 		return &pb.DeleteSchemaResponse{
 			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
 		}, nil
 	}
+	if frozenErr := serviceUtil.CheckSchemaFreeze(service); frozenErr != nil {
+		util.Logger().Errorf(nil, "delete schema failed, serviceId %s, schemaId %s: schemas are frozen.", request.ServiceId, request.SchemaId)
+		return &pb.DeleteSchemaResponse{
+			Response: pb.CreateResponse(frozenErr.Code, frozenErr.Detail),
+		}, nil
+	}
 
 	key := apt.GenerateServiceSchemaKey(domainProject, request.ServiceId, request.SchemaId)
 	exist, err := serviceUtil.CheckSchemaInfoExist(ctx, key)
@@ -236,16 +335,37 @@ func (s *MicroServiceService) DeleteSchema(ctx context.Context, request *pb.Dele
 }
 
 func (s *MicroServiceService) ModifySchemas(ctx context.Context, request *pb.ModifySchemasRequest) (*pb.ModifySchemasResponse, error) {
+	// 先生成摘要，再校验必填
+	details := make([]*pb.ErrorDetail, 0)
+	for _, schema := range request.Schemas {
+		if respErr := checkSchemaSummary(schema); respErr != nil {
+			details = append(details, respErr.Details...)
+		}
+	}
+	if len(details) != 0 {
+		util.Logger().Errorf(nil, "modify schemas failed: schema summary mismatch. %s", request.ServiceId)
+		return &pb.ModifySchemasResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrSchemaSummaryMismatch,
+				"Schema summary does not match the content.", details...),
+		}, nil
+	}
+
 	err := apt.Validate(request)
 	if err != nil {
 		util.Logger().Errorf(err, "modify schemas failed: invalid params.")
 		return &pb.ModifySchemasResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Invalid request."),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, "Invalid request.", scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 	serviceId := request.ServiceId
 
 	domainProject := util.ParseDomainProject(ctx)
+	if guardErr := serviceUtil.AdmitWrite(ctx, serviceUtil.WRITE_PRIORITY_NORMAL); guardErr != nil {
+		util.Logger().Errorf(guardErr, "modify schemas failed: storage guard rejected. %s", serviceId)
+		return &pb.ModifySchemasResponse{
+			Response: pb.CreateResponse(guardErr.Code, guardErr.Detail),
+		}, nil
+	}
 
 	service, err := serviceUtil.GetService(ctx, domainProject, serviceId)
 	if err != nil {
@@ -260,11 +380,17 @@ func (s *MicroServiceService) ModifySchemas(ctx context.Context, request *pb.Mod
 			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Service does not exist."),
 		}, nil
 	}
+	if frozenErr := serviceUtil.CheckSchemaFreeze(service); frozenErr != nil {
+		util.Logger().Errorf(nil, "modify schemas failed: schemas are frozen. %s", serviceId)
+		return &pb.ModifySchemasResponse{
+			Response: pb.CreateResponse(frozenErr.Code, frozenErr.Detail),
+		}, nil
+	}
 
-	respErr := modifySchemas(ctx, domainProject, service, request.Schemas)
+	revision, respErr := modifySchemas(ctx, domainProject, service, request.Schemas, request.ExpectedRevision)
 	if respErr != nil {
 		resp := &pb.ModifySchemasResponse{
-			Response: pb.CreateResponse(respErr.Code, respErr.Detail),
+			Response: pb.CreateResponseWithDetails(respErr.Code, respErr.Detail, respErr.Details...),
 		}
 		if respErr.StatusCode() == http.StatusInternalServerError {
 			return resp, respErr
@@ -274,6 +400,7 @@ func (s *MicroServiceService) ModifySchemas(ctx context.Context, request *pb.Mod
 
 	return &pb.ModifySchemasResponse{
 		Response: pb.CreateResponse(pb.Response_SUCCESS, "modify schemas info successfully."),
+		Revision: revision,
 	}, nil
 }
 
@@ -310,12 +437,32 @@ func schemasAnalysis(schemas []*pb.Schema, schemasFromDb []*pb.Schema, schemaIds
 	return needUpdateSchemas, needAddSchemas, nonExistSchemaIds
 }
 
-func modifySchemas(ctx context.Context, domainProject string, service *pb.MicroService, schemas []*pb.Schema) *scerr.Error {
+// modifySchemas expectedRevision非空时，仅当服务契约集合的revision与之相等时才提交，
+// 返回提交后契约集合的revision
+func modifySchemas(ctx context.Context, domainProject string, service *pb.MicroService, schemas []*pb.Schema,
+	expectedRevision string) (int64, *scerr.Error) {
 	serviceId := service.ServiceId
-	schemasFromDatabase, err := GetSchemasFromDatabase(ctx, domainProject, serviceId)
+	kvs, err := getSchemaKvsFromDatabase(ctx, domainProject, serviceId)
 	if err != nil {
 		util.Logger().Errorf(nil, "modify schema failed: get schema from database failed, %s", serviceId)
-		return scerr.NewError(scerr.ErrInternal, err.Error())
+		return 0, scerr.NewError(scerr.ErrInternal, err.Error())
+	}
+	schemasFromDatabase := kvsToSchemas(kvs)
+
+	checkRevision := len(expectedRevision) > 0
+	var cmps []registry.CompareOp
+	if checkRevision {
+		expected, _ := strconv.ParseInt(expectedRevision, 10, 64)
+		if current := schemasRevision(kvs); current != expected {
+			util.Logger().Errorf(nil, "modify schemas failed, serviceId %s: revision is %d, expected %s",
+				serviceId, current, expectedRevision)
+			return 0, scerr.NewError(scerr.ErrSchemaRevisionConflict,
+				fmt.Sprintf("Schemas revision is %d, expected %s.", current, expectedRevision))
+		}
+		// 提交时要求读到的契约均未被修改，新增的契约仍不存在
+		for _, kv := range kvs {
+			cmps = append(cmps, registry.OpCmp(registry.CmpModRev(kv.Key), registry.CMP_EQUAL, kv.ModRevision))
+		}
 	}
 
 	needUpdateSchemas, needAddSchemas, nonExistSchemaIds := schemasAnalysis(schemas, schemasFromDatabase, service.Schemas)
@@ -326,30 +473,34 @@ func modifySchemas(ctx context.Context, domainProject string, service *pb.MicroS
 			_, ok, err := plugin.Plugins().Quota().Apply4Quotas(ctx, quota.SchemaQuotaType, domainProject, serviceId, int16(len(schemas)))
 			if err != nil {
 				util.Logger().Errorf(err, "modify schemas info failed, check resource num failed, %s", serviceId)
-				return scerr.NewError(scerr.ErrUnavailableQuota, err.Error())
+				return 0, scerr.NewError(scerr.ErrUnavailableQuota, err.Error())
 			}
 			if !ok {
 				util.Logger().Errorf(err, "modify schemas info failed, reach the max size of schema, %s", serviceId)
-				return scerr.NewError(scerr.ErrNotEnoughQuota, "reach the max size of schema")
+				return 0, scerr.NewError(scerr.ErrNotEnoughQuota, "reach the max size of schema")
 			}
 
 			service.Schemas = nonExistSchemaIds
 			opt, err := serviceUtil.UpdateService(domainProject, serviceId, service)
 			if err != nil {
 				util.Logger().Errorf(err, "modify schemas info failed, update service failed , %s", serviceId)
-				return scerr.NewError(scerr.ErrInternal, err.Error())
+				return 0, scerr.NewError(scerr.ErrInternal, err.Error())
 			}
 			pluginOps = append(pluginOps, opt)
 		} else {
 			if len(nonExistSchemaIds) != 0 {
 				errInfo := fmt.Sprintf("non-exist schemaId %s", util.StringJoin(nonExistSchemaIds, " ,"))
 				util.Logger().Errorf(nil, "modify schemas failed, serviceId %s, %s", serviceId, errInfo)
-				return scerr.NewError(scerr.ErrInvalidParams, errInfo)
+				details := make([]*pb.ErrorDetail, 0, len(nonExistSchemaIds))
+				for _, schemaId := range nonExistSchemaIds {
+					details = append(details, scerr.NewDetail(scerr.ErrUndefinedSchemaId, "schemaId", schemaId))
+				}
+				return 0, scerr.NewError(scerr.ErrInvalidParams, errInfo).WithDetails(details...)
 			}
 			for _, needUpdateSchema := range needUpdateSchemas {
 				exist, err := isExistSchemaSummary(ctx, domainProject, serviceId, needUpdateSchema.SchemaId)
 				if err != nil {
-					return scerr.NewError(scerr.ErrInternal, err.Error())
+					return 0, scerr.NewError(scerr.ErrInternal, err.Error())
 				}
 				if !exist {
 					opts := schemaWithDatabaseOpera(registry.OpPut, domainProject, serviceId, needUpdateSchema)
@@ -379,11 +530,11 @@ func modifySchemas(ctx context.Context, domainProject string, service *pb.MicroS
 			_, ok, err := plugin.Plugins().Quota().Apply4Quotas(ctx, quota.SchemaQuotaType, domainProject, serviceId, int16(quotaSize))
 			if err != nil {
 				util.Logger().Errorf(err, "modify schemas info failed, check resource num failed, %s", serviceId)
-				return scerr.NewError(scerr.ErrUnavailableQuota, err.Error())
+				return 0, scerr.NewError(scerr.ErrUnavailableQuota, err.Error())
 			}
 			if !ok {
 				util.Logger().Errorf(err, "modify schemas info failed, reach the max size of schema, %s", serviceId)
-				return scerr.NewError(scerr.ErrNotEnoughQuota, "reach the max size of schema")
+				return 0, scerr.NewError(scerr.ErrNotEnoughQuota, "reach the max size of schema")
 			}
 		}
 
@@ -403,7 +554,7 @@ func modifySchemas(ctx context.Context, domainProject string, service *pb.MicroS
 			opt, err := serviceUtil.UpdateService(domainProject, serviceId, service)
 			if err != nil {
 				util.Logger().Errorf(err, "modify schema info failed, update service failed , %s", serviceId)
-				return scerr.NewError(scerr.ErrInternal, err.Error())
+				return 0, scerr.NewError(scerr.ErrInternal, err.Error())
 			}
 			pluginOps = append(pluginOps, opt)
 		}
@@ -413,17 +564,48 @@ func modifySchemas(ctx context.Context, domainProject string, service *pb.MicroS
 		util.Logger().Infof("add new schema: serviceId %s, schemaId %s", serviceId, schema.SchemaId)
 		opts := schemaWithDatabaseOpera(registry.OpPut, domainProject, service.ServiceId, schema)
 		pluginOps = append(pluginOps, opts...)
+		if checkRevision {
+			key := apt.GenerateServiceSchemaKey(domainProject, serviceId, schema.SchemaId)
+			cmps = append(cmps, registry.OpCmp(registry.CmpStrModRev(key), registry.CMP_EQUAL, 0))
+		}
 	}
 
+	revision := schemasRevision(kvs)
 	if len(pluginOps) != 0 {
-		err = backend.BatchCommit(ctx, pluginOps)
-		if err != nil {
-			return scerr.NewError(scerr.ErrInternal, err.Error())
+		if checkRevision {
+			// 带条件的修改必须在一个事务内完成
+			if len(pluginOps) > backend.MAX_TXN_NUMBER_ONE_TIME {
+				util.Logger().Errorf(nil, "modify schemas failed, serviceId %s: too many operations %d with expected revision",
+					serviceId, len(pluginOps))
+				return 0, scerr.NewError(scerr.ErrInvalidParams, "Too many schemas to modify with an expected revision.")
+			}
+			resp, err := backend.Registry().TxnWithCmp(ctx, pluginOps, cmps, nil)
+			if err != nil {
+				return 0, scerr.NewError(scerr.ErrInternal, err.Error())
+			}
+			if !resp.Succeeded {
+				util.Logger().Errorf(nil, "modify schemas failed, serviceId %s: schemas changed since revision %s",
+					serviceId, expectedRevision)
+				return 0, scerr.NewError(scerr.ErrSchemaRevisionConflict,
+					fmt.Sprintf("Schemas have been modified since revision %s.", expectedRevision))
+			}
+			revision = schemasRevisionAfterCommit(apt.GenerateServiceSchemaKey(domainProject, serviceId, ""),
+				kvs, pluginOps, resp.Revision)
+		} else {
+			err = backend.BatchCommit(ctx, pluginOps)
+			if err != nil {
+				return 0, scerr.NewError(scerr.ErrInternal, err.Error())
+			}
+			kvs, err = getSchemaKvsFromDatabase(ctx, domainProject, serviceId)
+			if err != nil {
+				return 0, scerr.NewError(scerr.ErrInternal, err.Error())
+			}
+			revision = schemasRevision(kvs)
 		}
 	}
 	util.Logger().Infof("modify schemas info successfully, serviceId %s, schemaIds %s", serviceId, parseSchemaIds(schemas))
 
-	return nil
+	return revision, nil
 }
 
 func parseSchemaIds(schemas []*pb.Schema) string {
@@ -457,6 +639,14 @@ func schemaWithDatabaseOpera(invoke registry.Operation, domainProject string, se
 }
 
 func GetSchemasFromDatabase(ctx context.Context, domainProject string, serviceId string) ([]*pb.Schema, error) {
+	kvs, err := getSchemaKvsFromDatabase(ctx, domainProject, serviceId)
+	if err != nil {
+		return nil, err
+	}
+	return kvsToSchemas(kvs), nil
+}
+
+func getSchemaKvsFromDatabase(ctx context.Context, domainProject string, serviceId string) ([]*mvccpb.KeyValue, error) {
 	key := apt.GenerateServiceSchemaKey(domainProject, serviceId, "")
 	util.Logger().Debugf("key is %s", key)
 	resp, err := backend.Registry().Do(ctx,
@@ -467,8 +657,12 @@ func GetSchemasFromDatabase(ctx context.Context, domainProject string, serviceId
 		util.Logger().Errorf(err, "Get schema of one service failed. %s", serviceId)
 		return nil, err
 	}
-	schemas := make([]*pb.Schema, 0, len(resp.Kvs))
-	for _, kv := range resp.Kvs {
+	return resp.Kvs, nil
+}
+
+func kvsToSchemas(kvs []*mvccpb.KeyValue) []*pb.Schema {
+	schemas := make([]*pb.Schema, 0, len(kvs))
+	for _, kv := range kvs {
 		key := util.BytesToStringWithNoCopy(kv.Key)
 		tmp := strings.Split(key, "/")
 		schemaId := tmp[len(tmp)-1]
@@ -479,15 +673,54 @@ func GetSchemasFromDatabase(ctx context.Context, domainProject string, serviceId
 		}
 		schemas = append(schemas, schemaStruct)
 	}
-	return schemas, nil
+	return schemas
+}
+
+// schemasRevision 服务契约集合的revision，取契约内容key的最大ModRevision，没有契约时为0
+func schemasRevision(kvs []*mvccpb.KeyValue) (rev int64) {
+	for _, kv := range kvs {
+		if kv.ModRevision > rev {
+			rev = kv.ModRevision
+		}
+	}
+	return
+}
+
+// schemasRevisionAfterCommit 推算ops以revision rev提交后契约集合的revision
+func schemasRevisionAfterCommit(prefix string, kvs []*mvccpb.KeyValue, ops []registry.PluginOp, rev int64) int64 {
+	deleted := make(map[string]struct{}, len(ops))
+	for _, op := range ops {
+		key := util.BytesToStringWithNoCopy(op.Key)
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if op.Action == registry.Put {
+			return rev
+		}
+		deleted[key] = struct{}{}
+	}
+	remains := make([]*mvccpb.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		if _, ok := deleted[util.BytesToStringWithNoCopy(kv.Key)]; !ok {
+			remains = append(remains, kv)
+		}
+	}
+	return schemasRevision(remains)
 }
 
 func (s *MicroServiceService) ModifySchema(ctx context.Context, request *pb.ModifySchemaRequest) (*pb.ModifySchemaResponse, error) {
 	domainProject := util.ParseDomainProject(ctx)
+	if guardErr := serviceUtil.AdmitWrite(ctx, serviceUtil.WRITE_PRIORITY_NORMAL); guardErr != nil {
+		util.Logger().Errorf(guardErr, "modify schema failed, serviceId %s, schemaId %s: storage guard rejected.",
+			request.ServiceId, request.SchemaId)
+		return &pb.ModifySchemaResponse{
+			Response: pb.CreateResponse(guardErr.Code, guardErr.Detail),
+		}, nil
+	}
 	respErr := s.canModifySchema(ctx, domainProject, request)
 	if respErr != nil {
 		resp := &pb.ModifySchemaResponse{
-			Response: pb.CreateResponse(respErr.Code, respErr.Detail),
+			Response: pb.CreateResponseWithDetails(respErr.Code, respErr.Detail, respErr.Details...),
 		}
 		if respErr.StatusCode() == http.StatusInternalServerError {
 			return resp, respErr
@@ -503,7 +736,13 @@ func (s *MicroServiceService) ModifySchema(ctx context.Context, request *pb.Modi
 		Summary:  request.Summary,
 		Schema:   request.Schema,
 	}
-	err := s.modifySchema(ctx, serviceId, &schema)
+	if respErr := checkSchemaSummary(&schema); respErr != nil {
+		util.Logger().Errorf(nil, "modify schema failed, serviceId %s, schemaId %s: summary mismatch", serviceId, schemaId)
+		return &pb.ModifySchemaResponse{
+			Response: pb.CreateResponseWithDetails(respErr.Code, respErr.Detail, respErr.Details...),
+		}, nil
+	}
+	revision, err := s.modifySchema(ctx, serviceId, &schema, request.ExpectedRevision)
 	if err != nil {
 		util.Logger().Errorf(err, "modify schema failed, serviceId %s, schemaId %s", serviceId, schemaId)
 		resp := &pb.ModifySchemaResponse{
@@ -518,6 +757,7 @@ func (s *MicroServiceService) ModifySchema(ctx context.Context, request *pb.Modi
 	util.Logger().Infof("modify schema successfully: serviceId %s, schemaId %s.", serviceId, schemaId)
 	return &pb.ModifySchemaResponse{
 		Response: pb.CreateResponse(pb.Response_SUCCESS, "modify schema info success"),
+		Revision: revision,
 	}, nil
 }
 
@@ -531,7 +771,7 @@ func (s *MicroServiceService) canModifySchema(ctx context.Context, domainProject
 	err := apt.Validate(request)
 	if err != nil {
 		util.Logger().Errorf(err, "update schema failed, serviceId %s, schemaId %s: invalid params.", serviceId, schemaId)
-		return scerr.NewError(scerr.ErrInvalidParams, err.Error())
+		return scerr.NewError(scerr.ErrInvalidParams, err.Error()).WithDetails(scerr.ValidationDetails("", err)...)
 	}
 
 	_, ok, err := plugin.Plugins().Quota().Apply4Quotas(ctx, quota.SchemaQuotaType, domainProject, serviceId, 1)
@@ -549,18 +789,23 @@ func (s *MicroServiceService) canModifySchema(ctx context.Context, domainProject
 	return nil
 }
 
-func (s *MicroServiceService) modifySchema(ctx context.Context, serviceId string, schema *pb.Schema) *scerr.Error {
+// modifySchema expectedRevision非空时，仅当契约的revision与之相等时才提交，0表示契约必须不存在
+func (s *MicroServiceService) modifySchema(ctx context.Context, serviceId string, schema *pb.Schema,
+	expectedRevision string) (int64, *scerr.Error) {
 	domainProject := util.ParseDomainProject(ctx)
 	schemaId := schema.SchemaId
 
 	service, err := serviceUtil.GetService(ctx, domainProject, serviceId)
 	if err != nil {
 		util.Logger().Errorf(err, "modify schema failed, serviceId %s, schemaId %s: get service failed.", serviceId, schemaId)
-		return scerr.NewError(scerr.ErrInternal, "get service failed")
+		return 0, scerr.NewError(scerr.ErrInternal, "get service failed")
 	}
 	if service == nil {
 		util.Logger().Errorf(nil, "modify schema failed, serviceId %s, schemaId %s: service not exist", serviceId, schemaId)
-		return scerr.NewError(scerr.ErrServiceNotExists, "service non-exist")
+		return 0, scerr.NewError(scerr.ErrServiceNotExists, "service non-exist")
+	}
+	if frozenErr := serviceUtil.CheckSchemaFreeze(service); frozenErr != nil {
+		return 0, frozenErr
 	}
 
 	util.Logger().Infof("start to modify schema, serviceId  %s, schemaId %s", service.ServiceId, schemaId)
@@ -569,30 +814,33 @@ func (s *MicroServiceService) modifySchema(ctx context.Context, serviceId string
 
 	if service.Environment == pb.ENV_PROD {
 		if len(service.Schemas) != 0 && !isExist {
-			return scerr.NewError(scerr.ErrUndefinedSchemaId, "schemaId non-exist， can't be added, environment is production")
+			return 0, scerr.NewError(scerr.ErrUndefinedSchemaId, "schemaId non-exist， can't be added, environment is production").
+				WithDetails(scerr.NewDetail(scerr.ErrUndefinedSchemaId, "schemaId", schemaId))
 		}
 
 		key := apt.GenerateServiceSchemaKey(domainProject, serviceId, schemaId)
 		respSchema, err := store.Store().Schema().Search(ctx, registry.WithStrKey(key), registry.WithCountOnly())
 		if err != nil {
 			util.Logger().Errorf(err, "modify schema failed, get schema summary failed, %s %s", serviceId, schemaId)
-			return scerr.NewError(scerr.ErrInternal, "get schema summary failed")
+			return 0, scerr.NewError(scerr.ErrInternal, "get schema summary failed")
 		}
 
 		if respSchema.Count != 0 {
 			if len(schema.Summary) == 0 {
 				util.Logger().Errorf(err, "prod mode, schema more exist, can not change, %s %s", serviceId, schemaId)
-				return scerr.NewError(scerr.ErrModifySchemaNotAllow, "schema more exist, can not change, environment is production")
+				return 0, scerr.NewError(scerr.ErrModifySchemaNotAllow, "schema more exist, can not change, environment is production").
+					WithDetails(scerr.NewDetail(scerr.ErrModifySchemaNotAllow, "schemaId", schemaId))
 			}
 
 			exist, err := isExistSchemaSummary(ctx, domainProject, serviceId, schemaId)
 			if err != nil {
 				util.Logger().Errorf(err, "check schema summary is exist failed, serviceId %s, schemaId %s", serviceId, schemaId)
-				return scerr.NewError(scerr.ErrInternal, "check schema summary existence failed")
+				return 0, scerr.NewError(scerr.ErrInternal, "check schema summary existence failed")
 			}
 			if exist {
 				util.Logger().Errorf(err, "prod mode, schema more exist, can not change, %s %s", serviceId, schemaId)
-				return scerr.NewError(scerr.ErrModifySchemaNotAllow, "schema more exist, can not change, environment is production")
+				return 0, scerr.NewError(scerr.ErrModifySchemaNotAllow, "schema more exist, can not change, environment is production").
+					WithDetails(scerr.NewDetail(scerr.ErrModifySchemaNotAllow, "schemaId", schemaId))
 			}
 		}
 
@@ -601,7 +849,7 @@ func (s *MicroServiceService) modifySchema(ctx context.Context, serviceId string
 			opt, err := serviceUtil.UpdateService(domainProject, serviceId, service)
 			if err != nil {
 				util.Logger().Errorf(err, "modify schema failed, update service failed , serviceId %s, schemaId %s", serviceId, schemaId)
-				return scerr.NewError(scerr.ErrInternal, "update service failed")
+				return 0, scerr.NewError(scerr.ErrInternal, "update service failed")
 			}
 			pluginOps = append(pluginOps, opt)
 		}
@@ -611,7 +859,7 @@ func (s *MicroServiceService) modifySchema(ctx context.Context, serviceId string
 			opt, err := serviceUtil.UpdateService(domainProject, serviceId, service)
 			if err != nil {
 				util.Logger().Errorf(err, "modify schema failed, update service failed , serviceId %s, schemaId %s", serviceId, schemaId)
-				return scerr.NewError(scerr.ErrInternal, "update service failed")
+				return 0, scerr.NewError(scerr.ErrInternal, "update service failed")
 			}
 			pluginOps = append(pluginOps, opt)
 		}
@@ -620,10 +868,50 @@ func (s *MicroServiceService) modifySchema(ctx context.Context, serviceId string
 	opts := CommitSchemaInfo(domainProject, serviceId, schema)
 	pluginOps = append(pluginOps, opts...)
 
-	_, err = backend.Registry().Txn(ctx, pluginOps)
+	var cmps []registry.CompareOp
+	if len(expectedRevision) > 0 {
+		expected, _ := strconv.ParseInt(expectedRevision, 10, 64)
+		key := apt.GenerateServiceSchemaKey(domainProject, serviceId, schemaId)
+		cmps = append(cmps, registry.OpCmp(registry.CmpStrModRev(key), registry.CMP_EQUAL, expected))
+	}
+	resp, err := backend.Registry().TxnWithCmp(ctx, pluginOps, cmps, nil)
 	if err != nil {
 		util.Logger().Errorf(err, "commit update schema failed, serviceId %s, schemaId %s", serviceId, schemaId)
-		return scerr.NewError(scerr.ErrInternal, "commit update schema failed")
+		return 0, scerr.NewError(scerr.ErrInternal, "commit update schema failed")
+	}
+	if !resp.Succeeded {
+		util.Logger().Errorf(nil, "modify schema failed, serviceId %s, schemaId %s: schema changed since revision %s",
+			serviceId, schemaId, expectedRevision)
+		return 0, scerr.NewError(scerr.ErrSchemaRevisionConflict,
+			fmt.Sprintf("Schema has been modified since revision %s.", expectedRevision))
+	}
+	return resp.Revision, nil
+}
+
+// computeSchemaSummary 契约摘要，与java chassis一致取内容的sha256
+func computeSchemaSummary(content string) string {
+	sum := sha256.Sum256(util.StringToBytesWithNoCopy(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkSchemaSummary 未携带摘要时按内容生成，开启校验时摘要须与内容一致
+func checkSchemaSummary(schema *pb.Schema) *scerr.Error {
+	if len(schema.Schema) == 0 {
+		return nil
+	}
+	if len(schema.Summary) == 0 {
+		if apt.ServerInfo.Config.SchemaSummaryGenerate {
+			schema.Summary = computeSchemaSummary(schema.Schema)
+		}
+		return nil
+	}
+	if !apt.ServerInfo.Config.SchemaSummaryVerify {
+		return nil
+	}
+	if expected := computeSchemaSummary(schema.Schema); schema.Summary != expected {
+		return scerr.NewError(scerr.ErrSchemaSummaryMismatch,
+			fmt.Sprintf("Summary of schema %s does not match the content, expected %s.", schema.SchemaId, expected)).
+			WithDetails(scerr.NewDetail(scerr.ErrSchemaSummaryMismatch, "schemaId", schema.SchemaId))
 	}
 	return nil
 }
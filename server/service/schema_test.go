@@ -17,6 +17,9 @@
 package service_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
 	"github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/quota/buildin"
@@ -1092,4 +1095,560 @@ var _ = Describe("'Schema' service", func() {
 			})
 		})
 	})
+
+	Describe("execute 'shared definition' operation", func() {
+		var (
+			serviceId string
+		)
+
+		It("should be passed", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "default",
+					ServiceName: "shared_definition_service",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Schemas:     []string{"bundle.yaml", "bundle.json"},
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreateService.ServiceId
+
+			resp, err := serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "bundle.yaml",
+				Schema: "swagger: '2.0'\npaths:\n  /users:\n    get:\n      responses:\n        200:\n" +
+					"          schema:\n            $ref: 'shared:User'\n",
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			resp, err = serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "bundle.json",
+				Schema:    `{"swagger":"2.0","definitions":{"Users":{"type":"array","items":{"$ref":"shared:User"}}}}`,
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				By("invalid name")
+				resp, err := serviceResource.ModifySharedDefinition(getContext(), &pb.ModifySharedDefinitionRequest{
+					Name:    invalidSchemaId,
+					Content: "type: object",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("content is not an object")
+				resp, err = serviceResource.ModifySharedDefinition(getContext(), &pb.ModifySharedDefinitionRequest{
+					Name:    "User",
+					Content: "[1, 2]",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("definition does not exist")
+				respDel, err := serviceResource.DeleteSharedDefinition(getContext(), &pb.DeleteSharedDefinitionRequest{
+					Name: "NotExist",
+				})
+				Expect(err).To(BeNil())
+				Expect(respDel.Response.Code).To(Equal(scerr.ErrSharedDefinitionNotExists))
+
+				By("referenced definition does not exist")
+				respGet, err := serviceResource.GetSchemaInfo(getContext(), &pb.GetSchemaRequest{
+					ServiceId: serviceId,
+					SchemaId:  "bundle.yaml",
+					Resolve:   true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(scerr.ErrSharedDefinitionNotExists))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				resp, err := serviceResource.ModifySharedDefinition(getContext(), &pb.ModifySharedDefinitionRequest{
+					Name:    "User",
+					Content: "type: object\nproperties:\n  address:\n    $ref: 'shared:Address'\n",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				resp, err = serviceResource.ModifySharedDefinition(getContext(), &pb.ModifySharedDefinitionRequest{
+					Name:    "Address",
+					Content: `{"type": "string"}`,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respList, err := serviceResource.GetSharedDefinitions(getContext(), &pb.GetSharedDefinitionsRequest{})
+				Expect(err).To(BeNil())
+				Expect(respList.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(respList.Definitions)).To(Equal(2))
+
+				By("unresolved schema")
+				respGet, err := serviceResource.GetSchemaInfo(getContext(), &pb.GetSchemaRequest{
+					ServiceId: serviceId,
+					SchemaId:  "bundle.yaml",
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGet.Schema).To(ContainSubstring("shared:User"))
+
+				By("resolve yaml schema")
+				respGet, err = serviceResource.GetSchemaInfo(getContext(), &pb.GetSchemaRequest{
+					ServiceId: serviceId,
+					SchemaId:  "bundle.yaml",
+					Resolve:   true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGet.Schema).NotTo(ContainSubstring("shared:"))
+				Expect(respGet.Schema).To(ContainSubstring("#/definitions/User"))
+				Expect(respGet.Schema).To(ContainSubstring("#/definitions/Address"))
+				Expect(respGet.Schema).To(ContainSubstring("Address:"))
+
+				By("resolve json schema")
+				respGet, err = serviceResource.GetSchemaInfo(getContext(), &pb.GetSchemaRequest{
+					ServiceId: serviceId,
+					SchemaId:  "bundle.json",
+					Resolve:   true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(respGet.Schema).To(HavePrefix("{"))
+				Expect(respGet.Schema).To(ContainSubstring(`"Users":`))
+				Expect(respGet.Schema).To(ContainSubstring(`"User":`))
+				Expect(respGet.Schema).NotTo(ContainSubstring("shared:"))
+
+				respDel, err := serviceResource.DeleteSharedDefinition(getContext(), &pb.DeleteSharedDefinitionRequest{
+					Name: "Address",
+				})
+				Expect(err).To(BeNil())
+				Expect(respDel.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respList, err = serviceResource.GetSharedDefinitions(getContext(), &pb.GetSharedDefinitionsRequest{})
+				Expect(err).To(BeNil())
+				Expect(len(respList.Definitions)).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("execute 'summary' operation", func() {
+		var (
+			serviceId string
+			content   = "summary schema content"
+		)
+		sum := sha256.Sum256([]byte(content))
+		expected := hex.EncodeToString(sum[:])
+
+		It("should be passed", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "summary_schema_group",
+					ServiceName: "summary_schema_service",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreateService.ServiceId
+
+			core.ServerInfo.Config.SchemaSummaryGenerate = true
+			core.ServerInfo.Config.SchemaSummaryVerify = true
+			defer func() {
+				core.ServerInfo.Config.SchemaSummaryGenerate = false
+				core.ServerInfo.Config.SchemaSummaryVerify = false
+			}()
+
+			By("generate summary when absent")
+			resp, err := serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "summary.generate",
+				Schema:    content,
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			respGet, err := serviceResource.GetSchemaInfo(getContext(), &pb.GetSchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "summary.generate",
+			})
+			Expect(err).To(BeNil())
+			Expect(respGet.Response.Code).To(Equal(pb.Response_SUCCESS))
+			Expect(respGet.SchemaSummary).To(Equal(expected))
+
+			By("matched summary")
+			resp, err = serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "summary.verify",
+				Schema:    content,
+				Summary:   expected,
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			By("mismatched summary")
+			resp, err = serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "summary.verify",
+				Schema:    content,
+				Summary:   "wrong_summary",
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(scerr.ErrSchemaSummaryMismatch))
+			Expect(resp.Response.Message).To(ContainSubstring(expected))
+
+			respModify, err := serviceResource.ModifySchemas(getContext(), &pb.ModifySchemasRequest{
+				ServiceId: serviceId,
+				Schemas: []*pb.Schema{
+					{SchemaId: "summary.batch.ok", Schema: content},
+					{SchemaId: "summary.batch.wrong", Schema: content, Summary: "wrong_summary"},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respModify.Response.Code).To(Equal(scerr.ErrSchemaSummaryMismatch))
+			Expect(len(respModify.Response.Details)).To(Equal(1))
+			Expect(respModify.Response.Details[0].Reason).To(Equal("summary.batch.wrong"))
+
+			By("generate summaries in batch")
+			respModify, err = serviceResource.ModifySchemas(getContext(), &pb.ModifySchemasRequest{
+				ServiceId: serviceId,
+				Schemas: []*pb.Schema{
+					{SchemaId: "summary.batch.ok", Schema: content},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respModify.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			respGet, err = serviceResource.GetSchemaInfo(getContext(), &pb.GetSchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "summary.batch.ok",
+			})
+			Expect(err).To(BeNil())
+			Expect(respGet.SchemaSummary).To(Equal(expected))
+		})
+	})
+
+	Describe("execute 'revision' operation", func() {
+		var (
+			serviceId string
+		)
+
+		It("should be passed", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "revision_schema_group",
+					ServiceName: "revision_schema_service",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId = respCreateService.ServiceId
+
+			By("invalid expected revision")
+			resp, err := serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId:        serviceId,
+				SchemaId:         "revision.one",
+				Schema:           "revision schema",
+				ExpectedRevision: "abc",
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+			By("create schema when not exist")
+			resp, err = serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId:        serviceId,
+				SchemaId:         "revision.one",
+				Schema:           "revision schema",
+				ExpectedRevision: "0",
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+			Expect(resp.Revision).ToNot(Equal(int64(0)))
+			revision := resp.Revision
+
+			respGet, err := serviceResource.GetSchemaInfo(getContext(), &pb.GetSchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "revision.one",
+			})
+			Expect(err).To(BeNil())
+			Expect(respGet.Revision).To(Equal(revision))
+
+			By("schema already exists")
+			resp, err = serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId:        serviceId,
+				SchemaId:         "revision.one",
+				Schema:           "revision schema changed",
+				ExpectedRevision: "0",
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(scerr.ErrSchemaRevisionConflict))
+
+			By("matched revision")
+			resp, err = serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId:        serviceId,
+				SchemaId:         "revision.one",
+				Schema:           "revision schema changed",
+				ExpectedRevision: strconv.FormatInt(revision, 10),
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+			Expect(resp.Revision > revision).To(BeTrue())
+
+			By("stale revision")
+			resp, err = serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId:        serviceId,
+				SchemaId:         "revision.one",
+				Schema:           "revision schema stale",
+				ExpectedRevision: strconv.FormatInt(revision, 10),
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Response.Code).To(Equal(scerr.ErrSchemaRevisionConflict))
+
+			By("batch modify with current revision")
+			respAll, err := serviceResource.GetAllSchemaInfo(getContext(), &pb.GetAllSchemaRequest{
+				ServiceId: serviceId,
+			})
+			Expect(err).To(BeNil())
+			Expect(respAll.Response.Code).To(Equal(pb.Response_SUCCESS))
+			Expect(respAll.Revision > revision).To(BeTrue())
+			revision = respAll.Revision
+
+			respModify, err := serviceResource.ModifySchemas(getContext(), &pb.ModifySchemasRequest{
+				ServiceId: serviceId,
+				Schemas: []*pb.Schema{
+					{SchemaId: "revision.one", Schema: "revision schema batch", Summary: "batch"},
+					{SchemaId: "revision.two", Schema: "revision schema batch", Summary: "batch"},
+				},
+				ExpectedRevision: strconv.FormatInt(revision, 10),
+			})
+			Expect(err).To(BeNil())
+			Expect(respModify.Response.Code).To(Equal(pb.Response_SUCCESS))
+			Expect(respModify.Revision > revision).To(BeTrue())
+
+			respAll, err = serviceResource.GetAllSchemaInfo(getContext(), &pb.GetAllSchemaRequest{
+				ServiceId:  serviceId,
+				WithSchema: true,
+			})
+			Expect(err).To(BeNil())
+			Expect(respAll.Revision).To(Equal(respModify.Revision))
+
+			By("batch modify with stale revision")
+			respModify, err = serviceResource.ModifySchemas(getContext(), &pb.ModifySchemasRequest{
+				ServiceId: serviceId,
+				Schemas: []*pb.Schema{
+					{SchemaId: "revision.one", Schema: "revision schema stale", Summary: "stale"},
+				},
+				ExpectedRevision: strconv.FormatInt(revision, 10),
+			})
+			Expect(err).To(BeNil())
+			Expect(respModify.Response.Code).To(Equal(scerr.ErrSchemaRevisionConflict))
+
+			By("batch delete keeps the revision of remaining schemas")
+			respModify, err = serviceResource.ModifySchemas(getContext(), &pb.ModifySchemasRequest{
+				ServiceId: serviceId,
+				Schemas: []*pb.Schema{
+					{SchemaId: "revision.one", Schema: "revision schema batch", Summary: "batch"},
+				},
+				ExpectedRevision: strconv.FormatInt(respAll.Revision, 10),
+			})
+			Expect(err).To(BeNil())
+			Expect(respModify.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			respAll, err = serviceResource.GetAllSchemaInfo(getContext(), &pb.GetAllSchemaRequest{
+				ServiceId: serviceId,
+			})
+			Expect(err).To(BeNil())
+			Expect(respAll.Revision).To(Equal(respModify.Revision))
+		})
+	})
+
+	Describe("execute 'visibility' operation", func() {
+		It("should be restricted", func() {
+			create := func(name, version, visibility string) string {
+				service := &pb.MicroService{
+					AppId:       "visibility_group",
+					ServiceName: name,
+					Version:     version,
+					Level:       "BACK",
+					Schemas:     []string{"visibility.schema"},
+					Status:      pb.MS_UP,
+				}
+				if len(visibility) > 0 {
+					service.Properties = map[string]string{pb.PROP_SCHEMA_VISIBILITY: visibility}
+				}
+				resp, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{Service: service})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				return resp.ServiceId
+			}
+			providerId := create("visibility_provider", "1.0.0", pb.SCHEMA_VISIBILITY_CONSUMERS)
+			ownerId := create("visibility_provider", "2.0.0", "")
+			consumerId := create("visibility_consumer", "1.0.0", "")
+			otherId := create("visibility_other", "1.0.0", "")
+
+			respModify, err := serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId: providerId,
+				SchemaId:  "visibility.schema",
+				Schema:    "visibility schema",
+			})
+			Expect(err).To(BeNil())
+			Expect(respModify.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			respDep, err := serviceResource.CreateDependenciesForMicroServices(getContext(), &pb.CreateDependenciesRequest{
+				Dependencies: []*pb.ConsumerDependency{
+					{
+						Consumer: &pb.DependencyKey{AppId: "visibility_group", ServiceName: "visibility_consumer", Version: "1.0.0"},
+						Providers: []*pb.DependencyKey{
+							{AppId: "visibility_group", ServiceName: "visibility_provider", Version: "1.0.0"},
+						},
+					},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respDep.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			get := func(consumer string) int32 {
+				resp, err := serviceResource.GetSchemaInfo(getContext(), &pb.GetSchemaRequest{
+					ServiceId:         providerId,
+					SchemaId:          "visibility.schema",
+					ConsumerServiceId: consumer,
+				})
+				Expect(err).To(BeNil())
+				return resp.Response.Code
+			}
+			Expect(get(providerId)).To(Equal(pb.Response_SUCCESS))
+			Expect(get(ownerId)).To(Equal(pb.Response_SUCCESS))
+			Expect(get(consumerId)).To(Equal(pb.Response_SUCCESS))
+			Expect(get(otherId)).To(Equal(scerr.ErrPermissionDeny))
+			Expect(get("")).To(Equal(scerr.ErrPermissionDeny))
+
+			By("owners only")
+			respProps, err := serviceResource.UpdateProperties(getContext(), &pb.UpdateServicePropsRequest{
+				ServiceId:  providerId,
+				Properties: map[string]string{pb.PROP_SCHEMA_VISIBILITY: pb.SCHEMA_VISIBILITY_OWNERS},
+			})
+			Expect(err).To(BeNil())
+			Expect(respProps.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			Expect(get(ownerId)).To(Equal(pb.Response_SUCCESS))
+			Expect(get(consumerId)).To(Equal(scerr.ErrPermissionDeny))
+
+			respAll, err := serviceResource.GetAllSchemaInfo(getContext(), &pb.GetAllSchemaRequest{
+				ServiceId:         providerId,
+				WithSchema:        true,
+				ConsumerServiceId: consumerId,
+			})
+			Expect(err).To(BeNil())
+			Expect(respAll.Response.Code).To(Equal(scerr.ErrPermissionDeny))
+
+			respAll, err = serviceResource.GetAllSchemaInfo(getContext(), &pb.GetAllSchemaRequest{
+				ServiceId:         providerId,
+				WithSchema:        true,
+				ConsumerServiceId: providerId,
+			})
+			Expect(err).To(BeNil())
+			Expect(respAll.Response.Code).To(Equal(pb.Response_SUCCESS))
+			Expect(len(respAll.Schema)).To(Equal(1))
+		})
+	})
+
+	Describe("execute 'freeze' operation", func() {
+		It("should reject the schema changes while frozen", func() {
+			respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:        "freeze_group",
+					ServiceName:  "freeze_service",
+					Version:      "1.0.0",
+					Level:        "BACK",
+					Schemas:      []string{"freeze.schema"},
+					Status:       pb.MS_UP,
+					SchemaFreeze: &pb.SchemaFreeze{Reason: "ignored"},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreate.Response.Code).To(Equal(pb.Response_SUCCESS))
+			serviceId := respCreate.ServiceId
+
+			respGet, err := serviceResource.GetOne(getContext(), &pb.GetServiceRequest{ServiceId: serviceId})
+			Expect(err).To(BeNil())
+			Expect(respGet.Service.SchemaFreeze).To(BeNil())
+
+			respModify, err := serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "freeze.schema",
+				Schema:    "freeze schema",
+			})
+			Expect(err).To(BeNil())
+			Expect(respModify.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			respFreeze, err := serviceResource.UpdateSchemaFreeze(getContext(), &pb.UpdateSchemaFreezeRequest{
+				ServiceId: serviceId,
+				Freeze:    &pb.SchemaFreeze{Reason: "release stabilization"},
+			})
+			Expect(err).To(BeNil())
+			Expect(respFreeze.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			respGet, err = serviceResource.GetOne(getContext(), &pb.GetServiceRequest{ServiceId: serviceId})
+			Expect(err).To(BeNil())
+			Expect(respGet.Service.SchemaFreeze).NotTo(BeNil())
+			Expect(respGet.Service.SchemaFreeze.Reason).To(Equal("release stabilization"))
+			Expect(respGet.Service.SchemaFreeze.Timestamp).NotTo(BeEmpty())
+
+			respModify, err = serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "freeze.schema",
+				Schema:    "freeze schema changed",
+			})
+			Expect(err).To(BeNil())
+			Expect(respModify.Response.Code).To(Equal(scerr.ErrSchemaFrozen))
+
+			respModifys, err := serviceResource.ModifySchemas(getContext(), &pb.ModifySchemasRequest{
+				ServiceId: serviceId,
+				Schemas: []*pb.Schema{
+					{SchemaId: "freeze.schema", Schema: "freeze schema changed"},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respModifys.Response.Code).To(Equal(scerr.ErrSchemaFrozen))
+
+			respDelete, err := serviceResource.DeleteSchema(getContext(), &pb.DeleteSchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "freeze.schema",
+			})
+			Expect(err).To(BeNil())
+			Expect(respDelete.Response.Code).To(Equal(scerr.ErrSchemaFrozen))
+
+			respFreeze, err = serviceResource.UpdateSchemaFreeze(getContext(), &pb.UpdateSchemaFreezeRequest{
+				ServiceId: serviceId,
+			})
+			Expect(err).To(BeNil())
+			Expect(respFreeze.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			respModify, err = serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+				ServiceId: serviceId,
+				SchemaId:  "freeze.schema",
+				Schema:    "freeze schema changed",
+			})
+			Expect(err).To(BeNil())
+			Expect(respModify.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+			respFreeze, err = serviceResource.UpdateSchemaFreeze(getContext(), &pb.UpdateSchemaFreezeRequest{
+				ServiceId: "notexistservice",
+			})
+			Expect(err).To(BeNil())
+			Expect(respFreeze.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+		})
+	})
 })
@@ -21,11 +21,17 @@ import (
 	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
 	"github.com/apache/incubator-servicecomb-service-center/server/mux"
 	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
 	"golang.org/x/net/context"
+	"strconv"
+	"time"
 )
 
+// 未指定window时，统计最近24小时内的访问者
+const DEFAULT_ACCESSORS_WINDOW = 24 * time.Hour
+
 func (s *MicroServiceService) AddDependenciesForMicroServices(ctx context.Context, in *pb.AddDependenciesRequest) (*pb.AddDependenciesResponse, error) {
 	resp, err := s.AddOrUpdateDependencies(ctx, in.Dependencies, false)
 	return &pb.AddDependenciesResponse{
@@ -72,14 +78,15 @@ func (s *MicroServiceService) AddOrUpdateDependencies(ctx context.Context, depen
 		}
 		if len(consumerId) == 0 {
 			util.Logger().Errorf(nil, "create dependency failed, consumer %s: consumer not exist.", consumerFlag)
-			return pb.CreateResponse(scerr.ErrServiceNotExists, "Get consumer's serviceId is empty."), nil
+			return pb.CreateResponseWithDetails(scerr.ErrServiceNotExists, "Get consumer's serviceId is empty.",
+				scerr.NewDetail(scerr.ErrServiceNotExists, "consumer", consumerFlag)), nil
 		}
 
 		//建立依赖规则，用于维护依赖关系
 		lock, err := mux.Lock(mux.GLOBAL_LOCK)
 		if err != nil {
 			util.Logger().Errorf(err, "create dependency failed, consumer %s: create lock failed.", consumerFlag)
-			return pb.CreateResponse(scerr.ErrInternal, err.Error()), err
+			return pb.CreateResponse(mux.ErrorCode(err), err.Error()), err
 		}
 
 		var dep serviceUtil.Dependency
@@ -108,7 +115,7 @@ func (s *MicroServiceService) GetProviderDependencies(ctx context.Context, in *p
 	if err != nil {
 		util.Logger().Errorf(err, "GetProviderDependencies failed for validating parameters failed.")
 		return &pb.GetProDependenciesResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 	domainProject := util.ParseDomainProject(ctx)
@@ -122,7 +129,8 @@ func (s *MicroServiceService) GetProviderDependencies(ctx context.Context, in *p
 	if provider == nil {
 		util.Logger().Errorf(err, "GetProviderDependencies failed for provider does not exist, %s.", providerServiceId)
 		return &pb.GetProDependenciesResponse{
-			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Provider does not exist"),
+			Response: pb.CreateResponseWithDetails(scerr.ErrServiceNotExists, "Provider does not exist",
+				scerr.NewDetail(scerr.ErrServiceNotExists, "serviceId", providerServiceId)),
 		}, nil
 	}
 
@@ -134,10 +142,16 @@ func (s *MicroServiceService) GetProviderDependencies(ctx context.Context, in *p
 			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
 		}, err
 	}
+	// 流量数据由外部上报，查询失败时不影响依赖查询
+	traffics, err := serviceUtil.GetProviderTraffics(ctx, domainProject, providerServiceId)
+	if err != nil {
+		util.Logger().Errorf(err, "GetProviderDependencies get traffics failed, %s.", providerServiceId)
+	}
 	util.Logger().Debugf("GetProviderDependencies successfully, providerId is %s.", in.ServiceId)
 	return &pb.GetProDependenciesResponse{
 		Response:  pb.CreateResponse(pb.Response_SUCCESS, "Get all consumers successful."),
 		Consumers: services,
+		Traffics:  traffics,
 	}, nil
 }
 
@@ -146,7 +160,7 @@ func (s *MicroServiceService) GetConsumerDependencies(ctx context.Context, in *p
 	if err != nil {
 		util.Logger().Errorf(err, "GetConsumerDependencies failed for validating parameters failed.")
 		return &pb.GetConDependenciesResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 	consumerId := in.ServiceId
@@ -162,7 +176,8 @@ func (s *MicroServiceService) GetConsumerDependencies(ctx context.Context, in *p
 	if consumer == nil {
 		util.Logger().Errorf(err, "GetConsumerDependencies failed for consumer does not exist, %s.", consumerId)
 		return &pb.GetConDependenciesResponse{
-			Response: pb.CreateResponse(scerr.ErrServiceNotExists, "Consumer does not exist"),
+			Response: pb.CreateResponseWithDetails(scerr.ErrServiceNotExists, "Consumer does not exist",
+				scerr.NewDetail(scerr.ErrServiceNotExists, "serviceId", consumerId)),
 		}, nil
 	}
 
@@ -175,9 +190,263 @@ func (s *MicroServiceService) GetConsumerDependencies(ctx context.Context, in *p
 		}, err
 	}
 
+	traffics, err := serviceUtil.GetConsumerTraffics(ctx, domainProject, consumerId)
+	if err != nil {
+		util.Logger().Errorf(err, "GetConsumerDependencies get traffics failed, %s.", consumerId)
+	}
 	util.Logger().Debugf("GetConsumerDependencies successfully, consumerId is %s.", consumerId)
 	return &pb.GetConDependenciesResponse{
 		Response:  pb.CreateResponse(pb.Response_SUCCESS, "Get all providers successfully."),
 		Providers: services,
+		Traffics:  traffics,
+	}, nil
+}
+
+func (s *MicroServiceService) GetDependencyDiff(ctx context.Context, in *pb.GetDependenciesRequest) (*pb.GetDependencyDiffResponse, error) {
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "GetDependencyDiff failed for validating parameters failed.")
+		return &pb.GetDependencyDiffResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+	consumerId := in.ServiceId
+	domainProject := util.ParseDomainProject(ctx)
+
+	consumer, err := serviceUtil.GetService(ctx, domainProject, consumerId)
+	if err != nil {
+		util.Logger().Errorf(err, "GetDependencyDiff failed for get consumer failed.")
+		return &pb.GetDependencyDiffResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if consumer == nil {
+		util.Logger().Errorf(err, "GetDependencyDiff failed for consumer does not exist, %s.", consumerId)
+		return &pb.GetDependencyDiffResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrServiceNotExists, "Consumer does not exist",
+				scerr.NewDetail(scerr.ErrServiceNotExists, "serviceId", consumerId)),
+		}, nil
+	}
+
+	unused, undeclared, err := serviceUtil.DependencyDiff(ctx, domainProject, consumer)
+	if err != nil {
+		util.Logger().Errorf(err, "GetDependencyDiff failed for compare dependencies failed.")
+		return &pb.GetDependencyDiffResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	util.Logger().Debugf("GetDependencyDiff successfully, consumerId is %s.", consumerId)
+	return &pb.GetDependencyDiffResponse{
+		Response:   pb.CreateResponse(pb.Response_SUCCESS, "Get dependency diff successfully."),
+		Unused:     unused,
+		Undeclared: undeclared,
+	}, nil
+}
+
+func (s *MicroServiceService) GetDependencyHistory(ctx context.Context, in *pb.GetDependencyHistoryRequest) (*pb.GetDependencyHistoryResponse, error) {
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "GetDependencyHistory failed for validating parameters failed.")
+		return &pb.GetDependencyHistoryResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+	if (len(in.Revision) == 0) == (len(in.Timestamp) == 0) {
+		util.Logger().Errorf(nil, "GetDependencyHistory failed for either revision or timestamp is required.")
+		return &pb.GetDependencyHistoryResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, "Either revision or timestamp is required.",
+				scerr.NewDetail(scerr.ErrInvalidParams, "revision", "either revision or timestamp is required")),
+		}, nil
+	}
+	consumerId := in.ServiceId
+	domainProject := util.ParseDomainProject(ctx)
+
+	var rev int64
+	if len(in.Revision) > 0 {
+		rev, _ = strconv.ParseInt(in.Revision, 10, 64)
+	} else {
+		ts, _ := strconv.ParseInt(in.Timestamp, 10, 64)
+		var ok bool
+		rev, ok = serviceUtil.GetRevisionTimeline().Lookup(time.Unix(ts, 0))
+		if !ok {
+			util.Logger().Errorf(nil, "GetDependencyHistory failed for timestamp %s is out of the history, consumer %s.",
+				in.Timestamp, consumerId)
+			return &pb.GetDependencyHistoryResponse{
+				Response: pb.CreateResponseWithDetails(scerr.ErrRevisionCompacted, "Timestamp is out of the history.",
+					scerr.NewDetail(scerr.ErrRevisionCompacted, "timestamp", in.Timestamp)),
+			}, nil
+		}
+	}
+	if rev <= 0 {
+		return &pb.GetDependencyHistoryResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, "Invalid revision.",
+				scerr.NewDetail(scerr.ErrInvalidParams, "revision", "revision must be positive")),
+		}, nil
+	}
+
+	consumer, deps, err := serviceUtil.GetDependencyRuleAt(ctx, domainProject, consumerId, rev)
+	switch err {
+	case nil:
+	case registry.ErrRevisionCompacted, registry.ErrFutureRevision:
+		util.Logger().Errorf(err, "GetDependencyHistory failed, consumer %s, revision %d.", consumerId, rev)
+		return &pb.GetDependencyHistoryResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrRevisionCompacted, err.Error(),
+				scerr.NewDetail(scerr.ErrRevisionCompacted, "revision", strconv.FormatInt(rev, 10))),
+		}, nil
+	default:
+		util.Logger().Errorf(err, "GetDependencyHistory failed for get dependency rule failed, consumer %s, revision %d.",
+			consumerId, rev)
+		return &pb.GetDependencyHistoryResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+	if consumer == nil {
+		util.Logger().Errorf(nil, "GetDependencyHistory failed for consumer does not exist at revision %d, %s.", rev, consumerId)
+		return &pb.GetDependencyHistoryResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrServiceNotExists, "Consumer does not exist at the revision",
+				scerr.NewDetail(scerr.ErrServiceNotExists, "serviceId", consumerId)),
+		}, nil
+	}
+
+	util.Logger().Debugf("GetDependencyHistory successfully, consumerId is %s, revision is %d.", consumerId, rev)
+	return &pb.GetDependencyHistoryResponse{
+		Response:  pb.CreateResponse(pb.Response_SUCCESS, "Get dependency history successfully."),
+		Revision:  rev,
+		Consumer:  consumer,
+		Providers: deps.Dependency,
 	}, nil
 }
+
+func (s *MicroServiceService) GetProviderAccessors(ctx context.Context, in *pb.GetProviderAccessorsRequest) (*pb.GetProviderAccessorsResponse, error) {
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "GetProviderAccessors failed for validating parameters failed.")
+		return &pb.GetProviderAccessorsResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+	window := DEFAULT_ACCESSORS_WINDOW
+	if len(in.Window) > 0 {
+		window, err = time.ParseDuration(in.Window)
+		if err != nil || window <= 0 {
+			util.Logger().Errorf(err, "GetProviderAccessors failed for invalid window %s.", in.Window)
+			return &pb.GetProviderAccessorsResponse{
+				Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, "Invalid window.",
+					scerr.NewDetail(scerr.ErrInvalidParams, "window", "window must be a positive duration, e.g. 24h")),
+			}, nil
+		}
+	}
+	providerId := in.ProviderServiceId
+	domainProject := util.ParseDomainProject(ctx)
+
+	if !serviceUtil.ServiceExist(ctx, domainProject, providerId) {
+		util.Logger().Errorf(nil, "GetProviderAccessors failed for provider does not exist, %s.", providerId)
+		return &pb.GetProviderAccessorsResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrServiceNotExists, "Provider does not exist",
+				scerr.NewDetail(scerr.ErrServiceNotExists, "serviceId", providerId)),
+		}, nil
+	}
+
+	accessors, err := serviceUtil.GetProviderAccessors(ctx, domainProject, providerId, time.Now().Add(-window))
+	if err != nil {
+		util.Logger().Errorf(err, "GetProviderAccessors failed for get dependency usages failed.")
+		return &pb.GetProviderAccessorsResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	util.Logger().Debugf("GetProviderAccessors successfully, providerId is %s.", providerId)
+	return &pb.GetProviderAccessorsResponse{
+		Response:  pb.CreateResponse(pb.Response_SUCCESS, "Get provider accessors successfully."),
+		Accessors: accessors,
+	}, nil
+}
+
+// ReportDependencyTraffic 保存外部遥测系统上报的依赖流量，消费者或提供者不存在的流量被忽略，
+// 返回保存的条数；ttl(秒)为0时使用dependency_traffic_ttl
+func (s *MicroServiceService) ReportDependencyTraffic(ctx context.Context, in *pb.ReportDependencyTrafficRequest) (*pb.ReportDependencyTrafficResponse, error) {
+	if in == nil {
+		util.Logger().Errorf(nil, "ReportDependencyTraffic failed for invalid params.")
+		return &pb.ReportDependencyTrafficResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "ReportDependencyTraffic failed for validating parameters failed.")
+		return &pb.ReportDependencyTrafficResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+	for _, t := range in.Traffics {
+		if err := serviceUtil.CheckDependencyTraffic(t); err != nil {
+			util.Logger().Errorf(err, "ReportDependencyTraffic failed for invalid traffic.")
+			return &pb.ReportDependencyTrafficResponse{
+				Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			}, nil
+		}
+	}
+	domainProject := util.ParseDomainProject(ctx)
+
+	accepted := make([]*pb.DependencyTraffic, 0, len(in.Traffics))
+	for _, t := range in.Traffics {
+		if !serviceUtil.ServiceExist(ctx, domainProject, t.ConsumerServiceId) ||
+			!serviceUtil.ServiceExist(ctx, domainProject, t.ProviderServiceId) {
+			util.Logger().Warnf(nil, "ReportDependencyTraffic ignores %s->%s, service does not exist.",
+				t.ConsumerServiceId, t.ProviderServiceId)
+			continue
+		}
+		accepted = append(accepted, t)
+	}
+
+	ttl := in.Ttl
+	if ttl == 0 {
+		ttl = int64(serviceUtil.DependencyTrafficTTL() / time.Second)
+	}
+	if err := serviceUtil.SaveDependencyTraffics(ctx, domainProject, accepted, ttl); err != nil {
+		util.Logger().Errorf(err, "ReportDependencyTraffic failed for saving traffics failed.")
+		return &pb.ReportDependencyTrafficResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, err.Error()),
+		}, err
+	}
+
+	util.Logger().Debugf("ReportDependencyTraffic successfully, %d/%d accepted.", len(accepted), len(in.Traffics))
+	return &pb.ReportDependencyTrafficResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Report dependency traffic successfully."),
+		Accepted: int32(len(accepted)),
+	}, nil
+}
+
+// LintDependencies 检查依赖声明而不创建，供CI在合入前校验，
+// 有问题时仍返回成功，由调用方根据errors和warnings决定是否通过
+func (s *MicroServiceService) LintDependencies(ctx context.Context, in *pb.LintDependenciesRequest) (*pb.LintDependenciesResponse, error) {
+	if in == nil || len(in.Dependencies) == 0 {
+		util.Logger().Errorf(nil, "LintDependencies failed for invalid params.")
+		return &pb.LintDependenciesResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	domainProject := util.ParseDomainProject(ctx)
+
+	issues, err := serviceUtil.LintDependencies(ctx, domainProject, in.Dependencies)
+	if err != nil {
+		util.Logger().Errorf(err, "LintDependencies failed for checking dependencies failed.")
+		return &pb.LintDependenciesResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	resp := &pb.LintDependenciesResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Lint dependencies successfully."),
+		Issues:   issues,
+	}
+	for _, issue := range issues {
+		if issue.Level == serviceUtil.LINT_LEVEL_ERROR {
+			resp.Errors++
+		} else {
+			resp.Warnings++
+		}
+	}
+	return resp, nil
+}
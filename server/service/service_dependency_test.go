@@ -17,7 +17,10 @@
 package service_test
 
 import (
+	"fmt"
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -134,6 +137,8 @@ var _ = Describe("'Dependency' service", func() {
 				})
 				Expect(err).To(BeNil())
 				Expect(respCreateDependency.Response.Code).ToNot(Equal(pb.Response_SUCCESS))
+				Expect(len(respCreateDependency.Response.Details)).To(Equal(1))
+				Expect(respCreateDependency.Response.Details[0].Field).To(Equal("consumer.Version"))
 
 				By("consumer serviceName is invalid")
 				respCreateDependency, err = serviceResource.CreateDependenciesForMicroServices(getContext(), &pb.CreateDependenciesRequest{
@@ -544,4 +549,436 @@ var _ = Describe("'Dependency' service", func() {
 			})
 		})
 	})
+
+	Describe("execute 'diff' operartion", func() {
+		var (
+			consumerId string
+			providerC  string
+		)
+
+		It("should be passed", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "diff_dep_group",
+					ServiceName: "diff_dep_consumer",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			consumerId = respCreateService.ServiceId
+
+			for _, name := range []string{"diff_dep_provider_a", "diff_dep_provider_b", "diff_dep_provider_c"} {
+				respCreateService, err = serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						AppId:       "diff_dep_group",
+						ServiceName: name,
+						Version:     "1.0.0",
+						Level:       "BACK",
+						Status:      pb.MS_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+				providerC = respCreateService.ServiceId
+			}
+
+			respAddDependency, err := serviceResource.AddDependenciesForMicroServices(getContext(), &pb.AddDependenciesRequest{
+				Dependencies: []*pb.ConsumerDependency{
+					{
+						Consumer: &pb.DependencyKey{
+							AppId:       "diff_dep_group",
+							ServiceName: "diff_dep_consumer",
+							Version:     "1.0.0",
+						},
+						Providers: []*pb.DependencyKey{
+							{
+								AppId:       "diff_dep_group",
+								ServiceName: "diff_dep_provider_a",
+								Version:     "1.0.0+",
+							},
+							{
+								AppId:       "diff_dep_group",
+								ServiceName: "diff_dep_provider_b",
+								Version:     "1.0.0",
+							},
+						},
+					},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respAddDependency.Response.Code).To(Equal(pb.Response_SUCCESS))
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				By("service id is empty")
+				resp, err := serviceResource.GetDependencyDiff(getContext(), &pb.GetDependenciesRequest{
+					ServiceId: "",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).ToNot(Equal(pb.Response_SUCCESS))
+
+				By("service does not exist")
+				resp, err = serviceResource.GetDependencyDiff(getContext(), &pb.GetDependenciesRequest{
+					ServiceId: "noneservice",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).ToNot(Equal(pb.Response_SUCCESS))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				By("nothing found yet")
+				resp, err := serviceResource.GetDependencyDiff(getContext(), &pb.GetDependenciesRequest{
+					ServiceId: consumerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Unused)).To(Equal(2))
+				Expect(len(resp.Undeclared)).To(Equal(0))
+
+				By("find declared and undeclared providers")
+				respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: consumerId,
+					AppId:             "diff_dep_group",
+					ServiceName:       "diff_dep_provider_a",
+					VersionRule:       "1.0.0+",
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				respFind, err = instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: consumerId,
+					AppId:             "diff_dep_group",
+					ServiceName:       "diff_dep_provider_c",
+					VersionRule:       "latest",
+					NoDependency:      true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				resp, err = serviceResource.GetDependencyDiff(getContext(), &pb.GetDependenciesRequest{
+					ServiceId: consumerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Unused)).To(Equal(1))
+				Expect(resp.Unused[0].ServiceName).To(Equal("diff_dep_provider_b"))
+				Expect(len(resp.Undeclared)).To(Equal(1))
+				Expect(resp.Undeclared[0].ServiceId).To(Equal(providerC))
+			})
+		})
+	})
+
+	Describe("execute 'accessors' operartion", func() {
+		var (
+			consumerId string
+			providerId string
+		)
+
+		It("should be passed", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "accessor_dep_group",
+					ServiceName: "accessor_dep_consumer",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			consumerId = respCreateService.ServiceId
+
+			respCreateService, err = serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "accessor_dep_group",
+					ServiceName: "accessor_dep_provider",
+					Version:     "1.0.0",
+					Level:       "BACK",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			providerId = respCreateService.ServiceId
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				By("provider id is empty")
+				resp, err := serviceResource.GetProviderAccessors(getContext(), &pb.GetProviderAccessorsRequest{
+					ProviderServiceId: "",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("window is invalid")
+				resp, err = serviceResource.GetProviderAccessors(getContext(), &pb.GetProviderAccessorsRequest{
+					ProviderServiceId: providerId,
+					Window:            "xxx",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				resp, err = serviceResource.GetProviderAccessors(getContext(), &pb.GetProviderAccessorsRequest{
+					ProviderServiceId: providerId,
+					Window:            "-1h",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("provider does not exist")
+				resp, err = serviceResource.GetProviderAccessors(getContext(), &pb.GetProviderAccessorsRequest{
+					ProviderServiceId: "noneservice",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrServiceNotExists))
+			})
+		})
+
+		Context("when request is valid", func() {
+			It("should be passed", func() {
+				By("nobody found yet")
+				resp, err := serviceResource.GetProviderAccessors(getContext(), &pb.GetProviderAccessorsRequest{
+					ProviderServiceId: providerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Accessors)).To(Equal(0))
+
+				By("consumer finds the provider")
+				respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+					ConsumerServiceId: consumerId,
+					AppId:             "accessor_dep_group",
+					ServiceName:       "accessor_dep_provider",
+					VersionRule:       "latest",
+				})
+				Expect(err).To(BeNil())
+				Expect(respFind.Response.Code).To(Equal(pb.Response_SUCCESS))
+
+				resp, err = serviceResource.GetProviderAccessors(getContext(), &pb.GetProviderAccessorsRequest{
+					ProviderServiceId: providerId,
+					Window:            "1h",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Accessors)).To(Equal(1))
+				Expect(resp.Accessors[0].ConsumerServiceId).To(Equal(consumerId))
+				Expect(resp.Accessors[0].Consumer.ServiceName).To(Equal("accessor_dep_consumer"))
+				Expect(resp.Accessors[0].InstanceCount).To(Equal(int64(0)))
+			})
+		})
+	})
+
+	Describe("execute 'history' operartion", func() {
+		var (
+			consumerId string
+		)
+
+		It("should be passed", func() {
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "history_dep_group",
+					ServiceName: "history_dep_consumer",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			consumerId = respCreateService.ServiceId
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				By("service id is empty")
+				resp, err := serviceResource.GetDependencyHistory(getContext(), &pb.GetDependencyHistoryRequest{
+					ServiceId: "",
+					Revision:  "1",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("neither revision nor timestamp")
+				resp, err = serviceResource.GetDependencyHistory(getContext(), &pb.GetDependencyHistoryRequest{
+					ServiceId: consumerId,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("both revision and timestamp")
+				resp, err = serviceResource.GetDependencyHistory(getContext(), &pb.GetDependencyHistoryRequest{
+					ServiceId: consumerId,
+					Revision:  "1",
+					Timestamp: "1",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("revision is invalid")
+				resp, err = serviceResource.GetDependencyHistory(getContext(), &pb.GetDependencyHistoryRequest{
+					ServiceId: consumerId,
+					Revision:  "abc",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+
+				By("timestamp is out of the history")
+				resp, err = serviceResource.GetDependencyHistory(getContext(), &pb.GetDependencyHistoryRequest{
+					ServiceId: consumerId,
+					Timestamp: "1",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrRevisionCompacted))
+			})
+		})
+	})
+
+	Describe("execute 'lint' operartion", func() {
+		It("should be passed", func() {
+			for _, v := range []string{"1.0.0", "1.1.0"} {
+				respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						AppId:       "lint_dep_group",
+						ServiceName: "lint_dep_provider",
+						Version:     v,
+						Level:       "FRONT",
+						Status:      pb.MS_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+			}
+			respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "lint_dep_group",
+					ServiceName: "lint_dep_consumer",
+					Version:     "1.0.0",
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+		})
+
+		Context("when request is invalid", func() {
+			It("should be failed", func() {
+				resp, err := serviceResource.LintDependencies(getContext(), &pb.LintDependenciesRequest{})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(scerr.ErrInvalidParams))
+			})
+		})
+
+		Context("when dependencies are clean", func() {
+			It("should report nothing", func() {
+				resp, err := serviceResource.LintDependencies(getContext(), &pb.LintDependenciesRequest{
+					Dependencies: []*pb.ConsumerDependency{
+						{
+							Consumer: &pb.DependencyKey{
+								AppId:       "lint_dep_group",
+								ServiceName: "lint_dep_consumer",
+								Version:     "1.0.0",
+							},
+							Providers: []*pb.DependencyKey{
+								{
+									AppId:       "lint_dep_group",
+									ServiceName: "lint_dep_provider",
+									Version:     "1.0.0+",
+								},
+							},
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				Expect(len(resp.Issues)).To(Equal(0))
+			})
+		})
+
+		Context("when dependencies have issues", func() {
+			It("should report them", func() {
+				resp, err := serviceResource.LintDependencies(getContext(), &pb.LintDependenciesRequest{
+					Dependencies: []*pb.ConsumerDependency{
+						{
+							Consumer: &pb.DependencyKey{
+								AppId:       "lint_dep_group",
+								ServiceName: "lint_dep_consumer",
+								Version:     "1.0.0",
+							},
+							Providers: []*pb.DependencyKey{
+								{
+									AppId:       "lint_dep_group",
+									ServiceName: "lint_dep_provider",
+									Version:     "2.0.0+",
+								},
+								{
+									AppId:       "lint_dep_group",
+									ServiceName: "lint_dep_not_exist",
+									Version:     "latest",
+								},
+								{
+									Environment: pb.ENV_PROD,
+									AppId:       "lint_dep_group",
+									ServiceName: "lint_dep_provider",
+									Version:     "latest",
+								},
+							},
+						},
+						{
+							Consumer: &pb.DependencyKey{
+								AppId:       "lint_dep_group",
+								ServiceName: "lint_dep_consumer",
+								Version:     "1.0.0",
+							},
+							Providers: []*pb.DependencyKey{
+								{
+									AppId:       "lint_dep_group",
+									ServiceName: "lint_dep_provider",
+									Version:     "2.0.0",
+								},
+							},
+						},
+						{
+							Consumer: &pb.DependencyKey{
+								AppId:       "lint_dep_group",
+								ServiceName: "lint_dep_consumer_not_exist",
+								Version:     "1.0.0",
+							},
+							Providers: []*pb.DependencyKey{
+								{
+									ServiceName: "*",
+								},
+								{
+									AppId:       "lint_dep_group",
+									ServiceName: "lint_dep_provider",
+									Version:     "1.0.0",
+								},
+							},
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.Code).To(Equal(pb.Response_SUCCESS))
+				codes := make(map[string]string)
+				for _, issue := range resp.Issues {
+					codes[fmt.Sprintf("%d/%s", issue.Index, issue.Field)] = issue.Code
+				}
+				Expect(codes["0/providers[0]"]).To(Equal(serviceUtil.LINT_VERSION_NOT_MATCHED))
+				Expect(codes["0/providers[1]"]).To(Equal(serviceUtil.LINT_PROVIDER_NOT_EXIST))
+				Expect(codes["0/providers[2]"]).To(Equal(serviceUtil.LINT_ENVIRONMENT_MISMATCH))
+				Expect(codes["1/consumer"]).To(Equal(serviceUtil.LINT_DUPLICATE_CONSUMER))
+				Expect(codes["1/providers[0]"]).To(Equal(serviceUtil.LINT_REDUNDANT_PROVIDER))
+				Expect(codes["2/consumer"]).To(Equal(serviceUtil.LINT_CONSUMER_NOT_EXIST))
+				Expect(codes["2/providers[1]"]).To(Equal(serviceUtil.LINT_IGNORED_AFTER_ALL))
+				Expect(resp.Errors).To(Equal(int32(1)))
+			})
+		})
+	})
 })
@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package service
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+)
+
+func (s *MicroServiceService) ModifySharedDefinition(ctx context.Context, in *pb.ModifySharedDefinitionRequest) (*pb.ModifySharedDefinitionResponse, error) {
+	if in == nil || len(in.Name) == 0 {
+		util.Logger().Errorf(nil, "modify shared definition failed: invalid params.")
+		return &pb.ModifySharedDefinitionResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "modify shared definition failed, name %s: invalid parameters.", in.Name)
+		return &pb.ModifySharedDefinitionResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	err = serviceUtil.CheckSharedDefinition(in.Content)
+	if err != nil {
+		util.Logger().Errorf(err, "modify shared definition failed, name %s: invalid content.", in.Name)
+		return &pb.ModifySharedDefinitionResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(),
+				scerr.NewDetail(scerr.ErrInvalidParams, "content", err.Error())),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	err = serviceUtil.PutSharedDefinition(ctx, domainProject, in.Name, in.Content)
+	if err != nil {
+		util.Logger().Errorf(err, "modify shared definition failed, name %s: commit definition into etcd failed.", in.Name)
+		return &pb.ModifySharedDefinitionResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, "Commit operations failed."),
+		}, err
+	}
+
+	util.Logger().Infof("modify shared definition successful, name %s, operator %s.",
+		in.Name, util.GetIPFromContext(ctx))
+	return &pb.ModifySharedDefinitionResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Modify shared definition successfully."),
+	}, nil
+}
+
+func (s *MicroServiceService) GetSharedDefinitions(ctx context.Context, in *pb.GetSharedDefinitionsRequest) (*pb.GetSharedDefinitionsResponse, error) {
+	domainProject := util.ParseDomainProject(ctx)
+
+	definitions, err := serviceUtil.GetSharedDefinitions(ctx, domainProject)
+	if err != nil {
+		util.Logger().Errorf(err, "get shared definitions failed.")
+		return &pb.GetSharedDefinitionsResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, err.Error()),
+		}, err
+	}
+
+	return &pb.GetSharedDefinitionsResponse{
+		Response:    pb.CreateResponse(pb.Response_SUCCESS, "Get shared definitions successfully."),
+		Definitions: definitions,
+	}, nil
+}
+
+func (s *MicroServiceService) DeleteSharedDefinition(ctx context.Context, in *pb.DeleteSharedDefinitionRequest) (*pb.DeleteSharedDefinitionResponse, error) {
+	if in == nil || len(in.Name) == 0 {
+		util.Logger().Errorf(nil, "delete shared definition failed: invalid params.")
+		return &pb.DeleteSharedDefinitionResponse{
+			Response: pb.CreateResponse(scerr.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	err := apt.Validate(in)
+	if err != nil {
+		util.Logger().Errorf(err, "delete shared definition failed, name %s: invalid parameters.", in.Name)
+		return &pb.DeleteSharedDefinitionResponse{
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
+		}, nil
+	}
+
+	domainProject := util.ParseDomainProject(ctx)
+
+	definition, err := serviceUtil.GetSharedDefinition(ctx, domainProject, in.Name)
+	if err != nil {
+		util.Logger().Errorf(err, "delete shared definition failed, name %s: get definition failed.", in.Name)
+		return &pb.DeleteSharedDefinitionResponse{
+			Response: pb.CreateResponse(scerr.ErrInternal, "Get shared definition failed."),
+		}, err
+	}
+	if definition == nil {
+		util.Logger().Errorf(nil, "delete shared definition failed, name %s: definition not exist.", in.Name)
+		return &pb.DeleteSharedDefinitionResponse{
+			Response: pb.CreateResponse(scerr.ErrSharedDefinitionNotExists, "Shared definition does not exist."),
+		}, nil
+	}
+
+	_, err = backend.Registry().Do(ctx,
+		registry.DEL,
+		registry.WithStrKey(apt.GenerateSharedDefinitionKey(domainProject, in.Name)))
+	if err != nil {
+		util.Logger().Errorf(err, "delete shared definition failed, name %s: commit operations failed.", in.Name)
+		return &pb.DeleteSharedDefinitionResponse{
+			Response: pb.CreateResponse(scerr.ErrUnavailableBackend, "Commit operations failed."),
+		}, err
+	}
+
+	util.Logger().Infof("delete shared definition successful, name %s, operator %s.",
+		in.Name, util.GetIPFromContext(ctx))
+	return &pb.DeleteSharedDefinitionResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Delete shared definition successfully."),
+	}, nil
+}
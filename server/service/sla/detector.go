@@ -0,0 +1,221 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sla
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+type providerState struct {
+	domainProject string
+	serviceId     string
+	since         time.Time
+	breached      bool
+}
+
+// Detector 统计被依赖provider的健康实例数，
+// provider没有健康实例超过Threshold时，通知所有受影响的consumer
+type Detector struct {
+	Threshold  time.Duration
+	Interval   time.Duration
+	WebhookURL string
+
+	client    *rest.HttpClient
+	handlers  []BreachHandleFunc
+	dirty     map[string]*providerState
+	unhealthy map[string]*providerState
+	lock      sync.Mutex
+}
+
+func NewDetector(threshold time.Duration, webhook string) *Detector {
+	d := &Detector{
+		Threshold:  threshold,
+		Interval:   DEFAULT_CHECK_INTERVAL,
+		WebhookURL: webhook,
+		dirty:      make(map[string]*providerState),
+		unhealthy:  make(map[string]*providerState),
+	}
+	if d.Threshold > 0 && d.Threshold < d.Interval {
+		d.Interval = d.Threshold
+	}
+	if len(webhook) > 0 {
+		u, err := url.Parse(webhook)
+		if err != nil || len(u.Host) == 0 {
+			util.Logger().Errorf(err, "invalid sla_webhook_url '%s'", webhook)
+			return d
+		}
+		d.client, err = rest.GetClient(u.Scheme)
+		if err != nil {
+			util.Logger().Errorf(err, "create sla webhook client failed")
+		}
+	}
+	return d
+}
+
+func (d *Detector) Enabled() bool {
+	return d.Threshold > 0
+}
+
+func (d *Detector) AddHandleFunc(f BreachHandleFunc) {
+	d.lock.Lock()
+	d.handlers = append(d.handlers, f)
+	d.lock.Unlock()
+}
+
+func (d *Detector) Type() store.StoreType {
+	return store.INSTANCE
+}
+
+// OnEvent 只记录发生变化的provider，由后台任务统一计算，避免阻塞缓存的事件分发；
+// INIT事件也要记录，否则启动前已经失去健康实例的provider不会被检查
+func (d *Detector) OnEvent(evt *store.KvEvent) {
+	providerId, _, domainProject, _ := pb.GetInfoFromInstKV(evt.KV)
+	if len(providerId) == 0 {
+		return
+	}
+	d.lock.Lock()
+	d.dirty[util.StringJoin([]string{domainProject, providerId}, "/")] = &providerState{
+		domainProject: domainProject,
+		serviceId:     providerId,
+	}
+	d.lock.Unlock()
+}
+
+func (d *Detector) run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(d.Interval):
+			d.Check(time.Now())
+		}
+	}
+}
+
+// Check 重新统计变化过的provider并检查是否超过阈值
+func (d *Detector) Check(now time.Time) {
+	ctx := context.Background()
+
+	d.lock.Lock()
+	dirty := d.dirty
+	d.dirty = make(map[string]*providerState)
+	d.lock.Unlock()
+
+	for key, st := range dirty {
+		healthy, err := serviceUtil.GetHealthyInstanceCount(ctx, st.domainProject, st.serviceId)
+		if err != nil {
+			util.Logger().Errorf(err, "count provider %s healthy instances failed", key)
+			continue
+		}
+		old, ok := d.unhealthy[key]
+		switch {
+		case healthy == 0 && !ok:
+			st.since = now
+			d.unhealthy[key] = st
+		case healthy > 0 && ok:
+			delete(d.unhealthy, key)
+			if !old.breached {
+				continue
+			}
+			provider, err := serviceUtil.GetServiceInCache(ctx, old.domainProject, old.serviceId)
+			if err != nil || provider == nil {
+				util.Logger().Errorf(err, "get provider %s failed", key)
+				continue
+			}
+			d.notify(ctx, EVT_RECOVER, old, provider)
+		}
+	}
+
+	for key, st := range d.unhealthy {
+		if st.breached || now.Sub(st.since) < d.Threshold {
+			continue
+		}
+		provider, err := serviceUtil.GetServiceInCache(ctx, st.domainProject, st.serviceId)
+		if err != nil {
+			util.Logger().Errorf(err, "get provider %s failed", key)
+			continue
+		}
+		if provider == nil {
+			// provider已删除
+			delete(d.unhealthy, key)
+			continue
+		}
+		st.breached = d.notify(ctx, EVT_BREACH, st, provider)
+	}
+}
+
+// notify 通知有consumer依赖的provider的SLA事件，没有consumer时返回false待下次检查
+func (d *Detector) notify(ctx context.Context, t string, st *providerState, provider *pb.MicroService) bool {
+	dr := serviceUtil.NewProviderDependencyRelation(ctx, st.domainProject, st.serviceId, provider)
+	consumers, err := dr.GetDependencyConsumers()
+	if err != nil {
+		util.Logger().Errorf(err, "get provider %s/%s consumers failed", st.domainProject, st.serviceId)
+		return false
+	}
+	if len(consumers) == 0 {
+		return false
+	}
+
+	evt := &BreachEvent{
+		Type:          t,
+		DomainProject: st.domainProject,
+		ProviderId:    st.serviceId,
+		Provider:      pb.MicroServiceToKey(st.domainProject, provider),
+		Since:         st.since.Unix(),
+		Consumers:     make([]*pb.MicroServiceKey, 0, len(consumers)),
+	}
+	for _, consumer := range consumers {
+		evt.Consumers = append(evt.Consumers, pb.MicroServiceToKey(st.domainProject, consumer))
+	}
+
+	util.Logger().Warnf(nil, "provider %s/%s/%s/%s[%s] sla %s, no healthy instance since %s, %d consumer(s) impacted",
+		provider.Environment, provider.AppId, provider.ServiceName, provider.Version, st.serviceId,
+		t, st.since.Format(time.RFC3339), len(evt.Consumers))
+
+	d.lock.Lock()
+	handlers := d.handlers
+	d.lock.Unlock()
+	for _, f := range handlers {
+		f(evt)
+	}
+	d.post(evt)
+	return true
+}
+
+func (d *Detector) post(evt *BreachEvent) {
+	if d.client == nil {
+		return
+	}
+	resp, err := d.client.HttpDo(http.MethodPost, d.WebhookURL, nil, evt)
+	if err != nil {
+		util.Logger().Errorf(err, "post sla %s event to %s failed", evt.Type, d.WebhookURL)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		util.Logger().Errorf(nil, "post sla %s event to %s failed, status code %d", evt.Type, d.WebhookURL, resp.StatusCode)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sla_test
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/admission/buildin"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/quota/buildin"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/etcd"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/uuid/dynamic"
+	"github.com/apache/incubator-servicecomb-service-center/server/service"
+	"github.com/apache/incubator-servicecomb-service-center/server/service/sla"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"golang.org/x/net/context"
+	"testing"
+	"time"
+)
+
+func getContext() context.Context {
+	ctx := context.TODO()
+	ctx = util.SetContext(ctx, "domain", "default")
+	ctx = util.SetContext(ctx, "project", "default")
+	ctx = util.SetContext(ctx, "noCache", "1")
+	return ctx
+}
+
+func instanceEvent(action pb.EventType, serviceId, instanceId string) *store.KvEvent {
+	return &store.KvEvent{
+		Action: action,
+		KV: &mvccpb.KeyValue{
+			Key: []byte(apt.GenerateInstanceKey("default/default", serviceId, instanceId)),
+		},
+	}
+}
+
+func TestDetector_Check(t *testing.T) {
+	serviceResource, instanceResource := service.AssembleResources()
+
+	respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+		Service: &pb.MicroService{
+			AppId:       "sla_group",
+			ServiceName: "sla_consumer",
+			Version:     "1.0.0",
+			Level:       "FRONT",
+			Status:      pb.MS_UP,
+		},
+	})
+	if err != nil || respCreate.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("create consumer failed, %v", respCreate.Response)
+	}
+	consumerId := respCreate.ServiceId
+
+	respCreate, err = serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+		Service: &pb.MicroService{
+			AppId:       "sla_group",
+			ServiceName: "sla_provider",
+			Version:     "1.0.0",
+			Level:       "BACK",
+			Status:      pb.MS_UP,
+		},
+	})
+	if err != nil || respCreate.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("create provider failed, %v", respCreate.Response)
+	}
+	providerId := respCreate.ServiceId
+
+	register := func() string {
+		resp, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+			Instance: &pb.MicroServiceInstance{
+				ServiceId: providerId,
+				Endpoints: []string{"sla:127.0.0.1:8080"},
+				HostName:  "UT-HOST",
+				Status:    pb.MSI_UP,
+			},
+		})
+		if err != nil || resp.Response.Code != pb.Response_SUCCESS {
+			t.Fatalf("register instance failed, %v", resp.Response)
+		}
+		return resp.InstanceId
+	}
+	instanceId := register()
+
+	respFind, err := instanceResource.Find(getContext(), &pb.FindInstancesRequest{
+		ConsumerServiceId: consumerId,
+		AppId:             "sla_group",
+		ServiceName:       "sla_provider",
+		VersionRule:       "latest",
+	})
+	if err != nil || respFind.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("find instances failed, %v", respFind.Response)
+	}
+
+	respUnregister, err := instanceResource.Unregister(getContext(), &pb.UnregisterInstanceRequest{
+		ServiceId:  providerId,
+		InstanceId: instanceId,
+	})
+	if err != nil || respUnregister.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("unregister instance failed, %v", respUnregister.Response)
+	}
+
+	var events []*sla.BreachEvent
+	d := sla.NewDetector(time.Second, "")
+	d.AddHandleFunc(func(evt *sla.BreachEvent) {
+		events = append(events, evt)
+	})
+
+	now := time.Now()
+	d.OnEvent(instanceEvent(pb.EVT_DELETE, providerId, instanceId))
+	d.Check(now)
+	if len(events) != 0 {
+		t.Fatalf("TestDetector_Check failed, breach event emitted before threshold")
+	}
+
+	d.Check(now.Add(2 * time.Second))
+	if len(events) != 1 || events[0].Type != sla.EVT_BREACH || events[0].ProviderId != providerId {
+		t.Fatalf("TestDetector_Check failed, %v", events)
+	}
+	if len(events[0].Consumers) != 1 || events[0].Consumers[0].ServiceName != "sla_consumer" {
+		t.Fatalf("TestDetector_Check failed, impacted consumers %v", events[0].Consumers)
+	}
+
+	d.Check(now.Add(3 * time.Second))
+	if len(events) != 1 {
+		t.Fatalf("TestDetector_Check failed, breach event emitted repeatedly")
+	}
+
+	instanceId = register()
+	d.OnEvent(instanceEvent(pb.EVT_CREATE, providerId, instanceId))
+	d.Check(now.Add(4 * time.Second))
+	if len(events) != 2 || events[1].Type != sla.EVT_RECOVER {
+		t.Fatalf("TestDetector_Check failed, %v", events)
+	}
+
+	// 重启后缓存初始化推送的INIT事件同样需要检查
+	respUnregister, err = instanceResource.Unregister(getContext(), &pb.UnregisterInstanceRequest{
+		ServiceId:  providerId,
+		InstanceId: instanceId,
+	})
+	if err != nil || respUnregister.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("unregister instance failed, %v", respUnregister.Response)
+	}
+	events = nil
+	d = sla.NewDetector(time.Second, "")
+	d.AddHandleFunc(func(evt *sla.BreachEvent) {
+		events = append(events, evt)
+	})
+	d.OnEvent(instanceEvent(pb.EVT_INIT, providerId, instanceId))
+	d.Check(now)
+	d.Check(now.Add(2 * time.Second))
+	if len(events) != 1 || events[0].Type != sla.EVT_BREACH || events[0].ProviderId != providerId {
+		t.Fatalf("TestDetector_Check failed, init event not checked, %v", events)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package sla
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/astaxie/beego"
+	"time"
+)
+
+const (
+	EVT_BREACH  = "BREACH"
+	EVT_RECOVER = "RECOVER"
+
+	DEFAULT_CHECK_INTERVAL = 10 * time.Second
+)
+
+// BreachEvent 被依赖的provider持续没有健康实例时产生
+type BreachEvent struct {
+	Type          string                `json:"type"`
+	DomainProject string                `json:"domainProject"`
+	ProviderId    string                `json:"providerId"`
+	Provider      *pb.MicroServiceKey   `json:"provider"`
+	Since         int64                 `json:"since"`
+	Consumers     []*pb.MicroServiceKey `json:"consumers,omitempty"`
+}
+
+type BreachHandleFunc func(evt *BreachEvent)
+
+var detector *Detector
+
+func init() {
+	detector = NewDetector(
+		time.Duration(beego.AppConfig.DefaultInt64("sla_breach_threshold", 0))*time.Second,
+		beego.AppConfig.String("sla_webhook_url"))
+	if !detector.Enabled() {
+		return
+	}
+	store.AddEventHandler(detector)
+	util.Logger().Infof("sla detector enabled, breach threshold %s", detector.Threshold)
+}
+
+func GetDetector() *Detector {
+	return detector
+}
+
+// AddBreachHandleFunc 注册进程内的SLA事件处理函数
+func AddBreachHandleFunc(f BreachHandleFunc) {
+	detector.AddHandleFunc(f)
+}
+
+func Run() {
+	if !detector.Enabled() {
+		return
+	}
+	util.Go(detector.run)
+}
@@ -42,11 +42,17 @@ func (s *MicroServiceService) AddTags(ctx context.Context, in *pb.AddServiceTags
 	if err != nil {
 		util.Logger().Errorf(err, "add service tags failed, serviceId %s, tags %v: invalid parameters.", in.ServiceId, in.Tags)
 		return &pb.AddServiceTagsResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 
 	domainProject := util.ParseDomainProject(ctx)
+	if guardErr := serviceUtil.AdmitWrite(ctx, serviceUtil.WRITE_PRIORITY_LOW); guardErr != nil {
+		util.Logger().Errorf(guardErr, "add service tags failed, serviceId %s: storage guard rejected.", in.ServiceId)
+		return &pb.AddServiceTagsResponse{
+			Response: pb.CreateResponse(guardErr.Code, guardErr.Detail),
+		}, nil
+	}
 	// service id存在性校验
 	if !serviceUtil.ServiceExist(ctx, domainProject, in.ServiceId) {
 		util.Logger().Errorf(nil, "add service tags failed, serviceId %s, tags %v: service not exist.", in.ServiceId, in.Tags)
@@ -111,11 +117,17 @@ func (s *MicroServiceService) UpdateTag(ctx context.Context, in *pb.UpdateServic
 	if err != nil {
 		util.Logger().Errorf(err, "update service tag failed, serviceId %s, tag %s: invalid params.", in.ServiceId, tagFlag)
 		return &pb.UpdateServiceTagResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 
 	domainProject := util.ParseDomainProject(ctx)
+	if guardErr := serviceUtil.AdmitWrite(ctx, serviceUtil.WRITE_PRIORITY_LOW); guardErr != nil {
+		util.Logger().Errorf(guardErr, "update service tag failed, serviceId %s, tag %s: storage guard rejected.", in.ServiceId, tagFlag)
+		return &pb.UpdateServiceTagResponse{
+			Response: pb.CreateResponse(guardErr.Code, guardErr.Detail),
+		}, nil
+	}
 
 	if !serviceUtil.ServiceExist(ctx, domainProject, in.ServiceId) {
 		util.Logger().Errorf(err, "update service tag failed, serviceId %s, tag %s: service not exist.", in.ServiceId, tagFlag)
@@ -166,7 +178,7 @@ func (s *MicroServiceService) DeleteTags(ctx context.Context, in *pb.DeleteServi
 	if err != nil {
 		util.Logger().Errorf(err, "delete service tags failed, serviceId %s, tags %v: invalid params.", in.ServiceId, in.Keys)
 		return &pb.DeleteServiceTagsResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 
@@ -236,7 +248,7 @@ func (s *MicroServiceService) GetTags(ctx context.Context, in *pb.GetServiceTags
 	if err != nil {
 		util.Logger().Errorf(err, "get service tags failed, serviceId %s: invalid parameters.", in.ServiceId)
 		return &pb.GetServiceTagsResponse{
-			Response: pb.CreateResponse(scerr.ErrInvalidParams, err.Error()),
+			Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, err.Error(), scerr.ValidationDetails("", err)...),
 		}, nil
 	}
 
@@ -20,6 +20,7 @@ import (
 	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
 	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
 	"github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/quota/buildin"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"strconv"
@@ -124,6 +125,58 @@ var _ = Describe("'Tag' service", func() {
 				Expect(respAddTags.Response.Code).To(Equal(scerr.ErrNotEnoughQuota))
 			})
 		})
+
+		Context("when registry storage is near the limit", func() {
+			It("should be failed", func() {
+				g := serviceUtil.GetGuardrail()
+				g.MaxKeys = 100
+				defer func() {
+					g.MaxKeys = 0
+					g.Update(0, 0)
+				}()
+
+				g.Update(95, 0)
+				respAddTags, err := serviceResource.AddTags(getContext(), &pb.AddServiceTagsRequest{
+					ServiceId: serviceId,
+					Tags:      map[string]string{"guard": "guard"},
+				})
+				Expect(err).To(BeNil())
+				Expect(respAddTags.Response.Code).To(Equal(scerr.ErrStorageLimited))
+
+				respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						AppId:       "create_tag_group",
+						ServiceName: "create_tag_guard",
+						Version:     "1.0.0",
+						Level:       "FRONT",
+						Status:      pb.MS_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respCreateService.Response.Code).To(Equal(pb.Response_SUCCESS))
+				guardServiceId := respCreateService.ServiceId
+
+				g.Update(100, 0)
+				respCreateService, err = serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						AppId:       "create_tag_group",
+						ServiceName: "create_tag_guard_rejected",
+						Version:     "1.0.0",
+						Level:       "FRONT",
+						Status:      pb.MS_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respCreateService.Response.Code).To(Equal(scerr.ErrStorageLimited))
+
+				respDelete, err := serviceResource.Delete(getContext(), &pb.DeleteServiceRequest{
+					ServiceId: guardServiceId,
+					Force:     true,
+				})
+				Expect(err).To(BeNil())
+				Expect(respDelete.Response.Code).To(Equal(pb.Response_SUCCESS))
+			})
+		})
 	})
 
 	Describe("execute 'get' operartion", func() {
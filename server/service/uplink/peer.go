@@ -0,0 +1,257 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package uplink
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/tlsutil"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	sctls "github.com/apache/incubator-servicecomb-service-center/server/tls"
+	"golang.org/x/net/context"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// 其他节点上登记的对端变更最多延迟该时间生效
+const DEFAULT_PEER_SYNC_INTERVAL = 30 * time.Second
+
+var (
+	peerNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,63}$`)
+
+	ErrNoPendingRotation = errors.New("no CA rotation in progress")
+)
+
+// Peer 登记的对端集群，CA为信任的证书(PEM，可包含多个)。轮换证书时先用StagePeerCA
+// 加入新CA，新旧CA同时被信任，两端都替换为新CA签发的证书后再CompletePeerRotation
+// 只保留新CA，整个过程中已有连接和新建连接都不会中断
+type Peer struct {
+	Name      string `json:"name"`
+	Addr      string `json:"addr,omitempty"`
+	CA        string `json:"ca"`
+	NextCA    string `json:"nextCa,omitempty"`
+	Operator  string `json:"operator,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+}
+
+func parseCA(ca string) ([]*x509.Certificate, error) {
+	certs, err := tlsutil.ParsePEMCertificates([]byte(ca))
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+	for _, cert := range certs {
+		if !cert.IsCA {
+			return nil, fmt.Errorf("certificate %s is not a CA", cert.Subject.CommonName)
+		}
+	}
+	return certs, nil
+}
+
+func (p *Peer) Check() error {
+	if !peerNameRegex.MatchString(p.Name) {
+		return fmt.Errorf("invalid peer name '%s'", p.Name)
+	}
+	if _, err := parseCA(p.CA); err != nil {
+		return fmt.Errorf("invalid ca, %s", err.Error())
+	}
+	if len(p.NextCA) == 0 {
+		return nil
+	}
+	if _, err := parseCA(p.NextCA); err != nil {
+		return fmt.Errorf("invalid nextCa, %s", err.Error())
+	}
+	return nil
+}
+
+// Certificates 返回当前信任的所有CA，轮换期间包括新CA
+func (p *Peer) Certificates() ([]*x509.Certificate, error) {
+	certs, err := parseCA(p.CA)
+	if err != nil || len(p.NextCA) == 0 {
+		return certs, err
+	}
+	next, err := parseCA(p.NextCA)
+	if err != nil {
+		return nil, err
+	}
+	return append(certs, next...), nil
+}
+
+// GetPeers 查询登记的所有对端集群，按名称排序
+func GetPeers(ctx context.Context) ([]*Peer, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GetPeerRootKey()+"/"),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	peers := make([]*Peer, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		p := &Peer{}
+		if err := json.Unmarshal(kv.Value, p); err != nil {
+			util.Logger().Errorf(err, "invalid peer %s", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		peers = append(peers, p)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Name < peers[j].Name
+	})
+	return peers, nil
+}
+
+// GetPeer 对端不存在时返回nil
+func GetPeer(ctx context.Context, name string) (*Peer, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GeneratePeerKey(name)))
+	if err != nil || len(resp.Kvs) == 0 {
+		return nil, err
+	}
+	p := &Peer{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// PutPeer 登记或覆盖对端集群，本节点立即生效
+func PutPeer(ctx context.Context, p *Peer) error {
+	p.Timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(apt.GeneratePeerKey(p.Name)),
+		registry.WithValue(data))
+	if err != nil {
+		return err
+	}
+	return SyncPeers(ctx)
+}
+
+// DeletePeer 删除对端集群，不再信任其CA，返回对端是否存在
+func DeletePeer(ctx context.Context, name string) (bool, error) {
+	key := apt.GeneratePeerKey(name)
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(key),
+		registry.WithCountOnly())
+	if err != nil || resp.Count == 0 {
+		return false, err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.DEL,
+		registry.WithStrKey(key))
+	if err != nil {
+		return false, err
+	}
+	return true, SyncPeers(ctx)
+}
+
+// updatePeer 修改已登记的对端，对端不存在时返回nil
+func updatePeer(ctx context.Context, name, operator string, f func(p *Peer) error) (*Peer, error) {
+	p, err := GetPeer(ctx, name)
+	if err != nil || p == nil {
+		return nil, err
+	}
+	if err := f(p); err != nil {
+		return nil, err
+	}
+	p.Operator = operator
+	if err := PutPeer(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// StagePeerCA 开始轮换，新CA与当前CA同时被信任，重复调用时替换新CA
+func StagePeerCA(ctx context.Context, name, ca, operator string) (*Peer, error) {
+	if _, err := parseCA(ca); err != nil {
+		return nil, fmt.Errorf("invalid ca, %s", err.Error())
+	}
+	return updatePeer(ctx, name, operator, func(p *Peer) error {
+		p.NextCA = ca
+		return nil
+	})
+}
+
+// CompletePeerRotation 结束轮换，只信任新CA
+func CompletePeerRotation(ctx context.Context, name, operator string) (*Peer, error) {
+	return updatePeer(ctx, name, operator, func(p *Peer) error {
+		if len(p.NextCA) == 0 {
+			return ErrNoPendingRotation
+		}
+		p.CA, p.NextCA = p.NextCA, ""
+		return nil
+	})
+}
+
+// AbortPeerRotation 放弃轮换，只信任原来的CA
+func AbortPeerRotation(ctx context.Context, name, operator string) (*Peer, error) {
+	return updatePeer(ctx, name, operator, func(p *Peer) error {
+		if len(p.NextCA) == 0 {
+			return ErrNoPendingRotation
+		}
+		p.NextCA = ""
+		return nil
+	})
+}
+
+// SyncPeers 从注册中心加载所有对端的CA，替换本节点TLS信任的对端CA
+func SyncPeers(ctx context.Context) error {
+	peers, err := GetPeers(ctx)
+	if err != nil {
+		return err
+	}
+	cas := make(map[string][]*x509.Certificate, len(peers))
+	for _, p := range peers {
+		certs, err := p.Certificates()
+		if err != nil {
+			util.Logger().Errorf(err, "invalid ca of peer %s", p.Name)
+			continue
+		}
+		cas[p.Name] = certs
+	}
+	sctls.SetPeerCAs(cas)
+	return nil
+}
+
+func runPeerSync(stopCh <-chan struct{}) {
+	for {
+		if err := SyncPeers(context.Background()); err != nil {
+			util.Logger().Errorf(err, "sync peer CAs failed")
+		}
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(DEFAULT_PEER_SYNC_INTERVAL):
+		}
+	}
+}
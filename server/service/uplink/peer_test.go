@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package uplink_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/apache/incubator-servicecomb-service-center/server/service/uplink"
+	sctls "github.com/apache/incubator-servicecomb-service-center/server/tls"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func generateCA(t *testing.T, cn string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key failed, %s", err.Error())
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate failed, %s", err.Error())
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func peerStatus(name string) *sctls.PeerStatus {
+	for _, st := range sctls.PeerStatuses() {
+		if st.Name == name {
+			return st
+		}
+	}
+	return nil
+}
+
+func TestPeer_Check(t *testing.T) {
+	ca := generateCA(t, "peer-ca")
+	for _, c := range []struct {
+		peer   *uplink.Peer
+		expect bool
+	}{
+		{&uplink.Peer{Name: "central", CA: ca}, true},
+		{&uplink.Peer{Name: "central", CA: ca, NextCA: ca}, true},
+		{&uplink.Peer{Name: "", CA: ca}, false},
+		{&uplink.Peer{Name: "a/b", CA: ca}, false},
+		{&uplink.Peer{Name: "central", CA: ""}, false},
+		{&uplink.Peer{Name: "central", CA: ca, NextCA: "invalid"}, false},
+	} {
+		if err := c.peer.Check(); (err == nil) != c.expect {
+			t.Fatalf("TestPeer_Check %s failed, %v", c.peer.Name, err)
+		}
+	}
+}
+
+func TestPeerRotation(t *testing.T) {
+	oldCA, newCA := generateCA(t, "old-ca"), generateCA(t, "new-ca")
+	err := uplink.PutPeer(getContext(), &uplink.Peer{Name: "ut-peer", CA: oldCA})
+	if err != nil {
+		t.Fatalf("TestPeerRotation failed, %s", err.Error())
+	}
+	defer uplink.DeletePeer(getContext(), "ut-peer")
+	if st := peerStatus("ut-peer"); st == nil || len(st.CAs) != 1 {
+		t.Fatalf("TestPeerRotation failed, status %v", st)
+	}
+
+	if _, err := uplink.CompletePeerRotation(getContext(), "ut-peer", ""); err != uplink.ErrNoPendingRotation {
+		t.Fatalf("TestPeerRotation failed, no rotation should be in progress, %v", err)
+	}
+	p, err := uplink.StagePeerCA(getContext(), "ut-peer", newCA, "")
+	if err != nil || p.NextCA != newCA {
+		t.Fatalf("TestPeerRotation failed, stage %v, %v", p, err)
+	}
+	// 轮换期间新旧CA同时被信任
+	if st := peerStatus("ut-peer"); st == nil || len(st.CAs) != 2 {
+		t.Fatalf("TestPeerRotation failed, status %v", st)
+	}
+	p, err = uplink.CompletePeerRotation(getContext(), "ut-peer", "")
+	if err != nil || p.CA != newCA || len(p.NextCA) != 0 {
+		t.Fatalf("TestPeerRotation failed, complete %v, %v", p, err)
+	}
+	if st := peerStatus("ut-peer"); st == nil || len(st.CAs) != 1 || st.CAs[0].Subject != "new-ca" {
+		t.Fatalf("TestPeerRotation failed, status %v", st)
+	}
+
+	p, err = uplink.StagePeerCA(getContext(), "not-exist", newCA, "")
+	if err != nil || p != nil {
+		t.Fatalf("TestPeerRotation failed, peer should not exist, %v, %v", p, err)
+	}
+
+	ok, err := uplink.DeletePeer(getContext(), "ut-peer")
+	if err != nil || !ok || peerStatus("ut-peer") != nil {
+		t.Fatalf("TestPeerRotation failed, delete %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,359 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package uplink
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/rest"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	sctls "github.com/apache/incubator-servicecomb-service-center/server/tls"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type syncedInstance struct {
+	domainProject    string
+	localServiceId   string
+	remoteServiceId  string
+	remoteInstanceId string
+}
+
+// Replicator 把边缘节点本地注册的指定服务及实例周期性地同步到中心集群，
+// 中心集群上的实例以心跳保活，广域网中断超过TTL后自动过期，
+// 边缘节点本地的服务发现不受影响
+type Replicator struct {
+	Addr     string
+	Interval time.Duration
+	// 中心集群登记的对端名称，设置后以对端的CA校验中心集群并记录握手和认证失败
+	Peer string
+
+	services  map[string]struct{}
+	all       bool
+	client    *rest.HttpClient
+	remoteIds map[string]string
+	instances map[string]*syncedInstance
+	lastSync  int64
+	lock      sync.Mutex
+}
+
+func NewReplicator(addr string, services []string, interval time.Duration) *Replicator {
+	r := &Replicator{
+		Addr:      strings.TrimRight(addr, "/"),
+		Interval:  interval,
+		services:  make(map[string]struct{}),
+		remoteIds: make(map[string]string),
+		instances: make(map[string]*syncedInstance),
+		lastSync:  time.Now().UnixNano(),
+	}
+	if r.Interval <= 0 {
+		r.Interval = DEFAULT_SYNC_INTERVAL
+	}
+	for _, s := range services {
+		s = strings.TrimSpace(s)
+		switch s {
+		case "":
+		case "*":
+			r.all = true
+		default:
+			r.services[s] = struct{}{}
+		}
+	}
+	if len(r.Addr) == 0 {
+		return r
+	}
+	u, err := url.Parse(r.Addr)
+	if err != nil || len(u.Host) == 0 {
+		util.Logger().Errorf(err, "invalid uplink_addr '%s'", addr)
+		return r
+	}
+	r.client, err = rest.GetClient(u.Scheme)
+	if err != nil {
+		util.Logger().Errorf(err, "create uplink client failed")
+	}
+	return r
+}
+
+// UsePeer 以登记的对端CA校验https的中心集群，CA轮换后新建立的连接生效
+func (r *Replicator) UsePeer(peer string) {
+	r.Peer = peer
+	if !strings.HasPrefix(r.Addr, "https://") {
+		return
+	}
+	cfg, err := sctls.GetPeerClientTLSConfig(peer)
+	if err != nil {
+		util.Logger().Errorf(err, "create uplink client of peer %s failed", peer)
+		r.client = nil
+		return
+	}
+	r.client = rest.GetTLSClient(false, cfg)
+}
+
+func (r *Replicator) Enabled() bool {
+	return r.client != nil && (r.all || len(r.services) > 0)
+}
+
+// Selected 服务按serviceName或appId/serviceName匹配，不同步SC自身
+func (r *Replicator) Selected(service *pb.MicroService) bool {
+	if service.AppId == apt.REGISTRY_APP_ID && service.ServiceName == apt.REGISTRY_SERVICE_NAME {
+		return false
+	}
+	if r.all {
+		return true
+	}
+	if _, ok := r.services[service.ServiceName]; ok {
+		return true
+	}
+	_, ok := r.services[service.AppId+"/"+service.ServiceName]
+	return ok
+}
+
+// Lag 返回距离上次全部同步成功的时长
+func (r *Replicator) Lag(now time.Time) time.Duration {
+	return now.Sub(time.Unix(0, atomic.LoadInt64(&r.lastSync)))
+}
+
+func (r *Replicator) run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(r.Interval):
+			if err := r.Sync(context.Background()); err != nil {
+				util.Logger().Errorf(err, "sync to central cluster %s failed", r.Addr)
+			}
+		}
+	}
+}
+
+// Sync 执行一次同步，返回第一个连接中心集群失败的错误
+func (r *Replicator) Sync(ctx context.Context) error {
+	resp, err := store.Store().Service().Search(ctx,
+		registry.WithStrKey(apt.GetServiceRootKey("")),
+		registry.WithPrefix())
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var firstErr error
+	seen := make(map[string]struct{})
+	for _, kv := range resp.Kvs {
+		_, domainProject, data := pb.GetInfoFromSvcKV(kv)
+		service := &pb.MicroService{}
+		if err := json.Unmarshal(data, service); err != nil || !r.Selected(service) {
+			continue
+		}
+		if err := r.syncService(ctx, domainProject, service, seen); err != nil {
+			util.Logger().Errorf(err, "sync service %s/%s/%s to central cluster failed",
+				service.AppId, service.ServiceName, service.Version)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	for key, inst := range r.instances {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		err := r.call(http.MethodDelete, inst.domainProject,
+			fmt.Sprintf("/registry/microservices/%s/instances/%s", inst.remoteServiceId, inst.remoteInstanceId),
+			nil, nil)
+		if isNetworkError(err) {
+			// 中心集群不可达，下次重试
+			continue
+		}
+		delete(r.instances, key)
+		util.Logger().Infof("remove instance %s from central cluster", key)
+	}
+	if firstErr == nil {
+		atomic.StoreInt64(&r.lastSync, time.Now().UnixNano())
+	}
+	return firstErr
+}
+
+func (r *Replicator) syncService(ctx context.Context, domainProject string, service *pb.MicroService,
+	seen map[string]struct{}) error {
+	instances, err := serviceUtil.GetAllInstancesOfOneService(ctx, domainProject, service.ServiceId)
+	if err != nil {
+		return err
+	}
+	// 匹配的服务在本地的实例，即使同步失败也不能从中心集群删除
+	for _, instance := range instances {
+		seen[domainProject+"/"+instance.InstanceId] = struct{}{}
+	}
+
+	remoteId, err := r.ensureService(domainProject, service)
+	if err != nil {
+		return err
+	}
+
+	for _, instance := range instances {
+		key := domainProject + "/" + instance.InstanceId
+		synced, ok := r.instances[key]
+		if ok && synced.remoteServiceId == remoteId {
+			err = r.call(http.MethodPut, domainProject,
+				fmt.Sprintf("/registry/microservices/%s/instances/%s/heartbeat", remoteId, synced.remoteInstanceId),
+				nil, nil)
+			if err == nil {
+				continue
+			}
+			if isNetworkError(err) {
+				return err
+			}
+			// 中心集群上的实例已过期，重新注册
+		}
+
+		remoteInstanceId, err := r.registerInstance(domainProject, remoteId, instance)
+		if err != nil {
+			if e, ok := err.(*scerr.Error); ok && e.Code == scerr.ErrServiceNotExists {
+				delete(r.remoteIds, domainProject+"/"+service.ServiceId)
+			}
+			return err
+		}
+		r.instances[key] = &syncedInstance{
+			domainProject:    domainProject,
+			localServiceId:   service.ServiceId,
+			remoteServiceId:  remoteId,
+			remoteInstanceId: remoteInstanceId,
+		}
+	}
+	return nil
+}
+
+// ensureService 返回中心集群上对应的serviceId，不存在则以本地serviceId创建
+func (r *Replicator) ensureService(domainProject string, service *pb.MicroService) (string, error) {
+	key := domainProject + "/" + service.ServiceId
+	if id, ok := r.remoteIds[key]; ok {
+		return id, nil
+	}
+
+	exist := &pb.GetExistenceResponse{}
+	err := r.call(http.MethodGet, domainProject,
+		fmt.Sprintf("/registry/existence?type=microservice&env=%s&appId=%s&serviceName=%s&version=%s",
+			url.QueryEscape(service.Environment), url.QueryEscape(service.AppId),
+			url.QueryEscape(service.ServiceName), url.QueryEscape(service.Version)),
+		nil, exist)
+	switch {
+	case err == nil && len(exist.ServiceId) > 0:
+		r.remoteIds[key] = exist.ServiceId
+		return exist.ServiceId, nil
+	case isNetworkError(err):
+		return "", err
+	}
+
+	copied := *service
+	copied.Timestamp, copied.ModTimestamp = "", ""
+	created := &pb.CreateServiceResponse{}
+	if err := r.call(http.MethodPost, domainProject, "/registry/microservices",
+		&pb.CreateServiceRequest{Service: &copied}, created); err != nil {
+		return "", err
+	}
+	r.remoteIds[key] = created.ServiceId
+	util.Logger().Infof("create service %s/%s/%s in central cluster, serviceId %s",
+		service.AppId, service.ServiceName, service.Version, created.ServiceId)
+	return created.ServiceId, nil
+}
+
+func (r *Replicator) registerInstance(domainProject, remoteId string, instance *pb.MicroServiceInstance) (string, error) {
+	copied := *instance
+	copied.ServiceId = remoteId
+	copied.Timestamp, copied.ModTimestamp = "", ""
+	copied.HealthCheck = &pb.HealthCheck{
+		Mode:     pb.CHECK_BY_HEARTBEAT,
+		Interval: int32(r.Interval / time.Second),
+		Times:    DEFAULT_RETRY_TIMES,
+	}
+	if copied.HealthCheck.Interval <= 0 {
+		copied.HealthCheck.Interval = 1
+	}
+
+	registered := &pb.RegisterInstanceResponse{}
+	if err := r.call(http.MethodPost, domainProject,
+		fmt.Sprintf("/registry/microservices/%s/instances", remoteId),
+		&pb.RegisterInstanceRequest{Instance: &copied}, registered); err != nil {
+		return "", err
+	}
+	util.Logger().Infof("register instance %s/%s to central cluster", domainProject, instance.InstanceId)
+	return registered.InstanceId, nil
+}
+
+// call 请求中心集群，业务错误返回*scerr.Error，其它错误视为网络错误
+func (r *Replicator) call(method, domainProject, path string, body, out interface{}) error {
+	domain, project := domainProject, apt.REGISTRY_PROJECT
+	if i := strings.Index(domainProject, "/"); i >= 0 {
+		domain, project = domainProject[:i], domainProject[i+1:]
+	}
+
+	resp, err := r.client.HttpDo(method, r.Addr+"/v4/"+project+path,
+		map[string]string{"X-Domain-Name": domain}, body)
+	if err != nil {
+		if len(r.Peer) > 0 && sctls.IsHandshakeError(err) {
+			sctls.RecordPeerFailure(r.Peer, sctls.PEER_FAILURE_HANDSHAKE, err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if len(r.Peer) > 0 {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			sctls.RecordPeerFailure(r.Peer, sctls.PEER_FAILURE_AUTH,
+				fmt.Errorf("%s %s, status code %d", method, path, resp.StatusCode))
+		default:
+			sctls.RecordPeerSuccess(r.Peer)
+		}
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		e := &scerr.Error{}
+		if resp.StatusCode >= http.StatusInternalServerError || json.Unmarshal(data, e) != nil || e.Code == 0 {
+			return fmt.Errorf("unexpected status code %d, %s", resp.StatusCode, util.BytesToStringWithNoCopy(data))
+		}
+		return e
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+func isNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.(*scerr.Error)
+	return !ok
+}
@@ -0,0 +1,197 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package uplink_test
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/admission/buildin"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/quota/buildin"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/etcd"
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/uuid/dynamic"
+	"github.com/apache/incubator-servicecomb-service-center/server/service"
+	"github.com/apache/incubator-servicecomb-service-center/server/service/uplink"
+	"golang.org/x/net/context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func getContext() context.Context {
+	ctx := context.TODO()
+	ctx = util.SetContext(ctx, "domain", "default")
+	ctx = util.SetContext(ctx, "project", "default")
+	ctx = util.SetContext(ctx, "noCache", "1")
+	return ctx
+}
+
+// central 模拟中心集群
+type central struct {
+	services   map[string]*pb.MicroService
+	instances  map[string]*pb.MicroServiceInstance
+	heartbeats int
+	lock       sync.Mutex
+}
+
+func (c *central) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	notExist := func(code int32) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(scerr.NewError(code, ""))
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/v4/default/registry")
+	switch {
+	case path == "/existence":
+		for id, s := range c.services {
+			if s.ServiceName == r.URL.Query().Get("serviceName") {
+				json.NewEncoder(w).Encode(&pb.GetExistenceResponse{ServiceId: id})
+				return
+			}
+		}
+		notExist(scerr.ErrServiceNotExists)
+	case path == "/microservices" && r.Method == http.MethodPost:
+		in := &pb.CreateServiceRequest{}
+		json.NewDecoder(r.Body).Decode(in)
+		c.services[in.Service.ServiceId] = in.Service
+		json.NewEncoder(w).Encode(&pb.CreateServiceResponse{ServiceId: in.Service.ServiceId})
+	case strings.HasSuffix(path, "/instances") && r.Method == http.MethodPost:
+		in := &pb.RegisterInstanceRequest{}
+		json.NewDecoder(r.Body).Decode(in)
+		if _, ok := c.services[in.Instance.ServiceId]; !ok {
+			notExist(scerr.ErrServiceNotExists)
+			return
+		}
+		c.instances[in.Instance.InstanceId] = in.Instance
+		json.NewEncoder(w).Encode(&pb.RegisterInstanceResponse{InstanceId: in.Instance.InstanceId})
+	case strings.HasSuffix(path, "/heartbeat"):
+		if _, ok := c.instances[strings.Split(path, "/")[4]]; !ok {
+			notExist(scerr.ErrInstanceNotExists)
+			return
+		}
+		c.heartbeats++
+	case r.Method == http.MethodDelete:
+		delete(c.instances, strings.Split(path, "/")[4])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestReplicator_Sync(t *testing.T) {
+	serviceResource, instanceResource := service.AssembleResources()
+
+	respCreate, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+		Service: &pb.MicroService{
+			AppId:       "uplink_group",
+			ServiceName: "uplink_service",
+			Version:     "1.0.0",
+			Level:       "FRONT",
+			Status:      pb.MS_UP,
+		},
+	})
+	if err != nil || respCreate.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("create service failed, %v", respCreate.Response)
+	}
+	serviceId := respCreate.ServiceId
+
+	respRegister, err := instanceResource.Register(getContext(), &pb.RegisterInstanceRequest{
+		Instance: &pb.MicroServiceInstance{
+			ServiceId: serviceId,
+			Endpoints: []string{"uplink:127.0.0.1:8080"},
+			HostName:  "UT-HOST",
+			Status:    pb.MSI_UP,
+		},
+	})
+	if err != nil || respRegister.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("register instance failed, %v", respRegister.Response)
+	}
+	instanceId := respRegister.InstanceId
+
+	c := &central{
+		services:  make(map[string]*pb.MicroService),
+		instances: make(map[string]*pb.MicroServiceInstance),
+	}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	r := uplink.NewReplicator(server.URL, []string{"uplink_group/uplink_service"}, time.Second)
+	if !r.Enabled() {
+		t.Fatalf("TestReplicator_Sync failed, replicator disabled")
+	}
+	if err := r.Sync(getContext()); err != nil {
+		t.Fatalf("TestReplicator_Sync failed, %s", err.Error())
+	}
+	if len(c.services) != 1 || c.services[serviceId] == nil {
+		t.Fatalf("TestReplicator_Sync failed, services %v", c.services)
+	}
+	inst, ok := c.instances[instanceId]
+	if !ok || inst.ServiceId != serviceId || inst.HealthCheck.Mode != pb.CHECK_BY_HEARTBEAT {
+		t.Fatalf("TestReplicator_Sync failed, instances %v", c.instances)
+	}
+
+	if err := r.Sync(getContext()); err != nil || c.heartbeats != 1 {
+		t.Fatalf("TestReplicator_Sync failed, heartbeats %d, %v", c.heartbeats, err)
+	}
+
+	// 中心集群上的实例过期后重新注册
+	delete(c.instances, instanceId)
+	if err := r.Sync(getContext()); err != nil || c.instances[instanceId] == nil {
+		t.Fatalf("TestReplicator_Sync failed, instance not registered again, %v", err)
+	}
+
+	respUnregister, err := instanceResource.Unregister(getContext(), &pb.UnregisterInstanceRequest{
+		ServiceId:  serviceId,
+		InstanceId: instanceId,
+	})
+	if err != nil || respUnregister.Response.Code != pb.Response_SUCCESS {
+		t.Fatalf("unregister instance failed, %v", respUnregister.Response)
+	}
+	if err := r.Sync(getContext()); err != nil || len(c.instances) != 0 {
+		t.Fatalf("TestReplicator_Sync failed, instances %v, %v", c.instances, err)
+	}
+
+	server.Close()
+	if err := r.Sync(getContext()); err != nil {
+		t.Fatalf("TestReplicator_Sync failed, no instance should be synced, %v", err)
+	}
+}
+
+func TestReplicator_Selected(t *testing.T) {
+	r := uplink.NewReplicator("", []string{"a/x", "y", ""}, 0)
+	if r.Enabled() || r.Interval != uplink.DEFAULT_SYNC_INTERVAL {
+		t.Fatalf("TestReplicator_Selected failed")
+	}
+	for _, c := range []struct {
+		appId, serviceName string
+		expect             bool
+	}{
+		{"a", "x", true},
+		{"b", "x", false},
+		{"b", "y", true},
+		{"default", "SERVICECENTER", false},
+	} {
+		if r.Selected(&pb.MicroService{AppId: c.appId, ServiceName: c.serviceName}) != c.expect {
+			t.Fatalf("TestReplicator_Selected %s/%s failed", c.appId, c.serviceName)
+		}
+	}
+}
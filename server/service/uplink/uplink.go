@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package uplink
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/astaxie/beego"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_SYNC_INTERVAL = 30 * time.Second
+	// 中心集群上的实例在连续丢失这么多次同步后过期
+	DEFAULT_RETRY_TIMES = 3
+)
+
+var replicator *Replicator
+
+func init() {
+	interval := time.Duration(beego.AppConfig.DefaultInt64("uplink_interval", 0)) * time.Second
+	replicator = NewReplicator(
+		beego.AppConfig.String("uplink_addr"),
+		strings.Split(beego.AppConfig.String("uplink_services"), ","),
+		interval)
+	if peer := beego.AppConfig.String("uplink_peer"); len(peer) > 0 {
+		replicator.UsePeer(peer)
+	}
+	if !replicator.Enabled() {
+		return
+	}
+	util.Logger().Infof("uplink replication enabled, central cluster %s, peer %s, interval %s",
+		replicator.Addr, replicator.Peer, replicator.Interval)
+}
+
+func GetReplicator() *Replicator {
+	return replicator
+}
+
+// Run 启动对端CA的同步，中心集群也需要信任边缘集群的CA；启用上行同步时启动同步任务
+func Run() {
+	util.Go(runPeerSync)
+	if !replicator.Enabled() {
+		return
+	}
+	util.Go(replicator.run)
+}
@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+)
+
+// EndpointFamily 返回endpoint地址的地址族，地址为域名等非IP时返回空
+func EndpointFamily(endpoint string) string {
+	ip := EndpointIP(endpoint)
+	switch {
+	case ip == nil:
+		return ""
+	case ip.To4() != nil:
+		return pb.ADDRESS_FAMILY_IPV4
+	default:
+		return pb.ADDRESS_FAMILY_IPV6
+	}
+}
+
+// ApplyAddressFamily 只保留指定地址族和域名的endpoint，双栈实例返回裁剪后的副本，
+// 没有可用endpoint的实例被过滤，family为空时不过滤
+func ApplyAddressFamily(family string, instances []*pb.MicroServiceInstance) []*pb.MicroServiceInstance {
+	if len(family) == 0 || len(instances) == 0 {
+		return instances
+	}
+	matched := make([]*pb.MicroServiceInstance, 0, len(instances))
+	for _, instance := range instances {
+		endpoints := make([]string, 0, len(instance.Endpoints))
+		for _, ep := range instance.Endpoints {
+			if f := EndpointFamily(ep); len(f) == 0 || f == family {
+				endpoints = append(endpoints, ep)
+			}
+		}
+		if len(endpoints) == 0 && len(instance.Endpoints) > 0 {
+			continue
+		}
+		if len(endpoints) < len(instance.Endpoints) {
+			instance = keepEndpoints(instance, endpoints)
+		}
+		matched = append(matched, instance)
+	}
+	return matched
+}
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func TestEndpointFamily(t *testing.T) {
+	cases := map[string]string{
+		"rest://127.0.0.1:8080":         pb.ADDRESS_FAMILY_IPV4,
+		"rest://[2001:db8::1]:8080":     pb.ADDRESS_FAMILY_IPV6,
+		"rest://[::ffff:10.0.0.1]:8080": pb.ADDRESS_FAMILY_IPV4,
+		"rest://svc.local:8080":         "",
+	}
+	for ep, family := range cases {
+		if f := serviceUtil.EndpointFamily(ep); f != family {
+			fmt.Printf(`EndpointFamily %s failed, got %s`, ep, f)
+			t.FailNow()
+		}
+	}
+}
+
+func TestApplyAddressFamily(t *testing.T) {
+	instances := []*pb.MicroServiceInstance{
+		{InstanceId: "1", Endpoints: []string{"rest://127.0.0.1:8080"}},
+		{InstanceId: "2", Endpoints: []string{"rest://127.0.0.2:8080", "rest://[2001:db8::2]:8080"}},
+		{InstanceId: "3", Endpoints: []string{"rest://[2001:db8::3]:8080"}},
+		{InstanceId: "4", Endpoints: []string{"rest://svc.local:8080"}},
+	}
+
+	result := serviceUtil.ApplyAddressFamily("", instances)
+	if len(result) != 4 {
+		fmt.Printf(`ApplyAddressFamily with empty family failed`)
+		t.FailNow()
+	}
+
+	result = serviceUtil.ApplyAddressFamily(pb.ADDRESS_FAMILY_IPV6, instances)
+	if len(result) != 3 || result[0].InstanceId != "2" || len(result[0].Endpoints) != 1 ||
+		result[0].Endpoints[0] != "rest://[2001:db8::2]:8080" || result[2].InstanceId != "4" {
+		fmt.Printf(`ApplyAddressFamily with ipv6 failed`)
+		t.FailNow()
+	}
+	// 原实例不被修改
+	if len(instances[1].Endpoints) != 2 {
+		fmt.Printf(`ApplyAddressFamily modified the origin instance`)
+		t.FailNow()
+	}
+
+	result = serviceUtil.ApplyAddressFamily(pb.ADDRESS_FAMILY_IPV4, instances)
+	if len(result) != 3 || result[1].InstanceId != "2" || len(result[1].Endpoints) != 1 {
+		fmt.Printf(`ApplyAddressFamily with ipv4 failed`)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"strings"
+)
+
+var admissionDomains map[string]struct{}
+
+func init() {
+	for _, d := range strings.Split(beego.AppConfig.DefaultString("admission_domains", ""), ",") {
+		d = strings.TrimSpace(d)
+		if len(d) == 0 {
+			continue
+		}
+		if admissionDomains == nil {
+			admissionDomains = make(map[string]struct{})
+		}
+		admissionDomains[d] = struct{}{}
+	}
+}
+
+func needAdmit(ctx context.Context, domainProject string) bool {
+	if apt.IsSCInstance(ctx) {
+		return false
+	}
+	if admissionDomains == nil {
+		return true
+	}
+	_, ok := admissionDomains[strings.Split(domainProject, "/")[0]]
+	return ok
+}
+
+func AdmitService(ctx context.Context, domainProject string, in *pb.CreateServiceRequest) *scerr.Error {
+	if !needAdmit(ctx, domainProject) {
+		return nil
+	}
+	if err := plugin.Plugins().Admission().AdmitService(ctx, domainProject, in); err != nil {
+		util.Logger().Warnf(nil, "service is rejected by admission, %s: %s", domainProject, err.Error())
+		return err
+	}
+	if in.Service == nil {
+		return scerr.NewError(scerr.ErrAdmissionDenied, "service is removed by admission")
+	}
+	return nil
+}
+
+func AdmitInstance(ctx context.Context, domainProject string, in *pb.RegisterInstanceRequest) *scerr.Error {
+	if !needAdmit(ctx, domainProject) {
+		return nil
+	}
+	if err := plugin.Plugins().Admission().AdmitInstance(ctx, domainProject, in); err != nil {
+		util.Logger().Warnf(nil, "instance is rejected by admission, %s: %s", domainProject, err.Error())
+		return err
+	}
+	if in.Instance == nil {
+		return scerr.NewError(scerr.ErrAdmissionDenied, "instance is removed by admission")
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/uuid"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// token格式为sc.{keyId}.{secret}
+	API_KEY_TOKEN_PREFIX = "sc"
+	// 轮换后旧secret的最大宽限期(秒)
+	MAX_API_KEY_GRACE_PERIOD int64 = 86400
+	// 鉴权时缓存密钥的时间，其他节点上的轮换和删除最多延迟该时间生效
+	API_KEY_CACHE_TTL = 30 * time.Second
+
+	// 请求上下文中通过鉴权的keyId
+	CTX_API_KEY = "x-api-key-id"
+)
+
+var (
+	ErrInvalidApiKey = errors.New("Invalid API key.")
+	ErrExpiredApiKey = errors.New("API key has expired.")
+
+	apiKeyCache = &apiKeyRecordCache{records: make(map[string]*cachedApiKeyRecord)}
+)
+
+// ApiKeyRecord etcd中保存的API密钥，只保存secret的摘要
+type ApiKeyRecord struct {
+	*pb.ApiKey
+	SecretHash              string `json:"secretHash"`
+	PreviousSecretHash      string `json:"previousSecretHash,omitempty"`
+	PreviousExpireTimestamp string `json:"previousExpireTimestamp,omitempty"`
+}
+
+type cachedApiKeyRecord struct {
+	record   *ApiKeyRecord
+	loadTime time.Time
+}
+
+type apiKeyRecordCache struct {
+	records map[string]*cachedApiKeyRecord
+	lock    sync.RWMutex
+}
+
+func (c *apiKeyRecordCache) Get(keyId string) *ApiKeyRecord {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	cached, ok := c.records[keyId]
+	if !ok || time.Since(cached.loadTime) > API_KEY_CACHE_TTL {
+		return nil
+	}
+	return cached.record
+}
+
+func (c *apiKeyRecordCache) Set(keyId string, record *ApiKeyRecord) {
+	c.lock.Lock()
+	c.records[keyId] = &cachedApiKeyRecord{record: record, loadTime: time.Now()}
+	c.lock.Unlock()
+}
+
+func (c *apiKeyRecordCache) Delete(keyId string) {
+	c.lock.Lock()
+	delete(c.records, keyId)
+	c.lock.Unlock()
+}
+
+func hashApiKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func newApiKeySecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func apiKeyToken(keyId, secret string) string {
+	return util.StringJoin([]string{API_KEY_TOKEN_PREFIX, keyId, secret}, ".")
+}
+
+// ParseApiKeyToken 解析token中的keyId和secret
+func ParseApiKeyToken(token string) (keyId, secret string, err error) {
+	arr := strings.Split(token, ".")
+	if len(arr) != 3 || arr[0] != API_KEY_TOKEN_PREFIX || len(arr[1]) == 0 || len(arr[2]) == 0 {
+		return "", "", ErrInvalidApiKey
+	}
+	return arr[1], arr[2], nil
+}
+
+func expireTimestamp(now time.Time, ttl int64) string {
+	if ttl <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(now.Add(time.Duration(ttl)*time.Second).Unix(), 10)
+}
+
+func isExpired(now time.Time, timestamp string) bool {
+	if len(timestamp) == 0 {
+		return false
+	}
+	t, err := strconv.ParseInt(timestamp, 10, 64)
+	return err != nil || now.Unix() >= t
+}
+
+func putApiKeyRecord(ctx context.Context, domainProject string, record *ApiKeyRecord, withIndex bool) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	opts := []registry.PluginOp{
+		registry.OpPut(registry.WithStrKey(apt.GenerateApiKeyKey(domainProject, record.KeyId)), registry.WithValue(data)),
+	}
+	if withIndex {
+		opts = append(opts, registry.OpPut(registry.WithStrKey(apt.GenerateApiKeyIndexKey(record.KeyId)),
+			registry.WithStrValue(domainProject)))
+	}
+	_, err = backend.Registry().Txn(ctx, opts)
+	return err
+}
+
+// CreateApiKey 创建domainProject范围的API密钥，ttl(秒)<=0表示永不过期，token只在创建时返回
+func CreateApiKey(ctx context.Context, domainProject, name string, ttl int64) (*pb.ApiKey, string, error) {
+	secret, err := newApiKeySecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	arr := strings.SplitN(domainProject, "/", 2)
+	record := &ApiKeyRecord{
+		ApiKey: &pb.ApiKey{
+			KeyId:           uuid.GenerateUuid(),
+			Name:            name,
+			Domain:          arr[0],
+			Project:         arr[1],
+			Timestamp:       strconv.FormatInt(now.Unix(), 10),
+			ExpireTimestamp: expireTimestamp(now, ttl),
+		},
+		SecretHash: hashApiKeySecret(secret),
+	}
+	if err := putApiKeyRecord(ctx, domainProject, record, true); err != nil {
+		return nil, "", err
+	}
+	return record.ApiKey, apiKeyToken(record.KeyId, secret), nil
+}
+
+// GetApiKeyRecord 查询API密钥，不存在时返回nil
+func GetApiKeyRecord(ctx context.Context, domainProject, keyId string) (*ApiKeyRecord, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateApiKeyKey(domainProject, keyId)))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	record := &ApiKeyRecord{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, record); err != nil || record.ApiKey == nil {
+		util.Logger().Errorf(err, "invalid api key %s/%s", domainProject, keyId)
+		return nil, ErrInvalidApiKey
+	}
+	return record, nil
+}
+
+// GetApiKeys 查询domainProject下的所有API密钥，包括已过期的
+func GetApiKeys(ctx context.Context, domainProject string) ([]*pb.ApiKey, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GetApiKeyRootKey(domainProject)+"/"),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*pb.ApiKey, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		record := &ApiKeyRecord{}
+		if err := json.Unmarshal(kv.Value, record); err != nil || record.ApiKey == nil {
+			util.Logger().Errorf(err, "invalid api key %s", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		keys = append(keys, record.ApiKey)
+	}
+	return keys, nil
+}
+
+// RotateApiKey 生成新的secret，旧secret在gracePeriod(秒)内仍然有效；
+// ttl>0时重新设置有效期，否则保持原有效期。密钥不存在时返回nil
+func RotateApiKey(ctx context.Context, domainProject, keyId string, ttl, gracePeriod int64) (*pb.ApiKey, string, error) {
+	record, err := GetApiKeyRecord(ctx, domainProject, keyId)
+	if err != nil || record == nil {
+		return nil, "", err
+	}
+	secret, err := newApiKeySecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	if gracePeriod > MAX_API_KEY_GRACE_PERIOD {
+		gracePeriod = MAX_API_KEY_GRACE_PERIOD
+	}
+	record.PreviousSecretHash, record.PreviousExpireTimestamp = "", ""
+	if gracePeriod > 0 {
+		record.PreviousSecretHash = record.SecretHash
+		record.PreviousExpireTimestamp = expireTimestamp(now, gracePeriod)
+	}
+	record.SecretHash = hashApiKeySecret(secret)
+	record.RotateTimestamp = strconv.FormatInt(now.Unix(), 10)
+	if ttl > 0 {
+		record.ExpireTimestamp = expireTimestamp(now, ttl)
+	}
+	if err := putApiKeyRecord(ctx, domainProject, record, false); err != nil {
+		return nil, "", err
+	}
+	apiKeyCache.Delete(keyId)
+	return record.ApiKey, apiKeyToken(keyId, secret), nil
+}
+
+// DeleteApiKey 吊销API密钥，返回密钥是否存在
+func DeleteApiKey(ctx context.Context, domainProject, keyId string) (bool, error) {
+	record, err := GetApiKeyRecord(ctx, domainProject, keyId)
+	if err != nil || record == nil {
+		return false, err
+	}
+	_, err = backend.Registry().Txn(ctx, []registry.PluginOp{
+		registry.OpDel(registry.WithStrKey(apt.GenerateApiKeyKey(domainProject, keyId))),
+		registry.OpDel(registry.WithStrKey(apt.GenerateApiKeyIndexKey(keyId))),
+	})
+	if err != nil {
+		return false, err
+	}
+	apiKeyCache.Delete(keyId)
+	return true, nil
+}
+
+func loadApiKeyRecord(ctx context.Context, keyId string) (*ApiKeyRecord, error) {
+	if record := apiKeyCache.Get(keyId); record != nil {
+		return record, nil
+	}
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateApiKeyIndexKey(keyId)))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, ErrInvalidApiKey
+	}
+	record, err := GetApiKeyRecord(ctx, util.BytesToStringWithNoCopy(resp.Kvs[0].Value), keyId)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, ErrInvalidApiKey
+	}
+	apiKeyCache.Set(keyId, record)
+	return record, nil
+}
+
+// VerifyApiKey 校验token，成功时返回密钥及其domain/project
+func VerifyApiKey(ctx context.Context, token string) (*pb.ApiKey, error) {
+	keyId, secret, err := ParseApiKeyToken(token)
+	if err != nil {
+		return nil, err
+	}
+	record, err := loadApiKeyRecord(ctx, keyId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	hash := []byte(hashApiKeySecret(secret))
+	switch {
+	case subtle.ConstantTimeCompare(hash, []byte(record.SecretHash)) == 1:
+	case len(record.PreviousSecretHash) > 0 &&
+		subtle.ConstantTimeCompare(hash, []byte(record.PreviousSecretHash)) == 1:
+		if isExpired(now, record.PreviousExpireTimestamp) {
+			return nil, ErrInvalidApiKey
+		}
+	default:
+		return nil, ErrInvalidApiKey
+	}
+	if isExpired(now, record.ExpireTimestamp) {
+		return nil, ErrExpiredApiKey
+	}
+	return record.ApiKey, nil
+}
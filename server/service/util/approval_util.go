@@ -0,0 +1,120 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"strconv"
+	"time"
+)
+
+// RequireApproval 提供者properties中requireApproval为true时，消费者需经提供者审批后才能发现
+func RequireApproval(provider *pb.MicroService) bool {
+	if provider == nil || provider.Properties == nil {
+		return false
+	}
+	v, err := strconv.ParseBool(provider.Properties[pb.PROP_REQUIRE_APPROVAL])
+	return err == nil && v
+}
+
+func PutDependencyApproval(ctx context.Context, domainProject, providerId, consumerId string) error {
+	key := apt.GenerateDependencyApprovalKey(domainProject, providerId, consumerId)
+	data, err := json.Marshal(&pb.DependencyApproval{
+		ConsumerServiceId: consumerId,
+		Status:            pb.APPROVAL_APPROVED,
+		Timestamp:         strconv.FormatInt(time.Now().Unix(), 10),
+	})
+	if err != nil {
+		util.Logger().Errorf(err, "put dependency approval %s: json marshal failed.", key)
+		return err
+	}
+
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(key),
+		registry.WithValue(data))
+	if err != nil {
+		util.Logger().Errorf(err, "put dependency approval %s: commit into etcd failed.", key)
+		return err
+	}
+	return nil
+}
+
+func GetDependencyApproval(ctx context.Context, domainProject, providerId, consumerId string) (*pb.DependencyApproval, error) {
+	key := apt.GenerateDependencyApprovalKey(domainProject, providerId, consumerId)
+	opts := append(FromContext(ctx), registry.WithStrKey(key))
+	resp, err := store.Store().DependencyApproval().Search(ctx, opts...)
+	if err != nil {
+		util.Logger().Errorf(err, "get dependency approval %s failed", key)
+		return nil, err
+	}
+
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	approval := &pb.DependencyApproval{}
+	err = json.Unmarshal(resp.Kvs[0].Value, approval)
+	if err != nil {
+		util.Logger().Errorf(err, "unmarshal dependency approval %s failed", key)
+		return nil, err
+	}
+	return approval, nil
+}
+
+// GetDependencyApprovals 返回依赖该提供者的所有消费者的审批状态，未审批的为PENDING
+func GetDependencyApprovals(ctx context.Context, domainProject string, provider *pb.MicroService) ([]*pb.DependencyApproval, error) {
+	consumers, err := NewProviderDependencyRelation(ctx, domainProject, provider.ServiceId, provider).GetDependencyConsumers()
+	if err != nil {
+		return nil, err
+	}
+
+	approvals := make([]*pb.DependencyApproval, 0, len(consumers))
+	for _, consumer := range consumers {
+		approval, err := GetDependencyApproval(ctx, domainProject, provider.ServiceId, consumer.ServiceId)
+		if err != nil {
+			return nil, err
+		}
+		if approval == nil {
+			approval = &pb.DependencyApproval{
+				ConsumerServiceId: consumer.ServiceId,
+				Status:            pb.APPROVAL_PENDING,
+			}
+		}
+		approval.Consumer = pb.MicroServiceToKey(domainProject, consumer)
+		approvals = append(approvals, approval)
+	}
+	return approvals, nil
+}
+
+// DependencyApproved 提供者无需审批或者已审批通过时返回true
+func DependencyApproved(ctx context.Context, domainProject string, provider *pb.MicroService, consumerId string) (bool, error) {
+	if !RequireApproval(provider) {
+		return true, nil
+	}
+	approval, err := GetDependencyApproval(ctx, domainProject, provider.ServiceId, consumerId)
+	if err != nil {
+		return false, err
+	}
+	return approval != nil, nil
+}
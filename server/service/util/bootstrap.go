@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"golang.org/x/net/context"
+)
+
+// HeartbeatHint 未携带healthCheck注册时使用的心跳参数，TTL超出[minTTL, maxTTL]时注册会被改写
+type HeartbeatHint struct {
+	Interval int32 `json:"interval"`
+	Times    int32 `json:"times"`
+	MinTTL   int32 `json:"minTTL,omitempty"`
+	MaxTTL   int32 `json:"maxTTL,omitempty"`
+}
+
+// RateLimitHint 0表示不限制，Requests为每个客户端IP在Per时间内允许的请求数
+type RateLimitHint struct {
+	Requests                     int64  `json:"requests"`
+	Per                          string `json:"per"`
+	WatchMaxSubscribersPerTenant int64  `json:"watchMaxSubscribersPerTenant"`
+}
+
+// WatchHint websocket订阅地址，serviceId未知时地址中保留{serviceId}
+type WatchHint struct {
+	Watcher     string `json:"watcher"`
+	ListWatcher string `json:"listWatcher"`
+}
+
+// ClientBootstrap 客户端启动时需要的配置，一次请求代替服务查询、租约策略、特性开关等多次请求
+type ClientBootstrap struct {
+	ServiceId string          `json:"serviceId,omitempty"`
+	Heartbeat *HeartbeatHint  `json:"heartbeat"`
+	Watch     *WatchHint      `json:"watch,omitempty"`
+	RateLimit *RateLimitHint  `json:"rateLimit"`
+	Features  map[string]bool `json:"features"`
+}
+
+// HeartbeatHintOf 按租户的租约策略计算心跳参数，查询失败时返回默认值
+func HeartbeatHintOf(ctx context.Context, domainProject string) *HeartbeatHint {
+	hint := &HeartbeatHint{
+		Interval: apt.REGISTRY_DEFAULT_LEASE_RENEWALINTERVAL,
+		Times:    apt.REGISTRY_DEFAULT_LEASE_RETRYTIMES,
+	}
+	p, err := FindLeasePolicy(ctx, domainProject)
+	if err != nil {
+		util.Logger().Errorf(err, "find lease policy of %s failed, use the default heartbeat", domainProject)
+		return hint
+	}
+	if p == nil {
+		return hint
+	}
+	hc := &pb.HealthCheck{}
+	p.Apply(hc, false)
+	hint.Interval, hint.Times = hc.Interval, hc.Times
+	hint.MinTTL, hint.MaxTTL = p.MinTTL, p.MaxTTL
+	return hint
+}
+
+// RateLimitHintOf 按IP的请求限流和租户的watch数上限
+func RateLimitHintOf() *RateLimitHint {
+	config := apt.ServerInfo.Config
+	return &RateLimitHint{
+		Requests:                     config.LimitConnections,
+		Per:                          config.LimitTTLUnit,
+		WatchMaxSubscribersPerTenant: config.WatchMaxSubscribersPerTenant,
+	}
+}
+
+// GetClientBootstrap 返回与请求地址无关的启动配置
+func GetClientBootstrap(ctx context.Context, domainProject, serviceId string) *ClientBootstrap {
+	return &ClientBootstrap{
+		ServiceId: serviceId,
+		Heartbeat: HeartbeatHintOf(ctx, domainProject),
+		RateLimit: RateLimitHintOf(),
+		Features:  TenantFeatures(ctx, domainProject),
+	}
+}
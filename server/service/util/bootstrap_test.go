@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"strings"
+	"testing"
+)
+
+func TestGetClientBootstrap(t *testing.T) {
+	config := *core.ServerInfo.Config
+	defer func() {
+		*core.ServerInfo.Config = config
+	}()
+	core.ServerInfo.Config.LimitConnections = 100
+	core.ServerInfo.Config.LimitTTLUnit = "s"
+	core.ServerInfo.Config.WatchMaxSubscribersPerTenant = 5
+
+	// 查询租约策略失败时使用默认的心跳参数
+	b := serviceUtil.GetClientBootstrap(context.Background(), "default/default", "1")
+	if b.ServiceId != "1" || b.Heartbeat.Interval != core.REGISTRY_DEFAULT_LEASE_RENEWALINTERVAL ||
+		b.Heartbeat.Times != core.REGISTRY_DEFAULT_LEASE_RETRYTIMES {
+		fmt.Printf(`GetClientBootstrap default heartbeat failed, %v`, b.Heartbeat)
+		t.FailNow()
+	}
+	if b.RateLimit.Requests != 100 || b.RateLimit.Per != "s" || b.RateLimit.WatchMaxSubscribersPerTenant != 5 {
+		fmt.Printf(`GetClientBootstrap rate limit failed, %v`, b.RateLimit)
+		t.FailNow()
+	}
+	if _, ok := b.Features[serviceUtil.FEATURE_NO_IMPLICIT_DEPENDENCY]; !ok {
+		fmt.Printf(`GetClientBootstrap features failed, %v`, b.Features)
+		t.FailNow()
+	}
+
+	// 服务未注册时不返回serviceId，watch地址由controller按请求地址填充
+	b = serviceUtil.GetClientBootstrap(context.Background(), "default/default", "")
+	data, err := json.Marshal(b)
+	if err != nil || strings.Contains(string(data), `"serviceId"`) || strings.Contains(string(data), `"watch"`) {
+		fmt.Printf(`GetClientBootstrap without service failed, %s`, data)
+		t.FailNow()
+	}
+}
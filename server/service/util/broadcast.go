@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"golang.org/x/net/context"
+	"net/http"
+	"time"
+)
+
+const BROADCAST_WEBHOOK_TIMEOUT = 5 * time.Second
+
+var broadcastClient = &http.Client{Timeout: BROADCAST_WEBHOOK_TIMEOUT}
+
+// BroadcastWebhookEvent 推送给消费者webhook的通知
+type BroadcastWebhookEvent struct {
+	ConsumerId string               `json:"consumerId"`
+	Provider   *pb.MicroServiceKey  `json:"provider"`
+	Message    *pb.BroadcastMessage `json:"message"`
+}
+
+// PostBroadcastWebhooks 异步地向配置了broadcastWebhook属性的消费者推送通知，失败只记录日志
+func PostBroadcastWebhooks(ctx context.Context, domainProject string, provider *pb.MicroServiceKey,
+	message *pb.BroadcastMessage, consumerIds []string) {
+	for _, consumerId := range consumerIds {
+		consumer, err := GetServiceInCache(ctx, domainProject, consumerId)
+		if err != nil || consumer == nil {
+			continue
+		}
+		webhook := consumer.Properties[pb.PROP_BROADCAST_WEBHOOK]
+		if len(webhook) == 0 {
+			continue
+		}
+		event := &BroadcastWebhookEvent{ConsumerId: consumerId, Provider: provider, Message: message}
+		go func() {
+			if err := postBroadcastWebhook(webhook, event); err != nil {
+				util.Logger().Errorf(err, "post broadcast %s to consumer %s webhook failed",
+					event.Message.Id, event.ConsumerId)
+			}
+		}()
+	}
+}
+
+func postBroadcastWebhook(webhook string, event *BroadcastWebhookEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	resp, err := broadcastClient.Post(webhook, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook returns %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"strconv"
+	"time"
+)
+
+const (
+	CTX_DELEGATED_BY = "delegated-by"
+
+	// 审计记录保留7天，到期由租约自动删除
+	DELEGATION_AUDIT_TTL = 7 * 24 * 3600
+	// 查询时只返回最近的审计记录
+	DELEGATION_AUDIT_LIMIT = 100
+
+	DELEGATION_RESULT_SUCCESS = "success"
+	DELEGATION_RESULT_DENIED  = "denied"
+	DELEGATION_RESULT_FAILED  = "failed"
+)
+
+// SetDelegatedBy 将代理注册的控制器服务ID写入实例的保留属性，非代理注册时清除客户端提交的值
+func SetDelegatedBy(ctx context.Context, instance *pb.MicroServiceInstance) {
+	controllerId, _ := util.FromContext(ctx, CTX_DELEGATED_BY).(string)
+	if len(controllerId) == 0 {
+		if instance.Properties != nil {
+			delete(instance.Properties, pb.PROP_DELEGATED_BY)
+		}
+		return
+	}
+	if instance.Properties == nil {
+		instance.Properties = make(map[string]string)
+	}
+	instance.Properties[pb.PROP_DELEGATED_BY] = controllerId
+}
+
+func PutDelegationGrant(ctx context.Context, domainProject, serviceId, controllerId string) error {
+	key := apt.GenerateDelegationKey(domainProject, serviceId, controllerId)
+	data, err := json.Marshal(&pb.DelegationGrant{
+		ControllerServiceId: controllerId,
+		Timestamp:           strconv.FormatInt(time.Now().Unix(), 10),
+	})
+	if err != nil {
+		util.Logger().Errorf(err, "put delegation grant %s: json marshal failed.", key)
+		return err
+	}
+
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(key),
+		registry.WithValue(data))
+	if err != nil {
+		util.Logger().Errorf(err, "put delegation grant %s: commit into etcd failed.", key)
+		return err
+	}
+	return nil
+}
+
+func GetDelegationGrant(ctx context.Context, domainProject, serviceId, controllerId string) (*pb.DelegationGrant, error) {
+	key := apt.GenerateDelegationKey(domainProject, serviceId, controllerId)
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(key))
+	if err != nil {
+		util.Logger().Errorf(err, "get delegation grant %s failed", key)
+		return nil, err
+	}
+
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	grant := &pb.DelegationGrant{}
+	err = json.Unmarshal(resp.Kvs[0].Value, grant)
+	if err != nil {
+		util.Logger().Errorf(err, "unmarshal delegation grant %s failed", key)
+		return nil, err
+	}
+	return grant, nil
+}
+
+// GetDelegationGrants 返回服务授权的所有控制器，已删除的控制器仍然返回但不带服务信息
+func GetDelegationGrants(ctx context.Context, domainProject, serviceId string) ([]*pb.DelegationGrant, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateDelegationKey(domainProject, serviceId, "")),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make([]*pb.DelegationGrant, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		grant := &pb.DelegationGrant{}
+		if err := json.Unmarshal(kv.Value, grant); err != nil {
+			util.Logger().Errorf(err, "invalid delegation grant %s", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		controller, err := GetService(ctx, domainProject, grant.ControllerServiceId)
+		if err != nil {
+			return nil, err
+		}
+		if controller != nil {
+			grant.Controller = pb.MicroServiceToKey(domainProject, controller)
+		}
+		grants = append(grants, grant)
+	}
+	return grants, nil
+}
+
+// RecordDelegationAudit 记录一次代理注册/注销，记录失败不影响请求结果
+func RecordDelegationAudit(ctx context.Context, domainProject string, audit *pb.DelegationAudit) {
+	now := time.Now()
+	audit.Operator = util.GetIPFromContext(ctx)
+	audit.Timestamp = strconv.FormatInt(now.Unix(), 10)
+
+	util.Logger().Infof("delegation audit: controller %s %s instance %s/%s, result %s, operator %s.",
+		audit.ControllerServiceId, audit.Action, audit.ServiceId, audit.InstanceId, audit.Result, audit.Operator)
+
+	data, err := json.Marshal(audit)
+	if err != nil {
+		util.Logger().Errorf(err, "record delegation audit failed: json marshal failed.")
+		return
+	}
+	leaseID, err := backend.Registry().LeaseGrant(ctx, DELEGATION_AUDIT_TTL)
+	if err != nil {
+		util.Logger().Errorf(err, "record delegation audit failed: grant lease failed.")
+		return
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(apt.GenerateDelegationAuditKey(domainProject, audit.ServiceId,
+			strconv.FormatInt(now.UnixNano(), 10))),
+		registry.WithValue(data),
+		registry.WithLease(leaseID))
+	if err != nil {
+		util.Logger().Errorf(err, "record delegation audit failed: commit into etcd failed.")
+	}
+}
+
+// GetDelegationAudits 按时间顺序返回服务最近的代理注册/注销记录
+func GetDelegationAudits(ctx context.Context, domainProject, serviceId string) ([]*pb.DelegationAudit, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateDelegationAuditKey(domainProject, serviceId, "")),
+		registry.WithPrefix(),
+		registry.WithAscendOrder())
+	if err != nil {
+		return nil, err
+	}
+
+	kvs := resp.Kvs
+	if len(kvs) > DELEGATION_AUDIT_LIMIT {
+		kvs = kvs[len(kvs)-DELEGATION_AUDIT_LIMIT:]
+	}
+	audits := make([]*pb.DelegationAudit, 0, len(kvs))
+	for _, kv := range kvs {
+		audit := &pb.DelegationAudit{}
+		if err := json.Unmarshal(kv.Value, audit); err != nil {
+			util.Logger().Errorf(err, "invalid delegation audit %s", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		audits = append(audits, audit)
+	}
+	return audits, nil
+}
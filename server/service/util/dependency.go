@@ -30,7 +30,9 @@ import (
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
 	"github.com/apache/incubator-servicecomb-service-center/server/mux"
 	"golang.org/x/net/context"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -122,19 +124,19 @@ func GetConsumerIds(ctx context.Context, domainProject string, provider *pb.Micr
 	}
 
 	//todo 删除服务，最后实例推送有误差
-	providerRules, err := GetRulesUtil(util.SetContext(util.CloneContext(ctx), "cacheOnly", "1"),
+	matcher, err := GetRuleMatcher(util.SetContext(util.CloneContext(ctx), "cacheOnly", "1"),
 		domainProject, provider.ServiceId)
 	if err != nil {
 		return nil, nil, err
 	}
-	if len(providerRules) == 0 {
+	if matcher.Empty() && !RequireApproval(provider) {
 		return getConsumerIdsWithFilter(ctx, domainProject, provider.ServiceId, provider, noFilter)
 	}
 
 	rf := RuleFilter{
 		DomainProject: domainProject,
 		Provider:      provider,
-		ProviderRules: providerRules,
+		Matcher:       matcher,
 	}
 
 	allow, deny, err = getConsumerIdsWithFilter(ctx, domainProject, provider.ServiceId, provider, rf.Filter)
@@ -198,17 +200,17 @@ func GetProviderIdsByConsumerId(ctx context.Context, domainProject, consumerId s
 		if provider == nil {
 			continue
 		}
-		providerRules, err := GetRulesUtil(copyCtx, domainProject, provider.ServiceId)
+		matcher, err := GetRuleMatcher(copyCtx, domainProject, provider.ServiceId)
 		if err != nil {
 			return nil, nil, err
 		}
-		if len(providerRules) == 0 {
+		if matcher.Empty() && !RequireApproval(provider) {
 			providerIds[allowIdx] = providerId
 			allowIdx++
 			continue
 		}
 		rf.Provider = provider
-		rf.ProviderRules = providerRules
+		rf.Matcher = matcher
 		ok, err := rf.Filter(ctx, consumerId)
 		if err != nil {
 			return nil, nil, err
@@ -259,7 +261,17 @@ func AddServiceVersionRule(ctx context.Context, domainProject string, provider *
 	return err
 }
 
+// createDependencyRuleForFind 调用方需持有全局锁
+func createDependencyRuleForFind(ctx context.Context, domainProject string, provider *pb.MicroServiceKey, consumer *pb.MicroServiceKey) error {
+	exist, err := ProviderDependencyRuleExist(ctx, domainProject, provider, consumer)
+	if exist || err != nil {
+		return err
+	}
+	return CreateDependencyRuleForFind(ctx, domainProject, provider, consumer)
+}
+
 func DeleteDependencyForService(ctx context.Context, consumer *pb.MicroServiceKey, serviceId string) ([]registry.PluginOp, error) {
+	dependencyWriter.Reset()
 	ops := []registry.PluginOp{}
 	opsTmps := []registry.PluginOp{}
 	domainProject := consumer.Tenant
@@ -499,42 +511,61 @@ func syncDependencyRule(ctx context.Context, dep *Dependency, filter func(contex
 	consumerFlag := strings.Join([]string{dep.Consumer.AppId, dep.Consumer.ServiceName, dep.Consumer.Version}, "/")
 
 	newDependencyRuleList, existDependencyRuleList, deleteDependencyRuleList := filter(ctx, dep)
+	newDependencyRuleList, existDependencyRuleList, deleteDependencyRuleList = normalizeSyncRules(dep,
+		newDependencyRuleList, existDependencyRuleList, deleteDependencyRuleList)
 	if len(newDependencyRuleList) == 0 && len(existDependencyRuleList) == 0 && len(deleteDependencyRuleList) == 0 {
 		return nil
 	}
 
+	// provider和consumer的规则分多步写入，开始后不随请求取消而中断，避免两边规则不一致
+	wctx, cancel := context.WithTimeout(detachedContext{ctx}, DEPENDENCY_RULE_WRITE_TIMEOUT)
+	defer cancel()
+
 	dep.err = make(chan error, 5)
 	dep.chanNum = 0
 	if len(deleteDependencyRuleList) != 0 {
 		util.Logger().Infof("Delete dependency rule remove for consumer %s, %v, ", consumerFlag, deleteDependencyRuleList)
 		dep.removedDependencyRuleList = deleteDependencyRuleList
-		dep.RemoveConsumerOfProviderRule()
+		dep.RemoveConsumerOfProviderRule(wctx)
 	}
 
 	if len(newDependencyRuleList) != 0 {
 		util.Logger().Infof("New dependency rule add for consumer %s, %v, ", consumerFlag, newDependencyRuleList)
 		dep.NewDependencyRuleList = newDependencyRuleList
-		dep.AddConsumerOfProviderRule()
+		dep.AddConsumerOfProviderRule(wctx)
 	}
 
 	conKey := apt.GenerateConsumerDependencyRuleKey(dep.DomainProject, dep.Consumer)
-	err := dep.UpdateProvidersRuleOfConsumer(conKey)
-	if err != nil {
-		return err
-	}
+	err := dep.UpdateProvidersRuleOfConsumer(wctx, conKey)
 
+	// 等待provider规则写完再返回，避免cancel中断仍在进行的写入
 	if dep.chanNum != 0 {
 		for tmpErr := range dep.err {
 			dep.chanNum--
-			if tmpErr != nil {
-				return tmpErr
+			if tmpErr != nil && err == nil {
+				err = tmpErr
 			}
 			if 0 == dep.chanNum {
 				close(dep.err)
 			}
 		}
 	}
-	return nil
+	return err
+}
+
+// 依赖规则多步写入的超时时间
+const DEPENDENCY_RULE_WRITE_TIMEOUT = 30 * time.Second
+
+// detachedContext 保留请求上下文中的值，但不继承其期限和取消
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
 }
 
 func AddDependencyRule(ctx context.Context, dep *Dependency) error {
@@ -542,7 +573,9 @@ func AddDependencyRule(ctx context.Context, dep *Dependency) error {
 }
 
 func CreateDependencyRule(ctx context.Context, dep *Dependency) error {
-	return syncDependencyRule(ctx, dep, parseOverrideRules)
+	err := syncDependencyRule(ctx, dep, parseOverrideRules)
+	dependencyWriter.Reset()
+	return err
 }
 
 func CreateDependencyRuleForFind(ctx context.Context, domainProject string, provider *pb.MicroServiceKey, consumer *pb.MicroServiceKey) error {
@@ -735,25 +768,26 @@ func validateMicroServiceKey(in *pb.MicroServiceKey, fuzzyMatch bool) error {
 	}
 }
 
-func BadParamsResponse(detailErr string) *pb.CreateDependenciesResponse {
+func BadParamsResponse(detailErr string, details ...*pb.ErrorDetail) *pb.CreateDependenciesResponse {
 	util.Logger().Errorf(nil, "Request params is invalid.")
 	if len(detailErr) == 0 {
 		detailErr = "Request params is invalid."
 	}
 	return &pb.CreateDependenciesResponse{
-		Response: pb.CreateResponse(scerr.ErrInvalidParams, detailErr),
+		Response: pb.CreateResponseWithDetails(scerr.ErrInvalidParams, detailErr, details...),
 	}
 }
 
 func ParamsChecker(consumerInfo *pb.MicroServiceKey, providersInfo []*pb.MicroServiceKey) *pb.CreateDependenciesResponse {
 	if err := validateMicroServiceKey(consumerInfo, false); err != nil {
-		return BadParamsResponse(err.Error())
+		return BadParamsResponse(err.Error(), scerr.ValidationDetails("consumer", err)...)
 	}
 	if providersInfo == nil {
-		return BadParamsResponse("Invalid request body for provider info.")
+		return BadParamsResponse("Invalid request body for provider info.",
+			scerr.NewDetail(scerr.ErrInvalidParams, "providers", "providers is required"))
 	}
 	flag := make(map[string]bool, len(providersInfo))
-	for _, providerInfo := range providersInfo {
+	for i, providerInfo := range providersInfo {
 		//存在带*的情况，后面的数据就不校验了
 		if providerInfo.ServiceName == "*" {
 			util.Logger().Debugf("%s 's provider contains *.", consumerInfo.ServiceName)
@@ -762,14 +796,16 @@ func ParamsChecker(consumerInfo *pb.MicroServiceKey, providersInfo []*pb.MicroSe
 		if len(providerInfo.AppId) == 0 {
 			providerInfo.AppId = consumerInfo.AppId
 		}
+		field := "providers[" + strconv.Itoa(i) + "]"
 		if err := validateMicroServiceKey(providerInfo, true); err != nil {
-			return BadParamsResponse(err.Error())
+			return BadParamsResponse(err.Error(), scerr.ValidationDetails(field, err)...)
 		}
 
 		version := providerInfo.Version
 		providerInfo.Version = ""
 		if _, ok := flag[toString(providerInfo)]; ok {
-			return BadParamsResponse("Invalid request body for provider info.Duplicate provider or (serviceName and appid is same).")
+			return BadParamsResponse("Invalid request body for provider info.Duplicate provider or (serviceName and appid is same).",
+				scerr.NewDetail(scerr.ErrInvalidParams, field, "duplicate provider"))
 		} else {
 			flag[toString(providerInfo)] = true
 		}
@@ -789,13 +825,12 @@ type Dependency struct {
 	ProvidersRule             []*pb.MicroServiceKey
 }
 
-func (dep *Dependency) RemoveConsumerOfProviderRule() {
+func (dep *Dependency) RemoveConsumerOfProviderRule(ctx context.Context) {
 	dep.chanNum++
-	go dep.removeConsumerOfProviderRule()
+	go dep.removeConsumerOfProviderRule(ctx)
 }
 
-func (dep *Dependency) removeConsumerOfProviderRule() {
-	ctx := context.TODO()
+func (dep *Dependency) removeConsumerOfProviderRule(ctx context.Context) {
 	opts := make([]registry.PluginOp, 0, len(dep.removedDependencyRuleList))
 	for _, providerRule := range dep.removedDependencyRuleList {
 		proProkey := apt.GenerateProviderDependencyRuleKey(dep.DomainProject, providerRule)
@@ -837,13 +872,12 @@ func (dep *Dependency) removeConsumerOfProviderRule() {
 	dep.err <- nil
 }
 
-func (dep *Dependency) AddConsumerOfProviderRule() {
+func (dep *Dependency) AddConsumerOfProviderRule(ctx context.Context) {
 	dep.chanNum++
-	go dep.addConsumerOfProviderRule()
+	go dep.addConsumerOfProviderRule(ctx)
 }
 
-func (dep *Dependency) addConsumerOfProviderRule() {
-	ctx := context.TODO()
+func (dep *Dependency) addConsumerOfProviderRule(ctx context.Context) {
 	opts := []registry.PluginOp{}
 	for _, prividerRule := range dep.NewDependencyRuleList {
 		proProkey := apt.GenerateProviderDependencyRuleKey(dep.DomainProject, prividerRule)
@@ -877,7 +911,7 @@ func (dep *Dependency) addConsumerOfProviderRule() {
 	dep.err <- nil
 }
 
-func (dep *Dependency) UpdateProvidersRuleOfConsumer(conKey string) error {
+func (dep *Dependency) UpdateProvidersRuleOfConsumer(ctx context.Context, conKey string) error {
 	dependency := &pb.MicroServiceDependency{
 		Dependency: dep.ProvidersRule,
 	}
@@ -886,7 +920,7 @@ func (dep *Dependency) UpdateProvidersRuleOfConsumer(conKey string) error {
 		util.Logger().Errorf(nil, "Marshal tmpValue fialed.")
 		return err
 	}
-	_, err = backend.Registry().Do(context.TODO(),
+	_, err = backend.Registry().Do(ctx,
 		registry.PUT,
 		registry.WithStrKey(conKey),
 		registry.WithValue(data))
@@ -897,6 +931,9 @@ func (dep *Dependency) UpdateProvidersRuleOfConsumer(conKey string) error {
 	return nil
 }
 
+// 并发查询依赖关系中的服务时使用的最大goroutine数
+const DEFAULT_DEPENDENCY_WORKERS = 10
+
 type DependencyRelation struct {
 	ctx           context.Context
 	domainProject string
@@ -904,6 +941,9 @@ type DependencyRelation struct {
 	consumer      *pb.MicroService
 	providerId    string
 	provider      *pb.MicroService
+	// 同一次请求内缓存已查询的服务，避免重复查询
+	lock     sync.Mutex
+	services map[string]*pb.MicroService
 }
 
 func NewProviderDependencyRelation(ctx context.Context, domainProject string, providerId string, provider *pb.MicroService) *DependencyRelation {
@@ -930,14 +970,21 @@ func (dr *DependencyRelation) GetDependencyProviders() ([]*pb.MicroService, erro
 	if err != nil {
 		return nil, err
 	}
-	services := make([]*pb.MicroService, 0)
-	for _, providerId := range providerIds {
-		provider, err := GetService(dr.ctx, dr.domainProject, providerId)
-		if err != nil {
-			return nil, err
-		}
+	providerIds = uniqueServiceIds(providerIds)
+
+	providers := make([]*pb.MicroService, len(providerIds))
+	err = util.ParallelDo(len(providerIds), DEFAULT_DEPENDENCY_WORKERS, func(i int) (err error) {
+		providers[i], err = dr.getService(providerIds[i])
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]*pb.MicroService, 0, len(providers))
+	for i, provider := range providers {
 		if provider == nil {
-			util.Logger().Warnf(nil, "Provider not exist, %s", providerId)
+			util.Logger().Warnf(nil, "Provider not exist, %s", providerIds[i])
 			continue
 		}
 		services = append(services, provider)
@@ -945,6 +992,29 @@ func (dr *DependencyRelation) GetDependencyProviders() ([]*pb.MicroService, erro
 	return services, nil
 }
 
+// getService 查询服务，同一次请求内相同的服务只查询一次
+func (dr *DependencyRelation) getService(serviceId string) (*pb.MicroService, error) {
+	dr.lock.Lock()
+	service, ok := dr.services[serviceId]
+	dr.lock.Unlock()
+	if ok {
+		return service, nil
+	}
+
+	service, err := GetService(dr.ctx, dr.domainProject, serviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	dr.lock.Lock()
+	if dr.services == nil {
+		dr.services = make(map[string]*pb.MicroService)
+	}
+	dr.services[serviceId] = service
+	dr.lock.Unlock()
+	return service, nil
+}
+
 func (dr *DependencyRelation) GetDependencyProviderIds() ([]string, error) {
 	if dr.consumer == nil {
 		util.LOGGER.Infof("dr.consumer is nil ------->")
@@ -961,41 +1031,54 @@ func (dr *DependencyRelation) GetDependencyProviderIds() ([]string, error) {
 }
 
 func (dr *DependencyRelation) getDependencyProviderIds(providerRules []*pb.MicroServiceKey) ([]string, error) {
-	provideServiceIds := make([]string, 0)
-	opts := FromContext(dr.ctx)
-	for _, provider := range providerRules {
-		switch {
-		case provider.ServiceName == "*":
-			util.Logger().Infof("Rely all service,* type, consumerId %s", dr.consumerId)
-			splited := strings.Split(apt.GenerateServiceIndexKey(provider), "/")
-			allServiceKey := util.StringJoin(splited[:len(splited)-3], "/") + "/"
-			sopts := append(opts,
-				registry.WithStrKey(allServiceKey),
-				registry.WithPrefix())
-			resp, err := store.Store().Service().Search(dr.ctx, sopts...)
-			if err != nil {
-				util.Logger().Errorf(err, "Add dependency failed, rely all service: get all services failed.")
-				return provideServiceIds, err
-			}
+	// 依赖所有服务('*')时，其后的规则不需要再查询
+	var relyAll *pb.MicroServiceKey
+	for i, provider := range providerRules {
+		if provider.ServiceName == "*" {
+			relyAll, providerRules = provider, providerRules[:i]
+			break
+		}
+	}
 
-			for _, kv := range resp.Kvs {
-				provideServiceIds = append(provideServiceIds, util.BytesToStringWithNoCopy(kv.Value))
-			}
-			return provideServiceIds, nil
-		default:
-			serviceIds, err := FindServiceIds(dr.ctx, provider.Version, provider)
-			if err != nil {
-				util.Logger().Errorf(err, "Get providerIds failed, service: %s/%s/%s",
-					provider.AppId, provider.ServiceName, provider.Version)
-				return provideServiceIds, err
-			}
-			if len(serviceIds) == 0 {
-				util.Logger().Warnf(nil, "Get providerIds is empty, service: %s/%s/%s does not exist",
-					provider.AppId, provider.ServiceName, provider.Version)
-				continue
-			}
-			provideServiceIds = append(provideServiceIds, serviceIds...)
+	serviceIdsList := make([][]string, len(providerRules))
+	err := util.ParallelDo(len(providerRules), DEFAULT_DEPENDENCY_WORKERS, func(i int) error {
+		provider := providerRules[i]
+		serviceIds, err := FindServiceIds(dr.ctx, provider.Version, provider)
+		if err != nil {
+			util.Logger().Errorf(err, "Get providerIds failed, service: %s/%s/%s",
+				provider.AppId, provider.ServiceName, provider.Version)
+			return err
 		}
+		if len(serviceIds) == 0 {
+			util.Logger().Warnf(nil, "Get providerIds is empty, service: %s/%s/%s does not exist",
+				provider.AppId, provider.ServiceName, provider.Version)
+		}
+		serviceIdsList[i] = serviceIds
+		return nil
+	})
+
+	provideServiceIds := make([]string, 0, len(providerRules))
+	for _, serviceIds := range serviceIdsList {
+		provideServiceIds = append(provideServiceIds, serviceIds...)
+	}
+	if err != nil || relyAll == nil {
+		return provideServiceIds, err
+	}
+
+	util.Logger().Infof("Rely all service,* type, consumerId %s", dr.consumerId)
+	splited := strings.Split(apt.GenerateServiceIndexKey(relyAll), "/")
+	allServiceKey := util.StringJoin(splited[:len(splited)-3], "/") + "/"
+	sopts := append(FromContext(dr.ctx),
+		registry.WithStrKey(allServiceKey),
+		registry.WithPrefix())
+	resp, err := store.Store().Service().Search(dr.ctx, sopts...)
+	if err != nil {
+		util.Logger().Errorf(err, "Add dependency failed, rely all service: get all services failed.")
+		return provideServiceIds, err
+	}
+
+	for _, kv := range resp.Kvs {
+		provideServiceIds = append(provideServiceIds, util.BytesToStringWithNoCopy(kv.Value))
 	}
 	return provideServiceIds, nil
 }
@@ -1006,15 +1089,21 @@ func (dr *DependencyRelation) GetDependencyConsumers() ([]*pb.MicroService, erro
 		util.Logger().Errorf(err, "Get consumers of provider rule failed, %s", dr.providerId)
 		return nil, err
 	}
-	consumers := make([]*pb.MicroService, 0)
+	consumerDependAllList = uniqueServiceKeys(consumerDependAllList)
 
-	for _, consumer := range consumerDependAllList {
-		service, err := dr.getServiceByMicroServiceKey(dr.domainProject, consumer)
-		if err != nil {
-			return nil, err
-		}
+	services := make([]*pb.MicroService, len(consumerDependAllList))
+	err = util.ParallelDo(len(consumerDependAllList), DEFAULT_DEPENDENCY_WORKERS, func(i int) (err error) {
+		services[i], err = dr.getServiceByMicroServiceKey(dr.domainProject, consumerDependAllList[i])
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	consumers := make([]*pb.MicroService, 0, len(services))
+	for i, service := range services {
 		if service == nil {
-			util.Logger().Warnf(nil, "Consumer not exist,%v", service)
+			util.Logger().Warnf(nil, "Consumer not exist,%v", consumerDependAllList[i])
 			continue
 		}
 		consumers = append(consumers, service)
@@ -1031,7 +1120,7 @@ func (dr *DependencyRelation) getServiceByMicroServiceKey(domainProject string,
 		util.Logger().Warnf(nil, "Service not exist,%v", service)
 		return nil, nil
 	}
-	return GetService(dr.ctx, domainProject, serviceId)
+	return dr.getService(serviceId)
 }
 
 func (dr *DependencyRelation) GetDependencyConsumerIds() ([]string, error) {
@@ -1039,21 +1128,62 @@ func (dr *DependencyRelation) GetDependencyConsumerIds() ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	consumerIds := make([]string, 0)
-	for _, consumer := range consumerDependAllList {
-		consumerId, err := GetServiceId(context.TODO(), consumer)
+	consumerDependAllList = uniqueServiceKeys(consumerDependAllList)
+
+	ids := make([]string, len(consumerDependAllList))
+	err = util.ParallelDo(len(consumerDependAllList), DEFAULT_DEPENDENCY_WORKERS, func(i int) error {
+		consumerId, err := GetServiceId(dr.ctx, consumerDependAllList[i])
 		if err != nil {
-			util.Logger().Errorf(err, "Get consumer failed, %v", consumer)
-			return nil, err
+			util.Logger().Errorf(err, "Get consumer failed, %v", consumerDependAllList[i])
+			return err
 		}
 		if len(consumerId) == 0 {
-			util.Logger().Warnf(nil, "Get consumer not exist, %v", consumer)
+			util.Logger().Warnf(nil, "Get consumer not exist, %v", consumerDependAllList[i])
+		}
+		ids[i] = consumerId
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	consumerIds := make([]string, 0, len(ids))
+	for _, consumerId := range ids {
+		if len(consumerId) == 0 {
 			continue
 		}
 		consumerIds = append(consumerIds, consumerId)
 	}
 	return consumerIds, nil
+}
+
+// uniqueServiceIds 去除重复的服务id，保持原有顺序
+func uniqueServiceIds(ids []string) []string {
+	exist := make(map[string]struct{}, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := exist[id]; ok {
+			continue
+		}
+		exist[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
 
+// uniqueServiceKeys 去除重复的服务，保持原有顺序
+func uniqueServiceKeys(keys []*pb.MicroServiceKey) []*pb.MicroServiceKey {
+	exist := make(map[string]struct{}, len(keys))
+	unique := make([]*pb.MicroServiceKey, 0, len(keys))
+	for _, key := range keys {
+		s := toString(key)
+		if _, ok := exist[s]; ok {
+			continue
+		}
+		exist[s] = struct{}{}
+		unique = append(unique, key)
+	}
+	return unique
 }
 
 func (dr *DependencyRelation) getDependencyConsumersOfProvider() ([]*pb.MicroServiceKey, error) {
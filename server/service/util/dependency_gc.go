@@ -0,0 +1,322 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/mux"
+	"github.com/apache/incubator-servicecomb-service-center/server/scheduler"
+	"github.com/astaxie/beego"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_DEPENDENCY_GC_INTERVAL = time.Hour
+	// 结果中最多列出的key数
+	MAX_DEPENDENCY_GC_KEYS = 1000
+
+	DEPENDENCY_GC_KIND_RULE     = "rule"
+	DEPENDENCY_GC_KIND_RELATION = "relation"
+	DEPENDENCY_GC_KIND_USAGE    = "usage"
+)
+
+var (
+	dependencyGCRemoved = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "dependency",
+			Name:      "gc_removed_total",
+			Help:      "Counter of the orphan dependency keys removed, by rule, relation or usage",
+		}, []string{"kind"})
+
+	dependencyGCRuns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "dependency",
+			Name:      "gc_runs_total",
+			Help:      "Counter of the orphan dependency collections, by success or failure",
+		}, []string{"result"})
+
+	dependencyGCOrphans = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "dependency",
+			Name:      "gc_orphans",
+			Help:      "Number of the orphan dependency keys found by the last collection",
+		}, []string{"kind"})
+)
+
+func init() {
+	prometheus.MustRegister(dependencyGCRemoved, dependencyGCRuns, dependencyGCOrphans)
+}
+
+// DependencyGCResult 一次回收找到的孤立依赖数据，dryRun时只统计不删除
+type DependencyGCResult struct {
+	DryRun    bool     `json:"dryRun"`
+	Rules     int      `json:"rules"`
+	Relations int      `json:"relations"`
+	Usages    int      `json:"usages"`
+	Keys      []string `json:"keys,omitempty"`
+	Failed    []string `json:"failed,omitempty"`
+}
+
+func (r *DependencyGCResult) add(kind, key string) {
+	switch kind {
+	case DEPENDENCY_GC_KIND_RULE:
+		r.Rules++
+	case DEPENDENCY_GC_KIND_RELATION:
+		r.Relations++
+	case DEPENDENCY_GC_KIND_USAGE:
+		r.Usages++
+	}
+	if len(r.Keys) < MAX_DEPENDENCY_GC_KEYS {
+		r.Keys = append(r.Keys, key)
+	}
+}
+
+// existingServices 返回所有服务，key为{domain}/{project}/{serviceId}
+func existingServices(ctx context.Context) (map[string]struct{}, error) {
+	resp, err := store.Store().Service().Search(ctx,
+		registry.WithStrKey(apt.GetServiceRootKey("")),
+		registry.WithPrefix(),
+		registry.WithKeyOnly(),
+		registry.WithNoCache())
+	if err != nil {
+		return nil, err
+	}
+	services := make(map[string]struct{}, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		serviceId, domainProject, _ := pb.GetInfoFromSvcKV(kv)
+		services[domainProject+"/"+serviceId] = struct{}{}
+	}
+	return services, nil
+}
+
+func listKeys(ctx context.Context, prefix string) ([]string, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(prefix),
+		registry.WithPrefix(),
+		registry.WithKeyOnly())
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		keys = append(keys, util.BytesToStringWithNoCopy(kv.Key))
+	}
+	return keys, nil
+}
+
+// orphanRelationConsumer 解析依赖关系和发现记录的key，返回所属的consumer
+func orphanRelationConsumer(kind, root, key string) string {
+	arr := strings.Split(key[len(root):], "/")
+	switch {
+	case kind == DEPENDENCY_GC_KIND_USAGE && len(arr) == 4:
+		// {domain}/{project}/{consumerId}/{providerId}
+		return util.StringJoin([]string{arr[0], arr[1], arr[2]}, "/")
+	case kind == DEPENDENCY_GC_KIND_RELATION && len(arr) == 5 && arr[2] == "c":
+		// {domain}/{project}/c/{consumerId}/{providerId}
+		return util.StringJoin([]string{arr[0], arr[1], arr[3]}, "/")
+	case kind == DEPENDENCY_GC_KIND_RELATION && len(arr) == 5 && arr[2] == "p":
+		// {domain}/{project}/p/{providerId}/{consumerId}
+		return util.StringJoin([]string{arr[0], arr[1], arr[4]}, "/")
+	}
+	return ""
+}
+
+// collectOrphanRelations 回收consumer已删除的依赖关系和发现记录，先列出key再查询服务，
+// 避免把扫描期间新注册的consumer的数据当作孤立数据
+func collectOrphanRelations(ctx context.Context, result *DependencyGCResult) error {
+	roots := []struct {
+		kind string
+		root string
+	}{
+		{DEPENDENCY_GC_KIND_RELATION, apt.GetServiceDependencyRootKey("")},
+		{DEPENDENCY_GC_KIND_USAGE, apt.GetDependencyUsageRootKey("")},
+	}
+	keys := make([][]string, len(roots))
+	for i, r := range roots {
+		ks, err := listKeys(ctx, r.root)
+		if err != nil {
+			return err
+		}
+		keys[i] = ks
+	}
+	services, err := existingServices(ctx)
+	if err != nil {
+		return err
+	}
+
+	var ops []registry.PluginOp
+	removed := make(map[string]int, len(roots))
+	for i, r := range roots {
+		for _, key := range keys[i] {
+			consumer := orphanRelationConsumer(r.kind, r.root, key)
+			if len(consumer) == 0 {
+				continue
+			}
+			if _, ok := services[consumer]; ok {
+				continue
+			}
+			result.add(r.kind, key)
+			removed[r.kind]++
+			ops = append(ops, registry.OpDel(registry.WithStrKey(key)))
+		}
+	}
+	if result.DryRun || len(ops) == 0 {
+		return nil
+	}
+	if err := backend.BatchCommit(ctx, ops); err != nil {
+		return err
+	}
+	for kind, n := range removed {
+		dependencyGCRemoved.WithLabelValues(kind).Add(float64(n))
+	}
+	return nil
+}
+
+// removeOrphanRule 从provider的规则中去掉已删除的consumer后删除consumer的规则，调用方需持有全局锁
+func removeOrphanRule(ctx context.Context, domainProject string, consumer *pb.MicroServiceKey) error {
+	conKey := apt.GenerateConsumerDependencyRuleKey(domainProject, consumer)
+	providerRules, err := TransferToMicroServiceDependency(ctx, conKey)
+	if err != nil {
+		return err
+	}
+	for _, providerRule := range providerRules.Dependency {
+		proKey := apt.GenerateProviderDependencyRuleKey(domainProject, providerRule)
+		consumers, err := TransferToMicroServiceDependency(ctx, proKey)
+		if err != nil {
+			return err
+		}
+		if indexOfServiceDependency(consumers.Dependency, consumer) < 0 {
+			continue
+		}
+		if err := deleteDependencyRuleUtil(ctx, consumers, consumer, proKey); err != nil {
+			return err
+		}
+	}
+	_, err = backend.Registry().Do(ctx, registry.DEL, registry.WithStrKey(conKey))
+	return err
+}
+
+// collectOrphanRules 回收consumer已删除的依赖规则，如删除consumer后才提交的Find依赖
+func collectOrphanRules(ctx context.Context, result *DependencyGCResult) error {
+	keys, err := listKeys(ctx, apt.GetServiceDependencyRuleRootKey(""))
+	if err != nil {
+		return err
+	}
+	var orphans []string
+	for _, key := range keys {
+		_, consumer := parseConsumerDependencyRuleKey(key)
+		if consumer == nil {
+			continue
+		}
+		serviceId, err := GetServiceId(ctx, consumer)
+		if err != nil {
+			return err
+		}
+		if len(serviceId) == 0 {
+			orphans = append(orphans, key)
+		}
+	}
+	if result.DryRun {
+		for _, key := range orphans {
+			result.add(DEPENDENCY_GC_KIND_RULE, key)
+		}
+		return nil
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	lock, err := mux.Lock(mux.GLOBAL_LOCK)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+	for _, key := range orphans {
+		domainProject, consumer := parseConsumerDependencyRuleKey(key)
+		// 加锁前consumer可能已重新注册
+		serviceId, err := GetServiceId(ctx, consumer)
+		if err != nil {
+			return err
+		}
+		if len(serviceId) > 0 {
+			continue
+		}
+		if err := removeOrphanRule(ctx, domainProject, consumer); err != nil {
+			util.Logger().Errorf(err, "remove orphan dependency rule %s failed", key)
+			result.Failed = append(result.Failed, key)
+			continue
+		}
+		result.add(DEPENDENCY_GC_KIND_RULE, key)
+		dependencyGCRemoved.WithLabelValues(DEPENDENCY_GC_KIND_RULE).Inc()
+	}
+	return nil
+}
+
+// CollectOrphanDependencies 回收consumer已不存在的依赖规则、依赖关系和发现记录，
+// 这些数据在consumer删除过程中被并发写入时会残留
+func CollectOrphanDependencies(ctx context.Context, dryRun bool) (*DependencyGCResult, error) {
+	ctx = util.SetContext(ctx, "noCache", "1")
+	result := &DependencyGCResult{DryRun: dryRun}
+	err := collectOrphanRelations(ctx, result)
+	if err == nil {
+		err = collectOrphanRules(ctx, result)
+	}
+	if err != nil {
+		dependencyGCRuns.WithLabelValues("failure").Inc()
+		return nil, err
+	}
+	dependencyGCRuns.WithLabelValues("success").Inc()
+	dependencyGCOrphans.WithLabelValues(DEPENDENCY_GC_KIND_RULE).Set(float64(result.Rules))
+	dependencyGCOrphans.WithLabelValues(DEPENDENCY_GC_KIND_RELATION).Set(float64(result.Relations))
+	dependencyGCOrphans.WithLabelValues(DEPENDENCY_GC_KIND_USAGE).Set(float64(result.Usages))
+	if !dryRun && result.Rules+result.Relations+result.Usages > 0 {
+		util.Logger().Infof("collect orphan dependencies successfully, %d rule(s), %d relation(s), %d usage(s)",
+			result.Rules, result.Relations, result.Usages)
+	}
+	return result, nil
+}
+
+// RunDependencyGC 周期性地回收孤立的依赖数据，dependency_gc_interval为0时不回收
+func RunDependencyGC() {
+	interval := time.Duration(beego.AppConfig.DefaultInt64("dependency_gc_interval",
+		int64(DEFAULT_DEPENDENCY_GC_INTERVAL/time.Second))) * time.Second
+	if interval <= 0 {
+		return
+	}
+	scheduler.Register(&scheduler.Job{
+		Name:      "dependency_gc",
+		Priority:  scheduler.PRIORITY_LOW,
+		Interval:  interval,
+		Singleton: true,
+		Func: func(ctx context.Context) error {
+			_, err := CollectOrphanDependencies(ctx, false)
+			return err
+		},
+	})
+}
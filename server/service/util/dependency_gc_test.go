@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"testing"
+)
+
+func TestOrphanRelationConsumer(t *testing.T) {
+	depRoot := apt.GetServiceDependencyRootKey("")
+	usageRoot := apt.GetDependencyUsageRootKey("")
+	cases := []struct {
+		kind     string
+		root     string
+		key      string
+		consumer string
+	}{
+		{DEPENDENCY_GC_KIND_RELATION, depRoot, apt.GenerateConsumerDependencyKey("d/p", "c1", "p1"), "d/p/c1"},
+		{DEPENDENCY_GC_KIND_RELATION, depRoot, apt.GenerateProviderDependencyKey("d/p", "p1", "c1"), "d/p/c1"},
+		{DEPENDENCY_GC_KIND_RELATION, depRoot, depRoot + "d/p/x/a/b", ""},
+		{DEPENDENCY_GC_KIND_USAGE, usageRoot, apt.GenerateDependencyUsageKey("d/p", "c1", "p1"), "d/p/c1"},
+		{DEPENDENCY_GC_KIND_USAGE, usageRoot, usageRoot + "d/p/c1", ""},
+	}
+	for _, c := range cases {
+		if consumer := orphanRelationConsumer(c.kind, c.root, c.key); consumer != c.consumer {
+			t.Fatalf("TestOrphanRelationConsumer %s failed, got '%s'", c.key, consumer)
+		}
+	}
+}
+
+func TestDependencyGCResult_add(t *testing.T) {
+	result := &DependencyGCResult{}
+	for i := 0; i < MAX_DEPENDENCY_GC_KEYS+1; i++ {
+		result.add(DEPENDENCY_GC_KIND_USAGE, "key")
+	}
+	result.add(DEPENDENCY_GC_KIND_RULE, "rule")
+	result.add(DEPENDENCY_GC_KIND_RELATION, "relation")
+	if result.Usages != MAX_DEPENDENCY_GC_KEYS+1 || result.Rules != 1 || result.Relations != 1 {
+		t.Fatalf("TestDependencyGCResult_add failed, %d %d %d", result.Rules, result.Relations, result.Usages)
+	}
+	if len(result.Keys) != MAX_DEPENDENCY_GC_KEYS {
+		t.Fatalf("TestDependencyGCResult_add failed, the keys should be limited, %d", len(result.Keys))
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/scheduler"
+	"golang.org/x/net/context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_REVISION_TIMELINE_INTERVAL = time.Minute
+	// 保留7天的采样点，超出压缩窗口的采样点查询时会返回revision已压缩
+	DEFAULT_REVISION_TIMELINE_SIZE = 7 * 24 * 60
+)
+
+type revisionPoint struct {
+	Timestamp int64
+	Revision  int64
+}
+
+// RevisionTimeline 周期采样后端的revision，用于把时间换算成revision，
+// 采样点只保存在内存中，重启后只能查询重启之后的时间
+type RevisionTimeline struct {
+	Size int
+
+	lock   sync.RWMutex
+	points []revisionPoint
+}
+
+var revisionTimeline = NewRevisionTimeline(DEFAULT_REVISION_TIMELINE_SIZE)
+
+func NewRevisionTimeline(size int) *RevisionTimeline {
+	return &RevisionTimeline{Size: size}
+}
+
+func GetRevisionTimeline() *RevisionTimeline {
+	return revisionTimeline
+}
+
+// Record 记录t时刻的revision，revision未变化时不产生新的采样点
+func (tl *RevisionTimeline) Record(t time.Time, rev int64) {
+	tl.lock.Lock()
+	defer tl.lock.Unlock()
+	if l := len(tl.points); l > 0 && tl.points[l-1].Revision >= rev {
+		return
+	}
+	tl.points = append(tl.points, revisionPoint{Timestamp: t.Unix(), Revision: rev})
+	if tl.Size > 0 && len(tl.points) > tl.Size {
+		tl.points = append(tl.points[:0], tl.points[len(tl.points)-tl.Size:]...)
+	}
+}
+
+// Lookup 返回t时刻之前最后一次采样的revision，t早于第一个采样点时返回false
+func (tl *RevisionTimeline) Lookup(t time.Time) (int64, bool) {
+	ts := t.Unix()
+	tl.lock.RLock()
+	defer tl.lock.RUnlock()
+	i := sort.Search(len(tl.points), func(i int) bool {
+		return tl.points[i].Timestamp > ts
+	})
+	if i == 0 {
+		return 0, false
+	}
+	return tl.points[i-1].Revision, true
+}
+
+// RecordRevision 采样后端当前的revision
+func RecordRevision(ctx context.Context) error {
+	resp, err := backend.Registry().Do(ctx, registry.GET,
+		registry.WithStrKey(apt.GetRootKey()),
+		registry.WithPrefix(),
+		registry.WithCountOnly())
+	if err != nil {
+		return err
+	}
+	revisionTimeline.Record(time.Now(), resp.Revision)
+	return nil
+}
+
+// RunRevisionTimeline 周期性地采样后端的revision
+func RunRevisionTimeline() {
+	scheduler.Register(&scheduler.Job{
+		Name:      "revision_timeline",
+		Priority:  scheduler.PRIORITY_NORMAL,
+		Interval:  DEFAULT_REVISION_TIMELINE_INTERVAL,
+		Immediate: true,
+		Func:      RecordRevision,
+	})
+}
+
+// GetDependencyRuleAt 查询消费者在rev时的依赖规则，rev时消费者不存在返回nil，
+// rev已被压缩返回registry.ErrRevisionCompacted
+func GetDependencyRuleAt(ctx context.Context, domainProject, consumerId string, rev int64) (
+	*pb.MicroServiceKey, *pb.MicroServiceDependency, error) {
+	resp, err := backend.Registry().Do(ctx, registry.GET,
+		registry.WithStrKey(apt.GenerateServiceKey(domainProject, consumerId)),
+		registry.WithRev(rev))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil, nil
+	}
+	consumer := &pb.MicroService{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, consumer); err != nil {
+		return nil, nil, err
+	}
+	consumerKey := pb.MicroServiceToKey(domainProject, consumer)
+
+	resp, err = backend.Registry().Do(ctx, registry.GET,
+		registry.WithStrKey(apt.GenerateConsumerDependencyRuleKey(domainProject, consumerKey)),
+		registry.WithRev(rev))
+	if err != nil {
+		return nil, nil, err
+	}
+	deps := &pb.MicroServiceDependency{Dependency: []*pb.MicroServiceKey{}}
+	if len(resp.Kvs) != 0 {
+		if err := json.Unmarshal(resp.Kvs[0].Value, deps); err != nil {
+			util.Logger().Errorf(err, "unmarshal dependency rule of consumer %s at revision %d failed", consumerId, rev)
+			return nil, nil, err
+		}
+	}
+	return consumerKey, deps, nil
+}
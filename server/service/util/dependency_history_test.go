@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+	"time"
+)
+
+func TestRevisionTimeline(t *testing.T) {
+	start := time.Unix(1000, 0)
+	tl := serviceUtil.NewRevisionTimeline(2)
+	if _, ok := tl.Lookup(start); ok {
+		fmt.Printf(`Lookup empty timeline failed`)
+		t.FailNow()
+	}
+
+	tl.Record(start, 10)
+	tl.Record(start.Add(time.Minute), 10)
+	tl.Record(start.Add(2*time.Minute), 20)
+	if rev, ok := tl.Lookup(start.Add(90 * time.Second)); !ok || rev != 10 {
+		fmt.Printf(`Lookup between points failed`)
+		t.FailNow()
+	}
+	if rev, ok := tl.Lookup(start.Add(time.Hour)); !ok || rev != 20 {
+		fmt.Printf(`Lookup after last point failed`)
+		t.FailNow()
+	}
+
+	tl.Record(start.Add(3*time.Minute), 30)
+	if _, ok := tl.Lookup(start.Add(time.Minute)); ok {
+		fmt.Printf(`Lookup out of the history failed`)
+		t.FailNow()
+	}
+	if rev, ok := tl.Lookup(start.Add(2 * time.Minute)); !ok || rev != 20 {
+		fmt.Printf(`Lookup oldest point failed`)
+		t.FailNow()
+	}
+}
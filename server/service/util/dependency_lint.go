@@ -0,0 +1,209 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"fmt"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"golang.org/x/net/context"
+	"strconv"
+)
+
+const (
+	LINT_LEVEL_ERROR   = "error"
+	LINT_LEVEL_WARNING = "warning"
+
+	LINT_INVALID_PARAMS       = "INVALID_PARAMS"
+	LINT_CONSUMER_NOT_EXIST   = "CONSUMER_NOT_EXIST"
+	LINT_DUPLICATE_CONSUMER   = "DUPLICATE_CONSUMER"
+	LINT_REDUNDANT_PROVIDER   = "REDUNDANT_PROVIDER"
+	LINT_IGNORED_AFTER_ALL    = "IGNORED_AFTER_ALL"
+	LINT_ENVIRONMENT_MISMATCH = "ENVIRONMENT_MISMATCH"
+	LINT_PROVIDER_NOT_EXIST   = "PROVIDER_NOT_EXIST"
+	LINT_VERSION_NOT_MATCHED  = "VERSION_NOT_MATCHED"
+)
+
+type lintRule struct {
+	index int
+	field string
+}
+
+// dependencyLinter 记录同一批声明中每个消费者已出现的规则，用于检查跨条目的重复
+type dependencyLinter struct {
+	ctx           context.Context
+	domainProject string
+	issues        []*pb.DependencyLintIssue
+	consumers     map[string]int
+	rules         map[string][]*pb.MicroServiceKey
+	positions     map[*pb.MicroServiceKey]lintRule
+	redundant     map[*pb.MicroServiceKey]bool
+}
+
+func (l *dependencyLinter) add(index int, field, level, code, format string, args ...interface{}) {
+	l.issues = append(l.issues, &pb.DependencyLintIssue{
+		Index:   int32(index),
+		Field:   field,
+		Level:   level,
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// LintDependencies 检查依赖声明，除创建依赖时的参数校验外，还检查提供者是否存在、版本规则能否匹配、
+// 规则是否重复以及因环境不同不会生效的规则，不修改任何数据
+func LintDependencies(ctx context.Context, domainProject string, dependencies []*pb.ConsumerDependency) ([]*pb.DependencyLintIssue, error) {
+	l := &dependencyLinter{
+		ctx:           ctx,
+		domainProject: domainProject,
+		issues:        make([]*pb.DependencyLintIssue, 0),
+		consumers:     make(map[string]int),
+		rules:         make(map[string][]*pb.MicroServiceKey),
+		positions:     make(map[*pb.MicroServiceKey]lintRule),
+		redundant:     make(map[*pb.MicroServiceKey]bool),
+	}
+	for i, dependency := range dependencies {
+		if err := l.lint(i, dependency); err != nil {
+			return nil, err
+		}
+	}
+	return l.issues, nil
+}
+
+func (l *dependencyLinter) lint(i int, dependency *pb.ConsumerDependency) error {
+	if dependency == nil || dependency.Consumer == nil || len(dependency.Providers) == 0 {
+		l.add(i, "", LINT_LEVEL_ERROR, LINT_INVALID_PARAMS, "consumer and providers are required")
+		return nil
+	}
+	SetDependencyDefaultValue(dependency)
+	consumer := pb.DependenciesToKeys([]*pb.DependencyKey{dependency.Consumer}, l.domainProject)[0]
+	providers := pb.DependenciesToKeys(dependency.Providers, l.domainProject)
+	if rsp := ParamsChecker(consumer, providers); rsp != nil {
+		field := ""
+		if details := rsp.Response.GetDetails(); len(details) > 0 {
+			field = details[0].Field
+		}
+		l.add(i, field, LINT_LEVEL_ERROR, LINT_INVALID_PARAMS, "%s", rsp.Response.Message)
+		return nil
+	}
+
+	consumerId, err := GetServiceId(l.ctx, consumer)
+	if err != nil {
+		return err
+	}
+	if len(consumerId) == 0 {
+		l.add(i, "consumer", LINT_LEVEL_ERROR, LINT_CONSUMER_NOT_EXIST, "consumer %s/%s/%s does not exist",
+			consumer.AppId, consumer.ServiceName, consumer.Version)
+	}
+
+	conKey := apt.GenerateConsumerDependencyRuleKey(l.domainProject, consumer)
+	if k, ok := l.consumers[conKey]; ok {
+		l.add(i, "consumer", LINT_LEVEL_WARNING, LINT_DUPLICATE_CONSUMER,
+			"consumer is already declared in dependencies[%d], PUT keeps only the last one", k)
+	} else {
+		l.consumers[conKey] = i
+	}
+
+	// 依赖所有服务('*')时，其后的规则不会被使用
+	for j, provider := range providers {
+		if provider.ServiceName != "*" {
+			continue
+		}
+		for k := j + 1; k < len(providers); k++ {
+			l.add(i, "providers["+strconv.Itoa(k)+"]", LINT_LEVEL_WARNING, LINT_IGNORED_AFTER_ALL,
+				"rule after '*' in providers[%d] is ignored", j)
+		}
+		providers = providers[:j+1]
+		break
+	}
+
+	for j, provider := range providers {
+		l.positions[provider] = lintRule{index: i, field: "providers[" + strconv.Itoa(j) + "]"}
+	}
+	rules := append(l.rules[conKey], providers...)
+	l.rules[conKey] = rules
+	_, removed := NormalizeDependencyRules(rules)
+	for _, rule := range removed {
+		if l.redundant[rule] {
+			continue
+		}
+		l.redundant[rule] = true
+		pos := l.positions[rule]
+		l.add(pos.index, pos.field, LINT_LEVEL_WARNING, LINT_REDUNDANT_PROVIDER,
+			"rule %s/%s/%s is duplicated or covered by another rule of the same consumer",
+			rule.AppId, rule.ServiceName, rule.Version)
+	}
+
+	for j, provider := range providers {
+		field := "providers[" + strconv.Itoa(j) + "]"
+		// 发现时按消费者的环境查找提供者
+		if provider.Environment != consumer.Environment {
+			l.add(i, field, LINT_LEVEL_WARNING, LINT_ENVIRONMENT_MISMATCH,
+				"environment '%s' differs from the consumer's '%s', the rule never takes effect",
+				provider.Environment, consumer.Environment)
+			continue
+		}
+		if provider.ServiceName == "*" || l.redundant[provider] {
+			continue
+		}
+		if err := l.lintProvider(i, field, provider); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lintProvider 检查提供者是否存在及版本规则能否匹配到版本，共享服务不在本租户，不做检查
+func (l *dependencyLinter) lintProvider(i int, field string, provider *pb.MicroServiceKey) error {
+	key := *provider
+	key.Alias = key.ServiceName
+	ids, err := FindServiceIds(l.ctx, provider.Version, &key)
+	if err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		return nil
+	}
+
+	key = *provider
+	exist, err := GetServiceAllVersions(l.ctx, &key, false)
+	if err != nil {
+		return err
+	}
+	if len(exist.Kvs) == 0 {
+		key.Alias = provider.ServiceName
+		exist, err = GetServiceAllVersions(l.ctx, &key, true)
+		if err != nil {
+			return err
+		}
+	}
+	if len(exist.Kvs) > 0 {
+		l.add(i, field, LINT_LEVEL_WARNING, LINT_VERSION_NOT_MATCHED,
+			"version rule '%s' matches no version of %s/%s", provider.Version, provider.AppId, provider.ServiceName)
+		return nil
+	}
+
+	shared, err := FindSharedService(l.ctx, provider.AppId, provider.ServiceName)
+	if err != nil {
+		return err
+	}
+	if shared == nil {
+		l.add(i, field, LINT_LEVEL_WARNING, LINT_PROVIDER_NOT_EXIST,
+			"provider %s/%s does not exist yet", provider.AppId, provider.ServiceName)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/mux"
+	"golang.org/x/net/context"
+	"sort"
+)
+
+const (
+	MIGRATE_KIND_DEPENDENCY = "dependency"
+
+	MIGRATE_ACTION_CREATE = "create"
+	MIGRATE_ACTION_UPDATE = "update"
+	MIGRATE_ACTION_DELETE = "delete"
+)
+
+// DependencyMigration 依赖规则中appId的映射，key为旧appId，value为新appId
+type DependencyMigration struct {
+	DomainProject string            `json:"domainProject,omitempty"`
+	AppIds        map[string]string `json:"appIds"`
+}
+
+type DependencyMigrationResult struct {
+	DryRun    bool              `json:"dryRun"`
+	Consumers int               `json:"consumers"`
+	Changes   []*pb.ApplyChange `json:"changes"`
+	Failed    []string          `json:"failed,omitempty"`
+}
+
+func (m *DependencyMigration) Check() error {
+	if len(m.AppIds) == 0 {
+		return fmt.Errorf("appIds is empty")
+	}
+	for from, to := range m.AppIds {
+		if len(from) == 0 || len(to) == 0 || from == to {
+			return fmt.Errorf("invalid appId mapping '%s' -> '%s'", from, to)
+		}
+		if !apt.MicroServiceKeyValidator.GetRule("AppId").Match(to) {
+			return fmt.Errorf("invalid appId '%s'", to)
+		}
+	}
+	return nil
+}
+
+func (m *DependencyMigration) migrateKey(in *pb.MicroServiceKey) (*pb.MicroServiceKey, bool) {
+	to, ok := m.AppIds[in.AppId]
+	if !ok {
+		return in, false
+	}
+	out := *in
+	out.AppId = to
+	return &out, true
+}
+
+// depRuleStage 暂存一个consumer迁移涉及的规则，读取时优先使用暂存的值
+type depRuleStage struct {
+	rules  map[string][]*pb.MicroServiceKey
+	staged map[string][]*pb.MicroServiceKey
+}
+
+func (s *depRuleStage) get(key string) []*pb.MicroServiceKey {
+	if deps, ok := s.staged[key]; ok {
+		return deps
+	}
+	return append([]*pb.MicroServiceKey{}, s.rules[key]...)
+}
+
+func (s *depRuleStage) add(key string, service *pb.MicroServiceKey) {
+	deps := s.get(key)
+	if !isExist(deps, service) {
+		deps = append(deps, service)
+	}
+	s.staged[key] = deps
+}
+
+func (s *depRuleStage) remove(key string, service *pb.MicroServiceKey) {
+	deps := s.get(key)
+	if i := indexOfServiceDependency(deps, service); i >= 0 {
+		deps = append(deps[:i:i], deps[i+1:]...)
+	}
+	s.staged[key] = deps
+}
+
+func (s *depRuleStage) ops() (ops []registry.PluginOp, changes []*pb.ApplyChange, err error) {
+	keys := make([]string, 0, len(s.staged))
+	for key := range s.staged {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rootLen := len(apt.GetServiceDependencyRuleRootKey(""))
+	for _, key := range keys {
+		deps := s.staged[key]
+		old, exist := s.rules[key]
+		var action string
+		switch {
+		case len(deps) == 0:
+			if !exist {
+				continue
+			}
+			action = MIGRATE_ACTION_DELETE
+			ops = append(ops, registry.OpDel(registry.WithStrKey(key)))
+		default:
+			data, err := json.Marshal(&pb.MicroServiceDependency{Dependency: deps})
+			if err != nil {
+				return nil, nil, err
+			}
+			if exist {
+				oldData, _ := json.Marshal(&pb.MicroServiceDependency{Dependency: old})
+				if bytes.Equal(oldData, data) {
+					continue
+				}
+				action = MIGRATE_ACTION_UPDATE
+			} else {
+				action = MIGRATE_ACTION_CREATE
+			}
+			ops = append(ops, registry.OpPut(registry.WithStrKey(key), registry.WithValue(data)))
+		}
+		changes = append(changes, &pb.ApplyChange{Kind: MIGRATE_KIND_DEPENDENCY, Action: action, Name: key[rootLen:]})
+	}
+	return
+}
+
+func (s *depRuleStage) commit() {
+	for key, deps := range s.staged {
+		if len(deps) == 0 {
+			delete(s.rules, key)
+			continue
+		}
+		s.rules[key] = deps
+	}
+	s.staged = make(map[string][]*pb.MicroServiceKey)
+}
+
+// loadDependencyRules 读取prefix下的所有依赖规则，返回暂存区和按key排序的consumer规则
+func loadDependencyRules(ctx context.Context, prefix string) (*depRuleStage, []string, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(prefix),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stage := &depRuleStage{
+		rules:  make(map[string][]*pb.MicroServiceKey, len(resp.Kvs)),
+		staged: make(map[string][]*pb.MicroServiceKey),
+	}
+	conKeys := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		deps := &pb.MicroServiceDependency{}
+		if err := json.Unmarshal(kv.Value, deps); err != nil {
+			util.Logger().Errorf(err, "invalid dependency rule %s, skip it", key)
+			continue
+		}
+		stage.rules[key] = deps.Dependency
+		if _, consumer := parseConsumerDependencyRuleKey(key); consumer != nil {
+			conKeys = append(conKeys, key)
+		}
+	}
+	sort.Strings(conKeys)
+	return stage, conKeys, nil
+}
+
+// migrateConsumer 按映射暂存consumer规则及相关provider规则的变更，返回新的consumer规则key，无变化时返回空
+func (s *depRuleStage) migrateConsumer(conKey string, migrate func(*pb.MicroServiceKey) (*pb.MicroServiceKey, bool)) string {
+	domainProject, consumer := parseConsumerDependencyRuleKey(conKey)
+	newConsumer, changed := migrate(consumer)
+	providers := s.get(conKey)
+	newProviders := make([]*pb.MicroServiceKey, 0, len(providers))
+	for _, provider := range providers {
+		newProvider, ok := migrate(provider)
+		changed = changed || ok
+		if !isExist(newProviders, newProvider) {
+			newProviders = append(newProviders, newProvider)
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	for _, provider := range providers {
+		s.remove(apt.GenerateProviderDependencyRuleKey(domainProject, provider), consumer)
+	}
+	newConKey := apt.GenerateConsumerDependencyRuleKey(domainProject, newConsumer)
+	if newConKey != conKey {
+		s.staged[conKey] = nil
+		for _, provider := range newProviders {
+			s.add(newConKey, provider)
+		}
+	} else {
+		s.staged[conKey] = newProviders
+	}
+	for _, provider := range newProviders {
+		s.add(apt.GenerateProviderDependencyRuleKey(domainProject, provider), newConsumer)
+	}
+	return newConKey
+}
+
+// MigrateDependencies 按appId映射改写consumer和provider的依赖规则，每个consumer在一个事务中完成，
+// dryRun时只返回将要发生的变更
+func MigrateDependencies(ctx context.Context, m *DependencyMigration, dryRun bool) (*DependencyMigrationResult, error) {
+	if err := m.Check(); err != nil {
+		return nil, err
+	}
+
+	lock, err := mux.Lock(mux.GLOBAL_LOCK)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Unlock()
+
+	prefix := apt.GetServiceDependencyRuleRootKey("")
+	if len(m.DomainProject) > 0 {
+		prefix = apt.GetServiceDependencyRuleRootKey(m.DomainProject) + "/"
+	}
+	ctx = util.SetContext(util.CloneContext(ctx), "noCache", "1")
+	stage, conKeys, err := loadDependencyRules(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DependencyMigrationResult{DryRun: dryRun, Changes: []*pb.ApplyChange{}}
+	for _, conKey := range conKeys {
+		newConKey := stage.migrateConsumer(conKey, m.migrateKey)
+		if len(newConKey) == 0 {
+			continue
+		}
+
+		ops, changes, err := stage.ops()
+		if err != nil {
+			return nil, err
+		}
+		if !dryRun && len(ops) > 0 {
+			if _, err := backend.Registry().Txn(ctx, ops); err != nil {
+				util.Logger().Errorf(err, "migrate dependency rule %s failed", conKey)
+				result.Failed = append(result.Failed, conKey[len(apt.GetServiceDependencyRuleRootKey("")):])
+				stage.staged = make(map[string][]*pb.MicroServiceKey)
+				continue
+			}
+			util.Logger().Infof("migrate dependency rule %s to %s successfully", conKey, newConKey)
+		}
+		stage.commit()
+		result.Consumers++
+		result.Changes = append(result.Changes, changes...)
+	}
+	if !dryRun && result.Consumers > 0 {
+		dependencyWriter.Reset()
+	}
+	return result, nil
+}
@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"testing"
+)
+
+func TestDependencyMigrationCheck(t *testing.T) {
+	for i, appIds := range []map[string]string{
+		nil,
+		{"a": ""},
+		{"": "b"},
+		{"a": "a"},
+		{"a": "b c"},
+	} {
+		if err := (&DependencyMigration{AppIds: appIds}).Check(); err == nil {
+			t.Fatalf("case %d: Check failed", i)
+		}
+	}
+	if err := (&DependencyMigration{AppIds: map[string]string{"a": "b"}}).Check(); err != nil {
+		t.Fatalf("Check failed, %s", err.Error())
+	}
+}
+
+func TestDepRuleStage(t *testing.T) {
+	domainProject := "default/default"
+	m := &DependencyMigration{AppIds: map[string]string{"old": "new"}}
+	consumer := &proto.MicroServiceKey{Tenant: domainProject, Environment: "development", AppId: "old", ServiceName: "c", Version: "1.0.0"}
+	provider := &proto.MicroServiceKey{Tenant: domainProject, AppId: "old", ServiceName: "p", Version: "1.0.0+"}
+	newConsumer, ok := m.migrateKey(consumer)
+	if !ok || newConsumer.AppId != "new" || consumer.AppId != "old" {
+		t.Fatalf("migrateKey failed")
+	}
+	if _, ok := m.migrateKey(&proto.MicroServiceKey{AppId: "other"}); ok {
+		t.Fatalf("migrateKey failed")
+	}
+	newProvider, _ := m.migrateKey(provider)
+
+	conKey := apt.GenerateConsumerDependencyRuleKey(domainProject, consumer)
+	proKey := apt.GenerateProviderDependencyRuleKey(domainProject, provider)
+	newConKey := apt.GenerateConsumerDependencyRuleKey(domainProject, newConsumer)
+	newProKey := apt.GenerateProviderDependencyRuleKey(domainProject, newProvider)
+	stage := &depRuleStage{
+		rules: map[string][]*proto.MicroServiceKey{
+			conKey: {provider},
+			proKey: {consumer},
+		},
+		staged: map[string][]*proto.MicroServiceKey{},
+	}
+	stage.remove(proKey, consumer)
+	stage.staged[conKey] = nil
+	stage.add(newConKey, newProvider)
+	stage.add(newConKey, newProvider)
+	stage.add(newProKey, newConsumer)
+
+	ops, changes, err := stage.ops()
+	if err != nil || len(ops) != 4 || len(changes) != 4 {
+		t.Fatalf("ops failed, %v", changes)
+	}
+	actions := map[string]string{}
+	for _, c := range changes {
+		actions[c.Name] = c.Action
+	}
+	rootLen := len(apt.GetServiceDependencyRuleRootKey(""))
+	if actions[conKey[rootLen:]] != MIGRATE_ACTION_DELETE ||
+		actions[proKey[rootLen:]] != MIGRATE_ACTION_DELETE ||
+		actions[newConKey[rootLen:]] != MIGRATE_ACTION_CREATE ||
+		actions[newProKey[rootLen:]] != MIGRATE_ACTION_CREATE {
+		t.Fatalf("ops failed, %v", actions)
+	}
+
+	stage.commit()
+	if _, ok := stage.rules[conKey]; ok || len(stage.rules[newConKey]) != 1 || len(stage.staged) != 0 {
+		t.Fatalf("commit failed")
+	}
+	stage.staged[newConKey] = stage.get(newConKey)
+	if ops, _, _ := stage.ops(); len(ops) != 0 {
+		t.Fatalf("ops of unchanged rule failed")
+	}
+}
@@ -0,0 +1,268 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/mux"
+	"github.com/apache/incubator-servicecomb-service-center/server/scheduler"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"strings"
+	"time"
+)
+
+const DEFAULT_DEPENDENCY_NORMALIZE_INTERVAL = 10 * time.Minute
+
+var normalizedRules = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "service_center",
+		Subsystem: "dependency",
+		Name:      "normalized_rules_total",
+		Help:      "Counter of duplicate or overlapping dependency rules removed",
+	}, []string{"source"})
+
+func init() {
+	prometheus.MustRegister(normalizedRules)
+}
+
+type versionBound struct {
+	kind  int
+	start int32
+	end   int32
+}
+
+const (
+	boundExact = iota
+	boundLatest
+	boundAtLess
+	boundRange
+)
+
+func parseVersionBound(versionRule string) versionBound {
+	rangeIdx := strings.Index(versionRule, "-")
+	switch {
+	case versionRule == "latest":
+		return versionBound{kind: boundLatest}
+	case len(versionRule) > 0 && versionRule[len(versionRule)-1:] == "+":
+		return versionBound{kind: boundAtLess, start: versionToInt(versionRule[:len(versionRule)-1])}
+	case rangeIdx > 0:
+		start, end := versionToInt(versionRule[:rangeIdx]), versionToInt(versionRule[rangeIdx+1:])
+		if start > end {
+			start, end = end, start
+		}
+		return versionBound{kind: boundRange, start: start, end: end}
+	default:
+		v := versionToInt(versionRule)
+		return versionBound{kind: boundExact, start: v, end: v}
+	}
+}
+
+// covers 判断a匹配的版本集合是否包含b，与Range一致，范围规则不包含结束版本
+func (a versionBound) covers(b versionBound) bool {
+	switch a.kind {
+	case boundAtLess:
+		return b.kind != boundLatest && b.start >= a.start
+	case boundRange:
+		switch b.kind {
+		case boundExact:
+			return b.start >= a.start && b.start < a.end
+		case boundRange:
+			return b.start >= a.start && b.end <= a.end
+		}
+	}
+	return false
+}
+
+func coverDependencyRule(a, b *pb.MicroServiceKey) bool {
+	if a.ServiceName == "*" {
+		return true
+	}
+	if !diffServiceVersion(a, b) {
+		return false
+	}
+	return parseVersionBound(a.Version).covers(parseVersionBound(b.Version))
+}
+
+// NormalizeDependencyRules 去除重复的provider规则以及被其它规则的版本范围覆盖的规则，
+// 如1.0.0被1.0.0+覆盖，返回保留的规则和被移除的规则
+func NormalizeDependencyRules(rules []*pb.MicroServiceKey) (normalized, removed []*pb.MicroServiceKey) {
+	normalized = make([]*pb.MicroServiceKey, 0, len(rules))
+	for i, rule := range rules {
+		redundant := false
+		for j, other := range rules {
+			if i == j {
+				continue
+			}
+			if equalServiceDependency(other, rule) || coverDependencyRule(other, rule) {
+				// 互相覆盖时保留先出现的规则
+				if j > i && (equalServiceDependency(other, rule) || coverDependencyRule(rule, other)) {
+					continue
+				}
+				redundant = true
+				break
+			}
+		}
+		if redundant {
+			removed = append(removed, rule)
+			continue
+		}
+		normalized = append(normalized, rule)
+	}
+	return
+}
+
+func indexOfServiceDependency(services []*pb.MicroServiceKey, service *pb.MicroServiceKey) int {
+	for i, tmp := range services {
+		if equalServiceDependency(tmp, service) {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalizeSyncRules 写入前规整consumer的规则，被移除的新规则不再写入provider，
+// 被移除的已有规则改为删除
+func normalizeSyncRules(dep *Dependency, newList, existList, deleteList []*pb.MicroServiceKey) (_, _, _ []*pb.MicroServiceKey) {
+	if len(newList) == 0 && len(existList) == 0 && len(deleteList) == 0 {
+		return newList, existList, deleteList
+	}
+	rules, removed := NormalizeDependencyRules(dep.ProvidersRule)
+	if len(removed) == 0 {
+		return newList, existList, deleteList
+	}
+	dep.ProvidersRule = rules
+	for _, rule := range removed {
+		if i := indexOfServiceDependency(newList, rule); i >= 0 {
+			newList = append(newList[:i:i], newList[i+1:]...)
+			continue
+		}
+		if isExist(rules, rule) {
+			continue
+		}
+		if i := indexOfServiceDependency(existList, rule); i >= 0 {
+			existList = append(existList[:i:i], existList[i+1:]...)
+			deleteList = append(deleteList, rule)
+		}
+	}
+	util.Logger().Infof("normalize dependency rule for consumer %s/%s/%s, remove %v",
+		dep.Consumer.AppId, dep.Consumer.ServiceName, dep.Consumer.Version, removed)
+	normalizedRules.WithLabelValues("write").Add(float64(len(removed)))
+	return newList, existList, deleteList
+}
+
+func parseConsumerDependencyRuleKey(key string) (string, *pb.MicroServiceKey) {
+	// {domain}/{project}/c/{env}/{appId}/{serviceName}/{version}
+	arr := strings.Split(key[len(apt.GetServiceDependencyRuleRootKey("")):], "/")
+	if len(arr) != 7 || arr[2] != "c" {
+		return "", nil
+	}
+	domainProject := arr[0] + "/" + arr[1]
+	return domainProject, &pb.MicroServiceKey{
+		Tenant:      domainProject,
+		Environment: arr[3],
+		AppId:       arr[4],
+		ServiceName: arr[5],
+		Version:     arr[6],
+	}
+}
+
+func normalizeConsumerDependencyRule(ctx context.Context, domainProject string, consumer *pb.MicroServiceKey) (int, error) {
+	lock, err := mux.Lock(mux.GLOBAL_LOCK)
+	if err != nil {
+		return 0, err
+	}
+	defer lock.Unlock()
+
+	conKey := apt.GenerateConsumerDependencyRuleKey(domainProject, consumer)
+	oldProviderRules, err := TransferToMicroServiceDependency(ctx, conKey)
+	if err != nil {
+		return 0, err
+	}
+	rules, removed := NormalizeDependencyRules(oldProviderRules.Dependency)
+	if len(removed) == 0 {
+		return 0, nil
+	}
+	err = CreateDependencyRule(ctx, &Dependency{
+		DomainProject: domainProject,
+		Consumer:      consumer,
+		ProvidersRule: rules,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(removed), nil
+}
+
+// NormalizeDependencies 规整所有consumer的依赖规则，返回移除的规则数
+func NormalizeDependencies(ctx context.Context) (int, error) {
+	ctx = util.SetContext(ctx, "noCache", "1")
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GetServiceDependencyRuleRootKey("")),
+		registry.WithPrefix())
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, kv := range resp.Kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		domainProject, consumer := parseConsumerDependencyRuleKey(key)
+		if consumer == nil {
+			continue
+		}
+		deps := &pb.MicroServiceDependency{}
+		if err := json.Unmarshal(kv.Value, deps); err != nil {
+			util.Logger().Errorf(err, "invalid dependency rule %s, skip it", key)
+			continue
+		}
+		if _, removed := NormalizeDependencyRules(deps.Dependency); len(removed) == 0 {
+			continue
+		}
+		c, err := normalizeConsumerDependencyRule(ctx, domainProject, consumer)
+		if err != nil {
+			util.Logger().Errorf(err, "normalize dependency rule %s failed", key)
+			continue
+		}
+		n += c
+	}
+	if n > 0 {
+		util.Logger().Infof("normalize dependency rules successfully, remove %d rule(s)", n)
+		normalizedRules.WithLabelValues("job").Add(float64(n))
+	}
+	return n, nil
+}
+
+// RunDependencyNormalize 周期性地规整存量的依赖规则
+func RunDependencyNormalize() {
+	scheduler.Register(&scheduler.Job{
+		Name:      "dependency_normalize",
+		Priority:  scheduler.PRIORITY_LOW,
+		Interval:  DEFAULT_DEPENDENCY_NORMALIZE_INTERVAL,
+		Singleton: true,
+		Func: func(ctx context.Context) error {
+			_, err := NormalizeDependencies(ctx)
+			return err
+		},
+	})
+}
@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"testing"
+)
+
+func versionsOf(keys []*proto.MicroServiceKey) (vers []string) {
+	for _, key := range keys {
+		vers = append(vers, key.ServiceName+":"+key.Version)
+	}
+	return
+}
+
+func TestNormalizeDependencyRules(t *testing.T) {
+	rule := func(name, version string) *proto.MicroServiceKey {
+		return &proto.MicroServiceKey{Tenant: "default/default", AppId: "a", ServiceName: name, Version: version}
+	}
+
+	cases := []struct {
+		rules   []*proto.MicroServiceKey
+		expects []string
+		removed int
+	}{
+		{[]*proto.MicroServiceKey{rule("p", "1.0.0"), rule("p", "1.0.0")}, []string{"p:1.0.0"}, 1},
+		{[]*proto.MicroServiceKey{rule("p", "1.0.0"), rule("p", "1.0.0+")}, []string{"p:1.0.0+"}, 1},
+		{[]*proto.MicroServiceKey{rule("p", "0.9.0"), rule("p", "1.0.0+")}, []string{"p:0.9.0", "p:1.0.0+"}, 0},
+		{[]*proto.MicroServiceKey{rule("p", "1.0.0-2.0.0"), rule("p", "1.5.0"), rule("p", "2.0.0")},
+			[]string{"p:1.0.0-2.0.0", "p:2.0.0"}, 1},
+		{[]*proto.MicroServiceKey{rule("p", "1.2.0-1.5.0"), rule("p", "1.0.0-2.0.0"), rule("p", "1.1.0+")},
+			[]string{"p:1.0.0-2.0.0", "p:1.1.0+"}, 1},
+		{[]*proto.MicroServiceKey{rule("p", "2.0.0+"), rule("p", "1.0.0+")}, []string{"p:1.0.0+"}, 1},
+		{[]*proto.MicroServiceKey{rule("p", "1.0+"), rule("p", "1.0.0+")}, []string{"p:1.0+"}, 1},
+		{[]*proto.MicroServiceKey{rule("p", "latest"), rule("p", "1.0.0+")}, []string{"p:latest", "p:1.0.0+"}, 0},
+		{[]*proto.MicroServiceKey{rule("p", "1.0.0"), rule("q", "1.0.0+")}, []string{"p:1.0.0", "q:1.0.0+"}, 0},
+		{[]*proto.MicroServiceKey{rule("p", "1.0.0"), rule("*", "")}, []string{"*:"}, 1},
+	}
+	for i, c := range cases {
+		normalized, removed := NormalizeDependencyRules(c.rules)
+		vers := versionsOf(normalized)
+		if len(removed) != c.removed || len(vers) != len(c.expects) {
+			t.Fatalf("case %d: NormalizeDependencyRules failed, %v", i, vers)
+		}
+		for j := range vers {
+			if vers[j] != c.expects[j] {
+				t.Fatalf("case %d: NormalizeDependencyRules failed, %v", i, vers)
+			}
+		}
+	}
+}
+
+func TestNormalizeSyncRules(t *testing.T) {
+	exact := &proto.MicroServiceKey{Tenant: "default/default", AppId: "a", ServiceName: "p", Version: "1.0.0"}
+	atLess := &proto.MicroServiceKey{Tenant: "default/default", AppId: "a", ServiceName: "p", Version: "1.0.0+"}
+	other := &proto.MicroServiceKey{Tenant: "default/default", AppId: "a", ServiceName: "q", Version: "1.0.0"}
+
+	dep := &Dependency{
+		Consumer:      &proto.MicroServiceKey{AppId: "a", ServiceName: "c", Version: "1.0.0"},
+		ProvidersRule: []*proto.MicroServiceKey{atLess, exact, other},
+	}
+	newList, existList, deleteList := normalizeSyncRules(dep,
+		[]*proto.MicroServiceKey{atLess}, []*proto.MicroServiceKey{exact, other}, nil)
+	if len(dep.ProvidersRule) != 2 || len(newList) != 1 || len(existList) != 1 || len(deleteList) != 1 ||
+		!equalServiceDependency(deleteList[0], exact) {
+		t.Fatalf("normalizeSyncRules exist rule failed")
+	}
+
+	dep.ProvidersRule = []*proto.MicroServiceKey{atLess, exact, exact}
+	newList, existList, deleteList = normalizeSyncRules(dep,
+		[]*proto.MicroServiceKey{exact, exact}, []*proto.MicroServiceKey{atLess}, nil)
+	if len(dep.ProvidersRule) != 1 || len(newList) != 0 || len(existList) != 1 || len(deleteList) != 0 {
+		t.Fatalf("normalizeSyncRules new rule failed")
+	}
+}
@@ -397,9 +397,9 @@ func TestDependency(t *testing.T) {
 			{ServiceName: "a", Version: "1.0.0"},
 		},
 	}
-	d.RemoveConsumerOfProviderRule()
-	d.AddConsumerOfProviderRule()
-	err := d.UpdateProvidersRuleOfConsumer("")
+	d.RemoveConsumerOfProviderRule(context.Background())
+	d.AddConsumerOfProviderRule(context.Background())
+	err := d.UpdateProvidersRuleOfConsumer(context.Background(), "")
 	if err == nil {
 		fmt.Printf(`Dependency_UpdateProvidersRuleOfConsumer failed`)
 		t.FailNow()
@@ -501,3 +501,40 @@ func TestDependency(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestUniqueServices(t *testing.T) {
+	ids := uniqueServiceIds([]string{"a", "b", "a", "c", "b"})
+	if len(ids) != 3 || ids[0] != "a" || ids[1] != "b" || ids[2] != "c" {
+		fmt.Printf(`uniqueServiceIds failed`)
+		t.FailNow()
+	}
+
+	keys := uniqueServiceKeys([]*proto.MicroServiceKey{
+		{Tenant: "default/default", AppId: "a", ServiceName: "s", Version: "1.0.0"},
+		{Tenant: "default/default", AppId: "a", ServiceName: "s", Version: "2.0.0"},
+		{Tenant: "default/default", AppId: "a", ServiceName: "s", Version: "1.0.0"},
+	})
+	if len(keys) != 2 || keys[1].Version != "2.0.0" {
+		fmt.Printf(`uniqueServiceKeys failed`)
+		t.FailNow()
+	}
+}
+
+func TestDetachedContext(t *testing.T) {
+	parent, cancel := context.WithCancel(util.SetContext(context.Background(), "domain", "default"))
+	cancel()
+	ctx, cancel := context.WithTimeout(detachedContext{parent}, DEPENDENCY_RULE_WRITE_TIMEOUT)
+	defer cancel()
+	if ctx.Err() != nil {
+		fmt.Printf(`TestDetachedContext failed, canceled with parent`)
+		t.FailNow()
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		fmt.Printf(`TestDetachedContext failed, no deadline`)
+		t.FailNow()
+	}
+	if util.FromContext(ctx, "domain") != "default" {
+		fmt.Printf(`TestDetachedContext failed, value lost`)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"math"
+	"strconv"
+	"time"
+)
+
+const DEFAULT_DEPENDENCY_TRAFFIC_TTL = 5 * time.Minute
+
+// DependencyTrafficTTL 上报未指定ttl时流量数据的保存时间，上报方需在过期前再次上报
+func DependencyTrafficTTL() time.Duration {
+	v := beego.AppConfig.DefaultString("dependency_traffic_ttl", DEFAULT_DEPENDENCY_TRAFFIC_TTL.String())
+	d, err := time.ParseDuration(v)
+	if err != nil || d < time.Second {
+		util.Logger().Errorf(err, "invalid dependency_traffic_ttl '%s', use %s", v, DEFAULT_DEPENDENCY_TRAFFIC_TTL)
+		return DEFAULT_DEPENDENCY_TRAFFIC_TTL
+	}
+	return d
+}
+
+func validRate(f float64) bool {
+	return f >= 0 && !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// CheckDependencyTraffic 调用率和延迟不能为负数，errorRate的上限由校验规则限制
+func CheckDependencyTraffic(t *pb.DependencyTraffic) error {
+	if t.ConsumerServiceId == t.ProviderServiceId {
+		return fmt.Errorf("consumer and provider of the traffic are the same service %s", t.ConsumerServiceId)
+	}
+	if !validRate(t.CallRate) || !validRate(t.ErrorRate) || !validRate(t.LatencyP99) {
+		return fmt.Errorf("invalid traffic of %s->%s, rates and latency must be non-negative numbers",
+			t.ConsumerServiceId, t.ProviderServiceId)
+	}
+	return nil
+}
+
+// SaveDependencyTraffics 保存上报的流量，同一批流量共用一个租约，每条依赖只保留最近一次上报
+func SaveDependencyTraffics(ctx context.Context, domainProject string, traffics []*pb.DependencyTraffic, ttl int64) error {
+	if len(traffics) == 0 {
+		return nil
+	}
+	leaseID, err := backend.Registry().LeaseGrant(ctx, ttl)
+	if err != nil {
+		return err
+	}
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	opts := make([]registry.PluginOp, 0, len(traffics))
+	for _, t := range traffics {
+		t.Timestamp = now
+		data, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, registry.OpPut(
+			registry.WithStrKey(apt.GenerateDependencyTrafficKey(domainProject, t.ConsumerServiceId, t.ProviderServiceId)),
+			registry.WithValue(data),
+			registry.WithLease(leaseID)))
+	}
+	return backend.BatchCommit(ctx, opts)
+}
+
+func getDependencyTraffics(ctx context.Context, prefix string, filter func(t *pb.DependencyTraffic) bool) ([]*pb.DependencyTraffic, error) {
+	resp, err := backend.Registry().Do(ctx, registry.GET,
+		registry.WithStrKey(prefix),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	traffics := make([]*pb.DependencyTraffic, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		t := &pb.DependencyTraffic{}
+		if err := json.Unmarshal(kv.Value, t); err != nil {
+			util.Logger().Errorf(err, "unmarshal dependency traffic %s failed", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		if filter == nil || filter(t) {
+			traffics = append(traffics, t)
+		}
+	}
+	return traffics, nil
+}
+
+// GetConsumerTraffics 查询消费者到各提供者的流量
+func GetConsumerTraffics(ctx context.Context, domainProject, consumerId string) ([]*pb.DependencyTraffic, error) {
+	return getDependencyTraffics(ctx, apt.GenerateDependencyTrafficKey(domainProject, consumerId, ""), nil)
+}
+
+// GetProviderTraffics 查询各消费者到提供者的流量，需要遍历租户的所有流量
+func GetProviderTraffics(ctx context.Context, domainProject, providerId string) ([]*pb.DependencyTraffic, error) {
+	return getDependencyTraffics(ctx, apt.GetDependencyTrafficRootKey(domainProject)+"/",
+		func(t *pb.DependencyTraffic) bool {
+			return t.ProviderServiceId == providerId
+		})
+}
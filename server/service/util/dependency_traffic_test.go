@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"math"
+	"testing"
+)
+
+func TestCheckDependencyTraffic(t *testing.T) {
+	err := serviceUtil.CheckDependencyTraffic(&pb.DependencyTraffic{
+		ConsumerServiceId: "c",
+		ProviderServiceId: "p",
+		CallRate:          100,
+		ErrorRate:         0.01,
+		LatencyP99:        25.5,
+	})
+	if err != nil {
+		fmt.Printf("CheckDependencyTraffic valid traffic failed")
+		t.FailNow()
+	}
+
+	err = serviceUtil.CheckDependencyTraffic(&pb.DependencyTraffic{ConsumerServiceId: "c", ProviderServiceId: "c"})
+	if err == nil {
+		fmt.Printf("CheckDependencyTraffic self dependency failed")
+		t.FailNow()
+	}
+
+	for _, f := range []float64{-1, math.NaN(), math.Inf(1)} {
+		err = serviceUtil.CheckDependencyTraffic(&pb.DependencyTraffic{
+			ConsumerServiceId: "c",
+			ProviderServiceId: "p",
+			LatencyP99:        f,
+		})
+		if err == nil {
+			fmt.Printf("CheckDependencyTraffic invalid latency %v failed", f)
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,323 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/scheduler"
+	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DEPENDENCY_GRAPH_METRICS_NAME      = "dependency-graph"
+	DEFAULT_DEPENDENCY_TREND_INTERVAL  = time.Hour
+	DEFAULT_DEPENDENCY_TREND_RETENTION = 90 * 24 * time.Hour
+	dependencyGraphEdgeSeparator       = " -> "
+)
+
+// DependencyGraphStats 租户依赖图在某个周期的统计，新增和删除的边相对于上一次快照
+type DependencyGraphStats struct {
+	Domain       string `json:"domain"`
+	Project      string `json:"project"`
+	Timestamp    int64  `json:"timestamp"`
+	Nodes        int    `json:"nodes"`
+	Edges        int    `json:"edges"`
+	MaxFanIn     int    `json:"maxFanIn"`
+	MaxFanOut    int    `json:"maxFanOut"`
+	NewEdges     int    `json:"newEdges"`
+	RemovedEdges int    `json:"removedEdges"`
+}
+
+// DependencyGraph 服务级别的依赖图，同一服务不同版本的依赖合并为一条边
+type DependencyGraph struct {
+	providers map[string]map[string]struct{}
+}
+
+func NewDependencyGraph() *DependencyGraph {
+	return &DependencyGraph{providers: make(map[string]map[string]struct{})}
+}
+
+func (g *DependencyGraph) AddEdge(consumer, provider string) {
+	ps, ok := g.providers[consumer]
+	if !ok {
+		ps = make(map[string]struct{})
+		g.providers[consumer] = ps
+	}
+	ps[provider] = struct{}{}
+}
+
+// Edges 返回排序后的边，格式为consumer -> provider
+func (g *DependencyGraph) Edges() []string {
+	edges := make([]string, 0, len(g.providers))
+	for consumer, ps := range g.providers {
+		for provider := range ps {
+			edges = append(edges, consumer+dependencyGraphEdgeSeparator+provider)
+		}
+	}
+	sort.Strings(edges)
+	return edges
+}
+
+// Stats 统计节点、边和最大扇入扇出，prevEdges为上一次快照的边
+func (g *DependencyGraph) Stats(prevEdges []string) *DependencyGraphStats {
+	stats := &DependencyGraphStats{}
+	nodes := make(map[string]struct{})
+	fanIn := make(map[string]int)
+	for consumer, ps := range g.providers {
+		nodes[consumer] = struct{}{}
+		if len(ps) > stats.MaxFanOut {
+			stats.MaxFanOut = len(ps)
+		}
+		for provider := range ps {
+			nodes[provider] = struct{}{}
+			fanIn[provider]++
+			stats.Edges++
+		}
+	}
+	for _, n := range fanIn {
+		if n > stats.MaxFanIn {
+			stats.MaxFanIn = n
+		}
+	}
+	stats.Nodes = len(nodes)
+
+	prev := make(map[string]struct{}, len(prevEdges))
+	for _, edge := range prevEdges {
+		prev[edge] = struct{}{}
+	}
+	for _, edge := range g.Edges() {
+		if _, ok := prev[edge]; ok {
+			delete(prev, edge)
+			continue
+		}
+		stats.NewEdges++
+	}
+	stats.RemovedEdges = len(prev)
+	return stats
+}
+
+// loadDependencyGraphs 按消费者的依赖规则构建各租户的依赖图，
+// 依赖所有服务的'*'规则不是具体的边，不参与统计
+func loadDependencyGraphs(ctx context.Context) (map[string]*DependencyGraph, error) {
+	resp, err := store.Store().DependencyRule().Search(ctx,
+		registry.WithStrKey(apt.GetServiceDependencyRuleRootKey("")),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	graphs := make(map[string]*DependencyGraph)
+	for _, kv := range resp.Kvs {
+		domainProject, consumer, ok := getInfoFromConsumerRuleKV(kv)
+		if !ok {
+			continue
+		}
+		deps := &pb.MicroServiceDependency{}
+		if err := json.Unmarshal(kv.Value, deps); err != nil {
+			util.Logger().Errorf(err, "unmarshal dependency rule %s failed", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		for _, provider := range deps.Dependency {
+			if provider.ServiceName == "*" {
+				continue
+			}
+			g, ok := graphs[domainProject]
+			if !ok {
+				g = NewDependencyGraph()
+				graphs[domainProject] = g
+			}
+			g.AddEdge(topologyName(consumer), topologyName(provider))
+		}
+	}
+	return graphs, nil
+}
+
+func loadPrevDependencyGraphEdges(ctx context.Context) (map[string][]string, error) {
+	rootKey := apt.GetDependencyGraphEdgesRootKey() + "/"
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(rootKey),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	prev := make(map[string][]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		var edges []string
+		if err := json.Unmarshal(kv.Value, &edges); err != nil {
+			util.Logger().Errorf(err, "invalid dependency graph edges %s", key)
+			continue
+		}
+		prev[key[len(rootKey):]] = edges
+	}
+	return prev, nil
+}
+
+// saveDependencyGraphStats 同一周期只保存一次，多个节点同时快照时后写入的放弃
+func saveDependencyGraphStats(ctx context.Context, stats *DependencyGraphStats, edges []string) (bool, error) {
+	domainProject := stats.Domain + "/" + stats.Project
+	key := apt.GenerateMetricsKey(DEPENDENCY_GRAPH_METRICS_NAME, strconv.FormatInt(stats.Timestamp, 10), domainProject)
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return false, err
+	}
+	edgesData, err := json.Marshal(edges)
+	if err != nil {
+		return false, err
+	}
+	resp, err := backend.Registry().TxnWithCmp(ctx,
+		[]registry.PluginOp{
+			registry.OpPut(registry.WithStrKey(key), registry.WithValue(data)),
+			registry.OpPut(registry.WithStrKey(apt.GenerateDependencyGraphEdgesKey(domainProject)),
+				registry.WithValue(edgesData)),
+		},
+		[]registry.CompareOp{registry.OpCmp(registry.CmpStrCreateRev(key), registry.CMP_EQUAL, 0)},
+		nil)
+	if err != nil {
+		return false, err
+	}
+	return resp.Succeeded, nil
+}
+
+// SnapshotDependencyGraphs 保存各租户在period周期的依赖图统计，返回保存的快照数；
+// 依赖已全部删除的租户再保存一次空图，记录删除的边
+func SnapshotDependencyGraphs(ctx context.Context, period time.Time) (int, error) {
+	graphs, err := loadDependencyGraphs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	prev, err := loadPrevDependencyGraphEdges(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for domainProject, edges := range prev {
+		if _, ok := graphs[domainProject]; !ok && len(edges) > 0 {
+			graphs[domainProject] = NewDependencyGraph()
+		}
+	}
+
+	n := 0
+	for domainProject, g := range graphs {
+		arr := strings.SplitN(domainProject, "/", 2)
+		if len(arr) != 2 {
+			continue
+		}
+		stats := g.Stats(prev[domainProject])
+		stats.Domain, stats.Project, stats.Timestamp = arr[0], arr[1], period.Unix()
+		ok, err := saveDependencyGraphStats(ctx, stats, g.Edges())
+		if err != nil {
+			return n, err
+		}
+		if ok {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// QueryDependencyGraphStats 查询周期开始时间在[start, end)内的快照，domain、project为空时不过滤
+func QueryDependencyGraphStats(ctx context.Context, start, end time.Time, domain, project string) ([]*DependencyGraphStats, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateMetricsKey(DEPENDENCY_GRAPH_METRICS_NAME, strconv.FormatInt(start.Unix(), 10), "")),
+		registry.WithStrEndKey(apt.GenerateMetricsKey(DEPENDENCY_GRAPH_METRICS_NAME, strconv.FormatInt(end.Unix(), 10), "")),
+		registry.WithAscendOrder())
+	if err != nil {
+		return nil, err
+	}
+
+	trends := make([]*DependencyGraphStats, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		stats := &DependencyGraphStats{}
+		if err := json.Unmarshal(kv.Value, stats); err != nil {
+			util.Logger().Errorf(err, "invalid dependency graph stats %s", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		if stats.Timestamp >= end.Unix() ||
+			(len(domain) > 0 && stats.Domain != domain) ||
+			(len(project) > 0 && stats.Project != project) {
+			continue
+		}
+		trends = append(trends, stats)
+	}
+	return trends, nil
+}
+
+// ExpireDependencyGraphStats 删除周期开始时间早于before的快照，返回删除的个数
+func ExpireDependencyGraphStats(ctx context.Context, before time.Time) (int, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateMetricsKey(DEPENDENCY_GRAPH_METRICS_NAME, "", "")),
+		registry.WithStrEndKey(apt.GenerateMetricsKey(DEPENDENCY_GRAPH_METRICS_NAME, strconv.FormatInt(before.Unix(), 10), "")),
+		registry.WithKeyOnly())
+	if err != nil || len(resp.Kvs) == 0 {
+		return 0, err
+	}
+	opts := make([]registry.PluginOp, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		opts = append(opts, registry.OpDel(registry.WithKey(kv.Key)))
+	}
+	return len(opts), backend.BatchCommit(ctx, opts)
+}
+
+// RunDependencyTrend 按dependency_trend_interval周期快照依赖图，为0时不开启，
+// 快照保留dependency_trend_retention天
+func RunDependencyTrend() {
+	interval := time.Duration(beego.AppConfig.DefaultInt64("dependency_trend_interval",
+		int64(DEFAULT_DEPENDENCY_TREND_INTERVAL/time.Second))) * time.Second
+	if interval <= 0 {
+		return
+	}
+	retention := time.Duration(beego.AppConfig.DefaultInt64("dependency_trend_retention",
+		int64(DEFAULT_DEPENDENCY_TREND_RETENTION/(24*time.Hour)))) * 24 * time.Hour
+	scheduler.Register(&scheduler.Job{
+		Name:      "dependency_trend",
+		Priority:  scheduler.PRIORITY_LOW,
+		Interval:  interval,
+		Immediate: true,
+		Singleton: true,
+		Func: func(ctx context.Context) error {
+			now := time.Now()
+			// 按周期对齐，节点重启或多个节点执行时同一周期只有一个快照
+			period := now.Truncate(interval)
+			n, err := SnapshotDependencyGraphs(ctx, period)
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				util.Logger().Infof("%d dependency graph snapshot(s) are saved at %d", n, period.Unix())
+			}
+			if retention <= 0 {
+				return nil
+			}
+			_, err = ExpireDependencyGraphStats(ctx, now.Add(-retention))
+			return err
+		},
+	})
+}
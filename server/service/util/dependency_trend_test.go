@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func TestDependencyGraphStats(t *testing.T) {
+	g := serviceUtil.NewDependencyGraph()
+	if s := g.Stats(nil); s.Nodes != 0 || s.Edges != 0 || s.NewEdges != 0 {
+		fmt.Printf(`Stats of empty graph failed, %v`, s)
+		t.FailNow()
+	}
+
+	g.AddEdge("app/a", "app/b")
+	g.AddEdge("app/a", "app/c")
+	g.AddEdge("app/a", "app/b")
+	g.AddEdge("app/d", "app/b")
+	s := g.Stats(nil)
+	if s.Nodes != 4 || s.Edges != 3 || s.MaxFanIn != 2 || s.MaxFanOut != 2 ||
+		s.NewEdges != 3 || s.RemovedEdges != 0 {
+		fmt.Printf(`Stats failed, %v`, s)
+		t.FailNow()
+	}
+
+	edges := g.Edges()
+	if len(edges) != 3 || edges[0] != "app/a -> app/b" {
+		fmt.Printf(`Edges failed, %v`, edges)
+		t.FailNow()
+	}
+
+	s = g.Stats([]string{"app/a -> app/b", "app/x -> app/b"})
+	if s.NewEdges != 2 || s.RemovedEdges != 1 {
+		fmt.Printf(`Stats with previous edges failed, %v`, s)
+		t.FailNow()
+	}
+
+	s = serviceUtil.NewDependencyGraph().Stats(edges)
+	if s.Edges != 0 || s.NewEdges != 0 || s.RemovedEdges != 3 {
+		fmt.Printf(`Stats of removed graph failed, %v`, s)
+		t.FailNow()
+	}
+}
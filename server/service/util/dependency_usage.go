@@ -0,0 +1,222 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 同一对消费者、提供者的发现记录在该时间内不重复写入
+const DEFAULT_USAGE_REFRESH_INTERVAL = time.Hour
+
+func getDependencyUsage(ctx context.Context, key string) (*pb.DependencyUsage, error) {
+	opts := append(FromContext(ctx), registry.WithStrKey(key))
+	resp, err := store.Store().DependencyUsage().Search(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	usage := &pb.DependencyUsage{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// RecordDependencyUsage 记录消费者通过Find发现的提供者
+func RecordDependencyUsage(ctx context.Context, domainProject, consumerId, versionRule string, providerIds []string) error {
+	now := time.Now()
+	for _, providerId := range providerIds {
+		key := apt.GenerateDependencyUsageKey(domainProject, consumerId, providerId)
+		usage, err := getDependencyUsage(ctx, key)
+		if err != nil {
+			util.Logger().Errorf(err, "get dependency usage %s failed", key)
+			return err
+		}
+		if usage != nil && usage.VersionRule == versionRule {
+			ts, _ := strconv.ParseInt(usage.Timestamp, 10, 64)
+			if now.Sub(time.Unix(ts, 0)) < DEFAULT_USAGE_REFRESH_INTERVAL {
+				continue
+			}
+		}
+
+		data, err := json.Marshal(&pb.DependencyUsage{
+			ProviderServiceId: providerId,
+			VersionRule:       versionRule,
+			Timestamp:         strconv.FormatInt(now.Unix(), 10),
+		})
+		if err != nil {
+			util.Logger().Errorf(err, "put dependency usage %s: json marshal failed.", key)
+			return err
+		}
+		_, err = backend.Registry().Do(ctx,
+			registry.PUT,
+			registry.WithStrKey(key),
+			registry.WithValue(data))
+		if err != nil {
+			util.Logger().Errorf(err, "put dependency usage %s: commit into etcd failed.", key)
+			return err
+		}
+	}
+	return nil
+}
+
+func GetDependencyUsages(ctx context.Context, domainProject, consumerId string) ([]*pb.DependencyUsage, error) {
+	key := apt.GenerateDependencyUsageKey(domainProject, consumerId, "")
+	opts := append(FromContext(ctx),
+		registry.WithStrKey(key),
+		registry.WithPrefix())
+	resp, err := store.Store().DependencyUsage().Search(ctx, opts...)
+	if err != nil {
+		util.Logger().Errorf(err, "get dependency usages %s failed", key)
+		return nil, err
+	}
+
+	usages := make([]*pb.DependencyUsage, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		usage := &pb.DependencyUsage{}
+		if err := json.Unmarshal(kv.Value, usage); err != nil {
+			util.Logger().Errorf(err, "unmarshal dependency usage %s failed", util.BytesToStringWithNoCopy(kv.Key))
+			return nil, err
+		}
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+// GetProviderAccessors 返回since之后发现过该提供者的消费者，按最近发现时间倒序
+func GetProviderAccessors(ctx context.Context, domainProject, providerId string, since time.Time) ([]*pb.ProviderAccessor, error) {
+	prefix := apt.GetDependencyUsageRootKey(domainProject) + "/"
+	opts := append(FromContext(ctx),
+		registry.WithStrKey(prefix),
+		registry.WithPrefix())
+	resp, err := store.Store().DependencyUsage().Search(ctx, opts...)
+	if err != nil {
+		util.Logger().Errorf(err, "get dependency usages %s failed", prefix)
+		return nil, err
+	}
+
+	accessors := make([]*pb.ProviderAccessor, 0)
+	for _, kv := range resp.Kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		// key: {prefix}{consumerId}/{providerId}
+		arr := strings.Split(key[len(prefix):], "/")
+		if len(arr) != 2 || arr[1] != providerId {
+			continue
+		}
+		usage := &pb.DependencyUsage{}
+		if err := json.Unmarshal(kv.Value, usage); err != nil {
+			util.Logger().Errorf(err, "unmarshal dependency usage %s failed", key)
+			return nil, err
+		}
+		ts, _ := strconv.ParseInt(usage.Timestamp, 10, 64)
+		if time.Unix(ts, 0).Before(since) {
+			continue
+		}
+
+		consumer, err := GetService(ctx, domainProject, arr[0])
+		if err != nil {
+			return nil, err
+		}
+		if consumer == nil {
+			// 消费者已删除
+			continue
+		}
+		count, err := GetInstanceCountOfOneService(ctx, domainProject, consumer.ServiceId)
+		if err != nil {
+			return nil, err
+		}
+		accessors = append(accessors, &pb.ProviderAccessor{
+			ConsumerServiceId:   consumer.ServiceId,
+			Consumer:            pb.MicroServiceToKey(domainProject, consumer),
+			InstanceCount:       count,
+			LastAccessTimestamp: usage.Timestamp,
+		})
+	}
+	sort.Slice(accessors, func(i, j int) bool {
+		return accessors[i].LastAccessTimestamp > accessors[j].LastAccessTimestamp
+	})
+	return accessors, nil
+}
+
+// DependencyDiff 对比消费者声明的依赖规则与实际发现的提供者，
+// 返回未被使用的规则以及未声明而被发现的提供者
+func DependencyDiff(ctx context.Context, domainProject string, consumer *pb.MicroService) (unused []*pb.MicroServiceKey, undeclared []*pb.MicroService, err error) {
+	conKey := apt.GenerateConsumerDependencyRuleKey(domainProject, pb.MicroServiceToKey(domainProject, consumer))
+	rules, err := TransferToMicroServiceDependency(ctx, conKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	usages, err := GetDependencyUsages(ctx, domainProject, consumer.ServiceId)
+	if err != nil {
+		return nil, nil, err
+	}
+	used := make(map[string]struct{}, len(usages))
+	for _, usage := range usages {
+		used[usage.ProviderServiceId] = struct{}{}
+	}
+
+	dr := NewConsumerDependencyRelation(ctx, domainProject, consumer.ServiceId, consumer)
+	declared := make(map[string]struct{})
+	unused = make([]*pb.MicroServiceKey, 0)
+	for _, rule := range rules.Dependency {
+		providerIds, err := dr.getDependencyProviderIds([]*pb.MicroServiceKey{rule})
+		if err != nil {
+			return nil, nil, err
+		}
+		inUse := false
+		for _, providerId := range providerIds {
+			declared[providerId] = struct{}{}
+			if _, ok := used[providerId]; ok {
+				inUse = true
+			}
+		}
+		if !inUse {
+			unused = append(unused, rule)
+		}
+	}
+
+	undeclared = make([]*pb.MicroService, 0)
+	for _, usage := range usages {
+		if _, ok := declared[usage.ProviderServiceId]; ok {
+			continue
+		}
+		provider, err := GetService(ctx, domainProject, usage.ProviderServiceId)
+		if err != nil {
+			return nil, nil, err
+		}
+		if provider == nil {
+			// 提供者已删除
+			continue
+		}
+		undeclared = append(undeclared, provider)
+	}
+	return unused, undeclared, nil
+}
@@ -0,0 +1,267 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/mux"
+	"github.com/astaxie/beego"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_DEPENDENCY_FLUSH_INTERVAL = time.Second
+	DEFAULT_DEPENDENCY_DEDUPE_WINDOW  = time.Minute
+	MAX_PENDING_DEPENDENCIES          = 10000
+)
+
+var (
+	findDependencyWrites = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "dependency",
+			Name:      "find_writes_total",
+			Help:      "Counter of the dependency writes triggered by finding instances",
+		}, []string{"result"})
+
+	dependencyWriter = NewDependencyWriter()
+)
+
+func init() {
+	prometheus.MustRegister(findDependencyWrites)
+}
+
+type pendingDependency struct {
+	domainProject string
+	provider      *pb.MicroServiceKey
+	consumer      *pb.MicroServiceKey
+}
+
+type writtenDependency struct {
+	versionRule string
+	// 写入后provider依赖规则的revision
+	revision  int64
+	timestamp time.Time
+}
+
+// DependencyWriter 合并Find隐式产生的依赖写入，DedupeWindow内相同的依赖只写一次，
+// 后台每隔FlushInterval批量提交，失败的依赖保留到下次重试，保证依赖关系最终一致
+type DependencyWriter struct {
+	FlushInterval time.Duration
+	DedupeWindow  time.Duration
+
+	mux     sync.Mutex
+	running bool
+	pending map[string]*pendingDependency
+	written map[string]*writtenDependency
+	// 查询provider依赖规则当前的revision
+	ruleRevision func(ctx context.Context, domainProject string, provider *pb.MicroServiceKey) (int64, error)
+}
+
+func NewDependencyWriter() *DependencyWriter {
+	return &DependencyWriter{
+		FlushInterval: DEFAULT_DEPENDENCY_FLUSH_INTERVAL,
+		DedupeWindow:  DEFAULT_DEPENDENCY_DEDUPE_WINDOW,
+		pending:       make(map[string]*pendingDependency),
+		written:       make(map[string]*writtenDependency),
+		ruleRevision:  providerRuleRevision,
+	}
+}
+
+// providerRuleRevision 返回provider依赖规则的revision，规则不存在时为0
+func providerRuleRevision(ctx context.Context, domainProject string, provider *pb.MicroServiceKey) (int64, error) {
+	key := apt.GenerateProviderDependencyRuleKey(domainProject, provider)
+	opts := append(FromContext(ctx), registry.WithStrKey(key))
+	resp, err := store.Store().DependencyRule().Search(ctx, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, nil
+	}
+	return resp.Kvs[0].ModRevision, nil
+}
+
+// dependencyWriteKey 同一consumer对同一provider的依赖只保留最新的版本规则
+func dependencyWriteKey(domainProject string, provider, consumer *pb.MicroServiceKey) string {
+	return strings.Join([]string{apt.GenerateConsumerDependencyRuleKey(domainProject, consumer),
+		provider.Environment, provider.AppId, provider.ServiceName}, "|")
+}
+
+// Add 记录Find产生的依赖，未运行后台提交或积压过多时直接写入
+func (w *DependencyWriter) Add(ctx context.Context, domainProject string, provider, consumer *pb.MicroServiceKey) error {
+	key := dependencyWriteKey(domainProject, provider, consumer)
+	now := time.Now()
+
+	if w.deduped(ctx, key, domainProject, provider, now) {
+		findDependencyWrites.WithLabelValues("deduped").Inc()
+		return nil
+	}
+
+	w.mux.Lock()
+	if w.running && len(w.pending) < MAX_PENDING_DEPENDENCIES {
+		w.pending[key] = &pendingDependency{domainProject, provider, consumer}
+		w.mux.Unlock()
+		findDependencyWrites.WithLabelValues("queued").Inc()
+		return nil
+	}
+	w.mux.Unlock()
+
+	if err := AddServiceVersionRule(ctx, domainProject, provider, consumer); err != nil {
+		findDependencyWrites.WithLabelValues("failed").Inc()
+		return err
+	}
+	findDependencyWrites.WithLabelValues("written").Inc()
+	w.markWritten(ctx, key, domainProject, provider, now)
+	return nil
+}
+
+// deduped 窗口内写过相同的依赖，且provider依赖规则没有被改写过；
+// 规则被覆盖、删除、改名、迁移、孤儿回收或由其他节点修改后revision变化，需重新写入
+func (w *DependencyWriter) deduped(ctx context.Context, key, domainProject string, provider *pb.MicroServiceKey, now time.Time) bool {
+	w.mux.Lock()
+	item, ok := w.written[key]
+	w.mux.Unlock()
+	if !ok || item.versionRule != provider.Version || now.Sub(item.timestamp) >= w.DedupeWindow {
+		return false
+	}
+	rev, err := w.ruleRevision(ctx, domainProject, provider)
+	return err == nil && rev == item.revision
+}
+
+func (w *DependencyWriter) markWritten(ctx context.Context, key, domainProject string, provider *pb.MicroServiceKey, now time.Time) {
+	rev, err := w.ruleRevision(ctx, domainProject, provider)
+	if err != nil {
+		return
+	}
+	w.mux.Lock()
+	w.written[key] = &writtenDependency{provider.Version, rev, now}
+	w.mux.Unlock()
+}
+
+// Pending 待提交的依赖数
+func (w *DependencyWriter) Pending() int {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	return len(w.pending)
+}
+
+// Flush 在一次全局锁内提交所有待写入的依赖，返回成功写入数
+func (w *DependencyWriter) Flush(ctx context.Context) (int, error) {
+	now := time.Now()
+	w.mux.Lock()
+	for key, item := range w.written {
+		if now.Sub(item.timestamp) >= w.DedupeWindow {
+			delete(w.written, key)
+		}
+	}
+	pending := w.pending
+	w.pending = make(map[string]*pendingDependency)
+	w.mux.Unlock()
+
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	lock, err := mux.Lock(mux.GLOBAL_LOCK)
+	if err != nil {
+		w.requeue(pending)
+		return 0, err
+	}
+	defer lock.Unlock()
+
+	// 同一批次可能多次修改同一consumer的规则，不能读缓存
+	ctx = util.SetContext(ctx, "noCache", "1")
+	n := 0
+	var lastErr error
+	for key, item := range pending {
+		if err := createDependencyRuleForFind(ctx, item.domainProject, item.provider, item.consumer); err != nil {
+			util.Logger().Errorf(err, "flush dependency %s failed, retry later", key)
+			findDependencyWrites.WithLabelValues("failed").Inc()
+			lastErr = err
+			continue
+		}
+		delete(pending, key)
+		w.markWritten(ctx, key, item.domainProject, item.provider, now)
+		n++
+	}
+	findDependencyWrites.WithLabelValues("written").Add(float64(n))
+	w.requeue(pending)
+	return n, lastErr
+}
+
+// Reset 依赖规则被覆盖或删除后清空去重记录，之后的Find重新写入依赖
+func (w *DependencyWriter) Reset() {
+	w.mux.Lock()
+	w.written = make(map[string]*writtenDependency)
+	w.mux.Unlock()
+}
+
+// requeue 失败的依赖放回队列，已有更新的同key依赖时丢弃旧值
+func (w *DependencyWriter) requeue(pending map[string]*pendingDependency) {
+	w.mux.Lock()
+	for key, item := range pending {
+		if _, ok := w.pending[key]; !ok {
+			w.pending[key] = item
+		}
+	}
+	w.mux.Unlock()
+}
+
+func (w *DependencyWriter) Run() {
+	if w.FlushInterval <= 0 {
+		return
+	}
+	w.mux.Lock()
+	w.running = true
+	w.mux.Unlock()
+
+	util.Go(func(stopCh <-chan struct{}) {
+		for {
+			select {
+			case <-stopCh:
+				w.Flush(context.Background())
+				return
+			case <-time.After(w.FlushInterval):
+				if _, err := w.Flush(context.Background()); err != nil {
+					util.Logger().Errorf(err, "flush dependencies failed, %d pending", w.Pending())
+				}
+			}
+		}
+	})
+}
+
+func GetDependencyWriter() *DependencyWriter {
+	return dependencyWriter
+}
+
+// RunDependencyWriter 开启依赖的异步批量写入，dependency_flush_interval为0时仍同步写入
+func RunDependencyWriter() {
+	dependencyWriter.FlushInterval = time.Duration(beego.AppConfig.DefaultInt64("dependency_flush_interval",
+		int64(DEFAULT_DEPENDENCY_FLUSH_INTERVAL/time.Second))) * time.Second
+	dependencyWriter.DedupeWindow = time.Duration(beego.AppConfig.DefaultInt64("dependency_dedupe_window",
+		int64(DEFAULT_DEPENDENCY_DEDUPE_WINDOW/time.Second))) * time.Second
+	dependencyWriter.Run()
+}
@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"golang.org/x/net/context"
+	"testing"
+	"time"
+)
+
+func TestDependencyWriter_Add(t *testing.T) {
+	domainProject := "default/default"
+	consumer := &proto.MicroServiceKey{Tenant: domainProject, AppId: "a", ServiceName: "c", Version: "1.0.0"}
+	provider := func(version string) *proto.MicroServiceKey {
+		return &proto.MicroServiceKey{Tenant: domainProject, AppId: "a", ServiceName: "p", Version: version}
+	}
+	key := dependencyWriteKey(domainProject, provider(""), consumer)
+
+	// 模拟provider依赖规则的revision
+	rev := int64(10)
+	w := NewDependencyWriter()
+	w.ruleRevision = func(context.Context, string, *proto.MicroServiceKey) (int64, error) {
+		return rev, nil
+	}
+	w.running = true
+	ctx := context.Background()
+	w.Add(ctx, domainProject, provider("1.0.0"), consumer)
+	w.Add(ctx, domainProject, provider("1.0.0+"), consumer)
+	if w.Pending() != 1 || w.pending[key].provider.Version != "1.0.0+" {
+		t.Fatalf("TestDependencyWriter_Add failed, the latest version rule should be kept, %d", w.Pending())
+	}
+
+	w.pending = make(map[string]*pendingDependency)
+	w.markWritten(ctx, key, domainProject, provider("1.0.0+"), time.Now())
+	w.Add(ctx, domainProject, provider("1.0.0+"), consumer)
+	if w.Pending() != 0 {
+		t.Fatalf("TestDependencyWriter_Add failed, the written dependency should be deduped")
+	}
+	w.Add(ctx, domainProject, provider("latest"), consumer)
+	if w.Pending() != 1 {
+		t.Fatalf("TestDependencyWriter_Add failed, the changed version rule should be queued")
+	}
+
+	w.pending = make(map[string]*pendingDependency)
+	w.markWritten(ctx, key, domainProject, provider("1.0.0+"), time.Now().Add(-2*w.DedupeWindow))
+	w.Add(ctx, domainProject, provider("1.0.0+"), consumer)
+	if w.Pending() != 1 {
+		t.Fatalf("TestDependencyWriter_Add failed, the dependency should be queued after the window")
+	}
+
+	w.pending = make(map[string]*pendingDependency)
+	w.markWritten(ctx, key, domainProject, provider("1.0.0+"), time.Now())
+	w.Reset()
+	w.Add(ctx, domainProject, provider("1.0.0+"), consumer)
+	if w.Pending() != 1 {
+		t.Fatalf("TestDependencyWriter_Add failed, the dependency should be queued after reset")
+	}
+
+	// 规则被其他节点、改名、迁移或孤儿回收等路径改写后不再去重
+	w.pending = make(map[string]*pendingDependency)
+	w.markWritten(ctx, key, domainProject, provider("1.0.0+"), time.Now())
+	rev = 11
+	w.Add(ctx, domainProject, provider("1.0.0+"), consumer)
+	if w.Pending() != 1 {
+		t.Fatalf("TestDependencyWriter_Add failed, the dependency should be queued after the rule is rewritten")
+	}
+	w.pending = make(map[string]*pendingDependency)
+	w.markWritten(ctx, key, domainProject, provider("1.0.0+"), time.Now())
+	w.Add(ctx, domainProject, provider("1.0.0+"), consumer)
+	if w.Pending() != 0 {
+		t.Fatalf("TestDependencyWriter_Add failed, the rewritten dependency should be deduped again")
+	}
+}
+
+func TestDependencyWriter_Requeue(t *testing.T) {
+	w := NewDependencyWriter()
+	old := &pendingDependency{provider: &proto.MicroServiceKey{Version: "1.0.0"}}
+	newer := &pendingDependency{provider: &proto.MicroServiceKey{Version: "2.0.0"}}
+	w.pending["k1"] = newer
+	w.requeue(map[string]*pendingDependency{"k1": old, "k2": old})
+	if w.Pending() != 2 || w.pending["k1"] != newer {
+		t.Fatalf("TestDependencyWriter_Requeue failed, the newer dependency should be kept")
+	}
+}
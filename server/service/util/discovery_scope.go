@@ -0,0 +1,247 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 发现时缓存发现范围的时间，其他节点上的变更最多延迟该时间生效
+const DISCOVERY_SCOPE_CACHE_TTL = 30 * time.Second
+
+// ENV_ANY 表示所有环境
+const ENV_ANY = "*"
+
+var (
+	environments = []string{pb.ENV_DEV, pb.ENV_TEST, pb.ENV_ACCEPT, pb.ENV_PROD}
+
+	discoveryScopeCache = &discoveryScopeListCache{}
+)
+
+// DiscoveryScope 管理员按domain/project配置的跨环境发现范围，未配置时只能发现同一环境的服务。
+// visibility为消费者环境到可访问的提供者环境的映射，值可以为*；
+// allowCrossEnvironment为true时发现实例默认包含可访问的其他环境，否则只在请求指定crossEnvironment时包含
+type DiscoveryScope struct {
+	Domain                string              `json:"domain"`
+	Project               string              `json:"project"`
+	AllowCrossEnvironment bool                `json:"allowCrossEnvironment"`
+	Visibility            map[string][]string `json:"visibility,omitempty"`
+	Operator              string              `json:"operator,omitempty"`
+	Timestamp             string              `json:"timestamp,omitempty"`
+}
+
+func (s *DiscoveryScope) DomainProject() string {
+	return util.StringJoin([]string{s.Domain, s.Project}, "/")
+}
+
+func validEnvironment(env string) bool {
+	for _, e := range environments {
+		if e == env {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeEnvironment 服务环境为空时按development处理，与服务索引一致
+func normalizeEnvironment(env string) string {
+	if len(env) == 0 {
+		return pb.ENV_DEV
+	}
+	return env
+}
+
+func (s *DiscoveryScope) Check() error {
+	if len(s.Domain) == 0 || len(s.Project) == 0 {
+		return errors.New("domain and project are required")
+	}
+	for consumerEnv, providerEnvs := range s.Visibility {
+		if !validEnvironment(consumerEnv) {
+			return fmt.Errorf("invalid consumer environment '%s'", consumerEnv)
+		}
+		for _, env := range providerEnvs {
+			if env != ENV_ANY && !validEnvironment(env) {
+				return fmt.Errorf("invalid provider environment '%s' of %s", env, consumerEnv)
+			}
+		}
+	}
+	return nil
+}
+
+// Visible 消费者环境能否访问提供者环境，同一环境总是可以访问
+func (s *DiscoveryScope) Visible(consumerEnv, providerEnv string) bool {
+	consumerEnv, providerEnv = normalizeEnvironment(consumerEnv), normalizeEnvironment(providerEnv)
+	if consumerEnv == providerEnv {
+		return true
+	}
+	if s == nil {
+		return false
+	}
+	for _, env := range s.Visibility[consumerEnv] {
+		if env == ENV_ANY || env == providerEnv {
+			return true
+		}
+	}
+	return false
+}
+
+// CrossEnvironments 返回发现实例时除消费者环境外还要查找的环境，
+// 未开启allowCrossEnvironment且请求未指定crossEnvironment时返回空
+func (s *DiscoveryScope) CrossEnvironments(consumerEnv string, override bool) []string {
+	if s == nil || (!s.AllowCrossEnvironment && !override) {
+		return nil
+	}
+	var envs []string
+	for _, env := range environments {
+		if env != normalizeEnvironment(consumerEnv) && s.Visible(consumerEnv, env) {
+			envs = append(envs, env)
+		}
+	}
+	return envs
+}
+
+type discoveryScopeListCache struct {
+	lock     sync.RWMutex
+	scopes   map[string]*DiscoveryScope
+	loadTime time.Time
+}
+
+func (c *discoveryScopeListCache) Get(domainProject string) (*DiscoveryScope, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if c.scopes == nil || time.Since(c.loadTime) > DISCOVERY_SCOPE_CACHE_TTL {
+		return nil, false
+	}
+	return c.scopes[domainProject], true
+}
+
+func (c *discoveryScopeListCache) Set(scopes []*DiscoveryScope) {
+	m := make(map[string]*DiscoveryScope, len(scopes))
+	for _, s := range scopes {
+		m[s.DomainProject()] = s
+	}
+	c.lock.Lock()
+	c.scopes, c.loadTime = m, time.Now()
+	c.lock.Unlock()
+}
+
+func (c *discoveryScopeListCache) Invalidate() {
+	c.lock.Lock()
+	c.scopes = nil
+	c.lock.Unlock()
+}
+
+// GetDiscoveryScopes 查询所有发现范围
+func GetDiscoveryScopes(ctx context.Context) ([]*DiscoveryScope, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GetDiscoveryScopeRootKey()+"/"),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	scopes := make([]*DiscoveryScope, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		s := &DiscoveryScope{}
+		if err := json.Unmarshal(kv.Value, s); err != nil {
+			util.Logger().Errorf(err, "invalid discovery scope %s", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		scopes = append(scopes, s)
+	}
+	return scopes, nil
+}
+
+// FindDiscoveryScope 发现时查询消费者所在租户的发现范围，未配置时返回nil
+func FindDiscoveryScope(ctx context.Context, domainProject string) (*DiscoveryScope, error) {
+	if s, ok := discoveryScopeCache.Get(domainProject); ok {
+		return s, nil
+	}
+	scopes, err := GetDiscoveryScopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	discoveryScopeCache.Set(scopes)
+	s, _ := discoveryScopeCache.Get(domainProject)
+	return s, nil
+}
+
+// PutDiscoveryScope 新增或覆盖发现范围
+func PutDiscoveryScope(ctx context.Context, s *DiscoveryScope) error {
+	s.Timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(apt.GenerateDiscoveryScopeKey(s.DomainProject())),
+		registry.WithValue(data))
+	if err != nil {
+		return err
+	}
+	discoveryScopeCache.Invalidate()
+	return nil
+}
+
+// DeleteDiscoveryScope 删除发现范围，返回发现范围是否存在
+func DeleteDiscoveryScope(ctx context.Context, domainProject string) (bool, error) {
+	key := apt.GenerateDiscoveryScopeKey(domainProject)
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(key),
+		registry.WithCountOnly())
+	if err != nil || resp.Count == 0 {
+		return false, err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.DEL,
+		registry.WithStrKey(key))
+	if err != nil {
+		return false, err
+	}
+	discoveryScopeCache.Invalidate()
+	return true, nil
+}
+
+// FindProviderIds 按版本规则查找消费者环境和发现范围内其他环境的提供者，消费者环境的排在前面
+func FindProviderIds(ctx context.Context, versionRule string, key *pb.MicroServiceKey,
+	scope *DiscoveryScope, override bool) ([]string, error) {
+	envs := append([]string{key.Environment}, scope.CrossEnvironments(key.Environment, override)...)
+	ids := make([]string, 0)
+	for _, env := range envs {
+		k := *key
+		k.Environment = env
+		found, err := FindServiceIds(ctx, versionRule, &k)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, found...)
+	}
+	return ids, nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"reflect"
+	"testing"
+)
+
+func TestDiscoveryScopeCheck(t *testing.T) {
+	s := &serviceUtil.DiscoveryScope{Domain: "default"}
+	if s.Check() == nil {
+		fmt.Printf(`Check without project failed`)
+		t.FailNow()
+	}
+
+	s.Project = "default"
+	s.Visibility = map[string][]string{pb.ENV_DEV: {pb.ENV_TEST, "*"}}
+	if s.Check() != nil || s.DomainProject() != "default/default" {
+		fmt.Printf(`Check discovery scope failed`)
+		t.FailNow()
+	}
+
+	s.Visibility = map[string][]string{"dev": {pb.ENV_TEST}}
+	if s.Check() == nil {
+		fmt.Printf(`Check invalid consumer environment failed`)
+		t.FailNow()
+	}
+
+	s.Visibility = map[string][]string{pb.ENV_DEV: {"test"}}
+	if s.Check() == nil {
+		fmt.Printf(`Check invalid provider environment failed`)
+		t.FailNow()
+	}
+}
+
+func TestDiscoveryScopeVisible(t *testing.T) {
+	var s *serviceUtil.DiscoveryScope
+	if !s.Visible("", pb.ENV_DEV) || s.Visible(pb.ENV_DEV, pb.ENV_TEST) {
+		fmt.Printf(`Visible without scope failed`)
+		t.FailNow()
+	}
+	if len(s.CrossEnvironments(pb.ENV_DEV, true)) != 0 {
+		fmt.Printf(`CrossEnvironments without scope failed`)
+		t.FailNow()
+	}
+
+	s = &serviceUtil.DiscoveryScope{
+		Visibility: map[string][]string{
+			pb.ENV_DEV:  {pb.ENV_TEST},
+			pb.ENV_TEST: {"*"},
+		},
+	}
+	if !s.Visible("", pb.ENV_TEST) || s.Visible(pb.ENV_DEV, pb.ENV_PROD) || s.Visible(pb.ENV_PROD, pb.ENV_DEV) {
+		fmt.Printf(`Visible of development failed`)
+		t.FailNow()
+	}
+	if !s.Visible(pb.ENV_TEST, pb.ENV_PROD) {
+		fmt.Printf(`Visible of any environment failed`)
+		t.FailNow()
+	}
+
+	if len(s.CrossEnvironments(pb.ENV_DEV, false)) != 0 {
+		fmt.Printf(`CrossEnvironments without override failed`)
+		t.FailNow()
+	}
+	envs := s.CrossEnvironments(pb.ENV_TEST, true)
+	if !reflect.DeepEqual(envs, []string{pb.ENV_DEV, pb.ENV_ACCEPT, pb.ENV_PROD}) {
+		fmt.Printf(`CrossEnvironments with override failed, %v`, envs)
+		t.FailNow()
+	}
+	s.AllowCrossEnvironment = true
+	envs = s.CrossEnvironments("", false)
+	if !reflect.DeepEqual(envs, []string{pb.ENV_TEST}) {
+		fmt.Printf(`CrossEnvironments allowed by default failed, %v`, envs)
+		t.FailNow()
+	}
+
+	consumer := &pb.MicroService{AppId: "a", Environment: pb.ENV_DEV}
+	provider := &pb.MicroService{AppId: "a", Environment: pb.ENV_TEST}
+	if serviceUtil.AllowAcrossDimension(provider, consumer) == nil ||
+		serviceUtil.AllowAcrossDimensionInScope(provider, consumer, s) != nil {
+		fmt.Printf(`AllowAcrossDimensionInScope failed`)
+		t.FailNow()
+	}
+}
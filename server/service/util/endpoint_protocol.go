@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"net/url"
+	"strings"
+)
+
+// ParseEndpoint 解析endpoint的协议、地址和参数，没有协议前缀时protocol为空
+func ParseEndpoint(endpoint string) *pb.InstanceEndpoint {
+	info := &pb.InstanceEndpoint{}
+	if i := strings.Index(endpoint, "://"); i >= 0 {
+		info.Protocol = strings.ToLower(endpoint[:i])
+		endpoint = endpoint[i+3:]
+	}
+	if i := strings.Index(endpoint, "?"); i >= 0 {
+		values, _ := url.ParseQuery(endpoint[i+1:])
+		if len(values) > 0 {
+			info.Params = make(map[string]string, len(values))
+			for k, v := range values {
+				info.Params[k] = v[0]
+			}
+		}
+		endpoint = endpoint[:i]
+	}
+	info.Address = strings.TrimSuffix(endpoint, "/")
+	return info
+}
+
+func ParseEndpoints(endpoints []string) []*pb.InstanceEndpoint {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	infos := make([]*pb.InstanceEndpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		infos = append(infos, ParseEndpoint(ep))
+	}
+	return infos
+}
+
+// FormatEndpoint 生成endpoint字符串，参数按key排序
+func FormatEndpoint(info *pb.InstanceEndpoint) string {
+	endpoint := info.Address
+	if len(info.Protocol) > 0 {
+		endpoint = strings.ToLower(info.Protocol) + "://" + endpoint
+	}
+	if len(info.Params) > 0 {
+		values := make(url.Values, len(info.Params))
+		for k, v := range info.Params {
+			values.Set(k, v)
+		}
+		endpoint += "?" + values.Encode()
+	}
+	return endpoint
+}
+
+func sameEndpoint(a, b *pb.InstanceEndpoint) bool {
+	if a.Protocol != strings.ToLower(b.Protocol) || a.Address != b.Address || len(a.Params) != len(b.Params) {
+		return false
+	}
+	for k, v := range a.Params {
+		if bv, ok := b.Params[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeEndpoints 只传入endpointInfos时据此生成endpoints，两者都传入时必须一一对应，
+// 最后由endpoints重新生成endpointInfos，保证存储的两者一致
+func NormalizeEndpoints(instance *pb.MicroServiceInstance) error {
+	if len(instance.EndpointInfos) > 0 {
+		if len(instance.Endpoints) == 0 {
+			instance.Endpoints = make([]string, 0, len(instance.EndpointInfos))
+			for _, info := range instance.EndpointInfos {
+				if info == nil {
+					return fmt.Errorf("endpointInfos contains an empty endpoint")
+				}
+				instance.Endpoints = append(instance.Endpoints, FormatEndpoint(info))
+			}
+		} else {
+			if len(instance.Endpoints) != len(instance.EndpointInfos) {
+				return fmt.Errorf("endpointInfos do not match endpoints")
+			}
+			for i, ep := range instance.Endpoints {
+				info := instance.EndpointInfos[i]
+				if info == nil || !sameEndpoint(ParseEndpoint(ep), info) {
+					return fmt.Errorf("endpointInfos[%d] does not match endpoint '%s'", i, ep)
+				}
+			}
+		}
+	}
+	instance.EndpointInfos = ParseEndpoints(instance.Endpoints)
+	return nil
+}
+
+// keepEndpoints 返回只保留指定endpoint的副本，不修改缓存中的实例
+func keepEndpoints(instance *pb.MicroServiceInstance, endpoints []string) *pb.MicroServiceInstance {
+	copied := *instance
+	copied.Endpoints = endpoints
+	if len(instance.EndpointInfos) > 0 {
+		copied.EndpointInfos = ParseEndpoints(endpoints)
+	}
+	return &copied
+}
+
+// ApplyProtocols 只保留指定协议的endpoint，没有这些协议endpoint的实例被过滤，
+// 没有协议前缀的endpoint无法确认协议，同样被过滤；protocols为空时不过滤
+func ApplyProtocols(protocols []string, instances []*pb.MicroServiceInstance) []*pb.MicroServiceInstance {
+	if len(protocols) == 0 || len(instances) == 0 {
+		return instances
+	}
+	required := make(map[string]struct{}, len(protocols))
+	for _, p := range protocols {
+		required[strings.ToLower(p)] = struct{}{}
+	}
+	matched := make([]*pb.MicroServiceInstance, 0, len(instances))
+	for _, instance := range instances {
+		endpoints := make([]string, 0, len(instance.Endpoints))
+		for _, ep := range instance.Endpoints {
+			if _, ok := required[ParseEndpoint(ep).Protocol]; ok {
+				endpoints = append(endpoints, ep)
+			}
+		}
+		if len(endpoints) == 0 {
+			continue
+		}
+		if len(endpoints) < len(instance.Endpoints) {
+			instance = keepEndpoints(instance, endpoints)
+		}
+		matched = append(matched, instance)
+	}
+	return matched
+}
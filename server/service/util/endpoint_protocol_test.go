@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	info := serviceUtil.ParseEndpoint("REST://[2001:db8::1]:8080/?sslEnabled=true&urlPrefix=%2Fapi")
+	if info.Protocol != "rest" || info.Address != "[2001:db8::1]:8080" ||
+		len(info.Params) != 2 || info.Params["sslEnabled"] != "true" || info.Params["urlPrefix"] != "/api" {
+		fmt.Printf(`ParseEndpoint failed, got %v`, info)
+		t.FailNow()
+	}
+	info = serviceUtil.ParseEndpoint("127.0.0.1:8080")
+	if len(info.Protocol) != 0 || info.Address != "127.0.0.1:8080" || info.Params != nil {
+		fmt.Printf(`ParseEndpoint without protocol failed, got %v`, info)
+		t.FailNow()
+	}
+	ep := serviceUtil.FormatEndpoint(&pb.InstanceEndpoint{
+		Protocol: "highway",
+		Address:  "127.0.0.1:7070",
+		Params:   map[string]string{"b": "2", "a": "1"},
+	})
+	if ep != "highway://127.0.0.1:7070?a=1&b=2" {
+		fmt.Printf(`FormatEndpoint failed, got %s`, ep)
+		t.FailNow()
+	}
+}
+
+func TestNormalizeEndpoints(t *testing.T) {
+	instance := &pb.MicroServiceInstance{
+		EndpointInfos: []*pb.InstanceEndpoint{
+			{Protocol: "rest", Address: "127.0.0.1:8080"},
+			{Protocol: "highway", Address: "127.0.0.1:7070", Params: map[string]string{"login": "true"}},
+		},
+	}
+	if err := serviceUtil.NormalizeEndpoints(instance); err != nil || len(instance.Endpoints) != 2 ||
+		instance.Endpoints[0] != "rest://127.0.0.1:8080" || instance.Endpoints[1] != "highway://127.0.0.1:7070?login=true" {
+		fmt.Printf(`NormalizeEndpoints from endpointInfos failed, %v %v`, err, instance.Endpoints)
+		t.FailNow()
+	}
+
+	instance = &pb.MicroServiceInstance{Endpoints: []string{"rest://127.0.0.1:8080", "127.0.0.1:9090"}}
+	if err := serviceUtil.NormalizeEndpoints(instance); err != nil || len(instance.EndpointInfos) != 2 ||
+		instance.EndpointInfos[0].Protocol != "rest" || len(instance.EndpointInfos[1].Protocol) != 0 {
+		fmt.Printf(`NormalizeEndpoints from endpoints failed, %v %v`, err, instance.EndpointInfos)
+		t.FailNow()
+	}
+
+	instance = &pb.MicroServiceInstance{
+		Endpoints:     []string{"rest://127.0.0.1:8080"},
+		EndpointInfos: []*pb.InstanceEndpoint{{Protocol: "highway", Address: "127.0.0.1:8080"}},
+	}
+	if err := serviceUtil.NormalizeEndpoints(instance); err == nil {
+		fmt.Printf(`NormalizeEndpoints with mismatched endpointInfos failed`)
+		t.FailNow()
+	}
+}
+
+func TestApplyProtocols(t *testing.T) {
+	instances := []*pb.MicroServiceInstance{
+		{InstanceId: "1", Endpoints: []string{"rest://127.0.0.1:8080"}},
+		{InstanceId: "2", Endpoints: []string{"rest://127.0.0.2:8080", "highway://127.0.0.2:7070"},
+			EndpointInfos: serviceUtil.ParseEndpoints([]string{"rest://127.0.0.2:8080", "highway://127.0.0.2:7070"})},
+		{InstanceId: "3", Endpoints: []string{"highway://127.0.0.3:7070"}},
+		{InstanceId: "4", Endpoints: []string{"127.0.0.4:8080"}},
+		{InstanceId: "5"},
+	}
+
+	result := serviceUtil.ApplyProtocols(nil, instances)
+	if len(result) != 5 {
+		fmt.Printf(`ApplyProtocols with empty protocols failed`)
+		t.FailNow()
+	}
+
+	result = serviceUtil.ApplyProtocols([]string{"rest"}, instances)
+	if len(result) != 2 || result[0].InstanceId != "1" || result[1].InstanceId != "2" ||
+		len(result[1].Endpoints) != 1 || len(result[1].EndpointInfos) != 1 ||
+		result[1].EndpointInfos[0].Protocol != "rest" {
+		fmt.Printf(`ApplyProtocols with rest failed`)
+		t.FailNow()
+	}
+	// 原实例不被修改
+	if len(instances[1].Endpoints) != 2 || len(instances[1].EndpointInfos) != 2 {
+		fmt.Printf(`ApplyProtocols modified the origin instance`)
+		t.FailNow()
+	}
+
+	result = serviceUtil.ApplyProtocols([]string{"rest", "highway"}, instances)
+	if len(result) != 3 || len(result[1].Endpoints) != 2 {
+		fmt.Printf(`ApplyProtocols with rest and highway failed`)
+		t.FailNow()
+	}
+}
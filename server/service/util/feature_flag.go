@@ -0,0 +1,277 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 缓存特性开关的时间，其他节点上的变更最多延迟该时间生效
+const FEATURE_FLAG_CACHE_TTL = 30 * time.Second
+
+const (
+	// 发现时不再隐式创建消费者到提供者的依赖关系
+	FEATURE_NO_IMPLICIT_DEPENDENCY = "no-implicit-dependency"
+	// latest规则跳过已下线且配置了excludeFromLatest的版本
+	FEATURE_LATEST_EXCLUDES_RETIRED = "latest-excludes-retired"
+)
+
+// FeatureDefinition 由代码声明的特性及其默认状态，未声明的特性不能配置
+type FeatureDefinition struct {
+	Name        string
+	Description string
+	Default     bool
+}
+
+var featureDefinitions = []*FeatureDefinition{
+	{
+		Name:        FEATURE_NO_IMPLICIT_DEPENDENCY,
+		Description: "Do not create dependencies implicitly when consumers find instances.",
+		Default:     false,
+	},
+	{
+		Name:        FEATURE_LATEST_EXCLUDES_RETIRED,
+		Description: "Version rule 'latest' skips the retired versions marked excludeFromLatest.",
+		Default:     true,
+	},
+}
+
+var featureFlagCache = &featureFlagListCache{}
+
+var featureEvaluations = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "service_center",
+		Subsystem: "feature",
+		Name:      "evaluations_total",
+		Help:      "Counter of the feature flag evaluations by state",
+	}, []string{"flag", "state"})
+
+func init() {
+	prometheus.MustRegister(featureEvaluations)
+}
+
+func getFeatureDefinition(name string) *FeatureDefinition {
+	for _, def := range featureDefinitions {
+		if def.Name == name {
+			return def
+		}
+	}
+	return nil
+}
+
+// FeatureFlag 管理员配置的特性开关，Tenants按domain/project或domain覆盖Enabled，
+// domain/project优先，未配置的特性使用声明的默认状态
+type FeatureFlag struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Default     bool            `json:"default"`
+	Enabled     bool            `json:"enabled"`
+	Tenants     map[string]bool `json:"tenants,omitempty"`
+	Operator    string          `json:"operator,omitempty"`
+	Timestamp   string          `json:"timestamp,omitempty"`
+}
+
+func (f *FeatureFlag) Check() error {
+	if getFeatureDefinition(f.Name) == nil {
+		return fmt.Errorf("unknown feature '%s'", f.Name)
+	}
+	for tenant := range f.Tenants {
+		parts := strings.Split(tenant, "/")
+		if len(parts) > 2 || len(parts[0]) == 0 || (len(parts) == 2 && len(parts[1]) == 0) {
+			return fmt.Errorf("invalid tenant '%s', should be domain or domain/project", tenant)
+		}
+	}
+	return nil
+}
+
+// IsEnabled 返回租户的特性状态
+func (f *FeatureFlag) IsEnabled(domainProject string) bool {
+	if enabled, ok := f.Tenants[domainProject]; ok {
+		return enabled
+	}
+	if i := strings.Index(domainProject, "/"); i > 0 {
+		if enabled, ok := f.Tenants[domainProject[:i]]; ok {
+			return enabled
+		}
+	}
+	return f.Enabled
+}
+
+func newDefaultFeatureFlag(def *FeatureDefinition) *FeatureFlag {
+	return &FeatureFlag{
+		Name:        def.Name,
+		Description: def.Description,
+		Default:     def.Default,
+		Enabled:     def.Default,
+	}
+}
+
+type featureFlagListCache struct {
+	lock     sync.RWMutex
+	flags    map[string]*FeatureFlag
+	loadTime time.Time
+}
+
+func (c *featureFlagListCache) Get(name string) (*FeatureFlag, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if c.flags == nil || time.Since(c.loadTime) > FEATURE_FLAG_CACHE_TTL {
+		return nil, false
+	}
+	return c.flags[name], true
+}
+
+func (c *featureFlagListCache) Set(flags []*FeatureFlag) {
+	m := make(map[string]*FeatureFlag, len(flags))
+	for _, f := range flags {
+		m[f.Name] = f
+	}
+	c.lock.Lock()
+	c.flags, c.loadTime = m, time.Now()
+	c.lock.Unlock()
+}
+
+func (c *featureFlagListCache) Invalidate() {
+	c.lock.Lock()
+	c.flags = nil
+	c.lock.Unlock()
+}
+
+// GetFeatureFlags 查询所有声明的特性，未配置的特性返回默认状态
+func GetFeatureFlags(ctx context.Context) ([]*FeatureFlag, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GetFeatureFlagRootKey()+"/"),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[string]*FeatureFlag, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		f := &FeatureFlag{}
+		if err := json.Unmarshal(kv.Value, f); err != nil {
+			util.Logger().Errorf(err, "invalid feature flag %s", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		stored[f.Name] = f
+	}
+	flags := make([]*FeatureFlag, 0, len(featureDefinitions))
+	for _, def := range featureDefinitions {
+		f, ok := stored[def.Name]
+		if !ok {
+			flags = append(flags, newDefaultFeatureFlag(def))
+			continue
+		}
+		// 描述和默认值以当前版本的声明为准
+		f.Description, f.Default = def.Description, def.Default
+		flags = append(flags, f)
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Name < flags[j].Name
+	})
+	return flags, nil
+}
+
+// FeatureEnabled 租户是否启用特性，查询失败时使用声明的默认状态
+func FeatureEnabled(ctx context.Context, name, domainProject string) (enabled bool) {
+	defer func() {
+		state := "off"
+		if enabled {
+			state = "on"
+		}
+		featureEvaluations.WithLabelValues(name, state).Inc()
+	}()
+
+	f, ok := featureFlagCache.Get(name)
+	if !ok {
+		flags, err := GetFeatureFlags(ctx)
+		if err != nil {
+			util.Logger().Errorf(err, "get feature flags failed, %s uses the default state", name)
+			if def := getFeatureDefinition(name); def != nil {
+				return def.Default
+			}
+			return false
+		}
+		featureFlagCache.Set(flags)
+		f, _ = featureFlagCache.Get(name)
+	}
+	if f == nil {
+		return false
+	}
+	return f.IsEnabled(domainProject)
+}
+
+// TenantFeatures 返回租户所有特性的状态，查询失败时使用声明的默认状态
+func TenantFeatures(ctx context.Context, domainProject string) map[string]bool {
+	features := make(map[string]bool, len(featureDefinitions))
+	for _, def := range featureDefinitions {
+		features[def.Name] = FeatureEnabled(ctx, def.Name, domainProject)
+	}
+	return features
+}
+
+// PutFeatureFlag 覆盖特性开关的配置
+func PutFeatureFlag(ctx context.Context, f *FeatureFlag) error {
+	def := getFeatureDefinition(f.Name)
+	f.Description, f.Default = def.Description, def.Default
+	f.Timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(apt.GenerateFeatureFlagKey(f.Name)),
+		registry.WithValue(data))
+	if err != nil {
+		return err
+	}
+	featureFlagCache.Invalidate()
+	return nil
+}
+
+// DeleteFeatureFlag 删除特性开关的配置，恢复默认状态，返回配置是否存在
+func DeleteFeatureFlag(ctx context.Context, name string) (bool, error) {
+	key := apt.GenerateFeatureFlagKey(name)
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(key),
+		registry.WithCountOnly())
+	if err != nil || resp.Count == 0 {
+		return false, err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.DEL,
+		registry.WithStrKey(key))
+	if err != nil {
+		return false, err
+	}
+	featureFlagCache.Invalidate()
+	return true, nil
+}
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func TestFeatureFlagCheck(t *testing.T) {
+	f := &serviceUtil.FeatureFlag{Name: "unknown"}
+	if f.Check() == nil {
+		fmt.Printf(`Check unknown feature failed`)
+		t.FailNow()
+	}
+
+	f.Name = serviceUtil.FEATURE_NO_IMPLICIT_DEPENDENCY
+	f.Tenants = map[string]bool{"default": true, "default/default": false}
+	if f.Check() != nil {
+		fmt.Printf(`Check feature flag failed`)
+		t.FailNow()
+	}
+
+	for _, tenant := range []string{"", "/default", "default/", "a/b/c"} {
+		f.Tenants = map[string]bool{tenant: true}
+		if f.Check() == nil {
+			fmt.Printf(`Check invalid tenant '%s' failed`, tenant)
+			t.FailNow()
+		}
+	}
+}
+
+func TestFeatureFlagIsEnabled(t *testing.T) {
+	f := &serviceUtil.FeatureFlag{
+		Name:    serviceUtil.FEATURE_NO_IMPLICIT_DEPENDENCY,
+		Enabled: false,
+		Tenants: map[string]bool{"canary": true, "canary/stable": false},
+	}
+	if f.IsEnabled("default/default") {
+		fmt.Printf(`IsEnabled global state failed`)
+		t.FailNow()
+	}
+	if !f.IsEnabled("canary/default") {
+		fmt.Printf(`IsEnabled domain override failed`)
+		t.FailNow()
+	}
+	if f.IsEnabled("canary/stable") {
+		fmt.Printf(`IsEnabled project override failed`)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,38 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"golang.org/x/net/context"
+)
+
+// GetGovernanceConfigs 查询提供者生效的治理配置，配置中心不可用时不影响服务发现
+func GetGovernanceConfigs(ctx context.Context, domainProject string, provider *pb.MicroService) []*pb.GovernanceConfig {
+	if provider == nil {
+		return nil
+	}
+	configs, err := plugin.Plugins().Governance().GetConfigs(ctx, domainProject, provider)
+	if err != nil {
+		util.Logger().Warnf(err, "get governance configs of provider %s/%s/%s failed.",
+			provider.Environment, provider.AppId, provider.ServiceName)
+		return nil
+	}
+	return configs
+}
@@ -0,0 +1,199 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"sync"
+)
+
+type GuardLevel int
+
+const (
+	GUARD_NORMAL GuardLevel = iota
+	// 告警
+	GUARD_WARNING
+	// 拒绝低优先级的写入
+	GUARD_RESTRICTED
+	// 只允许关键的写入
+	GUARD_CRITICAL
+)
+
+func (l GuardLevel) String() string {
+	switch l {
+	case GUARD_NORMAL:
+		return "NORMAL"
+	case GUARD_WARNING:
+		return "WARNING"
+	case GUARD_RESTRICTED:
+		return "RESTRICTED"
+	case GUARD_CRITICAL:
+		return "CRITICAL"
+	default:
+		return fmt.Sprintf("GUARD_LEVEL%d", int(l))
+	}
+}
+
+type WritePriority int
+
+const (
+	// tags、properties等
+	WRITE_PRIORITY_LOW WritePriority = iota
+	// 注册服务、实例、契约等
+	WRITE_PRIORITY_NORMAL
+	// 心跳、注销等，总是允许
+	WRITE_PRIORITY_CRITICAL
+)
+
+const (
+	DEFAULT_GUARD_WARN_PERCENT     = 0.8
+	DEFAULT_GUARD_RESTRICT_PERCENT = 0.9
+)
+
+type GuardrailUsage struct {
+	Keys   int64
+	DbSize int64
+	Level  GuardLevel
+}
+
+// Guardrail 注册中心对象总数和存储大小的保护，使用量超过WarnPercent告警，
+// 超过RestrictPercent拒绝低优先级的写入，达到上限后只允许关键的写入。上限为0表示不限制
+type Guardrail struct {
+	MaxKeys         int64
+	MaxDbSize       int64
+	WarnPercent     float64
+	RestrictPercent float64
+
+	lock  sync.RWMutex
+	usage GuardrailUsage
+}
+
+var guardrail *Guardrail
+
+func init() {
+	guardrail = &Guardrail{
+		MaxKeys:         beego.AppConfig.DefaultInt64("registry_max_keys", 0),
+		MaxDbSize:       beego.AppConfig.DefaultInt64("registry_max_db_size_mb", 0) * 1024 * 1024,
+		WarnPercent:     beego.AppConfig.DefaultFloat("registry_guard_warn_percent", DEFAULT_GUARD_WARN_PERCENT),
+		RestrictPercent: beego.AppConfig.DefaultFloat("registry_guard_restrict_percent", DEFAULT_GUARD_RESTRICT_PERCENT),
+	}
+}
+
+func GetGuardrail() *Guardrail {
+	return guardrail
+}
+
+func (g *Guardrail) Enabled() bool {
+	return g.MaxKeys > 0 || g.MaxDbSize > 0
+}
+
+func (g *Guardrail) Usage() GuardrailUsage {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.usage
+}
+
+func (g *Guardrail) Level() GuardLevel {
+	return g.Usage().Level
+}
+
+// Update 按使用量刷新保护级别
+func (g *Guardrail) Update(keys, dbSize int64) GuardrailUsage {
+	ratio := 0.0
+	if g.MaxKeys > 0 {
+		ratio = float64(keys) / float64(g.MaxKeys)
+	}
+	if g.MaxDbSize > 0 {
+		if r := float64(dbSize) / float64(g.MaxDbSize); r > ratio {
+			ratio = r
+		}
+	}
+	level := GUARD_NORMAL
+	switch {
+	case !g.Enabled():
+	case ratio >= 1:
+		level = GUARD_CRITICAL
+	case ratio >= g.RestrictPercent:
+		level = GUARD_RESTRICTED
+	case ratio >= g.WarnPercent:
+		level = GUARD_WARNING
+	}
+
+	usage := GuardrailUsage{Keys: keys, DbSize: dbSize, Level: level}
+	g.lock.Lock()
+	old := g.usage.Level
+	g.usage = usage
+	g.lock.Unlock()
+	if old != level {
+		util.Logger().Warnf(nil, "registry guard level changes from %s to %s, keys %d, db size %d",
+			old, level, keys, dbSize)
+	}
+	return usage
+}
+
+// Refresh 统计后端的对象总数和存储大小并刷新保护级别
+func (g *Guardrail) Refresh(ctx context.Context) (GuardrailUsage, error) {
+	if !g.Enabled() {
+		return g.Usage(), nil
+	}
+	var keys, dbSize int64
+	if g.MaxKeys > 0 {
+		resp, err := backend.Registry().Do(ctx, registry.GET,
+			registry.WithStrKey(apt.GetRootKey()),
+			registry.WithPrefix(),
+			registry.WithCountOnly())
+		if err != nil {
+			return g.Usage(), err
+		}
+		keys = resp.Count
+	}
+	if g.MaxDbSize > 0 {
+		reporter, ok := backend.Registry().(registry.StatusReporter)
+		if ok {
+			status, err := reporter.Status(ctx)
+			if err != nil {
+				return g.Usage(), err
+			}
+			dbSize = status.DbSize
+		}
+	}
+	return g.Update(keys, dbSize), nil
+}
+
+// Admit 按当前保护级别检查写入是否允许
+func (g *Guardrail) Admit(ctx context.Context, p WritePriority) *scerr.Error {
+	if p >= WRITE_PRIORITY_CRITICAL || apt.IsSCInstance(ctx) {
+		return nil
+	}
+	level := g.Level()
+	if level == GUARD_CRITICAL || (level == GUARD_RESTRICTED && p == WRITE_PRIORITY_LOW) {
+		return scerr.NewError(scerr.ErrStorageLimited,
+			fmt.Sprintf("registry storage is near the limit(%s), the write is rejected", level))
+	}
+	return nil
+}
+
+func AdmitWrite(ctx context.Context, p WritePriority) *scerr.Error {
+	return guardrail.Admit(ctx, p)
+}
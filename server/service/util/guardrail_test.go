@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"testing"
+)
+
+func TestGuardrail_Admit(t *testing.T) {
+	ctx := context.Background()
+	g := &serviceUtil.Guardrail{
+		MaxKeys:         100,
+		MaxDbSize:       1000,
+		WarnPercent:     serviceUtil.DEFAULT_GUARD_WARN_PERCENT,
+		RestrictPercent: serviceUtil.DEFAULT_GUARD_RESTRICT_PERCENT,
+	}
+	cases := []struct {
+		keys, dbSize int64
+		level        serviceUtil.GuardLevel
+		low, normal  bool
+	}{
+		{10, 100, serviceUtil.GUARD_NORMAL, true, true},
+		{80, 100, serviceUtil.GUARD_WARNING, true, true},
+		{10, 900, serviceUtil.GUARD_RESTRICTED, false, true},
+		{100, 100, serviceUtil.GUARD_CRITICAL, false, false},
+	}
+	for _, c := range cases {
+		usage := g.Update(c.keys, c.dbSize)
+		if usage.Level != c.level || g.Level() != c.level {
+			fmt.Printf(`Guardrail update %d/%d failed, level %s`, c.keys, c.dbSize, usage.Level)
+			t.FailNow()
+		}
+		if (g.Admit(ctx, serviceUtil.WRITE_PRIORITY_LOW) == nil) != c.low ||
+			(g.Admit(ctx, serviceUtil.WRITE_PRIORITY_NORMAL) == nil) != c.normal ||
+			g.Admit(ctx, serviceUtil.WRITE_PRIORITY_CRITICAL) != nil {
+			fmt.Printf(`Guardrail admit at level %s failed`, c.level)
+			t.FailNow()
+		}
+	}
+	if err := g.Admit(ctx, serviceUtil.WRITE_PRIORITY_NORMAL); err.Code != scerr.ErrStorageLimited {
+		fmt.Printf(`Guardrail admit error code %d failed`, err.Code)
+		t.FailNow()
+	}
+
+	g = &serviceUtil.Guardrail{}
+	if g.Enabled() || g.Update(100, 100).Level != serviceUtil.GUARD_NORMAL {
+		fmt.Printf(`Guardrail without limits should be disabled`)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,280 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/scheduler"
+	"github.com/astaxie/beego"
+	"golang.org/x/net/context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_INSTANCE_HISTORY_INTERVAL  = 10 * time.Minute
+	DEFAULT_INSTANCE_HISTORY_RETENTION = 7 * 24 * time.Hour
+	// 节点未采样的周期
+	noInstanceSample int32 = -1
+)
+
+// InstanceHistory 服务健康实例数的环形缓冲区，Samples[Head]为Timestamp周期的样本，
+// 之前的样本依次向前，每个样本间隔Interval秒
+type InstanceHistory struct {
+	Interval  int64   `json:"interval"`
+	Timestamp int64   `json:"timestamp"`
+	Head      int     `json:"head"`
+	Samples   []int32 `json:"samples"`
+}
+
+func NewInstanceHistory(interval time.Duration, size int) *InstanceHistory {
+	h := &InstanceHistory{Interval: int64(interval / time.Second), Samples: make([]int32, size)}
+	for i := range h.Samples {
+		h.Samples[i] = noInstanceSample
+	}
+	return h
+}
+
+// Add 记录period周期的样本，跳过的周期记为未采样，早于最新样本的周期忽略
+func (h *InstanceHistory) Add(period int64, count int32) {
+	n := len(h.Samples)
+	if n == 0 || h.Interval <= 0 {
+		return
+	}
+	if h.Timestamp > 0 && period < h.Timestamp {
+		return
+	}
+	steps := int64(n)
+	if h.Timestamp > 0 {
+		steps = (period - h.Timestamp) / h.Interval
+	}
+	if steps > int64(n) {
+		steps = int64(n)
+	}
+	for i := int64(0); i < steps; i++ {
+		h.Head = (h.Head + 1) % n
+		h.Samples[h.Head] = noInstanceSample
+	}
+	h.Samples[h.Head] = count
+	h.Timestamp = period
+}
+
+// Resize 保留最近的size个样本
+func (h *InstanceHistory) Resize(size int) {
+	n := len(h.Samples)
+	if n == size {
+		return
+	}
+	samples := make([]int32, size)
+	for i := 0; i < size; i++ {
+		// 新缓冲区从最新样本向前填充
+		samples[size-1-i] = noInstanceSample
+		if i < n {
+			samples[size-1-i] = h.Samples[(h.Head-i+n)%n]
+		}
+	}
+	h.Samples, h.Head = samples, size-1
+}
+
+// Series 按时间顺序返回周期开始时间不早于since的样本，未采样的周期不返回
+func (h *InstanceHistory) Series(since int64) []*pb.InstanceCountSample {
+	n := len(h.Samples)
+	series := make([]*pb.InstanceCountSample, 0, n)
+	if h.Timestamp == 0 {
+		return series
+	}
+	for i := n - 1; i >= 0; i-- {
+		ts := h.Timestamp - int64(i)*h.Interval
+		count := h.Samples[(h.Head-i+n)%n]
+		if ts < since || count == noInstanceSample {
+			continue
+		}
+		series = append(series, &pb.InstanceCountSample{
+			Timestamp: strconv.FormatInt(ts, 10),
+			Instances: count,
+		})
+	}
+	return series
+}
+
+// ParseHistoryWindow 解析查询范围，支持m、h和d(天)，为空时返回0
+func ParseHistoryWindow(window string) (time.Duration, error) {
+	if len(window) == 0 {
+		return 0, nil
+	}
+	if strings.HasSuffix(window, "d") {
+		days, err := strconv.ParseInt(window[:len(window)-1], 10, 64)
+		if err != nil || days <= 0 {
+			return 0, fmt.Errorf("invalid window '%s'", window)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid window '%s'", window)
+	}
+	return d, nil
+}
+
+func loadInstanceHistories(ctx context.Context) (map[string]*InstanceHistory, error) {
+	rootKey := apt.GetInstanceHistoryRootKey("")
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(rootKey),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	histories := make(map[string]*InstanceHistory, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		h := &InstanceHistory{}
+		if err := json.Unmarshal(kv.Value, h); err != nil {
+			util.Logger().Errorf(err, "invalid instance history %s", key)
+			continue
+		}
+		// key: {domain}/{project}/{serviceId}
+		histories[key[len(rootKey):]] = h
+	}
+	return histories, nil
+}
+
+// SampleInstanceCounts 记录所有服务在period周期的健康实例数，每个服务保留size个样本，
+// 返回采样的服务数；服务删除后其历史一并删除
+func SampleInstanceCounts(ctx context.Context, period time.Time, interval time.Duration, size int) (int, error) {
+	respSvc, err := store.Store().Service().Search(ctx,
+		registry.WithStrKey(apt.GetServiceRootKey("")),
+		registry.WithPrefix(),
+		registry.WithKeyOnly())
+	if err != nil {
+		return 0, err
+	}
+	respIns, err := store.Store().Instance().Search(ctx,
+		registry.WithStrKey(apt.GetInstanceRootKey("")),
+		registry.WithPrefix())
+	if err != nil {
+		return 0, err
+	}
+	histories, err := loadInstanceHistories(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	counts := make(map[string]int32, len(respSvc.Kvs))
+	for _, kv := range respSvc.Kvs {
+		serviceId, domainProject, _ := pb.GetInfoFromSvcKV(kv)
+		counts[domainProject+"/"+serviceId] = 0
+	}
+	for _, kv := range respIns.Kvs {
+		serviceId, _, domainProject, _ := pb.GetInfoFromInstKV(kv)
+		key := domainProject + "/" + serviceId
+		if _, ok := counts[key]; !ok {
+			continue
+		}
+		instance := &pb.MicroServiceInstance{}
+		if err := store.Unmarshal(kv.Value, instance); err != nil {
+			util.Logger().Errorf(err, "unmarshal instance %s failed.", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		if instance.Status == pb.MSI_UP {
+			counts[key]++
+		}
+	}
+
+	rootKey := apt.GetInstanceHistoryRootKey("")
+	opts := make([]registry.PluginOp, 0, len(counts))
+	for key, count := range counts {
+		h, ok := histories[key]
+		// 采样周期变化后之前的样本无法对齐，重新记录
+		if !ok || h.Interval != int64(interval/time.Second) {
+			h = NewInstanceHistory(interval, size)
+		}
+		h.Resize(size)
+		h.Add(period.Unix(), count)
+		data, err := json.Marshal(h)
+		if err != nil {
+			return 0, err
+		}
+		opts = append(opts, registry.OpPut(registry.WithStrKey(rootKey+key), registry.WithValue(data)))
+	}
+	for key := range histories {
+		if _, ok := counts[key]; !ok {
+			opts = append(opts, registry.OpDel(registry.WithStrKey(rootKey+key)))
+		}
+	}
+	return len(counts), backend.BatchCommit(ctx, opts)
+}
+
+// GetInstanceHistory 查询服务的健康实例数历史，没有采样过时返回nil
+func GetInstanceHistory(ctx context.Context, domainProject, serviceId string) (*InstanceHistory, error) {
+	key := apt.GenerateInstanceHistoryKey(domainProject, serviceId)
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	h := &InstanceHistory{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// RunInstanceHistory 按instance_history_interval周期采样各服务的健康实例数，为0时不开启，
+// 样本保留instance_history_retention天
+func RunInstanceHistory() {
+	interval := time.Duration(beego.AppConfig.DefaultInt64("instance_history_interval",
+		int64(DEFAULT_INSTANCE_HISTORY_INTERVAL/time.Second))) * time.Second
+	if interval <= 0 {
+		return
+	}
+	retention := time.Duration(beego.AppConfig.DefaultInt64("instance_history_retention",
+		int64(DEFAULT_INSTANCE_HISTORY_RETENTION/(24*time.Hour)))) * 24 * time.Hour
+	size := int(retention / interval)
+	if size <= 0 {
+		size = 1
+	}
+	scheduler.Register(&scheduler.Job{
+		Name:      "instance_history",
+		Priority:  scheduler.PRIORITY_LOW,
+		Interval:  interval,
+		Immediate: true,
+		Singleton: true,
+		Func: func(ctx context.Context) error {
+			// 按周期对齐，同一周期多次采样时后一次覆盖
+			period := time.Now().Truncate(interval)
+			n, err := SampleInstanceCounts(ctx, period, interval, size)
+			if err != nil {
+				return err
+			}
+			util.Logger().Debugf("instance counts of %d service(s) are sampled at %d", n, period.Unix())
+			return nil
+		},
+	})
+}
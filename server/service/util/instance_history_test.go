@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+	"time"
+)
+
+func TestInstanceHistory(t *testing.T) {
+	h := serviceUtil.NewInstanceHistory(time.Minute, 3)
+	if s := h.Series(0); len(s) != 0 {
+		fmt.Printf(`Series of empty history failed, %v`, s)
+		t.FailNow()
+	}
+
+	h.Add(60, 1)
+	h.Add(120, 2)
+	h.Add(60, 9)
+	s := h.Series(0)
+	if len(s) != 2 || s[0].Timestamp != "60" || s[0].Instances != 1 || s[1].Instances != 2 {
+		fmt.Printf(`Add failed, %v`, s)
+		t.FailNow()
+	}
+
+	// 跳过的周期没有样本，超出容量的旧样本被覆盖
+	h.Add(240, 4)
+	s = h.Series(0)
+	if len(s) != 2 || s[0].Timestamp != "120" || s[1].Timestamp != "240" || s[1].Instances != 4 {
+		fmt.Printf(`Add with gap failed, %v`, s)
+		t.FailNow()
+	}
+	if s = h.Series(180); len(s) != 1 || s[0].Timestamp != "240" {
+		fmt.Printf(`Series since failed, %v`, s)
+		t.FailNow()
+	}
+
+	h.Add(240, 5)
+	if s = h.Series(0); s[len(s)-1].Instances != 5 {
+		fmt.Printf(`Add in the same period failed, %v`, s)
+		t.FailNow()
+	}
+
+	h.Add(1200, 6)
+	if s = h.Series(0); len(s) != 1 || s[0].Timestamp != "1200" {
+		fmt.Printf(`Add after a long gap failed, %v`, s)
+		t.FailNow()
+	}
+}
+
+func TestInstanceHistoryResize(t *testing.T) {
+	h := serviceUtil.NewInstanceHistory(time.Minute, 3)
+	for i := int64(1); i <= 4; i++ {
+		h.Add(i*60, int32(i))
+	}
+
+	h.Resize(5)
+	s := h.Series(0)
+	if len(s) != 3 || s[0].Timestamp != "120" || s[2].Timestamp != "240" {
+		fmt.Printf(`Resize larger failed, %v`, s)
+		t.FailNow()
+	}
+	h.Add(300, 5)
+	h.Add(360, 6)
+	if s = h.Series(0); len(s) != 5 || s[0].Instances != 2 || s[4].Instances != 6 {
+		fmt.Printf(`Add after resize failed, %v`, s)
+		t.FailNow()
+	}
+
+	h.Resize(2)
+	if s = h.Series(0); len(s) != 2 || s[0].Instances != 5 || s[1].Instances != 6 {
+		fmt.Printf(`Resize smaller failed, %v`, s)
+		t.FailNow()
+	}
+}
+
+func TestParseHistoryWindow(t *testing.T) {
+	for window, expected := range map[string]time.Duration{
+		"":    0,
+		"30m": 30 * time.Minute,
+		"24h": 24 * time.Hour,
+		"7d":  7 * 24 * time.Hour,
+	} {
+		d, err := serviceUtil.ParseHistoryWindow(window)
+		if err != nil || d != expected {
+			fmt.Printf(`ParseHistoryWindow '%s' failed, %v, %v`, window, d, err)
+			t.FailNow()
+		}
+	}
+	for _, window := range []string{"d", "0d", "-1h", "abc"} {
+		if _, err := serviceUtil.ParseHistoryWindow(window); err == nil {
+			fmt.Printf(`ParseHistoryWindow '%s' should fail`, window)
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/astaxie/beego"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_INSTANCE_WEIGHT = 1
+	// 超过该时间没有被返回过的实例从本地记录中清除
+	instanceReturnRecordTTL = 10 * time.Minute
+)
+
+var instanceReturns = &returnRecorder{records: make(map[string]time.Time)}
+
+// returnRecorder 本节点上实例最近一次排在发现结果第一位的时间
+type returnRecorder struct {
+	lock      sync.Mutex
+	records   map[string]time.Time
+	cleanTime time.Time
+}
+
+// Next 按最近一次排在第一位的时间从早到晚排序，从未排在第一位的在最前面，
+// 并记录新的第一个实例
+func (r *returnRecorder) Next(instances []*pb.MicroServiceInstance) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	now := time.Now()
+	if now.Sub(r.cleanTime) > instanceReturnRecordTTL {
+		for k, t := range r.records {
+			if now.Sub(t) > instanceReturnRecordTTL {
+				delete(r.records, k)
+			}
+		}
+		r.cleanTime = now
+	}
+	sort.SliceStable(instances, func(i, j int) bool {
+		return r.records[instanceOrderKey(instances[i])].Before(r.records[instanceOrderKey(instances[j])])
+	})
+	r.records[instanceOrderKey(instances[0])] = now
+}
+
+func instanceOrderKey(instance *pb.MicroServiceInstance) string {
+	return util.StringJoin([]string{instance.ServiceId, instance.InstanceId}, "/")
+}
+
+// InstanceWeight 实例properties中的weight，未设置或无效时为1
+func InstanceWeight(instance *pb.MicroServiceInstance) float64 {
+	v, ok := instance.Properties[pb.PROP_INSTANCE_WEIGHT]
+	if !ok {
+		return DEFAULT_INSTANCE_WEIGHT
+	}
+	w, err := strconv.ParseFloat(v, 64)
+	if err != nil || w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+		return DEFAULT_INSTANCE_WEIGHT
+	}
+	return w
+}
+
+func shuffleInstances(instances []*pb.MicroServiceInstance) {
+	for i := len(instances) - 1; i > 0; i-- {
+		j := rand.Intn(i + 1)
+		instances[i], instances[j] = instances[j], instances[i]
+	}
+}
+
+// weightedShuffle 每个实例取-ln(u)/weight，从小到大排序，实例排在第一位的概率与权重成正比
+func weightedShuffle(instances []*pb.MicroServiceInstance) {
+	keys := make(map[*pb.MicroServiceInstance]float64, len(instances))
+	for _, instance := range instances {
+		w := InstanceWeight(instance)
+		if w == 0 {
+			keys[instance] = math.Inf(1)
+			continue
+		}
+		keys[instance] = -math.Log(1-rand.Float64()) / w
+	}
+	sort.SliceStable(instances, func(i, j int) bool {
+		return keys[instances[i]] < keys[instances[j]]
+	})
+}
+
+// zoneFirst 与消费者在同一可用区的实例排在前面，两部分各自随机排序
+func zoneFirst(zone string, instances []*pb.MicroServiceInstance) []*pb.MicroServiceInstance {
+	shuffleInstances(instances)
+	if len(zone) == 0 {
+		return instances
+	}
+	matched := make([]*pb.MicroServiceInstance, 0, len(instances))
+	others := make([]*pb.MicroServiceInstance, 0, len(instances))
+	for _, instance := range instances {
+		if instance.DataCenterInfo != nil && instance.DataCenterInfo.AvailableZone == zone {
+			matched = append(matched, instance)
+			continue
+		}
+		others = append(others, instance)
+	}
+	return append(matched, others...)
+}
+
+// InstanceOrder 依次使用请求指定的、消费者发现策略中的和discovery_instance_order配置的顺序，
+// 都为空时保持存储中的顺序
+func InstanceOrder(order string, policy *pb.DiscoveryPolicy) string {
+	if len(order) > 0 {
+		return order
+	}
+	if len(policy.GetOrder()) > 0 {
+		return policy.GetOrder()
+	}
+	return beego.AppConfig.DefaultString("discovery_instance_order", "")
+}
+
+// ApplyInstanceOrder 按顺序策略重排实例，在平台和发现策略之前执行，
+// 这些策略的分组和maxInstances截断保持这里的相对顺序
+func ApplyInstanceOrder(order, zone string, instances []*pb.MicroServiceInstance) []*pb.MicroServiceInstance {
+	if len(instances) < 2 {
+		return instances
+	}
+	switch order {
+	case pb.ORDER_RANDOM:
+		shuffleInstances(instances)
+	case pb.ORDER_LEAST_RECENT:
+		// 先随机排序，从未返回过的实例之间也能分散
+		shuffleInstances(instances)
+		instanceReturns.Next(instances)
+	case pb.ORDER_ZONE:
+		instances = zoneFirst(zone, instances)
+	case pb.ORDER_WEIGHT:
+		weightedShuffle(instances)
+	}
+	return instances
+}
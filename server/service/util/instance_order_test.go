@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func orderTestInstances() []*pb.MicroServiceInstance {
+	return []*pb.MicroServiceInstance{
+		{ServiceId: "s", InstanceId: "1", DataCenterInfo: &pb.DataCenterInfo{AvailableZone: "az1"}},
+		{ServiceId: "s", InstanceId: "2", DataCenterInfo: &pb.DataCenterInfo{AvailableZone: "az2"}},
+		{ServiceId: "s", InstanceId: "3", Properties: map[string]string{pb.PROP_INSTANCE_WEIGHT: "0"}},
+		{ServiceId: "s", InstanceId: "4", DataCenterInfo: &pb.DataCenterInfo{AvailableZone: "az2"}},
+	}
+}
+
+func TestInstanceOrder(t *testing.T) {
+	if serviceUtil.InstanceOrder(pb.ORDER_ZONE, &pb.DiscoveryPolicy{Order: pb.ORDER_RANDOM}) != pb.ORDER_ZONE {
+		fmt.Printf(`InstanceOrder with request order failed`)
+		t.FailNow()
+	}
+	if serviceUtil.InstanceOrder("", &pb.DiscoveryPolicy{Order: pb.ORDER_RANDOM}) != pb.ORDER_RANDOM {
+		fmt.Printf(`InstanceOrder with policy order failed`)
+		t.FailNow()
+	}
+}
+
+func TestApplyInstanceOrder(t *testing.T) {
+	result := serviceUtil.ApplyInstanceOrder("", "", orderTestInstances())
+	if result[0].InstanceId != "1" || result[3].InstanceId != "4" {
+		fmt.Printf(`ApplyInstanceOrder with empty order failed`)
+		t.FailNow()
+	}
+
+	result = serviceUtil.ApplyInstanceOrder(pb.ORDER_RANDOM, "", orderTestInstances())
+	if len(result) != 4 {
+		fmt.Printf(`ApplyInstanceOrder with random failed`)
+		t.FailNow()
+	}
+
+	result = serviceUtil.ApplyInstanceOrder(pb.ORDER_ZONE, "az2", orderTestInstances())
+	if len(result) != 4 || result[0].DataCenterInfo.GetAvailableZone() != "az2" ||
+		result[1].DataCenterInfo.GetAvailableZone() != "az2" {
+		fmt.Printf(`ApplyInstanceOrder with zone failed`)
+		t.FailNow()
+	}
+
+	for i := 0; i < 10; i++ {
+		result = serviceUtil.ApplyInstanceOrder(pb.ORDER_WEIGHT, "", orderTestInstances())
+		if result[3].InstanceId != "3" {
+			fmt.Printf(`ApplyInstanceOrder with weight failed`)
+			t.FailNow()
+		}
+	}
+
+	// 连续4次的第一个实例互不相同
+	first := make(map[string]struct{})
+	for i := 0; i < 4; i++ {
+		result = serviceUtil.ApplyInstanceOrder(pb.ORDER_LEAST_RECENT, "", orderTestInstances())
+		first[result[0].InstanceId] = struct{}{}
+	}
+	if len(first) != 4 {
+		fmt.Printf(`ApplyInstanceOrder with leastRecent failed`)
+		t.FailNow()
+	}
+}
+
+func TestInstanceWeight(t *testing.T) {
+	for v, w := range map[string]float64{"": 1, "x": 1, "-1": 1, "0": 0, "2.5": 2.5} {
+		instance := &pb.MicroServiceInstance{}
+		if len(v) > 0 {
+			instance.Properties = map[string]string{pb.PROP_INSTANCE_WEIGHT: v}
+		}
+		if serviceUtil.InstanceWeight(instance) != w {
+			fmt.Printf(`InstanceWeight %s failed`, v)
+			t.FailNow()
+		}
+	}
+}
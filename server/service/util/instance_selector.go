@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"errors"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// InstanceSelector 批量操作实例时的选择条件，各条件同时满足才匹配
+type InstanceSelector struct {
+	Status     string
+	Properties map[string]string
+	// 任一endpoint的IP在该网段内
+	IPNet *net.IPNet
+	// 注册时间早于该时间(unix秒)，为0时不限制
+	RegisteredBefore int64
+}
+
+// NewInstanceSelector 至少需要一个条件，避免误操作服务的所有实例
+func NewInstanceSelector(in *pb.UnregisterInstancesRequest) (*InstanceSelector, error) {
+	selector := &InstanceSelector{
+		Status:           in.Status,
+		Properties:       in.Properties,
+		RegisteredBefore: in.RegisteredBefore,
+	}
+	if len(in.Cidr) > 0 {
+		_, ipNet, err := net.ParseCIDR(in.Cidr)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid parameter cidr.")
+		}
+		selector.IPNet = ipNet
+	}
+	if len(selector.Status) == 0 && len(selector.Properties) == 0 &&
+		selector.IPNet == nil && selector.RegisteredBefore <= 0 {
+		return nil, errors.New("At least one selector is required.")
+	}
+	return selector, nil
+}
+
+func (s *InstanceSelector) Match(instance *pb.MicroServiceInstance) bool {
+	if len(s.Status) > 0 && instance.Status != s.Status {
+		return false
+	}
+	for k, v := range s.Properties {
+		if pv, ok := instance.Properties[k]; !ok || pv != v {
+			return false
+		}
+	}
+	if s.RegisteredBefore > 0 {
+		ts, err := strconv.ParseInt(instance.Timestamp, 10, 64)
+		if err != nil || ts >= s.RegisteredBefore {
+			return false
+		}
+	}
+	if s.IPNet != nil {
+		for _, ep := range instance.Endpoints {
+			if ip := EndpointIP(ep); ip != nil && s.IPNet.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// EndpointIP 解析rest://127.0.0.1:8080、highway:127.0.0.1:8080等格式的地址
+func EndpointIP(endpoint string) net.IP {
+	if i := strings.Index(endpoint, "?"); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+	if i := strings.Index(endpoint, "://"); i >= 0 {
+		endpoint = endpoint[i+3:]
+	} else if ip := hostIP(endpoint); ip != nil {
+		return ip
+	} else if i := strings.Index(endpoint, ":"); i >= 0 {
+		endpoint = endpoint[i+1:]
+	}
+	return hostIP(strings.TrimSuffix(endpoint, "/"))
+}
+
+func hostIP(hostPort string) net.IP {
+	if ip := util.NormalizeIP(hostPort); len(ip) > 0 {
+		return net.ParseIP(ip)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func TestInstanceSelector(t *testing.T) {
+	_, err := serviceUtil.NewInstanceSelector(&pb.UnregisterInstancesRequest{ServiceId: "a"})
+	if err == nil {
+		fmt.Printf("NewInstanceSelector without selector should fail")
+		t.FailNow()
+	}
+	_, err = serviceUtil.NewInstanceSelector(&pb.UnregisterInstancesRequest{Cidr: "10.0.0.1"})
+	if err == nil {
+		fmt.Printf("NewInstanceSelector with invalid cidr should fail")
+		t.FailNow()
+	}
+
+	selector, err := serviceUtil.NewInstanceSelector(&pb.UnregisterInstancesRequest{
+		Status:           pb.MSI_DOWN,
+		Properties:       map[string]string{"version": "bad"},
+		Cidr:             "10.0.0.0/8",
+		RegisteredBefore: 100,
+	})
+	if err != nil {
+		fmt.Printf("NewInstanceSelector failed, %s", err)
+		t.FailNow()
+	}
+	instance := &pb.MicroServiceInstance{
+		Status:     pb.MSI_DOWN,
+		Properties: map[string]string{"version": "bad", "zone": "a"},
+		Endpoints:  []string{"highway:192.168.0.1:7070", "rest://10.1.2.3:8080?sslEnabled=false"},
+		Timestamp:  "99",
+	}
+	if !selector.Match(instance) {
+		fmt.Printf("Match failed")
+		t.FailNow()
+	}
+	for _, f := range []func(i *pb.MicroServiceInstance){
+		func(i *pb.MicroServiceInstance) { i.Status = pb.MSI_UP },
+		func(i *pb.MicroServiceInstance) { i.Properties = nil },
+		func(i *pb.MicroServiceInstance) { i.Endpoints = i.Endpoints[:1] },
+		func(i *pb.MicroServiceInstance) { i.Timestamp = "100" },
+	} {
+		i := *instance
+		f(&i)
+		if selector.Match(&i) {
+			fmt.Printf("Match %v should fail", i)
+			t.FailNow()
+		}
+	}
+}
+
+func TestEndpointIP(t *testing.T) {
+	for endpoint, ip := range map[string]string{
+		"rest://127.0.0.1:8080":         "127.0.0.1",
+		"highway:10.0.0.1:7070":         "10.0.0.1",
+		"10.0.0.2:80":                   "10.0.0.2",
+		"rest://[::1]:8080?ssl=true":    "::1",
+		"rest://[fe80::1%25eth0]:8080":  "fe80::1",
+		"highway:[2001:db8::1]:7070":    "2001:db8::1",
+		"2001:db8::2":                   "2001:db8::2",
+		"rest://[::ffff:10.0.0.3]:8080": "10.0.0.3",
+	} {
+		if got := serviceUtil.EndpointIP(endpoint); got == nil || got.String() != ip {
+			fmt.Printf("EndpointIP %s failed, %v", endpoint, got)
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,297 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/astaxie/beego"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	MAX_INSTANCE_STATE_ENTRIES      = 8
+	MAX_INSTANCE_STATE_VALUE_LEN    = 64
+	DEFAULT_INSTANCE_STATE_INTERVAL = 10 * time.Second
+	// 超过该时间没有上报的实例从本地记录中清除
+	instanceStateRecordTTL = 10 * time.Minute
+)
+
+var (
+	instanceStateKeyRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_.-]{0,31}$`)
+
+	instanceStateReports = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "instance",
+			Name:      "state_reports_total",
+			Help:      "Counter of the instance states reported by heartbeats, by written, unchanged or throttled",
+		}, []string{"result"})
+
+	instanceStateValues = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "instance",
+			Name:      "state_value",
+			Help:      "Numeric values of the instance states reported by heartbeats",
+		}, []string{"domain", "instance", "key"})
+
+	instanceStateRecorder = &stateRecorder{records: make(map[string]*stateRecord)}
+	instanceStateMetrics  = &stateMetrics{keys: make(map[string][]string)}
+)
+
+func init() {
+	prometheus.MustRegister(instanceStateReports, instanceStateValues)
+}
+
+// CheckInstanceState 状态最多8项，key以字母开头且不超过32个字符，value不超过64个字符
+func CheckInstanceState(values map[string]string) error {
+	if len(values) > MAX_INSTANCE_STATE_ENTRIES {
+		return fmt.Errorf("state has more than %d entries", MAX_INSTANCE_STATE_ENTRIES)
+	}
+	for k, v := range values {
+		if !instanceStateKeyRegex.MatchString(k) {
+			return fmt.Errorf("invalid state key '%s'", k)
+		}
+		if len(v) > MAX_INSTANCE_STATE_VALUE_LEN {
+			return fmt.Errorf("value of state '%s' is longer than %d", k, MAX_INSTANCE_STATE_VALUE_LEN)
+		}
+	}
+	return nil
+}
+
+type stateRecord struct {
+	values    map[string]string
+	leaseID   int64
+	writeTime time.Time
+	seenTime  time.Time
+}
+
+// stateRecorder 本节点最近写入的实例状态，状态未变化时心跳不产生写操作
+type stateRecorder struct {
+	lock      sync.Mutex
+	records   map[string]*stateRecord
+	cleanTime time.Time
+}
+
+func (r *stateRecorder) Get(key string) (stateRecord, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	now := time.Now()
+	if now.Sub(r.cleanTime) > instanceStateRecordTTL {
+		for k, record := range r.records {
+			if now.Sub(record.seenTime) > instanceStateRecordTTL {
+				delete(r.records, k)
+			}
+		}
+		r.cleanTime = now
+	}
+	record, ok := r.records[key]
+	if !ok {
+		return stateRecord{}, false
+	}
+	record.seenTime = now
+	return *record, true
+}
+
+func (r *stateRecorder) Set(key string, record stateRecord) {
+	record.seenTime = time.Now()
+	r.lock.Lock()
+	r.records[key] = &record
+	r.lock.Unlock()
+}
+
+func sameInstanceState(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func instanceStateInterval() time.Duration {
+	v := beego.AppConfig.DefaultString("instance_state_min_interval", DEFAULT_INSTANCE_STATE_INTERVAL.String())
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		util.Logger().Errorf(err, "invalid instance_state_min_interval '%s', use %s", v, DEFAULT_INSTANCE_STATE_INTERVAL)
+		return DEFAULT_INSTANCE_STATE_INTERVAL
+	}
+	return d
+}
+
+// ReportInstanceState 保存随心跳上报的实例状态，与实例使用同一租约；状态和租约未变化时不写入，
+// 变化时两次写入至少间隔instance_state_min_interval，期间的变化由之后的心跳带上，返回是否写入
+func ReportInstanceState(ctx context.Context, domainProject, serviceId, instanceId string,
+	leaseID int64, values map[string]string) (bool, error) {
+	key := apt.GenerateInstanceStateKey(domainProject, serviceId, instanceId)
+	record, ok := instanceStateRecorder.Get(key)
+	if !ok {
+		// 其他节点可能已写入相同的状态
+		resp, err := store.Store().InstanceState().Search(ctx, registry.WithStrKey(key))
+		if err != nil {
+			return false, err
+		}
+		if len(resp.Kvs) > 0 {
+			state := &pb.InstanceState{}
+			if err := json.Unmarshal(resp.Kvs[0].Value, state); err == nil {
+				ts, _ := strconv.ParseInt(state.Timestamp, 10, 64)
+				record = stateRecord{values: state.Values, leaseID: resp.Kvs[0].Lease, writeTime: time.Unix(ts, 0)}
+				instanceStateRecorder.Set(key, record)
+				ok = true
+			}
+		}
+	}
+	if ok && record.leaseID == leaseID && sameInstanceState(record.values, values) {
+		instanceStateReports.WithLabelValues("unchanged").Inc()
+		return false, nil
+	}
+	now := time.Now()
+	if ok && record.leaseID == leaseID && now.Sub(record.writeTime) < instanceStateInterval() {
+		instanceStateReports.WithLabelValues("throttled").Inc()
+		return false, nil
+	}
+
+	data, err := json.Marshal(&pb.InstanceState{
+		Values:    values,
+		Timestamp: strconv.FormatInt(now.Unix(), 10),
+	})
+	if err != nil {
+		return false, err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(key),
+		registry.WithValue(data),
+		registry.WithLease(leaseID))
+	if err != nil {
+		return false, err
+	}
+	instanceStateRecorder.Set(key, stateRecord{values: values, leaseID: leaseID, writeTime: now})
+	instanceStateReports.WithLabelValues("written").Inc()
+	return true, nil
+}
+
+// GetInstanceStates 查询服务所有实例上报的状态，key为instanceId
+func GetInstanceStates(ctx context.Context, domainProject, serviceId string) (map[string]*pb.InstanceState, error) {
+	prefix := apt.GenerateInstanceStateKey(domainProject, serviceId, "")
+	opts := append(FromContext(ctx), registry.WithStrKey(prefix), registry.WithPrefix())
+	resp, err := store.Store().InstanceState().Search(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]*pb.InstanceState, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		state := &pb.InstanceState{}
+		if err := json.Unmarshal(kv.Value, state); err != nil {
+			util.Logger().Errorf(err, "unmarshal instance state %s failed", key)
+			continue
+		}
+		states[key[len(prefix):]] = state
+	}
+	return states, nil
+}
+
+// AttachInstanceStates 发现时附加实例上报的状态，查询失败时不影响发现
+func AttachInstanceStates(ctx context.Context, domainProject, serviceId string, instances []*pb.MicroServiceInstance) {
+	if len(instances) == 0 {
+		return
+	}
+	states, err := GetInstanceStates(ctx, domainProject, serviceId)
+	if err != nil {
+		util.Logger().Errorf(err, "get instance states of service %s failed", serviceId)
+		return
+	}
+	for _, instance := range instances {
+		instance.State = states[instance.InstanceId]
+	}
+}
+
+// stateMetrics 按注册中心事件维护实例状态中数值项的指标，所有节点的指标一致
+type stateMetrics struct {
+	lock sync.Mutex
+	// 实例上次设置过指标的状态项，用于删除不再上报的项
+	keys map[string][]string
+}
+
+func (m *stateMetrics) OnEvent(action pb.EventType, key string, value []byte) {
+	// key: {root}/{domain}/{project}/{serviceId}/{instanceId}
+	root := apt.GetInstanceStateRootKey("")
+	if !strings.HasPrefix(key, root) {
+		return
+	}
+	arr := strings.Split(key[len(root):], "/")
+	if len(arr) != 4 {
+		return
+	}
+	domainProject := arr[0] + "/" + arr[1]
+	instance := arr[2] + "/" + arr[3]
+
+	state := &pb.InstanceState{}
+	if action != pb.EVT_DELETE {
+		if err := json.Unmarshal(value, state); err != nil {
+			util.Logger().Errorf(err, "unmarshal instance state %s failed", key)
+			return
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, k := range m.keys[key] {
+		if _, ok := state.Values[k]; !ok {
+			instanceStateValues.DeleteLabelValues(domainProject, instance, k)
+		}
+	}
+	delete(m.keys, key)
+	if action == pb.EVT_DELETE || !beego.AppConfig.DefaultBool("instance_state_metrics", false) {
+		return
+	}
+	var keys []string
+	for k, v := range state.Values {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			continue
+		}
+		instanceStateValues.WithLabelValues(domainProject, instance, k).Set(f)
+		keys = append(keys, k)
+	}
+	if len(keys) > 0 {
+		m.keys[key] = keys
+	}
+}
+
+// OnInstanceStateEvent 实例状态变化时刷新指标，instance_state_metrics开启时才导出，
+// 指标按实例区分，实例规模较大时注意标签数量
+func OnInstanceStateEvent(evt *store.KvEvent) {
+	instanceStateMetrics.OnEvent(evt.Action, util.BytesToStringWithNoCopy(evt.KV.Key), evt.KV.Value)
+}
@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"strings"
+	"testing"
+)
+
+func TestCheckInstanceState(t *testing.T) {
+	err := serviceUtil.CheckInstanceState(nil)
+	if err != nil {
+		fmt.Printf("CheckInstanceState nil failed")
+		t.FailNow()
+	}
+
+	err = serviceUtil.CheckInstanceState(map[string]string{"load": "0.5", "ready_score": "90"})
+	if err != nil {
+		fmt.Printf("CheckInstanceState valid state failed")
+		t.FailNow()
+	}
+
+	for _, k := range []string{"", "1load", "load/1", strings.Repeat("a", 33)} {
+		err = serviceUtil.CheckInstanceState(map[string]string{k: "1"})
+		if err == nil {
+			fmt.Printf("CheckInstanceState invalid key '%s' failed", k)
+			t.FailNow()
+		}
+	}
+
+	err = serviceUtil.CheckInstanceState(map[string]string{"load": strings.Repeat("1", 65)})
+	if err == nil {
+		fmt.Printf("CheckInstanceState too long value failed")
+		t.FailNow()
+	}
+
+	values := make(map[string]string)
+	for i := 0; i < serviceUtil.MAX_INSTANCE_STATE_ENTRIES+1; i++ {
+		values[fmt.Sprintf("k%d", i)] = "1"
+	}
+	err = serviceUtil.CheckInstanceState(values)
+	if err == nil {
+		fmt.Printf("CheckInstanceState too many entries failed")
+		t.FailNow()
+	}
+}
@@ -17,7 +17,6 @@
 package util
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
 	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
@@ -59,9 +58,10 @@ func GetInstance(ctx context.Context, domainProject string, serviceId string, in
 	if len(resp.Kvs) == 0 {
 		return nil, nil
 	}
+	RecordModRevision(ctx, resp.Kvs)
 
-	var instance *pb.MicroServiceInstance
-	err = json.Unmarshal(resp.Kvs[0].Value, &instance)
+	instance := &pb.MicroServiceInstance{}
+	err = store.Unmarshal(resp.Kvs[0].Value, instance)
 	if err != nil {
 		return nil, err
 	}
@@ -76,12 +76,13 @@ func GetAllInstancesOfOneService(ctx context.Context, domainProject string, serv
 		util.Logger().Errorf(err, "Get instance of service %s from etcd failed.", serviceId)
 		return nil, err
 	}
+	RecordModRevision(ctx, resp.Kvs)
 
 	instances := make([]*pb.MicroServiceInstance, 0, len(resp.Kvs))
 	for _, kvs := range resp.Kvs {
 		util.Logger().Debugf("start unmarshal service instance file: %s", util.BytesToStringWithNoCopy(kvs.Key))
 		instance := &pb.MicroServiceInstance{}
-		err := json.Unmarshal(kvs.Value, instance)
+		err := store.Unmarshal(kvs.Value, instance)
 		if err != nil {
 			util.Logger().Errorf(err, "Unmarshal instance of service %s failed.", serviceId)
 			return nil, err
@@ -91,6 +92,37 @@ func GetAllInstancesOfOneService(ctx context.Context, domainProject string, serv
 	return instances, nil
 }
 
+// IsDiscoverable 待命和预注册的实例不参与服务发现
+func IsDiscoverable(instance *pb.MicroServiceInstance) bool {
+	return instance.Status != pb.MSI_STANDBY && instance.Status != pb.MSI_PENDING
+}
+
+// ExcludeUndiscoverableInstances 剔除不参与服务发现的实例
+func ExcludeUndiscoverableInstances(instances []*pb.MicroServiceInstance) []*pb.MicroServiceInstance {
+	result := instances[:0]
+	for _, instance := range instances {
+		if IsDiscoverable(instance) {
+			result = append(result, instance)
+		}
+	}
+	return result
+}
+
+// GetHealthyInstanceCount 统计状态为UP的实例数
+func GetHealthyInstanceCount(ctx context.Context, domainProject string, serviceId string) (int, error) {
+	instances, err := GetAllInstancesOfOneService(ctx, domainProject, serviceId)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, instance := range instances {
+		if len(instance.Status) == 0 || instance.Status == pb.MSI_UP {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func GetInstanceCountOfOneService(ctx context.Context, domainProject string, serviceId string) (int64, error) {
 	key := apt.GenerateInstanceKey(domainProject, serviceId, "")
 	opts := append(FromContext(ctx),
@@ -121,15 +153,11 @@ func InstanceExist(ctx context.Context, domainProject string, serviceId string,
 
 func CheckEndPoints(ctx context.Context, in *pb.RegisterInstanceRequest) (string, string, error) {
 	domainProject := util.ParseDomainProject(ctx)
-	endpoints := in.Instance.Endpoints
-	sort.Strings(endpoints)
-	endpointsJoin := util.StringJoin(endpoints, "/")
-	region, availableZone := apt.GetRegionAndAvailableZone(in.Instance.DataCenterInfo)
-	nodeIP := ""
-	if value, ok := in.Instance.Properties[NODEIP]; ok {
-		nodeIP = value
-	}
-	instanceEndpointsIndexKey := apt.GenerateEndpointsIndexKey(domainProject, region, availableZone, nodeIP, endpointsJoin)
+	sort.Strings(in.Instance.Endpoints)
+	if len(in.Instance.EndpointInfos) > 0 {
+		in.Instance.EndpointInfos = ParseEndpoints(in.Instance.Endpoints)
+	}
+	instanceEndpointsIndexKey := generateEndpointsIndexKey(domainProject, in.Instance)
 	resp, err := store.Store().Endpoints().Search(ctx,
 		registry.WithStrKey(instanceEndpointsIndexKey))
 	if err != nil {
@@ -237,12 +265,15 @@ func QueryAllProvidersIntances(ctx context.Context, selfServiceId string) (resul
 			util.Logger().Debugf("start unmarshal service instance file with revision %d: %s",
 				rev, util.BytesToStringWithNoCopy(kv.Key))
 			instance := &pb.MicroServiceInstance{}
-			err := json.Unmarshal(kv.Value, instance)
+			err := store.Unmarshal(kv.Value, instance)
 			if err != nil {
 				util.Logger().Errorf(err, "unmarshal instance of service %s with revision %d failed.",
 					providerId, rev)
 				return
 			}
+			if !IsDiscoverable(instance) {
+				continue
+			}
 			results = append(results, &pb.WatchInstanceResponse{
 				Response: pb.CreateResponse(pb.Response_SUCCESS, "List instance successfully."),
 				Action:   string(pb.EVT_CREATE),
@@ -273,3 +304,55 @@ func queryServiceInstancesKvs(ctx context.Context, serviceId string, rev int64)
 	}
 	return resp.Kvs, nil
 }
+
+func removeReservedProperties(properties map[string]string) {
+	for k := range properties {
+		if strings.HasPrefix(k, pb.PROP_RESERVED_PREFIX) {
+			delete(properties, k)
+		}
+	}
+}
+
+// EnrichInstanceProperties 将注册请求的来源IP、客户端证书CN和User-Agent写入实例的保留属性，
+// 客户端提交的保留属性会被忽略
+func EnrichInstanceProperties(ctx context.Context, instance *pb.MicroServiceInstance) {
+	if instance.Properties == nil {
+		instance.Properties = make(map[string]string)
+	}
+	removeReservedProperties(instance.Properties)
+	for k, v := range map[string]string{
+		pb.PROP_REGISTER_IP:  util.GetIPFromContext(ctx),
+		pb.PROP_TLS_IDENTITY: util.GetTLSIdentityFromContext(ctx),
+		pb.PROP_USER_AGENT:   util.GetUserAgentFromContext(ctx),
+	} {
+		if len(v) > 0 {
+			instance.Properties[k] = v
+		}
+	}
+}
+
+// KeepReservedProperties 更新实例属性时保留服务端写入的保留属性
+func KeepReservedProperties(old map[string]string, properties map[string]string) {
+	removeReservedProperties(properties)
+	for k, v := range old {
+		if strings.HasPrefix(k, pb.PROP_RESERVED_PREFIX) {
+			properties[k] = v
+		}
+	}
+}
+
+// MergeProperties 按JSON Merge Patch语义返回合并后的属性，先删除remove中的属性，
+// 再用patch覆盖，不修改old
+func MergeProperties(old map[string]string, patch map[string]string, remove []string) map[string]string {
+	properties := make(map[string]string, len(old)+len(patch))
+	for k, v := range old {
+		properties[k] = v
+	}
+	for _, k := range remove {
+		delete(properties, k)
+	}
+	for k, v := range patch {
+		properties[k] = v
+	}
+	return properties
+}
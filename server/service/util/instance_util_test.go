@@ -126,3 +126,22 @@ func TestParseEndpointValue(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestMergeProperties(t *testing.T) {
+	old := map[string]string{"a": "1", "b": "2", "c": "3"}
+	properties := MergeProperties(old, map[string]string{"b": "20", "d": "4"}, []string{"c", "x"})
+	if len(properties) != 3 || properties["a"] != "1" || properties["b"] != "20" || properties["d"] != "4" {
+		fmt.Printf(`MergeProperties failed`)
+		t.FailNow()
+	}
+	if len(old) != 3 || old["b"] != "2" {
+		fmt.Printf(`MergeProperties modified the old properties`)
+		t.FailNow()
+	}
+
+	properties = MergeProperties(nil, nil, []string{"a"})
+	if properties == nil || len(properties) != 0 {
+		fmt.Printf(`MergeProperties empty failed`)
+		t.FailNow()
+	}
+}
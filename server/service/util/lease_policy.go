@@ -0,0 +1,224 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 注册时缓存租约策略的时间，其他节点上的变更最多延迟该时间生效
+const LEASE_POLICY_CACHE_TTL = 30 * time.Second
+
+var leasePolicyCache = &leasePolicyListCache{}
+
+// LeasePolicy 管理员按domain/project配置的心跳实例租约策略，
+// 未携带healthCheck的实例使用默认值，TTL(interval*(times+1))超出范围时按边界改写interval
+type LeasePolicy struct {
+	Domain          string `json:"domain"`
+	Project         string `json:"project"`
+	DefaultInterval int32  `json:"defaultInterval,omitempty"`
+	DefaultTimes    int32  `json:"defaultTimes,omitempty"`
+	MinTTL          int32  `json:"minTTL,omitempty"`
+	MaxTTL          int32  `json:"maxTTL,omitempty"`
+	Operator        string `json:"operator,omitempty"`
+	Timestamp       string `json:"timestamp,omitempty"`
+}
+
+func (p *LeasePolicy) DomainProject() string {
+	return util.StringJoin([]string{p.Domain, p.Project}, "/")
+}
+
+func (p *LeasePolicy) Check() error {
+	if len(p.Domain) == 0 || len(p.Project) == 0 {
+		return errors.New("domain and project are required")
+	}
+	if p.DefaultInterval < 0 || p.DefaultTimes < 0 || p.MinTTL < 0 || p.MaxTTL < 0 {
+		return errors.New("interval, times and ttl can not be negative")
+	}
+	if int64(p.DefaultInterval)*int64(p.DefaultTimes+1) >= math.MaxInt32 {
+		return errors.New("default interval or times is out of range")
+	}
+	if p.MaxTTL > 0 && p.MinTTL > p.MaxTTL {
+		return errors.New("minTTL is greater than maxTTL")
+	}
+	if ttl := p.defaultTTL(); ttl > 0 && !p.inRange(ttl) {
+		return errors.New("default ttl is out of [minTTL, maxTTL]")
+	}
+	return nil
+}
+
+func (p *LeasePolicy) defaultTTL() int32 {
+	if p.DefaultInterval == 0 && p.DefaultTimes == 0 {
+		return 0
+	}
+	interval, times := p.defaults()
+	return interval * (times + 1)
+}
+
+func (p *LeasePolicy) defaults() (interval, times int32) {
+	interval, times = apt.REGISTRY_DEFAULT_LEASE_RENEWALINTERVAL, apt.REGISTRY_DEFAULT_LEASE_RETRYTIMES
+	if p.DefaultInterval > 0 {
+		interval = p.DefaultInterval
+	}
+	if p.DefaultTimes > 0 {
+		times = p.DefaultTimes
+	}
+	return
+}
+
+func (p *LeasePolicy) inRange(ttl int32) bool {
+	return ttl >= p.MinTTL && (p.MaxTTL == 0 || ttl <= p.MaxTTL)
+}
+
+// Apply 按策略改写心跳实例的healthCheck，返回是否改写了客户端指定的值；
+// 调用前healthCheck需已通过合法性校验，times保持不变，只调整interval
+func (p *LeasePolicy) Apply(hc *pb.HealthCheck, specified bool) bool {
+	if !specified {
+		hc.Interval, hc.Times = p.defaults()
+	}
+	ttl := hc.Interval * (hc.Times + 1)
+	if p.inRange(ttl) {
+		return false
+	}
+	var interval int32
+	if ttl < p.MinTTL {
+		// 向上取整，保证不低于minTTL
+		interval = (p.MinTTL + hc.Times) / (hc.Times + 1)
+	} else {
+		interval = p.MaxTTL / (hc.Times + 1)
+	}
+	if interval <= 0 {
+		interval = 1
+	}
+	hc.Interval = interval
+	return specified
+}
+
+type leasePolicyListCache struct {
+	lock     sync.RWMutex
+	policies map[string]*LeasePolicy
+	loadTime time.Time
+}
+
+func (c *leasePolicyListCache) Get(domainProject string) (*LeasePolicy, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if c.policies == nil || time.Since(c.loadTime) > LEASE_POLICY_CACHE_TTL {
+		return nil, false
+	}
+	return c.policies[domainProject], true
+}
+
+func (c *leasePolicyListCache) Set(policies []*LeasePolicy) {
+	m := make(map[string]*LeasePolicy, len(policies))
+	for _, p := range policies {
+		m[p.DomainProject()] = p
+	}
+	c.lock.Lock()
+	c.policies, c.loadTime = m, time.Now()
+	c.lock.Unlock()
+}
+
+func (c *leasePolicyListCache) Invalidate() {
+	c.lock.Lock()
+	c.policies = nil
+	c.lock.Unlock()
+}
+
+// GetLeasePolicies 查询所有租约策略
+func GetLeasePolicies(ctx context.Context) ([]*LeasePolicy, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GetLeasePolicyRootKey()+"/"),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]*LeasePolicy, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		p := &LeasePolicy{}
+		if err := json.Unmarshal(kv.Value, p); err != nil {
+			util.Logger().Errorf(err, "invalid lease policy %s", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+// FindLeasePolicy 注册实例时查询租户的租约策略，未配置时返回nil
+func FindLeasePolicy(ctx context.Context, domainProject string) (*LeasePolicy, error) {
+	if p, ok := leasePolicyCache.Get(domainProject); ok {
+		return p, nil
+	}
+	policies, err := GetLeasePolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	leasePolicyCache.Set(policies)
+	p, _ := leasePolicyCache.Get(domainProject)
+	return p, nil
+}
+
+// PutLeasePolicy 新增或覆盖租约策略，只影响之后注册的实例
+func PutLeasePolicy(ctx context.Context, p *LeasePolicy) error {
+	p.Timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(apt.GenerateLeasePolicyKey(p.DomainProject())),
+		registry.WithValue(data))
+	if err != nil {
+		return err
+	}
+	leasePolicyCache.Invalidate()
+	return nil
+}
+
+// DeleteLeasePolicy 删除租约策略，返回策略是否存在
+func DeleteLeasePolicy(ctx context.Context, domainProject string) (bool, error) {
+	key := apt.GenerateLeasePolicyKey(domainProject)
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(key),
+		registry.WithCountOnly())
+	if err != nil || resp.Count == 0 {
+		return false, err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.DEL,
+		registry.WithStrKey(key))
+	if err != nil {
+		return false, err
+	}
+	leasePolicyCache.Invalidate()
+	return true, nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func TestLeasePolicyCheck(t *testing.T) {
+	p := &serviceUtil.LeasePolicy{Domain: "default", MinTTL: 60, MaxTTL: 600}
+	if p.Check() == nil {
+		fmt.Printf(`Check without project failed`)
+		t.FailNow()
+	}
+
+	p.Project = "default"
+	if p.Check() != nil || p.DomainProject() != "default/default" {
+		fmt.Printf(`Check lease policy failed`)
+		t.FailNow()
+	}
+
+	p.MinTTL = 900
+	if p.Check() == nil {
+		fmt.Printf(`Check minTTL greater than maxTTL failed`)
+		t.FailNow()
+	}
+
+	p.MinTTL, p.DefaultInterval, p.DefaultTimes = 60, 300, 3
+	if p.Check() == nil {
+		fmt.Printf(`Check default ttl out of range failed`)
+		t.FailNow()
+	}
+}
+
+func TestLeasePolicyApply(t *testing.T) {
+	p := &serviceUtil.LeasePolicy{Domain: "default", Project: "default", DefaultInterval: 10, MinTTL: 25, MaxTTL: 600}
+
+	hc := &pb.HealthCheck{Mode: pb.CHECK_BY_HEARTBEAT}
+	if p.Apply(hc, false) || hc.Interval != 10 || hc.Times != 3 {
+		fmt.Printf(`Apply default lease failed, %v`, hc)
+		t.FailNow()
+	}
+
+	hc = &pb.HealthCheck{Mode: pb.CHECK_BY_HEARTBEAT, Interval: 30, Times: 3}
+	if p.Apply(hc, true) || hc.Interval != 30 {
+		fmt.Printf(`Apply lease in range failed, %v`, hc)
+		t.FailNow()
+	}
+
+	hc = &pb.HealthCheck{Mode: pb.CHECK_BY_HEARTBEAT, Interval: 86400, Times: 3}
+	if !p.Apply(hc, true) || hc.Interval != 150 || hc.Times != 3 {
+		fmt.Printf(`Apply lease greater than maxTTL failed, %v`, hc)
+		t.FailNow()
+	}
+
+	hc = &pb.HealthCheck{Mode: pb.CHECK_BY_HEARTBEAT, Interval: 1, Times: 1}
+	if !p.Apply(hc, true) || hc.Interval != 13 {
+		fmt.Printf(`Apply lease less than minTTL failed, %v`, hc)
+		t.FailNow()
+	}
+}
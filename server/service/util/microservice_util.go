@@ -85,6 +85,7 @@ func GetService(ctx context.Context, domainProject string, serviceId string) (*p
 	if err != nil {
 		return nil, err
 	}
+	RecordModRevision(ctx, serviceResp.Kvs)
 	if len(serviceResp.Kvs) == 0 {
 		return nil, nil
 	}
@@ -105,6 +106,7 @@ func GetServicesRawData(ctx context.Context, domainProject string) ([]*mvccpb.Ke
 	if err != nil {
 		return nil, err
 	}
+	RecordModRevision(ctx, resp.Kvs)
 	return resp.Kvs, err
 }
 
@@ -125,6 +127,29 @@ func GetServicesByDomain(ctx context.Context, domainProject string) ([]*pb.Micro
 	return services, nil
 }
 
+// GetServicesByName 通过serviceName索引查询租户下所有appId中同名的服务
+func GetServicesByName(ctx context.Context, domainProject string, serviceName string) ([]*pb.MicroService, error) {
+	opts := append(FromContext(ctx),
+		registry.WithStrKey(apt.GenerateServiceNameIndexKey(domainProject, serviceName, "")),
+		registry.WithPrefix())
+	resp, err := store.Store().ServiceNameIndex().Search(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	services := make([]*pb.MicroService, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		service, err := GetService(ctx, domainProject, util.BytesToStringWithNoCopy(kv.Value))
+		if err != nil {
+			return nil, err
+		}
+		if service == nil {
+			continue
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
+
 func GetServiceId(ctx context.Context, key *pb.MicroServiceKey) (serviceId string, err error) {
 	serviceId, err = searchServiceId(ctx, key)
 	if err != nil {
@@ -179,6 +204,29 @@ func GetServiceAllVersions(ctx context.Context, key *pb.MicroServiceKey, alias b
 	return resp, err
 }
 
+// BatchGetServiceIds 一次前缀查询租户的所有服务索引，按key精确匹配，不存在的serviceId为空
+func BatchGetServiceIds(ctx context.Context, domainProject string, keys []*pb.MicroServiceKey) ([]string, error) {
+	opts := append(FromContext(ctx),
+		registry.WithStrKey(apt.GetServiceIndexRootKey(domainProject)+"/"),
+		registry.WithPrefix())
+	resp, err := store.Store().ServiceIndex().Search(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make(map[string]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		indexes[util.BytesToStringWithNoCopy(kv.Key)] = util.BytesToStringWithNoCopy(kv.Value)
+	}
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		k := *key
+		k.Tenant = domainProject
+		ids[i] = indexes[apt.GenerateServiceIndexKey(&k)]
+	}
+	return ids, nil
+}
+
 func FindServiceIds(ctx context.Context, versionRule string, key *pb.MicroServiceKey) ([]string, error) {
 	// 版本规则
 	ids := []string{}
@@ -204,7 +252,15 @@ FIND_RULE:
 		return nil, err
 	}
 	if len(resp.Kvs) > 0 {
-		ids = match(resp.Kvs)
+		if versionRule == "latest" && FeatureEnabled(ctx, FEATURE_LATEST_EXCLUDES_RETIRED, key.Tenant) {
+			// 已下线的版本可能被排除，按版本从高到低依次检查
+			ids, err = findLatestServiceId(ctx, key.Tenant, VersionRule(AtLess).Match(resp.Kvs, "0"))
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			ids = match(resp.Kvs)
+		}
 	}
 	if len(ids) == 0 && alsoFindAlias {
 		searchAlias = true
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"golang.org/x/net/context"
+	"sync"
+)
+
+const CTX_MOD_REVISION = "_modRevision"
+
+// ModRevisionRecorder 记录一次读请求读取的注册中心数据的最大mod revision，
+// 其中任一数据变化都会使其变大，客户端和中间缓存可以据此判断响应内容是否变化
+type ModRevisionRecorder struct {
+	lock   sync.Mutex
+	rev    int64
+	closed bool
+	// 记录的revision变大时回调
+	onChange func(rev int64)
+}
+
+func NewModRevisionRecorder(onChange func(rev int64)) *ModRevisionRecorder {
+	return &ModRevisionRecorder{onChange: onChange}
+}
+
+func (r *ModRevisionRecorder) Record(rev int64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed || rev <= r.rev {
+		return
+	}
+	r.rev = rev
+	if r.onChange != nil {
+		r.onChange(rev)
+	}
+}
+
+func (r *ModRevisionRecorder) Revision() int64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.rev
+}
+
+// Close 响应返回后不再记录，仍在使用请求ctx的异步任务不影响已返回的响应
+func (r *ModRevisionRecorder) Close() {
+	r.lock.Lock()
+	r.closed = true
+	r.lock.Unlock()
+}
+
+// RecordModRevision 请求ctx中带有recorder时记录kvs的最大mod revision
+func RecordModRevision(ctx context.Context, kvs []*mvccpb.KeyValue) {
+	r, ok := ctx.Value(CTX_MOD_REVISION).(*ModRevisionRecorder)
+	if !ok || len(kvs) == 0 {
+		return
+	}
+	var max int64
+	for _, kv := range kvs {
+		if kv.ModRevision > max {
+			max = kv.ModRevision
+		}
+	}
+	r.Record(max)
+}
@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"golang.org/x/net/context"
+	"testing"
+)
+
+func TestRecordModRevision(t *testing.T) {
+	var changed []int64
+	recorder := serviceUtil.NewModRevisionRecorder(func(rev int64) {
+		changed = append(changed, rev)
+	})
+
+	serviceUtil.RecordModRevision(context.Background(), []*mvccpb.KeyValue{{ModRevision: 1}})
+	if recorder.Revision() != 0 {
+		fmt.Printf("RecordModRevision without recorder failed")
+		t.FailNow()
+	}
+
+	ctx := context.WithValue(context.Background(), serviceUtil.CTX_MOD_REVISION, recorder)
+	serviceUtil.RecordModRevision(ctx, []*mvccpb.KeyValue{{ModRevision: 3}, {ModRevision: 5}})
+	serviceUtil.RecordModRevision(ctx, []*mvccpb.KeyValue{{ModRevision: 4}})
+	serviceUtil.RecordModRevision(ctx, nil)
+	if recorder.Revision() != 5 || len(changed) != 1 || changed[0] != 5 {
+		fmt.Printf("RecordModRevision max revision failed, %d %v", recorder.Revision(), changed)
+		t.FailNow()
+	}
+
+	recorder.Close()
+	serviceUtil.RecordModRevision(ctx, []*mvccpb.KeyValue{{ModRevision: 6}})
+	if recorder.Revision() != 5 || len(changed) != 1 {
+		fmt.Printf("RecordModRevision after closed failed")
+		t.FailNow()
+	}
+}
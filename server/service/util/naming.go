@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/naming"
+	"github.com/apache/incubator-servicecomb-service-center/server/plugin"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"strings"
+)
+
+var namingViolations = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "service_center",
+		Subsystem: "naming",
+		Name:      "violations_total",
+		Help:      "Counter of the services violating the naming rules, by rejected or grandfathered",
+	}, []string{"domain", "result"})
+
+func init() {
+	prometheus.MustRegister(namingViolations)
+}
+
+func namingPolicy(ctx context.Context) naming.Policy {
+	if apt.IsSCInstance(ctx) {
+		return nil
+	}
+	policy, _ := plugin.Plugins().Instance(plugin.NAMING).(naming.Policy)
+	return policy
+}
+
+func namingError(violations []*naming.Violation) *scerr.Error {
+	msgs := make([]string, 0, len(violations))
+	details := make([]*pb.ErrorDetail, 0, len(violations))
+	for _, v := range violations {
+		msgs = append(msgs, v.Error())
+		detail := scerr.NewDetail(scerr.ErrNamingViolation, "service."+v.Field, v.Reason)
+		detail.Value = v.Value
+		details = append(details, detail)
+	}
+	return scerr.NewError(scerr.ErrNamingViolation, strings.Join(msgs, "; ")).WithDetails(details...)
+}
+
+// CheckServiceNaming 注册服务前检查命名规范，同一环境和应用下已有同名服务(任意版本)时只记录不拒绝，
+// 规范收紧前注册的服务仍可以注册新版本，可通过重命名使其符合规范
+func CheckServiceNaming(ctx context.Context, domainProject string, service *pb.MicroService) *scerr.Error {
+	policy := namingPolicy(ctx)
+	if policy == nil {
+		return nil
+	}
+	violations := policy.Check(ctx, domainProject, service)
+	if len(violations) == 0 {
+		return nil
+	}
+	domain := strings.Split(domainProject, "/")[0]
+	resp, err := GetServiceAllVersions(ctx, &pb.MicroServiceKey{
+		Tenant:      domainProject,
+		Environment: service.Environment,
+		AppId:       service.AppId,
+		ServiceName: service.ServiceName,
+	}, false)
+	if err != nil {
+		util.Logger().Errorf(err, "check the naming of service %s/%s failed", service.AppId, service.ServiceName)
+		return scerr.NewError(scerr.ErrUnavailableBackend, err.Error())
+	}
+	if len(resp.Kvs) > 0 {
+		namingViolations.WithLabelValues(domain, "grandfathered").Inc()
+		util.Logger().Warnf(nil, "service %s/%s/%s in %s violates the naming rules but already exists, %s",
+			service.AppId, service.ServiceName, service.Version, domainProject, violations[0].Error())
+		return nil
+	}
+	namingViolations.WithLabelValues(domain, "rejected").Inc()
+	return namingError(violations)
+}
+
+// CheckServiceRenaming 重命名的目标名称必须符合命名规范
+func CheckServiceRenaming(ctx context.Context, domainProject string, service *pb.MicroService, appId, serviceName string) *scerr.Error {
+	policy := namingPolicy(ctx)
+	if policy == nil {
+		return nil
+	}
+	to := *service
+	to.AppId, to.ServiceName = appId, serviceName
+	violations := policy.Check(ctx, domainProject, &to)
+	if len(violations) == 0 {
+		return nil
+	}
+	namingViolations.WithLabelValues(strings.Split(domainProject, "/")[0], "rejected").Inc()
+	return namingError(violations)
+}
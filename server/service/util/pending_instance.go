@@ -0,0 +1,175 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/scheduler"
+	"golang.org/x/net/context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DEFAULT_PENDING_ACTIVATION_INTERVAL = 5 * time.Second
+
+// PendingInstance 到期待激活的预注册实例
+type PendingInstance struct {
+	DomainProject string
+	ServiceId     string
+	InstanceId    string
+	ActivateTime  time.Time
+
+	key    string
+	modRev int64
+}
+
+// PendingInstanceIndexOps 有计划激活时间的预注册实例写入激活索引，索引与实例使用同一租约
+func PendingInstanceIndexOps(domainProject string, instance *pb.MicroServiceInstance, leaseID int64) []registry.PluginOp {
+	if instance.Status != pb.MSI_PENDING || len(instance.ActivateTime) == 0 {
+		return nil
+	}
+	return []registry.PluginOp{
+		registry.OpPut(
+			registry.WithStrKey(apt.GeneratePendingInstanceKey(domainProject, instance.ServiceId, instance.InstanceId)),
+			registry.WithStrValue(instance.ActivateTime),
+			registry.WithLease(leaseID), registry.WithIgnoreLease()),
+	}
+}
+
+// DeletePendingInstanceIndex 实例已激活或不再是预注册状态时删除激活索引
+func DeletePendingInstanceIndex(ctx context.Context, domainProject, serviceId, instanceId string) error {
+	_, err := backend.Registry().Do(ctx,
+		registry.DEL,
+		registry.WithStrKey(apt.GeneratePendingInstanceKey(domainProject, serviceId, instanceId)))
+	return err
+}
+
+// GetDuePendingInstances 查询计划激活时间不晚于now的预注册实例
+func GetDuePendingInstances(ctx context.Context, now time.Time) ([]*PendingInstance, error) {
+	rootKey := apt.GetPendingInstanceRootKey("")
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(rootKey),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	var due []*PendingInstance
+	for _, kv := range resp.Kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		// {domain}/{project}/{serviceId}/{instanceId}
+		arr := strings.Split(key[len(rootKey):], "/")
+		if len(arr) != 4 {
+			continue
+		}
+		ts, err := strconv.ParseInt(util.BytesToStringWithNoCopy(kv.Value), 10, 64)
+		if err != nil {
+			util.Logger().Errorf(err, "invalid activate time of pending instance %s", key)
+			continue
+		}
+		activateTime := time.Unix(ts, 0)
+		if activateTime.After(now) {
+			continue
+		}
+		due = append(due, &PendingInstance{
+			DomainProject: arr[0] + "/" + arr[1],
+			ServiceId:     arr[2],
+			InstanceId:    arr[3],
+			ActivateTime:  activateTime,
+			key:           key,
+			modRev:        kv.ModRevision,
+		})
+	}
+	return due, nil
+}
+
+// claim 删除激活索引，多个节点同时到期时只有删除成功的节点执行激活
+func (p *PendingInstance) claim(ctx context.Context) (bool, error) {
+	resp, err := backend.Registry().TxnWithCmp(ctx,
+		[]registry.PluginOp{registry.OpDel(registry.WithStrKey(p.key))},
+		[]registry.CompareOp{registry.OpCmp(registry.CmpStrModRev(p.key), registry.CMP_EQUAL, p.modRev)},
+		nil)
+	if err != nil {
+		return false, err
+	}
+	return resp.Succeeded, nil
+}
+
+// ActivateDuePendingInstances 激活到期的预注册实例，返回激活的个数
+func ActivateDuePendingInstances(ctx context.Context, now time.Time) (int, error) {
+	due, err := GetDuePendingInstances(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, p := range due {
+		ok, err := p.claim(ctx)
+		if err != nil {
+			return n, err
+		}
+		if !ok {
+			continue
+		}
+		arr := strings.SplitN(p.DomainProject, "/", 2)
+		tctx := util.SetContext(ctx, "domain", arr[0])
+		tctx = util.SetContext(tctx, "project", arr[1])
+		resp, err := apt.InstanceAPI.ActivateInstances(tctx, &pb.PromoteInstancesRequest{
+			ServiceId:   p.ServiceId,
+			InstanceIds: []string{p.InstanceId},
+		})
+		if err != nil {
+			util.Logger().Errorf(err, "activate pending instance %s/%s/%s failed",
+				p.DomainProject, p.ServiceId, p.InstanceId)
+			continue
+		}
+		switch resp.Response.Code {
+		case pb.Response_SUCCESS:
+			n += len(resp.InstanceIds)
+		case scerr.ErrServiceNotExists, scerr.ErrInstanceNotExists:
+			// 实例已注销，只需清理索引
+		default:
+			util.Logger().Errorf(nil, "activate pending instance %s/%s/%s failed, %s",
+				p.DomainProject, p.ServiceId, p.InstanceId, resp.Response.Message)
+		}
+	}
+	return n, nil
+}
+
+// RunPendingActivation 周期性地激活到达计划时间的预注册实例
+func RunPendingActivation() {
+	scheduler.Register(&scheduler.Job{
+		Name:      "pending_activation",
+		Priority:  scheduler.PRIORITY_HIGH,
+		Interval:  DEFAULT_PENDING_ACTIVATION_INTERVAL,
+		Immediate: true,
+		Singleton: true,
+		Func: func(ctx context.Context) error {
+			n, err := ActivateDuePendingInstances(ctx, time.Now())
+			if n > 0 {
+				util.Logger().Infof("%d pending instance(s) are activated as scheduled", n)
+			}
+			return err
+		},
+	})
+}
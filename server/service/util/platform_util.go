@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"strings"
+)
+
+// 常见的架构别名，统一为GOARCH的写法
+var archAliases = map[string]string{
+	"x86_64":  "amd64",
+	"x64":     "amd64",
+	"aarch64": "arm64",
+	"armv8":   "arm64",
+	"i386":    "386",
+	"i686":    "386",
+}
+
+// NormalizePlatform 统一为小写并转换架构别名，os和arch都为空时返回nil
+func NormalizePlatform(platform *pb.Platform) *pb.Platform {
+	if platform == nil {
+		return nil
+	}
+	platform.Os = strings.ToLower(platform.Os)
+	platform.Arch = strings.ToLower(platform.Arch)
+	if alias, ok := archAliases[platform.Arch]; ok {
+		platform.Arch = alias
+	}
+	if len(platform.Os) == 0 && len(platform.Arch) == 0 {
+		return nil
+	}
+	return platform
+}
+
+// MatchPlatform 实例未声明的os或arch视为与该项无关
+func MatchPlatform(instance *pb.MicroServiceInstance, platform *pb.Platform) bool {
+	if instance.Platform == nil || platform == nil {
+		return true
+	}
+	if len(platform.Os) > 0 && len(instance.Platform.Os) > 0 && platform.Os != instance.Platform.Os {
+		return false
+	}
+	if len(platform.Arch) > 0 && len(instance.Platform.Arch) > 0 && platform.Arch != instance.Platform.Arch {
+		return false
+	}
+	return true
+}
+
+// ApplyPlatformPolicy 按消费者平台裁剪实例列表:
+// prefer时匹配的实例排在前面，require时只返回匹配的实例
+func ApplyPlatformPolicy(platform *pb.Platform, policy string, instances []*pb.MicroServiceInstance) []*pb.MicroServiceInstance {
+	platform = NormalizePlatform(platform)
+	if platform == nil || len(instances) == 0 {
+		return instances
+	}
+
+	matched := make([]*pb.MicroServiceInstance, 0, len(instances))
+	others := make([]*pb.MicroServiceInstance, 0, len(instances))
+	for _, instance := range instances {
+		if MatchPlatform(instance, platform) {
+			matched = append(matched, instance)
+			continue
+		}
+		others = append(others, instance)
+	}
+	if policy == pb.PLATFORM_REQUIRE {
+		return matched
+	}
+	return append(matched, others...)
+}
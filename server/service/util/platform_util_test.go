@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func TestNormalizePlatform(t *testing.T) {
+	if serviceUtil.NormalizePlatform(nil) != nil || serviceUtil.NormalizePlatform(&pb.Platform{}) != nil {
+		fmt.Printf(`NormalizePlatform with empty platform failed`)
+		t.FailNow()
+	}
+	p := serviceUtil.NormalizePlatform(&pb.Platform{Os: "Linux", Arch: "AARCH64"})
+	if p.Os != "linux" || p.Arch != "arm64" {
+		fmt.Printf(`NormalizePlatform failed`)
+		t.FailNow()
+	}
+}
+
+func TestApplyPlatformPolicy(t *testing.T) {
+	instances := []*pb.MicroServiceInstance{
+		{InstanceId: "1", Platform: &pb.Platform{Os: "linux", Arch: "amd64"}},
+		{InstanceId: "2"},
+		{InstanceId: "3", Platform: &pb.Platform{Os: "linux", Arch: "arm64"}},
+		{InstanceId: "4", Platform: &pb.Platform{Arch: "arm64"}},
+	}
+
+	result := serviceUtil.ApplyPlatformPolicy(nil, pb.PLATFORM_REQUIRE, instances)
+	if len(result) != len(instances) {
+		fmt.Printf(`ApplyPlatformPolicy with nil platform failed`)
+		t.FailNow()
+	}
+
+	result = serviceUtil.ApplyPlatformPolicy(&pb.Platform{Os: "linux", Arch: "x86_64"}, "", instances)
+	if len(result) != 4 || result[0].InstanceId != "1" || result[1].InstanceId != "2" {
+		fmt.Printf(`ApplyPlatformPolicy with prefer failed`)
+		t.FailNow()
+	}
+
+	result = serviceUtil.ApplyPlatformPolicy(&pb.Platform{Arch: "arm64"}, pb.PLATFORM_REQUIRE, instances)
+	if len(result) != 3 || result[0].InstanceId != "2" || result[1].InstanceId != "3" || result[2].InstanceId != "4" {
+		fmt.Printf(`ApplyPlatformPolicy with require failed`)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+)
+
+func PutDiscoveryPolicy(ctx context.Context, domainProject string, serviceId string, policy *pb.DiscoveryPolicy) error {
+	key := apt.GenerateServicePolicyKey(domainProject, serviceId)
+	data, err := json.Marshal(policy)
+	if err != nil {
+		util.Logger().Errorf(err, "put discovery policy, serviceId %s: json marshal policy failed.", serviceId)
+		return err
+	}
+
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(key),
+		registry.WithValue(data))
+	if err != nil {
+		util.Logger().Errorf(err, "put discovery policy, serviceId %s: commit policy into etcd failed.", serviceId)
+		return err
+	}
+	return nil
+}
+
+func GetDiscoveryPolicy(ctx context.Context, domainProject, serviceId string) (*pb.DiscoveryPolicy, error) {
+	key := apt.GenerateServicePolicyKey(domainProject, serviceId)
+	opts := append(FromContext(ctx), registry.WithStrKey(key))
+	resp, err := store.Store().ServicePolicy().Search(ctx, opts...)
+	if err != nil {
+		util.Logger().Errorf(err, "get service %s discovery policy failed", key)
+		return nil, err
+	}
+
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	policy := &pb.DiscoveryPolicy{}
+	err = json.Unmarshal(resp.Kvs[0].Value, policy)
+	if err != nil {
+		util.Logger().Errorf(err, "unmarshal service %s discovery policy failed", key)
+		return nil, err
+	}
+	return policy, nil
+}
+
+func matchProperties(props map[string]string, match map[string]string) bool {
+	for k, v := range match {
+		if pv, ok := props[k]; !ok || pv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyDiscoveryPolicy 按消费者的发现策略裁剪实例列表:
+// 剔除properties命中excludeProperties任一项的实例，
+// 带有全部preferredTags的实例排在前面，最多返回maxInstances个实例
+func ApplyDiscoveryPolicy(policy *pb.DiscoveryPolicy, instances []*pb.MicroServiceInstance) []*pb.MicroServiceInstance {
+	if policy == nil || len(instances) == 0 {
+		return instances
+	}
+
+	preferred := make([]*pb.MicroServiceInstance, 0, len(instances))
+	others := make([]*pb.MicroServiceInstance, 0, len(instances))
+	for _, instance := range instances {
+		excluded := false
+		for k, v := range policy.ExcludeProperties {
+			if pv, ok := instance.Properties[k]; ok && pv == v {
+				excluded = true
+				break
+			}
+		}
+		if excluded {
+			continue
+		}
+		if len(policy.PreferredTags) > 0 && matchProperties(instance.Properties, policy.PreferredTags) {
+			preferred = append(preferred, instance)
+			continue
+		}
+		others = append(others, instance)
+	}
+
+	result := append(preferred, others...)
+	if policy.MaxInstances > 0 && len(result) > int(policy.MaxInstances) {
+		result = result[:policy.MaxInstances]
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"golang.org/x/net/context"
+	"testing"
+)
+
+func TestPutDiscoveryPolicy(t *testing.T) {
+	err := serviceUtil.PutDiscoveryPolicy(context.Background(), "", "", &pb.DiscoveryPolicy{MaxInstances: 1})
+	if err == nil {
+		fmt.Printf(`PutDiscoveryPolicy failed`)
+		t.FailNow()
+	}
+}
+
+func TestGetDiscoveryPolicy(t *testing.T) {
+	_, err := serviceUtil.GetDiscoveryPolicy(util.SetContext(context.Background(), "cacheOnly", "1"), "", "")
+	if err != nil {
+		fmt.Printf(`GetDiscoveryPolicy WithCacheOnly failed`)
+		t.FailNow()
+	}
+
+	_, err = serviceUtil.GetDiscoveryPolicy(context.Background(), "", "")
+	if err == nil {
+		fmt.Printf(`GetDiscoveryPolicy failed`)
+		t.FailNow()
+	}
+}
+
+func TestApplyDiscoveryPolicy(t *testing.T) {
+	instances := []*pb.MicroServiceInstance{
+		{InstanceId: "1", Properties: map[string]string{"canary": "true"}},
+		{InstanceId: "2"},
+		{InstanceId: "3", Properties: map[string]string{"zone": "a"}},
+		{InstanceId: "4", Properties: map[string]string{"zone": "a"}},
+	}
+
+	result := serviceUtil.ApplyDiscoveryPolicy(nil, instances)
+	if len(result) != len(instances) {
+		fmt.Printf(`ApplyDiscoveryPolicy with nil policy failed`)
+		t.FailNow()
+	}
+
+	result = serviceUtil.ApplyDiscoveryPolicy(&pb.DiscoveryPolicy{
+		MaxInstances:      2,
+		PreferredTags:     map[string]string{"zone": "a"},
+		ExcludeProperties: map[string]string{"canary": "true"},
+	}, instances)
+	if len(result) != 2 || result[0].InstanceId != "3" || result[1].InstanceId != "4" {
+		fmt.Printf(`ApplyDiscoveryPolicy failed`)
+		t.FailNow()
+	}
+
+	result = serviceUtil.ApplyDiscoveryPolicy(&pb.DiscoveryPolicy{
+		ExcludeProperties: map[string]string{"canary": "true"},
+	}, instances)
+	if len(result) != 3 || result[0].InstanceId != "2" {
+		fmt.Printf(`ApplyDiscoveryPolicy with exclusion failed`)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/scheduler"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"time"
+)
+
+const (
+	RETIREMENT_DATE_FORMAT = "2006-01-02"
+
+	DEFAULT_RETIREMENT_REPORT_INTERVAL = time.Minute
+)
+
+var retiringConsumers = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "service_center",
+		Subsystem: "service",
+		Name:      "retiring_version_consumers",
+		Help:      "Gauge of consumers still depending on the retiring service versions",
+	}, []string{"domain", "service", "version", "targetDate"})
+
+func init() {
+	prometheus.MustRegister(retiringConsumers)
+}
+
+// ParseRetirementDate 解析下线日期，返回当天0点(UTC)
+func ParseRetirementDate(date string) (time.Time, error) {
+	return time.Parse(RETIREMENT_DATE_FORMAT, date)
+}
+
+// IsRetired 是否已过下线日期
+func IsRetired(service *pb.MicroService, now time.Time) bool {
+	if service == nil || service.Retirement == nil {
+		return false
+	}
+	t, err := ParseRetirementDate(service.Retirement.TargetDate)
+	if err != nil {
+		return false
+	}
+	return !now.Before(t)
+}
+
+// ExcludedFromLatest 已过下线日期且配置了excludeFromLatest的版本不参与latest规则的匹配
+func ExcludedFromLatest(service *pb.MicroService, now time.Time) bool {
+	return service != nil && service.Retirement != nil &&
+		service.Retirement.ExcludeFromLatest && IsRetired(service, now)
+}
+
+// findLatestServiceId 按版本从高到低返回第一个未被排除的服务，全部被排除时返回最高版本
+func findLatestServiceId(ctx context.Context, domainProject string, ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return ids, nil
+	}
+	now := time.Now()
+	for _, serviceId := range ids {
+		service, err := GetService(ctx, domainProject, serviceId)
+		if err != nil {
+			return nil, err
+		}
+		if !ExcludedFromLatest(service, now) {
+			return []string{serviceId}, nil
+		}
+	}
+	return ids[:1], nil
+}
+
+// GetRetiringVersions 返回ids中计划下线的版本，用于在发现结果中提示消费者
+func GetRetiringVersions(ctx context.Context, domainProject string, ids []string) []*pb.RetiringVersion {
+	var versions []*pb.RetiringVersion
+	for _, serviceId := range ids {
+		service, err := GetService(ctx, domainProject, serviceId)
+		if err != nil || service == nil || service.Retirement == nil {
+			continue
+		}
+		versions = append(versions, &pb.RetiringVersion{
+			ServiceId:   service.ServiceId,
+			ServiceName: service.ServiceName,
+			Version:     service.Version,
+			TargetDate:  service.Retirement.TargetDate,
+			Message:     service.Retirement.Message,
+		})
+	}
+	return versions
+}
+
+// ReportRetiringConsumers 统计所有计划下线版本的剩余消费者数
+func ReportRetiringConsumers(ctx context.Context) error {
+	resp, err := store.Store().Service().Search(ctx,
+		registry.WithStrKey(apt.GetServiceRootKey("")),
+		registry.WithPrefix())
+	if err != nil {
+		return err
+	}
+
+	retiringConsumers.Reset()
+	for _, kv := range resp.Kvs {
+		_, domainProject, data := pb.GetInfoFromSvcKV(kv)
+		service := &pb.MicroService{}
+		if err := json.Unmarshal(data, service); err != nil || service.Retirement == nil {
+			continue
+		}
+		dr := NewDependencyRelation(ctx, domainProject, "", nil, service.ServiceId, service)
+		consumerIds, err := dr.GetDependencyConsumerIds()
+		if err != nil {
+			util.Logger().Errorf(err, "get consumers of retiring service %s failed", service.ServiceId)
+			continue
+		}
+		retiringConsumers.WithLabelValues(domainProject, service.ServiceName, service.Version,
+			service.Retirement.TargetDate).Set(float64(len(consumerIds)))
+	}
+	return nil
+}
+
+// RunRetirementReport 周期性地统计计划下线版本的剩余消费者数
+func RunRetirementReport() {
+	scheduler.Register(&scheduler.Job{
+		Name:     "retirement_report",
+		Priority: scheduler.PRIORITY_NORMAL,
+		Interval: DEFAULT_RETIREMENT_REPORT_INTERVAL,
+		Func:     ReportRetiringConsumers,
+	})
+}
@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+	"time"
+)
+
+func TestIsRetired(t *testing.T) {
+	now := time.Date(2030, 6, 30, 12, 0, 0, 0, time.UTC)
+	service := &pb.MicroService{}
+	if serviceUtil.IsRetired(service, now) || serviceUtil.ExcludedFromLatest(service, now) {
+		fmt.Printf(`IsRetired without retirement failed`)
+		t.FailNow()
+	}
+
+	service.Retirement = &pb.ServiceRetirement{TargetDate: "2030-07-01", ExcludeFromLatest: true}
+	if serviceUtil.IsRetired(service, now) || serviceUtil.ExcludedFromLatest(service, now) {
+		fmt.Printf(`IsRetired before target date failed`)
+		t.FailNow()
+	}
+
+	service.Retirement.TargetDate = "2030-06-30"
+	if !serviceUtil.IsRetired(service, now) || !serviceUtil.ExcludedFromLatest(service, now) {
+		fmt.Printf(`IsRetired on target date failed`)
+		t.FailNow()
+	}
+
+	service.Retirement.ExcludeFromLatest = false
+	if serviceUtil.ExcludedFromLatest(service, now) {
+		fmt.Printf(`ExcludedFromLatest without excludeFromLatest failed`)
+		t.FailNow()
+	}
+
+	service.Retirement.TargetDate = "invalid"
+	if serviceUtil.IsRetired(service, now) {
+		fmt.Printf(`IsRetired with invalid target date failed`)
+		t.FailNow()
+	}
+}
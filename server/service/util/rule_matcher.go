@@ -0,0 +1,230 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"reflect"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	// 缓存的黑白名单在没有收到事件时的最长有效时间，防止缓存重建等情况漏掉事件
+	DEFAULT_RULE_MATCHER_TTL = 5 * time.Minute
+	MAX_RULE_MATCHERS        = 100000
+)
+
+var (
+	ruleMatchers = &ruleMatcherCacher{items: make(map[string]*cachedRuleMatcher)}
+
+	ruleMatcherCompiles = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "rule",
+			Name:      "matcher_compiles_total",
+			Help:      "Counter of the provider rules compiled into matchers",
+		})
+
+	ruleMatcherLatency = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "service_center",
+			Subsystem: "rule",
+			Name:      "matcher_evaluation_seconds",
+			Help:      "Histogram of the time evaluating the provider rules against a consumer",
+			Buckets:   prometheus.ExponentialBuckets(0.00001, 4, 10),
+		})
+)
+
+func init() {
+	prometheus.MustRegister(ruleMatcherCompiles, ruleMatcherLatency)
+}
+
+type compiledRule struct {
+	rule *pb.ServiceRule
+	// tag_开头的规则匹配的标签名，为空时匹配服务的字段
+	tag string
+	// 无效的表达式为nil，与原先一样视为不匹配
+	regex *regexp.Regexp
+}
+
+// RuleMatcher 服务黑白名单编译后的匹配器，nil表示没有规则
+type RuleMatcher struct {
+	rules []compiledRule
+}
+
+func NewRuleMatcher(rules []*pb.ServiceRule) *RuleMatcher {
+	if len(rules) == 0 {
+		return nil
+	}
+	ruleMatcherCompiles.Inc()
+	m := &RuleMatcher{rules: make([]compiledRule, 0, len(rules))}
+	for _, rule := range rules {
+		c := compiledRule{rule: rule}
+		if tagRegEx.MatchString(rule.Attribute) {
+			c.tag = tagRegEx.FindStringSubmatch(rule.Attribute)[1]
+		}
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			util.Logger().Errorf(err, "compile rule %s pattern '%s' failed", rule.RuleId, rule.Pattern)
+		} else {
+			c.regex = re
+		}
+		m.rules = append(m.rules, c)
+	}
+	return m
+}
+
+func (m *RuleMatcher) Empty() bool {
+	return m == nil || len(m.rules) == 0
+}
+
+// Match 命中黑名单或有白名单但未命中时返回ErrPermissionDeny
+func (m *RuleMatcher) Match(service *pb.MicroService, serviceTags map[string]string) *scerr.Error {
+	if service == nil {
+		return scerr.NewError(scerr.ErrInvalidParams, "service is nil")
+	}
+	if m.Empty() {
+		return nil
+	}
+	start := time.Now()
+	defer func() {
+		ruleMatcherLatency.Observe(time.Since(start).Seconds())
+	}()
+
+	v := reflect.Indirect(reflect.ValueOf(service))
+
+	hasWhite := false
+	for _, c := range m.rules {
+		rule := c.rule
+		var value string
+		if len(c.tag) > 0 {
+			value = serviceTags[c.tag]
+			if len(value) == 0 {
+				util.Logger().Infof("can not find service %s tag '%s'", service.ServiceId, c.tag)
+				continue
+			}
+		} else {
+			key := v.FieldByName(rule.Attribute)
+			if !key.IsValid() {
+				util.Logger().Errorf(nil, "can not find service %s field '%s', rule %s",
+					service.ServiceId, rule.Attribute, rule.RuleId)
+				return scerr.NewError(scerr.ErrInternal, fmt.Sprintf("Can not find field '%s'", rule.Attribute))
+			}
+			value = key.String()
+		}
+
+		match := c.regex != nil && c.regex.MatchString(value)
+		switch rule.RuleType {
+		case "WHITE":
+			hasWhite = true
+			if match {
+				util.Logger().Infof("service %s match white list, rule.Pattern is %s, value is %s",
+					service.ServiceId, rule.Pattern, value)
+				return nil
+			}
+		case "BLACK":
+			if match {
+				util.Logger().Infof("service %s match black list, rule.Pattern is %s, value is %s",
+					service.ServiceId, rule.Pattern, value)
+				return scerr.NewError(scerr.ErrPermissionDeny, "Found in black list")
+			}
+		}
+	}
+	if hasWhite {
+		util.Logger().Infof("service %s do not match white list", service.ServiceId)
+		return scerr.NewError(scerr.ErrPermissionDeny, "Not found in white list")
+	}
+	return nil
+}
+
+type cachedRuleMatcher struct {
+	matcher   *RuleMatcher
+	buildTime time.Time
+}
+
+// ruleMatcherCacher 按提供者缓存编译后的黑白名单，收到规则变化事件时失效
+type ruleMatcherCacher struct {
+	lock  sync.RWMutex
+	items map[string]*cachedRuleMatcher
+	// 失效的次数，加载期间有失效时不缓存加载的结果
+	generation int64
+}
+
+func (c *ruleMatcherCacher) Get(key string) (*RuleMatcher, int64, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	item, ok := c.items[key]
+	if !ok || time.Since(item.buildTime) > DEFAULT_RULE_MATCHER_TTL {
+		return nil, c.generation, false
+	}
+	return item.matcher, c.generation, true
+}
+
+func (c *ruleMatcherCacher) Set(key string, m *RuleMatcher, generation int64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.generation != generation {
+		return
+	}
+	if len(c.items) >= MAX_RULE_MATCHERS {
+		c.items = make(map[string]*cachedRuleMatcher)
+	}
+	c.items[key] = &cachedRuleMatcher{matcher: m, buildTime: time.Now()}
+}
+
+func (c *ruleMatcherCacher) Invalidate(key string) {
+	c.lock.Lock()
+	delete(c.items, key)
+	c.generation++
+	c.lock.Unlock()
+}
+
+func ruleMatcherKey(domainProject, providerId string) string {
+	return util.StringJoin([]string{domainProject, providerId}, "/")
+}
+
+// GetRuleMatcher 返回提供者编译后的黑白名单，没有规则时返回nil，
+// noCache的请求直接读取并编译，不使用也不更新缓存
+func GetRuleMatcher(ctx context.Context, domainProject, providerId string) (*RuleMatcher, error) {
+	key := ruleMatcherKey(domainProject, providerId)
+	cacheable := ctx.Value("noCache") != "1"
+	m, generation, ok := ruleMatchers.Get(key)
+	if ok && cacheable {
+		return m, nil
+	}
+	rules, err := GetRulesUtil(ctx, domainProject, providerId)
+	if err != nil {
+		return nil, err
+	}
+	m = NewRuleMatcher(rules)
+	if cacheable {
+		ruleMatchers.Set(key, m, generation)
+	}
+	return m, nil
+}
+
+// InvalidateRuleMatcher 提供者的黑白名单变化时调用
+func InvalidateRuleMatcher(domainProject, providerId string) {
+	ruleMatchers.Invalidate(ruleMatcherKey(domainProject, providerId))
+}
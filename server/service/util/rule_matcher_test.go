@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"testing"
+)
+
+func TestRuleMatcher_Match(t *testing.T) {
+	var m *RuleMatcher
+	if !m.Empty() || NewRuleMatcher(nil) != nil {
+		fmt.Printf("RuleMatcher empty failed")
+		t.FailNow()
+	}
+	if m.Match(&proto.MicroService{}, nil) != nil {
+		fmt.Printf("RuleMatcher Match with nil matcher failed")
+		t.FailNow()
+	}
+
+	m = NewRuleMatcher([]*proto.ServiceRule{
+		{RuleType: "BLACK", Attribute: "ServiceName", Pattern: "["},
+		{RuleType: "WHITE", Attribute: "tag_a", Pattern: "^b$"},
+	})
+	if err := m.Match(&proto.MicroService{ServiceName: "["}, map[string]string{"a": "b"}); err != nil {
+		fmt.Printf("RuleMatcher Match with invalid pattern failed")
+		t.FailNow()
+	}
+	if err := m.Match(&proto.MicroService{}, map[string]string{"a": "c"}); err == nil || err.Code != scerr.ErrPermissionDeny {
+		fmt.Printf("RuleMatcher Match WHITE with tag a failed")
+		t.FailNow()
+	}
+}
+
+func TestRuleMatcherCacher(t *testing.T) {
+	c := &ruleMatcherCacher{items: make(map[string]*cachedRuleMatcher)}
+	m := NewRuleMatcher([]*proto.ServiceRule{{RuleType: "WHITE", Attribute: "ServiceName", Pattern: "a"}})
+
+	_, generation, ok := c.Get("p")
+	if ok {
+		fmt.Printf("ruleMatcherCacher Get failed")
+		t.FailNow()
+	}
+	c.Set("p", m, generation)
+	if cached, _, ok := c.Get("p"); !ok || cached != m {
+		fmt.Printf("ruleMatcherCacher Set failed")
+		t.FailNow()
+	}
+
+	c.Invalidate("p")
+	if _, _, ok := c.Get("p"); ok {
+		fmt.Printf("ruleMatcherCacher Invalidate failed")
+		t.FailNow()
+	}
+
+	// 加载期间失效时不缓存旧的结果
+	_, generation, _ = c.Get("p")
+	c.Invalidate("p")
+	c.Set("p", m, generation)
+	if _, _, ok := c.Get("p"); ok {
+		fmt.Printf("ruleMatcherCacher Set after Invalidate failed")
+		t.FailNow()
+	}
+}
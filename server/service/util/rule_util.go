@@ -26,7 +26,6 @@ import (
 	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
 	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
 	"golang.org/x/net/context"
-	"reflect"
 	"regexp"
 	"strings"
 )
@@ -37,10 +36,12 @@ func init() {
 	tagRegEx, _ = regexp.Compile("tag_(.*)")
 }
 
+// RuleFilter 优先使用Matcher，未设置时按ProviderRules编译
 type RuleFilter struct {
 	DomainProject string
 	Provider      *pb.MicroService
 	ProviderRules []*pb.ServiceRule
+	Matcher       *RuleMatcher
 }
 
 func (rf *RuleFilter) Filter(ctx context.Context, consumerId string) (bool, error) {
@@ -50,18 +51,24 @@ func (rf *RuleFilter) Filter(ctx context.Context, consumerId string) (bool, erro
 		return false, err
 	}
 
-	tags, err := GetTagsUtils(copyCtx, rf.DomainProject, consumerId)
-	if err != nil {
-		return false, err
+	matcher := rf.Matcher
+	if matcher == nil {
+		matcher = NewRuleMatcher(rf.ProviderRules)
 	}
-	matchErr := MatchRules(rf.ProviderRules, consumer, tags)
-	if matchErr != nil {
-		if matchErr.Code == scerr.ErrPermissionDeny {
-			return false, nil
+	if !matcher.Empty() {
+		tags, err := GetTagsUtils(copyCtx, rf.DomainProject, consumerId)
+		if err != nil {
+			return false, err
+		}
+		matchErr := matcher.Match(consumer, tags)
+		if matchErr != nil {
+			if matchErr.Code == scerr.ErrPermissionDeny {
+				return false, nil
+			}
+			return false, matchErr
 		}
-		return false, matchErr
 	}
-	return true, nil
+	return DependencyApproved(copyCtx, rf.DomainProject, rf.Provider, consumerId)
 }
 
 func GetRulesUtil(ctx context.Context, domainProject string, serviceId string) ([]*pb.ServiceRule, error) {
@@ -76,6 +83,7 @@ func GetRulesUtil(ctx context.Context, domainProject string, serviceId string) (
 	if err != nil {
 		return nil, err
 	}
+	RecordModRevision(ctx, resp.Kvs)
 
 	rules := []*pb.ServiceRule{}
 	for _, kvs := range resp.Kvs {
@@ -130,6 +138,7 @@ func GetOneRule(ctx context.Context, domainProject, serviceId, ruleId string) (*
 		util.Logger().Errorf(nil, "Get rule for service failed for %s.", err.Error())
 		return nil, err
 	}
+	RecordModRevision(ctx, resp.Kvs)
 	rule := &pb.ServiceRule{}
 	if len(resp.Kvs) == 0 {
 		util.Logger().Errorf(nil, "Get rule failed, ruleId is %s.", ruleId)
@@ -144,6 +153,12 @@ func GetOneRule(ctx context.Context, domainProject, serviceId, ruleId string) (*
 }
 
 func AllowAcrossDimension(providerService *pb.MicroService, consumerService *pb.MicroService) error {
+	return AllowAcrossDimensionInScope(providerService, consumerService, nil)
+}
+
+// AllowAcrossDimensionInScope 跨环境访问由消费者租户的发现范围决定，scope为nil时只允许同一环境
+func AllowAcrossDimensionInScope(providerService *pb.MicroService, consumerService *pb.MicroService,
+	scope *DiscoveryScope) error {
 	if providerService.AppId != consumerService.AppId {
 		if len(providerService.Properties) == 0 {
 			return fmt.Errorf("not allow across app access")
@@ -154,7 +169,8 @@ func AllowAcrossDimension(providerService *pb.MicroService, consumerService *pb.
 		}
 	}
 
-	if providerService.Environment != consumerService.Environment {
+	if providerService.Environment != consumerService.Environment &&
+		!scope.Visible(consumerService.Environment, providerService.Environment) {
 		return fmt.Errorf("not allow across environment access")
 	}
 
@@ -165,56 +181,17 @@ func MatchRules(rules []*pb.ServiceRule, service *pb.MicroService, serviceTags m
 	if service == nil {
 		return scerr.NewError(scerr.ErrInvalidParams, "service is nil")
 	}
-
-	v := reflect.Indirect(reflect.ValueOf(service))
-
-	hasWhite := false
-	for _, rule := range rules {
-		var value string
-		if tagRegEx.MatchString(rule.Attribute) {
-			key := tagRegEx.FindStringSubmatch(rule.Attribute)[1]
-			value = serviceTags[key]
-			if len(value) == 0 {
-				util.Logger().Infof("can not find service %s tag '%s'", service.ServiceId, key)
-				continue
-			}
-		} else {
-			key := v.FieldByName(rule.Attribute)
-			if !key.IsValid() {
-				util.Logger().Errorf(nil, "can not find service %s field '%s', rule %s",
-					service.ServiceId, rule.Attribute, rule.RuleId)
-				return scerr.NewError(scerr.ErrInternal, fmt.Sprintf("Can not find field '%s'", rule.Attribute))
-			}
-			value = key.String()
-		}
-
-		switch rule.RuleType {
-		case "WHITE":
-			hasWhite = true
-			match, _ := regexp.MatchString(rule.Pattern, value)
-			if match {
-				util.Logger().Infof("service %s match white list, rule.Pattern is %s, value is %s",
-					service.ServiceId, rule.Pattern, value)
-				return nil
-			}
-		case "BLACK":
-			match, _ := regexp.MatchString(rule.Pattern, value)
-			if match {
-				util.Logger().Infof("service %s match black list, rule.Pattern is %s, value is %s",
-					service.ServiceId, rule.Pattern, value)
-				return scerr.NewError(scerr.ErrPermissionDeny, "Found in black list")
-			}
-		}
-	}
-	if hasWhite {
-		util.Logger().Infof("service %s do not match white list", service.ServiceId)
-		return scerr.NewError(scerr.ErrPermissionDeny, "Not found in white list")
-	}
-	return nil
+	return NewRuleMatcher(rules).Match(service, serviceTags)
 }
 
 func Accessible(ctx context.Context, domainProject string, consumerId string, providerId string) *scerr.Error {
-	consumerService, err := GetService(ctx, domainProject, consumerId)
+	return AccessibleAcrossDomain(ctx, domainProject, consumerId, domainProject, providerId)
+}
+
+// AccessibleAcrossDomain 消费者发现其它租户的共享服务时，提供者的审批和黑白名单仍然生效
+func AccessibleAcrossDomain(ctx context.Context, consumerDomainProject string, consumerId string,
+	providerDomainProject string, providerId string) *scerr.Error {
+	consumerService, err := GetService(ctx, consumerDomainProject, consumerId)
 	if err != nil {
 		return scerr.NewError(scerr.ErrInternal, fmt.Sprintf("An error occurred in query consumer(%s)", err.Error()))
 	}
@@ -223,7 +200,7 @@ func Accessible(ctx context.Context, domainProject string, consumerId string, pr
 	}
 
 	// 跨应用权限
-	providerService, err := GetService(ctx, domainProject, providerId)
+	providerService, err := GetService(ctx, providerDomainProject, providerId)
 	if err != nil {
 		return scerr.NewError(scerr.ErrInternal, fmt.Sprintf("An error occurred in query provider(%s)", err.Error()))
 	}
@@ -231,27 +208,40 @@ func Accessible(ctx context.Context, domainProject string, consumerId string, pr
 		return scerr.NewError(scerr.ErrServiceNotExists, "provider serviceId is invalid")
 	}
 
-	err = AllowAcrossDimension(providerService, consumerService)
+	scope, err := FindDiscoveryScope(ctx, consumerDomainProject)
+	if err != nil {
+		return scerr.NewError(scerr.ErrInternal, fmt.Sprintf("An error occurred in query discovery scope(%s)", err.Error()))
+	}
+	err = AllowAcrossDimensionInScope(providerService, consumerService, scope)
 	if err != nil {
 		return scerr.NewError(scerr.ErrPermissionDeny, err.Error())
 	}
 
 	ctx = util.SetContext(util.CloneContext(ctx), "cacheOnly", "1")
 
+	// 提供者审批
+	approved, err := DependencyApproved(ctx, providerDomainProject, providerService, consumerId)
+	if err != nil {
+		return scerr.NewError(scerr.ErrInternal, fmt.Sprintf("An error occurred in query dependency approval(%s)", err.Error()))
+	}
+	if !approved {
+		return scerr.NewError(scerr.ErrPermissionDeny, "Dependency is pending approval of the provider")
+	}
+
 	// 黑白名单
-	rules, err := GetRulesUtil(ctx, domainProject, providerId)
+	matcher, err := GetRuleMatcher(ctx, providerDomainProject, providerId)
 	if err != nil {
 		return scerr.NewError(scerr.ErrInternal, fmt.Sprintf("An error occurred in query provider rules(%s)", err.Error()))
 	}
 
-	if len(rules) == 0 {
+	if matcher.Empty() {
 		return nil
 	}
 
-	validateTags, err := GetTagsUtils(ctx, domainProject, consumerService.ServiceId)
+	validateTags, err := GetTagsUtils(ctx, consumerDomainProject, consumerService.ServiceId)
 	if err != nil {
 		return scerr.NewError(scerr.ErrInternal, fmt.Sprintf("An error occurred in query consumer tags(%s)", err.Error()))
 	}
 
-	return MatchRules(rules, consumerService, validateTags)
+	return matcher.Match(consumerService, validateTags)
 }
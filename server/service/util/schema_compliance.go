@@ -0,0 +1,448 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/astaxie/beego"
+	"github.com/ghodss/yaml"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DEFAULT_SCHEMA_COMPLIANCE_VERSION_HEADER = "x-api-version"
+	// 每个检查项最多返回的违规项
+	MAX_COMPLIANCE_VIOLATIONS = 20
+	// 合并该时间内同一服务的契约变化事件
+	DEFAULT_SCHEMA_COMPLIANCE_DELAY = time.Second
+)
+
+var (
+	schemaChecks = map[string]func(doc *swaggerDoc, header string) []string{
+		pb.SCHEMA_CHECK_DESCRIPTION:     checkDescription,
+		pb.SCHEMA_CHECK_ERROR_RESPONSES: checkErrorResponses,
+		pb.SCHEMA_CHECK_VERSION_HEADER:  checkVersionHeader,
+	}
+
+	swaggerMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+	complianceServices = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "schema",
+			Name:      "compliance_services",
+			Help:      "Number of the services checked since startup by the schema compliance badge",
+		}, []string{"badge"})
+
+	complianceReports = &complianceCacher{reports: make(map[string]*pb.SchemaComplianceReport)}
+	complianceQueue   = NewComplianceQueue()
+)
+
+func init() {
+	prometheus.MustRegister(complianceServices)
+}
+
+// SchemaComplianceChecks 返回schema_compliance_checks配置的检查项，默认全部检查，无法识别的项忽略
+func SchemaComplianceChecks() []string {
+	v := beego.AppConfig.DefaultString("schema_compliance_checks", strings.Join([]string{
+		pb.SCHEMA_CHECK_DESCRIPTION, pb.SCHEMA_CHECK_ERROR_RESPONSES, pb.SCHEMA_CHECK_VERSION_HEADER}, ","))
+	var checks []string
+	for _, name := range strings.Split(v, ",") {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			continue
+		}
+		if _, ok := schemaChecks[name]; !ok {
+			util.Logger().Errorf(nil, "unknown schema compliance check '%s'", name)
+			continue
+		}
+		checks = append(checks, name)
+	}
+	return checks
+}
+
+func schemaComplianceVersionHeader() string {
+	return beego.AppConfig.DefaultString("schema_compliance_version_header", DEFAULT_SCHEMA_COMPLIANCE_VERSION_HEADER)
+}
+
+type swaggerOperation struct {
+	name      string
+	operation map[string]interface{}
+	// path上定义的公共参数
+	pathParameters []interface{}
+}
+
+type swaggerDoc struct {
+	root       map[string]interface{}
+	operations []swaggerOperation
+}
+
+func parseSwagger(content string) (*swaggerDoc, error) {
+	doc := &swaggerDoc{}
+	if err := yaml.Unmarshal(util.StringToBytesWithNoCopy(content), &doc.root); err != nil {
+		return nil, err
+	}
+	if doc.root == nil {
+		return nil, fmt.Errorf("schema must be an object")
+	}
+	paths, _ := doc.root["paths"].(map[string]interface{})
+	names := make([]string, 0, len(paths))
+	for path := range paths {
+		names = append(names, path)
+	}
+	sort.Strings(names)
+	for _, path := range names {
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		params, _ := item["parameters"].([]interface{})
+		for _, method := range swaggerMethods {
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			doc.operations = append(doc.operations, swaggerOperation{
+				name:           strings.ToUpper(method) + " " + path,
+				operation:      op,
+				pathParameters: params,
+			})
+		}
+	}
+	return doc, nil
+}
+
+func nonEmptyString(m map[string]interface{}, key string) bool {
+	s, _ := m[key].(string)
+	return len(strings.TrimSpace(s)) > 0
+}
+
+// checkDescription info和每个操作都有描述，操作的summary或description任一非空即可
+func checkDescription(doc *swaggerDoc, _ string) (violations []string) {
+	info, _ := doc.root["info"].(map[string]interface{})
+	if info == nil || !nonEmptyString(info, "description") {
+		violations = append(violations, "info")
+	}
+	for _, op := range doc.operations {
+		if !nonEmptyString(op.operation, "summary") && !nonEmptyString(op.operation, "description") {
+			violations = append(violations, op.name)
+		}
+	}
+	return
+}
+
+// checkErrorResponses 每个操作至少定义一个4xx、5xx或default响应
+func checkErrorResponses(doc *swaggerDoc, _ string) (violations []string) {
+	for _, op := range doc.operations {
+		responses, _ := op.operation["responses"].(map[string]interface{})
+		found := false
+		for code := range responses {
+			if code == "default" {
+				found = true
+				break
+			}
+			if n, err := strconv.Atoi(code); err == nil && n >= 400 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			violations = append(violations, op.name)
+		}
+	}
+	return
+}
+
+// resolveParameter 支持引用契约中parameters定义的公共参数
+func (doc *swaggerDoc) resolveParameter(p interface{}) map[string]interface{} {
+	param, _ := p.(map[string]interface{})
+	ref, ok := param["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, "#/parameters/") {
+		return param
+	}
+	params, _ := doc.root["parameters"].(map[string]interface{})
+	param, _ = params[ref[len("#/parameters/"):]].(map[string]interface{})
+	return param
+}
+
+// checkVersionHeader 每个操作都声明了版本header参数，header名不区分大小写
+func checkVersionHeader(doc *swaggerDoc, header string) (violations []string) {
+	for _, op := range doc.operations {
+		params, _ := op.operation["parameters"].([]interface{})
+		found := false
+		for _, p := range append(append([]interface{}(nil), params...), op.pathParameters...) {
+			param := doc.resolveParameter(p)
+			in, _ := param["in"].(string)
+			name, _ := param["name"].(string)
+			if in == "header" && strings.EqualFold(name, header) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			violations = append(violations, op.name)
+		}
+	}
+	return
+}
+
+// CheckSchemaCompliance 对契约内容执行检查，内容无法解析时所有检查项都不通过
+func CheckSchemaCompliance(schemaId, content string, checks []string, header string) *pb.SchemaCompliance {
+	result := &pb.SchemaCompliance{SchemaId: schemaId, Passed: true}
+	doc, err := parseSwagger(content)
+	for _, name := range checks {
+		check := &pb.SchemaComplianceCheck{Name: name}
+		if err != nil {
+			check.Violations = []string{"invalid schema: " + err.Error()}
+		} else {
+			check.Violations = schemaChecks[name](doc, header)
+		}
+		if len(check.Violations) > MAX_COMPLIANCE_VIOLATIONS {
+			check.Violations = check.Violations[:MAX_COMPLIANCE_VIOLATIONS]
+		}
+		check.Passed = len(check.Violations) == 0
+		result.Passed = result.Passed && check.Passed
+		result.Checks = append(result.Checks, check)
+	}
+	return result
+}
+
+// ComplianceBadge 按通过的检查项比例计算徽章，没有契约或检查项时为unknown
+func ComplianceBadge(schemas []*pb.SchemaCompliance) (string, float64) {
+	var total, passed int
+	for _, schema := range schemas {
+		for _, check := range schema.Checks {
+			total++
+			if check.Passed {
+				passed++
+			}
+		}
+	}
+	switch {
+	case total == 0:
+		return pb.COMPLIANCE_BADGE_UNKNOWN, 0
+	case passed == total:
+		return pb.COMPLIANCE_BADGE_PASSING, 1
+	case passed == 0:
+		return pb.COMPLIANCE_BADGE_FAILING, 0
+	default:
+		return pb.COMPLIANCE_BADGE_PARTIAL, float64(passed) / float64(total)
+	}
+}
+
+func searchSchemas(ctx context.Context, domainProject, serviceId string, opts ...registry.PluginOpOption) (*registry.PluginResponse, int64, error) {
+	key := apt.GenerateServiceSchemaKey(domainProject, serviceId, "")
+	opts = append(opts, registry.WithStrKey(key), registry.WithPrefix())
+	resp, err := store.Store().Schema().Search(ctx, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	var rev int64
+	for _, kv := range resp.Kvs {
+		if kv.ModRevision > rev {
+			rev = kv.ModRevision
+		}
+	}
+	return resp, rev, nil
+}
+
+// BuildComplianceReport 检查服务的所有契约，revision为契约的最大修改版本
+func BuildComplianceReport(ctx context.Context, domainProject, serviceId string) (*pb.SchemaComplianceReport, error) {
+	resp, rev, err := searchSchemas(ctx, domainProject, serviceId)
+	if err != nil {
+		return nil, err
+	}
+	checks := SchemaComplianceChecks()
+	header := schemaComplianceVersionHeader()
+	report := &pb.SchemaComplianceReport{
+		ServiceId: serviceId,
+		Checks:    checks,
+		Schemas:   make([]*pb.SchemaCompliance, 0, len(resp.Kvs)),
+		Revision:  rev,
+		Timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+	}
+	for _, kv := range resp.Kvs {
+		schemaId, data := pb.GetInfoFromSchemaKV(kv)
+		report.Schemas = append(report.Schemas,
+			CheckSchemaCompliance(schemaId, util.BytesToStringWithNoCopy(data), checks, header))
+	}
+	sort.Slice(report.Schemas, func(i, j int) bool {
+		return report.Schemas[i].SchemaId < report.Schemas[j].SchemaId
+	})
+	report.Badge, report.Score = ComplianceBadge(report.Schemas)
+	return report, nil
+}
+
+// complianceCacher 缓存服务的合规报告，契约变化事件到达前读取时按契约revision校验
+type complianceCacher struct {
+	lock    sync.RWMutex
+	reports map[string]*pb.SchemaComplianceReport
+}
+
+func (c *complianceCacher) Get(key string) *pb.SchemaComplianceReport {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.reports[key]
+}
+
+func (c *complianceCacher) Set(key string, report *pb.SchemaComplianceReport) {
+	c.lock.Lock()
+	if report == nil {
+		delete(c.reports, key)
+	} else {
+		c.reports[key] = report
+	}
+	c.refreshMetrics()
+	c.lock.Unlock()
+}
+
+// refreshMetrics 按徽章统计已计算过的服务数，调用方持有锁
+func (c *complianceCacher) refreshMetrics() {
+	counts := map[string]float64{
+		pb.COMPLIANCE_BADGE_PASSING: 0,
+		pb.COMPLIANCE_BADGE_PARTIAL: 0,
+		pb.COMPLIANCE_BADGE_FAILING: 0,
+		pb.COMPLIANCE_BADGE_UNKNOWN: 0,
+	}
+	for _, report := range c.reports {
+		counts[report.Badge]++
+	}
+	for badge, n := range counts {
+		complianceServices.WithLabelValues(badge).Set(n)
+	}
+}
+
+func complianceKey(domainProject, serviceId string) string {
+	return util.StringJoin([]string{domainProject, serviceId}, "/")
+}
+
+// GetComplianceReport 优先返回缓存的报告，契约revision变化或不使用缓存时重新计算
+func GetComplianceReport(ctx context.Context, domainProject, serviceId string) (*pb.SchemaComplianceReport, error) {
+	key := complianceKey(domainProject, serviceId)
+	if ctx.Value("noCache") != "1" {
+		if report := complianceReports.Get(key); report != nil {
+			_, rev, err := searchSchemas(ctx, domainProject, serviceId, registry.WithKeyOnly())
+			if err != nil {
+				return nil, err
+			}
+			if rev == report.Revision {
+				return report, nil
+			}
+		}
+	}
+	report, err := BuildComplianceReport(ctx, domainProject, serviceId)
+	if err != nil {
+		return nil, err
+	}
+	complianceReports.Set(key, report)
+	return report, nil
+}
+
+type complianceTask struct {
+	domainProject string
+	serviceId     string
+	deleted       bool
+}
+
+// ComplianceQueue 契约或服务变化后延迟Delay重新计算合规报告，同一服务的多次变化合并为一次
+type ComplianceQueue struct {
+	Delay time.Duration
+
+	lock    sync.Mutex
+	pending map[string]complianceTask
+	notify  chan struct{}
+}
+
+func NewComplianceQueue() *ComplianceQueue {
+	return &ComplianceQueue{
+		Delay:   DEFAULT_SCHEMA_COMPLIANCE_DELAY,
+		pending: make(map[string]complianceTask),
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+func (q *ComplianceQueue) Add(task complianceTask) {
+	q.lock.Lock()
+	q.pending[complianceKey(task.domainProject, task.serviceId)] = task
+	q.lock.Unlock()
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (q *ComplianceQueue) take() map[string]complianceTask {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	tasks := q.pending
+	q.pending = make(map[string]complianceTask)
+	return tasks
+}
+
+func (q *ComplianceQueue) Run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-q.notify:
+		}
+
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(q.Delay):
+		}
+
+		for key, task := range q.take() {
+			if task.deleted {
+				complianceReports.Set(key, nil)
+				continue
+			}
+			report, err := BuildComplianceReport(context.Background(), task.domainProject, task.serviceId)
+			if err != nil {
+				util.Logger().Errorf(err, "check schema compliance of service %s failed", task.serviceId)
+				continue
+			}
+			complianceReports.Set(key, report)
+		}
+	}
+}
+
+// NotifySchemaChanged 服务的契约变化时调用，异步重新计算合规报告
+func NotifySchemaChanged(domainProject, serviceId string) {
+	complianceQueue.Add(complianceTask{domainProject: domainProject, serviceId: serviceId})
+}
+
+// NotifyServiceDeleted 服务删除时清除合规报告
+func NotifyServiceDeleted(domainProject, serviceId string) {
+	complianceQueue.Add(complianceTask{domainProject: domainProject, serviceId: serviceId, deleted: true})
+}
+
+func RunSchemaCompliance() {
+	util.Go(complianceQueue.Run)
+}
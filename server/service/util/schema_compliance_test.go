@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"reflect"
+	"testing"
+)
+
+const complianceSchema = `
+swagger: "2.0"
+info:
+  title: hello
+  description: hello service
+parameters:
+  version:
+    name: X-Api-Version
+    in: header
+    type: string
+paths:
+  /hello:
+    parameters:
+      - $ref: "#/parameters/version"
+    get:
+      summary: say hello
+      responses:
+        200:
+          description: ok
+        500:
+          description: error
+  /bye:
+    post:
+      responses:
+        default:
+          description: error
+    delete:
+      description: bye
+      parameters:
+        - name: x-api-version
+          in: header
+          type: string
+      responses:
+        200:
+          description: ok
+`
+
+func TestCheckSchemaCompliance(t *testing.T) {
+	checks := []string{pb.SCHEMA_CHECK_DESCRIPTION, pb.SCHEMA_CHECK_ERROR_RESPONSES, pb.SCHEMA_CHECK_VERSION_HEADER}
+	result := serviceUtil.CheckSchemaCompliance("hello", complianceSchema, checks, "x-api-version")
+	if result.Passed || len(result.Checks) != 3 {
+		fmt.Printf(`CheckSchemaCompliance failed, %v`, result)
+		t.FailNow()
+	}
+	expected := map[string][]string{
+		pb.SCHEMA_CHECK_DESCRIPTION:     {"POST /bye"},
+		pb.SCHEMA_CHECK_ERROR_RESPONSES: {"DELETE /bye"},
+		pb.SCHEMA_CHECK_VERSION_HEADER:  {"POST /bye"},
+	}
+	for _, check := range result.Checks {
+		if check.Passed || !reflect.DeepEqual(check.Violations, expected[check.Name]) {
+			fmt.Printf(`CheckSchemaCompliance %s failed, %v`, check.Name, check.Violations)
+			t.FailNow()
+		}
+	}
+
+	result = serviceUtil.CheckSchemaCompliance("hello", "- a", checks, "x-api-version")
+	if result.Passed || len(result.Checks[0].Violations) != 1 {
+		fmt.Printf(`CheckSchemaCompliance invalid schema failed, %v`, result)
+		t.FailNow()
+	}
+}
+
+func TestComplianceBadge(t *testing.T) {
+	passed := &pb.SchemaComplianceCheck{Passed: true}
+	failed := &pb.SchemaComplianceCheck{}
+	cases := []struct {
+		checks []*pb.SchemaComplianceCheck
+		badge  string
+		score  float64
+	}{
+		{nil, pb.COMPLIANCE_BADGE_UNKNOWN, 0},
+		{[]*pb.SchemaComplianceCheck{passed, passed}, pb.COMPLIANCE_BADGE_PASSING, 1},
+		{[]*pb.SchemaComplianceCheck{passed, failed, failed, failed}, pb.COMPLIANCE_BADGE_PARTIAL, 0.25},
+		{[]*pb.SchemaComplianceCheck{failed}, pb.COMPLIANCE_BADGE_FAILING, 0},
+	}
+	for _, c := range cases {
+		badge, score := serviceUtil.ComplianceBadge([]*pb.SchemaCompliance{{Checks: c.checks}})
+		if badge != c.badge || score != c.score {
+			fmt.Printf(`ComplianceBadge failed, %s %f`, badge, score)
+			t.FailNow()
+		}
+	}
+}
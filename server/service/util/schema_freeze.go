@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"golang.org/x/net/context"
+)
+
+// SchemaFreezeOperator 冻结操作人，通过API密钥鉴权时为keyId，否则为请求来源地址
+func SchemaFreezeOperator(ctx context.Context) string {
+	if keyId, ok := ctx.Value(CTX_API_KEY).(string); ok && len(keyId) > 0 {
+		return "apikey:" + keyId
+	}
+	return util.GetIPFromContext(ctx)
+}
+
+// CheckSchemaFreeze 服务契约冻结时返回ErrSchemaFrozen
+func CheckSchemaFreeze(service *pb.MicroService) *scerr.Error {
+	if service == nil || service.SchemaFreeze == nil {
+		return nil
+	}
+	freeze := service.SchemaFreeze
+	detail := fmt.Sprintf("Schemas of service %s are frozen by %s.", service.ServiceId, freeze.Operator)
+	if len(freeze.Reason) > 0 {
+		detail = fmt.Sprintf("Schemas of service %s are frozen by %s: %s.", service.ServiceId, freeze.Operator, freeze.Reason)
+	}
+	return scerr.NewError(scerr.ErrSchemaFrozen, detail)
+}
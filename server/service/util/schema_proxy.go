@@ -0,0 +1,204 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"errors"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"golang.org/x/net/context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// 从外部契约源获取的契约大小上限
+	SCHEMA_PROXY_MAX_SIZE = 4 * 1024 * 1024
+	// 缓存的契约数上限，超出时先清理过期的契约
+	SCHEMA_PROXY_MAX_ENTRIES = 1000
+)
+
+// SchemaFetcher 按地址获取契约内容，按地址的scheme注册
+type SchemaFetcher func(ctx context.Context, u *url.URL) ([]byte, error)
+
+var (
+	schemaFetchers = map[string]SchemaFetcher{
+		"http":  fetchSchemaByHTTP,
+		"https": fetchSchemaByHTTP,
+		"s3":    fetchSchemaFromS3,
+	}
+	schemaProxyCache = &schemaCache{entries: make(map[string]*schemaCacheEntry)}
+)
+
+// RegisterSchemaFetcher 注册其它类型的契约源，如git，需在启动时调用
+func RegisterSchemaFetcher(scheme string, f SchemaFetcher) {
+	schemaFetchers[scheme] = f
+}
+
+type schemaCacheEntry struct {
+	source  string
+	content string
+	expire  time.Time
+}
+
+type schemaCache struct {
+	lock    sync.RWMutex
+	entries map[string]*schemaCacheEntry
+}
+
+func (c *schemaCache) Get(key, source string) (string, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	e, ok := c.entries[key]
+	if !ok || e.source != source || time.Now().After(e.expire) {
+		return "", false
+	}
+	return e.content, true
+}
+
+func (c *schemaCache) Set(key, source, content string, ttl time.Duration) {
+	now := time.Now()
+	c.lock.Lock()
+	if len(c.entries) >= SCHEMA_PROXY_MAX_ENTRIES {
+		for k, e := range c.entries {
+			if now.After(e.expire) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	if len(c.entries) < SCHEMA_PROXY_MAX_ENTRIES {
+		c.entries[key] = &schemaCacheEntry{source: source, content: content, expire: now.Add(ttl)}
+	}
+	c.lock.Unlock()
+}
+
+// SchemaSourceURL 以契约ID替换契约源中的{schemaId}
+func SchemaSourceURL(source, schemaId string) (*url.URL, error) {
+	u, err := url.Parse(strings.Replace(source, "{schemaId}", url.PathEscape(schemaId), -1))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := schemaFetchers[u.Scheme]; !ok {
+		return nil, fmt.Errorf("unsupported schema source '%s'", u.Scheme)
+	}
+	if len(u.Host) == 0 {
+		return nil, fmt.Errorf("invalid schema source '%s'", source)
+	}
+	return u, nil
+}
+
+// schemaSourceAllowed s3的host为桶名
+func schemaSourceAllowed(host string) bool {
+	allowed := strings.TrimSpace(apt.ServerInfo.Config.SchemaProxyAllowedHosts)
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, h := range strings.Split(allowed, ",") {
+		if strings.TrimSpace(h) == host {
+			return true
+		}
+	}
+	return false
+}
+
+// FetchSchemaFromSource 契约已声明但未上传时，从服务的schemaSource属性指定的外部契约源获取，
+// 未开启或未配置契约源时返回false
+func FetchSchemaFromSource(ctx context.Context, domainProject string, service *pb.MicroService, schemaId string) (string, bool, error) {
+	cfg := apt.ServerInfo.Config
+	source := service.Properties[pb.PROP_SCHEMA_SOURCE]
+	if !cfg.SchemaProxyEnabled || len(source) == 0 {
+		return "", false, nil
+	}
+	declared := false
+	for _, id := range service.Schemas {
+		if id == schemaId {
+			declared = true
+			break
+		}
+	}
+	if !declared {
+		return "", false, nil
+	}
+
+	key := util.StringJoin([]string{domainProject, service.ServiceId, schemaId}, "/")
+	if content, ok := schemaProxyCache.Get(key, source); ok {
+		return content, true, nil
+	}
+
+	u, err := SchemaSourceURL(source, schemaId)
+	if err != nil {
+		return "", true, err
+	}
+	if !schemaSourceAllowed(u.Host) {
+		return "", true, fmt.Errorf("schema source host '%s' is not allowed", u.Host)
+	}
+	timeout, err := time.ParseDuration(cfg.SchemaProxyTimeout)
+	if err != nil || timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	data, err := schemaFetchers[u.Scheme](ctx, u)
+	if err != nil {
+		return "", true, err
+	}
+	content := util.BytesToStringWithNoCopy(data)
+
+	if ttl, err := time.ParseDuration(cfg.SchemaProxyCacheTTL); err == nil && ttl > 0 {
+		schemaProxyCache.Set(key, source, content, ttl)
+	}
+	return content, true, nil
+}
+
+func fetchSchemaByHTTP(ctx context.Context, u *url.URL) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch schema from %s returns %d", u.Host, resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, SCHEMA_PROXY_MAX_SIZE+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 || len(data) > SCHEMA_PROXY_MAX_SIZE {
+		return nil, errors.New("schema is empty or too large")
+	}
+	return data, nil
+}
+
+// fetchSchemaFromS3 s3://bucket/key按virtual-hosted形式读取，仅支持公开读的桶
+func fetchSchemaFromS3(ctx context.Context, u *url.URL) ([]byte, error) {
+	return fetchSchemaByHTTP(ctx, &url.URL{
+		Scheme: "https",
+		Host:   u.Host + ".s3.amazonaws.com",
+		Path:   u.Path,
+	})
+}
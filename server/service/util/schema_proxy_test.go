@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func TestSchemaSourceURL(t *testing.T) {
+	u, err := serviceUtil.SchemaSourceURL("https://repo.example.com/contracts/{schemaId}.yaml", "a b")
+	if err != nil || u.Host != "repo.example.com" || u.Path != "/contracts/a b.yaml" {
+		fmt.Printf(`SchemaSourceURL failed, %v`, u)
+		t.FailNow()
+	}
+
+	u, err = serviceUtil.SchemaSourceURL("s3://bucket/contracts/{schemaId}.yaml", "hello")
+	if err != nil || u.Host != "bucket" || u.Path != "/contracts/hello.yaml" {
+		fmt.Printf(`SchemaSourceURL s3 failed, %v`, u)
+		t.FailNow()
+	}
+
+	for _, source := range []string{"ftp://host/{schemaId}", "https:///{schemaId}", "{schemaId}"} {
+		if _, err := serviceUtil.SchemaSourceURL(source, "hello"); err == nil {
+			fmt.Printf(`SchemaSourceURL %s should fail`, source)
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"golang.org/x/net/context"
+	"strings"
+)
+
+// SchemaVisibility 返回服务properties中配置的契约可见范围，未配置时租户内可见，
+// 无法识别的配置按最严格的owners处理
+func SchemaVisibility(service *pb.MicroService) string {
+	if service == nil || service.Properties == nil {
+		return pb.SCHEMA_VISIBILITY_TENANT
+	}
+	v := strings.ToLower(strings.TrimSpace(service.Properties[pb.PROP_SCHEMA_VISIBILITY]))
+	switch v {
+	case "", pb.SCHEMA_VISIBILITY_TENANT:
+		return pb.SCHEMA_VISIBILITY_TENANT
+	case pb.SCHEMA_VISIBILITY_CONSUMERS:
+		return pb.SCHEMA_VISIBILITY_CONSUMERS
+	default:
+		return pb.SCHEMA_VISIBILITY_OWNERS
+	}
+}
+
+// isSchemaOwner 服务自身及同一服务的其他版本
+func isSchemaOwner(provider, consumer *pb.MicroService) bool {
+	if consumer.ServiceId == provider.ServiceId {
+		return true
+	}
+	return consumer.Environment == provider.Environment &&
+		consumer.AppId == provider.AppId &&
+		consumer.ServiceName == provider.ServiceName
+}
+
+// CanReadSchema 检查consumerId对应的服务能否读取provider的契约，
+// consumers范围内可读的服务为依赖规则中声明依赖provider的消费者
+func CanReadSchema(ctx context.Context, domainProject string, provider *pb.MicroService, consumerId string) (bool, error) {
+	visibility := SchemaVisibility(provider)
+	if visibility == pb.SCHEMA_VISIBILITY_TENANT {
+		return true, nil
+	}
+	if len(consumerId) == 0 {
+		return false, nil
+	}
+	consumer, err := GetService(ctx, domainProject, consumerId)
+	if err != nil {
+		return false, err
+	}
+	if consumer == nil {
+		return false, nil
+	}
+	if isSchemaOwner(provider, consumer) {
+		return true, nil
+	}
+	if visibility != pb.SCHEMA_VISIBILITY_CONSUMERS {
+		return false, nil
+	}
+
+	dr := NewProviderDependencyRelation(ctx, domainProject, provider.ServiceId, provider)
+	consumerIds, err := dr.GetDependencyConsumerIds()
+	if err != nil {
+		util.Logger().Errorf(err, "get consumers of provider %s failed", provider.ServiceId)
+		return false, err
+	}
+	for _, id := range consumerIds {
+		if id == consumerId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,45 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func TestSchemaVisibility(t *testing.T) {
+	cases := map[string]string{
+		"":            pb.SCHEMA_VISIBILITY_TENANT,
+		"tenant":      pb.SCHEMA_VISIBILITY_TENANT,
+		" Consumers ": pb.SCHEMA_VISIBILITY_CONSUMERS,
+		"owners":      pb.SCHEMA_VISIBILITY_OWNERS,
+		"private":     pb.SCHEMA_VISIBILITY_OWNERS,
+	}
+	for v, expected := range cases {
+		service := &pb.MicroService{Properties: map[string]string{pb.PROP_SCHEMA_VISIBILITY: v}}
+		if serviceUtil.SchemaVisibility(service) != expected {
+			fmt.Printf(`SchemaVisibility '%s' failed`, v)
+			t.FailNow()
+		}
+	}
+	if serviceUtil.SchemaVisibility(&pb.MicroService{}) != pb.SCHEMA_VISIBILITY_TENANT {
+		fmt.Printf(`SchemaVisibility without properties failed`)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,231 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/mux"
+	"github.com/apache/incubator-servicecomb-service-center/server/scheduler"
+	"golang.org/x/net/context"
+	"strconv"
+	"time"
+)
+
+const (
+	DEFAULT_CLEANUP_INTERVAL = 5 * time.Minute
+	// 未超过该时间的日志可能属于其它节点正在进行的删除
+	DEFAULT_CLEANUP_DELAY = time.Minute
+)
+
+// DeleteJournal 服务删除的清理日志，关联数据全部清理后才删除
+type DeleteJournal struct {
+	DomainProject string           `json:"domainProject"`
+	Service       *pb.MicroService `json:"service"`
+	Timestamp     string           `json:"timestamp"`
+}
+
+func toServiceKey(domainProject string, service *pb.MicroService) *pb.MicroServiceKey {
+	key := pb.MicroServiceToKey(domainProject, service)
+	key.Alias = service.Alias
+	return key
+}
+
+// UnregisterService 在同一事务中删除服务文件、索引、别名、名称索引并写入清理日志，
+// 此后服务不可见，关联数据由CleanupService清理
+func UnregisterService(ctx context.Context, domainProject string, service *pb.MicroService) error {
+	journal, err := json.Marshal(&DeleteJournal{
+		DomainProject: domainProject,
+		Service:       service,
+		Timestamp:     strconv.FormatInt(time.Now().Unix(), 10),
+	})
+	if err != nil {
+		return err
+	}
+
+	serviceKey := toServiceKey(domainProject, service)
+	opts := []registry.PluginOp{
+		registry.OpPut(registry.WithStrKey(apt.GenerateServiceDeleteJournalKey(domainProject, service.ServiceId)),
+			registry.WithValue(journal)),
+		registry.OpDel(registry.WithStrKey(apt.GenerateServiceIndexKey(serviceKey))),
+		registry.OpDel(registry.WithStrKey(apt.GenerateServiceAliasKey(serviceKey))),
+		registry.OpDel(registry.WithStrKey(apt.GenerateServiceNameIndexKey(domainProject,
+			service.ServiceName, service.ServiceId))),
+		registry.OpDel(registry.WithStrKey(apt.GenerateServiceKey(domainProject, service.ServiceId))),
+	}
+	_, err = backend.Registry().Txn(ctx, opts)
+	return err
+}
+
+// CleanupService 删除服务的依赖、黑白名单、schemas、tags、实例等关联数据，可重复执行
+func CleanupService(ctx context.Context, domainProject string, service *pb.MicroService) error {
+	serviceId := service.ServiceId
+	consumer := toServiceKey(domainProject, service)
+
+	//删除依赖规则
+	lock, err := mux.Lock(mux.GLOBAL_LOCK)
+	if err != nil {
+		util.Logger().Errorf(err, "cleanup service %s failed: create lock failed.", serviceId)
+		return err
+	}
+	opts, err := DeleteDependencyForService(ctx, consumer, serviceId)
+	lock.Unlock()
+	if err != nil {
+		util.Logger().Errorf(err, "cleanup service %s failed: delete dependency failed.", serviceId)
+		return err
+	}
+
+	//删除黑白名单
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(apt.GenerateServiceRuleKey(domainProject, serviceId, "")),
+		registry.WithPrefix()))
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(apt.GenerateRuleIndexKey(domainProject, serviceId, "", ""))))
+
+	//删除shemas
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(apt.GenerateServiceSchemaKey(domainProject, serviceId, "")),
+		registry.WithPrefix()))
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(apt.GenerateServiceSchemaSummaryKey(domainProject, serviceId, "")),
+		registry.WithPrefix()))
+
+	//删除tags
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(apt.GenerateServiceTagKey(domainProject, serviceId))))
+
+	//删除服务发现策略
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(apt.GenerateServicePolicyKey(domainProject, serviceId))))
+
+	//删除作为提供者的依赖审批
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(apt.GenerateDependencyApprovalKey(domainProject, serviceId, "")),
+		registry.WithPrefix()))
+
+	//删除代理授权和审计记录
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(apt.GenerateDelegationKey(domainProject, serviceId, "")),
+		registry.WithPrefix()))
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(apt.GenerateDelegationAuditKey(domainProject, serviceId, "")),
+		registry.WithPrefix()))
+
+	//删除作为消费者的发现记录
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(apt.GenerateDependencyUsageKey(domainProject, serviceId, "")),
+		registry.WithPrefix()))
+
+	//删除实例，租约在数据删除后再回收
+	leaseKey := apt.GenerateInstanceLeaseKey(domainProject, serviceId, "")
+	resp, err := store.Store().Lease().Search(ctx,
+		registry.WithStrKey(leaseKey),
+		registry.WithPrefix(),
+		registry.WithNoCache())
+	if err != nil {
+		util.Logger().Errorf(err, "cleanup service %s failed: get instance leases failed.", serviceId)
+		return err
+	}
+	staticOpts, err := cleanupStaticInstanceOps(ctx, domainProject, serviceId)
+	if err != nil {
+		util.Logger().Errorf(err, "cleanup service %s failed: get static instances failed.", serviceId)
+		return err
+	}
+	opts = append(opts, staticOpts...)
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(apt.GeneratePendingInstanceKey(domainProject, serviceId, "")),
+		registry.WithPrefix()))
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(apt.GenerateInstanceKey(domainProject, serviceId, "")),
+		registry.WithPrefix()))
+	opts = append(opts, registry.OpDel(
+		registry.WithStrKey(leaseKey),
+		registry.WithPrefix()))
+
+	err = backend.BatchCommit(ctx, opts)
+	if err != nil {
+		util.Logger().Errorf(err, "cleanup service %s failed: commit data into etcd failed.", serviceId)
+		return err
+	}
+
+	for _, kv := range resp.Kvs {
+		leaseID, _ := strconv.ParseInt(util.BytesToStringWithNoCopy(kv.Value), 10, 64)
+		if err := backend.Registry().LeaseRevoke(ctx, leaseID); err != nil {
+			util.Logger().Warnf(err, "cleanup service %s: revoke lease %d failed, wait for it expired.", serviceId, leaseID)
+		}
+	}
+
+	_, err = backend.Registry().Do(ctx,
+		registry.DEL,
+		registry.WithStrKey(apt.GenerateServiceDeleteJournalKey(domainProject, serviceId)))
+	if err != nil {
+		util.Logger().Errorf(err, "cleanup service %s failed: delete journal failed.", serviceId)
+		return err
+	}
+	return nil
+}
+
+// ResumeServiceCleanup 重新执行早于before的未完成清理，返回完成的个数
+func ResumeServiceCleanup(ctx context.Context, before time.Time) (int, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GetServiceDeleteJournalRootKey("")),
+		registry.WithPrefix())
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, kv := range resp.Kvs {
+		journal := &DeleteJournal{}
+		if err := json.Unmarshal(kv.Value, journal); err != nil || journal.Service == nil {
+			util.Logger().Errorf(err, "invalid delete journal %s, skip it", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		ts, _ := strconv.ParseInt(journal.Timestamp, 10, 64)
+		if time.Unix(ts, 0).After(before) {
+			continue
+		}
+		if err := CleanupService(ctx, journal.DomainProject, journal.Service); err != nil {
+			continue
+		}
+		util.Logger().Infof("resume cleanup of service %s/%s successfully",
+			journal.DomainProject, journal.Service.ServiceId)
+		n++
+	}
+	return n, nil
+}
+
+// RunServiceCleanup 周期性地恢复中断的服务删除，如删除过程中进程退出
+func RunServiceCleanup() {
+	scheduler.Register(&scheduler.Job{
+		Name:      "service_cleanup",
+		Priority:  scheduler.PRIORITY_HIGH,
+		Interval:  DEFAULT_CLEANUP_INTERVAL,
+		Immediate: true,
+		Singleton: true,
+		Func: func(ctx context.Context) error {
+			_, err := ResumeServiceCleanup(ctx, time.Now().Add(-DEFAULT_CLEANUP_DELAY))
+			return err
+		},
+	})
+}
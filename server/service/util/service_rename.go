@@ -0,0 +1,322 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	scerr "github.com/apache/incubator-servicecomb-service-center/server/error"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/apache/incubator-servicecomb-service-center/server/mux"
+	"golang.org/x/net/context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MIGRATE_KIND_SERVICE        = "service"
+	MIGRATE_KIND_RULE           = "rule"
+	MIGRATE_KIND_SHARED_SERVICE = "sharedService"
+
+	RULE_ATTR_SERVICE_NAME = "ServiceName"
+)
+
+// ServiceRename 修改服务的appId或serviceName，同一环境下该服务的所有版本一起改名，为空的字段不变
+type ServiceRename struct {
+	DomainProject string `json:"domainProject,omitempty"`
+	ServiceId     string `json:"serviceId"`
+	AppId         string `json:"appId,omitempty"`
+	ServiceName   string `json:"serviceName,omitempty"`
+
+	from *pb.MicroServiceKey
+	to   *pb.MicroServiceKey
+}
+
+type ServiceRenameResult struct {
+	DryRun     bool              `json:"dryRun"`
+	ServiceIds []string          `json:"serviceIds"`
+	Changes    []*pb.ApplyChange `json:"changes"`
+}
+
+func (m *ServiceRename) Check() error {
+	if len(m.ServiceId) == 0 {
+		return fmt.Errorf("serviceId is empty")
+	}
+	if len(m.AppId) == 0 && len(m.ServiceName) == 0 {
+		return fmt.Errorf("both of appId and serviceName are empty")
+	}
+	if len(m.AppId) > 0 && !apt.MicroServiceKeyValidator.GetRule("AppId").Match(m.AppId) {
+		return fmt.Errorf("invalid appId '%s'", m.AppId)
+	}
+	if len(m.ServiceName) > 0 && !apt.MicroServiceKeyValidator.GetRule("ServiceName").Match(m.ServiceName) {
+		return fmt.Errorf("invalid serviceName '%s'", m.ServiceName)
+	}
+	return nil
+}
+
+func envOf(env string) string {
+	if len(strings.TrimSpace(env)) == 0 {
+		return pb.ENV_DEV
+	}
+	return env
+}
+
+// migrateKey 改写依赖规则中引用旧名称的服务，版本规则不变
+func (m *ServiceRename) migrateKey(in *pb.MicroServiceKey) (*pb.MicroServiceKey, bool) {
+	if envOf(in.Environment) != envOf(m.from.Environment) ||
+		in.AppId != m.from.AppId || in.ServiceName != m.from.ServiceName {
+		return in, false
+	}
+	out := *in
+	out.AppId, out.ServiceName = m.to.AppId, m.to.ServiceName
+	return &out, true
+}
+
+// renameOps 在一个事务中完成改名的所有变更，目标名称已被使用时事务不执行
+type renameOps struct {
+	ops     []registry.PluginOp
+	cmps    []registry.CompareOp
+	changes []*pb.ApplyChange
+}
+
+func (r *renameOps) put(key string, value []byte) {
+	r.ops = append(r.ops, registry.OpPut(registry.WithStrKey(key), registry.WithValue(value)))
+}
+
+// move 删除旧key并写入新key，新key必须不存在
+func (r *renameOps) move(from, to string, value []byte) {
+	r.ops = append(r.ops,
+		registry.OpDel(registry.WithStrKey(from)),
+		registry.OpPut(registry.WithStrKey(to), registry.WithValue(value)))
+	r.cmps = append(r.cmps, registry.OpCmp(registry.CmpVer(util.StringToBytesWithNoCopy(to)), registry.CMP_EQUAL, 0))
+}
+
+func (r *renameOps) change(kind, action, name string) {
+	r.changes = append(r.changes, &pb.ApplyChange{Kind: kind, Action: action, Name: name})
+}
+
+// RenameService 在一个事务中修改服务的所有版本及其索引、别名、名称索引，改写引用旧serviceName的黑白名单、
+// 依赖规则和共享服务标记；tags、schemas、实例和依赖关系按serviceId保存，不受影响。
+// 旧名称在事务提交后立即失效，仍按旧名称发现的消费者需要更新配置
+func RenameService(ctx context.Context, domainProject string, m *ServiceRename, dryRun bool) (*ServiceRenameResult, *scerr.Error) {
+	if err := m.Check(); err != nil {
+		return nil, scerr.NewError(scerr.ErrInvalidParams, err.Error())
+	}
+
+	lock, err := mux.Lock(mux.GLOBAL_LOCK)
+	if err != nil {
+		return nil, scerr.NewError(mux.ErrorCode(err), err.Error())
+	}
+	defer lock.Unlock()
+
+	ctx = util.SetContext(util.CloneContext(ctx), "noCache", "1")
+	service, err := GetService(ctx, domainProject, m.ServiceId)
+	if err != nil {
+		return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+	}
+	if service == nil {
+		return nil, scerr.NewError(scerr.ErrServiceNotExists, "Service does not exist.")
+	}
+	m.from = &pb.MicroServiceKey{
+		Tenant:      domainProject,
+		Environment: service.Environment,
+		AppId:       service.AppId,
+		ServiceName: service.ServiceName,
+	}
+	to := *m.from
+	if len(m.AppId) > 0 {
+		to.AppId = m.AppId
+	}
+	if len(m.ServiceName) > 0 {
+		to.ServiceName = m.ServiceName
+	}
+	m.to = &to
+	if m.to.AppId == m.from.AppId && m.to.ServiceName == m.from.ServiceName {
+		return nil, scerr.NewError(scerr.ErrInvalidParams, "The service name is not changed.")
+	}
+	if err := CheckServiceRenaming(ctx, domainProject, service, m.to.AppId, m.to.ServiceName); err != nil {
+		return nil, err
+	}
+
+	r := &renameOps{changes: []*pb.ApplyChange{}}
+	result := &ServiceRenameResult{DryRun: dryRun, ServiceIds: []string{}}
+	if err := r.renameServices(ctx, domainProject, m, result); err != nil {
+		return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+	}
+	if m.to.ServiceName != m.from.ServiceName {
+		if err := r.renameRules(ctx, domainProject, m); err != nil {
+			return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+		}
+	}
+	if err := r.renameDependencyRules(ctx, domainProject, m); err != nil {
+		return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+	}
+	if err := r.renameSharedService(ctx, domainProject, m); err != nil {
+		return nil, scerr.NewError(scerr.ErrInternal, err.Error())
+	}
+	result.Changes = r.changes
+
+	if len(r.ops) > backend.MAX_TXN_NUMBER_ONE_TIME {
+		return nil, scerr.NewError(scerr.ErrInvalidParams,
+			fmt.Sprintf("Too many keys(%d) to rename in one transaction, max is %d.",
+				len(r.ops), backend.MAX_TXN_NUMBER_ONE_TIME))
+	}
+	if dryRun {
+		return result, nil
+	}
+	resp, err := backend.Registry().TxnWithCmp(ctx, r.ops, r.cmps, nil)
+	if err != nil {
+		return nil, scerr.NewError(scerr.ErrUnavailableBackend, err.Error())
+	}
+	if !resp.Succeeded {
+		return nil, scerr.NewError(scerr.ErrServiceAlreadyExists, "The target service name is already in use.")
+	}
+	dependencyWriter.Reset()
+	sharedServiceCache.Invalidate()
+	return result, nil
+}
+
+func (r *renameOps) renameServices(ctx context.Context, domainProject string, m *ServiceRename, result *ServiceRenameResult) error {
+	prefix := *m.from
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateServiceIndexKey(&prefix)),
+		registry.WithPrefix())
+	if err != nil {
+		return err
+	}
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	for _, kv := range resp.Kvs {
+		serviceId := util.BytesToStringWithNoCopy(kv.Value)
+		service, err := GetService(ctx, domainProject, serviceId)
+		if err != nil {
+			return err
+		}
+		if service == nil {
+			continue
+		}
+		oldKey := toServiceKey(domainProject, service)
+		service.AppId, service.ServiceName = m.to.AppId, m.to.ServiceName
+		service.ModTimestamp = now
+		newKey := toServiceKey(domainProject, service)
+		data, err := json.Marshal(service)
+		if err != nil {
+			return err
+		}
+
+		r.put(apt.GenerateServiceKey(domainProject, serviceId), data)
+		r.move(apt.GenerateServiceIndexKey(oldKey), apt.GenerateServiceIndexKey(newKey),
+			kv.Value)
+		if len(service.Alias) > 0 && oldKey.AppId != newKey.AppId {
+			r.move(apt.GenerateServiceAliasKey(oldKey), apt.GenerateServiceAliasKey(newKey),
+				kv.Value)
+		}
+		if oldKey.ServiceName != newKey.ServiceName {
+			r.move(apt.GenerateServiceNameIndexKey(domainProject, oldKey.ServiceName, serviceId),
+				apt.GenerateServiceNameIndexKey(domainProject, newKey.ServiceName, serviceId),
+				kv.Value)
+		}
+		r.change(MIGRATE_KIND_SERVICE, MIGRATE_ACTION_UPDATE, serviceId)
+		result.ServiceIds = append(result.ServiceIds, serviceId)
+	}
+	return nil
+}
+
+// renameRules 改写按serviceName精确匹配旧名称的黑白名单，正则模式不改写
+func (r *renameOps) renameRules(ctx context.Context, domainProject string, m *ServiceRename) error {
+	prefix := apt.GetServiceRuleRootKey(domainProject) + "/"
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(prefix),
+		registry.WithPrefix())
+	if err != nil {
+		return err
+	}
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	for _, kv := range resp.Kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		// {serviceId}/{ruleId}
+		arr := strings.Split(key[len(prefix):], "/")
+		if len(arr) != 2 {
+			continue
+		}
+		rule := &pb.ServiceRule{}
+		if err := json.Unmarshal(kv.Value, rule); err != nil {
+			util.Logger().Errorf(err, "invalid rule %s, skip it", key)
+			continue
+		}
+		if rule.Attribute != RULE_ATTR_SERVICE_NAME || rule.Pattern != m.from.ServiceName {
+			continue
+		}
+		rule.Pattern, rule.ModTimestamp = m.to.ServiceName, now
+		data, err := json.Marshal(rule)
+		if err != nil {
+			return err
+		}
+		r.put(key, data)
+		r.move(apt.GenerateRuleIndexKey(domainProject, arr[0], rule.Attribute, m.from.ServiceName),
+			apt.GenerateRuleIndexKey(domainProject, arr[0], rule.Attribute, rule.Pattern),
+			util.StringToBytesWithNoCopy(rule.RuleId))
+		r.change(MIGRATE_KIND_RULE, MIGRATE_ACTION_UPDATE, arr[0]+"/"+arr[1])
+	}
+	return nil
+}
+
+func (r *renameOps) renameDependencyRules(ctx context.Context, domainProject string, m *ServiceRename) error {
+	stage, conKeys, err := loadDependencyRules(ctx, apt.GetServiceDependencyRuleRootKey(domainProject)+"/")
+	if err != nil {
+		return err
+	}
+	for _, conKey := range conKeys {
+		stage.migrateConsumer(conKey, m.migrateKey)
+	}
+	ops, changes, err := stage.ops()
+	if err != nil {
+		return err
+	}
+	r.ops = append(r.ops, ops...)
+	r.changes = append(r.changes, changes...)
+	return nil
+}
+
+// renameSharedService 共享服务标记按appId/serviceName保存，由本租户共享时一起改名
+func (r *renameOps) renameSharedService(ctx context.Context, domainProject string, m *ServiceRename) error {
+	key := apt.GenerateSharedServiceKey(m.from.AppId, m.from.ServiceName)
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(key))
+	if err != nil || len(resp.Kvs) == 0 {
+		return err
+	}
+	s := &SharedService{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, s); err != nil || s.DomainProject() != domainProject {
+		return nil
+	}
+	s.AppId, s.ServiceName = m.to.AppId, m.to.ServiceName
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	r.move(key, apt.GenerateSharedServiceKey(s.AppId, s.ServiceName), data)
+	r.change(MIGRATE_KIND_SHARED_SERVICE, MIGRATE_ACTION_UPDATE, s.AppId+"/"+s.ServiceName)
+	return nil
+}
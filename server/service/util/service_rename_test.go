@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"testing"
+)
+
+func TestServiceRenameCheck(t *testing.T) {
+	for i, m := range []*ServiceRename{
+		{},
+		{ServiceId: "1"},
+		{ServiceId: "1", AppId: "a b"},
+		{ServiceId: "1", ServiceName: "@"},
+	} {
+		if err := m.Check(); err == nil {
+			t.Fatalf("case %d: Check failed", i)
+		}
+	}
+	if err := (&ServiceRename{ServiceId: "1", ServiceName: "b"}).Check(); err != nil {
+		t.Fatalf("Check failed, %s", err.Error())
+	}
+}
+
+func TestServiceRenameMigrateKey(t *testing.T) {
+	m := &ServiceRename{
+		from: &pb.MicroServiceKey{AppId: "a", ServiceName: "s"},
+		to:   &pb.MicroServiceKey{AppId: "b", ServiceName: "s"},
+	}
+	out, ok := m.migrateKey(&pb.MicroServiceKey{Environment: pb.ENV_DEV, AppId: "a", ServiceName: "s", Version: "1.0.0+"})
+	if !ok || out.AppId != "b" || out.ServiceName != "s" || out.Version != "1.0.0+" || out.Environment != pb.ENV_DEV {
+		t.Fatalf("migrateKey failed, %v", out)
+	}
+	for i, in := range []*pb.MicroServiceKey{
+		{Environment: pb.ENV_PROD, AppId: "a", ServiceName: "s"},
+		{AppId: "a", ServiceName: "x"},
+		{AppId: "x", ServiceName: "s"},
+	} {
+		if out, ok := m.migrateKey(in); ok || out != in {
+			t.Fatalf("case %d: migrateKey failed", i)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/ghodss/yaml"
+	"golang.org/x/net/context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SHARED_DEFINITION_REF_PREFIX = "shared:"
+	LOCAL_DEFINITION_REF_PREFIX  = "#/definitions/"
+)
+
+// SharedDefinitionNotFoundError 契约引用了不存在的共享定义
+type SharedDefinitionNotFoundError struct {
+	Name string
+}
+
+func (e *SharedDefinitionNotFoundError) Error() string {
+	return fmt.Sprintf("shared definition '%s' does not exist", e.Name)
+}
+
+func PutSharedDefinition(ctx context.Context, domainProject string, name, content string) error {
+	key := apt.GenerateSharedDefinitionKey(domainProject, name)
+	data, err := json.Marshal(&pb.SharedDefinition{
+		Name:         name,
+		Content:      content,
+		ModTimestamp: strconv.FormatInt(time.Now().Unix(), 10),
+	})
+	if err != nil {
+		util.Logger().Errorf(err, "put shared definition %s: json marshal failed.", key)
+		return err
+	}
+
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(key),
+		registry.WithValue(data))
+	if err != nil {
+		util.Logger().Errorf(err, "put shared definition %s: commit into etcd failed.", key)
+		return err
+	}
+	return nil
+}
+
+func GetSharedDefinition(ctx context.Context, domainProject string, name string) (*pb.SharedDefinition, error) {
+	definitions, err := searchSharedDefinitions(ctx, apt.GenerateSharedDefinitionKey(domainProject, name))
+	if err != nil || len(definitions) == 0 {
+		return nil, err
+	}
+	return definitions[0], nil
+}
+
+func GetSharedDefinitions(ctx context.Context, domainProject string) ([]*pb.SharedDefinition, error) {
+	return searchSharedDefinitions(ctx, apt.GenerateSharedDefinitionKey(domainProject, ""), registry.WithPrefix())
+}
+
+func searchSharedDefinitions(ctx context.Context, key string, opts ...registry.PluginOpOption) ([]*pb.SharedDefinition, error) {
+	opts = append(append(FromContext(ctx), registry.WithStrKey(key)), opts...)
+	resp, err := store.Store().SharedDefinition().Search(ctx, opts...)
+	if err != nil {
+		util.Logger().Errorf(err, "get shared definitions %s failed", key)
+		return nil, err
+	}
+
+	definitions := make([]*pb.SharedDefinition, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		definition := &pb.SharedDefinition{}
+		err = json.Unmarshal(kv.Value, definition)
+		if err != nil {
+			util.Logger().Errorf(err, "unmarshal shared definition %s failed", util.BytesToStringWithNoCopy(kv.Key))
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+// CheckSharedDefinition 共享定义的内容必须是JSON或YAML对象
+func CheckSharedDefinition(content string) error {
+	var definition map[string]interface{}
+	if err := yaml.Unmarshal(util.StringToBytesWithNoCopy(content), &definition); err != nil {
+		return err
+	}
+	if definition == nil {
+		return fmt.Errorf("shared definition must be an object")
+	}
+	return nil
+}
+
+type schemaBundler struct {
+	ctx           context.Context
+	domainProject string
+	definitions   map[string]interface{}
+}
+
+// resolve 将$ref: 'shared:<name>'替换为本地引用，并把共享定义(包括其引用的定义)合并到definitions
+func (b *schemaBundler) resolve(node interface{}) error {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := n["$ref"].(string); ok && strings.HasPrefix(ref, SHARED_DEFINITION_REF_PREFIX) {
+			name := ref[len(SHARED_DEFINITION_REF_PREFIX):]
+			n["$ref"] = LOCAL_DEFINITION_REF_PREFIX + name
+			return b.load(name)
+		}
+		for _, v := range n {
+			if err := b.resolve(v); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, v := range n {
+			if err := b.resolve(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *schemaBundler) load(name string) error {
+	if _, ok := b.definitions[name]; ok {
+		return nil
+	}
+	definition, err := GetSharedDefinition(b.ctx, b.domainProject, name)
+	if err != nil {
+		return err
+	}
+	if definition == nil {
+		return &SharedDefinitionNotFoundError{Name: name}
+	}
+	var content interface{}
+	if err := yaml.Unmarshal(util.StringToBytesWithNoCopy(definition.Content), &content); err != nil {
+		return fmt.Errorf("parse shared definition '%s' failed, %s", name, err.Error())
+	}
+	// 先占位，避免定义间的循环引用
+	b.definitions[name] = content
+	return b.resolve(content)
+}
+
+// BundleSchema 解析契约中引用的共享定义，返回自包含的契约，格式与原契约(JSON或YAML)一致
+func BundleSchema(ctx context.Context, domainProject string, schema string) (string, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(util.StringToBytesWithNoCopy(schema), &doc); err != nil || doc == nil {
+		// 不是JSON或YAML对象的契约原样返回
+		return schema, nil
+	}
+
+	definitions, _ := doc["definitions"].(map[string]interface{})
+	if definitions == nil {
+		definitions = make(map[string]interface{})
+	}
+	b := &schemaBundler{ctx: ctx, domainProject: domainProject, definitions: definitions}
+	if err := b.resolve(doc); err != nil {
+		return "", err
+	}
+	if len(definitions) > 0 {
+		doc["definitions"] = definitions
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(strings.TrimSpace(schema), "{") {
+		return util.BytesToStringWithNoCopy(data), nil
+	}
+	data, err = yaml.JSONToYAML(data)
+	if err != nil {
+		return "", err
+	}
+	return util.BytesToStringWithNoCopy(data), nil
+}
@@ -0,0 +1,164 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 发现时缓存共享服务列表的时间，其他节点上的变更最多延迟该时间生效
+const SHARED_SERVICE_CACHE_TTL = 30 * time.Second
+
+var sharedServiceCache = &sharedServiceListCache{}
+
+// SharedService 管理员标记的全局共享服务，其它租户的消费者按appId/serviceName
+// 在本租户找不到提供者时，可以只读地发现该服务的实例
+type SharedService struct {
+	Domain      string `json:"domain"`
+	Project     string `json:"project"`
+	AppId       string `json:"appId"`
+	ServiceName string `json:"serviceName"`
+	Operator    string `json:"operator,omitempty"`
+	Timestamp   string `json:"timestamp,omitempty"`
+}
+
+func (s *SharedService) DomainProject() string {
+	return util.StringJoin([]string{s.Domain, s.Project}, "/")
+}
+
+func (s *SharedService) Check() error {
+	if len(s.Domain) == 0 || len(s.Project) == 0 {
+		return errors.New("domain and project are required")
+	}
+	if len(s.AppId) == 0 || len(s.ServiceName) == 0 {
+		return errors.New("appId and serviceName are required")
+	}
+	return nil
+}
+
+type sharedServiceListCache struct {
+	lock     sync.RWMutex
+	services map[string]*SharedService
+	loadTime time.Time
+}
+
+func (c *sharedServiceListCache) Get(appId, serviceName string) (*SharedService, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if c.services == nil || time.Since(c.loadTime) > SHARED_SERVICE_CACHE_TTL {
+		return nil, false
+	}
+	return c.services[apt.GenerateSharedServiceKey(appId, serviceName)], true
+}
+
+func (c *sharedServiceListCache) Set(services []*SharedService) {
+	m := make(map[string]*SharedService, len(services))
+	for _, s := range services {
+		m[apt.GenerateSharedServiceKey(s.AppId, s.ServiceName)] = s
+	}
+	c.lock.Lock()
+	c.services, c.loadTime = m, time.Now()
+	c.lock.Unlock()
+}
+
+func (c *sharedServiceListCache) Invalidate() {
+	c.lock.Lock()
+	c.services = nil
+	c.lock.Unlock()
+}
+
+// GetSharedServices 查询所有共享服务
+func GetSharedServices(ctx context.Context) ([]*SharedService, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GetSharedServiceRootKey()+"/"),
+		registry.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	services := make([]*SharedService, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		s := &SharedService{}
+		if err := json.Unmarshal(kv.Value, s); err != nil {
+			util.Logger().Errorf(err, "invalid shared service %s", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		services = append(services, s)
+	}
+	return services, nil
+}
+
+// FindSharedService 发现时按appId/serviceName查询共享服务，不存在时返回nil
+func FindSharedService(ctx context.Context, appId, serviceName string) (*SharedService, error) {
+	if s, ok := sharedServiceCache.Get(appId, serviceName); ok {
+		return s, nil
+	}
+	services, err := GetSharedServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sharedServiceCache.Set(services)
+	s, _ := sharedServiceCache.Get(appId, serviceName)
+	return s, nil
+}
+
+// AddSharedService 标记共享服务，同一appId/serviceName只能由一个租户共享，重复标记时覆盖
+func AddSharedService(ctx context.Context, s *SharedService) error {
+	s.Timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(apt.GenerateSharedServiceKey(s.AppId, s.ServiceName)),
+		registry.WithValue(data))
+	if err != nil {
+		return err
+	}
+	sharedServiceCache.Invalidate()
+	return nil
+}
+
+// DeleteSharedService 取消共享，返回共享服务是否存在
+func DeleteSharedService(ctx context.Context, appId, serviceName string) (bool, error) {
+	key := apt.GenerateSharedServiceKey(appId, serviceName)
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(key),
+		registry.WithCountOnly())
+	if err != nil || resp.Count == 0 {
+		return false, err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.DEL,
+		registry.WithStrKey(key))
+	if err != nil {
+		return false, err
+	}
+	sharedServiceCache.Invalidate()
+	return true, nil
+}
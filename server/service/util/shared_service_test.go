@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func TestSharedService(t *testing.T) {
+	s := &serviceUtil.SharedService{Domain: "default", AppId: "a", ServiceName: "b"}
+	if s.Check() == nil {
+		fmt.Printf(`Check without project failed`)
+		t.FailNow()
+	}
+
+	s.Project = "default"
+	if s.Check() != nil || s.DomainProject() != "default/default" {
+		fmt.Printf(`Check shared service failed`)
+		t.FailNow()
+	}
+
+	s.ServiceName = ""
+	if s.Check() == nil {
+		fmt.Printf(`Check without serviceName failed`)
+		t.FailNow()
+	}
+}
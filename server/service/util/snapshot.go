@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/uuid"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"strconv"
+	"time"
+)
+
+const (
+	// 快照默认和最大有效期(秒)，过期后etcd的历史版本可能已被压缩
+	DEFAULT_SNAPSHOT_TTL int64 = 300
+	MAX_SNAPSHOT_TTL     int64 = 3600
+
+	// 请求上下文中固定读取的revision
+	CTX_REVISION = "revision"
+)
+
+// CreateSnapshot 固定domainProject当前的revision，ttl秒内可按该revision读取
+func CreateSnapshot(ctx context.Context, domainProject string, ttl int64) (*pb.Snapshot, error) {
+	if ttl <= 0 {
+		ttl = DEFAULT_SNAPSHOT_TTL
+	}
+	if ttl > MAX_SNAPSHOT_TTL {
+		ttl = MAX_SNAPSHOT_TTL
+	}
+
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GetSnapshotRootKey(domainProject)),
+		registry.WithPrefix(),
+		registry.WithCountOnly())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	snapshot := &pb.Snapshot{
+		SnapshotId:      uuid.GenerateUuid(),
+		Revision:        resp.Revision,
+		Timestamp:       strconv.FormatInt(now.Unix(), 10),
+		ExpireTimestamp: strconv.FormatInt(now.Add(time.Duration(ttl)*time.Second).Unix(), 10),
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	leaseID, err := backend.Registry().LeaseGrant(ctx, ttl)
+	if err != nil {
+		return nil, err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(apt.GenerateSnapshotKey(domainProject, snapshot.SnapshotId)),
+		registry.WithValue(data),
+		registry.WithLease(leaseID))
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetSnapshot 查询未过期的快照，不存在时返回nil
+func GetSnapshot(ctx context.Context, domainProject, snapshotId string) (*pb.Snapshot, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateSnapshotKey(domainProject, snapshotId)))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	snapshot := &pb.Snapshot{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, snapshot); err != nil {
+		util.Logger().Errorf(err, "invalid snapshot %s/%s", domainProject, snapshotId)
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// DeleteSnapshot 提前释放快照，返回快照是否存在
+func DeleteSnapshot(ctx context.Context, domainProject, snapshotId string) (bool, error) {
+	snapshot, err := GetSnapshot(ctx, domainProject, snapshotId)
+	if err != nil || snapshot == nil {
+		return false, err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.DEL,
+		registry.WithStrKey(apt.GenerateSnapshotKey(domainProject, snapshotId)))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
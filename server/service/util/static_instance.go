@@ -0,0 +1,162 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"golang.org/x/net/context"
+	"sort"
+	"strings"
+)
+
+// IsStaticInstance 是否为外部管理的静态实例，静态实例没有租约，不需要心跳
+func IsStaticInstance(instance *pb.MicroServiceInstance) bool {
+	return instance != nil && instance.HealthCheck != nil && instance.HealthCheck.Mode == pb.CHECK_BY_STATIC
+}
+
+func generateEndpointsIndexKey(domainProject string, instance *pb.MicroServiceInstance) string {
+	endpoints := append([]string{}, instance.Endpoints...)
+	sort.Strings(endpoints)
+	region, availableZone := apt.GetRegionAndAvailableZone(instance.DataCenterInfo)
+	return apt.GenerateEndpointsIndexKey(domainProject, region, availableZone,
+		instance.Properties[NODEIP], util.StringJoin(endpoints, "/"))
+}
+
+// staticInstanceIndexOps 静态实例的索引没有随租约过期，需要显式删除，endpoints索引只在属于该实例时删除
+func staticInstanceIndexOps(ctx context.Context, domainProject string, instance *pb.MicroServiceInstance) ([]registry.PluginOp, error) {
+	opts := []registry.PluginOp{
+		registry.OpDel(registry.WithStrKey(apt.GenerateInstanceIndexKey(domainProject, instance.InstanceId))),
+	}
+	if len(instance.Endpoints) == 0 {
+		return opts, nil
+	}
+	key := generateEndpointsIndexKey(domainProject, instance)
+	resp, err := backend.Registry().Do(ctx, registry.GET, registry.WithStrKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) > 0 &&
+		ParseEndpointValue(resp.Kvs[0].Value).instanceId == instance.InstanceId {
+		opts = append(opts, registry.OpDel(registry.WithStrKey(key)))
+	}
+	return opts, nil
+}
+
+// DeleteStaticInstance 注销静态实例，删除实例数据及其索引
+func DeleteStaticInstance(ctx context.Context, domainProject string, instance *pb.MicroServiceInstance) error {
+	opts, err := staticInstanceIndexOps(ctx, domainProject, instance)
+	if err != nil {
+		return err
+	}
+	opts = append(opts,
+		registry.OpDel(registry.WithStrKey(apt.GenerateInstanceKey(domainProject, instance.ServiceId, instance.InstanceId))),
+		registry.OpDel(registry.WithStrKey(apt.GenerateStaticInstanceKey(domainProject, instance.ServiceId, instance.InstanceId))))
+	_, err = backend.Registry().Txn(ctx, opts)
+	return err
+}
+
+// cleanupStaticInstanceOps 删除服务时清理该服务下静态实例的索引
+func cleanupStaticInstanceOps(ctx context.Context, domainProject string, serviceId string) ([]registry.PluginOp, error) {
+	staticKey := apt.GenerateStaticInstanceKey(domainProject, serviceId, "")
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(staticKey),
+		registry.WithPrefix(),
+		registry.WithKeyOnly())
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+
+	opts := []registry.PluginOp{}
+	for _, kv := range resp.Kvs {
+		key := util.BytesToStringWithNoCopy(kv.Key)
+		instanceId := key[len(staticKey):]
+		instance, err := GetInstance(util.SetContext(util.CloneContext(ctx), "noCache", "1"),
+			domainProject, serviceId, instanceId)
+		if err != nil {
+			return nil, err
+		}
+		if instance == nil {
+			opts = append(opts, registry.OpDel(
+				registry.WithStrKey(apt.GenerateInstanceIndexKey(domainProject, instanceId))))
+			continue
+		}
+		indexOpts, err := staticInstanceIndexOps(ctx, domainProject, instance)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, indexOpts...)
+	}
+	opts = append(opts, registry.OpDel(registry.WithStrKey(staticKey), registry.WithPrefix()))
+	return opts, nil
+}
+
+// GetStaticInstancesCount 查询静态实例总数，domainProject为空时统计所有租户
+func GetStaticInstancesCount(ctx context.Context, domainProject string) (int64, error) {
+	key := apt.GetStaticInstanceRootKey(domainProject)
+	if len(domainProject) > 0 {
+		key += "/"
+	}
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(key),
+		registry.WithPrefix(),
+		registry.WithCountOnly())
+	if err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
+// GetStaticInstances 查询所有静态实例，key为domainProject
+func GetStaticInstances(ctx context.Context) (map[string][]*pb.MicroServiceInstance, error) {
+	rootKey := apt.GetStaticInstanceRootKey("")
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(rootKey),
+		registry.WithPrefix(),
+		registry.WithKeyOnly())
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make(map[string][]*pb.MicroServiceInstance)
+	for _, kv := range resp.Kvs {
+		// {domain}/{project}/{serviceId}/{instanceId}
+		arr := strings.Split(util.BytesToStringWithNoCopy(kv.Key)[len(rootKey):], "/")
+		if len(arr) != 4 {
+			continue
+		}
+		domainProject := arr[0] + "/" + arr[1]
+		instance, err := GetInstance(ctx, domainProject, arr[2], arr[3])
+		if err != nil {
+			return nil, err
+		}
+		if !IsStaticInstance(instance) {
+			continue
+		}
+		instances[domainProject] = append(instances[domainProject], instance)
+	}
+	return instances, nil
+}
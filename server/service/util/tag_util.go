@@ -53,6 +53,7 @@ func GetTagsUtils(ctx context.Context, domainProject, serviceId string) (tags ma
 		util.Logger().Errorf(err, "get service %s tags file failed", key)
 		return tags, err
 	}
+	RecordModRevision(ctx, resp.Kvs)
 
 	l := len(resp.Kvs)
 	if l != 0 {
@@ -0,0 +1,239 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/net/context"
+	"strings"
+	"time"
+)
+
+// 合并该时间内的注册中心事件，避免事件风暴时反复计算拓扑
+const DEFAULT_TOPOLOGY_REPORT_DELAY = 5 * time.Second
+
+var (
+	topologyEdges = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "topology",
+			Name:      "dependency_edges",
+			Help:      "Gauge of the dependency rules from the consumer versions to the provider",
+		}, []string{"domain", "consumer", "provider"})
+
+	topologyProviderHealth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "topology",
+			Name:      "provider_health",
+			Help:      "Ratio of the UP instances of the provider matched by the consumer dependency",
+		}, []string{"domain", "consumer", "provider"})
+
+	topologyReporter = NewTopologyReporter()
+)
+
+func init() {
+	prometheus.MustRegister(topologyEdges, topologyProviderHealth)
+}
+
+// TopologyReporter 收到注册中心事件后延迟Delay重新计算依赖拓扑指标，期间的事件合并为一次
+type TopologyReporter struct {
+	Delay time.Duration
+
+	notify chan struct{}
+}
+
+func NewTopologyReporter() *TopologyReporter {
+	return &TopologyReporter{
+		Delay:  DEFAULT_TOPOLOGY_REPORT_DELAY,
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// Notify 不阻塞，已有待处理的通知时直接丢弃
+func (r *TopologyReporter) Notify() {
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (r *TopologyReporter) Run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-r.notify:
+		}
+
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(r.Delay):
+		}
+
+		if err := ReportTopology(context.Background()); err != nil {
+			util.Logger().Errorf(err, "report dependency topology failed")
+		}
+	}
+}
+
+type topologyEdge struct {
+	domainProject string
+	consumer      string
+	provider      string
+}
+
+// ReportTopology 按消费者的依赖规则统计consumer/provider之间的依赖数及provider的健康度
+func ReportTopology(ctx context.Context) error {
+	resp, err := store.Store().DependencyRule().Search(ctx,
+		registry.WithStrKey(apt.GetServiceDependencyRuleRootKey("")),
+		registry.WithPrefix())
+	if err != nil {
+		return err
+	}
+
+	edges := make(map[topologyEdge]int)
+	health := make(map[topologyEdge]float64)
+	// 同一provider版本规则的健康度在一次统计中只计算一次
+	providers := make(map[string]float64)
+	for _, kv := range resp.Kvs {
+		domainProject, consumer, ok := getInfoFromConsumerRuleKV(kv)
+		if !ok {
+			continue
+		}
+		deps := &pb.MicroServiceDependency{}
+		if err := json.Unmarshal(kv.Value, deps); err != nil {
+			util.Logger().Errorf(err, "unmarshal dependency rule %s failed", util.BytesToStringWithNoCopy(kv.Key))
+			continue
+		}
+		for _, provider := range deps.Dependency {
+			edge := topologyEdge{
+				domainProject: domainProject,
+				consumer:      topologyName(consumer),
+				provider:      topologyName(provider),
+			}
+			edges[edge]++
+			if provider.ServiceName == "*" {
+				continue
+			}
+			ruleKey := apt.GenerateProviderDependencyRuleKey(domainProject, provider)
+			h, ok := providers[ruleKey]
+			if !ok {
+				h, err = providerHealth(ctx, domainProject, provider)
+				if err != nil {
+					util.Logger().Errorf(err, "get health of provider %s failed", edge.provider)
+					continue
+				}
+				providers[ruleKey] = h
+			}
+			// 多个消费者版本依赖不同的provider版本时，取最差的健康度
+			if old, ok := health[edge]; !ok || h < old {
+				health[edge] = h
+			}
+		}
+	}
+
+	topologyEdges.Reset()
+	topologyProviderHealth.Reset()
+	for edge, n := range edges {
+		topologyEdges.WithLabelValues(edge.domainProject, edge.consumer, edge.provider).Set(float64(n))
+	}
+	for edge, h := range health {
+		topologyProviderHealth.WithLabelValues(edge.domainProject, edge.consumer, edge.provider).Set(h)
+	}
+	return nil
+}
+
+// InstancesHealth 返回UP实例的比例，待命和预注册实例不参与统计，没有实例时为0
+func InstancesHealth(instances []*pb.MicroServiceInstance) float64 {
+	var total, up int
+	for _, instance := range instances {
+		switch instance.Status {
+		case pb.MSI_STANDBY, pb.MSI_PENDING:
+			continue
+		case pb.MSI_UP:
+			up++
+		}
+		total++
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(up) / float64(total)
+}
+
+func providerHealth(ctx context.Context, domainProject string, provider *pb.MicroServiceKey) (float64, error) {
+	key := *provider
+	key.Tenant = domainProject
+	ids, err := FindServiceIds(ctx, provider.Version, &key)
+	if err != nil {
+		return 0, err
+	}
+	var instances []*pb.MicroServiceInstance
+	for _, serviceId := range ids {
+		insts, err := GetAllInstancesOfOneService(ctx, domainProject, serviceId)
+		if err != nil {
+			return 0, err
+		}
+		instances = append(instances, insts...)
+	}
+	return InstancesHealth(instances), nil
+}
+
+// getInfoFromConsumerRuleKV 解析消费者依赖规则的key:
+// .../{domain}/{project}/c/{env}/{appId}/{serviceName}/{version}
+func getInfoFromConsumerRuleKV(kv *mvccpb.KeyValue) (string, *pb.MicroServiceKey, bool) {
+	keys, _ := pb.KvToResponse(kv)
+	l := len(keys)
+	if l < 7 || keys[l-5] != "c" {
+		return "", nil, false
+	}
+	return util.StringJoin([]string{keys[l-7], keys[l-6]}, "/"), &pb.MicroServiceKey{
+		Environment: keys[l-4],
+		AppId:       keys[l-3],
+		ServiceName: keys[l-2],
+		Version:     keys[l-1],
+	}, true
+}
+
+func topologyName(key *pb.MicroServiceKey) string {
+	if key.ServiceName == "*" {
+		return key.ServiceName
+	}
+	appId := key.AppId
+	if len(strings.TrimSpace(appId)) == 0 {
+		appId = apt.REGISTRY_APP_ID
+	}
+	return util.StringJoin([]string{appId, key.ServiceName}, "/")
+}
+
+// NotifyTopologyChanged 注册中心依赖、服务或实例变化时调用，异步刷新依赖拓扑指标
+func NotifyTopologyChanged() {
+	topologyReporter.Notify()
+}
+
+func RunTopologyReport() {
+	util.Go(topologyReporter.Run)
+}
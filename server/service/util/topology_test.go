@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func TestInstancesHealth(t *testing.T) {
+	if h := serviceUtil.InstancesHealth(nil); h != 0 {
+		fmt.Printf(`InstancesHealth without instances failed, %v`, h)
+		t.FailNow()
+	}
+
+	instances := []*pb.MicroServiceInstance{
+		{Status: pb.MSI_UP},
+		{Status: pb.MSI_DOWN},
+		{Status: pb.MSI_UP},
+		{Status: pb.MSI_OUTOFSERVICE},
+		{Status: pb.MSI_STANDBY},
+	}
+	if h := serviceUtil.InstancesHealth(instances); h != 0.5 {
+		fmt.Printf(`InstancesHealth failed, %v`, h)
+		t.FailNow()
+	}
+
+	if h := serviceUtil.InstancesHealth(instances[4:]); h != 0 {
+		fmt.Printf(`InstancesHealth with standby instances only failed, %v`, h)
+		t.FailNow()
+	}
+}
+
+func TestTopologyReporterNotify(t *testing.T) {
+	r := serviceUtil.NewTopologyReporter()
+	// 未运行时多次通知也不能阻塞
+	for i := 0; i < 3; i++ {
+		r.Notify()
+	}
+}
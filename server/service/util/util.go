@@ -29,5 +29,8 @@ func FromContext(ctx context.Context) []registry.PluginOpOption {
 	case ctx.Value("cacheOnly") == "1":
 		opts = append(opts, registry.WithCacheOnly())
 	}
+	if rev, ok := ctx.Value(CTX_REVISION).(int64); ok && rev > 0 {
+		opts = append(opts, registry.WithRev(rev))
+	}
 	return opts
 }
@@ -17,6 +17,7 @@
 package util_test
 
 import (
+	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/admission/buildin"
 	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/quota/buildin"
 	_ "github.com/apache/incubator-servicecomb-service-center/server/plugin/infra/registry/buildin"
 )
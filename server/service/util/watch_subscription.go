@@ -0,0 +1,191 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	apt "github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend"
+	"github.com/apache/incubator-servicecomb-service-center/server/core/backend/store"
+	pb "github.com/apache/incubator-servicecomb-service-center/server/core/proto"
+	"github.com/apache/incubator-servicecomb-service-center/server/infra/registry"
+	"github.com/astaxie/beego"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"golang.org/x/net/context"
+	"regexp"
+	"time"
+)
+
+// 订阅断开后保留的时间，期间重连可续接
+const DEFAULT_WATCH_SUBSCRIPTION_TTL = time.Hour
+
+var watchSubscriptionNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,63}$`)
+
+// WatchSubscription 持久化的watch订阅，记录订阅者、watch的提供者key和最近推送的revision
+type WatchSubscription struct {
+	Name         string `json:"name"`
+	SubscriberId string `json:"subscriberId"`
+	ProviderKey  string `json:"providerKey"`
+	Revision     int64  `json:"revision"`
+	Timestamp    string `json:"timestamp"`
+}
+
+// CheckWatchSubscription 订阅名为空表示不持久化，否则以字母或数字开头且不超过64个字符
+func CheckWatchSubscription(name string) error {
+	if len(name) > 0 && !watchSubscriptionNameRegex.MatchString(name) {
+		return fmt.Errorf("Invalid subscription '%s'.", name)
+	}
+	return nil
+}
+
+func WatchSubscriptionTTL() time.Duration {
+	v := beego.AppConfig.DefaultString("watch_subscription_ttl", DEFAULT_WATCH_SUBSCRIPTION_TTL.String())
+	d, err := time.ParseDuration(v)
+	if err != nil || d < time.Second {
+		util.Logger().Errorf(err, "invalid watch_subscription_ttl '%s', use %s", v, DEFAULT_WATCH_SUBSCRIPTION_TTL)
+		return DEFAULT_WATCH_SUBSCRIPTION_TTL
+	}
+	return d
+}
+
+// GetWatchSubscription 订阅不存在或已过期时返回nil
+func GetWatchSubscription(ctx context.Context, domainProject, serviceId, name string) (*WatchSubscription, error) {
+	resp, err := backend.Registry().Do(ctx,
+		registry.GET,
+		registry.WithStrKey(apt.GenerateWatchSubscriptionKey(domainProject, serviceId, name)))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	sub := &WatchSubscription{}
+	if err := json.Unmarshal(resp.Kvs[0].Value, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// SaveWatchSubscription 使用leaseID写入订阅，租约过期后订阅被删除
+func SaveWatchSubscription(ctx context.Context, domainProject string, sub *WatchSubscription, leaseID int64) error {
+	data, err := json.Marshal(sub)
+	if err != nil {
+		return err
+	}
+	_, err = backend.Registry().Do(ctx,
+		registry.PUT,
+		registry.WithStrKey(apt.GenerateWatchSubscriptionKey(domainProject, sub.SubscriberId, sub.Name)),
+		registry.WithValue(data),
+		registry.WithLease(leaseID))
+	return err
+}
+
+// QueryProvidersInstancesSince 比较rev和当前revision时消费者的提供者实例，返回期间变化的实例事件
+// 和当前revision，供没有事件历史的节点续接订阅；提供者按当前的依赖关系计算，
+// rev已被etcd压缩时返回错误，客户端需重新Find
+func QueryProvidersInstancesSince(ctx context.Context, selfServiceId string, rev int64) ([]*pb.WatchInstanceResponse, int64, error) {
+	domainProject := util.ParseDomainProject(ctx)
+	service, err := GetService(ctx, domainProject, selfServiceId)
+	if err != nil {
+		return nil, 0, err
+	}
+	if service == nil {
+		return nil, 0, fmt.Errorf("service %s does not exist", selfServiceId)
+	}
+	providerIds, _, err := GetProviderIdsByConsumerId(ctx, domainProject, selfServiceId, service)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	current := store.Revision()
+	results := []*pb.WatchInstanceResponse{}
+	for _, providerId := range providerIds {
+		provider, err := GetServiceWithRev(ctx, domainProject, providerId, current)
+		if err != nil {
+			return nil, 0, err
+		}
+		if provider == nil {
+			continue
+		}
+		key := &pb.MicroServiceKey{
+			Environment: provider.Environment,
+			AppId:       provider.AppId,
+			ServiceName: provider.ServiceName,
+			Version:     provider.Version,
+		}
+		before, err := queryServiceInstancesKvs(ctx, providerId, rev)
+		if err != nil {
+			return nil, 0, err
+		}
+		after, err := queryServiceInstancesKvs(ctx, providerId, current)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		removed := make(map[string]*mvccpb.KeyValue, len(before))
+		for _, kv := range before {
+			removed[util.BytesToStringWithNoCopy(kv.Key)] = kv
+		}
+		for _, kv := range after {
+			k := util.BytesToStringWithNoCopy(kv.Key)
+			_, existed := removed[k]
+			delete(removed, k)
+			if existed && kv.ModRevision <= rev {
+				continue
+			}
+			action := pb.EVT_CREATE
+			if existed {
+				action = pb.EVT_UPDATE
+			}
+			if response := instanceChange(action, key, kv); response != nil {
+				results = append(results, response)
+			}
+		}
+		for _, kv := range before {
+			if _, ok := removed[util.BytesToStringWithNoCopy(kv.Key)]; !ok {
+				continue
+			}
+			if response := instanceChange(pb.EVT_DELETE, key, kv); response != nil {
+				results = append(results, response)
+			}
+		}
+	}
+	return results, current, nil
+}
+
+func instanceChange(action pb.EventType, key *pb.MicroServiceKey, kv *mvccpb.KeyValue) *pb.WatchInstanceResponse {
+	instance := &pb.MicroServiceInstance{}
+	if err := store.Unmarshal(kv.Value, instance); err != nil {
+		util.Logger().Errorf(err, "unmarshal instance %s failed", util.BytesToStringWithNoCopy(kv.Key))
+		return nil
+	}
+	if action != pb.EVT_DELETE && !IsDiscoverable(instance) {
+		// 变为不可发现的实例对消费者等同于下线
+		if action == pb.EVT_CREATE {
+			return nil
+		}
+		action = pb.EVT_DELETE
+	}
+	return &pb.WatchInstanceResponse{
+		Response: pb.CreateResponse(pb.Response_SUCCESS, "Watch instance successfully."),
+		Action:   string(action),
+		Key:      key,
+		Instance: instance,
+	}
+}
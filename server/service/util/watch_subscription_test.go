@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util_test
+
+import (
+	"fmt"
+	serviceUtil "github.com/apache/incubator-servicecomb-service-center/server/service/util"
+	"testing"
+)
+
+func TestCheckWatchSubscription(t *testing.T) {
+	for _, name := range []string{"", "a", "consumer-1.sub_2"} {
+		if err := serviceUtil.CheckWatchSubscription(name); err != nil {
+			fmt.Printf("CheckWatchSubscription %s failed, %s", name, err)
+			t.FailNow()
+		}
+	}
+	for _, name := range []string{"-a", "a/b", string(make([]byte, 65))} {
+		if err := serviceUtil.CheckWatchSubscription(name); err == nil {
+			fmt.Printf("CheckWatchSubscription %q should fail", name)
+			t.FailNow()
+		}
+	}
+	if serviceUtil.WatchSubscriptionTTL() != serviceUtil.DEFAULT_WATCH_SUBSCRIPTION_TTL {
+		fmt.Printf("WatchSubscriptionTTL failed")
+		t.FailNow()
+	}
+}
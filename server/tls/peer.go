@@ -0,0 +1,262 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package tls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// 证书校验失败或TLS握手被对端拒绝
+	PEER_FAILURE_HANDSHAKE = "handshake"
+	// 握手成功但请求被对端拒绝(401/403)
+	PEER_FAILURE_AUTH = "auth"
+)
+
+var (
+	peerFailureCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "tls",
+			Name:      "peer_failures_total",
+			Help:      "Counter of the TLS handshake and authentication failures by peer cluster",
+		}, []string{"peer", "reason"})
+
+	peerTrust = &peerTrustStore{
+		pools:   make(map[string]*x509.CertPool),
+		issuers: make(map[string]string),
+		status:  make(map[string]*PeerStatus),
+	}
+)
+
+func init() {
+	prometheus.MustRegister(peerFailureCounter)
+}
+
+// PeerStatus 对端集群的CA和最近一次握手、认证的结果
+type PeerStatus struct {
+	Name        string             `json:"name"`
+	CAs         []*CertificateInfo `json:"cas,omitempty"`
+	Failures    int64              `json:"failures"`
+	LastFailure int64              `json:"lastFailure,omitempty"`
+	LastReason  string             `json:"lastReason,omitempty"`
+	LastError   string             `json:"lastError,omitempty"`
+	LastSuccess int64              `json:"lastSuccess,omitempty"`
+}
+
+// Failing 最近一次访问的结果是失败
+func (s *PeerStatus) Failing() bool {
+	return s.LastFailure > 0 && s.LastFailure >= s.LastSuccess
+}
+
+// peerTrustStore 登记的对端集群CA，作为本集群CA之外的信任根，
+// 轮换期间新旧CA同时加入，替换后只影响新建立的连接
+type peerTrustStore struct {
+	lock  sync.RWMutex
+	pools map[string]*x509.CertPool
+	// CA的subject到对端名称，用于把校验失败的客户端证书归到对端
+	issuers map[string]string
+	status  map[string]*PeerStatus
+}
+
+func (s *peerTrustStore) Set(cas map[string][]*x509.Certificate) {
+	pools := make(map[string]*x509.CertPool, len(cas))
+	issuers := make(map[string]string)
+	status := make(map[string]*PeerStatus, len(cas))
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for name, certs := range cas {
+		pool := x509.NewCertPool()
+		infos := make([]*CertificateInfo, 0, len(certs))
+		for _, cert := range certs {
+			pool.AddCert(cert)
+			issuers[cert.Subject.CommonName] = name
+			infos = append(infos, toCertificateInfo(name, cert))
+		}
+		pools[name] = pool
+
+		st, ok := s.status[name]
+		if !ok {
+			st = &PeerStatus{Name: name}
+		}
+		st.CAs = infos
+		status[name] = st
+	}
+	for name := range s.status {
+		if _, ok := status[name]; !ok {
+			peerFailureCounter.DeleteLabelValues(name, PEER_FAILURE_HANDSHAKE)
+			peerFailureCounter.DeleteLabelValues(name, PEER_FAILURE_AUTH)
+		}
+	}
+	s.pools, s.issuers, s.status = pools, issuers, status
+}
+
+func (s *peerTrustStore) pool(name string) *x509.CertPool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.pools[name]
+}
+
+// verifyClient 本集群CA校验失败的客户端证书，再使用各对端的CA校验，
+// 都不通过时按证书的签发者记录到对应的对端，返回原始的错误
+func (s *peerTrustStore) verifyClient(certs []*x509.Certificate, cause error) error {
+	s.lock.RLock()
+	pools := s.pools
+	peer, ok := s.issuers[certs[0].Issuer.CommonName]
+	s.lock.RUnlock()
+
+	for name, pool := range pools {
+		if verifyChain(certs, pool, x509.ExtKeyUsageClientAuth) == nil {
+			s.Success(name)
+			return nil
+		}
+	}
+	if ok {
+		s.Failure(peer, PEER_FAILURE_HANDSHAKE, cause)
+	}
+	return cause
+}
+
+func (s *peerTrustStore) Failure(name, reason string, err error) {
+	s.lock.Lock()
+	st, ok := s.status[name]
+	if !ok {
+		s.lock.Unlock()
+		return
+	}
+	st.Failures++
+	st.LastFailure = time.Now().Unix()
+	st.LastReason = reason
+	st.LastError = err.Error()
+	s.lock.Unlock()
+
+	peerFailureCounter.WithLabelValues(name, reason).Inc()
+	util.Logger().Errorf(err, "tls %s failure of peer %s", reason, name)
+}
+
+func (s *peerTrustStore) Success(name string) {
+	s.lock.Lock()
+	if st, ok := s.status[name]; ok {
+		st.LastSuccess = time.Now().Unix()
+	}
+	s.lock.Unlock()
+}
+
+func (s *peerTrustStore) Status() []*PeerStatus {
+	s.lock.RLock()
+	status := make([]*PeerStatus, 0, len(s.status))
+	for _, st := range s.status {
+		copied := *st
+		status = append(status, &copied)
+	}
+	s.lock.RUnlock()
+
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Name < status[j].Name
+	})
+	return status
+}
+
+func parseChain(rawCerts [][]byte) ([]*x509.Certificate, error) {
+	if len(rawCerts) == 0 {
+		return nil, fmt.Errorf("peer certificate required")
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+func verifyChain(certs []*x509.Certificate, roots *x509.CertPool, usage x509.ExtKeyUsage) error {
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+// SetPeerCAs 替换所有对端集群的CA，key为对端名称，不在其中的对端不再被信任
+func SetPeerCAs(cas map[string][]*x509.Certificate) {
+	peerTrust.Set(cas)
+}
+
+// GetPeerClientTLSConfig 访问对端集群的客户端配置，客户端证书与本集群共用，
+// 服务端证书以登记的对端CA校验，证书和CA更新后新建立的连接生效
+func GetPeerClientTLSConfig(peer string) (*tls.Config, error) {
+	base, err := GetClientTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg := base.Clone()
+	// 由VerifyPeerCertificate校验证书链，与其他客户端一样不校验主机名
+	cfg.InsecureSkipVerify = true
+	cfg.RootCAs = nil
+	cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		pool := peerTrust.pool(peer)
+		if pool == nil {
+			return fmt.Errorf("no CA registered for peer %s", peer)
+		}
+		certs, err := parseChain(rawCerts)
+		if err != nil {
+			return err
+		}
+		return verifyChain(certs, pool, x509.ExtKeyUsageServerAuth)
+	}
+	return cfg, nil
+}
+
+// IsHandshakeError 请求对端时的错误是否由证书校验或TLS握手引起
+func IsHandshakeError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "x509: ") || strings.Contains(msg, "tls: ")
+}
+
+// RecordPeerFailure 记录对端的握手或认证失败，未登记的对端忽略
+func RecordPeerFailure(peer, reason string, err error) {
+	peerTrust.Failure(peer, reason, err)
+}
+
+func RecordPeerSuccess(peer string) {
+	peerTrust.Success(peer)
+}
+
+// PeerStatuses 返回所有登记的对端集群的状态，按名称排序
+func PeerStatuses() []*PeerStatus {
+	return peerTrust.Status()
+}
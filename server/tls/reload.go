@@ -0,0 +1,287 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package tls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/tlsutil"
+	"github.com/apache/incubator-servicecomb-service-center/pkg/util"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"github.com/astaxie/beego"
+	"github.com/prometheus/client_golang/prometheus"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const DEFAULT_RELOAD_INTERVAL = 60 * time.Second
+
+var (
+	certExpireGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "service_center",
+			Subsystem: "tls",
+			Name:      "certificate_expire_timestamp_seconds",
+			Help:      "Unix time when the loaded certificate expires",
+		}, []string{"file", "subject"})
+
+	reloadCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "service_center",
+			Subsystem: "tls",
+			Name:      "reloads_total",
+			Help:      "Counter of the certificate reloads",
+		}, []string{"result"})
+
+	reloader = &Reloader{}
+)
+
+func init() {
+	prometheus.MustRegister(certExpireGauge, reloadCounter)
+}
+
+// CertificateInfo 已加载证书的概要，用于过期告警
+type CertificateInfo struct {
+	File      string `json:"file"`
+	Subject   string `json:"subject"`
+	Issuer    string `json:"issuer"`
+	NotBefore int64  `json:"notBefore"`
+	NotAfter  int64  `json:"notAfter"`
+}
+
+type ReloadStatus struct {
+	Certificates []*CertificateInfo `json:"certificates"`
+	LastReload   int64              `json:"lastReload,omitempty"`
+	LastError    string             `json:"lastError,omitempty"`
+}
+
+// Reloader 持有当前生效的证书和CA，服务端和访问其他服务的客户端共用，重新加载后只影响新建立的连接，
+// 已建立的连接不会断开
+type Reloader struct {
+	cert atomic.Value // *tls.Certificate
+	pool atomic.Value // *x509.CertPool
+
+	mux        sync.Mutex
+	modTimes   map[string]time.Time
+	certs      []*CertificateInfo
+	lastReload time.Time
+	lastErr    error
+}
+
+func (r *Reloader) files() []string {
+	files := []string{GetSSLPath("server.cer"), GetSSLPath("server_key.pem"), GetSSLPath("cert_pwd")}
+	if core.ServerInfo.Config.SslVerifyPeer {
+		files = append(files, GetSSLPath("trust.cer"))
+	}
+	return files
+}
+
+func (r *Reloader) loaded() bool {
+	return r.cert.Load() != nil
+}
+
+// Reload 重新加载证书、私钥和CA，失败时继续使用原有证书
+func (r *Reloader) Reload() error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.lastReload = time.Now()
+	r.lastErr = r.load()
+	if r.lastErr != nil {
+		reloadCounter.WithLabelValues("failure").Inc()
+		return r.lastErr
+	}
+	reloadCounter.WithLabelValues("success").Inc()
+	return nil
+}
+
+func (r *Reloader) load() error {
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.files() {
+		if fi, err := os.Stat(file); err == nil {
+			modTimes[file] = fi.ModTime()
+		}
+	}
+
+	_, decrypt := GetPassphase()
+	certFile := GetSSLPath("server.cer")
+	certs, err := tlsutil.LoadTLSCertificate(certFile, GetSSLPath("server_key.pem"), decrypt)
+	if err != nil {
+		return err
+	}
+	leaf, err := x509.ParseCertificate(certs[0].Certificate[0])
+	if err != nil {
+		return err
+	}
+	infos := []*CertificateInfo{toCertificateInfo(certFile, leaf)}
+
+	var pool *x509.CertPool
+	if core.ServerInfo.Config.SslVerifyPeer {
+		caFile := GetSSLPath("trust.cer")
+		pool, err = tlsutil.GetX509CACertPool(caFile)
+		if err != nil {
+			return err
+		}
+		cas, err := tlsutil.ParseCertificates(caFile)
+		if err != nil {
+			return err
+		}
+		if len(cas) == 0 {
+			return errors.New("no certificate found in " + caFile)
+		}
+		for _, ca := range cas {
+			infos = append(infos, toCertificateInfo(caFile, ca))
+		}
+		r.pool.Store(pool)
+	}
+	r.cert.Store(&certs[0])
+
+	certExpireGauge.Reset()
+	for _, info := range infos {
+		certExpireGauge.WithLabelValues(filepath.Base(info.File), info.Subject).Set(float64(info.NotAfter))
+	}
+	r.certs = infos
+	r.modTimes = modTimes
+	util.Logger().Infof("tls certificates loaded, %s expires at %s.",
+		leaf.Subject.CommonName, leaf.NotAfter.Format(time.RFC3339))
+	return nil
+}
+
+// Changed 证书文件的修改时间与上次加载时不一致
+func (r *Reloader) Changed() bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	for _, file := range r.files() {
+		fi, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if t, ok := r.modTimes[file]; !ok || !t.Equal(fi.ModTime()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) Status() *ReloadStatus {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	status := &ReloadStatus{Certificates: r.certs}
+	if !r.lastReload.IsZero() {
+		status.LastReload = r.lastReload.Unix()
+	}
+	if r.lastErr != nil {
+		status.LastError = r.lastErr.Error()
+	}
+	return status
+}
+
+func (r *Reloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.cert.Load().(*tls.Certificate), nil
+}
+
+func (r *Reloader) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.cert.Load().(*tls.Certificate), nil
+}
+
+// verifyPeerCertificate 使用当前的CA校验客户端证书，等同于RequireAndVerifyClientCert，
+// 本集群CA不能校验时再使用登记的对端集群CA
+func (r *Reloader) verifyPeerCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	certs, err := parseChain(rawCerts)
+	if err != nil {
+		return err
+	}
+	err = verifyChain(certs, r.pool.Load().(*x509.CertPool), x509.ExtKeyUsageClientAuth)
+	if err == nil {
+		return nil
+	}
+	return peerTrust.verifyClient(certs, err)
+}
+
+// verifyServerCertificate 访问etcd和其他服务端时，使用当前的CA校验服务端证书
+func (r *Reloader) verifyServerCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	certs, err := parseChain(rawCerts)
+	if err != nil {
+		return err
+	}
+	return verifyChain(certs, r.pool.Load().(*x509.CertPool), x509.ExtKeyUsageServerAuth)
+}
+
+func (r *Reloader) run(stopCh <-chan struct{}, interval time.Duration) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(interval):
+			if !r.Changed() {
+				continue
+			}
+			util.Logger().Warnf(nil, "tls certificate files changed, reload them.")
+			if err := r.Reload(); err != nil {
+				util.Logger().Errorf(err, "reload tls certificates failed, keep the old ones.")
+			}
+		}
+	}
+}
+
+func toCertificateInfo(file string, cert *x509.Certificate) *CertificateInfo {
+	return &CertificateInfo{
+		File:      file,
+		Subject:   cert.Subject.CommonName,
+		Issuer:    cert.Issuer.CommonName,
+		NotBefore: cert.NotBefore.Unix(),
+		NotAfter:  cert.NotAfter.Unix(),
+	}
+}
+
+func ensureLoaded() error {
+	if reloader.loaded() {
+		return nil
+	}
+	return reloader.Reload()
+}
+
+// Reload 手动触发证书重新加载，REST和gRPC的新连接使用新证书
+func Reload() error {
+	if !core.ServerInfo.Config.SslEnabled {
+		return errors.New("ssl is disabled")
+	}
+	return reloader.Reload()
+}
+
+func Status() *ReloadStatus {
+	return reloader.Status()
+}
+
+// Run 定期检查证书文件，变化时自动重新加载，ssl_reload_interval为0时关闭
+func Run() {
+	if !core.ServerInfo.Config.SslEnabled {
+		return
+	}
+	interval := time.Duration(beego.AppConfig.DefaultInt64("ssl_reload_interval",
+		int64(DEFAULT_RELOAD_INTERVAL/time.Second))) * time.Second
+	if interval <= 0 {
+		return
+	}
+	util.Go(func(stopCh <-chan struct{}) {
+		reloader.run(stopCh, interval)
+	})
+}
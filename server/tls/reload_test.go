@@ -0,0 +1,206 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/apache/incubator-servicecomb-service-center/server/core"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	raw  []byte
+}
+
+// newTestCert 生成证书，parent为nil时生成自签名的CA
+func newTestCert(t *testing.T, cn string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{cert: cert, key: key, raw: raw}
+}
+
+// writeFile 写入后把修改时间设为mtime，避免文件系统时间精度导致修改不可见
+func writeFile(t *testing.T, file string, data []byte, mtime time.Time) {
+	if err := ioutil.WriteFile(file, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(file, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeCert(t *testing.T, c *testCert, mtime time.Time) {
+	writeFile(t, GetSSLPath("server.cer"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.raw}), mtime)
+	der, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, GetSSLPath("server_key.pem"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), mtime)
+}
+
+func writeCA(t *testing.T, ca *testCert, mtime time.Time) {
+	writeFile(t, GetSSLPath("trust.cer"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.raw}), mtime)
+}
+
+func setupSSLRoot(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "sc-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, verify := os.Getenv("SSL_ROOT"), core.ServerInfo.Config.SslVerifyPeer
+	os.Setenv("SSL_ROOT", dir)
+	core.ServerInfo.Config.SslVerifyPeer = true
+	return func() {
+		os.Setenv("SSL_ROOT", root)
+		core.ServerInfo.Config.SslVerifyPeer = verify
+		os.RemoveAll(dir)
+	}
+}
+
+func TestReloader_Changed(t *testing.T) {
+	defer setupSSLRoot(t)()
+
+	ca := newTestCert(t, "ca", nil)
+	mtime := time.Now().Add(-time.Minute)
+	writeCert(t, newTestCert(t, "server", ca), mtime)
+	writeCA(t, ca, mtime)
+
+	r := &Reloader{}
+	if !r.Changed() {
+		t.Fatalf("TestReloader_Changed not loaded, should be changed")
+	}
+	if err := r.Reload(); err != nil {
+		t.Fatalf("TestReloader_Changed reload failed, %s", err)
+	}
+	// 不存在的cert_pwd不视为变化
+	if r.Changed() {
+		t.Fatalf("TestReloader_Changed files are not modified")
+	}
+
+	// 内容不变只更新修改时间也视为变化
+	writeCA(t, ca, mtime.Add(time.Second))
+	if !r.Changed() {
+		t.Fatalf("TestReloader_Changed trust.cer is modified")
+	}
+	if err := r.Reload(); err != nil || r.Changed() {
+		t.Fatalf("TestReloader_Changed reload failed, %v", err)
+	}
+}
+
+func TestReloader_VerifyPeerCertificate(t *testing.T) {
+	defer setupSSLRoot(t)()
+
+	oldCA, newCA := newTestCert(t, "old-ca", nil), newTestCert(t, "new-ca", nil)
+	oldClient, newClient := newTestCert(t, "old-client", oldCA), newTestCert(t, "new-client", newCA)
+	mtime := time.Now().Add(-time.Minute)
+	writeCert(t, newTestCert(t, "server", oldCA), mtime)
+	writeCA(t, oldCA, mtime)
+
+	r := &Reloader{}
+	if err := r.Reload(); err != nil {
+		t.Fatalf("TestReloader_VerifyPeerCertificate reload failed, %s", err)
+	}
+	verify := func(c *testCert) error {
+		if err := r.verifyPeerCertificate([][]byte{c.raw}, nil); err != nil {
+			return err
+		}
+		return r.verifyServerCertificate([][]byte{c.raw}, nil)
+	}
+	if err := verify(oldClient); err != nil {
+		t.Fatalf("TestReloader_VerifyPeerCertificate old client, %s", err)
+	}
+	if verify(newClient) == nil {
+		t.Fatalf("TestReloader_VerifyPeerCertificate new client should be rejected before rotation")
+	}
+	if r.verifyPeerCertificate(nil, nil) == nil {
+		t.Fatalf("TestReloader_VerifyPeerCertificate no certificate should be rejected")
+	}
+
+	// 轮换CA后由run按修改时间自动重新加载
+	writeCA(t, newCA, mtime.Add(time.Second))
+	stopCh := make(chan struct{})
+	go r.run(stopCh, 10*time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for r.Changed() {
+		if time.Now().After(deadline) {
+			close(stopCh)
+			t.Fatalf("TestReloader_VerifyPeerCertificate rotated CA is not reloaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(stopCh)
+	if err := verify(newClient); err != nil {
+		t.Fatalf("TestReloader_VerifyPeerCertificate new client after rotation, %s", err)
+	}
+	if verify(oldClient) == nil {
+		t.Fatalf("TestReloader_VerifyPeerCertificate old client should be rejected after rotation")
+	}
+	status := r.Status()
+	if len(status.LastError) > 0 || len(status.Certificates) != 2 || status.Certificates[1].Subject != "new-ca" {
+		t.Fatalf("TestReloader_VerifyPeerCertificate status %v", status)
+	}
+
+	// 加载失败时继续使用原有的CA
+	writeFile(t, GetSSLPath("trust.cer"), []byte("invalid"), mtime.Add(2*time.Second))
+	if r.Reload() == nil {
+		t.Fatalf("TestReloader_VerifyPeerCertificate invalid CA should fail to load")
+	}
+	if err := verify(newClient); err != nil {
+		t.Fatalf("TestReloader_VerifyPeerCertificate keep the old CA, %s", err)
+	}
+	if status := r.Status(); len(status.LastError) == 0 || len(status.Certificates) != 2 {
+		t.Fatalf("TestReloader_VerifyPeerCertificate status %v", status)
+	}
+}
@@ -98,16 +98,31 @@ func GetClientTLSConfig() (_ *tls.Config, err error) {
 	opts := append(DefaultClientTLSOptions(),
 		tlsutil.WithKeyPass(decrypt),
 	)
-	clientTLSConfig, err = tlsutil.GetClientTLSConfig(opts...)
-
-	if clientTLSConfig != nil {
-		util.Logger().Infof("client ssl configs enabled, verifyclient %t, minv %#x, cipers %d, pphase %d.",
-			core.ServerInfo.Config.SslVerifyPeer,
-			clientTLSConfig.MinVersion,
-			len(clientTLSConfig.CipherSuites),
-			len(passphase))
+	cfg, err := tlsutil.GetClientTLSConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = ensureLoaded(); err != nil {
+		return nil, err
 	}
-	return clientTLSConfig, err
+	// 客户端证书与服务端共用，重新加载后新建立的连接生效
+	cfg.Certificates = nil
+	cfg.GetClientCertificate = reloader.getClientCertificate
+	if core.ServerInfo.Config.SslVerifyPeer {
+		// RootCAs不能在握手时替换，由VerifyPeerCertificate使用当前CA校验服务端证书链，
+		// 与原有配置一样不校验主机名
+		cfg.RootCAs = nil
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyPeerCertificate = reloader.verifyServerCertificate
+	}
+
+	util.Logger().Infof("client ssl configs enabled, verifyclient %t, minv %#x, cipers %d, pphase %d.",
+		core.ServerInfo.Config.SslVerifyPeer,
+		cfg.MinVersion,
+		len(cfg.CipherSuites),
+		len(passphase))
+	clientTLSConfig = cfg
+	return clientTLSConfig, nil
 }
 
 func GetServerTLSConfig() (_ *tls.Config, err error) {
@@ -122,15 +137,27 @@ func GetServerTLSConfig() (_ *tls.Config, err error) {
 	opts := append(DefaultServerTLSOptions(),
 		tlsutil.WithKeyPass(decrypt),
 	)
-
-	serverTLSConfig, err = tlsutil.GetServerTLSConfig(opts...)
-
-	if serverTLSConfig != nil {
-		util.Logger().Infof("server ssl configs enabled, verifyClient %t, minv %#x, ciphers %d, phase %d.",
-			core.ServerInfo.Config.SslVerifyPeer,
-			serverTLSConfig.MinVersion,
-			len(serverTLSConfig.CipherSuites),
-			len(passphase))
+	cfg, err := tlsutil.GetServerTLSConfig(opts...)
+	if err != nil {
+		return nil, err
 	}
-	return serverTLSConfig, err
+	if err = ensureLoaded(); err != nil {
+		return nil, err
+	}
+	// 每次握手时取当前证书和CA，支持不重启更新证书
+	cfg.Certificates = nil
+	cfg.GetCertificate = reloader.getCertificate
+	if core.ServerInfo.Config.SslVerifyPeer {
+		cfg.ClientCAs = nil
+		cfg.ClientAuth = tls.RequireAnyClientCert
+		cfg.VerifyPeerCertificate = reloader.verifyPeerCertificate
+	}
+
+	util.Logger().Infof("server ssl configs enabled, verifyClient %t, minv %#x, ciphers %d, phase %d.",
+		core.ServerInfo.Config.SslVerifyPeer,
+		cfg.MinVersion,
+		len(cfg.CipherSuites),
+		len(passphase))
+	serverTLSConfig = cfg
+	return serverTLSConfig, nil
 }